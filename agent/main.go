@@ -1,18 +1,24 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/shellhub-io/shellhub/pkg/agent"
 	"github.com/shellhub-io/shellhub/pkg/agent/connector"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sandbox"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/selfupdater"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/service"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
 	log "github.com/sirupsen/logrus"
@@ -33,16 +39,25 @@ func main() {
 		Use: "agent",
 		Run: func(cmd *cobra.Command, _ []string) {
 			loglevel.SetLogLevel()
+			log.AddHook(correlation.NewHook())
 
 			cfg, fields, err := agent.LoadConfigFromEnv()
 			if err != nil {
-				log.WithError(err).WithFields(fields).Fatal("Failed to load de configuration from the environmental variables")
+				log.WithError(err).WithFields(fields).Error("Failed to load de configuration from the environmental variables")
+				agent.Exit(agent.ExitConfigInvalid, err)
+			}
+
+			// NOTICE: on Windows, the sessions run as the account running the agent, as there is no multi-user mode.
+			if runtime.GOOS == "windows" && cfg.SingleUserPassword == "" {
+				log.Error("ShellHub agent only supports single-user mode on Windows.")
+				log.Error("Set the password for single-user mode by SHELLHUB_SINGLE_USER_PASSWORD environment variable.")
+				agent.Exit(agent.ExitConfigInvalid, errors.New("single-user password not set on Windows"))
 			}
 
 			if os.Geteuid() == 0 && cfg.SingleUserPassword != "" {
 				log.Error("ShellHub agent cannot run as root when single-user mode is enabled.")
 				log.Error("To disable single-user mode unset SHELLHUB_SINGLE_USER_PASSWORD env.")
-				os.Exit(1)
+				agent.Exit(agent.ExitConfigInvalid, errors.New("single-user mode enabled as root"))
 			}
 
 			if os.Geteuid() != 0 && cfg.SingleUserPassword == "" {
@@ -50,7 +65,7 @@ func main() {
 				log.Error("You can use openssl passwd utility to generate password hash. The following algorithms are supported: bsd1, apr1, sha256, sha512.")
 				log.Error("Example: SHELLHUB_SINGLE_USER_PASSWORD=$(openssl passwd -6)")
 				log.Error("See man openssl-passwd for more information.")
-				os.Exit(1)
+				agent.Exit(agent.ExitConfigInvalid, errors.New("single-user password not set as non-root user"))
 			}
 
 			updater, err := selfupdater.NewUpdater(AgentVersion)
@@ -60,7 +75,7 @@ func main() {
 
 			if err := updater.CompleteUpdate(); err != nil {
 				log.Warning(err)
-				os.Exit(0)
+				agent.Exit(agent.ExitUpdateInProgress, err)
 			}
 
 			currentVersion := new(semver.Version)
@@ -90,14 +105,16 @@ func main() {
 				log.WithError(err).WithFields(log.Fields{
 					"version":       AgentVersion,
 					"configuration": cfg,
-				}).Fatal("Failed to create agent")
+				}).Error("Failed to create agent")
+				agent.Exit(agent.ExitCodeFromError(err), err)
 			}
 
 			if err := ag.Initialize(); err != nil {
 				log.WithError(err).WithFields(log.Fields{
 					"version":       AgentVersion,
 					"configuration": cfg,
-				}).Fatal("Failed to initialize agent")
+				}).Error("Failed to initialize agent")
+				agent.Exit(agent.ExitCodeFromError(err), err)
 			}
 
 			ctx := cmd.Context()
@@ -108,6 +125,7 @@ func main() {
 				"tenant_id":          cfg.TenantID,
 				"server_address":     cfg.ServerAddress,
 				"preferred_hostname": cfg.PreferredHostname,
+				"claim_code":         ag.ClaimCode(),
 			}).Info("Listening for connections")
 
 			// Disable check update in development mode
@@ -129,13 +147,24 @@ func main() {
 
 						if nextVersion.GreaterThan(currentVersion) {
 							if err := updater.ApplyUpdate(nextVersion); err != nil {
-								log.WithError(err).WithFields(log.Fields{
+								logger := log.WithError(err).WithFields(log.Fields{
 									"version":            AgentVersion,
+									"next_version":       nextVersion.String(),
 									"mode":               mode,
 									"tenant_id":          cfg.TenantID,
 									"server_address":     cfg.ServerAddress,
 									"preferred_hostname": cfg.PreferredHostname,
-								}).Error("Failed to apply update")
+								})
+
+								// NOTICE: As errors are reported to the server, the refused updates can be found
+								// on the device's agent logs.
+								if errors.Is(err, selfupdater.ErrPlatformMismatch) {
+									logger.Error("Refused to apply an update built for another platform")
+								} else {
+									logger.Error("Failed to apply update")
+								}
+
+								goto sleep
 							}
 
 							log.WithFields(log.Fields{
@@ -169,7 +198,8 @@ func main() {
 					"tenant_id":          cfg.TenantID,
 					"server_address":     cfg.ServerAddress,
 					"preferred_hostname": cfg.PreferredHostname,
-				}).Fatal("Failed to listen for connections")
+				}).Error("Failed to listen for connections")
+				agent.Exit(agent.ExitFailure, err)
 			}
 
 			log.WithFields(log.Fields{
@@ -194,7 +224,7 @@ func main() {
 			err = updater.CompleteUpdate()
 			if err != nil {
 				log.Warning(err)
-				os.Exit(0)
+				agent.Exit(agent.ExitUpdateInProgress, err)
 			}
 
 			currentVersion := new(semver.Version)
@@ -210,7 +240,8 @@ func main() {
 			if err != nil {
 				log.WithError(err).
 					WithFields(fields).
-					Fatal("Failed to load de configuration from the environmental variables")
+					Error("Failed to load de configuration from the environmental variables")
+				agent.Exit(agent.ExitConfigInvalid, err)
 			}
 
 			logger := log.WithFields(
@@ -227,9 +258,10 @@ func main() {
 			logger.Info("Starting ShellHub Agent Connector")
 
 			connector.ConnectorVersion = AgentVersion
-			connector, err := connector.NewDockerConnector(cfg.ServerAddress, cfg.TenantID, cfg.PrivateKeys)
+			connector, err := connector.NewDockerConnector(cfg.ServerAddress, cfg.ServerCA, cfg.TenantID, cfg.PrivateKeys)
 			if err != nil {
-				logger.Fatal("Failed to create ShellHub Agent Connector")
+				logger.WithError(err).Error("Failed to create ShellHub Agent Connector")
+				agent.Exit(agent.ExitFailure, err)
 			}
 
 			if AgentVersion != "latest" {
@@ -268,7 +300,8 @@ func main() {
 			}
 
 			if err := connector.Listen(cmd.Context()); err != nil {
-				logger.Fatal("Failed to listen for connections")
+				logger.WithError(err).Error("Failed to listen for connections")
+				agent.Exit(agent.ExitFailure, err)
 			}
 
 			logger.Info("ShellHub Agent Connector stopped")
@@ -283,7 +316,8 @@ func main() {
 
 			cfg, err := envs.ParseWithPrefix[agent.Config]("SHELLHUB_")
 			if err != nil {
-				log.Fatal(err)
+				log.Error(err)
+				agent.Exit(agent.ExitConfigInvalid, err)
 			}
 
 			info, err := agent.GetInfo(cfg)
@@ -291,21 +325,30 @@ func main() {
 				log.WithError(err).WithFields(log.Fields{
 					"version":       AgentVersion,
 					"configuration": cfg,
-				}).Fatal("Failed to get agent information")
+				}).Error("Failed to get agent information")
+				agent.Exit(agent.ExitFailure, err)
 			}
 
-			log.WithFields(log.Fields{
+			fields := log.Fields{
 				"version": info.Version,
 				"api":     info.Endpoints.API,
 				"ssh":     info.Endpoints.SSH,
-			}).Info("ShellHub agent information")
+			}
+
+			// NOTICE: the private key only exists after the agent has started once.
+			if code, err := agent.ReadClaimCode(cfg.PrivateKey); err == nil {
+				fields["claim_code"] = code
+			}
+
+			log.WithFields(fields).Info("ShellHub agent information")
 
 			data, err := json.Marshal(info)
 			if err != nil {
 				log.WithError(err).WithFields(log.Fields{
 					"version":       AgentVersion,
 					"configuration": cfg,
-				}).Fatal("Failed to marshal agent information")
+				}).Error("Failed to marshal agent information")
+				agent.Exit(agent.ExitFailure, err)
 			}
 
 			// NOTICE: this output was made to enable the agent's user to check and parse the agent's information with
@@ -325,6 +368,88 @@ It is initialized by the agent when a new SFTP session is created.`,
 		},
 	})
 
+	rootCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+		Use:   sandbox.Subcommand + " <home> <path> <argv>...",
+		Short: "Starts a program inside the sandbox",
+		Long: `Starts a program inside the sandbox. This command is used internally by the agent and should not be used directly.
+It is initialized by the agent when a new session is created in single-user mode with the sandbox enabled.`,
+		DisableFlagParsing: true,
+		Args:               cobra.MinimumNArgs(3),
+		Run: func(_ *cobra.Command, args []string) {
+			if err := sandbox.Run(args[0], args[1], args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+
+				os.Exit(1)
+			}
+		},
+	})
+
+	serviceCmd := &cobra.Command{ // nolint: exhaustruct
+		Use:   service.Subcommand,
+		Short: "Manages the agent as a Windows service",
+	}
+
+	serviceCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+		Use:   "install",
+		Short: "Installs the agent as a Windows service",
+		Long: `Installs the agent as a Windows service, started with the system and restarted when it fails.
+The SHELLHUB_ environmental variables of the current environment are kept as the service's configuration.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			executable, err := os.Executable()
+			if err != nil {
+				log.WithError(err).Error("Failed to get the agent's executable")
+				agent.Exit(agent.ExitFailure, err)
+			}
+
+			env := []string{}
+			for _, variable := range os.Environ() {
+				if strings.HasPrefix(variable, "SHELLHUB_") {
+					env = append(env, variable)
+				}
+			}
+
+			if err := service.Install(executable, env); err != nil {
+				log.WithError(err).Error("Failed to install the agent's service")
+				agent.Exit(agent.ExitFailure, err)
+			}
+
+			log.WithField("service", service.Name).Info("Service installed and started")
+		},
+	})
+
+	serviceCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+		Use:   "uninstall",
+		Short: "Stops and uninstalls the agent's Windows service",
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := service.Uninstall(); err != nil {
+				log.WithError(err).Error("Failed to uninstall the agent's service")
+				agent.Exit(agent.ExitFailure, err)
+			}
+
+			log.WithField("service", service.Name).Info("Service uninstalled")
+		},
+	})
+
+	serviceCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+		Use:   "run",
+		Short: "Runs the agent as a Windows service",
+		Long:  `Runs the agent as a Windows service. This command is used internally by the Service Control Manager and should not be used directly.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := service.Run(func(ctx context.Context) error {
+				cmd.SetContext(ctx)
+				rootCmd.Run(cmd, args)
+
+				return nil
+			})
+			if err != nil {
+				log.WithError(err).Error("Failed to run the agent's service")
+				agent.Exit(agent.ExitFailure, err)
+			}
+		},
+	})
+
+	rootCmd.AddCommand(serviceCmd)
+
 	rootCmd.Version = AgentVersion
 
 	rootCmd.SetVersionTemplate(fmt.Sprintf("{{ .Name }} version: {{ .Version }}\ngo: %s\n",
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
 	"github.com/sirupsen/logrus"
@@ -11,6 +12,7 @@ import (
 
 func main() {
 	loglevel.UseEnvs()
+	logrus.AddHook(correlation.NewHook())
 
 	rootCmd := &cobra.Command{Use: "api"}
 
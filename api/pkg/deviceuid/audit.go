@@ -0,0 +1,67 @@
+package deviceuid
+
+import (
+	"sort"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Report is the audit of the devices' UIDs against a scheme.
+type Report struct {
+	Scheme Scheme `json:"scheme"`
+	// Devices is the number of devices audited.
+	Devices int `json:"devices"`
+	// Derived is the number of devices whose UIDs are derived on the scheme.
+	Derived int `json:"derived"`
+	// Legacy are the UIDs of the devices whose UIDs are derived on the [SchemeLegacy], when the scheme audited is
+	// another one. They keep their UIDs on the scheme audited.
+	Legacy []string `json:"legacy"`
+	// Mismatched are the UIDs of the devices whose UIDs aren't derived from their identities on any scheme, like the
+	// devices whose public keys were replaced.
+	Mismatched []string `json:"mismatched"`
+	// Collisions are the UIDs of the devices whose identities derive the same UID on the scheme, by the UID derived.
+	// Those devices are authorized as the same device.
+	Collisions map[string][]string `json:"collisions"`
+}
+
+// Audit audits the devices' UIDs against the scheme, reporting the devices derived on it, the legacy ones, the ones not
+// derived from their identities and the ones colliding.
+func Audit(scheme Scheme, devices []models.Device) *Report {
+	report := &Report{
+		Scheme:     scheme,
+		Devices:    len(devices),
+		Legacy:     []string{},
+		Mismatched: []string{},
+		Collisions: map[string][]string{},
+	}
+
+	derived := make(map[string][]string)
+	for i := range devices {
+		device := &devices[i]
+		auth := DeviceAuth(device)
+
+		uid := Derive(scheme, auth)
+		derived[uid] = append(derived[uid], device.UID)
+
+		switch {
+		case device.UID == uid:
+			report.Derived++
+		case scheme != SchemeLegacy && device.UID == Derive(SchemeLegacy, auth):
+			report.Legacy = append(report.Legacy, device.UID)
+		default:
+			report.Mismatched = append(report.Mismatched, device.UID)
+		}
+	}
+
+	for uid, uids := range derived {
+		if len(uids) > 1 {
+			sort.Strings(uids)
+			report.Collisions[uid] = uids
+		}
+	}
+
+	sort.Strings(report.Legacy)
+	sort.Strings(report.Mismatched)
+
+	return report
+}
@@ -0,0 +1,102 @@
+// Package deviceuid derives the UIDs of the devices from the identity they present on their authorization.
+//
+// A device's UID is the hex encoded SHA-256 of its namespace's tenant ID, its identity, which is its MAC address, and
+// its public key. The device's hostname isn't part of it, so a renamed device keeps its UID. How those are serialized
+// before hashing depends on the [Scheme]:
+//
+//   - [SchemeLegacy] serializes them with the github.com/cnf/structhash dump of the device's authorization, version 1,
+//     as the UIDs were always derived. A device without an identity is serialized with an "<invalid Value>" identity.
+//   - [SchemeV2] serializes them as the "shellhub/device-uid/v2" prefix, the tenant ID, the MAC address and the public
+//     key, in this order, separated by a NUL byte. A device without an identity has an empty MAC address.
+//
+// The devices registered with the legacy scheme keep their UIDs after the instance moves to the version 2, as the
+// authorization falls back to the legacy UID of the devices already registered with it. Use [Audit] to check the
+// devices before the move.
+package deviceuid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cnf/structhash"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Scheme is how the identity of a device is serialized before hashing it into the device's UID.
+type Scheme string
+
+const (
+	// SchemeLegacy is the scheme of the UIDs derived before the derivation was configurable.
+	SchemeLegacy Scheme = "legacy"
+	// SchemeV2 is the explicit, versioned, scheme.
+	SchemeV2 Scheme = "v2"
+)
+
+// prefixV2 is the prefix of the identities serialized by the [SchemeV2].
+const prefixV2 = "shellhub/device-uid/v2"
+
+var ErrScheme = errors.New("invalid device UID scheme")
+
+// ParseScheme parses a scheme from its name. An empty name is the [SchemeLegacy].
+func ParseScheme(name string) (Scheme, error) {
+	switch Scheme(strings.ToLower(name)) {
+	case "", SchemeLegacy:
+		return SchemeLegacy, nil
+	case SchemeV2:
+		return SchemeV2, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrScheme, name)
+	}
+}
+
+// DeviceAuth returns the authorization a registered device derives its UID from.
+func DeviceAuth(device *models.Device) models.DeviceAuth {
+	return models.DeviceAuth{
+		Identity:  device.Identity,
+		PublicKey: device.PublicKey,
+		TenantID:  device.TenantID,
+	}
+}
+
+// legacyIdentity and legacyAuth freeze the structures dumped by the [SchemeLegacy], as the dump depends on their
+// fields' names and order, so a change on the device's authorization model doesn't change the legacy UIDs.
+type (
+	legacyIdentity struct {
+		MAC string
+	}
+
+	legacyAuth struct {
+		Identity  *legacyIdentity
+		PublicKey string
+		TenantID  string
+	}
+)
+
+// Derive derives the UID of the device authorizing on the scheme. An unknown scheme derives as the [SchemeLegacy].
+func Derive(scheme Scheme, auth models.DeviceAuth) string {
+	var data []byte
+
+	switch scheme {
+	case SchemeV2:
+		mac := ""
+		if auth.Identity != nil {
+			mac = auth.Identity.MAC
+		}
+
+		data = []byte(strings.Join([]string{prefixV2, auth.TenantID, mac, auth.PublicKey}, "\x00"))
+	default:
+		legacy := legacyAuth{PublicKey: auth.PublicKey, TenantID: auth.TenantID}
+		if auth.Identity != nil {
+			legacy.Identity = &legacyIdentity{MAC: auth.Identity.MAC}
+		}
+
+		data = structhash.Dump(legacy, 1)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,126 @@
+package deviceuid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cnf/structhash"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScheme(t *testing.T) {
+	cases := []struct {
+		description string
+		name        string
+		expected    Scheme
+		err         bool
+	}{
+		{description: "defaults to the legacy scheme", name: "", expected: SchemeLegacy},
+		{description: "parses the legacy scheme", name: "legacy", expected: SchemeLegacy},
+		{description: "parses the version 2 scheme", name: "V2", expected: SchemeV2},
+		{description: "fails with an unknown scheme", name: "v3", err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			scheme, err := ParseScheme(tc.name)
+			assert.Equal(t, tc.expected, scheme)
+			assert.Equal(t, tc.err, err != nil)
+		})
+	}
+}
+
+func TestDerive(t *testing.T) {
+	// legacy is how the UIDs were derived before the derivation was configurable.
+	legacy := func(auth models.DeviceAuth) string {
+		sum := sha256.Sum256(structhash.Dump(auth, 1))
+
+		return hex.EncodeToString(sum[:])
+	}
+
+	cases := []struct {
+		description string
+		auth        models.DeviceAuth
+	}{
+		{
+			description: "with an identity",
+			auth: models.DeviceAuth{
+				Hostname:  "device",
+				Identity:  &models.DeviceIdentity{MAC: "00:00:00:00:00:01"},
+				PublicKey: "public-key",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+			},
+		},
+		{
+			description: "with an empty identity",
+			auth: models.DeviceAuth{
+				Identity:  &models.DeviceIdentity{},
+				PublicKey: "public-key",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+			},
+		},
+		{
+			description: "without an identity",
+			auth: models.DeviceAuth{
+				Hostname:  "device",
+				PublicKey: "public-key",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, legacy(tc.auth), Derive(SchemeLegacy, tc.auth))
+
+			renamed := tc.auth
+			renamed.Hostname = "renamed"
+			assert.Equal(t, Derive(SchemeV2, tc.auth), Derive(SchemeV2, renamed))
+			assert.NotEqual(t, Derive(SchemeLegacy, tc.auth), Derive(SchemeV2, tc.auth))
+		})
+	}
+
+	t.Run("derives the version 2 as documented", func(t *testing.T) {
+		sum := sha256.Sum256([]byte("shellhub/device-uid/v2\x0000000000-0000-4000-0000-000000000000\x0000:00:00:00:00:01\x00public-key"))
+
+		assert.Equal(t, hex.EncodeToString(sum[:]), Derive(SchemeV2, models.DeviceAuth{
+			Identity:  &models.DeviceIdentity{MAC: "00:00:00:00:00:01"},
+			PublicKey: "public-key",
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+		}))
+	})
+}
+
+func TestAudit(t *testing.T) {
+	auth := func(mac string) models.DeviceAuth {
+		return models.DeviceAuth{
+			Identity:  &models.DeviceIdentity{MAC: mac},
+			PublicKey: "public-key",
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+		}
+	}
+
+	device := func(uid string, auth models.DeviceAuth) models.Device {
+		return models.Device{UID: uid, Identity: auth.Identity, PublicKey: auth.PublicKey, TenantID: auth.TenantID}
+	}
+
+	devices := []models.Device{
+		device(Derive(SchemeV2, auth("00:00:00:00:00:01")), auth("00:00:00:00:00:01")),
+		device(Derive(SchemeLegacy, auth("00:00:00:00:00:02")), auth("00:00:00:00:00:02")),
+		device("replaced", auth("00:00:00:00:00:03")),
+		device("cloned", auth("00:00:00:00:00:01")),
+	}
+
+	assert.Equal(t, &Report{
+		Scheme:     SchemeV2,
+		Devices:    4,
+		Derived:    1,
+		Legacy:     []string{Derive(SchemeLegacy, auth("00:00:00:00:00:02"))},
+		Mismatched: []string{"cloned", "replaced"},
+		Collisions: map[string][]string{
+			Derive(SchemeV2, auth("00:00:00:00:00:01")): {Derive(SchemeV2, auth("00:00:00:00:00:01")), "cloned"},
+		},
+	}, Audit(SchemeV2, devices))
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"net/url"
+
 	"github.com/labstack/echo/v4"
 	errors "github.com/shellhub-io/shellhub/api/routes/errors"
 )
@@ -12,6 +14,17 @@ func NewBinder() *Binder {
 }
 
 func (b *Binder) Bind(s interface{}, c echo.Context) error {
+	// NOTICE: path parameters are received escaped, as values with slashes, like hierarchical tags, must be sent
+	// encoded to not be split by the router.
+	values := c.ParamValues()
+	for i, value := range values {
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			values[i] = unescaped
+		}
+	}
+
+	c.SetParamValues(values...)
+
 	binder := new(echo.DefaultBinder)
 	if err := binder.Bind(s, c); err != nil {
 		err := err.(*echo.HTTPError) //nolint:forcetypeassert
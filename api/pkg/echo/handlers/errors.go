@@ -11,6 +11,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/services"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo"
+	"github.com/shellhub-io/shellhub/api/store/postgres"
 	"github.com/shellhub-io/shellhub/pkg/errors"
 )
 
@@ -36,9 +37,18 @@ func NewErrors(reporter *sentry.Client) func(error, echo.Context) {
 		// happens in each case, avoiding the use of else statements, which would make the code more confusing or a big
 		// switch statement, which would make the code less readable.
 
-		// Every Mongo error that isn't mapped as a store error must be reported to Sentry and responded with HTTP
-		// status code 500.
-		if errors.Is(err, mongo.ErrMongo) {
+		// When the database can't keep up with the requests, the client is hinted to retry later, instead of the
+		// request being handled as an unknown failure.
+		if errors.Is(err, store.ErrUnavailable) {
+			store.UnavailableBackoff.SetHeader(ctx.Response().Header())
+			ctx.NoContent(http.StatusServiceUnavailable) //nolint:errcheck
+
+			return
+		}
+
+		// Every Mongo or PostgreSQL error that isn't mapped as a store error must be reported to Sentry and responded
+		// with HTTP status code 500.
+		if errors.Is(err, mongo.ErrMongo) || errors.Is(err, postgres.ErrPostgres) {
 			report(reporter, err, ctx.Request())
 			ctx.NoContent(http.StatusInternalServerError) //nolint:errcheck
 
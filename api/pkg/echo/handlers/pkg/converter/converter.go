@@ -28,6 +28,8 @@ func FromErrServiceToHTTPStatus(code int) int {
 		return http.StatusForbidden
 	case services.ErrCodeNoContentChange:
 		return http.StatusNoContent
+	case services.ErrCodePreconditionFailed:
+		return http.StatusPreconditionFailed
 	default:
 		return http.StatusInternalServerError
 	}
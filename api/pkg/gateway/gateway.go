@@ -6,6 +6,8 @@ package gateway
 
 import (
 	"context"
+	"net/http"
+	"strings"
 
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
@@ -54,3 +56,37 @@ func IDFromContext(ctx context.Context) *models.ID {
 
 	return nil
 }
+
+// SourceIPFromContext returns the address the request was sent from, as forwarded by the gateway. It is empty when
+// the context isn't a request's.
+func SourceIPFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("ctx").(*Context); ok {
+		return c.RealIP()
+	}
+
+	return ""
+}
+
+// APIKeyFromContext returns the API key the request was authenticated with, set by the gateway. It is empty when the
+// request wasn't authenticated by an API key, or the context isn't a request's.
+func APIKeyFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("ctx").(*Context); ok {
+		return c.Request().Header.Get("X-API-KEY")
+	}
+
+	return ""
+}
+
+// APIKeyFromRequest returns the API key sent on the request, either on the X-API-Key header or as a bearer token
+// prefixed by [models.APIKeyPrefix] on the Authorization header. It is empty when the request carries no API key.
+func APIKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	if token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); strings.HasPrefix(token, models.APIKeyPrefix) {
+		return token
+	}
+
+	return ""
+}
@@ -0,0 +1,111 @@
+package policyengine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrOPAInvalidConfig = errors.New("invalid Open Policy Agent configuration")
+
+// DefaultOPAPath is the Rego package evaluated when none is configured.
+const DefaultOPAPath = "shellhub/authz"
+
+// OPAConfig holds the information required to evaluate the policies on an Open Policy Agent server, like one started
+// with "opa run --server --bundle /policies" to serve a local bundle.
+type OPAConfig struct {
+	// Address is the server's URL, like http://opa:8181.
+	Address string
+	// Path is the Rego package, or rule, evaluated, like "shellhub/authz" or "shellhub.authz". A package must define
+	// the "allow" rule and, optionally, the "reason" rule; a rule must be a boolean.
+	Path string
+	// Timeout is how long an evaluation may take.
+	Timeout time.Duration
+}
+
+type opa struct {
+	endpoint string
+	client   *http.Client
+}
+
+var _ Engine = (*opa)(nil)
+
+// NewOPA creates an [Engine] that evaluates the policies on the Open Policy Agent server described by cfg, through its
+// Data API.
+func NewOPA(cfg OPAConfig) (Engine, error) {
+	address, err := url.Parse(cfg.Address)
+	if err != nil || address.Host == "" || (address.Scheme != "http" && address.Scheme != "https") {
+		return nil, ErrOPAInvalidConfig
+	}
+
+	path := cfg.Path
+	if path == "" {
+		path = DefaultOPAPath
+	}
+
+	path = strings.Trim(strings.ReplaceAll(path, ".", "/"), "/")
+	if path == "" {
+		return nil, ErrOPAInvalidConfig
+	}
+
+	return &opa{
+		endpoint: strings.TrimSuffix(address.String(), "/") + "/v1/data/" + path,
+		client:   &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (o *opa) Evaluate(ctx context.Context, input *Input) (*Decision, error) {
+	body, err := json.Marshal(map[string]*Input{"input": input})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("opa: unexpected status %d", res.StatusCode)
+	}
+
+	response := new(struct {
+		Result json.RawMessage `json:"result"`
+	})
+
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	// NOTICE: an undefined document, like a package without the "allow" rule, has no result and denies the action.
+	if len(response.Result) == 0 {
+		return &Decision{Allow: false, Reason: "policy is undefined"}, nil
+	}
+
+	var allow bool
+	if err := json.Unmarshal(response.Result, &allow); err == nil {
+		return &Decision{Allow: allow}, nil
+	}
+
+	decision := new(Decision)
+	if err := json.Unmarshal(response.Result, decision); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDecisionInvalid, err.Error())
+	}
+
+	return decision, nil
+}
@@ -0,0 +1,137 @@
+package policyengine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOPA(t *testing.T) {
+	cases := []struct {
+		description string
+		cfg         OPAConfig
+		endpoint    string
+		err         error
+	}{
+		{
+			description: "fails when the address is invalid",
+			cfg:         OPAConfig{Address: "opa:8181"},
+			err:         ErrOPAInvalidConfig,
+		},
+		{
+			description: "fails when the path is invalid",
+			cfg:         OPAConfig{Address: "http://opa:8181", Path: "/"},
+			err:         ErrOPAInvalidConfig,
+		},
+		{
+			description: "succeeds with the default path",
+			cfg:         OPAConfig{Address: "http://opa:8181/"},
+			endpoint:    "http://opa:8181/v1/data/shellhub/authz",
+		},
+		{
+			description: "succeeds with a dotted path",
+			cfg:         OPAConfig{Address: "http://opa:8181", Path: "acme.authz.allow"},
+			endpoint:    "http://opa:8181/v1/data/acme/authz/allow",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			engine, err := NewOPA(tc.cfg)
+			assert.ErrorIs(t, err, tc.err)
+
+			if tc.err == nil {
+				assert.Equal(t, tc.endpoint, engine.(*opa).endpoint)
+			}
+		})
+	}
+}
+
+func TestOPAEvaluate(t *testing.T) {
+	input := &Input{
+		Action:    ActionSessionOpen,
+		Time:      time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		SourceIP:  "192.168.0.1",
+		Namespace: &Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"},
+		Device:    &Device{UID: "uid", Name: "device", Tags: []string{"production"}, Groups: []string{}},
+		Login:     "root",
+	}
+
+	cases := []struct {
+		description string
+		status      int
+		response    string
+		expected    *Decision
+		err         bool
+	}{
+		{
+			description: "fails when the server answers an error",
+			status:      http.StatusInternalServerError,
+			response:    `{"code":"internal_error"}`,
+			err:         true,
+		},
+		{
+			description: "fails when the result isn't a decision",
+			status:      http.StatusOK,
+			response:    `{"result":"allow"}`,
+			err:         true,
+		},
+		{
+			description: "denies when the policy is undefined",
+			status:      http.StatusOK,
+			response:    `{}`,
+			expected:    &Decision{Allow: false, Reason: "policy is undefined"},
+		},
+		{
+			description: "evaluates a boolean rule",
+			status:      http.StatusOK,
+			response:    `{"result":true}`,
+			expected:    &Decision{Allow: true},
+		},
+		{
+			description: "evaluates a package",
+			status:      http.StatusOK,
+			response:    `{"result":{"allow":false,"reason":"production devices are closed on weekends"}}`,
+			expected:    &Decision{Allow: false, Reason: "production devices are closed on weekends"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/v1/data/shellhub/authz", r.URL.Path)
+
+				body := new(struct {
+					Input *Input `json:"input"`
+				})
+
+				require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+				assert.Equal(t, input, body.Input)
+
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.response)) //nolint:errcheck
+			}))
+			defer server.Close()
+
+			engine, err := NewOPA(OPAConfig{Address: server.URL, Timeout: time.Second})
+			require.NoError(t, err)
+
+			decision, err := engine.Evaluate(context.Background(), input)
+			if tc.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, decision)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package policyengine evaluates the authorization decisions against the custom policies of an organization, written
+// in Rego and served by an Open Policy Agent, without changing the roles and permissions of the API's authorizer.
+//
+// The engine is only consulted after the authorizer allowed the action, so the policies can only restrict it further.
+package policyengine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var ErrDecisionInvalid = errors.New("invalid policy decision")
+
+// Actions evaluated by the engine. The sensitive API calls are evaluated with the action set on the routes' table.
+const (
+	// ActionSessionOpen is the opening of a SSH session to a device.
+	ActionSessionOpen = "session.open"
+)
+
+// User is the user who performs the action.
+type User struct {
+	ID       string `json:"id,omitempty"`
+	Username string `json:"username,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+// Namespace is the namespace where the action is performed.
+type Namespace struct {
+	TenantID string `json:"tenant_id"`
+	Name     string `json:"name,omitempty"`
+}
+
+// Device is the device the action is performed on.
+type Device struct {
+	UID    string             `json:"uid"`
+	Name   string             `json:"name"`
+	Tags   []string           `json:"tags"`
+	Groups []string           `json:"groups"`
+	Info   *models.DeviceInfo `json:"info,omitempty"`
+}
+
+// Request is the API call performed.
+type Request struct {
+	Method string `json:"method"`
+	// Path is the route's path, like "/api/devices/:uid", with its parameters on Params.
+	Path   string            `json:"path"`
+	Params map[string]string `json:"params"`
+}
+
+// Input is the document the policies are evaluated with, available on Rego as the input.
+type Input struct {
+	// Action is the action being authorized, like [ActionSessionOpen].
+	Action string `json:"action"`
+	// Time is when the action is performed.
+	Time time.Time `json:"time"`
+	// SourceIP is the address of the client performing the action.
+	SourceIP  string     `json:"source_ip"`
+	User      *User      `json:"user,omitempty"`
+	Namespace *Namespace `json:"namespace,omitempty"`
+	Device    *Device    `json:"device,omitempty"`
+	// Login is the username the session logs in as on the device.
+	Login   string   `json:"login,omitempty"`
+	Request *Request `json:"request,omitempty"`
+}
+
+// Decision is the result of a policy evaluation.
+type Decision struct {
+	Allow bool `json:"allow"`
+	// Reason explains, when the action is denied, why it was denied.
+	Reason string `json:"reason,omitempty"`
+}
+
+//go:generate mockery --name Engine --filename engine.go
+type Engine interface {
+	// Evaluate evaluates the policies with the input, returning if the action is allowed. It returns an error when the
+	// policies couldn't be evaluated, which must be handled as a denial.
+	Evaluate(ctx context.Context, input *Input) (*Decision, error)
+}
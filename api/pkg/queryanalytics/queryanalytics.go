@@ -0,0 +1,129 @@
+// Package queryanalytics records, on a sample of the listing queries, the fields they are filtered by and the keys
+// they are sorted by, so the operators can create the MongoDB indexes that serve the actual usage of their instance.
+//
+// The usage is kept in memory, by each API instance, since it was started.
+package queryanalytics
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Names of the queries recorded.
+const (
+	QueryDevices        = "devices"
+	QueryRemovedDevices = "removed_devices"
+	QuerySessions       = "sessions"
+)
+
+// Usage is the usage of a query's fields on its sampled executions.
+type Usage struct {
+	// Sampled is the number of executions sampled.
+	Sampled int64 `json:"sampled"`
+	// Fields is the number of sampled executions filtered by each field.
+	Fields map[string]int64 `json:"fields"`
+	// Sorts is the number of sampled executions sorted by each key, written as "key:order".
+	Sorts map[string]int64 `json:"sorts"`
+	// Shapes is the number of sampled executions by their fields and sort key, written as "field,field|key:order",
+	// which is the shape of the compound index that serves them.
+	Shapes map[string]int64 `json:"shapes"`
+}
+
+// Report is the usage of the queries recorded since Since.
+type Report struct {
+	// SampleRate is the percentage of the executions sampled.
+	SampleRate int              `json:"sample_rate"`
+	Since      time.Time        `json:"since"`
+	Queries    map[string]Usage `json:"queries"`
+}
+
+// Recorder records the usage of the queries' fields on a sample of their executions. A nil Recorder records nothing.
+type Recorder struct {
+	mu      sync.Mutex
+	rate    int
+	since   time.Time
+	queries map[string]*Usage
+	// random returns a number in [0, 100) used to sample the executions.
+	random func() int
+}
+
+// New creates a [Recorder] sampling rate percent of the queries' executions. A rate lower or equal to zero disables
+// the recording.
+func New(rate int) *Recorder {
+	if rate <= 0 {
+		return nil
+	}
+
+	return &Recorder{
+		rate:    min(rate, 100),
+		since:   time.Now(),
+		queries: make(map[string]*Usage),
+		random:  func() int { return rand.Intn(100) }, //nolint:gosec
+	}
+}
+
+// Record records, when the execution is sampled, the fields the query was filtered by and the key it was sorted by.
+// An empty sort key records the query as sorted by its default order. The order of the fields isn't relevant.
+func (r *Recorder) Record(query string, fields []string, sortBy, order string) {
+	if r == nil || r.random() >= r.rate {
+		return
+	}
+
+	unique := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field != "" && !slices.Contains(unique, field) {
+			unique = append(unique, field)
+		}
+	}
+
+	sort.Strings(unique)
+
+	sorting := "default"
+	if sortBy != "" {
+		sorting = sortBy + ":" + order
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage, ok := r.queries[query]
+	if !ok {
+		usage = &Usage{Fields: map[string]int64{}, Sorts: map[string]int64{}, Shapes: map[string]int64{}}
+		r.queries[query] = usage
+	}
+
+	usage.Sampled++
+	for _, field := range unique {
+		usage.Fields[field]++
+	}
+
+	usage.Sorts[sorting]++
+	usage.Shapes[strings.Join(unique, ",")+"|"+sorting]++
+}
+
+// Report returns a copy of the usage recorded. It returns nil when the recording is disabled.
+func (r *Recorder) Report() *Report {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{SampleRate: r.rate, Since: r.since, Queries: make(map[string]Usage, len(r.queries))}
+	for name, usage := range r.queries {
+		report.Queries[name] = Usage{
+			Sampled: usage.Sampled,
+			Fields:  maps.Clone(usage.Fields),
+			Sorts:   maps.Clone(usage.Sorts),
+			Shapes:  maps.Clone(usage.Shapes),
+		}
+	}
+
+	return report
+}
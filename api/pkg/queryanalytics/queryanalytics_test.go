@@ -0,0 +1,62 @@
+package queryanalytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Run("records nothing when disabled", func(t *testing.T) {
+		recorder := New(0)
+		recorder.Record(QueryDevices, []string{"name"}, "", "")
+
+		assert.Nil(t, recorder.Report())
+	})
+
+	t.Run("records only the sampled executions", func(t *testing.T) {
+		recorder := New(10)
+
+		samples := []int{5, 50}
+		recorder.random = func() int {
+			sample := samples[0]
+			samples = samples[1:]
+
+			return sample
+		}
+
+		recorder.Record(QueryDevices, []string{"name"}, "", "")
+		recorder.Record(QueryDevices, []string{"name"}, "", "")
+
+		report := recorder.Report()
+		assert.Equal(t, 10, report.SampleRate)
+		assert.Equal(t, int64(1), report.Queries[QueryDevices].Sampled)
+	})
+
+	t.Run("records the fields, sort keys and shapes", func(t *testing.T) {
+		recorder := New(100)
+
+		recorder.Record(QueryDevices, []string{"tenant_id", "status", "name", "status"}, "last_seen", "desc")
+		recorder.Record(QueryDevices, []string{"status", "tenant_id"}, "", "")
+		recorder.Record(QuerySessions, []string{"tenant_id"}, "started_at", "desc")
+
+		report := recorder.Report()
+		assert.Equal(t, map[string]Usage{
+			QueryDevices: {
+				Sampled: 2,
+				Fields:  map[string]int64{"name": 1, "status": 2, "tenant_id": 2},
+				Sorts:   map[string]int64{"last_seen:desc": 1, "default": 1},
+				Shapes: map[string]int64{
+					"name,status,tenant_id|last_seen:desc": 1,
+					"status,tenant_id|default":             1,
+				},
+			},
+			QuerySessions: {
+				Sampled: 1,
+				Fields:  map[string]int64{"tenant_id": 1},
+				Sorts:   map[string]int64{"started_at:desc": 1},
+				Shapes:  map[string]int64{"tenant_id|started_at:desc": 1},
+			},
+		}, report.Queries)
+	})
+}
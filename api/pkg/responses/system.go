@@ -5,6 +5,12 @@ type SystemInfo struct {
 	Endpoints      *SystemEndpointsInfo      `json:"endpoints"`
 	Setup          bool                      `json:"setup"`
 	Authentication *SystemAuthenticationInfo `json:"authentication"`
+	Features       *SystemFeaturesInfo       `json:"features"`
+}
+
+type SystemFeaturesInfo struct {
+	// DeviceInfoHash indicates the devices may report their information by its digest when it is unchanged.
+	DeviceInfoHash bool `json:"device_info_hash"`
 }
 
 type SystemAuthenticationInfo struct {
@@ -15,4 +21,20 @@ type SystemAuthenticationInfo struct {
 type SystemEndpointsInfo struct {
 	API string `json:"api"`
 	SSH string `json:"ssh"`
+	// Tunnel is the path prefix where the agents open the reverse tunnel.
+	Tunnel string `json:"tunnel"`
+	// Gateways are the regional SSH gateways where the agents may open the reverse tunnel, instead of the API's host.
+	Gateways []SystemGatewayInfo `json:"gateways,omitempty"`
+}
+
+type SystemGatewayInfo struct {
+	// Region labels the gateway's location.
+	Region string `json:"region"`
+	// URL is the address, with the scheme, where the agents open the reverse tunnel to the gateway.
+	URL string `json:"url"`
+	// SSH is the gateway's SSH endpoint, used on the devices' SSHIDs.
+	SSH string `json:"ssh,omitempty"`
+	// Latency is the expected latency, in milliseconds, to the gateway. It orders the gateways whose latency couldn't
+	// be measured by the agent.
+	Latency int `json:"latency,omitempty"`
 }
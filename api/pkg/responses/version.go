@@ -0,0 +1,21 @@
+package responses
+
+import "time"
+
+// APIVersion is a version of the public API and the breaking changes it introduced over the previous one.
+type APIVersion struct {
+	Version string `json:"version"`
+	Prefix  string `json:"prefix"`
+	// ReleasedAt is when the version was released, deprecating the routes it changed. It is nil on the first version.
+	ReleasedAt *time.Time  `json:"released_at,omitempty"`
+	Changes    []APIChange `json:"changes"`
+}
+
+// APIChange is a breaking change on a route of the public API.
+type APIChange struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	// Successor is the route that replaces the changed one. It is empty when there isn't one.
+	Successor string `json:"successor,omitempty"`
+}
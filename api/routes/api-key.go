@@ -2,7 +2,6 @@ package routes
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -60,7 +59,7 @@ func (h *Handler) ListAPIKeys(c gateway.Context) error {
 		return err
 	}
 
-	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+	setPaginationHeaders(c, &req.Paginator, count)
 
 	return c.JSON(http.StatusOK, res)
 }
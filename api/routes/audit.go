@@ -0,0 +1,182 @@
+package routes
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListAuditEntriesURL   = "/audit"
+	ExportAuditEntriesURL = "/audit/export"
+)
+
+const (
+	AuditExportFormatCSV  = "csv"
+	AuditExportFormatJSON = "json"
+)
+
+// ListAuditEntries lists the entries of the namespace's audit trail, most recent first.
+func (h *Handler) ListAuditEntries(c gateway.Context) error {
+	req := new(requests.AuditEntriesList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListAuditEntries(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ExportAuditEntries downloads every entry of the namespace's audit trail selected by the filter, most recent first,
+// encoded as a CSV file or a JSON array.
+func (h *Handler) ExportAuditEntries(c gateway.Context) error {
+	req := new(requests.AuditEntriesExport)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if req.Format == "" {
+		req.Format = AuditExportFormatCSV
+	}
+
+	var exporter auditExporter
+	switch req.Format {
+	case AuditExportFormatJSON:
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		exporter = &auditJSONExporter{w: c.Response()}
+	default:
+		c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+		exporter = &auditCSVExporter{w: csv.NewWriter(c.Response())}
+	}
+
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "audit."+req.Format))
+
+	// NOTICE: the exporters only write the response on the first entry, so a failure to read the first page is still
+	// answered with its status code.
+	if err := h.service.ExportAuditEntries(c.Ctx(), req, exporter.Write); err != nil {
+		return err
+	}
+
+	return exporter.Close()
+}
+
+// auditExporter encodes the entries of an audit trail's export.
+type auditExporter interface {
+	// Write encodes the entry, writing the export's header before the first one.
+	Write(entry *models.AuditEntry) error
+	// Close finishes the export, writing its header when no entry was written.
+	Close() error
+}
+
+type auditCSVExporter struct {
+	w       *csv.Writer
+	started bool
+}
+
+func (e *auditCSVExporter) start() error {
+	if e.started {
+		return nil
+	}
+
+	e.started = true
+
+	return e.w.Write([]string{"created_at", "action", "actor_type", "actor_id", "actor_username", "target_type", "target_id", "source_ip", "details"})
+}
+
+func (e *auditCSVExporter) Write(entry *models.AuditEntry) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	// NOTICE: the details are encoded as "key=value" pairs, sorted by key, so the column is the same on every export.
+	details := make([]string, 0, len(entry.Details))
+	for k, v := range entry.Details {
+		details = append(details, k+"="+v)
+	}
+
+	sort.Strings(details)
+
+	return e.w.Write([]string{
+		entry.CreatedAt.UTC().Format(time.RFC3339),
+		string(entry.Action),
+		string(entry.Actor.Type),
+		entry.Actor.ID,
+		entry.Actor.Username,
+		string(entry.Target.Type),
+		entry.Target.ID,
+		entry.SourceIP,
+		strings.Join(details, ";"),
+	})
+}
+
+func (e *auditCSVExporter) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	e.w.Flush()
+
+	return e.w.Error()
+}
+
+type auditJSONExporter struct {
+	w       io.Writer
+	started bool
+}
+
+func (e *auditJSONExporter) Write(entry *models.AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if !e.started {
+		separator = "["
+		e.started = true
+	}
+
+	_, err = e.w.Write(append([]byte(separator), data...))
+
+	return err
+}
+
+func (e *auditJSONExporter) Close() error {
+	if !e.started {
+		_, err := e.w.Write([]byte("[]"))
+
+		return err
+	}
+
+	_, err := e.w.Write([]byte("]"))
+
+	return err
+}
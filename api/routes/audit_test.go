@@ -0,0 +1,194 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListAuditEntries(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		query         string
+		role          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the role cannot review the audit trail",
+			query:         "",
+			role:          "operator",
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description:   "fails when the target type is invalid",
+			query:         "?target_type=session",
+			role:          "administrator",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			query:       "?action=device.remove&target_type=device&from=2023-01-01T00:00:00Z",
+			role:        "administrator",
+			requiredMocks: func() {
+				svcMock.
+					On("ListAuditEntries", gomock.Anything, &requests.AuditEntriesList{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						AuditEntryFilter: requests.AuditEntryFilter{
+							Action:     "device.remove",
+							TargetType: "device",
+							From:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+						Paginator: query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return([]models.AuditEntry{{ID: "00000000-0000-4000-0000-000000000001"}}, 1, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/audit"+tc.query, nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestExportAuditEntries(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	entry := &models.AuditEntry{
+		ID:        "00000000-0000-4000-0000-000000000001",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		Actor:     models.AuditActor{Type: models.AuditActorUser, ID: "000000000000000000000000", Username: "john_doe"},
+		Action:    models.AuditActionDeviceRename,
+		Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+		Details:   map[string]string{"name": "new-name"},
+		SourceIP:  "203.0.113.10",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	export := func(args gomock.Arguments) {
+		fn := args.Get(2).(func(*models.AuditEntry) error)
+		fn(entry) //nolint:errcheck
+	}
+
+	type Expected struct {
+		status int
+		body   string
+	}
+
+	cases := []struct {
+		description   string
+		query         string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the format is invalid",
+			query:         "?format=xml",
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description: "fails when the entries cannot be exported",
+			query:       "",
+			requiredMocks: func() {
+				svcMock.
+					On("ExportAuditEntries", gomock.Anything, &requests.AuditEntriesExport{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Format:   "csv",
+					}, gomock.Anything).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: Expected{status: http.StatusInternalServerError},
+		},
+		{
+			description: "succeeds exporting as CSV",
+			query:       "",
+			requiredMocks: func() {
+				svcMock.
+					On("ExportAuditEntries", gomock.Anything, &requests.AuditEntriesExport{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Format:   "csv",
+					}, gomock.Anything).
+					Run(export).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				status: http.StatusOK,
+				body: "created_at,action,actor_type,actor_id,actor_username,target_type,target_id,source_ip,details\n" +
+					"2023-01-01T12:00:00Z,device.rename,user,000000000000000000000000,john_doe,device,uid,203.0.113.10,name=new-name\n",
+			},
+		},
+		{
+			description: "succeeds exporting as JSON",
+			query:       "?format=json",
+			requiredMocks: func() {
+				svcMock.
+					On("ExportAuditEntries", gomock.Anything, &requests.AuditEntriesExport{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Format:   "json",
+					}, gomock.Anything).
+					Run(export).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				status: http.StatusOK,
+				body:   `[{"id":"00000000-0000-4000-0000-000000000001","tenant_id":"00000000-0000-4000-0000-000000000000","actor":{"type":"user","id":"000000000000000000000000","username":"john_doe"},"action":"device.rename","target":{"type":"device","id":"uid"},"details":{"name":"new-name"},"source_ip":"203.0.113.10","created_at":"2023-01-01T12:00:00Z"}]`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/audit/export"+tc.query, nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", "owner")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+			if tc.expected.body != "" {
+				assert.Equal(t, tc.expected.body, rec.Body.String())
+			}
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	errs "github.com/shellhub-io/shellhub/api/routes/errors"
@@ -11,6 +12,8 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	pkgerrors "github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 const (
@@ -23,6 +26,15 @@ const (
 	AuthUserTokenPublicURL   = "/auth/token/:tenant" //nolint:gosec
 	AuthPublicKeyURL         = "/auth/ssh"
 	AuthMFAURL               = "/auth/mfa"
+	AuthRefreshUserTokenURL  = "/auth/refresh" //nolint:gosec
+)
+
+var (
+	// DeviceAuthOverloadBackoff is the backoff hinted to the devices refused when the device authentications handled
+	// at the same time are above the budget.
+	DeviceAuthOverloadBackoff = models.Backoff{RetryAfter: 10 * time.Second, Jitter: 50 * time.Second}
+	// DeviceAuthLimitBackoff is the backoff hinted to the devices refused by the namespace's limits.
+	DeviceAuthLimitBackoff = models.Backoff{RetryAfter: 2 * time.Minute, Jitter: 3 * time.Minute}
 )
 
 // AuthRequest is a proxy-level authentication middleware. It decodes a specified
@@ -48,8 +60,11 @@ const (
 // If the authentication fails for any reason, it must return the failed status
 // without redirecting the request. A token can be use to authenticate either a
 // device or a user.
+//
+// API keys are read from the X-API-Key header or, when prefixed by [models.APIKeyPrefix],
+// from the Authorization header as a bearer token, in place of a JWT.
 func (h *Handler) AuthRequest(c gateway.Context) error {
-	if key := c.Request().Header.Get("X-API-Key"); key != "" {
+	if key := gateway.APIKeyFromRequest(c.Request()); key != "" {
 		apiKey, err := h.service.AuthAPIKey(c.Ctx(), key)
 		if err != nil {
 			return err
@@ -73,6 +88,13 @@ func (h *Handler) AuthRequest(c gateway.Context) error {
 		c.Response().Header().Set("X-Device-UID", claims.UID)
 		c.Response().Header().Set("X-Tenant-ID", claims.TenantID)
 	case *authorizer.UserClaims:
+		// Tokens of revoked sessions are rejected even before they expire.
+		if claims.SessionID != "" {
+			if err := h.service.AuthUserSession(c.Ctx(), claims.SessionID); err != nil {
+				return err
+			}
+		}
+
 		// As the role is a dynamic attribute, and a JWT token must be stateless, we need to retrieve the role
 		// every time this middleware is invoked (generally from the cache).
 		if claims.TenantID != "" {
@@ -88,6 +110,7 @@ func (h *Handler) AuthRequest(c gateway.Context) error {
 		c.Response().Header().Set("X-Username", claims.Username)
 		c.Response().Header().Set("X-Tenant-ID", claims.TenantID)
 		c.Response().Header().Set("X-Role", claims.Role.String())
+		c.Response().Header().Set("X-Session-ID", claims.SessionID)
 	default:
 		return c.NoContent(http.StatusUnauthorized)
 	}
@@ -105,9 +128,26 @@ func (h *Handler) AuthDevice(c gateway.Context) error {
 		return err
 	}
 
+	if h.deviceAuthBudget != nil {
+		select {
+		case h.deviceAuthBudget <- struct{}{}:
+			defer func() { <-h.deviceAuthBudget }()
+		default:
+			DeviceAuthOverloadBackoff.SetHeader(c.Response().Header())
+
+			return c.NoContent(http.StatusServiceUnavailable)
+		}
+	}
+
 	ip := c.Request().Header.Get("X-Real-IP")
 	res, err := h.service.AuthDevice(c.Ctx(), req, ip)
 	if err != nil {
+		// NOTICE: the namespace's limits aren't lifted in seconds, so the device waits longer before trying again.
+		var e pkgerrors.Error
+		if errors.As(err, &e) && e.Layer == svc.ErrLayer && (e.Code == svc.ErrCodeLimit || e.Code == svc.ErrCodePayment) {
+			DeviceAuthLimitBackoff.SetHeader(c.Response().Header())
+		}
+
 		return err
 	}
 
@@ -168,6 +208,25 @@ func (h *Handler) CreateUserToken(c gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h *Handler) RefreshUserToken(c gateway.Context) error {
+	req := new(requests.RefreshUserToken)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.RefreshUserToken(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
 func (h *Handler) AuthPublicKey(c gateway.Context) error {
 	var req requests.PublicKeyAuth
 	if err := c.Bind(&req); err != nil {
@@ -143,6 +143,81 @@ func TestAuthDevice(t *testing.T) {
 	}
 }
 
+func TestAuthDeviceBackoff(t *testing.T) {
+	body, err := json.Marshal(&requests.DeviceAuth{
+		Info: &requests.DeviceInfo{
+			ID:         "device_id",
+			PrettyName: "Device Name",
+			Version:    "1.0",
+			Arch:       "amd64",
+			Platform:   "Linux",
+		},
+		Hostname:  "test",
+		PublicKey: "your_public_key",
+		TenantID:  "your_tenant_id",
+	})
+	require.NoError(t, err)
+
+	request := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/devices/auth", strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/json")
+
+		return req
+	}
+
+	t.Run("hints the backoff when the namespace's limit is reached", func(t *testing.T) {
+		mock := new(mocks.Service)
+		mock.On("AuthDevice", gomock.Anything, gomock.AnythingOfType("requests.DeviceAuth"), "").
+			Return(nil, svc.NewErrDeviceMaxPendingDevicesReached(3)).
+			Once()
+
+		rec := httptest.NewRecorder()
+		NewRouter(mock).ServeHTTP(rec, request())
+
+		assert.Equal(t, http.StatusForbidden, rec.Result().StatusCode)
+		assert.Equal(t, "120", rec.Result().Header.Get(models.RetryAfterHeader))
+		assert.Equal(t, "180", rec.Result().Header.Get(models.RetryJitterHeader))
+	})
+
+	t.Run("hints the backoff when the budget is exhausted", func(t *testing.T) {
+		entered := make(chan struct{})
+		release := make(chan struct{})
+
+		mock := new(mocks.Service)
+		mock.On("AuthDevice", gomock.Anything, gomock.AnythingOfType("requests.DeviceAuth"), "").
+			Run(func(gomock.Arguments) {
+				close(entered)
+				<-release
+			}).
+			Return(&models.DeviceAuthResponse{}, nil).
+			Once()
+
+		e := NewRouter(mock, WithDeviceAuthBudget(1))
+
+		done := make(chan int)
+		go func() {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, request())
+
+			done <- rec.Result().StatusCode
+		}()
+
+		<-entered
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, request())
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Result().StatusCode)
+		assert.Equal(t, "10", rec.Result().Header.Get(models.RetryAfterHeader))
+		assert.Equal(t, "50", rec.Result().Header.Get(models.RetryJitterHeader))
+
+		close(release)
+		assert.Equal(t, http.StatusOK, <-done)
+
+		mock.AssertExpectations(t)
+	})
+}
+
 func TestAuthLocalUser(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -463,6 +538,77 @@ func TestCreateUserToken(t *testing.T) {
 	}
 }
 
+func TestRefreshUserToken(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	type Expected struct {
+		body   *models.UserAuthResponse
+		status int
+	}
+
+	cases := []struct {
+		description string
+		body        string
+		mocks       func()
+		expected    Expected
+	}{
+		{
+			description: "fails when the refresh token is missing",
+			body:        `{}`,
+			mocks:       func() {},
+			expected:    Expected{body: nil, status: http.StatusBadRequest},
+		},
+		{
+			description: "fails when the refresh token is invalid",
+			body:        `{"refresh_token":"session.secret"}`,
+			mocks: func() {
+				svcMock.
+					On("RefreshUserToken", gomock.Anything, &requests.RefreshUserToken{RefreshToken: "session.secret", UserAgent: "Mozilla/5.0"}).
+					Return(nil, svc.NewErrAuthUnathorized(nil)).
+					Once()
+			},
+			expected: Expected{body: nil, status: http.StatusUnauthorized},
+		},
+		{
+			description: "succeeds",
+			body:        `{"refresh_token":"session.secret"}`,
+			mocks: func() {
+				svcMock.
+					On("RefreshUserToken", gomock.Anything, &requests.RefreshUserToken{RefreshToken: "session.secret", UserAgent: "Mozilla/5.0"}).
+					Return(&models.UserAuthResponse{ID: "000000000000000000000000", Token: "not-empty", RefreshToken: "session.rotated"}, nil).
+					Once()
+			},
+			expected: Expected{
+				body:   &models.UserAuthResponse{ID: "000000000000000000000000", Token: "not-empty", RefreshToken: "session.rotated"},
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+
+			rec := httptest.NewRecorder()
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			var body *models.UserAuthResponse
+			if rec.Result().StatusCode == http.StatusOK {
+				require.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&body))
+			}
+
+			assert.Equal(t, tc.expected, Expected{body, rec.Result().StatusCode})
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
 func TestAuthPublicKey(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -569,25 +715,82 @@ func TestHandler_AuthRequest_with_authorization_header(t *testing.T) {
 			description: "succeeds to authenticate a user",
 			token: func() (string, error) {
 				claims := authorizer.UserClaims{
-					ID:       "000000000000000000000000",
-					TenantID: "00000000-0000-4000-0000-000000000000",
-					Role:     authorizer.RoleOwner,
-					Username: "john_doe",
+					ID:        "000000000000000000000000",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Role:      authorizer.RoleOwner,
+					Username:  "john_doe",
+					SessionID: "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
 				}
 
 				return jwttoken.EncodeUserClaims(claims, privateKey)
 			},
 			requiredMocks: func() {
 				svcMock.On("PublicKey").Return(&privateKey.PublicKey).Once()
+				svcMock.On("AuthUserSession", gomock.Anything, "6f1b2c3d-0c1b-4d7e-8f3a-000000000001").Return(nil).Once()
 				svcMock.On("GetUserRole", gomock.Anything, "00000000-0000-4000-0000-000000000000", "000000000000000000000000").Return("owner", nil).Once()
 			},
 			expected: Expected{
 				status: 200,
 				headers: map[string]string{
-					"X-ID":        "000000000000000000000000",
+					"X-ID":         "000000000000000000000000",
+					"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+					"X-Role":       authorizer.RoleOwner.String(),
+					"X-Username":   "john_doe",
+					"X-Session-ID": "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+				},
+			},
+		},
+		{
+			description: "fails when the user's session was revoked",
+			token: func() (string, error) {
+				claims := authorizer.UserClaims{
+					ID:        "000000000000000000000000",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Username:  "john_doe",
+					SessionID: "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+				}
+
+				return jwttoken.EncodeUserClaims(claims, privateKey)
+			},
+			requiredMocks: func() {
+				svcMock.On("PublicKey").Return(&privateKey.PublicKey).Once()
+				svcMock.On("AuthUserSession", gomock.Anything, "6f1b2c3d-0c1b-4d7e-8f3a-000000000001").Return(svc.NewErrUserSessionRevoked(nil)).Once()
+			},
+			expected: Expected{
+				status:  401,
+				headers: map[string]string{},
+			},
+		},
+		{
+			description: "fails when the API key is invalid",
+			token: func() (string, error) {
+				return "Bearer sk_cdfd3cb0-c44e-4e54-b931-6d57713ad159", nil
+			},
+			requiredMocks: func() {
+				svcMock.On("AuthAPIKey", gomock.Anything, "sk_cdfd3cb0-c44e-4e54-b931-6d57713ad159").Return(nil, svc.NewErrAPIKeyInvalid("dev")).Once()
+			},
+			expected: Expected{
+				status:  400,
+				headers: map[string]string{},
+			},
+		},
+		{
+			description: "succeeds to authenticate an API key",
+			token: func() (string, error) {
+				return "Bearer sk_cdfd3cb0-c44e-4e54-b931-6d57713ad159", nil
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("AuthAPIKey", gomock.Anything, "sk_cdfd3cb0-c44e-4e54-b931-6d57713ad159").
+					Return(&models.APIKey{Name: "dev", TenantID: "00000000-0000-4000-0000-000000000000", Role: authorizer.RoleOperator}, nil).
+					Once()
+			},
+			expected: Expected{
+				status: 200,
+				headers: map[string]string{
 					"X-Tenant-ID": "00000000-0000-4000-0000-000000000000",
-					"X-Role":      authorizer.RoleOwner.String(),
-					"X-Username":  "john_doe",
+					"X-Role":      authorizer.RoleOperator.String(),
+					"X-API-KEY":   "sk_cdfd3cb0-c44e-4e54-b931-6d57713ad159",
 				},
 			},
 		},
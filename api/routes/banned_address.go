@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+// The banned addresses are only available on the internal API, to be used by the instance's administrator and by the
+// SSH server, which records the authentication failures of its clients.
+const (
+	ListBannedAddressesURL  = "/banned-addresses"
+	CreateBannedAddressURL  = "/banned-addresses"
+	DeleteBannedAddressURL  = "/banned-addresses"
+	RecordAddressFailureURL = "/banned-addresses/failures"
+)
+
+func (h *Handler) ListBannedAddresses(c gateway.Context) error {
+	bans, err := h.service.ListBannedAddresses(c.Ctx())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, bans)
+}
+
+func (h *Handler) CreateBannedAddress(c gateway.Context) error {
+	req := new(requests.BannedAddressCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	ban, err := h.service.CreateBannedAddress(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ban)
+}
+
+func (h *Handler) DeleteBannedAddress(c gateway.Context) error {
+	req := new(requests.BannedAddressDelete)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteBannedAddress(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) RecordAddressFailure(c gateway.Context) error {
+	req := new(requests.BannedAddressFailure)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.RecordAddressFailure(c.Ctx(), req.Address); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,232 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/banlist"
+	cachemock "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateBannedAddress(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the address is missing",
+			body:          map[string]interface{}{"reason": "scanner"},
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when the address is invalid",
+			body:        map[string]interface{}{"address": "192.0.2"},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateBannedAddress", gomock.Anything, &requests.BannedAddressCreate{Address: "192.0.2"}).
+					Return(nil, svc.NewErrBannedAddressInvalid("192.0.2", banlist.ErrAddressInvalid)).
+					Once()
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			body:        map[string]interface{}{"address": "192.0.2.0/24", "reason": "scanner", "duration": 3600},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateBannedAddress", gomock.Anything, &requests.BannedAddressCreate{Address: "192.0.2.0/24", Reason: "scanner", Duration: 3600}).
+					Return(&models.BannedAddress{Address: "192.0.2.0/24", Reason: "scanner"}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/banned-addresses", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestDeleteBannedAddress(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		query         string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the address is missing",
+			query:         "",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when the address isn't banned",
+			query:       "?address=198.51.100.7",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteBannedAddress", gomock.Anything, &requests.BannedAddressDelete{Address: "198.51.100.7"}).
+					Return(svc.NewErrBannedAddressNotFound("198.51.100.7", nil)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			query:       "?address=192.0.2.0%2F24",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteBannedAddress", gomock.Anything, &requests.BannedAddressDelete{Address: "192.0.2.0/24"}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/internal/banned-addresses"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestRecordAddressFailure(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the address isn't an IP address",
+			body:          map[string]interface{}{"address": "192.0.2.0/24"},
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			body:        map[string]interface{}{"address": "198.51.100.7"},
+			requiredMocks: func() {
+				svcMock.
+					On("RecordAddressFailure", gomock.Anything, "198.51.100.7").
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/banned-addresses/failures", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestBanlist(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cache := new(cachemock.Cache)
+	cache.
+		On("Get", gomock.Anything, banlist.CacheKey, gomock.Anything).
+		Run(func(args gomock.Arguments) {
+			*args.Get(2).(*[]models.BannedAddress) = []models.BannedAddress{{Address: "192.0.2.0/24"}}
+		}).
+		Return(nil)
+
+	e := NewRouter(svcMock, WithBanlist(banlist.New(cache, time.Minute)))
+
+	cases := []struct {
+		description string
+		url         string
+		address     string
+		expected    int
+	}{
+		{
+			description: "refuses the banned addresses on the public API",
+			url:         "/api/healthcheck",
+			address:     "192.0.2.7",
+			expected:    http.StatusForbidden,
+		},
+		{
+			description: "allows the addresses not banned",
+			url:         "/api/healthcheck",
+			address:     "198.51.100.7",
+			expected:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.RemoteAddr = tc.address + ":1234"
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListCommandPoliciesURL          = "/command-policies"
+	CreateCommandPolicyURL          = "/command-policies"
+	UpdateCommandPolicyURL          = "/command-policies/:id"
+	DeleteCommandPolicyURL          = "/command-policies/:id"
+	ListCommandPolicyEvaluationsURL = "/command-policies/evaluations"
+
+	GetDeviceCommandPoliciesURL      = "/devices/:uid/command-policies"
+	CreateCommandPolicyEvaluationURL = "/devices/:uid/command-policies/evaluations"
+)
+
+// ListCommandPolicies lists the policies restricting the command lines run on the sessions to the namespace's devices.
+func (h *Handler) ListCommandPolicies(c gateway.Context) error {
+	req := new(requests.CommandPolicyList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	policies, err := h.service.ListCommandPolicies(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, policies)
+}
+
+func (h *Handler) CreateCommandPolicy(c gateway.Context) error {
+	req := new(requests.CommandPolicyCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	policy, err := h.service.CreateCommandPolicy(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+func (h *Handler) UpdateCommandPolicy(c gateway.Context) error {
+	req := new(requests.CommandPolicyUpdate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	policy, err := h.service.UpdateCommandPolicy(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+func (h *Handler) DeleteCommandPolicy(c gateway.Context) error {
+	req := new(requests.CommandPolicyDelete)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteCommandPolicy(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ListCommandPolicyEvaluations lists the command lines that violated the namespace's command policies.
+func (h *Handler) ListCommandPolicyEvaluations(c gateway.Context) error {
+	req := new(requests.CommandPolicyEvaluationsList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	evaluations, count, err := h.service.ListCommandPolicyEvaluations(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, evaluations)
+}
+
+// GetDeviceCommandPolicies gets the command policies the SSH service evaluates on the sessions to the device.
+func (h *Handler) GetDeviceCommandPolicies(c gateway.Context) error {
+	req := new(requests.DeviceCommandPoliciesGet)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	policies, err := h.service.GetDeviceCommandPolicies(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, policies)
+}
+
+// CreateCommandPolicyEvaluation receives, from the SSH service, a command line that violated a command policy on a
+// session to the device.
+func (h *Handler) CreateCommandPolicyEvaluation(c gateway.Context) error {
+	req := new(requests.CommandPolicyEvaluationCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.CreateCommandPolicyEvaluation(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
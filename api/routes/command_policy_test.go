@@ -0,0 +1,280 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateCommandPolicy(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when role is operator",
+			role:        "operator",
+			body: map[string]interface{}{
+				"name":     "destructive",
+				"mode":     "deny",
+				"patterns": []string{`rm\s+-rf`},
+				"action":   "block",
+			},
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "fails when the policy has no patterns",
+			role:        "owner",
+			body: map[string]interface{}{
+				"name":     "destructive",
+				"mode":     "deny",
+				"patterns": []string{},
+				"action":   "block",
+			},
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when the action is unknown",
+			role:        "owner",
+			body: map[string]interface{}{
+				"name":     "destructive",
+				"mode":     "deny",
+				"patterns": []string{`rm\s+-rf`},
+				"action":   "kill",
+			},
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when a pattern is invalid",
+			role:        "owner",
+			body: map[string]interface{}{
+				"name":     "destructive",
+				"mode":     "deny",
+				"patterns": []string{`(rm`},
+				"action":   "block",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateCommandPolicy", gomock.Anything, &requests.CommandPolicyCreate{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Name:     "destructive",
+						Mode:     models.CommandPolicyModeDeny,
+						Patterns: []string{`(rm`},
+						Action:   models.CommandPolicyActionBlock,
+					}).
+					Return(nil, svc.NewErrCommandPolicyInvalid(svc.ErrCommandPolicyInvalid)).
+					Once()
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			role:        "administrator",
+			body: map[string]interface{}{
+				"name":     "destructive",
+				"tags":     []string{"production"},
+				"mode":     "deny",
+				"patterns": []string{`rm\s+-rf`},
+				"action":   "block",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateCommandPolicy", gomock.Anything, &requests.CommandPolicyCreate{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Name:     "destructive",
+						Tags:     []string{"production"},
+						Mode:     models.CommandPolicyModeDeny,
+						Patterns: []string{`rm\s+-rf`},
+						Action:   models.CommandPolicyActionBlock,
+					}).
+					Return(&models.CommandPolicy{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/command-policies", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestDeleteCommandPolicy(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when role is operator",
+			role:          "operator",
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "fails when the policy is not found",
+			role:        "administrator",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteCommandPolicy", gomock.Anything, &requests.CommandPolicyDelete{
+						CommandPolicyParam: requests.CommandPolicyParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+						TenantID:           "00000000-0000-4000-0000-000000000000",
+					}).
+					Return(svc.NewErrCommandPolicyNotFound("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a", nil)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			role:        "owner",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteCommandPolicy", gomock.Anything, &requests.CommandPolicyDelete{
+						CommandPolicyParam: requests.CommandPolicyParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+						TenantID:           "00000000-0000-4000-0000-000000000000",
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/command-policies/c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a", nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestCreateCommandPolicyEvaluation(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	at := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		description   string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when the action is unknown",
+			body: map[string]interface{}{
+				"tenant_id":   "00000000-0000-4000-0000-000000000000",
+				"policy_id":   "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+				"session_uid": "session",
+				"command":     "rm -rf /",
+				"action":      "kill",
+				"time":        at,
+			},
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			body: map[string]interface{}{
+				"tenant_id":   "00000000-0000-4000-0000-000000000000",
+				"policy_id":   "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+				"session_uid": "session",
+				"command":     "rm -rf /",
+				"action":      "block",
+				"time":        at,
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateCommandPolicyEvaluation", gomock.Anything, &requests.CommandPolicyEvaluationCreate{
+						DeviceParam: requests.DeviceParam{UID: "device"},
+						CommandPolicyEvaluation: models.CommandPolicyEvaluation{
+							TenantID:   "00000000-0000-4000-0000-000000000000",
+							PolicyID:   "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+							SessionUID: "session",
+							Command:    "rm -rf /",
+							Action:     models.CommandPolicyActionBlock,
+							Time:       at,
+						},
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/devices/device/command-policies/evaluations", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
@@ -2,9 +2,9 @@ package routes
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -23,6 +23,10 @@ const (
 	UpdateTagURL                = "/devices/:uid/tags"      // Update device's tags with a new set.
 	RemoveTagURL                = "/devices/:uid/tags/:tag" // Delete a tag from a device.
 	UpdateDevice                = "/devices/:uid"
+	ClaimDeviceURL              = "/devices/claim"
+	UpdateDeviceLoginShellURL   = "/devices/:uid/login-shell"
+	UpdateDeviceRemoteAccessURL = "/devices/:uid/remote-access"
+	UpdateDeviceNoteURL         = "/devices/:uid/connection-note"
 )
 
 const (
@@ -90,12 +94,16 @@ func (h *Handler) GetDeviceList(c gateway.Context) error {
 	}
 
 	res, count, err := h.service.ListDevices(c.Ctx(), req)
-	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
-
 	if err != nil {
 		return err
 	}
 
+	for i := range res {
+		maskDevice(c.Role(), &res[i])
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
 	return c.JSON(http.StatusOK, res)
 }
 
@@ -114,9 +122,24 @@ func (h *Handler) GetDevice(c gateway.Context) error {
 		return err
 	}
 
+	maskDevice(c.Role(), device)
+
 	return c.JSON(http.StatusOK, device)
 }
 
+// maskDevice clears the device's sensitive fields, what could help to target it outside ShellHub, when the role
+// doesn't have the [authorizer.DeviceSensitiveDetails] permission.
+func maskDevice(role authorizer.Role, device *models.Device) {
+	if device == nil || role.HasPermission(authorizer.DeviceSensitiveDetails) {
+		return
+	}
+
+	device.PublicKey = ""
+	device.RemoteAddr = ""
+	device.PublicURLAddress = ""
+	device.Addresses = nil
+}
+
 func (h *Handler) GetDeviceByPublicURLAddress(c gateway.Context) error {
 	var req requests.DevicePublicURLAddress
 	if err := c.Bind(&req); err != nil {
@@ -243,6 +266,75 @@ func (h *Handler) UpdateDeviceStatus(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) ClaimDevice(c gateway.Context) error {
+	var req requests.DeviceClaim
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	device, err := h.service.ClaimDevice(c.Ctx(), &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, device)
+}
+
+func (h *Handler) UpdateDeviceLoginShell(c gateway.Context) error {
+	var req requests.DeviceUpdateLoginShell
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.UpdateDeviceLoginShell(c.Ctx(), &req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) UpdateDeviceConnectionNote(c gateway.Context) error {
+	var req requests.DeviceUpdateConnectionNote
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.UpdateDeviceConnectionNote(c.Ctx(), &req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) UpdateDeviceRemoteAccess(c gateway.Context) error {
+	var req requests.DeviceUpdateRemoteAccess
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.UpdateDeviceRemoteAccess(c.Ctx(), &req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) CreateDeviceTag(c gateway.Context) error {
 	var req requests.DeviceCreateTag
 	if err := c.Bind(&req); err != nil {
@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	CreateDeviceAgentLogsURL = "/devices/:uid/agent-logs"
+	ListDeviceAgentLogsURL   = "/devices/:uid/agent-logs"
+)
+
+// CreateDeviceAgentLogs receives the logs reported by the device's agent. It must be authenticated by the device's
+// token, as the device can only report its own logs.
+func (h *Handler) CreateDeviceAgentLogs(c gateway.Context) error {
+	req := new(requests.DeviceAgentLogsCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.CreateDeviceAgentLogs(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListDeviceAgentLogs(c gateway.Context) error {
+	req := new(requests.DeviceAgentLogsList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListDeviceAgentLogs(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,178 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceAgentLogs(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		deviceUID      string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the request isn't authenticated by a device",
+			body:           `{"logs": [{"level": "error", "message": "Failed to start the PTY", "time": "2023-01-01T12:00:00Z"}]}`,
+			deviceUID:      "",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when there are no logs",
+			body:           `{"logs": []}`,
+			deviceUID:      "1234",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the log's level is invalid",
+			body:           `{"logs": [{"level": "debug", "message": "Failed to start the PTY", "time": "2023-01-01T12:00:00Z"}]}`,
+			deviceUID:      "1234",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:     "fails when the rate limit is reached",
+			body:      `{"logs": [{"level": "error", "message": "Failed to start the PTY", "time": "2023-01-01T12:00:00Z"}]}`,
+			deviceUID: "1234",
+			requiredMocks: func() {
+				mock.
+					On("CreateDeviceAgentLogs", gomock.Anything, &requests.DeviceAgentLogsCreate{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						DeviceUID:   "1234",
+						Logs: []models.DeviceAgentLog{
+							{Level: "error", Message: "Failed to start the PTY", Time: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)},
+						},
+					}).
+					Return(svc.NewErrDeviceAgentLogsLimit(svc.DeviceAgentLogsRateLimit)).
+					Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:     "succeeds",
+			body:      `{"logs": [{"level": "error", "message": "Failed to start the PTY", "time": "2023-01-01T12:00:00Z"}]}`,
+			deviceUID: "1234",
+			requiredMocks: func() {
+				mock.
+					On("CreateDeviceAgentLogs", gomock.Anything, &requests.DeviceAgentLogsCreate{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						DeviceUID:   "1234",
+						Logs: []models.DeviceAgentLog{
+							{Level: "error", Message: "Failed to start the PTY", Time: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)},
+						},
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/1234/agent-logs", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			if tc.deviceUID != "" {
+				req.Header.Set("X-Device-UID", tc.deviceUID)
+			}
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceAgentLogs(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		query          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the level is invalid",
+			query:          "?level=debug",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the device is not found",
+			query: "",
+			requiredMocks: func() {
+				mock.
+					On("ListDeviceAgentLogs", gomock.Anything, &requests.DeviceAgentLogsList{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Paginator:   query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return(nil, 0, svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			query: "?level=error",
+			requiredMocks: func() {
+				mock.
+					On("ListDeviceAgentLogs", gomock.Anything, &requests.DeviceAgentLogsList{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Level:       "error",
+						Paginator:   query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return([]models.DeviceAgentLog{{ID: "id", DeviceUID: "1234", Level: "error"}}, 1, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/1234/agent-logs"+tc.query, nil)
+			req.Header.Set("X-Role", authorizer.RoleObserver.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const ListDeviceChangesURL = "/devices/changes"
+
+// ListDeviceChanges lists the changes on the namespace's devices since the cursor, with the cursor of the next request.
+func (h *Handler) ListDeviceChanges(c gateway.Context) error {
+	req := new(requests.DeviceChangesList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	changes, err := h.service.ListDeviceChanges(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	for i := range changes.Changes {
+		maskDevice(c.Role(), changes.Changes[i].Device)
+	}
+
+	return c.JSON(http.StatusOK, changes)
+}
@@ -0,0 +1,111 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDeviceChanges(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		query          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the cursor isn't numeric",
+			query:          "?since=abc",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the limit is out of range",
+			query:          "?limit=1001",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "succeeds",
+			query: "?since=10&limit=2",
+			requiredMocks: func() {
+				mock.
+					On("ListDeviceChanges", gomock.Anything, &requests.DeviceChangesList{TenantID: "tenant-id", Since: "10", Limit: 2}).
+					Return(&responses.DeviceChanges{
+						Changes: []models.DeviceChange{{UID: "1234", Type: models.DeviceChangeDeleted}},
+						Cursor:  "11",
+					}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/changes"+tc.query, nil)
+			req.Header.Set("X-Role", authorizer.RoleObserver.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	t.Run("masks the devices' sensitive details for an observer", func(t *testing.T) {
+		mock.
+			On("ListDeviceChanges", gomock.Anything, &requests.DeviceChangesList{TenantID: "tenant-id", Since: "11"}).
+			Return(&responses.DeviceChanges{
+				Changes: []models.DeviceChange{
+					{UID: "1234", Type: models.DeviceChangeDeleted},
+					{
+						UID:  "5678",
+						Type: models.DeviceChangeUpdated,
+						Device: &models.Device{
+							UID:              "5678",
+							PublicKey:        "public-key",
+							RemoteAddr:       "192.168.0.10",
+							PublicURLAddress: "address",
+						},
+					},
+				},
+				Cursor: "13",
+			}, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/devices/changes?since=11", nil)
+		req.Header.Set("X-Role", authorizer.RoleObserver.String())
+		req.Header.Set("X-Tenant-ID", "tenant-id")
+		rec := httptest.NewRecorder()
+
+		NewRouter(mock).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+		changes := new(responses.DeviceChanges)
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(changes))
+
+		require.Len(t, changes.Changes, 2)
+		assert.Nil(t, changes.Changes[0].Device)
+		assert.Equal(t, &models.Device{UID: "5678"}, changes.Changes[1].Device)
+	})
+
+	mock.AssertExpectations(t)
+}
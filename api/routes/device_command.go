@@ -0,0 +1,99 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	CreateDeviceCommandURL    = "/devices/:uid/commands"
+	ListDeviceCommandsURL     = "/devices/:uid/commands"
+	DispatchDeviceCommandsURL = "/devices/:uid/commands/dispatch"
+	FinishDeviceCommandURL    = "/devices/:uid/commands/:id/result"
+)
+
+// CreateDeviceCommand queues a command to the device, executed by its agent when it authorizes on the server.
+func (h *Handler) CreateDeviceCommand(c gateway.Context) error {
+	req := new(requests.DeviceCommandCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	command, err := h.service.CreateDeviceCommand(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, command)
+}
+
+func (h *Handler) ListDeviceCommands(c gateway.Context) error {
+	req := new(requests.DeviceCommandsList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListDeviceCommands(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DispatchDeviceCommands delivers the commands queued to the device to its agent. It must be authenticated by the
+// device's token, as the device can only fetch its own commands.
+func (h *Handler) DispatchDeviceCommands(c gateway.Context) error {
+	req := new(requests.DeviceCommandsDispatch)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	commands, err := h.service.DispatchDeviceCommands(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, commands)
+}
+
+// FinishDeviceCommand receives the result of a command executed by the device's agent. It must be authenticated by
+// the device's token, as the device can only report the result of its own commands.
+func (h *Handler) FinishDeviceCommand(c gateway.Context) error {
+	req := new(requests.DeviceCommandFinish)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.FinishDeviceCommand(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,208 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceCommand(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		body          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when role is operator",
+			role:          "operator",
+			body:          `{"command": "apt-get update"}`,
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description:   "fails when the command is missing",
+			role:          "owner",
+			body:          `{"user": "root"}`,
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			role:        "administrator",
+			body:        `{"command": "apt-get update", "user": "root"}`,
+			requiredMocks: func() {
+				svcMock.
+					On("CreateDeviceCommand", gomock.Anything, &requests.DeviceCommandCreate{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						UserID:      "000000000000000000000000",
+						Command:     "apt-get update",
+						User:        "root",
+					}).
+					Return(&models.DeviceCommand{ID: "id", Status: models.DeviceCommandStatusQueued}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/1234/commands", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestDispatchDeviceCommands(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		deviceUID     string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the request isn't authenticated by a device",
+			deviceUID:     "",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			deviceUID:   "1234",
+			requiredMocks: func() {
+				svcMock.
+					On("DispatchDeviceCommands", gomock.Anything, &requests.DeviceCommandsDispatch{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						DeviceUID:   "1234",
+					}).
+					Return([]models.DeviceCommand{{ID: "id", Command: "reboot", Status: models.DeviceCommandStatusSent}}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/1234/commands/dispatch", nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			if tc.deviceUID != "" {
+				req.Header.Set("X-Device-UID", tc.deviceUID)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestFinishDeviceCommand(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		body          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the output is too large",
+			body:          `{"exit_code": 0, "output": "` + strings.Repeat("a", models.DeviceCommandOutputMaxSize+1) + `"}`,
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when the command isn't sent to the device",
+			body:        `{"exit_code": 0, "output": "done"}`,
+			requiredMocks: func() {
+				svcMock.
+					On("FinishDeviceCommand", gomock.Anything, &requests.DeviceCommandFinish{
+						DeviceParam:         requests.DeviceParam{UID: "1234"},
+						TenantID:            "00000000-0000-4000-0000-000000000000",
+						DeviceUID:           "1234",
+						ID:                  "id",
+						DeviceCommandResult: models.DeviceCommandResult{ExitCode: 0, Output: "done"},
+					}).
+					Return(svc.NewErrDeviceCommandNotFound("id", store.ErrNoDocuments)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			body:        `{"exit_code": 127, "output": "command not found"}`,
+			requiredMocks: func() {
+				svcMock.
+					On("FinishDeviceCommand", gomock.Anything, &requests.DeviceCommandFinish{
+						DeviceParam:         requests.DeviceParam{UID: "1234"},
+						TenantID:            "00000000-0000-4000-0000-000000000000",
+						DeviceUID:           "1234",
+						ID:                  "id",
+						DeviceCommandResult: models.DeviceCommandResult{ExitCode: 127, Output: "command not found"},
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/devices/1234/commands/id/result", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Device-UID", "1234")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
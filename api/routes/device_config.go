@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	UpdateDeviceConfigURL = "/devices/:uid/config"
+)
+
+func (h *Handler) UpdateDeviceConfig(c gateway.Context) error {
+	var req requests.DeviceUpdateConfig
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	config, err := h.service.UpdateDeviceConfig(c.Ctx(), &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, config)
+}
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestUpdateDeviceConfig(t *testing.T) {
+	cases := []struct {
+		description   string
+		role          string
+		body          string
+		requiredMocks func(service *mocks.Service)
+		expected      int
+	}{
+		{
+			description:   "fails when the key is unknown",
+			role:          "owner",
+			body:          `{"values":{"shell":"/bin/sh"}}`,
+			requiredMocks: func(_ *mocks.Service) {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description:   "fails when the role cannot update the device",
+			role:          "observer",
+			body:          `{"values":{"log_level":"debug"}}`,
+			requiredMocks: func(_ *mocks.Service) {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "succeeds when the config is updated",
+			role:        "owner",
+			body:        `{"values":{"log_level":"debug"}}`,
+			requiredMocks: func(service *mocks.Service) {
+				service.
+					On("UpdateDeviceConfig", gomock.Anything, &requests.DeviceUpdateConfig{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Values:      map[string]string{"log_level": "debug"},
+					}).
+					Return(&models.DeviceConfig{Values: map[string]string{"log_level": "debug"}, Version: 1}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			service := new(mocks.Service)
+			tc.requiredMocks(service)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/devices/1234/config", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			NewRouter(service).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DeviceEventsURL = "/ws/devices"
+)
+
+const (
+	// deviceEventsPingInterval is the interval between the pings sent to keep the device events connection alive
+	// through the proxies.
+	deviceEventsPingInterval = 30 * time.Second
+	// deviceEventsWriteTimeout is how long a write on the device events connection may take before the client is
+	// considered gone.
+	deviceEventsWriteTimeout = 10 * time.Second
+)
+
+var deviceEventsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// NOTICE: the connection is authenticated by the token sent on the request, not by cookies, so any origin is
+	// accepted, like on the other API routes.
+	CheckOrigin: func(_ *http.Request) bool {
+		return true
+	},
+}
+
+// DeviceEvents upgrades the connection to a WebSocket and streams the changes on the tenant's devices, as JSON
+// encoded [models.DeviceEvent], until the client disconnects.
+func (h *Handler) DeviceEvents(c gateway.Context) error {
+	req := new(requests.DeviceEventsSubscribe)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(c.Ctx())
+	defer cancel()
+
+	events, err := h.service.SubscribeDeviceEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	role := c.Role()
+
+	// NOTICE: the event's device is shared with the other subscribers, so it is copied before being masked.
+	mask := func(event *models.DeviceEvent) {
+		if event.Device == nil {
+			return
+		}
+
+		device := *event.Device
+		maskDevice(role, &device)
+		event.Device = &device
+	}
+
+	return streamEvents(ctx, cancel, c, events, req.TenantID, mask)
+}
+
+// streamEvents upgrades the connection to a WebSocket and writes the events, encoded as JSON, until the client
+// disconnects, ctx is done or the events' channel is closed. cancel is called when the client disconnects. When mask
+// isn't nil, it is applied to each event before it is written.
+func streamEvents[T any](ctx context.Context, cancel context.CancelFunc, c gateway.Context, events <-chan T, tenant string, mask func(*T)) error {
+	conn, err := deviceEventsUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// NOTICE: the upgrader has already responded to the client with the failure.
+		return nil
+	}
+
+	defer conn.Close()
+
+	// NOTICE: the connection must be read to handle the control messages, like the close one, sent by the client.
+	go func() {
+		defer cancel()
+
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(deviceEventsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(deviceEventsWriteTimeout)); err != nil {
+				return nil
+			}
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if mask != nil {
+				mask(&event)
+			}
+
+			conn.SetWriteDeadline(time.Now().Add(deviceEventsWriteTimeout)) //nolint:errcheck
+			if err := conn.WriteJSON(&event); err != nil {
+				log.WithError(err).WithField("tenant_id", tenant).Debug("failed to write the event")
+
+				return nil
+			}
+		}
+	}
+}
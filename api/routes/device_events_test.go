@@ -0,0 +1,127 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceEvents(t *testing.T) {
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	t.Run("fails when the filters are invalid", func(t *testing.T) {
+		mock := new(mocks.Service)
+
+		req := httptest.NewRequest(http.MethodGet, "/ws/devices?status=invalid", nil)
+		req.Header.Set("X-Role", authorizer.RoleOwner.String())
+		req.Header.Set("X-Tenant-ID", tenant)
+		rec := httptest.NewRecorder()
+
+		NewRouter(mock).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("streams the device events", func(t *testing.T) {
+		mock := new(mocks.Service)
+
+		events := make(chan models.DeviceEvent, 1)
+		events <- models.DeviceEvent{
+			Type:     models.DeviceEventOnline,
+			UID:      "uid",
+			TenantID: tenant,
+			Device:   &models.Device{UID: "uid", TenantID: tenant, Status: models.DeviceStatusAccepted},
+		}
+
+		mock.
+			On("SubscribeDeviceEvents", gomock.Anything, &requests.DeviceEventsSubscribe{
+				TenantID: tenant,
+				Status:   models.DeviceStatusAccepted,
+				Tags:     []string{"region/europe", "lab"},
+			}).
+			Return((<-chan models.DeviceEvent)(events), nil).
+			Once()
+
+		server := httptest.NewServer(NewRouter(mock))
+		defer server.Close()
+
+		header := http.Header{}
+		header.Set("X-Role", authorizer.RoleOwner.String())
+		header.Set("X-Tenant-ID", tenant)
+
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/devices?status=accepted&tags=region/europe&tags=lab"
+		conn, _, err := websocket.DefaultDialer.Dial(url, header)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var event models.DeviceEvent
+		require.NoError(t, conn.ReadJSON(&event))
+
+		assert.Equal(t, models.DeviceEventOnline, event.Type)
+		assert.Equal(t, "uid", event.UID)
+		assert.Equal(t, models.DeviceStatusAccepted, event.Device.Status)
+
+		close(events)
+
+		_, _, err = conn.ReadMessage()
+		assert.Error(t, err)
+
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("masks the device's sensitive details for an observer", func(t *testing.T) {
+		mock := new(mocks.Service)
+
+		device := &models.Device{
+			UID:              "uid",
+			TenantID:         tenant,
+			PublicKey:        "public-key",
+			RemoteAddr:       "192.168.0.10",
+			PublicURLAddress: "address",
+		}
+
+		events := make(chan models.DeviceEvent, 1)
+		events <- models.DeviceEvent{Type: models.DeviceEventOnline, UID: "uid", TenantID: tenant, Device: device}
+
+		mock.
+			On("SubscribeDeviceEvents", gomock.Anything, &requests.DeviceEventsSubscribe{TenantID: tenant}).
+			Return((<-chan models.DeviceEvent)(events), nil).
+			Once()
+
+		server := httptest.NewServer(NewRouter(mock))
+		defer server.Close()
+
+		header := http.Header{}
+		header.Set("X-Role", authorizer.RoleObserver.String())
+		header.Set("X-Tenant-ID", tenant)
+
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws/devices", header)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var event models.DeviceEvent
+		require.NoError(t, conn.ReadJSON(&event))
+
+		assert.Equal(t, "uid", event.Device.UID)
+		assert.Empty(t, event.Device.PublicKey)
+		assert.Empty(t, event.Device.RemoteAddr)
+		assert.Empty(t, event.Device.PublicURLAddress)
+		// NOTICE: the event's device must not be changed, as it is shared with the other subscribers.
+		assert.Equal(t, "public-key", device.PublicKey)
+
+		close(events)
+
+		mock.AssertExpectations(t)
+	})
+}
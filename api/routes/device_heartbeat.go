@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const EnqueueDevicesHeartbeatURL = "/devices/heartbeat"
+
+// EnqueueDevicesHeartbeat receives a batch of the devices' heartbeats from the services keeping their connections.
+func (h *Handler) EnqueueDevicesHeartbeat(c gateway.Context) error {
+	req := new(requests.DevicesHeartbeat)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.EnqueueDevicesHeartbeat(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
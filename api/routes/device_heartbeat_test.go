@@ -0,0 +1,67 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestEnqueueDevicesHeartbeat(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the batch is empty",
+			body:           `{"heartbeats": []}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when a heartbeat has no device",
+			body:           `{"heartbeats": [{"tenant_id": "tenant-id", "seen_at": 1721912837}]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "succeeds",
+			body:  `{"heartbeats": [{"tenant_id": "tenant-id", "uid": "1234", "seen_at": 1721912837}]}`,
+			requiredMocks: func() {
+				mock.
+					On("EnqueueDevicesHeartbeat", gomock.Anything, &requests.DevicesHeartbeat{
+						Heartbeats: []requests.DeviceHeartbeat{{TenantID: "tenant-id", UID: "1234", SeenAt: 1721912837}},
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/devices/heartbeat", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
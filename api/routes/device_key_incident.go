@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListDeviceKeyIncidentsURL  = "/devices/key-incidents"
+	UpdateDeviceKeyIncidentURL = "/devices/key-incidents/:id"
+)
+
+func (h *Handler) ListDeviceKeyIncidents(c gateway.Context) error {
+	req := new(requests.DeviceKeyIncidentList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListDeviceKeyIncidents(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) UpdateDeviceKeyIncident(c gateway.Context) error {
+	req := new(requests.DeviceKeyIncidentUpdate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.UpdateDeviceKeyIncident(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
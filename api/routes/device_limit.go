@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	UpdateDeviceLimitExemptionURL = "/devices/:uid/limit-exemption"
+	ListDeviceLimitExemptionsURL  = "/devices/:uid/limit-exemptions"
+)
+
+// UpdateDeviceLimitExemption exempts, or revokes the exemption of, a device from the namespace's maximum number of
+// devices.
+func (h *Handler) UpdateDeviceLimitExemption(c gateway.Context) error {
+	req := new(requests.DeviceUpdateLimitExemption)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.UpdateDeviceLimitExemption(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ListDeviceLimitExemptions lists the changes on a device's exemption from the namespace's maximum number of devices.
+func (h *Handler) ListDeviceLimitExemptions(c gateway.Context) error {
+	req := new(requests.DeviceLimitExemptionsList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListDeviceLimitExemptions(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
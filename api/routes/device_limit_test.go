@@ -0,0 +1,166 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestUpdateDeviceLimitExemption(t *testing.T) {
+	mock := new(mocks.Service)
+
+	exempt := true
+
+	cases := []struct {
+		title          string
+		role           authorizer.Role
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is not allowed",
+			role:           authorizer.RoleOperator,
+			body:           `{"exempt": true}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "fails when the exemption is missing",
+			role:           authorizer.RoleOwner,
+			body:           `{"reason": "lab device"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the reason is too long",
+			role:           authorizer.RoleOwner,
+			body:           `{"exempt": true, "reason": "` + strings.Repeat("a", 256) + `"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the device is not found",
+			role:  authorizer.RoleAdministrator,
+			body:  `{"exempt": true, "reason": "lab device"}`,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceLimitExemption", gomock.Anything, &requests.DeviceUpdateLimitExemption{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						UserID:      "user-id",
+						Exempt:      &exempt,
+						Reason:      "lab device",
+					}).
+					Return(svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			role:  authorizer.RoleOwner,
+			body:  `{"exempt": true, "reason": "lab device"}`,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceLimitExemption", gomock.Anything, &requests.DeviceUpdateLimitExemption{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						UserID:      "user-id",
+						Exempt:      &exempt,
+						Reason:      "lab device",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/devices/1234/limit-exemption", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			req.Header.Set("X-ID", "user-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceLimitExemptions(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.
+					On("ListDeviceLimitExemptions", gomock.Anything, &requests.DeviceLimitExemptionsList{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Paginator:   query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return(nil, 0, svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			requiredMocks: func() {
+				mock.
+					On("ListDeviceLimitExemptions", gomock.Anything, &requests.DeviceLimitExemptionsList{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Paginator:   query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return([]models.DeviceLimitExemption{{ID: "id", DeviceUID: "1234", Exempt: true}}, 1, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/1234/limit-exemptions", nil)
+			req.Header.Set("X-Role", authorizer.RoleObserver.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
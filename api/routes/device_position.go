@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListDevicePositionsURL = "/devices/positions"
+)
+
+// ListDevicePositions lists the positions of the namespace's devices inside the map's bounding box, clustered by the
+// map's zoom, so the map can be drawn without listing every device.
+func (h *Handler) ListDevicePositions(c gateway.Context) error {
+	req := new(requests.DevicePositionsList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.ListDevicePositions(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
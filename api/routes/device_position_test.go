@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListDevicePositions(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		query          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the bounding box is missing",
+			query:          "zoom=3",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the bounding box is invalid",
+			query:          "bbox=-73.7,40.9,-74.1,40.6&zoom=3",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the zoom is out of range",
+			query:          "bbox=-74.1,40.6,-73.7,40.9&zoom=30",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "succeeds",
+			query: "bbox=-74.1,40.6,-73.7,40.9&zoom=12",
+			requiredMocks: func() {
+				mock.
+					On("ListDevicePositions", gomock.Anything, &requests.DevicePositionsList{
+						TenantID: "tenant-id",
+						BBox:     "-74.1,40.6,-73.7,40.9",
+						Zoom:     12,
+					}).
+					Return([]models.DevicePositionCluster{{Geohash: "dr5ru", Latitude: 40.7, Longitude: -73.9, Count: 42}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/positions?"+tc.query, nil)
+			req.Header.Set("X-Role", authorizer.RoleObserver.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
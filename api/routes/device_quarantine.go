@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListDeviceQuarantineAttemptsURL = "/devices/quarantine-attempts"
+)
+
+// ListDeviceQuarantineAttempts lists the authentication attempts of the namespace's quarantined devices.
+func (h *Handler) ListDeviceQuarantineAttempts(c gateway.Context) error {
+	req := new(requests.DeviceQuarantineAttemptList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListDeviceQuarantineAttempts(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListDeviceQuarantineAttempts(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.
+		On("ListDeviceQuarantineAttempts", gomock.Anything, &requests.DeviceQuarantineAttemptList{
+			TenantID:  "tenant-id",
+			UID:       "1234",
+			Paginator: query.Paginator{Page: 1, PerPage: 10},
+		}).
+		Return([]models.DeviceQuarantineAttempt{{ID: "id", DeviceUID: "1234"}}, 1, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devices/quarantine-attempts?uid=1234", nil)
+	req.Header.Set("X-Role", "observer")
+	req.Header.Set("X-Tenant-ID", "tenant-id")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListDeviceQueueURL = "/devices/queue"
+	QueueDeviceURL     = "/devices/:uid/queue"
+	DequeueDeviceURL   = "/devices/:uid/queue"
+)
+
+// ListDeviceQueue lists the devices on the namespace's acceptance queue, in the order they are going to be accepted.
+func (h *Handler) ListDeviceQueue(c gateway.Context) error {
+	req := new(requests.DeviceQueueList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	devices, err := h.service.ListDeviceQueue(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, devices)
+}
+
+// QueueDevice adds a pending device to the namespace's acceptance queue, or changes its priority.
+func (h *Handler) QueueDevice(c gateway.Context) error {
+	req := new(requests.DeviceQueue)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	device, err := h.service.QueueDevice(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, device)
+}
+
+// DequeueDevice removes a device from the namespace's acceptance queue.
+func (h *Handler) DequeueDevice(c gateway.Context) error {
+	req := new(requests.DeviceDequeue)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DequeueDevice(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,182 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListDeviceQueue(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.
+		On("ListDeviceQueue", gomock.Anything, &requests.DeviceQueueList{TenantID: "tenant-id"}).
+		Return([]models.Device{{UID: "1234", Queue: &models.DeviceQueue{Priority: 1}}}, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devices/queue", nil)
+	req.Header.Set("X-Role", authorizer.RoleObserver.String())
+	req.Header.Set("X-Tenant-ID", "tenant-id")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	mock.AssertExpectations(t)
+}
+
+func TestQueueDevice(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           authorizer.Role
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is not allowed",
+			role:           authorizer.RoleOperator,
+			body:           `{"priority": 1}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "fails when the priority is out of range",
+			role:           authorizer.RoleOwner,
+			body:           `{"priority": 101}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the device isn't pending",
+			role:  authorizer.RoleAdministrator,
+			body:  `{"priority": 1}`,
+			requiredMocks: func() {
+				mock.
+					On("QueueDevice", gomock.Anything, &requests.DeviceQueue{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						UserID:      "user-id",
+						Priority:    1,
+					}).
+					Return(nil, svc.NewErrDeviceQueueStatus(models.DeviceStatusAccepted)).
+					Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "succeeds",
+			role:  authorizer.RoleOwner,
+			body:  `{"priority": 1}`,
+			requiredMocks: func() {
+				mock.
+					On("QueueDevice", gomock.Anything, &requests.DeviceQueue{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						UserID:      "user-id",
+						Priority:    1,
+					}).
+					Return(&models.Device{UID: "1234", Queue: &models.DeviceQueue{Priority: 1}}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/devices/1234/queue", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			req.Header.Set("X-ID", "user-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDequeueDevice(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           authorizer.Role
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is not allowed",
+			role:           authorizer.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the device isn't queued",
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("DequeueDevice", gomock.Anything, &requests.DeviceDequeue{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+					}).
+					Return(svc.NewErrDeviceNotQueued(models.UID("1234"))).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			role:  authorizer.RoleAdministrator,
+			requiredMocks: func() {
+				mock.
+					On("DequeueDevice", gomock.Anything, &requests.DeviceDequeue{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/devices/1234/queue", nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
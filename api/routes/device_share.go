@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListDeviceSharesURL  = "/devices/:uid/shares"
+	CreateDeviceShareURL = "/devices/:uid/shares"
+	DeleteDeviceShareURL = "/devices/:uid/shares/:id"
+)
+
+// ListDeviceShares lists the namespaces the device is shared with, including the expired shares.
+func (h *Handler) ListDeviceShares(c gateway.Context) error {
+	req := new(requests.DeviceShareList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	shares, err := h.service.ListDeviceShares(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shares)
+}
+
+// CreateDeviceShare shares the device with another namespace, allowing its members to see the device or to connect
+// to it.
+func (h *Handler) CreateDeviceShare(c gateway.Context) error {
+	req := new(requests.DeviceShareCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	share, err := h.service.CreateDeviceShare(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, share)
+}
+
+func (h *Handler) DeleteDeviceShare(c gateway.Context) error {
+	req := new(requests.DeviceShareDelete)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteDeviceShare(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,193 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceShare(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when role is operator",
+			role:        "operator",
+			body: map[string]interface{}{
+				"grantee_tenant_id": "00000000-0000-4001-0000-000000000000",
+				"permission":        "connect",
+			},
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "fails when the permission is unknown",
+			role:        "owner",
+			body: map[string]interface{}{
+				"grantee_tenant_id": "00000000-0000-4001-0000-000000000000",
+				"permission":        "write",
+			},
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when the grantee isn't a tenant",
+			role:        "owner",
+			body: map[string]interface{}{
+				"grantee_tenant_id": "namespace",
+				"permission":        "read",
+			},
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when the device is already shared with the grantee",
+			role:        "owner",
+			body: map[string]interface{}{
+				"grantee_tenant_id": "00000000-0000-4001-0000-000000000000",
+				"permission":        "read",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateDeviceShare", gomock.Anything, &requests.DeviceShareCreate{
+						DeviceParam:     requests.DeviceParam{UID: "uid"},
+						TenantID:        "00000000-0000-4000-0000-000000000000",
+						UserID:          "000000000000000000000000",
+						GranteeTenantID: "00000000-0000-4001-0000-000000000000",
+						Permission:      models.DeviceSharePermissionRead,
+					}).
+					Return(nil, svc.NewErrDeviceShareDuplicated([]string{"00000000-0000-4001-0000-000000000000"}, nil)).
+					Once()
+			},
+			expected: http.StatusConflict,
+		},
+		{
+			description: "succeeds",
+			role:        "administrator",
+			body: map[string]interface{}{
+				"grantee_tenant_id": "00000000-0000-4001-0000-000000000000",
+				"permission":        "connect",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateDeviceShare", gomock.Anything, &requests.DeviceShareCreate{
+						DeviceParam:     requests.DeviceParam{UID: "uid"},
+						TenantID:        "00000000-0000-4000-0000-000000000000",
+						UserID:          "000000000000000000000000",
+						GranteeTenantID: "00000000-0000-4001-0000-000000000000",
+						Permission:      models.DeviceSharePermissionConnect,
+					}).
+					Return(&models.DeviceShare{ID: "7a2b3c4d-1d2c-4e8f-9a4b-000000000001"}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/uid/shares", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestDeleteDeviceShare(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when role is operator",
+			role:          "operator",
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "fails when the share is not found",
+			role:        "administrator",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteDeviceShare", gomock.Anything, &requests.DeviceShareDelete{
+						DeviceParam: requests.DeviceParam{UID: "uid"},
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						ID:          "7a2b3c4d-1d2c-4e8f-9a4b-000000000001",
+					}).
+					Return(svc.NewErrDeviceShareNotFound("7a2b3c4d-1d2c-4e8f-9a4b-000000000001", nil)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			role:        "owner",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteDeviceShare", gomock.Anything, &requests.DeviceShareDelete{
+						DeviceParam: requests.DeviceParam{UID: "uid"},
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						ID:          "7a2b3c4d-1d2c-4e8f-9a4b-000000000001",
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/devices/uid/shares/7a2b3c4d-1d2c-4e8f-9a4b-000000000001", nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	DiffDeviceURL = "/devices/:uid/diff"
+)
+
+// DiffDevice returns what changed on the information reported by the device between the from and to points in time.
+func (h *Handler) DiffDevice(c gateway.Context) error {
+	req := new(requests.DeviceDiff)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	diff, err := h.service.DiffDevice(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestDiffDevice(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		query         string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the points in time are missing",
+			query:         "",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description:   "fails when the point in time is invalid",
+			query:         "?from=yesterday&to=2023-01-02T12:00:00Z",
+			requiredMocks: func() {},
+			expected:      http.StatusUnprocessableEntity,
+		},
+		{
+			description:   "fails when from is after to",
+			query:         "?from=2023-01-03T12:00:00Z&to=2023-01-02T12:00:00Z",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			query:       "?from=2023-01-01T12:00:00Z&to=2023-01-02T12:00:00Z",
+			requiredMocks: func() {
+				svcMock.
+					On("DiffDevice", gomock.Anything, &requests.DeviceDiff{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						From:        time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						To:          time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+					}).
+					Return(&models.DeviceDiff{
+						Changes: []models.DeviceSnapshotChange{{Field: "info.version", From: "v0.16.0", To: "v0.17.0"}},
+					}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/1234/diff"+tc.query, nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", "observer")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
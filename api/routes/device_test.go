@@ -92,6 +92,76 @@ func TestGetDevice(t *testing.T) {
 	}
 }
 
+func TestGetDeviceSensitiveFields(t *testing.T) {
+	mock := new(mocks.Service)
+
+	device := func() models.Device {
+		return models.Device{
+			UID:              "1234",
+			Name:             "device",
+			PublicKey:        "public-key",
+			RemoteAddr:       "192.168.0.1",
+			PublicURLAddress: "address",
+			Addresses:        []models.DeviceAddress{{RemoteAddr: "192.168.0.1"}},
+		}
+	}
+
+	masked := models.Device{UID: "1234", Name: "device"}
+
+	cases := []struct {
+		role     authorizer.Role
+		expected models.Device
+	}{
+		{role: authorizer.RoleObserver, expected: masked},
+		{role: authorizer.RoleOperator, expected: device()},
+		{role: authorizer.RoleAdministrator, expected: device()},
+		{role: authorizer.RoleOwner, expected: device()},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("get device as %s", tc.role), func(t *testing.T) {
+			d := device()
+			mock.On("GetDevice", gomock.Anything, models.UID("1234")).Return(&d, nil).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/1234", nil)
+			req.Header.Set("X-Role", tc.role.String())
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+			var res models.Device
+			require.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&res))
+			assert.Equal(t, tc.expected, res)
+		})
+
+		t.Run(fmt.Sprintf("list devices as %s", tc.role), func(t *testing.T) {
+			mock.
+				On("ListDevices", gomock.Anything, gomock.AnythingOfType("*requests.DeviceList")).
+				Return([]models.Device{device()}, 1, nil).
+				Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices", nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+			var res []models.Device
+			require.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&res))
+			assert.Equal(t, []models.Device{tc.expected}, res)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestDeleteDevice(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -730,6 +800,333 @@ func TestCreateDeviceTag(t *testing.T) {
 	}
 }
 
+func TestClaimDevice(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		role           authorizer.Role
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the code is missing",
+			body:           `{}`,
+			role:           authorizer.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role cannot accept devices",
+			body:           `{"code": "7K3QF-M2XAD"}`,
+			role:           authorizer.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when no pending device has the code",
+			body:  `{"code": "7K3QF-M2XAD"}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("ClaimDevice", gomock.Anything, &requests.DeviceClaim{TenantID: "tenant-id", Code: "7K3QF-M2XAD"}).
+					Return(nil, svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			body:  `{"code": "7K3QF-M2XAD"}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("ClaimDevice", gomock.Anything, &requests.DeviceClaim{TenantID: "tenant-id", Code: "7K3QF-M2XAD"}).
+					Return(&models.Device{UID: "uid", Status: models.DeviceStatusAccepted}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/claim", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceLoginShell(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		role           authorizer.Role
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the login shell is not an absolute path",
+			body:           `{"login_shell": "htop"}`,
+			role:           authorizer.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role cannot update devices",
+			body:           `{"login_shell": "/usr/bin/htop"}`,
+			role:           authorizer.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the device is not found",
+			body:  `{"login_shell": "/usr/bin/htop"}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceLoginShell", gomock.Anything, &requests.DeviceUpdateLoginShell{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						LoginShell:  "/usr/bin/htop",
+					}).
+					Return(svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds when the login shell is unset",
+			body:  `{"login_shell": ""}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceLoginShell", gomock.Anything, &requests.DeviceUpdateLoginShell{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title: "succeeds",
+			body:  `{"login_shell": "/usr/bin/htop"}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceLoginShell", gomock.Anything, &requests.DeviceUpdateLoginShell{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						LoginShell:  "/usr/bin/htop",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/devices/1234/login-shell", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceConnectionNote(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		role           authorizer.Role
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the note is too long",
+			body:           `{"connection_note": "` + strings.Repeat("a", 4097) + `"}`,
+			role:           authorizer.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role cannot update devices",
+			body:           `{"connection_note": "Use the user admin"}`,
+			role:           authorizer.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the device is not found",
+			body:  `{"connection_note": "Use the user admin"}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceConnectionNote", gomock.Anything, &requests.DeviceUpdateConnectionNote{
+						DeviceParam:    requests.DeviceParam{UID: "1234"},
+						TenantID:       "tenant-id",
+						ConnectionNote: "Use the user admin",
+					}).
+					Return(svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds when the note is unset",
+			body:  `{"connection_note": ""}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceConnectionNote", gomock.Anything, &requests.DeviceUpdateConnectionNote{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title: "succeeds",
+			body:  `{"connection_note": "Use the user admin"}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceConnectionNote", gomock.Anything, &requests.DeviceUpdateConnectionNote{
+						DeviceParam:    requests.DeviceParam{UID: "1234"},
+						TenantID:       "tenant-id",
+						ConnectionNote: "Use the user admin",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/devices/1234/connection-note", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceRemoteAccess(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		role           authorizer.Role
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role cannot update devices",
+			body:           `{"remote_access": true}`,
+			role:           authorizer.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the device is not found",
+			body:  `{"remote_access": true}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceRemoteAccess", gomock.Anything, &requests.DeviceUpdateRemoteAccess{
+						DeviceParam:  requests.DeviceParam{UID: "1234"},
+						TenantID:     "tenant-id",
+						RemoteAccess: true,
+					}).
+					Return(svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			body:  `{"remote_access": true}`,
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("UpdateDeviceRemoteAccess", gomock.Anything, &requests.DeviceUpdateRemoteAccess{
+						DeviceParam:  requests.DeviceParam{UID: "1234"},
+						TenantID:     "tenant-id",
+						RemoteAccess: true,
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/devices/1234/remote-access", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestUpdateDeviceTag(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	CreateEnrollTokenURL = "/namespaces/:tenant/enroll-tokens"
+	ListEnrollTokensURL  = "/namespaces/:tenant/enroll-tokens"
+	DeleteEnrollTokenURL = "/namespaces/:tenant/enroll-tokens/:id"
+)
+
+func (h *Handler) CreateEnrollToken(c gateway.Context) error {
+	req := new(requests.CreateEnrollToken)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.CreateEnrollToken(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) ListEnrollTokens(c gateway.Context) error {
+	req := new(requests.ListEnrollTokens)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListEnrollTokens(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteEnrollToken(c gateway.Context) error {
+	req := new(requests.DeleteEnrollToken)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteEnrollToken(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
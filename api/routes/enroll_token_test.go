@@ -0,0 +1,223 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	servicemock "github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEnrollToken(t *testing.T) {
+	svcMock := new(servicemock.Service)
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when role is operator",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-ID":         "000000000000000000000000",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "operator",
+			},
+			body: map[string]interface{}{
+				"max_uses": 10,
+				"ttl":      3600,
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "fails when max_uses is above the limit",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-ID":         "000000000000000000000000",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+			},
+			body: map[string]interface{}{
+				"max_uses": 10001,
+				"ttl":      3600,
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "fails when ttl is below the limit",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-ID":         "000000000000000000000000",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+			},
+			body: map[string]interface{}{
+				"max_uses": 10,
+				"ttl":      30,
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-ID":         "000000000000000000000000",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "administrator",
+			},
+			body: map[string]interface{}{
+				"max_uses": 10,
+				"ttl":      3600,
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateEnrollToken", mock.Anything, &requests.CreateEnrollToken{
+						TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						UserID:      "000000000000000000000000",
+						MaxUses:     10,
+						TTL:         3600,
+					}).
+					Return(&responses.CreateEnrollToken{
+						EnrollToken: models.EnrollToken{ID: "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", MaxUses: 10},
+						Token:       "a1b2c3d4-0000-4000-0000-000000000000",
+					}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/namespaces/00000000-0000-4000-0000-000000000000/enroll-tokens", strings.NewReader(string(data)))
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestDeleteEnrollToken(t *testing.T) {
+	svcMock := new(servicemock.Service)
+
+	cases := []struct {
+		description   string
+		id            string
+		headers       map[string]string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when role is observer",
+			id:          "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+			headers: map[string]string{
+				"X-ID":        "000000000000000000000000",
+				"X-Tenant-ID": "00000000-0000-4000-0000-000000000000",
+				"X-Role":      "observer",
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "fails when the id is invalid",
+			id:          "invalid",
+			headers: map[string]string{
+				"X-ID":        "000000000000000000000000",
+				"X-Tenant-ID": "00000000-0000-4000-0000-000000000000",
+				"X-Role":      "owner",
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the token is not found",
+			id:          "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+			headers: map[string]string{
+				"X-ID":        "000000000000000000000000",
+				"X-Tenant-ID": "00000000-0000-4000-0000-000000000000",
+				"X-Role":      "owner",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteEnrollToken", mock.Anything, &requests.DeleteEnrollToken{
+						TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						ID:          "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+					}).
+					Return(svc.NewErrEnrollTokenNotFound("6d1e2f3a-1d2c-4e8f-9a4b-000000000001", store.ErrNoDocuments)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			id:          "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+			headers: map[string]string{
+				"X-ID":        "000000000000000000000000",
+				"X-Tenant-ID": "00000000-0000-4000-0000-000000000000",
+				"X-Role":      "owner",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteEnrollToken", mock.Anything, &requests.DeleteEnrollToken{
+						TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						ID:          "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/namespaces/00000000-0000-4000-0000-000000000000/enroll-tokens/"+tc.id, nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
@@ -0,0 +1,129 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListGroupsURL        = "/groups"
+	CreateGroupURL       = "/groups"
+	UpdateGroupURL       = "/groups/:id"
+	DeleteGroupURL       = "/groups/:id"
+	AddGroupDeviceURL    = "/groups/:id/devices/:uid"
+	RemoveGroupDeviceURL = "/groups/:id/devices/:uid"
+)
+
+// ListGroups lists the namespace's device groups.
+func (h *Handler) ListGroups(c gateway.Context) error {
+	req := new(requests.GroupList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	groups, err := h.service.ListGroups(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, groups)
+}
+
+func (h *Handler) CreateGroup(c gateway.Context) error {
+	req := new(requests.GroupCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	group, err := h.service.CreateGroup(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) UpdateGroup(c gateway.Context) error {
+	req := new(requests.GroupUpdate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	group, err := h.service.UpdateGroup(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) DeleteGroup(c gateway.Context) error {
+	req := new(requests.GroupDelete)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteGroup(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) AddGroupDevice(c gateway.Context) error {
+	req := new(requests.GroupDevice)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.AddGroupDevice(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) RemoveGroupDevice(c gateway.Context) error {
+	req := new(requests.GroupDevice)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.RemoveGroupDevice(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
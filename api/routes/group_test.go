@@ -0,0 +1,135 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateGroup(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when role is operator",
+			role:          "operator",
+			body:          map[string]interface{}{"name": "berlin", "parent_id": "europe"},
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description:   "fails when the name is missing",
+			role:          "owner",
+			body:          map[string]interface{}{"parent_id": "europe"},
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			role:        "administrator",
+			body:        map[string]interface{}{"name": "berlin", "parent_id": "europe"},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateGroup", gomock.Anything, &requests.GroupCreate{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						ParentID: "europe",
+						Name:     "berlin",
+					}).
+					Return(&models.Group{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/groups", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestAddGroupDevice(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when role is observer",
+			role:          "observer",
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "succeeds",
+			role:        "operator",
+			requiredMocks: func() {
+				svcMock.
+					On("AddGroupDevice", gomock.Anything, &requests.GroupDevice{
+						GroupParam: requests.GroupParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						UID:        "uid",
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/groups/c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a/devices/uid", nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
@@ -2,10 +2,15 @@ package routes
 
 import (
 	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/banlist"
 )
 
 type Handler struct {
 	service svc.Service
+	// deviceAuthBudget limits the device authentications handled at the same time. It is nil when there is no limit.
+	deviceAuthBudget chan struct{}
+	// banlist refuses the requests from the banned addresses on the public API. It is nil when not enforced.
+	banlist *banlist.Banlist
 }
 
 func NewHandler(s svc.Service) *Handler {
@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	CreateAcceptDevicesJobURL  = "/jobs/devices/accept"
+	CreateTagDevicesJobURL     = "/jobs/devices/tag"
+	CreateExportDevicesJobURL  = "/jobs/devices/export"
+	CreateRefreshDevicesJobURL = "/jobs/devices/refresh"
+	GetJobURL                  = "/jobs/:id"
+)
+
+// CreateDevicesJob returns a handler that creates an asynchronous job executing the operation on a list of devices.
+// The job is returned right away, and its progress is followed on [GetJobURL].
+func (h *Handler) CreateDevicesJob(operation models.JobOperation) func(c gateway.Context) error {
+	return func(c gateway.Context) error {
+		req := new(requests.DevicesJobCreate)
+
+		if err := c.Bind(req); err != nil {
+			return err
+		}
+
+		req.Operation = operation
+
+		if err := c.Validate(req); err != nil {
+			return err
+		}
+
+		job, err := h.service.CreateDevicesJob(c.Ctx(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusAccepted, job)
+	}
+}
+
+// GetJob retrieves a job, with its progress and the result of each one of its items.
+func (h *Handler) GetJob(c gateway.Context) error {
+	req := new(requests.JobGet)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	job, err := h.service.GetJob(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range job.Items {
+		maskDevice(c.Role(), item.Device)
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,226 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDevicesJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		path           string
+		role           authorizer.Role
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role cannot accept devices",
+			path:           "/api/jobs/devices/accept",
+			role:           authorizer.RoleObserver,
+			body:           `{"uids": ["first"]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "fails when there are no devices",
+			path:           "/api/jobs/devices/accept",
+			role:           authorizer.RoleOwner,
+			body:           `{"uids": []}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the devices are duplicated",
+			path:           "/api/jobs/devices/accept",
+			role:           authorizer.RoleOwner,
+			body:           `{"uids": ["first", "first"]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the tag is missing from a tag job",
+			path:           "/api/jobs/devices/tag",
+			role:           authorizer.RoleOwner,
+			body:           `{"uids": ["first"]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the idempotency key was used by another job",
+			path:  "/api/jobs/devices/accept",
+			role:  authorizer.RoleOwner,
+			body:  `{"uids": ["first"]}`,
+			requiredMocks: func() {
+				mock.
+					On("CreateDevicesJob", gomock.Anything, &requests.DevicesJobCreate{
+						TenantID:       "tenant-id",
+						UserID:         "user-id",
+						IdempotencyKey: "key",
+						Operation:      models.JobOperationDeviceAccept,
+						UIDs:           []string{"first"},
+					}).
+					Return(nil, svc.NewErrJobIdempotencyKey("key")).
+					Once()
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			title: "succeeds creating a tag job",
+			path:  "/api/jobs/devices/tag",
+			role:  authorizer.RoleOperator,
+			body:  `{"uids": ["first"], "tag": "production"}`,
+			requiredMocks: func() {
+				mock.
+					On("CreateDevicesJob", gomock.Anything, &requests.DevicesJobCreate{
+						TenantID:       "tenant-id",
+						UserID:         "user-id",
+						IdempotencyKey: "key",
+						Operation:      models.JobOperationDeviceTag,
+						UIDs:           []string{"first"},
+						Tag:            "production",
+					}).
+					Return(&models.Job{ID: "id", Status: models.JobStatusQueued}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			title: "succeeds creating an export job for an observer",
+			path:  "/api/jobs/devices/export",
+			role:  authorizer.RoleObserver,
+			body:  `{"uids": ["first"]}`,
+			requiredMocks: func() {
+				mock.
+					On("CreateDevicesJob", gomock.Anything, &requests.DevicesJobCreate{
+						TenantID:       "tenant-id",
+						UserID:         "user-id",
+						IdempotencyKey: "key",
+						Operation:      models.JobOperationDeviceExport,
+						UIDs:           []string{"first"},
+					}).
+					Return(&models.Job{ID: "id", Status: models.JobStatusQueued}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			req.Header.Set("X-ID", "user-id")
+			req.Header.Set("Idempotency-Key", "key")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	job := func() *models.Job {
+		return &models.Job{
+			ID:        "id",
+			Operation: models.JobOperationDeviceExport,
+			Status:    models.JobStatusCompleted,
+			Items: []models.JobItem{
+				{UID: "first", Status: models.JobItemStatusSucceeded, Device: &models.Device{UID: "first", RemoteAddr: "192.168.0.1"}},
+				{UID: "second", Status: models.JobItemStatusFailed, Error: "device not found"},
+			},
+		}
+	}
+
+	cases := []struct {
+		title          string
+		role           authorizer.Role
+		requiredMocks  func()
+		expectedStatus int
+		expectedAddr   string
+	}{
+		{
+			title: "fails when the job is not found",
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("GetJob", gomock.Anything, &requests.JobGet{TenantID: "tenant-id", ID: "id"}).
+					Return(nil, svc.NewErrJobNotFound("id", nil)).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds masking the exported devices for an observer",
+			role:  authorizer.RoleObserver,
+			requiredMocks: func() {
+				mock.
+					On("GetJob", gomock.Anything, &requests.JobGet{TenantID: "tenant-id", ID: "id"}).
+					Return(job(), nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedAddr:   "",
+		},
+		{
+			title: "succeeds",
+			role:  authorizer.RoleOwner,
+			requiredMocks: func() {
+				mock.
+					On("GetJob", gomock.Anything, &requests.JobGet{TenantID: "tenant-id", ID: "id"}).
+					Return(job(), nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedAddr:   "192.168.0.1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/id", nil)
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var res models.Job
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+				assert.Equal(t, tc.expectedAddr, res.Items[0].Device.RemoteAddr)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -26,7 +26,7 @@ func Authorize(next echo.HandlerFunc) echo.HandlerFunc {
 // BlockAPIKey blocks request using API keys to continue.
 func BlockAPIKey(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		if key := c.Request().Header.Get("X-API-Key"); key != "" {
+		if key := gateway.APIKeyFromRequest(c.Request()); key != "" {
 			return c.NoContent(http.StatusForbidden)
 		}
 
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Pool is a resource shared by the requests, like the database's connection pool, that may be saturated.
+type Pool interface {
+	// Wait waits, up to timeout, for the pool to not be saturated, reporting whether it has room for a new request.
+	Wait(ctx context.Context, timeout time.Duration) bool
+}
+
+// Backpressure refuses the requests, hinting the client to retry them after backoff, when pool is still saturated after
+// waiting for it up to timeout, instead of letting them pile up.
+func Backpressure(pool Pool, timeout time.Duration, backoff models.Backoff) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !pool.Wait(c.Request().Context(), timeout) {
+				backoff.SetHeader(c.Response().Header())
+
+				return c.NoContent(http.StatusServiceUnavailable)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePool bool
+
+func (p fakePool) Wait(_ context.Context, _ time.Duration) bool {
+	return bool(p)
+}
+
+func TestBackpressure(t *testing.T) {
+	backoff := models.Backoff{RetryAfter: 5 * time.Second, Jitter: 10 * time.Second}
+
+	cases := []struct {
+		description string
+		pool        Pool
+		expected    func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			description: "serves the request when the pool has room for it",
+			pool:        fakePool(true),
+			expected: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Empty(t, rec.Header().Get(models.RetryAfterHeader))
+			},
+		},
+		{
+			description: "refuses the request when the pool is saturated",
+			pool:        fakePool(false),
+			expected: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+				assert.Equal(t, "5", rec.Header().Get(models.RetryAfterHeader))
+				assert.Equal(t, "10", rec.Header().Get(models.RetryJitterHeader))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			e := echo.New()
+			e.Use(Backpressure(tc.pool, time.Second, backoff))
+			e.GET("/", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			tc.expected(t, rec)
+		})
+	}
+}
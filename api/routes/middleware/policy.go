@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+)
+
+// Route identifies a route by its method and its full path, including the group's prefix.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Policy is the authorization policy of a route.
+type Policy struct {
+	permission *authorizer.Permission
+	reason     string
+}
+
+// Requires creates a [Policy] that allows the access only to clients with the specified permission.
+func Requires(permission authorizer.Permission) Policy {
+	return Policy{permission: &permission}
+}
+
+// Unrestricted creates a [Policy] that doesn't require any permission to access the route. The reason explains why
+// the route is unrestricted, making the exception explicit on the policies table.
+func Unrestricted(reason string) Policy {
+	return Policy{reason: reason}
+}
+
+// Permission returns the permission required by the policy. The boolean is false when the policy is unrestricted.
+func (p Policy) Permission() (authorizer.Permission, bool) {
+	if p.permission == nil {
+		return 0, false
+	}
+
+	return *p.permission, true
+}
+
+// Reason returns why the policy is unrestricted.
+func (p Policy) Reason() string {
+	return p.reason
+}
+
+// Policies maps routes to their authorization policies.
+type Policies map[Route]Policy
+
+// Enforce checks, for each request, the policy of the matched route. When the route requires a permission that the
+// client doesn't have, it returns an [http.StatusForbidden] response. A request changing a resource, on a route under
+// the prefix without a policy, is also rejected, so a route missing from the policies isn't left open.
+//
+// It must be registered with [echo.Echo.Use], so it runs after the router has matched the request's route.
+func Enforce(prefix string, policies Policies) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			policy, ok := policies[Route{Method: c.Request().Method, Path: c.Path()}]
+			if !ok {
+				if mutating(c.Request().Method) && strings.HasPrefix(c.Path(), prefix) {
+					return c.NoContent(http.StatusForbidden)
+				}
+
+				return next(c)
+			}
+
+			permission, ok := policy.Permission()
+			if !ok {
+				return next(c)
+			}
+
+			if ctx, ok := c.(*gateway.Context); !ok || !ctx.Role().HasPermission(permission) {
+				return c.NoContent(http.StatusForbidden)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// mutating reports whether the request's method changes a resource.
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/policyengine"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	log "github.com/sirupsen/logrus"
+)
+
+// Actions maps the sensitive routes to the actions evaluated on the policy engine, like "device.remove".
+type Actions map[Route]string
+
+// EvaluatePolicies evaluates, for each request to a sensitive route, the organization's policies on the engine. When
+// they don't allow the action, or couldn't be evaluated, it returns an [http.StatusForbidden] response. Routes without
+// an action aren't evaluated.
+//
+// It must be registered with [echo.Echo.Use], after [Enforce], so the policies only restrict the actions the client's
+// role already allows.
+func EvaluatePolicies(engine policyengine.Engine, actions Actions) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			action, ok := actions[Route{Method: c.Request().Method, Path: c.Path()}]
+			if !ok {
+				return next(c)
+			}
+
+			ctx, ok := c.(*gateway.Context)
+			if !ok {
+				return c.NoContent(http.StatusForbidden)
+			}
+
+			input := &policyengine.Input{
+				Action:   action,
+				Time:     clock.Now(),
+				SourceIP: c.RealIP(),
+				Request: &policyengine.Request{
+					Method: c.Request().Method,
+					Path:   c.Path(),
+					Params: make(map[string]string),
+				},
+			}
+
+			for i, name := range c.ParamNames() {
+				input.Request.Params[name] = c.ParamValues()[i]
+			}
+
+			if id, ok := ctx.GetID(); ok {
+				username, _ := ctx.GetUsername()
+				input.User = &policyengine.User{ID: id, Username: username, Role: ctx.Role().String()}
+			}
+
+			if tenant, ok := ctx.GetTennat(); ok {
+				input.Namespace = &policyengine.Namespace{TenantID: tenant}
+			}
+
+			logger := log.WithFields(log.Fields{"action": action, "path": c.Path()})
+
+			decision, err := engine.Evaluate(ctx.Ctx(), input)
+			if err != nil {
+				logger.WithError(err).Error("failed to evaluate the policies")
+
+				return c.NoContent(http.StatusForbidden)
+			}
+
+			if !decision.Allow {
+				logger.WithField("reason", decision.Reason).Info("the policies denied the request")
+
+				return c.NoContent(http.StatusForbidden)
+			}
+
+			return next(c)
+		}
+	}
+}
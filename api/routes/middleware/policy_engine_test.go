@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/policyengine"
+	"github.com/shellhub-io/shellhub/api/pkg/policyengine/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEvaluatePolicies(t *testing.T) {
+	actions := Actions{{Method: http.MethodDelete, Path: "/devices/:uid"}: "device.remove"}
+
+	// matchesInput matches the input of the device's removal, ignoring the time of the evaluation.
+	matchesInput := mock.MatchedBy(func(input *policyengine.Input) bool {
+		return input.Action == "device.remove" &&
+			input.SourceIP == "192.0.2.1" &&
+			assert.ObjectsAreEqual(&policyengine.User{ID: "id", Username: "john", Role: "owner"}, input.User) &&
+			assert.ObjectsAreEqual(&policyengine.Namespace{TenantID: "tenant"}, input.Namespace) &&
+			assert.ObjectsAreEqual(&policyengine.Request{
+				Method: http.MethodDelete,
+				Path:   "/devices/:uid",
+				Params: map[string]string{"uid": "uid"},
+			}, input.Request)
+	})
+
+	cases := []struct {
+		description   string
+		method        string
+		requiredMocks func(engine *mocks.Engine)
+		expected      int
+	}{
+		{
+			description:   "serves the routes without an action",
+			method:        http.MethodGet,
+			requiredMocks: func(_ *mocks.Engine) {},
+			expected:      http.StatusOK,
+		},
+		{
+			description: "refuses the request when the policies couldn't be evaluated",
+			method:      http.MethodDelete,
+			requiredMocks: func(engine *mocks.Engine) {
+				engine.On("Evaluate", mock.Anything, matchesInput).Return(nil, errors.New("error")).Once()
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "refuses the request when the policies deny it",
+			method:      http.MethodDelete,
+			requiredMocks: func(engine *mocks.Engine) {
+				engine.On("Evaluate", mock.Anything, matchesInput).Return(&policyengine.Decision{Allow: false}, nil).Once()
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "serves the request when the policies allow it",
+			method:      http.MethodDelete,
+			requiredMocks: func(engine *mocks.Engine) {
+				engine.On("Evaluate", mock.Anything, matchesInput).Return(&policyengine.Decision{Allow: true}, nil).Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			engine := mocks.NewEngine(t)
+			tc.requiredMocks(engine)
+
+			e := echo.New()
+			e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					return next(gateway.NewContext(nil, c))
+				}
+			})
+			e.Use(EvaluatePolicies(engine, actions))
+
+			handler := func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}
+
+			e.GET("/devices/:uid", handler)
+			e.DELETE("/devices/:uid", handler)
+
+			req := httptest.NewRequest(tc.method, "/devices/uid", nil)
+			req.Header.Set("X-ID", "id")
+			req.Header.Set("X-Username", "john")
+			req.Header.Set("X-Role", "owner")
+			req.Header.Set("X-Tenant-ID", "tenant")
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnforce(t *testing.T) {
+	policies := Policies{
+		{Method: http.MethodDelete, Path: "/api/devices/:uid"}: Requires(authorizer.DeviceRemove),
+		{Method: http.MethodPost, Path: "/api/login"}:          Unrestricted("authentication"),
+	}
+
+	cases := []struct {
+		description string
+		method      string
+		path        string
+		role        string
+		expected    int
+	}{
+		{
+			description: "serves the request when the role has the required permission",
+			method:      http.MethodDelete,
+			path:        "/api/devices/uid",
+			role:        "owner",
+			expected:    http.StatusOK,
+		},
+		{
+			description: "refuses the request when the role doesn't have the required permission",
+			method:      http.MethodDelete,
+			path:        "/api/devices/uid",
+			role:        "observer",
+			expected:    http.StatusForbidden,
+		},
+		{
+			description: "serves the request to an unrestricted route",
+			method:      http.MethodPost,
+			path:        "/api/login",
+			expected:    http.StatusOK,
+		},
+		{
+			description: "serves the request that doesn't change resources on a route without a policy",
+			method:      http.MethodGet,
+			path:        "/api/devices/uid",
+			role:        "observer",
+			expected:    http.StatusOK,
+		},
+		{
+			description: "refuses the request that changes resources on a route without a policy",
+			method:      http.MethodPut,
+			path:        "/api/devices/uid",
+			role:        "owner",
+			expected:    http.StatusForbidden,
+		},
+		{
+			description: "serves the request that changes resources on a route out of the prefix",
+			method:      http.MethodPost,
+			path:        "/internal/devices/uid",
+			expected:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			e := echo.New()
+			e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					return next(gateway.NewContext(nil, c))
+				}
+			})
+			e.Use(Enforce("/api", policies))
+
+			handler := func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}
+
+			e.GET("/api/devices/:uid", handler)
+			e.PUT("/api/devices/:uid", handler)
+			e.DELETE("/api/devices/:uid", handler)
+			e.POST("/api/login", handler)
+			e.POST("/internal/devices/:uid", handler)
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			if tc.role != "" {
+				req.Header.Set("X-Role", tc.role)
+			}
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Code)
+		})
+	}
+}
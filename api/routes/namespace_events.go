@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	NamespaceEventsURL = "/ws/events"
+)
+
+// NamespaceEvents upgrades the connection to a WebSocket and streams the changes on the tenant's devices and sessions,
+// as JSON encoded [models.NamespaceEvent], until the client disconnects.
+func (h *Handler) NamespaceEvents(c gateway.Context) error {
+	req := new(requests.NamespaceEventsSubscribe)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(c.Ctx())
+	defer cancel()
+
+	events, err := h.service.SubscribeNamespaceEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return streamEvents(ctx, cancel, c, events, req.TenantID, nil)
+}
@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceEvents(t *testing.T) {
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	t.Run("fails when the tenant is missing", func(t *testing.T) {
+		mock := new(mocks.Service)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/ws/events", nil)
+		req.Header.Set("X-Role", authorizer.RoleOwner.String())
+		rec := httptest.NewRecorder()
+
+		NewRouter(mock).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("streams the namespace events", func(t *testing.T) {
+		mock := new(mocks.Service)
+
+		events := make(chan models.NamespaceEvent, 2)
+		events <- models.NamespaceEvent{
+			Type:     models.NamespaceEventDeviceOnline,
+			TenantID: tenant,
+			Device:   &models.Device{UID: "uid", TenantID: tenant, Status: models.DeviceStatusAccepted},
+		}
+		events <- models.NamespaceEvent{
+			Type:     models.NamespaceEventSessionStarted,
+			TenantID: tenant,
+			Session:  &models.Session{UID: "session", TenantID: tenant, DeviceUID: "uid"},
+		}
+
+		mock.
+			On("SubscribeNamespaceEvents", gomock.Anything, &requests.NamespaceEventsSubscribe{TenantID: tenant}).
+			Return((<-chan models.NamespaceEvent)(events), nil).
+			Once()
+
+		server := httptest.NewServer(NewRouter(mock))
+		defer server.Close()
+
+		header := http.Header{}
+		header.Set("X-Role", authorizer.RoleObserver.String())
+		header.Set("X-Tenant-ID", tenant)
+
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/ws/events"
+		conn, _, err := websocket.DefaultDialer.Dial(url, header)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var event models.NamespaceEvent
+		require.NoError(t, conn.ReadJSON(&event))
+		assert.Equal(t, models.NamespaceEventDeviceOnline, event.Type)
+		assert.Equal(t, "uid", event.Device.UID)
+
+		require.NoError(t, conn.ReadJSON(&event))
+		assert.Equal(t, models.NamespaceEventSessionStarted, event.Type)
+		assert.Equal(t, "session", event.Session.UID)
+
+		close(events)
+
+		_, _, err = conn.ReadMessage()
+		assert.Error(t, err)
+
+		mock.AssertExpectations(t)
+	})
+}
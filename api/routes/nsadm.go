@@ -2,7 +2,6 @@ package routes
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -22,6 +21,22 @@ const (
 	EditSessionRecordStatusURL = "/users/security/:tenant"
 )
 
+// PreviewConnectionAnnouncementURL renders a connection announcement with sample data of a session.
+const PreviewConnectionAnnouncementURL = "/namespaces/:tenant/connection-announcement/preview"
+
+// PreviewDeviceNameTemplateURL renders a device name template for the namespace's pending devices.
+const PreviewDeviceNameTemplateURL = "/namespaces/:tenant/device-name-template/preview"
+
+// CloneNamespaceURL creates a namespace with the configuration of the namespace the user is authenticated on.
+const CloneNamespaceURL = "/namespaces/:tenant/clone"
+
+// UpdateNamespaceDeviceLimitsURL sets the namespace's device limits. It is only available on the internal API, to be
+// used by the instance's administrator.
+const UpdateNamespaceDeviceLimitsURL = "/namespaces/:tenant/device-limits"
+
+// GetNamespaceMemberActivityURL reports the activity of a namespace's member, used on the periodic access reviews.
+const GetNamespaceMemberActivityURL = "/namespaces/:tenant/members/:uid/activity"
+
 const (
 	ParamNamespaceTenant   = "tenant"
 	ParamNamespaceMemberID = "uid"
@@ -48,7 +63,7 @@ func (h *Handler) GetNamespaceList(c gateway.Context) error {
 		return err
 	}
 
-	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+	setPaginationHeaders(c, &req.Paginator, count)
 
 	return c.JSON(http.StatusOK, namespaces)
 }
@@ -137,6 +152,101 @@ func (h *Handler) EditNamespace(c gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h *Handler) CloneNamespace(c gateway.Context) error {
+	req := new(requests.NamespaceClone)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	namespace, err := h.service.CloneNamespace(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, namespace)
+}
+
+func (h *Handler) PreviewDeviceNameTemplate(c gateway.Context) error {
+	req := new(requests.NamespaceDeviceNameTemplatePreview)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.PreviewDeviceNameTemplate(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) PreviewConnectionAnnouncement(c gateway.Context) error {
+	req := new(requests.NamespaceConnectionAnnouncementPreview)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.PreviewConnectionAnnouncement(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) UpdateNamespaceDeviceLimits(c gateway.Context) error {
+	req := new(requests.NamespaceDeviceLimits)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.UpdateNamespaceDeviceLimits(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) GetNamespaceMemberActivity(c gateway.Context) error {
+	req := new(requests.NamespaceMemberActivity)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	activity, err := h.service.GetNamespaceMemberActivity(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, activity)
+}
+
 func (h *Handler) AddNamespaceMember(c gateway.Context) error {
 	req := new(requests.NamespaceAddMember)
 
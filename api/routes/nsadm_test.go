@@ -14,6 +14,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/services/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 	gomock "github.com/stretchr/testify/mock"
@@ -458,3 +459,496 @@ func TestHandler_LeaveNamespace(t *testing.T) {
 
 	svcMock.AssertExpectations(t)
 }
+
+func TestPreviewDeviceNameTemplate(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when role is operator",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "operator",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"template": "{{.Hostname}}",
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "fails when the template is empty",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"template": "",
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the template is invalid",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"template": "{{.Hostname",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("PreviewDeviceNameTemplate", gomock.Anything, &requests.NamespaceDeviceNameTemplatePreview{
+						TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						Template:    "{{.Hostname",
+					}).
+					Return(nil, svc.NewErrNamespaceDeviceNameTemplateInvalid(errors.New("error"))).
+					Once()
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"template": "{{.Hostname}}",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("PreviewDeviceNameTemplate", gomock.Anything, &requests.NamespaceDeviceNameTemplatePreview{
+						TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						Template:    "{{.Hostname}}",
+					}).
+					Return([]responses.DeviceNamePreview{{UID: "uid", Name: "name", New: "name"}}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/namespaces/%s/device-name-template/preview", tc.headers["X-Tenant-ID"]), strings.NewReader(string(jsonData)))
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestPreviewConnectionAnnouncement(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when role is operator",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "operator",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"announcement": "Welcome to {{.Device.Name}}",
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "fails when the announcement is empty",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"announcement": "",
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the announcement is invalid",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"announcement": "Welcome to {{.Device.Name",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("PreviewConnectionAnnouncement", gomock.Anything, &requests.NamespaceConnectionAnnouncementPreview{
+						TenantParam:  requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						Announcement: "Welcome to {{.Device.Name",
+					}).
+					Return(nil, svc.NewErrNamespaceConnectionAnnouncementInvalid(errors.New("error"))).
+					Once()
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"announcement": "Welcome to {{.Device.Name}}",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("PreviewConnectionAnnouncement", gomock.Anything, &requests.NamespaceConnectionAnnouncementPreview{
+						TenantParam:  requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						Announcement: "Welcome to {{.Device.Name}}",
+					}).
+					Return(&responses.ConnectionAnnouncementPreview{Announcement: "Welcome to device"}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/namespaces/%s/connection-announcement/preview", tc.headers["X-Tenant-ID"]), strings.NewReader(string(jsonData)))
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestCloneNamespace(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when role is operator",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "operator",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"name": "customer",
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "fails when the name is invalid",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"name": "customer.example",
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the namespace is not found",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"name": "customer",
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CloneNamespace", gomock.Anything, &requests.NamespaceClone{
+						TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						UserID:      "000000000000000000000000",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Name:        "customer",
+					}).
+					Return(nil, svc.NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", nil)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "administrator",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"name":        "customer",
+				"tenant":      "00000000-0000-4000-0000-000000000001",
+				"public_keys": true,
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CloneNamespace", gomock.Anything, &requests.NamespaceClone{
+						TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						UserID:      "000000000000000000000000",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Name:        "customer",
+						Clone:       "00000000-0000-4000-0000-000000000001",
+						PublicKeys:  true,
+					}).
+					Return(&models.Namespace{Name: "customer", TenantID: "00000000-0000-4000-0000-000000000001"}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/namespaces/%s/clone", tc.headers["X-Tenant-ID"]), strings.NewReader(string(jsonData)))
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestUpdateNamespaceDeviceLimits(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	maxDevices := 10
+	maxPendingDevices := 5
+
+	cases := []struct {
+		description   string
+		tenant        string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when the tenant is invalid",
+			tenant:      "invalid",
+			body: map[string]interface{}{
+				"max_devices": maxDevices,
+			},
+			requiredMocks: func() {
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the namespace is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			body: map[string]interface{}{
+				"max_devices": maxDevices,
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("UpdateNamespaceDeviceLimits", gomock.Anything, &requests.NamespaceDeviceLimits{
+						TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						MaxDevices:  &maxDevices,
+					}).
+					Return(nil, svc.NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", nil)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			body: map[string]interface{}{
+				"max_devices":         maxDevices,
+				"max_pending_devices": maxPendingDevices,
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("UpdateNamespaceDeviceLimits", gomock.Anything, &requests.NamespaceDeviceLimits{
+						TenantParam:       requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+						MaxDevices:        &maxDevices,
+						MaxPendingDevices: &maxPendingDevices,
+					}).
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", MaxDevices: maxDevices, MaxPendingDevices: maxPendingDevices}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/internal/namespaces/%s/device-limits", tc.tenant), strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestGetNamespaceMemberActivity(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          authorizer.Role
+		query         string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the role isn't allowed to review the members",
+			role:          authorizer.RoleObserver,
+			query:         "",
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description:   "fails when the window is too large",
+			role:          authorizer.RoleOwner,
+			query:         "?days=366",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			role:        authorizer.RoleOwner,
+			query:       "?days=7",
+			requiredMocks: func() {
+				svcMock.
+					On("GetNamespaceMemberActivity", gomock.Anything, &requests.NamespaceMemberActivity{
+						UserID:   "000000000000000000000000",
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						MemberID: "000000000000000000000001",
+						Days:     7,
+					}).
+					Return(&models.MemberActivity{ID: "000000000000000000000001"}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/namespaces/00000000-0000-4000-0000-000000000000/members/000000000000000000000001/activity"+tc.query, nil)
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role.String())
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
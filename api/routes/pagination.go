@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+)
+
+const (
+	// HeaderTotalCount is the header with the total number of items in a list, regardless of the pagination.
+	HeaderTotalCount = "X-Total-Count"
+	// HeaderLink is the header with the links to the first, previous, next and last pages of a paginated list.
+	//
+	// https://www.rfc-editor.org/rfc/rfc8288
+	HeaderLink = "Link"
+)
+
+// setPaginationHeaders sets the [HeaderTotalCount] header with count and, when paginator isn't nil, the [HeaderLink]
+// header with the pages of the list. The links keep the request's query parameters, changing only the page.
+func setPaginationHeaders(c gateway.Context, paginator *query.Paginator, count int) {
+	c.Response().Header().Set(HeaderTotalCount, strconv.Itoa(count))
+
+	if paginator == nil {
+		return
+	}
+
+	link := func(page int, rel string) string {
+		url := *c.Request().URL
+
+		values := url.Query()
+		values.Set("page", strconv.Itoa(page))
+		values.Set("per_page", strconv.Itoa(paginator.PerPage))
+		url.RawQuery = values.Encode()
+
+		return fmt.Sprintf(`<%s>; rel="%s"`, url.RequestURI(), rel)
+	}
+
+	last := paginator.LastPage(count)
+
+	links := []string{link(query.MinPage, "first")}
+
+	if paginator.Page > query.MinPage {
+		// NOTICE: When the page is beyond the last one, the previous page is the last one.
+		links = append(links, link(min(paginator.Page-1, last), "prev"))
+	}
+
+	if paginator.Page < last {
+		links = append(links, link(paginator.Page+1, "next"))
+	}
+
+	links = append(links, link(last, "last"))
+
+	c.Response().Header().Set(HeaderLink, strings.Join(links, ", "))
+}
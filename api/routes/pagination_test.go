@@ -0,0 +1,88 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPaginationHeaders(t *testing.T) {
+	type Expected struct {
+		total string
+		link  string
+	}
+
+	cases := []struct {
+		description string
+		url         string
+		paginator   *query.Paginator
+		count       int
+		expected    Expected
+	}{
+		{
+			description: "sets only the total count when the list is not paginated",
+			url:         "/api/tags",
+			paginator:   nil,
+			count:       3,
+			expected: Expected{
+				total: "3",
+				link:  "",
+			},
+		},
+		{
+			description: "sets the first and last pages when the list has a single page",
+			url:         "/api/devices?page=1&per_page=10",
+			paginator:   &query.Paginator{Page: 1, PerPage: 10},
+			count:       5,
+			expected: Expected{
+				total: "5",
+				link:  `</api/devices?page=1&per_page=10>; rel="first", </api/devices?page=1&per_page=10>; rel="last"`,
+			},
+		},
+		{
+			description: "sets the previous and next pages when the page is in the middle",
+			url:         "/api/devices?filter=abc&page=2&per_page=10",
+			paginator:   &query.Paginator{Page: 2, PerPage: 10},
+			count:       35,
+			expected: Expected{
+				total: "35",
+				link: `</api/devices?filter=abc&page=1&per_page=10>; rel="first", ` +
+					`</api/devices?filter=abc&page=1&per_page=10>; rel="prev", ` +
+					`</api/devices?filter=abc&page=3&per_page=10>; rel="next", ` +
+					`</api/devices?filter=abc&page=4&per_page=10>; rel="last"`,
+			},
+		},
+		{
+			description: "sets the previous page as the last one when the page is beyond the last",
+			url:         "/api/sessions?page=9&per_page=10",
+			paginator:   &query.Paginator{Page: 9, PerPage: 10},
+			count:       20,
+			expected: Expected{
+				total: "20",
+				link: `</api/sessions?page=1&per_page=10>; rel="first", ` +
+					`</api/sessions?page=2&per_page=10>; rel="prev", ` +
+					`</api/sessions?page=2&per_page=10>; rel="last"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			rec := httptest.NewRecorder()
+
+			c := gateway.NewContext(nil, echo.New().NewContext(req, rec))
+			setPaginationHeaders(*c, tc.paginator, tc.count)
+
+			assert.Equal(t, tc.expected, Expected{
+				total: rec.Header().Get(HeaderTotalCount),
+				link:  rec.Header().Get(HeaderLink),
+			})
+		})
+	}
+}
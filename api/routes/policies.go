@@ -0,0 +1,179 @@
+package routes
+
+import (
+	"net/http"
+
+	routesmiddleware "github.com/shellhub-io/shellhub/api/routes/middleware"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+)
+
+const (
+	// InternalPrefix is the prefix of the routes only accessible by other services in the local container network.
+	InternalPrefix = "/internal"
+	// PublicPrefix is the prefix of the routes accessible through the API gateway.
+	PublicPrefix = "/api"
+	// PublicPrefixV2 is the prefix of the version 2 of the routes accessible through the API gateway.
+	PublicPrefixV2 = PublicPrefix + "/v2"
+)
+
+// policies is the authorization table of the API's routes. Every route that changes a resource must be listed here,
+// either requiring a permission or explicitly marked as unrestricted.
+var policies = routesmiddleware.Policies{
+	{Method: http.MethodPost, Path: InternalPrefix + OfflineDeviceURL}:    routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + CreateSessionURL}:    routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + FinishSessionURL}:    routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + KeepAliveSessionURL}: routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPatch, Path: InternalPrefix + UpdateSessionURL}:   routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + RecordSessionURL}:    routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + RedeemWebSessionURL}: routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + CreatePrivateKeyURL}: routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + EvaluateKeyURL}:      routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + EventsSessionsURL}:   routesmiddleware.Unrestricted("internal"),
+
+	{Method: http.MethodPost, Path: InternalPrefix + CreatePublicURLLogURL}:            routesmiddleware.Unrestricted("internal"),
+	{Method: http.MethodPost, Path: InternalPrefix + CreateCommandPolicyEvaluationURL}: routesmiddleware.Unrestricted("internal"),
+
+	{Method: http.MethodPost, Path: InternalPrefix + EvaluateSessionPolicyURL}:  routesmiddleware.Unrestricted("read-only evaluation"),
+	{Method: http.MethodPost, Path: InternalPrefix + EvaluateSessionWebhookURL}: routesmiddleware.Unrestricted("read-only evaluation"),
+
+	{Method: http.MethodPost, Path: InternalPrefix + EnqueueDevicesHeartbeatURL}: routesmiddleware.Unrestricted("internal"),
+
+	{Method: http.MethodPut, Path: InternalPrefix + UpdateNamespaceDeviceLimitsURL}: routesmiddleware.Unrestricted("instance's administrator"),
+
+	{Method: http.MethodPost, Path: InternalPrefix + CreateBannedAddressURL}:   routesmiddleware.Unrestricted("instance's administrator"),
+	{Method: http.MethodDelete, Path: InternalPrefix + DeleteBannedAddressURL}: routesmiddleware.Unrestricted("instance's administrator"),
+	{Method: http.MethodPost, Path: InternalPrefix + RecordAddressFailureURL}:  routesmiddleware.Unrestricted("internal"),
+
+	{Method: http.MethodPost, Path: PublicPrefix + AuthDeviceURL}:      routesmiddleware.Unrestricted("authentication"),
+	{Method: http.MethodPost, Path: PublicPrefix + AuthDeviceURLV2}:    routesmiddleware.Unrestricted("authentication"),
+	{Method: http.MethodPost, Path: PublicPrefix + AuthLocalUserURL}:   routesmiddleware.Unrestricted("authentication"),
+	{Method: http.MethodPost, Path: PublicPrefix + AuthLocalUserURLV2}: routesmiddleware.Unrestricted("authentication"),
+	{Method: http.MethodPost, Path: PublicPrefix + AuthPublicKeyURL}:   routesmiddleware.Unrestricted("authentication"),
+
+	{Method: http.MethodPost, Path: PublicPrefix + AuthRefreshUserTokenURL}: routesmiddleware.Unrestricted("authentication"),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateDeviceAgentLogsURL}:  routesmiddleware.Unrestricted("device's own logs"),
+	{Method: http.MethodPost, Path: PublicPrefix + DispatchDeviceCommandsURL}: routesmiddleware.Unrestricted("device's own commands"),
+	{Method: http.MethodPut, Path: PublicPrefix + FinishDeviceCommandURL}:     routesmiddleware.Unrestricted("device's own commands"),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateAPIKeyURL}:   routesmiddleware.Requires(authorizer.APIKeyCreate),
+	{Method: http.MethodPatch, Path: PublicPrefix + UpdateAPIKeyURL}:  routesmiddleware.Requires(authorizer.APIKeyUpdate),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteAPIKeyURL}: routesmiddleware.Requires(authorizer.APIKeyDelete),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateEnrollTokenURL}:   routesmiddleware.Requires(authorizer.EnrollTokenCreate),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteEnrollTokenURL}: routesmiddleware.Requires(authorizer.EnrollTokenDelete),
+
+	{Method: http.MethodPatch, Path: PublicPrefix + URLUpdateUser}:                   routesmiddleware.Unrestricted("user's own account"),
+	{Method: http.MethodPatch, Path: PublicPrefix + URLDeprecatedUpdateUser}:         routesmiddleware.Unrestricted("user's own account"),
+	{Method: http.MethodPatch, Path: PublicPrefix + URLDeprecatedUpdateUserPassword}: routesmiddleware.Unrestricted("user's own account"),
+	{Method: http.MethodPost, Path: PublicPrefix + URLResendUserEmailVerification}:   routesmiddleware.Unrestricted("user's own account"),
+	{Method: http.MethodPut, Path: PublicPrefix + SaveUserAliasURL}:                  routesmiddleware.Unrestricted("user's own account"),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteUserAliasURL}:             routesmiddleware.Unrestricted("user's own account"),
+
+	{Method: http.MethodDelete, Path: PublicPrefix + RevokeUserSessionURL}:  routesmiddleware.Unrestricted("user's own account"),
+	{Method: http.MethodDelete, Path: PublicPrefix + RevokeUserSessionsURL}: routesmiddleware.Unrestricted("user's own account"),
+
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateDevice}:                 routesmiddleware.Requires(authorizer.DeviceUpdate),
+	{Method: http.MethodPatch, Path: PublicPrefix + RenameDeviceURL}:            routesmiddleware.Requires(authorizer.DeviceRename),
+	{Method: http.MethodPatch, Path: PublicPrefix + UpdateDeviceStatusURL}:      routesmiddleware.Requires(authorizer.DeviceAccept),
+	{Method: http.MethodPost, Path: PublicPrefix + ClaimDeviceURL}:              routesmiddleware.Requires(authorizer.DeviceAccept),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateDeviceLoginShellURL}:    routesmiddleware.Requires(authorizer.DeviceUpdate),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateDeviceRemoteAccessURL}:  routesmiddleware.Requires(authorizer.DeviceUpdate),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateDeviceNoteURL}:          routesmiddleware.Requires(authorizer.DeviceUpdate),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateDeviceConfigURL}:        routesmiddleware.Requires(authorizer.DeviceUpdate),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteDeviceURL}:           routesmiddleware.Requires(authorizer.DeviceRemove),
+	{Method: http.MethodPatch, Path: PublicPrefix + UpdateDeviceKeyIncidentURL}: routesmiddleware.Requires(authorizer.DeviceAccept),
+	{Method: http.MethodPost, Path: PublicPrefix + CreateTagURL}:                routesmiddleware.Requires(authorizer.DeviceCreateTag),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateTagURL}:                 routesmiddleware.Requires(authorizer.DeviceUpdateTag),
+	{Method: http.MethodDelete, Path: PublicPrefix + RemoveTagURL}:              routesmiddleware.Requires(authorizer.DeviceRemoveTag),
+	{Method: http.MethodPut, Path: PublicPrefix + RenameTagURL}:                 routesmiddleware.Requires(authorizer.DeviceRenameTag),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteTagsURL}:             routesmiddleware.Requires(authorizer.DeviceDeleteTag),
+
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateDeviceLimitExemptionURL}: routesmiddleware.Requires(authorizer.DeviceLimitExempt),
+	{Method: http.MethodPut, Path: PublicPrefix + QueueDeviceURL}:                routesmiddleware.Requires(authorizer.DeviceAcceptanceQueue),
+	{Method: http.MethodDelete, Path: PublicPrefix + DequeueDeviceURL}:           routesmiddleware.Requires(authorizer.DeviceAcceptanceQueue),
+	{Method: http.MethodPost, Path: PublicPrefix + OverrideSessionScheduleURL}:   routesmiddleware.Requires(authorizer.DeviceScheduleOverride),
+	{Method: http.MethodPost, Path: PublicPrefix + CreateWebSessionURL}:          routesmiddleware.Requires(authorizer.DeviceWebSession),
+	{Method: http.MethodPost, Path: PublicPrefix + CreateAcceptDevicesJobURL}:    routesmiddleware.Requires(authorizer.DeviceAccept),
+	{Method: http.MethodPost, Path: PublicPrefix + CreateTagDevicesJobURL}:       routesmiddleware.Requires(authorizer.DeviceCreateTag),
+	{Method: http.MethodPost, Path: PublicPrefix + CreateExportDevicesJobURL}:    routesmiddleware.Unrestricted("read-only export, masked on the job's result"),
+	{Method: http.MethodPost, Path: PublicPrefix + CreateRefreshDevicesJobURL}:   routesmiddleware.Requires(authorizer.DeviceUpdate),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateTagRuleURL}:   routesmiddleware.Requires(authorizer.DeviceTagRules),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateTagRuleURL}:    routesmiddleware.Requires(authorizer.DeviceTagRules),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteTagRuleURL}: routesmiddleware.Requires(authorizer.DeviceTagRules),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateCommandPolicyURL}:   routesmiddleware.Requires(authorizer.SessionCommandPolicies),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateCommandPolicyURL}:    routesmiddleware.Requires(authorizer.SessionCommandPolicies),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteCommandPolicyURL}: routesmiddleware.Requires(authorizer.SessionCommandPolicies),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateGroupURL}:         routesmiddleware.Requires(authorizer.DeviceGroups),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateGroupURL}:          routesmiddleware.Requires(authorizer.DeviceGroups),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteGroupURL}:       routesmiddleware.Requires(authorizer.DeviceGroups),
+	{Method: http.MethodPost, Path: PublicPrefix + AddGroupDeviceURL}:      routesmiddleware.Requires(authorizer.DeviceUpdate),
+	{Method: http.MethodDelete, Path: PublicPrefix + RemoveGroupDeviceURL}: routesmiddleware.Requires(authorizer.DeviceUpdate),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateDeviceCommandURL}: routesmiddleware.Requires(authorizer.DeviceCommand),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateDeviceShareURL}:   routesmiddleware.Requires(authorizer.DeviceShare),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteDeviceShareURL}: routesmiddleware.Requires(authorizer.DeviceShare),
+
+	{Method: http.MethodDelete, Path: PublicPrefix + RecordSessionURL}:          routesmiddleware.Requires(authorizer.SessionRemove),
+	{Method: http.MethodPost, Path: PublicPrefix + VerifySessionAttestationURL}: routesmiddleware.Unrestricted("read-only verification"),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreatePublicKeyURL}:      routesmiddleware.Requires(authorizer.PublicKeyCreate),
+	{Method: http.MethodPost, Path: PublicPrefix + ImportPublicKeysURL}:     routesmiddleware.Requires(authorizer.PublicKeyCreate),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdatePublicKeyURL}:       routesmiddleware.Requires(authorizer.PublicKeyEdit),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeletePublicKeyURL}:    routesmiddleware.Requires(authorizer.PublicKeyRemove),
+	{Method: http.MethodPost, Path: PublicPrefix + TestPublicKeyURL}:        routesmiddleware.Unrestricted("read-only evaluation"),
+	{Method: http.MethodPost, Path: PublicPrefix + AddPublicKeyTagURL}:      routesmiddleware.Requires(authorizer.PublicKeyAddTag),
+	{Method: http.MethodPut, Path: PublicPrefix + UpdatePublicKeyTagsURL}:   routesmiddleware.Requires(authorizer.PublicKeyUpdateTag),
+	{Method: http.MethodDelete, Path: PublicPrefix + RemovePublicKeyTagURL}: routesmiddleware.Requires(authorizer.PublicKeyRemoveTag),
+
+	{Method: http.MethodPut, Path: PublicPrefix + SetSSHCertificateAuthorityURL}:       routesmiddleware.Requires(authorizer.NamespaceUpdate),
+	{Method: http.MethodPost, Path: PublicPrefix + RotateSSHCertificateAuthorityURL}:   routesmiddleware.Requires(authorizer.NamespaceUpdate),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteSSHCertificateAuthorityURL}: routesmiddleware.Requires(authorizer.NamespaceUpdate),
+
+	{Method: http.MethodPost, Path: PublicPrefix + CreateNamespaceURL}:         routesmiddleware.Unrestricted("any user can create a namespace"),
+	{Method: http.MethodPut, Path: PublicPrefix + EditNamespaceURL}:            routesmiddleware.Requires(authorizer.NamespaceUpdate),
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteNamespaceURL}:       routesmiddleware.Requires(authorizer.NamespaceDelete),
+	{Method: http.MethodPost, Path: PublicPrefix + AddNamespaceMemberURL}:      routesmiddleware.Requires(authorizer.NamespaceAddMember),
+	{Method: http.MethodPatch, Path: PublicPrefix + EditNamespaceMemberURL}:    routesmiddleware.Requires(authorizer.NamespaceEditMember),
+	{Method: http.MethodDelete, Path: PublicPrefix + RemoveNamespaceMemberURL}: routesmiddleware.Requires(authorizer.NamespaceRemoveMember),
+	{Method: http.MethodDelete, Path: PublicPrefix + LeaveNamespaceURL}:        routesmiddleware.Unrestricted("any member can leave a namespace"),
+	{Method: http.MethodPut, Path: PublicPrefix + EditSessionRecordStatusURL}:  routesmiddleware.Requires(authorizer.NamespaceEnableSessionRecord),
+
+	{Method: http.MethodPost, Path: PublicPrefix + PreviewDeviceNameTemplateURL}:     routesmiddleware.Requires(authorizer.NamespaceUpdate),
+	{Method: http.MethodPost, Path: PublicPrefix + PreviewConnectionAnnouncementURL}: routesmiddleware.Requires(authorizer.NamespaceUpdate),
+	{Method: http.MethodPost, Path: PublicPrefix + CloneNamespaceURL}:                routesmiddleware.Requires(authorizer.NamespaceUpdate),
+
+	{Method: http.MethodGet, Path: PublicPrefix + GetNamespaceMemberActivityURL}:   routesmiddleware.Requires(authorizer.NamespaceReviewMembers),
+	{Method: http.MethodGet, Path: PublicPrefix + ListSessionRecordingAccessesURL}: routesmiddleware.Requires(authorizer.NamespaceReviewMembers),
+	{Method: http.MethodGet, Path: PublicPrefix + ListAuditEntriesURL}:             routesmiddleware.Requires(authorizer.NamespaceAudit),
+	{Method: http.MethodGet, Path: PublicPrefix + ExportAuditEntriesURL}:           routesmiddleware.Requires(authorizer.NamespaceAudit),
+
+	{Method: http.MethodPost, Path: PublicPrefix + SetupEndpoint}: routesmiddleware.Unrestricted("instance setup"),
+}
+
+// actions are the sensitive routes evaluated on the policy engine, when it is configured, by the action name the
+// organization's policies receive. They are evaluated after the policies table, so they only restrict the access.
+var actions = routesmiddleware.Actions{
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteDeviceURL}:                  "device.remove",
+	{Method: http.MethodPatch, Path: PublicPrefix + UpdateDeviceStatusURL}:             "device.status.update",
+	{Method: http.MethodPost, Path: PublicPrefix + OverrideSessionScheduleURL}:         "device.session_schedule.override",
+	{Method: http.MethodPost, Path: PublicPrefix + CreateWebSessionURL}:                "device.web_session.create",
+	{Method: http.MethodGet, Path: PublicPrefix + GetSessionRecordingURL}:              "session.recording.get",
+	{Method: http.MethodDelete, Path: PublicPrefix + RecordSessionURL}:                 "session.recording.remove",
+	{Method: http.MethodPost, Path: PublicPrefix + CreateAPIKeyURL}:                    "api_key.create",
+	{Method: http.MethodPost, Path: PublicPrefix + CreateEnrollTokenURL}:               "enroll_token.create",
+	{Method: http.MethodPut, Path: PublicPrefix + EditSessionRecordStatusURL}:          "namespace.session_record.update",
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteNamespaceURL}:               "namespace.remove",
+	{Method: http.MethodPost, Path: PublicPrefix + AddNamespaceMemberURL}:              "namespace.member.add",
+	{Method: http.MethodPatch, Path: PublicPrefix + EditNamespaceMemberURL}:            "namespace.member.update",
+	{Method: http.MethodDelete, Path: PublicPrefix + RemoveNamespaceMemberURL}:         "namespace.member.remove",
+	{Method: http.MethodPut, Path: PublicPrefix + UpdateDeviceLimitExemptionURL}:       "device.limit.exempt",
+	{Method: http.MethodPost, Path: PublicPrefix + CreateDeviceCommandURL}:             "device.command.create",
+	{Method: http.MethodPut, Path: PublicPrefix + SetSSHCertificateAuthorityURL}:       "namespace.ssh_ca.set",
+	{Method: http.MethodPost, Path: PublicPrefix + RotateSSHCertificateAuthorityURL}:   "namespace.ssh_ca.rotate",
+	{Method: http.MethodDelete, Path: PublicPrefix + DeleteSSHCertificateAuthorityURL}: "namespace.ssh_ca.delete",
+}
@@ -0,0 +1,76 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	routesmiddleware "github.com/shellhub-io/shellhub/api/routes/middleware"
+	servicemock "github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoliciesCoverage(t *testing.T) {
+	mutating := []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+	router := NewRouter(new(servicemock.Service))
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, InternalPrefix) && !strings.HasPrefix(route.Path, PublicPrefix) {
+			continue
+		}
+
+		if !slices.Contains(mutating, route.Method) {
+			continue
+		}
+
+		t.Run(route.Method+" "+route.Path, func(t *testing.T) {
+			_, ok := policies[routesmiddleware.Route{Method: route.Method, Path: route.Path}]
+			assert.True(t, ok, "route changes resources but it has no authorization policy")
+		})
+	}
+}
+
+func TestPoliciesEnforcement(t *testing.T) {
+	params := regexp.MustCompile(`:[a-zA-Z_]+`)
+
+	roles := []authorizer.Role{
+		authorizer.RoleInvalid,
+		authorizer.RoleObserver,
+		authorizer.RoleOperator,
+		authorizer.RoleAdministrator,
+		authorizer.RoleOwner,
+	}
+
+	// NOTICE: the service mock has no expectations, so any request that isn't blocked by the policy and reaches the
+	// service panics. Only the roles without the required permission are checked.
+	router := NewRouter(new(servicemock.Service))
+	for route, policy := range policies {
+		permission, ok := policy.Permission()
+		if !ok {
+			continue
+		}
+
+		for _, role := range roles {
+			if role.HasPermission(permission) {
+				continue
+			}
+
+			t.Run(route.Method+" "+route.Path+" as "+string(role), func(t *testing.T) {
+				req := httptest.NewRequest(route.Method, params.ReplaceAllString(route.Path, "00000000-0000-4000-0000-000000000000"), nil)
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-ID", "000000000000000000000000")
+				req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+				req.Header.Set("X-Role", role.String())
+
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				assert.Equal(t, http.StatusForbidden, rec.Result().StatusCode)
+			})
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	CreatePublicURLLogURL = "/devices/:uid/public-url/logs"
+	ListPublicURLLogsURL  = "/devices/:uid/public-url/logs"
+	GetPublicURLStatsURL  = "/devices/:uid/public-url/stats"
+)
+
+// CreatePublicURLLog receives the log of a request proxied to the device's public URL by the SSH service.
+func (h *Handler) CreatePublicURLLog(c gateway.Context) error {
+	req := new(requests.PublicURLLogCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.CreatePublicURLLog(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListPublicURLLogs(c gateway.Context) error {
+	req := new(requests.PublicURLLogsList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListPublicURLLogs(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) GetPublicURLStats(c gateway.Context) error {
+	req := new(requests.PublicURLStatsGet)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.GetPublicURLStats(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,188 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreatePublicURLLog(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the tenant is missing",
+			body:           `{"method": "GET", "path": "/", "status": 200, "time": "2023-01-01T12:00:00Z"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "succeeds",
+			body:  `{"tenant_id": "tenant-id", "method": "GET", "path": "/", "status": 200, "bytes": 1024, "latency": 10, "source_ip": "192.168.0.1", "time": "2023-01-01T12:00:00Z"}`,
+			requiredMocks: func() {
+				mock.
+					On("CreatePublicURLLog", gomock.Anything, &requests.PublicURLLogCreate{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						PublicURLLog: models.PublicURLLog{
+							TenantID: "tenant-id",
+							Method:   "GET",
+							Path:     "/",
+							Status:   200,
+							Bytes:    1024,
+							Latency:  10,
+							SourceIP: "192.168.0.1",
+							Time:     time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						},
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/devices/1234/public-url/logs", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListPublicURLLogs(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.
+					On("ListPublicURLLogs", gomock.Anything, &requests.PublicURLLogsList{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Paginator:   query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return(nil, 0, svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			requiredMocks: func() {
+				mock.
+					On("ListPublicURLLogs", gomock.Anything, &requests.PublicURLLogsList{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Paginator:   query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return([]models.PublicURLLog{{ID: "id", DeviceUID: "1234", Status: 200}}, 1, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/1234/public-url/logs", nil)
+			req.Header.Set("X-Role", authorizer.RoleObserver.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetPublicURLStats(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.
+					On("GetPublicURLStats", gomock.Anything, &requests.PublicURLStatsGet{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+					}).
+					Return(nil, svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			requiredMocks: func() {
+				mock.
+					On("GetPublicURLStats", gomock.Anything, &requests.PublicURLStatsGet{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+					}).
+					Return(&models.PublicURLStats{Requests: 1, Bytes: 1024, Latency: 10, SourceIPs: 1}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/1234/public-url/stats", nil)
+			req.Header.Set("X-Role", authorizer.RoleObserver.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
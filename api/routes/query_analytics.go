@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+)
+
+const (
+	GetQueryAnalyticsURL = "/analytics/queries"
+)
+
+// GetQueryAnalytics returns the usage of the listing queries' fields, sampled by the API instance that serves the
+// request, so the operators can create the indexes their usage requires. It is only available on the internal API.
+func (h *Handler) GetQueryAnalytics(c gateway.Context) error {
+	report := h.service.GetQueryAnalytics(c.Ctx())
+	if report == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/queryanalytics"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestGetQueryAnalytics(t *testing.T) {
+	cases := []struct {
+		description string
+		report      *queryanalytics.Report
+		expected    int
+	}{
+		{
+			description: "fails when the analytics are disabled",
+			report:      nil,
+			expected:    http.StatusNotFound,
+		},
+		{
+			description: "succeeds when the analytics are enabled",
+			report:      &queryanalytics.Report{SampleRate: 10, Queries: map[string]queryanalytics.Usage{}},
+			expected:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			service := new(mocks.Service)
+			service.On("GetQueryAnalytics", gomock.Anything).Return(tc.report).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/internal/analytics/queries", nil)
+			rec := httptest.NewRecorder()
+
+			NewRouter(service).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+			service.AssertExpectations(t)
+		})
+	}
+}
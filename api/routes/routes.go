@@ -2,17 +2,22 @@ package routes
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/shellhub-io/shellhub/api/pkg/echo/handlers"
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/policyengine"
 	routesmiddleware "github.com/shellhub-io/shellhub/api/routes/middleware"
 	"github.com/shellhub-io/shellhub/api/services"
-	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/banlist"
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	pkgmiddleware "github.com/shellhub-io/shellhub/pkg/middleware"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 type DefaultHTTPHandlerConfig struct {
@@ -39,7 +44,7 @@ func DefaultHTTPHandler[S any](service S, cfg *DefaultHTTPHandlerConfig) http.Ha
 	// Configures the default IP extractor for a header.
 	server.IPExtractor = echo.ExtractIPFromRealIPHeader()
 
-	server.Use(echoMiddleware.RequestID())
+	server.Use(correlation.Middleware)
 	server.Use(echoMiddleware.Secure())
 	server.Use(pkgmiddleware.Log)
 	server.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -63,9 +68,56 @@ func WithReporter(reporter *sentry.Client) Option {
 	}
 }
 
+// WithDeviceAuthBudget limits the device authentications handled at the same time to max. The authentications above
+// it are refused with a backoff hint, spreading the reconnection of large fleets, like after a server restart, instead
+// of overloading the server.
+func WithDeviceAuthBudget(max int) Option {
+	return func(_ *echo.Echo, handler *Handler) error {
+		if max > 0 {
+			handler.deviceAuthBudget = make(chan struct{}, max)
+		}
+
+		return nil
+	}
+}
+
+// WithBanlist refuses the requests from the addresses on list to the public API.
+func WithBanlist(list *banlist.Banlist) Option {
+	return func(_ *echo.Echo, handler *Handler) error {
+		handler.banlist = list
+
+		return nil
+	}
+}
+
+// WithStoreBackpressure refuses the requests, with a backoff hint, when the store's connection pool is still saturated
+// after waiting for it up to timeout.
+func WithStoreBackpressure(pool routesmiddleware.Pool, timeout time.Duration) Option {
+	return func(e *echo.Echo, _ *Handler) error {
+		e.Use(routesmiddleware.Backpressure(pool, timeout, store.UnavailableBackoff))
+
+		return nil
+	}
+}
+
+// WithPolicyEngine evaluates the requests to the sensitive routes, after the client's role allowed them, against the
+// organization's policies on the engine.
+func WithPolicyEngine(engine policyengine.Engine) Option {
+	return func(e *echo.Echo, _ *Handler) error {
+		e.Use(routesmiddleware.EvaluatePolicies(engine, actions))
+
+		return nil
+	}
+}
+
 func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	router := DefaultHTTPHandler(service, new(DefaultHTTPHandlerConfig)).(*echo.Echo)
 
+	// NOTICE: the versions and the policies are enforced after the routing, when the matched route's path is already
+	// known.
+	router.Use(versionEnforce)
+	router.Use(routesmiddleware.Enforce(PublicPrefix, policies))
+
 	handler := NewHandler(service)
 	for _, opt := range opts {
 		if err := opt(router, handler); err != nil {
@@ -74,20 +126,38 @@ func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	}
 
 	// Internal routes only accessible by other services in the local container network
-	internalAPI := router.Group("/internal")
+	internalAPI := router.Group(InternalPrefix)
 
 	internalAPI.GET(AuthRequestURL, gateway.Handler(handler.AuthRequest))
 	internalAPI.GET(AuthUserTokenInternalURL, gateway.Handler(handler.CreateUserToken)) // TODO: same as defined in public API. remove it.
 
 	internalAPI.GET(GetDeviceByPublicURLAddress, gateway.Handler(handler.GetDeviceByPublicURLAddress))
 	internalAPI.POST(OfflineDeviceURL, gateway.Handler(handler.OfflineDevice))
+	internalAPI.POST(EnqueueDevicesHeartbeatURL, gateway.Handler(handler.EnqueueDevicesHeartbeat))
 	internalAPI.GET(LookupDeviceURL, gateway.Handler(handler.LookupDevice))
+	internalAPI.GET(EvaluateSessionScheduleURL, gateway.Handler(handler.EvaluateSessionSchedule))
+	internalAPI.POST(EvaluateSessionPolicyURL, gateway.Handler(handler.EvaluateSessionPolicy))
+	internalAPI.POST(EvaluateSessionWebhookURL, gateway.Handler(handler.EvaluateSessionWebhook))
+	internalAPI.POST(CreatePublicURLLogURL, gateway.Handler(handler.CreatePublicURLLog))
+	internalAPI.GET(GetDeviceCommandPoliciesURL, gateway.Handler(handler.GetDeviceCommandPolicies))
+	internalAPI.POST(CreateCommandPolicyEvaluationURL, gateway.Handler(handler.CreateCommandPolicyEvaluation))
+	internalAPI.PUT(UpdateNamespaceDeviceLimitsURL, gateway.Handler(handler.UpdateNamespaceDeviceLimits))
+
+	internalAPI.GET(ListBannedAddressesURL, gateway.Handler(handler.ListBannedAddresses))
+	internalAPI.POST(CreateBannedAddressURL, gateway.Handler(handler.CreateBannedAddress))
+	internalAPI.DELETE(DeleteBannedAddressURL, gateway.Handler(handler.DeleteBannedAddress))
+	internalAPI.POST(RecordAddressFailureURL, gateway.Handler(handler.RecordAddressFailure))
 
 	internalAPI.POST(CreateSessionURL, gateway.Handler(handler.CreateSession))
 	internalAPI.POST(FinishSessionURL, gateway.Handler(handler.FinishSession))
 	internalAPI.POST(KeepAliveSessionURL, gateway.Handler(handler.KeepAliveSession))
 	internalAPI.PATCH(UpdateSessionURL, gateway.Handler(handler.UpdateSession))
 	internalAPI.POST(RecordSessionURL, gateway.Handler(handler.RecordSession))
+	internalAPI.POST(RedeemWebSessionURL, gateway.Handler(handler.RedeemWebSession))
+
+	internalAPI.GET(ResolveUserAliasURL, gateway.Handler(handler.ResolveUserAlias))
+
+	internalAPI.GET(GetQueryAnalyticsURL, gateway.Handler(handler.GetQueryAnalytics))
 
 	internalAPI.GET(GetPublicKeyURL, gateway.Handler(handler.GetPublicKey))
 	internalAPI.POST(CreatePrivateKeyURL, gateway.Handler(handler.CreatePrivateKey))
@@ -95,8 +165,13 @@ func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	internalAPI.POST(EventsSessionsURL, gateway.Handler(handler.EventSession))
 
 	// Public routes for external access through API gateway
-	publicAPI := router.Group("/api")
+	publicAPI := router.Group(PublicPrefix)
+	if handler.banlist != nil {
+		publicAPI.Use(routesmiddleware.Banned(handler.banlist))
+	}
+
 	publicAPI.GET(HealthCheckURL, gateway.Handler(handler.EvaluateHealth))
+	publicAPI.GET(ChangelogURL, gateway.Handler(handler.GetChangelog))
 
 	publicAPI.GET(AuthLocalUserURLV2, gateway.Handler(handler.CreateUserToken))                                   // TODO: method POST
 	publicAPI.GET(AuthUserTokenPublicURL, gateway.Handler(handler.CreateUserToken), routesmiddleware.BlockAPIKey) // TODO: method POST
@@ -104,68 +179,162 @@ func NewRouter(service services.Service, opts ...Option) *echo.Echo {
 	publicAPI.POST(AuthDeviceURLV2, gateway.Handler(handler.AuthDevice))
 	publicAPI.POST(AuthLocalUserURL, gateway.Handler(handler.AuthLocalUser))
 	publicAPI.POST(AuthLocalUserURLV2, gateway.Handler(handler.AuthLocalUser))
+	publicAPI.POST(AuthRefreshUserTokenURL, gateway.Handler(handler.RefreshUserToken))
 	publicAPI.POST(AuthPublicKeyURL, gateway.Handler(handler.AuthPublicKey))
 
-	publicAPI.POST(CreateAPIKeyURL, gateway.Handler(handler.CreateAPIKey), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.APIKeyCreate))
+	publicAPI.POST(CreateAPIKeyURL, gateway.Handler(handler.CreateAPIKey), routesmiddleware.BlockAPIKey)
 	publicAPI.GET(ListAPIKeysURL, gateway.Handler(handler.ListAPIKeys))
-	publicAPI.PATCH(UpdateAPIKeyURL, gateway.Handler(handler.UpdateAPIKey), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.APIKeyUpdate))
-	publicAPI.DELETE(DeleteAPIKeyURL, gateway.Handler(handler.DeleteAPIKey), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.APIKeyDelete))
+	publicAPI.PATCH(UpdateAPIKeyURL, gateway.Handler(handler.UpdateAPIKey), routesmiddleware.BlockAPIKey)
+	publicAPI.DELETE(DeleteAPIKeyURL, gateway.Handler(handler.DeleteAPIKey), routesmiddleware.BlockAPIKey)
+
+	publicAPI.POST(CreateEnrollTokenURL, gateway.Handler(handler.CreateEnrollToken))
+	publicAPI.GET(ListEnrollTokensURL, gateway.Handler(handler.ListEnrollTokens))
+	publicAPI.DELETE(DeleteEnrollTokenURL, gateway.Handler(handler.DeleteEnrollToken))
 
 	publicAPI.PATCH(URLUpdateUser, gateway.Handler(handler.UpdateUser), routesmiddleware.BlockAPIKey)
 	publicAPI.PATCH(URLDeprecatedUpdateUser, gateway.Handler(handler.UpdateUser), routesmiddleware.BlockAPIKey)                 // WARN: DEPRECATED.
 	publicAPI.PATCH(URLDeprecatedUpdateUserPassword, gateway.Handler(handler.UpdateUserPassword), routesmiddleware.BlockAPIKey) // WARN: DEPRECATED.
+	publicAPI.POST(URLResendUserEmailVerification, gateway.Handler(handler.ResendUserEmailVerification))
+	publicAPI.GET(URLVerifyUserEmail, gateway.Handler(handler.VerifyUserEmail))
+
+	publicAPI.GET(ListUserAliasesURL, gateway.Handler(handler.ListUserAliases), routesmiddleware.BlockAPIKey)
+	publicAPI.PUT(SaveUserAliasURL, gateway.Handler(handler.SaveUserAlias), routesmiddleware.BlockAPIKey)
+	publicAPI.DELETE(DeleteUserAliasURL, gateway.Handler(handler.DeleteUserAlias), routesmiddleware.BlockAPIKey)
+
+	publicAPI.GET(ListUserSessionsURL, gateway.Handler(handler.ListUserSessions), routesmiddleware.BlockAPIKey)
+	publicAPI.DELETE(RevokeUserSessionURL, gateway.Handler(handler.RevokeUserSession), routesmiddleware.BlockAPIKey)
+	publicAPI.DELETE(RevokeUserSessionsURL, gateway.Handler(handler.RevokeUserSessions), routesmiddleware.BlockAPIKey)
+
+	publicAPI.GET(SearchUserDevicesURL, gateway.Handler(handler.SearchUserDevices), routesmiddleware.BlockAPIKey)
 
 	publicAPI.GET(GetDeviceListURL, routesmiddleware.Authorize(gateway.Handler(handler.GetDeviceList)))
+	publicAPI.GET(ListDevicePositionsURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDevicePositions)))
 	publicAPI.GET(GetDeviceURL, routesmiddleware.Authorize(gateway.Handler(handler.GetDevice)))
-	publicAPI.PUT(UpdateDevice, gateway.Handler(handler.UpdateDevice), routesmiddleware.RequiresPermission(authorizer.DeviceUpdate))
-	publicAPI.PATCH(RenameDeviceURL, gateway.Handler(handler.RenameDevice), routesmiddleware.RequiresPermission(authorizer.DeviceRename))
-	publicAPI.PATCH(UpdateDeviceStatusURL, gateway.Handler(handler.UpdateDeviceStatus), routesmiddleware.RequiresPermission(authorizer.DeviceAccept)) // TODO: DeviceWrite
-	publicAPI.DELETE(DeleteDeviceURL, gateway.Handler(handler.DeleteDevice), routesmiddleware.RequiresPermission(authorizer.DeviceRemove))
-
-	publicAPI.POST(CreateTagURL, gateway.Handler(handler.CreateDeviceTag), routesmiddleware.RequiresPermission(authorizer.DeviceCreateTag))
-	publicAPI.PUT(UpdateTagURL, gateway.Handler(handler.UpdateDeviceTag), routesmiddleware.RequiresPermission(authorizer.DeviceUpdateTag))
-	publicAPI.DELETE(RemoveTagURL, gateway.Handler(handler.RemoveDeviceTag), routesmiddleware.RequiresPermission(authorizer.DeviceRemoveTag))
+	publicAPI.PUT(UpdateDevice, gateway.Handler(handler.UpdateDevice))
+	publicAPI.PATCH(RenameDeviceURL, gateway.Handler(handler.RenameDevice))
+	publicAPI.PATCH(UpdateDeviceStatusURL, gateway.Handler(handler.UpdateDeviceStatus)) // TODO: DeviceWrite
+	publicAPI.POST(ClaimDeviceURL, gateway.Handler(handler.ClaimDevice))
+	publicAPI.PUT(UpdateDeviceLoginShellURL, gateway.Handler(handler.UpdateDeviceLoginShell))
+	publicAPI.PUT(UpdateDeviceRemoteAccessURL, gateway.Handler(handler.UpdateDeviceRemoteAccess))
+	publicAPI.PUT(UpdateDeviceNoteURL, gateway.Handler(handler.UpdateDeviceConnectionNote))
+	publicAPI.PUT(UpdateDeviceConfigURL, gateway.Handler(handler.UpdateDeviceConfig))
+	publicAPI.DELETE(DeleteDeviceURL, gateway.Handler(handler.DeleteDevice))
+	publicAPI.GET(ListDeviceKeyIncidentsURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDeviceKeyIncidents)))
+	publicAPI.GET(ListDeviceQuarantineAttemptsURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDeviceQuarantineAttempts)))
+	publicAPI.PATCH(UpdateDeviceKeyIncidentURL, gateway.Handler(handler.UpdateDeviceKeyIncident))
+	publicAPI.POST(CreateDeviceAgentLogsURL, gateway.Handler(handler.CreateDeviceAgentLogs))
+	publicAPI.GET(ListDeviceAgentLogsURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDeviceAgentLogs)))
+	publicAPI.POST(CreateDeviceCommandURL, gateway.Handler(handler.CreateDeviceCommand))
+	publicAPI.GET(ListDeviceCommandsURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDeviceCommands)))
+	publicAPI.POST(DispatchDeviceCommandsURL, gateway.Handler(handler.DispatchDeviceCommands))
+	publicAPI.PUT(FinishDeviceCommandURL, gateway.Handler(handler.FinishDeviceCommand))
+	publicAPI.GET(ListDeviceSharesURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDeviceShares)))
+	publicAPI.POST(CreateDeviceShareURL, gateway.Handler(handler.CreateDeviceShare))
+	publicAPI.DELETE(DeleteDeviceShareURL, gateway.Handler(handler.DeleteDeviceShare))
+	publicAPI.GET(DiffDeviceURL, routesmiddleware.Authorize(gateway.Handler(handler.DiffDevice)))
+	publicAPI.GET(ListPublicURLLogsURL, routesmiddleware.Authorize(gateway.Handler(handler.ListPublicURLLogs)))
+	publicAPI.GET(GetPublicURLStatsURL, routesmiddleware.Authorize(gateway.Handler(handler.GetPublicURLStats)))
+	publicAPI.PUT(UpdateDeviceLimitExemptionURL, gateway.Handler(handler.UpdateDeviceLimitExemption))
+	publicAPI.GET(ListDeviceLimitExemptionsURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDeviceLimitExemptions)))
+	publicAPI.POST(OverrideSessionScheduleURL, gateway.Handler(handler.OverrideSessionSchedule))
+	publicAPI.POST(CreateWebSessionURL, gateway.Handler(handler.CreateWebSession), routesmiddleware.BlockAPIKey)
+	publicAPI.GET(ListSessionScheduleOverridesURL, routesmiddleware.Authorize(gateway.Handler(handler.ListSessionScheduleOverrides)))
+	publicAPI.GET(ListDeviceQueueURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDeviceQueue)))
+	publicAPI.GET(ListDeviceChangesURL, routesmiddleware.Authorize(gateway.Handler(handler.ListDeviceChanges)))
+	publicAPI.PUT(QueueDeviceURL, gateway.Handler(handler.QueueDevice))
+	publicAPI.DELETE(DequeueDeviceURL, gateway.Handler(handler.DequeueDevice))
+	publicAPI.POST(CreateAcceptDevicesJobURL, gateway.Handler(handler.CreateDevicesJob(models.JobOperationDeviceAccept)))
+	publicAPI.POST(CreateTagDevicesJobURL, gateway.Handler(handler.CreateDevicesJob(models.JobOperationDeviceTag)))
+	publicAPI.POST(CreateExportDevicesJobURL, gateway.Handler(handler.CreateDevicesJob(models.JobOperationDeviceExport)))
+	publicAPI.POST(CreateRefreshDevicesJobURL, gateway.Handler(handler.CreateDevicesJob(models.JobOperationDeviceRefresh)))
+	publicAPI.GET(GetJobURL, routesmiddleware.Authorize(gateway.Handler(handler.GetJob)))
+
+	publicAPI.POST(CreateTagURL, gateway.Handler(handler.CreateDeviceTag))
+	publicAPI.PUT(UpdateTagURL, gateway.Handler(handler.UpdateDeviceTag))
+	publicAPI.DELETE(RemoveTagURL, gateway.Handler(handler.RemoveDeviceTag))
 
 	publicAPI.GET(GetTagsURL, gateway.Handler(handler.GetTags))
-	publicAPI.PUT(RenameTagURL, gateway.Handler(handler.RenameTag), routesmiddleware.RequiresPermission(authorizer.DeviceRenameTag))
-	publicAPI.DELETE(DeleteTagsURL, gateway.Handler(handler.DeleteTag), routesmiddleware.RequiresPermission(authorizer.DeviceDeleteTag))
+	publicAPI.PUT(RenameTagURL, gateway.Handler(handler.RenameTag))
+	publicAPI.DELETE(DeleteTagsURL, gateway.Handler(handler.DeleteTag))
+
+	publicAPI.GET(ListTagRulesURL, gateway.Handler(handler.ListTagRules))
+	publicAPI.POST(CreateTagRuleURL, gateway.Handler(handler.CreateTagRule))
+	publicAPI.PUT(UpdateTagRuleURL, gateway.Handler(handler.UpdateTagRule))
+	publicAPI.DELETE(DeleteTagRuleURL, gateway.Handler(handler.DeleteTagRule))
+
+	publicAPI.GET(ListCommandPoliciesURL, gateway.Handler(handler.ListCommandPolicies))
+	publicAPI.POST(CreateCommandPolicyURL, gateway.Handler(handler.CreateCommandPolicy))
+	publicAPI.PUT(UpdateCommandPolicyURL, gateway.Handler(handler.UpdateCommandPolicy))
+	publicAPI.DELETE(DeleteCommandPolicyURL, gateway.Handler(handler.DeleteCommandPolicy))
+	publicAPI.GET(ListCommandPolicyEvaluationsURL, gateway.Handler(handler.ListCommandPolicyEvaluations))
+
+	publicAPI.GET(ListGroupsURL, gateway.Handler(handler.ListGroups))
+	publicAPI.POST(CreateGroupURL, gateway.Handler(handler.CreateGroup))
+	publicAPI.PUT(UpdateGroupURL, gateway.Handler(handler.UpdateGroup))
+	publicAPI.DELETE(DeleteGroupURL, gateway.Handler(handler.DeleteGroup))
+	publicAPI.POST(AddGroupDeviceURL, gateway.Handler(handler.AddGroupDevice))
+	publicAPI.DELETE(RemoveGroupDeviceURL, gateway.Handler(handler.RemoveGroupDevice))
 
 	publicAPI.GET(GetSessionsURL, routesmiddleware.Authorize(gateway.Handler(handler.GetSessionList)))
 	publicAPI.GET(GetSessionURL, routesmiddleware.Authorize(gateway.Handler(handler.GetSession)))
 	publicAPI.GET(PlaySessionURL, gateway.Handler(handler.PlaySession))
+	publicAPI.GET(GetSessionRecordingURL, routesmiddleware.Authorize(gateway.Handler(handler.GetSessionRecording)))
+	publicAPI.GET(ListSessionRecordingAccessesURL, gateway.Handler(handler.ListSessionRecordingAccesses))
+	publicAPI.GET(TranscriptSessionURL, gateway.Handler(handler.TranscriptSession))
 	publicAPI.DELETE(RecordSessionURL, gateway.Handler(handler.DeleteRecordedSession))
+	publicAPI.POST(VerifySessionAttestationURL, gateway.Handler(handler.VerifySessionAttestation))
 
 	publicAPI.GET(GetStatsURL, routesmiddleware.Authorize(gateway.Handler(handler.GetStats)))
 	publicAPI.GET(GetSystemInfoURL, gateway.Handler(handler.GetSystemInfo))
 	publicAPI.GET(GetSystemDownloadInstallScriptURL, gateway.Handler(handler.GetSystemDownloadInstallScript))
 
-	publicAPI.POST(CreatePublicKeyURL, gateway.Handler(handler.CreatePublicKey), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.PublicKeyCreate))
+	publicAPI.POST(CreatePublicKeyURL, gateway.Handler(handler.CreatePublicKey), routesmiddleware.BlockAPIKey)
+	publicAPI.POST(ImportPublicKeysURL, gateway.Handler(handler.ImportPublicKeys), routesmiddleware.BlockAPIKey)
 	publicAPI.GET(GetPublicKeysURL, gateway.Handler(handler.GetPublicKeys))
-	publicAPI.PUT(UpdatePublicKeyURL, gateway.Handler(handler.UpdatePublicKey), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.PublicKeyEdit))
-	publicAPI.DELETE(DeletePublicKeyURL, gateway.Handler(handler.DeletePublicKey), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.PublicKeyRemove))
+	publicAPI.PUT(UpdatePublicKeyURL, gateway.Handler(handler.UpdatePublicKey), routesmiddleware.BlockAPIKey)
+	publicAPI.DELETE(DeletePublicKeyURL, gateway.Handler(handler.DeletePublicKey), routesmiddleware.BlockAPIKey)
+	publicAPI.POST(TestPublicKeyURL, gateway.Handler(handler.TestPublicKey))
 
-	publicAPI.POST(AddPublicKeyTagURL, gateway.Handler(handler.AddPublicKeyTag), routesmiddleware.RequiresPermission(authorizer.PublicKeyAddTag))
-	publicAPI.PUT(UpdatePublicKeyTagsURL, gateway.Handler(handler.UpdatePublicKeyTags), routesmiddleware.RequiresPermission(authorizer.PublicKeyUpdateTag))
-	publicAPI.DELETE(RemovePublicKeyTagURL, gateway.Handler(handler.RemovePublicKeyTag), routesmiddleware.RequiresPermission(authorizer.PublicKeyRemoveTag))
+	publicAPI.POST(AddPublicKeyTagURL, gateway.Handler(handler.AddPublicKeyTag))
+	publicAPI.PUT(UpdatePublicKeyTagsURL, gateway.Handler(handler.UpdatePublicKeyTags))
+	publicAPI.DELETE(RemovePublicKeyTagURL, gateway.Handler(handler.RemovePublicKeyTag))
+
+	publicAPI.GET(GetSSHCertificateAuthoritiesURL, routesmiddleware.Authorize(gateway.Handler(handler.GetSSHCertificateAuthorities)))
+	publicAPI.PUT(SetSSHCertificateAuthorityURL, routesmiddleware.Authorize(gateway.Handler(handler.SetSSHCertificateAuthority)))
+	publicAPI.POST(RotateSSHCertificateAuthorityURL, routesmiddleware.Authorize(gateway.Handler(handler.RotateSSHCertificateAuthority)))
+	publicAPI.DELETE(DeleteSSHCertificateAuthorityURL, routesmiddleware.Authorize(gateway.Handler(handler.DeleteSSHCertificateAuthority)))
 
 	publicAPI.POST(CreateNamespaceURL, gateway.Handler(handler.CreateNamespace))
 	publicAPI.GET(GetNamespaceURL, gateway.Handler(handler.GetNamespace))
 	publicAPI.GET(ListNamespaceURL, gateway.Handler(handler.GetNamespaceList))
-	publicAPI.PUT(EditNamespaceURL, gateway.Handler(handler.EditNamespace), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.NamespaceUpdate))
-	publicAPI.DELETE(DeleteNamespaceURL, gateway.Handler(handler.DeleteNamespace), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.NamespaceDelete))
-
-	publicAPI.POST(AddNamespaceMemberURL, gateway.Handler(handler.AddNamespaceMember), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.NamespaceAddMember))
-	publicAPI.PATCH(EditNamespaceMemberURL, gateway.Handler(handler.EditNamespaceMember), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.NamespaceEditMember))
-	publicAPI.DELETE(RemoveNamespaceMemberURL, gateway.Handler(handler.RemoveNamespaceMember), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.NamespaceRemoveMember))
+	publicAPI.PUT(EditNamespaceURL, gateway.Handler(handler.EditNamespace), routesmiddleware.BlockAPIKey)
+	publicAPI.POST(CloneNamespaceURL, routesmiddleware.Authorize(gateway.Handler(handler.CloneNamespace)), routesmiddleware.BlockAPIKey)
+	publicAPI.POST(PreviewDeviceNameTemplateURL, gateway.Handler(handler.PreviewDeviceNameTemplate))
+	publicAPI.POST(PreviewConnectionAnnouncementURL, gateway.Handler(handler.PreviewConnectionAnnouncement))
+	publicAPI.DELETE(DeleteNamespaceURL, gateway.Handler(handler.DeleteNamespace), routesmiddleware.BlockAPIKey)
+
+	publicAPI.POST(AddNamespaceMemberURL, gateway.Handler(handler.AddNamespaceMember), routesmiddleware.BlockAPIKey)
+	publicAPI.PATCH(EditNamespaceMemberURL, gateway.Handler(handler.EditNamespaceMember), routesmiddleware.BlockAPIKey)
+	publicAPI.DELETE(RemoveNamespaceMemberURL, gateway.Handler(handler.RemoveNamespaceMember), routesmiddleware.BlockAPIKey)
 	publicAPI.DELETE(LeaveNamespaceURL, gateway.Handler(handler.LeaveNamespace), routesmiddleware.BlockAPIKey)
+	publicAPI.GET(GetNamespaceMemberActivityURL, routesmiddleware.Authorize(gateway.Handler(handler.GetNamespaceMemberActivity)), routesmiddleware.BlockAPIKey)
+	publicAPI.GET(ListAuditEntriesURL, routesmiddleware.Authorize(gateway.Handler(handler.ListAuditEntries)))
+	publicAPI.GET(ExportAuditEntriesURL, routesmiddleware.Authorize(gateway.Handler(handler.ExportAuditEntries)))
 
 	publicAPI.GET(GetSessionRecordURL, gateway.Handler(handler.GetSessionRecord))
-	publicAPI.PUT(EditSessionRecordStatusURL, gateway.Handler(handler.EditSessionRecordStatus), routesmiddleware.BlockAPIKey, routesmiddleware.RequiresPermission(authorizer.NamespaceEnableSessionRecord))
+	publicAPI.PUT(EditSessionRecordStatusURL, gateway.Handler(handler.EditSessionRecordStatus), routesmiddleware.BlockAPIKey)
+
+	// NOTICE: the device events' WebSocket route is exposed by the API gateway outside the public prefix.
+	router.GET(DeviceEventsURL, routesmiddleware.Authorize(gateway.Handler(handler.DeviceEvents)))
+	publicAPI.GET(NamespaceEventsURL, routesmiddleware.Authorize(gateway.Handler(handler.NamespaceEvents)))
 
 	if envs.IsCommunity() {
 		publicAPI.POST(SetupEndpoint, gateway.Handler(handler.Setup))
 	}
 
+	// NOTE: Serve the newer versions of the public API through the routes of the first one, before any other rewrite.
+	router.Pre(versionRewrite)
+
 	// NOTE: Rewrite requests to containers to devices, as they are the same thing under the hood, using it as an alias.
 	router.Pre(echoMiddleware.Rewrite(map[string]string{
 		"/api/containers":   "/api/devices?connector=true",
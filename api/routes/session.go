@@ -2,7 +2,6 @@ package routes
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
@@ -20,6 +19,8 @@ const (
 	RecordSessionURL    = "/sessions/:uid/record"
 	PlaySessionURL      = "/sessions/:uid/play"
 	EventsSessionsURL   = "/sessions/:uid/events"
+	// TranscriptSessionURL is the recording rendered as a plain text transcript.
+	TranscriptSessionURL = "/sessions/:uid/record/transcript"
 )
 
 const (
@@ -40,7 +41,11 @@ func (h *Handler) GetSessionList(c gateway.Context) error {
 		return err
 	}
 
-	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+	for i := range sessions {
+		maskDevice(c.Role(), sessions[i].Device)
+	}
+
+	setPaginationHeaders(c, paginator, count)
 
 	return c.JSON(http.StatusOK, sessions)
 }
@@ -60,6 +65,8 @@ func (h *Handler) GetSession(c gateway.Context) error {
 		return err
 	}
 
+	maskDevice(c.Role(), session.Device)
+
 	return c.JSON(http.StatusOK, session)
 }
 
@@ -74,8 +81,12 @@ func (h *Handler) UpdateSession(c gateway.Context) error {
 	}
 
 	return h.service.UpdateSession(c.Ctx(), models.UID(req.UID), models.SessionUpdate{
-		Authenticated: req.Authenticated,
-		Type:          req.Type,
+		Authenticated:    req.Authenticated,
+		Type:             req.Type,
+		AuthMethod:       req.AuthMethod,
+		CertificateKeyID: req.CertificateKeyID,
+		RecordHash:       req.RecordHash,
+		RecordObject:     req.RecordObject,
 	})
 }
 
@@ -131,6 +142,12 @@ func (h *Handler) PlaySession(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// TranscriptSession downloads the session's recording as a plain text transcript. Like the other recording routes,
+// it is served by the API keeping the recordings, which converts them with the asciicast package.
+func (h *Handler) TranscriptSession(c gateway.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) DeleteRecordedSession(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+)
+
+// VerifySessionAttestationURL verifies an attestation exported with its session, proving the session's audit
+// evidence untampered.
+const VerifySessionAttestationURL = "/sessions/attestation/verify"
+
+func (h *Handler) VerifySessionAttestation(c gateway.Context) error {
+	var req requests.SessionAttestationVerify
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, responses.SessionAttestationVerify{
+		Valid: h.service.VerifySessionAttestation(c.Ctx(), &req),
+	})
+}
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestVerifySessionAttestation(t *testing.T) {
+	digest := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	type Expected struct {
+		status int
+		body   string
+	}
+
+	cases := []struct {
+		description   string
+		body          string
+		requiredMocks func(service *mocks.Service)
+		expected      Expected
+	}{
+		{
+			description:   "fails when the digest is invalid",
+			body:          `{"statement":{"uid":"uid"},"digest":"digest","signature":"c2lnbmF0dXJl"}`,
+			requiredMocks: func(_ *mocks.Service) {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description: "succeeds when the attestation is invalid",
+			body:        `{"statement":{"uid":"uid"},"digest":"` + digest + `","signature":"c2lnbmF0dXJl"}`,
+			requiredMocks: func(service *mocks.Service) {
+				service.
+					On("VerifySessionAttestation", gomock.Anything, &requests.SessionAttestationVerify{
+						Statement: models.SessionStatement{UID: "uid"},
+						Digest:    digest,
+						Signature: "c2lnbmF0dXJl",
+					}).
+					Return(false).
+					Once()
+			},
+			expected: Expected{status: http.StatusOK, body: `{"valid":false}`},
+		},
+		{
+			description: "succeeds when the attestation is valid",
+			body:        `{"statement":{"uid":"uid"},"digest":"` + digest + `","signature":"c2lnbmF0dXJl"}`,
+			requiredMocks: func(service *mocks.Service) {
+				service.
+					On("VerifySessionAttestation", gomock.Anything, &requests.SessionAttestationVerify{
+						Statement: models.SessionStatement{UID: "uid"},
+						Digest:    digest,
+						Signature: "c2lnbmF0dXJl",
+					}).
+					Return(true).
+					Once()
+			},
+			expected: Expected{status: http.StatusOK, body: `{"valid":true}`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			service := new(mocks.Service)
+			tc.requiredMocks(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/sessions/attestation/verify", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", "observer")
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			NewRouter(service).ServeHTTP(rec, req)
+
+			body := ""
+			if rec.Code == http.StatusOK {
+				body = strings.TrimSpace(rec.Body.String())
+			}
+
+			assert.Equal(t, tc.expected, Expected{rec.Code, body})
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package routes
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	// GetSessionRecordingURL is the session's recording, kept on the object storage, encoded as an asciicast file.
+	GetSessionRecordingURL = "/sessions/:uid/recording"
+	// ListSessionRecordingAccessesURL is the access log of the session's recording.
+	ListSessionRecordingAccessesURL = "/sessions/:uid/recording/accesses"
+)
+
+// AsciicastContentType is the content type of the recordings encoded as asciicast files.
+const AsciicastContentType = "application/x-asciicast"
+
+func (h *Handler) GetSessionRecording(c gateway.Context) error {
+	var req requests.SessionRecordingGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	recording, err := h.service.GetSessionRecording(c.Ctx(), &req)
+	if err != nil {
+		return err
+	}
+
+	if req.Download {
+		c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", req.UID+".cast"))
+	}
+
+	// NOTICE: the recording is served honoring the request's Range header, so the players can retrieve only the
+	// segments being viewed.
+	c.Response().Header().Set("Content-Type", AsciicastContentType)
+	http.ServeContent(c.Response(), c.Request(), "", time.Time{}, bytes.NewReader(recording))
+
+	return nil
+}
+
+// ListSessionRecordingAccesses lists who accessed the session's recording, when and which part of it.
+func (h *Handler) ListSessionRecordingAccesses(c gateway.Context) error {
+	req := new(requests.SessionRecordingAccessList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListSessionRecordingAccesses(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,84 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestGetSessionRecording(t *testing.T) {
+	type Expected struct {
+		status      int
+		contentType string
+		body        string
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(service *mocks.Service)
+		expected      Expected
+	}{
+		{
+			description: "fails when the session's recording isn't found",
+			requiredMocks: func(service *mocks.Service) {
+				service.
+					On("GetSessionRecording", gomock.Anything, &requests.SessionRecordingGet{
+						SessionIDParam: requests.SessionIDParam{UID: "uid"},
+						TenantID:       "tenant-id",
+						Username:       "john",
+					}).
+					Return(nil, svc.NewErrSessionRecordingNotFound("uid", nil)).
+					Once()
+			},
+			expected: Expected{status: http.StatusNotFound},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(service *mocks.Service) {
+				service.
+					On("GetSessionRecording", gomock.Anything, &requests.SessionRecordingGet{
+						SessionIDParam: requests.SessionIDParam{UID: "uid"},
+						TenantID:       "tenant-id",
+						Username:       "john",
+					}).
+					Return([]byte("{\"version\":2,\"width\":80,\"height\":24}\n"), nil).
+					Once()
+			},
+			expected: Expected{
+				status:      http.StatusOK,
+				contentType: AsciicastContentType,
+				body:        "{\"version\":2,\"width\":80,\"height\":24}\n",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			service := new(mocks.Service)
+			tc.requiredMocks(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/sessions/uid/recording", nil)
+			req.Header.Set("X-Role", "observer")
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			req.Header.Set("X-Username", "john")
+			rec := httptest.NewRecorder()
+
+			NewRouter(service).ServeHTTP(rec, req)
+
+			result := Expected{status: rec.Code}
+			if rec.Code == http.StatusOK {
+				result.contentType = rec.Header().Get("Content-Type")
+				result.body = rec.Body.String()
+			}
+
+			assert.Equal(t, tc.expected, result)
+			service.AssertExpectations(t)
+		})
+	}
+}
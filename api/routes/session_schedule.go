@@ -0,0 +1,117 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	EvaluateSessionScheduleURL      = "/devices/:uid/session-schedule"
+	EvaluateSessionPolicyURL        = "/devices/:uid/session-policy"
+	EvaluateSessionWebhookURL       = "/devices/:uid/session-webhook"
+	OverrideSessionScheduleURL      = "/devices/:uid/session-schedule/override"
+	ListSessionScheduleOverridesURL = "/devices/:uid/session-schedule/overrides"
+)
+
+// EvaluateSessionSchedule evaluates if the namespace's session schedules allow connections to a device now.
+func (h *Handler) EvaluateSessionSchedule(c gateway.Context) error {
+	req := new(requests.DeviceEvaluateSessionSchedule)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.EvaluateSessionSchedule(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// EvaluateSessionPolicy evaluates if the organization's policies, on the policy engine, allow a session to a device.
+func (h *Handler) EvaluateSessionPolicy(c gateway.Context) error {
+	req := new(requests.DeviceEvaluateSessionPolicy)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.EvaluateSessionPolicy(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// EvaluateSessionWebhook evaluates if the namespace's connection webhook allows a session to a device.
+func (h *Handler) EvaluateSessionWebhook(c gateway.Context) error {
+	req := new(requests.DeviceEvaluateSessionWebhook)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.EvaluateSessionWebhook(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// OverrideSessionSchedule allows connections to a device outside the namespace's session schedules for a while.
+func (h *Handler) OverrideSessionSchedule(c gateway.Context) error {
+	req := new(requests.DeviceOverrideSessionSchedule)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	override, err := h.service.OverrideSessionSchedule(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, override)
+}
+
+// ListSessionScheduleOverrides lists the overrides of the namespace's session schedules for a device.
+func (h *Handler) ListSessionScheduleOverrides(c gateway.Context) error {
+	req := new(requests.DeviceSessionScheduleOverridesList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, count, err := h.service.ListSessionScheduleOverrides(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,378 @@
+package routes
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	policyenginemocks "github.com/shellhub-io/shellhub/api/pkg/policyengine/mocks"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	storemocks "github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateSessionSchedule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the schedules don't allow the connection",
+			requiredMocks: func() {
+				mock.
+					On("EvaluateSessionSchedule", gomock.Anything, &requests.DeviceEvaluateSessionSchedule{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+					}).
+					Return(svc.NewErrSessionScheduleBlock()).
+					Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "succeeds",
+			requiredMocks: func() {
+				mock.
+					On("EvaluateSessionSchedule", gomock.Anything, &requests.DeviceEvaluateSessionSchedule{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/internal/devices/1234/session-schedule", nil)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateSessionPolicy(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the client's address is missing",
+			body:           `{"username": "root"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the policies don't allow the session",
+			body:  `{"username": "root", "ip_address": "192.168.0.1"}`,
+			requiredMocks: func() {
+				mock.
+					On("EvaluateSessionPolicy", gomock.Anything, &requests.DeviceEvaluateSessionPolicy{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Username:    "root",
+						IPAddress:   "192.168.0.1",
+					}).
+					Return(svc.NewErrSessionPolicyBlock()).
+					Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "succeeds",
+			body:  `{"username": "root", "ip_address": "192.168.0.1"}`,
+			requiredMocks: func() {
+				mock.
+					On("EvaluateSessionPolicy", gomock.Anything, &requests.DeviceEvaluateSessionPolicy{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Username:    "root",
+						IPAddress:   "192.168.0.1",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/devices/1234/session-policy", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateSessionPolicyFailsClosed(t *testing.T) {
+	storeMock := new(storemocks.Store)
+	engineMock := new(policyenginemocks.Engine)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	storeMock.
+		On("NamespaceGet", gomock.Anything, "tenant-id").
+		Return(&models.Namespace{TenantID: "tenant-id"}, nil).
+		Once()
+	storeMock.
+		On("DeviceGetByUID", gomock.Anything, models.UID("1234"), "tenant-id").
+		Return(&models.Device{UID: "1234", Name: "device"}, nil).
+		Once()
+	engineMock.
+		On("Evaluate", gomock.Anything, gomock.Anything).
+		Return(nil, errors.New("error")).
+		Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/internal/devices/1234/session-policy", strings.NewReader(`{"username": "root", "ip_address": "192.168.0.1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", "tenant-id")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(svc.NewService(storeMock, privateKey, &privateKey.PublicKey, cache.NewNullCache(), nil, svc.WithPolicyEngine(engineMock)))
+	e.ServeHTTP(rec, req)
+
+	// NOTICE: the SSH server's client retries the requests answered with a server error, so the session must be
+	// denied with a client error instead.
+	assert.Equal(t, http.StatusForbidden, rec.Result().StatusCode)
+
+	storeMock.AssertExpectations(t)
+	engineMock.AssertExpectations(t)
+}
+
+func TestEvaluateSessionWebhook(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the client's address is missing",
+			body:           `{"username": "root"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the webhook doesn't allow the session",
+			body:  `{"username": "root", "ip_address": "192.168.0.1"}`,
+			requiredMocks: func() {
+				mock.
+					On("EvaluateSessionWebhook", gomock.Anything, &requests.DeviceEvaluateSessionWebhook{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Username:    "root",
+						IPAddress:   "192.168.0.1",
+					}).
+					Return(svc.NewErrSessionWebhookBlock()).
+					Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "succeeds",
+			body:  `{"username": "root", "ip_address": "192.168.0.1"}`,
+			requiredMocks: func() {
+				mock.
+					On("EvaluateSessionWebhook", gomock.Anything, &requests.DeviceEvaluateSessionWebhook{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						Username:    "root",
+						IPAddress:   "192.168.0.1",
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/devices/1234/session-webhook", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateSessionWebhookFailsClosed(t *testing.T) {
+	storeMock := new(storemocks.Store)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	// NOTICE: the webhook is down, so the request to it fails right away.
+	webhook := httptest.NewServer(http.NotFoundHandler())
+	webhook.Close()
+
+	storeMock.
+		On("NamespaceGet", gomock.Anything, "tenant-id").
+		Return(&models.Namespace{
+			TenantID: "tenant-id",
+			Settings: &models.NamespaceSettings{ConnectionWebhook: &models.ConnectionWebhook{URL: webhook.URL, FailOpen: false}},
+		}, nil).
+		Once()
+	storeMock.
+		On("DeviceGetByUID", gomock.Anything, models.UID("1234"), "tenant-id").
+		Return(&models.Device{UID: "1234", Name: "device"}, nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/internal/devices/1234/session-webhook", strings.NewReader(`{"username": "root", "ip_address": "192.168.0.1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", "tenant-id")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(svc.NewService(storeMock, privateKey, &privateKey.PublicKey, cache.NewNullCache(), nil))
+	e.ServeHTTP(rec, req)
+
+	// NOTICE: the SSH server's client retries the requests answered with a server error, so the session must be
+	// denied with a client error instead.
+	assert.Equal(t, http.StatusForbidden, rec.Result().StatusCode)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestOverrideSessionSchedule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           authorizer.Role
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is not allowed",
+			role:           authorizer.RoleObserver,
+			body:           `{"reason": "incident", "duration": 60}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "fails when the reason is missing",
+			role:           authorizer.RoleOwner,
+			body:           `{"duration": 60}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the duration is too long",
+			role:           authorizer.RoleOwner,
+			body:           `{"reason": "incident", "duration": 1441}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role cannot override the schedules",
+			role:  authorizer.RoleOperator,
+			body:  `{"reason": "incident", "duration": 60}`,
+			requiredMocks: func() {
+				mock.
+					On("OverrideSessionSchedule", gomock.Anything, &requests.DeviceOverrideSessionSchedule{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						UserID:      "user-id",
+						Role:        authorizer.RoleOperator,
+						Reason:      "incident",
+						Duration:    60,
+					}).
+					Return(nil, svc.NewErrSessionScheduleOverrideRole()).
+					Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "succeeds",
+			role:  authorizer.RoleOwner,
+			body:  `{"reason": "incident", "duration": 60}`,
+			requiredMocks: func() {
+				mock.
+					On("OverrideSessionSchedule", gomock.Anything, &requests.DeviceOverrideSessionSchedule{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						UserID:      "user-id",
+						Role:        authorizer.RoleOwner,
+						Reason:      "incident",
+						Duration:    60,
+					}).
+					Return(&models.SessionScheduleOverride{ID: "id"}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/1234/session-schedule/override", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			req.Header.Set("X-ID", "user-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	GetSSHCertificateAuthoritiesURL  = "/ssh-certificate-authority"
+	SetSSHCertificateAuthorityURL    = "/ssh-certificate-authority"
+	RotateSSHCertificateAuthorityURL = "/ssh-certificate-authority/rotate"
+	DeleteSSHCertificateAuthorityURL = "/ssh-certificate-authority"
+)
+
+// GetSSHCertificateAuthorities lists the namespace's SSH certificate authorities still trusted, the current one first.
+func (h *Handler) GetSSHCertificateAuthorities(c gateway.Context) error {
+	req := new(requests.SSHCertificateAuthoritiesGet)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.GetSSHCertificateAuthorities(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// SetSSHCertificateAuthority uploads the namespace's SSH certificate authority, replacing the trusted ones.
+func (h *Handler) SetSSHCertificateAuthority(c gateway.Context) error {
+	req := new(requests.SSHCertificateAuthoritySet)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.SetSSHCertificateAuthority(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// RotateSSHCertificateAuthority replaces the namespace's current SSH certificate authority, keeping the previous one
+// trusted for a grace period.
+func (h *Handler) RotateSSHCertificateAuthority(c gateway.Context) error {
+	req := new(requests.SSHCertificateAuthorityRotate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.RotateSSHCertificateAuthority(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// DeleteSSHCertificateAuthority stops trusting the namespace's SSH certificate authorities.
+func (h *Handler) DeleteSSHCertificateAuthority(c gateway.Context) error {
+	req := new(requests.SSHCertificateAuthorityDelete)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteSSHCertificateAuthority(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
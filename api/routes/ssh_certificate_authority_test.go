@@ -0,0 +1,129 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestSetSSHCertificateAuthority(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		body          string
+		role          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the role cannot update the namespace",
+			body:          `{"public_key": "ssh-ed25519 AAAA"}`,
+			role:          "operator",
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description:   "fails when the public key is missing",
+			body:          `{}`,
+			role:          "administrator",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			body:        `{"public_key": "ssh-ed25519 AAAA"}`,
+			role:        "administrator",
+			requiredMocks: func() {
+				svcMock.
+					On("SetSSHCertificateAuthority", gomock.Anything, &requests.SSHCertificateAuthoritySet{
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						PublicKey: "ssh-ed25519 AAAA",
+					}).
+					Return([]models.SSHCertificateAuthority{{PublicKey: "ssh-ed25519 AAAA"}}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/ssh-certificate-authority", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestRotateSSHCertificateAuthority(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		body          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the grace period is negative",
+			body:          `{"public_key": "ssh-ed25519 AAAA", "grace_period": -1}`,
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			body:        `{"public_key": "ssh-ed25519 AAAA", "grace_period": 3600}`,
+			requiredMocks: func() {
+				svcMock.
+					On("RotateSSHCertificateAuthority", gomock.Anything, &requests.SSHCertificateAuthorityRotate{
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						PublicKey:   "ssh-ed25519 AAAA",
+						GracePeriod: 3600,
+					}).
+					Return([]models.SSHCertificateAuthority{{PublicKey: "ssh-ed25519 AAAA"}}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/ssh-certificate-authority/rotate", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", "owner")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
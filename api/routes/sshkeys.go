@@ -3,7 +3,6 @@ package routes
 import (
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/store"
@@ -16,10 +15,12 @@ const (
 	GetPublicKeysURL       = "/sshkeys/public-keys"
 	GetPublicKeyURL        = "/sshkeys/public-keys/:fingerprint/:tenant"
 	CreatePublicKeyURL     = "/sshkeys/public-keys"
+	ImportPublicKeysURL    = "/sshkeys/public-keys/import"
 	UpdatePublicKeyURL     = "/sshkeys/public-keys/:fingerprint"
 	DeletePublicKeyURL     = "/sshkeys/public-keys/:fingerprint"
 	CreatePrivateKeyURL    = "/sshkeys/private-keys"
 	EvaluateKeyURL         = "/sshkeys/public-keys/evaluate/:fingerprint/:username"
+	TestPublicKeyURL       = "/sshkeys/public-keys/:fingerprint/test"
 	AddPublicKeyTagURL     = "/sshkeys/public-keys/:fingerprint/tags"      // Add a tag to a public key.
 	RemovePublicKeyTagURL  = "/sshkeys/public-keys/:fingerprint/tags/:tag" // Remove a tag to a public key.
 	UpdatePublicKeyTagsURL = "/sshkeys/public-keys/:fingerprint/tags"      // Update all tags from a public key.
@@ -43,7 +44,7 @@ func (h *Handler) GetPublicKeys(c gateway.Context) error {
 		return err
 	}
 
-	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+	setPaginationHeaders(c, paginator, count)
 
 	return c.JSON(http.StatusOK, list)
 }
@@ -96,6 +97,28 @@ func (h *Handler) CreatePublicKey(c gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h *Handler) ImportPublicKeys(c gateway.Context) error {
+	var req requests.PublicKeyImport
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if c.Tenant() != nil {
+		req.TenantID = c.Tenant().ID
+	}
+
+	res, err := h.service.ImportPublicKeys(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
 func (h *Handler) UpdatePublicKey(c gateway.Context) error {
 	var req requests.PublicKeyUpdate
 	if err := c.Bind(&req); err != nil {
@@ -178,6 +201,26 @@ func (h *Handler) EvaluateKey(c gateway.Context) error {
 	return c.JSON(http.StatusOK, usernameOk && filterOk)
 }
 
+// TestPublicKey checks whether a public key would authorize the access to a device with a username, explaining why.
+func (h *Handler) TestPublicKey(c gateway.Context) error {
+	req := new(requests.PublicKeyTest)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	res, err := h.service.TestPublicKey(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
 func (h *Handler) AddPublicKeyTag(c gateway.Context) error {
 	var req requests.PublicKeyTagAdd
 	if err := c.Bind(&req); err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 	gomock "github.com/stretchr/testify/mock"
@@ -595,6 +596,119 @@ func TestAddPublicKeyTag(t *testing.T) {
 	}
 }
 
+func TestImportPublicKeys(t *testing.T) {
+	type Expected struct {
+		status int
+	}
+
+	svcMock := new(mocks.Service)
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+		"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+		"X-Role":       "owner",
+		"X-ID":         "000000000000000000000000",
+	}
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when role is observer",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "observer",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"provider":  "github",
+				"usernames": []string{"john"},
+				"username":  ".*",
+				"filter":    map[string]interface{}{"hostname": ".*"},
+			},
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "fails when the provider is unknown",
+			headers:     headers,
+			body: map[string]interface{}{
+				"provider":  "bitbucket",
+				"usernames": []string{"john"},
+				"username":  ".*",
+				"filter":    map[string]interface{}{"hostname": ".*"},
+			},
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description: "fails when no username is provided",
+			headers:     headers,
+			body: map[string]interface{}{
+				"provider":  "github",
+				"usernames": []string{},
+				"username":  ".*",
+				"filter":    map[string]interface{}{"hostname": ".*"},
+			},
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description: "succeeds",
+			headers:     headers,
+			body: map[string]interface{}{
+				"provider":  "github",
+				"usernames": []string{"john"},
+				"username":  "{username}",
+				"filter":    map[string]interface{}{"hostname": ".*"},
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("ImportPublicKeys", gomock.Anything, requests.PublicKeyImport{
+						Provider:  "github",
+						Usernames: []string{"john"},
+						Username:  "{username}",
+						Filter:    requests.PublicKeyFilter{Hostname: ".*"},
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+					}).
+					Return(&responses.PublicKeyImport{Results: []responses.PublicKeyImportResult{}}, nil).
+					Once()
+			},
+			expected: Expected{status: http.StatusOK},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/sshkeys/public-keys/import", strings.NewReader(string(jsonData)))
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
 func TestCreatePrivateKey(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -635,3 +749,72 @@ func TestCreatePrivateKey(t *testing.T) {
 		})
 	}
 }
+
+func TestTestPublicKey(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the username is missing",
+			body:           `{"device": "device"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the public key is not found",
+			body:  `{"device": "device", "username": "root"}`,
+			requiredMocks: func() {
+				mock.
+					On("TestPublicKey", gomock.Anything, &requests.PublicKeyTest{
+						FingerprintParam: requests.FingerprintParam{Fingerprint: "fingerprint"},
+						TenantID:         "00000000-0000-4000-0000-000000000000",
+						Device:           "device",
+						Username:         "root",
+					}).
+					Return(nil, svc.ErrNotFound).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			body:  `{"device": "device", "username": "root"}`,
+			requiredMocks: func() {
+				mock.
+					On("TestPublicKey", gomock.Anything, &requests.PublicKeyTest{
+						FingerprintParam: requests.FingerprintParam{Fingerprint: "fingerprint"},
+						TenantID:         "00000000-0000-4000-0000-000000000000",
+						Device:           "device",
+						Username:         "root",
+					}).
+					Return(&responses.PublicKeyTest{Allowed: true}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/sshkeys/public-keys/fingerprint/test", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", authorizer.RoleObserver.String())
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -2,7 +2,6 @@ package routes
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -15,6 +14,11 @@ const (
 	RenameTagURL = "/tags/:tag"
 	// DeleteTagsURL deletes a tag from all collections.
 	DeleteTagsURL = "/tags/:tag"
+
+	ListTagRulesURL  = "/tag-rules"
+	CreateTagRuleURL = "/tag-rules"
+	UpdateTagRuleURL = "/tag-rules/:id"
+	DeleteTagRuleURL = "/tag-rules/:id"
 )
 
 func (h *Handler) GetTags(c gateway.Context) error {
@@ -28,7 +32,7 @@ func (h *Handler) GetTags(c gateway.Context) error {
 		return err
 	}
 
-	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+	setPaginationHeaders(c, nil, count)
 
 	return c.JSON(http.StatusOK, tags)
 }
@@ -76,3 +80,79 @@ func (h *Handler) DeleteTag(c gateway.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+// ListTagRules lists the rules that tag the namespace's devices automatically.
+func (h *Handler) ListTagRules(c gateway.Context) error {
+	req := new(requests.TagRuleList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	rules, err := h.service.ListTagRules(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+func (h *Handler) CreateTagRule(c gateway.Context) error {
+	req := new(requests.TagRuleCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	rule, err := h.service.CreateTagRule(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) UpdateTagRule(c gateway.Context) error {
+	req := new(requests.TagRuleUpdate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	rule, err := h.service.UpdateTagRule(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) DeleteTagRule(c gateway.Context) error {
+	req := new(requests.TagRuleDelete)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteTagRule(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -8,8 +8,11 @@ import (
 	"strings"
 	"testing"
 
+	svc "github.com/shellhub-io/shellhub/api/services"
 	"github.com/shellhub-io/shellhub/api/services/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 	gomock "github.com/stretchr/testify/mock"
 )
@@ -358,3 +361,191 @@ func TestDeleteTag(t *testing.T) {
 
 	svcMock.AssertExpectations(t)
 }
+
+func TestCreateTagRule(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	type Expected struct {
+		status int
+	}
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		body          map[string]interface{}
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when role is operator",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "operator",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"name":       "containers",
+				"tag":        "container",
+				"conditions": []map[string]string{{"attribute": "platform", "operator": "eq", "value": "docker"}},
+			},
+			requiredMocks: func() {},
+			expected: Expected{
+				status: http.StatusForbidden,
+			},
+		},
+		{
+			description: "fails when the rule has no conditions",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"name":       "containers",
+				"tag":        "container",
+				"conditions": []map[string]string{},
+			},
+			requiredMocks: func() {},
+			expected: Expected{
+				status: http.StatusBadRequest,
+			},
+		},
+		{
+			description: "fails when the condition's attribute is unknown",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"name":       "containers",
+				"tag":        "container",
+				"conditions": []map[string]string{{"attribute": "kernel", "operator": "eq", "value": "6.1"}},
+			},
+			requiredMocks: func() {},
+			expected: Expected{
+				status: http.StatusBadRequest,
+			},
+		},
+		{
+			description: "succeeds",
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Tenant-ID":  "00000000-0000-4000-0000-000000000000",
+				"X-Role":       "owner",
+				"X-ID":         "000000000000000000000000",
+			},
+			body: map[string]interface{}{
+				"name":       "containers",
+				"tag":        "container",
+				"conditions": []map[string]string{{"attribute": "platform", "operator": "eq", "value": "docker"}},
+			},
+			requiredMocks: func() {
+				svcMock.
+					On("CreateTagRule", gomock.Anything, &requests.TagRuleCreate{
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Name:       "containers",
+						Tag:        "container",
+						Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"}},
+					}).
+					Return(&models.TagRule{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"}, nil).
+					Once()
+			},
+			expected: Expected{
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/tag-rules", strings.NewReader(string(jsonData)))
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestDeleteTagRule(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when role is operator",
+			role:          "operator",
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "fails when the rule is not found",
+			role:        "administrator",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteTagRule", gomock.Anything, &requests.TagRuleDelete{
+						TagRuleParam: requests.TagRuleParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+						TenantID:     "00000000-0000-4000-0000-000000000000",
+					}).
+					Return(svc.NewErrTagRuleNotFound("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a", nil)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			role:        "owner",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteTagRule", gomock.Anything, &requests.TagRuleDelete{
+						TagRuleParam: requests.TagRuleParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+						TenantID:     "00000000-0000-4000-0000-000000000000",
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/tag-rules/c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a", nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
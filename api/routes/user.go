@@ -13,6 +13,8 @@ const (
 	URLUpdateUser                   = "/users"
 	URLDeprecatedUpdateUser         = "/users/:id/data"
 	URLDeprecatedUpdateUserPassword = "/users/:id/password" //nolint:gosec
+	URLResendUserEmailVerification  = "/user/resend_email"
+	URLVerifyUserEmail              = "/user/validation_account"
 )
 
 const (
@@ -73,3 +75,44 @@ func (h *Handler) UpdateUserPassword(c gateway.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+func (h *Handler) ResendUserEmailVerification(c gateway.Context) error {
+	req := new(requests.ResendUserEmailVerification)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.ResendUserEmailVerification(c.Ctx(), req); err != nil {
+		var e errors.Error
+		if errors.As(err, &e) && e.Layer == services.ErrLayer && e.Code == services.ErrCodeLimit {
+			return c.NoContent(http.StatusTooManyRequests)
+		}
+
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) VerifyUserEmail(c gateway.Context) error {
+	req := new(requests.VerifyUserEmail)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.VerifyUserEmail(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
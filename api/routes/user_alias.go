@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListUserAliasesURL  = "/users/me/aliases"
+	SaveUserAliasURL    = "/users/me/aliases/:name"
+	DeleteUserAliasURL  = "/users/me/aliases/:name"
+	ResolveUserAliasURL = "/users/:username/aliases/:name"
+)
+
+func (h *Handler) ListUserAliases(c gateway.Context) error {
+	req := new(requests.UserAliasList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	aliases, err := h.service.ListUserAliases(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, aliases)
+}
+
+func (h *Handler) SaveUserAlias(c gateway.Context) error {
+	req := new(requests.UserAliasSave)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	alias, err := h.service.SaveUserAlias(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, alias)
+}
+
+func (h *Handler) DeleteUserAlias(c gateway.Context) error {
+	req := new(requests.UserAliasDelete)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteUserAlias(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ResolveUserAlias(c gateway.Context) error {
+	req := new(requests.UserAliasResolve)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	alias, err := h.service.ResolveUserAlias(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, alias)
+}
@@ -0,0 +1,211 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUserAliases(t *testing.T) {
+	type Expected struct {
+		aliases []models.UserAlias
+		status  int
+	}
+
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the user is not found",
+			headers:     map[string]string{"X-ID": "000000000000000000000000"},
+			requiredMocks: func() {
+				svcMock.
+					On("ListUserAliases", gomock.Anything, &requests.UserAliasList{UserID: "000000000000000000000000"}).
+					Return(nil, svc.ErrUserNotFound).
+					Once()
+			},
+			expected: Expected{aliases: nil, status: http.StatusNotFound},
+		},
+		{
+			description: "succeeds",
+			headers:     map[string]string{"X-ID": "000000000000000000000000"},
+			requiredMocks: func() {
+				svcMock.
+					On("ListUserAliases", gomock.Anything, &requests.UserAliasList{UserID: "000000000000000000000000"}).
+					Return([]models.UserAlias{{Name: "web1", Target: "namespace.device"}}, nil).
+					Once()
+			},
+			expected: Expected{
+				aliases: []models.UserAlias{{Name: "web1", Target: "namespace.device"}},
+				status:  http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/users/me/aliases", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			var aliases []models.UserAlias
+			if rec.Result().StatusCode == http.StatusOK {
+				require.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&aliases))
+			}
+
+			assert.Equal(t, tc.expected, Expected{aliases, rec.Result().StatusCode})
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestSaveUserAlias(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		name          string
+		body          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the alias's name is invalid",
+			name:          "web.1",
+			body:          `{"target": "namespace.device"}`,
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description:   "fails when the alias's target is invalid",
+			name:          "web1",
+			body:          `{"target": "device"}`,
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			description: "fails when the user has reached the aliases limit",
+			name:        "web1",
+			body:        `{"target": "namespace.device"}`,
+			requiredMocks: func() {
+				svcMock.
+					On("SaveUserAlias", gomock.Anything, &requests.UserAliasSave{
+						UserID: "000000000000000000000000",
+						Name:   "web1",
+						Target: "namespace.device",
+					}).
+					Return(nil, svc.NewErrUserAliasLimit(svc.UserMaxAliases, nil)).
+					Once()
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			description: "succeeds",
+			name:        "web1",
+			body:        `{"target": "namespace.device"}`,
+			requiredMocks: func() {
+				svcMock.
+					On("SaveUserAlias", gomock.Anything, &requests.UserAliasSave{
+						UserID: "000000000000000000000000",
+						Name:   "web1",
+						Target: "namespace.device",
+					}).
+					Return(&models.UserAlias{Name: "web1", Target: "namespace.device"}, nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/users/me/aliases/"+tc.name, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestDeleteUserAlias(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when the alias is not found",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteUserAlias", gomock.Anything, &requests.UserAliasDelete{UserID: "000000000000000000000000", Name: "web1"}).
+					Return(svc.NewErrUserAliasNotFound("web1", nil)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				svcMock.
+					On("DeleteUserAlias", gomock.Anything, &requests.UserAliasDelete{UserID: "000000000000000000000000", Name: "web1"}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/users/me/aliases/web1", nil)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	SearchUserDevicesURL = "/users/me/devices"
+)
+
+// SearchUserDevices searches, by name, the devices of all the namespaces where the user is a member.
+func (h *Handler) SearchUserDevices(c gateway.Context) error {
+	req := new(requests.UserDeviceSearch)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	devices, count, err := h.service.SearchUserDevices(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	// NOTICE: the devices come from namespaces where the user may have different roles, so each one is masked by the
+	// user's role on its namespace.
+	for i := range devices {
+		maskDevice(devices[i].Role, &devices[i].Device)
+	}
+
+	setPaginationHeaders(c, &req.Paginator, count)
+
+	return c.JSON(http.StatusOK, devices)
+}
@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchUserDevices(t *testing.T) {
+	type Expected struct {
+		devices []responses.UserDevice
+		status  int
+	}
+
+	svcMock := new(mocks.Service)
+
+	device := responses.UserDevice{
+		Device: models.Device{UID: "uid", Name: "device", TenantID: "00000000-0000-4000-0000-000000000000", Namespace: "namespace"},
+		Role:   authorizer.RoleObserver,
+	}
+
+	sensitive := models.Device{
+		UID:              "uid",
+		Name:             "device",
+		TenantID:         "00000000-0000-4000-0000-000000000000",
+		PublicKey:        "public-key",
+		RemoteAddr:       "192.168.0.10",
+		PublicURLAddress: "address",
+	}
+
+	masked := sensitive
+	masked.PublicKey = ""
+	masked.RemoteAddr = ""
+	masked.PublicURLAddress = ""
+
+	cases := []struct {
+		description   string
+		query         string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the name is missing",
+			query:         "",
+			requiredMocks: func() {},
+			expected:      Expected{devices: nil, status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the status is invalid",
+			query:         "?name=device&status=invalid",
+			requiredMocks: func() {},
+			expected:      Expected{devices: nil, status: http.StatusBadRequest},
+		},
+		{
+			description: "succeeds",
+			query:       "?name=device&status=accepted",
+			requiredMocks: func() {
+				svcMock.
+					On("SearchUserDevices", gomock.Anything, &requests.UserDeviceSearch{
+						UserID:    "000000000000000000000000",
+						Name:      "device",
+						Status:    models.DeviceStatusAccepted,
+						Paginator: query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return([]responses.UserDevice{device}, 1, nil).
+					Once()
+			},
+			expected: Expected{devices: []responses.UserDevice{device}, status: http.StatusOK},
+		},
+		{
+			description: "succeeds masking the sensitive details of the devices where the user is an observer",
+			query:       "?name=sensitive",
+			requiredMocks: func() {
+				svcMock.
+					On("SearchUserDevices", gomock.Anything, &requests.UserDeviceSearch{
+						UserID:    "000000000000000000000000",
+						Name:      "sensitive",
+						Paginator: query.Paginator{Page: 1, PerPage: 10},
+					}).
+					Return([]responses.UserDevice{
+						{Device: sensitive, Role: authorizer.RoleObserver},
+						{Device: sensitive, Role: authorizer.RoleOwner},
+					}, 1, nil).
+					Once()
+			},
+			expected: Expected{
+				devices: []responses.UserDevice{
+					{Device: masked, Role: authorizer.RoleObserver},
+					{Device: sensitive, Role: authorizer.RoleOwner},
+				},
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/users/me/devices"+tc.query, nil)
+			req.Header.Set("X-ID", "000000000000000000000000")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expected.status, rec.Result().StatusCode)
+			if tc.expected.status != http.StatusOK {
+				return
+			}
+
+			var devices []responses.UserDevice
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&devices))
+			assert.Equal(t, tc.expected.devices, devices)
+			assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	ListUserSessionsURL   = "/users/me/sessions"
+	RevokeUserSessionURL  = "/users/me/sessions/:id"
+	RevokeUserSessionsURL = "/users/me/sessions"
+)
+
+func (h *Handler) ListUserSessions(c gateway.Context) error {
+	req := new(requests.UserSessionList)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	sessions, err := h.service.ListUserSessions(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, sessions)
+}
+
+func (h *Handler) RevokeUserSession(c gateway.Context) error {
+	req := new(requests.UserSessionRevoke)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.RevokeUserSession(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) RevokeUserSessions(c gateway.Context) error {
+	req := new(requests.UserSessionRevokeAll)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.service.RevokeUserSessions(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
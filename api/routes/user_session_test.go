@@ -0,0 +1,156 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUserSessions(t *testing.T) {
+	type Expected struct {
+		sessions []models.UserSession
+		status   int
+	}
+
+	svcMock := new(mocks.Service)
+
+	session := models.UserSession{
+		ID:         "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+		UserAgent:  "Mozilla/5.0",
+		IP:         "192.168.0.1",
+		CreatedAt:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		LastSeenAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		ExpiresAt:  time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC),
+		Current:    true,
+	}
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the user is not authenticated",
+			headers:       map[string]string{},
+			requiredMocks: func() {},
+			expected:      Expected{sessions: nil, status: http.StatusBadRequest},
+		},
+		{
+			description: "succeeds",
+			headers:     map[string]string{"X-ID": "000000000000000000000000", "X-Session-ID": "6f1b2c3d-0c1b-4d7e-8f3a-000000000001"},
+			requiredMocks: func() {
+				svcMock.
+					On("ListUserSessions", gomock.Anything, &requests.UserSessionList{UserID: "000000000000000000000000", SessionID: "6f1b2c3d-0c1b-4d7e-8f3a-000000000001"}).
+					Return([]models.UserSession{session}, nil).
+					Once()
+			},
+			expected: Expected{sessions: []models.UserSession{session}, status: http.StatusOK},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/users/me/sessions", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			var sessions []models.UserSession
+			if rec.Result().StatusCode == http.StatusOK {
+				require.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&sessions))
+			}
+
+			assert.Equal(t, tc.expected, Expected{sessions, rec.Result().StatusCode})
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestRevokeUserSession(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description: "fails when the session is not found",
+			requiredMocks: func() {
+				svcMock.
+					On("RevokeUserSession", gomock.Anything, &requests.UserSessionRevoke{UserID: "000000000000000000000000", ID: "6f1b2c3d-0c1b-4d7e-8f3a-000000000001"}).
+					Return(svc.NewErrUserSessionNotFound("6f1b2c3d-0c1b-4d7e-8f3a-000000000001", nil)).
+					Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				svcMock.
+					On("RevokeUserSession", gomock.Anything, &requests.UserSessionRevoke{UserID: "000000000000000000000000", ID: "6f1b2c3d-0c1b-4d7e-8f3a-000000000001"}).
+					Return(nil).
+					Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/users/me/sessions/6f1b2c3d-0c1b-4d7e-8f3a-000000000001", nil)
+			req.Header.Set("X-ID", "000000000000000000000000")
+
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	svcMock := new(mocks.Service)
+
+	svcMock.
+		On("RevokeUserSessions", gomock.Anything, &requests.UserSessionRevokeAll{UserID: "000000000000000000000000"}).
+		Return(nil).
+		Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/users/me/sessions", nil)
+	req.Header.Set("X-ID", "000000000000000000000000")
+
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(svcMock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	svcMock.AssertExpectations(t)
+}
@@ -289,3 +289,142 @@ func TestUpdateUserPassword(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestResendUserEmailVerification(t *testing.T) {
+	type Expected struct {
+		status int
+	}
+
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		body          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the identifier is empty",
+			body:          `{"username":""}`,
+			requiredMocks: func() {},
+			expected:      Expected{http.StatusBadRequest},
+		},
+		{
+			description: "fails when the requests limit is reached",
+			body:        `{"username":"john_doe"}`,
+			requiredMocks: func() {
+				svcMock.
+					On("ResendUserEmailVerification", gomock.Anything, &requests.ResendUserEmailVerification{Identifier: "john_doe", IP: "192.168.0.1"}).
+					Return(svc.NewErrUserVerificationLimit(svc.UserEmailVerificationResendLimit)).
+					Once()
+			},
+			expected: Expected{http.StatusTooManyRequests},
+		},
+		{
+			description: "fails when the email verification is disabled",
+			body:        `{"username":"john_doe"}`,
+			requiredMocks: func() {
+				svcMock.
+					On("ResendUserEmailVerification", gomock.Anything, &requests.ResendUserEmailVerification{Identifier: "john_doe", IP: "192.168.0.1"}).
+					Return(svc.ErrUserVerificationDisabled).
+					Once()
+			},
+			expected: Expected{http.StatusForbidden},
+		},
+		{
+			description: "succeeds",
+			body:        `{"username":"john_doe"}`,
+			requiredMocks: func() {
+				svcMock.
+					On("ResendUserEmailVerification", gomock.Anything, &requests.ResendUserEmailVerification{Identifier: "john_doe", IP: "192.168.0.1"}).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{http.StatusOK},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/user/resend_email", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Real-IP", "192.168.0.1")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, Expected{rec.Result().StatusCode})
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
+
+func TestVerifyUserEmail(t *testing.T) {
+	type Expected struct {
+		status int
+	}
+
+	svcMock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		query         string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the email is invalid",
+			query:         "email=invalid.com&token=00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {},
+			expected:      Expected{http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the token is empty",
+			query:         "email=john.doe@test.com",
+			requiredMocks: func() {},
+			expected:      Expected{http.StatusBadRequest},
+		},
+		{
+			description: "fails when the token is not found",
+			query:       "email=john.doe@test.com&token=00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {
+				svcMock.
+					On("VerifyUserEmail", gomock.Anything, &requests.VerifyUserEmail{Email: "john.doe@test.com", Token: "00000000-0000-4000-0000-000000000000"}).
+					Return(svc.ErrUserVerificationNotFound).
+					Once()
+			},
+			expected: Expected{http.StatusNotFound},
+		},
+		{
+			description: "succeeds",
+			query:       "email=john.doe@test.com&token=00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {
+				svcMock.
+					On("VerifyUserEmail", gomock.Anything, &requests.VerifyUserEmail{Email: "john.doe@test.com", Token: "00000000-0000-4000-0000-000000000000"}).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{http.StatusOK},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/user/validation_account?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(svcMock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, Expected{rec.Result().StatusCode})
+		})
+	}
+
+	svcMock.AssertExpectations(t)
+}
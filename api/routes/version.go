@@ -0,0 +1,151 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/responses"
+)
+
+const (
+	ChangelogURL = "/changelog"
+)
+
+const (
+	APIVersionV1 = "v1"
+	APIVersionV2 = "v2"
+)
+
+const (
+	// HeaderAPIVersion is the header with the version of the public API that served the request.
+	HeaderAPIVersion = "X-API-Version"
+	// HeaderDeprecation is the header that flags a route as deprecated, as it is changed on a newer version of the
+	// public API. Its value is the structured date when the route was deprecated, as "@<unix seconds>".
+	//
+	// https://www.rfc-editor.org/rfc/rfc9745
+	HeaderDeprecation = "Deprecation"
+)
+
+// apiVersionV2ReleasedAt is when the version 2 of the public API was released.
+var apiVersionV2ReleasedAt = time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+
+// versionContextKey is the key, on the request's context, of the index on the changelog of the version requested.
+const versionContextKey = "api-version"
+
+// changelog lists the versions of the public API, from the oldest to the newest, with the breaking changes each one
+// introduced. A version serves the same routes of the previous one, except the changed ones, which it refuses. The
+// paths of the changes are the paths of the routes on the first version.
+var changelog = []responses.APIVersion{
+	{
+		Version: APIVersionV1,
+		Prefix:  PublicPrefix,
+		Changes: []responses.APIChange{},
+	},
+	{
+		Version:    APIVersionV2,
+		Prefix:     PublicPrefixV2,
+		ReleasedAt: &apiVersionV2ReleasedAt,
+		Changes: []responses.APIChange{
+			{
+				Method:      http.MethodPost,
+				Path:        PublicPrefix + AuthDeviceURL,
+				Description: "The device's authentication moved to the authentication routes.",
+				Successor:   PublicPrefixV2 + AuthDeviceURLV2,
+			},
+			{
+				Method:      http.MethodPost,
+				Path:        PublicPrefix + AuthLocalUserURL,
+				Description: "The user's authentication moved to the authentication routes.",
+				Successor:   PublicPrefixV2 + AuthLocalUserURLV2,
+			},
+			{
+				Method:      http.MethodPatch,
+				Path:        PublicPrefix + URLDeprecatedUpdateUser,
+				Description: "The user's data is updated on the user's route, without the user's ID.",
+				Successor:   PublicPrefixV2 + URLUpdateUser,
+			},
+			{
+				Method:      http.MethodPatch,
+				Path:        PublicPrefix + URLDeprecatedUpdateUserPassword,
+				Description: "The user's password is updated on the user's route, without the user's ID.",
+				Successor:   PublicPrefixV2 + URLUpdateUser,
+			},
+		},
+	},
+}
+
+// versionRewrite serves the requests to the newer versions of the public API through the routes of the first one,
+// rewriting the request's path to the [PublicPrefix]. The version requested is kept on the request's context, as the
+// routes changed by it are only known after the routing, by [versionEnforce].
+//
+// It must be registered with [echo.Echo.Pre], so it runs before the router.
+func versionRewrite(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+
+		for i := len(changelog) - 1; i > 0; i-- {
+			rest, ok := strings.CutPrefix(req.URL.Path, changelog[i].Prefix)
+			if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+				continue
+			}
+
+			req.URL.Path = PublicPrefix + rest
+			if raw, ok := strings.CutPrefix(req.URL.RawPath, changelog[i].Prefix); ok {
+				req.URL.RawPath = PublicPrefix + raw
+			}
+
+			c.Set(versionContextKey, i)
+
+			break
+		}
+
+		return next(c)
+	}
+}
+
+// versionEnforce refuses the routes changed by the version requested, or by an older one, as not found. The routes
+// changed only by a newer version are served, but flagged as deprecated since the newer version's release, with a link
+// to their successor, if any.
+//
+// It must be registered with [echo.Echo.Use], so it runs after the router has matched the request's route.
+func versionEnforce(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !strings.HasPrefix(c.Path(), PublicPrefix+"/") {
+			return next(c)
+		}
+
+		version, _ := c.Get(versionContextKey).(int)
+
+		header := c.Response().Header()
+		header.Set(HeaderAPIVersion, changelog[version].Version)
+
+		for i, v := range changelog {
+			for _, change := range v.Changes {
+				if change.Method != c.Request().Method || change.Path != c.Path() {
+					continue
+				}
+
+				if i <= version {
+					return echo.ErrNotFound
+				}
+
+				header.Set(HeaderDeprecation, fmt.Sprintf("@%d", v.ReleasedAt.Unix()))
+				header.Add(HeaderLink, fmt.Sprintf(`<%s>; rel="deprecation"`, PublicPrefix+ChangelogURL))
+				if change.Successor != "" {
+					header.Add(HeaderLink, fmt.Sprintf(`<%s>; rel="successor-version"`, change.Successor))
+				}
+			}
+		}
+
+		return next(c)
+	}
+}
+
+// GetChangelog lists the versions of the public API with the breaking changes each one introduced.
+func (h *Handler) GetChangelog(c gateway.Context) error {
+	return c.JSON(http.StatusOK, changelog)
+}
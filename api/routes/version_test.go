@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/responses"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVersions(t *testing.T) {
+	cases := []struct {
+		description   string
+		method        string
+		path          string
+		requiredMocks func(service *mocks.Service)
+		expected      func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			description: "serves the route of the first version through the version 2",
+			method:      http.MethodGet,
+			path:        "/api/v2/info",
+			requiredMocks: func(service *mocks.Service) {
+				service.
+					On("GetSystemInfo", mock.Anything, &requests.GetSystemInfo{Host: "example.com"}).
+					Return(&responses.SystemInfo{Version: "latest"}, nil).
+					Once()
+			},
+			expected: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, APIVersionV2, rec.Header().Get(HeaderAPIVersion))
+				assert.Empty(t, rec.Header().Get(HeaderDeprecation))
+			},
+		},
+		{
+			description: "serves the route of the first version",
+			method:      http.MethodGet,
+			path:        "/api/info",
+			requiredMocks: func(service *mocks.Service) {
+				service.
+					On("GetSystemInfo", mock.Anything, &requests.GetSystemInfo{Host: "example.com"}).
+					Return(&responses.SystemInfo{Version: "latest"}, nil).
+					Once()
+			},
+			expected: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, APIVersionV1, rec.Header().Get(HeaderAPIVersion))
+			},
+		},
+		{
+			description:   "refuses the route changed by the version 2",
+			method:        http.MethodPatch,
+			path:          "/api/v2/users/000000000000000000000000/data",
+			requiredMocks: func(_ *mocks.Service) {},
+			expected: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			description: "flags the route changed by the version 2 as deprecated on the first version",
+			method:      http.MethodPatch,
+			path:        "/api/users/000000000000000000000000/data",
+			requiredMocks: func(service *mocks.Service) {
+				service.
+					On("UpdateUser", mock.Anything, &requests.UpdateUser{UserID: "000000000000000000000000"}).
+					Return(nil, nil).
+					Once()
+			},
+			expected: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, "@1792108800", rec.Header().Get(HeaderDeprecation))
+				assert.Equal(t, []string{
+					`</api/changelog>; rel="deprecation"`,
+					`</api/v2/users>; rel="successor-version"`,
+				}, rec.Header().Values(HeaderLink))
+			},
+		},
+		{
+			description:   "lists the versions on the changelog",
+			method:        http.MethodGet,
+			path:          "/api/v2/changelog",
+			requiredMocks: func(_ *mocks.Service) {},
+			expected: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+
+				var versions []responses.APIVersion
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&versions))
+				assert.Equal(t, changelog, versions)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			service := new(mocks.Service)
+			tc.requiredMocks(service)
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader("{}"))
+			req.Host = "example.com"
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-ID", "000000000000000000000000")
+			req.Header.Set("X-Role", "owner")
+
+			rec := httptest.NewRecorder()
+			NewRouter(service).ServeHTTP(rec, req)
+
+			tc.expected(t, rec)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChangelogReleases(t *testing.T) {
+	// NOTICE: the release of the version deprecates the routes it changed, so every version with changes must have it.
+	for _, v := range changelog {
+		if len(v.Changes) > 0 {
+			assert.NotNil(t, v.ReleasedAt, v.Version)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	CreateWebSessionURL = "/devices/:uid/web-session"
+	RedeemWebSessionURL = "/web-sessions/redeem"
+)
+
+// CreateWebSession mints a single-use token that opens a web terminal on a device without prompting for credentials.
+func (h *Handler) CreateWebSession(c gateway.Context) error {
+	req := new(requests.DeviceCreateWebSession)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	token, err := h.service.CreateWebSession(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, token)
+}
+
+// RedeemWebSession redeems a web session's token on behalf of the SSH server's web bridge.
+func (h *Handler) RedeemWebSession(c gateway.Context) error {
+	req := new(requests.WebSessionRedeem)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	session, err := h.service.RedeemWebSession(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, session)
+}
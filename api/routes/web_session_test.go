@@ -0,0 +1,162 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateWebSession(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           authorizer.Role
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role can't register unrestricted public keys",
+			role:           authorizer.RoleOperator,
+			body:           `{"username": "root"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "fails when the username is missing",
+			role:           authorizer.RoleAdministrator,
+			body:           `{}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the TTL is too long",
+			role:           authorizer.RoleAdministrator,
+			body:           `{"username": "root", "ttl": 301}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the device is not found",
+			role:  authorizer.RoleAdministrator,
+			body:  `{"username": "root"}`,
+			requiredMocks: func() {
+				mock.
+					On("CreateWebSession", gomock.Anything, &requests.DeviceCreateWebSession{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						UserID:      "user-id",
+						Username:    "root",
+					}).
+					Return(nil, svc.NewErrDeviceNotFound(models.UID("1234"), nil)).
+					Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "succeeds",
+			role:  authorizer.RoleAdministrator,
+			body:  `{"username": "root", "ttl": 30}`,
+			requiredMocks: func() {
+				mock.
+					On("CreateWebSession", gomock.Anything, &requests.DeviceCreateWebSession{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						TenantID:    "tenant-id",
+						UserID:      "user-id",
+						Username:    "root",
+						TTL:         30,
+					}).
+					Return(&models.WebSessionToken{Token: "token"}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/1234/web-session", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role.String())
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			req.Header.Set("X-ID", "user-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRedeemWebSession(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the token is missing",
+			body:           `{}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the token was already redeemed",
+			body:  `{"token": "token"}`,
+			requiredMocks: func() {
+				mock.
+					On("RedeemWebSession", gomock.Anything, &requests.WebSessionRedeem{Token: "token"}).
+					Return(nil, svc.NewErrWebSessionInvalid(nil)).
+					Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title: "succeeds",
+			body:  `{"token": "token"}`,
+			requiredMocks: func() {
+				mock.
+					On("RedeemWebSession", gomock.Anything, &requests.WebSessionRedeem{Token: "token"}).
+					Return(&models.WebSession{ID: "id", DeviceUID: "1234", Username: "root"}, nil).
+					Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/web-sessions/redeem", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
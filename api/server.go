@@ -6,19 +6,31 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/shellhub-io/shellhub/api/pkg/deviceuid"
+	"github.com/shellhub-io/shellhub/api/pkg/policyengine"
+	"github.com/shellhub-io/shellhub/api/pkg/queryanalytics"
 	"github.com/shellhub-io/shellhub/api/routes"
 	"github.com/shellhub-io/shellhub/api/services"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo"
 	"github.com/shellhub-io/shellhub/api/store/mongo/options"
+	"github.com/shellhub-io/shellhub/api/store/postgres"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/banlist"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/events"
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
 	"github.com/shellhub-io/shellhub/pkg/geoip/geolite2"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
 	"github.com/shellhub-io/shellhub/pkg/worker/asynq"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	mongooptions "go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var serverCmd = &cobra.Command{
@@ -43,16 +55,57 @@ var serverCmd = &cobra.Command{
 
 		log.Info("Connected to Redis")
 
-		log.Trace("Connecting to MongoDB")
-
-		store, err := mongo.NewStore(ctx, cfg.MongoURI, cache, options.RunMigatrions)
+		cipher, err := fieldcrypt.Load(cfg.FieldEncryptionKey, cfg.FieldEncryptionKeyFile, cfg.FieldEncryptionPreviousKeys)
 		if err != nil {
 			log.
 				WithError(err).
-				Fatal("failed to create the store")
+				Fatal("failed to load the field encryption key")
 		}
 
-		log.Info("Connected to MongoDB")
+		var store store.Store
+		var pool *mongo.PoolMonitor
+
+		switch cfg.Store {
+		case "postgres":
+			log.Trace("Connecting to PostgreSQL")
+
+			store, err = postgres.NewStore(ctx, cfg.PostgresURI, cache, cipher, postgres.RunMigrations)
+			if err != nil {
+				log.
+					WithError(err).
+					Fatal("failed to create the store")
+			}
+
+			log.Info("Connected to PostgreSQL")
+		default:
+			log.Trace("Connecting to MongoDB")
+
+			pool = mongo.NewPoolMonitor(cfg.MongoMaxPoolSize)
+
+			client := mongooptions.Client().
+				SetMaxPoolSize(cfg.MongoMaxPoolSize).
+				SetPoolMonitor(pool.Monitor())
+			if cfg.MongoOperationTimeout > 0 {
+				client.SetTimeout(time.Duration(cfg.MongoOperationTimeout) * time.Millisecond)
+			}
+
+			store, err = mongo.NewStore(ctx, cfg.MongoURI, cache, cipher, client, options.RunMigatrions)
+			if err != nil {
+				log.
+					WithError(err).
+					Fatal("failed to create the store")
+			}
+
+			log.Info("Connected to MongoDB")
+
+			if db, ok := store.(*mongo.Store); ok && cfg.MongoChangeStreams {
+				go func() {
+					if err := mongo.NewCacheInvalidator(db.GetDB(), cache).Run(ctx); err != nil {
+						log.WithError(err).Warn("The cache is only invalidated by the changes made through the API")
+					}
+				}()
+			}
+		}
 
 		go func() {
 			sig := <-sigs
@@ -64,15 +117,31 @@ var serverCmd = &cobra.Command{
 			cancel()
 		}()
 
-		return startServer(ctx, cfg, store, cache)
+		return startServer(ctx, cfg, store, cache, pool)
 	},
 }
 
 // Provides the configuration for the API service.
 // The values are load from the system environment variables.
 type config struct {
+	// Store is the database where the API stores its data, either "mongo" or "postgres".
+	Store string `env:"API_STORE,default=mongo"`
+	// PostgresURI is the PostgreSQL connection string (URI format), used when the store is "postgres".
+	PostgresURI string `env:"POSTGRES_URI,default=postgres://postgres:5432/main?sslmode=disable"`
 	// MongoDB connection string (URI format)
 	MongoURI string `env:"MONGO_URI,default=mongodb://mongo:27017/main"`
+	// MongoMaxPoolSize is the maximum number of connections on the Mongo client's pool. Zero means no limit.
+	MongoMaxPoolSize uint64 `env:"MONGO_MAX_POOL_SIZE,default=100"`
+	// MongoWaitQueueTimeout is how long, in milliseconds, a request waits for a connection when the Mongo client's pool
+	// is saturated, before being refused with a hint of when to try again.
+	MongoWaitQueueTimeout int `env:"MONGO_WAIT_QUEUE_TIMEOUT,default=2000"`
+	// MongoOperationTimeout is how long, in milliseconds, each operation on the database may take, including the wait
+	// for a connection. Zero means no timeout.
+	MongoOperationTimeout int `env:"MONGO_OPERATION_TIMEOUT,default=0"`
+	// MongoChangeStreams enables the invalidation of the cached devices and namespaces as soon as they change on the
+	// database, through Mongo change streams, which require a replica set. Without it, the entries changed outside of
+	// the store's methods, like by the CLI, are served until they expire.
+	MongoChangeStreams bool `env:"MONGO_CHANGE_STREAMS,default=true"`
 	// Redis connection string (URI format)
 	RedisURI string `env:"REDIS_URI,default=redis://redis:6379"`
 	// RedisCachePoolSize is the pool size of connections available for Redis cache.
@@ -113,6 +182,102 @@ type config struct {
 	// downloading the GeoIP database directly from MaxMind. If [GeoipMirror] is not set,
 	// this license key will be used as the fallback method for fetching the database.
 	GeoipMaxmindLicense string `env:"MAXMIND_LICENSE,default="`
+
+	// Domain is the domain of the server. It is used to build the links sent to users by email.
+	Domain string `env:"SHELLHUB_DOMAIN,default=localhost"`
+	// AutoSSL indicates whether the server is exposed through HTTPS. It is used to build the links sent to users by email.
+	AutoSSL bool `env:"SHELLHUB_AUTO_SSL,default=false"`
+
+	// SMTPHost is the SMTP server's hostname used to deliver emails. Required when the email verification is enabled.
+	SMTPHost string `env:"SMTP_HOST,default="`
+	// SMTPPort is the SMTP server's port.
+	SMTPPort int `env:"SMTP_PORT,default=587"`
+	// SMTPUsername is the user used to authenticate on the SMTP server. When empty, no authentication is performed.
+	SMTPUsername string `env:"SMTP_USERNAME,default="`
+	// SMTPPassword is the password used to authenticate on the SMTP server.
+	SMTPPassword string `env:"SMTP_PASSWORD,default="`
+	// SMTPFrom is the sender's address of every email.
+	SMTPFrom string `env:"SMTP_FROM,default="`
+
+	// EmailVerificationExpiration is how long, in hours, an email verification link remains valid.
+	EmailVerificationExpiration int `env:"EMAIL_VERIFICATION_EXPIRATION,default=24"`
+
+	// MaxNamespaceMembers is the default maximum number of members, including the pending invitations, per namespace.
+	// Zero means no limit.
+	MaxNamespaceMembers int `env:"MAX_NAMESPACE_MEMBERS,default=0"`
+
+	// MaxNamespaceInvitations is the default maximum number of pending invitations per namespace. Zero means no limit.
+	MaxNamespaceInvitations int `env:"MAX_NAMESPACE_INVITATIONS,default=0"`
+
+	// DeviceOfflineGracePeriod is how long, in seconds, a device remains online after its connection was closed. A
+	// device reconnecting within it doesn't change its state. Zero sets it offline as soon as the connection is closed.
+	DeviceOfflineGracePeriod int `env:"DEVICE_OFFLINE_GRACE_PERIOD,default=0"`
+
+	// DeviceMinOnlineDuration is the minimum time, in seconds, a device remains online after it got online, suppressing
+	// the state changes of flapping devices. Zero means no minimum.
+	DeviceMinOnlineDuration int `env:"DEVICE_MIN_ONLINE_DURATION,default=0"`
+
+	// AccessTokenTTL is how long, in minutes, a user's access token is valid. Zero keeps the lifetime of the legacy
+	// long-lived tokens, 72 hours, while the clients move to refresh tokens.
+	AccessTokenTTL int `env:"ACCESS_TOKEN_TTL,default=0"`
+
+	// RefreshTokenTTL is how long, in hours, a user's refresh token is valid.
+	RefreshTokenTTL int `env:"REFRESH_TOKEN_TTL,default=720"`
+
+	// DeviceAuthBudget is the maximum number of device authentications handled at the same time. The devices above it
+	// are refused with a hint of when to try again. Zero means no limit.
+	DeviceAuthBudget int `env:"DEVICE_AUTH_BUDGET,default=0"`
+
+	// AddressBanFailures is the number of authentication failures, on the SSH server and on the user's login, that bans
+	// the client's address temporarily. Zero disables the temporary bans.
+	AddressBanFailures int `env:"ADDRESS_BAN_FAILURES,default=20"`
+
+	// AddressBanWindow is how long, in minutes, an address' authentication failure is remembered after its last one.
+	AddressBanWindow int `env:"ADDRESS_BAN_WINDOW,default=10"`
+
+	// AddressBanDuration is how long, in minutes, an address failing to authenticate repeatedly is banned.
+	AddressBanDuration int `env:"ADDRESS_BAN_DURATION,default=60"`
+
+	// DeviceUIDScheme is the scheme the UIDs of the new devices are derived on, "legacy" or "v2". The devices already
+	// registered keep their UIDs when it changes.
+	DeviceUIDScheme string `env:"DEVICE_UID_SCHEME,default=legacy"`
+
+	// QueryAnalyticsSampleRate is the percentage of the devices and sessions listings whose filter fields and sort keys
+	// are recorded to guide the creation of indexes. Zero disables the recording.
+	QueryAnalyticsSampleRate int `env:"QUERY_ANALYTICS_SAMPLE_RATE,default=0"`
+
+	// RecordingS3Endpoint is the URL of the S3-compatible storage where the SSH server keeps the sessions' recordings,
+	// retrieved from it for playback. When empty, the recordings aren't retrieved from an object storage.
+	RecordingS3Endpoint string `env:"RECORDING_S3_ENDPOINT,default="`
+	// RecordingS3Region is the region of the recordings' bucket.
+	RecordingS3Region string `env:"RECORDING_S3_REGION,default=us-east-1"`
+	// RecordingS3Bucket is the bucket where the recordings are kept.
+	RecordingS3Bucket string `env:"RECORDING_S3_BUCKET,default="`
+	// RecordingS3AccessKeyID is the access key used to read the recordings.
+	RecordingS3AccessKeyID string `env:"RECORDING_S3_ACCESS_KEY_ID,default="`
+	// RecordingS3SecretAccessKey is the secret of the access key.
+	RecordingS3SecretAccessKey string `env:"RECORDING_S3_SECRET_ACCESS_KEY,default="`
+	// RecordingS3PathStyle addresses the bucket on the URL's path, as required by MinIO.
+	RecordingS3PathStyle bool `env:"RECORDING_S3_PATH_STYLE,default=false"`
+
+	// PolicyEngineURL is the URL of the Open Policy Agent server where the organization's policies are evaluated before
+	// opening a session or calling a sensitive route. When empty, the policies aren't evaluated.
+	PolicyEngineURL string `env:"POLICY_ENGINE_URL,default="`
+	// PolicyEnginePath is the Rego package evaluated, which must define the "allow" rule and, optionally, the "reason"
+	// rule.
+	PolicyEnginePath string `env:"POLICY_ENGINE_PATH,default=shellhub/authz"`
+	// PolicyEngineTimeout is how long, in milliseconds, an evaluation may take before the action is denied.
+	PolicyEngineTimeout int `env:"POLICY_ENGINE_TIMEOUT,default=1000"`
+
+	// FieldEncryptionKey is the base64 encoded 32 bytes key encrypting, on the database, the users' emails and the
+	// devices' and sessions' IP addresses. When empty, the fields are kept in plain text.
+	FieldEncryptionKey string `env:"FIELD_ENCRYPTION_KEY,default="`
+	// FieldEncryptionKeyFile is the file holding the encryption key, like the ones written by the agents of the key
+	// management services. It is read when FieldEncryptionKey is empty.
+	FieldEncryptionKeyFile string `env:"FIELD_ENCRYPTION_KEY_FILE,default="`
+	// FieldEncryptionPreviousKeys are the comma-separated keys the fields were encrypted with before the current key,
+	// still decrypting them until they are rekeyed.
+	FieldEncryptionPreviousKeys string `env:"FIELD_ENCRYPTION_PREVIOUS_KEYS,default="`
 }
 
 // startSentry initializes the Sentry client.
@@ -141,7 +306,7 @@ func startSentry(dsn string) (*sentry.Client, error) {
 	return nil, errors.New("sentry DSN not provided")
 }
 
-func startServer(ctx context.Context, cfg *config, store store.Store, cache storecache.Cache) error {
+func startServer(ctx context.Context, cfg *config, store store.Store, cache storecache.Cache, pool *mongo.PoolMonitor) error {
 	log.Info("Starting API server")
 
 	apiClient, err := internalclient.NewClient(internalclient.WithAsynqWorker(cfg.RedisURI))
@@ -172,9 +337,119 @@ func startServer(ctx context.Context, cfg *config, store store.Store, cache stor
 		log.Info("GeoIP feature is enable")
 	}
 
+	if envs.HasEmailVerification() {
+		mailer, err := mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+		if err != nil {
+			log.WithError(err).Fatal("Failed to configure the SMTP mailer")
+		}
+
+		scheme := "http"
+		if cfg.AutoSSL {
+			scheme = "https"
+		}
+
+		servicesOptions = append(
+			servicesOptions,
+			services.WithMailer(mailer),
+			services.WithEmailVerification(scheme+"://"+cfg.Domain, time.Duration(cfg.EmailVerificationExpiration)*time.Hour),
+		)
+
+		log.Info("Email verification is enabled")
+	}
+
+	bus, err := events.NewRedisBus(cfg.RedisURI)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to configure the events bus")
+	}
+
+	servicesOptions = append(servicesOptions, services.WithEventBus(bus))
+
+	servicesOptions = append(servicesOptions, services.WithMemberQuota(cfg.MaxNamespaceMembers, cfg.MaxNamespaceInvitations))
+
+	servicesOptions = append(servicesOptions, services.WithDeviceOffline(
+		time.Duration(cfg.DeviceOfflineGracePeriod)*time.Second,
+		time.Duration(cfg.DeviceMinOnlineDuration)*time.Second,
+	))
+
+	servicesOptions = append(servicesOptions, services.WithUserTokens(
+		time.Duration(cfg.AccessTokenTTL)*time.Minute,
+		time.Duration(cfg.RefreshTokenTTL)*time.Hour,
+	))
+
+	servicesOptions = append(servicesOptions, services.WithAddressBan(
+		cfg.AddressBanFailures,
+		time.Duration(cfg.AddressBanWindow)*time.Minute,
+		time.Duration(cfg.AddressBanDuration)*time.Minute,
+	))
+
+	scheme, err := deviceuid.ParseScheme(cfg.DeviceUIDScheme)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to configure the device UID scheme")
+	}
+
+	servicesOptions = append(servicesOptions, services.WithDeviceUIDScheme(scheme))
+	servicesOptions = append(servicesOptions, services.WithQueryAnalytics(queryanalytics.New(cfg.QueryAnalyticsSampleRate)))
+
+	if cfg.RecordingS3Endpoint != "" {
+		storage, err := objectstorage.NewS3Storage(objectstorage.S3Config{
+			Endpoint:        cfg.RecordingS3Endpoint,
+			Region:          cfg.RecordingS3Region,
+			Bucket:          cfg.RecordingS3Bucket,
+			AccessKeyID:     cfg.RecordingS3AccessKeyID,
+			SecretAccessKey: cfg.RecordingS3SecretAccessKey,
+			PathStyle:       cfg.RecordingS3PathStyle,
+		})
+		if err != nil {
+			log.WithError(err).Fatal("Failed to configure the recordings' object storage")
+		}
+
+		servicesOptions = append(servicesOptions, services.WithRecordingStorage(storage))
+
+		log.Info("Session recordings are retrieved from the object storage")
+	}
+
+	var engine policyengine.Engine
+	if cfg.PolicyEngineURL != "" {
+		engine, err = policyengine.NewOPA(policyengine.OPAConfig{
+			Address: cfg.PolicyEngineURL,
+			Path:    cfg.PolicyEnginePath,
+			Timeout: time.Duration(cfg.PolicyEngineTimeout) * time.Millisecond,
+		})
+		if err != nil {
+			log.WithError(err).Fatal("Failed to configure the policy engine")
+		}
+
+		servicesOptions = append(servicesOptions, services.WithPolicyEngine(engine))
+
+		log.Info("Sessions and sensitive routes are evaluated on the policy engine")
+	}
+
 	service := services.NewService(store, nil, nil, cache, apiClient, servicesOptions...)
 
-	routerOptions := []routes.Option{}
+	// NOTICE: the temporary bans expire on the store without notice, so the list is published again on every start.
+	if err := service.PublishBannedAddresses(ctx); err != nil {
+		log.WithError(err).Error("Failed to publish the banned addresses")
+	}
+
+	routerOptions := []routes.Option{
+		routes.WithDeviceAuthBudget(cfg.DeviceAuthBudget),
+		routes.WithBanlist(banlist.New(cache, banlist.DefaultRefreshInterval)),
+	}
+
+	// NOTICE: the backpressure is measured on the Mongo client's pool, so it is only applied on the Mongo store.
+	if pool != nil {
+		routerOptions = append(routerOptions, routes.WithStoreBackpressure(pool, time.Duration(cfg.MongoWaitQueueTimeout)*time.Millisecond))
+	}
+
+	if engine != nil {
+		routerOptions = append(routerOptions, routes.WithPolicyEngine(engine))
+	}
 
 	if cfg.SentryDSN != "" {
 		log.Info("Sentry report is enabled")
@@ -196,6 +471,9 @@ func startServer(ctx context.Context, cfg *config, store store.Store, cache stor
 	)
 
 	worker.HandleTask(services.TaskDevicesHeartbeat, service.DevicesHeartbeat(), asynq.BatchTask())
+	worker.HandleTask(services.TaskTagRulesEvaluate, service.TagRulesEvaluate())
+	worker.HandleTask(services.TaskJobsRun, service.JobsRun())
+	worker.HandleCron(services.CronDevicesOffline, service.DevicesOffline(), asynq.Unique())
 
 	if err := worker.Start(); err != nil {
 		log.WithError(err).
@@ -15,7 +15,7 @@ import (
 
 type APIKeyService interface {
 	// CreateAPIKey creates a new API key for the specified namespace. If req.Key is empty it will generate a
-	// random UUID, the optional req.OptRole must be less or equal than the user's role when provided. The key
+	// random UUID prefixed by [models.APIKeyPrefix], the optional req.OptRole must be less or equal than the user's role when provided. The key
 	// will be hashed into an SHA256 hash. It returns the inserted UUID and an error, if any.
 	CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey) (res *responses.CreateAPIKey, err error)
 
@@ -48,7 +48,7 @@ func (s *service) CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey)
 	}
 
 	if req.Key == "" {
-		req.Key = uuid.Generate()
+		req.Key = models.APIKeyPrefix + uuid.Generate()
 	}
 
 	if req.OptRole != "" {
@@ -84,6 +84,10 @@ func (s *service) CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey)
 		return nil, err
 	}
 
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionAPIKeyCreate, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: req.Name}, map[string]string{"role": req.Role.String()}); err != nil {
+		return nil, err
+	}
+
 	// As we need to return the plain key in the create service, we temporarily set
 	// the apiKey.ID to the plain key here.
 	apiKey, _ := s.store.APIKeyGet(ctx, hashedKey)
@@ -118,7 +122,16 @@ func (s *service) UpdateAPIKey(ctx context.Context, req *requests.UpdateAPIKey)
 		return NewErrAPIKeyNotFound(req.CurrentName, err)
 	}
 
-	return nil
+	details := map[string]string{}
+	if req.Name != "" {
+		details["name"] = req.Name
+	}
+
+	if req.Role != "" {
+		details["role"] = req.Role.String()
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionAPIKeyUpdate, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: req.CurrentName}, details)
 }
 
 func (s *service) DeleteAPIKey(ctx context.Context, req *requests.DeleteAPIKey) error {
@@ -126,5 +139,5 @@ func (s *service) DeleteAPIKey(ctx context.Context, req *requests.DeleteAPIKey)
 		return NewErrAPIKeyNotFound(req.Name, err)
 	}
 
-	return nil
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionAPIKeyDelete, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: req.Name}, nil)
 }
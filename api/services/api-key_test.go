@@ -17,6 +17,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/uuid"
 	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -284,6 +285,12 @@ func TestCreateAPIKey(t *testing.T) {
 						ExpiresIn: -1,
 					}, nil).
 					Once()
+
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &responses.CreateAPIKey{
@@ -331,9 +338,9 @@ func TestCreateAPIKey(t *testing.T) {
 				uuidMock.
 					On("Generate").
 					Return("1e7b0f4b-aca4-48eb-a353-7469f00665ed").
-					Once()
+					Twice()
 
-				keySum := sha256.Sum256([]byte("1e7b0f4b-aca4-48eb-a353-7469f00665ed"))
+				keySum := sha256.Sum256([]byte("sk_1e7b0f4b-aca4-48eb-a353-7469f00665ed"))
 				hashedKey := hex.EncodeToString(keySum[:])
 
 				storeMock.
@@ -362,10 +369,16 @@ func TestCreateAPIKey(t *testing.T) {
 						ExpiresIn: -1,
 					}, nil).
 					Once()
+
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &responses.CreateAPIKey{
-					ID:        "1e7b0f4b-aca4-48eb-a353-7469f00665ed",
+					ID:        "sk_1e7b0f4b-aca4-48eb-a353-7469f00665ed",
 					Name:      "dev",
 					UserID:    "000000000000000000000000",
 					TenantID:  "00000000-0000-4000-0000-000000000000",
@@ -484,6 +497,11 @@ func TestListAPIKey(t *testing.T) {
 }
 
 func TestUpdateAPIKey(t *testing.T) {
+	backend := uuid.DefaultBackend
+	uuidMock := &uuidmock.Uuid{}
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
 	storeMock := new(storemock.Store)
 
 	cases := []struct {
@@ -594,6 +612,13 @@ func TestUpdateAPIKey(t *testing.T) {
 					On("APIKeyUpdate", ctx, "00000000-0000-4000-0000-000000000000", "dev", &models.APIKeyChanges{Name: "newName", Role: "administrator"}).
 					Return(nil).
 					Once()
+
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000000").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -618,6 +643,11 @@ func TestUpdateAPIKey(t *testing.T) {
 }
 
 func TestDeleteAPIKey(t *testing.T) {
+	backend := uuid.DefaultBackend
+	uuidMock := &uuidmock.Uuid{}
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
 	storeMock := new(storemock.Store)
 
 	cases := []struct {
@@ -652,6 +682,13 @@ func TestDeleteAPIKey(t *testing.T) {
 					On("APIKeyDelete", ctx, "00000000-0000-4000-0000-000000000000", "dev").
 					Return(nil).
 					Once()
+
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000000").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type AuditService interface {
+	// ListAuditEntries retrieves a list of the entries of the tenant's audit trail selected by the request's filter,
+	// most recent first. It returns the list of entries, the total count of matched documents and an error if any.
+	ListAuditEntries(ctx context.Context, req *requests.AuditEntriesList) (entries []models.AuditEntry, count int, err error)
+
+	// ExportAuditEntries calls fn with each one of the entries of the tenant's audit trail selected by the request's
+	// filter, most recent first, stopping on the first error returned by it. The entries created after the export
+	// has started aren't exported.
+	ExportAuditEntries(ctx context.Context, req *requests.AuditEntriesExport, fn func(entry *models.AuditEntry) error) (err error)
+}
+
+func (s *service) ListAuditEntries(ctx context.Context, req *requests.AuditEntriesList) ([]models.AuditEntry, int, error) {
+	return s.store.AuditEntryList(ctx, req.TenantID, auditEntryFilter(req.AuditEntryFilter), req.Paginator)
+}
+
+func (s *service) ExportAuditEntries(ctx context.Context, req *requests.AuditEntriesExport, fn func(entry *models.AuditEntry) error) error {
+	filter := auditEntryFilter(req.AuditEntryFilter)
+	// NOTICE: the entries are read page by page, most recent first, so the ones created while exporting would shift
+	// the pages.
+	if filter.To == nil {
+		now := clock.Now()
+		filter.To = &now
+	}
+
+	paginator := query.Paginator{Page: query.MinPage, PerPage: query.MaxPerPage}
+	for {
+		entries, count, err := s.store.AuditEntryList(ctx, req.TenantID, filter, paginator)
+		if err != nil {
+			return err
+		}
+
+		for i := range entries {
+			if err := fn(&entries[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(entries) == 0 || paginator.Page >= paginator.LastPage(count) {
+			return nil
+		}
+
+		paginator.Page++
+	}
+}
+
+// auditEntryFilter converts the filter received on the request to the store's one.
+func auditEntryFilter(req requests.AuditEntryFilter) models.AuditEntryFilter {
+	filter := models.AuditEntryFilter{
+		Action:     models.AuditAction(req.Action),
+		ActorID:    req.ActorID,
+		TargetType: models.AuditTargetType(req.TargetType),
+		TargetID:   req.TargetID,
+	}
+
+	if !req.From.IsZero() {
+		filter.From = &req.From
+	}
+
+	if !req.To.IsZero() {
+		filter.To = &req.To
+	}
+
+	return filter
+}
+
+// recordAudit appends the action, executed on the target of the tenant's namespace, to the namespace's audit trail,
+// with the actor and the source address of the request that executed it. It returns an error when the entry couldn't
+// be appended, so the action isn't reported as succeeded without being audited, although it was already executed.
+func (s *service) recordAudit(ctx context.Context, tenantID string, action models.AuditAction, target models.AuditTarget, details map[string]string) error {
+	entry := &models.AuditEntry{
+		ID:        uuid.Generate(),
+		TenantID:  tenantID,
+		Actor:     auditActor(ctx),
+		Action:    action,
+		Target:    target,
+		Details:   details,
+		SourceIP:  gateway.SourceIPFromContext(ctx),
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.AuditEntryCreate(ctx, entry); err != nil {
+		log.WithContext(ctx).WithError(err).WithFields(log.Fields{
+			"tenant_id": tenantID,
+			"action":    action,
+			"target":    target.ID,
+		}).Error("unable to record the audit entry")
+
+		return err
+	}
+
+	return nil
+}
+
+// auditActor returns who has requested the action on the context: the user or the API key the request was
+// authenticated by. When the context isn't a request's, like the jobs' ones, the actor is the system.
+func auditActor(ctx context.Context) models.AuditActor {
+	// NOTICE: the API key itself is a credential, so it is identified by its digest, the same one the key is stored by.
+	if key := gateway.APIKeyFromContext(ctx); key != "" {
+		sum := sha256.Sum256([]byte(key))
+
+		return models.AuditActor{Type: models.AuditActorAPIKey, ID: hex.EncodeToString(sum[:])}
+	}
+
+	if id := gateway.IDFromContext(ctx); id != nil {
+		actor := models.AuditActor{Type: models.AuditActorUser, ID: id.ID}
+		if username := gateway.UsernameFromContext(ctx); username != nil {
+			actor.Username = username.ID
+		}
+
+		return actor
+	}
+
+	return models.AuditActor{Type: models.AuditActorSystem}
+}
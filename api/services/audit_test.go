@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAuditEntries(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type Expected struct {
+		entries []models.AuditEntry
+		count   int
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.AuditEntriesList
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the store fails to list the entries",
+			req: &requests.AuditEntriesList{
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				Paginator: query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("AuditEntryList", context.TODO(), "00000000-0000-4000-0000-000000000000", models.AuditEntryFilter{}, query.Paginator{Page: 1, PerPage: 10}).
+					Return(nil, 0, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{nil, 0, errors.New("error", "", 0)},
+		},
+		{
+			description: "succeeds converting the request's filter",
+			req: &requests.AuditEntriesList{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				AuditEntryFilter: requests.AuditEntryFilter{
+					Action:     "device.remove",
+					TargetType: "device",
+					TargetID:   "uid",
+					From:       from,
+				},
+				Paginator: query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("AuditEntryList", context.TODO(), "00000000-0000-4000-0000-000000000000", models.AuditEntryFilter{
+						Action:     models.AuditActionDeviceRemove,
+						TargetType: models.AuditTargetDevice,
+						TargetID:   "uid",
+						From:       &from,
+					}, query.Paginator{Page: 1, PerPage: 10}).
+					Return([]models.AuditEntry{{ID: "00000000-0000-4000-0000-000000000001"}}, 1, nil).
+					Once()
+			},
+			expected: Expected{[]models.AuditEntry{{ID: "00000000-0000-4000-0000-000000000001"}}, 1, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			entries, count, err := s.ListAuditEntries(context.TODO(), tc.req)
+			assert.Equal(t, tc.expected, Expected{entries, count, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestExportAuditEntries(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	entries := make([]models.AuditEntry, query.MaxPerPage)
+	for i := range entries {
+		entries[i] = models.AuditEntry{Action: models.AuditActionDeviceRename}
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		exported      int
+		expected      error
+	}{
+		{
+			description: "fails when the store fails to list the entries",
+			requiredMocks: func() {
+				storeMock.
+					On("AuditEntryList", context.TODO(), "00000000-0000-4000-0000-000000000000", models.AuditEntryFilter{To: &now}, query.Paginator{Page: 1, PerPage: query.MaxPerPage}).
+					Return(nil, 0, errors.New("error", "", 0)).
+					Once()
+			},
+			exported: 0,
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "succeeds exporting every page",
+			requiredMocks: func() {
+				storeMock.
+					On("AuditEntryList", context.TODO(), "00000000-0000-4000-0000-000000000000", models.AuditEntryFilter{To: &now}, query.Paginator{Page: 1, PerPage: query.MaxPerPage}).
+					Return(entries, query.MaxPerPage+1, nil).
+					Once()
+				storeMock.
+					On("AuditEntryList", context.TODO(), "00000000-0000-4000-0000-000000000000", models.AuditEntryFilter{To: &now}, query.Paginator{Page: 2, PerPage: query.MaxPerPage}).
+					Return(entries[:1], query.MaxPerPage+1, nil).
+					Once()
+			},
+			exported: query.MaxPerPage + 1,
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			exported := 0
+			err := s.ExportAuditEntries(context.TODO(), &requests.AuditEntriesExport{TenantID: "00000000-0000-4000-0000-000000000000"}, func(*models.AuditEntry) error {
+				exported++
+
+				return nil
+			})
+			assert.Equal(t, tc.expected, err)
+			assert.Equal(t, tc.exported, exported)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRecordAudit(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	keySum := sha256.Sum256([]byte("cdfd3cb0-c44e-4e54-b931-6d57713ad159"))
+
+	cases := []struct {
+		description string
+		headers     map[string]string
+		expected    *models.AuditEntry
+	}{
+		{
+			description: "records the system as actor out of a request",
+			headers:     nil,
+			expected: &models.AuditEntry{
+				ID:        "00000000-0000-4000-0000-000000000001",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				Actor:     models.AuditActor{Type: models.AuditActorSystem},
+				Action:    models.AuditActionDeviceRemove,
+				Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+				CreatedAt: now,
+			},
+		},
+		{
+			description: "records the user as actor",
+			headers: map[string]string{
+				"X-ID":        "000000000000000000000000",
+				"X-Username":  "john_doe",
+				"X-Real-Ip":   "203.0.113.10",
+				"X-Tenant-ID": "00000000-0000-4000-0000-000000000000",
+			},
+			expected: &models.AuditEntry{
+				ID:        "00000000-0000-4000-0000-000000000001",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				Actor:     models.AuditActor{Type: models.AuditActorUser, ID: "000000000000000000000000", Username: "john_doe"},
+				Action:    models.AuditActionDeviceRemove,
+				Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+				SourceIP:  "203.0.113.10",
+				CreatedAt: now,
+			},
+		},
+		{
+			description: "records the API key's digest as actor",
+			headers: map[string]string{
+				"X-API-KEY":   "cdfd3cb0-c44e-4e54-b931-6d57713ad159",
+				"X-Real-Ip":   "203.0.113.10",
+				"X-Tenant-ID": "00000000-0000-4000-0000-000000000000",
+			},
+			expected: &models.AuditEntry{
+				ID:        "00000000-0000-4000-0000-000000000001",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				Actor:     models.AuditActor{Type: models.AuditActorAPIKey, ID: hex.EncodeToString(keySum[:])},
+				Action:    models.AuditActionDeviceRemove,
+				Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+				SourceIP:  "203.0.113.10",
+				CreatedAt: now,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.TODO()
+			if tc.headers != nil {
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+				for k, v := range tc.headers {
+					req.Header.Set(k, v)
+				}
+
+				c := echo.New().NewContext(req, httptest.NewRecorder())
+				ctx = context.WithValue(ctx, "ctx", gateway.NewContext(nil, c)) // nolint:revive,staticcheck
+			}
+
+			uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+			storeMock.On("AuditEntryCreate", ctx, tc.expected).Return(nil).Once()
+
+			err := s.recordAudit(ctx, "00000000-0000-4000-0000-000000000000", models.AuditActionDeviceRemove, models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"}, nil)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("fails when the entry cannot be appended", func(t *testing.T) {
+		ctx := context.TODO()
+
+		uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+		storeMock.On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).Return(errors.New("error", "", 0)).Once()
+
+		err := s.recordAudit(ctx, "00000000-0000-4000-0000-000000000000", models.AuditActionDeviceRemove, models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"}, nil)
+		assert.Equal(t, errors.New("error", "", 0), err)
+	})
+
+	storeMock.AssertExpectations(t)
+}
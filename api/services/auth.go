@@ -15,7 +15,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/cnf/structhash"
+	"github.com/shellhub-io/shellhub/api/pkg/deviceuid"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -66,6 +66,17 @@ type AuthService interface {
 }
 
 func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remoteAddr string) (*models.DeviceAuthResponse, error) {
+	var enrollToken *models.EnrollToken
+	if req.TenantID == "" {
+		token, err := s.resolveEnrollToken(ctx, req.EnrollToken)
+		if err != nil {
+			return nil, err
+		}
+
+		enrollToken = token
+		req.TenantID = token.TenantID
+	}
+
 	var identity *models.DeviceIdentity
 	if req.Identity != nil {
 		identity = &models.DeviceIdentity{
@@ -79,8 +90,7 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 		TenantID:  req.TenantID,
 	}
 
-	uid := sha256.Sum256(structhash.Dump(auth, 1))
-	key := hex.EncodeToString(uid[:])
+	key := s.deriveDeviceUID(ctx, auth)
 
 	claims := authorizer.DeviceClaims{
 		UID:      key,
@@ -93,29 +103,31 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	}
 
 	type Device struct {
-		Name      string
-		Namespace string
+		Name         string
+		Namespace    string
+		RemoteAccess bool
+		Config       *models.DeviceConfig
+		// UserProvisioning is the namespace's user provisioning, when it is allowed.
+		UserProvisioning *models.UserProvisioning
 	}
 
 	var value *Device
 
 	if err := s.cache.Get(ctx, strings.Join([]string{"auth_device", key}, "/"), &value); err == nil && value != nil {
 		return &models.DeviceAuthResponse{
-			UID:       key,
-			Token:     token,
-			Name:      value.Name,
-			Namespace: value.Namespace,
+			UID:              key,
+			Token:            token,
+			TenantID:         req.TenantID,
+			Name:             value.Name,
+			Namespace:        value.Namespace,
+			RemoteAccess:     value.RemoteAccess,
+			Config:           value.Config,
+			UserProvisioning: value.UserProvisioning,
 		}, nil
 	}
-	var info *models.DeviceInfo
-	if req.Info != nil {
-		info = &models.DeviceInfo{
-			ID:         req.Info.ID,
-			PrettyName: req.Info.PrettyName,
-			Version:    req.Info.Version,
-			Arch:       req.Info.Arch,
-			Platform:   req.Info.Platform,
-		}
+	info, err := s.deviceAuthInfo(ctx, models.UID(key), req)
+	if err != nil {
+		return nil, err
 	}
 
 	position, err := s.locator.GetPosition(net.ParseIP(remoteAddr))
@@ -128,13 +140,13 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 		Identity:   identity,
 		Info:       info,
 		PublicKey:  req.PublicKey,
+		ClaimCode:  models.NewDeviceClaimCode(req.PublicKey),
 		TenantID:   req.TenantID,
 		LastSeen:   clock.Now(),
 		RemoteAddr: remoteAddr,
-		Position: &models.DevicePosition{
-			Longitude: position.Longitude,
-			Latitude:  position.Latitude,
-		},
+		Position:   models.NewDevicePosition(position.Latitude, position.Longitude),
+		// NOTICE: agents that don't apply the device's configuration report no version, which keeps the stored one.
+		ConfigVersion: req.ConfigVersion,
 	}
 
 	// The order here is critical as we don't want to register devices if the tenant id is invalid
@@ -143,6 +155,28 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 		return nil, NewErrNamespaceNotFound(device.TenantID, err)
 	}
 
+	if namespace.Settings != nil && namespace.Settings.DeviceQuarantine {
+		if err := s.checkDeviceQuarantine(ctx, namespace, &device, req.Hostname); err != nil {
+			return nil, err
+		}
+	}
+
+	if namespace.Settings != nil && namespace.Settings.DeviceKeyPinning {
+		if err := s.checkDeviceKeyPinning(ctx, &device); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.checkPendingDevicesLimit(ctx, namespace, &device); err != nil {
+		return nil, err
+	}
+
+	if enrollToken != nil {
+		if err := s.useEnrollToken(ctx, enrollToken, device.UID); err != nil {
+			return nil, err
+		}
+	}
+
 	hostname := strings.ToLower(req.Hostname)
 
 	if err := s.store.DeviceCreate(ctx, device, hostname); err != nil {
@@ -159,15 +193,88 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	if err != nil {
 		return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
 	}
-	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name}, time.Second*30); err != nil {
+
+	// NOTICE: the device is created with the time it was first seen, so a device created by this authorization wasn't
+	// created before it was seen, on the precision of the stored times.
+	if !dev.CreatedAt.Before(device.LastSeen.Truncate(time.Millisecond)) {
+		s.publishDeviceEvent(ctx, dev.TenantID, dev.UID, models.DeviceEventCreated)
+	}
+
+	s.recordDeviceAddress(ctx, namespace, dev, remoteAddr)
+	s.recordDeviceConnection(ctx, dev, req.Connection)
+	s.recordDeviceSnapshot(ctx, dev)
+	s.applyTagRules(ctx, dev)
+
+	var provisioning *models.UserProvisioning
+	if namespace.Settings != nil && namespace.Settings.UserProvisioning.Allowed() {
+		provisioning = namespace.Settings.UserProvisioning
+	}
+
+	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name, RemoteAccess: dev.RemoteAccess, Config: dev.Config, UserProvisioning: provisioning}, time.Second*30); err != nil {
 		return nil, err
 	}
 
 	return &models.DeviceAuthResponse{
-		UID:       key,
-		Token:     token,
-		Name:      dev.Name,
-		Namespace: namespace.Name,
+		UID:              key,
+		Token:            token,
+		TenantID:         req.TenantID,
+		Name:             dev.Name,
+		Namespace:        namespace.Name,
+		RemoteAccess:     dev.RemoteAccess,
+		Config:           dev.Config,
+		UserProvisioning: provisioning,
+	}, nil
+}
+
+// deriveDeviceUID derives the UID of the device authorizing on the service's scheme. As the UIDs derived on the
+// [deviceuid.SchemeLegacy] aren't migrated, a device already registered with its legacy UID keeps it.
+func (s *service) deriveDeviceUID(ctx context.Context, auth models.DeviceAuth) string {
+	uid := deviceuid.Derive(s.deviceUIDScheme, auth)
+	if s.deviceUIDScheme == deviceuid.SchemeLegacy {
+		return uid
+	}
+
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(uid), auth.TenantID); err == nil {
+		return uid
+	}
+
+	legacy := deviceuid.Derive(deviceuid.SchemeLegacy, auth)
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(legacy), auth.TenantID); err == nil {
+		return legacy
+	}
+
+	return uid
+}
+
+// deviceAuthInfo returns the information reported by the device on its authorization. When the device reports only
+// the hash of its information, the stored information is kept, as long as its hash matches the reported one;
+// otherwise, the device is required to report the whole information again.
+func (s *service) deviceAuthInfo(ctx context.Context, uid models.UID, req requests.DeviceAuth) (*models.DeviceInfo, error) {
+	if req.Info == nil {
+		device, err := s.store.DeviceGetByUID(ctx, uid, req.TenantID)
+		if err != nil {
+			return nil, NewErrDeviceInfoHash(req.InfoHash, err)
+		}
+
+		if device.Info == nil || device.Info.Hash() != req.InfoHash {
+			return nil, NewErrDeviceInfoHash(req.InfoHash, nil)
+		}
+
+		return device.Info, nil
+	}
+
+	return &models.DeviceInfo{
+		ID:         req.Info.ID,
+		PrettyName: req.Info.PrettyName,
+		Version:    req.Info.Version,
+		Arch:       req.Info.Arch,
+		Platform:   req.Info.Platform,
+		ClockSkew:  req.Info.ClockSkew,
+		// NOTICE: the flag is stored, instead of the threshold being applied on the query, so the unsynchronized
+		// devices can be listed through the generic filters.
+		ClockUnsynchronized: models.IsClockSkewed(time.Duration(req.Info.ClockSkew) * time.Second),
+		Hardware:            req.Info.Hardware,
+		Interfaces:          req.Info.Interfaces,
 	}, nil
 }
 
@@ -219,18 +326,24 @@ func (s *service) AuthLocalUser(ctx context.Context, req *requests.AuthLocalUser
 	if !user.Password.Compare(req.Password) {
 		lockout, _, err := s.cache.StoreLoginAttempt(ctx, sourceIP, user.ID)
 		if err != nil {
-			log.WithError(err).
+			log.WithContext(ctx).WithError(err).
 				WithField("source_ip", sourceIP).
 				WithField("user_id", user.ID).
 				Warn("unable to store login attempt")
 		}
 
+		if err := s.RecordAddressFailure(ctx, sourceIP); err != nil {
+			log.WithContext(ctx).WithError(err).
+				WithField("source_ip", sourceIP).
+				Warn("unable to record the address' authentication failure")
+		}
+
 		return nil, lockout, "", NewErrAuthUnathorized(nil)
 	}
 
 	// Reset the attempt and timeout values when succeeds
 	if err := s.cache.ResetLoginAttempts(ctx, sourceIP, user.ID); err != nil {
-		log.WithError(err).
+		log.WithContext(ctx).WithError(err).
 			WithField("source_ip", sourceIP).
 			WithField("user_id", user.ID).
 			Warn("unable to reset authentication attempts")
@@ -240,7 +353,7 @@ func (s *service) AuthLocalUser(ctx context.Context, req *requests.AuthLocalUser
 	if user.MFA.Enabled {
 		mfaToken := uuid.Generate()
 		if err := s.cache.Set(ctx, "mfa-token={"+mfaToken+"}", user.ID, 30*time.Minute); err != nil {
-			log.WithError(err).
+			log.WithContext(ctx).WithError(err).
 				WithField("source_ip", sourceIP).
 				WithField("user_id", user.ID).
 				Warn("unable to store mfa-token")
@@ -261,14 +374,15 @@ func (s *service) AuthLocalUser(ctx context.Context, req *requests.AuthLocalUser
 	}
 
 	claims := authorizer.UserClaims{
-		ID:       user.ID,
-		Origin:   user.Origin.String(),
-		TenantID: tenantID,
-		Username: user.Username,
-		MFA:      user.MFA.Enabled,
+		ID:        user.ID,
+		Origin:    user.Origin.String(),
+		TenantID:  tenantID,
+		Username:  user.Username,
+		MFA:       user.MFA.Enabled,
+		SessionID: uuid.Generate(),
 	}
 
-	token, err := jwttoken.EncodeUserClaims(claims, s.privKey)
+	token, err := jwttoken.EncodeUserClaimsWithTTL(claims, s.tokens.access, s.privKey)
 	if err != nil {
 		return nil, 0, "", NewErrTokenSigned(err)
 	}
@@ -286,8 +400,13 @@ func (s *service) AuthLocalUser(ctx context.Context, req *requests.AuthLocalUser
 		return nil, 0, "", NewErrUserUpdate(user, err)
 	}
 
+	refreshToken, err := s.saveUserSession(ctx, user.ID, claims.SessionID, req.UserAgent, sourceIP, "")
+	if err != nil {
+		return nil, 0, "", err
+	}
+
 	if err := s.AuthCacheToken(ctx, tenantID, user.ID, token); err != nil {
-		log.WithError(err).
+		log.WithContext(ctx).WithError(err).
 			WithFields(log.Fields{"id": user.ID}).
 			Warn("unable to cache the authentication token")
 	}
@@ -305,12 +424,19 @@ func (s *service) AuthLocalUser(ctx context.Context, req *requests.AuthLocalUser
 		Role:          role,
 		Token:         token,
 		MaxNamespaces: user.MaxNamespaces,
+		RefreshToken:  refreshToken,
 	}
 
 	return res, 0, "", nil
 }
 
 func (s *service) CreateUserToken(ctx context.Context, req *requests.CreateUserToken) (*models.UserAuthResponse, error) {
+	return s.createUserToken(ctx, req, "")
+}
+
+// createUserToken creates the token requested and, when previous is set, rotates the session's refresh token only
+// while it is still previous, as [service.saveUserSession].
+func (s *service) createUserToken(ctx context.Context, req *requests.CreateUserToken, previous string) (*models.UserAuthResponse, error) {
 	user, _, err := s.store.UserGetByID(ctx, req.UserID, false)
 	if err != nil {
 		return nil, NewErrUserNotFound(req.UserID, err)
@@ -359,21 +485,33 @@ func (s *service) CreateUserToken(ctx context.Context, req *requests.CreateUserT
 		}
 	}
 
+	// NOTICE: a token requested with another one, like when switching namespaces, is kept on the same session.
+	sessionID := req.SessionID
+	if sessionID == "" {
+		sessionID = uuid.Generate()
+	}
+
 	claims := authorizer.UserClaims{
-		ID:       user.ID,
-		Origin:   user.Origin.String(),
-		TenantID: tenantID,
-		Username: user.Username,
-		MFA:      user.MFA.Enabled,
+		ID:        user.ID,
+		Origin:    user.Origin.String(),
+		TenantID:  tenantID,
+		Username:  user.Username,
+		MFA:       user.MFA.Enabled,
+		SessionID: sessionID,
 	}
 
-	token, err := jwttoken.EncodeUserClaims(claims, s.privKey)
+	token, err := jwttoken.EncodeUserClaimsWithTTL(claims, s.tokens.access, s.privKey)
 	if err != nil {
 		return nil, NewErrTokenSigned(err)
 	}
 
+	refreshToken, err := s.saveUserSession(ctx, user.ID, sessionID, req.UserAgent, req.IP, previous)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.AuthCacheToken(ctx, tenantID, user.ID, token); err != nil {
-		log.WithError(err).Warn("unable to cache the user's auth token")
+		log.WithContext(ctx).WithError(err).Warn("unable to cache the user's auth token")
 	}
 
 	return &models.UserAuthResponse{
@@ -389,6 +527,7 @@ func (s *service) CreateUserToken(ctx context.Context, req *requests.CreateUserT
 		Role:          role,
 		Token:         token,
 		MaxNamespaces: user.MaxNamespaces,
+		RefreshToken:  refreshToken,
 	}, nil
 }
 
@@ -413,7 +552,7 @@ func (s *service) AuthAPIKey(ctx context.Context, key string) (*models.APIKey, e
 	}
 
 	if err := s.cache.Set(ctx, "api-key={"+key+"}", apiKey, 2*time.Minute); err != nil {
-		log.WithError(err).Info("Unable to set the api-key in cache")
+		log.WithContext(ctx).WithError(err).Info("Unable to set the api-key in cache")
 	}
 
 	return apiKey, nil
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/cnf/structhash"
+	"github.com/shellhub-io/shellhub/api/pkg/deviceuid"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -41,6 +42,10 @@ func TestAuthDevice(t *testing.T) {
 			MAC: "mac",
 		},
 		Sessions: []string{"session"},
+		Info: &requests.DeviceInfo{
+			ID:        "debian",
+			ClockSkew: 120,
+		},
 	}
 
 	auth := models.DeviceAuth{
@@ -57,16 +62,19 @@ func TestAuthDevice(t *testing.T) {
 		Identity: &models.DeviceIdentity{
 			MAC: authReq.Identity.MAC,
 		},
+		Info: &models.DeviceInfo{
+			ID:                  "debian",
+			ClockSkew:           120,
+			ClockUnsynchronized: true,
+		},
+		ClaimCode:  models.NewDeviceClaimCode(authReq.PublicKey),
 		TenantID:   authReq.TenantID,
 		LastSeen:   now,
 		RemoteAddr: "127.0.0.1",
-		Position: &models.DevicePosition{
-			Latitude:  0,
-			Longitude: 0,
-		},
+		Position:   models.NewDevicePosition(0, 0),
 	}
 
-	clockMock.On("Now").Return(now).Twice()
+	clockMock.On("Now").Return(now).Times(4)
 	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "tenant"}
 
 	// [DeviceAuthClaims.WithDefaults]
@@ -75,7 +83,7 @@ func TestAuthDevice(t *testing.T) {
 	uuidMock.
 		On("Generate").
 		Return("cdfd3cb0-c44e-4e54-b931-6d57713ad159").
-		Once()
+		Twice()
 
 	mock.On("DeviceCreate", ctx, *device, "").
 		Return(nil).Once()
@@ -85,6 +93,30 @@ func TestAuthDevice(t *testing.T) {
 		Return(device, nil).Once()
 	mock.On("NamespaceGet", ctx, namespace.TenantID).
 		Return(namespace, nil).Once()
+	mock.On("DeviceAddAddress", ctx, models.UID(device.UID), models.DeviceAddress{RemoteAddr: "127.0.0.1", SeenAt: now}).
+		Return(nil).Once()
+	mock.On("TagRuleList", ctx, device.TenantID).
+		Return([]models.TagRule{
+			{
+				Tag:        "debian",
+				Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributeOS, Operator: models.TagRuleOperatorEqual, Value: "debian"}},
+			},
+		}, nil).Once()
+	mock.On("DeviceSetTags", ctx, models.UID(device.UID), []string{"debian"}).
+		Return(int64(1), int64(1), nil).Once()
+	mock.On("DeviceChangeRecord", ctx, &models.DeviceChange{TenantID: device.TenantID, UID: device.UID, Type: models.DeviceChangeUpdated, ChangedAt: now}).
+		Return(nil).Once()
+	mock.On("DeviceSnapshotGet", ctx, device.TenantID, models.UID(device.UID), now).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("DeviceSnapshotCreate", ctx, &models.DeviceSnapshot{
+		ID:        "cdfd3cb0-c44e-4e54-b931-6d57713ad159",
+		TenantID:  device.TenantID,
+		DeviceUID: device.UID,
+		Identity:  device.Identity,
+		Info:      device.Info,
+		TakenAt:   now,
+	}).
+		Return(nil).Once()
 
 	// Mock time.Now using monkey patch
 	patch, err := mpatch.PatchMethod(time.Now, func() time.Time { return now })
@@ -100,6 +132,8 @@ func TestAuthDevice(t *testing.T) {
 			Latitude:  0,
 			Longitude: 0,
 		}, nil).Once()
+	locator.On("GetCountry", net.ParseIP("127.0.0.1")).
+		Return("", nil).Once()
 
 	service := NewService(store.Store(mock), privateKey, &privateKey.PublicKey, storecache.NewNullCache(), clientMock, WithLocator(locator))
 
@@ -787,6 +821,10 @@ func TestService_AuthLocalUser(t *testing.T) {
 					On("UserUpdate", ctx, user.ID, &models.UserChanges{LastLogin: now, PreferredNamespace: &preferredNamespace}).
 					Return(nil).
 					Once()
+				mock.
+					On("UserSessionSave", ctx, testifymock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &models.UserAuthResponse{
@@ -893,6 +931,10 @@ func TestService_AuthLocalUser(t *testing.T) {
 					On("UserUpdate", ctx, user.ID, &models.UserChanges{LastLogin: now, PreferredNamespace: &preferredNamespace}).
 					Return(nil).
 					Once()
+				mock.
+					On("UserSessionSave", ctx, testifymock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &models.UserAuthResponse{
@@ -1001,6 +1043,10 @@ func TestService_AuthLocalUser(t *testing.T) {
 					On("UserUpdate", ctx, user.ID, &models.UserChanges{LastLogin: now, PreferredNamespace: &preferredNamespace}).
 					Return(nil).
 					Once()
+				mock.
+					On("UserSessionSave", ctx, testifymock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &models.UserAuthResponse{
@@ -1108,6 +1154,10 @@ func TestService_AuthLocalUser(t *testing.T) {
 					On("UserUpdate", ctx, user.ID, &models.UserChanges{LastLogin: now, PreferredNamespace: &preferredNamespace}).
 					Return(nil).
 					Once()
+				mock.
+					On("UserSessionSave", ctx, testifymock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &models.UserAuthResponse{
@@ -1213,6 +1263,10 @@ func TestService_AuthLocalUser(t *testing.T) {
 					On("UserUpdate", ctx, user.ID, &models.UserChanges{LastLogin: now, PreferredNamespace: &preferredNamespace, Password: "$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YWQCIa2UYuFV4OJby7Yi"}).
 					Return(nil).
 					Once()
+				mock.
+					On("UserSessionSave", ctx, testifymock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: &models.UserAuthResponse{
@@ -1242,10 +1296,13 @@ func TestService_AuthLocalUser(t *testing.T) {
 			tc.requiredMocks()
 
 			res, lockout, mfaToken, err := service.AuthLocalUser(ctx, tc.req, tc.sourceIP)
-			// Since the resulting token is not crucial for the assertion and
-			// difficult to mock, it is safe to ignore this field.
+			// Since the resulting tokens are not crucial for the assertion and
+			// difficult to mock, it is safe to ignore these fields.
 			if res != nil {
 				res.Token = "must ignore"
+
+				assert.NotEmpty(t, res.RefreshToken)
+				res.RefreshToken = ""
 			}
 
 			assert.Equal(t, tc.expected, Expected{res, lockout, mfaToken, err})
@@ -1476,6 +1533,10 @@ func TestCreateUserToken(t *testing.T) {
 				clockMock := new(clockmock.Clock)
 				clock.DefaultBackend = clockMock
 				clockMock.On("Now").Return(now)
+				storeMock.
+					On("UserSessionSave", ctx, testifymock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 				cacheMock.
 					On("Set", ctx, "token_00000000-0000-4000-0000-000000000000000000000000000000000000", testifymock.Anything, time.Hour*72).
 					Return(nil).
@@ -1543,6 +1604,10 @@ func TestCreateUserToken(t *testing.T) {
 				clockMock := new(clockmock.Clock)
 				clock.DefaultBackend = clockMock
 				clockMock.On("Now").Return(now)
+				storeMock.
+					On("UserSessionSave", ctx, testifymock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 				cacheMock.
 					On("Set", ctx, "token_00000000-0000-4000-0000-000000000000000000000000000000000000", testifymock.Anything, time.Hour*72).
 					Return(nil).
@@ -1598,6 +1663,10 @@ func TestCreateUserToken(t *testing.T) {
 				clockMock := new(clockmock.Clock)
 				clock.DefaultBackend = clockMock
 				clockMock.On("Now").Return(now)
+				storeMock.
+					On("UserSessionSave", ctx, testifymock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 				cacheMock.
 					On("Set", ctx, "token_000000000000000000000000", testifymock.Anything, time.Hour*72).
 					Return(nil).
@@ -1629,10 +1698,13 @@ func TestCreateUserToken(t *testing.T) {
 			tc.requiredMocks(ctx)
 
 			res, err := s.CreateUserToken(ctx, tc.req)
-			// Since the resulting token is not crucial for the assertion and
-			// difficult to mock, it is safe to ignore this field.
+			// Since the resulting tokens are not crucial for the assertion and
+			// difficult to mock, it is safe to ignore these fields.
 			if res != nil {
 				res.Token = "must ignore"
+
+				assert.NotEmpty(t, res.RefreshToken)
+				res.RefreshToken = ""
 			}
 
 			assert.Equal(t, tc.expected, Expected{res, err})
@@ -1753,3 +1825,172 @@ func TestAuthAPIKey(t *testing.T) {
 
 	storeMock.AssertExpectations(t)
 }
+
+func TestDeviceAuthInfo(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	info := &models.DeviceInfo{ID: "debian", Version: "latest", ClockSkew: 120, ClockUnsynchronized: true}
+
+	type Expected struct {
+		info *models.DeviceInfo
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.DeviceAuth
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description:   "succeeds with the whole information",
+			req:           requests.DeviceAuth{TenantID: "tenant", Info: &requests.DeviceInfo{ID: "debian", Version: "latest", ClockSkew: 120}},
+			requiredMocks: func(_ context.Context) {},
+			expected:      Expected{info: info, err: nil},
+		},
+		{
+			description: "succeeds with the hardware inventory",
+			req: requests.DeviceAuth{TenantID: "tenant", Info: &requests.DeviceInfo{
+				ID:       "debian",
+				Version:  "latest",
+				Hardware: &models.DeviceHardware{CPU: models.DeviceHardwareCPU{Model: "BCM2835", Cores: 4}, Memory: 4294967296},
+			}},
+			requiredMocks: func(_ context.Context) {},
+			expected: Expected{
+				info: &models.DeviceInfo{
+					ID:       "debian",
+					Version:  "latest",
+					Hardware: &models.DeviceHardware{CPU: models.DeviceHardwareCPU{Model: "BCM2835", Cores: 4}, Memory: 4294967296},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "fails when the device is not found",
+			req:         requests.DeviceAuth{TenantID: "tenant", InfoHash: info.Hash()},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{info: nil, err: NewErrDeviceInfoHash(info.Hash(), store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the hash doesn't match the stored information",
+			req:         requests.DeviceAuth{TenantID: "tenant", InfoHash: info.Hash()},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Info: &models.DeviceInfo{ID: "debian", Version: "0.1.0"}}, nil).
+					Once()
+			},
+			expected: Expected{info: nil, err: NewErrDeviceInfoHash(info.Hash(), nil)},
+		},
+		{
+			description: "succeeds keeping the stored information when the hash matches",
+			req:         requests.DeviceAuth{TenantID: "tenant", InfoHash: info.Hash()},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Info: info}, nil).
+					Once()
+			},
+			expected: Expected{info: info, err: nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			info, err := s.deviceAuthInfo(ctx, models.UID("uid"), tc.req)
+			assert.Equal(t, tc.expected, Expected{info, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeriveDeviceUID(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	auth := models.DeviceAuth{
+		Identity:  &models.DeviceIdentity{MAC: "mac"},
+		PublicKey: "key",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+	}
+
+	legacy := deviceuid.Derive(deviceuid.SchemeLegacy, auth)
+	v2 := deviceuid.Derive(deviceuid.SchemeV2, auth)
+
+	cases := []struct {
+		description   string
+		scheme        deviceuid.Scheme
+		requiredMocks func(context.Context)
+		expected      string
+	}{
+		{
+			description:   "derives on the legacy scheme",
+			scheme:        deviceuid.SchemeLegacy,
+			requiredMocks: func(context.Context) {},
+			expected:      legacy,
+		},
+		{
+			description: "derives on the scheme when the device is registered with it",
+			scheme:      deviceuid.SchemeV2,
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID(v2), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: v2}, nil).
+					Once()
+			},
+			expected: v2,
+		},
+		{
+			description: "keeps the legacy UID of the device registered with it",
+			scheme:      deviceuid.SchemeV2,
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID(v2), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID(legacy), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: legacy}, nil).
+					Once()
+			},
+			expected: legacy,
+		},
+		{
+			description: "derives on the scheme when the device isn't registered",
+			scheme:      deviceuid.SchemeV2,
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID(v2), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID(legacy), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: v2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock, WithDeviceUIDScheme(tc.scheme))
+			assert.Equal(t, tc.expected, s.deriveDeviceUID(ctx, auth))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
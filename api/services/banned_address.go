@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/banlist"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// BannedAddressFailureReason is the reason of the temporary bans set after repeated authentication failures.
+const BannedAddressFailureReason = "repeated authentication failures"
+
+type BannedAddressService interface {
+	// ListBannedAddresses retrieves the addresses currently banned.
+	ListBannedAddresses(ctx context.Context) ([]models.BannedAddress, error)
+
+	// CreateBannedAddress bans an IP address, or a range of them, until removed or, when a duration is set, for that
+	// long. Banning an address already banned replaces its ban. It returns the ban created.
+	CreateBannedAddress(ctx context.Context, req *requests.BannedAddressCreate) (*models.BannedAddress, error)
+
+	// DeleteBannedAddress lifts the ban of an address. The address must be written as it was banned, a range banning
+	// an address can't be lifted through the address alone.
+	DeleteBannedAddress(ctx context.Context, req *requests.BannedAddressDelete) error
+
+	// RecordAddressFailure records an authentication failure from the IP address. When the address fails too many
+	// times in a row, it is banned temporarily.
+	RecordAddressFailure(ctx context.Context, address string) error
+
+	// PublishBannedAddresses publishes the banned addresses to the cache, where the SSH server and the API read them
+	// from. It is done on every change, and when the API starts, as the temporary bans expire on the store.
+	PublishBannedAddresses(ctx context.Context) error
+}
+
+func (s *service) ListBannedAddresses(ctx context.Context) ([]models.BannedAddress, error) {
+	bans, err := s.store.BannedAddressList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+
+	active := make([]models.BannedAddress, 0, len(bans))
+	for _, ban := range bans {
+		if !ban.Expired(now) {
+			active = append(active, ban)
+		}
+	}
+
+	return active, nil
+}
+
+func (s *service) CreateBannedAddress(ctx context.Context, req *requests.BannedAddressCreate) (*models.BannedAddress, error) {
+	prefix, err := banlist.Parse(req.Address)
+	if err != nil {
+		return nil, NewErrBannedAddressInvalid(req.Address, err)
+	}
+
+	ban := &models.BannedAddress{
+		Address:   prefix.String(),
+		Reason:    req.Reason,
+		CreatedAt: clock.Now(),
+	}
+
+	if req.Duration > 0 {
+		expiresAt := ban.CreatedAt.Add(time.Duration(req.Duration) * time.Second)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	if err := s.store.BannedAddressSave(ctx, ban); err != nil {
+		return nil, err
+	}
+
+	if err := s.PublishBannedAddresses(ctx); err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
+
+func (s *service) DeleteBannedAddress(ctx context.Context, req *requests.BannedAddressDelete) error {
+	prefix, err := banlist.Parse(req.Address)
+	if err != nil {
+		return NewErrBannedAddressInvalid(req.Address, err)
+	}
+
+	if err := s.store.BannedAddressDelete(ctx, prefix.String()); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNoDocuments):
+			return NewErrBannedAddressNotFound(req.Address, err)
+		default:
+			return err
+		}
+	}
+
+	return s.PublishBannedAddresses(ctx)
+}
+
+func (s *service) RecordAddressFailure(ctx context.Context, address string) error {
+	if s.addressBan.failures <= 0 {
+		return nil
+	}
+
+	prefix, err := banlist.Parse(address)
+	if err != nil || !prefix.IsSingleIP() {
+		return NewErrBannedAddressInvalid(address, err)
+	}
+
+	key := "banned-address-failures=" + prefix.String()
+
+	failures := 0
+	if err := s.cache.Get(ctx, key, &failures); err != nil {
+		return err
+	}
+
+	failures++
+	if failures < s.addressBan.failures {
+		return s.cache.Set(ctx, key, failures, s.addressBan.window)
+	}
+
+	if err := s.cache.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	bans, err := s.ListBannedAddresses(ctx)
+	if err != nil {
+		return err
+	}
+
+	// NOTICE: the address may be on a ban set by the instance's administrator, not yet enforced by the replica where
+	// it failed, which must not be replaced by a temporary one.
+	for _, ban := range bans {
+		if banned, err := netip.ParsePrefix(ban.Address); err == nil && banned.Contains(prefix.Addr()) {
+			return nil
+		}
+	}
+
+	now := clock.Now()
+	expiresAt := now.Add(s.addressBan.duration)
+
+	if err := s.store.BannedAddressSave(ctx, &models.BannedAddress{
+		Address:   prefix.String(),
+		Reason:    BannedAddressFailureReason,
+		CreatedAt: now,
+		ExpiresAt: &expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	return s.PublishBannedAddresses(ctx)
+}
+
+func (s *service) PublishBannedAddresses(ctx context.Context) error {
+	bans, err := s.ListBannedAddresses(ctx)
+	if err != nil {
+		return err
+	}
+
+	return banlist.Publish(ctx, s.cache, bans)
+}
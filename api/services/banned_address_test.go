@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/banlist"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateBannedAddress(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+
+	clockMock.On("Now").Return(now)
+	expiresAt := now.Add(time.Hour)
+
+	type Expected struct {
+		ban *models.BannedAddress
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.BannedAddressCreate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description:   "fails when the address is invalid",
+			req:           &requests.BannedAddressCreate{Address: "192.0.2"},
+			requiredMocks: func(context.Context) {},
+			expected: Expected{
+				ban: nil,
+				err: NewErrBannedAddressInvalid("192.0.2", banlist.ErrAddressInvalid),
+			},
+		},
+		{
+			description: "fails when the ban cannot be saved",
+			req:         &requests.BannedAddressCreate{Address: "192.0.2.10/24", Reason: "scanner"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("BannedAddressSave", ctx, &models.BannedAddress{Address: "192.0.2.0/24", Reason: "scanner", CreatedAt: now}).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: Expected{
+				ban: nil,
+				err: errors.New("error"),
+			},
+		},
+		{
+			description: "succeeds banning a range until removed",
+			req:         &requests.BannedAddressCreate{Address: "192.0.2.10/24", Reason: "scanner"},
+			requiredMocks: func(ctx context.Context) {
+				ban := &models.BannedAddress{Address: "192.0.2.0/24", Reason: "scanner", CreatedAt: now}
+				storeMock.
+					On("BannedAddressSave", ctx, ban).
+					Return(nil).
+					Once()
+				storeMock.
+					On("BannedAddressList", ctx).
+					Return([]models.BannedAddress{*ban}, nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, banlist.CacheKey, []models.BannedAddress{*ban}, time.Duration(0)).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				ban: &models.BannedAddress{Address: "192.0.2.0/24", Reason: "scanner", CreatedAt: now},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds banning an address for a while",
+			req:         &requests.BannedAddressCreate{Address: "198.51.100.7", Duration: 3600},
+			requiredMocks: func(ctx context.Context) {
+				ban := &models.BannedAddress{Address: "198.51.100.7/32", CreatedAt: now, ExpiresAt: &expiresAt}
+				storeMock.
+					On("BannedAddressSave", ctx, ban).
+					Return(nil).
+					Once()
+				storeMock.
+					On("BannedAddressList", ctx).
+					Return([]models.BannedAddress{*ban}, nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, banlist.CacheKey, []models.BannedAddress{*ban}, time.Duration(0)).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				ban: &models.BannedAddress{Address: "198.51.100.7/32", CreatedAt: now, ExpiresAt: &expiresAt},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			ban, err := s.CreateBannedAddress(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{ban, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
+
+func TestDeleteBannedAddress(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+
+	clockMock.On("Now").Return(now)
+	expired := now.Add(-time.Minute)
+
+	cases := []struct {
+		description   string
+		req           *requests.BannedAddressDelete
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description:   "fails when the address is invalid",
+			req:           &requests.BannedAddressDelete{Address: "invalid"},
+			requiredMocks: func(context.Context) {},
+			expected:      NewErrBannedAddressInvalid("invalid", banlist.ErrAddressInvalid),
+		},
+		{
+			description: "fails when the address isn't banned",
+			req:         &requests.BannedAddressDelete{Address: "198.51.100.7"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("BannedAddressDelete", ctx, "198.51.100.7/32").
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrBannedAddressNotFound("198.51.100.7", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds publishing only the bans not expired",
+			req:         &requests.BannedAddressDelete{Address: "198.51.100.7"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("BannedAddressDelete", ctx, "198.51.100.7/32").
+					Return(nil).
+					Once()
+				storeMock.
+					On("BannedAddressList", ctx).
+					Return([]models.BannedAddress{
+						{Address: "192.0.2.0/24", CreatedAt: now},
+						{Address: "203.0.113.9/32", CreatedAt: now, ExpiresAt: &expired},
+					}, nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, banlist.CacheKey, []models.BannedAddress{{Address: "192.0.2.0/24", CreatedAt: now}}, time.Duration(0)).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.DeleteBannedAddress(ctx, tc.req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
+
+func TestRecordAddressFailure(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+
+	clockMock.On("Now").Return(now)
+	expiresAt := now.Add(time.Hour)
+
+	failures := func(count int) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			*args.Get(2).(*int) = count
+		}
+	}
+
+	cases := []struct {
+		description   string
+		address       string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description:   "fails when the address is a range",
+			address:       "192.0.2.0/24",
+			requiredMocks: func(context.Context) {},
+			expected:      NewErrBannedAddressInvalid("192.0.2.0/24", nil),
+		},
+		{
+			description: "succeeds counting the failure",
+			address:     "198.51.100.7",
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, "banned-address-failures=198.51.100.7/32", mock.Anything).
+					Run(failures(1)).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, "banned-address-failures=198.51.100.7/32", 2, 10*time.Minute).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds without replacing a ban covering the address",
+			address:     "192.0.2.7",
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, "banned-address-failures=192.0.2.7/32", mock.Anything).
+					Run(failures(2)).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Delete", ctx, "banned-address-failures=192.0.2.7/32").
+					Return(nil).
+					Once()
+				storeMock.
+					On("BannedAddressList", ctx).
+					Return([]models.BannedAddress{{Address: "192.0.2.0/24", CreatedAt: now}}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds banning the address temporarily",
+			address:     "198.51.100.7",
+			requiredMocks: func(ctx context.Context) {
+				ban := models.BannedAddress{
+					Address:   "198.51.100.7/32",
+					Reason:    BannedAddressFailureReason,
+					CreatedAt: now,
+					ExpiresAt: &expiresAt,
+				}
+
+				cacheMock.
+					On("Get", ctx, "banned-address-failures=198.51.100.7/32", mock.Anything).
+					Run(failures(2)).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Delete", ctx, "banned-address-failures=198.51.100.7/32").
+					Return(nil).
+					Once()
+				storeMock.
+					On("BannedAddressList", ctx).
+					Return([]models.BannedAddress{{Address: "192.0.2.0/24", CreatedAt: now}}, nil).
+					Once()
+				storeMock.
+					On("BannedAddressSave", ctx, &ban).
+					Return(nil).
+					Once()
+				storeMock.
+					On("BannedAddressList", ctx).
+					Return([]models.BannedAddress{{Address: "192.0.2.0/24", CreatedAt: now}, ban}, nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, banlist.CacheKey, []models.BannedAddress{{Address: "192.0.2.0/24", CreatedAt: now}, ban}, time.Duration(0)).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock, WithAddressBan(3, 10*time.Minute, time.Hour))
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.RecordAddressFailure(ctx, tc.address))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/commandpolicy"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// CommandPolicyMaxPolicies is the maximum number of command policies a namespace can have.
+const CommandPolicyMaxPolicies = 20
+
+type CommandPolicyService interface {
+	// ListCommandPolicies lists the namespace's command policies, the oldest first.
+	ListCommandPolicies(ctx context.Context, req *requests.CommandPolicyList) ([]models.CommandPolicy, error)
+
+	// CreateCommandPolicy creates a command policy, up to [CommandPolicyMaxPolicies] per namespace. The policy is
+	// evaluated on the sessions started after it.
+	CreateCommandPolicy(ctx context.Context, req *requests.CommandPolicyCreate) (*models.CommandPolicy, error)
+
+	// UpdateCommandPolicy replaces the name, the tags, the mode, the patterns and the action of a command policy.
+	UpdateCommandPolicy(ctx context.Context, req *requests.CommandPolicyUpdate) (*models.CommandPolicy, error)
+
+	// DeleteCommandPolicy deletes a command policy. Its evaluations are kept on the namespace's log.
+	DeleteCommandPolicy(ctx context.Context, req *requests.CommandPolicyDelete) error
+
+	// ListCommandPolicyEvaluations retrieves the command lines that violated the namespace's command policies, most
+	// recent first, and their total count.
+	ListCommandPolicyEvaluations(ctx context.Context, req *requests.CommandPolicyEvaluationsList) ([]models.CommandPolicyEvaluation, int, error)
+
+	// GetDeviceCommandPolicies retrieves the namespace's command policies applying to the device, by its tags, to be
+	// evaluated by the SSH server on the sessions to it.
+	GetDeviceCommandPolicies(ctx context.Context, req *requests.DeviceCommandPoliciesGet) ([]models.CommandPolicy, error)
+
+	// CreateCommandPolicyEvaluation stores a command line that violated a command policy on a session to the device.
+	CreateCommandPolicyEvaluation(ctx context.Context, req *requests.CommandPolicyEvaluationCreate) error
+}
+
+func (s *service) ListCommandPolicies(ctx context.Context, req *requests.CommandPolicyList) ([]models.CommandPolicy, error) {
+	return s.store.CommandPolicyList(ctx, req.TenantID)
+}
+
+func (s *service) CreateCommandPolicy(ctx context.Context, req *requests.CommandPolicyCreate) (*models.CommandPolicy, error) {
+	if err := commandpolicy.Validate(req.Mode, req.Action, req.Patterns); err != nil {
+		return nil, NewErrCommandPolicyInvalid(err)
+	}
+
+	policies, err := s.store.CommandPolicyList(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) >= CommandPolicyMaxPolicies {
+		return nil, NewErrCommandPolicyLimit(CommandPolicyMaxPolicies, nil)
+	}
+
+	now := clock.Now()
+	policy := &models.CommandPolicy{
+		ID:        uuid.Generate(),
+		TenantID:  req.TenantID,
+		Name:      req.Name,
+		Tags:      commandPolicyTags(req.Tags),
+		Mode:      req.Mode,
+		Patterns:  req.Patterns,
+		Action:    req.Action,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.store.CommandPolicyCreate(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionCommandPolicyCreate, models.AuditTarget{Type: models.AuditTargetCommandPolicy, ID: policy.ID}, map[string]string{"name": policy.Name, "action": string(policy.Action)}); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (s *service) UpdateCommandPolicy(ctx context.Context, req *requests.CommandPolicyUpdate) (*models.CommandPolicy, error) {
+	if err := commandpolicy.Validate(req.Mode, req.Action, req.Patterns); err != nil {
+		return nil, NewErrCommandPolicyInvalid(err)
+	}
+
+	policy, err := s.store.CommandPolicyGet(ctx, req.TenantID, req.ID)
+	if err != nil {
+		return nil, NewErrCommandPolicyNotFound(req.ID, err)
+	}
+
+	policy.Name = req.Name
+	policy.Tags = commandPolicyTags(req.Tags)
+	policy.Mode = req.Mode
+	policy.Patterns = req.Patterns
+	policy.Action = req.Action
+	policy.UpdatedAt = clock.Now()
+
+	if err := s.store.CommandPolicyUpdate(ctx, policy); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil, NewErrCommandPolicyNotFound(req.ID, err)
+		}
+
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionCommandPolicyUpdate, models.AuditTarget{Type: models.AuditTargetCommandPolicy, ID: policy.ID}, map[string]string{"name": policy.Name, "action": string(policy.Action)}); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (s *service) DeleteCommandPolicy(ctx context.Context, req *requests.CommandPolicyDelete) error {
+	if err := s.store.CommandPolicyDelete(ctx, req.TenantID, req.ID); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrCommandPolicyNotFound(req.ID, err)
+		}
+
+		return err
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionCommandPolicyDelete, models.AuditTarget{Type: models.AuditTargetCommandPolicy, ID: req.ID}, nil)
+}
+
+func (s *service) ListCommandPolicyEvaluations(ctx context.Context, req *requests.CommandPolicyEvaluationsList) ([]models.CommandPolicyEvaluation, int, error) {
+	return s.store.CommandPolicyEvaluationList(ctx, req.TenantID, req.Paginator)
+}
+
+func (s *service) GetDeviceCommandPolicies(ctx context.Context, req *requests.DeviceCommandPoliciesGet) ([]models.CommandPolicy, error) {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	policies, err := s.store.CommandPolicyList(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	applying := make([]models.CommandPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.Applies(device.Tags) {
+			applying = append(applying, policy)
+		}
+	}
+
+	return applying, nil
+}
+
+func (s *service) CreateCommandPolicyEvaluation(ctx context.Context, req *requests.CommandPolicyEvaluationCreate) error {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	evaluation := req.CommandPolicyEvaluation
+	evaluation.ID = uuid.Generate()
+	evaluation.DeviceUID = req.UID
+
+	return s.store.CommandPolicyEvaluationCreate(ctx, &evaluation)
+}
+
+// commandPolicyTags returns the policy's tags, empty instead of nil when the policy applies to every device.
+func commandPolicyTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+
+	return tags
+}
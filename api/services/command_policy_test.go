@@ -0,0 +1,521 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/commandpolicy"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateCommandPolicy(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	backend := uuid.DefaultBackend
+	uuidMock := new(uuidmock.Uuid)
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+
+	type Expected struct {
+		policy *models.CommandPolicy
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.CommandPolicyCreate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when a pattern is invalid",
+			req: &requests.CommandPolicyCreate{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Name:     "destructive",
+				Mode:     models.CommandPolicyModeDeny,
+				Patterns: []string{"(rm"},
+				Action:   models.CommandPolicyActionBlock,
+			},
+			requiredMocks: func(context.Context) {},
+			expected: Expected{
+				policy: nil,
+				err:    NewErrCommandPolicyInvalid(commandpolicy.ErrPatternInvalid),
+			},
+		},
+		{
+			description: "fails when the namespace has the maximum number of policies",
+			req: &requests.CommandPolicyCreate{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Name:     "destructive",
+				Mode:     models.CommandPolicyModeDeny,
+				Patterns: []string{`rm\s+-rf`},
+				Action:   models.CommandPolicyActionBlock,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("CommandPolicyList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(make([]models.CommandPolicy, CommandPolicyMaxPolicies), nil).
+					Once()
+			},
+			expected: Expected{
+				policy: nil,
+				err:    NewErrCommandPolicyLimit(CommandPolicyMaxPolicies, nil),
+			},
+		},
+		{
+			description: "succeeds applying the policy to every device when it has no tags",
+			req: &requests.CommandPolicyCreate{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Name:     "destructive",
+				Mode:     models.CommandPolicyModeDeny,
+				Patterns: []string{`rm\s+-rf`},
+				Action:   models.CommandPolicyActionBlock,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("CommandPolicyList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.CommandPolicy{}, nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.
+					On("Generate").
+					Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Once()
+				storeMock.
+					On("CommandPolicyCreate", ctx, &models.CommandPolicy{
+						ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Name:      "destructive",
+						Tags:      []string{},
+						Mode:      models.CommandPolicyModeDeny,
+						Patterns:  []string{`rm\s+-rf`},
+						Action:    models.CommandPolicyActionBlock,
+						CreatedAt: now,
+						UpdatedAt: now,
+					}).
+					Return(nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionCommandPolicyCreate &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetCommandPolicy, ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"} &&
+							reflect.DeepEqual(entry.Details, map[string]string{"name": "destructive", "action": "block"})
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				policy: &models.CommandPolicy{
+					ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Name:      "destructive",
+					Tags:      []string{},
+					Mode:      models.CommandPolicyModeDeny,
+					Patterns:  []string{`rm\s+-rf`},
+					Action:    models.CommandPolicyActionBlock,
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			policy, err := s.CreateCommandPolicy(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{policy, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateCommandPolicy(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	uuidMock := new(uuidmock.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+
+	req := &requests.CommandPolicyUpdate{
+		CommandPolicyParam: requests.CommandPolicyParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+		CommandPolicyCreate: requests.CommandPolicyCreate{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			Name:     "read only",
+			Tags:     []string{"production"},
+			Mode:     models.CommandPolicyModeAllow,
+			Patterns: []string{`^(ls|cat)\b`},
+			Action:   models.CommandPolicyActionTerminate,
+		},
+	}
+
+	type Expected struct {
+		policy *models.CommandPolicy
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the policy is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("CommandPolicyGet", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{
+				policy: nil,
+				err:    NewErrCommandPolicyNotFound("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("CommandPolicyGet", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(&models.CommandPolicy{
+						ID:       "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Name:     "destructive",
+						Tags:     []string{},
+						Mode:     models.CommandPolicyModeDeny,
+						Patterns: []string{`rm\s+-rf`},
+						Action:   models.CommandPolicyActionBlock,
+					}, nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("CommandPolicyUpdate", ctx, &models.CommandPolicy{
+						ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Name:      "read only",
+						Tags:      []string{"production"},
+						Mode:      models.CommandPolicyModeAllow,
+						Patterns:  []string{`^(ls|cat)\b`},
+						Action:    models.CommandPolicyActionTerminate,
+						UpdatedAt: now,
+					}).
+					Return(nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionCommandPolicyUpdate &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetCommandPolicy, ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"} &&
+							reflect.DeepEqual(entry.Details, map[string]string{"name": "read only", "action": "terminate"})
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				policy: &models.CommandPolicy{
+					ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Name:      "read only",
+					Tags:      []string{"production"},
+					Mode:      models.CommandPolicyModeAllow,
+					Patterns:  []string{`^(ls|cat)\b`},
+					Action:    models.CommandPolicyActionTerminate,
+					UpdatedAt: now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			policy, err := s.UpdateCommandPolicy(ctx, req)
+			assert.Equal(t, tc.expected, Expected{policy, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteCommandPolicy(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	uuidMock := new(uuidmock.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+
+	req := &requests.CommandPolicyDelete{
+		CommandPolicyParam: requests.CommandPolicyParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+		TenantID:           "00000000-0000-4000-0000-000000000000",
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the policy is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("CommandPolicyDelete", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrCommandPolicyNotFound("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the audit entry cannot be recorded",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("CommandPolicyDelete", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionCommandPolicyDelete &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetCommandPolicy, ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"} &&
+							reflect.DeepEqual(entry.Details, map[string]string(nil))
+					})).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("CommandPolicyDelete", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionCommandPolicyDelete &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetCommandPolicy, ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"} &&
+							reflect.DeepEqual(entry.Details, map[string]string(nil))
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.DeleteCommandPolicy(ctx, req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestGetDeviceCommandPolicies(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	req := &requests.DeviceCommandPoliciesGet{
+		DeviceParam: requests.DeviceParam{UID: "uid"},
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+	}
+
+	everywhere := models.CommandPolicy{ID: "everywhere", Tags: []string{}}
+	production := models.CommandPolicy{ID: "production", Tags: []string{"production"}}
+	staging := models.CommandPolicy{ID: "staging", Tags: []string{"staging"}}
+
+	type Expected struct {
+		policies []models.CommandPolicy
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the policies cannot be listed",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Tags: []string{"production"}}, nil).
+					Once()
+				storeMock.
+					On("CommandPolicyList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: Expected{nil, errors.New("error")},
+		},
+		{
+			description: "succeeds with the policies applying to the device's tags",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Tags: []string{"production"}}, nil).
+					Once()
+				storeMock.
+					On("CommandPolicyList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.CommandPolicy{everywhere, production, staging}, nil).
+					Once()
+			},
+			expected: Expected{[]models.CommandPolicy{everywhere, production}, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			policies, err := s.GetDeviceCommandPolicies(ctx, req)
+			assert.Equal(t, tc.expected, Expected{policies, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestCreateCommandPolicyEvaluation(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	backend := uuid.DefaultBackend
+	uuidMock := new(uuidmock.Uuid)
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	req := &requests.CommandPolicyEvaluationCreate{
+		DeviceParam: requests.DeviceParam{UID: "uid"},
+		CommandPolicyEvaluation: models.CommandPolicyEvaluation{
+			TenantID:   "00000000-0000-4000-0000-000000000000",
+			PolicyID:   "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+			SessionUID: "session",
+			Command:    "rm -rf /",
+			Action:     models.CommandPolicyActionBlock,
+			Time:       now,
+		},
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("5f2d1a0b-3c4e-4b8a-9d6f-7e8a9b0c1d2e").
+					Once()
+				storeMock.
+					On("CommandPolicyEvaluationCreate", ctx, &models.CommandPolicyEvaluation{
+						ID:         "5f2d1a0b-3c4e-4b8a-9d6f-7e8a9b0c1d2e",
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						PolicyID:   "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						SessionUID: "session",
+						DeviceUID:  "uid",
+						Command:    "rm -rf /",
+						Action:     models.CommandPolicyActionBlock,
+						Time:       now,
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.CreateCommandPolicyEvaluation(ctx, req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
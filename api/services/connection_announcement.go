@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/announcement"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+)
+
+type ConnectionAnnouncementService interface {
+	// PreviewConnectionAnnouncement renders the connection announcement with sample data of a session to one of the
+	// namespace's devices, returning an error when it isn't a valid template.
+	PreviewConnectionAnnouncement(ctx context.Context, req *requests.NamespaceConnectionAnnouncementPreview) (*responses.ConnectionAnnouncementPreview, error)
+}
+
+func (s *service) PreviewConnectionAnnouncement(ctx context.Context, req *requests.NamespaceConnectionAnnouncementPreview) (*responses.ConnectionAnnouncementPreview, error) {
+	namespace, err := s.store.NamespaceGet(ctx, req.Tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.Tenant, err)
+	}
+
+	if err := announcement.Validate(req.Announcement); err != nil {
+		return nil, NewErrNamespaceConnectionAnnouncementInvalid(err)
+	}
+
+	return &responses.ConnectionAnnouncementPreview{
+		Announcement: announcement.Render(req.Announcement, announcement.Sample(namespace.Name)),
+	}, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	storemock "github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/announcement"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewConnectionAnnouncement(t *testing.T) {
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	errTemplate := announcement.Validate("Welcome to {{.Hostname}}")
+
+	type Expected struct {
+		preview *responses.ConnectionAnnouncementPreview
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.NamespaceConnectionAnnouncementPreview
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			req: &requests.NamespaceConnectionAnnouncementPreview{
+				TenantParam:  requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				Announcement: "Welcome to {{.Device.Name}}",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", errors.New("error", "", 0))},
+		},
+		{
+			description: "fails when the announcement is invalid",
+			req: &requests.NamespaceConnectionAnnouncementPreview{
+				TenantParam:  requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				Announcement: "Welcome to {{.Hostname}}",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceConnectionAnnouncementInvalid(errTemplate)},
+		},
+		{
+			description: "succeeds rendering the announcement with sample data",
+			req: &requests.NamespaceConnectionAnnouncementPreview{
+				TenantParam:  requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				Announcement: "Welcome to {{.Device.Name}} on {{.Namespace}}, {{.User}}.",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
+					Once()
+			},
+			expected: Expected{&responses.ConnectionAnnouncementPreview{Announcement: "Welcome to device on namespace, root."}, nil},
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			preview, err := service.PreviewConnectionAnnouncement(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{preview, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/shellhub-io/shellhub/api/pkg/queryanalytics"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/markdown"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 )
@@ -26,10 +30,28 @@ type DeviceService interface {
 	OfflineDevice(ctx context.Context, uid models.UID) error
 	UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error
 	UpdateDevice(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error
+	// ClaimDevice accepts the pending device of the tenant with the claim code shown by its agent, returning it.
+	ClaimDevice(ctx context.Context, req *requests.DeviceClaim) (*models.Device, error)
+	// UpdateDeviceLoginShell sets the program started, instead of the user's shell, on the device's interactive
+	// sessions. It is only used when allowed by the device's agent.
+	UpdateDeviceLoginShell(ctx context.Context, req *requests.DeviceUpdateLoginShell) error
+	// UpdateDeviceConnectionNote sets the markdown note with instructions to connect to the device, sanitizing it
+	// before it is stored.
+	UpdateDeviceConnectionNote(ctx context.Context, req *requests.DeviceUpdateConnectionNote) error
+	// UpdateDeviceRemoteAccess enables or disables the reverse SSH tunnel of a device whose agent runs in
+	// inventory-only mode. The agent honors it on its next ping.
+	UpdateDeviceRemoteAccess(ctx context.Context, req *requests.DeviceUpdateRemoteAccess) error
 }
 
 func (s *service) ListDevices(ctx context.Context, req *requests.DeviceList) ([]models.Device, int, error) {
+	fields := filtersFields(req.Filters)
+	if req.TenantID != "" {
+		fields = append(fields, "tenant_id")
+	}
+
 	if req.DeviceStatus == models.DeviceStatusRemoved {
+		s.queries.Record(queryanalytics.QueryRemovedDevices, fields, req.Sorter.By, req.Sorter.Order)
+
 		// TODO: unique DeviceList
 		removed, count, err := s.store.DeviceRemovedList(ctx, req.TenantID, req.Paginator, req.Filters, req.Sorter)
 		if err != nil {
@@ -44,6 +66,22 @@ func (s *service) ListDevices(ctx context.Context, req *requests.DeviceList) ([]
 		return devices, count, nil
 	}
 
+	if req.DeviceStatus != "" {
+		fields = append(fields, "status")
+	}
+
+	if req.Group != "" {
+		filters, err := s.groupFilters(ctx, req.TenantID, req.Group)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		req.Filters.Data = append(req.Filters.Data, filters...)
+		fields = append(fields, "groups")
+	}
+
+	s.queries.Record(queryanalytics.QueryDevices, fields, req.Sorter.By, req.Sorter.Order)
+
 	if req.TenantID != "" {
 		ns, err := s.store.NamespaceGet(ctx, req.TenantID, s.store.Options().CountAcceptedDevices())
 		if err != nil {
@@ -114,13 +152,25 @@ func (s *service) DeleteDevice(ctx context.Context, uid models.UID, tenant strin
 	// If the namespace has a limit of devices, we change the device's slot status to removed.
 	// This way, we can keep track of the number of devices that were removed from the namespace and void the device
 	// switching.
-	if envs.IsCloud() && envs.HasBilling() && !ns.Billing.IsActive() {
+	// The devices exempt from the limit aren't kept, as they were never counted.
+	if envs.IsCloud() && envs.HasBilling() && !ns.Billing.IsActive() && !device.LimitExempt {
 		if err := s.store.DeviceRemovedInsert(ctx, tenant, device); err != nil {
 			return NewErrDeviceRemovedInsert(err)
 		}
 	}
 
-	return s.store.DeviceDelete(ctx, uid)
+	if err := s.store.DeviceDelete(ctx, uid); err != nil {
+		return err
+	}
+
+	s.publishDeviceEvent(ctx, tenant, string(uid), models.DeviceEventRemoved)
+
+	// NOTICE: the removal of an accepted device frees a slot for the devices on the acceptance queue.
+	if ns.HasMaxDevices() && device.Status == models.DeviceStatusAccepted && !device.LimitExempt {
+		s.acceptQueuedDevices(ctx, tenant)
+	}
+
+	return s.recordAudit(ctx, tenant, models.AuditActionDeviceRemove, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, nil)
 }
 
 func (s *service) RenameDevice(ctx context.Context, uid models.UID, name, tenant string) error {
@@ -164,15 +214,27 @@ func (s *service) RenameDevice(ctx context.Context, uid models.UID, name, tenant
 		return NewErrDeviceDuplicated(otherDevice.Name, err)
 	}
 
-	return s.store.DeviceRename(ctx, uid, name)
+	if err := s.store.DeviceRename(ctx, uid, name); err != nil {
+		return err
+	}
+
+	s.publishDeviceEvent(ctx, tenant, string(uid), models.DeviceEventName)
+	return s.recordAudit(ctx, tenant, models.AuditActionDeviceRename, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]string{"name": name})
 }
 
 // LookupDevice looks for a device in a namespace.
 //
 // It receives a context, used to "control" the request flow and, the namespace name from a models.Namespace and a
 // device name from models.Device.
+//
+// When the namespace has no device with the name, the devices shared with it, allowing the connections, are looked
+// for instead, and the device found is marked with its share.
 func (s *service) LookupDevice(ctx context.Context, namespace, name string) (*models.Device, error) {
 	device, err := s.store.DeviceLookup(ctx, namespace, name)
+	if errors.Is(err, store.ErrNoDocuments) {
+		device, err = s.lookupSharedDevice(ctx, namespace, name)
+	}
+
 	if err != nil || device == nil {
 		return nil, NewErrDeviceLookupNotFound(namespace, name, err)
 	}
@@ -181,6 +243,29 @@ func (s *service) LookupDevice(ctx context.Context, namespace, name string) (*mo
 }
 
 func (s *service) OfflineDevice(ctx context.Context, uid models.UID) error {
+	if s.offline.grace > 0 || s.offline.minOnline > 0 {
+		connected, err := s.store.DeviceGetConnected(ctx, string(uid))
+		if err != nil {
+			if errors.Is(err, store.ErrNoDocuments) {
+				return NewErrDeviceNotFound(uid, err)
+			}
+
+			return err
+		}
+
+		// NOTICE: the device remains online until both the grace period and the minimum online time are over. When
+		// it reconnects before it, no state change is seen.
+		now := clock.Now()
+		at := now.Add(s.offline.grace)
+		if minOnline := connected.ConnectedAt.Add(s.offline.minOnline); minOnline.After(at) {
+			at = minOnline
+		}
+
+		if at.After(now) {
+			return s.store.DeviceSetOfflineAt(ctx, string(uid), at)
+		}
+	}
+
 	if err := s.store.DeviceSetOffline(ctx, string(uid)); err != nil {
 		if errors.Is(err, store.ErrNoDocuments) {
 			return NewErrDeviceNotFound(uid, err)
@@ -189,11 +274,103 @@ func (s *service) OfflineDevice(ctx context.Context, uid models.UID) error {
 		return err
 	}
 
+	// NOTICE: the device's tenant is required to publish the event, but it is not known by who sets it offline.
+	if device, err := s.store.DeviceGet(ctx, uid); err == nil {
+		s.publishDeviceEvent(ctx, device.TenantID, string(uid), models.DeviceEventOffline)
+	}
+
 	return nil
 }
 
 // UpdateDeviceStatus updates the device status.
 func (s *service) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
+	if err := s.updateDeviceStatus(ctx, tenant, uid, status); err != nil {
+		return err
+	}
+
+	s.publishDeviceEvent(ctx, tenant, string(uid), models.DeviceEventStatus)
+	return s.recordAudit(ctx, tenant, models.AuditActionDeviceStatusUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]string{"status": string(status)})
+}
+
+// ClaimDevice looks for the tenant's pending device with the claim code and accepts it, following the same rules of
+// [service.UpdateDeviceStatus], like the namespace's device limit.
+//
+// It returns NewErrDeviceClaimCodeInvalid when the code isn't well formed and NewErrDeviceClaimCodeNotFound when
+// there is no pending device with it.
+func (s *service) ClaimDevice(ctx context.Context, req *requests.DeviceClaim) (*models.Device, error) {
+	code := models.NormalizeDeviceClaimCode(req.Code)
+	if code == "" {
+		return nil, NewErrDeviceClaimCodeInvalid(req.Code)
+	}
+
+	device, err := s.store.DeviceGetByClaimCode(ctx, code, req.TenantID, models.DeviceStatusPending)
+	if err != nil {
+		return nil, NewErrDeviceClaimCodeNotFound(code, err)
+	}
+
+	if err := s.UpdateDeviceStatus(ctx, req.TenantID, models.UID(device.UID), models.DeviceStatusAccepted); err != nil {
+		return nil, err
+	}
+
+	device.Status = models.DeviceStatusAccepted
+
+	return device, nil
+}
+
+func (s *service) UpdateDeviceLoginShell(ctx context.Context, req *requests.DeviceUpdateLoginShell) error {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	if device.LoginShell == req.LoginShell {
+		return nil
+	}
+
+	if err := s.store.DeviceSetLoginShell(ctx, req.TenantID, models.UID(device.UID), req.LoginShell); err != nil {
+		return err
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionDeviceUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, map[string]string{"login_shell": req.LoginShell})
+}
+
+func (s *service) UpdateDeviceConnectionNote(ctx context.Context, req *requests.DeviceUpdateConnectionNote) error {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	note := markdown.Sanitize(req.ConnectionNote)
+	if device.ConnectionNote == note {
+		return nil
+	}
+
+	if err := s.store.DeviceSetConnectionNote(ctx, req.TenantID, models.UID(device.UID), note); err != nil {
+		return err
+	}
+
+	// NOTICE: the note itself isn't kept on the audit trail, as it may be as long as a document.
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionDeviceUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, nil)
+}
+
+func (s *service) UpdateDeviceRemoteAccess(ctx context.Context, req *requests.DeviceUpdateRemoteAccess) error {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	if device.RemoteAccess == req.RemoteAccess {
+		return nil
+	}
+
+	if err := s.store.DeviceSetRemoteAccess(ctx, req.TenantID, models.UID(device.UID), req.RemoteAccess); err != nil {
+		return err
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionDeviceUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, map[string]string{"remote_access": strconv.FormatBool(req.RemoteAccess)})
+}
+
+func (s *service) updateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
 	namespace, err := s.store.NamespaceGet(ctx, tenant, s.store.Options().CountAcceptedDevices())
 	if err != nil {
 		return NewErrNamespaceNotFound(tenant, err)
@@ -245,11 +422,21 @@ func (s *service) UpdateDeviceStatus(ctx context.Context, tenant string, uid mod
 			return err
 		}
 
-		return s.store.DeviceUpdateStatus(ctx, uid, status)
+		if err := s.store.DeviceUpdateStatus(ctx, uid, status); err != nil {
+			return err
+		}
+
+		s.applyDefaultTags(ctx, namespace, device)
+
+		return nil
 	}
 
-	if sameName, err := s.store.DeviceGetByName(ctx, device.Name, device.TenantID, models.DeviceStatusAccepted); sameName != nil {
-		return NewErrDeviceDuplicated(device.Name, err)
+	// NOTICE: when the namespace has a device name template, the device is named from it on acceptance, using the
+	// first name not used by another accepted device.
+	name := s.deviceNameFromTemplate(ctx, namespace, device)
+
+	if sameName, err := s.store.DeviceGetByName(ctx, name, device.TenantID, models.DeviceStatusAccepted); sameName != nil {
+		return NewErrDeviceDuplicated(name, err)
 	}
 
 	if status != models.DeviceStatusAccepted {
@@ -258,11 +445,17 @@ func (s *service) UpdateDeviceStatus(ctx context.Context, tenant string, uid mod
 
 	switch {
 	case envs.IsCommunity(), envs.IsEnterprise():
-		if namespace.HasMaxDevices() && namespace.HasMaxDevicesReached() {
-			return NewErrDeviceMaxDevicesReached(namespace.MaxDevices)
+		if err := s.checkAcceptedDevicesLimit(ctx, namespace, device); err != nil {
+			return err
 		}
 	case envs.IsCloud():
 		if namespace.Billing.IsActive() {
+			// NOTICE: the maximum number of accepted devices set by the instance's administrator is enforced even
+			// when the namespace's billing is active.
+			if err := s.checkAcceptedDevicesLimit(ctx, namespace, device); err != nil {
+				return err
+			}
+
 			if err := s.BillingReport(s.client, namespace.TenantID, ReportDeviceAccept); err != nil {
 				return NewErrBillingReportNamespaceDelete(err)
 			}
@@ -283,7 +476,9 @@ func (s *service) UpdateDeviceStatus(ctx context.Context, tenant string, uid mod
 					return NewErrDeviceRemovedCount(err)
 				}
 
-				if namespace.HasMaxDevices() && namespace.HasLimitDevicesReached(count) {
+				if !device.LimitExempt && namespace.HasMaxDevices() && namespace.HasLimitDevicesReached(count) {
+					s.publishDeviceEvent(ctx, tenant, string(uid), models.DeviceEventAcceptedLimit)
+
 					return NewErrDeviceRemovedFull(namespace.MaxDevices, nil)
 				}
 			}
@@ -299,7 +494,21 @@ func (s *service) UpdateDeviceStatus(ctx context.Context, tenant string, uid mod
 		}
 	}
 
-	return s.store.DeviceUpdateStatus(ctx, uid, status)
+	if name != device.Name {
+		if err := s.store.DeviceRename(ctx, models.UID(device.UID), name); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.DeviceUpdateStatus(ctx, uid, status); err != nil {
+		return err
+	}
+
+	// NOTICE: the default tags are applied after the device is accepted, so a failure to apply them doesn't prevent
+	// the acceptance.
+	s.applyDefaultTags(ctx, namespace, device)
+
+	return nil
 }
 
 func (s *service) UpdateDevice(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
@@ -337,5 +546,22 @@ func (s *service) UpdateDevice(ctx context.Context, tenant string, uid models.UI
 		}
 	}
 
-	return s.store.DeviceUpdate(ctx, tenant, uid, name, publicURL)
+	if err := s.store.DeviceUpdate(ctx, tenant, uid, name, publicURL); err != nil {
+		return err
+	}
+
+	if name != nil {
+		s.publishDeviceEvent(ctx, tenant, string(uid), models.DeviceEventName)
+	}
+
+	details := map[string]string{}
+	if name != nil {
+		details["name"] = *name
+	}
+
+	if publicURL != nil {
+		details["public_url"] = strconv.FormatBool(*publicURL)
+	}
+
+	return s.recordAudit(ctx, tenant, models.AuditActionDeviceUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, details)
 }
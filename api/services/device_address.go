@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// recordDeviceAddress appends the remote address to the device's addresses history when it differs from the last one
+// recorded. When the namespace has the geo alerts enabled and the new address is located in a country different from
+// the previous one, the namespace's owner is notified.
+//
+// Failures are only logged, as the history must not prevent the device from authenticating.
+func (s *service) recordDeviceAddress(ctx context.Context, namespace *models.Namespace, device *models.Device, remoteAddr string) {
+	var last *models.DeviceAddress
+	if n := len(device.Addresses); n > 0 {
+		last = &device.Addresses[n-1]
+		if last.RemoteAddr == remoteAddr {
+			return
+		}
+	}
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"uid":         device.UID,
+		"tenant_id":   device.TenantID,
+		"remote_addr": remoteAddr,
+	})
+
+	country, err := s.locator.GetCountry(net.ParseIP(remoteAddr))
+	if err != nil {
+		logger.WithError(err).Warn("unable to locate the device's remote address")
+	}
+
+	address := models.DeviceAddress{
+		RemoteAddr: remoteAddr,
+		Country:    country,
+		SeenAt:     clock.Now(),
+	}
+
+	if err := s.store.DeviceAddAddress(ctx, models.UID(device.UID), address); err != nil {
+		logger.WithError(err).Error("unable to record the device's remote address")
+
+		return
+	}
+
+	if last == nil || last.Country == "" || address.Country == "" || last.Country == address.Country {
+		return
+	}
+
+	if namespace.Settings == nil || !namespace.Settings.DeviceGeoAlert {
+		return
+	}
+
+	owner, _, err := s.store.UserGetByID(ctx, namespace.Owner, false)
+	if err != nil {
+		logger.WithError(err).Error("unable to get the namespace's owner to notify the device's country change")
+
+		return
+	}
+
+	message := &mailer.Message{
+		To:      owner.Email,
+		Subject: fmt.Sprintf("Device %s is connecting from a new country", device.Name),
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nThe device %s, on namespace %s, is now connecting from %s (%s), while it was previously connecting from %s (%s).\n\nIf you did not expect this change, the device may have been stolen or cloned.\n",
+			owner.Name,
+			device.Name,
+			namespace.Name,
+			address.RemoteAddr,
+			address.Country,
+			last.RemoteAddr,
+			last.Country,
+		),
+	}
+
+	if err := s.mailer.Send(ctx, message); err != nil {
+		logger.WithError(err).Error("unable to notify the device's country change")
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	mockmailer "github.com/shellhub-io/shellhub/pkg/mailer/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestRecordDeviceAddress(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+	mailerMock := new(mockmailer.Mailer)
+	locatorMock := new(mocksGeoIp.Locator)
+
+	history := []models.DeviceAddress{{RemoteAddr: "192.168.0.1", Country: "BR", SeenAt: now}}
+
+	cases := []struct {
+		description   string
+		namespace     *models.Namespace
+		device        *models.Device
+		remoteAddr    string
+		requiredMocks func(context.Context)
+	}{
+		{
+			description: "does nothing when the address is the same as the last one",
+			namespace:   &models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"},
+			device:      &models.Device{UID: "uid", Addresses: history},
+			remoteAddr:  "192.168.0.1",
+			requiredMocks: func(_ context.Context) {
+			},
+		},
+		{
+			description: "records the address when the device has no history",
+			namespace:   &models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"},
+			device:      &models.Device{UID: "uid"},
+			remoteAddr:  "192.168.0.1",
+			requiredMocks: func(ctx context.Context) {
+				locatorMock.On("GetCountry", net.ParseIP("192.168.0.1")).Return("BR", nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("DeviceAddAddress", ctx, models.UID("uid"), models.DeviceAddress{RemoteAddr: "192.168.0.1", Country: "BR", SeenAt: now}).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			description: "does not notify when the alerts are disabled",
+			namespace:   &models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Settings: &models.NamespaceSettings{}},
+			device:      &models.Device{UID: "uid", Addresses: history},
+			remoteAddr:  "10.0.0.1",
+			requiredMocks: func(ctx context.Context) {
+				locatorMock.On("GetCountry", net.ParseIP("10.0.0.1")).Return("US", nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("DeviceAddAddress", ctx, models.UID("uid"), models.DeviceAddress{RemoteAddr: "10.0.0.1", Country: "US", SeenAt: now}).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			description: "does not notify when the address cannot be recorded",
+			namespace: &models.Namespace{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Settings: &models.NamespaceSettings{DeviceGeoAlert: true},
+			},
+			device:     &models.Device{UID: "uid", Addresses: history},
+			remoteAddr: "10.0.0.1",
+			requiredMocks: func(ctx context.Context) {
+				locatorMock.On("GetCountry", net.ParseIP("10.0.0.1")).Return("US", nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("DeviceAddAddress", ctx, models.UID("uid"), models.DeviceAddress{RemoteAddr: "10.0.0.1", Country: "US", SeenAt: now}).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+		},
+		{
+			description: "does not notify when the country is the same",
+			namespace: &models.Namespace{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Settings: &models.NamespaceSettings{DeviceGeoAlert: true},
+			},
+			device:     &models.Device{UID: "uid", Addresses: history},
+			remoteAddr: "192.168.0.2",
+			requiredMocks: func(ctx context.Context) {
+				locatorMock.On("GetCountry", net.ParseIP("192.168.0.2")).Return("BR", nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("DeviceAddAddress", ctx, models.UID("uid"), models.DeviceAddress{RemoteAddr: "192.168.0.2", Country: "BR", SeenAt: now}).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			description: "notifies the owner when the country changes",
+			namespace: &models.Namespace{
+				Name:     "dev",
+				Owner:    "000000000000000000000000",
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Settings: &models.NamespaceSettings{DeviceGeoAlert: true},
+			},
+			device:     &models.Device{UID: "uid", Name: "device", Addresses: history},
+			remoteAddr: "10.0.0.1",
+			requiredMocks: func(ctx context.Context) {
+				locatorMock.On("GetCountry", net.ParseIP("10.0.0.1")).Return("US", nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("DeviceAddAddress", ctx, models.UID("uid"), models.DeviceAddress{RemoteAddr: "10.0.0.1", Country: "US", SeenAt: now}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000", UserData: models.UserData{Name: "John Doe", Email: "john.doe@test.com"}}, 0, nil).
+					Once()
+				mailerMock.
+					On("Send", ctx, testifymock.MatchedBy(func(m *mailer.Message) bool {
+						return m.To == "john.doe@test.com"
+					})).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, cacheMock, clientMock, WithLocator(locatorMock), WithMailer(mailerMock))
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s.recordDeviceAddress(ctx, tc.namespace, tc.device, tc.remoteAddr)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	locatorMock.AssertExpectations(t)
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeviceAgentLogsRateLimit is the maximum number of logs a device's agent can report per minute.
+const DeviceAgentLogsRateLimit = 100
+
+type DeviceAgentLogService interface {
+	// CreateDeviceAgentLogs stores the logs reported by the device's agent. The device can only report its own logs,
+	// limited to [DeviceAgentLogsRateLimit] per minute.
+	CreateDeviceAgentLogs(ctx context.Context, req *requests.DeviceAgentLogsCreate) (err error)
+
+	// ListDeviceAgentLogs retrieves a list of logs reported by the agent of the tenant's device. It returns the list
+	// of logs, the total count of matched documents and an error if any.
+	ListDeviceAgentLogs(ctx context.Context, req *requests.DeviceAgentLogsList) (logs []models.DeviceAgentLog, count int, err error)
+}
+
+// deviceAgentLogsKey returns the cache's key used to count the logs reported by the device on the current minute.
+func deviceAgentLogsKey(uid string) string {
+	return fmt.Sprintf("agent-logs={%s}/%d", uid, clock.Now().Unix()/60)
+}
+
+func (s *service) CreateDeviceAgentLogs(ctx context.Context, req *requests.DeviceAgentLogsCreate) error {
+	if req.DeviceUID != req.UID {
+		return NewErrAuthForbidden()
+	}
+
+	key := deviceAgentLogsKey(req.UID)
+
+	var count int
+	if err := s.cache.Get(ctx, key, &count); err != nil {
+		log.WithContext(ctx).WithError(err).WithField("uid", req.UID).Warn("failed to get the device's agent logs count from cache")
+	}
+
+	if count+len(req.Logs) > DeviceAgentLogsRateLimit {
+		return NewErrDeviceAgentLogsLimit(DeviceAgentLogsRateLimit)
+	}
+
+	if err := s.cache.Set(ctx, key, count+len(req.Logs), time.Minute); err != nil {
+		log.WithContext(ctx).WithError(err).WithField("uid", req.UID).Warn("failed to set the device's agent logs count on cache")
+	}
+
+	logs := make([]models.DeviceAgentLog, len(req.Logs))
+	for i, l := range req.Logs {
+		logs[i] = models.DeviceAgentLog{
+			ID:        uuid.Generate(),
+			TenantID:  req.TenantID,
+			DeviceUID: req.UID,
+			Level:     l.Level,
+			Message:   l.Message,
+			Error:     l.Error,
+			Time:      l.Time,
+		}
+	}
+
+	return s.store.DeviceAgentLogCreateMany(ctx, logs)
+}
+
+func (s *service) ListDeviceAgentLogs(ctx context.Context, req *requests.DeviceAgentLogsList) ([]models.DeviceAgentLog, int, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, 0, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	return s.store.DeviceAgentLogList(ctx, req.TenantID, models.UID(req.UID), req.Level, req.Paginator)
+}
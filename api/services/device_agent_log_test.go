@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceAgentLogs(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	key := fmt.Sprintf("agent-logs={uid}/%d", now.Unix()/60)
+
+	logs := []models.DeviceAgentLog{
+		{Level: "error", Message: "Failed to start the PTY", Time: now},
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceAgentLogsCreate
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device reports logs of another device",
+			req: &requests.DeviceAgentLogsCreate{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				DeviceUID:   "other",
+				Logs:        logs,
+			},
+			requiredMocks: func(_ context.Context) {},
+			expected:      NewErrAuthForbidden(),
+		},
+		{
+			description: "fails when the rate limit is reached",
+			req: &requests.DeviceAgentLogsCreate{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				DeviceUID:   "uid",
+				Logs:        logs,
+			},
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, key, testifymock.Anything).
+					Run(func(args testifymock.Arguments) { *args.Get(2).(*int) = DeviceAgentLogsRateLimit }).
+					Return(nil).
+					Once()
+			},
+			expected: NewErrDeviceAgentLogsLimit(DeviceAgentLogsRateLimit),
+		},
+		{
+			description: "fails when the logs cannot be stored",
+			req: &requests.DeviceAgentLogsCreate{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				DeviceUID:   "uid",
+				Logs:        logs,
+			},
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, key, testifymock.Anything).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, key, 1, time.Minute).
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000000").
+					Once()
+				storeMock.
+					On("DeviceAgentLogCreateMany", ctx, []models.DeviceAgentLog{
+						{
+							ID:        "00000000-0000-4000-0000-000000000000",
+							TenantID:  "00000000-0000-4000-0000-000000000000",
+							DeviceUID: "uid",
+							Level:     "error",
+							Message:   "Failed to start the PTY",
+							Time:      now,
+						},
+					}).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceAgentLogsCreate{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				DeviceUID:   "uid",
+				Logs:        logs,
+			},
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, key, testifymock.Anything).
+					Run(func(args testifymock.Arguments) { *args.Get(2).(*int) = 10 }).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, key, 11, time.Minute).
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000000").
+					Once()
+				storeMock.
+					On("DeviceAgentLogCreateMany", ctx, []models.DeviceAgentLog{
+						{
+							ID:        "00000000-0000-4000-0000-000000000000",
+							TenantID:  "00000000-0000-4000-0000-000000000000",
+							DeviceUID: "uid",
+							Level:     "error",
+							Message:   "Failed to start the PTY",
+							Time:      now,
+						},
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.CreateDeviceAgentLogs(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
+
+func TestListDeviceAgentLogs(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		logs  []models.DeviceAgentLog
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceAgentLogsList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceAgentLogsList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, 0, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceAgentLogsList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Level:       "error",
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceAgentLogList", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), "error", query.Paginator{Page: 1, PerPage: 10}).
+					Return([]models.DeviceAgentLog{{ID: "id", DeviceUID: "uid", Level: "error"}}, 1, nil).
+					Once()
+			},
+			expected: Expected{[]models.DeviceAgentLog{{ID: "id", DeviceUID: "uid", Level: "error"}}, 1, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			logs, count, err := s.ListDeviceAgentLogs(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{logs, count, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DeviceChangesDefaultLimit is the number of changes returned when the request doesn't limit them.
+	DeviceChangesDefaultLimit = 100
+	// DeviceChangesRetention is how long the change of a deleted device is kept. A sync whose cursor is older than it
+	// may miss the deletions, so it must be started again from the beginning.
+	DeviceChangesRetention = 30 * 24 * time.Hour
+)
+
+type DeviceChangesService interface {
+	// ListDeviceChanges retrieves the changes on the tenant's devices since the request's cursor, in the order they
+	// happened, with the devices' current records. As only the last change of each device is kept, the created and
+	// updated changes must be applied as upserts.
+	ListDeviceChanges(ctx context.Context, req *requests.DeviceChangesList) (*responses.DeviceChanges, error)
+}
+
+func (s *service) ListDeviceChanges(ctx context.Context, req *requests.DeviceChangesList) (*responses.DeviceChanges, error) {
+	// NOTICE: the cursor is validated as numeric by the request.
+	since, _ := strconv.ParseInt(req.Since, 10, 64)
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DeviceChangesDefaultLimit
+	}
+
+	changes, err := s.store.DeviceChangeList(ctx, req.TenantID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &responses.DeviceChanges{Changes: make([]models.DeviceChange, 0, len(changes)), Cursor: strconv.FormatInt(since, 10)}
+	for _, change := range changes {
+		res.Cursor = strconv.FormatInt(change.Seq, 10)
+
+		if change.Type != models.DeviceChangeDeleted {
+			device, err := s.store.DeviceGetByUID(ctx, models.UID(change.UID), req.TenantID)
+			// NOTICE: a device deleted after its change was listed has a newer change, returned on the next request.
+			if err != nil {
+				continue
+			}
+
+			change.Device = device
+		}
+
+		res.Changes = append(res.Changes, change)
+	}
+
+	res.HasMore = len(changes) == limit
+
+	return res, nil
+}
+
+// deviceChangeTypes maps the device events recorded as changes to the kinds of change they are. The other events, like
+// the device getting online, don't change the device's record for the external systems.
+var deviceChangeTypes = map[models.DeviceEventType]models.DeviceChangeType{
+	models.DeviceEventCreated: models.DeviceChangeCreated,
+	models.DeviceEventStatus:  models.DeviceChangeUpdated,
+	models.DeviceEventName:    models.DeviceChangeUpdated,
+	models.DeviceEventTags:    models.DeviceChangeUpdated,
+	models.DeviceEventQueue:   models.DeviceChangeUpdated,
+	models.DeviceEventRemoved: models.DeviceChangeDeleted,
+}
+
+// recordDeviceChange records the device event as the device's last change, when the event changes the device's
+// record. As the change is recorded after the device was changed, a failure is logged and doesn't fail the operation.
+func (s *service) recordDeviceChange(ctx context.Context, tenant, uid string, kind models.DeviceEventType) {
+	typ, ok := deviceChangeTypes[kind]
+	if !ok || uid == "" {
+		return
+	}
+
+	change := &models.DeviceChange{TenantID: tenant, UID: uid, Type: typ, ChangedAt: clock.Now()}
+	if typ == models.DeviceChangeDeleted {
+		expiresAt := change.ChangedAt.Add(DeviceChangesRetention)
+		change.ExpiresAt = &expiresAt
+	}
+
+	if err := s.store.DeviceChangeRecord(ctx, change); err != nil {
+		log.WithContext(ctx).WithError(err).
+			WithFields(log.Fields{"tenant_id": tenant, "uid": uid, "type": typ}).
+			Warn("failed to record the device change")
+	}
+}
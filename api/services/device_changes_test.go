@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListDeviceChanges(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+
+	type Expected struct {
+		changes *responses.DeviceChanges
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceChangesList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the changes cannot be listed",
+			req:         &requests.DeviceChangesList{TenantID: tenant},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceChangeList", ctx, tenant, int64(0), DeviceChangesDefaultLimit).
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: Expected{err: errors.New("error")},
+		},
+		{
+			description: "succeeds keeping the cursor when there are no changes",
+			req:         &requests.DeviceChangesList{TenantID: tenant, Since: "42"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceChangeList", ctx, tenant, int64(42), DeviceChangesDefaultLimit).
+					Return([]models.DeviceChange{}, nil).
+					Once()
+			},
+			expected: Expected{changes: &responses.DeviceChanges{Changes: []models.DeviceChange{}, Cursor: "42"}},
+		},
+		{
+			description: "succeeds with the current devices, skipping the devices removed since their changes",
+			req:         &requests.DeviceChangesList{TenantID: tenant, Since: "10", Limit: 3},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceChangeList", ctx, tenant, int64(10), 3).
+					Return([]models.DeviceChange{
+						{Seq: 11, UID: "created", Type: models.DeviceChangeCreated},
+						{Seq: 12, UID: "deleted", Type: models.DeviceChangeDeleted},
+						{Seq: 13, UID: "gone", Type: models.DeviceChangeUpdated},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("created"), tenant).
+					Return(&models.Device{UID: "created", Name: "name"}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("gone"), tenant).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{changes: &responses.DeviceChanges{
+				Changes: []models.DeviceChange{
+					{Seq: 11, UID: "created", Type: models.DeviceChangeCreated, Device: &models.Device{UID: "created", Name: "name"}},
+					{Seq: 12, UID: "deleted", Type: models.DeviceChangeDeleted},
+				},
+				Cursor:  "13",
+				HasMore: true,
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+			changes, err := s.ListDeviceChanges(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{changes, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRecordDeviceChange(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clockMock.On("Now").Return(now)
+
+	ctx := context.Background()
+	tenant := "00000000-0000-4000-0000-000000000000"
+
+	storeMock.
+		On("DeviceChangeRecord", ctx, mock.MatchedBy(func(change *models.DeviceChange) bool {
+			return change.UID == "created" && change.Type == models.DeviceChangeCreated && change.ExpiresAt == nil
+		})).
+		Return(nil).
+		Once()
+	storeMock.
+		On("DeviceChangeRecord", ctx, mock.MatchedBy(func(change *models.DeviceChange) bool {
+			return change.UID == "renamed" && change.Type == models.DeviceChangeUpdated && change.ExpiresAt == nil
+		})).
+		Return(nil).
+		Once()
+	storeMock.
+		On("DeviceChangeRecord", ctx, mock.MatchedBy(func(change *models.DeviceChange) bool {
+			return change.UID == "removed" && change.Type == models.DeviceChangeDeleted &&
+				change.ExpiresAt != nil && change.ExpiresAt.Equal(change.ChangedAt.Add(DeviceChangesRetention))
+		})).
+		Return(errors.New("error")).
+		Once()
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+	s.recordDeviceChange(ctx, tenant, "created", models.DeviceEventCreated)
+	s.recordDeviceChange(ctx, tenant, "renamed", models.DeviceEventName)
+	s.recordDeviceChange(ctx, tenant, "removed", models.DeviceEventRemoved)
+	// NOTICE: the device getting online doesn't change its record and the resync isn't a device's change.
+	s.recordDeviceChange(ctx, tenant, "online", models.DeviceEventOnline)
+	s.recordDeviceChange(ctx, tenant, "", models.DeviceEventResync)
+
+	storeMock.AssertExpectations(t)
+}
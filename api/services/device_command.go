@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// DeviceCommandsDispatchLimit is the maximum number of queued commands the device's agent fetches at once. The
+// remaining ones are fetched on its next authorization.
+const DeviceCommandsDispatchLimit = 10
+
+type DeviceCommandService interface {
+	// CreateDeviceCommand queues a command to the tenant's device, to be executed by its agent when it authorizes on
+	// the server, even if the device is offline now.
+	CreateDeviceCommand(ctx context.Context, req *requests.DeviceCommandCreate) (command *models.DeviceCommand, err error)
+
+	// ListDeviceCommands retrieves a list of the commands queued to the tenant's device, with their results. It
+	// returns the list of commands, the total count of matched documents and an error if any.
+	ListDeviceCommands(ctx context.Context, req *requests.DeviceCommandsList) (commands []models.DeviceCommand, count int, err error)
+
+	// DispatchDeviceCommands retrieves up to [DeviceCommandsDispatchLimit] commands queued to the device, marking them
+	// as sent. The device can only fetch its own commands.
+	DispatchDeviceCommands(ctx context.Context, req *requests.DeviceCommandsDispatch) (commands []models.DeviceCommand, err error)
+
+	// FinishDeviceCommand records the exit code and the output of a command sent to the device. The device can only
+	// report the result of its own commands, once.
+	FinishDeviceCommand(ctx context.Context, req *requests.DeviceCommandFinish) (err error)
+}
+
+func (s *service) CreateDeviceCommand(ctx context.Context, req *requests.DeviceCommandCreate) (*models.DeviceCommand, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	user := req.User
+	if user == "" {
+		user = "root"
+	}
+
+	command := &models.DeviceCommand{
+		ID:        uuid.Generate(),
+		TenantID:  req.TenantID,
+		DeviceUID: req.UID,
+		Command:   req.Command,
+		User:      user,
+		Status:    models.DeviceCommandStatusQueued,
+		CreatedBy: req.UserID,
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.DeviceCommandCreate(ctx, command); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+func (s *service) ListDeviceCommands(ctx context.Context, req *requests.DeviceCommandsList) ([]models.DeviceCommand, int, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, 0, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	return s.store.DeviceCommandList(ctx, req.TenantID, models.UID(req.UID), req.Paginator)
+}
+
+func (s *service) DispatchDeviceCommands(ctx context.Context, req *requests.DeviceCommandsDispatch) ([]models.DeviceCommand, error) {
+	if req.DeviceUID != req.UID {
+		return nil, NewErrAuthForbidden()
+	}
+
+	return s.store.DeviceCommandDispatch(ctx, req.TenantID, models.UID(req.UID), DeviceCommandsDispatchLimit, clock.Now())
+}
+
+func (s *service) FinishDeviceCommand(ctx context.Context, req *requests.DeviceCommandFinish) error {
+	if req.DeviceUID != req.UID {
+		return NewErrAuthForbidden()
+	}
+
+	if err := s.store.DeviceCommandFinish(ctx, req.TenantID, models.UID(req.UID), req.ID, req.DeviceCommandResult, clock.Now()); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrDeviceCommandNotFound(req.ID, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
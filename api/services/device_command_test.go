@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDeviceCommand(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	type Expected struct {
+		command *models.DeviceCommand
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceCommandCreate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceCommandCreate{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "507f1f77bcf86cd799439011",
+				Command:     "apt-get update",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the command cannot be stored",
+			req: &requests.DeviceCommandCreate{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "507f1f77bcf86cd799439011",
+				Command:     "apt-get update",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("DeviceCommandCreate", ctx, &models.DeviceCommand{
+						ID:        "00000000-0000-4000-0000-000000000001",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						DeviceUID: "uid",
+						Command:   "apt-get update",
+						User:      "root",
+						Status:    models.DeviceCommandStatusQueued,
+						CreatedBy: "507f1f77bcf86cd799439011",
+						CreatedAt: now,
+					}).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{nil, errors.New("error", "", 0)},
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceCommandCreate{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "507f1f77bcf86cd799439011",
+				Command:     "systemctl restart app",
+				User:        "app",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("DeviceCommandCreate", ctx, &models.DeviceCommand{
+						ID:        "00000000-0000-4000-0000-000000000001",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						DeviceUID: "uid",
+						Command:   "systemctl restart app",
+						User:      "app",
+						Status:    models.DeviceCommandStatusQueued,
+						CreatedBy: "507f1f77bcf86cd799439011",
+						CreatedAt: now,
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				&models.DeviceCommand{
+					ID:        "00000000-0000-4000-0000-000000000001",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					DeviceUID: "uid",
+					Command:   "systemctl restart app",
+					User:      "app",
+					Status:    models.DeviceCommandStatusQueued,
+					CreatedBy: "507f1f77bcf86cd799439011",
+					CreatedAt: now,
+				},
+				nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			command, err := s.CreateDeviceCommand(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{command, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestListDeviceCommands(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		commands []models.DeviceCommand
+		count    int
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceCommandsList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceCommandsList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, 0, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceCommandsList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceCommandList", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), query.Paginator{Page: 1, PerPage: 10}).
+					Return([]models.DeviceCommand{{ID: "id", DeviceUID: "uid", Status: models.DeviceCommandStatusQueued}}, 1, nil).
+					Once()
+			},
+			expected: Expected{[]models.DeviceCommand{{ID: "id", DeviceUID: "uid", Status: models.DeviceCommandStatusQueued}}, 1, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			commands, count, err := s.ListDeviceCommands(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{commands, count, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDispatchDeviceCommands(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	type Expected struct {
+		commands []models.DeviceCommand
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceCommandsDispatch
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device fetches the commands of another device",
+			req: &requests.DeviceCommandsDispatch{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				DeviceUID:   "other",
+			},
+			requiredMocks: func(_ context.Context) {},
+			expected:      Expected{nil, NewErrAuthForbidden()},
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceCommandsDispatch{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				DeviceUID:   "uid",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceCommandDispatch", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), DeviceCommandsDispatchLimit, now).
+					Return([]models.DeviceCommand{{ID: "id", DeviceUID: "uid", Command: "reboot", Status: models.DeviceCommandStatusSent}}, nil).
+					Once()
+			},
+			expected: Expected{[]models.DeviceCommand{{ID: "id", DeviceUID: "uid", Command: "reboot", Status: models.DeviceCommandStatusSent}}, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			commands, err := s.DispatchDeviceCommands(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{commands, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestFinishDeviceCommand(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	result := models.DeviceCommandResult{ExitCode: 0, Output: "done"}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceCommandFinish
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device reports the result of another device's command",
+			req: &requests.DeviceCommandFinish{
+				DeviceParam:         requests.DeviceParam{UID: "uid"},
+				TenantID:            "00000000-0000-4000-0000-000000000000",
+				DeviceUID:           "other",
+				ID:                  "id",
+				DeviceCommandResult: result,
+			},
+			requiredMocks: func(_ context.Context) {},
+			expected:      NewErrAuthForbidden(),
+		},
+		{
+			description: "fails when the command isn't sent to the device",
+			req: &requests.DeviceCommandFinish{
+				DeviceParam:         requests.DeviceParam{UID: "uid"},
+				TenantID:            "00000000-0000-4000-0000-000000000000",
+				DeviceUID:           "uid",
+				ID:                  "id",
+				DeviceCommandResult: result,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceCommandFinish", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), "id", result, now).
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceCommandNotFound("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceCommandFinish{
+				DeviceParam:         requests.DeviceParam{UID: "uid"},
+				TenantID:            "00000000-0000-4000-0000-000000000000",
+				DeviceUID:           "uid",
+				ID:                  "id",
+				DeviceCommandResult: result,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceCommandFinish", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), "id", result, now).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.FinishDeviceCommand(ctx, tc.req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
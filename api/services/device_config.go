@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceConfigService interface {
+	// UpdateDeviceConfig replaces the configuration delivered to the tenant's device agent, incrementing its version.
+	// The agent applies it on its next authorization and reports the applied version back.
+	UpdateDeviceConfig(ctx context.Context, req *requests.DeviceUpdateConfig) (*models.DeviceConfig, error)
+}
+
+func (s *service) UpdateDeviceConfig(ctx context.Context, req *requests.DeviceUpdateConfig) (*models.DeviceConfig, error) {
+	for key, value := range req.Values {
+		if !models.IsDeviceConfigValueValid(key, value) {
+			return nil, NewErrDeviceConfigInvalid(key, value)
+		}
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	values := req.Values
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	if err := s.store.DeviceSetConfig(ctx, req.TenantID, models.UID(device.UID), values, clock.Now()); err != nil {
+		return nil, err
+	}
+
+	device, err = s.store.DeviceGetByUID(ctx, models.UID(device.UID), req.TenantID)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	return device.Config, nil
+}
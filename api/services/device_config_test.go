@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDeviceConfig(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clockMock.On("Now").Return(now)
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+
+	type Expected struct {
+		config *models.DeviceConfig
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceUpdateConfig
+		requiredMocks func(ctx context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when a value isn't accepted by its key",
+			req: &requests.DeviceUpdateConfig{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    tenant,
+				Values:      map[string]string{models.DeviceConfigSFTP: "write-only"},
+			},
+			requiredMocks: func(_ context.Context) {},
+			expected:      Expected{err: NewErrDeviceConfigInvalid(models.DeviceConfigSFTP, "write-only")},
+		},
+		{
+			description: "fails when the device isn't found",
+			req: &requests.DeviceUpdateConfig{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    tenant,
+				Values:      map[string]string{models.DeviceConfigLogLevel: "debug"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{err: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds replacing the device's config",
+			req: &requests.DeviceUpdateConfig{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    tenant,
+				Values: map[string]string{
+					models.DeviceConfigLogLevel:      "debug",
+					models.DeviceConfigForcedCommand: "/usr/bin/htop",
+					models.DeviceConfigSFTP:          models.DeviceConfigSFTPReadOnly,
+				},
+			},
+			requiredMocks: func(ctx context.Context) {
+				values := map[string]string{
+					models.DeviceConfigLogLevel:      "debug",
+					models.DeviceConfigForcedCommand: "/usr/bin/htop",
+					models.DeviceConfigSFTP:          models.DeviceConfigSFTPReadOnly,
+				}
+
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetConfig", ctx, tenant, models.UID("uid"), values, now).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant, Config: &models.DeviceConfig{Values: values, Version: 2, UpdatedAt: now}}, nil).
+					Once()
+			},
+			expected: Expected{
+				config: &models.DeviceConfig{
+					Values: map[string]string{
+						models.DeviceConfigLogLevel:      "debug",
+						models.DeviceConfigForcedCommand: "/usr/bin/htop",
+						models.DeviceConfigSFTP:          models.DeviceConfigSFTPReadOnly,
+					},
+					Version:   2,
+					UpdatedAt: now,
+				},
+			},
+		},
+		{
+			description: "succeeds clearing the device's config",
+			req: &requests.DeviceUpdateConfig{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    tenant,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetConfig", ctx, tenant, models.UID("uid"), map[string]string{}, now).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant, Config: &models.DeviceConfig{Values: map[string]string{}, Version: 3, UpdatedAt: now}}, nil).
+					Once()
+			},
+			expected: Expected{config: &models.DeviceConfig{Values: map[string]string{}, Version: 3, UpdatedAt: now}},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			config, err := s.UpdateDeviceConfig(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{config, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// recordDeviceConnection appends the connection measure reported by the agent to the device's connection rolling
+// window, updating its connection quality. Agents that don't report the measure are ignored.
+//
+// Failures are only logged, as the rolling window must not prevent the device from authenticating.
+func (s *service) recordDeviceConnection(ctx context.Context, device *models.Device, connection *requests.DeviceConnection) {
+	if connection == nil {
+		return
+	}
+
+	sample := models.DeviceConnectionSample{
+		RTT:        connection.RTT,
+		Reconnects: connection.Reconnects,
+		ReportedAt: clock.Now(),
+	}
+
+	samples := append(slices.Clone(device.ConnectionSamples), sample)
+	if len(samples) > models.DeviceConnectionSamplesMax {
+		samples = samples[len(samples)-models.DeviceConnectionSamplesMax:]
+	}
+
+	if err := s.store.DeviceAddConnectionSample(ctx, models.UID(device.UID), sample, models.NewDeviceConnectionQuality(samples)); err != nil {
+		log.WithContext(ctx).WithError(err).
+			WithFields(log.Fields{"uid": device.UID, "tenant_id": device.TenantID}).
+			Error("unable to record the device's connection measure")
+	}
+}
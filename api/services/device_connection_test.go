@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func TestRecordDeviceConnection(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+
+	full := make([]models.DeviceConnectionSample, models.DeviceConnectionSamplesMax)
+	for i := range full {
+		full[i] = models.DeviceConnectionSample{RTT: 600, Reconnects: 1}
+	}
+
+	cases := []struct {
+		description   string
+		device        *models.Device
+		connection    *requests.DeviceConnection
+		requiredMocks func(context.Context)
+	}{
+		{
+			description:   "does nothing when the agent doesn't report the measure",
+			device:        &models.Device{UID: "uid"},
+			connection:    nil,
+			requiredMocks: func(_ context.Context) {},
+		},
+		{
+			description: "records the measure when the device has no samples",
+			device:      &models.Device{UID: "uid"},
+			connection:  &requests.DeviceConnection{RTT: 100, Reconnects: 0},
+			requiredMocks: func(ctx context.Context) {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On(
+						"DeviceAddConnectionSample",
+						ctx,
+						models.UID("uid"),
+						models.DeviceConnectionSample{RTT: 100, Reconnects: 0, ReportedAt: now},
+						&models.DeviceConnectionQuality{Level: models.DeviceConnectionQualityGood, RTT: 100, Reconnects: 0, Samples: 1},
+					).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			description: "summarizes only the samples kept on the rolling window",
+			device:      &models.Device{UID: "uid", ConnectionSamples: full},
+			connection:  &requests.DeviceConnection{RTT: 0, Reconnects: 0},
+			requiredMocks: func(ctx context.Context) {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On(
+						"DeviceAddConnectionSample",
+						ctx,
+						models.UID("uid"),
+						models.DeviceConnectionSample{RTT: 0, Reconnects: 0, ReportedAt: now},
+						&models.DeviceConnectionQuality{
+							Level:      models.DeviceConnectionQualityPoor,
+							RTT:        600 * int64(models.DeviceConnectionSamplesMax-1) / int64(models.DeviceConnectionSamplesMax),
+							Reconnects: models.DeviceConnectionSamplesMax - 1,
+							Samples:    models.DeviceConnectionSamplesMax,
+						},
+					).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			description: "does not fail when the measure cannot be recorded",
+			device:      &models.Device{UID: "uid"},
+			connection:  &requests.DeviceConnection{RTT: 200, Reconnects: 1},
+			requiredMocks: func(ctx context.Context) {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On(
+						"DeviceAddConnectionSample",
+						ctx,
+						models.UID("uid"),
+						models.DeviceConnectionSample{RTT: 200, Reconnects: 1, ReportedAt: now},
+						&models.DeviceConnectionQuality{Level: models.DeviceConnectionQualityFair, RTT: 200, Reconnects: 1, Samples: 1},
+					).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s.recordDeviceConnection(ctx, tc.device, tc.connection)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
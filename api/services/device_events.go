@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeviceEventsBufferSize is the number of device events kept for a subscriber that is not consuming them fast
+// enough. When the buffer is full, the next events are dropped and a [models.DeviceEventResync] is delivered as soon
+// as the subscriber catches up.
+const DeviceEventsBufferSize = 32
+
+type DeviceEventsService interface {
+	// SubscribeDeviceEvents returns a channel that receives the changes on the devices of the tenant until ctx is
+	// done, when the channel is closed.
+	SubscribeDeviceEvents(ctx context.Context, req *requests.DeviceEventsSubscribe) (<-chan models.DeviceEvent, error)
+}
+
+// deviceEventsTopic returns the events bus' topic where the device events of a tenant are published.
+func deviceEventsTopic(tenant string) string {
+	return "devices:" + tenant
+}
+
+// publishDeviceEvent notifies the subscribers of the tenant about a change on a device, recording it on the device
+// changes synced by the external systems. As the event is only a hint to the subscribers, a failure to publish it is
+// logged and doesn't fail the operation that changed the device.
+func (s *service) publishDeviceEvent(ctx context.Context, tenant, uid string, kind models.DeviceEventType) {
+	s.recordDeviceChange(ctx, tenant, uid, kind)
+
+	data, err := json.Marshal(&models.DeviceEvent{Type: kind, UID: uid, TenantID: tenant})
+	if err != nil {
+		return
+	}
+
+	if err := s.events.Publish(ctx, deviceEventsTopic(tenant), data); err != nil {
+		log.WithContext(ctx).WithError(err).
+			WithFields(log.Fields{"tenant_id": tenant, "uid": uid, "type": kind}).
+			Warn("failed to publish the device event")
+	}
+}
+
+// SubscribeDeviceEvents subscribes to the device events of the tenant, filtered by the device's status and tags.
+//
+// The event carries the device's state after the change. Events of devices that don't match the filters are not
+// delivered, except the status and tags changes, so the subscriber can drop the devices that left the filtered set.
+func (s *service) SubscribeDeviceEvents(ctx context.Context, req *requests.DeviceEventsSubscribe) (<-chan models.DeviceEvent, error) {
+	messages, err := s.events.Subscribe(ctx, deviceEventsTopic(req.TenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan models.DeviceEvent, DeviceEventsBufferSize)
+
+	go func() {
+		defer close(events)
+
+		resync := false
+		for {
+			// NOTICE: sending on a nil channel blocks forever, so the resync event is only sent when it's pending.
+			var pending chan models.DeviceEvent
+			if resync {
+				pending = events
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case pending <- models.DeviceEvent{Type: models.DeviceEventResync, TenantID: req.TenantID}:
+				resync = false
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				// NOTICE: the subscriber is going to list the devices again, so the events until there are useless.
+				if resync {
+					continue
+				}
+
+				event := s.deviceEvent(ctx, req, message)
+				if event == nil {
+					continue
+				}
+
+				select {
+				case events <- *event:
+				default:
+					resync = true
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// deviceEvent decodes the event published on the bus, filling it with the device's current state. It returns nil
+// when the event must not be delivered to the subscriber.
+func (s *service) deviceEvent(ctx context.Context, req *requests.DeviceEventsSubscribe, message []byte) *models.DeviceEvent {
+	event := new(models.DeviceEvent)
+	if err := json.Unmarshal(message, event); err != nil || event.TenantID != req.TenantID {
+		return nil
+	}
+
+	switch event.Type {
+	case models.DeviceEventResync, models.DeviceEventRemoved, models.DeviceEventPendingLimit:
+		return event
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(event.UID), req.TenantID)
+	if err != nil {
+		return nil
+	}
+
+	event.Device = device
+
+	switch event.Type {
+	case models.DeviceEventStatus, models.DeviceEventTags, models.DeviceEventAcceptedLimit, models.DeviceEventQueue:
+		return event
+	}
+
+	if req.Status != "" && device.Status != req.Status {
+		return nil
+	}
+
+	if len(req.Tags) > 0 && !models.TagsMatch(device.Tags, req.Tags) {
+		return nil
+	}
+
+	return event
+}
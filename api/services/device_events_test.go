@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	storemocks "github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/events"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// channelBus is a [events.Bus] whose subscription is a channel controlled by the test.
+type channelBus struct {
+	messages chan []byte
+}
+
+func (*channelBus) Publish(context.Context, string, []byte) error {
+	return nil
+}
+
+func (b *channelBus) Subscribe(context.Context, string) (<-chan []byte, error) {
+	return b.messages, nil
+}
+
+func deviceEventMessage(t *testing.T, event models.DeviceEvent) []byte {
+	data, err := json.Marshal(&event)
+	require.NoError(t, err)
+
+	return data
+}
+
+func receiveDeviceEvent(t *testing.T, events <-chan models.DeviceEvent) models.DeviceEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("device event not received")
+	}
+
+	return models.DeviceEvent{}
+}
+
+func TestSubscribeDeviceEvents(t *testing.T) {
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	accepted := &models.Device{UID: "accepted", TenantID: tenant, Status: models.DeviceStatusAccepted, Tags: []string{"region/europe"}}
+	pending := &models.Device{UID: "pending", TenantID: tenant, Status: models.DeviceStatusPending, Tags: []string{"lab"}}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceEventsSubscribe
+		published     []models.DeviceEvent
+		requiredMocks func(*storemocks.Store)
+		expected      []models.DeviceEvent
+	}{
+		{
+			description: "delivers the events with the device's state",
+			req:         &requests.DeviceEventsSubscribe{TenantID: tenant},
+			published: []models.DeviceEvent{
+				{Type: models.DeviceEventOnline, UID: "accepted", TenantID: tenant},
+				{Type: models.DeviceEventRemoved, UID: "removed", TenantID: tenant},
+				{Type: models.DeviceEventResync, TenantID: tenant},
+			},
+			requiredMocks: func(storeMock *storemocks.Store) {
+				storeMock.On("DeviceGetByUID", testifymock.Anything, models.UID("accepted"), tenant).Return(accepted, nil).Once()
+			},
+			expected: []models.DeviceEvent{
+				{Type: models.DeviceEventOnline, UID: "accepted", TenantID: tenant, Device: accepted},
+				{Type: models.DeviceEventRemoved, UID: "removed", TenantID: tenant},
+				{Type: models.DeviceEventResync, TenantID: tenant},
+			},
+		},
+		{
+			description: "filters the events by the device's status and tags",
+			req:         &requests.DeviceEventsSubscribe{TenantID: tenant, Status: models.DeviceStatusAccepted, Tags: []string{"region"}},
+			published: []models.DeviceEvent{
+				{Type: models.DeviceEventOffline, UID: "pending", TenantID: tenant},
+				{Type: models.DeviceEventOnline, UID: "accepted", TenantID: tenant},
+			},
+			requiredMocks: func(storeMock *storemocks.Store) {
+				storeMock.On("DeviceGetByUID", testifymock.Anything, models.UID("pending"), tenant).Return(pending, nil).Once()
+				storeMock.On("DeviceGetByUID", testifymock.Anything, models.UID("accepted"), tenant).Return(accepted, nil).Once()
+			},
+			expected: []models.DeviceEvent{
+				{Type: models.DeviceEventOnline, UID: "accepted", TenantID: tenant, Device: accepted},
+			},
+		},
+		{
+			description: "delivers the status and tags changes of devices that left the filter",
+			req:         &requests.DeviceEventsSubscribe{TenantID: tenant, Status: models.DeviceStatusAccepted},
+			published: []models.DeviceEvent{
+				{Type: models.DeviceEventStatus, UID: "pending", TenantID: tenant},
+			},
+			requiredMocks: func(storeMock *storemocks.Store) {
+				storeMock.On("DeviceGetByUID", testifymock.Anything, models.UID("pending"), tenant).Return(pending, nil).Once()
+			},
+			expected: []models.DeviceEvent{
+				{Type: models.DeviceEventStatus, UID: "pending", TenantID: tenant, Device: pending},
+			},
+		},
+		{
+			description: "ignores the events of other tenants",
+			req:         &requests.DeviceEventsSubscribe{TenantID: tenant},
+			published: []models.DeviceEvent{
+				{Type: models.DeviceEventResync, TenantID: "00000000-0000-4000-0000-000000000001"},
+				{Type: models.DeviceEventRemoved, UID: "removed", TenantID: tenant},
+			},
+			requiredMocks: func(*storemocks.Store) {},
+			expected: []models.DeviceEvent{
+				{Type: models.DeviceEventRemoved, UID: "removed", TenantID: tenant},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemocks.Store)
+			tc.requiredMocks(storeMock)
+
+			bus := &channelBus{messages: make(chan []byte)}
+			s := NewService(storeMock, privateKey, publicKey, cache.NewNullCache(), clientMock, WithEventBus(bus))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			subscription, err := s.SubscribeDeviceEvents(ctx, tc.req)
+			require.NoError(t, err)
+
+			for _, event := range tc.published {
+				bus.messages <- deviceEventMessage(t, event)
+			}
+
+			for _, expected := range tc.expected {
+				assert.Equal(t, expected, receiveDeviceEvent(t, subscription))
+			}
+
+			assert.Empty(t, subscription)
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubscribeDeviceEvents_backpressure(t *testing.T) {
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	storeMock := new(storemocks.Store)
+	bus := &channelBus{messages: make(chan []byte)}
+	s := NewService(storeMock, privateKey, publicKey, cache.NewNullCache(), clientMock, WithEventBus(bus))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscription, err := s.SubscribeDeviceEvents(ctx, &requests.DeviceEventsSubscribe{TenantID: tenant})
+	require.NoError(t, err)
+
+	// NOTICE: as the bus' channel is unbuffered, the last message is only received after the previous one, which
+	// overflows the subscription's buffer, was handled.
+	for i := 0; i < DeviceEventsBufferSize+2; i++ {
+		bus.messages <- deviceEventMessage(t, models.DeviceEvent{Type: models.DeviceEventRemoved, UID: "removed", TenantID: tenant})
+	}
+
+	for i := 0; i < DeviceEventsBufferSize; i++ {
+		assert.Equal(t, models.DeviceEventRemoved, receiveDeviceEvent(t, subscription).Type)
+	}
+
+	assert.Equal(t, models.DeviceEvent{Type: models.DeviceEventResync, TenantID: tenant}, receiveDeviceEvent(t, subscription))
+
+	bus.messages <- deviceEventMessage(t, models.DeviceEvent{Type: models.DeviceEventRemoved, UID: "removed", TenantID: tenant})
+	assert.Equal(t, models.DeviceEventRemoved, receiveDeviceEvent(t, subscription).Type)
+
+	cancel()
+
+	_, ok := <-subscription
+	assert.False(t, ok)
+}
+
+func TestPublishDeviceEvent(t *testing.T) {
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	storeMock := new(storemocks.Store)
+	bus := events.NewLocalBus()
+	s := NewService(storeMock, privateKey, publicKey, cache.NewNullCache(), clientMock, WithEventBus(bus))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, err := bus.Subscribe(ctx, "devices:"+tenant)
+	require.NoError(t, err)
+
+	storeMock.On("DeviceSetOffline", ctx, "uid").Return(nil).Once()
+	storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: tenant}, nil).Once()
+
+	require.NoError(t, s.OfflineDevice(ctx, models.UID("uid")))
+
+	assert.Equal(t, deviceEventMessage(t, models.DeviceEvent{Type: models.DeviceEventOffline, UID: "uid", TenantID: tenant}), <-messages)
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceHeartbeatService interface {
+	// EnqueueDevicesHeartbeat buffers a batch of the devices' heartbeats on the worker's queue, where they are flushed
+	// periodically to the store by [TaskDevicesHeartbeat], instead of writing each one of them on the devices.
+	EnqueueDevicesHeartbeat(ctx context.Context, req *requests.DevicesHeartbeat) error
+}
+
+func (s *service) EnqueueDevicesHeartbeat(ctx context.Context, req *requests.DevicesHeartbeat) error {
+	now := clock.Now()
+
+	heartbeats := make([]models.ConnectedDevice, 0, len(req.Heartbeats))
+	for _, h := range req.Heartbeats {
+		seenAt := time.Unix(h.SeenAt, 0)
+		// NOTICE: a heartbeat from the future, sent by a service with a skewed clock, would keep the device online
+		// after its connection was closed.
+		if seenAt.After(now) {
+			seenAt = now
+		}
+
+		heartbeats = append(heartbeats, models.ConnectedDevice{UID: h.UID, TenantID: h.TenantID, LastSeen: seenAt})
+	}
+
+	return s.client.EnqueueDevicesHeartbeat(ctx, heartbeats)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnqueueDevicesHeartbeat(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clockMock.On("Now").Return(now)
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+	seenAt := now.Add(-time.Minute).Truncate(time.Second)
+
+	cases := []struct {
+		description   string
+		req           *requests.DevicesHeartbeat
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the heartbeats cannot be enqueued",
+			req: &requests.DevicesHeartbeat{Heartbeats: []requests.DeviceHeartbeat{
+				{TenantID: tenant, UID: "uid", SeenAt: seenAt.Unix()},
+			}},
+			requiredMocks: func(ctx context.Context) {
+				clientMock.
+					On("EnqueueDevicesHeartbeat", ctx, []models.ConnectedDevice{{UID: "uid", TenantID: tenant, LastSeen: seenAt}}).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds bringing the heartbeats from the future to now",
+			req: &requests.DevicesHeartbeat{Heartbeats: []requests.DeviceHeartbeat{
+				{TenantID: tenant, UID: "first", SeenAt: seenAt.Unix()},
+				{TenantID: tenant, UID: "second", SeenAt: now.Add(time.Hour).Unix()},
+			}},
+			requiredMocks: func(ctx context.Context) {
+				clientMock.
+					On("EnqueueDevicesHeartbeat", ctx, []models.ConnectedDevice{
+						{UID: "first", TenantID: tenant, LastSeen: seenAt},
+						{UID: "second", TenantID: tenant, LastSeen: now},
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+			assert.Equal(t, tc.expected, s.EnqueueDevicesHeartbeat(ctx, tc.req))
+		})
+	}
+}
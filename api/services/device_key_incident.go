@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type DeviceKeyIncidentService interface {
+	// ListDeviceKeyIncidents retrieves a list of device key incidents within the specified tenant ID. It returns the
+	// list of incidents, the total count of documents in the database, and an error, if any.
+	ListDeviceKeyIncidents(ctx context.Context, req *requests.DeviceKeyIncidentList) (incidents []models.DeviceKeyIncident, count int, err error)
+
+	// UpdateDeviceKeyIncident reviews an open device key incident. When approved, the device is allowed to
+	// authenticate using the new public key; when rejected, the new public key remains blocked. In both cases, the
+	// pinned device is no longer flagged as compromised. It returns an error, if any.
+	UpdateDeviceKeyIncident(ctx context.Context, req *requests.DeviceKeyIncidentUpdate) (err error)
+}
+
+// checkDeviceKeyPinning checks if the device, authenticating in a namespace with device key pinning enabled, presents
+// the same public key pinned by the accepted device with the same identity. When the public key differs and no admin
+// has approved it yet, a [models.DeviceKeyIncident] is opened, the accepted device is flagged as compromised and an
+// error is returned.
+func (s *service) checkDeviceKeyPinning(ctx context.Context, device *models.Device) error {
+	if device.Identity == nil || device.Identity.MAC == "" {
+		return nil
+	}
+
+	pinned, err := s.store.DeviceGetByMac(ctx, device.Identity.MAC, device.TenantID, models.DeviceStatusAccepted)
+	if err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil
+		}
+
+		return err
+	}
+
+	if pinned.UID == device.UID || pinned.PublicKey == device.PublicKey {
+		return nil
+	}
+
+	incident, err := s.store.DeviceKeyIncidentGetByPublicKey(ctx, device.TenantID, models.UID(pinned.UID), device.PublicKey)
+	if err != nil && !errors.Is(err, store.ErrNoDocuments) {
+		return err
+	}
+
+	if incident != nil {
+		if incident.Status == models.DeviceKeyIncidentStatusApproved {
+			return nil
+		}
+
+		return NewErrDeviceKeyMismatch()
+	}
+
+	log.WithContext(ctx).WithFields(log.Fields{
+		"tenant_id":   device.TenantID,
+		"device_uid":  pinned.UID,
+		"remote_addr": device.RemoteAddr,
+	}).Warn("device presented a public key different from the pinned one")
+
+	if _, err := s.store.DeviceKeyIncidentCreate(ctx, &models.DeviceKeyIncident{
+		ID:              uuid.Generate(),
+		TenantID:        device.TenantID,
+		DeviceUID:       pinned.UID,
+		MAC:             device.Identity.MAC,
+		PinnedPublicKey: pinned.PublicKey,
+		PublicKey:       device.PublicKey,
+		RemoteAddr:      device.RemoteAddr,
+		Status:          models.DeviceKeyIncidentStatusOpen,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.store.DeviceSetCompromised(ctx, models.UID(pinned.UID), true); err != nil {
+		return err
+	}
+
+	return NewErrDeviceKeyMismatch()
+}
+
+func (s *service) ListDeviceKeyIncidents(ctx context.Context, req *requests.DeviceKeyIncidentList) ([]models.DeviceKeyIncident, int, error) {
+	return s.store.DeviceKeyIncidentList(ctx, req.TenantID, req.Status, req.Paginator)
+}
+
+func (s *service) UpdateDeviceKeyIncident(ctx context.Context, req *requests.DeviceKeyIncidentUpdate) error {
+	incident, err := s.store.DeviceKeyIncidentGet(ctx, req.TenantID, req.ID)
+	if err != nil {
+		return NewErrDeviceKeyIncidentNotFound(req.ID, err)
+	}
+
+	if incident.Status != models.DeviceKeyIncidentStatusOpen {
+		return NewErrDeviceKeyIncidentReviewed(req.ID)
+	}
+
+	if err := s.store.DeviceKeyIncidentUpdateStatus(ctx, req.TenantID, req.ID, req.Status); err != nil {
+		return err
+	}
+
+	if err := s.store.DeviceSetCompromised(ctx, models.UID(incident.DeviceUID), false); err != nil && !errors.Is(err, store.ErrNoDocuments) {
+		return err
+	}
+
+	return nil
+}
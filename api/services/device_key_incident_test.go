@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDeviceKeyPinning(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	device := &models.Device{
+		UID:        "new",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		Identity:   &models.DeviceIdentity{MAC: "mac"},
+		PublicKey:  "new-key",
+		RemoteAddr: "192.168.0.1",
+	}
+
+	pinned := &models.Device{
+		UID:       "pinned",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		Identity:  &models.DeviceIdentity{MAC: "mac"},
+		PublicKey: "pinned-key",
+	}
+
+	cases := []struct {
+		description   string
+		device        *models.Device
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description:   "succeeds when device has no identity",
+			device:        &models.Device{UID: "new", TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(_ context.Context) {},
+			expected:      nil,
+		},
+		{
+			description: "succeeds when there is no accepted device with the same identity",
+			device:      device,
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the public key is the pinned one",
+			device:      device,
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(&models.Device{UID: "pinned", PublicKey: "new-key"}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the new public key was approved",
+			device:      device,
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(pinned, nil).
+					Once()
+				storeMock.
+					On("DeviceKeyIncidentGetByPublicKey", ctx, "00000000-0000-4000-0000-000000000000", models.UID("pinned"), "new-key").
+					Return(&models.DeviceKeyIncident{ID: "incident", Status: models.DeviceKeyIncidentStatusApproved}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the incident for the new public key is still open",
+			device:      device,
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(pinned, nil).
+					Once()
+				storeMock.
+					On("DeviceKeyIncidentGetByPublicKey", ctx, "00000000-0000-4000-0000-000000000000", models.UID("pinned"), "new-key").
+					Return(&models.DeviceKeyIncident{ID: "incident", Status: models.DeviceKeyIncidentStatusOpen}, nil).
+					Once()
+			},
+			expected: NewErrDeviceKeyMismatch(),
+		},
+		{
+			description: "fails opening an incident when the public key changes",
+			device:      device,
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(pinned, nil).
+					Once()
+				storeMock.
+					On("DeviceKeyIncidentGetByPublicKey", ctx, "00000000-0000-4000-0000-000000000000", models.UID("pinned"), "new-key").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("DeviceKeyIncidentCreate", ctx, &models.DeviceKeyIncident{
+						ID:              "00000000-0000-4000-0000-000000000001",
+						TenantID:        "00000000-0000-4000-0000-000000000000",
+						DeviceUID:       "pinned",
+						MAC:             "mac",
+						PinnedPublicKey: "pinned-key",
+						PublicKey:       "new-key",
+						RemoteAddr:      "192.168.0.1",
+						Status:          models.DeviceKeyIncidentStatusOpen,
+					}).
+					Return("00000000-0000-4000-0000-000000000001", nil).
+					Once()
+				storeMock.
+					On("DeviceSetCompromised", ctx, models.UID("pinned"), true).
+					Return(nil).
+					Once()
+			},
+			expected: NewErrDeviceKeyMismatch(),
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.checkDeviceKeyPinning(ctx, tc.device)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceKeyIncident(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceKeyIncidentUpdate
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the incident is not found",
+			req:         &requests.DeviceKeyIncidentUpdate{TenantID: "00000000-0000-4000-0000-000000000000", ID: "incident", Status: models.DeviceKeyIncidentStatusApproved},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceKeyIncidentGet", ctx, "00000000-0000-4000-0000-000000000000", "incident").
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: NewErrDeviceKeyIncidentNotFound("incident", errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when the incident was already reviewed",
+			req:         &requests.DeviceKeyIncidentUpdate{TenantID: "00000000-0000-4000-0000-000000000000", ID: "incident", Status: models.DeviceKeyIncidentStatusApproved},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceKeyIncidentGet", ctx, "00000000-0000-4000-0000-000000000000", "incident").
+					Return(&models.DeviceKeyIncident{ID: "incident", DeviceUID: "pinned", Status: models.DeviceKeyIncidentStatusRejected}, nil).
+					Once()
+			},
+			expected: NewErrDeviceKeyIncidentReviewed("incident"),
+		},
+		{
+			description: "succeeds",
+			req:         &requests.DeviceKeyIncidentUpdate{TenantID: "00000000-0000-4000-0000-000000000000", ID: "incident", Status: models.DeviceKeyIncidentStatusApproved},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceKeyIncidentGet", ctx, "00000000-0000-4000-0000-000000000000", "incident").
+					Return(&models.DeviceKeyIncident{ID: "incident", DeviceUID: "pinned", Status: models.DeviceKeyIncidentStatusOpen}, nil).
+					Once()
+				storeMock.
+					On("DeviceKeyIncidentUpdateStatus", ctx, "00000000-0000-4000-0000-000000000000", "incident", models.DeviceKeyIncidentStatusApproved).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceSetCompromised", ctx, models.UID("pinned"), false).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.UpdateDeviceKeyIncident(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
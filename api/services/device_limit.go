@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+type DeviceLimitService interface {
+	// UpdateNamespaceDeviceLimits sets the maximum number of accepted devices and of devices waiting for acceptance
+	// in the namespace, regardless of its billing. It returns the namespace with the limits updated.
+	UpdateNamespaceDeviceLimits(ctx context.Context, req *requests.NamespaceDeviceLimits) (*models.Namespace, error)
+
+	// UpdateDeviceLimitExemption exempts, or revokes the exemption of, the tenant's device from the namespace's maximum
+	// number of devices, recording who changed it and why. Nothing is recorded when the exemption is unchanged.
+	UpdateDeviceLimitExemption(ctx context.Context, req *requests.DeviceUpdateLimitExemption) error
+
+	// ListDeviceLimitExemptions retrieves the changes on the exemption of the tenant's device, most recent first. It
+	// returns the list of changes, the total count of matched documents and an error if any.
+	ListDeviceLimitExemptions(ctx context.Context, req *requests.DeviceLimitExemptionsList) ([]models.DeviceLimitExemption, int, error)
+}
+
+func (s *service) UpdateNamespaceDeviceLimits(ctx context.Context, req *requests.NamespaceDeviceLimits) (*models.Namespace, error) {
+	changes := &models.NamespaceChanges{
+		MaxDevices:        req.MaxDevices,
+		MaxPendingDevices: req.MaxPendingDevices,
+	}
+
+	if err := s.store.NamespaceEdit(ctx, req.Tenant, changes); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNoDocuments):
+			return nil, NewErrNamespaceNotFound(req.Tenant, err)
+		default:
+			return nil, err
+		}
+	}
+
+	// NOTICE: the new limits may have room for the devices on the acceptance queue.
+	s.acceptQueuedDevices(ctx, req.Tenant)
+
+	return s.store.NamespaceGet(ctx, req.Tenant, s.store.Options().CountAcceptedDevices())
+}
+
+func (s *service) UpdateDeviceLimitExemption(ctx context.Context, req *requests.DeviceUpdateLimitExemption) error {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	if device.LimitExempt == *req.Exempt {
+		return nil
+	}
+
+	if err := s.store.WithTransaction(ctx, s.updateDeviceLimitExemption(device, req)); err != nil {
+		return err
+	}
+
+	// NOTICE: an accepted device exempted from the limit frees a slot for the devices on the acceptance queue.
+	if *req.Exempt && device.Status == models.DeviceStatusAccepted {
+		s.acceptQueuedDevices(ctx, req.TenantID)
+	}
+
+	return nil
+}
+
+// updateDeviceLimitExemption returns a transaction callback that changes the device's exemption and records it.
+func (s *service) updateDeviceLimitExemption(device *models.Device, req *requests.DeviceUpdateLimitExemption) store.TransactionCb {
+	return func(ctx context.Context) error {
+		if err := s.store.DeviceSetLimitExempt(ctx, req.TenantID, models.UID(device.UID), *req.Exempt); err != nil {
+			return err
+		}
+
+		return s.store.DeviceLimitExemptionCreate(ctx, &models.DeviceLimitExemption{
+			ID:        uuid.Generate(),
+			TenantID:  req.TenantID,
+			DeviceUID: device.UID,
+			Exempt:    *req.Exempt,
+			UserID:    req.UserID,
+			Reason:    req.Reason,
+			CreatedAt: clock.Now(),
+		})
+	}
+}
+
+func (s *service) ListDeviceLimitExemptions(ctx context.Context, req *requests.DeviceLimitExemptionsList) ([]models.DeviceLimitExemption, int, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, 0, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	return s.store.DeviceLimitExemptionList(ctx, req.TenantID, models.UID(req.UID), req.Paginator)
+}
+
+// checkAcceptedDevicesLimit checks if one more device can be accepted in the namespace, when it has a maximum number
+// of accepted devices. The devices exempt from the limit are always accepted. The namespace must have been got with its
+// accepted devices counted.
+func (s *service) checkAcceptedDevicesLimit(ctx context.Context, namespace *models.Namespace, device *models.Device) error {
+	if device.LimitExempt {
+		return nil
+	}
+
+	if namespace.HasMaxDevices() && namespace.HasMaxDevicesReached() {
+		s.publishDeviceEvent(ctx, namespace.TenantID, device.UID, models.DeviceEventAcceptedLimit)
+
+		return NewErrDeviceMaxDevicesReached(namespace.MaxDevices)
+	}
+
+	return nil
+}
+
+// checkPendingDevicesLimit checks if a new device can be registered in the namespace, when it has a maximum number of
+// devices waiting for acceptance. The devices already registered are always allowed to authenticate.
+func (s *service) checkPendingDevicesLimit(ctx context.Context, namespace *models.Namespace, device *models.Device) error {
+	if !namespace.HasMaxPendingDevices() {
+		return nil
+	}
+
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(device.UID), namespace.TenantID); err == nil {
+		return nil
+	} else if !errors.Is(err, store.ErrNoDocuments) {
+		return NewErrDeviceNotFound(models.UID(device.UID), err)
+	}
+
+	count, err := s.store.DeviceCountByStatus(ctx, namespace.TenantID, models.DeviceStatusPending)
+	if err != nil {
+		return err
+	}
+
+	if count >= int64(namespace.MaxPendingDevices) {
+		s.publishDeviceEvent(ctx, namespace.TenantID, device.UID, models.DeviceEventPendingLimit)
+
+		return NewErrDeviceMaxPendingDevicesReached(namespace.MaxPendingDevices)
+	}
+
+	return nil
+}
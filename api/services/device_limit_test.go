@@ -0,0 +1,438 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateNamespaceDeviceLimits(t *testing.T) {
+	storeMock := new(mocks.Store)
+	queryOptionsMock := new(mocks.QueryOptions)
+	storeMock.On("Options").Return(queryOptionsMock)
+
+	maxDevices := 10
+	maxPendingDevices := 5
+
+	type Expected struct {
+		namespace *models.Namespace
+		err       error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.NamespaceDeviceLimits
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			req: &requests.NamespaceDeviceLimits{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				MaxDevices:  &maxDevices,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceEdit", ctx, "00000000-0000-4000-0000-000000000000", &models.NamespaceChanges{MaxDevices: &maxDevices}).
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{
+				namespace: nil,
+				err:       NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the namespace cannot be updated",
+			req: &requests.NamespaceDeviceLimits{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				MaxDevices:  &maxDevices,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceEdit", ctx, "00000000-0000-4000-0000-000000000000", &models.NamespaceChanges{MaxDevices: &maxDevices}).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: Expected{
+				namespace: nil,
+				err:       errors.New("error"),
+			},
+		},
+		{
+			description: "succeeds",
+			req: &requests.NamespaceDeviceLimits{
+				TenantParam:       requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				MaxDevices:        &maxDevices,
+				MaxPendingDevices: &maxPendingDevices,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceEdit", ctx, "00000000-0000-4000-0000-000000000000", &models.NamespaceChanges{MaxDevices: &maxDevices, MaxPendingDevices: &maxPendingDevices}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceQueueList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.Device{}, nil).
+					Once()
+				queryOptionsMock.
+					On("CountAcceptedDevices").
+					Return(nil).
+					Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", MaxDevices: maxDevices, MaxPendingDevices: maxPendingDevices}, nil).
+					Once()
+			},
+			expected: Expected{
+				namespace: &models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", MaxDevices: maxDevices, MaxPendingDevices: maxPendingDevices},
+				err:       nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			namespace, err := s.UpdateNamespaceDeviceLimits(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{namespace, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestCheckPendingDevicesLimit(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	device := &models.Device{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000"}
+
+	cases := []struct {
+		description   string
+		namespace     *models.Namespace
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description:   "succeeds when the namespace has no pending devices limit",
+			namespace:     &models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(context.Context) {},
+			expected:      nil,
+		},
+		{
+			description: "succeeds when the device is already registered",
+			namespace:   &models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", MaxPendingDevices: 1},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(device, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the pending devices limit is not reached",
+			namespace:   &models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", MaxPendingDevices: 2},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceCountByStatus", ctx, "00000000-0000-4000-0000-000000000000", models.DeviceStatusPending).
+					Return(int64(1), nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the pending devices limit is reached",
+			namespace:   &models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", MaxPendingDevices: 2},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceCountByStatus", ctx, "00000000-0000-4000-0000-000000000000", models.DeviceStatusPending).
+					Return(int64(2), nil).
+					Once()
+			},
+			expected: NewErrDeviceMaxPendingDevicesReached(2),
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.checkPendingDevicesLimit(ctx, tc.namespace, device)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceLimitExemption(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	exempt := true
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceUpdateLimitExemption
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceUpdateLimitExemption{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Exempt:      &exempt,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds when the exemption is unchanged",
+			req: &requests.DeviceUpdateLimitExemption{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Exempt:      &exempt,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", LimitExempt: true}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the exemption cannot be updated",
+			req: &requests.DeviceUpdateLimitExemption{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Exempt:      &exempt,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("WithTransaction", ctx, mock.Anything).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceUpdateLimitExemption{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Exempt:      &exempt,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("WithTransaction", ctx, mock.Anything).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.UpdateDeviceLimitExemption(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceLimitExemptionTransaction(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	exempt := false
+	req := &requests.DeviceUpdateLimitExemption{
+		DeviceParam: requests.DeviceParam{UID: "uid"},
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		UserID:      "000000000000000000000000",
+		Exempt:      &exempt,
+		Reason:      "moved to production",
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device cannot be updated",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceSetLimitExempt", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), false).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceSetLimitExempt", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), false).
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("cdfd3cb0-c44e-4e54-b931-6d57713ad159").
+					Once()
+				clockMock.
+					On("Now").
+					Return(now).
+					Once()
+
+				storeMock.
+					On("DeviceLimitExemptionCreate", ctx, &models.DeviceLimitExemption{
+						ID:        "cdfd3cb0-c44e-4e54-b931-6d57713ad159",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						DeviceUID: "uid",
+						Exempt:    false,
+						UserID:    "000000000000000000000000",
+						Reason:    "moved to production",
+						CreatedAt: now,
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.updateDeviceLimitExemption(&models.Device{UID: "uid", LimitExempt: true}, req)(ctx)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestListDeviceLimitExemptions(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		exemptions []models.DeviceLimitExemption
+		count      int
+		err        error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceLimitExemptionsList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceLimitExemptionsList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, 0, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceLimitExemptionsList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceLimitExemptionList", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), query.Paginator{Page: 1, PerPage: 10}).
+					Return([]models.DeviceLimitExemption{{ID: "id", DeviceUID: "uid", Exempt: true}}, 1, nil).
+					Once()
+			},
+			expected: Expected{[]models.DeviceLimitExemption{{ID: "id", DeviceUID: "uid", Exempt: true}}, 1, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			exemptions, count, err := s.ListDeviceLimitExemptions(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{exemptions, count, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
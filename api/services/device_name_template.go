@@ -0,0 +1,219 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeviceNameTemplatePreviewLimit is the maximum number of pending devices included on a device name template preview.
+const DeviceNameTemplatePreviewLimit = 20
+
+// deviceNameMaxLength is the maximum length of a device's name.
+const deviceNameMaxLength = 64
+
+// deviceNameMaxSuffix is the maximum suffix appended to a rendered name before giving up to find a free one.
+const deviceNameMaxSuffix = 100
+
+var deviceNameInvalidChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+type DeviceNameTemplateService interface {
+	// PreviewDeviceNameTemplate renders the template for the namespace's pending devices, returning the names they
+	// would receive when accepted, up to [DeviceNameTemplatePreviewLimit] devices.
+	PreviewDeviceNameTemplate(ctx context.Context, req *requests.NamespaceDeviceNameTemplatePreview) ([]responses.DeviceNamePreview, error)
+}
+
+// DeviceNameTemplateData is the data available to the namespace's device name template.
+type DeviceNameTemplateData struct {
+	UID string
+	// Hostname is the name the device has when it is registered, usually its hostname or MAC address.
+	Hostname  string
+	Namespace string
+	Identity  models.DeviceIdentity
+	Info      models.DeviceInfo
+	Tags      []string
+}
+
+// deviceNameTemplateFuncs are the functions available to the namespace's device name template.
+var deviceNameTemplateFuncs = template.FuncMap{
+	// last4 returns the last four characters of a value without its separators, like a MAC address.
+	"last4": func(value string) string {
+		value = strings.NewReplacer(":", "", "-", "", ".", "").Replace(value)
+		if len(value) <= 4 {
+			return value
+		}
+
+		return value[len(value)-4:]
+	},
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"replace": func(old, new, value string) string { return strings.ReplaceAll(value, old, new) },
+}
+
+// parseDeviceNameTemplate parses a namespace's device name template.
+func parseDeviceNameTemplate(text string) (*template.Template, error) {
+	return template.New("device_name").Option("missingkey=error").Funcs(deviceNameTemplateFuncs).Parse(text)
+}
+
+// renderDeviceName renders the template for the device, normalizing the result to a valid device's name. It returns
+// an error when the template cannot be executed or the result is empty.
+func renderDeviceName(tmpl *template.Template, namespace *models.Namespace, device *models.Device) (string, error) {
+	data := DeviceNameTemplateData{
+		UID:       device.UID,
+		Hostname:  device.Name,
+		Namespace: namespace.Name,
+		Tags:      device.Tags,
+	}
+
+	if device.Identity != nil {
+		data.Identity = *device.Identity
+	}
+
+	if device.Info != nil {
+		data.Info = *device.Info
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", err
+	}
+
+	name := deviceNameInvalidChars.ReplaceAllString(strings.ToLower(buffer.String()), "-")
+	name = strings.Trim(name, "-")
+	if len(name) > deviceNameMaxLength {
+		name = strings.TrimRight(name[:deviceNameMaxLength], "-")
+	}
+
+	if name == "" {
+		return "", errors.New("the template rendered an empty name")
+	}
+
+	return name, nil
+}
+
+// suffixDeviceName appends a numeric suffix to name, keeping it within the device's name maximum length.
+func suffixDeviceName(name string, suffix int) string {
+	s := fmt.Sprintf("-%d", suffix)
+	if len(name)+len(s) > deviceNameMaxLength {
+		name = name[:deviceNameMaxLength-len(s)]
+	}
+
+	return name + s
+}
+
+// freeDeviceName returns the first name, among name and its suffixed versions, that isn't used by another accepted
+// device of the namespace nor present in taken.
+func (s *service) freeDeviceName(ctx context.Context, tenantID, uid, name string, taken map[string]bool) (string, error) {
+	for suffix := 1; suffix <= deviceNameMaxSuffix; suffix++ {
+		candidate := name
+		if suffix > 1 {
+			candidate = suffixDeviceName(name, suffix)
+		}
+
+		if taken[candidate] {
+			continue
+		}
+
+		device, err := s.store.DeviceGetByName(ctx, candidate, tenantID, models.DeviceStatusAccepted)
+		if err != nil && !errors.Is(err, store.ErrNoDocuments) {
+			return "", err
+		}
+
+		if device == nil || device.UID == uid {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free name found for %s", name)
+}
+
+// deviceNameFromTemplate returns the name the device receives on acceptance from the namespace's device name template.
+// When the namespace has no template or the name cannot be rendered, the device's current name is returned.
+func (s *service) deviceNameFromTemplate(ctx context.Context, namespace *models.Namespace, device *models.Device) string {
+	if namespace.Settings == nil || namespace.Settings.DeviceNameTemplate == "" {
+		return device.Name
+	}
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{"tenant_id": namespace.TenantID, "uid": device.UID})
+
+	tmpl, err := parseDeviceNameTemplate(namespace.Settings.DeviceNameTemplate)
+	if err != nil {
+		logger.WithError(err).Warn("failed to parse the namespace's device name template")
+
+		return device.Name
+	}
+
+	name, err := renderDeviceName(tmpl, namespace, device)
+	if err != nil {
+		logger.WithError(err).Warn("failed to render the namespace's device name template")
+
+		return device.Name
+	}
+
+	name, err = s.freeDeviceName(ctx, namespace.TenantID, device.UID, name, nil)
+	if err != nil {
+		logger.WithError(err).Warn("failed to find a free name from the namespace's device name template")
+
+		return device.Name
+	}
+
+	return name
+}
+
+func (s *service) PreviewDeviceNameTemplate(ctx context.Context, req *requests.NamespaceDeviceNameTemplatePreview) ([]responses.DeviceNamePreview, error) {
+	namespace, err := s.store.NamespaceGet(ctx, req.Tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.Tenant, err)
+	}
+
+	tmpl, err := parseDeviceNameTemplate(req.Template)
+	if err != nil {
+		return nil, NewErrNamespaceDeviceNameTemplateInvalid(err)
+	}
+
+	devices, _, err := s.store.DeviceList(
+		ctx,
+		models.DeviceStatusPending,
+		query.Paginator{Page: 1, PerPage: DeviceNameTemplatePreviewLimit},
+		query.Filters{},
+		query.Sorter{By: "last_seen", Order: query.OrderAsc},
+		store.DeviceAcceptableAsFalse,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[string]bool)
+	previews := make([]responses.DeviceNamePreview, 0, len(devices))
+	for _, device := range devices {
+		name, err := renderDeviceName(tmpl, namespace, &device)
+		if err != nil {
+			return nil, NewErrNamespaceDeviceNameTemplateInvalid(err)
+		}
+
+		name, err = s.freeDeviceName(ctx, namespace.TenantID, device.UID, name, taken)
+		if err != nil {
+			return nil, err
+		}
+
+		taken[name] = true
+		previews = append(previews, responses.DeviceNamePreview{
+			UID:  device.UID,
+			Name: device.Name,
+			New:  name,
+		})
+	}
+
+	return previews, nil
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	storemock "github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDeviceName(t *testing.T) {
+	cases := []struct {
+		description string
+		template    string
+		device      *models.Device
+		expected    string
+		fails       bool
+	}{
+		{
+			description: "succeeds with the hostname and the MAC address last characters",
+			template:    "{{.Hostname}}-{{.Identity.MAC | last4}}",
+			device:      &models.Device{Name: "raspberrypi", Identity: &models.DeviceIdentity{MAC: "aa:bb:cc:dd:ee:ff"}},
+			expected:    "raspberrypi-eeff",
+		},
+		{
+			description: "succeeds normalizing the invalid characters",
+			template:    "{{.Namespace}} {{.Info.PrettyName}}",
+			device:      &models.Device{Name: "name", Info: &models.DeviceInfo{PrettyName: "Ubuntu 22.04 LTS"}},
+			expected:    "namespace-ubuntu-22-04-lts",
+		},
+		{
+			description: "succeeds without the device's identity",
+			template:    "{{.Hostname}}{{.Identity.MAC}}",
+			device:      &models.Device{Name: "name"},
+			expected:    "name",
+		},
+		{
+			description: "fails when the template renders an empty name",
+			template:    "{{.Identity.MAC}}",
+			device:      &models.Device{Name: "name"},
+			fails:       true,
+		},
+		{
+			description: "fails when the template uses an unknown field",
+			template:    "{{.Site}}",
+			device:      &models.Device{Name: "name"},
+			fails:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpl, err := parseDeviceNameTemplate(tc.template)
+			assert.NoError(t, err)
+
+			name, err := renderDeviceName(tmpl, &models.Namespace{Name: "namespace"}, tc.device)
+			if tc.fails {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestPreviewDeviceNameTemplate(t *testing.T) {
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	_, errParse := parseDeviceNameTemplate("{{.Hostname")
+
+	type Expected struct {
+		previews []responses.DeviceNamePreview
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.NamespaceDeviceNameTemplatePreview
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			req: &requests.NamespaceDeviceNameTemplatePreview{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				Template:    "{{.Hostname}}",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", errors.New("error", "", 0))},
+		},
+		{
+			description: "fails when the template is invalid",
+			req: &requests.NamespaceDeviceNameTemplatePreview{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				Template:    "{{.Hostname",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceDeviceNameTemplateInvalid(errParse)},
+		},
+		{
+			description: "succeeds suffixing the names already in use",
+			req: &requests.NamespaceDeviceNameTemplatePreview{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				Template:    "{{.Namespace}}-{{.Identity.MAC | last4}}",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
+					Once()
+				storeMock.
+					On(
+						"DeviceList",
+						ctx,
+						models.DeviceStatusPending,
+						query.Paginator{Page: 1, PerPage: DeviceNameTemplatePreviewLimit},
+						query.Filters{},
+						query.Sorter{By: "last_seen", Order: query.OrderAsc},
+						store.DeviceAcceptableAsFalse,
+					).
+					Return([]models.Device{
+						{UID: "uid-1", Name: "device-1", Identity: &models.DeviceIdentity{MAC: "00:00:00:00:aa:bb"}},
+						{UID: "uid-2", Name: "device-2", Identity: &models.DeviceIdentity{MAC: "11:11:11:11:aa:bb"}},
+					}, 2, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "namespace-aabb", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(&models.Device{UID: "uid-3", Name: "namespace-aabb"}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "namespace-aabb-2", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "namespace-aabb", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(&models.Device{UID: "uid-3", Name: "namespace-aabb"}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "namespace-aabb-3", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{
+				[]responses.DeviceNamePreview{
+					{UID: "uid-1", Name: "device-1", New: "namespace-aabb-2"},
+					{UID: "uid-2", Name: "device-2", New: "namespace-aabb-3"},
+				},
+				nil,
+			},
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			previews, err := service.PreviewDeviceNameTemplate(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{previews, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/geohash"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DevicePositionService interface {
+	// ListDevicePositions clusters the positions of the tenant's accepted devices inside the request's bounding box,
+	// grouping the devices whose positions share a geohash cell sized by the map's zoom.
+	ListDevicePositions(ctx context.Context, req *requests.DevicePositionsList) ([]models.DevicePositionCluster, error)
+}
+
+func (s *service) ListDevicePositions(ctx context.Context, req *requests.DevicePositionsList) ([]models.DevicePositionCluster, error) {
+	box, err := geohash.ParseBox(req.BBox)
+	if err != nil {
+		return nil, NewErrBadRequest(err)
+	}
+
+	return s.store.DevicePositionClusters(ctx, req.TenantID, box, geohash.PrecisionFromZoom(req.Zoom))
+}
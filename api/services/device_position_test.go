@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/geohash"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDevicePositions(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		clusters []models.DevicePositionCluster
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DevicePositionsList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description:   "fails when the bounding box is invalid",
+			req:           &requests.DevicePositionsList{TenantID: "00000000-0000-4000-0000-000000000000", BBox: "north", Zoom: 3},
+			requiredMocks: func(context.Context) {},
+			expected:      Expected{nil, NewErrBadRequest(geohash.ErrBoxInvalid)},
+		},
+		{
+			description: "fails when the store fails",
+			req:         &requests.DevicePositionsList{TenantID: "00000000-0000-4000-0000-000000000000", BBox: "-74.1,40.6,-73.7,40.9", Zoom: 3},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DevicePositionClusters", ctx, "00000000-0000-4000-0000-000000000000", geohash.Box{West: -74.1, South: 40.6, East: -73.7, North: 40.9}, 2).
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: Expected{nil, errors.New("error")},
+		},
+		{
+			description: "succeeds with the precision of the zoom",
+			req:         &requests.DevicePositionsList{TenantID: "00000000-0000-4000-0000-000000000000", BBox: "-74.1,40.6,-73.7,40.9", Zoom: 12},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DevicePositionClusters", ctx, "00000000-0000-4000-0000-000000000000", geohash.Box{West: -74.1, South: 40.6, East: -73.7, North: 40.9}, 5).
+					Return([]models.DevicePositionCluster{{Geohash: "dr5ru", Latitude: 40.7, Longitude: -73.9, Count: 42}}, nil).
+					Once()
+			},
+			expected: Expected{[]models.DevicePositionCluster{{Geohash: "dr5ru", Latitude: 40.7, Longitude: -73.9, Count: 42}}, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			clusters, err := s.ListDevicePositions(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{clusters, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
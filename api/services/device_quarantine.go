@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeviceQuarantineAlertInterval is the minimum interval between two alerts about the same quarantined device, as the
+// agent retries its authentication continuously.
+const DeviceQuarantineAlertInterval = time.Hour
+
+type DeviceQuarantineService interface {
+	// ListDeviceQuarantineAttempts retrieves a list of authentication attempts of the tenant's quarantined devices,
+	// optionally of a single device. It returns the list of attempts, the total count of documents in the database,
+	// and an error, if any.
+	ListDeviceQuarantineAttempts(ctx context.Context, req *requests.DeviceQuarantineAttemptList) (attempts []models.DeviceQuarantineAttempt, count int, err error)
+}
+
+func (s *service) ListDeviceQuarantineAttempts(ctx context.Context, req *requests.DeviceQuarantineAttemptList) ([]models.DeviceQuarantineAttempt, int, error) {
+	return s.store.DeviceQuarantineAttemptList(ctx, req.TenantID, models.UID(req.UID), req.Paginator)
+}
+
+// checkDeviceQuarantine checks if the device, authenticating in a namespace that quarantines its rejected devices,
+// was rejected. When it was, the attempt is recorded with what the device has presented, the namespace's owner is
+// alerted, no more than once per [DeviceQuarantineAlertInterval], and an error is returned.
+func (s *service) checkDeviceQuarantine(ctx context.Context, namespace *models.Namespace, device *models.Device, hostname string) error {
+	rejected, err := s.store.DeviceGetByUID(ctx, models.UID(device.UID), device.TenantID)
+	if err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil
+		}
+
+		return err
+	}
+
+	if rejected.Status != models.DeviceStatusRejected {
+		return nil
+	}
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"tenant_id":   device.TenantID,
+		"uid":         device.UID,
+		"remote_addr": device.RemoteAddr,
+	})
+
+	logger.Warn("quarantined device tried to authenticate")
+
+	last, err := s.store.DeviceQuarantineAttemptGetLast(ctx, device.TenantID, models.UID(device.UID))
+	if err != nil && !errors.Is(err, store.ErrNoDocuments) {
+		return err
+	}
+
+	country, err := s.locator.GetCountry(net.ParseIP(device.RemoteAddr))
+	if err != nil {
+		logger.WithError(err).Warn("unable to locate the quarantined device's remote address")
+	}
+
+	attempt := &models.DeviceQuarantineAttempt{
+		ID:         uuid.Generate(),
+		TenantID:   device.TenantID,
+		DeviceUID:  device.UID,
+		Hostname:   hostname,
+		PublicKey:  device.PublicKey,
+		RemoteAddr: device.RemoteAddr,
+		Country:    country,
+		Info:       device.Info,
+		CreatedAt:  clock.Now(),
+	}
+
+	if device.Identity != nil {
+		attempt.MAC = device.Identity.MAC
+	}
+
+	if err := s.store.DeviceQuarantineAttemptCreate(ctx, attempt); err != nil {
+		return err
+	}
+
+	if last == nil || attempt.CreatedAt.Sub(last.CreatedAt) >= DeviceQuarantineAlertInterval {
+		s.alertDeviceQuarantine(ctx, namespace, rejected, attempt)
+	}
+
+	return NewErrDeviceQuarantined()
+}
+
+// alertDeviceQuarantine publishes the quarantined device's event and notifies the namespace's owner about its attempt.
+// Failures are only logged, as the attempt is already recorded.
+func (s *service) alertDeviceQuarantine(ctx context.Context, namespace *models.Namespace, device *models.Device, attempt *models.DeviceQuarantineAttempt) {
+	s.publishDeviceEvent(ctx, device.TenantID, device.UID, models.DeviceEventQuarantine)
+
+	logger := log.WithContext(ctx).WithFields(log.Fields{"tenant_id": device.TenantID, "uid": device.UID})
+
+	owner, _, err := s.store.UserGetByID(ctx, namespace.Owner, false)
+	if err != nil {
+		logger.WithError(err).Error("unable to get the namespace's owner to notify the quarantined device's attempt")
+
+		return
+	}
+
+	message := &mailer.Message{
+		To:      owner.Email,
+		Subject: fmt.Sprintf("Rejected device %s tried to join namespace %s", device.Name, namespace.Name),
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nThe rejected device %s tried to authenticate on namespace %s from %s, presenting the hostname %s and the MAC address %s.\n\nIf you did not expect this attempt, the namespace's tenant ID may have been copied to another image or machine.\n",
+			owner.Name,
+			device.Name,
+			namespace.Name,
+			attempt.RemoteAddr,
+			attempt.Hostname,
+			attempt.MAC,
+		),
+	}
+
+	if err := s.mailer.Send(ctx, message); err != nil {
+		logger.WithError(err).Error("unable to notify the quarantined device's attempt")
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	mockmailer "github.com/shellhub-io/shellhub/pkg/mailer/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestCheckDeviceQuarantine(t *testing.T) {
+	storeMock := new(mocks.Store)
+	mailerMock := new(mockmailer.Mailer)
+	locatorMock := new(mocksGeoIp.Locator)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clockMock.On("Now").Return(now)
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		Owner:    "000000000000000000000000",
+		TenantID: tenant,
+		Settings: &models.NamespaceSettings{DeviceQuarantine: true},
+	}
+
+	device := &models.Device{
+		UID:        "uid",
+		TenantID:   tenant,
+		Identity:   &models.DeviceIdentity{MAC: "mac"},
+		PublicKey:  "key",
+		RemoteAddr: "192.168.0.1",
+		Info:       &models.DeviceInfo{ID: "debian"},
+	}
+
+	attempt := &models.DeviceQuarantineAttempt{
+		ID:         "00000000-0000-4000-0000-000000000001",
+		TenantID:   tenant,
+		DeviceUID:  "uid",
+		Hostname:   "clone",
+		MAC:        "mac",
+		PublicKey:  "key",
+		RemoteAddr: "192.168.0.1",
+		Country:    "BR",
+		Info:       &models.DeviceInfo{ID: "debian"},
+		CreatedAt:  now,
+	}
+
+	record := func(ctx context.Context) {
+		locatorMock.On("GetCountry", net.ParseIP("192.168.0.1")).Return("BR", nil).Once()
+		uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+		storeMock.
+			On("DeviceQuarantineAttemptCreate", ctx, attempt).
+			Return(nil).
+			Once()
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "succeeds when the device isn't registered",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the device isn't rejected",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", Status: models.DeviceStatusPending}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails recording the attempt without alerting when the device was alerted recently",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", Name: "device", Status: models.DeviceStatusRejected}, nil).
+					Once()
+				storeMock.
+					On("DeviceQuarantineAttemptGetLast", ctx, tenant, models.UID("uid")).
+					Return(&models.DeviceQuarantineAttempt{CreatedAt: now.Add(-time.Minute)}, nil).
+					Once()
+				record(ctx)
+			},
+			expected: NewErrDeviceQuarantined(),
+		},
+		{
+			description: "fails recording the attempt and alerting the namespace's owner",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", Name: "device", Status: models.DeviceStatusRejected}, nil).
+					Once()
+				storeMock.
+					On("DeviceQuarantineAttemptGetLast", ctx, tenant, models.UID("uid")).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				record(ctx)
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000", UserData: models.UserData{Name: "John Doe", Email: "john.doe@test.com"}}, 0, nil).
+					Once()
+				mailerMock.
+					On("Send", ctx, testifymock.MatchedBy(func(m *mailer.Message) bool {
+						return m.To == "john.doe@test.com"
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: NewErrDeviceQuarantined(),
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock, WithLocator(locatorMock), WithMailer(mailerMock))
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.checkDeviceQuarantine(ctx, namespace, device, "clone"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	locatorMock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestListDeviceQuarantineAttempts(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	ctx := context.Background()
+	paginator := query.Paginator{Page: 1, PerPage: 10}
+
+	storeMock.
+		On("DeviceQuarantineAttemptList", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), paginator).
+		Return([]models.DeviceQuarantineAttempt{{ID: "id"}}, 1, nil).
+		Once()
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	attempts, count, err := s.ListDeviceQuarantineAttempts(ctx, &requests.DeviceQuarantineAttemptList{
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		UID:       "uid",
+		Paginator: paginator,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []models.DeviceQuarantineAttempt{{ID: "id"}}, attempts)
+
+	storeMock.AssertExpectations(t)
+}
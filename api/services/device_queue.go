@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	pkgerrors "github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+type DeviceQueueService interface {
+	// ListDeviceQueue retrieves the tenant's devices on the acceptance queue, in the order they are going to be
+	// accepted.
+	ListDeviceQueue(ctx context.Context, req *requests.DeviceQueueList) ([]models.Device, error)
+
+	// QueueDevice adds the tenant's pending device to the acceptance queue, or changes its priority when it is already
+	// queued. The queue is processed right away, so the device is accepted when the namespace has room for it.
+	QueueDevice(ctx context.Context, req *requests.DeviceQueue) (*models.Device, error)
+
+	// DequeueDevice removes the tenant's device from the acceptance queue, keeping it pending.
+	DequeueDevice(ctx context.Context, req *requests.DeviceDequeue) error
+}
+
+func (s *service) ListDeviceQueue(ctx context.Context, req *requests.DeviceQueueList) ([]models.Device, error) {
+	return s.store.DeviceQueueList(ctx, req.TenantID)
+}
+
+func (s *service) QueueDevice(ctx context.Context, req *requests.DeviceQueue) (*models.Device, error) {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	if device.Status != models.DeviceStatusPending {
+		return nil, NewErrDeviceQueueStatus(device.Status)
+	}
+
+	queue := &models.DeviceQueue{Priority: req.Priority, QueuedAt: clock.Now(), QueuedBy: req.UserID}
+	// NOTICE: changing the priority of a queued device keeps its place among the devices with the same priority.
+	if device.Queue != nil {
+		queue.QueuedAt = device.Queue.QueuedAt
+		queue.QueuedBy = device.Queue.QueuedBy
+	}
+
+	if err := s.store.DeviceSetQueue(ctx, req.TenantID, models.UID(device.UID), queue); err != nil {
+		return nil, err
+	}
+
+	s.publishDeviceEvent(ctx, req.TenantID, device.UID, models.DeviceEventQueue)
+
+	s.acceptQueuedDevices(ctx, req.TenantID)
+
+	return s.store.DeviceGetByUID(ctx, models.UID(device.UID), req.TenantID)
+}
+
+func (s *service) DequeueDevice(ctx context.Context, req *requests.DeviceDequeue) error {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	if device.Queue == nil {
+		return NewErrDeviceNotQueued(models.UID(device.UID))
+	}
+
+	if err := s.store.DeviceSetQueue(ctx, req.TenantID, models.UID(device.UID), nil); err != nil {
+		return err
+	}
+
+	s.publishDeviceEvent(ctx, req.TenantID, device.UID, models.DeviceEventQueue)
+
+	return nil
+}
+
+// acceptQueuedDevices accepts the tenant's queued devices, in the queue's order, until the namespace cannot accept
+// more devices. A queued device refused for another reason, like a duplicated name, is removed from the queue, so it
+// doesn't hold the devices behind it. As the queue is processed after the operations that free the namespace's slots,
+// a failure is logged and doesn't fail them.
+func (s *service) acceptQueuedDevices(ctx context.Context, tenant string) {
+	logger := log.WithContext(ctx).WithField("tenant_id", tenant)
+
+	devices, err := s.store.DeviceQueueList(ctx, tenant)
+	if err != nil {
+		logger.WithError(err).Warn("failed to list the devices on the acceptance queue")
+
+		return
+	}
+
+	for _, device := range devices {
+		err := s.updateDeviceStatus(ctx, tenant, models.UID(device.UID), models.DeviceStatusAccepted)
+		if err == nil {
+			logger.WithField("uid", device.UID).Info("device accepted from the acceptance queue")
+
+			s.publishDeviceEvent(ctx, tenant, device.UID, models.DeviceEventStatus)
+
+			continue
+		}
+
+		if isDeviceLimitError(err) {
+			return
+		}
+
+		logger.WithError(err).WithField("uid", device.UID).Warn("removing the device refused from the acceptance queue")
+
+		if err := s.store.DeviceSetQueue(ctx, tenant, models.UID(device.UID), nil); err != nil {
+			logger.WithError(err).WithField("uid", device.UID).Warn("failed to remove the device from the acceptance queue")
+		}
+
+		s.publishDeviceEvent(ctx, tenant, device.UID, models.DeviceEventQueue)
+	}
+}
+
+// isDeviceLimitError reports if the error was returned because the namespace cannot accept more devices, due to its
+// maximum number of devices or to its billing.
+func isDeviceLimitError(err error) bool {
+	var e pkgerrors.Error
+
+	return errors.As(err, &e) && e.Layer == ErrLayer && (e.Code == ErrCodeLimit || e.Code == ErrCodePayment)
+}
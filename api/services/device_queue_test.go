@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQueueDevice(t *testing.T) {
+	storeMock := new(mocks.Store)
+	queryOptionsMock := new(mocks.QueryOptions)
+	storeMock.On("Options").Return(queryOptionsMock)
+
+	clockMock.On("Now").Return(now)
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+	queuedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type Expected struct {
+		device *models.Device
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceQueue
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			req:         &requests.DeviceQueue{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: tenant, UserID: "user", Priority: 1},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{err: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the device isn't pending",
+			req:         &requests.DeviceQueue{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: tenant, UserID: "user", Priority: 1},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant, Status: models.DeviceStatusAccepted}, nil).
+					Once()
+			},
+			expected: Expected{err: NewErrDeviceQueueStatus(models.DeviceStatusAccepted)},
+		},
+		{
+			description: "succeeds keeping the device queued when the namespace reached the limit of devices",
+			req:         &requests.DeviceQueue{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: tenant, UserID: "user", Priority: 1},
+			requiredMocks: func(ctx context.Context) {
+				device := &models.Device{
+					UID:      "uid",
+					Name:     "name",
+					TenantID: tenant,
+					Status:   models.DeviceStatusPending,
+					Identity: &models.DeviceIdentity{MAC: "mac"},
+				}
+				queue := &models.DeviceQueue{Priority: 1, QueuedAt: now, QueuedBy: "user"}
+
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(device, nil).
+					Once()
+				storeMock.
+					On("DeviceSetQueue", ctx, tenant, models.UID("uid"), queue).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceQueueList", ctx, tenant).
+					Return([]models.Device{*device}, nil).
+					Once()
+				queryOptionsMock.
+					On("CountAcceptedDevices").
+					Return(nil).
+					Once()
+				storeMock.
+					On("NamespaceGet", ctx, tenant, mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(&models.Namespace{TenantID: tenant, MaxDevices: 3, DevicesCount: 3}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(device, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", tenant, models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "name", tenant, models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_CLOUD").
+					Return("false").
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_ENTERPRISE").
+					Return("false").
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant, Status: models.DeviceStatusPending, Queue: queue}, nil).
+					Once()
+			},
+			expected: Expected{
+				device: &models.Device{
+					UID:      "uid",
+					TenantID: tenant,
+					Status:   models.DeviceStatusPending,
+					Queue:    &models.DeviceQueue{Priority: 1, QueuedAt: now, QueuedBy: "user"},
+				},
+			},
+		},
+		{
+			description: "succeeds changing the priority of a queued device",
+			req:         &requests.DeviceQueue{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: tenant, UserID: "user", Priority: 5},
+			requiredMocks: func(ctx context.Context) {
+				queue := &models.DeviceQueue{Priority: 5, QueuedAt: queuedAt, QueuedBy: "other"}
+
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{
+						UID:      "uid",
+						TenantID: tenant,
+						Status:   models.DeviceStatusPending,
+						Queue:    &models.DeviceQueue{Priority: 1, QueuedAt: queuedAt, QueuedBy: "other"},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetQueue", ctx, tenant, models.UID("uid"), queue).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceQueueList", ctx, tenant).
+					Return([]models.Device{}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant, Status: models.DeviceStatusPending, Queue: queue}, nil).
+					Once()
+			},
+			expected: Expected{
+				device: &models.Device{
+					UID:      "uid",
+					TenantID: tenant,
+					Status:   models.DeviceStatusPending,
+					Queue:    &models.DeviceQueue{Priority: 5, QueuedAt: queuedAt, QueuedBy: "other"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+			device, err := s.QueueDevice(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{device, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDequeueDevice(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceDequeue
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			req:         &requests.DeviceDequeue{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: tenant},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device isn't queued",
+			req:         &requests.DeviceDequeue{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: tenant},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant, Status: models.DeviceStatusPending}, nil).
+					Once()
+			},
+			expected: NewErrDeviceNotQueued(models.UID("uid")),
+		},
+		{
+			description: "succeeds",
+			req:         &requests.DeviceDequeue{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: tenant},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", TenantID: tenant, Status: models.DeviceStatusPending, Queue: &models.DeviceQueue{Priority: 1}}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetQueue", ctx, tenant, models.UID("uid"), (*models.DeviceQueue)(nil)).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+			assert.Equal(t, tc.expected, s.DequeueDevice(ctx, tc.req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAcceptQueuedDevices(t *testing.T) {
+	storeMock := new(mocks.Store)
+	queryOptionsMock := new(mocks.QueryOptions)
+	storeMock.On("Options").Return(queryOptionsMock)
+
+	ctx := context.Background()
+	tenant := "00000000-0000-4000-0000-000000000000"
+
+	first := models.Device{UID: "first", Name: "first", TenantID: tenant, Status: models.DeviceStatusPending, Identity: &models.DeviceIdentity{MAC: "first"}}
+	second := models.Device{UID: "second", Name: "second", TenantID: tenant, Status: models.DeviceStatusPending, Identity: &models.DeviceIdentity{MAC: "second"}}
+	third := models.Device{UID: "third", Name: "third", TenantID: tenant, Status: models.DeviceStatusPending, Identity: &models.DeviceIdentity{MAC: "third"}}
+
+	storeMock.
+		On("DeviceQueueList", ctx, tenant).
+		Return([]models.Device{first, second, third}, nil).
+		Once()
+
+	accept := func(device models.Device, count int) {
+		queryOptionsMock.
+			On("CountAcceptedDevices").
+			Return(nil).
+			Once()
+		storeMock.
+			On("NamespaceGet", ctx, tenant, mock.AnythingOfType("store.NamespaceQueryOption")).
+			Return(&models.Namespace{TenantID: tenant, MaxDevices: 2, DevicesCount: count}, nil).
+			Once()
+		storeMock.
+			On("DeviceGetByUID", ctx, models.UID(device.UID), tenant).
+			Return(&device, nil).
+			Once()
+		storeMock.
+			On("DeviceGetByMac", ctx, device.Identity.MAC, tenant, models.DeviceStatusAccepted).
+			Return(nil, store.ErrNoDocuments).
+			Once()
+	}
+
+	// NOTICE: the first device is accepted, the second one has a duplicated name, so it is removed from the queue,
+	// and the third one is kept on the queue as the namespace reached its limit of devices.
+	accept(first, 1)
+	storeMock.
+		On("DeviceGetByName", ctx, "first", tenant, models.DeviceStatusAccepted).
+		Return(nil, store.ErrNoDocuments).
+		Once()
+	envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+	envMock.On("Get", "SHELLHUB_ENTERPRISE").Return("false").Once()
+	storeMock.
+		On("DeviceUpdateStatus", ctx, models.UID("first"), models.DeviceStatusAccepted).
+		Return(nil).
+		Once()
+	storeMock.
+		On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+		Return(nil).
+		Once()
+
+	accept(second, 2)
+	storeMock.
+		On("DeviceGetByName", ctx, "second", tenant, models.DeviceStatusAccepted).
+		Return(&models.Device{UID: "other", Name: "second"}, nil).
+		Once()
+	storeMock.
+		On("DeviceSetQueue", ctx, tenant, models.UID("second"), (*models.DeviceQueue)(nil)).
+		Return(nil).
+		Once()
+	storeMock.
+		On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+		Return(nil).
+		Once()
+
+	accept(third, 2)
+	storeMock.
+		On("DeviceGetByName", ctx, "third", tenant, models.DeviceStatusAccepted).
+		Return(nil, store.ErrNoDocuments).
+		Once()
+	envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+	envMock.On("Get", "SHELLHUB_ENTERPRISE").Return("false").Once()
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+	s.acceptQueuedDevices(ctx, tenant)
+
+	storeMock.AssertExpectations(t)
+}
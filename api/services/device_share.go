@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+type DeviceShareService interface {
+	// ListDeviceShares lists the shares of the namespace's device, the oldest first, including the expired ones.
+	ListDeviceShares(ctx context.Context, req *requests.DeviceShareList) ([]models.DeviceShare, error)
+
+	// CreateDeviceShare shares the namespace's accepted device with another namespace, which lists it from now on and,
+	// when the share allows it, connects to it as one of its own devices, until the share expires.
+	CreateDeviceShare(ctx context.Context, req *requests.DeviceShareCreate) (*models.DeviceShare, error)
+
+	// DeleteDeviceShare deletes a share of the namespace's device, revoking the grantee namespace's access to it. The
+	// sessions already established aren't closed.
+	DeleteDeviceShare(ctx context.Context, req *requests.DeviceShareDelete) error
+}
+
+func (s *service) ListDeviceShares(ctx context.Context, req *requests.DeviceShareList) ([]models.DeviceShare, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	return s.store.DeviceShareList(ctx, req.TenantID, req.UID)
+}
+
+func (s *service) CreateDeviceShare(ctx context.Context, req *requests.DeviceShareCreate) (*models.DeviceShare, error) {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	if device.Status != models.DeviceStatusAccepted {
+		return nil, NewErrDeviceShareInvalid("only accepted devices can be shared")
+	}
+
+	if req.GranteeTenantID == req.TenantID {
+		return nil, NewErrDeviceShareInvalid("the device can't be shared with its own namespace")
+	}
+
+	now := clock.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, NewErrDeviceShareInvalid("the share must expire in the future")
+	}
+
+	if _, err := s.store.NamespaceGet(ctx, req.GranteeTenantID); err != nil {
+		return nil, NewErrNamespaceNotFound(req.GranteeTenantID, err)
+	}
+
+	shares, err := s.store.DeviceShareList(ctx, req.TenantID, req.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, share := range shares {
+		if share.GranteeTenantID == req.GranteeTenantID && share.Active(now) {
+			return nil, NewErrDeviceShareDuplicated([]string{req.GranteeTenantID}, nil)
+		}
+	}
+
+	// NOTICE: the grantee's members connect to the shared device by its name on their own namespace, where a device
+	// with the same name would hide it.
+	switch _, err := s.store.DeviceGetByName(ctx, device.Name, req.GranteeTenantID, models.DeviceStatusAccepted); {
+	case err == nil:
+		return nil, NewErrDeviceShareDuplicated([]string{device.Name}, nil)
+	case !errors.Is(err, store.ErrNoDocuments):
+		return nil, err
+	}
+
+	share := &models.DeviceShare{
+		ID:              uuid.Generate(),
+		TenantID:        req.TenantID,
+		UID:             req.UID,
+		GranteeTenantID: req.GranteeTenantID,
+		Permission:      req.Permission,
+		CreatedBy:       req.UserID,
+		CreatedAt:       now,
+		ExpiresAt:       req.ExpiresAt,
+	}
+
+	if err := s.store.DeviceShareCreate(ctx, share); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{
+		"grantee_tenant_id": share.GranteeTenantID,
+		"permission":        string(share.Permission),
+	}
+
+	if share.ExpiresAt != nil {
+		details["expires_at"] = share.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionDeviceShareCreate, models.AuditTarget{Type: models.AuditTargetDevice, ID: req.UID}, details); err != nil {
+		return nil, err
+	}
+
+	return share, nil
+}
+
+func (s *service) DeleteDeviceShare(ctx context.Context, req *requests.DeviceShareDelete) error {
+	if err := s.store.DeviceShareDelete(ctx, req.TenantID, req.UID, req.ID); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrDeviceShareNotFound(req.ID, err)
+		}
+
+		return err
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionDeviceShareDelete, models.AuditTarget{Type: models.AuditTargetDevice, ID: req.UID}, map[string]string{"share": req.ID})
+}
+
+// lookupSharedDevice looks for a device shared, allowing the connections, with the namespace by its name. The device is
+// returned marked with the share granting the access.
+func (s *service) lookupSharedDevice(ctx context.Context, namespace, name string) (*models.Device, error) {
+	ns, err := s.store.NamespaceGetByName(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := s.store.DeviceShareListByGrantee(ctx, ns.TenantID, clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range shares {
+		share := &shares[i]
+		if !share.AllowsConnect() {
+			continue
+		}
+
+		device, err := s.store.DeviceGetByName(ctx, name, share.TenantID, models.DeviceStatusAccepted)
+		switch {
+		case errors.Is(err, store.ErrNoDocuments):
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		if device.UID != share.UID {
+			continue
+		}
+
+		device.Shared = share
+
+		return device, nil
+	}
+
+	return nil, store.ErrNoDocuments
+}
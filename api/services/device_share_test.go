@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListDeviceShares(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		shares []models.DeviceShare
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceShareList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device isn't found on the namespace",
+			req: &requests.DeviceShareList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{
+				shares: nil,
+				err:    NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceShareList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Name: "device", Status: models.DeviceStatusAccepted}, nil).
+					Once()
+				storeMock.
+					On("DeviceShareList", ctx, "00000000-0000-4000-0000-000000000000", "uid").
+					Return([]models.DeviceShare{{ID: "share", UID: "uid"}}, nil).
+					Once()
+			},
+			expected: Expected{
+				shares: []models.DeviceShare{{ID: "share", UID: "uid"}},
+				err:    nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			shares, err := s.ListDeviceShares(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{shares, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestCreateDeviceShare(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	backend := uuid.DefaultBackend
+	uuidMock := new(uuidmock.Uuid)
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	device := &models.Device{UID: "uid", Name: "device", TenantID: "00000000-0000-4000-0000-000000000000", Status: models.DeviceStatusAccepted}
+
+	request := func(expiresAt *time.Time) *requests.DeviceShareCreate {
+		return &requests.DeviceShareCreate{
+			DeviceParam:     requests.DeviceParam{UID: "uid"},
+			TenantID:        "00000000-0000-4000-0000-000000000000",
+			UserID:          "507f1f77bcf86cd799439011",
+			GranteeTenantID: "00000000-0000-4001-0000-000000000000",
+			Permission:      models.DeviceSharePermissionConnect,
+			ExpiresAt:       expiresAt,
+		}
+	}
+
+	type Expected struct {
+		share *models.DeviceShare
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceShareCreate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device isn't found on the namespace",
+			req:         request(nil),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{
+				share: nil,
+				err:   NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the device isn't accepted",
+			req:         request(nil),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Name: "device", Status: models.DeviceStatusPending}, nil).
+					Once()
+			},
+			expected: Expected{
+				share: nil,
+				err:   NewErrDeviceShareInvalid("only accepted devices can be shared"),
+			},
+		},
+		{
+			description: "fails when the device is shared with its own namespace",
+			req: func() *requests.DeviceShareCreate {
+				req := request(nil)
+				req.GranteeTenantID = req.TenantID
+
+				return req
+			}(),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(device, nil).
+					Once()
+			},
+			expected: Expected{
+				share: nil,
+				err:   NewErrDeviceShareInvalid("the device can't be shared with its own namespace"),
+			},
+		},
+		{
+			description: "fails when the share expires in the past",
+			req:         request(&past),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(device, nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				share: nil,
+				err:   NewErrDeviceShareInvalid("the share must expire in the future"),
+			},
+		},
+		{
+			description: "fails when the grantee namespace isn't found",
+			req:         request(nil),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(device, nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4001-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{
+				share: nil,
+				err:   NewErrNamespaceNotFound("00000000-0000-4001-0000-000000000000", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the device is already shared with the grantee namespace",
+			req:         request(nil),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(device, nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4001-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4001-0000-000000000000"}, nil).
+					Once()
+				storeMock.
+					On("DeviceShareList", ctx, "00000000-0000-4000-0000-000000000000", "uid").
+					Return([]models.DeviceShare{
+						{ID: "expired", GranteeTenantID: "00000000-0000-4001-0000-000000000000", ExpiresAt: &past},
+						{ID: "active", GranteeTenantID: "00000000-0000-4001-0000-000000000000", ExpiresAt: &future},
+					}, nil).
+					Once()
+			},
+			expected: Expected{
+				share: nil,
+				err:   NewErrDeviceShareDuplicated([]string{"00000000-0000-4001-0000-000000000000"}, nil),
+			},
+		},
+		{
+			description: "fails when the grantee namespace has a device with the same name",
+			req:         request(nil),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(device, nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4001-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4001-0000-000000000000"}, nil).
+					Once()
+				storeMock.
+					On("DeviceShareList", ctx, "00000000-0000-4000-0000-000000000000", "uid").
+					Return([]models.DeviceShare{}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "device", "00000000-0000-4001-0000-000000000000", models.DeviceStatusAccepted).
+					Return(&models.Device{UID: "other", Name: "device"}, nil).
+					Once()
+			},
+			expected: Expected{
+				share: nil,
+				err:   NewErrDeviceShareDuplicated([]string{"device"}, nil),
+			},
+		},
+		{
+			description: "succeeds",
+			req:         request(&future),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(device, nil).
+					Once()
+				clockMock.On("Now").Return(now).Twice()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4001-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4001-0000-000000000000"}, nil).
+					Once()
+				storeMock.
+					On("DeviceShareList", ctx, "00000000-0000-4000-0000-000000000000", "uid").
+					Return([]models.DeviceShare{
+						{ID: "expired", GranteeTenantID: "00000000-0000-4001-0000-000000000000", ExpiresAt: &past},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "device", "00000000-0000-4001-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				uuidMock.On("Generate").Return("7a2b3c4d-1d2c-4e8f-9a4b-000000000001").Once()
+				storeMock.
+					On("DeviceShareCreate", ctx, &models.DeviceShare{
+						ID:              "7a2b3c4d-1d2c-4e8f-9a4b-000000000001",
+						TenantID:        "00000000-0000-4000-0000-000000000000",
+						UID:             "uid",
+						GranteeTenantID: "00000000-0000-4001-0000-000000000000",
+						Permission:      models.DeviceSharePermissionConnect,
+						CreatedBy:       "507f1f77bcf86cd799439011",
+						CreatedAt:       now,
+						ExpiresAt:       &future,
+					}).
+					Return(nil).
+					Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				share: &models.DeviceShare{
+					ID:              "7a2b3c4d-1d2c-4e8f-9a4b-000000000001",
+					TenantID:        "00000000-0000-4000-0000-000000000000",
+					UID:             "uid",
+					GranteeTenantID: "00000000-0000-4001-0000-000000000000",
+					Permission:      models.DeviceSharePermissionConnect,
+					CreatedBy:       "507f1f77bcf86cd799439011",
+					CreatedAt:       now,
+					ExpiresAt:       &future,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			share, err := s.CreateDeviceShare(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{share, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteDeviceShare(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceShareDelete
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the share isn't found",
+			req: &requests.DeviceShareDelete{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				ID:          "share",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceShareDelete", ctx, "00000000-0000-4000-0000-000000000000", "uid", "share").
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceShareNotFound("share", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceShareDelete{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				ID:          "share",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceShareDelete", ctx, "00000000-0000-4000-0000-000000000000", "uid", "share").
+					Return(nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.DeleteDeviceShare(ctx, tc.req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
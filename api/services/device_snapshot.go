@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type DeviceSnapshotService interface {
+	// DiffDevice compares the information reported by the tenant's device at two points in time, from the snapshots
+	// taken when it authorizes on the server, returning the changed fields.
+	DiffDevice(ctx context.Context, req *requests.DeviceDiff) (diff *models.DeviceDiff, err error)
+}
+
+func (s *service) DiffDevice(ctx context.Context, req *requests.DeviceDiff) (*models.DeviceDiff, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	diff := new(models.DeviceDiff)
+
+	// NOTICE: when no snapshot was taken before a point in time, like before the device's first authorization or after
+	// its snapshots expired, the device had no information then, so all the fields of the other snapshot are changed.
+	from, err := s.store.DeviceSnapshotGet(ctx, req.TenantID, models.UID(req.UID), req.From)
+	if err != nil && !errors.Is(err, store.ErrNoDocuments) {
+		return nil, err
+	}
+
+	if err == nil {
+		diff.From = from
+	}
+
+	to, err := s.store.DeviceSnapshotGet(ctx, req.TenantID, models.UID(req.UID), req.To)
+	if err != nil && !errors.Is(err, store.ErrNoDocuments) {
+		return nil, err
+	}
+
+	if err == nil {
+		diff.To = to
+	}
+
+	diff.Changes = diff.From.Diff(diff.To)
+
+	return diff, nil
+}
+
+// recordDeviceSnapshot takes a snapshot of the device's information when it differs from the latest snapshot's, or when
+// the latest snapshot is older than [models.DeviceSnapshotInterval]. A failure to take it doesn't fail the device's
+// authorization.
+func (s *service) recordDeviceSnapshot(ctx context.Context, device *models.Device) {
+	logger := log.WithContext(ctx).WithFields(log.Fields{
+		"uid":       device.UID,
+		"tenant_id": device.TenantID,
+	})
+
+	now := clock.Now()
+
+	snapshot := &models.DeviceSnapshot{
+		TenantID:      device.TenantID,
+		DeviceUID:     device.UID,
+		Identity:      device.Identity,
+		Info:          device.Info,
+		ConfigVersion: device.ConfigVersion,
+		TakenAt:       now,
+	}
+
+	latest, err := s.store.DeviceSnapshotGet(ctx, device.TenantID, models.UID(device.UID), now)
+	switch {
+	case errors.Is(err, store.ErrNoDocuments):
+	case err != nil:
+		logger.WithError(err).Error("unable to get the device's latest snapshot")
+
+		return
+	case len(latest.Diff(snapshot)) == 0 && now.Sub(latest.TakenAt) < models.DeviceSnapshotInterval:
+		return
+	}
+
+	snapshot.ID = uuid.Generate()
+
+	if err := s.store.DeviceSnapshotCreate(ctx, snapshot); err != nil {
+		logger.WithError(err).Error("unable to record the device's snapshot")
+	}
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffDevice(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	from := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	older := &models.DeviceSnapshot{
+		ID:       "00000000-0000-4000-0000-000000000001",
+		Identity: &models.DeviceIdentity{MAC: "mac"},
+		Info: &models.DeviceInfo{
+			ID:         "debian",
+			PrettyName: "Debian GNU/Linux 11 (bullseye)",
+			Version:    "v0.16.0",
+			Interfaces: []models.DeviceInterface{
+				{Name: "eth0", MAC: "mac", Addresses: []string{"192.168.1.10/24"}},
+				{Name: "wlan0", MAC: "mac2", Addresses: []string{"10.0.0.2/24"}},
+			},
+		},
+		ConfigVersion: 1,
+		TakenAt:       from.Add(-time.Hour),
+	}
+
+	newer := &models.DeviceSnapshot{
+		ID:       "00000000-0000-4000-0000-000000000002",
+		Identity: &models.DeviceIdentity{MAC: "mac"},
+		Info: &models.DeviceInfo{
+			ID:         "debian",
+			PrettyName: "Debian GNU/Linux 12 (bookworm)",
+			Version:    "v0.16.0",
+			ClockSkew:  60,
+			Interfaces: []models.DeviceInterface{
+				{Name: "eth0", MAC: "mac", Addresses: []string{"192.168.1.10/24"}},
+			},
+		},
+		ConfigVersion: 1,
+		TakenAt:       to.Add(-time.Hour),
+	}
+
+	req := &requests.DeviceDiff{
+		DeviceParam: requests.DeviceParam{UID: "uid"},
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		From:        from,
+		To:          to,
+	}
+
+	type Expected struct {
+		diff *models.DeviceDiff
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the snapshot cannot be retrieved",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), from).
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{nil, errors.New("error", "", 0)},
+		},
+		{
+			description: "succeeds when no snapshot was taken before from",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), from).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), to).
+					Return(&models.DeviceSnapshot{ID: "id", Info: &models.DeviceInfo{ID: "debian"}}, nil).
+					Once()
+			},
+			expected: Expected{
+				&models.DeviceDiff{
+					To: &models.DeviceSnapshot{ID: "id", Info: &models.DeviceInfo{ID: "debian"}},
+					Changes: []models.DeviceSnapshotChange{
+						{Field: "config_version", From: nil, To: 0},
+						{Field: "info.arch", From: nil, To: ""},
+						{Field: "info.id", From: nil, To: "debian"},
+						{Field: "info.platform", From: nil, To: ""},
+						{Field: "info.pretty_name", From: nil, To: ""},
+						{Field: "info.version", From: nil, To: ""},
+					},
+				},
+				nil,
+			},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), from).
+					Return(older, nil).
+					Once()
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), to).
+					Return(newer, nil).
+					Once()
+			},
+			expected: Expected{
+				&models.DeviceDiff{
+					From: older,
+					To:   newer,
+					Changes: []models.DeviceSnapshotChange{
+						{Field: "info.interfaces.wlan0", From: models.DeviceInterface{Name: "wlan0", MAC: "mac2", Addresses: []string{"10.0.0.2/24"}}, To: nil},
+						{Field: "info.pretty_name", From: "Debian GNU/Linux 11 (bullseye)", To: "Debian GNU/Linux 12 (bookworm)"},
+					},
+				},
+				nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			diff, err := s.DiffDevice(ctx, req)
+			assert.Equal(t, tc.expected, Expected{diff, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRecordDeviceSnapshot(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	device := &models.Device{
+		UID:           "uid",
+		TenantID:      "00000000-0000-4000-0000-000000000000",
+		Identity:      &models.DeviceIdentity{MAC: "mac"},
+		Info:          &models.DeviceInfo{ID: "debian", Version: "v0.17.0", ClockSkew: 5},
+		ConfigVersion: 2,
+	}
+
+	snapshot := &models.DeviceSnapshot{
+		ID:            "00000000-0000-4000-0000-000000000001",
+		TenantID:      "00000000-0000-4000-0000-000000000000",
+		DeviceUID:     "uid",
+		Identity:      &models.DeviceIdentity{MAC: "mac"},
+		Info:          &models.DeviceInfo{ID: "debian", Version: "v0.17.0", ClockSkew: 5},
+		ConfigVersion: 2,
+		TakenAt:       now,
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+	}{
+		{
+			description: "takes the device's first snapshot",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), now).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("DeviceSnapshotCreate", ctx, snapshot).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			description: "doesn't take a snapshot when the latest one is recent and unchanged",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), now).
+					Return(&models.DeviceSnapshot{
+						Identity:      &models.DeviceIdentity{MAC: "mac"},
+						Info:          &models.DeviceInfo{ID: "debian", Version: "v0.17.0", ClockSkew: -3},
+						ConfigVersion: 2,
+						TakenAt:       now.Add(-time.Hour),
+					}, nil).
+					Once()
+			},
+		},
+		{
+			description: "takes a snapshot when the latest one is changed",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), now).
+					Return(&models.DeviceSnapshot{
+						Identity:      &models.DeviceIdentity{MAC: "mac"},
+						Info:          &models.DeviceInfo{ID: "debian", Version: "v0.16.0"},
+						ConfigVersion: 2,
+						TakenAt:       now.Add(-time.Hour),
+					}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("DeviceSnapshotCreate", ctx, snapshot).
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			description: "takes a snapshot when the latest one is old",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceSnapshotGet", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), now).
+					Return(&models.DeviceSnapshot{
+						Identity:      &models.DeviceIdentity{MAC: "mac"},
+						Info:          &models.DeviceInfo{ID: "debian", Version: "v0.17.0", ClockSkew: 5},
+						ConfigVersion: 2,
+						TakenAt:       now.Add(-models.DeviceSnapshotInterval),
+					}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("DeviceSnapshotCreate", ctx, snapshot).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s.recordDeviceSnapshot(ctx, device)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
 )
 
 // DeviceTags contains the service's function to manage device tags.
@@ -18,8 +21,10 @@ const DeviceMaxTags = 3
 
 // CreateDeviceTag creates a new tag to a device. UID is the device's UID and tag is the tag's name.
 //
+// As a tag implies its ancestors, when the device has an ancestor of the new tag, the ancestor is replaced by it.
+//
 // If the device does not exist, a NewErrDeviceNotFound error will be returned.
-// If the tag already exist, a NewErrTagDuplicated error will be returned.
+// If the tag already exist, or is implied by one of the device's tags, a NewErrTagDuplicated error will be returned.
 // If the device already has the maximum number of tags, a NewErrTagLimit error will be returned.
 // A unknown error will be returned if the tag is not created.
 func (s *service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
@@ -28,21 +33,40 @@ func (s *service) CreateDeviceTag(ctx context.Context, uid models.UID, tag strin
 		return NewErrDeviceNotFound(uid, err)
 	}
 
-	if len(device.Tags) == DeviceMaxTags {
-		return NewErrTagLimit(DeviceMaxTags, nil)
+	if models.TagsMatch(device.Tags, []string{tag}) {
+		return NewErrTagDuplicated(tag, nil)
 	}
 
-	if contains(device.Tags, tag) {
-		return NewErrTagDuplicated(tag, nil)
+	tags := make([]string, 0, len(device.Tags))
+	for _, t := range device.Tags {
+		if !models.TagImplies(tag, t) {
+			tags = append(tags, t)
+		}
 	}
 
-	return s.store.DevicePushTag(ctx, uid, tag)
+	if len(tags) == len(device.Tags) {
+		if len(device.Tags) == DeviceMaxTags {
+			return NewErrTagLimit(DeviceMaxTags, nil)
+		}
+
+		err = s.store.DevicePushTag(ctx, uid, tag)
+	} else {
+		_, _, err = s.store.DeviceSetTags(ctx, uid, append(tags, tag))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.publishDeviceEvent(ctx, device.TenantID, string(uid), models.DeviceEventTags)
+	return s.recordAudit(ctx, device.TenantID, models.AuditActionDeviceTagAdd, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]string{"tag": tag})
 }
 
-// RemoveDeviceTag removes a tag from a device. UID is the device's UID and tag is the tag's name.
+// RemoveDeviceTag removes a tag from a device. UID is the device's UID and tag is the tag's name. The descendants of
+// the tag are removed as well, as they imply it.
 //
 // If the device does not exist, a NewErrDeviceNotFound error will be returned.
-// If the tag does not exist, a NewErrTagNotFound error will be returned.
+// If neither the tag nor its descendants exist, a NewErrTagNotFound error will be returned.
 // A unknown error will be returned if the tag is not removed.
 func (s *service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	device, err := s.store.DeviceGet(ctx, uid)
@@ -50,24 +74,44 @@ func (s *service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag strin
 		return NewErrDeviceNotFound(uid, err)
 	}
 
-	if !contains(device.Tags, tag) {
+	if !models.TagsMatch(device.Tags, []string{tag}) {
 		return NewErrTagNotFound(tag, nil)
 	}
 
-	return s.store.DevicePullTag(ctx, uid, tag)
+	tags := make([]string, 0, len(device.Tags))
+	for _, t := range device.Tags {
+		if !models.TagImplies(t, tag) {
+			tags = append(tags, t)
+		}
+	}
+
+	if len(tags) == len(device.Tags)-1 && contains(device.Tags, tag) {
+		err = s.store.DevicePullTag(ctx, uid, tag)
+	} else {
+		_, _, err = s.store.DeviceSetTags(ctx, uid, tags)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.publishDeviceEvent(ctx, device.TenantID, string(uid), models.DeviceEventTags)
+	return s.recordAudit(ctx, device.TenantID, models.AuditActionDeviceTagRemove, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]string{"tag": tag})
 }
 
 // UpdateDeviceTag updates a device's tags. UID is the device's UID and tags is the new tags.
 //
 // If length of tags is greater than DeviceMaxTags, a NewErrTagLimit error will be returned.
-// If tags' list contains a duplicated one, it is removed and the device's tag will be updated.
+// If tags' list contains a duplicated one, or an ancestor of another one, it is removed and the device's tag will be
+// updated.
 // If the device does not exist, a NewErrDeviceNotFound error will be returned.
 func (s *service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []string) error {
 	if len(tags) > DeviceMaxTags {
 		return NewErrTagLimit(DeviceMaxTags, nil)
 	}
 
-	if _, err := s.store.DeviceGet(ctx, uid); err != nil {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
 		return NewErrDeviceNotFound(uid, err)
 	}
 
@@ -85,9 +129,53 @@ func (s *service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []st
 		return l
 	}(tags)
 
-	if _, _, err := s.store.DeviceSetTags(ctx, uid, set); err != nil {
+	// NOTICE: an ancestor is redundant when any of its descendants is on the list, as the descendant implies it.
+	reduced := make([]string, 0, len(set))
+	for _, tag := range set {
+		if !slices.ContainsFunc(set, func(t string) bool { return t != tag && models.TagImplies(t, tag) }) {
+			reduced = append(reduced, tag)
+		}
+	}
+
+	if _, _, err := s.store.DeviceSetTags(ctx, uid, reduced); err != nil {
 		return err
 	}
 
-	return nil
+	s.publishDeviceEvent(ctx, device.TenantID, string(uid), models.DeviceEventTags)
+	return s.recordAudit(ctx, device.TenantID, models.AuditActionDeviceTagsUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]string{"tags": strings.Join(reduced, ",")})
+}
+
+// applyDefaultTags adds the namespace's default tags to a device being accepted. The tags already implied by the
+// device's tags are skipped, and the default tags exceeding the device's tags limit are ignored, keeping the device's
+// own tags.
+func (s *service) applyDefaultTags(ctx context.Context, namespace *models.Namespace, device *models.Device) {
+	if namespace.Settings == nil || len(namespace.Settings.DefaultTags) == 0 {
+		return
+	}
+
+	tags := slices.Clone(device.Tags)
+	for _, tag := range namespace.Settings.DefaultTags {
+		if len(tags) == DeviceMaxTags {
+			break
+		}
+
+		if !models.TagsMatch(tags, []string{tag}) {
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) == len(device.Tags) {
+		return
+	}
+
+	if _, _, err := s.store.DeviceSetTags(ctx, models.UID(device.UID), tags); err != nil {
+		log.WithContext(ctx).
+			WithError(err).
+			WithFields(log.Fields{"tenant_id": namespace.TenantID, "uid": device.UID}).
+			Warn("failed to apply the namespace's default tags to the accepted device")
+
+		return
+	}
+
+	s.publishDeviceEvent(ctx, device.TenantID, device.UID, models.DeviceEventTags)
 }
@@ -11,6 +11,7 @@ import (
 	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
 )
 
 const (
@@ -53,6 +54,56 @@ func TestCreateTag(t *testing.T) {
 			},
 			expected: NewErrTagDuplicated("device1", nil),
 		},
+		{
+			description: "Fails when the tag is implied by a device's tag",
+			uid:         models.UID("uid"),
+			deviceName:  "region",
+			requiredMocks: func() {
+				device := &models.Device{
+					UID:      "uid",
+					TenantID: "tenant",
+					Tags:     []string{"region/europe"},
+				}
+
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+			},
+			expected: NewErrTagDuplicated("region", nil),
+		},
+		{
+			description: "Fails when the device has the maximum number of tags",
+			uid:         models.UID("uid"),
+			deviceName:  "device4",
+			requiredMocks: func() {
+				device := &models.Device{
+					UID:      "uid",
+					TenantID: "tenant",
+					Tags:     []string{"device1", "device2", "device3"},
+				}
+
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+			},
+			expected: NewErrTagLimit(DeviceMaxTags, nil),
+		},
+		{
+			description: "Successful replace the ancestor of the tag",
+			uid:         models.UID("uid"),
+			deviceName:  "region/europe",
+			requiredMocks: func() {
+				device := &models.Device{
+					UID:      "uid",
+					TenantID: "tenant",
+					Tags:     []string{"region", "device2", "device3"},
+				}
+
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("DeviceSetTags", ctx, models.UID("uid"), []string{"device2", "device3", "region/europe"}).Return(int64(1), int64(1), nil).Once()
+				mock.On("DeviceChangeRecord", ctx, testifymock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
 		{
 			description: "Successful create a tag for the device",
 			uid:         models.UID("uid"),
@@ -66,6 +117,10 @@ func TestCreateTag(t *testing.T) {
 
 				mock.On("DeviceGet", ctx, models.UID(device.UID)).Return(device, nil).Once()
 				mock.On("DevicePushTag", ctx, models.UID(device.UID), "device6").Return(nil).Once()
+				mock.On("DeviceChangeRecord", ctx, testifymock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -122,6 +177,26 @@ func TestRemoveTag(t *testing.T) {
 			},
 			expected: NewErrTagNotFound("device2", nil),
 		},
+		{
+			description: "successful delete a tag and its descendants",
+			uid:         models.UID("uid"),
+			deviceName:  "region",
+			requiredMocks: func() {
+				device := &models.Device{
+					UID:      "uid",
+					TenantID: "tenant",
+					Tags:     []string{"region/europe", "device1"},
+				}
+
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				mock.On("DeviceSetTags", ctx, models.UID("uid"), []string{"device1"}).Return(int64(1), int64(1), nil).Once()
+				mock.On("DeviceChangeRecord", ctx, testifymock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
 		{
 			description: "fail delete a tag",
 			uid:         models.UID("uid"),
@@ -151,6 +226,10 @@ func TestRemoveTag(t *testing.T) {
 
 				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
 				mock.On("DevicePullTag", ctx, models.UID("uid"), "device1").Return(nil).Once()
+				mock.On("DeviceChangeRecord", ctx, testifymock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -214,6 +293,26 @@ func TestDeviceUpdateTag(t *testing.T) {
 			},
 			expected: errors.New("error", "layer", 1),
 		},
+		{
+			description: "successful update tags removing the ancestors",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			tags:        []string{"region", "region/europe", "device1"},
+			requiredMocks: func() {
+				device := &models.Device{
+					UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+					TenantID: "tenant",
+				}
+				storemock.On("DeviceGet", context.TODO(), models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")).Return(device, nil).Once()
+
+				tags := []string{"region/europe", "device1"}
+				storemock.On("DeviceSetTags", context.TODO(), models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"), tags).Return(int64(1), int64(2), nil).Once()
+				storemock.On("DeviceChangeRecord", context.TODO(), testifymock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).Once()
+				storemock.On("AuditEntryCreate", context.TODO(), testifymock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
 		{
 			description: "successful update tags for the device",
 			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
@@ -227,6 +326,10 @@ func TestDeviceUpdateTag(t *testing.T) {
 
 				tags := []string{"device1", "device2", "device3"}
 				storemock.On("DeviceSetTags", context.TODO(), models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"), tags).Return(int64(1), int64(3), nil).Once()
+				storemock.On("DeviceChangeRecord", context.TODO(), testifymock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).Once()
+				storemock.On("AuditEntryCreate", context.TODO(), testifymock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -12,6 +12,8 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -727,6 +729,14 @@ func TestDeleteDevice(t *testing.T) {
 					On("DeviceDelete", ctx, models.UID("uid")).
 					Return(nil).
 					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -851,6 +861,14 @@ func TestDeleteDevice(t *testing.T) {
 					On("DeviceDelete", ctx, models.UID("uid")).
 					Return(nil).
 					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -870,7 +888,7 @@ func TestDeleteDevice(t *testing.T) {
 }
 
 func TestRenameDevice(t *testing.T) {
-	mock := new(storemock.Store)
+	storeMock := new(storemock.Store)
 
 	ctx := context.TODO()
 
@@ -889,7 +907,7 @@ func TestRenameDevice(t *testing.T) {
 			uid:         models.UID("uid"),
 			device:      &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Identity: &models.DeviceIdentity{MAC: "00:00:00:00:00:00"}, Status: "accepted"},
 			requiredMocks: func(device *models.Device) {
-				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, errors.New("error", "", 0)).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, errors.New("error", "", 0)).Once()
 			},
 			expected: NewErrDeviceNotFound(models.UID("uid"), errors.New("error", "", 0)),
 		},
@@ -900,7 +918,7 @@ func TestRenameDevice(t *testing.T) {
 			uid:           models.UID("uid"),
 			device:        &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Identity: &models.DeviceIdentity{MAC: "00:00:00:00:00:00"}, Status: "accepted"},
 			requiredMocks: func(device *models.Device) {
-				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
 			},
 			expected: nil,
 		},
@@ -917,8 +935,8 @@ func TestRenameDevice(t *testing.T) {
 					TenantID: "tenant2",
 				}
 
-				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
-				mock.On("DeviceGetByName", ctx, "newname", "tenant", models.DeviceStatusAccepted).Return(device2, errors.New("error", "", 0)).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				storeMock.On("DeviceGetByName", ctx, "newname", "tenant", models.DeviceStatusAccepted).Return(device2, errors.New("error", "", 0)).Once()
 			},
 			expected: NewErrDeviceNotFound(models.UID("uid"), errors.New("error", "", 0)),
 		},
@@ -935,8 +953,8 @@ func TestRenameDevice(t *testing.T) {
 					TenantID: "tenant2",
 				}
 
-				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
-				mock.On("DeviceGetByName", ctx, "newname", "tenant", models.DeviceStatusAccepted).Return(device2, nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				storeMock.On("DeviceGetByName", ctx, "newname", "tenant", models.DeviceStatusAccepted).Return(device2, nil).Once()
 			},
 			expected: NewErrDeviceDuplicated("newname", nil),
 		},
@@ -947,9 +965,9 @@ func TestRenameDevice(t *testing.T) {
 			uid:           models.UID("uid"),
 			device:        &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Identity: &models.DeviceIdentity{MAC: "00:00:00:00:00:00"}, Status: "accepted"},
 			requiredMocks: func(device *models.Device) {
-				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
-				mock.On("DeviceGetByName", ctx, "anewname", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
-				mock.On("DeviceRename", ctx, models.UID("uid"), "anewname").Return(errors.New("error", "", 0)).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				storeMock.On("DeviceGetByName", ctx, "anewname", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceRename", ctx, models.UID("uid"), "anewname").Return(errors.New("error", "", 0)).Once()
 			},
 			expected: errors.New("error", "", 0),
 		},
@@ -960,9 +978,13 @@ func TestRenameDevice(t *testing.T) {
 			uid:           models.UID("uid"),
 			device:        &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Identity: &models.DeviceIdentity{MAC: "00:00:00:00:00:00"}, Status: "accepted"},
 			requiredMocks: func(device *models.Device) {
-				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
-				mock.On("DeviceGetByName", ctx, "anewname", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
-				mock.On("DeviceRename", ctx, models.UID("uid"), "anewname").Return(nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				storeMock.On("DeviceGetByName", ctx, "anewname", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceRename", ctx, models.UID("uid"), "anewname").Return(nil).Once()
+				storeMock.On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).Once()
+				storeMock.On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -972,13 +994,13 @@ func TestRenameDevice(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks(tc.device)
 
-			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+			service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
 			err := service.RenameDevice(ctx, tc.uid, tc.deviceNewName, tc.tenant)
 			assert.Equal(t, tc.expected, err)
 		})
 	}
 
-	mock.AssertExpectations(t)
+	storeMock.AssertExpectations(t)
 }
 
 func TestLookupDevice(t *testing.T) {
@@ -986,6 +1008,11 @@ func TestLookupDevice(t *testing.T) {
 
 	ctx := context.TODO()
 
+	now := time.Now()
+	clockMock := new(clockmock.Clock)
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
 	type Expected struct {
 		device *models.Device
 		err    error
@@ -1017,12 +1044,63 @@ func TestLookupDevice(t *testing.T) {
 			requiredMocks: func(device *models.Device, namespace string) {
 				mock.On("DeviceLookup", ctx, namespace, device.Name).
 					Return(nil, store.ErrNoDocuments).Once()
+				mock.On("NamespaceGetByName", ctx, namespace).
+					Return(&models.Namespace{TenantID: "grantee", Name: namespace}, nil).Once()
+				mock.On("DeviceShareListByGrantee", ctx, "grantee", now).
+					Return([]models.DeviceShare{}, nil).Once()
+			},
+			expected: Expected{
+				nil,
+				NewErrDeviceLookupNotFound("namespace", "name", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the device is only shared for reading",
+			namespace:   "namespace",
+			device:      &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Identity: &models.DeviceIdentity{MAC: "00:00:00:00:00:00"}, Status: "accepted"},
+			requiredMocks: func(device *models.Device, namespace string) {
+				mock.On("DeviceLookup", ctx, namespace, device.Name).
+					Return(nil, store.ErrNoDocuments).Once()
+				mock.On("NamespaceGetByName", ctx, namespace).
+					Return(&models.Namespace{TenantID: "grantee", Name: namespace}, nil).Once()
+				mock.On("DeviceShareListByGrantee", ctx, "grantee", now).
+					Return([]models.DeviceShare{
+						{ID: "share", TenantID: "tenant", UID: "uid", GranteeTenantID: "grantee", Permission: models.DeviceSharePermissionRead},
+					}, nil).Once()
 			},
 			expected: Expected{
 				nil,
 				NewErrDeviceLookupNotFound("namespace", "name", store.ErrNoDocuments),
 			},
 		},
+		{
+			description: "succeeds when the device is shared allowing the connections",
+			namespace:   "namespace",
+			device:      &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Identity: &models.DeviceIdentity{MAC: "00:00:00:00:00:00"}, Status: "accepted"},
+			requiredMocks: func(device *models.Device, namespace string) {
+				mock.On("DeviceLookup", ctx, namespace, device.Name).
+					Return(nil, store.ErrNoDocuments).Once()
+				mock.On("NamespaceGetByName", ctx, namespace).
+					Return(&models.Namespace{TenantID: "grantee", Name: namespace}, nil).Once()
+				mock.On("DeviceShareListByGrantee", ctx, "grantee", now).
+					Return([]models.DeviceShare{
+						{ID: "other", TenantID: "tenant", UID: "other", GranteeTenantID: "grantee", Permission: models.DeviceSharePermissionConnect},
+						{ID: "share", TenantID: "tenant", UID: "uid", GranteeTenantID: "grantee", Permission: models.DeviceSharePermissionConnect},
+					}, nil).Once()
+				mock.On("DeviceGetByName", ctx, device.Name, "tenant", models.DeviceStatusAccepted).
+					Return(&models.Device{UID: "uid", Name: "name", TenantID: "tenant", Status: "accepted"}, nil).Twice()
+			},
+			expected: Expected{
+				&models.Device{
+					UID:      "uid",
+					Name:     "name",
+					TenantID: "tenant",
+					Status:   "accepted",
+					Shared:   &models.DeviceShare{ID: "share", TenantID: "tenant", UID: "uid", GranteeTenantID: "grantee", Permission: models.DeviceSharePermissionConnect},
+				},
+				nil,
+			},
+		},
 		{
 			description: "succeeds",
 			namespace:   "namespace",
@@ -1089,6 +1167,10 @@ func TestOfflineDevice(t *testing.T) {
 					On("DeviceSetOffline", ctx, "uid").
 					Return(nil).
 					Once()
+				storeMock.
+					On("DeviceGet", ctx, models.UID("uid")).
+					Return(&models.Device{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -1107,6 +1189,96 @@ func TestOfflineDevice(t *testing.T) {
 	storeMock.AssertExpectations(t)
 }
 
+func TestOfflineDevice_grace(t *testing.T) {
+	storeMock := new(storemock.Store)
+
+	clockMock := new(clockmock.Clock)
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	cases := []struct {
+		name      string
+		grace     time.Duration
+		minOnline time.Duration
+		mocks     func(context.Context)
+		expected  error
+	}{
+		{
+			name:  "fails when connected_device does not exist",
+			grace: time.Minute,
+			mocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetConnected", ctx, "uid").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			name:  "schedules the offline after the grace period",
+			grace: time.Minute,
+			mocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetConnected", ctx, "uid").
+					Return(&models.ConnectedDevice{UID: "uid", ConnectedAt: now.Add(-time.Hour)}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetOfflineAt", ctx, "uid", now.Add(time.Minute)).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			name:      "schedules the offline after the minimum online duration",
+			grace:     time.Minute,
+			minOnline: 5 * time.Minute,
+			mocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetConnected", ctx, "uid").
+					Return(&models.ConnectedDevice{UID: "uid", ConnectedAt: now.Add(-time.Minute)}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetOfflineAt", ctx, "uid", now.Add(4*time.Minute)).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			name:      "sets offline when the minimum online duration is over",
+			minOnline: 5 * time.Minute,
+			mocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetConnected", ctx, "uid").
+					Return(&models.ConnectedDevice{UID: "uid", ConnectedAt: now.Add(-time.Hour)}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetOffline", ctx, "uid").
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceGet", ctx, models.UID("uid")).
+					Return(&models.Device{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, WithDeviceOffline(tc.grace, tc.minOnline))
+
+			ctx := context.Background()
+			tc.mocks(ctx)
+			assert.Equal(t, tc.expected, s.OfflineDevice(ctx, "uid"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
 func TestUpdateDeviceStatus_same_mac(t *testing.T) {
 	storeMock := new(storemock.Store)
 	queryOptionsMock := new(storemock.QueryOptions)
@@ -1593,6 +1765,14 @@ func TestUpdateDeviceStatus_same_mac(t *testing.T) {
 					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
 					Return(nil).
 					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -1832,7 +2012,7 @@ func TestUpdateDeviceStatus_community_and_enterprise(t *testing.T) {
 			expected: NewErrDeviceMaxDevicesReached(3),
 		},
 		{
-			description: "fails when could not update device status on database",
+			description: "succeeds when the device is exempt from the limit of devices",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -1842,7 +2022,9 @@ func TestUpdateDeviceStatus_community_and_enterprise(t *testing.T) {
 					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
 					Return(
 						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
+							TenantID:     "00000000-0000-0000-0000-000000000000",
+							MaxDevices:   3,
+							DevicesCount: 3,
 						},
 						nil,
 					).
@@ -1851,17 +2033,19 @@ func TestUpdateDeviceStatus_community_and_enterprise(t *testing.T) {
 					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
 					Return(
 						&models.Device{
-							UID:       "uid",
-							Name:      "name",
-							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "pending",
-							Identity:  &models.DeviceIdentity{MAC: "mac"},
-							CreatedAt: time.Time{},
+							UID:         "uid",
+							Name:        "name",
+							TenantID:    "00000000-0000-0000-0000-000000000000",
+							Status:      "pending",
+							Identity:    &models.DeviceIdentity{MAC: "mac"},
+							LimitExempt: true,
+							CreatedAt:   time.Time{},
 						},
 						nil,
 					).
 					Once()
-				storeMock.On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
 					Return(nil, store.ErrNoDocuments).
 					Once()
 				storeMock.
@@ -1878,13 +2062,21 @@ func TestUpdateDeviceStatus_community_and_enterprise(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
-					Return(errors.New("error", "", 0)).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
 					Once()
 			},
-			expected: errors.New("error", "", 0),
+			expected: nil,
 		},
 		{
-			description: "success to update device status",
+			description: "succeeds when the namespace's exempt devices are not counted",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -1894,7 +2086,10 @@ func TestUpdateDeviceStatus_community_and_enterprise(t *testing.T) {
 					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
 					Return(
 						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
+							TenantID:                "00000000-0000-0000-0000-000000000000",
+							MaxDevices:              3,
+							DevicesCount:            3,
+							LimitExemptDevicesCount: 1,
 						},
 						nil,
 					).
@@ -1923,86 +2118,29 @@ func TestUpdateDeviceStatus_community_and_enterprise(t *testing.T) {
 					Once()
 				envMock.
 					On("Get", "SHELLHUB_CLOUD").
-					Return("false").Once()
+					Return("false").
+					Once()
 				envMock.
 					On("Get", "SHELLHUB_ENTERPRISE").
-					Return("false").Once()
+					Return("false").
+					Once()
 				storeMock.
 					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
 					Return(nil).
 					Once()
-			},
-			expected: nil,
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.description, func(t *testing.T) {
-			tc.requiredMocks()
-
-			service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
-			err := service.UpdateDeviceStatus(ctx, tc.tenant, tc.uid, tc.status)
-			assert.Equal(t, tc.expected, err)
-		})
-	}
-
-	storeMock.AssertExpectations(t)
-}
-
-func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
-	storeMock := new(storemock.Store)
-	queryOptionsMock := new(storemock.QueryOptions)
-	storeMock.On("Options").Return(queryOptionsMock)
-
-	ctx := context.TODO()
-
-	cases := []struct {
-		description   string
-		uid           models.UID
-		status        models.DeviceStatus
-		tenant        string
-		requiredMocks func()
-		expected      error
-	}{
-		{
-			description: "fails when could not get the namespace",
-			uid:         models.UID("uid"),
-			status:      "accepted",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
-				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(nil, errors.New("error", "", 0)).
-					Once()
-			},
-			expected: NewErrNamespaceNotFound("00000000-0000-0000-0000-000000000000", errors.New("error", "", 0)),
-		},
-		{
-			description: "fails when could not get the device",
-			uid:         models.UID("uid"),
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			status:      "accepted",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(
-						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
-						},
-						nil,
-					).
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
 					Once()
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
-					Return(nil, errors.New("error", "", 0)).
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
 					Once()
 			},
-			expected: NewErrDeviceNotFound("uid", errors.New("error", "", 0)),
+			expected: nil,
 		},
 		{
-			description: "fails when device already accepted",
+			description: "fails when could not update device status on database",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2024,18 +2162,37 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 							UID:       "uid",
 							Name:      "name",
 							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "accepted",
+							Status:    "pending",
 							Identity:  &models.DeviceIdentity{MAC: "mac"},
 							CreatedAt: time.Time{},
 						},
 						nil,
 					).
 					Once()
+				storeMock.On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_CLOUD").
+					Return("false").
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_ENTERPRISE").
+					Return("false").
+					Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(errors.New("error", "", 0)).
+					Once()
 			},
-			expected: NewErrDeviceStatusAccepted(nil),
+			expected: errors.New("error", "", 0),
 		},
 		{
-			description: "fails when could not get the device by MAC",
+			description: "success to update device status",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2066,48 +2223,7 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
-					Return(nil, errors.New("error", "", 0)).
-					Once()
-			},
-			expected: NewErrDeviceNotFound(models.UID("uid"), errors.New("error", "", 0)),
-		},
-		{
-			description: "fails when namespace has a subscription active and could not report the device accepted",
-			uid:         models.UID("uid"),
-			status:      "accepted",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
-				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(
-						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
-							Billing: &models.Billing{
-								Active: true,
-							},
-						},
-						nil,
-					).
-					Once()
-
-				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
-					Return(
-						&models.Device{
-							UID:       "uid",
-							Name:      "name",
-							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "pending",
-							Identity:  &models.DeviceIdentity{MAC: "mac"},
-							CreatedAt: time.Time{},
-						},
-						nil,
-					).
-					Once()
-				storeMock.
-					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
-					Return(nil, store.ErrNoDocuments).
+					Return(nil, store.ErrNoDocuments).
 					Once()
 				storeMock.
 					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
@@ -2115,20 +2231,27 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 					Once()
 				envMock.
 					On("Get", "SHELLHUB_CLOUD").
-					Return("true").
-					Twice()
+					Return("false").Once()
 				envMock.
 					On("Get", "SHELLHUB_ENTERPRISE").
 					Return("false").Once()
-				clientMock.
-					On("BillingReport", "00000000-0000-0000-0000-000000000000", "device_accept").
-					Return(0, errors.New("error", "", 0)).
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
 					Once()
 			},
-			expected: NewErrBillingReportNamespaceDelete(errors.New("error", "", 0)),
+			expected: nil,
 		},
 		{
-			description: "fails when namespace has a subscription active and report block the action",
+			description: "succeeds naming the device from the namespace's template",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2139,9 +2262,7 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 					Return(
 						&models.Namespace{
 							TenantID: "00000000-0000-0000-0000-000000000000",
-							Billing: &models.Billing{
-								Active: true,
-							},
+							Settings: &models.NamespaceSettings{DeviceNameTemplate: "{{.Hostname}}-{{.Identity.MAC | last4}}"},
 						},
 						nil,
 					).
@@ -2154,37 +2275,51 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 							Name:      "name",
 							TenantID:  "00000000-0000-0000-0000-000000000000",
 							Status:    "pending",
-							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							Identity:  &models.DeviceIdentity{MAC: "aa:bb:cc:dd:ee:ff"},
 							CreatedAt: time.Time{},
 						},
 						nil,
 					).
 					Once()
 				storeMock.
-					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
+					On("DeviceGetByMac", ctx, "aa:bb:cc:dd:ee:ff", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
 					Return(nil, store.ErrNoDocuments).
 					Once()
 				storeMock.
-					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
-					Return(nil, store.ErrNoDocuments).
+					On("DeviceGetByName", ctx, "name-eeff", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(&models.Device{UID: "other", Name: "name-eeff"}, nil).
 					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "name-eeff-2", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Twice()
 				envMock.
 					On("Get", "SHELLHUB_CLOUD").
-					Return("true").
-					Twice()
+					Return("false").Once()
 				envMock.
 					On("Get", "SHELLHUB_ENTERPRISE").
-					Return("false").
+					Return("false").Once()
+				storeMock.
+					On("DeviceRename", ctx, models.UID("uid"), "name-eeff-2").
+					Return(nil).
 					Once()
-				clientMock.
-					On("BillingReport", "00000000-0000-0000-0000-000000000000", "device_accept").
-					Return(402, nil).
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
 					Once()
 			},
-			expected: NewErrBillingReportNamespaceDelete(ErrPaymentRequired),
+			expected: nil,
 		},
 		{
-			description: "fails when could not update device status on database",
+			description: "succeeds applying the namespace's default tags",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2195,9 +2330,7 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 					Return(
 						&models.Namespace{
 							TenantID: "00000000-0000-0000-0000-000000000000",
-							Billing: &models.Billing{
-								Active: true,
-							},
+							Settings: &models.NamespaceSettings{DefaultTags: []string{"unconfigured", "site"}},
 						},
 						nil,
 					).
@@ -2211,6 +2344,7 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 							TenantID:  "00000000-0000-0000-0000-000000000000",
 							Status:    "pending",
 							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							Tags:      []string{"site/lab"},
 							CreatedAt: time.Time{},
 						},
 						nil,
@@ -2226,25 +2360,31 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 					Once()
 				envMock.
 					On("Get", "SHELLHUB_CLOUD").
-					Return("true").
-					Twice()
+					Return("false").Once()
 				envMock.
 					On("Get", "SHELLHUB_ENTERPRISE").
-					Return("false").
+					Return("false").Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(nil).
 					Once()
-				clientMock.
-					On("BillingReport", "00000000-0000-0000-0000-000000000000", "device_accept").
-					Return(200, nil).
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Times(2)
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
 					Once()
 				storeMock.
-					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
-					Return(errors.New("error", "", 0)).
+					On("DeviceSetTags", ctx, models.UID("uid"), []string{"site/lab", "unconfigured"}).
+					Return(int64(1), int64(1), nil).
 					Once()
 			},
-			expected: errors.New("error", "", 0),
+			expected: nil,
 		},
 		{
-			description: "success to update device status",
+			description: "succeeds ignoring the default tags over the device's tags limit",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2255,9 +2395,7 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 					Return(
 						&models.Namespace{
 							TenantID: "00000000-0000-0000-0000-000000000000",
-							Billing: &models.Billing{
-								Active: true,
-							},
+							Settings: &models.NamespaceSettings{DefaultTags: []string{"unconfigured"}},
 						},
 						nil,
 					).
@@ -2271,6 +2409,7 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 							TenantID:  "00000000-0000-0000-0000-000000000000",
 							Status:    "pending",
 							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							Tags:      []string{"tag1", "tag2", "tag3"},
 							CreatedAt: time.Time{},
 						},
 						nil,
@@ -2286,18 +2425,20 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 					Once()
 				envMock.
 					On("Get", "SHELLHUB_CLOUD").
-					Return("true").
-					Twice()
+					Return("false").Once()
 				envMock.
 					On("Get", "SHELLHUB_ENTERPRISE").
-					Return("false").
+					Return("false").Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(nil).
 					Once()
-				clientMock.
-					On("BillingReport", "00000000-0000-0000-0000-000000000000", "device_accept").
-					Return(200, nil).
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
 					Once()
 				storeMock.
-					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
 					Return(nil).
 					Once()
 			},
@@ -2318,7 +2459,7 @@ func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 	storeMock.AssertExpectations(t)
 }
 
-func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
+func TestUpdateDeviceStatus_cloud_subscription_active(t *testing.T) {
 	storeMock := new(storemock.Store)
 	queryOptionsMock := new(storemock.QueryOptions)
 	storeMock.On("Options").Return(queryOptionsMock)
@@ -2441,7 +2582,7 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 			expected: NewErrDeviceNotFound(models.UID("uid"), errors.New("error", "", 0)),
 		},
 		{
-			description: "fails when could not check if device was removed recently",
+			description: "fails when namespace has a subscription active and could not report the device accepted",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2452,10 +2593,14 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Return(
 						&models.Namespace{
 							TenantID: "00000000-0000-0000-0000-000000000000",
+							Billing: &models.Billing{
+								Active: true,
+							},
 						},
 						nil,
 					).
 					Once()
+
 				storeMock.
 					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
 					Return(
@@ -2484,17 +2629,16 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Twice()
 				envMock.
 					On("Get", "SHELLHUB_ENTERPRISE").
-					Return("false").
-					Once()
-				storeMock.
-					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil, errors.New("error", "", 0)).
+					Return("false").Once()
+				clientMock.
+					On("BillingReport", "00000000-0000-0000-0000-000000000000", "device_accept").
+					Return(0, errors.New("error", "", 0)).
 					Once()
 			},
-			expected: NewErrDeviceRemovedGet(errors.New("error", "", 0)),
+			expected: NewErrBillingReportNamespaceDelete(errors.New("error", "", 0)),
 		},
 		{
-			description: "fails when could not count how many devices were removed recently",
+			description: "fails when namespace has a subscription active and report block the action",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2505,6 +2649,9 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Return(
 						&models.Namespace{
 							TenantID: "00000000-0000-0000-0000-000000000000",
+							Billing: &models.Billing{
+								Active: true,
+							},
 						},
 						nil,
 					).
@@ -2539,19 +2686,15 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					On("Get", "SHELLHUB_ENTERPRISE").
 					Return("false").
 					Once()
-				storeMock.
-					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil, nil).
-					Once()
-				storeMock.
-					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
-					Return(int64(0), errors.New("error", "", 0)).
+				clientMock.
+					On("BillingReport", "00000000-0000-0000-0000-000000000000", "device_accept").
+					Return(402, nil).
 					Once()
 			},
-			expected: NewErrDeviceRemovedCount(errors.New("error", "", 0)),
+			expected: NewErrBillingReportNamespaceDelete(ErrPaymentRequired),
 		},
 		{
-			description: "fails when namespace has reached the limit counting with removed devices",
+			description: "fails when could not update device status on database",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2561,11 +2704,9 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
 					Return(
 						&models.Namespace{
-							TenantID:     "00000000-0000-0000-0000-000000000000",
-							MaxDevices:   3,
-							DevicesCount: 1,
+							TenantID: "00000000-0000-0000-0000-000000000000",
 							Billing: &models.Billing{
-								Active: false,
+								Active: true,
 							},
 						},
 						nil,
@@ -2601,19 +2742,19 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					On("Get", "SHELLHUB_ENTERPRISE").
 					Return("false").
 					Once()
-				storeMock.
-					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil, nil).
+				clientMock.
+					On("BillingReport", "00000000-0000-0000-0000-000000000000", "device_accept").
+					Return(200, nil).
 					Once()
 				storeMock.
-					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
-					Return(int64(2), nil).
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(errors.New("error", "", 0)).
 					Once()
 			},
-			expected: NewErrDeviceRemovedFull(3, nil),
+			expected: errors.New("error", "", 0),
 		},
 		{
-			description: "fails when could not evaluate the namespace capabilities when accepted device is not removed",
+			description: "success to update device status",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2624,6 +2765,9 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Return(
 						&models.Namespace{
 							TenantID: "00000000-0000-0000-0000-000000000000",
+							Billing: &models.Billing{
+								Active: true,
+							},
 						},
 						nil,
 					).
@@ -2658,26 +2802,74 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					On("Get", "SHELLHUB_ENTERPRISE").
 					Return("false").
 					Once()
+				clientMock.
+					On("BillingReport", "00000000-0000-0000-0000-000000000000", "device_accept").
+					Return(200, nil).
+					Once()
 				storeMock.
-					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil, nil).
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(nil).
 					Once()
 				storeMock.
-					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
-					Return(int64(1), nil).
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
 					Once()
-				clientMock.
-					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
-					Return(nil, 0, errors.New("error", "", 0)).
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
 					Once()
 			},
-			expected: NewErrBillingEvaluate(ErrEvaluate),
+			expected: nil,
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+			err := service.UpdateDeviceStatus(ctx, tc.tenant, tc.uid, tc.status)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
+	storeMock := new(storemock.Store)
+	queryOptionsMock := new(storemock.QueryOptions)
+	storeMock.On("Options").Return(queryOptionsMock)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		status        models.DeviceStatus
+		tenant        string
+		requiredMocks func()
+		expected      error
+	}{
 		{
-			description: "fails when namespace cannot accept more devices",
+			description: "fails when could not get the namespace",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: NewErrNamespaceNotFound("00000000-0000-0000-0000-000000000000", errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when could not get the device",
+			uid:         models.UID("uid"),
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			status:      "accepted",
 			requiredMocks: func() {
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				storeMock.
@@ -2691,57 +2883,83 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
-					Return(
-						&models.Device{
-							UID:       "uid",
-							Name:      "name",
-							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "pending",
-							Identity:  &models.DeviceIdentity{MAC: "mac"},
-							CreatedAt: time.Time{},
-						},
-						nil,
-					).
-					Once()
-				storeMock.
-					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
-					Return(nil, store.ErrNoDocuments).
+					Return(nil, errors.New("error", "", 0)).
 					Once()
-				storeMock.
-					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
-					Return(nil, store.ErrNoDocuments).
-					Once()
-				envMock.
-					On("Get", "SHELLHUB_CLOUD").
-					Return("true").
-					Twice()
-				envMock.
-					On("Get", "SHELLHUB_ENTERPRISE").
-					Return("false").
+			},
+			expected: NewErrDeviceNotFound("uid", errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when device already accepted",
+			uid:         models.UID("uid"),
+			status:      "accepted",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
 					Once()
 				storeMock.
-					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil, nil).
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "accepted",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						},
+						nil,
+					).
 					Once()
+			},
+			expected: NewErrDeviceStatusAccepted(nil),
+		},
+		{
+			description: "fails when could not get the device by MAC",
+			uid:         models.UID("uid"),
+			status:      "accepted",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				storeMock.
-					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
-					Return(int64(1), nil).
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
 					Once()
-				clientMock.
-					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
 					Return(
-						&models.BillingEvaluation{
-							CanAccept: false,
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "pending",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
 						},
-						0,
 						nil,
 					).
 					Once()
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
+					Return(nil, errors.New("error", "", 0)).
+					Once()
 			},
-			expected: ErrDeviceLimit,
+			expected: NewErrDeviceNotFound(models.UID("uid"), errors.New("error", "", 0)),
 		},
 		{
-			description: "fails to update the device status when device is not on removed list",
+			description: "fails when could not check if device was removed recently",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2788,31 +3006,13 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil, nil).
-					Once()
-				storeMock.
-					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
-					Return(int64(1), nil).
-					Once()
-				clientMock.
-					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
-					Return(
-						&models.BillingEvaluation{
-							CanAccept: true,
-						},
-						0,
-						nil,
-					).
-					Once()
-				storeMock.
-					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
-					Return(errors.New("error", "", 0)).
+					Return(nil, errors.New("error", "", 0)).
 					Once()
 			},
-			expected: errors.New("error", "", 0),
+			expected: NewErrDeviceRemovedGet(errors.New("error", "", 0)),
 		},
 		{
-			description: "success to update the device status when device is not on removed list",
+			description: "fails when could not count how many devices were removed recently",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2863,27 +3063,13 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
-					Return(int64(1), nil).
-					Once()
-				clientMock.
-					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
-					Return(
-						&models.BillingEvaluation{
-							CanAccept: true,
-						},
-						0,
-						nil,
-					).
-					Once()
-				storeMock.
-					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
-					Return(nil).
+					Return(int64(0), errors.New("error", "", 0)).
 					Once()
 			},
-			expected: nil,
+			expected: NewErrDeviceRemovedCount(errors.New("error", "", 0)),
 		},
 		{
-			description: "fail when could not remove the device from removed device list when device is on removed list",
+			description: "fails when namespace has reached the limit counting with removed devices",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2893,7 +3079,12 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
 					Return(
 						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
+							TenantID:     "00000000-0000-0000-0000-000000000000",
+							MaxDevices:   3,
+							DevicesCount: 1,
+							Billing: &models.Billing{
+								Active: false,
+							},
 						},
 						nil,
 					).
@@ -2930,17 +3121,17 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(&models.DeviceRemoved{}, nil).
+					Return(nil, nil).
 					Once()
 				storeMock.
-					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(errors.New("error", "", 0)).
+					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
+					Return(int64(2), nil).
 					Once()
 			},
-			expected: NewErrDeviceRemovedDelete(errors.New("error", "", 0)),
+			expected: NewErrDeviceRemovedFull(3, nil),
 		},
 		{
-			description: "fail when could not evaluate the namespace when device is on removed list",
+			description: "fails when could not evaluate the namespace capabilities when accepted device is not removed",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -2987,10 +3178,11 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(&models.DeviceRemoved{}, nil).Once()
+					Return(nil, nil).
+					Once()
 				storeMock.
-					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil).
+					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
+					Return(int64(1), nil).
 					Once()
 				clientMock.
 					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
@@ -3000,7 +3192,7 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 			expected: NewErrBillingEvaluate(ErrEvaluate),
 		},
 		{
-			description: "fails when namespace evaluation block device acceptance when device is on removed list",
+			description: "fails when namespace cannot accept more devices",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -3047,11 +3239,11 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(&models.DeviceRemoved{}, nil).
+					Return(nil, nil).
 					Once()
 				storeMock.
-					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil).
+					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
+					Return(int64(1), nil).
 					Once()
 				clientMock.
 					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
@@ -3067,7 +3259,7 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 			expected: ErrDeviceLimit,
 		},
 		{
-			description: "fails to update device status when device is on removed list",
+			description: "fails to update the device status when device is not on removed list",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -3114,11 +3306,11 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(&models.DeviceRemoved{}, nil).
+					Return(nil, nil).
 					Once()
 				storeMock.
-					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil).
+					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
+					Return(int64(1), nil).
 					Once()
 				clientMock.
 					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
@@ -3138,7 +3330,7 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 			expected: errors.New("error", "", 0),
 		},
 		{
-			description: "success to update device status when device is on removed list",
+			description: "success to update the device status when device is not on removed list",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -3185,11 +3377,11 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					Once()
 				storeMock.
 					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(&models.DeviceRemoved{}, nil).
+					Return(nil, nil).
 					Once()
 				storeMock.
-					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
-					Return(nil).
+					On("DeviceRemovedCount", ctx, "00000000-0000-0000-0000-000000000000").
+					Return(int64(1), nil).
 					Once()
 				clientMock.
 					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
@@ -3205,246 +3397,76 @@ func TestUpdateDeviceStatus_cloud_subscription_inactive(t *testing.T) {
 					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
 					Return(nil).
 					Once()
-			},
-			expected: nil,
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.description, func(t *testing.T) {
-			tc.requiredMocks()
-
-			service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
-			err := service.UpdateDeviceStatus(ctx, tc.tenant, tc.uid, tc.status)
-			assert.Equal(t, tc.expected, err)
-		})
-	}
-
-	storeMock.AssertExpectations(t)
-}
-
-func TestDeviceUpdate(t *testing.T) {
-	storeMock := new(storemock.Store)
-
-	toPointer := func(s string) *string {
-		return &s
-	}
-
-	other := toPointer("other")
-
-	cases := []struct {
-		description   string
-		uid           string
-		tenant        string
-		name          *string
-		publicKey     *bool
-		requiredMocks func(ctx context.Context)
-		expected      error
-	}{
-		{
-			description: "fails when could not get the device by UID",
-			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			name:        nil,
-			publicKey:   nil,
-			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
-					Return(nil, errors.New("error", "", 0)).
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
 					Once()
-			},
-			expected: NewErrDeviceNotFound(models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), errors.New("error", "", 0)),
-		},
-		{
-			description: "success when updating the device name to same name",
-			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			name:        toPointer("name"),
-			publicKey:   nil,
-			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
-					Return(
-						&models.Device{
-							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-							Name: "name",
-						},
-						nil,
-					).
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
 					Once()
 			},
 			expected: nil,
 		},
 		{
-			description: "fails when name does not meet the validation requirements",
-			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+			description: "fail when could not remove the device from removed device list when device is on removed list",
+			uid:         models.UID("uid"),
+			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
-			name:        toPointer(""),
-			publicKey:   nil,
-			requiredMocks: func(ctx context.Context) {
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
 					Return(
-						&models.Device{
-							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-							Name: "name",
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
 						},
 						nil,
 					).
 					Once()
-			},
-			expected: NewErrDeviceInvalid(map[string]interface{}{"name": ""}, nil),
-		},
-		{
-			description: "fails when could not get the device by name",
-			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			name:        toPointer("same"),
-			publicKey:   nil,
-			requiredMocks: func(ctx context.Context) {
-				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
-					Return(
-						&models.Device{
-							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-							Name: "name",
-						},
-						nil,
-					).Once()
-				storeMock.
-					On("DeviceGetByName", ctx, "same", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
-					Return(nil, errors.New("error", "", 0)).
-					Once()
-			},
-			expected: NewErrDeviceNotFound(models.UID("same"), fmt.Errorf("failed to get device by name: %w", errors.New("error", "", 0))),
-		},
-		{
-			description: "fails when already exists a device with same name",
-			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			name:        toPointer("same"),
-			publicKey:   nil,
-			requiredMocks: func(ctx context.Context) {
-				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
-					Return(
-						&models.Device{
-							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-							Name: "name",
-						},
-						nil,
-					).
-					Once()
-				storeMock.
-					On("DeviceGetByName", ctx, "same", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
-					Return(
-						&models.Device{
-							UID:  "fb2de504e98d3ccab342b53d83395cd7fda297c71e8da550c31478bae0dbb8c5",
-							Name: "same",
-						},
-						nil,
-					).
-					Once()
-			},
-			expected: NewErrDeviceDuplicated("same", nil),
-		},
-		{
-			description: "success when update device for a different name",
-			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			name:        other,
-			publicKey:   new(bool),
-			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
 					Return(
 						&models.Device{
-							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
-							Name: "name",
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "pending",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
 						},
 						nil,
 					).
 					Once()
 				storeMock.
-					On("DeviceGetByName", ctx, "other", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
 					Return(nil, store.ErrNoDocuments).
 					Once()
 				storeMock.
-					On("DeviceUpdate", ctx, "00000000-0000-0000-0000-000000000000", models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), other, new(bool)).
-					Return(nil).
+					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
 					Once()
-			},
-			expected: nil,
-		},
-	}
-
-	service := NewService(storeMock, privateKey, publicKey, storecache.NewNullCache(), clientMock)
-
-	for _, test := range cases {
-		t.Run(test.description, func(t *testing.T) {
-			ctx := context.Background()
-			test.requiredMocks(ctx)
-
-			err := service.UpdateDevice(ctx, test.tenant, models.UID(test.uid), test.name, test.publicKey)
-			assert.Equal(t, test.expected, err)
-		})
-	}
-}
-
-func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
-	storeMock := new(storemock.Store)
-	queryOptionsMock := new(storemock.QueryOptions)
-	storeMock.On("Options").Return(queryOptionsMock)
-
-	ctx := context.TODO()
-
-	cases := []struct {
-		description   string
-		uid           models.UID
-		status        models.DeviceStatus
-		tenant        string
-		requiredMocks func()
-		expected      error
-	}{
-		{
-			description: "fails when could not get the namespace",
-			uid:         models.UID("uid"),
-			status:      "accepted",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
-				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(nil, errors.New("error", "", 0)).
+				envMock.
+					On("Get", "SHELLHUB_CLOUD").
+					Return("true").
+					Twice()
+				envMock.
+					On("Get", "SHELLHUB_ENTERPRISE").
+					Return("false").
 					Once()
-			},
-			expected: NewErrNamespaceNotFound("00000000-0000-0000-0000-000000000000", errors.New("error", "", 0)),
-		},
-		{
-			description: "fails when could not get the device",
-			uid:         models.UID("uid"),
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			status:      "accepted",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(
-						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
-						},
-						nil,
-					).
+					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(&models.DeviceRemoved{}, nil).
 					Once()
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
-					Return(nil, errors.New("error", "", 0)).
+					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(errors.New("error", "", 0)).
 					Once()
 			},
-			expected: NewErrDeviceNotFound("uid", errors.New("error", "", 0)),
+			expected: NewErrDeviceRemovedDelete(errors.New("error", "", 0)),
 		},
 		{
-			description: "fails when device already accepted",
+			description: "fail when could not evaluate the namespace when device is on removed list",
 			uid:         models.UID("uid"),
 			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
@@ -3466,20 +3488,47 @@ func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
 							UID:       "uid",
 							Name:      "name",
 							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "accepted",
+							Status:    "pending",
 							Identity:  &models.DeviceIdentity{MAC: "mac"},
 							CreatedAt: time.Time{},
 						},
 						nil,
 					).
 					Once()
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_CLOUD").
+					Return("true").
+					Twice()
+				envMock.
+					On("Get", "SHELLHUB_ENTERPRISE").
+					Return("false").
+					Once()
+				storeMock.
+					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(&models.DeviceRemoved{}, nil).Once()
+				storeMock.
+					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(nil).
+					Once()
+				clientMock.
+					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
+					Return(nil, 0, errors.New("error", "", 0)).
+					Once()
 			},
-			expected: NewErrDeviceStatusAccepted(nil),
+			expected: NewErrBillingEvaluate(ErrEvaluate),
 		},
 		{
-			description: "fails when the intended status is pending, but store update fails",
+			description: "fails when namespace evaluation block device acceptance when device is on removed list",
 			uid:         models.UID("uid"),
-			status:      "pending",
+			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
 			requiredMocks: func() {
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
@@ -3507,53 +3556,46 @@ func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
 					).
 					Once()
 				storeMock.
-					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("pending")).
-					Return(errors.New("error", "", 0)).
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
+					Return(nil, store.ErrNoDocuments).
 					Once()
-			},
-			expected: errors.New("error", "", 0),
-		},
-		{
-			description: "success to update device status when the intended status is pending",
-			uid:         models.UID("uid"),
-			status:      "pending",
-			tenant:      "00000000-0000-0000-0000-000000000000",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(
-						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
-						},
-						nil,
-					).
+					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_CLOUD").
+					Return("true").
+					Twice()
+				envMock.
+					On("Get", "SHELLHUB_ENTERPRISE").
+					Return("false").
 					Once()
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(&models.DeviceRemoved{}, nil).
+					Once()
+				storeMock.
+					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(nil).
+					Once()
+				clientMock.
+					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
 					Return(
-						&models.Device{
-							UID:       "uid",
-							Name:      "name",
-							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "pending",
-							Identity:  &models.DeviceIdentity{MAC: "mac"},
-							CreatedAt: time.Time{},
+						&models.BillingEvaluation{
+							CanAccept: false,
 						},
+						0,
 						nil,
 					).
 					Once()
-				storeMock.
-					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("pending")).
-					Return(nil).
-					Once()
 			},
-			expected: nil,
+			expected: ErrDeviceLimit,
 		},
 		{
-			description: "fails when the intended status is rejected, but store update fails",
+			description: "fails to update device status when device is on removed list",
 			uid:         models.UID("uid"),
-			status:      "rejected",
+			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
 			requiredMocks: func() {
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
@@ -3581,16 +3623,50 @@ func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
 					).
 					Once()
 				storeMock.
-					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("rejected")).
-					Return(errors.New("error", "", 0)).
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_CLOUD").
+					Return("true").
+					Twice()
+				envMock.
+					On("Get", "SHELLHUB_ENTERPRISE").
+					Return("false").
+					Once()
+				storeMock.
+					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(&models.DeviceRemoved{}, nil).
+					Once()
+				storeMock.
+					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(nil).
+					Once()
+				clientMock.
+					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.BillingEvaluation{
+							CanAccept: true,
+						},
+						0,
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(errors.New("error", "", 0)).
 					Once()
 			},
 			expected: errors.New("error", "", 0),
 		},
 		{
-			description: "success to update device status when the intended status is rejected",
+			description: "success to update device status when device is on removed list",
 			uid:         models.UID("uid"),
-			status:      "rejected",
+			status:      "accepted",
 			tenant:      "00000000-0000-0000-0000-000000000000",
 			requiredMocks: func() {
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
@@ -3613,133 +3689,615 @@ func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
 							Status:    "pending",
 							Identity:  &models.DeviceIdentity{MAC: "mac"},
 							CreatedAt: time.Time{},
-						}, nil,
+						},
+						nil,
 					).
 					Once()
 				storeMock.
-					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("rejected")).
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatus("accepted")).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_CLOUD").
+					Return("true").
+					Twice()
+				envMock.
+					On("Get", "SHELLHUB_ENTERPRISE").
+					Return("false").
+					Once()
+				storeMock.
+					On("DeviceRemovedGet", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(&models.DeviceRemoved{}, nil).
+					Once()
+				storeMock.
+					On("DeviceRemovedDelete", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid")).
+					Return(nil).
+					Once()
+				clientMock.
+					On("BillingEvaluate", "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.BillingEvaluation{
+							CanAccept: true,
+						},
+						0,
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("accepted")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
 					Return(nil).
 					Once()
 			},
 			expected: nil,
 		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+			err := service.UpdateDeviceStatus(ctx, tc.tenant, tc.uid, tc.status)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeviceUpdate(t *testing.T) {
+	storeMock := new(storemock.Store)
+
+	toPointer := func(s string) *string {
+		return &s
+	}
+
+	other := toPointer("other")
+
+	cases := []struct {
+		description   string
+		uid           string
+		tenant        string
+		name          *string
+		publicKey     *bool
+		requiredMocks func(ctx context.Context)
+		expected      error
+	}{
 		{
-			description: "fails when the device is already accepted",
-			uid:         models.UID("uid"),
-			status:      "accepted",
+			description: "fails when could not get the device by UID",
+			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
 			tenant:      "00000000-0000-0000-0000-000000000000",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+			name:        nil,
+			publicKey:   nil,
+			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(
-						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
-						},
-						nil,
-					).
+					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
+					Return(nil, errors.New("error", "", 0)).
 					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), errors.New("error", "", 0)),
+		},
+		{
+			description: "success when updating the device name to same name",
+			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			name:        toPointer("name"),
+			publicKey:   nil,
+			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
 					Return(
 						&models.Device{
-							UID:       "uid",
-							Name:      "name",
-							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "accepted",
-							Identity:  &models.DeviceIdentity{MAC: "mac"},
-							CreatedAt: time.Time{},
+							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+							Name: "name",
 						},
 						nil,
 					).
 					Once()
 			},
-			expected: NewErrDeviceStatusAccepted(nil),
+			expected: nil,
 		},
 		{
-			description: "fails when the intended status is removed",
-			uid:         models.UID("uid"),
-			status:      "removed",
+			description: "fails when name does not meet the validation requirements",
+			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
 			tenant:      "00000000-0000-0000-0000-000000000000",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+			name:        toPointer(""),
+			publicKey:   nil,
+			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
 					Return(
-						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
+						&models.Device{
+							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+							Name: "name",
 						},
 						nil,
 					).
 					Once()
+			},
+			expected: NewErrDeviceInvalid(map[string]interface{}{"name": ""}, nil),
+		},
+		{
+			description: "fails when could not get the device by name",
+			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			name:        toPointer("same"),
+			publicKey:   nil,
+			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
 					Return(
 						&models.Device{
-							UID:       "uid",
-							Name:      "name",
-							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "pending",
-							Identity:  &models.DeviceIdentity{MAC: "mac"},
-							CreatedAt: time.Time{},
-						}, nil,
-					).
+							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+							Name: "name",
+						},
+						nil,
+					).Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "same", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, errors.New("error", "", 0)).
 					Once()
 			},
-			expected: NewErrDeviceStatusInvalid("removed", nil),
+			expected: NewErrDeviceNotFound(models.UID("same"), fmt.Errorf("failed to get device by name: %w", errors.New("error", "", 0))),
 		},
 		{
-			description: "fails when the intended status is unused",
-			uid:         models.UID("uid"),
-			status:      "unused",
+			description: "fails when already exists a device with same name",
+			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
 			tenant:      "00000000-0000-0000-0000-000000000000",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+			name:        toPointer("same"),
+			publicKey:   nil,
+			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
 					Return(
-						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
+						&models.Device{
+							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+							Name: "name",
 						},
 						nil,
 					).
 					Once()
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					On("DeviceGetByName", ctx, "same", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
 					Return(
 						&models.Device{
-							UID:       "uid",
-							Name:      "name",
-							TenantID:  "00000000-0000-0000-0000-000000000000",
-							Status:    "pending",
-							Identity:  &models.DeviceIdentity{MAC: "mac"},
-							CreatedAt: time.Time{},
-						}, nil,
+							UID:  "fb2de504e98d3ccab342b53d83395cd7fda297c71e8da550c31478bae0dbb8c5",
+							Name: "same",
+						},
+						nil,
 					).
 					Once()
 			},
-			expected: NewErrDeviceStatusInvalid("unused", nil),
+			expected: NewErrDeviceDuplicated("same", nil),
 		},
 		{
-			description: "fails when the intended status is unknown",
-			uid:         models.UID("uid"),
-			status:      "unused",
+			description: "success when update device for a different name",
+			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
 			tenant:      "00000000-0000-0000-0000-000000000000",
-			requiredMocks: func() {
-				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+			name:        other,
+			publicKey:   new(bool),
+			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
 					Return(
-						&models.Namespace{
-							TenantID: "00000000-0000-0000-0000-000000000000",
+						&models.Device{
+							UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+							Name: "name",
 						},
 						nil,
 					).
 					Once()
 				storeMock.
-					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
-					Return(
-						&models.Device{
+					On("DeviceGetByName", ctx, "other", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceUpdate", ctx, "00000000-0000-0000-0000-000000000000", models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), other, new(bool)).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	service := NewService(storeMock, privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, test := range cases {
+		t.Run(test.description, func(t *testing.T) {
+			ctx := context.Background()
+			test.requiredMocks(ctx)
+
+			err := service.UpdateDevice(ctx, test.tenant, models.UID(test.uid), test.name, test.publicKey)
+			assert.Equal(t, test.expected, err)
+		})
+	}
+}
+
+func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
+	storeMock := new(storemock.Store)
+	queryOptionsMock := new(storemock.QueryOptions)
+	storeMock.On("Options").Return(queryOptionsMock)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		status        models.DeviceStatus
+		tenant        string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when could not get the namespace",
+			uid:         models.UID("uid"),
+			status:      "accepted",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: NewErrNamespaceNotFound("00000000-0000-0000-0000-000000000000", errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when could not get the device",
+			uid:         models.UID("uid"),
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			status:      "accepted",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: NewErrDeviceNotFound("uid", errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when device already accepted",
+			uid:         models.UID("uid"),
+			status:      "accepted",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "accepted",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						},
+						nil,
+					).
+					Once()
+			},
+			expected: NewErrDeviceStatusAccepted(nil),
+		},
+		{
+			description: "fails when the intended status is pending, but store update fails",
+			uid:         models.UID("uid"),
+			status:      "pending",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "pending",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("pending")).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "success to update device status when the intended status is pending",
+			uid:         models.UID("uid"),
+			status:      "pending",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "pending",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("pending")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the intended status is rejected, but store update fails",
+			uid:         models.UID("uid"),
+			status:      "rejected",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "pending",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("rejected")).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "success to update device status when the intended status is rejected",
+			uid:         models.UID("uid"),
+			status:      "rejected",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "pending",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						}, nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatus("rejected")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the device is already accepted",
+			uid:         models.UID("uid"),
+			status:      "accepted",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "accepted",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						},
+						nil,
+					).
+					Once()
+			},
+			expected: NewErrDeviceStatusAccepted(nil),
+		},
+		{
+			description: "fails when the intended status is removed",
+			uid:         models.UID("uid"),
+			status:      "removed",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "pending",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						}, nil,
+					).
+					Once()
+			},
+			expected: NewErrDeviceStatusInvalid("removed", nil),
+		},
+		{
+			description: "fails when the intended status is unused",
+			uid:         models.UID("uid"),
+			status:      "unused",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
+							UID:       "uid",
+							Name:      "name",
+							TenantID:  "00000000-0000-0000-0000-000000000000",
+							Status:    "pending",
+							Identity:  &models.DeviceIdentity{MAC: "mac"},
+							CreatedAt: time.Time{},
+						}, nil,
+					).
+					Once()
+			},
+			expected: NewErrDeviceStatusInvalid("unused", nil),
+		},
+		{
+			description: "fails when the intended status is unknown",
+			uid:         models.UID("uid"),
+			status:      "unused",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			requiredMocks: func() {
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(
+						&models.Namespace{
+							TenantID: "00000000-0000-0000-0000-000000000000",
+						},
+						nil,
+					).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(
+						&models.Device{
 							UID:       "uid",
 							Name:      "name",
 							TenantID:  "00000000-0000-0000-0000-000000000000",
@@ -3750,17 +4308,451 @@ func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
 					).
 					Once()
 			},
-			expected: NewErrDeviceStatusInvalid("unused", nil),
+			expected: NewErrDeviceStatusInvalid("unused", nil),
+		},
+	}
+
+	service := NewService(storeMock, privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := service.UpdateDeviceStatus(ctx, tc.tenant, tc.uid, tc.status)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestClaimDevice(t *testing.T) {
+	storeMock := new(storemock.Store)
+	queryOptionsMock := new(storemock.QueryOptions)
+	storeMock.On("Options").Return(queryOptionsMock)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		device *models.Device
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceClaim
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the code is invalid",
+			req:           &requests.DeviceClaim{TenantID: "00000000-0000-0000-0000-000000000000", Code: "7K3QF"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrDeviceClaimCodeInvalid("7K3QF")},
+		},
+		{
+			description: "fails when no pending device has the code",
+			req:         &requests.DeviceClaim{TenantID: "00000000-0000-0000-0000-000000000000", Code: "7k3qf m2xad"},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByClaimCode", ctx, "7K3QF-M2XAD", "00000000-0000-0000-0000-000000000000", models.DeviceStatusPending).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, NewErrDeviceClaimCodeNotFound("7K3QF-M2XAD", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the device cannot be accepted",
+			req:         &requests.DeviceClaim{TenantID: "00000000-0000-0000-0000-000000000000", Code: "7K3QF-M2XAD"},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByClaimCode", ctx, "7K3QF-M2XAD", "00000000-0000-0000-0000-000000000000", models.DeviceStatusPending).
+					Return(&models.Device{UID: "uid", TenantID: "00000000-0000-0000-0000-000000000000", Status: models.DeviceStatusPending}, nil).
+					Once()
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("00000000-0000-0000-0000-000000000000", errors.New("error", "", 0))},
+		},
+		{
+			description: "succeeds",
+			req:         &requests.DeviceClaim{TenantID: "00000000-0000-0000-0000-000000000000", Code: "7K3QF-M2XAD"},
+			requiredMocks: func() {
+				device := &models.Device{
+					UID:       "uid",
+					Name:      "name",
+					TenantID:  "00000000-0000-0000-0000-000000000000",
+					Status:    models.DeviceStatusPending,
+					Identity:  &models.DeviceIdentity{MAC: "mac"},
+					ClaimCode: "7K3QF-M2XAD",
+				}
+
+				storeMock.
+					On("DeviceGetByClaimCode", ctx, "7K3QF-M2XAD", "00000000-0000-0000-0000-000000000000", models.DeviceStatusPending).
+					Return(device, nil).
+					Once()
+				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
+					Return(&models.Namespace{TenantID: "00000000-0000-0000-0000-000000000000"}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{
+						UID:      "uid",
+						Name:     "name",
+						TenantID: "00000000-0000-0000-0000-000000000000",
+						Status:   models.DeviceStatusPending,
+						Identity: &models.DeviceIdentity{MAC: "mac"},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByMac", ctx, "mac", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "name", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_CLOUD").
+					Return("false").Once()
+				envMock.
+					On("Get", "SHELLHUB_ENTERPRISE").
+					Return("false").Once()
+				storeMock.
+					On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatusAccepted).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				&models.Device{
+					UID:       "uid",
+					Name:      "name",
+					TenantID:  "00000000-0000-0000-0000-000000000000",
+					Status:    models.DeviceStatusAccepted,
+					Identity:  &models.DeviceIdentity{MAC: "mac"},
+					ClaimCode: "7K3QF-M2XAD",
+				},
+				nil,
+			},
 		},
 	}
 
-	service := NewService(storeMock, privateKey, publicKey, storecache.NewNullCache(), clientMock)
+	service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
 
 	for _, tc := range cases {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			err := service.UpdateDeviceStatus(ctx, tc.tenant, tc.uid, tc.status)
+			device, err := service.ClaimDevice(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{device, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceLoginShell(t *testing.T) {
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceUpdateLoginShell
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceUpdateLoginShell{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-0000-0000-000000000000",
+				LoginShell:  "/usr/bin/htop",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds without changes when the login shell is the same",
+			req: &requests.DeviceUpdateLoginShell{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-0000-0000-000000000000",
+				LoginShell:  "/usr/bin/htop",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid", LoginShell: "/usr/bin/htop"}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the login shell cannot be set",
+			req: &requests.DeviceUpdateLoginShell{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-0000-0000-000000000000",
+				LoginShell:  "/usr/bin/htop",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetLoginShell", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid"), "/usr/bin/htop").
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceUpdateLoginShell{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-0000-0000-000000000000",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid", LoginShell: "/usr/bin/htop"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetLoginShell", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid"), "").
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := service.UpdateDeviceLoginShell(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceConnectionNote(t *testing.T) {
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceUpdateConnectionNote
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceUpdateConnectionNote{
+				DeviceParam:    requests.DeviceParam{UID: "uid"},
+				TenantID:       "00000000-0000-0000-0000-000000000000",
+				ConnectionNote: "Use the user **admin**",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds without changes when the sanitized note is the same",
+			req: &requests.DeviceUpdateConnectionNote{
+				DeviceParam:    requests.DeviceParam{UID: "uid"},
+				TenantID:       "00000000-0000-0000-0000-000000000000",
+				ConnectionNote: "Use the user **admin**<script></script>",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid", ConnectionNote: "Use the user **admin**"}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the note cannot be set",
+			req: &requests.DeviceUpdateConnectionNote{
+				DeviceParam:    requests.DeviceParam{UID: "uid"},
+				TenantID:       "00000000-0000-0000-0000-000000000000",
+				ConnectionNote: "Use the user **admin**",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetConnectionNote", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid"), "Use the user **admin**").
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "succeeds storing the sanitized note",
+			req: &requests.DeviceUpdateConnectionNote{
+				DeviceParam:    requests.DeviceParam{UID: "uid"},
+				TenantID:       "00000000-0000-0000-0000-000000000000",
+				ConnectionNote: "<b>sudo</b> requires a [ticket](javascript:alert(1))",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetConnectionNote", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid"), "sudo requires a [ticket](#)").
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := service.UpdateDeviceConnectionNote(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceRemoteAccess(t *testing.T) {
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceUpdateRemoteAccess
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceUpdateRemoteAccess{
+				DeviceParam:  requests.DeviceParam{UID: "uid"},
+				TenantID:     "00000000-0000-0000-0000-000000000000",
+				RemoteAccess: true,
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds without changes when the remote access is the same",
+			req: &requests.DeviceUpdateRemoteAccess{
+				DeviceParam:  requests.DeviceParam{UID: "uid"},
+				TenantID:     "00000000-0000-0000-0000-000000000000",
+				RemoteAccess: true,
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid", RemoteAccess: true}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the remote access cannot be set",
+			req: &requests.DeviceUpdateRemoteAccess{
+				DeviceParam:  requests.DeviceParam{UID: "uid"},
+				TenantID:     "00000000-0000-0000-0000-000000000000",
+				RemoteAccess: true,
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetRemoteAccess", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid"), true).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceUpdateRemoteAccess{
+				DeviceParam:  requests.DeviceParam{UID: "uid"},
+				TenantID:     "00000000-0000-0000-0000-000000000000",
+				RemoteAccess: true,
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetRemoteAccess", ctx, "00000000-0000-0000-0000-000000000000", models.UID("uid"), true).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := service.UpdateDeviceRemoteAccess(ctx, tc.req)
 			assert.Equal(t, tc.expected, err)
 		})
 	}
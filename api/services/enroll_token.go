@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+type EnrollTokenService interface {
+	// CreateEnrollToken creates a token that enrolls up to req.MaxUses devices on the namespace until req.TTL seconds
+	// from now. The token is only returned here, as only its digest is stored.
+	CreateEnrollToken(ctx context.Context, req *requests.CreateEnrollToken) (*responses.CreateEnrollToken, error)
+
+	// ListEnrollTokens retrieves the namespace's enroll tokens, most recent first, and their total count.
+	ListEnrollTokens(ctx context.Context, req *requests.ListEnrollTokens) ([]models.EnrollToken, int, error)
+
+	// DeleteEnrollToken revokes one of the namespace's enroll tokens. The token can no longer enroll devices, but the
+	// devices it has enrolled keep authenticating with it.
+	DeleteEnrollToken(ctx context.Context, req *requests.DeleteEnrollToken) error
+}
+
+func (s *service) CreateEnrollToken(ctx context.Context, req *requests.CreateEnrollToken) (*responses.CreateEnrollToken, error) {
+	if _, err := s.store.NamespaceGet(ctx, req.Tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(req.Tenant, err)
+	}
+
+	now := clock.Now()
+	value := uuid.Generate()
+
+	token := &models.EnrollToken{
+		ID:        uuid.Generate(),
+		TenantID:  req.Tenant,
+		Digest:    models.EnrollTokenDigest(value),
+		MaxUses:   req.MaxUses,
+		CreatedBy: req.UserID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Duration(req.TTL) * time.Second),
+	}
+
+	if err := s.store.EnrollTokenCreate(ctx, token); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, req.Tenant, models.AuditActionEnrollTokenCreate, models.AuditTarget{Type: models.AuditTargetEnrollToken, ID: token.ID}, map[string]string{
+		"max_uses":   strconv.Itoa(token.MaxUses),
+		"expires_at": token.ExpiresAt.UTC().Format(time.RFC3339),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &responses.CreateEnrollToken{EnrollToken: *token, Token: value}, nil
+}
+
+func (s *service) ListEnrollTokens(ctx context.Context, req *requests.ListEnrollTokens) ([]models.EnrollToken, int, error) {
+	return s.store.EnrollTokenList(ctx, req.Tenant, req.Paginator)
+}
+
+func (s *service) DeleteEnrollToken(ctx context.Context, req *requests.DeleteEnrollToken) error {
+	if err := s.store.EnrollTokenRevoke(ctx, req.Tenant, req.ID, clock.Now()); err != nil {
+		return NewErrEnrollTokenNotFound(req.ID, err)
+	}
+
+	return s.recordAudit(ctx, req.Tenant, models.AuditActionEnrollTokenDelete, models.AuditTarget{Type: models.AuditTargetEnrollToken, ID: req.ID}, nil)
+}
+
+// resolveEnrollToken returns the enroll token presented by a device instead of its namespace's tenant ID.
+//
+// A token that is revoked, expired or used up still resolves, as it identifies the namespace of the devices already
+// enrolled by it; the token is only used, on [service.useEnrollToken], when it enrolls a new device.
+func (s *service) resolveEnrollToken(ctx context.Context, value string) (*models.EnrollToken, error) {
+	token, err := s.store.EnrollTokenGetByDigest(ctx, models.EnrollTokenDigest(value))
+	if err != nil {
+		return nil, NewErrEnrollTokenInvalid(err)
+	}
+
+	return token, nil
+}
+
+// useEnrollToken uses the enroll token to enroll the device with the UID, when the device isn't on the token's
+// namespace yet.
+func (s *service) useEnrollToken(ctx context.Context, token *models.EnrollToken, uid string) error {
+	_, err := s.store.DeviceGetByUID(ctx, models.UID(uid), token.TenantID)
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, store.ErrNoDocuments):
+		return err
+	}
+
+	if err := s.store.EnrollTokenUse(ctx, token.ID, clock.Now()); err != nil {
+		return NewErrEnrollTokenInvalid(err)
+	}
+
+	return nil
+}
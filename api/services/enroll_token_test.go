@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	storemock "github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEnrollToken(t *testing.T) {
+	backend := uuid.DefaultBackend
+	uuidMock := &uuidmock.Uuid{}
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	type Expected struct {
+		res *responses.CreateEnrollToken
+		err error
+	}
+
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		req           *requests.CreateEnrollToken
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			req: &requests.CreateEnrollToken{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000000",
+				MaxUses:     10,
+				TTL:         3600,
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", errors.New("error"))},
+		},
+		{
+			description: "fails when the token cannot be created",
+			req: &requests.CreateEnrollToken{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000000",
+				MaxUses:     10,
+				TTL:         3600,
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.On("Generate").Return("a1b2c3d4-0000-4000-0000-000000000000").Once()
+				uuidMock.On("Generate").Return("6d1e2f3a-1d2c-4e8f-9a4b-000000000001").Once()
+				storeMock.
+					On("EnrollTokenCreate", ctx, mock.AnythingOfType("*models.EnrollToken")).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: Expected{nil, errors.New("error")},
+		},
+		{
+			description: "succeeds returning the token",
+			req: &requests.CreateEnrollToken{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000000",
+				MaxUses:     10,
+				TTL:         3600,
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.On("Generate").Return("a1b2c3d4-0000-4000-0000-000000000000").Once()
+				uuidMock.On("Generate").Return("6d1e2f3a-1d2c-4e8f-9a4b-000000000001").Once()
+				storeMock.
+					On("EnrollTokenCreate", ctx, &models.EnrollToken{
+						ID:        "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Digest:    models.EnrollTokenDigest("a1b2c3d4-0000-4000-0000-000000000000"),
+						MaxUses:   10,
+						CreatedBy: "000000000000000000000000",
+						CreatedAt: now,
+						ExpiresAt: now.Add(time.Hour),
+					}).
+					Return(nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				&responses.CreateEnrollToken{
+					EnrollToken: models.EnrollToken{
+						ID:        "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Digest:    models.EnrollTokenDigest("a1b2c3d4-0000-4000-0000-000000000000"),
+						MaxUses:   10,
+						CreatedBy: "000000000000000000000000",
+						CreatedAt: now,
+						ExpiresAt: now.Add(time.Hour),
+					},
+					Token: "a1b2c3d4-0000-4000-0000-000000000000",
+				},
+				nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			res, err := s.CreateEnrollToken(ctx, tc.req)
+			require.Equal(t, tc.expected, Expected{res, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestListEnrollTokens(t *testing.T) {
+	type Expected struct {
+		tokens []models.EnrollToken
+		count  int
+		err    error
+	}
+
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	tokens := []models.EnrollToken{{ID: "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", TenantID: "00000000-0000-4000-0000-000000000000"}}
+
+	storeMock.
+		On("EnrollTokenList", ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: 1, PerPage: 10}).
+		Return(tokens, 1, nil).
+		Once()
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	list, count, err := s.ListEnrollTokens(ctx, &requests.ListEnrollTokens{
+		TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+		Paginator:   query.Paginator{Page: 1, PerPage: 10},
+	})
+	require.Equal(t, Expected{tokens, 1, nil}, Expected{list, count, err})
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteEnrollToken(t *testing.T) {
+	backend := uuid.DefaultBackend
+	uuidMock := &uuidmock.Uuid{}
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		req           *requests.DeleteEnrollToken
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the token is not found",
+			req: &requests.DeleteEnrollToken{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				ID:          "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("EnrollTokenRevoke", ctx, "00000000-0000-4000-0000-000000000000", "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", now).
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrEnrollTokenNotFound("6d1e2f3a-1d2c-4e8f-9a4b-000000000001", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeleteEnrollToken{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				ID:          "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("EnrollTokenRevoke", ctx, "00000000-0000-4000-0000-000000000000", "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", now).
+					Return(nil).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			require.Equal(t, tc.expected, s.DeleteEnrollToken(ctx, tc.req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUseEnrollToken(t *testing.T) {
+	storeMock := new(storemock.Store)
+
+	ctx := context.TODO()
+
+	token := &models.EnrollToken{ID: "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", TenantID: "00000000-0000-4000-0000-000000000000"}
+	revoked := &models.EnrollToken{ID: "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", TenantID: "00000000-0000-4000-0000-000000000000", RevokedAt: &now}
+
+	cases := []struct {
+		description   string
+		token         *models.EnrollToken
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "succeeds without using the token when the device is already enrolled",
+			token:       token,
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the token is revoked, expired or used up",
+			token:       token,
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("EnrollTokenUse", ctx, "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", now).
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrEnrollTokenInvalid(store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds using the token when the device is new",
+			token:       token,
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.
+					On("EnrollTokenUse", ctx, "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", now).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds without using the token when it is revoked and the device is already enrolled",
+			token:       revoked,
+			requiredMocks: func() {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			require.Equal(t, tc.expected, s.useEnrollToken(ctx, tc.token, "uid"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
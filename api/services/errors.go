@@ -37,6 +37,8 @@ const (
 	ErrCodeCreated
 	// ErrCodeNotImplemented is the error code to be used when the resource is not yet implemented.
 	ErrCodeNotImplemented
+	// ErrCodePreconditionFailed is the error code for when a condition assumed by the request doesn't hold.
+	ErrCodePreconditionFailed
 )
 
 // ErrDataNotFound structure should be used to add errors.Data to an error when the resource is not found.
@@ -83,12 +85,15 @@ var (
 	ErrUserUpdate                   = errors.New("user update", ErrLayer, ErrCodeStore)
 	ErrNamespaceNotFound            = errors.New("namespace not found", ErrLayer, ErrCodeNotFound)
 	ErrNamespaceInvalid             = errors.New("namespace invalid", ErrLayer, ErrCodeInvalid)
+	ErrNamespaceDeviceNameTemplate  = errors.New("namespace device name template invalid", ErrLayer, ErrCodeInvalid)
 	ErrNamespaceList                = errors.New("namespace member list", ErrLayer, ErrCodeNotFound)
 	ErrNamespaceDuplicated          = errors.New("namespace duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrNamespaceMemberNotFound      = errors.New("member not found", ErrLayer, ErrCodeNotFound)
 	ErrNamespaceMemberInvalid       = errors.New("member invalid", ErrLayer, ErrCodeInvalid)
 	ErrNamespaceMemberFillData      = errors.New("member fill data", ErrLayer, ErrCodeInvalid)
 	ErrNamespaceMemberDuplicated    = errors.New("member duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrNamespaceMembersLimit        = errors.New("namespace member limit reached", ErrLayer, ErrCodeLimit)
+	ErrNamespaceInvitationsLimit    = errors.New("namespace pending invitation limit reached", ErrLayer, ErrCodeLimit)
 	ErrNamespaceCreateStore         = errors.New("namespace create store", ErrLayer, ErrCodeStore)
 	ErrMaxTagReached                = errors.New("tag limit reached", ErrLayer, ErrCodeLimit)
 	ErrDuplicateTagName             = errors.New("tag duplicated", ErrLayer, ErrCodeDuplicated)
@@ -107,6 +112,7 @@ var (
 	ErrDeviceCreate                 = errors.New("device create", ErrLayer, ErrCodeStore)
 	ErrDeviceSetOnline              = errors.New("device set online", ErrLayer, ErrCodeStore)
 	ErrMaxDeviceCountReached        = errors.New("maximum number of accepted devices reached", ErrLayer, ErrCodeLimit)
+	ErrMaxPendingDeviceCountReached = errors.New("maximum number of pending devices reached", ErrLayer, ErrCodeLimit)
 	ErrDuplicatedDeviceName         = errors.New("device name duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrPublicKeyDuplicated          = errors.New("public key duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrPublicKeyNotFound            = errors.New("public key not found", ErrLayer, ErrCodeNotFound)
@@ -126,17 +132,77 @@ var (
 	ErrDeviceRemovedFull            = errors.New("device removed full", ErrLayer, ErrCodePayment)
 	ErrDeviceRemovedDelete          = errors.New("device removed delete", ErrLayer, ErrCodeStore)
 	ErrDeviceRemovedGet             = errors.New("device removed get", ErrLayer, ErrCodeNotFound)
+	ErrDeviceKeyMismatch            = errors.New("device public key does not match the pinned one", ErrLayer, ErrCodeForbidden)
+	ErrDeviceKeyIncidentNotFound    = errors.New("device key incident not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceKeyIncidentReviewed    = errors.New("device key incident already reviewed", ErrLayer, ErrCodeInvalid)
+	ErrDeviceClaimCodeInvalid       = errors.New("device claim code is invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceClaimCodeNotFound      = errors.New("device claim code not found", ErrLayer, ErrCodeNotFound)
 	ErrBillingReportNamespaceDelete = errors.New("billing report namespace delete", ErrLayer, ErrCodePayment)
 	ErrBillingReportDevice          = errors.New("billing report device", ErrLayer, ErrCodePayment)
 	ErrBillingEvaluate              = errors.New("billing evaluate", ErrLayer, ErrCodePayment)
 	ErrSameTags                     = errors.New("trying to update tags with the same content", ErrLayer, ErrCodeNoContentChange)
 	ErrAPIKeyNotFound               = errors.New("APIKey not found", ErrLayer, ErrCodeNotFound)
 	ErrAPIKeyDuplicated             = errors.New("APIKey duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrEnrollTokenNotFound          = errors.New("enroll token not found", ErrLayer, ErrCodeNotFound)
+	ErrEnrollTokenInvalid           = errors.New("enroll token is invalid, expired or used up", ErrLayer, ErrCodeUnauthorized)
 	ErrAuthForbidden                = errors.New("user is authenticated but cannot access this resource", ErrLayer, ErrCodeForbidden)
 	ErrRoleInvalid                  = errors.New("role is invalid", ErrLayer, ErrCodeForbidden)
 	ErrUserDelete                   = errors.New("user couldn't be deleted", ErrLayer, ErrCodeInvalid)
 	ErrSetupForbidden               = errors.New("setup isn't allowed anymore", ErrLayer, ErrCodeForbidden)
 	ErrAuthMethodNotAllowed         = errors.New("auth method not allowed", ErrLayer, ErrCodeNotImplemented)
+	ErrUserConfirmed                = errors.New("user already confirmed", ErrLayer, ErrCodeInvalid)
+	ErrUserVerificationDisabled     = errors.New("email verification is disabled", ErrLayer, ErrCodeForbidden)
+	ErrUserVerificationNotFound     = errors.New("email verification token not found", ErrLayer, ErrCodeNotFound)
+	ErrUserVerificationInvalid      = errors.New("email verification token invalid", ErrLayer, ErrCodeInvalid)
+	ErrUserVerificationLimit        = errors.New("email verification requests limit reached", ErrLayer, ErrCodeLimit)
+	ErrUserAliasNotFound            = errors.New("user alias not found", ErrLayer, ErrCodeNotFound)
+	ErrUserAliasLimit               = errors.New("user alias limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceAgentLogsLimit         = errors.New("device agent logs rate limit reached", ErrLayer, ErrCodeLimit)
+	ErrBannedAddressInvalid         = errors.New("banned address invalid", ErrLayer, ErrCodeInvalid)
+	ErrBannedAddressNotFound        = errors.New("banned address not found", ErrLayer, ErrCodeNotFound)
+	ErrTagRuleNotFound              = errors.New("tag rule not found", ErrLayer, ErrCodeNotFound)
+	ErrTagRuleInvalid               = errors.New("tag rule invalid", ErrLayer, ErrCodeInvalid)
+	ErrTagRuleLimit                 = errors.New("tag rule limit reached", ErrLayer, ErrCodeLimit)
+	ErrCommandPolicyNotFound        = errors.New("command policy not found", ErrLayer, ErrCodeNotFound)
+	ErrCommandPolicyInvalid         = errors.New("command policy invalid", ErrLayer, ErrCodeInvalid)
+	ErrCommandPolicyLimit           = errors.New("command policy limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceShareNotFound          = errors.New("device share not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceShareInvalid           = errors.New("device share invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceShareDuplicated        = errors.New("device share duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrDeviceQueueStatus            = errors.New("only pending devices can be queued for acceptance", ErrLayer, ErrCodeInvalid)
+	ErrDeviceNotQueued              = errors.New("device isn't queued for acceptance", ErrLayer, ErrCodeNotFound)
+	ErrDeviceInfoHash               = errors.New("device's information hash is unknown", ErrLayer, ErrCodePreconditionFailed)
+	ErrNamespaceSessionSchedule     = errors.New("namespace session schedule invalid", ErrLayer, ErrCodeInvalid)
+	ErrSessionScheduleBlock         = errors.New("namespace session schedules don't allow connections to the device now", ErrLayer, ErrCodeForbidden)
+	ErrSessionScheduleUnrestricted  = errors.New("device isn't restricted by any session schedule", ErrLayer, ErrCodeInvalid)
+	ErrSessionScheduleOverrideRole  = errors.New("role cannot override the device's session schedules", ErrLayer, ErrCodeForbidden)
+	ErrSessionPolicyBlock           = errors.New("organization's policies don't allow the session to the device", ErrLayer, ErrCodeForbidden)
+	ErrNamespaceConnectionWebhook   = errors.New("namespace connection webhook invalid", ErrLayer, ErrCodeInvalid)
+	ErrSessionWebhookBlock          = errors.New("namespace connection webhook doesn't allow the session to the device", ErrLayer, ErrCodeForbidden)
+	ErrNamespaceUserProvisioning    = errors.New("namespace user provisioning invalid", ErrLayer, ErrCodeInvalid)
+	ErrNamespaceAnnouncement        = errors.New("namespace connection announcement invalid", ErrLayer, ErrCodeInvalid)
+	ErrJobNotFound                  = errors.New("job not found", ErrLayer, ErrCodeNotFound)
+	ErrJobIdempotencyKey            = errors.New("idempotency key already used by another job", ErrLayer, ErrCodeDuplicated)
+	ErrDeviceQuarantined            = errors.New("device is rejected and quarantined by the namespace", ErrLayer, ErrCodeForbidden)
+	ErrDeviceConfigInvalid          = errors.New("invalid value for the device's config key", ErrLayer, ErrCodeInvalid)
+	ErrSessionRecordingNotFound     = errors.New("session recording not found", ErrLayer, ErrCodeNotFound)
+	ErrSessionRecordingStorage      = errors.New("session recordings storage isn't configured", ErrLayer, ErrCodeNotFound)
+	ErrWebSessionDeviceStatus       = errors.New("only accepted devices can open web sessions", ErrLayer, ErrCodeInvalid)
+	ErrWebSessionInvalid            = errors.New("web session token is invalid, expired or already used", ErrLayer, ErrCodeUnauthorized)
+	ErrGroupNotFound                = errors.New("group not found", ErrLayer, ErrCodeNotFound)
+	ErrGroupDuplicated              = errors.New("group already exists", ErrLayer, ErrCodeDuplicated)
+	ErrGroupParentInvalid           = errors.New("group cannot be nested into itself or into one of its subgroups", ErrLayer, ErrCodeInvalid)
+	ErrGroupHasSubgroups            = errors.New("group has subgroups", ErrLayer, ErrCodeInvalid)
+	ErrGroupLimit                   = errors.New("group limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceCommandNotFound        = errors.New("device command not found", ErrLayer, ErrCodeNotFound)
+	ErrSSHCAInvalid                 = errors.New("SSH certificate authority's public key invalid", ErrLayer, ErrCodeInvalid)
+	ErrSSHCANotFound                = errors.New("namespace has no SSH certificate authority", ErrLayer, ErrCodeNotFound)
+	ErrSSHCADuplicated              = errors.New("SSH certificate authority is already the current one", ErrLayer, ErrCodeDuplicated)
+)
+
+var (
+	ErrUserSessionNotFound = errors.New("user session not found", ErrLayer, ErrCodeNotFound)
+	ErrUserSessionRevoked  = errors.New("user session revoked", ErrLayer, ErrCodeUnauthorized)
 )
 
 func NewErrRoleInvalid() error {
@@ -209,6 +275,16 @@ func NewErrAPIKeyInvalid(name string) error {
 	return NewErrAuthInvalid(map[string]interface{}{"api-key": name}, nil)
 }
 
+// NewErrEnrollTokenNotFound returns an error when the enroll token is not found.
+func NewErrEnrollTokenNotFound(id string, next error) error {
+	return NewErrNotFound(ErrEnrollTokenNotFound, id, next)
+}
+
+// NewErrEnrollTokenInvalid returns an error when a device cannot be enrolled with the enroll token.
+func NewErrEnrollTokenInvalid(next error) error {
+	return NewErrUnathorized(ErrEnrollTokenInvalid, next)
+}
+
 // NewErrAPIKeyDuplicated returns an error when the APIKey name is duplicated.
 func NewErrAPIKeyDuplicated(conflicts []string) error {
 	return NewErrDuplicated(ErrAPIKeyDuplicated, conflicts, nil)
@@ -335,6 +411,12 @@ func NewErrNamespaceInvalid(next error) error {
 	return NewErrInvalid(ErrNamespaceInvalid, nil, next)
 }
 
+// NewErrNamespaceDeviceNameTemplateInvalid returns an error to be used when the namespace's device name template
+// cannot be parsed or rendered.
+func NewErrNamespaceDeviceNameTemplateInvalid(next error) error {
+	return NewErrInvalid(ErrNamespaceDeviceNameTemplate, nil, next)
+}
+
 // NewErrNamespaceDuplicated returns an error to be used when the namespace is duplicated.
 func NewErrNamespaceDuplicated(next error) error {
 	return NewErrDuplicated(ErrNamespaceDuplicated, nil, next)
@@ -361,6 +443,17 @@ func NewErrNamespaceMemberFillData(next error) error {
 	return NewErrInvalid(ErrNamespaceMemberFillData, nil, next)
 }
 
+// NewErrNamespaceMembersLimit returns an error to be used when the namespace has reached its maximum number of members.
+func NewErrNamespaceMembersLimit(limit int, next error) error {
+	return NewErrLimit(ErrNamespaceMembersLimit, limit, next)
+}
+
+// NewErrNamespaceInvitationsLimit returns an error to be used when the namespace has reached its maximum number of
+// pending invitations.
+func NewErrNamespaceInvitationsLimit(limit int, next error) error {
+	return NewErrLimit(ErrNamespaceInvitationsLimit, limit, next)
+}
+
 // NewErrNamespaceMemberDuplicated returns an error to be used when the namespace member already exist in the namespace.
 func NewErrNamespaceMemberDuplicated(id string, next error) error {
 	return NewErrDuplicated(ErrNamespaceMemberDuplicated, []string{id}, next)
@@ -376,6 +469,32 @@ func NewErrDeviceDuplicated(name string, next error) error {
 	return NewErrDuplicated(ErrDeviceDuplicated, []string{name}, next)
 }
 
+// NewErrDeviceKeyMismatch returns an error to be used when a device presents a public key different from the one
+// pinned by the accepted device.
+func NewErrDeviceKeyMismatch() error {
+	return NewErrForbidden(ErrDeviceKeyMismatch, nil)
+}
+
+// NewErrDeviceKeyIncidentNotFound returns an error to be used when the device key incident is not found.
+func NewErrDeviceKeyIncidentNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceKeyIncidentNotFound, id, next)
+}
+
+// NewErrDeviceClaimCodeInvalid returns an error to be used when the claim code isn't well formed.
+func NewErrDeviceClaimCodeInvalid(code string) error {
+	return NewErrInvalid(ErrDeviceClaimCodeInvalid, map[string]interface{}{"code": code}, nil)
+}
+
+// NewErrDeviceClaimCodeNotFound returns an error to be used when no pending device has the claim code.
+func NewErrDeviceClaimCodeNotFound(code string, next error) error {
+	return NewErrNotFound(ErrDeviceClaimCodeNotFound, code, next)
+}
+
+// NewErrDeviceKeyIncidentReviewed returns an error to be used when the device key incident isn't open anymore.
+func NewErrDeviceKeyIncidentReviewed(id string) error {
+	return NewErrInvalid(ErrDeviceKeyIncidentReviewed, map[string]interface{}{"id": id}, nil)
+}
+
 // NewErrDeviceLookupNotFound returns an error to be used when the device lookup is not found.
 func NewErrDeviceLookupNotFound(namespace, name string, next error) error {
 	return NewErrNotFound(ErrDeviceLookupNotFound, fmt.Sprintf("device %s on namespace %s", name, namespace), next)
@@ -408,6 +527,56 @@ func NewErrUserNotConfirmed(err error) error {
 	return NewErrForbidden(ErrUserNotConfirmed, err)
 }
 
+// NewErrUserConfirmed returns an error to be used when the user's email is already confirmed.
+func NewErrUserConfirmed(id string) error {
+	return NewErrInvalid(ErrUserConfirmed, map[string]interface{}{"id": id}, nil)
+}
+
+// NewErrUserVerificationDisabled returns an error to be used when the email verification is disabled on the instance.
+func NewErrUserVerificationDisabled() error {
+	return NewErrForbidden(ErrUserVerificationDisabled, nil)
+}
+
+// NewErrUserVerificationNotFound returns an error to be used when the verification token doesn't exist or has expired.
+func NewErrUserVerificationNotFound(token string, next error) error {
+	return NewErrNotFound(ErrUserVerificationNotFound, token, next)
+}
+
+// NewErrUserVerificationInvalid returns an error to be used when the verification token doesn't belong to the email.
+func NewErrUserVerificationInvalid(email string) error {
+	return NewErrInvalid(ErrUserVerificationInvalid, map[string]interface{}{"email": email}, nil)
+}
+
+// NewErrUserVerificationLimit returns an error to be used when too many verification emails were requested.
+func NewErrUserVerificationLimit(limit int) error {
+	return NewErrLimit(ErrUserVerificationLimit, limit, nil)
+}
+
+// NewErrUserAliasNotFound returns an error to be used when the user doesn't have an alias with the name.
+func NewErrUserAliasNotFound(name string, next error) error {
+	return NewErrNotFound(ErrUserAliasNotFound, name, next)
+}
+
+// NewErrUserAliasLimit returns an error to be used when the user has already reached the maximum number of aliases.
+func NewErrUserAliasLimit(limit int, next error) error {
+	return NewErrLimit(ErrUserAliasLimit, limit, next)
+}
+
+// NewErrUserSessionNotFound returns an error to be used when the user doesn't have a session with the ID.
+func NewErrUserSessionNotFound(id string, next error) error {
+	return NewErrNotFound(ErrUserSessionNotFound, id, next)
+}
+
+// NewErrUserSessionRevoked returns an error to be used when a token of a revoked session is used.
+func NewErrUserSessionRevoked(next error) error {
+	return NewErrUnathorized(ErrUserSessionRevoked, next)
+}
+
+// NewErrDeviceAgentLogsLimit returns an error when the device's agent has reported more logs than allowed per minute.
+func NewErrDeviceAgentLogsLimit(limit int) error {
+	return NewErrLimit(ErrDeviceAgentLogsLimit, limit, nil)
+}
+
 // NewErrAuthInvalid returns a error to be used when the auth data is invalid.
 func NewErrAuthInvalid(data map[string]interface{}, err error) error {
 	return NewErrInvalid(ErrAuthInvalid, data, err)
@@ -484,6 +653,12 @@ func NewErrDeviceMaxDevicesReached(count int) error {
 	return NewErrLimit(ErrMaxDeviceCountReached, count, nil)
 }
 
+// NewErrDeviceMaxPendingDevicesReached returns an error to be used when the namespace has reached its maximum number
+// of devices waiting for acceptance.
+func NewErrDeviceMaxPendingDevicesReached(count int) error {
+	return NewErrLimit(ErrMaxPendingDeviceCountReached, count, nil)
+}
+
 func NewErrAuthForbidden() error {
 	return NewErrForbidden(ErrAuthForbidden, nil)
 }
@@ -495,3 +670,222 @@ func NewErrUserDelete(err error) error {
 func NewErrSetupForbidden(err error) error {
 	return NewErrForbidden(ErrSetupForbidden, err)
 }
+
+// NewErrBannedAddressInvalid returns an error to be used when the address to ban is neither an IP address nor a range
+// in CIDR notation.
+func NewErrBannedAddressInvalid(address string, next error) error {
+	return NewErrInvalid(ErrBannedAddressInvalid, map[string]interface{}{"address": address}, next)
+}
+
+// NewErrBannedAddressNotFound returns an error to be used when the address isn't banned.
+func NewErrBannedAddressNotFound(address string, next error) error {
+	return NewErrNotFound(ErrBannedAddressNotFound, address, next)
+}
+
+// NewErrTagRuleNotFound returns an error to be used when the tag rule isn't found on the namespace.
+func NewErrTagRuleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrTagRuleNotFound, id, next)
+}
+
+// NewErrTagRuleInvalid returns an error to be used when the tag rule's conditions can't be evaluated.
+func NewErrTagRuleInvalid(next error) error {
+	return NewErrInvalid(ErrTagRuleInvalid, map[string]interface{}{"reason": next.Error()}, next)
+}
+
+// NewErrTagRuleLimit returns an error to be used when the namespace already has the maximum number of tag rules.
+func NewErrTagRuleLimit(limit int, next error) error {
+	return NewErrLimit(ErrTagRuleLimit, limit, next)
+}
+
+// NewErrCommandPolicyNotFound returns an error to be used when the command policy isn't found on the namespace.
+func NewErrCommandPolicyNotFound(id string, next error) error {
+	return NewErrNotFound(ErrCommandPolicyNotFound, id, next)
+}
+
+// NewErrCommandPolicyInvalid returns an error to be used when the command policy can't be evaluated.
+func NewErrCommandPolicyInvalid(next error) error {
+	return NewErrInvalid(ErrCommandPolicyInvalid, map[string]interface{}{"reason": next.Error()}, next)
+}
+
+// NewErrCommandPolicyLimit returns an error to be used when the namespace already has the maximum number of command
+// policies.
+func NewErrCommandPolicyLimit(limit int, next error) error {
+	return NewErrLimit(ErrCommandPolicyLimit, limit, next)
+}
+
+// NewErrDeviceShareNotFound returns an error to be used when the share isn't found on the namespace's device.
+func NewErrDeviceShareNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceShareNotFound, id, next)
+}
+
+// NewErrDeviceShareInvalid returns an error to be used when the device can't be shared as requested.
+func NewErrDeviceShareInvalid(reason string) error {
+	return NewErrInvalid(ErrDeviceShareInvalid, map[string]interface{}{"reason": reason}, nil)
+}
+
+// NewErrDeviceShareDuplicated returns an error to be used when the device is already shared with the grantee
+// namespace, or the grantee namespace has another device with the same name.
+func NewErrDeviceShareDuplicated(values []string, next error) error {
+	return NewErrDuplicated(ErrDeviceShareDuplicated, values, next)
+}
+
+// NewErrDeviceQueueStatus returns an error to be used when a device that isn't pending is queued for acceptance.
+func NewErrDeviceQueueStatus(status models.DeviceStatus) error {
+	return NewErrInvalid(ErrDeviceQueueStatus, map[string]interface{}{"status": status}, nil)
+}
+
+// NewErrDeviceNotQueued returns an error to be used when a device that isn't queued is removed from the queue.
+func NewErrDeviceNotQueued(uid models.UID) error {
+	return NewErrNotFound(ErrDeviceNotQueued, string(uid), nil)
+}
+
+// NewErrDeviceInfoHash returns an error to be used when a device reports its information by a hash that doesn't match
+// the stored one, so the device must report the whole information.
+func NewErrDeviceInfoHash(hash string, next error) error {
+	return errors.Wrap(errors.WithData(ErrDeviceInfoHash, ErrDataInvalid{Data: map[string]interface{}{"info_hash": hash}}), next)
+}
+
+// NewErrNamespaceSessionScheduleInvalid returns an error to be used when a namespace's session schedule is invalid.
+func NewErrNamespaceSessionScheduleInvalid(next error) error {
+	return NewErrInvalid(ErrNamespaceSessionSchedule, map[string]interface{}{"reason": next.Error()}, next)
+}
+
+// NewErrSessionScheduleBlock returns an error to be used when the namespace's session schedules don't allow
+// connections to the device.
+func NewErrSessionScheduleBlock() error {
+	return NewErrForbidden(ErrSessionScheduleBlock, nil)
+}
+
+// NewErrSessionScheduleUnrestricted returns an error to be used when a device not restricted by any of the namespace's
+// session schedules is overridden.
+func NewErrSessionScheduleUnrestricted(uid models.UID) error {
+	return NewErrInvalid(ErrSessionScheduleUnrestricted, map[string]interface{}{"uid": uid}, nil)
+}
+
+// NewErrSessionScheduleOverrideRole returns an error to be used when a member's role isn't allowed to override one of
+// the session schedules restricting the device.
+func NewErrSessionScheduleOverrideRole() error {
+	return NewErrForbidden(ErrSessionScheduleOverrideRole, nil)
+}
+
+// NewErrSessionPolicyBlock returns an error to be used when the organization's policies, on the policy engine, don't
+// allow the session to the device.
+func NewErrSessionPolicyBlock() error {
+	return NewErrForbidden(ErrSessionPolicyBlock, nil)
+}
+
+// NewErrNamespaceConnectionWebhookInvalid returns an error to be used when a namespace's connection webhook is
+// invalid.
+func NewErrNamespaceConnectionWebhookInvalid(next error) error {
+	return NewErrInvalid(ErrNamespaceConnectionWebhook, map[string]interface{}{"reason": next.Error()}, next)
+}
+
+// NewErrSessionWebhookBlock returns an error to be used when the namespace's connection webhook doesn't allow the
+// session to the device.
+func NewErrSessionWebhookBlock() error {
+	return NewErrForbidden(ErrSessionWebhookBlock, nil)
+}
+
+// NewErrNamespaceUserProvisioningInvalid returns an error to be used when a namespace's user provisioning is invalid.
+func NewErrNamespaceUserProvisioningInvalid(next error) error {
+	return NewErrInvalid(ErrNamespaceUserProvisioning, map[string]interface{}{"reason": next.Error()}, next)
+}
+
+// NewErrNamespaceConnectionAnnouncementInvalid returns an error to be used when a namespace's connection announcement
+// isn't a valid template.
+func NewErrNamespaceConnectionAnnouncementInvalid(next error) error {
+	return NewErrInvalid(ErrNamespaceAnnouncement, map[string]interface{}{"reason": next.Error()}, next)
+}
+
+// NewErrJobNotFound returns an error to be used when the job isn't found on the namespace.
+func NewErrJobNotFound(id string, next error) error {
+	return NewErrNotFound(ErrJobNotFound, id, next)
+}
+
+// NewErrJobIdempotencyKey returns an error to be used when a job is created with the idempotency key of another job
+// of the namespace, with a different operation or items.
+func NewErrJobIdempotencyKey(key string) error {
+	return NewErrDuplicated(ErrJobIdempotencyKey, []string{key}, nil)
+}
+
+// NewErrDeviceQuarantined returns an error to be used when a rejected device tries to authenticate in a namespace
+// that quarantines its rejected devices.
+func NewErrDeviceQuarantined() error {
+	return NewErrForbidden(ErrDeviceQuarantined, nil)
+}
+
+// NewErrDeviceConfigInvalid returns an error to be used when a value of the device's config isn't accepted by its key.
+func NewErrDeviceConfigInvalid(key, value string) error {
+	return NewErrInvalid(ErrDeviceConfigInvalid, map[string]interface{}{"key": key, "value": value}, nil)
+}
+
+// NewErrSessionRecordingNotFound returns an error to be used when the session's recording isn't kept on the object
+// storage.
+func NewErrSessionRecordingNotFound(uid models.UID, next error) error {
+	return NewErrNotFound(ErrSessionRecordingNotFound, string(uid), next)
+}
+
+// NewErrSessionRecordingStorage returns an error to be used when the recordings are retrieved without an object
+// storage configured.
+func NewErrSessionRecordingStorage() error {
+	return ErrSessionRecordingStorage
+}
+
+// NewErrWebSessionDeviceStatus returns an error to be used when a web session is created for a device that isn't
+// accepted.
+func NewErrWebSessionDeviceStatus(status models.DeviceStatus) error {
+	return NewErrInvalid(ErrWebSessionDeviceStatus, map[string]interface{}{"status": status}, nil)
+}
+
+// NewErrWebSessionInvalid returns an error to be used when a web session's token can't be redeemed.
+func NewErrWebSessionInvalid(next error) error {
+	return NewErrUnathorized(ErrWebSessionInvalid, next)
+}
+
+// NewErrGroupNotFound returns an error to be used when the group isn't found on the namespace.
+func NewErrGroupNotFound(id string, next error) error {
+	return NewErrNotFound(ErrGroupNotFound, id, next)
+}
+
+// NewErrGroupDuplicated returns an error to be used when the group's parent already has a group with the same name.
+func NewErrGroupDuplicated(name string, next error) error {
+	return NewErrDuplicated(ErrGroupDuplicated, []string{name}, next)
+}
+
+// NewErrGroupParentInvalid returns an error to be used when a group would be nested into itself or into one of its
+// subgroups.
+func NewErrGroupParentInvalid(parentID string) error {
+	return NewErrInvalid(ErrGroupParentInvalid, map[string]interface{}{"parent_id": parentID}, nil)
+}
+
+// NewErrGroupHasSubgroups returns an error to be used when a group with subgroups is deleted.
+func NewErrGroupHasSubgroups(id string) error {
+	return NewErrInvalid(ErrGroupHasSubgroups, map[string]interface{}{"id": id}, nil)
+}
+
+// NewErrGroupLimit returns an error to be used when the namespace already has the maximum number of groups.
+func NewErrGroupLimit(limit int, next error) error {
+	return NewErrLimit(ErrGroupLimit, limit, next)
+}
+
+// NewErrDeviceCommandNotFound returns an error to be used when the command isn't queued to the device or its result
+// was already reported.
+func NewErrDeviceCommandNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceCommandNotFound, id, next)
+}
+
+// NewErrSSHCAInvalid returns an error to be used when the SSH certificate authority's public key can't be parsed or is
+// a certificate itself.
+func NewErrSSHCAInvalid(next error) error {
+	return NewErrInvalid(ErrSSHCAInvalid, nil, next)
+}
+
+// NewErrSSHCANotFound returns an error to be used when the namespace has no SSH certificate authority to rotate.
+func NewErrSSHCANotFound(tenant string, next error) error {
+	return NewErrNotFound(ErrSSHCANotFound, tenant, next)
+}
+
+// NewErrSSHCADuplicated returns an error to be used when the SSH certificate authority is rotated to the current one.
+func NewErrSSHCADuplicated(fingerprint string) error {
+	return NewErrDuplicated(ErrSSHCADuplicated, []string{fingerprint}, nil)
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// GroupMaxGroups is the maximum number of groups a namespace can have.
+const GroupMaxGroups = 100
+
+type GroupService interface {
+	// ListGroups lists the namespace's groups, sorted by name.
+	ListGroups(ctx context.Context, req *requests.GroupList) ([]models.Group, error)
+
+	// CreateGroup creates a group, up to [GroupMaxGroups] per namespace, nested into its parent group when it has one.
+	CreateGroup(ctx context.Context, req *requests.GroupCreate) (*models.Group, error)
+
+	// UpdateGroup replaces the parent, the name and the description of a group. A group cannot be nested into itself
+	// or into one of its subgroups.
+	UpdateGroup(ctx context.Context, req *requests.GroupUpdate) (*models.Group, error)
+
+	// DeleteGroup deletes a group without subgroups, removing it from its devices.
+	DeleteGroup(ctx context.Context, req *requests.GroupDelete) error
+
+	// AddGroupDevice adds a device to a group. A device may belong to many groups.
+	AddGroupDevice(ctx context.Context, req *requests.GroupDevice) error
+
+	// RemoveGroupDevice removes a device from a group.
+	RemoveGroupDevice(ctx context.Context, req *requests.GroupDevice) error
+}
+
+func (s *service) ListGroups(ctx context.Context, req *requests.GroupList) ([]models.Group, error) {
+	return s.store.GroupList(ctx, req.TenantID)
+}
+
+func (s *service) CreateGroup(ctx context.Context, req *requests.GroupCreate) (*models.Group, error) {
+	groups, err := s.store.GroupList(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) >= GroupMaxGroups {
+		return nil, NewErrGroupLimit(GroupMaxGroups, nil)
+	}
+
+	if err := s.checkGroupParent(ctx, req.TenantID, req.ParentID); err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	group := &models.Group{
+		ID:          uuid.Generate(),
+		TenantID:    req.TenantID,
+		ParentID:    req.ParentID,
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.store.GroupCreate(ctx, group); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			return nil, NewErrGroupDuplicated(req.Name, err)
+		}
+
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionGroupCreate, models.AuditTarget{Type: models.AuditTargetGroup, ID: group.ID}, map[string]string{"name": group.Name}); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *service) UpdateGroup(ctx context.Context, req *requests.GroupUpdate) (*models.Group, error) {
+	group, err := s.store.GroupGet(ctx, req.TenantID, req.ID)
+	if err != nil {
+		return nil, NewErrGroupNotFound(req.ID, err)
+	}
+
+	if req.ParentID != "" && req.ParentID != group.ParentID {
+		groups, err := s.store.GroupList(ctx, req.TenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range groupSubtree(groups, group.ID) {
+			if id == req.ParentID {
+				return nil, NewErrGroupParentInvalid(req.ParentID)
+			}
+		}
+
+		if err := s.checkGroupParent(ctx, req.TenantID, req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	group.ParentID = req.ParentID
+	group.Name = req.Name
+	group.Description = req.Description
+	group.UpdatedAt = clock.Now()
+
+	if err := s.store.GroupUpdate(ctx, group); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNoDocuments):
+			return nil, NewErrGroupNotFound(req.ID, err)
+		case errors.Is(err, store.ErrDuplicate):
+			return nil, NewErrGroupDuplicated(req.Name, err)
+		default:
+			return nil, err
+		}
+	}
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionGroupUpdate, models.AuditTarget{Type: models.AuditTargetGroup, ID: group.ID}, map[string]string{"name": group.Name}); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *service) DeleteGroup(ctx context.Context, req *requests.GroupDelete) error {
+	groups, err := s.store.GroupList(ctx, req.TenantID)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		if group.ParentID == req.ID {
+			return NewErrGroupHasSubgroups(req.ID)
+		}
+	}
+
+	if err := s.store.GroupDelete(ctx, req.TenantID, req.ID); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrGroupNotFound(req.ID, err)
+		}
+
+		return err
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionGroupDelete, models.AuditTarget{Type: models.AuditTargetGroup, ID: req.ID}, nil)
+}
+
+func (s *service) AddGroupDevice(ctx context.Context, req *requests.GroupDevice) error {
+	if _, err := s.store.GroupGet(ctx, req.TenantID, req.ID); err != nil {
+		return NewErrGroupNotFound(req.ID, err)
+	}
+
+	if err := s.store.GroupAddDevice(ctx, req.TenantID, req.ID, models.UID(req.UID)); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrDeviceNotFound(models.UID(req.UID), err)
+		}
+
+		return err
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionGroupDeviceAdd, models.AuditTarget{Type: models.AuditTargetGroup, ID: req.ID}, map[string]string{"device": req.UID})
+}
+
+func (s *service) RemoveGroupDevice(ctx context.Context, req *requests.GroupDevice) error {
+	if _, err := s.store.GroupGet(ctx, req.TenantID, req.ID); err != nil {
+		return NewErrGroupNotFound(req.ID, err)
+	}
+
+	if err := s.store.GroupRemoveDevice(ctx, req.TenantID, req.ID, models.UID(req.UID)); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrDeviceNotFound(models.UID(req.UID), err)
+		}
+
+		return err
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionGroupDeviceRemove, models.AuditTarget{Type: models.AuditTargetGroup, ID: req.ID}, map[string]string{"device": req.UID})
+}
+
+// checkGroupParent checks the group's parent, when it has one, belongs to the namespace.
+func (s *service) checkGroupParent(ctx context.Context, tenantID, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+
+	if _, err := s.store.GroupGet(ctx, tenantID, parentID); err != nil {
+		return NewErrGroupNotFound(parentID, err)
+	}
+
+	return nil
+}
+
+// groupFilters returns the filters that match the devices of the namespace's group, including the ones of its
+// subgroups.
+func (s *service) groupFilters(ctx context.Context, tenantID, id string) ([]query.Filter, error) {
+	if _, err := s.store.GroupGet(ctx, tenantID, id); err != nil {
+		return nil, NewErrGroupNotFound(id, err)
+	}
+
+	groups, err := s.store.GroupList(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []query.Filter{}
+	for _, group := range groupSubtree(groups, id) {
+		filters = append(filters, query.Filter{
+			Type:   query.FilterTypeProperty,
+			Params: &query.FilterProperty{Name: "groups", Operator: "eq", Value: group},
+		})
+	}
+
+	return append(filters, query.Filter{Type: query.FilterTypeOperator, Params: &query.FilterOperator{Name: "or"}}), nil
+}
+
+// groupSubtree returns the ID of the group followed by the IDs of all its subgroups, at any depth.
+func groupSubtree(groups []models.Group, id string) []string {
+	children := make(map[string][]string)
+	for _, group := range groups {
+		children[group.ParentID] = append(children[group.ParentID], group.ID)
+	}
+
+	// NOTICE: the visited groups are tracked, so a cycle, left by concurrent updates, doesn't loop forever.
+	visited := map[string]bool{id: true}
+	subtree := []string{id}
+	for i := 0; i < len(subtree); i++ {
+		for _, child := range children[subtree[i]] {
+			if !visited[child] {
+				visited[child] = true
+				subtree = append(subtree, child)
+			}
+		}
+	}
+
+	return subtree
+}
@@ -0,0 +1,500 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateGroup(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	uuidMock := new(uuidmock.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clockMock.On("Now").Return(now)
+
+	type Expected struct {
+		group *models.Group
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.GroupCreate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace has the maximum number of groups",
+			req:         &requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", Name: "europe"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(make([]models.Group, GroupMaxGroups), nil).
+					Once()
+			},
+			expected: Expected{group: nil, err: NewErrGroupLimit(GroupMaxGroups, nil)},
+		},
+		{
+			description: "fails when the parent is not found",
+			req:         &requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", ParentID: "europe", Name: "berlin"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.Group{}, nil).
+					Once()
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{group: nil, err: NewErrGroupNotFound("europe", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the parent already has a group with the same name",
+			req:         &requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", Name: "europe"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.Group{}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Once()
+				storeMock.
+					On("GroupCreate", ctx, &models.Group{
+						ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Name:      "europe",
+						CreatedAt: now,
+						UpdatedAt: now,
+					}).
+					Return(store.ErrDuplicate).
+					Once()
+			},
+			expected: Expected{group: nil, err: NewErrGroupDuplicated("europe", store.ErrDuplicate)},
+		},
+		{
+			description: "fails when the audit entry cannot be recorded",
+			req:         &requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", Name: "europe"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.Group{}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Once()
+				storeMock.
+					On("GroupCreate", ctx, &models.Group{
+						ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Name:      "europe",
+						CreatedAt: now,
+						UpdatedAt: now,
+					}).
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: Expected{group: nil, err: errors.New("error")},
+		},
+		{
+			description: "succeeds",
+			req:         &requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", ParentID: "europe", Name: "berlin"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.Group{}, nil).
+					Once()
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+					Return(&models.Group{ID: "europe"}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Once()
+				storeMock.
+					On("GroupCreate", ctx, &models.Group{
+						ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						ParentID:  "europe",
+						Name:      "berlin",
+						CreatedAt: now,
+						UpdatedAt: now,
+					}).
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				group: &models.Group{
+					ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					ParentID:  "europe",
+					Name:      "berlin",
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			group, err := s.CreateGroup(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{group, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateGroup(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clockMock.On("Now").Return(now)
+
+	groups := []models.Group{
+		{ID: "europe", TenantID: "00000000-0000-4000-0000-000000000000", Name: "europe"},
+		{ID: "germany", TenantID: "00000000-0000-4000-0000-000000000000", ParentID: "europe", Name: "germany"},
+		{ID: "berlin", TenantID: "00000000-0000-4000-0000-000000000000", ParentID: "germany", Name: "berlin"},
+		{ID: "america", TenantID: "00000000-0000-4000-0000-000000000000", Name: "america"},
+	}
+
+	type Expected struct {
+		group *models.Group
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.GroupUpdate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the group is not found",
+			req: &requests.GroupUpdate{
+				GroupParam:  requests.GroupParam{ID: "europe"},
+				GroupCreate: requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", Name: "eu"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{group: nil, err: NewErrGroupNotFound("europe", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the group is nested into one of its subgroups",
+			req: &requests.GroupUpdate{
+				GroupParam:  requests.GroupParam{ID: "europe"},
+				GroupCreate: requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", ParentID: "berlin", Name: "europe"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+					Return(&models.Group{ID: "europe", TenantID: "00000000-0000-4000-0000-000000000000", Name: "europe"}, nil).
+					Once()
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(groups, nil).
+					Once()
+			},
+			expected: Expected{group: nil, err: NewErrGroupParentInvalid("berlin")},
+		},
+		{
+			description: "fails when the group is nested into itself",
+			req: &requests.GroupUpdate{
+				GroupParam:  requests.GroupParam{ID: "europe"},
+				GroupCreate: requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", ParentID: "europe", Name: "europe"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+					Return(&models.Group{ID: "europe", TenantID: "00000000-0000-4000-0000-000000000000", Name: "europe"}, nil).
+					Once()
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(groups, nil).
+					Once()
+			},
+			expected: Expected{group: nil, err: NewErrGroupParentInvalid("europe")},
+		},
+		{
+			description: "succeeds",
+			req: &requests.GroupUpdate{
+				GroupParam:  requests.GroupParam{ID: "germany"},
+				GroupCreate: requests.GroupCreate{TenantID: "00000000-0000-4000-0000-000000000000", ParentID: "america", Name: "germany", Description: "moved"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "germany").
+					Return(&models.Group{ID: "germany", TenantID: "00000000-0000-4000-0000-000000000000", ParentID: "europe", Name: "germany"}, nil).
+					Once()
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(groups, nil).
+					Once()
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "america").
+					Return(&models.Group{ID: "america"}, nil).
+					Once()
+				storeMock.
+					On("GroupUpdate", ctx, &models.Group{
+						ID:          "germany",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						ParentID:    "america",
+						Name:        "germany",
+						Description: "moved",
+						UpdatedAt:   now,
+					}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				group: &models.Group{
+					ID:          "germany",
+					TenantID:    "00000000-0000-4000-0000-000000000000",
+					ParentID:    "america",
+					Name:        "germany",
+					Description: "moved",
+					UpdatedAt:   now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			group, err := s.UpdateGroup(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{group, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteGroup(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	groups := []models.Group{
+		{ID: "europe", Name: "europe"},
+		{ID: "berlin", ParentID: "europe", Name: "berlin"},
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.GroupDelete
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the group has subgroups",
+			req:         &requests.GroupDelete{GroupParam: requests.GroupParam{ID: "europe"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(groups, nil).
+					Once()
+			},
+			expected: NewErrGroupHasSubgroups("europe"),
+		},
+		{
+			description: "fails when the group is not found",
+			req:         &requests.GroupDelete{GroupParam: requests.GroupParam{ID: "munich"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(groups, nil).
+					Once()
+				storeMock.
+					On("GroupDelete", ctx, "00000000-0000-4000-0000-000000000000", "munich").
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrGroupNotFound("munich", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			req:         &requests.GroupDelete{GroupParam: requests.GroupParam{ID: "berlin"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(groups, nil).
+					Once()
+				storeMock.
+					On("GroupDelete", ctx, "00000000-0000-4000-0000-000000000000", "berlin").
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.DeleteGroup(ctx, tc.req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAddGroupDevice(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	req := &requests.GroupDevice{GroupParam: requests.GroupParam{ID: "europe"}, TenantID: "00000000-0000-4000-0000-000000000000", UID: "uid"}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the group is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrGroupNotFound("europe", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+					Return(&models.Group{ID: "europe"}, nil).
+					Once()
+				storeMock.
+					On("GroupAddDevice", ctx, "00000000-0000-4000-0000-000000000000", "europe", models.UID("uid")).
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+					Return(&models.Group{ID: "europe"}, nil).
+					Once()
+				storeMock.
+					On("GroupAddDevice", ctx, "00000000-0000-4000-0000-000000000000", "europe", models.UID("uid")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.AddGroupDevice(ctx, req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestGroupFilters(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	ctx := context.Background()
+
+	storeMock.
+		On("GroupGet", ctx, "00000000-0000-4000-0000-000000000000", "europe").
+		Return(&models.Group{ID: "europe"}, nil).
+		Once()
+	storeMock.
+		On("GroupList", ctx, "00000000-0000-4000-0000-000000000000").
+		Return([]models.Group{
+			{ID: "america"},
+			{ID: "berlin", ParentID: "germany"},
+			{ID: "europe"},
+			{ID: "germany", ParentID: "europe"},
+		}, nil).
+		Once()
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	filters, err := s.groupFilters(ctx, "00000000-0000-4000-0000-000000000000", "europe")
+	assert.NoError(t, err)
+	assert.Equal(t, []query.Filter{
+		{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "groups", Operator: "eq", Value: "europe"}},
+		{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "groups", Operator: "eq", Value: "germany"}},
+		{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "groups", Operator: "eq", Value: "berlin"}},
+		{Type: query.FilterTypeOperator, Params: &query.FilterOperator{Name: "or"}},
+	}, filters)
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type JobService interface {
+	// CreateDevicesJob creates an asynchronous job executing the request's operation on each one of the tenant's
+	// devices, and enqueues it to the worker. When the namespace already has a job with the request's idempotency key,
+	// that job is returned instead, unless its operation or devices are different.
+	CreateDevicesJob(ctx context.Context, req *requests.DevicesJobCreate) (*models.Job, error)
+
+	// GetJob retrieves the tenant's job, with its progress and the result of each one of its items.
+	GetJob(ctx context.Context, req *requests.JobGet) (*models.Job, error)
+}
+
+func (s *service) CreateDevicesJob(ctx context.Context, req *requests.DevicesJobCreate) (*models.Job, error) {
+	if req.IdempotencyKey != "" {
+		job, err := s.store.JobGetByIdempotencyKey(ctx, req.TenantID, req.IdempotencyKey)
+		if err == nil {
+			return matchDevicesJob(job, req)
+		}
+
+		if !errors.Is(err, store.ErrNoDocuments) {
+			return nil, err
+		}
+	}
+
+	items := make([]models.JobItem, len(req.UIDs))
+	for i, uid := range req.UIDs {
+		items[i] = models.JobItem{UID: uid, Status: models.JobItemStatusPending}
+	}
+
+	job := &models.Job{
+		ID:             uuid.Generate(),
+		TenantID:       req.TenantID,
+		UserID:         req.UserID,
+		Operation:      req.Operation,
+		IdempotencyKey: req.IdempotencyKey,
+		Tag:            req.Tag,
+		Status:         models.JobStatusQueued,
+		Total:          len(items),
+		Items:          items,
+		CreatedAt:      clock.Now(),
+	}
+
+	if err := s.store.JobCreate(ctx, job); err != nil {
+		if !errors.Is(err, store.ErrDuplicate) || req.IdempotencyKey == "" {
+			return nil, err
+		}
+
+		// NOTICE: a concurrent request with the same idempotency key has created the job first.
+		existing, err := s.store.JobGetByIdempotencyKey(ctx, req.TenantID, req.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return matchDevicesJob(existing, req)
+	}
+
+	if err := s.client.RunJob(ctx, job.ID); err != nil {
+		if err := s.store.JobSetFinished(ctx, job.ID, models.JobStatusFailed, "failed to enqueue the job", clock.Now()); err != nil {
+			log.WithContext(ctx).WithError(err).WithField("id", job.ID).Warn("failed to set the job as failed")
+		}
+
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *service) GetJob(ctx context.Context, req *requests.JobGet) (*models.Job, error) {
+	job, err := s.store.JobGet(ctx, req.ID)
+	if err != nil {
+		return nil, NewErrJobNotFound(req.ID, err)
+	}
+
+	if job.TenantID != req.TenantID {
+		return nil, NewErrJobNotFound(req.ID, nil)
+	}
+
+	return job, nil
+}
+
+// matchDevicesJob returns the job created with the request's idempotency key, when it has the request's operation and
+// devices. Otherwise, the key was reused for another job, and NewErrJobIdempotencyKey is returned.
+func matchDevicesJob(job *models.Job, req *requests.DevicesJobCreate) (*models.Job, error) {
+	if job.Operation != req.Operation || job.Tag != req.Tag || len(job.Items) != len(req.UIDs) {
+		return nil, NewErrJobIdempotencyKey(req.IdempotencyKey)
+	}
+
+	for i, item := range job.Items {
+		if item.UID != req.UIDs[i] {
+			return nil, NewErrJobIdempotencyKey(req.IdempotencyKey)
+		}
+	}
+
+	return job, nil
+}
+
+// runJobItem executes the job's operation on one of its devices, returning the item's result. The device must belong
+// to the job's tenant, as the item's UID comes from the request that created the job.
+func (s *service) runJobItem(ctx context.Context, job *models.Job, uid string) *models.JobItem {
+	item := &models.JobItem{UID: uid, Status: models.JobItemStatusSucceeded}
+
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(uid), job.TenantID)
+	if err != nil {
+		err = NewErrDeviceNotFound(models.UID(uid), err)
+	} else {
+		switch job.Operation {
+		case models.JobOperationDeviceAccept:
+			err = s.UpdateDeviceStatus(ctx, job.TenantID, models.UID(uid), models.DeviceStatusAccepted)
+		case models.JobOperationDeviceTag:
+			err = s.CreateDeviceTag(ctx, models.UID(uid), job.Tag)
+		case models.JobOperationDeviceExport:
+			item.Device = device
+		case models.JobOperationDeviceRefresh:
+			err = s.refreshDevice(ctx, device)
+		}
+	}
+
+	if err != nil {
+		item.Status = models.JobItemStatusFailed
+		item.Error = err.Error()
+	}
+
+	return item
+}
+
+// refreshDevice refreshes the information derived from the device: its position, resolved from its remote address, and
+// its tags, from the namespace's tag rules.
+func (s *service) refreshDevice(ctx context.Context, device *models.Device) error {
+	position, err := s.locator.GetPosition(net.ParseIP(device.RemoteAddr))
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeviceSetPosition(ctx, models.UID(device.UID), *models.NewDevicePosition(position.Latitude, position.Longitude)); err != nil {
+		return err
+	}
+
+	rules, err := s.store.TagRuleList(ctx, device.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return s.evaluateTagRules(ctx, rules, device)
+}
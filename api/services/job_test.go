@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDevicesJob(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clockMock.On("Now").Return(now)
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+
+	job := &models.Job{
+		ID:        "00000000-0000-4000-0000-000000000001",
+		TenantID:  tenant,
+		UserID:    "000000000000000000000000",
+		Operation: models.JobOperationDeviceAccept,
+		Status:    models.JobStatusQueued,
+		Total:     2,
+		Items: []models.JobItem{
+			{UID: "first", Status: models.JobItemStatusPending},
+			{UID: "second", Status: models.JobItemStatusPending},
+		},
+		CreatedAt: now,
+	}
+
+	keyed := *job
+	keyed.IdempotencyKey = "key"
+
+	type Expected struct {
+		job *models.Job
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DevicesJobCreate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "succeeds returning the job created with the idempotency key",
+			req: &requests.DevicesJobCreate{
+				TenantID:       tenant,
+				UserID:         "000000000000000000000000",
+				IdempotencyKey: "key",
+				Operation:      models.JobOperationDeviceAccept,
+				UIDs:           []string{"first", "second"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGetByIdempotencyKey", ctx, tenant, "key").
+					Return(&keyed, nil).
+					Once()
+			},
+			expected: Expected{job: &keyed},
+		},
+		{
+			description: "fails when the idempotency key was used by a job with another operation",
+			req: &requests.DevicesJobCreate{
+				TenantID:       tenant,
+				UserID:         "000000000000000000000000",
+				IdempotencyKey: "key",
+				Operation:      models.JobOperationDeviceRefresh,
+				UIDs:           []string{"first", "second"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGetByIdempotencyKey", ctx, tenant, "key").
+					Return(&keyed, nil).
+					Once()
+			},
+			expected: Expected{err: NewErrJobIdempotencyKey("key")},
+		},
+		{
+			description: "fails when the idempotency key was used by a job with other devices",
+			req: &requests.DevicesJobCreate{
+				TenantID:       tenant,
+				UserID:         "000000000000000000000000",
+				IdempotencyKey: "key",
+				Operation:      models.JobOperationDeviceAccept,
+				UIDs:           []string{"first", "third"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGetByIdempotencyKey", ctx, tenant, "key").
+					Return(&keyed, nil).
+					Once()
+			},
+			expected: Expected{err: NewErrJobIdempotencyKey("key")},
+		},
+		{
+			description: "fails when the job cannot be enqueued",
+			req: &requests.DevicesJobCreate{
+				TenantID:  tenant,
+				UserID:    "000000000000000000000000",
+				Operation: models.JobOperationDeviceAccept,
+				UIDs:      []string{"first", "second"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("JobCreate", ctx, job).
+					Return(nil).
+					Once()
+				clientMock.
+					On("RunJob", ctx, "00000000-0000-4000-0000-000000000001").
+					Return(errors.New("error")).
+					Once()
+				storeMock.
+					On("JobSetFinished", ctx, "00000000-0000-4000-0000-000000000001", models.JobStatusFailed, "failed to enqueue the job", now).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{err: errors.New("error")},
+		},
+		{
+			description: "succeeds",
+			req: &requests.DevicesJobCreate{
+				TenantID:  tenant,
+				UserID:    "000000000000000000000000",
+				Operation: models.JobOperationDeviceAccept,
+				UIDs:      []string{"first", "second"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("JobCreate", ctx, job).
+					Return(nil).
+					Once()
+				clientMock.
+					On("RunJob", ctx, "00000000-0000-4000-0000-000000000001").
+					Return(nil).
+					Once()
+			},
+			expected: Expected{job: job},
+		},
+		{
+			description: "succeeds returning the job created concurrently with the idempotency key",
+			req: &requests.DevicesJobCreate{
+				TenantID:       tenant,
+				UserID:         "000000000000000000000000",
+				IdempotencyKey: "key",
+				Operation:      models.JobOperationDeviceAccept,
+				UIDs:           []string{"first", "second"},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGetByIdempotencyKey", ctx, tenant, "key").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000002").
+					Once()
+				storeMock.
+					On("JobCreate", ctx, &models.Job{
+						ID:             "00000000-0000-4000-0000-000000000002",
+						TenantID:       tenant,
+						UserID:         "000000000000000000000000",
+						Operation:      models.JobOperationDeviceAccept,
+						IdempotencyKey: "key",
+						Status:         models.JobStatusQueued,
+						Total:          2,
+						Items:          job.Items,
+						CreatedAt:      now,
+					}).
+					Return(store.ErrDuplicate).
+					Once()
+				storeMock.
+					On("JobGetByIdempotencyKey", ctx, tenant, "key").
+					Return(&keyed, nil).
+					Once()
+			},
+			expected: Expected{job: &keyed},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			job, err := s.CreateDevicesJob(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{job, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestGetJob(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		job *models.Job
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.JobGet
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the job is not found",
+			req:         &requests.JobGet{TenantID: "00000000-0000-4000-0000-000000000000", ID: "id"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGet", ctx, "id").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{err: NewErrJobNotFound("id", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the job belongs to another namespace",
+			req:         &requests.JobGet{TenantID: "00000000-0000-4000-0000-000000000000", ID: "id"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGet", ctx, "id").
+					Return(&models.Job{ID: "id", TenantID: "00000000-0000-4000-0000-000000000001"}, nil).
+					Once()
+			},
+			expected: Expected{err: NewErrJobNotFound("id", nil)},
+		},
+		{
+			description: "succeeds",
+			req:         &requests.JobGet{TenantID: "00000000-0000-4000-0000-000000000000", ID: "id"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGet", ctx, "id").
+					Return(&models.Job{ID: "id", TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+					Once()
+			},
+			expected: Expected{job: &models.Job{ID: "id", TenantID: "00000000-0000-4000-0000-000000000000"}},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			job, err := s.GetJob(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{job, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo"
+	"github.com/shellhub-io/shellhub/api/store/postgres"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -78,6 +79,10 @@ func (s *service) AddNamespaceMember(ctx context.Context, req *requests.Namespac
 			return nil, NewErrUserNotFound(req.MemberEmail, err)
 		}
 
+		if err := s.checkMemberQuota(namespace); err != nil {
+			return nil, err
+		}
+
 		passiveUser = &models.User{}
 		passiveUser.ID, err = s.store.UserCreateInvited(ctx, strings.ToLower(req.MemberEmail))
 		if err != nil {
@@ -97,18 +102,66 @@ func (s *service) AddNamespaceMember(ctx context.Context, req *requests.Namespac
 			return nil, NewErrNamespaceMemberDuplicated(passiveUser.ID, nil)
 		}
 
+		if err := s.checkMemberQuota(namespace); err != nil {
+			return nil, err
+		}
+
 		if err := s.store.WithTransaction(ctx, s.resendMemberInvite(m.ID, req)); err != nil {
 			return nil, err
 		}
 	} else {
+		if err := s.checkMemberQuota(namespace); err != nil {
+			return nil, err
+		}
+
 		if err := s.store.WithTransaction(ctx, s.addMember(passiveUser.ID, req)); err != nil {
 			return nil, err
 		}
 	}
 
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionMemberAdd, models.AuditTarget{Type: models.AuditTargetUser, ID: passiveUser.ID}, map[string]string{
+		"email": strings.ToLower(req.MemberEmail),
+		"role":  req.MemberRole.String(),
+	}); err != nil {
+		return nil, err
+	}
+
 	return s.store.NamespaceGet(ctx, req.TenantID, s.store.Options().CountAcceptedDevices(), s.store.Options().EnrichMembersData())
 }
 
+// memberQuota returns the maximum number of members and pending invitations allowed in the namespace, using the
+// instance's defaults when the namespace doesn't override them. A value lower or equal to zero means no limit.
+func (s *service) memberQuota(namespace *models.Namespace) (int, int) {
+	members, invitations := s.quota.members, s.quota.invitations
+
+	if namespace.MaxMembers != 0 {
+		members = namespace.MaxMembers
+	}
+
+	if namespace.MaxInvitations != 0 {
+		invitations = namespace.MaxInvitations
+	}
+
+	return members, invitations
+}
+
+// checkMemberQuota checks if one more member, or pending invitation, fits in the namespace's quota.
+func (s *service) checkMemberQuota(namespace *models.Namespace) error {
+	maxMembers, maxInvitations := s.memberQuota(namespace)
+	members, invitations := namespace.CountMembers(clock.Now())
+
+	if maxMembers > 0 && members >= maxMembers {
+		return NewErrNamespaceMembersLimit(maxMembers, nil)
+	}
+
+	// NOTICE: Only cloud instances have pending invitations, so the invitations count is always zero otherwise.
+	if maxInvitations > 0 && invitations >= maxInvitations {
+		return NewErrNamespaceInvitationsLimit(maxInvitations, nil)
+	}
+
+	return nil
+}
+
 // addMember returns a transaction callback that adds a member and sends an invite if the instance is cloud.
 func (s *service) addMember(memberID string, req *requests.NamespaceAddMember) store.TransactionCb {
 	return func(ctx context.Context) error {
@@ -190,7 +243,12 @@ func (s *service) UpdateNamespaceMember(ctx context.Context, req *requests.Names
 
 	s.AuthUncacheToken(ctx, namespace.TenantID, req.MemberID) // nolint: errcheck
 
-	return nil
+	var details map[string]string
+	if changes.Role != authorizer.RoleInvalid {
+		details = map[string]string{"role": changes.Role.String()}
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionMemberUpdate, models.AuditTarget{Type: models.AuditTargetUser, ID: req.MemberID}, details)
 }
 
 func (s *service) RemoveNamespaceMember(ctx context.Context, req *requests.NamespaceRemoveMember) (*models.Namespace, error) {
@@ -223,12 +281,16 @@ func (s *service) RemoveNamespaceMember(ctx context.Context, req *requests.Names
 	}
 
 	if err := s.AuthUncacheToken(ctx, req.TenantID, req.UserID); err != nil {
-		log.WithError(err).
+		log.WithContext(ctx).WithError(err).
 			WithField("tenant_id", req.TenantID).
 			WithField("user_id", req.UserID).
 			Error("failed to uncache the token")
 	}
 
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionMemberRemove, models.AuditTarget{Type: models.AuditTargetUser, ID: req.MemberID}, nil); err != nil {
+		return nil, err
+	}
+
 	return s.store.NamespaceGet(ctx, req.TenantID, s.store.Options().CountAcceptedDevices(), s.store.Options().EnrichMembersData())
 }
 
@@ -246,6 +308,10 @@ func (s *service) LeaveNamespace(ctx context.Context, req *requests.LeaveNamespa
 		return nil, err
 	}
 
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionMemberLeave, models.AuditTarget{Type: models.AuditTargetUser, ID: req.UserID}, nil); err != nil {
+		return nil, err
+	}
+
 	// If the user is attempting to leave a namespace other than the authenticated one,
 	// there is no need to generate a new token.
 	if req.TenantID != req.AuthenticatedTenantID {
@@ -254,21 +320,26 @@ func (s *service) LeaveNamespace(ctx context.Context, req *requests.LeaveNamespa
 
 	emptyString := "" // just to be used as a pointer
 	if err := s.store.UserUpdate(ctx, req.UserID, &models.UserChanges{PreferredNamespace: &emptyString}); err != nil {
-		log.WithError(err).
+		log.WithContext(ctx).WithError(err).
 			WithField("tenant_id", req.TenantID).
 			WithField("user_id", req.UserID).
 			Error("failed to reset user's preferred namespace")
 	}
 
 	if err := s.AuthUncacheToken(ctx, req.TenantID, req.UserID); err != nil {
-		log.WithError(err).
+		log.WithContext(ctx).WithError(err).
 			WithField("tenant_id", req.TenantID).
 			WithField("user_id", req.UserID).
 			Error("failed to uncache the token")
 	}
 
 	// TODO: make this method a util function
-	return s.CreateUserToken(ctx, &requests.CreateUserToken{UserID: req.UserID})
+	return s.CreateUserToken(ctx, &requests.CreateUserToken{
+		UserID:    req.UserID,
+		SessionID: req.SessionID,
+		UserAgent: req.UserAgent,
+		IP:        req.IP,
+	})
 }
 
 func (s *service) removeMember(ctx context.Context, ns *models.Namespace, userID string) error {
@@ -276,7 +347,7 @@ func (s *service) removeMember(ctx context.Context, ns *models.Namespace, userID
 		switch {
 		case errors.Is(err, store.ErrNoDocuments):
 			return NewErrNamespaceNotFound(ns.TenantID, err)
-		case errors.Is(err, mongo.ErrUserNotFound):
+		case errors.Is(err, mongo.ErrUserNotFound), errors.Is(err, postgres.ErrUserNotFound):
 			return NewErrNamespaceMemberNotFound(userID, err)
 		default:
 			return err
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DefaultMemberActivityDays is the size, in days, of the member activity report's window when not set.
+const DefaultMemberActivityDays = 30
+
+type MemberActivityService interface {
+	// GetNamespaceMemberActivity reports the activity of the namespace's member over the requested window, ending now:
+	// the member's last login, sessions and changes made on the namespace. Only the members allowed to review the
+	// namespace's members can read it. It returns the report and an error, if any.
+	GetNamespaceMemberActivity(ctx context.Context, req *requests.NamespaceMemberActivity) (*models.MemberActivity, error)
+}
+
+func (s *service) GetNamespaceMemberActivity(ctx context.Context, req *requests.NamespaceMemberActivity) (*models.MemberActivity, error) {
+	namespace, err := s.store.NamespaceGet(ctx, req.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	reviewer, ok := namespace.FindMember(req.UserID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(req.UserID, nil)
+	}
+
+	if !reviewer.Role.HasPermission(authorizer.NamespaceReviewMembers) {
+		return nil, NewErrRoleInvalid()
+	}
+
+	member, ok := namespace.FindMember(req.MemberID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(req.MemberID, nil)
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, member.ID, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(member.ID, err)
+	}
+
+	days := req.Days
+	if days <= 0 {
+		days = DefaultMemberActivityDays
+	}
+
+	activity := &models.MemberActivity{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Role:     member.Role,
+		AddedAt:  member.AddedAt,
+		To:       clock.Now(),
+		Sessions: []models.UserSession{},
+		Actions:  []models.MemberAction{},
+	}
+
+	activity.From = activity.To.AddDate(0, 0, -days)
+
+	if !user.LastLogin.IsZero() {
+		lastLogin := user.LastLogin
+		activity.LastLogin = &lastLogin
+	}
+
+	sessions, err := s.store.UserSessionList(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		if !session.LastSeenAt.Before(activity.From) {
+			activity.Sessions = append(activity.Sessions, session)
+		}
+	}
+
+	apiKeys, err := s.store.APIKeyListByCreator(ctx, req.TenantID, user.ID, activity.From)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiKey := range apiKeys {
+		activity.Actions = append(activity.Actions, models.MemberAction{
+			Action:    models.MemberActionAPIKeyCreate,
+			Target:    apiKey.Name,
+			CreatedAt: apiKey.CreatedAt,
+		})
+	}
+
+	exemptions, err := s.store.DeviceLimitExemptionListByUser(ctx, req.TenantID, user.ID, activity.From)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, exemption := range exemptions {
+		action := models.MemberActionDeviceLimitExempt
+		if !exemption.Exempt {
+			action = models.MemberActionDeviceLimitExemptRevoke
+		}
+
+		activity.Actions = append(activity.Actions, models.MemberAction{
+			Action:    action,
+			Target:    exemption.DeviceUID,
+			CreatedAt: exemption.CreatedAt,
+		})
+	}
+
+	overrides, err := s.store.SessionScheduleOverrideListByUser(ctx, req.TenantID, user.ID, activity.From)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, override := range overrides {
+		activity.Actions = append(activity.Actions, models.MemberAction{
+			Action:    models.MemberActionSessionScheduleOverride,
+			Target:    override.DeviceUID,
+			CreatedAt: override.CreatedAt,
+		})
+	}
+
+	sort.SliceStable(activity.Actions, func(i, j int) bool {
+		return activity.Actions[i].CreatedAt.After(activity.Actions[j].CreatedAt)
+	})
+
+	return activity, nil
+}
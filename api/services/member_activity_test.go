@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	storemock "github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNamespaceMemberActivity(t *testing.T) {
+	type Expected struct {
+		activity *models.MemberActivity
+		err      error
+	}
+
+	storeMock := new(storemock.Store)
+
+	clockMock.On("Now").Return(now)
+	from := now.AddDate(0, 0, -7)
+	lastLogin := now.Add(-time.Hour)
+
+	namespace := &models.Namespace{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Name:     "namespace",
+		Owner:    "000000000000000000000000",
+		Members: []models.Member{
+			{ID: "000000000000000000000000", Role: authorizer.RoleOwner},
+			{ID: "000000000000000000000001", Role: authorizer.RoleOperator, AddedAt: now.AddDate(0, -1, 0)},
+			{ID: "000000000000000000000002", Role: authorizer.RoleObserver},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.NamespaceMemberActivity
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace was not found",
+			req: &requests.NamespaceMemberActivity{
+				UserID:   "000000000000000000000000",
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				MemberID: "000000000000000000000001",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{
+				activity: nil,
+				err:      NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the reviewer isn't allowed to review the members",
+			req: &requests.NamespaceMemberActivity{
+				UserID:   "000000000000000000000002",
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				MemberID: "000000000000000000000001",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+			},
+			expected: Expected{
+				activity: nil,
+				err:      NewErrRoleInvalid(),
+			},
+		},
+		{
+			description: "fails when the member was not found",
+			req: &requests.NamespaceMemberActivity{
+				UserID:   "000000000000000000000000",
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				MemberID: "000000000000000000000003",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+			},
+			expected: Expected{
+				activity: nil,
+				err:      NewErrNamespaceMemberNotFound("000000000000000000000003", nil),
+			},
+		},
+		{
+			description: "fails when the member's sessions cannot be listed",
+			req: &requests.NamespaceMemberActivity{
+				UserID:   "000000000000000000000000",
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				MemberID: "000000000000000000000001",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000001", false).
+					Return(&models.User{ID: "000000000000000000000001"}, 0, nil).
+					Once()
+				storeMock.
+					On("UserSessionList", ctx, "000000000000000000000001").
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: Expected{
+				activity: nil,
+				err:      errors.New("error"),
+			},
+		},
+		{
+			description: "succeeds",
+			req: &requests.NamespaceMemberActivity{
+				UserID:   "000000000000000000000000",
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				MemberID: "000000000000000000000001",
+				Days:     7,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000001", false).
+					Return(&models.User{
+						ID:        "000000000000000000000001",
+						LastLogin: lastLogin,
+						UserData:  models.UserData{Username: "john_doe", Email: "john.doe@test.com"},
+					}, 0, nil).
+					Once()
+				storeMock.
+					On("UserSessionList", ctx, "000000000000000000000001").
+					Return([]models.UserSession{
+						{ID: "recent", IP: "192.0.2.1", LastSeenAt: now.Add(-time.Hour)},
+						{ID: "stale", IP: "192.0.2.2", LastSeenAt: now.AddDate(0, 0, -8)},
+					}, nil).
+					Once()
+				storeMock.
+					On("APIKeyListByCreator", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001", from).
+					Return([]models.APIKey{{Name: "ci", CreatedAt: now.AddDate(0, 0, -2)}}, nil).
+					Once()
+				storeMock.
+					On("DeviceLimitExemptionListByUser", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001", from).
+					Return([]models.DeviceLimitExemption{
+						{DeviceUID: "uid", Exempt: false, CreatedAt: now.AddDate(0, 0, -1)},
+						{DeviceUID: "uid", Exempt: true, CreatedAt: now.AddDate(0, 0, -3)},
+					}, nil).
+					Once()
+				storeMock.
+					On("SessionScheduleOverrideListByUser", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001", from).
+					Return([]models.SessionScheduleOverride{
+						{DeviceUID: "uid", CreatedAt: now.AddDate(0, 0, -4)},
+					}, nil).
+					Once()
+			},
+			expected: Expected{
+				activity: &models.MemberActivity{
+					ID:        "000000000000000000000001",
+					Username:  "john_doe",
+					Email:     "john.doe@test.com",
+					Role:      authorizer.RoleOperator,
+					AddedAt:   now.AddDate(0, -1, 0),
+					LastLogin: &lastLogin,
+					From:      from,
+					To:        now,
+					Sessions: []models.UserSession{
+						{ID: "recent", IP: "192.0.2.1", LastSeenAt: now.Add(-time.Hour)},
+					},
+					Actions: []models.MemberAction{
+						{Action: models.MemberActionDeviceLimitExemptRevoke, Target: "uid", CreatedAt: now.AddDate(0, 0, -1)},
+						{Action: models.MemberActionAPIKeyCreate, Target: "ci", CreatedAt: now.AddDate(0, 0, -2)},
+						{Action: models.MemberActionDeviceLimitExempt, Target: "uid", CreatedAt: now.AddDate(0, 0, -3)},
+						{Action: models.MemberActionSessionScheduleOverride, Target: "uid", CreatedAt: now.AddDate(0, 0, -4)},
+					},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			activity, err := s.GetNamespaceMemberActivity(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{activity, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
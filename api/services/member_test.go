@@ -462,6 +462,11 @@ func TestAddNamespaceMember(t *testing.T) {
 					On("WithTransaction", ctx, mock.Anything).
 					Return(nil).
 					Once()
+
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				queryOptionsMock.On("EnrichMembersData").Return(nil).Once()
 				storeMock.
@@ -557,6 +562,11 @@ func TestAddNamespaceMember(t *testing.T) {
 					On("WithTransaction", ctx, mock.Anything).
 					Return(nil).
 					Once()
+
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				queryOptionsMock.On("EnrichMembersData").Return(nil).Once()
 				storeMock.
@@ -604,6 +614,104 @@ func TestAddNamespaceMember(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			description: "fails when the namespace's member limit is reached",
+			req: &requests.NamespaceAddMember{
+				FowardedHost: "localhost",
+				UserID:       "000000000000000000000000",
+				TenantID:     "00000000-0000-4000-0000-000000000000",
+				MemberEmail:  "john.doe@test.com",
+				MemberRole:   authorizer.RoleObserver,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Name:       "namespace",
+						Owner:      "000000000000000000000000",
+						MaxMembers: 1,
+						Members: []models.Member{
+							{
+								ID:     "000000000000000000000000",
+								Role:   authorizer.RoleOwner,
+								Status: models.MemberStatusAccepted,
+							},
+						},
+					}, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{
+						ID:       "000000000000000000000000",
+						UserData: models.UserData{Username: "jane_doe"},
+					}, 0, nil).
+					Once()
+				storeMock.
+					On("UserGetByEmail", ctx, "john.doe@test.com").
+					Return(&models.User{
+						ID:       "000000000000000000000001",
+						UserData: models.UserData{Username: "john_doe"},
+					}, nil).
+					Once()
+			},
+			expected: Expected{
+				namespace: nil,
+				err:       NewErrNamespaceMembersLimit(1, nil),
+			},
+		},
+		{
+			description: "fails when the namespace's pending invitation limit is reached",
+			req: &requests.NamespaceAddMember{
+				FowardedHost: "localhost",
+				UserID:       "000000000000000000000000",
+				TenantID:     "00000000-0000-4000-0000-000000000000",
+				MemberEmail:  "john.doe@test.com",
+				MemberRole:   authorizer.RoleObserver,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID:       "00000000-0000-4000-0000-000000000000",
+						Name:           "namespace",
+						Owner:          "000000000000000000000000",
+						MaxInvitations: 1,
+						Members: []models.Member{
+							{
+								ID:     "000000000000000000000000",
+								Role:   authorizer.RoleOwner,
+								Status: models.MemberStatusAccepted,
+							},
+							{
+								ID:        "000000000000000000000002",
+								Role:      authorizer.RoleObserver,
+								Status:    models.MemberStatusPending,
+								ExpiresAt: now.Add(time.Hour),
+							},
+						},
+					}, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{
+						ID:       "000000000000000000000000",
+						UserData: models.UserData{Username: "jane_doe"},
+					}, 0, nil).
+					Once()
+				storeMock.
+					On("UserGetByEmail", ctx, "john.doe@test.com").
+					Return(&models.User{
+						ID:       "000000000000000000000001",
+						UserData: models.UserData{Username: "john_doe"},
+					}, nil).
+					Once()
+			},
+			expected: Expected{
+				namespace: nil,
+				err:       NewErrNamespaceInvitationsLimit(1, nil),
+			},
+		},
 		{
 			description: "fails when cannot add the member",
 			req: &requests.NamespaceAddMember{
@@ -704,6 +812,11 @@ func TestAddNamespaceMember(t *testing.T) {
 					On("WithTransaction", ctx, mock.Anything).
 					Return(nil).
 					Once()
+
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				queryOptionsMock.On("EnrichMembersData").Return(nil).Once()
 				storeMock.
@@ -1249,6 +1362,10 @@ func TestUpdateNamespaceMember(t *testing.T) {
 					On("NamespaceUpdateMember", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001", &models.MemberChanges{Role: authorizer.RoleAdministrator}).
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -1512,6 +1629,11 @@ func TestRemoveNamespaceMember(t *testing.T) {
 					On("NamespaceRemoveMember", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000001").
 					Return(nil).
 					Once()
+
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				queryOptionsMock.On("EnrichMembersData").Return(nil).Once()
 				storeMock.
@@ -1702,6 +1824,10 @@ func TestService_LeaveNamespace(t *testing.T) {
 					On("NamespaceRemoveMember", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000000").
 					Return(nil).
 					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 			},
 			expected: Expected{
 				res: nil,
@@ -1734,6 +1860,11 @@ func TestService_LeaveNamespace(t *testing.T) {
 					On("NamespaceRemoveMember", ctx, "00000000-0000-4000-0000-000000000000", "000000000000000000000000").
 					Return(nil).
 					Once()
+
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
 				emptyString := ""
 				storeMock.
 					On("UserUpdate", ctx, "000000000000000000000000", &models.UserChanges{PreferredNamespace: &emptyString}).
@@ -1782,6 +1913,10 @@ func TestService_LeaveNamespace(t *testing.T) {
 				clockMock := new(clockmock.Clock)
 				clock.DefaultBackend = clockMock
 				clockMock.On("Now").Return(now)
+				storeMock.
+					On("UserSessionSave", ctx, mock.AnythingOfType("*models.UserSession")).
+					Return(nil).
+					Once()
 				cacheMock.
 					On("Set", ctx, "token_000000000000000000000000", mock.Anything, time.Hour*72).
 					Return(nil).
@@ -1812,10 +1947,13 @@ func TestService_LeaveNamespace(t *testing.T) {
 			tc.requiredMocks(ctx)
 
 			res, err := s.LeaveNamespace(ctx, tc.req)
-			// Since the resulting token is not crucial for the assertion and
-			// difficult to mock, it is safe to ignore this field.
+			// Since the resulting tokens are not crucial for the assertion and
+			// difficult to mock, it is safe to ignore these fields.
 			if res != nil {
 				res.Token = "must ignore"
+
+				assert.NotEmpty(t, res.RefreshToken)
+				res.RefreshToken = ""
 			}
 
 			assert.Equal(t, tc.expected, Expected{res, err})
@@ -14,6 +14,8 @@ import (
 
 	query "github.com/shellhub-io/shellhub/pkg/api/query"
 
+	queryanalytics "github.com/shellhub-io/shellhub/api/pkg/queryanalytics"
+
 	requests "github.com/shellhub-io/shellhub/pkg/api/requests"
 
 	responses "github.com/shellhub-io/shellhub/pkg/api/responses"
@@ -26,6 +28,24 @@ type Service struct {
 	mock.Mock
 }
 
+// AddGroupDevice provides a mock function with given fields: ctx, req
+func (_m *Service) AddGroupDevice(ctx context.Context, req *requests.GroupDevice) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddGroupDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupDevice) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddNamespaceMember provides a mock function with given fields: ctx, req
 func (_m *Service) AddNamespaceMember(ctx context.Context, req *requests.NamespaceAddMember) (*models.Namespace, error) {
 	ret := _m.Called(ctx, req)
@@ -272,6 +292,24 @@ func (_m *Service) AuthUncacheToken(ctx context.Context, tenant string, id strin
 	return r0
 }
 
+// AuthUserSession provides a mock function with given fields: ctx, id
+func (_m *Service) AuthUserSession(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthUserSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BillingEvaluate provides a mock function with given fields: _a0, _a1
 func (_m *Service) BillingEvaluate(_a0 internalclient.Client, _a1 string) (bool, error) {
 	ret := _m.Called(_a0, _a1)
@@ -318,28 +356,28 @@ func (_m *Service) BillingReport(_a0 internalclient.Client, _a1 string, _a2 stri
 	return r0
 }
 
-// CreateAPIKey provides a mock function with given fields: ctx, req
-func (_m *Service) CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey) (*responses.CreateAPIKey, error) {
+// ClaimDevice provides a mock function with given fields: ctx, req
+func (_m *Service) ClaimDevice(ctx context.Context, req *requests.DeviceClaim) (*models.Device, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateAPIKey")
+		panic("no return value specified for ClaimDevice")
 	}
 
-	var r0 *responses.CreateAPIKey
+	var r0 *models.Device
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateAPIKey) (*responses.CreateAPIKey, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceClaim) (*models.Device, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateAPIKey) *responses.CreateAPIKey); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceClaim) *models.Device); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*responses.CreateAPIKey)
+			r0 = ret.Get(0).(*models.Device)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.CreateAPIKey) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceClaim) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -348,77 +386,29 @@ func (_m *Service) CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey)
 	return r0, r1
 }
 
-// CreateDeviceTag provides a mock function with given fields: ctx, uid, tag
-func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
-	ret := _m.Called(ctx, uid, tag)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CreateDeviceTag")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
-		r0 = rf(ctx, uid, tag)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// CreateNamespace provides a mock function with given fields: ctx, namespace
-func (_m *Service) CreateNamespace(ctx context.Context, namespace *requests.NamespaceCreate) (*models.Namespace, error) {
-	ret := _m.Called(ctx, namespace)
+// CloneNamespace provides a mock function with given fields: ctx, req
+func (_m *Service) CloneNamespace(ctx context.Context, req *requests.NamespaceClone) (*models.Namespace, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateNamespace")
+		panic("no return value specified for CloneNamespace")
 	}
 
 	var r0 *models.Namespace
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceCreate) (*models.Namespace, error)); ok {
-		return rf(ctx, namespace)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceClone) (*models.Namespace, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceCreate) *models.Namespace); ok {
-		r0 = rf(ctx, namespace)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceClone) *models.Namespace); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Namespace)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceCreate) error); ok {
-		r1 = rf(ctx, namespace)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// CreatePrivateKey provides a mock function with given fields: ctx
-func (_m *Service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error) {
-	ret := _m.Called(ctx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CreatePrivateKey")
-	}
-
-	var r0 *models.PrivateKey
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*models.PrivateKey, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) *models.PrivateKey); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PrivateKey)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceClone) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -426,29 +416,29 @@ func (_m *Service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, er
 	return r0, r1
 }
 
-// CreatePublicKey provides a mock function with given fields: ctx, req, tenant
-func (_m *Service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCreate, tenant string) (*responses.PublicKeyCreate, error) {
-	ret := _m.Called(ctx, req, tenant)
+// CreateAPIKey provides a mock function with given fields: ctx, req
+func (_m *Service) CreateAPIKey(ctx context.Context, req *requests.CreateAPIKey) (*responses.CreateAPIKey, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePublicKey")
+		panic("no return value specified for CreateAPIKey")
 	}
 
-	var r0 *responses.PublicKeyCreate
+	var r0 *responses.CreateAPIKey
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, requests.PublicKeyCreate, string) (*responses.PublicKeyCreate, error)); ok {
-		return rf(ctx, req, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateAPIKey) (*responses.CreateAPIKey, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, requests.PublicKeyCreate, string) *responses.PublicKeyCreate); ok {
-		r0 = rf(ctx, req, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateAPIKey) *responses.CreateAPIKey); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*responses.PublicKeyCreate)
+			r0 = ret.Get(0).(*responses.CreateAPIKey)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, requests.PublicKeyCreate, string) error); ok {
-		r1 = rf(ctx, req, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.CreateAPIKey) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -456,29 +446,29 @@ func (_m *Service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCr
 	return r0, r1
 }
 
-// CreateSession provides a mock function with given fields: ctx, session
-func (_m *Service) CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error) {
-	ret := _m.Called(ctx, session)
+// CreateBannedAddress provides a mock function with given fields: ctx, req
+func (_m *Service) CreateBannedAddress(ctx context.Context, req *requests.BannedAddressCreate) (*models.BannedAddress, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateSession")
+		panic("no return value specified for CreateBannedAddress")
 	}
 
-	var r0 *models.Session
+	var r0 *models.BannedAddress
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, requests.SessionCreate) (*models.Session, error)); ok {
-		return rf(ctx, session)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.BannedAddressCreate) (*models.BannedAddress, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, requests.SessionCreate) *models.Session); ok {
-		r0 = rf(ctx, session)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.BannedAddressCreate) *models.BannedAddress); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Session)
+			r0 = ret.Get(0).(*models.BannedAddress)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, requests.SessionCreate) error); ok {
-		r1 = rf(ctx, session)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.BannedAddressCreate) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -486,28 +476,28 @@ func (_m *Service) CreateSession(ctx context.Context, session requests.SessionCr
 	return r0, r1
 }
 
-// CreateUserToken provides a mock function with given fields: ctx, req
-func (_m *Service) CreateUserToken(ctx context.Context, req *requests.CreateUserToken) (*models.UserAuthResponse, error) {
+// CreateCommandPolicy provides a mock function with given fields: ctx, req
+func (_m *Service) CreateCommandPolicy(ctx context.Context, req *requests.CommandPolicyCreate) (*models.CommandPolicy, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUserToken")
+		panic("no return value specified for CreateCommandPolicy")
 	}
 
-	var r0 *models.UserAuthResponse
+	var r0 *models.CommandPolicy
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateUserToken) (*models.UserAuthResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyCreate) (*models.CommandPolicy, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateUserToken) *models.UserAuthResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyCreate) *models.CommandPolicy); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.UserAuthResponse)
+			r0 = ret.Get(0).(*models.CommandPolicy)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.CreateUserToken) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.CommandPolicyCreate) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -516,17 +506,17 @@ func (_m *Service) CreateUserToken(ctx context.Context, req *requests.CreateUser
 	return r0, r1
 }
 
-// DeactivateSession provides a mock function with given fields: ctx, uid
-func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// CreateCommandPolicyEvaluation provides a mock function with given fields: ctx, req
+func (_m *Service) CreateCommandPolicyEvaluation(ctx context.Context, req *requests.CommandPolicyEvaluationCreate) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeactivateSession")
+		panic("no return value specified for CreateCommandPolicyEvaluation")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyEvaluationCreate) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -534,16 +524,16 @@ func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error
 	return r0
 }
 
-// DeleteAPIKey provides a mock function with given fields: ctx, req
-func (_m *Service) DeleteAPIKey(ctx context.Context, req *requests.DeleteAPIKey) error {
+// CreateDeviceAgentLogs provides a mock function with given fields: ctx, req
+func (_m *Service) CreateDeviceAgentLogs(ctx context.Context, req *requests.DeviceAgentLogsCreate) error {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAPIKey")
+		panic("no return value specified for CreateDeviceAgentLogs")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeleteAPIKey) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceAgentLogsCreate) error); ok {
 		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
@@ -552,53 +542,77 @@ func (_m *Service) DeleteAPIKey(ctx context.Context, req *requests.DeleteAPIKey)
 	return r0
 }
 
-// DeleteDevice provides a mock function with given fields: ctx, uid, tenant
-func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
-	ret := _m.Called(ctx, uid, tenant)
+// CreateDeviceCommand provides a mock function with given fields: ctx, req
+func (_m *Service) CreateDeviceCommand(ctx context.Context, req *requests.DeviceCommandCreate) (*models.DeviceCommand, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteDevice")
+		panic("no return value specified for CreateDeviceCommand")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
-		r0 = rf(ctx, uid, tenant)
+	var r0 *models.DeviceCommand
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandCreate) (*models.DeviceCommand, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandCreate) *models.DeviceCommand); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceCommand)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceCommandCreate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeleteNamespace provides a mock function with given fields: ctx, tenantID
-func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
-	ret := _m.Called(ctx, tenantID)
+// CreateDeviceShare provides a mock function with given fields: ctx, req
+func (_m *Service) CreateDeviceShare(ctx context.Context, req *requests.DeviceShareCreate) (*models.DeviceShare, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteNamespace")
+		panic("no return value specified for CreateDeviceShare")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, tenantID)
+	var r0 *models.DeviceShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceShareCreate) (*models.DeviceShare, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceShareCreate) *models.DeviceShare); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceShare)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceShareCreate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DeletePublicKey provides a mock function with given fields: ctx, fingerprint, tenant
-func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tenant string) error {
-	ret := _m.Called(ctx, fingerprint, tenant)
+// CreateDeviceTag provides a mock function with given fields: ctx, uid, tag
+func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
+	ret := _m.Called(ctx, uid, tag)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeletePublicKey")
+		panic("no return value specified for CreateDeviceTag")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, fingerprint, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, tag)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -606,46 +620,58 @@ func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tena
 	return r0
 }
 
-// DeleteTag provides a mock function with given fields: ctx, tenant, tag
-func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) error {
-	ret := _m.Called(ctx, tenant, tag)
+// CreateDevicesJob provides a mock function with given fields: ctx, req
+func (_m *Service) CreateDevicesJob(ctx context.Context, req *requests.DevicesJobCreate) (*models.Job, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTag")
+		panic("no return value specified for CreateDevicesJob")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, tenant, tag)
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DevicesJobCreate) (*models.Job, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DevicesJobCreate) *models.Job); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DevicesJobCreate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// EditNamespace provides a mock function with given fields: ctx, req
-func (_m *Service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit) (*models.Namespace, error) {
+// CreateEnrollToken provides a mock function with given fields: ctx, req
+func (_m *Service) CreateEnrollToken(ctx context.Context, req *requests.CreateEnrollToken) (*responses.CreateEnrollToken, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EditNamespace")
+		panic("no return value specified for CreateEnrollToken")
 	}
 
-	var r0 *models.Namespace
+	var r0 *responses.CreateEnrollToken
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceEdit) (*models.Namespace, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateEnrollToken) (*responses.CreateEnrollToken, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceEdit) *models.Namespace); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateEnrollToken) *responses.CreateEnrollToken); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Namespace)
+			r0 = ret.Get(0).(*responses.CreateEnrollToken)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceEdit) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.CreateEnrollToken) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -654,45 +680,2398 @@ func (_m *Service) EditNamespace(ctx context.Context, req *requests.NamespaceEdi
 	return r0, r1
 }
 
-// EditSessionRecordStatus provides a mock function with given fields: ctx, sessionRecord, tenantID
-func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
-	ret := _m.Called(ctx, sessionRecord, tenantID)
+// CreateGroup provides a mock function with given fields: ctx, req
+func (_m *Service) CreateGroup(ctx context.Context, req *requests.GroupCreate) (*models.Group, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EditSessionRecordStatus")
+		panic("no return value specified for CreateGroup")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
-		r0 = rf(ctx, sessionRecord, tenantID)
-	} else {
-		r0 = ret.Error(0)
+	var r0 *models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupCreate) (*models.Group, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupCreate) *models.Group); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.GroupCreate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateNamespace provides a mock function with given fields: ctx, namespace
+func (_m *Service) CreateNamespace(ctx context.Context, namespace *requests.NamespaceCreate) (*models.Namespace, error) {
+	ret := _m.Called(ctx, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNamespace")
+	}
+
+	var r0 *models.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceCreate) (*models.Namespace, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceCreate) *models.Namespace); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceCreate) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePrivateKey provides a mock function with given fields: ctx
+func (_m *Service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePrivateKey")
+	}
+
+	var r0 *models.PrivateKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.PrivateKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.PrivateKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PrivateKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePublicKey provides a mock function with given fields: ctx, req, tenant
+func (_m *Service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCreate, tenant string) (*responses.PublicKeyCreate, error) {
+	ret := _m.Called(ctx, req, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePublicKey")
+	}
+
+	var r0 *responses.PublicKeyCreate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.PublicKeyCreate, string) (*responses.PublicKeyCreate, error)); ok {
+		return rf(ctx, req, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, requests.PublicKeyCreate, string) *responses.PublicKeyCreate); ok {
+		r0 = rf(ctx, req, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.PublicKeyCreate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, requests.PublicKeyCreate, string) error); ok {
+		r1 = rf(ctx, req, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePublicURLLog provides a mock function with given fields: ctx, req
+func (_m *Service) CreatePublicURLLog(ctx context.Context, req *requests.PublicURLLogCreate) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePublicURLLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.PublicURLLogCreate) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSession provides a mock function with given fields: ctx, session
+func (_m *Service) CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error) {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSession")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.SessionCreate) (*models.Session, error)); ok {
+		return rf(ctx, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, requests.SessionCreate) *models.Session); ok {
+		r0 = rf(ctx, session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, requests.SessionCreate) error); ok {
+		r1 = rf(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTagRule provides a mock function with given fields: ctx, req
+func (_m *Service) CreateTagRule(ctx context.Context, req *requests.TagRuleCreate) (*models.TagRule, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTagRule")
+	}
+
+	var r0 *models.TagRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.TagRuleCreate) (*models.TagRule, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.TagRuleCreate) *models.TagRule); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TagRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.TagRuleCreate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserToken provides a mock function with given fields: ctx, req
+func (_m *Service) CreateUserToken(ctx context.Context, req *requests.CreateUserToken) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserToken")
+	}
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateUserToken) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CreateUserToken) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.CreateUserToken) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWebSession provides a mock function with given fields: ctx, req
+func (_m *Service) CreateWebSession(ctx context.Context, req *requests.DeviceCreateWebSession) (*models.WebSessionToken, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebSession")
+	}
+
+	var r0 *models.WebSessionToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCreateWebSession) (*models.WebSessionToken, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCreateWebSession) *models.WebSessionToken); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebSessionToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceCreateWebSession) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateSession provides a mock function with given fields: ctx, uid
+func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAPIKey provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteAPIKey(ctx context.Context, req *requests.DeleteAPIKey) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeleteAPIKey) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteBannedAddress provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteBannedAddress(ctx context.Context, req *requests.BannedAddressDelete) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBannedAddress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.BannedAddressDelete) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCommandPolicy provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteCommandPolicy(ctx context.Context, req *requests.CommandPolicyDelete) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCommandPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyDelete) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDevice provides a mock function with given fields: ctx, uid, tenant
+func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
+	ret := _m.Called(ctx, uid, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDeviceShare provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteDeviceShare(ctx context.Context, req *requests.DeviceShareDelete) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeviceShare")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceShareDelete) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEnrollToken provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteEnrollToken(ctx context.Context, req *requests.DeleteEnrollToken) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEnrollToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeleteEnrollToken) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteGroup(ctx context.Context, req *requests.GroupDelete) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupDelete) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNamespace provides a mock function with given fields: ctx, tenantID
+func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNamespace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePublicKey provides a mock function with given fields: ctx, fingerprint, tenant
+func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tenant string) error {
+	ret := _m.Called(ctx, fingerprint, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePublicKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, fingerprint, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSSHCertificateAuthority provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthorityDelete) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSSHCertificateAuthority")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateAuthorityDelete) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTag provides a mock function with given fields: ctx, tenant, tag
+func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) error {
+	ret := _m.Called(ctx, tenant, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTagRule provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteTagRule(ctx context.Context, req *requests.TagRuleDelete) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTagRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.TagRuleDelete) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserAlias provides a mock function with given fields: ctx, req
+func (_m *Service) DeleteUserAlias(ctx context.Context, req *requests.UserAliasDelete) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserAlias")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAliasDelete) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DequeueDevice provides a mock function with given fields: ctx, req
+func (_m *Service) DequeueDevice(ctx context.Context, req *requests.DeviceDequeue) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DequeueDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceDequeue) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DiffDevice provides a mock function with given fields: ctx, req
+func (_m *Service) DiffDevice(ctx context.Context, req *requests.DeviceDiff) (*models.DeviceDiff, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffDevice")
+	}
+
+	var r0 *models.DeviceDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceDiff) (*models.DeviceDiff, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceDiff) *models.DeviceDiff); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceDiff) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DispatchDeviceCommands provides a mock function with given fields: ctx, req
+func (_m *Service) DispatchDeviceCommands(ctx context.Context, req *requests.DeviceCommandsDispatch) ([]models.DeviceCommand, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DispatchDeviceCommands")
+	}
+
+	var r0 []models.DeviceCommand
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandsDispatch) ([]models.DeviceCommand, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandsDispatch) []models.DeviceCommand); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceCommand)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceCommandsDispatch) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EditNamespace provides a mock function with given fields: ctx, req
+func (_m *Service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit) (*models.Namespace, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditNamespace")
+	}
+
+	var r0 *models.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceEdit) (*models.Namespace, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceEdit) *models.Namespace); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceEdit) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EditSessionRecordStatus provides a mock function with given fields: ctx, sessionRecord, tenantID
+func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
+	ret := _m.Called(ctx, sessionRecord, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSessionRecordStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
+		r0 = rf(ctx, sessionRecord, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnqueueDevicesHeartbeat provides a mock function with given fields: ctx, req
+func (_m *Service) EnqueueDevicesHeartbeat(ctx context.Context, req *requests.DevicesHeartbeat) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueDevicesHeartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DevicesHeartbeat) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
+func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
+	ret := _m.Called(ctx, key, dev)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateKeyFilter")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, models.Device) (bool, error)); ok {
+		return rf(ctx, key, dev)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, models.Device) bool); ok {
+		r0 = rf(ctx, key, dev)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PublicKey, models.Device) error); ok {
+		r1 = rf(ctx, key, dev)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateKeyUsername provides a mock function with given fields: ctx, key, username
+func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKey, username string) (bool, error) {
+	ret := _m.Called(ctx, key, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateKeyUsername")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, string) (bool, error)); ok {
+		return rf(ctx, key, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, string) bool); ok {
+		r0 = rf(ctx, key, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PublicKey, string) error); ok {
+		r1 = rf(ctx, key, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateSessionPolicy provides a mock function with given fields: ctx, req
+func (_m *Service) EvaluateSessionPolicy(ctx context.Context, req *requests.DeviceEvaluateSessionPolicy) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateSessionPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceEvaluateSessionPolicy) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateSessionSchedule provides a mock function with given fields: ctx, req
+func (_m *Service) EvaluateSessionSchedule(ctx context.Context, req *requests.DeviceEvaluateSessionSchedule) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateSessionSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceEvaluateSessionSchedule) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateSessionWebhook provides a mock function with given fields: ctx, req
+func (_m *Service) EvaluateSessionWebhook(ctx context.Context, req *requests.DeviceEvaluateSessionWebhook) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateSessionWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceEvaluateSessionWebhook) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EventSession provides a mock function with given fields: ctx, uid, event
+func (_m *Service) EventSession(ctx context.Context, uid models.UID, event *models.SessionEvent) error {
+	ret := _m.Called(ctx, uid, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.SessionEvent) error); ok {
+		r0 = rf(ctx, uid, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportAuditEntries provides a mock function with given fields: ctx, req, fn
+func (_m *Service) ExportAuditEntries(ctx context.Context, req *requests.AuditEntriesExport, fn func(entry *models.AuditEntry) error) error {
+	ret := _m.Called(ctx, req, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportAuditEntries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.AuditEntriesExport, func(entry *models.AuditEntry) error) error); ok {
+		r0 = rf(ctx, req, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FinishDeviceCommand provides a mock function with given fields: ctx, req
+func (_m *Service) FinishDeviceCommand(ctx context.Context, req *requests.DeviceCommandFinish) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishDeviceCommand")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandFinish) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDevice provides a mock function with given fields: ctx, uid
+func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDevice")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Device, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Device); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceByPublicURLAddress provides a mock function with given fields: ctx, address
+func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceByPublicURLAddress")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Device, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Device); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceCommandPolicies provides a mock function with given fields: ctx, req
+func (_m *Service) GetDeviceCommandPolicies(ctx context.Context, req *requests.DeviceCommandPoliciesGet) ([]models.CommandPolicy, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceCommandPolicies")
+	}
+
+	var r0 []models.CommandPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandPoliciesGet) ([]models.CommandPolicy, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandPoliciesGet) []models.CommandPolicy); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CommandPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceCommandPoliciesGet) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, req
+func (_m *Service) GetJob(ctx context.Context, req *requests.JobGet) (*models.Job, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.JobGet) (*models.Job, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.JobGet) *models.Job); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.JobGet) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNamespace provides a mock function with given fields: ctx, tenantID
+func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespace")
+	}
+
+	var r0 *models.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Namespace, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNamespaceMemberActivity provides a mock function with given fields: ctx, req
+func (_m *Service) GetNamespaceMemberActivity(ctx context.Context, req *requests.NamespaceMemberActivity) (*models.MemberActivity, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespaceMemberActivity")
+	}
+
+	var r0 *models.MemberActivity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceMemberActivity) (*models.MemberActivity, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceMemberActivity) *models.MemberActivity); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MemberActivity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceMemberActivity) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicKey provides a mock function with given fields: ctx, fingerprint, tenant
+func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant string) (*models.PublicKey, error) {
+	ret := _m.Called(ctx, fingerprint, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicKey")
+	}
+
+	var r0 *models.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.PublicKey, error)); ok {
+		return rf(ctx, fingerprint, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PublicKey); ok {
+		r0 = rf(ctx, fingerprint, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, fingerprint, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicURLStats provides a mock function with given fields: ctx, req
+func (_m *Service) GetPublicURLStats(ctx context.Context, req *requests.PublicURLStatsGet) (*models.PublicURLStats, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicURLStats")
+	}
+
+	var r0 *models.PublicURLStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.PublicURLStatsGet) (*models.PublicURLStats, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.PublicURLStatsGet) *models.PublicURLStats); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicURLStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.PublicURLStatsGet) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQueryAnalytics provides a mock function with given fields: ctx
+func (_m *Service) GetQueryAnalytics(ctx context.Context) *queryanalytics.Report {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQueryAnalytics")
+	}
+
+	var r0 *queryanalytics.Report
+	if rf, ok := ret.Get(0).(func(context.Context) *queryanalytics.Report); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*queryanalytics.Report)
+		}
+	}
+
+	return r0
+}
+
+// GetSSHCertificateAuthorities provides a mock function with given fields: ctx, req
+func (_m *Service) GetSSHCertificateAuthorities(ctx context.Context, req *requests.SSHCertificateAuthoritiesGet) ([]models.SSHCertificateAuthority, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSSHCertificateAuthorities")
+	}
+
+	var r0 []models.SSHCertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateAuthoritiesGet) ([]models.SSHCertificateAuthority, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateAuthoritiesGet) []models.SSHCertificateAuthority); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SSHCertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.SSHCertificateAuthoritiesGet) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSession provides a mock function with given fields: ctx, uid
+func (_m *Service) GetSession(ctx context.Context, uid models.UID) (*models.Session, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSession")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Session, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Session); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionRecord provides a mock function with given fields: ctx, tenantID
+func (_m *Service) GetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionRecord")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionRecording provides a mock function with given fields: ctx, req
+func (_m *Service) GetSessionRecording(ctx context.Context, req *requests.SessionRecordingGet) ([]byte, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionRecording")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SessionRecordingGet) ([]byte, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SessionRecordingGet) []byte); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.SessionRecordingGet) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStats provides a mock function with given fields: ctx
+func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 *models.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.Stats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSystemInfo provides a mock function with given fields: ctx, req
+func (_m *Service) GetSystemInfo(ctx context.Context, req *requests.GetSystemInfo) (*pkgresponses.SystemInfo, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSystemInfo")
+	}
+
+	var r0 *pkgresponses.SystemInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GetSystemInfo) (*pkgresponses.SystemInfo, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GetSystemInfo) *pkgresponses.SystemInfo); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pkgresponses.SystemInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.GetSystemInfo) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTags provides a mock function with given fields: ctx, tenant
+func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []string
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tenant)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUserRole provides a mock function with given fields: ctx, tenantID, userID
+func (_m *Service) GetUserRole(ctx context.Context, tenantID string, userID string) (string, error) {
+	ret := _m.Called(ctx, tenantID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRole")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, tenantID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, tenantID, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportPublicKeys provides a mock function with given fields: ctx, req
+func (_m *Service) ImportPublicKeys(ctx context.Context, req requests.PublicKeyImport) (*responses.PublicKeyImport, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportPublicKeys")
+	}
+
+	var r0 *responses.PublicKeyImport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.PublicKeyImport) (*responses.PublicKeyImport, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, requests.PublicKeyImport) *responses.PublicKeyImport); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.PublicKeyImport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, requests.PublicKeyImport) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KeepAliveSession provides a mock function with given fields: ctx, uid
+func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KeepAliveSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LeaveNamespace provides a mock function with given fields: ctx, req
+func (_m *Service) LeaveNamespace(ctx context.Context, req *requests.LeaveNamespace) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaveNamespace")
+	}
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.LeaveNamespace) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.LeaveNamespace) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.LeaveNamespace) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAPIKeys provides a mock function with given fields: ctx, req
+func (_m *Service) ListAPIKeys(ctx context.Context, req *requests.ListAPIKey) ([]models.APIKey, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPIKeys")
+	}
+
+	var r0 []models.APIKey
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.ListAPIKey) ([]models.APIKey, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.ListAPIKey) []models.APIKey); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.ListAPIKey) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.ListAPIKey) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAuditEntries provides a mock function with given fields: ctx, req
+func (_m *Service) ListAuditEntries(ctx context.Context, req *requests.AuditEntriesList) ([]models.AuditEntry, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEntries")
+	}
+
+	var r0 []models.AuditEntry
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.AuditEntriesList) ([]models.AuditEntry, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.AuditEntriesList) []models.AuditEntry); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.AuditEntriesList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.AuditEntriesList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListBannedAddresses provides a mock function with given fields: ctx
+func (_m *Service) ListBannedAddresses(ctx context.Context) ([]models.BannedAddress, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBannedAddresses")
+	}
+
+	var r0 []models.BannedAddress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.BannedAddress, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.BannedAddress); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BannedAddress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCommandPolicies provides a mock function with given fields: ctx, req
+func (_m *Service) ListCommandPolicies(ctx context.Context, req *requests.CommandPolicyList) ([]models.CommandPolicy, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommandPolicies")
+	}
+
+	var r0 []models.CommandPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyList) ([]models.CommandPolicy, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyList) []models.CommandPolicy); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CommandPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.CommandPolicyList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCommandPolicyEvaluations provides a mock function with given fields: ctx, req
+func (_m *Service) ListCommandPolicyEvaluations(ctx context.Context, req *requests.CommandPolicyEvaluationsList) ([]models.CommandPolicyEvaluation, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCommandPolicyEvaluations")
+	}
+
+	var r0 []models.CommandPolicyEvaluation
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyEvaluationsList) ([]models.CommandPolicyEvaluation, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyEvaluationsList) []models.CommandPolicyEvaluation); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CommandPolicyEvaluation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.CommandPolicyEvaluationsList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.CommandPolicyEvaluationsList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceAgentLogs provides a mock function with given fields: ctx, req
+func (_m *Service) ListDeviceAgentLogs(ctx context.Context, req *requests.DeviceAgentLogsList) ([]models.DeviceAgentLog, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceAgentLogs")
+	}
+
+	var r0 []models.DeviceAgentLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceAgentLogsList) ([]models.DeviceAgentLog, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceAgentLogsList) []models.DeviceAgentLog); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceAgentLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceAgentLogsList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.DeviceAgentLogsList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceChanges provides a mock function with given fields: ctx, req
+func (_m *Service) ListDeviceChanges(ctx context.Context, req *requests.DeviceChangesList) (*responses.DeviceChanges, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceChanges")
+	}
+
+	var r0 *responses.DeviceChanges
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceChangesList) (*responses.DeviceChanges, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceChangesList) *responses.DeviceChanges); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.DeviceChanges)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceChangesList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeviceCommands provides a mock function with given fields: ctx, req
+func (_m *Service) ListDeviceCommands(ctx context.Context, req *requests.DeviceCommandsList) ([]models.DeviceCommand, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceCommands")
+	}
+
+	var r0 []models.DeviceCommand
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandsList) ([]models.DeviceCommand, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommandsList) []models.DeviceCommand); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceCommand)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceCommandsList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.DeviceCommandsList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceKeyIncidents provides a mock function with given fields: ctx, req
+func (_m *Service) ListDeviceKeyIncidents(ctx context.Context, req *requests.DeviceKeyIncidentList) ([]models.DeviceKeyIncident, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceKeyIncidents")
+	}
+
+	var r0 []models.DeviceKeyIncident
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceKeyIncidentList) ([]models.DeviceKeyIncident, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceKeyIncidentList) []models.DeviceKeyIncident); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceKeyIncident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceKeyIncidentList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.DeviceKeyIncidentList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceLimitExemptions provides a mock function with given fields: ctx, req
+func (_m *Service) ListDeviceLimitExemptions(ctx context.Context, req *requests.DeviceLimitExemptionsList) ([]models.DeviceLimitExemption, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceLimitExemptions")
+	}
+
+	var r0 []models.DeviceLimitExemption
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceLimitExemptionsList) ([]models.DeviceLimitExemption, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceLimitExemptionsList) []models.DeviceLimitExemption); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceLimitExemption)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceLimitExemptionsList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.DeviceLimitExemptionsList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDevicePositions provides a mock function with given fields: ctx, req
+func (_m *Service) ListDevicePositions(ctx context.Context, req *requests.DevicePositionsList) ([]models.DevicePositionCluster, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDevicePositions")
+	}
+
+	var r0 []models.DevicePositionCluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DevicePositionsList) ([]models.DevicePositionCluster, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DevicePositionsList) []models.DevicePositionCluster); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DevicePositionCluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DevicePositionsList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeviceQuarantineAttempts provides a mock function with given fields: ctx, req
+func (_m *Service) ListDeviceQuarantineAttempts(ctx context.Context, req *requests.DeviceQuarantineAttemptList) ([]models.DeviceQuarantineAttempt, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceQuarantineAttempts")
+	}
+
+	var r0 []models.DeviceQuarantineAttempt
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceQuarantineAttemptList) ([]models.DeviceQuarantineAttempt, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceQuarantineAttemptList) []models.DeviceQuarantineAttempt); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceQuarantineAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceQuarantineAttemptList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.DeviceQuarantineAttemptList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceQueue provides a mock function with given fields: ctx, req
+func (_m *Service) ListDeviceQueue(ctx context.Context, req *requests.DeviceQueueList) ([]models.Device, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceQueue")
+	}
+
+	var r0 []models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceQueueList) ([]models.Device, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceQueueList) []models.Device); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceQueueList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeviceShares provides a mock function with given fields: ctx, req
+func (_m *Service) ListDeviceShares(ctx context.Context, req *requests.DeviceShareList) ([]models.DeviceShare, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceShares")
+	}
+
+	var r0 []models.DeviceShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceShareList) ([]models.DeviceShare, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceShareList) []models.DeviceShare); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceShareList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDevices provides a mock function with given fields: ctx, req
+func (_m *Service) ListDevices(ctx context.Context, req *requests.DeviceList) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDevices")
+	}
+
+	var r0 []models.Device
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceList) ([]models.Device, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceList) []models.Device); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.DeviceList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListEnrollTokens provides a mock function with given fields: ctx, req
+func (_m *Service) ListEnrollTokens(ctx context.Context, req *requests.ListEnrollTokens) ([]models.EnrollToken, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEnrollTokens")
+	}
+
+	var r0 []models.EnrollToken
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.ListEnrollTokens) ([]models.EnrollToken, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.ListEnrollTokens) []models.EnrollToken); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.EnrollToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.ListEnrollTokens) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.ListEnrollTokens) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListGroups provides a mock function with given fields: ctx, req
+func (_m *Service) ListGroups(ctx context.Context, req *requests.GroupList) ([]models.Group, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroups")
+	}
+
+	var r0 []models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupList) ([]models.Group, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupList) []models.Group); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.GroupList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListNamespaces provides a mock function with given fields: ctx, req
+func (_m *Service) ListNamespaces(ctx context.Context, req *requests.NamespaceList) ([]models.Namespace, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNamespaces")
+	}
+
+	var r0 []models.Namespace
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceList) ([]models.Namespace, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceList) []models.Namespace); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.NamespaceList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListPublicKeys provides a mock function with given fields: ctx, paginator
+func (_m *Service) ListPublicKeys(ctx context.Context, paginator query.Paginator) ([]models.PublicKey, int, error) {
+	ret := _m.Called(ctx, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublicKeys")
+	}
+
+	var r0 []models.PublicKey
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.PublicKey, int, error)); ok {
+		return rf(ctx, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.PublicKey); ok {
+		r0 = rf(ctx, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
+		r1 = rf(ctx, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
+		r2 = rf(ctx, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListPublicURLLogs provides a mock function with given fields: ctx, req
+func (_m *Service) ListPublicURLLogs(ctx context.Context, req *requests.PublicURLLogsList) ([]models.PublicURLLog, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublicURLLogs")
+	}
+
+	var r0 []models.PublicURLLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.PublicURLLogsList) ([]models.PublicURLLog, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.PublicURLLogsList) []models.PublicURLLog); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicURLLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.PublicURLLogsList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.PublicURLLogsList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSessionRecordingAccesses provides a mock function with given fields: ctx, req
+func (_m *Service) ListSessionRecordingAccesses(ctx context.Context, req *requests.SessionRecordingAccessList) ([]models.SessionRecordingAccess, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessionRecordingAccesses")
+	}
+
+	var r0 []models.SessionRecordingAccess
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SessionRecordingAccessList) ([]models.SessionRecordingAccess, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SessionRecordingAccessList) []models.SessionRecordingAccess); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionRecordingAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.SessionRecordingAccessList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.SessionRecordingAccessList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSessionScheduleOverrides provides a mock function with given fields: ctx, req
+func (_m *Service) ListSessionScheduleOverrides(ctx context.Context, req *requests.DeviceSessionScheduleOverridesList) ([]models.SessionScheduleOverride, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessionScheduleOverrides")
+	}
+
+	var r0 []models.SessionScheduleOverride
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceSessionScheduleOverridesList) ([]models.SessionScheduleOverride, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceSessionScheduleOverridesList) []models.SessionScheduleOverride); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionScheduleOverride)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceSessionScheduleOverridesList) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.DeviceSessionScheduleOverridesList) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSessions provides a mock function with given fields: ctx, paginator
+func (_m *Service) ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []models.Session
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.Session, int, error)); ok {
+		return rf(ctx, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.Session); ok {
+		r0 = rf(ctx, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
+		r1 = rf(ctx, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
+		r2 = rf(ctx, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListTagRules provides a mock function with given fields: ctx, req
+func (_m *Service) ListTagRules(ctx context.Context, req *requests.TagRuleList) ([]models.TagRule, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTagRules")
+	}
+
+	var r0 []models.TagRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.TagRuleList) ([]models.TagRule, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.TagRuleList) []models.TagRule); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TagRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.TagRuleList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserAliases provides a mock function with given fields: ctx, req
+func (_m *Service) ListUserAliases(ctx context.Context, req *requests.UserAliasList) ([]models.UserAlias, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserAliases")
+	}
+
+	var r0 []models.UserAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAliasList) ([]models.UserAlias, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAliasList) []models.UserAlias); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserAlias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.UserAliasList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserSessions provides a mock function with given fields: ctx, req
+func (_m *Service) ListUserSessions(ctx context.Context, req *requests.UserSessionList) ([]models.UserSession, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserSessions")
+	}
+
+	var r0 []models.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserSessionList) ([]models.UserSession, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserSessionList) []models.UserSession); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.UserSessionList) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupDevice provides a mock function with given fields: ctx, namespace, name
+func (_m *Service) LookupDevice(ctx context.Context, namespace string, name string) (*models.Device, error) {
+	ret := _m.Called(ctx, namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupDevice")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
+		return rf(ctx, namespace, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
+		r0 = rf(ctx, namespace, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OfflineDevice provides a mock function with given fields: ctx, uid
+func (_m *Service) OfflineDevice(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OfflineDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OverrideSessionSchedule provides a mock function with given fields: ctx, req
+func (_m *Service) OverrideSessionSchedule(ctx context.Context, req *requests.DeviceOverrideSessionSchedule) (*models.SessionScheduleOverride, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OverrideSessionSchedule")
+	}
+
+	var r0 *models.SessionScheduleOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceOverrideSessionSchedule) (*models.SessionScheduleOverride, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceOverrideSessionSchedule) *models.SessionScheduleOverride); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionScheduleOverride)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceOverrideSessionSchedule) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
-func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
-	ret := _m.Called(ctx, key, dev)
+// PreviewConnectionAnnouncement provides a mock function with given fields: ctx, req
+func (_m *Service) PreviewConnectionAnnouncement(ctx context.Context, req *requests.NamespaceConnectionAnnouncementPreview) (*responses.ConnectionAnnouncementPreview, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EvaluateKeyFilter")
+		panic("no return value specified for PreviewConnectionAnnouncement")
 	}
 
-	var r0 bool
+	var r0 *responses.ConnectionAnnouncementPreview
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, models.Device) (bool, error)); ok {
-		return rf(ctx, key, dev)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceConnectionAnnouncementPreview) (*responses.ConnectionAnnouncementPreview, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, models.Device) bool); ok {
-		r0 = rf(ctx, key, dev)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceConnectionAnnouncementPreview) *responses.ConnectionAnnouncementPreview); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.ConnectionAnnouncementPreview)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.PublicKey, models.Device) error); ok {
-		r1 = rf(ctx, key, dev)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceConnectionAnnouncementPreview) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -700,27 +3079,29 @@ func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey,
 	return r0, r1
 }
 
-// EvaluateKeyUsername provides a mock function with given fields: ctx, key, username
-func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKey, username string) (bool, error) {
-	ret := _m.Called(ctx, key, username)
+// PreviewDeviceNameTemplate provides a mock function with given fields: ctx, req
+func (_m *Service) PreviewDeviceNameTemplate(ctx context.Context, req *requests.NamespaceDeviceNameTemplatePreview) ([]responses.DeviceNamePreview, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EvaluateKeyUsername")
+		panic("no return value specified for PreviewDeviceNameTemplate")
 	}
 
-	var r0 bool
+	var r0 []responses.DeviceNamePreview
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, string) (bool, error)); ok {
-		return rf(ctx, key, username)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceDeviceNameTemplatePreview) ([]responses.DeviceNamePreview, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, string) bool); ok {
-		r0 = rf(ctx, key, username)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceDeviceNameTemplatePreview) []responses.DeviceNamePreview); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]responses.DeviceNamePreview)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.PublicKey, string) error); ok {
-		r1 = rf(ctx, key, username)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceDeviceNameTemplatePreview) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -728,17 +3109,37 @@ func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKe
 	return r0, r1
 }
 
-// EventSession provides a mock function with given fields: ctx, uid, event
-func (_m *Service) EventSession(ctx context.Context, uid models.UID, event *models.SessionEvent) error {
-	ret := _m.Called(ctx, uid, event)
+// PublicKey provides a mock function with no fields
+func (_m *Service) PublicKey() *rsa.PublicKey {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for EventSession")
+		panic("no return value specified for PublicKey")
+	}
+
+	var r0 *rsa.PublicKey
+	if rf, ok := ret.Get(0).(func() *rsa.PublicKey); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rsa.PublicKey)
+		}
+	}
+
+	return r0
+}
+
+// PublishBannedAddresses provides a mock function with given fields: ctx
+func (_m *Service) PublishBannedAddresses(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishBannedAddresses")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.SessionEvent) error); ok {
-		r0 = rf(ctx, uid, event)
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -746,29 +3147,29 @@ func (_m *Service) EventSession(ctx context.Context, uid models.UID, event *mode
 	return r0
 }
 
-// GetDevice provides a mock function with given fields: ctx, uid
-func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
-	ret := _m.Called(ctx, uid)
+// QueueDevice provides a mock function with given fields: ctx, req
+func (_m *Service) QueueDevice(ctx context.Context, req *requests.DeviceQueue) (*models.Device, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDevice")
+		panic("no return value specified for QueueDevice")
 	}
 
 	var r0 *models.Device
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Device, error)); ok {
-		return rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceQueue) (*models.Device, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Device); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceQueue) *models.Device); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Device)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
-		r1 = rf(ctx, uid)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceQueue) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -776,59 +3177,47 @@ func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Devic
 	return r0, r1
 }
 
-// GetDeviceByPublicURLAddress provides a mock function with given fields: ctx, address
-func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
+// RecordAddressFailure provides a mock function with given fields: ctx, address
+func (_m *Service) RecordAddressFailure(ctx context.Context, address string) error {
 	ret := _m.Called(ctx, address)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDeviceByPublicURLAddress")
+		panic("no return value specified for RecordAddressFailure")
 	}
 
-	var r0 *models.Device
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Device, error)); ok {
-		return rf(ctx, address)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Device); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
 		r0 = rf(ctx, address)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, address)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetNamespace provides a mock function with given fields: ctx, tenantID
-func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
-	ret := _m.Called(ctx, tenantID)
+// RedeemWebSession provides a mock function with given fields: ctx, req
+func (_m *Service) RedeemWebSession(ctx context.Context, req *requests.WebSessionRedeem) (*models.WebSession, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNamespace")
+		panic("no return value specified for RedeemWebSession")
 	}
 
-	var r0 *models.Namespace
+	var r0 *models.WebSession
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Namespace, error)); ok {
-		return rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.WebSessionRedeem) (*models.WebSession, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
-		r0 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.WebSessionRedeem) *models.WebSession); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Namespace)
+			r0 = ret.Get(0).(*models.WebSession)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.WebSessionRedeem) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -836,29 +3225,29 @@ func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.N
 	return r0, r1
 }
 
-// GetPublicKey provides a mock function with given fields: ctx, fingerprint, tenant
-func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant string) (*models.PublicKey, error) {
-	ret := _m.Called(ctx, fingerprint, tenant)
+// RefreshUserToken provides a mock function with given fields: ctx, req
+func (_m *Service) RefreshUserToken(ctx context.Context, req *requests.RefreshUserToken) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPublicKey")
+		panic("no return value specified for RefreshUserToken")
 	}
 
-	var r0 *models.PublicKey
+	var r0 *models.UserAuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.PublicKey, error)); ok {
-		return rf(ctx, fingerprint, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.RefreshUserToken) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PublicKey); ok {
-		r0 = rf(ctx, fingerprint, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.RefreshUserToken) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PublicKey)
+			r0 = ret.Get(0).(*models.UserAuthResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, fingerprint, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.RefreshUserToken) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -866,57 +3255,65 @@ func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant
 	return r0, r1
 }
 
-// GetSession provides a mock function with given fields: ctx, uid
-func (_m *Service) GetSession(ctx context.Context, uid models.UID) (*models.Session, error) {
-	ret := _m.Called(ctx, uid)
+// RemoveDeviceTag provides a mock function with given fields: ctx, uid, tag
+func (_m *Service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag string) error {
+	ret := _m.Called(ctx, uid, tag)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSession")
+		panic("no return value specified for RemoveDeviceTag")
 	}
 
-	var r0 *models.Session
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Session, error)); ok {
-		return rf(ctx, uid)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Session); ok {
-		r0 = rf(ctx, uid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, tag)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Session)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
-		r1 = rf(ctx, uid)
+	return r0
+}
+
+// RemoveGroupDevice provides a mock function with given fields: ctx, req
+func (_m *Service) RemoveGroupDevice(ctx context.Context, req *requests.GroupDevice) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveGroupDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupDevice) error); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetSessionRecord provides a mock function with given fields: ctx, tenantID
-func (_m *Service) GetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
-	ret := _m.Called(ctx, tenantID)
+// RemoveNamespaceMember provides a mock function with given fields: ctx, req
+func (_m *Service) RemoveNamespaceMember(ctx context.Context, req *requests.NamespaceRemoveMember) (*models.Namespace, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSessionRecord")
+		panic("no return value specified for RemoveNamespaceMember")
 	}
 
-	var r0 bool
+	var r0 *models.Namespace
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceRemoveMember) (*models.Namespace, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceRemoveMember) *models.Namespace); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceRemoveMember) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -924,142 +3321,137 @@ func (_m *Service) GetSessionRecord(ctx context.Context, tenantID string) (bool,
 	return r0, r1
 }
 
-// GetStats provides a mock function with given fields: ctx
-func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
-	ret := _m.Called(ctx)
+// RemovePublicKeyTag provides a mock function with given fields: ctx, tenant, fingerprint, tag
+func (_m *Service) RemovePublicKeyTag(ctx context.Context, tenant string, fingerprint string, tag string) error {
+	ret := _m.Called(ctx, tenant, fingerprint, tag)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStats")
+		panic("no return value specified for RemovePublicKeyTag")
 	}
 
-	var r0 *models.Stats
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*models.Stats, error)); ok {
-		return rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, fingerprint, tag)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *models.Stats); ok {
-		r0 = rf(ctx)
+
+	return r0
+}
+
+// RenameDevice provides a mock function with given fields: ctx, uid, name, tenant
+func (_m *Service) RenameDevice(ctx context.Context, uid models.UID, name string, tenant string) error {
+	ret := _m.Called(ctx, uid, name, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameDevice")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string) error); ok {
+		r0 = rf(ctx, uid, name, tenant)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Stats)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenameTag provides a mock function with given fields: ctx, tenant, oldTag, newTag
+func (_m *Service) RenameTag(ctx context.Context, tenant string, oldTag string, newTag string) error {
+	ret := _m.Called(ctx, tenant, oldTag, newTag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameTag")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, oldTag, newTag)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetSystemInfo provides a mock function with given fields: ctx, req
-func (_m *Service) GetSystemInfo(ctx context.Context, req *requests.GetSystemInfo) (*pkgresponses.SystemInfo, error) {
+// ResendUserEmailVerification provides a mock function with given fields: ctx, req
+func (_m *Service) ResendUserEmailVerification(ctx context.Context, req *requests.ResendUserEmailVerification) error {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSystemInfo")
+		panic("no return value specified for ResendUserEmailVerification")
 	}
 
-	var r0 *pkgresponses.SystemInfo
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.GetSystemInfo) (*pkgresponses.SystemInfo, error)); ok {
-		return rf(ctx, req)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.GetSystemInfo) *pkgresponses.SystemInfo); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.ResendUserEmailVerification) error); ok {
 		r0 = rf(ctx, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*pkgresponses.SystemInfo)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.GetSystemInfo) error); ok {
-		r1 = rf(ctx, req)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetTags provides a mock function with given fields: ctx, tenant
-func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, error) {
-	ret := _m.Called(ctx, tenant)
+// ResolveUserAlias provides a mock function with given fields: ctx, req
+func (_m *Service) ResolveUserAlias(ctx context.Context, req *requests.UserAliasResolve) (*models.UserAlias, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTags")
+		panic("no return value specified for ResolveUserAlias")
 	}
 
-	var r0 []string
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
-		return rf(ctx, tenant)
+	var r0 *models.UserAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAliasResolve) (*models.UserAlias, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAliasResolve) *models.UserAlias); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).(*models.UserAlias)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
-		r1 = rf(ctx, tenant)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.UserAliasResolve) error); ok {
+		r1 = rf(ctx, req)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetUserRole provides a mock function with given fields: ctx, tenantID, userID
-func (_m *Service) GetUserRole(ctx context.Context, tenantID string, userID string) (string, error) {
-	ret := _m.Called(ctx, tenantID, userID)
+// RevokeUserSession provides a mock function with given fields: ctx, req
+func (_m *Service) RevokeUserSession(ctx context.Context, req *requests.UserSessionRevoke) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserRole")
-	}
-
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, tenantID, userID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, tenantID, userID)
-	} else {
-		r0 = ret.Get(0).(string)
+		panic("no return value specified for RevokeUserSession")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, tenantID, userID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserSessionRevoke) error); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// KeepAliveSession provides a mock function with given fields: ctx, uid
-func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// RevokeUserSessions provides a mock function with given fields: ctx, req
+func (_m *Service) RevokeUserSessions(ctx context.Context, req *requests.UserSessionRevokeAll) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for KeepAliveSession")
+		panic("no return value specified for RevokeUserSessions")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserSessionRevokeAll) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1067,28 +3459,28 @@ func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
-// LeaveNamespace provides a mock function with given fields: ctx, req
-func (_m *Service) LeaveNamespace(ctx context.Context, req *requests.LeaveNamespace) (*models.UserAuthResponse, error) {
+// RotateSSHCertificateAuthority provides a mock function with given fields: ctx, req
+func (_m *Service) RotateSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthorityRotate) ([]models.SSHCertificateAuthority, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LeaveNamespace")
+		panic("no return value specified for RotateSSHCertificateAuthority")
 	}
 
-	var r0 *models.UserAuthResponse
+	var r0 []models.SSHCertificateAuthority
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.LeaveNamespace) (*models.UserAuthResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateAuthorityRotate) ([]models.SSHCertificateAuthority, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.LeaveNamespace) *models.UserAuthResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateAuthorityRotate) []models.SSHCertificateAuthority); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.UserAuthResponse)
+			r0 = ret.Get(0).([]models.SSHCertificateAuthority)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.LeaveNamespace) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.SSHCertificateAuthorityRotate) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -1097,72 +3489,65 @@ func (_m *Service) LeaveNamespace(ctx context.Context, req *requests.LeaveNamesp
 	return r0, r1
 }
 
-// ListAPIKeys provides a mock function with given fields: ctx, req
-func (_m *Service) ListAPIKeys(ctx context.Context, req *requests.ListAPIKey) ([]models.APIKey, int, error) {
+// SaveUserAlias provides a mock function with given fields: ctx, req
+func (_m *Service) SaveUserAlias(ctx context.Context, req *requests.UserAliasSave) (*models.UserAlias, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAPIKeys")
+		panic("no return value specified for SaveUserAlias")
 	}
 
-	var r0 []models.APIKey
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.ListAPIKey) ([]models.APIKey, int, error)); ok {
+	var r0 *models.UserAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAliasSave) (*models.UserAlias, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.ListAPIKey) []models.APIKey); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAliasSave) *models.UserAlias); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.APIKey)
+			r0 = ret.Get(0).(*models.UserAlias)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.ListAPIKey) int); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.UserAliasSave) error); ok {
 		r1 = rf(ctx, req)
 	} else {
-		r1 = ret.Get(1).(int)
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, *requests.ListAPIKey) error); ok {
-		r2 = rf(ctx, req)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// ListDevices provides a mock function with given fields: ctx, req
-func (_m *Service) ListDevices(ctx context.Context, req *requests.DeviceList) ([]models.Device, int, error) {
+// SearchUserDevices provides a mock function with given fields: ctx, req
+func (_m *Service) SearchUserDevices(ctx context.Context, req *requests.UserDeviceSearch) ([]responses.UserDevice, int, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListDevices")
+		panic("no return value specified for SearchUserDevices")
 	}
 
-	var r0 []models.Device
+	var r0 []responses.UserDevice
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceList) ([]models.Device, int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserDeviceSearch) ([]responses.UserDevice, int, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceList) []models.Device); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserDeviceSearch) []responses.UserDevice); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Device)
+			r0 = ret.Get(0).([]responses.UserDevice)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceList) int); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.UserDeviceSearch) int); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, *requests.DeviceList) error); ok {
+	if rf, ok := ret.Get(2).(func(context.Context, *requests.UserDeviceSearch) error); ok {
 		r2 = rf(ctx, req)
 	} else {
 		r2 = ret.Error(2)
@@ -1171,140 +3556,181 @@ func (_m *Service) ListDevices(ctx context.Context, req *requests.DeviceList) ([
 	return r0, r1, r2
 }
 
-// ListNamespaces provides a mock function with given fields: ctx, req
-func (_m *Service) ListNamespaces(ctx context.Context, req *requests.NamespaceList) ([]models.Namespace, int, error) {
+// SetSSHCertificateAuthority provides a mock function with given fields: ctx, req
+func (_m *Service) SetSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthoritySet) ([]models.SSHCertificateAuthority, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListNamespaces")
+		panic("no return value specified for SetSSHCertificateAuthority")
 	}
 
-	var r0 []models.Namespace
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceList) ([]models.Namespace, int, error)); ok {
+	var r0 []models.SSHCertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateAuthoritySet) ([]models.SSHCertificateAuthority, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceList) []models.Namespace); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateAuthoritySet) []models.SSHCertificateAuthority); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Namespace)
+			r0 = ret.Get(0).([]models.SSHCertificateAuthority)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceList) int); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.SSHCertificateAuthoritySet) error); ok {
 		r1 = rf(ctx, req)
 	} else {
-		r1 = ret.Get(1).(int)
+		r1 = ret.Error(1)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, *requests.NamespaceList) error); ok {
-		r2 = rf(ctx, req)
+	return r0, r1
+}
+
+// Setup provides a mock function with given fields: ctx, req
+func (_m *Service) Setup(ctx context.Context, req requests.Setup) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Setup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.Setup) error); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// ListPublicKeys provides a mock function with given fields: ctx, paginator
-func (_m *Service) ListPublicKeys(ctx context.Context, paginator query.Paginator) ([]models.PublicKey, int, error) {
-	ret := _m.Called(ctx, paginator)
+// SetupVerify provides a mock function with given fields: ctx, sign
+func (_m *Service) SetupVerify(ctx context.Context, sign string) error {
+	ret := _m.Called(ctx, sign)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPublicKeys")
+		panic("no return value specified for SetupVerify")
 	}
 
-	var r0 []models.PublicKey
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.PublicKey, int, error)); ok {
-		return rf(ctx, paginator)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.PublicKey); ok {
-		r0 = rf(ctx, paginator)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, sign)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PublicKey)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
-		r1 = rf(ctx, paginator)
+	return r0
+}
+
+// SubscribeDeviceEvents provides a mock function with given fields: ctx, req
+func (_m *Service) SubscribeDeviceEvents(ctx context.Context, req *requests.DeviceEventsSubscribe) (<-chan models.DeviceEvent, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeDeviceEvents")
+	}
+
+	var r0 <-chan models.DeviceEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceEventsSubscribe) (<-chan models.DeviceEvent, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceEventsSubscribe) <-chan models.DeviceEvent); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r1 = ret.Get(1).(int)
+		r0 = ret.Get(0).(<-chan models.DeviceEvent)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
-		r2 = rf(ctx, paginator)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceEventsSubscribe) error); ok {
+		r1 = rf(ctx, req)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// ListSessions provides a mock function with given fields: ctx, paginator
-func (_m *Service) ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, paginator)
+// SubscribeNamespaceEvents provides a mock function with given fields: ctx, req
+func (_m *Service) SubscribeNamespaceEvents(ctx context.Context, req *requests.NamespaceEventsSubscribe) (<-chan models.NamespaceEvent, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListSessions")
+		panic("no return value specified for SubscribeNamespaceEvents")
 	}
 
-	var r0 []models.Session
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.Session, int, error)); ok {
-		return rf(ctx, paginator)
+	var r0 <-chan models.NamespaceEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceEventsSubscribe) (<-chan models.NamespaceEvent, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.Session); ok {
-		r0 = rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceEventsSubscribe) <-chan models.NamespaceEvent); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Session)
+			r0 = ret.Get(0).(<-chan models.NamespaceEvent)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
-		r1 = rf(ctx, paginator)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceEventsSubscribe) error); ok {
+		r1 = rf(ctx, req)
 	} else {
-		r1 = ret.Get(1).(int)
+		r1 = ret.Error(1)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
-		r2 = rf(ctx, paginator)
+	return r0, r1
+}
+
+// SystemDownloadInstallScript provides a mock function with given fields: ctx
+func (_m *Service) SystemDownloadInstallScript(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SystemDownloadInstallScript")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// LookupDevice provides a mock function with given fields: ctx, namespace, name
-func (_m *Service) LookupDevice(ctx context.Context, namespace string, name string) (*models.Device, error) {
-	ret := _m.Called(ctx, namespace, name)
+// TestPublicKey provides a mock function with given fields: ctx, req
+func (_m *Service) TestPublicKey(ctx context.Context, req *requests.PublicKeyTest) (*responses.PublicKeyTest, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LookupDevice")
+		panic("no return value specified for TestPublicKey")
 	}
 
-	var r0 *models.Device
+	var r0 *responses.PublicKeyTest
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
-		return rf(ctx, namespace, name)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.PublicKeyTest) (*responses.PublicKeyTest, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
-		r0 = rf(ctx, namespace, name)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.PublicKeyTest) *responses.PublicKeyTest); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).(*responses.PublicKeyTest)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, name)
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.PublicKeyTest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1312,17 +3738,17 @@ func (_m *Service) LookupDevice(ctx context.Context, namespace string, name stri
 	return r0, r1
 }
 
-// OfflineDevice provides a mock function with given fields: ctx, uid
-func (_m *Service) OfflineDevice(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// UpdateAPIKey provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateAPIKey(ctx context.Context, req *requests.UpdateAPIKey) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for OfflineDevice")
+		panic("no return value specified for UpdateAPIKey")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.UpdateAPIKey) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1330,37 +3756,47 @@ func (_m *Service) OfflineDevice(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
-// PublicKey provides a mock function with no fields
-func (_m *Service) PublicKey() *rsa.PublicKey {
-	ret := _m.Called()
+// UpdateCommandPolicy provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateCommandPolicy(ctx context.Context, req *requests.CommandPolicyUpdate) (*models.CommandPolicy, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PublicKey")
+		panic("no return value specified for UpdateCommandPolicy")
 	}
 
-	var r0 *rsa.PublicKey
-	if rf, ok := ret.Get(0).(func() *rsa.PublicKey); ok {
-		r0 = rf()
+	var r0 *models.CommandPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyUpdate) (*models.CommandPolicy, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.CommandPolicyUpdate) *models.CommandPolicy); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*rsa.PublicKey)
+			r0 = ret.Get(0).(*models.CommandPolicy)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.CommandPolicyUpdate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// RemoveDeviceTag provides a mock function with given fields: ctx, uid, tag
-func (_m *Service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag string) error {
-	ret := _m.Called(ctx, uid, tag)
+// UpdateDevice provides a mock function with given fields: ctx, tenant, uid, name, publicURL
+func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
+	ret := _m.Called(ctx, tenant, uid, name, publicURL)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveDeviceTag")
+		panic("no return value specified for UpdateDevice")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
-		r0 = rf(ctx, uid, tag)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, *string, *bool) error); ok {
+		r0 = rf(ctx, tenant, uid, name, publicURL)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1368,28 +3804,28 @@ func (_m *Service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag stri
 	return r0
 }
 
-// RemoveNamespaceMember provides a mock function with given fields: ctx, req
-func (_m *Service) RemoveNamespaceMember(ctx context.Context, req *requests.NamespaceRemoveMember) (*models.Namespace, error) {
+// UpdateDeviceConfig provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateDeviceConfig(ctx context.Context, req *requests.DeviceUpdateConfig) (*models.DeviceConfig, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveNamespaceMember")
+		panic("no return value specified for UpdateDeviceConfig")
 	}
 
-	var r0 *models.Namespace
+	var r0 *models.DeviceConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceRemoveMember) (*models.Namespace, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceUpdateConfig) (*models.DeviceConfig, error)); ok {
 		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceRemoveMember) *models.Namespace); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceUpdateConfig) *models.DeviceConfig); ok {
 		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Namespace)
+			r0 = ret.Get(0).(*models.DeviceConfig)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceRemoveMember) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceUpdateConfig) error); ok {
 		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
@@ -1398,17 +3834,17 @@ func (_m *Service) RemoveNamespaceMember(ctx context.Context, req *requests.Name
 	return r0, r1
 }
 
-// RemovePublicKeyTag provides a mock function with given fields: ctx, tenant, fingerprint, tag
-func (_m *Service) RemovePublicKeyTag(ctx context.Context, tenant string, fingerprint string, tag string) error {
-	ret := _m.Called(ctx, tenant, fingerprint, tag)
+// UpdateDeviceConnectionNote provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateDeviceConnectionNote(ctx context.Context, req *requests.DeviceUpdateConnectionNote) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemovePublicKeyTag")
+		panic("no return value specified for UpdateDeviceConnectionNote")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, tenant, fingerprint, tag)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceUpdateConnectionNote) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1416,17 +3852,17 @@ func (_m *Service) RemovePublicKeyTag(ctx context.Context, tenant string, finger
 	return r0
 }
 
-// RenameDevice provides a mock function with given fields: ctx, uid, name, tenant
-func (_m *Service) RenameDevice(ctx context.Context, uid models.UID, name string, tenant string) error {
-	ret := _m.Called(ctx, uid, name, tenant)
+// UpdateDeviceKeyIncident provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateDeviceKeyIncident(ctx context.Context, req *requests.DeviceKeyIncidentUpdate) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RenameDevice")
+		panic("no return value specified for UpdateDeviceKeyIncident")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string) error); ok {
-		r0 = rf(ctx, uid, name, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceKeyIncidentUpdate) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1434,17 +3870,17 @@ func (_m *Service) RenameDevice(ctx context.Context, uid models.UID, name string
 	return r0
 }
 
-// RenameTag provides a mock function with given fields: ctx, tenant, oldTag, newTag
-func (_m *Service) RenameTag(ctx context.Context, tenant string, oldTag string, newTag string) error {
-	ret := _m.Called(ctx, tenant, oldTag, newTag)
+// UpdateDeviceLimitExemption provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateDeviceLimitExemption(ctx context.Context, req *requests.DeviceUpdateLimitExemption) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RenameTag")
+		panic("no return value specified for UpdateDeviceLimitExemption")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, tenant, oldTag, newTag)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceUpdateLimitExemption) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1452,16 +3888,16 @@ func (_m *Service) RenameTag(ctx context.Context, tenant string, oldTag string,
 	return r0
 }
 
-// Setup provides a mock function with given fields: ctx, req
-func (_m *Service) Setup(ctx context.Context, req requests.Setup) error {
+// UpdateDeviceLoginShell provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateDeviceLoginShell(ctx context.Context, req *requests.DeviceUpdateLoginShell) error {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Setup")
+		panic("no return value specified for UpdateDeviceLoginShell")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, requests.Setup) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceUpdateLoginShell) error); ok {
 		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
@@ -1470,17 +3906,17 @@ func (_m *Service) Setup(ctx context.Context, req requests.Setup) error {
 	return r0
 }
 
-// SetupVerify provides a mock function with given fields: ctx, sign
-func (_m *Service) SetupVerify(ctx context.Context, sign string) error {
-	ret := _m.Called(ctx, sign)
+// UpdateDeviceRemoteAccess provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateDeviceRemoteAccess(ctx context.Context, req *requests.DeviceUpdateRemoteAccess) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetupVerify")
+		panic("no return value specified for UpdateDeviceRemoteAccess")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, sign)
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceUpdateRemoteAccess) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1488,45 +3924,17 @@ func (_m *Service) SetupVerify(ctx context.Context, sign string) error {
 	return r0
 }
 
-// SystemDownloadInstallScript provides a mock function with given fields: ctx
-func (_m *Service) SystemDownloadInstallScript(ctx context.Context) (string, error) {
-	ret := _m.Called(ctx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for SystemDownloadInstallScript")
-	}
-
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
-		r0 = rf(ctx)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// UpdateAPIKey provides a mock function with given fields: ctx, req
-func (_m *Service) UpdateAPIKey(ctx context.Context, req *requests.UpdateAPIKey) error {
-	ret := _m.Called(ctx, req)
+// UpdateDeviceStatus provides a mock function with given fields: ctx, tenant, uid, status
+func (_m *Service) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
+	ret := _m.Called(ctx, tenant, uid, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAPIKey")
+		panic("no return value specified for UpdateDeviceStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *requests.UpdateAPIKey) error); ok {
-		r0 = rf(ctx, req)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, models.DeviceStatus) error); ok {
+		r0 = rf(ctx, tenant, uid, status)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1534,17 +3942,17 @@ func (_m *Service) UpdateAPIKey(ctx context.Context, req *requests.UpdateAPIKey)
 	return r0
 }
 
-// UpdateDevice provides a mock function with given fields: ctx, tenant, uid, name, publicURL
-func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
-	ret := _m.Called(ctx, tenant, uid, name, publicURL)
+// UpdateDeviceTag provides a mock function with given fields: ctx, uid, tags
+func (_m *Service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []string) error {
+	ret := _m.Called(ctx, uid, tags)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateDevice")
+		panic("no return value specified for UpdateDeviceTag")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, *string, *bool) error); ok {
-		r0 = rf(ctx, tenant, uid, name, publicURL)
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []string) error); ok {
+		r0 = rf(ctx, uid, tags)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1552,40 +3960,64 @@ func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.U
 	return r0
 }
 
-// UpdateDeviceStatus provides a mock function with given fields: ctx, tenant, uid, status
-func (_m *Service) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
-	ret := _m.Called(ctx, tenant, uid, status)
+// UpdateGroup provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateGroup(ctx context.Context, req *requests.GroupUpdate) (*models.Group, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateDeviceStatus")
+		panic("no return value specified for UpdateGroup")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, models.DeviceStatus) error); ok {
-		r0 = rf(ctx, tenant, uid, status)
+	var r0 *models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupUpdate) (*models.Group, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.GroupUpdate) *models.Group); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Group)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.GroupUpdate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UpdateDeviceTag provides a mock function with given fields: ctx, uid, tags
-func (_m *Service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []string) error {
-	ret := _m.Called(ctx, uid, tags)
+// UpdateNamespaceDeviceLimits provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateNamespaceDeviceLimits(ctx context.Context, req *requests.NamespaceDeviceLimits) (*models.Namespace, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateDeviceTag")
+		panic("no return value specified for UpdateNamespaceDeviceLimits")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []string) error); ok {
-		r0 = rf(ctx, uid, tags)
+	var r0 *models.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceDeviceLimits) (*models.Namespace, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.NamespaceDeviceLimits) *models.Namespace); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.NamespaceDeviceLimits) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UpdateNamespaceMember provides a mock function with given fields: ctx, req
@@ -1690,6 +4122,36 @@ func (_m *Service) UpdateSession(ctx context.Context, uid models.UID, model mode
 	return r0
 }
 
+// UpdateTagRule provides a mock function with given fields: ctx, req
+func (_m *Service) UpdateTagRule(ctx context.Context, req *requests.TagRuleUpdate) (*models.TagRule, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTagRule")
+	}
+
+	var r0 *models.TagRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.TagRuleUpdate) (*models.TagRule, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.TagRuleUpdate) *models.TagRule); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TagRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.TagRuleUpdate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUser provides a mock function with given fields: ctx, req
 func (_m *Service) UpdateUser(ctx context.Context, req *requests.UpdateUser) ([]string, error) {
 	ret := _m.Called(ctx, req)
@@ -1720,6 +4182,42 @@ func (_m *Service) UpdateUser(ctx context.Context, req *requests.UpdateUser) ([]
 	return r0, r1
 }
 
+// VerifySessionAttestation provides a mock function with given fields: ctx, req
+func (_m *Service) VerifySessionAttestation(ctx context.Context, req *requests.SessionAttestationVerify) bool {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySessionAttestation")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SessionAttestationVerify) bool); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// VerifyUserEmail provides a mock function with given fields: ctx, req
+func (_m *Service) VerifyUserEmail(ctx context.Context, req *requests.VerifyUserEmail) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyUserEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.VerifyUserEmail) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
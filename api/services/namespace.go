@@ -3,9 +3,11 @@ package services
 import (
 	"context"
 	"errors"
+	"strconv"
 	"strings"
 
 	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/announcement"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -25,27 +27,9 @@ type NamespaceService interface {
 
 // CreateNamespace creates a new namespace.
 func (s *service) CreateNamespace(ctx context.Context, req *requests.NamespaceCreate) (*models.Namespace, error) {
-	user, _, err := s.store.UserGetByID(ctx, req.UserID, false)
-	if err != nil || user == nil {
-		return nil, NewErrUserNotFound(req.UserID, err)
-	}
-
-	// When MaxNamespaces is less than zero, it means that the user has no limit
-	// of namespaces. If the value is zero, it means he has no right to create a new namespace
-	if user.MaxNamespaces == 0 {
-		return nil, NewErrNamespaceCreationIsForbidden(user.MaxNamespaces, nil)
-	} else if user.MaxNamespaces > 0 {
-		info, err := s.store.UserGetInfo(ctx, req.UserID)
-		switch {
-		case err != nil:
-			return nil, err
-		case len(info.OwnedNamespaces) >= user.MaxNamespaces:
-			return nil, NewErrNamespaceLimitReached(user.MaxNamespaces, nil)
-		}
-	}
-
-	if dup, err := s.store.NamespaceGetByName(ctx, strings.ToLower(req.Name)); dup != nil || (err != nil && err != store.ErrNoDocuments) {
-		return nil, NewErrNamespaceDuplicated(err)
+	user, err := s.checkNamespaceCreation(ctx, req.UserID, req.Name)
+	if err != nil {
+		return nil, err
 	}
 
 	ns := &models.Namespace{
@@ -81,14 +65,7 @@ func (s *service) CreateNamespace(ctx context.Context, req *requests.NamespaceCr
 		ns.TenantID = uuid.Generate()
 	}
 
-	// Set limits according to ShellHub instance type
-	if envs.IsCloud() {
-		// cloud free plan is limited only by the max of devices
-		ns.MaxDevices = 3
-	} else {
-		// we don't set limits on enterprise and community instances
-		ns.MaxDevices = -1
-	}
+	ns.MaxDevices = namespaceMaxDevices()
 
 	if _, err := s.store.NamespaceCreate(ctx, ns); err != nil {
 		return nil, NewErrNamespaceCreateStore(err)
@@ -97,6 +74,45 @@ func (s *service) CreateNamespace(ctx context.Context, req *requests.NamespaceCr
 	return ns, nil
 }
 
+// checkNamespaceCreation checks if the user can create one more namespace, named name, returning the user.
+func (s *service) checkNamespaceCreation(ctx context.Context, userID, name string) (*models.User, error) {
+	user, _, err := s.store.UserGetByID(ctx, userID, false)
+	if err != nil || user == nil {
+		return nil, NewErrUserNotFound(userID, err)
+	}
+
+	// When MaxNamespaces is less than zero, it means that the user has no limit
+	// of namespaces. If the value is zero, it means he has no right to create a new namespace
+	if user.MaxNamespaces == 0 {
+		return nil, NewErrNamespaceCreationIsForbidden(user.MaxNamespaces, nil)
+	} else if user.MaxNamespaces > 0 {
+		info, err := s.store.UserGetInfo(ctx, userID)
+		switch {
+		case err != nil:
+			return nil, err
+		case len(info.OwnedNamespaces) >= user.MaxNamespaces:
+			return nil, NewErrNamespaceLimitReached(user.MaxNamespaces, nil)
+		}
+	}
+
+	if dup, err := s.store.NamespaceGetByName(ctx, strings.ToLower(name)); dup != nil || (err != nil && err != store.ErrNoDocuments) {
+		return nil, NewErrNamespaceDuplicated(err)
+	}
+
+	return user, nil
+}
+
+// namespaceMaxDevices returns the maximum number of devices of a new namespace, according to the instance's type.
+func namespaceMaxDevices() int {
+	// cloud free plan is limited only by the max of devices
+	if envs.IsCloud() {
+		return 3
+	}
+
+	// we don't set limits on enterprise and community instances
+	return -1
+}
+
 func (s *service) ListNamespaces(ctx context.Context, req *requests.NamespaceList) ([]models.Namespace, int, error) {
 	namespaces, count, err := s.store.NamespaceList(ctx, req.Paginator, req.Filters, s.store.Options().CountAcceptedDevices(), s.store.Options().EnrichMembersData())
 	if err != nil {
@@ -117,6 +133,9 @@ func (s *service) GetNamespace(ctx context.Context, tenantID string) (*models.Na
 		return nil, NewErrNamespaceNotFound(tenantID, err)
 	}
 
+	namespace.MaxMembers, namespace.MaxInvitations = s.memberQuota(namespace)
+	namespace.MembersCount, namespace.InvitationsCount = namespace.CountMembers(clock.Now())
+
 	return namespace, nil
 }
 
@@ -142,7 +161,12 @@ func (s *service) DeleteNamespace(ctx context.Context, tenantID string) error {
 		}
 	}
 
-	return s.store.NamespaceDelete(ctx, tenantID)
+	if err := s.store.NamespaceDelete(ctx, tenantID); err != nil {
+		return err
+	}
+
+	// NOTICE: the namespace's audit trail is kept after it is deleted.
+	return s.recordAudit(ctx, tenantID, models.AuditActionNamespaceDelete, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}, map[string]string{"name": ns.Name})
 }
 
 func (s *service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit) (*models.Namespace, error) {
@@ -150,6 +174,52 @@ func (s *service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit
 		Name:                   strings.ToLower(req.Name),
 		SessionRecord:          req.Settings.SessionRecord,
 		ConnectionAnnouncement: req.Settings.ConnectionAnnouncement,
+		DeviceKeyPinning:       req.Settings.DeviceKeyPinning,
+		WebSessionMaxDuration:  req.Settings.WebSessionMaxDuration,
+		DeviceGeoAlert:         req.Settings.DeviceGeoAlert,
+		DeviceNameTemplate:     req.Settings.DeviceNameTemplate,
+		RecordWatermark:        req.Settings.RecordWatermark,
+		DefaultTags:            req.Settings.DefaultTags,
+		SessionKeepAlive:       req.Settings.SessionKeepAlive,
+		SessionSchedules:       req.Settings.SessionSchedules,
+		DeviceQuarantine:       req.Settings.DeviceQuarantine,
+		SessionRecordPause:     req.Settings.SessionRecordPause,
+		SessionAttestation:     req.Settings.SessionAttestation,
+		MaxSessions:            req.Settings.MaxSessions,
+		ConnectionWebhook:      req.Settings.ConnectionWebhook,
+		UserProvisioning:       req.Settings.UserProvisioning,
+	}
+
+	if req.Settings.ConnectionAnnouncement != nil {
+		if err := announcement.Validate(*req.Settings.ConnectionAnnouncement); err != nil {
+			return nil, NewErrNamespaceConnectionAnnouncementInvalid(err)
+		}
+	}
+
+	if req.Settings.DeviceNameTemplate != nil && *req.Settings.DeviceNameTemplate != "" {
+		if _, err := parseDeviceNameTemplate(*req.Settings.DeviceNameTemplate); err != nil {
+			return nil, NewErrNamespaceDeviceNameTemplateInvalid(err)
+		}
+	}
+
+	if req.Settings.SessionSchedules != nil {
+		for _, schedule := range *req.Settings.SessionSchedules {
+			if err := schedule.Validate(); err != nil {
+				return nil, NewErrNamespaceSessionScheduleInvalid(err)
+			}
+		}
+	}
+
+	if req.Settings.ConnectionWebhook != nil {
+		if err := req.Settings.ConnectionWebhook.Validate(); err != nil {
+			return nil, NewErrNamespaceConnectionWebhookInvalid(err)
+		}
+	}
+
+	if req.Settings.UserProvisioning != nil {
+		if err := req.Settings.UserProvisioning.Validate(); err != nil {
+			return nil, NewErrNamespaceUserProvisioningInvalid(err)
+		}
 	}
 
 	if err := s.store.NamespaceEdit(ctx, req.Tenant, changes); err != nil {
@@ -161,6 +231,15 @@ func (s *service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit
 		}
 	}
 
+	var details map[string]string
+	if changes.Name != "" {
+		details = map[string]string{"name": changes.Name}
+	}
+
+	if err := s.recordAudit(ctx, req.Tenant, models.AuditActionNamespaceUpdate, models.AuditTarget{Type: models.AuditTargetNamespace, ID: req.Tenant}, details); err != nil {
+		return nil, err
+	}
+
 	return s.store.NamespaceGet(ctx, req.Tenant, s.store.Options().CountAcceptedDevices(), s.store.Options().EnrichMembersData())
 }
 
@@ -169,7 +248,11 @@ func (s *service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit
 // It receives a context, used to "control" the request flow, a boolean to define if the sessions will be recorded and
 // the tenant ID from models.Namespace.
 func (s *service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
-	return s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID)
+	if err := s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID); err != nil {
+		return err
+	}
+
+	return s.recordAudit(ctx, tenantID, models.AuditActionNamespaceSessionRecordUpdate, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}, map[string]string{"session_record": strconv.FormatBool(sessionRecord)})
 }
 
 // GetSessionRecord gets the session record data.
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+type NamespaceCloneService interface {
+	// CloneNamespace creates a namespace, owned by the user, with the configuration of the namespace the user is
+	// authenticated on: its settings, its members' roles, its tag rules and, optionally, its public keys. The devices,
+	// sessions and the namespace's history aren't copied.
+	//
+	// In cloud environments, the members are invited to the namespace created, with their roles, and only enter it
+	// when they accept the invite. In community and enterprise environments, where the invites can't be accepted, the
+	// members aren't copied.
+	CloneNamespace(ctx context.Context, req *requests.NamespaceClone) (*models.Namespace, error)
+}
+
+func (s *service) CloneNamespace(ctx context.Context, req *requests.NamespaceClone) (*models.Namespace, error) {
+	if req.Tenant != req.TenantID {
+		return nil, NewErrNamespaceNotFound(req.Tenant, nil)
+	}
+
+	source, err := s.store.NamespaceGet(ctx, req.Tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.Tenant, err)
+	}
+
+	user, err := s.checkNamespaceCreation(ctx, req.UserID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := &models.Namespace{
+		Name:       strings.ToLower(req.Name),
+		Owner:      user.ID,
+		Members:    cloneMembers(source.Members, user.ID, envs.IsCloud()),
+		Settings:   cloneSettings(source.Settings),
+		TenantID:   req.Clone,
+		Type:       source.Type,
+		MaxDevices: namespaceMaxDevices(),
+	}
+
+	if ns.TenantID == "" {
+		ns.TenantID = uuid.Generate()
+	}
+
+	maxMembers, maxInvitations := s.memberQuota(ns)
+	members, invitations := ns.CountMembers(clock.Now())
+
+	if maxMembers > 0 && members > maxMembers {
+		return nil, NewErrNamespaceMembersLimit(maxMembers, nil)
+	}
+
+	if maxInvitations > 0 && invitations > maxInvitations {
+		return nil, NewErrNamespaceInvitationsLimit(maxInvitations, nil)
+	}
+
+	rules, err := s.store.TagRuleList(ctx, source.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []models.PublicKey
+	if req.PublicKeys {
+		if keys, err = s.listPublicKeys(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.store.WithTransaction(ctx, s.cloneNamespace(ns, rules, keys, req.FowardedHost)); err != nil {
+		return nil, NewErrNamespaceCreateStore(err)
+	}
+
+	return ns, nil
+}
+
+// cloneNamespace returns a transaction callback that creates the namespace with copies of the tag rules and of the
+// public keys, sending the invites to its pending members.
+func (s *service) cloneNamespace(ns *models.Namespace, rules []models.TagRule, keys []models.PublicKey, host string) store.TransactionCb {
+	return func(ctx context.Context) error {
+		if _, err := s.store.NamespaceCreate(ctx, ns); err != nil {
+			return err
+		}
+
+		now := clock.Now()
+
+		for _, rule := range rules {
+			rule.ID = uuid.Generate()
+			rule.TenantID = ns.TenantID
+			rule.CreatedAt = now
+			rule.UpdatedAt = now
+
+			if err := s.store.TagRuleCreate(ctx, &rule); err != nil {
+				return err
+			}
+		}
+
+		for _, key := range keys {
+			key.TenantID = ns.TenantID
+			key.CreatedAt = now
+
+			if err := s.store.PublicKeyCreate(ctx, &key); err != nil {
+				return err
+			}
+		}
+
+		for _, member := range ns.Members {
+			if member.Status != models.MemberStatusPending {
+				continue
+			}
+
+			if err := s.client.InviteMember(ctx, ns.TenantID, member.ID, host); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// listPublicKeys lists all the public keys of the namespace the user is authenticated on.
+func (s *service) listPublicKeys(ctx context.Context) ([]models.PublicKey, error) {
+	paginator := query.Paginator{Page: query.MinPage, PerPage: query.MaxPerPage}
+
+	keys := []models.PublicKey{}
+	for {
+		page, count, err := s.store.PublicKeyList(ctx, paginator)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, page...)
+		if len(page) == 0 || len(keys) >= count {
+			return keys, nil
+		}
+
+		paginator.Page++
+	}
+}
+
+// cloneMembers returns the members of a namespace owned by owner. When invite is true, the accepted members of the
+// namespace are invited to it, with their roles, as pending members; the previous owner is invited as an
+// administrator, and the pending invitations are dropped.
+func cloneMembers(members []models.Member, owner string, invite bool) []models.Member {
+	now := clock.Now()
+
+	cloned := []models.Member{{ID: owner, Role: authorizer.RoleOwner, Status: models.MemberStatusAccepted, AddedAt: now}}
+	if !invite {
+		return cloned
+	}
+
+	for _, member := range members {
+		if member.ID == owner || member.Status != models.MemberStatusAccepted {
+			continue
+		}
+
+		role := member.Role
+		if role == authorizer.RoleOwner {
+			role = authorizer.RoleAdministrator
+		}
+
+		cloned = append(cloned, models.Member{
+			ID:        member.ID,
+			Role:      role,
+			Status:    models.MemberStatusPending,
+			AddedAt:   now,
+			ExpiresAt: now.Add(7 * (24 * time.Hour)),
+		})
+	}
+
+	return cloned
+}
+
+// cloneSettings returns a copy of the namespace's settings that doesn't share their lists, nor their webhook and
+// user provisioning.
+func cloneSettings(settings *models.NamespaceSettings) *models.NamespaceSettings {
+	if settings == nil {
+		return &models.NamespaceSettings{SessionRecord: true}
+	}
+
+	cloned := *settings
+	cloned.DefaultTags = slices.Clone(settings.DefaultTags)
+	cloned.SessionSchedules = slices.Clone(settings.SessionSchedules)
+	cloned.SSHCertificateAuthorities = slices.Clone(settings.SSHCertificateAuthorities)
+
+	if settings.ConnectionWebhook != nil {
+		webhook := *settings.ConnectionWebhook
+		cloned.ConnectionWebhook = &webhook
+	}
+
+	if settings.UserProvisioning != nil {
+		provisioning := *settings.UserProvisioning
+		provisioning.Groups = slices.Clone(settings.UserProvisioning.Groups)
+		cloned.UserProvisioning = &provisioning
+	}
+
+	return &cloned
+}
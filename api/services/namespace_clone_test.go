@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloneNamespace(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000002")
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	source := &models.Namespace{
+		Name:     "template",
+		Owner:    "000000000000000000000000",
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Members: []models.Member{
+			{ID: "000000000000000000000000", Role: authorizer.RoleOwner, Status: models.MemberStatusAccepted},
+			{ID: "000000000000000000000001", Role: authorizer.RoleAdministrator, Status: models.MemberStatusAccepted},
+			{ID: "000000000000000000000002", Role: authorizer.RoleOperator, Status: models.MemberStatusAccepted},
+			{ID: "000000000000000000000003", Role: authorizer.RoleObserver, Status: models.MemberStatusPending},
+		},
+		Settings: &models.NamespaceSettings{SessionRecord: true, DeviceKeyPinning: true, DefaultTags: []string{"managed"}},
+		Type:     models.TypeTeam,
+	}
+
+	rule := models.TagRule{
+		ID:         "00000000-0000-4000-0000-000000000003",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		Name:       "linux",
+		Tag:        "linux",
+		Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributeOS, Operator: models.TagRuleOperatorEqual, Value: "linux"}},
+	}
+
+	key := models.PublicKey{
+		Data:        []byte("ssh-ed25519 AAAA"),
+		Fingerprint: "fingerprint",
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		PublicKeyFields: models.PublicKeyFields{
+			Name:     "ops",
+			Username: ".*",
+			Filter:   models.PublicKeyFilter{Tags: []string{"linux"}},
+		},
+	}
+
+	cloned := &models.Namespace{
+		Name:     "customer",
+		Owner:    "000000000000000000000001",
+		TenantID: "00000000-0000-4000-0000-000000000001",
+		Members: []models.Member{
+			{ID: "000000000000000000000001", Role: authorizer.RoleOwner, Status: models.MemberStatusAccepted, AddedAt: now},
+		},
+		Settings:   &models.NamespaceSettings{SessionRecord: true, DeviceKeyPinning: true, DefaultTags: []string{"managed"}},
+		Type:       models.TypeTeam,
+		MaxDevices: -1,
+	}
+
+	invited := &models.Namespace{
+		Name:     "customer",
+		Owner:    "000000000000000000000001",
+		TenantID: "00000000-0000-4000-0000-000000000001",
+		Members: []models.Member{
+			{ID: "000000000000000000000001", Role: authorizer.RoleOwner, Status: models.MemberStatusAccepted, AddedAt: now},
+			{ID: "000000000000000000000000", Role: authorizer.RoleAdministrator, Status: models.MemberStatusPending, AddedAt: now, ExpiresAt: now.Add(7 * (24 * time.Hour))},
+			{ID: "000000000000000000000002", Role: authorizer.RoleOperator, Status: models.MemberStatusPending, AddedAt: now, ExpiresAt: now.Add(7 * (24 * time.Hour))},
+		},
+		Settings:   &models.NamespaceSettings{SessionRecord: true, DeviceKeyPinning: true, DefaultTags: []string{"managed"}},
+		Type:       models.TypeTeam,
+		MaxDevices: 3,
+	}
+
+	transaction := func(ctx context.Context, cb store.TransactionCb) error { return cb(ctx) }
+
+	// creatable mocks the checks of the user's namespaces before the namespace is created.
+	creatable := func(cloud string) {
+		storeMock.
+			On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+			Return(source, nil).
+			Once()
+		storeMock.
+			On("UserGetByID", mock.Anything, "000000000000000000000001", false).
+			Return(&models.User{ID: "000000000000000000000001", MaxNamespaces: -1}, 0, nil).
+			Once()
+		storeMock.
+			On("NamespaceGetByName", mock.Anything, "customer").
+			Return(nil, store.ErrNoDocuments).
+			Once()
+		envMock.
+			On("Get", "SHELLHUB_CLOUD").
+			Return(cloud).
+			Twice()
+		storeMock.
+			On("TagRuleList", mock.Anything, "00000000-0000-4000-0000-000000000000").
+			Return([]models.TagRule{rule}, nil).
+			Once()
+	}
+
+	type Expected struct {
+		namespace *models.Namespace
+		err       error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.NamespaceClone
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace isn't the one the user is authenticated on",
+			req: &requests.NamespaceClone{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000001",
+				TenantID:    "00000000-0000-4000-0000-000000000009",
+				Name:        "customer",
+			},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", nil)},
+		},
+		{
+			description: "fails when the namespace is not found",
+			req: &requests.NamespaceClone{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000001",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Name:        "customer",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the name is duplicated",
+			req: &requests.NamespaceClone{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000001",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Name:        "customer",
+			},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(source, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", mock.Anything, "000000000000000000000001", false).
+					Return(&models.User{ID: "000000000000000000000001", MaxNamespaces: -1}, 0, nil).
+					Once()
+				storeMock.
+					On("NamespaceGetByName", mock.Anything, "customer").
+					Return(&models.Namespace{Name: "customer"}, nil).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceDuplicated(nil)},
+		},
+		{
+			description: "fails when the namespace cannot be created",
+			req: &requests.NamespaceClone{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000001",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Name:        "customer",
+				Clone:       "00000000-0000-4000-0000-000000000001",
+			},
+			requiredMocks: func() {
+				creatable("false")
+				storeMock.
+					On("WithTransaction", mock.Anything, mock.Anything).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: Expected{nil, NewErrNamespaceCreateStore(errors.New("error"))},
+		},
+		{
+			description: "succeeds without the public keys",
+			req: &requests.NamespaceClone{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000001",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Name:        "customer",
+				Clone:       "00000000-0000-4000-0000-000000000001",
+			},
+			requiredMocks: func() {
+				creatable("false")
+				storeMock.
+					On("WithTransaction", mock.Anything, mock.Anything).
+					Return(transaction).
+					Once()
+				storeMock.
+					On("NamespaceCreate", mock.Anything, cloned).
+					Return(cloned, nil).
+					Once()
+				storeMock.
+					On("TagRuleCreate", mock.Anything, &models.TagRule{
+						ID:         "00000000-0000-4000-0000-000000000002",
+						TenantID:   "00000000-0000-4000-0000-000000000001",
+						Name:       "linux",
+						Tag:        "linux",
+						Conditions: rule.Conditions,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{cloned, nil},
+		},
+		{
+			description: "succeeds inviting the members on cloud",
+			req: &requests.NamespaceClone{
+				TenantParam:  requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:       "000000000000000000000001",
+				TenantID:     "00000000-0000-4000-0000-000000000000",
+				Name:         "customer",
+				Clone:        "00000000-0000-4000-0000-000000000001",
+				FowardedHost: "localhost",
+			},
+			requiredMocks: func() {
+				creatable("true")
+				storeMock.
+					On("WithTransaction", mock.Anything, mock.Anything).
+					Return(transaction).
+					Once()
+				storeMock.
+					On("NamespaceCreate", mock.Anything, invited).
+					Return(invited, nil).
+					Once()
+				storeMock.
+					On("TagRuleCreate", mock.Anything, mock.AnythingOfType("*models.TagRule")).
+					Return(nil).
+					Once()
+				clientMock.
+					On("InviteMember", mock.Anything, "00000000-0000-4000-0000-000000000001", "000000000000000000000000", "localhost").
+					Return(nil).
+					Once()
+				clientMock.
+					On("InviteMember", mock.Anything, "00000000-0000-4000-0000-000000000001", "000000000000000000000002", "localhost").
+					Return(nil).
+					Once()
+			},
+			expected: Expected{invited, nil},
+		},
+		{
+			description: "succeeds with the public keys",
+			req: &requests.NamespaceClone{
+				TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+				UserID:      "000000000000000000000001",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Name:        "customer",
+				Clone:       "00000000-0000-4000-0000-000000000001",
+				PublicKeys:  true,
+			},
+			requiredMocks: func() {
+				creatable("false")
+				storeMock.
+					On("PublicKeyList", mock.Anything, query.Paginator{Page: query.MinPage, PerPage: query.MaxPerPage}).
+					Return([]models.PublicKey{key}, 1, nil).
+					Once()
+				storeMock.
+					On("WithTransaction", mock.Anything, mock.Anything).
+					Return(transaction).
+					Once()
+				storeMock.
+					On("NamespaceCreate", mock.Anything, cloned).
+					Return(cloned, nil).
+					Once()
+				storeMock.
+					On("TagRuleCreate", mock.Anything, mock.AnythingOfType("*models.TagRule")).
+					Return(nil).
+					Once()
+				storeMock.
+					On("PublicKeyCreate", mock.Anything, &models.PublicKey{
+						Data:            key.Data,
+						Fingerprint:     key.Fingerprint,
+						CreatedAt:       now,
+						TenantID:        "00000000-0000-4000-0000-000000000001",
+						PublicKeyFields: key.PublicKeyFields,
+					}).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{cloned, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			namespace, err := s.CloneNamespace(context.TODO(), tc.req)
+			assert.Equal(t, tc.expected, Expected{namespace, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// NamespaceEventsBufferSize is the number of namespace events kept for a subscriber that is not consuming them fast
+// enough. When the buffer is full, the next events are dropped and a [models.NamespaceEventResync] is delivered as
+// soon as the subscriber catches up.
+const NamespaceEventsBufferSize = 32
+
+type NamespaceEventsService interface {
+	// SubscribeNamespaceEvents returns a channel that receives the changes on the devices and on the sessions of the
+	// tenant until ctx is done, when the channel is closed.
+	SubscribeNamespaceEvents(ctx context.Context, req *requests.NamespaceEventsSubscribe) (<-chan models.NamespaceEvent, error)
+}
+
+// sessionEventsTopic returns the events bus' topic where the session events of a tenant are published.
+func sessionEventsTopic(tenant string) string {
+	return "sessions:" + tenant
+}
+
+// publishSessionEvent notifies the subscribers of the session's tenant that the session has started or ended. As the
+// event is only a hint to the subscribers, a failure to publish it is logged and doesn't fail the operation that
+// changed the session.
+func (s *service) publishSessionEvent(ctx context.Context, session *models.Session, kind models.NamespaceEventType) {
+	data, err := json.Marshal(&models.NamespaceEvent{Type: kind, TenantID: session.TenantID, Session: session})
+	if err != nil {
+		return
+	}
+
+	if err := s.events.Publish(ctx, sessionEventsTopic(session.TenantID), data); err != nil {
+		log.WithContext(ctx).WithError(err).
+			WithFields(log.Fields{"tenant_id": session.TenantID, "uid": session.UID, "type": kind}).
+			Warn("failed to publish the session event")
+	}
+}
+
+// SubscribeNamespaceEvents subscribes to the device and session events of the tenant. The devices' events are
+// delivered when they got online or offline, were accepted or renamed, with the device's state after the change.
+func (s *service) SubscribeNamespaceEvents(ctx context.Context, req *requests.NamespaceEventsSubscribe) (<-chan models.NamespaceEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	devices, err := s.events.Subscribe(ctx, deviceEventsTopic(req.TenantID))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	sessions, err := s.events.Subscribe(ctx, sessionEventsTopic(req.TenantID))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	events := make(chan models.NamespaceEvent, NamespaceEventsBufferSize)
+
+	go func() {
+		defer cancel()
+		defer close(events)
+
+		resync := false
+		for {
+			// NOTICE: sending on a nil channel blocks forever, so the resync event is only sent when it's pending.
+			var pending chan models.NamespaceEvent
+			if resync {
+				pending = events
+			}
+
+			var event *models.NamespaceEvent
+
+			select {
+			case <-ctx.Done():
+				return
+			case pending <- models.NamespaceEvent{Type: models.NamespaceEventResync, TenantID: req.TenantID}:
+				resync = false
+
+				continue
+			case message, ok := <-devices:
+				if !ok {
+					return
+				}
+
+				// NOTICE: the subscriber is going to list the devices again, so the events until there are useless.
+				if resync {
+					continue
+				}
+
+				event = s.namespaceDeviceEvent(ctx, req, message)
+			case message, ok := <-sessions:
+				if !ok {
+					return
+				}
+
+				if resync {
+					continue
+				}
+
+				event = namespaceSessionEvent(req, message)
+			}
+
+			if event == nil {
+				continue
+			}
+
+			select {
+			case events <- *event:
+			default:
+				resync = true
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// namespaceDeviceEvent converts the device event published on the bus into a namespace event, filled with the
+// device's current state. It returns nil when the event must not be delivered to the subscriber.
+func (s *service) namespaceDeviceEvent(ctx context.Context, req *requests.NamespaceEventsSubscribe, message []byte) *models.NamespaceEvent {
+	published := new(models.DeviceEvent)
+	if err := json.Unmarshal(message, published); err != nil || published.TenantID != req.TenantID {
+		return nil
+	}
+
+	event := &models.NamespaceEvent{TenantID: req.TenantID}
+
+	switch published.Type {
+	case models.DeviceEventOnline:
+		event.Type = models.NamespaceEventDeviceOnline
+	case models.DeviceEventOffline:
+		event.Type = models.NamespaceEventDeviceOffline
+	case models.DeviceEventStatus:
+		event.Type = models.NamespaceEventDeviceAccepted
+	case models.DeviceEventName:
+		event.Type = models.NamespaceEventDeviceRenamed
+	case models.DeviceEventResync:
+		event.Type = models.NamespaceEventResync
+
+		return event
+	default:
+		return nil
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(published.UID), req.TenantID)
+	if err != nil {
+		return nil
+	}
+
+	// NOTICE: the status changes are only delivered when the device was accepted.
+	if published.Type == models.DeviceEventStatus && device.Status != models.DeviceStatusAccepted {
+		return nil
+	}
+
+	event.Device = device
+
+	return event
+}
+
+// namespaceSessionEvent decodes the session event published on the bus. It returns nil when the event must not be
+// delivered to the subscriber.
+func namespaceSessionEvent(req *requests.NamespaceEventsSubscribe, message []byte) *models.NamespaceEvent {
+	event := new(models.NamespaceEvent)
+	if err := json.Unmarshal(message, event); err != nil || event.TenantID != req.TenantID || event.Session == nil {
+		return nil
+	}
+
+	return event
+}
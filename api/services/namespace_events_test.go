@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	storemocks "github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/events"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// topicBus is a [events.Bus] whose subscriptions are channels, one for each topic, controlled by the test.
+type topicBus struct {
+	topics map[string]chan []byte
+}
+
+func (*topicBus) Publish(context.Context, string, []byte) error {
+	return nil
+}
+
+func (b *topicBus) Subscribe(_ context.Context, topic string) (<-chan []byte, error) {
+	return b.topics[topic], nil
+}
+
+func receiveNamespaceEvent(t *testing.T, events <-chan models.NamespaceEvent) models.NamespaceEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("namespace event not received")
+	}
+
+	return models.NamespaceEvent{}
+}
+
+func TestSubscribeNamespaceEvents(t *testing.T) {
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	accepted := &models.Device{UID: "accepted", TenantID: tenant, Status: models.DeviceStatusAccepted}
+	rejected := &models.Device{UID: "rejected", TenantID: tenant, Status: models.DeviceStatusRejected}
+	session := &models.Session{UID: "session", TenantID: tenant, DeviceUID: "accepted", Username: "root"}
+
+	type message struct {
+		topic string
+		data  any
+	}
+
+	cases := []struct {
+		description   string
+		published     []message
+		requiredMocks func(*storemocks.Store)
+		expected      []models.NamespaceEvent
+	}{
+		{
+			description: "delivers the device events with the device's state",
+			published: []message{
+				{"devices:" + tenant, models.DeviceEvent{Type: models.DeviceEventOnline, UID: "accepted", TenantID: tenant}},
+				{"devices:" + tenant, models.DeviceEvent{Type: models.DeviceEventName, UID: "accepted", TenantID: tenant}},
+				{"devices:" + tenant, models.DeviceEvent{Type: models.DeviceEventStatus, UID: "accepted", TenantID: tenant}},
+				{"devices:" + tenant, models.DeviceEvent{Type: models.DeviceEventOffline, UID: "accepted", TenantID: tenant}},
+			},
+			requiredMocks: func(storeMock *storemocks.Store) {
+				storeMock.On("DeviceGetByUID", testifymock.Anything, models.UID("accepted"), tenant).Return(accepted, nil).Times(4)
+			},
+			expected: []models.NamespaceEvent{
+				{Type: models.NamespaceEventDeviceOnline, TenantID: tenant, Device: accepted},
+				{Type: models.NamespaceEventDeviceRenamed, TenantID: tenant, Device: accepted},
+				{Type: models.NamespaceEventDeviceAccepted, TenantID: tenant, Device: accepted},
+				{Type: models.NamespaceEventDeviceOffline, TenantID: tenant, Device: accepted},
+			},
+		},
+		{
+			description: "ignores the status changes of devices not accepted and the other device events",
+			published: []message{
+				{"devices:" + tenant, models.DeviceEvent{Type: models.DeviceEventStatus, UID: "rejected", TenantID: tenant}},
+				{"devices:" + tenant, models.DeviceEvent{Type: models.DeviceEventTags, UID: "accepted", TenantID: tenant}},
+				{"devices:" + tenant, models.DeviceEvent{Type: models.DeviceEventResync, TenantID: tenant}},
+			},
+			requiredMocks: func(storeMock *storemocks.Store) {
+				storeMock.On("DeviceGetByUID", testifymock.Anything, models.UID("rejected"), tenant).Return(rejected, nil).Once()
+			},
+			expected: []models.NamespaceEvent{
+				{Type: models.NamespaceEventResync, TenantID: tenant},
+			},
+		},
+		{
+			description: "delivers the session events",
+			published: []message{
+				{"sessions:" + tenant, models.NamespaceEvent{Type: models.NamespaceEventSessionStarted, TenantID: tenant, Session: session}},
+				{"sessions:" + tenant, models.NamespaceEvent{Type: models.NamespaceEventSessionEnded, TenantID: tenant, Session: session}},
+			},
+			requiredMocks: func(*storemocks.Store) {},
+			expected: []models.NamespaceEvent{
+				{Type: models.NamespaceEventSessionStarted, TenantID: tenant, Session: session},
+				{Type: models.NamespaceEventSessionEnded, TenantID: tenant, Session: session},
+			},
+		},
+		{
+			description: "ignores the events of other tenants",
+			published: []message{
+				{"sessions:" + tenant, models.NamespaceEvent{Type: models.NamespaceEventSessionStarted, TenantID: "00000000-0000-4000-0000-000000000001", Session: session}},
+				{"devices:" + tenant, models.DeviceEvent{Type: models.DeviceEventResync, TenantID: "00000000-0000-4000-0000-000000000001"}},
+				{"sessions:" + tenant, models.NamespaceEvent{Type: models.NamespaceEventSessionEnded, TenantID: tenant, Session: session}},
+			},
+			requiredMocks: func(*storemocks.Store) {},
+			expected: []models.NamespaceEvent{
+				{Type: models.NamespaceEventSessionEnded, TenantID: tenant, Session: session},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock := new(storemocks.Store)
+			tc.requiredMocks(storeMock)
+
+			bus := &topicBus{topics: map[string]chan []byte{
+				"devices:" + tenant:  make(chan []byte),
+				"sessions:" + tenant: make(chan []byte),
+			}}
+			s := NewService(storeMock, privateKey, publicKey, cache.NewNullCache(), clientMock, WithEventBus(bus))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			subscription, err := s.SubscribeNamespaceEvents(ctx, &requests.NamespaceEventsSubscribe{TenantID: tenant})
+			require.NoError(t, err)
+
+			// NOTICE: as the topics' channels are unbuffered, each message is handled before the next one is sent,
+			// keeping the order of the events between the topics.
+			for _, message := range tc.published {
+				data, err := json.Marshal(message.data)
+				require.NoError(t, err)
+
+				bus.topics[message.topic] <- data
+			}
+
+			for _, expected := range tc.expected {
+				assert.Equal(t, expected, receiveNamespaceEvent(t, subscription))
+			}
+
+			assert.Empty(t, subscription)
+			storeMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPublishSessionEvent(t *testing.T) {
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	storeMock := new(storemocks.Store)
+	bus := events.NewLocalBus()
+	s := NewService(storeMock, privateKey, publicKey, cache.NewNullCache(), clientMock, WithEventBus(bus))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, err := bus.Subscribe(ctx, "sessions:"+tenant)
+	require.NoError(t, err)
+
+	session := &models.Session{UID: "uid", TenantID: tenant, Authenticated: false}
+	authenticated := true
+
+	storeMock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+	storeMock.On("SessionUpdate", ctx, models.UID("uid"), session).Return(nil).Once()
+	storeMock.On("SessionActiveCreate", ctx, models.UID("uid"), session).Return(nil).Once()
+
+	require.NoError(t, s.UpdateSession(ctx, models.UID("uid"), models.SessionUpdate{Authenticated: &authenticated}))
+
+	var event models.NamespaceEvent
+	require.NoError(t, json.Unmarshal(<-messages, &event))
+	assert.Equal(t, models.NamespaceEventSessionStarted, event.Type)
+	assert.Equal(t, tenant, event.TenantID)
+	assert.Equal(t, "uid", event.Session.UID)
+	assert.True(t, event.Session.Authenticated)
+
+	storeMock.AssertExpectations(t)
+}
@@ -276,6 +276,7 @@ func TestGetNamespace(t *testing.T) {
 							Email: "john.doe@test.com",
 						},
 					},
+					MembersCount: 1,
 				},
 				err: nil,
 			},
@@ -318,7 +319,8 @@ func TestGetNamespace(t *testing.T) {
 							Email: "john.doe@test.com",
 						},
 					},
-					Type: models.TypeTeam,
+					Type:         models.TypeTeam,
+					MembersCount: 1,
 				},
 				err: nil,
 			},
@@ -1071,6 +1073,14 @@ func TestEditNamespace(t *testing.T) {
 	queryOptionsMock := new(storemock.QueryOptions)
 	storeMock.On("Options").Return(queryOptionsMock)
 
+	uuidMock := new(uuidmocks.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
 	ctx := context.TODO()
 
 	type Expected struct {
@@ -1124,6 +1134,19 @@ func TestEditNamespace(t *testing.T) {
 					On("NamespaceEdit", ctx, "xxxxx", &models.NamespaceChanges{Name: "newname"}).
 					Return(nil).
 					Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, &models.AuditEntry{
+						ID:        "00000000-0000-4000-0000-000000000001",
+						TenantID:  "xxxxx",
+						Actor:     models.AuditActor{Type: models.AuditActorSystem},
+						Action:    models.AuditActionNamespaceUpdate,
+						Target:    models.AuditTarget{Type: models.AuditTargetNamespace, ID: "xxxxx"},
+						Details:   map[string]string{"name": "newname"},
+						CreatedAt: now,
+					}).
+					Return(nil).
+					Once()
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				queryOptionsMock.On("EnrichMembersData").Return(nil).Once()
 				storeMock.
@@ -1153,6 +1176,19 @@ func TestEditNamespace(t *testing.T) {
 					On("NamespaceEdit", ctx, "xxxxx", &models.NamespaceChanges{Name: "newname"}).
 					Return(nil).
 					Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, &models.AuditEntry{
+						ID:        "00000000-0000-4000-0000-000000000001",
+						TenantID:  "xxxxx",
+						Actor:     models.AuditActor{Type: models.AuditActorSystem},
+						Action:    models.AuditActionNamespaceUpdate,
+						Target:    models.AuditTarget{Type: models.AuditTargetNamespace, ID: "xxxxx"},
+						Details:   map[string]string{"name": "newname"},
+						CreatedAt: now,
+					}).
+					Return(nil).
+					Once()
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				queryOptionsMock.On("EnrichMembersData").Return(nil).Once()
 				storeMock.
@@ -1198,6 +1234,14 @@ func TestDeleteNamespace(t *testing.T) {
 	queryOptionsMock := new(storemock.QueryOptions)
 	storeMock.On("Options").Return(queryOptionsMock)
 
+	uuidMock := new(uuidmocks.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
 	ctx := context.TODO()
 
 	cases := []struct {
@@ -1225,7 +1269,7 @@ func TestDeleteNamespace(t *testing.T) {
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				storeMock.
 					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
 					Once()
 				envMock.
 					On("Get", "SHELLHUB_CLOUD").
@@ -1249,7 +1293,7 @@ func TestDeleteNamespace(t *testing.T) {
 				queryOptionsMock.On("CountAcceptedDevices").Return(nil).Once()
 				storeMock.
 					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000", mock.AnythingOfType("store.NamespaceQueryOption")).
-					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
 					Once()
 				envMock.
 					On("Get", "SHELLHUB_CLOUD").
@@ -1263,6 +1307,19 @@ func TestDeleteNamespace(t *testing.T) {
 					On("NamespaceDelete", ctx, "00000000-0000-4000-0000-000000000000").
 					Return(nil).
 					Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, &models.AuditEntry{
+						ID:        "00000000-0000-4000-0000-000000000001",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Actor:     models.AuditActor{Type: models.AuditActorSystem},
+						Action:    models.AuditActionNamespaceDelete,
+						Target:    models.AuditTarget{Type: models.AuditTargetNamespace, ID: "00000000-0000-4000-0000-000000000000"},
+						Details:   map[string]string{"name": "namespace"},
+						CreatedAt: now,
+					}).
+					Return(nil).
+					Once()
 			},
 			expected: nil,
 		},
@@ -1354,6 +1411,14 @@ func TestGetSessionRecord(t *testing.T) {
 func TestEditSessionRecord(t *testing.T) {
 	storeMock := new(storemock.Store)
 
+	uuidMock := new(uuidmocks.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
 	ctx := context.TODO()
 
 	cases := []struct {
@@ -1433,6 +1498,19 @@ func TestEditSessionRecord(t *testing.T) {
 
 				status := true
 				storeMock.On("NamespaceSetSessionRecord", ctx, status, namespace.TenantID).Return(nil).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, &models.AuditEntry{
+						ID:        "00000000-0000-4000-0000-000000000001",
+						TenantID:  "xxxx",
+						Actor:     models.AuditActor{Type: models.AuditActorSystem},
+						Action:    models.AuditActionNamespaceSessionRecordUpdate,
+						Target:    models.AuditTarget{Type: models.AuditTargetNamespace, ID: "xxxx"},
+						Details:   map[string]string{"session_record": "true"},
+						CreatedAt: now,
+					}).
+					Return(nil).
+					Once()
 			},
 			tenantID:      "xxxx",
 			sessionRecord: true,
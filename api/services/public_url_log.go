@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+type PublicURLLogService interface {
+	// CreatePublicURLLog stores a request made to the HTTP service exposed by the device through its public URL.
+	CreatePublicURLLog(ctx context.Context, req *requests.PublicURLLogCreate) (err error)
+
+	// ListPublicURLLogs retrieves a list of requests made to the public URL of the tenant's device. It returns the
+	// list of logs, the total count of matched documents and an error if any.
+	ListPublicURLLogs(ctx context.Context, req *requests.PublicURLLogsList) (logs []models.PublicURLLog, count int, err error)
+
+	// GetPublicURLStats retrieves the aggregate counters of the requests made to the public URL of the tenant's device.
+	GetPublicURLStats(ctx context.Context, req *requests.PublicURLStatsGet) (stats *models.PublicURLStats, err error)
+}
+
+func (s *service) CreatePublicURLLog(ctx context.Context, req *requests.PublicURLLogCreate) error {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	log := req.PublicURLLog
+	log.ID = uuid.Generate()
+	log.DeviceUID = req.UID
+
+	return s.store.PublicURLLogCreate(ctx, &log)
+}
+
+func (s *service) ListPublicURLLogs(ctx context.Context, req *requests.PublicURLLogsList) ([]models.PublicURLLog, int, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, 0, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	return s.store.PublicURLLogList(ctx, req.TenantID, models.UID(req.UID), req.Paginator)
+}
+
+func (s *service) GetPublicURLStats(ctx context.Context, req *requests.PublicURLStatsGet) (*models.PublicURLStats, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	return s.store.PublicURLLogStats(ctx, req.TenantID, models.UID(req.UID))
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePublicURLLog(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	log := models.PublicURLLog{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Method:   "GET",
+		Path:     "/",
+		Status:   200,
+		Bytes:    1024,
+		Latency:  10,
+		SourceIP: "192.168.0.1",
+		Time:     time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.PublicURLLogCreate
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.PublicURLLogCreate{
+				DeviceParam:  requests.DeviceParam{UID: "uid"},
+				PublicURLLog: log,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			req: &requests.PublicURLLogCreate{
+				DeviceParam:  requests.DeviceParam{UID: "uid"},
+				PublicURLLog: log,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("id").
+					Once()
+
+				expected := log
+				expected.ID = "id"
+				expected.DeviceUID = "uid"
+
+				storeMock.
+					On("PublicURLLogCreate", ctx, &expected).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.CreatePublicURLLog(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestListPublicURLLogs(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		logs  []models.PublicURLLog
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.PublicURLLogsList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.PublicURLLogsList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, 0, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			req: &requests.PublicURLLogsList{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Paginator:   query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("PublicURLLogList", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), query.Paginator{Page: 1, PerPage: 10}).
+					Return([]models.PublicURLLog{{ID: "id", DeviceUID: "uid", Status: 200}}, 1, nil).
+					Once()
+			},
+			expected: Expected{[]models.PublicURLLog{{ID: "id", DeviceUID: "uid", Status: 200}}, 1, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			logs, count, err := s.ListPublicURLLogs(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{logs, count, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestGetPublicURLStats(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		stats *models.PublicURLStats
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.PublicURLStatsGet
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.PublicURLStatsGet{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			req: &requests.PublicURLStatsGet{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("PublicURLLogStats", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid")).
+					Return(&models.PublicURLStats{Requests: 2, Errors: 1, Bytes: 1536, Latency: 20, SourceIPs: 2}, nil).
+					Once()
+			},
+			expected: Expected{&models.PublicURLStats{Requests: 2, Errors: 1, Bytes: 1536, Latency: 20, SourceIPs: 2}, nil},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			stats, err := s.GetPublicURLStats(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{stats, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/queryanalytics"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+)
+
+type QueryAnalyticsService interface {
+	// GetQueryAnalytics returns the usage of the listing queries' fields, sampled by the API instance since it was
+	// started. It returns nil when the analytics are disabled.
+	GetQueryAnalytics(ctx context.Context) *queryanalytics.Report
+}
+
+func (s *service) GetQueryAnalytics(_ context.Context) *queryanalytics.Report {
+	return s.queries.Report()
+}
+
+// filtersFields returns the names of the properties the filters are applied to.
+func filtersFields(filters query.Filters) []string {
+	fields := make([]string, 0, len(filters.Data))
+	for _, filter := range filters.Data {
+		if property, ok := filter.Params.(*query.FilterProperty); ok {
+			fields = append(fields, property.Name)
+		}
+	}
+
+	return fields
+}
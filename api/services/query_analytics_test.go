@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/queryanalytics"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetQueryAnalytics(t *testing.T) {
+	t.Run("returns nil when the analytics are disabled", func(t *testing.T) {
+		s := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, nil, clientMock)
+
+		assert.Nil(t, s.GetQueryAnalytics(context.Background()))
+	})
+
+	t.Run("returns the fields used by the devices listing", func(t *testing.T) {
+		storeMock := new(mocks.Store)
+
+		filters := query.Filters{
+			Data: []query.Filter{
+				{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "name", Operator: "contains", Value: "dev"}},
+				{Type: query.FilterTypeOperator, Params: &query.FilterOperator{Name: "and"}},
+			},
+		}
+
+		req := &requests.DeviceList{
+			DeviceStatus: models.DeviceStatusPending,
+			Paginator:    query.Paginator{Page: 1, PerPage: 10},
+			Sorter:       query.Sorter{By: "last_seen", Order: query.OrderDesc},
+			Filters:      filters,
+		}
+
+		ctx := context.Background()
+
+		storeMock.
+			On("DeviceList", ctx, models.DeviceStatusPending, req.Paginator, filters, req.Sorter, store.DeviceAcceptableIfNotAccepted).
+			Return([]models.Device{}, 0, nil).
+			Once()
+
+		s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock, WithQueryAnalytics(queryanalytics.New(100)))
+
+		_, _, err := s.ListDevices(ctx, req)
+		require.NoError(t, err)
+
+		report := s.GetQueryAnalytics(ctx)
+		require.NotNil(t, report)
+		assert.Equal(t, queryanalytics.Usage{
+			Sampled: 1,
+			Fields:  map[string]int64{"name": 1, "status": 1},
+			Sorts:   map[string]int64{"last_seen:desc": 1},
+			Shapes:  map[string]int64{"name,status|last_seen:desc": 1},
+		}, report.Queries[queryanalytics.QueryDevices])
+
+		storeMock.AssertExpectations(t)
+	})
+}
@@ -2,11 +2,20 @@ package services
 
 import (
 	"crypto/rsa"
+	"time"
 
+	"github.com/shellhub-io/shellhub/api/pkg/deviceuid"
+	"github.com/shellhub-io/shellhub/api/pkg/policyengine"
+	"github.com/shellhub-io/shellhub/api/pkg/queryanalytics"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
 	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/events"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
+	"github.com/shellhub-io/shellhub/pkg/keysource"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 )
 
@@ -24,6 +33,68 @@ type service struct {
 	client    internalclient.Client
 	locator   geoip.Locator
 	validator *validator.Validator
+	mailer    mailer.Mailer
+	// verification holds the settings used by the email verification flow.
+	verification emailVerification
+	// quota holds the instance's default limits of members and pending invitations per namespace.
+	quota memberQuota
+	// events is the bus where the changes on devices and sessions are published to the subscribers.
+	events events.Bus
+	// offline holds the settings used to delay a device's offline state.
+	offline deviceOffline
+	// keys fetches the public keys published by users on code hosting providers.
+	keys keysource.Fetcher
+	// tokens holds the lifetimes of the tokens issued to users.
+	tokens userTokens
+	// addressBan holds the settings used to ban the addresses failing to authenticate repeatedly.
+	addressBan addressBan
+	// deviceUIDScheme is the scheme the UIDs of the new devices are derived on.
+	deviceUIDScheme deviceuid.Scheme
+	// queries records the usage of the listing queries' fields. It is nil when the analytics are disabled.
+	queries *queryanalytics.Recorder
+	// recordings is the object storage where the SSH server keeps the sessions' recordings. It is nil when the
+	// recordings aren't kept on an object storage.
+	recordings objectstorage.Storage
+	// policies is the engine where the organization's policies are evaluated before opening a session. It is nil when
+	// the sessions are only restricted by the namespace's rules.
+	policies policyengine.Engine
+}
+
+type emailVerification struct {
+	// url is the base address used to build the verification link sent to users.
+	url string
+	// ttl is how long a verification token remains valid.
+	ttl time.Duration
+}
+
+type deviceOffline struct {
+	// grace is how long a device remains online after its connection was closed.
+	grace time.Duration
+	// minOnline is the minimum time a device remains online after it got online.
+	minOnline time.Duration
+}
+
+type userTokens struct {
+	// access is how long an access token remains valid.
+	access time.Duration
+	// refresh is how long a refresh token remains valid.
+	refresh time.Duration
+}
+
+type addressBan struct {
+	// failures is the number of authentication failures that bans an address. Zero disables the temporary bans.
+	failures int
+	// window is how long a failure is remembered after the address' last one.
+	window time.Duration
+	// duration is how long the address is banned.
+	duration time.Duration
+}
+
+type memberQuota struct {
+	// members is the default maximum number of members, including the pending invitations, per namespace.
+	members int
+	// invitations is the default maximum number of pending invitations per namespace.
+	invitations int
 }
 
 //go:generate mockery --name Service --filename services.go
@@ -31,28 +102,172 @@ type Service interface {
 	BillingInterface
 	TagsService
 	DeviceService
+	DeviceEventsService
+	NamespaceEventsService
 	DeviceTags
+	TagRuleService
+	CommandPolicyService
+	DeviceShareService
+	GroupService
+	DeviceQueueService
+	DeviceKeyIncidentService
+	DeviceQuarantineService
+	DeviceConfigService
+	QueryAnalyticsService
+	SessionAttestationService
+	DeviceChangesService
+	DeviceHeartbeatService
+	DeviceAgentLogService
+	DeviceCommandService
+	DeviceSnapshotService
+	AuditService
+	PublicURLLogService
+	DeviceNameTemplateService
+	ConnectionAnnouncementService
+	DeviceLimitService
+	SessionScheduleService
+	SessionPolicyService
+	SessionWebhookService
+	WebSessionService
+	DevicePositionService
+	JobService
 	UserService
+	UserAliasService
+	UserSessionService
+	UserDeviceService
 	SSHKeysService
 	SSHKeysTagsService
+	SSHCertificateAuthorityService
 	SessionService
+	SessionRecordingService
 	NamespaceService
+	NamespaceCloneService
 	MemberService
+	MemberActivityService
 	AuthService
 	StatsService
 	SetupService
+	BannedAddressService
 	SystemService
 	APIKeyService
+	EnrollTokenService
+	UserVerificationService
 }
 
 type Option func(service *APIService)
 
+// DefaultEmailVerificationTTL is how long an email verification token remains valid when not configured.
+const DefaultEmailVerificationTTL = 24 * time.Hour
+
+// DefaultRefreshTokenTTL is how long a user's refresh token remains valid when not configured.
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
 func WithLocator(locator geoip.Locator) Option {
 	return func(service *APIService) {
 		service.locator = locator
 	}
 }
 
+// WithMailer sets the mailer used to deliver emails, like account verification messages, to users.
+func WithMailer(mailer mailer.Mailer) Option {
+	return func(service *APIService) {
+		service.mailer = mailer
+	}
+}
+
+// WithEmailVerification sets the base URL used to build the verification links sent to users and how long a
+// verification token remains valid.
+func WithEmailVerification(url string, ttl time.Duration) Option {
+	return func(service *APIService) {
+		service.verification = emailVerification{url: url, ttl: ttl}
+	}
+}
+
+// WithMemberQuota sets the default maximum number of members and pending invitations per namespace. Values lower or
+// equal to zero mean no limit. Each namespace can override these values.
+func WithMemberQuota(members, invitations int) Option {
+	return func(service *APIService) {
+		service.quota = memberQuota{members: members, invitations: invitations}
+	}
+}
+
+// WithEventBus sets the bus used to publish and subscribe to the changes on devices and sessions. When the API runs with more than
+// one instance, it must be shared among them, like a Redis backed one.
+func WithEventBus(bus events.Bus) Option {
+	return func(service *APIService) {
+		service.events = bus
+	}
+}
+
+// WithDeviceOffline sets how long a device remains online after its connection was closed, and the minimum time it
+// remains online after it got online, suppressing the state changes of devices on lossy links. Values lower or equal
+// to zero mean the device is set offline as soon as its connection is closed.
+func WithDeviceOffline(grace, minOnline time.Duration) Option {
+	return func(service *APIService) {
+		service.offline = deviceOffline{grace: grace, minOnline: minOnline}
+	}
+}
+
+// WithUserTokens sets how long the access and refresh tokens issued to users remain valid. Values lower or equal to
+// zero keep the defaults: [jwttoken.UserTokenTTL] for access tokens, which is the lifetime of the legacy long-lived
+// tokens, and [DefaultRefreshTokenTTL] for refresh tokens.
+func WithUserTokens(access, refresh time.Duration) Option {
+	return func(service *APIService) {
+		if access > 0 {
+			service.tokens.access = access
+		}
+
+		if refresh > 0 {
+			service.tokens.refresh = refresh
+		}
+	}
+}
+
+// WithKeySource sets the fetcher of the public keys published by users on code hosting providers, used to import them.
+func WithKeySource(fetcher keysource.Fetcher) Option {
+	return func(service *APIService) {
+		service.keys = fetcher
+	}
+}
+
+// WithAddressBan bans, for duration, the addresses failing to authenticate failures times, each failure no more than
+// window apart from the previous one. A failures lower or equal to zero disables the temporary bans.
+func WithAddressBan(failures int, window, duration time.Duration) Option {
+	return func(service *APIService) {
+		service.addressBan = addressBan{failures: failures, window: window, duration: duration}
+	}
+}
+
+// WithDeviceUIDScheme sets the scheme the UIDs of the new devices are derived on. The devices registered on the
+// [deviceuid.SchemeLegacy] keep their UIDs on another scheme.
+func WithDeviceUIDScheme(scheme deviceuid.Scheme) Option {
+	return func(service *APIService) {
+		service.deviceUIDScheme = scheme
+	}
+}
+
+// WithQueryAnalytics records, on recorder, the usage of the listing queries' fields.
+func WithQueryAnalytics(recorder *queryanalytics.Recorder) Option {
+	return func(service *APIService) {
+		service.queries = recorder
+	}
+}
+
+// WithRecordingStorage sets the object storage where the SSH server keeps the sessions' recordings, retrieved from it
+// for playback.
+func WithRecordingStorage(storage objectstorage.Storage) Option {
+	return func(service *APIService) {
+		service.recordings = storage
+	}
+}
+
+// WithPolicyEngine sets the engine where the organization's policies are evaluated before opening a session.
+func WithPolicyEngine(engine policyengine.Engine) Option {
+	return func(service *APIService) {
+		service.policies = engine
+	}
+}
+
 func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c internalclient.Client, options ...Option) *APIService {
 	if privKey == nil || pubKey == nil {
 		var err error
@@ -71,6 +286,18 @@ func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKe
 			c,
 			geoip.NewNullGeoLite(),
 			validator.New(),
+			mailer.NewNullMailer(),
+			emailVerification{ttl: DefaultEmailVerificationTTL},
+			memberQuota{},
+			events.NewLocalBus(),
+			deviceOffline{},
+			keysource.NewHTTPFetcher(),
+			userTokens{access: jwttoken.UserTokenTTL, refresh: DefaultRefreshTokenTTL},
+			addressBan{},
+			deviceuid.SchemeLegacy,
+			nil,
+			nil,
+			nil,
 		},
 	}
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net"
 
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/queryanalytics"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -21,6 +23,14 @@ type SessionService interface {
 }
 
 func (s *service) ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
+	fields := []string{}
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		fields = append(fields, "tenant_id")
+	}
+
+	// NOTICE: the sessions are always sorted by their start, newest first.
+	s.queries.Record(queryanalytics.QuerySessions, fields, "started_at", query.OrderDesc)
+
 	return s.store.SessionList(ctx, paginator)
 }
 
@@ -43,6 +53,7 @@ func (s *service) CreateSession(ctx context.Context, session requests.SessionCre
 		IPAddress: session.IPAddress,
 		Type:      session.Type,
 		Term:      session.Term,
+		Client:    session.Client,
 		Position: models.SessionPosition{
 			Longitude: position.Longitude,
 			Latitude:  position.Latitude,
@@ -56,7 +67,15 @@ func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
 		return NewErrSessionNotFound(uid, err)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	if session := s.attestSession(ctx, uid); session != nil {
+		s.publishSessionEvent(ctx, session, models.NamespaceEventSessionEnded)
+	}
+
+	return nil
 }
 
 func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
@@ -83,12 +102,37 @@ func (s *service) UpdateSession(ctx context.Context, uid models.UID, model model
 		sess.Type = *model.Type
 	}
 
+	if model.AuthMethod != nil {
+		sess.Client.AuthMethod = *model.AuthMethod
+	}
+
+	if model.CertificateKeyID != nil {
+		sess.Client.CertificateKeyID = *model.CertificateKeyID
+	}
+
+	if model.RecordType != nil {
+		sess.RecordType = *model.RecordType
+	}
+
+	if model.RecordHash != nil {
+		sess.RecordHash = *model.RecordHash
+	}
+
+	if model.RecordObject != nil {
+		sess.RecordObject = *model.RecordObject
+		sess.Recorded = true
+	}
+
 	if err := s.store.SessionUpdate(ctx, uid, sess); err != nil {
 		return err
 	}
 
 	if insertActiveSession {
-		return s.store.SessionActiveCreate(ctx, uid, sess)
+		if err := s.store.SessionActiveCreate(ctx, uid, sess); err != nil {
+			return err
+		}
+
+		s.publishSessionEvent(ctx, sess, models.NamespaceEventSessionStarted)
 	}
 
 	return nil
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+type SessionAttestationService interface {
+	// VerifySessionAttestation reports whether the session's attestation, as exported with the session, was signed by
+	// the server and its statement is untampered.
+	VerifySessionAttestation(ctx context.Context, req *requests.SessionAttestationVerify) bool
+}
+
+func (s *service) VerifySessionAttestation(_ context.Context, req *requests.SessionAttestationVerify) bool {
+	digest, err := sessionStatementDigest(&req.Statement)
+	if err != nil || hex.EncodeToString(digest) != req.Digest {
+		return false
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(req.Signature)
+	if err != nil {
+		return false
+	}
+
+	return rsa.VerifyPKCS1v15(s.pubKey, crypto.SHA256, digest, signature) == nil
+}
+
+// attestSession signs the statement of the completed session, storing it with the session, when the session's
+// namespace attests its sessions. As the attestation is done after the session is completed, a failure is logged
+// and doesn't fail the completion. It returns the completed session, or nil when it cannot be retrieved.
+func (s *service) attestSession(ctx context.Context, uid models.UID) *models.Session {
+	logger := log.WithContext(ctx).WithField("uid", uid)
+
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get the session to attest it")
+
+		return nil
+	}
+
+	// NOTICE: a session completed more than once keeps its first attestation.
+	if session.Attestation != nil {
+		return session
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, session.TenantID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get the session's namespace to attest it")
+
+		return session
+	}
+
+	if namespace.Settings == nil || !namespace.Settings.SessionAttestation {
+		return session
+	}
+
+	// NOTICE: the times are kept on the precision stored by the database, so the statement read back from it is
+	// encoded as it was signed.
+	statement := models.SessionStatement{
+		UID:        session.UID,
+		TenantID:   session.TenantID,
+		DeviceUID:  session.DeviceUID,
+		Username:   session.Username,
+		IPAddress:  session.IPAddress,
+		Type:       session.Type,
+		StartedAt:  session.StartedAt.UTC().Truncate(time.Millisecond),
+		FinishedAt: session.LastSeen.UTC().Truncate(time.Millisecond),
+		RecordType: session.RecordType,
+		RecordHash: session.RecordHash,
+	}
+
+	attestation, err := s.signSessionStatement(&statement)
+	if err != nil {
+		logger.WithError(err).Error("failed to sign the session's statement")
+
+		return session
+	}
+
+	session.Attestation = attestation
+	if err := s.store.SessionUpdate(ctx, uid, session); err != nil {
+		logger.WithError(err).Error("failed to store the session's attestation")
+
+		session.Attestation = nil
+
+		return session
+	}
+
+	logger.Info("session attested")
+
+	return session
+}
+
+// signSessionStatement signs the statement's digest with the server's private key.
+func (s *service) signSessionStatement(statement *models.SessionStatement) (*models.SessionAttestation, error) {
+	digest, err := sessionStatementDigest(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privKey, crypto.SHA256, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SessionAttestation{
+		Statement: *statement,
+		Digest:    hex.EncodeToString(digest),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+		Algorithm: models.SessionAttestationAlgorithm,
+		SignedAt:  clock.Now(),
+	}, nil
+}
+
+// sessionStatementDigest returns the SHA-256 of the statement encoded as JSON.
+func sessionStatementDigest(statement *models.SessionStatement) ([]byte, error) {
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(data)
+
+	return digest[:], nil
+}
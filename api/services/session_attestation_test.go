@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestSession(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clockMock.On("Now").Return(now)
+
+	startedAt := time.Date(2023, 1, 1, 0, 0, 0, 123456789, time.UTC)
+	finishedAt := time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	session := func() *models.Session {
+		return &models.Session{
+			UID:        "uid",
+			DeviceUID:  "device",
+			TenantID:   "tenant",
+			Username:   "root",
+			IPAddress:  "127.0.0.1",
+			Type:       "shell",
+			StartedAt:  startedAt,
+			LastSeen:   finishedAt,
+			RecordType: models.SessionRecordTypePty,
+			RecordHash: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		}
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+	}{
+		{
+			description: "does nothing when the session is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+		},
+		{
+			description: "does nothing when the session is already attested",
+			requiredMocks: func(ctx context.Context) {
+				attested := session()
+				attested.Attestation = &models.SessionAttestation{}
+
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(attested, nil).
+					Once()
+			},
+		},
+		{
+			description: "does nothing when the namespace doesn't attest its sessions",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(session(), nil).
+					Once()
+				storeMock.
+					On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{}}, nil).
+					Once()
+			},
+		},
+		{
+			description: "does nothing when the session cannot be stored",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(session(), nil).
+					Once()
+				storeMock.
+					On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{SessionAttestation: true}}, nil).
+					Once()
+				storeMock.
+					On("SessionUpdate", ctx, models.UID("uid"), mock.AnythingOfType("*models.Session")).
+					Return(errors.New("error")).
+					Once()
+			},
+		},
+		{
+			description: "succeeds signing the session's statement",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(session(), nil).
+					Once()
+				storeMock.
+					On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{SessionAttestation: true}}, nil).
+					Once()
+				storeMock.
+					On("SessionUpdate", ctx, models.UID("uid"), mock.MatchedBy(func(s *models.Session) bool {
+						return s.Attestation != nil &&
+							s.Attestation.Statement == models.SessionStatement{
+								UID:        "uid",
+								TenantID:   "tenant",
+								DeviceUID:  "device",
+								Username:   "root",
+								IPAddress:  "127.0.0.1",
+								Type:       "shell",
+								StartedAt:  startedAt.Truncate(time.Millisecond),
+								FinishedAt: finishedAt,
+								RecordType: models.SessionRecordTypePty,
+								RecordHash: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+							} &&
+							s.Attestation.Algorithm == models.SessionAttestationAlgorithm
+					})).
+					Return(nil).
+					Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+			s.attestSession(ctx, models.UID("uid"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestVerifySessionAttestation(t *testing.T) {
+	clockMock.On("Now").Return(now)
+
+	s := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, nil, clientMock)
+
+	statement := models.SessionStatement{
+		UID:        "uid",
+		TenantID:   "tenant",
+		DeviceUID:  "device",
+		Username:   "root",
+		IPAddress:  "127.0.0.1",
+		Type:       "shell",
+		StartedAt:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		FinishedAt: time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC),
+	}
+
+	attestation, err := s.signSessionStatement(&statement)
+	require.NoError(t, err)
+
+	cases := []struct {
+		description string
+		req         func() *requests.SessionAttestationVerify
+		expected    bool
+	}{
+		{
+			description: "fails when the statement was tampered",
+			req: func() *requests.SessionAttestationVerify {
+				tampered := statement
+				tampered.Username = "admin"
+
+				return &requests.SessionAttestationVerify{Statement: tampered, Digest: attestation.Digest, Signature: attestation.Signature}
+			},
+			expected: false,
+		},
+		{
+			description: "fails when the digest and the signature were tampered",
+			req: func() *requests.SessionAttestationVerify {
+				tampered := statement
+				tampered.Username = "admin"
+
+				other, err := s.signSessionStatement(&tampered)
+				require.NoError(t, err)
+
+				return &requests.SessionAttestationVerify{Statement: tampered, Digest: other.Digest, Signature: attestation.Signature}
+			},
+			expected: false,
+		},
+		{
+			description: "fails when the signature is invalid",
+			req: func() *requests.SessionAttestationVerify {
+				return &requests.SessionAttestationVerify{Statement: statement, Digest: attestation.Digest, Signature: "c2lnbmF0dXJl"}
+			},
+			expected: false,
+		},
+		{
+			description: "succeeds",
+			req: func() *requests.SessionAttestationVerify {
+				return &requests.SessionAttestationVerify{Statement: statement, Digest: attestation.Digest, Signature: attestation.Signature}
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, s.VerifySessionAttestation(context.Background(), tc.req()))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/policyengine"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+type SessionPolicyService interface {
+	// EvaluateSessionPolicy evaluates if the organization's policies, on the policy engine, allow the session to the
+	// tenant's device, with the device's tags and info, the namespace, the login, the client's address and the current
+	// time as input. Every session is allowed when the policy engine isn't configured, and none is allowed when the
+	// policies couldn't be evaluated, as if the policies have denied it.
+	EvaluateSessionPolicy(ctx context.Context, req *requests.DeviceEvaluateSessionPolicy) error
+}
+
+func (s *service) EvaluateSessionPolicy(ctx context.Context, req *requests.DeviceEvaluateSessionPolicy) error {
+	if s.policies == nil {
+		return nil
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, req.TenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	input := &policyengine.Input{
+		Action:    policyengine.ActionSessionOpen,
+		Time:      clock.Now(),
+		SourceIP:  req.IPAddress,
+		Namespace: &policyengine.Namespace{TenantID: namespace.TenantID, Name: namespace.Name},
+		Device: &policyengine.Device{
+			UID:    device.UID,
+			Name:   device.Name,
+			Tags:   device.Tags,
+			Groups: device.Groups,
+			Info:   device.Info,
+		},
+		Login: req.Username,
+	}
+
+	if input.Device.Tags == nil {
+		input.Device.Tags = []string{}
+	}
+
+	if input.Device.Groups == nil {
+		input.Device.Groups = []string{}
+	}
+
+	logger := log.WithFields(log.Fields{"tenant_id": req.TenantID, "uid": req.UID, "username": req.Username})
+
+	decision, err := s.policies.Evaluate(ctx, input)
+	if err != nil {
+		logger.WithError(err).Error("failed to evaluate the session's policies, denying the session")
+
+		return NewErrSessionPolicyBlock()
+	}
+
+	if !decision.Allow {
+		logger.WithField("reason", decision.Reason).Info("the policies denied the session")
+
+		return NewErrSessionPolicyBlock()
+	}
+
+	return nil
+}
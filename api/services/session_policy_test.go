@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/policyengine"
+	policyenginemocks "github.com/shellhub-io/shellhub/api/pkg/policyengine/mocks"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateSessionPolicy(t *testing.T) {
+	storeMock := new(mocks.Store)
+	engineMock := new(policyenginemocks.Engine)
+
+	clockMock := new(clockmock.Clock)
+	backend := clock.DefaultBackend
+	clock.DefaultBackend = clockMock
+	t.Cleanup(func() { clock.DefaultBackend = backend })
+
+	clockMock.On("Now").Return(now)
+
+	req := &requests.DeviceEvaluateSessionPolicy{
+		DeviceParam: requests.DeviceParam{UID: "uid"},
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		Username:    "root",
+		IPAddress:   "192.168.0.1",
+	}
+
+	input := &policyengine.Input{
+		Action:    policyengine.ActionSessionOpen,
+		Time:      now,
+		SourceIP:  "192.168.0.1",
+		Namespace: &policyengine.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"},
+		Device: &policyengine.Device{
+			UID:    "uid",
+			Name:   "device",
+			Tags:   []string{"production"},
+			Groups: []string{},
+			Info:   &models.DeviceInfo{ID: "ubuntu"},
+		},
+		Login: "root",
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the policies couldn't be evaluated",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Name: "device", Tags: []string{"production"}, Info: &models.DeviceInfo{ID: "ubuntu"}}, nil).
+					Once()
+				engineMock.
+					On("Evaluate", ctx, input).
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: NewErrSessionPolicyBlock(),
+		},
+		{
+			description: "fails when the policies deny the session",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Name: "device", Tags: []string{"production"}, Info: &models.DeviceInfo{ID: "ubuntu"}}, nil).
+					Once()
+				engineMock.
+					On("Evaluate", ctx, input).
+					Return(&policyengine.Decision{Allow: false, Reason: "root logins are denied"}, nil).
+					Once()
+			},
+			expected: NewErrSessionPolicyBlock(),
+		},
+		{
+			description: "succeeds when the policies allow the session",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Name: "namespace"}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Name: "device", Tags: []string{"production"}, Info: &models.DeviceInfo{ID: "ubuntu"}}, nil).
+					Once()
+				engineMock.
+					On("Evaluate", ctx, input).
+					Return(&policyengine.Decision{Allow: true}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock, WithPolicyEngine(engineMock))
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, service.EvaluateSessionPolicy(ctx, req))
+		})
+	}
+
+	t.Run("succeeds when the policy engine isn't configured", func(t *testing.T) {
+		service := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+		assert.NoError(t, service.EvaluateSessionPolicy(context.Background(), req))
+	})
+
+	storeMock.AssertExpectations(t)
+	engineMock.AssertExpectations(t)
+}
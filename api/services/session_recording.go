@@ -0,0 +1,126 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/asciicast"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+type SessionRecordingService interface {
+	// GetSessionRecording retrieves the tenant's session recording from the object storage, encoded as an asciicast
+	// file and watermarked with its viewer when the namespace watermarks the recordings.
+	//
+	// Every access to the recording is recorded on its access log, failing the access when it cannot be recorded.
+	GetSessionRecording(ctx context.Context, req *requests.SessionRecordingGet) ([]byte, error)
+
+	// ListSessionRecordingAccesses lists the access log of the tenant's session recording, most recent first. It
+	// returns the list of accesses, the total count of matched documents and an error if any.
+	ListSessionRecordingAccesses(ctx context.Context, req *requests.SessionRecordingAccessList) ([]models.SessionRecordingAccess, int, error)
+}
+
+func (s *service) GetSessionRecording(ctx context.Context, req *requests.SessionRecordingGet) ([]byte, error) {
+	if s.recordings == nil {
+		return nil, NewErrSessionRecordingStorage()
+	}
+
+	session, err := s.store.SessionGet(ctx, models.UID(req.UID))
+	if err != nil {
+		return nil, NewErrSessionNotFound(models.UID(req.UID), err)
+	}
+
+	if session.TenantID != req.TenantID {
+		return nil, NewErrSessionNotFound(models.UID(req.UID), nil)
+	}
+
+	if session.RecordObject == "" {
+		return nil, NewErrSessionRecordingNotFound(models.UID(session.UID), nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, req.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	object, err := s.recordings.GetObject(ctx, session.RecordObject)
+	if err != nil {
+		if errors.Is(err, objectstorage.ErrObjectNotFound) {
+			return nil, NewErrSessionRecordingNotFound(models.UID(session.UID), err)
+		}
+
+		return nil, err
+	}
+
+	defer object.Close()
+
+	frames := []models.SessionRecorded{}
+
+	// NOTICE: the recording is kept as JSON lines, one document per frame.
+	decoder := json.NewDecoder(object)
+	for {
+		var frame models.SessionRecorded
+		if err := decoder.Decode(&frame); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+
+		frames = append(frames, frame)
+	}
+
+	var mode models.RecordWatermark
+	if namespace.Settings != nil {
+		mode = namespace.Settings.RecordWatermark
+	}
+
+	var buffer bytes.Buffer
+	if err := asciicast.Encode(&buffer, session.UID, frames, mode, &asciicast.Watermark{Viewer: req.Username, Time: clock.Now()}); err != nil {
+		return nil, err
+	}
+
+	action := models.SessionRecordingAccessActionPlay
+	if req.Download {
+		action = models.SessionRecordingAccessActionExport
+	}
+
+	// NOTICE: the access is recorded before the recording is served, so no recording is served without its access
+	// being logged.
+	if err := s.store.SessionRecordingAccessCreate(ctx, &models.SessionRecordingAccess{
+		ID:         uuid.Generate(),
+		TenantID:   req.TenantID,
+		SessionUID: session.UID,
+		UserID:     req.UserID,
+		Username:   req.Username,
+		Action:     action,
+		Range:      req.Range,
+		SourceIP:   req.IP,
+		AccessedAt: clock.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (s *service) ListSessionRecordingAccesses(ctx context.Context, req *requests.SessionRecordingAccessList) ([]models.SessionRecordingAccess, int, error) {
+	session, err := s.store.SessionGet(ctx, models.UID(req.UID))
+	if err != nil {
+		return nil, 0, NewErrSessionNotFound(models.UID(req.UID), err)
+	}
+
+	if session.TenantID != req.TenantID {
+		return nil, 0, NewErrSessionNotFound(models.UID(req.UID), nil)
+	}
+
+	return s.store.SessionRecordingAccessList(ctx, req.TenantID, models.UID(req.UID), req.Paginator)
+}
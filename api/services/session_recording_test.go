@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
+	storagemocks "github.com/shellhub-io/shellhub/pkg/objectstorage/mocks"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSessionRecording(t *testing.T) {
+	storeMock := new(mocks.Store)
+	storageMock := new(storagemocks.Storage)
+
+	uuidMock := new(uuidmock.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clockMock := new(clockmock.Clock)
+	clockBackend := clock.DefaultBackend
+	clock.DefaultBackend = clockMock
+	t.Cleanup(func() { clock.DefaultBackend = clockBackend })
+
+	clockMock.On("Now").Return(now)
+
+	req := &requests.SessionRecordingGet{
+		SessionIDParam: requests.SessionIDParam{UID: "uid"},
+		TenantID:       "tenant",
+		Username:       "john",
+		UserID:         "507f1f77bcf86cd799439011",
+		IP:             "192.168.0.1",
+		Range:          "bytes=0-1023",
+	}
+
+	access := &models.SessionRecordingAccess{
+		ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+		TenantID:   "tenant",
+		SessionUID: "uid",
+		UserID:     "507f1f77bcf86cd799439011",
+		Username:   "john",
+		Action:     models.SessionRecordingAccessActionPlay,
+		Range:      "bytes=0-1023",
+		SourceIP:   "192.168.0.1",
+		AccessedAt: now,
+	}
+
+	type Expected struct {
+		recording string
+		err       error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the session is not found",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{err: NewErrSessionNotFound("uid", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the session belongs to another tenant",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "other", RecordObject: "other/uid.jsonl"}, nil).
+					Once()
+			},
+			expected: Expected{err: NewErrSessionNotFound("uid", nil)},
+		},
+		{
+			description: "fails when the session isn't recorded on the object storage",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).
+					Once()
+			},
+			expected: Expected{err: NewErrSessionRecordingNotFound("uid", nil)},
+		},
+		{
+			description: "fails when the recording is missing from the object storage",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant", RecordObject: "tenant/uid.jsonl"}, nil).
+					Once()
+				storeMock.
+					On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{}}, nil).
+					Once()
+				storageMock.
+					On("GetObject", ctx, "tenant/uid.jsonl").
+					Return(nil, objectstorage.ErrObjectNotFound).
+					Once()
+			},
+			expected: Expected{err: NewErrSessionRecordingNotFound("uid", objectstorage.ErrObjectNotFound)},
+		},
+		{
+			description: "fails when the access cannot be recorded",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant", RecordObject: "tenant/uid.jsonl"}, nil).
+					Once()
+				storeMock.
+					On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{}}, nil).
+					Once()
+				storageMock.
+					On("GetObject", ctx, "tenant/uid.jsonl").
+					Return(io.NopCloser(strings.NewReader(`{"uid":"uid","message":"ls\r\n","width":80,"height":24}`+"\n")), nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Once()
+				storeMock.
+					On("SessionRecordingAccessCreate", ctx, access).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: Expected{err: errors.New("error")},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant", RecordObject: "tenant/uid.jsonl"}, nil).
+					Once()
+				storeMock.
+					On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{}}, nil).
+					Once()
+				storageMock.
+					On("GetObject", ctx, "tenant/uid.jsonl").
+					Return(io.NopCloser(strings.NewReader(
+						`{"uid":"uid","message":"ls\r\n","width":80,"height":24}`+"\n"+
+							`{"uid":"uid","message":"file\r\n","width":80,"height":24}`+"\n",
+					)), nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Once()
+				storeMock.
+					On("SessionRecordingAccessCreate", ctx, access).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				recording: `{"version":2,"width":80,"height":24,"title":"uid"}` + "\n" +
+					`[0,"o","ls\r\n"]` + "\n" +
+					`[0,"o","file\r\n"]` + "\n",
+			},
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock, WithRecordingStorage(storageMock))
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			recording, err := service.GetSessionRecording(ctx, req)
+			assert.Equal(t, tc.expected, Expected{string(recording), err})
+		})
+	}
+
+	t.Run("succeeds watermarking the recording with its viewer", func(t *testing.T) {
+		ctx := context.Background()
+
+		storeMock.
+			On("SessionGet", ctx, models.UID("uid")).
+			Return(&models.Session{UID: "uid", TenantID: "tenant", RecordObject: "tenant/uid.jsonl"}, nil).
+			Once()
+		storeMock.
+			On("NamespaceGet", ctx, "tenant").
+			Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{RecordWatermark: models.RecordWatermarkMetadata}}, nil).
+			Once()
+		storageMock.
+			On("GetObject", ctx, "tenant/uid.jsonl").
+			Return(io.NopCloser(strings.NewReader(`{"uid":"uid","message":"ls\r\n","width":80,"height":24}`+"\n")), nil).
+			Once()
+		uuidMock.
+			On("Generate").
+			Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+			Once()
+		storeMock.
+			On("SessionRecordingAccessCreate", ctx, access).
+			Return(nil).
+			Once()
+
+		recording, err := service.GetSessionRecording(ctx, req)
+		assert.NoError(t, err)
+		assert.Contains(t, string(recording), `"watermark":{"viewer":"john"`)
+	})
+
+	storeMock.AssertExpectations(t)
+	storageMock.AssertExpectations(t)
+
+	t.Run("fails when the object storage isn't configured", func(t *testing.T) {
+		service := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, nil, clientMock)
+
+		_, err := service.GetSessionRecording(context.Background(), req)
+		assert.Equal(t, NewErrSessionRecordingStorage(), err)
+	})
+}
+
+func TestListSessionRecordingAccesses(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	req := &requests.SessionRecordingAccessList{
+		SessionIDParam: requests.SessionIDParam{UID: "uid"},
+		TenantID:       "tenant",
+		Paginator:      query.Paginator{Page: 1, PerPage: 10},
+	}
+
+	type Expected struct {
+		accesses []models.SessionRecordingAccess
+		count    int
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the session belongs to another tenant",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "other"}, nil).
+					Once()
+			},
+			expected: Expected{nil, 0, NewErrSessionNotFound("uid", nil)},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).
+					Once()
+				storeMock.
+					On("SessionRecordingAccessList", ctx, "tenant", models.UID("uid"), query.Paginator{Page: 1, PerPage: 10}).
+					Return([]models.SessionRecordingAccess{{ID: "access", Action: models.SessionRecordingAccessActionExport}}, 1, nil).
+					Once()
+			},
+			expected: Expected{[]models.SessionRecordingAccess{{ID: "access", Action: models.SessionRecordingAccessActionExport}}, 1, nil},
+		},
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			accesses, count, err := service.ListSessionRecordingAccesses(ctx, req)
+			assert.Equal(t, tc.expected, Expected{accesses, count, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+type SessionScheduleService interface {
+	// EvaluateSessionSchedule evaluates if the namespace's session schedules allow connections to the tenant's device
+	// now. A device restricted by schedules only accepts connections inside the windows of all of them, unless it has
+	// an active break-glass override.
+	EvaluateSessionSchedule(ctx context.Context, req *requests.DeviceEvaluateSessionSchedule) error
+
+	// OverrideSessionSchedule allows connections to the tenant's device outside the namespace's session schedules, for
+	// the requested duration, recording who overrode them and why. The member's role must be allowed to override every
+	// schedule restricting the device. It returns the override created.
+	OverrideSessionSchedule(ctx context.Context, req *requests.DeviceOverrideSessionSchedule) (*models.SessionScheduleOverride, error)
+
+	// ListSessionScheduleOverrides retrieves the overrides of the namespace's session schedules for the tenant's
+	// device, most recent first. It returns the list of overrides, the total count of matched documents and an error
+	// if any.
+	ListSessionScheduleOverrides(ctx context.Context, req *requests.DeviceSessionScheduleOverridesList) ([]models.SessionScheduleOverride, int, error)
+}
+
+func (s *service) EvaluateSessionSchedule(ctx context.Context, req *requests.DeviceEvaluateSessionSchedule) error {
+	schedules, device, err := s.deviceSessionSchedules(ctx, req.TenantID, models.UID(req.UID))
+	if err != nil {
+		return err
+	}
+
+	now := clock.Now()
+
+	allowed := true
+	for _, schedule := range schedules {
+		if !schedule.Allows(now) {
+			allowed = false
+
+			break
+		}
+	}
+
+	if allowed {
+		return nil
+	}
+
+	if _, err := s.store.SessionScheduleOverrideGetActive(ctx, req.TenantID, models.UID(device.UID), now); err == nil {
+		return nil
+	} else if !errors.Is(err, store.ErrNoDocuments) {
+		return err
+	}
+
+	return NewErrSessionScheduleBlock()
+}
+
+func (s *service) OverrideSessionSchedule(ctx context.Context, req *requests.DeviceOverrideSessionSchedule) (*models.SessionScheduleOverride, error) {
+	schedules, device, err := s.deviceSessionSchedules(ctx, req.TenantID, models.UID(req.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedules) == 0 {
+		return nil, NewErrSessionScheduleUnrestricted(models.UID(device.UID))
+	}
+
+	for _, schedule := range schedules {
+		if !schedule.Overridable(req.Role) {
+			return nil, NewErrSessionScheduleOverrideRole()
+		}
+	}
+
+	now := clock.Now()
+	override := &models.SessionScheduleOverride{
+		ID:        uuid.Generate(),
+		TenantID:  req.TenantID,
+		DeviceUID: device.UID,
+		UserID:    req.UserID,
+		Reason:    req.Reason,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Duration(req.Duration) * time.Minute),
+	}
+
+	if err := s.store.SessionScheduleOverrideCreate(ctx, override); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionSessionScheduleOverride, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, map[string]string{
+		"override":   override.ID,
+		"reason":     override.Reason,
+		"expires_at": override.ExpiresAt.UTC().Format(time.RFC3339),
+	}); err != nil {
+		return nil, err
+	}
+
+	return override, nil
+}
+
+func (s *service) ListSessionScheduleOverrides(ctx context.Context, req *requests.DeviceSessionScheduleOverridesList) ([]models.SessionScheduleOverride, int, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID); err != nil {
+		return nil, 0, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	return s.store.SessionScheduleOverrideList(ctx, req.TenantID, models.UID(req.UID), req.Paginator)
+}
+
+// deviceSessionSchedules returns the namespace's session schedules restricting the tenant's device, with the device.
+func (s *service) deviceSessionSchedules(ctx context.Context, tenant string, uid models.UID) ([]models.SessionSchedule, *models.Device, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return nil, nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return nil, nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	schedules := make([]models.SessionSchedule, 0)
+	if namespace.Settings == nil {
+		return schedules, device, nil
+	}
+
+	for _, schedule := range namespace.Settings.SessionSchedules {
+		if schedule.Applies(device.Tags) {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	return schedules, device, nil
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	// alwaysSchedule is a session schedule whose windows cover the whole week.
+	alwaysSchedule = models.SessionSchedule{
+		Timezone: "UTC",
+		Windows: []models.SessionScheduleWindow{
+			{Days: []time.Weekday{0, 1, 2, 3, 4, 5, 6}, Start: "00:00", End: "00:00"},
+		},
+	}
+	// neverSchedule is a session schedule whose windows cover no time of the week.
+	neverSchedule = models.SessionSchedule{
+		Timezone: "UTC",
+		Windows:  []models.SessionScheduleWindow{{Days: []time.Weekday{}, Start: "09:00", End: "18:00"}},
+	}
+)
+
+func TestEvaluateSessionSchedule(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	taggedNeverSchedule := neverSchedule
+	taggedNeverSchedule.Tags = []string{"production"}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceEvaluateSessionSchedule
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			req:         &requests.DeviceEvaluateSessionSchedule{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds when the device isn't restricted by the schedules",
+			req:         &requests.DeviceEvaluateSessionSchedule{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{SessionSchedules: []models.SessionSchedule{taggedNeverSchedule}}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Tags: []string{"staging"}}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the schedules allow the connection",
+			req:         &requests.DeviceEvaluateSessionSchedule{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{SessionSchedules: []models.SessionSchedule{alwaysSchedule}}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the device has an active override",
+			req:         &requests.DeviceEvaluateSessionSchedule{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{SessionSchedules: []models.SessionSchedule{alwaysSchedule, taggedNeverSchedule}}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Tags: []string{"production"}}, nil).
+					Once()
+				storeMock.
+					On("SessionScheduleOverrideGetActive", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), mock.Anything).
+					Return(&models.SessionScheduleOverride{ID: "id"}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the active override cannot be retrieved",
+			req:         &requests.DeviceEvaluateSessionSchedule{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{SessionSchedules: []models.SessionSchedule{neverSchedule}}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("SessionScheduleOverrideGetActive", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), mock.Anything).
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "fails when the schedules don't allow the connection",
+			req:         &requests.DeviceEvaluateSessionSchedule{DeviceParam: requests.DeviceParam{UID: "uid"}, TenantID: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{SessionSchedules: []models.SessionSchedule{alwaysSchedule, neverSchedule}}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				storeMock.
+					On("SessionScheduleOverrideGetActive", ctx, "00000000-0000-4000-0000-000000000000", models.UID("uid"), mock.Anything).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrSessionScheduleBlock(),
+		},
+	}
+
+	clockMock.On("Now").Return(now)
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.EvaluateSessionSchedule(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestOverrideSessionSchedule(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	ownersSchedule := neverSchedule
+	ownersSchedule.Roles = []authorizer.Role{authorizer.RoleOwner}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceOverrideSessionSchedule
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceOverrideSessionSchedule{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Role:        authorizer.RoleAdministrator,
+				Reason:      "incident",
+				Duration:    60,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device isn't restricted by the schedules",
+			req: &requests.DeviceOverrideSessionSchedule{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Role:        authorizer.RoleAdministrator,
+				Reason:      "incident",
+				Duration:    60,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+			},
+			expected: NewErrSessionScheduleUnrestricted(models.UID("uid")),
+		},
+		{
+			description: "fails when the member's role cannot override the schedules",
+			req: &requests.DeviceOverrideSessionSchedule{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Role:        authorizer.RoleAdministrator,
+				Reason:      "incident",
+				Duration:    60,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{SessionSchedules: []models.SessionSchedule{neverSchedule, ownersSchedule}}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+			},
+			expected: NewErrSessionScheduleOverrideRole(),
+		},
+		{
+			description: "succeeds",
+			req: &requests.DeviceOverrideSessionSchedule{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Role:        authorizer.RoleOwner,
+				Reason:      "incident",
+				Duration:    60,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{SessionSchedules: []models.SessionSchedule{neverSchedule, ownersSchedule}}}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("SessionScheduleOverrideCreate", ctx, mock.MatchedBy(func(override *models.SessionScheduleOverride) bool {
+						return override.ID == "00000000-0000-4000-0000-000000000001" &&
+							override.DeviceUID == "uid" &&
+							override.UserID == "000000000000000000000000" &&
+							override.Reason == "incident" &&
+							override.ExpiresAt.Sub(override.CreatedAt) == time.Hour
+					})).
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000002").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionSessionScheduleOverride &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"} &&
+							entry.Details["override"] == "00000000-0000-4000-0000-000000000001" &&
+							entry.Details["reason"] == "incident" &&
+							entry.Details["expires_at"] == now.Add(time.Hour).UTC().Format(time.RFC3339)
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			_, err := s.OverrideSessionSchedule(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
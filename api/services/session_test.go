@@ -251,6 +251,10 @@ func TestDeactivateSession(t *testing.T) {
 			requiredMocks: func() {
 				mock.On("SessionDeleteActives", ctx, models.UID("uid")).
 					Return(nil).Once()
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{}}, nil).Once()
 			},
 			expected: nil,
 		},
@@ -275,6 +279,10 @@ func TestUpdateSession(t *testing.T) {
 	ctx := context.TODO()
 
 	theTrue := true
+	authMethod := "publickey"
+	recordType := models.SessionRecordTypeExec
+	recordHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	recordObject := "tenant/uid.jsonl"
 
 	cases := []struct {
 		name          string
@@ -318,6 +326,62 @@ func TestUpdateSession(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "success to update the session when auth method field is updated",
+			uid:  models.UID("_uid"),
+			model: models.SessionUpdate{
+				AuthMethod: &authMethod,
+			},
+			requiredMocks: func() {
+				sess := &models.Session{}
+
+				mock.On("SessionGet", ctx, models.UID("_uid")).Return(sess, nil).Once()
+				mock.On("SessionUpdate", ctx, models.UID("_uid"), &models.Session{Client: models.SessionClient{AuthMethod: "publickey"}}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "success to update the session when record type field is updated",
+			uid:  models.UID("_uid"),
+			model: models.SessionUpdate{
+				RecordType: &recordType,
+			},
+			requiredMocks: func() {
+				sess := &models.Session{}
+
+				mock.On("SessionGet", ctx, models.UID("_uid")).Return(sess, nil).Once()
+				mock.On("SessionUpdate", ctx, models.UID("_uid"), &models.Session{RecordType: models.SessionRecordTypeExec}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "success to update the session when record hash field is updated",
+			uid:  models.UID("_uid"),
+			model: models.SessionUpdate{
+				RecordHash: &recordHash,
+			},
+			requiredMocks: func() {
+				sess := &models.Session{}
+
+				mock.On("SessionGet", ctx, models.UID("_uid")).Return(sess, nil).Once()
+				mock.On("SessionUpdate", ctx, models.UID("_uid"), &models.Session{RecordHash: recordHash}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "success to update the session when record object field is updated",
+			uid:  models.UID("_uid"),
+			model: models.SessionUpdate{
+				RecordObject: &recordObject,
+			},
+			requiredMocks: func() {
+				sess := &models.Session{}
+
+				mock.On("SessionGet", ctx, models.UID("_uid")).Return(sess, nil).Once()
+				mock.On("SessionUpdate", ctx, models.UID("_uid"), &models.Session{Recorded: true, RecordObject: recordObject}).Return(nil).Once()
+			},
+			expected: nil,
+		},
 		{
 			name: "fails to update the session when authenticated field is updated",
 			uid:  models.UID("_uid"),
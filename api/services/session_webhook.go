@@ -0,0 +1,108 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+type SessionWebhookService interface {
+	// EvaluateSessionWebhook asks the namespace's connection webhook if the session, logging in as the username from
+	// the client's address, to the tenant's device is allowed. Every session is allowed when the namespace has no
+	// webhook. When the webhook doesn't answer in time, or answers an error, the session is allowed only if the
+	// webhook fails open, otherwise it is denied as if the webhook has denied it.
+	EvaluateSessionWebhook(ctx context.Context, req *requests.DeviceEvaluateSessionWebhook) error
+}
+
+func (s *service) EvaluateSessionWebhook(ctx context.Context, req *requests.DeviceEvaluateSessionWebhook) error {
+	namespace, err := s.store.NamespaceGet(ctx, req.TenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	if namespace.Settings == nil || !namespace.Settings.ConnectionWebhook.Enabled() {
+		return nil
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	webhook := namespace.Settings.ConnectionWebhook
+
+	body := &models.ConnectionWebhookRequest{
+		TenantID:  namespace.TenantID,
+		Namespace: namespace.Name,
+		Device:    &models.ConnectionWebhookDevice{UID: device.UID, Name: device.Name},
+		User:      req.Username,
+		SourceIP:  req.IPAddress,
+		Time:      clock.Now(),
+	}
+
+	logger := log.WithFields(log.Fields{"tenant_id": req.TenantID, "uid": req.UID, "username": req.Username})
+
+	res, err := callConnectionWebhook(ctx, webhook, body)
+	if err != nil {
+		if webhook.FailOpen {
+			logger.WithError(err).Warn("failed to call the connection webhook, allowing the session as it fails open")
+
+			return nil
+		}
+
+		logger.WithError(err).Error("failed to call the connection webhook, denying the session as it fails closed")
+
+		return NewErrSessionWebhookBlock()
+	}
+
+	if !res.Allow {
+		logger.WithField("reason", res.Reason).Info("the connection webhook denied the session")
+
+		return NewErrSessionWebhookBlock()
+	}
+
+	return nil
+}
+
+// callConnectionWebhook sends the connection to the webhook, waiting its answer up to the webhook's deadline.
+func callConnectionWebhook(ctx context.Context, webhook *models.ConnectionWebhook, body *models.ConnectionWebhookRequest) (*models.ConnectionWebhookResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhook.Deadline())
+	defer cancel()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("connection webhook: unexpected status %d", res.StatusCode)
+	}
+
+	response := new(models.ConnectionWebhookResponse)
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("connection webhook: invalid response: %w", err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateSessionWebhook(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clockMock := new(clockmock.Clock)
+	backend := clock.DefaultBackend
+	clock.DefaultBackend = clockMock
+	t.Cleanup(func() { clock.DefaultBackend = backend })
+
+	clockMock.On("Now").Return(now)
+
+	req := &requests.DeviceEvaluateSessionWebhook{
+		DeviceParam: requests.DeviceParam{UID: "uid"},
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		Username:    "root",
+		IPAddress:   "192.168.0.1",
+	}
+
+	expected := &models.ConnectionWebhookRequest{
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		Namespace: "namespace",
+		Device:    &models.ConnectionWebhookDevice{UID: "uid", Name: "device"},
+		User:      "root",
+		SourceIP:  "192.168.0.1",
+		Time:      now,
+	}
+
+	// server answers the webhook's requests with the status and the body, after checking the request's body.
+	server := func(t *testing.T, status int, body string, delay time.Duration) string {
+		t.Helper()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received := new(models.ConnectionWebhookRequest)
+			if assert.NoError(t, json.NewDecoder(r.Body).Decode(received)) {
+				assert.Equal(t, expected.TenantID, received.TenantID)
+				assert.Equal(t, expected.Namespace, received.Namespace)
+				assert.Equal(t, expected.Device, received.Device)
+				assert.Equal(t, expected.User, received.User)
+				assert.Equal(t, expected.SourceIP, received.SourceIP)
+				assert.True(t, expected.Time.Equal(received.Time))
+			}
+
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+
+			w.WriteHeader(status)
+			w.Write([]byte(body)) //nolint:errcheck
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv.URL
+	}
+
+	// namespace mocks the namespace, with the webhook, and the device the session is made to.
+	namespace := func(ctx context.Context, webhook *models.ConnectionWebhook) {
+		storeMock.
+			On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+			Return(&models.Namespace{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Name:     "namespace",
+				Settings: &models.NamespaceSettings{ConnectionWebhook: webhook},
+			}, nil).
+			Once()
+		storeMock.
+			On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+			Return(&models.Device{UID: "uid", Name: "device"}, nil).
+			Once()
+	}
+
+	service := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	t.Run("fails when the namespace is not found", func(t *testing.T) {
+		ctx := context.Background()
+
+		storeMock.
+			On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+			Return(nil, store.ErrNoDocuments).
+			Once()
+
+		err := service.EvaluateSessionWebhook(ctx, req)
+		assert.Equal(t, NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments), err)
+	})
+
+	t.Run("succeeds when the namespace has no webhook", func(t *testing.T) {
+		ctx := context.Background()
+
+		storeMock.
+			On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+			Return(&models.Namespace{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Settings: &models.NamespaceSettings{ConnectionWebhook: &models.ConnectionWebhook{URL: ""}},
+			}, nil).
+			Once()
+
+		assert.NoError(t, service.EvaluateSessionWebhook(ctx, req))
+	})
+
+	t.Run("fails when the device is not found", func(t *testing.T) {
+		ctx := context.Background()
+
+		storeMock.
+			On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+			Return(&models.Namespace{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Settings: &models.NamespaceSettings{ConnectionWebhook: &models.ConnectionWebhook{URL: "http://localhost"}},
+			}, nil).
+			Once()
+		storeMock.
+			On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+			Return(nil, store.ErrNoDocuments).
+			Once()
+
+		err := service.EvaluateSessionWebhook(ctx, req)
+		assert.Equal(t, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments), err)
+	})
+
+	t.Run("fails when the webhook denies the session", func(t *testing.T) {
+		ctx := context.Background()
+		namespace(ctx, &models.ConnectionWebhook{
+			URL:      server(t, http.StatusOK, `{"allow": false, "reason": "no approved change ticket"}`, 0),
+			FailOpen: true,
+		})
+
+		assert.Equal(t, NewErrSessionWebhookBlock(), service.EvaluateSessionWebhook(ctx, req))
+	})
+
+	t.Run("succeeds when the webhook allows the session", func(t *testing.T) {
+		ctx := context.Background()
+		namespace(ctx, &models.ConnectionWebhook{URL: server(t, http.StatusOK, `{"allow": true}`, 0)})
+
+		assert.NoError(t, service.EvaluateSessionWebhook(ctx, req))
+	})
+
+	t.Run("fails when the webhook answers an error and fails closed", func(t *testing.T) {
+		ctx := context.Background()
+		namespace(ctx, &models.ConnectionWebhook{URL: server(t, http.StatusInternalServerError, ``, 0)})
+
+		assert.Equal(t, NewErrSessionWebhookBlock(), service.EvaluateSessionWebhook(ctx, req))
+	})
+
+	t.Run("fails when the webhook doesn't answer in time and fails closed", func(t *testing.T) {
+		ctx := context.Background()
+		namespace(ctx, &models.ConnectionWebhook{URL: server(t, http.StatusOK, `{"allow": true}`, 2*time.Second), Timeout: 1})
+
+		assert.Equal(t, NewErrSessionWebhookBlock(), service.EvaluateSessionWebhook(ctx, req))
+	})
+
+	t.Run("succeeds when the webhook doesn't answer in time and fails open", func(t *testing.T) {
+		ctx := context.Background()
+		namespace(ctx, &models.ConnectionWebhook{
+			URL:      server(t, http.StatusOK, `{"allow": false}`, 2*time.Second),
+			Timeout:  1,
+			FailOpen: true,
+		})
+
+		assert.NoError(t, service.EvaluateSessionWebhook(ctx, req))
+	})
+
+	t.Run("succeeds when the webhook answers an invalid response and fails open", func(t *testing.T) {
+		ctx := context.Background()
+		namespace(ctx, &models.ConnectionWebhook{URL: server(t, http.StatusOK, `allow`, 0), FailOpen: true})
+
+		assert.NoError(t, service.EvaluateSessionWebhook(ctx, req))
+	})
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"golang.org/x/crypto/ssh"
+)
+
+type SSHCertificateAuthorityService interface {
+	// GetSSHCertificateAuthorities retrieves the tenant's SSH certificate authorities still trusted, the current one
+	// first.
+	GetSSHCertificateAuthorities(ctx context.Context, req *requests.SSHCertificateAuthoritiesGet) ([]models.SSHCertificateAuthority, error)
+
+	// SetSSHCertificateAuthority sets the tenant's SSH certificate authority, replacing the current one and the rotated
+	// ones at once. It returns the tenant's SSH certificate authorities.
+	SetSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthoritySet) ([]models.SSHCertificateAuthority, error)
+
+	// RotateSSHCertificateAuthority replaces the tenant's current SSH certificate authority, keeping the previous one
+	// trusted for the request's grace period, so the certificates it has signed can be renewed meanwhile. It returns
+	// the tenant's SSH certificate authorities.
+	RotateSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthorityRotate) ([]models.SSHCertificateAuthority, error)
+
+	// DeleteSSHCertificateAuthority stops trusting the tenant's SSH certificate authorities, including the rotated
+	// ones, so the user certificates are no longer accepted.
+	DeleteSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthorityDelete) error
+}
+
+func (s *service) GetSSHCertificateAuthorities(ctx context.Context, req *requests.SSHCertificateAuthoritiesGet) ([]models.SSHCertificateAuthority, error) {
+	namespace, err := s.store.NamespaceGet(ctx, req.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	return trustedSSHCertificateAuthorities(namespace, clock.Now()), nil
+}
+
+func (s *service) SetSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthoritySet) ([]models.SSHCertificateAuthority, error) {
+	if _, err := s.store.NamespaceGet(ctx, req.TenantID); err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	ca, err := newSSHCertificateAuthority(req.PublicKey, clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	authorities := []models.SSHCertificateAuthority{*ca}
+	if err := s.store.NamespaceEdit(ctx, req.TenantID, &models.NamespaceChanges{SSHCertificateAuthorities: &authorities}); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionSSHCASet, models.AuditTarget{Type: models.AuditTargetSSHCA, ID: ca.Fingerprint}, nil); err != nil {
+		return nil, err
+	}
+
+	return authorities, nil
+}
+
+func (s *service) RotateSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthorityRotate) ([]models.SSHCertificateAuthority, error) {
+	namespace, err := s.store.NamespaceGet(ctx, req.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	now := clock.Now()
+
+	trusted := trustedSSHCertificateAuthorities(namespace, now)
+	if len(trusted) == 0 || trusted[0].ExpiresAt != nil {
+		return nil, NewErrSSHCANotFound(req.TenantID, nil)
+	}
+
+	ca, err := newSSHCertificateAuthority(req.PublicKey, now)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := trusted[0]
+	if previous.Fingerprint == ca.Fingerprint {
+		return nil, NewErrSSHCADuplicated(ca.Fingerprint)
+	}
+
+	authorities := []models.SSHCertificateAuthority{*ca}
+	if req.GracePeriod > 0 {
+		expiresAt := now.Add(time.Duration(req.GracePeriod) * time.Second)
+		previous.ExpiresAt = &expiresAt
+
+		authorities = append(authorities, previous)
+	}
+
+	// NOTICE: the CAs rotated before are kept until their own grace period ends, unless they are the new current one.
+	for _, authority := range trusted[1:] {
+		if authority.Fingerprint != ca.Fingerprint {
+			authorities = append(authorities, authority)
+		}
+	}
+
+	if err := s.store.NamespaceEdit(ctx, req.TenantID, &models.NamespaceChanges{SSHCertificateAuthorities: &authorities}); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionSSHCARotate, models.AuditTarget{Type: models.AuditTargetSSHCA, ID: ca.Fingerprint}, map[string]string{
+		"previous":     previous.Fingerprint,
+		"grace_period": strconv.Itoa(req.GracePeriod),
+	}); err != nil {
+		return nil, err
+	}
+
+	return authorities, nil
+}
+
+func (s *service) DeleteSSHCertificateAuthority(ctx context.Context, req *requests.SSHCertificateAuthorityDelete) error {
+	namespace, err := s.store.NamespaceGet(ctx, req.TenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	trusted := trustedSSHCertificateAuthorities(namespace, clock.Now())
+	if len(trusted) == 0 {
+		return NewErrSSHCANotFound(req.TenantID, nil)
+	}
+
+	authorities := []models.SSHCertificateAuthority{}
+	if err := s.store.NamespaceEdit(ctx, req.TenantID, &models.NamespaceChanges{SSHCertificateAuthorities: &authorities}); err != nil {
+		return err
+	}
+
+	for _, authority := range trusted {
+		if err := s.recordAudit(ctx, req.TenantID, models.AuditActionSSHCADelete, models.AuditTarget{Type: models.AuditTargetSSHCA, ID: authority.Fingerprint}, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newSSHCertificateAuthority parses the CA's public key, in the authorized_keys format, into a current CA. The key's
+// comment and options are dropped.
+func newSSHCertificateAuthority(data string, now time.Time) (*models.SSHCertificateAuthority, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(data)) //nolint:dogsled
+	if err != nil {
+		return nil, NewErrSSHCAInvalid(err)
+	}
+
+	if _, ok := key.(*ssh.Certificate); ok {
+		return nil, NewErrSSHCAInvalid(errors.New("the public key is a certificate"))
+	}
+
+	return &models.SSHCertificateAuthority{
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		Fingerprint: ssh.FingerprintSHA256(key),
+		CreatedAt:   now,
+	}, nil
+}
+
+// trustedSSHCertificateAuthorities returns the namespace's SSH certificate authorities still trusted at now.
+func trustedSSHCertificateAuthorities(namespace *models.Namespace, now time.Time) []models.SSHCertificateAuthority {
+	trusted := []models.SSHCertificateAuthority{}
+	if namespace.Settings == nil {
+		return trusted
+	}
+
+	for _, authority := range namespace.Settings.SSHCertificateAuthorities {
+		if authority.Trusted(now) {
+			trusted = append(trusted, authority)
+		}
+	}
+
+	return trusted
+}
@@ -0,0 +1,402 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// generateSSHCertificateAuthority generates a CA's key, returning it in the authorized_keys format with the CA that
+// the service creates from it.
+func generateSSHCertificateAuthority(t *testing.T) (string, models.SSHCertificateAuthority) {
+	t.Helper()
+
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := ssh.NewPublicKey(public)
+	require.NoError(t, err)
+
+	data := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+
+	return data + " ca@example.com", models.SSHCertificateAuthority{
+		PublicKey:   data,
+		Fingerprint: ssh.FingerprintSHA256(key),
+		CreatedAt:   now,
+	}
+}
+
+func TestGetSSHCertificateAuthorities(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	_, current := generateSSHCertificateAuthority(t)
+	_, rotated := generateSSHCertificateAuthority(t)
+	_, expired := generateSSHCertificateAuthority(t)
+
+	rotatedExpiresAt := now.Add(time.Hour)
+	rotated.ExpiresAt = &rotatedExpiresAt
+	expiredExpiresAt := now.Add(-time.Hour)
+	expired.ExpiresAt = &expiredExpiresAt
+
+	type Expected struct {
+		authorities []models.SSHCertificateAuthority
+		err         error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{err: NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds without the expired authorities",
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						Settings: &models.NamespaceSettings{
+							SSHCertificateAuthorities: []models.SSHCertificateAuthority{current, rotated, expired},
+						},
+					}, nil).
+					Once()
+			},
+			expected: Expected{authorities: []models.SSHCertificateAuthority{current, rotated}},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, nil)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			authorities, err := s.GetSSHCertificateAuthorities(context.TODO(), &requests.SSHCertificateAuthoritiesGet{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+			})
+			assert.Equal(t, tc.expected, Expected{authorities, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestSetSSHCertificateAuthority(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001")
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	data, ca := generateSSHCertificateAuthority(t)
+
+	cases := []struct {
+		description   string
+		publicKey     string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			publicKey:   data,
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the public key is invalid",
+			publicKey:   "ssh-ed25519 invalid",
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{}, nil).
+					Once()
+			},
+			expected: NewErrSSHCAInvalid(errors.New("ssh: no key found")),
+		},
+		{
+			description: "succeeds",
+			publicKey:   data,
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{}, nil).
+					Once()
+				storeMock.
+					On("NamespaceEdit", mock.Anything, "00000000-0000-4000-0000-000000000000", &models.NamespaceChanges{
+						SSHCertificateAuthorities: &[]models.SSHCertificateAuthority{ca},
+					}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", mock.Anything, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionSSHCASet && entry.Target.ID == ca.Fingerprint
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, nil)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			authorities, err := s.SetSSHCertificateAuthority(context.TODO(), &requests.SSHCertificateAuthoritySet{
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				PublicKey: tc.publicKey,
+			})
+			if tc.expected != nil {
+				assert.Equal(t, tc.expected, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, []models.SSHCertificateAuthority{ca}, authorities)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRotateSSHCertificateAuthority(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001")
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	currentData, current := generateSSHCertificateAuthority(t)
+	data, ca := generateSSHCertificateAuthority(t)
+
+	namespace := &models.Namespace{
+		Settings: &models.NamespaceSettings{SSHCertificateAuthorities: []models.SSHCertificateAuthority{current}},
+	}
+
+	expiresAt := now.Add(time.Hour)
+	rotated := current
+	rotated.ExpiresAt = &expiresAt
+
+	type Expected struct {
+		authorities []models.SSHCertificateAuthority
+		err         error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.SSHCertificateAuthorityRotate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace has no authority",
+			req:         &requests.SSHCertificateAuthorityRotate{TenantID: "00000000-0000-4000-0000-000000000000", PublicKey: data},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{}, nil).
+					Once()
+			},
+			expected: Expected{err: NewErrSSHCANotFound("00000000-0000-4000-0000-000000000000", nil)},
+		},
+		{
+			description: "fails when the authority is the current one",
+			req:         &requests.SSHCertificateAuthorityRotate{TenantID: "00000000-0000-4000-0000-000000000000", PublicKey: currentData},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+			},
+			expected: Expected{err: NewErrSSHCADuplicated(current.Fingerprint)},
+		},
+		{
+			description: "succeeds stopping to trust the previous authority at once",
+			req:         &requests.SSHCertificateAuthorityRotate{TenantID: "00000000-0000-4000-0000-000000000000", PublicKey: data},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+				storeMock.
+					On("NamespaceEdit", mock.Anything, "00000000-0000-4000-0000-000000000000", &models.NamespaceChanges{
+						SSHCertificateAuthorities: &[]models.SSHCertificateAuthority{ca},
+					}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", mock.Anything, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{authorities: []models.SSHCertificateAuthority{ca}},
+		},
+		{
+			description: "succeeds trusting the previous authority for the grace period",
+			req:         &requests.SSHCertificateAuthorityRotate{TenantID: "00000000-0000-4000-0000-000000000000", PublicKey: data, GracePeriod: 3600},
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+				storeMock.
+					On("NamespaceEdit", mock.Anything, "00000000-0000-4000-0000-000000000000", &models.NamespaceChanges{
+						SSHCertificateAuthorities: &[]models.SSHCertificateAuthority{ca, rotated},
+					}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", mock.Anything, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionSSHCARotate && entry.Details["previous"] == current.Fingerprint
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{authorities: []models.SSHCertificateAuthority{ca, rotated}},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, nil)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			authorities, err := s.RotateSSHCertificateAuthority(context.TODO(), tc.req)
+			if tc.expected.err != nil {
+				assert.Equal(t, tc.expected.err, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.authorities, authorities)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteSSHCertificateAuthority(t *testing.T) {
+	storeMock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001")
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	_, current := generateSSHCertificateAuthority(t)
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace has no authority",
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{}, nil).
+					Once()
+			},
+			expected: NewErrSSHCANotFound("00000000-0000-4000-0000-000000000000", nil),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				storeMock.
+					On("NamespaceGet", mock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						Settings: &models.NamespaceSettings{SSHCertificateAuthorities: []models.SSHCertificateAuthority{current}},
+					}, nil).
+					Once()
+				storeMock.
+					On("NamespaceEdit", mock.Anything, "00000000-0000-4000-0000-000000000000", &models.NamespaceChanges{
+						SSHCertificateAuthorities: &[]models.SSHCertificateAuthority{},
+					}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", mock.Anything, mock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, nil)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.DeleteSSHCertificateAuthority(context.TODO(), &requests.SSHCertificateAuthorityDelete{
+				TenantID: "00000000-0000-4000-0000-000000000000",
+			})
+			if tc.expected != nil {
+				assert.Equal(t, tc.expected, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
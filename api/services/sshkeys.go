@@ -6,13 +6,18 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
+	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/api/responses"
 	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/keysource"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"golang.org/x/crypto/ssh"
 )
@@ -20,9 +25,14 @@ import (
 type SSHKeysService interface {
 	EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error)
 	EvaluateKeyUsername(ctx context.Context, key *models.PublicKey, username string) (bool, error)
+	// TestPublicKey checks whether the tenant's public key would authorize the access, with the username, to the
+	// device, found by its UID or name, explaining the result of each check done by [EvaluateKeyUsername] and
+	// [EvaluateKeyFilter].
+	TestPublicKey(ctx context.Context, req *requests.PublicKeyTest) (*responses.PublicKeyTest, error)
 	ListPublicKeys(ctx context.Context, paginator query.Paginator) ([]models.PublicKey, int, error)
 	GetPublicKey(ctx context.Context, fingerprint, tenant string) (*models.PublicKey, error)
 	CreatePublicKey(ctx context.Context, req requests.PublicKeyCreate, tenant string) (*responses.PublicKeyCreate, error)
+	ImportPublicKeys(ctx context.Context, req requests.PublicKeyImport) (*responses.PublicKeyImport, error)
 	UpdatePublicKey(ctx context.Context, fingerprint, tenant string, key requests.PublicKeyUpdate) (*models.PublicKey, error)
 	DeletePublicKey(ctx context.Context, fingerprint, tenant string) error
 	CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error)
@@ -41,13 +51,8 @@ func (s *service) EvaluateKeyFilter(_ context.Context, key *models.PublicKey, de
 
 		return ok, nil
 	} else if len(key.Filter.Tags) > 0 {
-		for _, tag := range dev.Tags {
-			if contains(key.Filter.Tags, tag) {
-				return true, nil
-			}
-		}
-
-		return false, nil
+		// NOTICE: a filter targeting a tag also matches devices with any of its descendants.
+		return models.TagsMatch(dev.Tags, key.Filter.Tags), nil
 	}
 
 	return true, nil
@@ -66,6 +71,55 @@ func (s *service) EvaluateKeyUsername(_ context.Context, key *models.PublicKey,
 	return ok, nil
 }
 
+func (s *service) TestPublicKey(ctx context.Context, req *requests.PublicKeyTest) (*responses.PublicKeyTest, error) {
+	key, err := s.store.PublicKeyGet(ctx, req.Fingerprint, req.TenantID)
+	if err != nil {
+		return nil, NewErrPublicKeyNotFound(req.Fingerprint, err)
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.Device), req.TenantID)
+	if errors.Is(err, store.ErrNoDocuments) {
+		device, err = s.store.DeviceGetByName(ctx, req.Device, req.TenantID, models.DeviceStatusAccepted)
+	}
+
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.Device), err)
+	}
+
+	res := &responses.PublicKeyTest{DeviceUID: device.UID, DeviceName: device.Name}
+
+	switch ok, err := s.EvaluateKeyUsername(ctx, key, req.Username); {
+	case err != nil:
+		res.Username = responses.PublicKeyCheck{Allowed: false, Reason: fmt.Sprintf("the key's username pattern %q is invalid", key.Username)}
+	case key.Username == "":
+		res.Username = responses.PublicKeyCheck{Allowed: true, Reason: "the key allows any username"}
+	case ok:
+		res.Username = responses.PublicKeyCheck{Allowed: true, Reason: fmt.Sprintf("the username %q matches the key's username pattern %q", req.Username, key.Username)}
+	default:
+		res.Username = responses.PublicKeyCheck{Allowed: false, Reason: fmt.Sprintf("the username %q doesn't match the key's username pattern %q", req.Username, key.Username)}
+	}
+
+	ok, err := s.EvaluateKeyFilter(ctx, key, *device)
+	switch {
+	case err != nil:
+		res.Filter = responses.PublicKeyCheck{Allowed: false, Reason: fmt.Sprintf("the key's hostname pattern %q is invalid", key.Filter.Hostname)}
+	case key.Filter.Hostname != "" && ok:
+		res.Filter = responses.PublicKeyCheck{Allowed: true, Reason: fmt.Sprintf("the device's name %q matches the key's hostname pattern %q", device.Name, key.Filter.Hostname)}
+	case key.Filter.Hostname != "":
+		res.Filter = responses.PublicKeyCheck{Allowed: false, Reason: fmt.Sprintf("the device's name %q doesn't match the key's hostname pattern %q", device.Name, key.Filter.Hostname)}
+	case len(key.Filter.Tags) > 0 && ok:
+		res.Filter = responses.PublicKeyCheck{Allowed: true, Reason: fmt.Sprintf("the device's tags %v include one of the key's tags %v", device.Tags, key.Filter.Tags)}
+	case len(key.Filter.Tags) > 0:
+		res.Filter = responses.PublicKeyCheck{Allowed: false, Reason: fmt.Sprintf("the device's tags %v don't include any of the key's tags %v", device.Tags, key.Filter.Tags)}
+	default:
+		res.Filter = responses.PublicKeyCheck{Allowed: true, Reason: "the key allows any device"}
+	}
+
+	res.Allowed = res.Username.Allowed && res.Filter.Allowed
+
+	return res, nil
+}
+
 func (s *service) GetPublicKey(ctx context.Context, fingerprint, tenant string) (*models.PublicKey, error) {
 	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
 		return nil, NewErrNamespaceNotFound(tenant, err)
@@ -126,6 +180,10 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 		return nil, err
 	}
 
+	if err := s.recordAudit(ctx, tenant, models.AuditActionPublicKeyCreate, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: model.Fingerprint}, map[string]string{"name": model.Name}); err != nil {
+		return nil, err
+	}
+
 	return &responses.PublicKeyCreate{
 		Data:        model.Data,
 		Filter:      responses.PublicKeyFilter(model.Filter),
@@ -167,7 +225,16 @@ func (s *service) UpdatePublicKey(ctx context.Context, fingerprint, tenant strin
 		},
 	}
 
-	return s.store.PublicKeyUpdate(ctx, fingerprint, tenant, &model)
+	updated, err := s.store.PublicKeyUpdate(ctx, fingerprint, tenant, &model)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, tenant, models.AuditActionPublicKeyUpdate, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, map[string]string{"name": key.Name}); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
 func (s *service) DeletePublicKey(ctx context.Context, fingerprint, tenant string) error {
@@ -179,7 +246,11 @@ func (s *service) DeletePublicKey(ctx context.Context, fingerprint, tenant strin
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	return s.store.PublicKeyDelete(ctx, fingerprint, tenant)
+	if err := s.store.PublicKeyDelete(ctx, fingerprint, tenant); err != nil {
+		return err
+	}
+
+	return s.recordAudit(ctx, tenant, models.AuditActionPublicKeyDelete, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, nil)
 }
 
 func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error) {
@@ -208,3 +279,123 @@ func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, err
 
 	return privateKey, nil
 }
+
+// PublicKeyImportMaxPerUser is the maximum number of keys imported from each provider's user.
+const PublicKeyImportMaxPerUser = 50
+
+// ImportPublicKeys fetches the public keys published by each username on the provider and creates them on the
+// namespace, naming them and setting their username from the request's templates. Keys already on the namespace, or
+// repeated on the import, are skipped.
+//
+// A failure on a single username or key doesn't stop the import; it is reported on the result of each one.
+func (s *service) ImportPublicKeys(ctx context.Context, req requests.PublicKeyImport) (*responses.PublicKeyImport, error) {
+	if req.Filter.Tags != nil {
+		tags, _, err := s.store.TagsGet(ctx, req.TenantID)
+		if err != nil {
+			return nil, NewErrTagEmpty(req.TenantID, err)
+		}
+
+		for _, tag := range req.Filter.Tags {
+			if !contains(tags, tag) {
+				return nil, NewErrTagNotFound(tag, nil)
+			}
+		}
+	}
+
+	name := req.Name
+	if name == "" {
+		name = "{username}-{index}"
+	}
+
+	res := &responses.PublicKeyImport{Results: make([]responses.PublicKeyImportResult, 0)}
+	seen := make(map[string]bool)
+
+	for _, username := range req.Usernames {
+		data, err := s.keys.Fetch(ctx, keysource.Provider(req.Provider), username)
+		if err != nil {
+			res.Results = append(res.Results, responses.PublicKeyImportResult{
+				Username: username,
+				Status:   responses.PublicKeyImportFailed,
+				Error:    err.Error(),
+			})
+
+			continue
+		}
+
+		index := 0
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.TrimSpace(line) == "" || index >= PublicKeyImportMaxPerUser {
+				continue
+			}
+
+			index++
+
+			result := responses.PublicKeyImportResult{
+				Username: username,
+				Name:     strings.NewReplacer("{username}", username, "{index}", strconv.Itoa(index)).Replace(name),
+			}
+
+			key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line)) //nolint:dogsled
+			if err != nil {
+				result.Status = responses.PublicKeyImportFailed
+				result.Error = ErrPublicKeyDataInvalid.Error()
+				res.Results = append(res.Results, result)
+
+				continue
+			}
+
+			result.Fingerprint = ssh.FingerprintLegacyMD5(key)
+
+			if seen[result.Fingerprint] {
+				result.Status = responses.PublicKeyImportDuplicated
+				res.Results = append(res.Results, result)
+
+				continue
+			}
+
+			seen[result.Fingerprint] = true
+
+			switch existing, err := s.store.PublicKeyGet(ctx, result.Fingerprint, req.TenantID); {
+			case err != nil && !errors.Is(err, store.ErrNoDocuments):
+				result.Status = responses.PublicKeyImportFailed
+				result.Error = err.Error()
+			case existing != nil:
+				result.Status = responses.PublicKeyImportDuplicated
+			default:
+				model := &models.PublicKey{
+					Data:        ssh.MarshalAuthorizedKey(key),
+					Fingerprint: result.Fingerprint,
+					CreatedAt:   clock.Now(),
+					TenantID:    req.TenantID,
+					PublicKeyFields: models.PublicKeyFields{
+						Name:     result.Name,
+						Username: strings.ReplaceAll(req.Username, "{username}", regexp.QuoteMeta(username)),
+						Filter: models.PublicKeyFilter{
+							Hostname: req.Filter.Hostname,
+							Tags:     req.Filter.Tags,
+						},
+					},
+				}
+
+				if err := s.store.PublicKeyCreate(ctx, model); err != nil {
+					result.Status = responses.PublicKeyImportFailed
+					result.Error = err.Error()
+				} else {
+					result.Status = responses.PublicKeyImportCreated
+					res.Created++
+
+					if err := s.recordAudit(ctx, req.TenantID, models.AuditActionPublicKeyCreate, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: model.Fingerprint}, map[string]string{
+						"name":     model.Name,
+						"provider": req.Provider,
+					}); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			res.Results = append(res.Results, result)
+		}
+	}
+
+	return res, nil
+}
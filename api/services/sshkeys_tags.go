@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"strings"
 
 	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 type SSHKeysTagsService interface {
@@ -54,7 +56,7 @@ func (s *service) AddPublicKeyTag(ctx context.Context, tenant, fingerprint, tag
 		}
 	}
 
-	return nil
+	return s.recordAudit(ctx, tenant, models.AuditActionPublicKeyTagAdd, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, map[string]string{"tag": tag})
 }
 
 // RemovePublicKeyTag trys to remove a tag from the models.PublicKey, when its filter is from Tags type.
@@ -84,7 +86,7 @@ func (s *service) RemovePublicKeyTag(ctx context.Context, tenant, fingerprint, t
 		return err
 	}
 
-	return nil
+	return s.recordAudit(ctx, tenant, models.AuditActionPublicKeyTagRemove, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, map[string]string{"tag": tag})
 }
 
 // UpdatePublicKeyTags trys to update the tags of the models.PublicKey, when its filter is from Tags type.
@@ -138,5 +140,5 @@ func (s *service) UpdatePublicKeyTags(ctx context.Context, tenant, fingerprint s
 		return err
 	}
 
-	return nil
+	return s.recordAudit(ctx, tenant, models.AuditActionPublicKeyTagsUpdate, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, map[string]string{"tags": strings.Join(tags, ",")})
 }
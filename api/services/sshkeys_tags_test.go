@@ -10,6 +10,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
 )
 
 func TestAddPublicKeyTag(t *testing.T) {
@@ -148,6 +149,7 @@ func TestAddPublicKeyTag(t *testing.T) {
 				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
 				mock.On("TagsGet", ctx, "tenant").Return(tags, len(tags), nil).Once()
 				mock.On("PublicKeyPushTag", ctx, "tenant", "fingerprint", "tag").Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -274,6 +276,7 @@ func TestRemovePublicKeyTag(t *testing.T) {
 				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
 				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
 				mock.On("PublicKeyPullTag", ctx, "tenant", "fingerprint", "tag").Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -428,6 +431,7 @@ func TestUpdatePublicKeyTags(t *testing.T) {
 				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
 				mock.On("TagsGet", ctx, "tenant").Return(tags, len(tags), nil).Once()
 				mock.On("PublicKeySetTags", ctx, "tenant", "fingerprint", []string{"tag1", "tag2", "tag3"}).Return(int64(1), int64(1), nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"testing"
 
 	"github.com/shellhub-io/shellhub/api/store"
@@ -12,8 +14,11 @@ import (
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/keysource"
+	keysourcemocks "github.com/shellhub-io/shellhub/pkg/keysource/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -106,6 +111,38 @@ func TestEvaluateKeyFilter(t *testing.T) {
 			},
 			expected: Expected{true, nil},
 		},
+		{
+			description: "success to evaluate filter tags when device has a descendant tag",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Tags: []string{"region"},
+					},
+				},
+			},
+			device: models.Device{
+				Tags: []string{"region/europe"},
+			},
+			requiredMocks: func() {
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "fail to evaluate filter tags when device has an ancestor tag",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Tags: []string{"region/europe"},
+					},
+				},
+			},
+			device: models.Device{
+				Tags: []string{"region", "regionx"},
+			},
+			requiredMocks: func() {
+			},
+			expected: Expected{false, nil},
+		},
 		{
 			description: "success to evaluate when key has no filter",
 			key: &models.PublicKey{
@@ -133,6 +170,131 @@ func TestEvaluateKeyFilter(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestTestPublicKey(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+
+	type Expected struct {
+		res *responses.PublicKeyTest
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.PublicKeyTest
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the public key is not found",
+			req:         &requests.PublicKeyTest{FingerprintParam: requests.FingerprintParam{Fingerprint: "fingerprint"}, TenantID: tenant, Device: "device", Username: "root"},
+			requiredMocks: func() {
+				storeMock.On("PublicKeyGet", ctx, "fingerprint", tenant).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrPublicKeyNotFound("fingerprint", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the device is not found",
+			req:         &requests.PublicKeyTest{FingerprintParam: requests.FingerprintParam{Fingerprint: "fingerprint"}, TenantID: tenant, Device: "device", Username: "root"},
+			requiredMocks: func() {
+				storeMock.On("PublicKeyGet", ctx, "fingerprint", tenant).Return(&models.PublicKey{Fingerprint: "fingerprint"}, nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("device"), tenant).Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceGetByName", ctx, "device", tenant, models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("device"), store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds refusing the username that doesn't match the pattern",
+			req:         &requests.PublicKeyTest{FingerprintParam: requests.FingerprintParam{Fingerprint: "fingerprint"}, TenantID: tenant, Device: "uid", Username: "root"},
+			requiredMocks: func() {
+				storeMock.
+					On("PublicKeyGet", ctx, "fingerprint", tenant).
+					Return(&models.PublicKey{Fingerprint: "fingerprint", PublicKeyFields: models.PublicKeyFields{Username: "^admin$"}}, nil).
+					Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), tenant).Return(&models.Device{UID: "uid", Name: "device"}, nil).Once()
+			},
+			expected: Expected{
+				&responses.PublicKeyTest{
+					Allowed:    false,
+					DeviceUID:  "uid",
+					DeviceName: "device",
+					Username:   responses.PublicKeyCheck{Allowed: false, Reason: `the username "root" doesn't match the key's username pattern "^admin$"`},
+					Filter:     responses.PublicKeyCheck{Allowed: true, Reason: "the key allows any device"},
+				},
+				nil,
+			},
+		},
+		{
+			description: "succeeds refusing the device that doesn't match the hostname pattern",
+			req:         &requests.PublicKeyTest{FingerprintParam: requests.FingerprintParam{Fingerprint: "fingerprint"}, TenantID: tenant, Device: "device", Username: "root"},
+			requiredMocks: func() {
+				storeMock.
+					On("PublicKeyGet", ctx, "fingerprint", tenant).
+					Return(&models.PublicKey{Fingerprint: "fingerprint", PublicKeyFields: models.PublicKeyFields{Filter: models.PublicKeyFilter{Hostname: "^web-"}}}, nil).
+					Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("device"), tenant).Return(nil, store.ErrNoDocuments).Once()
+				storeMock.
+					On("DeviceGetByName", ctx, "device", tenant, models.DeviceStatusAccepted).
+					Return(&models.Device{UID: "uid", Name: "device"}, nil).
+					Once()
+			},
+			expected: Expected{
+				&responses.PublicKeyTest{
+					Allowed:    false,
+					DeviceUID:  "uid",
+					DeviceName: "device",
+					Username:   responses.PublicKeyCheck{Allowed: true, Reason: "the key allows any username"},
+					Filter:     responses.PublicKeyCheck{Allowed: false, Reason: `the device's name "device" doesn't match the key's hostname pattern "^web-"`},
+				},
+				nil,
+			},
+		},
+		{
+			description: "succeeds allowing the access",
+			req:         &requests.PublicKeyTest{FingerprintParam: requests.FingerprintParam{Fingerprint: "fingerprint"}, TenantID: tenant, Device: "uid", Username: "root"},
+			requiredMocks: func() {
+				storeMock.
+					On("PublicKeyGet", ctx, "fingerprint", tenant).
+					Return(&models.PublicKey{
+						Fingerprint:     "fingerprint",
+						PublicKeyFields: models.PublicKeyFields{Username: ".*", Filter: models.PublicKeyFilter{Tags: []string{"prod"}}},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), tenant).
+					Return(&models.Device{UID: "uid", Name: "device", Tags: []string{"prod"}}, nil).
+					Once()
+			},
+			expected: Expected{
+				&responses.PublicKeyTest{
+					Allowed:    true,
+					DeviceUID:  "uid",
+					DeviceName: "device",
+					Username:   responses.PublicKeyCheck{Allowed: true, Reason: `the username "root" matches the key's username pattern ".*"`},
+					Filter:     responses.PublicKeyCheck{Allowed: true, Reason: "the device's tags [prod] include one of the key's tags [prod]"},
+				},
+				nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			res, err := s.TestPublicKey(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{res, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
 func TestListPublicKeys(t *testing.T) {
 	mock := &mocks.Store{}
 
@@ -364,6 +526,7 @@ func TestUpdatePublicKeys(t *testing.T) {
 
 				mock.On("TagsGet", ctx, "tenant").Return([]string{"tag1", "tag2"}, 2, nil).Once()
 				mock.On("PublicKeyUpdate", ctx, "fingerprint", "tenant", &model).Return(keyUpdateWithTagsModel, nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: Expected{&models.PublicKey{
 				PublicKeyFields: models.PublicKeyFields{
@@ -421,6 +584,7 @@ func TestUpdatePublicKeys(t *testing.T) {
 					},
 				}
 				mock.On("PublicKeyUpdate", ctx, "fingerprint", "tenant", &model).Return(keyUpdateWithHostnameModel, nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: Expected{&models.PublicKey{
 				PublicKeyFields: models.PublicKeyFields{
@@ -529,6 +693,7 @@ func TestDeletePublicKeys(t *testing.T) {
 						PublicKeyFields: models.PublicKeyFields{Name: "teste"},
 					}, nil).Once()
 				mock.On("PublicKeyDelete", ctx, "fingerprint", "tenant1").Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: Expected{nil},
 		},
@@ -765,6 +930,7 @@ func TestCreatePublicKeys(t *testing.T) {
 
 				mock.On("PublicKeyGet", ctx, keyWithHostname.Fingerprint, "tenant").Return(nil, nil).Once()
 				mock.On("PublicKeyCreate", ctx, &keyWithHostnameModel).Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: Expected{&responses.PublicKeyCreate{
 				Data: models.PublicKey{
@@ -910,6 +1076,7 @@ func TestCreatePublicKeys(t *testing.T) {
 				mock.On("TagsGet", ctx, keyWithTags.TenantID).Return([]string{"tag1", "tag2"}, 2, nil).Once()
 				mock.On("PublicKeyGet", ctx, keyWithTags.Fingerprint, "tenant").Return(nil, nil).Once()
 				mock.On("PublicKeyCreate", ctx, &keyWithTagsModel).Return(nil).Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: Expected{&responses.PublicKeyCreate{
 				Data: models.PublicKey{
@@ -993,3 +1160,130 @@ func TestCreatePublicKeys(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestImportPublicKeys(t *testing.T) {
+	storeMock := new(mocks.Store)
+	fetcherMock := new(keysourcemocks.Fetcher)
+
+	ctx := context.TODO()
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, WithKeySource(fetcherMock))
+
+	_, first, _ := ed25519.GenerateKey(rand.Reader)
+	firstKey, _ := ssh.NewPublicKey(first.Public())
+
+	_, second, _ := ed25519.GenerateKey(rand.Reader)
+	secondKey, _ := ssh.NewPublicKey(second.Public())
+
+	data := append(append(ssh.MarshalAuthorizedKey(firstKey), ssh.MarshalAuthorizedKey(secondKey)...), ssh.MarshalAuthorizedKey(firstKey)...)
+
+	cases := []struct {
+		description   string
+		req           requests.PublicKeyImport
+		requiredMocks func()
+		expected      *responses.PublicKeyImport
+		err           error
+	}{
+		{
+			description: "fails when a tag does not exist",
+			req: requests.PublicKeyImport{
+				Provider:  "github",
+				Usernames: []string{"john"},
+				Username:  ".*",
+				Filter:    requests.PublicKeyFilter{Tags: []string{"tag1"}},
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func() {
+				storeMock.On("TagsGet", ctx, "00000000-0000-4000-0000-000000000000").Return([]string{"tag2"}, 1, nil).Once()
+			},
+			expected: nil,
+			err:      NewErrTagNotFound("tag1", nil),
+		},
+		{
+			description: "reports the username when its keys cannot be fetched",
+			req: requests.PublicKeyImport{
+				Provider:  "github",
+				Usernames: []string{"john"},
+				Username:  ".*",
+				Filter:    requests.PublicKeyFilter{Hostname: ".*"},
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func() {
+				fetcherMock.On("Fetch", ctx, keysource.ProviderGitHub, "john").Return(nil, keysource.ErrUserNotFound).Once()
+			},
+			expected: &responses.PublicKeyImport{
+				Created: 0,
+				Results: []responses.PublicKeyImportResult{
+					{Username: "john", Status: responses.PublicKeyImportFailed, Error: keysource.ErrUserNotFound.Error()},
+				},
+			},
+			err: nil,
+		},
+		{
+			description: "succeeds deduplicating the keys by fingerprint",
+			req: requests.PublicKeyImport{
+				Provider:  "github",
+				Usernames: []string{"john"},
+				Username:  "{username}",
+				Filter:    requests.PublicKeyFilter{Hostname: ".*"},
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func() {
+				fetcherMock.On("Fetch", ctx, keysource.ProviderGitHub, "john").Return(data, nil).Once()
+
+				storeMock.
+					On("PublicKeyGet", ctx, ssh.FingerprintLegacyMD5(firstKey), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("PublicKeyCreate", ctx, &models.PublicKey{
+						Data:        ssh.MarshalAuthorizedKey(firstKey),
+						Fingerprint: ssh.FingerprintLegacyMD5(firstKey),
+						CreatedAt:   now,
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						PublicKeyFields: models.PublicKeyFields{
+							Name:     "john-1",
+							Username: "john",
+							Filter:   models.PublicKeyFilter{Hostname: ".*"},
+						},
+					}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).
+					Return(nil).
+					Once()
+
+				storeMock.
+					On("PublicKeyGet", ctx, ssh.FingerprintLegacyMD5(secondKey), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{}, nil).
+					Once()
+			},
+			expected: &responses.PublicKeyImport{
+				Created: 1,
+				Results: []responses.PublicKeyImportResult{
+					{Username: "john", Name: "john-1", Fingerprint: ssh.FingerprintLegacyMD5(firstKey), Status: responses.PublicKeyImportCreated},
+					{Username: "john", Name: "john-2", Fingerprint: ssh.FingerprintLegacyMD5(secondKey), Status: responses.PublicKeyImportDuplicated},
+					{Username: "john", Name: "john-3", Fingerprint: ssh.FingerprintLegacyMD5(firstKey), Status: responses.PublicKeyImportDuplicated},
+				},
+			},
+			err: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			res, err := s.ImportPublicKeys(ctx, tc.req)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	fetcherMock.AssertExpectations(t)
+}
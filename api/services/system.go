@@ -1,16 +1,23 @@
 package services
 
 import (
+	"cmp"
 	"context"
-	"fmt"
+	"encoding/json"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/shellhub-io/shellhub/api/pkg/responses"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/envs"
+	log "github.com/sirupsen/logrus"
 )
 
+// DefaultTunnelPathPrefix is the path prefix of the reverse tunnel advertised when no other is configured.
+const DefaultTunnelPathPrefix = "/ssh"
+
 type SystemService interface {
 	// GetSystemInfo retrieves the instance's information
 	GetSystemInfo(ctx context.Context, req *requests.GetSystemInfo) (*responses.SystemInfo, error)
@@ -24,24 +31,40 @@ func (s *service) GetSystemInfo(ctx context.Context, req *requests.GetSystemInfo
 		return nil, err
 	}
 
-	apiHost := strings.Split(req.Host, ":")[0]
+	// NOTICE: the host may be an IPv6 literal, like "[2001:db8::1]:80", so the port and the square brackets are removed
+	// before the endpoints are formatted with the right ports.
+	apiHost := req.Host
+	if host, _, err := net.SplitHostPort(req.Host); err == nil {
+		apiHost = host
+	}
+
+	apiHost = strings.TrimSuffix(strings.TrimPrefix(apiHost, "["), "]")
 	sshPort := envs.DefaultBackend.Get("SHELLHUB_SSH_PORT")
+	tunnelPrefix := cmp.Or(envs.DefaultBackend.Get("SHELLHUB_TUNNEL_PATH_PREFIX"), DefaultTunnelPathPrefix)
 
 	resp := &responses.SystemInfo{
 		Version: envs.DefaultBackend.Get("SHELLHUB_VERSION"),
 		Setup:   system.Setup,
 		Endpoints: &responses.SystemEndpointsInfo{
-			API: apiHost,
-			SSH: fmt.Sprintf("%s:%s", apiHost, sshPort),
+			API:    apiHost,
+			SSH:    net.JoinHostPort(apiHost, sshPort),
+			Tunnel: tunnelPrefix,
 		},
 		Authentication: &responses.SystemAuthenticationInfo{
 			Local: system.Authentication.Local.Enabled,
 			SAML:  system.Authentication.SAML.Enabled,
 		},
+		Features: &responses.SystemFeaturesInfo{
+			DeviceInfoHash: true,
+		},
+	}
+
+	if gateways := envs.DefaultBackend.Get("SHELLHUB_GATEWAYS"); gateways != "" {
+		resp.Endpoints.Gateways = parseGateways(gateways)
 	}
 
 	if req.Port > 0 {
-		resp.Endpoints.API = fmt.Sprintf("%s:%d", apiHost, req.Port)
+		resp.Endpoints.API = net.JoinHostPort(apiHost, strconv.Itoa(req.Port))
 	} else {
 		resp.Endpoints.API = req.Host
 	}
@@ -49,6 +72,28 @@ func (s *service) GetSystemInfo(ctx context.Context, req *requests.GetSystemInfo
 	return resp, nil
 }
 
+// parseGateways parses the regional SSH gateways advertised to the agents, configured as a JSON list. The gateways
+// without an URL are ignored, and none is advertised when the list is invalid.
+func parseGateways(value string) []responses.SystemGatewayInfo {
+	var gateways []responses.SystemGatewayInfo
+	if err := json.Unmarshal([]byte(value), &gateways); err != nil {
+		log.WithError(err).Warn("failed to parse the SSH gateways advertised to the agents")
+
+		return nil
+	}
+
+	valid := make([]responses.SystemGatewayInfo, 0, len(gateways))
+	for _, gateway := range gateways {
+		if gateway.URL == "" {
+			continue
+		}
+
+		valid = append(valid, gateway)
+	}
+
+	return valid
+}
+
 func (s *service) SystemDownloadInstallScript(_ context.Context) (string, error) {
 	data, err := os.ReadFile("/templates/install.sh")
 	if err != nil {
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/responses"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSystemInfo(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	system := &models.System{
+		Setup: true,
+		Authentication: &models.SystemAuthentication{
+			Local: &models.SystemAuthenticationLocal{Enabled: true},
+			SAML:  &models.SystemAuthenticationSAML{Enabled: false},
+		},
+	}
+
+	cases := []struct {
+		description string
+		req         *requests.GetSystemInfo
+		tunnel      string
+		gateways    string
+		expected    *responses.SystemEndpointsInfo
+	}{
+		{
+			description: "succeeds when the host is a domain",
+			req:         &requests.GetSystemInfo{Host: "shellhub.io"},
+			expected:    &responses.SystemEndpointsInfo{API: "shellhub.io", SSH: "shellhub.io:22", Tunnel: "/ssh"},
+		},
+		{
+			description: "succeeds when the host is a domain with the port",
+			req:         &requests.GetSystemInfo{Host: "shellhub.io:8080", Port: 443},
+			expected:    &responses.SystemEndpointsInfo{API: "shellhub.io:443", SSH: "shellhub.io:22", Tunnel: "/ssh"},
+		},
+		{
+			description: "succeeds when the host is an IPv6 literal",
+			req:         &requests.GetSystemInfo{Host: "[2001:db8::1]"},
+			expected:    &responses.SystemEndpointsInfo{API: "[2001:db8::1]", SSH: "[2001:db8::1]:22", Tunnel: "/ssh"},
+		},
+		{
+			description: "succeeds when the host is an IPv6 literal with the port",
+			req:         &requests.GetSystemInfo{Host: "[2001:db8::1]:8080", Port: 80},
+			expected:    &responses.SystemEndpointsInfo{API: "[2001:db8::1]:80", SSH: "[2001:db8::1]:22", Tunnel: "/ssh"},
+		},
+		{
+			description: "succeeds when the tunnel path prefix is configured",
+			req:         &requests.GetSystemInfo{Host: "shellhub.io"},
+			tunnel:      "/edge/ssh",
+			expected:    &responses.SystemEndpointsInfo{API: "shellhub.io", SSH: "shellhub.io:22", Tunnel: "/edge/ssh"},
+		},
+		{
+			description: "succeeds when the gateways are configured",
+			req:         &requests.GetSystemInfo{Host: "shellhub.io"},
+			gateways:    `[{"region":"eu-west","url":"https://eu.shellhub.io","ssh":"eu.shellhub.io:22","latency":40},{"region":"invalid"}]`,
+			expected: &responses.SystemEndpointsInfo{
+				API:    "shellhub.io",
+				SSH:    "shellhub.io:22",
+				Tunnel: "/ssh",
+				Gateways: []responses.SystemGatewayInfo{
+					{Region: "eu-west", URL: "https://eu.shellhub.io", SSH: "eu.shellhub.io:22", Latency: 40},
+				},
+			},
+		},
+		{
+			description: "succeeds without the gateways when they are invalid",
+			req:         &requests.GetSystemInfo{Host: "shellhub.io"},
+			gateways:    `eu-west=https://eu.shellhub.io`,
+			expected:    &responses.SystemEndpointsInfo{API: "shellhub.io", SSH: "shellhub.io:22", Tunnel: "/ssh"},
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			storeMock.On("SystemGet", ctx).Return(system, nil).Once()
+			envMock.On("Get", "SHELLHUB_SSH_PORT").Return("22").Once()
+			envMock.On("Get", "SHELLHUB_TUNNEL_PATH_PREFIX").Return(tc.tunnel).Once()
+			envMock.On("Get", "SHELLHUB_VERSION").Return("latest").Once()
+			envMock.On("Get", "SHELLHUB_GATEWAYS").Return(tc.gateways).Once()
+
+			info, err := s.GetSystemInfo(ctx, tc.req)
+			assert.NoError(t, err)
+			assert.Equal(t, &responses.SystemInfo{
+				Version:        "latest",
+				Setup:          true,
+				Endpoints:      tc.expected,
+				Authentication: &responses.SystemAuthenticationInfo{Local: true, SAML: false},
+				Features:       &responses.SystemFeaturesInfo{DeviceInfoHash: true},
+			}, info)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
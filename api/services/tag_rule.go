@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/tagrule"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// TagRuleMaxRules is the maximum number of tag rules a namespace can have.
+const TagRuleMaxRules = 20
+
+type TagRuleService interface {
+	// ListTagRules lists the namespace's tag rules, the oldest first.
+	ListTagRules(ctx context.Context, req *requests.TagRuleList) ([]models.TagRule, error)
+
+	// CreateTagRule creates a tag rule, up to [TagRuleMaxRules] per namespace, and schedules the evaluation of the
+	// namespace's rules against its devices.
+	CreateTagRule(ctx context.Context, req *requests.TagRuleCreate) (*models.TagRule, error)
+
+	// UpdateTagRule replaces the name, the tag and the conditions of a tag rule, and schedules the evaluation of the
+	// namespace's rules against its devices.
+	UpdateTagRule(ctx context.Context, req *requests.TagRuleUpdate) (*models.TagRule, error)
+
+	// DeleteTagRule deletes a tag rule. The tag it assigned is kept on the devices, as it isn't managed by any rule
+	// anymore.
+	DeleteTagRule(ctx context.Context, req *requests.TagRuleDelete) error
+}
+
+func (s *service) ListTagRules(ctx context.Context, req *requests.TagRuleList) ([]models.TagRule, error) {
+	return s.store.TagRuleList(ctx, req.TenantID)
+}
+
+func (s *service) CreateTagRule(ctx context.Context, req *requests.TagRuleCreate) (*models.TagRule, error) {
+	if err := tagrule.Validate(req.Conditions); err != nil {
+		return nil, NewErrTagRuleInvalid(err)
+	}
+
+	rules, err := s.store.TagRuleList(ctx, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) >= TagRuleMaxRules {
+		return nil, NewErrTagRuleLimit(TagRuleMaxRules, nil)
+	}
+
+	now := clock.Now()
+	rule := &models.TagRule{
+		ID:         uuid.Generate(),
+		TenantID:   req.TenantID,
+		Name:       req.Name,
+		Tag:        req.Tag,
+		Conditions: req.Conditions,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.store.TagRuleCreate(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.scheduleTagRules(ctx, req.TenantID)
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionTagRuleCreate, models.AuditTarget{Type: models.AuditTargetTagRule, ID: rule.ID}, map[string]string{"name": rule.Name, "tag": rule.Tag}); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *service) UpdateTagRule(ctx context.Context, req *requests.TagRuleUpdate) (*models.TagRule, error) {
+	if err := tagrule.Validate(req.Conditions); err != nil {
+		return nil, NewErrTagRuleInvalid(err)
+	}
+
+	rule, err := s.store.TagRuleGet(ctx, req.TenantID, req.ID)
+	if err != nil {
+		return nil, NewErrTagRuleNotFound(req.ID, err)
+	}
+
+	rule.Name = req.Name
+	rule.Tag = req.Tag
+	rule.Conditions = req.Conditions
+	rule.UpdatedAt = clock.Now()
+
+	if err := s.store.TagRuleUpdate(ctx, rule); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil, NewErrTagRuleNotFound(req.ID, err)
+		}
+
+		return nil, err
+	}
+
+	s.scheduleTagRules(ctx, req.TenantID)
+
+	if err := s.recordAudit(ctx, req.TenantID, models.AuditActionTagRuleUpdate, models.AuditTarget{Type: models.AuditTargetTagRule, ID: rule.ID}, map[string]string{"name": rule.Name, "tag": rule.Tag}); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *service) DeleteTagRule(ctx context.Context, req *requests.TagRuleDelete) error {
+	if err := s.store.TagRuleDelete(ctx, req.TenantID, req.ID); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrTagRuleNotFound(req.ID, err)
+		}
+
+		return err
+	}
+
+	return s.recordAudit(ctx, req.TenantID, models.AuditActionTagRuleDelete, models.AuditTarget{Type: models.AuditTargetTagRule, ID: req.ID}, nil)
+}
+
+// scheduleTagRules enqueues the evaluation of the namespace's tag rules against all of its devices. A failure is only
+// logged, as the devices are evaluated again on their next authentication.
+func (s *service) scheduleTagRules(ctx context.Context, tenantID string) {
+	if err := s.client.EvaluateTagRules(ctx, tenantID); err != nil {
+		log.WithContext(ctx).
+			WithError(err).
+			WithField("tenant_id", tenantID).
+			Warn("failed to schedule the evaluation of the namespace's tag rules")
+	}
+}
+
+// evaluateTagRules applies the namespace's tag rules to the device, updating its tags when they change.
+func (s *service) evaluateTagRules(ctx context.Context, rules []models.TagRule, device *models.Device) error {
+	tags, changed := tagrule.Apply(rules, device, DeviceMaxTags)
+	if !changed {
+		return nil
+	}
+
+	if _, _, err := s.store.DeviceSetTags(ctx, models.UID(device.UID), tags); err != nil {
+		return err
+	}
+
+	s.publishDeviceEvent(ctx, device.TenantID, device.UID, models.DeviceEventTags)
+
+	return nil
+}
+
+// applyTagRules applies the namespace's tag rules to a device that has just reported its attributes. A failure is
+// only logged, as it must not prevent the device from authenticating.
+func (s *service) applyTagRules(ctx context.Context, device *models.Device) {
+	logger := log.WithContext(ctx).WithFields(log.Fields{"tenant_id": device.TenantID, "uid": device.UID})
+
+	rules, err := s.store.TagRuleList(ctx, device.TenantID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to list the namespace's tag rules")
+
+		return
+	}
+
+	if len(rules) == 0 {
+		return
+	}
+
+	if err := s.evaluateTagRules(ctx, rules, device); err != nil {
+		logger.WithError(err).Warn("failed to apply the namespace's tag rules to the device")
+	}
+}
@@ -0,0 +1,413 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/tagrule"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTagRule(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	uuidMock := new(uuidmock.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	conditions := []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"}}
+
+	type Expected struct {
+		rule *models.TagRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.TagRuleCreate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the conditions are invalid",
+			req: &requests.TagRuleCreate{
+				TenantID:   "00000000-0000-4000-0000-000000000000",
+				Name:       "outdated agents",
+				Tag:        "needs-update",
+				Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorLess, Value: "latest"}},
+			},
+			requiredMocks: func(context.Context) {},
+			expected: Expected{
+				rule: nil,
+				err:  NewErrTagRuleInvalid(tagrule.ErrVersionInvalid),
+			},
+		},
+		{
+			description: "fails when the namespace has the maximum number of rules",
+			req: &requests.TagRuleCreate{
+				TenantID:   "00000000-0000-4000-0000-000000000000",
+				Name:       "containers",
+				Tag:        "container",
+				Conditions: conditions,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(make([]models.TagRule, TagRuleMaxRules), nil).
+					Once()
+			},
+			expected: Expected{
+				rule: nil,
+				err:  NewErrTagRuleLimit(TagRuleMaxRules, nil),
+			},
+		},
+		{
+			description: "fails when the rule cannot be created",
+			req: &requests.TagRuleCreate{
+				TenantID:   "00000000-0000-4000-0000-000000000000",
+				Name:       "containers",
+				Tag:        "container",
+				Conditions: conditions,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.TagRule{}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Once()
+				storeMock.
+					On("TagRuleCreate", ctx, &models.TagRule{
+						ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Name:       "containers",
+						Tag:        "container",
+						Conditions: conditions,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: Expected{
+				rule: nil,
+				err:  errors.New("error"),
+			},
+		},
+		{
+			description: "succeeds",
+			req: &requests.TagRuleCreate{
+				TenantID:   "00000000-0000-4000-0000-000000000000",
+				Name:       "containers",
+				Tag:        "container",
+				Conditions: conditions,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.TagRule{}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Once()
+				storeMock.
+					On("TagRuleCreate", ctx, &models.TagRule{
+						ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Name:       "containers",
+						Tag:        "container",
+						Conditions: conditions,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}).
+					Return(nil).
+					Once()
+				clientMock.
+					On("EvaluateTagRules", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionTagRuleCreate &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetTagRule, ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"} &&
+							reflect.DeepEqual(entry.Details, map[string]string{"name": "containers", "tag": "container"})
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				rule: &models.TagRule{
+					ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+					TenantID:   "00000000-0000-4000-0000-000000000000",
+					Name:       "containers",
+					Tag:        "container",
+					Conditions: conditions,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			rule, err := s.CreateTagRule(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestUpdateTagRule(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	uuidMock := new(uuidmock.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	conditions := []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"}}
+
+	type Expected struct {
+		rule *models.TagRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.TagRuleUpdate
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the rule is not found",
+			req: &requests.TagRuleUpdate{
+				TagRuleParam: requests.TagRuleParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+				TagRuleCreate: requests.TagRuleCreate{
+					TenantID:   "00000000-0000-4000-0000-000000000000",
+					Name:       "docker",
+					Tag:        "docker",
+					Conditions: conditions,
+				},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleGet", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{
+				rule: nil,
+				err:  NewErrTagRuleNotFound("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "succeeds",
+			req: &requests.TagRuleUpdate{
+				TagRuleParam: requests.TagRuleParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+				TagRuleCreate: requests.TagRuleCreate{
+					TenantID:   "00000000-0000-4000-0000-000000000000",
+					Name:       "docker",
+					Tag:        "docker",
+					Conditions: conditions,
+				},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleGet", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(&models.TagRule{
+						ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Name:       "containers",
+						Tag:        "container",
+						Conditions: conditions,
+					}, nil).
+					Once()
+				storeMock.
+					On("TagRuleUpdate", ctx, &models.TagRule{
+						ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Name:       "docker",
+						Tag:        "docker",
+						Conditions: conditions,
+						UpdatedAt:  now,
+					}).
+					Return(nil).
+					Once()
+				clientMock.
+					On("EvaluateTagRules", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionTagRuleUpdate &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetTagRule, ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"} &&
+							reflect.DeepEqual(entry.Details, map[string]string{"name": "docker", "tag": "docker"})
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				rule: &models.TagRule{
+					ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+					TenantID:   "00000000-0000-4000-0000-000000000000",
+					Name:       "docker",
+					Tag:        "docker",
+					Conditions: conditions,
+					UpdatedAt:  now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			rule, err := s.UpdateTagRule(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestDeleteTagRule(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	uuidMock := new(uuidmock.Uuid)
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	cases := []struct {
+		description   string
+		req           *requests.TagRuleDelete
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the rule is not found",
+			req: &requests.TagRuleDelete{
+				TagRuleParam: requests.TagRuleParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+				TenantID:     "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleDelete", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrTagRuleNotFound("c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the audit entry cannot be recorded",
+			req: &requests.TagRuleDelete{
+				TagRuleParam: requests.TagRuleParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+				TenantID:     "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleDelete", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionTagRuleDelete &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetTagRule, ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"} &&
+							reflect.DeepEqual(entry.Details, map[string]string(nil))
+					})).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds",
+			req: &requests.TagRuleDelete{
+				TagRuleParam: requests.TagRuleParam{ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"},
+				TenantID:     "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleDelete", ctx, "00000000-0000-4000-0000-000000000000", "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a").
+					Return(nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000001").
+					Once()
+				storeMock.
+					On("AuditEntryCreate", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+						return entry.Action == models.AuditActionTagRuleDelete &&
+							entry.Target == models.AuditTarget{Type: models.AuditTargetTagRule, ID: "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a"} &&
+							reflect.DeepEqual(entry.Details, map[string]string(nil))
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			assert.Equal(t, tc.expected, s.DeleteTagRule(ctx, tc.req))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -39,9 +39,18 @@ func (s *service) RenameTag(ctx context.Context, tenant string, oldTag string, n
 		return NewErrTagDuplicated(newTag, nil)
 	}
 
-	_, err = s.store.TagsRename(ctx, tenant, oldTag, newTag)
+	// NOTICE: a tag cannot be moved below itself, as its descendants would be renamed recursively.
+	if models.TagImplies(newTag, oldTag) {
+		return NewErrTagInvalid(newTag, nil)
+	}
+
+	if _, err := s.store.TagsRename(ctx, tenant, oldTag, newTag); err != nil {
+		return err
+	}
 
-	return err
+	// NOTICE: as many devices may have changed at once, the subscribers are asked to list the devices again.
+	s.publishDeviceEvent(ctx, tenant, "", models.DeviceEventResync)
+	return s.recordAudit(ctx, tenant, models.AuditActionTagRename, models.AuditTarget{Type: models.AuditTargetTag, ID: oldTag}, map[string]string{"name": newTag})
 }
 
 func (s *service) DeleteTag(ctx context.Context, tenant string, tag string) error {
@@ -63,7 +72,10 @@ func (s *service) DeleteTag(ctx context.Context, tenant string, tag string) erro
 		return NewErrTagNotFound(tag, nil)
 	}
 
-	_, err = s.store.TagsDelete(ctx, namespace.TenantID, tag)
+	if _, err := s.store.TagsDelete(ctx, namespace.TenantID, tag); err != nil {
+		return err
+	}
 
-	return err
+	s.publishDeviceEvent(ctx, namespace.TenantID, "", models.DeviceEventResync)
+	return s.recordAudit(ctx, namespace.TenantID, models.AuditActionTagDelete, models.AuditTarget{Type: models.AuditTargetTag, ID: tag}, nil)
 }
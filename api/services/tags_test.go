@@ -10,8 +10,11 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
 )
 
 func TestGetTags(t *testing.T) {
@@ -100,6 +103,11 @@ func TestGetTags(t *testing.T) {
 
 func TestRenameTag(t *testing.T) {
 	mock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
 
 	ctx := context.TODO()
 
@@ -220,6 +228,8 @@ func TestRenameTag(t *testing.T) {
 
 				mock.On("TagsGet", ctx, namespace.TenantID).Return(deviceWithTags.Tags, len(deviceWithTags.Tags), nil).Once()
 				mock.On("TagsRename", ctx, namespace.TenantID, "device3", "device1").Return(int64(1), nil).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000000").Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -242,6 +252,11 @@ func TestRenameTag(t *testing.T) {
 
 func TestDeleteTag(t *testing.T) {
 	mock := new(mocks.Store)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
 
 	ctx := context.TODO()
 
@@ -337,6 +352,8 @@ func TestDeleteTag(t *testing.T) {
 				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
 				mock.On("TagsGet", ctx, "tenant").Return(device.Tags, len(device.Tags), nil).Once()
 				mock.On("TagsDelete", ctx, "tenant", "device1").Return(int64(1), nil).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000000").Once()
+				mock.On("AuditEntryCreate", ctx, testifymock.AnythingOfType("*models.AuditEntry")).Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -4,10 +4,13 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/worker"
 	log "github.com/sirupsen/logrus"
@@ -15,6 +18,12 @@ import (
 
 const (
 	TaskDevicesHeartbeat = worker.TaskPattern("api:heartbeat")
+	TaskTagRulesEvaluate = worker.TaskPattern("api:tag-rules")
+	TaskJobsRun          = worker.TaskPattern("api:jobs")
+)
+
+const (
+	CronDevicesOffline = worker.CronSpec("* * * * *")
 )
 
 // Device Heartbeat sets the device status to "online". It processes in batch.
@@ -62,7 +71,8 @@ func (s *service) DevicesHeartbeat() worker.TaskHandler {
 			devices = append(devices, device)
 		}
 
-		if err := s.store.DeviceSetOnline(ctx, devices); err != nil {
+		connected, err := s.store.DeviceHeartbeatBulk(ctx, devices)
+		if err != nil {
 			log.WithField("task", TaskDevicesHeartbeat.String()).
 				WithError(err).
 				Error("failed to complete the heartbeat task")
@@ -70,9 +80,122 @@ func (s *service) DevicesHeartbeat() worker.TaskHandler {
 			return err
 		}
 
+		for _, device := range connected {
+			s.publishDeviceEvent(ctx, device.TenantID, device.UID, models.DeviceEventOnline)
+		}
+
 		log.WithField("task", TaskDevicesHeartbeat.String()).
 			Info("finishing heartbeat task")
 
 		return nil
 	}
 }
+
+// DevicesOffline sets offline the devices whose grace period, after its connection was closed, is over, publishing
+// their offline events.
+func (s *service) DevicesOffline() worker.CronHandler {
+	return func(ctx context.Context) error {
+		devices, err := s.store.DeviceSetOfflineExpired(ctx, clock.Now())
+		if err != nil {
+			log.WithError(err).Error("failed to set the expired devices offline")
+
+			return err
+		}
+
+		for _, device := range devices {
+			s.publishDeviceEvent(ctx, device.TenantID, device.UID, models.DeviceEventOffline)
+		}
+
+		return nil
+	}
+}
+
+// TagRulesEvaluate applies the namespace's tag rules to all of its devices. The payload is the namespace's tenant ID.
+func (s *service) TagRulesEvaluate() worker.TaskHandler {
+	return func(ctx context.Context, payload []byte) error {
+		tenantID := string(payload)
+
+		logger := log.WithFields(log.Fields{"task": TaskTagRulesEvaluate.String(), "tenant_id": tenantID})
+		logger.Info("executing tag rules task")
+
+		rules, err := s.store.TagRuleList(ctx, tenantID)
+		if err != nil {
+			logger.WithError(err).Error("failed to list the namespace's tag rules")
+
+			return err
+		}
+
+		devices, err := s.store.DeviceListByTenant(ctx, tenantID)
+		if err != nil {
+			logger.WithError(err).Error("failed to list the namespace's devices")
+
+			return err
+		}
+
+		for i := range devices {
+			if err := s.evaluateTagRules(ctx, rules, &devices[i]); err != nil {
+				logger.WithError(err).WithField("uid", devices[i].UID).Warn("failed to apply the tag rules to the device")
+			}
+		}
+
+		return nil
+	}
+}
+
+// JobsRun executes the asynchronous job whose ID is the payload. The items processed by a previous attempt of the task
+// are skipped, so a retried task doesn't execute the job's operation twice on them.
+func (s *service) JobsRun() worker.TaskHandler {
+	return func(ctx context.Context, payload []byte) error {
+		id := string(payload)
+
+		logger := log.WithFields(log.Fields{"task": TaskJobsRun.String(), "id": id})
+		logger.Info("executing job task")
+
+		job, err := s.store.JobGet(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrNoDocuments) {
+				logger.Warn("job not found")
+
+				return nil
+			}
+
+			logger.WithError(err).Error("failed to get the job")
+
+			return err
+		}
+
+		switch job.Status {
+		case models.JobStatusCompleted, models.JobStatusFailed:
+			return nil
+		case models.JobStatusQueued:
+			if err := s.store.JobSetRunning(ctx, id, clock.Now()); err != nil {
+				logger.WithError(err).Error("failed to set the job as running")
+
+				return err
+			}
+		}
+
+		for i := range job.Items {
+			if job.Items[i].Status != models.JobItemStatusPending {
+				continue
+			}
+
+			item := s.runJobItem(ctx, job, job.Items[i].UID)
+			if err := s.store.JobSetItem(ctx, id, i, item); err != nil {
+				logger.WithError(err).WithField("uid", item.UID).Error("failed to set the result of the job's item")
+
+				return err
+			}
+		}
+
+		if err := s.store.JobSetFinished(ctx, id, models.JobStatusCompleted, "", clock.Now()); err != nil {
+			logger.WithError(err).Error("failed to set the job as completed")
+
+			return err
+		}
+
+		logger.Info("finishing job task")
+
+		return nil
+	}
+}
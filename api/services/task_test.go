@@ -6,9 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shellhub-io/shellhub/api/store"
 	storemocks "github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,7 +30,7 @@ func TestService_DevicesHeartbeat(t *testing.T) {
 			payload:     []byte("00000000-0000-4000-0000-000000000000:0000000000000000000000000000000000000000000000000000000000000000=1721912837\n00000000-0000-4000-0000-000000000000:0000000000000000000000000000000000000000000000000000000000000001=1721912837"),
 			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceSetOnline", ctx, []models.ConnectedDevice{
+					On("DeviceHeartbeatBulk", ctx, []models.ConnectedDevice{
 						{
 							UID:      "0000000000000000000000000000000000000000000000000000000000000000",
 							TenantID: "00000000-0000-4000-0000-000000000000",
@@ -38,7 +42,7 @@ func TestService_DevicesHeartbeat(t *testing.T) {
 							LastSeen: time.Unix(1721912837, 0),
 						},
 					}).
-					Return(errors.New("error")).
+					Return(nil, errors.New("error")).
 					Once()
 			},
 			expected: errors.New("error"),
@@ -48,14 +52,14 @@ func TestService_DevicesHeartbeat(t *testing.T) {
 			payload:     []byte("00000000-0000-4000-0000-0000000000000000000000000000000000000000000000000000000000000000000000000000=1721912837\n00000000-0000-4000-0000-000000000000:0000000000000000000000000000000000000000000000000000000000000001=1721912837"),
 			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceSetOnline", ctx, []models.ConnectedDevice{
+					On("DeviceHeartbeatBulk", ctx, []models.ConnectedDevice{
 						{
 							UID:      "0000000000000000000000000000000000000000000000000000000000000001",
 							TenantID: "00000000-0000-4000-0000-000000000000",
 							LastSeen: time.Unix(1721912837, 0),
 						},
 					}).
-					Return(nil).
+					Return([]models.ConnectedDevice{}, nil).
 					Once()
 			},
 			expected: nil,
@@ -65,14 +69,14 @@ func TestService_DevicesHeartbeat(t *testing.T) {
 			payload:     []byte("00000000-0000-4000-0000-000000000000:00000000000000000000000000000000000000000000000000000000000000001721912837\n00000000-0000-4000-0000-000000000000:0000000000000000000000000000000000000000000000000000000000000001=1721912837"),
 			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceSetOnline", ctx, []models.ConnectedDevice{
+					On("DeviceHeartbeatBulk", ctx, []models.ConnectedDevice{
 						{
 							UID:      "0000000000000000000000000000000000000000000000000000000000000001",
 							TenantID: "00000000-0000-4000-0000-000000000000",
 							LastSeen: time.Unix(1721912837, 0),
 						},
 					}).
-					Return(nil).
+					Return([]models.ConnectedDevice{}, nil).
 					Once()
 			},
 			expected: nil,
@@ -82,7 +86,7 @@ func TestService_DevicesHeartbeat(t *testing.T) {
 			payload:     []byte("00000000-0000-4000-0000-000000000000:0000000000000000000000000000000000000000000000000000000000000000=1721912837\n00000000-0000-4000-0000-000000000000:0000000000000000000000000000000000000000000000000000000000000001=1721912837"),
 			requiredMocks: func(ctx context.Context) {
 				storeMock.
-					On("DeviceSetOnline", ctx, []models.ConnectedDevice{
+					On("DeviceHeartbeatBulk", ctx, []models.ConnectedDevice{
 						{
 							UID:      "0000000000000000000000000000000000000000000000000000000000000000",
 							TenantID: "00000000-0000-4000-0000-000000000000",
@@ -94,7 +98,7 @@ func TestService_DevicesHeartbeat(t *testing.T) {
 							LastSeen: time.Unix(1721912837, 0),
 						},
 					}).
-					Return(nil).
+					Return([]models.ConnectedDevice{}, nil).
 					Once()
 			},
 			expected: nil,
@@ -111,3 +115,296 @@ func TestService_DevicesHeartbeat(t *testing.T) {
 		})
 	}
 }
+
+func TestService_DevicesOffline(t *testing.T) {
+	storeMock := new(storemocks.Store)
+
+	clockMock := new(clockmock.Clock)
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when cannot set the expired devices offline",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceSetOfflineExpired", ctx, now).
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceSetOfflineExpired", ctx, now).
+					Return([]models.ConnectedDevice{
+						{
+							UID:      "0000000000000000000000000000000000000000000000000000000000000000",
+							TenantID: "00000000-0000-4000-0000-000000000000",
+						},
+					}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, cache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(tt *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+			require.Equal(tt, tc.expected, s.DevicesOffline()(ctx))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestService_TagRulesEvaluate(t *testing.T) {
+	storeMock := new(storemocks.Store)
+
+	rules := []models.TagRule{
+		{
+			Tag:        "container",
+			Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"}},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		payload       []byte
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when cannot list the rules",
+			payload:     []byte("00000000-0000-4000-0000-000000000000"),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "fails when cannot list the devices",
+			payload:     []byte("00000000-0000-4000-0000-000000000000"),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(rules, nil).
+					Once()
+				storeMock.
+					On("DeviceListByTenant", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds updating only the devices whose tags changed",
+			payload:     []byte("00000000-0000-4000-0000-000000000000"),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("TagRuleList", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(rules, nil).
+					Once()
+				storeMock.
+					On("DeviceListByTenant", ctx, "00000000-0000-4000-0000-000000000000").
+					Return([]models.Device{
+						{
+							UID:      "0000000000000000000000000000000000000000000000000000000000000000",
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							Tags:     []string{"production"},
+							Info:     &models.DeviceInfo{Platform: "docker"},
+						},
+						{
+							UID:      "0000000000000000000000000000000000000000000000000000000000000001",
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							Tags:     []string{"container"},
+							Info:     &models.DeviceInfo{Platform: "native"},
+						},
+						{
+							UID:      "0000000000000000000000000000000000000000000000000000000000000002",
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							Tags:     []string{"container"},
+							Info:     &models.DeviceInfo{Platform: "docker"},
+						},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceSetTags", ctx, models.UID("0000000000000000000000000000000000000000000000000000000000000000"), []string{"production", "container"}).
+					Return(int64(1), int64(1), nil).
+					Once()
+				storeMock.
+					On("DeviceSetTags", ctx, models.UID("0000000000000000000000000000000000000000000000000000000000000001"), []string{}).
+					Return(int64(1), int64(1), nil).
+					Once()
+				storeMock.
+					On("DeviceChangeRecord", ctx, mock.AnythingOfType("*models.DeviceChange")).
+					Return(nil).
+					Times(2)
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, cache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(tt *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+			require.Equal(tt, tc.expected, s.TagRulesEvaluate()(ctx, tc.payload))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestService_JobsRun(t *testing.T) {
+	storeMock := new(storemocks.Store)
+
+	clockMock := new(clockmock.Clock)
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+	device := &models.Device{UID: "second", TenantID: tenant, Name: "second"}
+
+	cases := []struct {
+		description   string
+		payload       []byte
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "succeeds when the job has expired",
+			payload:     []byte("id"),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGet", ctx, "id").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when cannot get the job",
+			payload:     []byte("id"),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGet", ctx, "id").
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds skipping the job already completed",
+			payload:     []byte("id"),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGet", ctx, "id").
+					Return(&models.Job{ID: "id", TenantID: tenant, Status: models.JobStatusCompleted}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when cannot set the result of an item",
+			payload:     []byte("id"),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGet", ctx, "id").
+					Return(&models.Job{
+						ID:        "id",
+						TenantID:  tenant,
+						Operation: models.JobOperationDeviceExport,
+						Status:    models.JobStatusQueued,
+						Items:     []models.JobItem{{UID: "second", Status: models.JobItemStatusPending}},
+					}, nil).
+					Once()
+				storeMock.
+					On("JobSetRunning", ctx, "id", now).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("second"), tenant).
+					Return(device, nil).
+					Once()
+				storeMock.
+					On("JobSetItem", ctx, "id", 0, &models.JobItem{UID: "second", Status: models.JobItemStatusSucceeded, Device: device}).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds resuming the items not processed by a previous attempt",
+			payload:     []byte("id"),
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("JobGet", ctx, "id").
+					Return(&models.Job{
+						ID:        "id",
+						TenantID:  tenant,
+						Operation: models.JobOperationDeviceExport,
+						Status:    models.JobStatusRunning,
+						Items: []models.JobItem{
+							{UID: "first", Status: models.JobItemStatusSucceeded},
+							{UID: "second", Status: models.JobItemStatusPending},
+							{UID: "third", Status: models.JobItemStatusPending},
+						},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("second"), tenant).
+					Return(device, nil).
+					Once()
+				storeMock.
+					On("JobSetItem", ctx, "id", 1, &models.JobItem{UID: "second", Status: models.JobItemStatusSucceeded, Device: device}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("third"), tenant).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("JobSetItem", ctx, "id", 2, &models.JobItem{
+						UID:    "third",
+						Status: models.JobItemStatusFailed,
+						Error:  NewErrDeviceNotFound(models.UID("third"), store.ErrNoDocuments).Error(),
+					}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("JobSetFinished", ctx, "id", models.JobStatusCompleted, "", now).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, cache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(tt *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+			require.Equal(tt, tc.expected, s.JobsRun()(ctx, tc.payload))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
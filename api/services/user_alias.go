@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// UserMaxAliases is the maximum number of aliases a user can define.
+const UserMaxAliases = 100
+
+type UserAliasService interface {
+	// ListUserAliases lists the aliases defined by the user.
+	ListUserAliases(ctx context.Context, req *requests.UserAliasList) ([]models.UserAlias, error)
+
+	// SaveUserAlias creates the user's alias, replacing its target when the user already has an alias with the same
+	// name. It returns an error when the user has already reached [UserMaxAliases].
+	SaveUserAlias(ctx context.Context, req *requests.UserAliasSave) (*models.UserAlias, error)
+
+	// DeleteUserAlias deletes the user's alias.
+	DeleteUserAlias(ctx context.Context, req *requests.UserAliasDelete) error
+
+	// ResolveUserAlias gets the alias, defined by the user with the specified username, that is used to replace the
+	// SSHID when connecting to a device.
+	ResolveUserAlias(ctx context.Context, req *requests.UserAliasResolve) (*models.UserAlias, error)
+}
+
+func (s *service) ListUserAliases(ctx context.Context, req *requests.UserAliasList) ([]models.UserAlias, error) {
+	user, _, err := s.store.UserGetByID(ctx, req.UserID, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(req.UserID, err)
+	}
+
+	if user.Aliases == nil {
+		return []models.UserAlias{}, nil
+	}
+
+	return user.Aliases, nil
+}
+
+func (s *service) SaveUserAlias(ctx context.Context, req *requests.UserAliasSave) (*models.UserAlias, error) {
+	user, _, err := s.store.UserGetByID(ctx, req.UserID, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(req.UserID, err)
+	}
+
+	if findUserAlias(user.Aliases, req.Name) == nil && len(user.Aliases) >= UserMaxAliases {
+		return nil, NewErrUserAliasLimit(UserMaxAliases, nil)
+	}
+
+	alias := &models.UserAlias{Name: req.Name, Target: req.Target}
+	if err := s.store.UserAliasSave(ctx, req.UserID, alias); err != nil {
+		return nil, NewErrUserUpdate(user, err)
+	}
+
+	return alias, nil
+}
+
+func (s *service) DeleteUserAlias(ctx context.Context, req *requests.UserAliasDelete) error {
+	if err := s.store.UserAliasDelete(ctx, req.UserID, req.Name); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrUserAliasNotFound(req.Name, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) ResolveUserAlias(ctx context.Context, req *requests.UserAliasResolve) (*models.UserAlias, error) {
+	user, err := s.store.UserGetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, NewErrUserNotFound(req.Username, err)
+	}
+
+	alias := findUserAlias(user.Aliases, req.Name)
+	if alias == nil {
+		return nil, NewErrUserAliasNotFound(req.Name, nil)
+	}
+
+	return alias, nil
+}
+
+// findUserAlias returns the alias with the specified name, or nil when there isn't one.
+func findUserAlias(aliases []models.UserAlias, name string) *models.UserAlias {
+	for i := range aliases {
+		if aliases[i].Name == name {
+			return &aliases[i]
+		}
+	}
+
+	return nil
+}
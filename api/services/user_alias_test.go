@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListUserAliases(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		aliases []models.UserAlias
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.UserAliasList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the user is not found",
+			req:         &requests.UserAliasList{UserID: "000000000000000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(nil, 0, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{
+				aliases: nil,
+				err:     NewErrUserNotFound("000000000000000000000000", errors.New("error", "", 0)),
+			},
+		},
+		{
+			description: "succeeds when the user doesn't have aliases",
+			req:         &requests.UserAliasList{UserID: "000000000000000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000"}, 0, nil).
+					Once()
+			},
+			expected: Expected{
+				aliases: []models.UserAlias{},
+				err:     nil,
+			},
+		},
+		{
+			description: "succeeds",
+			req:         &requests.UserAliasList{UserID: "000000000000000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{
+						ID:      "000000000000000000000000",
+						Aliases: []models.UserAlias{{Name: "web1", Target: "namespace.device"}},
+					}, 0, nil).
+					Once()
+			},
+			expected: Expected{
+				aliases: []models.UserAlias{{Name: "web1", Target: "namespace.device"}},
+				err:     nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			aliases, err := s.ListUserAliases(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{aliases, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestSaveUserAlias(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		alias *models.UserAlias
+		err   error
+	}
+
+	full := make([]models.UserAlias, UserMaxAliases)
+	for i := range full {
+		full[i] = models.UserAlias{Name: "alias", Target: "namespace.device"}
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.UserAliasSave
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the user is not found",
+			req:         &requests.UserAliasSave{UserID: "000000000000000000000000", Name: "web1", Target: "namespace.device"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(nil, 0, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{
+				alias: nil,
+				err:   NewErrUserNotFound("000000000000000000000000", errors.New("error", "", 0)),
+			},
+		},
+		{
+			description: "fails when the user has reached the aliases limit",
+			req:         &requests.UserAliasSave{UserID: "000000000000000000000000", Name: "web1", Target: "namespace.device"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000", Aliases: full}, 0, nil).
+					Once()
+			},
+			expected: Expected{
+				alias: nil,
+				err:   NewErrUserAliasLimit(UserMaxAliases, nil),
+			},
+		},
+		{
+			description: "fails when the alias cannot be saved",
+			req:         &requests.UserAliasSave{UserID: "000000000000000000000000", Name: "web1", Target: "namespace.device"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000"}, 0, nil).
+					Once()
+				storeMock.
+					On("UserAliasSave", ctx, "000000000000000000000000", &models.UserAlias{Name: "web1", Target: "namespace.device"}).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{
+				alias: nil,
+				err:   NewErrUserUpdate(&models.User{ID: "000000000000000000000000"}, errors.New("error", "", 0)),
+			},
+		},
+		{
+			description: "succeeds when replacing an alias of a user that has reached the limit",
+			req:         &requests.UserAliasSave{UserID: "000000000000000000000000", Name: "alias", Target: "namespace.device-2"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000", Aliases: full}, 0, nil).
+					Once()
+				storeMock.
+					On("UserAliasSave", ctx, "000000000000000000000000", &models.UserAlias{Name: "alias", Target: "namespace.device-2"}).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				alias: &models.UserAlias{Name: "alias", Target: "namespace.device-2"},
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds",
+			req:         &requests.UserAliasSave{UserID: "000000000000000000000000", Name: "web1", Target: "namespace.device"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000"}, 0, nil).
+					Once()
+				storeMock.
+					On("UserAliasSave", ctx, "000000000000000000000000", &models.UserAlias{Name: "web1", Target: "namespace.device"}).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				alias: &models.UserAlias{Name: "web1", Target: "namespace.device"},
+				err:   nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			alias, err := s.SaveUserAlias(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{alias, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteUserAlias(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	cases := []struct {
+		description   string
+		req           *requests.UserAliasDelete
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the alias is not found",
+			req:         &requests.UserAliasDelete{UserID: "000000000000000000000000", Name: "web1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserAliasDelete", ctx, "000000000000000000000000", "web1").
+					Return(store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrUserAliasNotFound("web1", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the alias cannot be deleted",
+			req:         &requests.UserAliasDelete{UserID: "000000000000000000000000", Name: "web1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserAliasDelete", ctx, "000000000000000000000000", "web1").
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "succeeds",
+			req:         &requests.UserAliasDelete{UserID: "000000000000000000000000", Name: "web1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserAliasDelete", ctx, "000000000000000000000000", "web1").
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.DeleteUserAlias(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestResolveUserAlias(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		alias *models.UserAlias
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.UserAliasResolve
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the user is not found",
+			req:         &requests.UserAliasResolve{Username: "john_doe", Name: "web1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByUsername", ctx, "john_doe").
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: Expected{
+				alias: nil,
+				err:   NewErrUserNotFound("john_doe", errors.New("error", "", 0)),
+			},
+		},
+		{
+			description: "fails when the alias is not found",
+			req:         &requests.UserAliasResolve{Username: "john_doe", Name: "web2"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByUsername", ctx, "john_doe").
+					Return(&models.User{
+						ID:      "000000000000000000000000",
+						Aliases: []models.UserAlias{{Name: "web1", Target: "namespace.device"}},
+					}, nil).
+					Once()
+			},
+			expected: Expected{
+				alias: nil,
+				err:   NewErrUserAliasNotFound("web2", nil),
+			},
+		},
+		{
+			description: "succeeds",
+			req:         &requests.UserAliasResolve{Username: "john_doe", Name: "web1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetByUsername", ctx, "john_doe").
+					Return(&models.User{
+						ID:      "000000000000000000000000",
+						Aliases: []models.UserAlias{{Name: "web1", Target: "namespace.device"}},
+					}, nil).
+					Once()
+			},
+			expected: Expected{
+				alias: &models.UserAlias{Name: "web1", Target: "namespace.device"},
+				err:   nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			alias, err := s.ResolveUserAlias(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{alias, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
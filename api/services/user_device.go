@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type UserDeviceService interface {
+	// SearchUserDevices searches, by name, the devices of all the namespaces where the user is a member, so the user
+	// doesn't need to switch between namespaces to find a device. The namespaces where the user's membership is still
+	// pending are not searched. Each device carries the user's role on its namespace.
+	//
+	// It returns the list of devices, the total count of matching devices (ignoring pagination), and an error if any.
+	SearchUserDevices(ctx context.Context, req *requests.UserDeviceSearch) ([]responses.UserDevice, int, error)
+}
+
+func (s *service) SearchUserDevices(ctx context.Context, req *requests.UserDeviceSearch) ([]responses.UserDevice, int, error) {
+	info, err := s.store.UserGetInfo(ctx, req.UserID)
+	if err != nil {
+		return nil, 0, NewErrUserNotFound(req.UserID, err)
+	}
+
+	roles := make(map[string]authorizer.Role)
+	tenants := make([]string, 0)
+	for _, namespace := range append(info.OwnedNamespaces, info.AssociatedNamespaces...) {
+		member, ok := namespace.FindMember(req.UserID)
+		if !ok || member.Status == models.MemberStatusPending {
+			continue
+		}
+
+		roles[namespace.TenantID] = member.Role
+		tenants = append(tenants, namespace.TenantID)
+	}
+
+	if len(tenants) == 0 {
+		return []responses.UserDevice{}, 0, nil
+	}
+
+	devices, count, err := s.store.DeviceSearch(ctx, tenants, req.Name, req.Status, req.Paginator)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]responses.UserDevice, 0, len(devices))
+	for _, device := range devices {
+		res = append(res, responses.UserDevice{Device: device, Role: roles[device.TenantID]})
+	}
+
+	return res, count, nil
+}
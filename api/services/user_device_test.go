@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchUserDevices(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		devices []responses.UserDevice
+		count   int
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.UserDeviceSearch
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the user is not found",
+			req:         &requests.UserDeviceSearch{UserID: "000000000000000000000000", Name: "device", Paginator: query.Paginator{Page: 1, PerPage: 10}},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetInfo", ctx, "000000000000000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{err: NewErrUserNotFound("000000000000000000000000", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds without searching when the user has no active memberships",
+			req:         &requests.UserDeviceSearch{UserID: "000000000000000000000000", Name: "device", Paginator: query.Paginator{Page: 1, PerPage: 10}},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetInfo", ctx, "000000000000000000000000").
+					Return(&models.UserInfo{
+						AssociatedNamespaces: []models.Namespace{
+							{
+								TenantID: "00000000-0000-4000-0000-000000000000",
+								Members:  []models.Member{{ID: "000000000000000000000000", Role: authorizer.RoleObserver, Status: models.MemberStatusPending}},
+							},
+						},
+					}, nil).
+					Once()
+			},
+			expected: Expected{devices: []responses.UserDevice{}, count: 0, err: nil},
+		},
+		{
+			description: "fails when the devices cannot be searched",
+			req:         &requests.UserDeviceSearch{UserID: "000000000000000000000000", Name: "device", Paginator: query.Paginator{Page: 1, PerPage: 10}},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetInfo", ctx, "000000000000000000000000").
+					Return(&models.UserInfo{
+						OwnedNamespaces: []models.Namespace{
+							{
+								TenantID: "00000000-0000-4000-0000-000000000000",
+								Members:  []models.Member{{ID: "000000000000000000000000", Role: authorizer.RoleOwner, Status: models.MemberStatusAccepted}},
+							},
+						},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceSearch", ctx, []string{"00000000-0000-4000-0000-000000000000"}, "device", models.DeviceStatus(""), query.Paginator{Page: 1, PerPage: 10}).
+					Return(nil, 0, errors.New("error")).
+					Once()
+			},
+			expected: Expected{err: errors.New("error")},
+		},
+		{
+			description: "succeeds searching the namespaces where the user is an active member",
+			req: &requests.UserDeviceSearch{
+				UserID:    "000000000000000000000000",
+				Name:      "device",
+				Status:    models.DeviceStatusAccepted,
+				Paginator: query.Paginator{Page: 1, PerPage: 10},
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserGetInfo", ctx, "000000000000000000000000").
+					Return(&models.UserInfo{
+						OwnedNamespaces: []models.Namespace{
+							{
+								TenantID: "00000000-0000-4000-0000-000000000000",
+								Members:  []models.Member{{ID: "000000000000000000000000", Role: authorizer.RoleOwner, Status: models.MemberStatusAccepted}},
+							},
+						},
+						AssociatedNamespaces: []models.Namespace{
+							{
+								TenantID: "00000000-0000-4001-0000-000000000000",
+								Members:  []models.Member{{ID: "000000000000000000000000", Role: authorizer.RoleObserver, Status: models.MemberStatusAccepted}},
+							},
+							{
+								TenantID: "00000000-0000-4002-0000-000000000000",
+								Members:  []models.Member{{ID: "000000000000000000000000", Role: authorizer.RoleOperator, Status: models.MemberStatusPending}},
+							},
+						},
+					}, nil).
+					Once()
+				storeMock.
+					On("DeviceSearch", ctx, []string{"00000000-0000-4000-0000-000000000000", "00000000-0000-4001-0000-000000000000"}, "device", models.DeviceStatusAccepted, query.Paginator{Page: 1, PerPage: 10}).
+					Return([]models.Device{
+						{UID: "uid-1", Name: "device-1", TenantID: "00000000-0000-4000-0000-000000000000"},
+						{UID: "uid-2", Name: "device-2", TenantID: "00000000-0000-4001-0000-000000000000"},
+					}, 2, nil).
+					Once()
+			},
+			expected: Expected{
+				devices: []responses.UserDevice{
+					{Device: models.Device{UID: "uid-1", Name: "device-1", TenantID: "00000000-0000-4000-0000-000000000000"}, Role: authorizer.RoleOwner},
+					{Device: models.Device{UID: "uid-2", Name: "device-2", TenantID: "00000000-0000-4001-0000-000000000000"}, Role: authorizer.RoleObserver},
+				},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			devices, count, err := s.SearchUserDevices(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{devices, count, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// UserSessionSeenInterval is the minimum interval between two updates of a session's last activity.
+const UserSessionSeenInterval = time.Minute
+
+// errRefreshTokenReused is returned when a session's refresh token was rotated since it was checked.
+var errRefreshTokenReused = errors.New("refresh token reused")
+
+type UserSessionService interface {
+	// ListUserSessions lists the sessions where the user is signed in, marking the session that requested the listing
+	// as the current one.
+	ListUserSessions(ctx context.Context, req *requests.UserSessionList) ([]models.UserSession, error)
+
+	// RevokeUserSession revokes the user's session, so its tokens are rejected by the auth middleware even before
+	// they expire.
+	RevokeUserSession(ctx context.Context, req *requests.UserSessionRevoke) error
+
+	// RevokeUserSessions revokes all the user's sessions, including the one that requested the revocation.
+	RevokeUserSessions(ctx context.Context, req *requests.UserSessionRevokeAll) error
+
+	// RefreshUserToken issues a new token, and rotates the refresh token, to the session of the specified refresh
+	// token. When a refresh token that was already rotated is used again, it may have been stolen, so the whole session
+	// is revoked.
+	RefreshUserToken(ctx context.Context, req *requests.RefreshUserToken) (*models.UserAuthResponse, error)
+
+	// AuthUserSession checks whether the session with the specified ID was revoked, returning [ErrUserSessionRevoked]
+	// when so. It also stores the session's last activity, at most once each [UserSessionSeenInterval].
+	AuthUserSession(ctx context.Context, id string) error
+}
+
+func (s *service) ListUserSessions(ctx context.Context, req *requests.UserSessionList) ([]models.UserSession, error) {
+	sessions, err := s.store.UserSessionList(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == req.SessionID
+	}
+
+	return sessions, nil
+}
+
+func (s *service) RevokeUserSession(ctx context.Context, req *requests.UserSessionRevoke) error {
+	sessions, err := s.store.UserSessionList(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	session := findUserSession(sessions, req.ID)
+	if session == nil {
+		return NewErrUserSessionNotFound(req.ID, nil)
+	}
+
+	if err := s.revokeUserSession(ctx, session); err != nil {
+		return err
+	}
+
+	if err := s.store.UserSessionDelete(ctx, req.UserID, req.ID); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrUserSessionNotFound(req.ID, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) RevokeUserSessions(ctx context.Context, req *requests.UserSessionRevokeAll) error {
+	sessions, err := s.store.UserSessionList(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	for i := range sessions {
+		if err := s.revokeUserSession(ctx, &sessions[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.store.UserSessionDeleteAll(ctx, req.UserID)
+}
+
+func (s *service) RefreshUserToken(ctx context.Context, req *requests.RefreshUserToken) (*models.UserAuthResponse, error) {
+	id, secret, ok := strings.Cut(req.RefreshToken, ".")
+	if !ok {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	session, err := s.store.UserSessionGet(ctx, id)
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	if !session.ExpiresAt.After(clock.Now()) {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	if session.RefreshToken != hashRefreshToken(secret) {
+		return nil, s.revokeReusedUserSession(ctx, session)
+	}
+
+	// NOTICE: the refresh token is only rotated while it is still the one checked above, so when the same token is
+	// used concurrently, all but one of the rotations are handled as a reuse.
+	res, err := s.createUserToken(ctx, &requests.CreateUserToken{
+		UserID:    session.UserID,
+		SessionID: session.ID,
+		UserAgent: req.UserAgent,
+		IP:        req.IP,
+	}, session.RefreshToken)
+	if errors.Is(err, errRefreshTokenReused) {
+		return nil, s.revokeReusedUserSession(ctx, session)
+	}
+
+	return res, err
+}
+
+func (s *service) AuthUserSession(ctx context.Context, id string) error {
+	var revoked bool
+	if err := s.cache.Get(ctx, "user-session-revoked={"+id+"}", &revoked); err != nil {
+		return err
+	}
+
+	if revoked {
+		return NewErrUserSessionRevoked(nil)
+	}
+
+	var seen bool
+	if err := s.cache.Get(ctx, "user-session-seen={"+id+"}", &seen); err == nil && seen {
+		return nil
+	}
+
+	// NOTICE: tokens issued before the sessions were tracked don't have a session to be updated.
+	if err := s.store.UserSessionTouch(ctx, id, clock.Now()); err != nil && !errors.Is(err, store.ErrNoDocuments) {
+		log.WithContext(ctx).WithError(err).WithField("session_id", id).Warn("unable to update the user session's last activity")
+	}
+
+	if err := s.cache.Set(ctx, "user-session-seen={"+id+"}", true, UserSessionSeenInterval); err != nil {
+		log.WithContext(ctx).WithError(err).WithField("session_id", id).Warn("unable to cache the user session's last activity")
+	}
+
+	return nil
+}
+
+// saveUserSession stores the session to which a token was issued to the user, so it can be listed and revoked. When
+// previous is set, the session is only updated while its refresh token's hash is still previous, returning
+// [errRefreshTokenReused] otherwise. It returns the session's new refresh token, formatted as "<session>.<secret>", and
+// an error if any.
+func (s *service) saveUserSession(ctx context.Context, userID, id, userAgent, ip, previous string) (string, error) {
+	secret := uuid.Generate()
+
+	// NOTICE: the session lasts while any of its tokens may be used.
+	ttl := s.tokens.refresh
+	if s.tokens.access > ttl {
+		ttl = s.tokens.access
+	}
+
+	now := clock.Now()
+	session := &models.UserSession{
+		ID:           id,
+		UserID:       userID,
+		UserAgent:    userAgent,
+		IP:           ip,
+		CreatedAt:    now,
+		LastSeenAt:   now,
+		ExpiresAt:    now.Add(ttl),
+		RefreshToken: hashRefreshToken(secret),
+	}
+
+	if previous == "" {
+		if err := s.store.UserSessionSave(ctx, session); err != nil {
+			return "", err
+		}
+
+		return id + "." + secret, nil
+	}
+
+	if err := s.store.UserSessionRotate(ctx, session, previous); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return "", errRefreshTokenReused
+		}
+
+		return "", err
+	}
+
+	return id + "." + secret, nil
+}
+
+// revokeReusedUserSession revokes and deletes the session whose refresh token was reused, as it may have been stolen,
+// returning the error to be replied to the request.
+func (s *service) revokeReusedUserSession(ctx context.Context, session *models.UserSession) error {
+	log.WithContext(ctx).
+		WithFields(log.Fields{"user_id": session.UserID, "session_id": session.ID}).
+		Warn("refresh token reused, revoking the user session")
+
+	if err := s.revokeUserSession(ctx, session); err != nil {
+		return err
+	}
+
+	if err := s.store.UserSessionDelete(ctx, session.UserID, session.ID); err != nil && !errors.Is(err, store.ErrNoDocuments) {
+		return err
+	}
+
+	return NewErrAuthUnathorized(nil)
+}
+
+// revokeUserSession adds the session to the revocation list until its tokens expire.
+func (s *service) revokeUserSession(ctx context.Context, session *models.UserSession) error {
+	ttl := session.ExpiresAt.Sub(clock.Now())
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.cache.Set(ctx, "user-session-revoked={"+session.ID+"}", true, ttl)
+}
+
+// hashRefreshToken returns the hash of a refresh token's secret, which is stored instead of the secret itself.
+func hashRefreshToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// findUserSession returns the session with the specified ID, or nil when there isn't one.
+func findUserSession(sessions []models.UserSession, id string) *models.UserSession {
+	for i := range sessions {
+		if sessions[i].ID == id {
+			return &sessions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,496 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestListUserSessions(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	type Expected struct {
+		sessions []models.UserSession
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.UserSessionList
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the sessions cannot be listed",
+			req:         &requests.UserSessionList{UserID: "000000000000000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionList", ctx, "000000000000000000000000").
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: Expected{sessions: nil, err: errors.New("error")},
+		},
+		{
+			description: "succeeds marking the current session",
+			req:         &requests.UserSessionList{UserID: "000000000000000000000000", SessionID: "session-2"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionList", ctx, "000000000000000000000000").
+					Return([]models.UserSession{{ID: "session-1"}, {ID: "session-2"}}, nil).
+					Once()
+			},
+			expected: Expected{
+				sessions: []models.UserSession{{ID: "session-1"}, {ID: "session-2", Current: true}},
+				err:      nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, nil, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			sessions, err := s.ListUserSessions(ctx, tc.req)
+			assert.Equal(t, tc.expected, Expected{sessions, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRevokeUserSession(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	sessions := []models.UserSession{
+		{ID: "session-1", ExpiresAt: now.Add(UserSessionSeenInterval)},
+		{ID: "session-2", ExpiresAt: now.Add(-UserSessionSeenInterval)},
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.UserSessionRevoke
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the user doesn't have the session",
+			req:         &requests.UserSessionRevoke{UserID: "000000000000000000000000", ID: "session-3"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionList", ctx, "000000000000000000000000").
+					Return(sessions, nil).
+					Once()
+			},
+			expected: NewErrUserSessionNotFound("session-3", nil),
+		},
+		{
+			description: "fails when the session cannot be added to the revocation list",
+			req:         &requests.UserSessionRevoke{UserID: "000000000000000000000000", ID: "session-1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionList", ctx, "000000000000000000000000").
+					Return(sessions, nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, "user-session-revoked={session-1}", true, UserSessionSeenInterval).
+					Return(errors.New("error")).
+					Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description: "succeeds",
+			req:         &requests.UserSessionRevoke{UserID: "000000000000000000000000", ID: "session-1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionList", ctx, "000000000000000000000000").
+					Return(sessions, nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, "user-session-revoked={session-1}", true, UserSessionSeenInterval).
+					Return(nil).
+					Once()
+				storeMock.
+					On("UserSessionDelete", ctx, "000000000000000000000000", "session-1").
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds without revoking an expired session",
+			req:         &requests.UserSessionRevoke{UserID: "000000000000000000000000", ID: "session-2"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionList", ctx, "000000000000000000000000").
+					Return(sessions, nil).
+					Once()
+				storeMock.
+					On("UserSessionDelete", ctx, "000000000000000000000000", "session-2").
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.RevokeUserSession(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	ctx := context.Background()
+
+	storeMock.
+		On("UserSessionList", ctx, "000000000000000000000000").
+		Return([]models.UserSession{
+			{ID: "session-1", ExpiresAt: now.Add(UserSessionSeenInterval)},
+			{ID: "session-2", ExpiresAt: now.Add(2 * UserSessionSeenInterval)},
+		}, nil).
+		Once()
+	cacheMock.
+		On("Set", ctx, "user-session-revoked={session-1}", true, UserSessionSeenInterval).
+		Return(nil).
+		Once()
+	cacheMock.
+		On("Set", ctx, "user-session-revoked={session-2}", true, 2*UserSessionSeenInterval).
+		Return(nil).
+		Once()
+	storeMock.
+		On("UserSessionDeleteAll", ctx, "000000000000000000000000").
+		Return(nil).
+		Once()
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock)
+
+	err := s.RevokeUserSessions(ctx, &requests.UserSessionRevokeAll{UserID: "000000000000000000000000"})
+	assert.NoError(t, err)
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
+
+func TestAuthUserSession(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	cases := []struct {
+		description   string
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the session was revoked",
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, "user-session-revoked={session-1}", testifymock.Anything).
+					Run(func(args testifymock.Arguments) { *args.Get(2).(*bool) = true }).
+					Return(nil).
+					Once()
+			},
+			expected: NewErrUserSessionRevoked(nil),
+		},
+		{
+			description: "succeeds without updating the last activity when it was recently updated",
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, "user-session-revoked={session-1}", testifymock.Anything).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Get", ctx, "user-session-seen={session-1}", testifymock.Anything).
+					Run(func(args testifymock.Arguments) { *args.Get(2).(*bool) = true }).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds updating the last activity",
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, "user-session-revoked={session-1}", testifymock.Anything).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Get", ctx, "user-session-seen={session-1}", testifymock.Anything).
+					Return(nil).
+					Once()
+				storeMock.
+					On("UserSessionTouch", ctx, "session-1", now).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, "user-session-seen={session-1}", true, UserSessionSeenInterval).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the session isn't tracked",
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("Get", ctx, "user-session-revoked={session-1}", testifymock.Anything).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Get", ctx, "user-session-seen={session-1}", testifymock.Anything).
+					Return(nil).
+					Once()
+				storeMock.
+					On("UserSessionTouch", ctx, "session-1", now).
+					Return(store.ErrNoDocuments).
+					Once()
+				cacheMock.
+					On("Set", ctx, "user-session-seen={session-1}", true, UserSessionSeenInterval).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.AuthUserSession(ctx, "session-1")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
+
+func TestRefreshUserToken(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	uuidMock.On("Generate").Return("secret-2")
+
+	session := &models.UserSession{
+		ID:           "session-1",
+		UserID:       "000000000000000000000000",
+		ExpiresAt:    now.Add(UserSessionSeenInterval),
+		RefreshToken: hashRefreshToken("secret-1"),
+	}
+
+	type Expected struct {
+		res *models.UserAuthResponse
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.RefreshUserToken
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description:   "fails when the refresh token is malformed",
+			req:           &requests.RefreshUserToken{RefreshToken: "secret-1"},
+			requiredMocks: func(context.Context) {},
+			expected:      Expected{res: nil, err: NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "fails when the session is not found",
+			req:         &requests.RefreshUserToken{RefreshToken: "session-1.secret-1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionGet", ctx, "session-1").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{res: nil, err: NewErrAuthUnathorized(store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the session is expired",
+			req:         &requests.RefreshUserToken{RefreshToken: "session-1.secret-1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionGet", ctx, "session-1").
+					Return(&models.UserSession{ID: "session-1", ExpiresAt: now, RefreshToken: hashRefreshToken("secret-1")}, nil).
+					Once()
+			},
+			expected: Expected{res: nil, err: NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "fails and revokes the session when the refresh token was already rotated",
+			req:         &requests.RefreshUserToken{RefreshToken: "session-1.secret-0"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionGet", ctx, "session-1").
+					Return(session, nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, "user-session-revoked={session-1}", true, UserSessionSeenInterval).
+					Return(nil).
+					Once()
+				storeMock.
+					On("UserSessionDelete", ctx, "000000000000000000000000", "session-1").
+					Return(nil).
+					Once()
+			},
+			expected: Expected{res: nil, err: NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "fails and revokes the session when the refresh token was rotated concurrently",
+			req:         &requests.RefreshUserToken{RefreshToken: "session-1.secret-1", UserAgent: "Mozilla/5.0", IP: "192.168.0.1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionGet", ctx, "session-1").
+					Return(session, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000", UserData: models.UserData{Username: "john_doe"}}, 0, nil).
+					Once()
+				storeMock.
+					On("NamespaceGetPreferred", ctx, "000000000000000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("UserSessionRotate", ctx, &models.UserSession{
+						ID:           "session-1",
+						UserID:       "000000000000000000000000",
+						UserAgent:    "Mozilla/5.0",
+						IP:           "192.168.0.1",
+						CreatedAt:    now,
+						LastSeenAt:   now,
+						ExpiresAt:    now.Add(DefaultRefreshTokenTTL),
+						RefreshToken: hashRefreshToken("secret-2"),
+					}, hashRefreshToken("secret-1")).
+					Return(store.ErrNoDocuments).
+					Once()
+				cacheMock.
+					On("Set", ctx, "user-session-revoked={session-1}", true, UserSessionSeenInterval).
+					Return(nil).
+					Once()
+				storeMock.
+					On("UserSessionDelete", ctx, "000000000000000000000000", "session-1").
+					Return(nil).
+					Once()
+			},
+			expected: Expected{res: nil, err: NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "succeeds rotating the refresh token",
+			req:         &requests.RefreshUserToken{RefreshToken: "session-1.secret-1", UserAgent: "Mozilla/5.0", IP: "192.168.0.1"},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("UserSessionGet", ctx, "session-1").
+					Return(session, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{ID: "000000000000000000000000", UserData: models.UserData{Username: "john_doe"}}, 0, nil).
+					Once()
+				storeMock.
+					On("NamespaceGetPreferred", ctx, "000000000000000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+				storeMock.
+					On("UserSessionRotate", ctx, &models.UserSession{
+						ID:           "session-1",
+						UserID:       "000000000000000000000000",
+						UserAgent:    "Mozilla/5.0",
+						IP:           "192.168.0.1",
+						CreatedAt:    now,
+						LastSeenAt:   now,
+						ExpiresAt:    now.Add(DefaultRefreshTokenTTL),
+						RefreshToken: hashRefreshToken("secret-2"),
+					}, hashRefreshToken("secret-1")).
+					Return(nil).
+					Once()
+				cacheMock.
+					On("Set", ctx, "token_000000000000000000000000", testifymock.Anything, time.Hour*72).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				res: &models.UserAuthResponse{
+					ID:           "000000000000000000000000",
+					User:         "john_doe",
+					Token:        "must ignore",
+					RefreshToken: "session-1.secret-2",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			res, err := s.RefreshUserToken(ctx, tc.req)
+			// Since the resulting token is not crucial for the assertion and
+			// difficult to mock, it is safe to ignore this field.
+			if res != nil {
+				res.Token = "must ignore"
+			}
+
+			assert.Equal(t, tc.expected, Expected{res, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
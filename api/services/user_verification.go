@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// UserEmailVerificationResendLimit is the maximum number of verification emails requested per hour for the same
+	// identifier.
+	UserEmailVerificationResendLimit = 3
+	// UserEmailVerificationResendIPLimit is the maximum number of verification emails requested per hour from the same
+	// IP address.
+	UserEmailVerificationResendIPLimit = 10
+)
+
+type UserVerificationService interface {
+	// ResendUserEmailVerification generates a new verification token for a not confirmed user and sends it to the
+	// user's email. The identifier can be either the username or the email. The requests are limited to
+	// [UserEmailVerificationResendLimit] per identifier and [UserEmailVerificationResendIPLimit] per IP address each
+	// hour. Besides when the email verification is disabled or the limit is reached, it returns no error, even when
+	// the user doesn't exist or is already confirmed, so the response doesn't reveal which users exist.
+	ResendUserEmailVerification(ctx context.Context, req *requests.ResendUserEmailVerification) error
+	// VerifyUserEmail confirms the user's email when the token is valid and belongs to the user with the specified
+	// email. The token is discarded after a successful verification.
+	VerifyUserEmail(ctx context.Context, req *requests.VerifyUserEmail) error
+}
+
+func (s *service) ResendUserEmailVerification(ctx context.Context, req *requests.ResendUserEmailVerification) error {
+	if !envs.HasEmailVerification() {
+		return NewErrUserVerificationDisabled()
+	}
+
+	identifier := strings.ToLower(string(req.Identifier))
+
+	// NOTICE: the limits are checked before looking the user up, so they are reached the same way for the identifiers
+	// of users that don't exist.
+	if !s.allowUserEmailVerificationResend(ctx, "identifier", identifier, UserEmailVerificationResendLimit) ||
+		!s.allowUserEmailVerificationResend(ctx, "ip", req.IP, UserEmailVerificationResendIPLimit) {
+		return NewErrUserVerificationLimit(UserEmailVerificationResendLimit)
+	}
+
+	logger := log.WithContext(ctx).WithField("identifier", identifier)
+
+	var err error
+	var user *models.User
+
+	if req.Identifier.IsEmail() {
+		user, err = s.store.UserGetByEmail(ctx, identifier)
+	} else {
+		user, err = s.store.UserGetByUsername(ctx, identifier)
+	}
+
+	if err != nil {
+		logger.WithError(err).Info("email verification requested for a user not found")
+
+		return nil
+	}
+
+	if user.Status != models.UserStatusNotConfirmed {
+		logger.WithField("user_id", user.ID).Info("email verification requested for a user already confirmed")
+
+		return nil
+	}
+
+	// NOTICE: the token is kept on the store, instead of the cache, so it outlives the cache's restarts and evictions
+	// until it expires. A new token replaces the previous one, so only the last email sent verifies the user.
+	token := uuid.Generate()
+	now := clock.Now()
+	if err := s.store.UserVerificationSave(ctx, &models.UserVerification{
+		UserID:    user.ID,
+		Digest:    models.UserVerificationDigest(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.verification.ttl),
+	}); err != nil {
+		return err
+	}
+
+	message := mailer.NewVerificationMessage(s.verification.url, user.Name, user.Email, token, s.verification.ttl)
+
+	if err := s.mailer.Send(ctx, message); err != nil {
+		logger.WithError(err).WithField("user_id", user.ID).Error("unable to send the email verification")
+	}
+
+	return nil
+}
+
+// allowUserEmailVerificationResend counts a verification email requested by the value, of the specified kind, on the
+// current hour, reporting whether it is within the limit. An empty value, or a failure to count it, isn't limited.
+func (s *service) allowUserEmailVerificationResend(ctx context.Context, kind, value string, limit int) bool {
+	if value == "" {
+		return true
+	}
+
+	key := fmt.Sprintf("email-verification-resend={%s:%s}/%d", kind, value, clock.Now().Unix()/3600)
+
+	var count int
+	if err := s.cache.Get(ctx, key, &count); err != nil {
+		log.WithContext(ctx).WithError(err).WithField(kind, value).Warn("failed to get the email verification requests count from cache")
+	}
+
+	if count >= limit {
+		return false
+	}
+
+	if err := s.cache.Set(ctx, key, count+1, time.Hour); err != nil {
+		log.WithContext(ctx).WithError(err).WithField(kind, value).Warn("failed to set the email verification requests count on cache")
+	}
+
+	return true
+}
+
+func (s *service) VerifyUserEmail(ctx context.Context, req *requests.VerifyUserEmail) error {
+	if !envs.HasEmailVerification() {
+		return NewErrUserVerificationDisabled()
+	}
+
+	verification, err := s.store.UserVerificationGetByDigest(ctx, models.UserVerificationDigest(req.Token), clock.Now())
+	if err != nil {
+		return NewErrUserVerificationNotFound(req.Token, err)
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, verification.UserID, false)
+	if err != nil {
+		return NewErrUserNotFound(verification.UserID, err)
+	}
+
+	if !strings.EqualFold(user.Email, req.Email) {
+		return NewErrUserVerificationInvalid(req.Email)
+	}
+
+	if user.Status != models.UserStatusNotConfirmed {
+		return NewErrUserConfirmed(user.ID)
+	}
+
+	if err := s.store.UserUpdate(ctx, user.ID, &models.UserChanges{Status: models.UserStatusConfirmed}); err != nil {
+		return NewErrUserUpdate(user, err)
+	}
+
+	if err := s.store.UserVerificationDelete(ctx, user.ID); err != nil {
+		log.WithContext(ctx).WithError(err).WithField("user_id", user.ID).Warn("unable to delete the email verification token")
+	}
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	mockmailer "github.com/shellhub-io/shellhub/pkg/mailer/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestResendUserEmailVerification(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+	mailerMock := new(mockmailer.Mailer)
+	uuidMock := new(uuidmock.Uuid)
+	uuid.DefaultBackend = uuidMock
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	// count mocks the count of the verification emails requested by the value on the current hour.
+	count := func(ctx context.Context, kind, value string, requested int, counted bool) {
+		key := fmt.Sprintf("email-verification-resend={%s:%s}/%d", kind, value, now.Unix()/3600)
+
+		cacheMock.
+			On("Get", ctx, key, testifymock.Anything).
+			Run(func(args testifymock.Arguments) { *args.Get(2).(*int) = requested }).
+			Return(nil).
+			Once()
+
+		if counted {
+			cacheMock.
+				On("Set", ctx, key, requested+1, time.Hour).
+				Return(nil).
+				Once()
+		}
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.ResendUserEmailVerification
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when email verification is disabled",
+			req:         &requests.ResendUserEmailVerification{Identifier: "john_doe", IP: "192.168.0.1"},
+			requiredMocks: func(_ context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("false").
+					Once()
+			},
+			expected: NewErrUserVerificationDisabled(),
+		},
+		{
+			description: "fails when the identifier reached the limit",
+			req:         &requests.ResendUserEmailVerification{Identifier: "John_Doe", IP: "192.168.0.1"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				count(ctx, "identifier", "john_doe", UserEmailVerificationResendLimit, false)
+			},
+			expected: NewErrUserVerificationLimit(UserEmailVerificationResendLimit),
+		},
+		{
+			description: "fails when the IP address reached the limit",
+			req:         &requests.ResendUserEmailVerification{Identifier: "john_doe", IP: "192.168.0.1"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				count(ctx, "identifier", "john_doe", 0, true)
+				count(ctx, "ip", "192.168.0.1", UserEmailVerificationResendIPLimit, false)
+			},
+			expected: NewErrUserVerificationLimit(UserEmailVerificationResendLimit),
+		},
+		{
+			description: "succeeds without sending the email when the user is not found",
+			req:         &requests.ResendUserEmailVerification{Identifier: "john_doe", IP: "192.168.0.1"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				count(ctx, "identifier", "john_doe", 0, true)
+				count(ctx, "ip", "192.168.0.1", 0, true)
+				storeMock.
+					On("UserGetByUsername", ctx, "john_doe").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds without sending the email when the user is already confirmed",
+			req:         &requests.ResendUserEmailVerification{Identifier: "john.doe@test.com", IP: "192.168.0.1"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				count(ctx, "identifier", "john.doe@test.com", 0, true)
+				count(ctx, "ip", "192.168.0.1", 0, true)
+				storeMock.
+					On("UserGetByEmail", ctx, "john.doe@test.com").
+					Return(&models.User{ID: "000000000000000000000000", Status: models.UserStatusConfirmed}, nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the email cannot be sent",
+			req:         &requests.ResendUserEmailVerification{Identifier: "john_doe", IP: "192.168.0.1"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				count(ctx, "identifier", "john_doe", 0, true)
+				count(ctx, "ip", "192.168.0.1", 0, true)
+				storeMock.
+					On("UserGetByUsername", ctx, "john_doe").
+					Return(&models.User{
+						ID:       "000000000000000000000000",
+						Status:   models.UserStatusNotConfirmed,
+						UserData: models.UserData{Username: "john_doe", Email: "john.doe@test.com"},
+					}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000000").
+					Once()
+				storeMock.
+					On("UserVerificationSave", ctx, &models.UserVerification{
+						UserID:    "000000000000000000000000",
+						Digest:    models.UserVerificationDigest("00000000-0000-4000-0000-000000000000"),
+						CreatedAt: now,
+						ExpiresAt: now.Add(DefaultEmailVerificationTTL),
+					}).
+					Return(nil).
+					Once()
+				mailerMock.
+					On("Send", ctx, testifymock.AnythingOfType("*mailer.Message")).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds",
+			req:         &requests.ResendUserEmailVerification{Identifier: "john_doe", IP: "192.168.0.1"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				count(ctx, "identifier", "john_doe", UserEmailVerificationResendLimit-1, true)
+				count(ctx, "ip", "192.168.0.1", UserEmailVerificationResendIPLimit-1, true)
+				storeMock.
+					On("UserGetByUsername", ctx, "john_doe").
+					Return(&models.User{
+						ID:       "000000000000000000000000",
+						Status:   models.UserStatusNotConfirmed,
+						UserData: models.UserData{Username: "john_doe", Email: "john.doe@test.com"},
+					}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("00000000-0000-4000-0000-000000000000").
+					Once()
+				storeMock.
+					On("UserVerificationSave", ctx, &models.UserVerification{
+						UserID:    "000000000000000000000000",
+						Digest:    models.UserVerificationDigest("00000000-0000-4000-0000-000000000000"),
+						CreatedAt: now,
+						ExpiresAt: now.Add(DefaultEmailVerificationTTL),
+					}).
+					Return(nil).
+					Once()
+				mailerMock.
+					On("Send", ctx, testifymock.MatchedBy(func(m *mailer.Message) bool {
+						return m.To == "john.doe@test.com" &&
+							strings.Contains(m.Body, "https://shellhub.io/validation-account?email=john.doe%40test.com&token=00000000-0000-4000-0000-000000000000")
+					})).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, cacheMock, clientMock, WithMailer(mailerMock), WithEmailVerification("https://shellhub.io", DefaultEmailVerificationTTL))
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.ResendUserEmailVerification(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+}
+
+func TestVerifyUserEmail(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(now)
+
+	cases := []struct {
+		description   string
+		req           *requests.VerifyUserEmail
+		requiredMocks func(context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when email verification is disabled",
+			req:         &requests.VerifyUserEmail{Email: "john.doe@test.com", Token: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(_ context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("false").
+					Once()
+			},
+			expected: NewErrUserVerificationDisabled(),
+		},
+		{
+			description: "fails when the token is not found",
+			req:         &requests.VerifyUserEmail{Email: "john.doe@test.com", Token: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				storeMock.
+					On("UserVerificationGetByDigest", ctx, models.UserVerificationDigest("00000000-0000-4000-0000-000000000000"), now).
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: NewErrUserVerificationNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the token belongs to another email",
+			req:         &requests.VerifyUserEmail{Email: "jane.doe@test.com", Token: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				storeMock.
+					On("UserVerificationGetByDigest", ctx, models.UserVerificationDigest("00000000-0000-4000-0000-000000000000"), now).
+					Return(&models.UserVerification{UserID: "000000000000000000000000"}, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{
+						ID:       "000000000000000000000000",
+						Status:   models.UserStatusNotConfirmed,
+						UserData: models.UserData{Email: "john.doe@test.com"},
+					}, 0, nil).
+					Once()
+			},
+			expected: NewErrUserVerificationInvalid("jane.doe@test.com"),
+		},
+		{
+			description: "fails when the user cannot be updated",
+			req:         &requests.VerifyUserEmail{Email: "john.doe@test.com", Token: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				storeMock.
+					On("UserVerificationGetByDigest", ctx, models.UserVerificationDigest("00000000-0000-4000-0000-000000000000"), now).
+					Return(&models.UserVerification{UserID: "000000000000000000000000"}, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{
+						ID:       "000000000000000000000000",
+						Status:   models.UserStatusNotConfirmed,
+						UserData: models.UserData{Email: "john.doe@test.com"},
+					}, 0, nil).
+					Once()
+				storeMock.
+					On("UserUpdate", ctx, "000000000000000000000000", &models.UserChanges{Status: models.UserStatusConfirmed}).
+					Return(errors.New("error", "", 0)).
+					Once()
+			},
+			expected: NewErrUserUpdate(
+				&models.User{
+					ID:       "000000000000000000000000",
+					Status:   models.UserStatusNotConfirmed,
+					UserData: models.UserData{Email: "john.doe@test.com"},
+				},
+				errors.New("error", "", 0),
+			),
+		},
+		{
+			description: "succeeds",
+			req:         &requests.VerifyUserEmail{Email: "john.doe@test.com", Token: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func(ctx context.Context) {
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+				storeMock.
+					On("UserVerificationGetByDigest", ctx, models.UserVerificationDigest("00000000-0000-4000-0000-000000000000"), now).
+					Return(&models.UserVerification{UserID: "000000000000000000000000"}, nil).
+					Once()
+				storeMock.
+					On("UserGetByID", ctx, "000000000000000000000000", false).
+					Return(&models.User{
+						ID:       "000000000000000000000000",
+						Status:   models.UserStatusNotConfirmed,
+						UserData: models.UserData{Email: "john.doe@test.com"},
+					}, 0, nil).
+					Once()
+				storeMock.
+					On("UserUpdate", ctx, "000000000000000000000000", &models.UserChanges{Status: models.UserStatusConfirmed}).
+					Return(nil).
+					Once()
+				storeMock.
+					On("UserVerificationDelete", ctx, "000000000000000000000000").
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	s := NewService(storeMock, privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			err := s.VerifyUserEmail(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
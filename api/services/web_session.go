@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// webSessionTTL is how long a web session's token can be redeemed, when the request doesn't specify it.
+const webSessionTTL = 1 * time.Minute
+
+type WebSessionService interface {
+	// CreateWebSession mints a short-lived token that opens a web terminal on the tenant's device, for the requested
+	// OS user, without prompting the user for the device's credentials. The token can only be redeemed once.
+	CreateWebSession(ctx context.Context, req *requests.DeviceCreateWebSession) (*models.WebSessionToken, error)
+
+	// RedeemWebSession returns the web session encoded by the token, invalidating it. It returns
+	// [ErrWebSessionInvalid] when the token wasn't minted by the server, has expired or was already redeemed.
+	RedeemWebSession(ctx context.Context, req *requests.WebSessionRedeem) (*models.WebSession, error)
+}
+
+func (s *service) CreateWebSession(ctx context.Context, req *requests.DeviceCreateWebSession) (*models.WebSessionToken, error) {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), req.TenantID)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	if device.Status != models.DeviceStatusAccepted {
+		return nil, NewErrWebSessionDeviceStatus(device.Status)
+	}
+
+	ttl := webSessionTTL
+	if req.TTL > 0 {
+		ttl = time.Duration(req.TTL) * time.Second
+	}
+
+	session := &models.WebSession{
+		ID:        uuid.Generate(),
+		TenantID:  req.TenantID,
+		DeviceUID: device.UID,
+		UserID:    req.UserID,
+		Username:  req.Username,
+		ExpiresAt: clock.Now().Add(ttl),
+	}
+
+	token, err := jwttoken.EncodeWebSessionClaims(session, s.privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTICE: the token is only redeemable while its ID is kept on the cache, what makes it single-use.
+	if err := s.cache.Set(ctx, "web-session={"+session.ID+"}", session.DeviceUID, ttl); err != nil {
+		return nil, err
+	}
+
+	return &models.WebSessionToken{Token: token, ExpiresAt: session.ExpiresAt}, nil
+}
+
+func (s *service) RedeemWebSession(ctx context.Context, req *requests.WebSessionRedeem) (*models.WebSession, error) {
+	session, err := jwttoken.DecodeWebSessionClaims(s.pubKey, req.Token)
+	if err != nil {
+		return nil, NewErrWebSessionInvalid(err)
+	}
+
+	// NOTICE: the token's ID is read and deleted on the same operation, so only one of the concurrent redemptions of
+	// the token gets it.
+	var device string
+	if err := s.cache.GetDelete(ctx, "web-session={"+session.ID+"}", &device); err != nil {
+		return nil, err
+	}
+
+	if device == "" || device != session.DeviceUID {
+		return nil, NewErrWebSessionInvalid(errors.New("web session already redeemed"))
+	}
+
+	return session, nil
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	mockcache "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWebSession(t *testing.T) {
+	storeMock := new(mocks.Store)
+	cacheMock := new(mockcache.Cache)
+	uuidMock := new(uuidmock.Uuid)
+
+	backend := uuid.DefaultBackend
+	uuid.DefaultBackend = uuidMock
+	t.Cleanup(func() { uuid.DefaultBackend = backend })
+
+	type Expected struct {
+		session *models.WebSession
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.DeviceCreateWebSession
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			req: &requests.DeviceCreateWebSession{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Username:    "root",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).
+					Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the device is not accepted",
+			req: &requests.DeviceCreateWebSession{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Username:    "root",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Status: models.DeviceStatusPending}, nil).
+					Once()
+			},
+			expected: Expected{nil, NewErrWebSessionDeviceStatus(models.DeviceStatusPending)},
+		},
+		{
+			description: "succeeds with the default TTL",
+			req: &requests.DeviceCreateWebSession{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Username:    "root",
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Status: models.DeviceStatusAccepted}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("ffffffff-ffff-4fff-ffff-ffffffffffff").
+					Once()
+				cacheMock.
+					On("Set", ctx, "web-session={ffffffff-ffff-4fff-ffff-ffffffffffff}", "uid", time.Minute).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				&models.WebSession{
+					ID:        "ffffffff-ffff-4fff-ffff-ffffffffffff",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					DeviceUID: "uid",
+					UserID:    "000000000000000000000000",
+					Username:  "root",
+					ExpiresAt: now.Add(time.Minute).Truncate(time.Second),
+				},
+				nil,
+			},
+		},
+		{
+			description: "succeeds with the requested TTL",
+			req: &requests.DeviceCreateWebSession{
+				DeviceParam: requests.DeviceParam{UID: "uid"},
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				UserID:      "000000000000000000000000",
+				Username:    "root",
+				TTL:         120,
+			},
+			requiredMocks: func(ctx context.Context) {
+				storeMock.
+					On("DeviceGetByUID", ctx, models.UID("uid"), "00000000-0000-4000-0000-000000000000").
+					Return(&models.Device{UID: "uid", Status: models.DeviceStatusAccepted}, nil).
+					Once()
+				uuidMock.
+					On("Generate").
+					Return("ffffffff-ffff-4fff-ffff-ffffffffffff").
+					Once()
+				cacheMock.
+					On("Set", ctx, "web-session={ffffffff-ffff-4fff-ffff-ffffffffffff}", "uid", 2*time.Minute).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{
+				&models.WebSession{
+					ID:        "ffffffff-ffff-4fff-ffff-ffffffffffff",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					DeviceUID: "uid",
+					UserID:    "000000000000000000000000",
+					Username:  "root",
+					ExpiresAt: now.Add(2 * time.Minute).Truncate(time.Second),
+				},
+				nil,
+			},
+		},
+	}
+
+	clockMock.On("Now").Return(now)
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			token, err := s.CreateWebSession(ctx, tc.req)
+			if tc.expected.err != nil {
+				assert.Equal(t, tc.expected.err, err)
+				assert.Nil(t, token)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			session, err := jwttoken.DecodeWebSessionClaims(publicKey, token.Token)
+			assert.NoError(t, err)
+			assert.True(t, tc.expected.session.ExpiresAt.Equal(session.ExpiresAt))
+
+			session.ExpiresAt = tc.expected.session.ExpiresAt
+			assert.Equal(t, tc.expected.session, session)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
+
+func TestRedeemWebSession(t *testing.T) {
+	cacheMock := new(mockcache.Cache)
+
+	session := &models.WebSession{
+		ID:        "ffffffff-ffff-4fff-ffff-ffffffffffff",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "uid",
+		UserID:    "000000000000000000000000",
+		Username:  "root",
+		ExpiresAt: time.Now().Add(time.Minute).Truncate(time.Second),
+	}
+
+	token, err := jwttoken.EncodeWebSessionClaims(session, privateKey)
+	assert.NoError(t, err)
+
+	expired := *session
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	expiredToken, err := jwttoken.EncodeWebSessionClaims(&expired, privateKey)
+	assert.NoError(t, err)
+
+	device := func(uid string) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			*args.Get(2).(*string) = uid
+		}
+	}
+
+	type Expected struct {
+		session *models.WebSession
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		token         string
+		requiredMocks func(context.Context)
+		expected      Expected
+	}{
+		{
+			description:   "fails when the token is malformed",
+			token:         "invalid",
+			requiredMocks: func(context.Context) {},
+			expected:      Expected{nil, ErrWebSessionInvalid},
+		},
+		{
+			description:   "fails when the token has expired",
+			token:         expiredToken,
+			requiredMocks: func(context.Context) {},
+			expected:      Expected{nil, ErrWebSessionInvalid},
+		},
+		{
+			description: "fails when the token was already redeemed",
+			token:       token,
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("GetDelete", ctx, "web-session={ffffffff-ffff-4fff-ffff-ffffffffffff}", mock.Anything).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{nil, ErrWebSessionInvalid},
+		},
+		{
+			description: "succeeds",
+			token:       token,
+			requiredMocks: func(ctx context.Context) {
+				cacheMock.
+					On("GetDelete", ctx, "web-session={ffffffff-ffff-4fff-ffff-ffffffffffff}", mock.Anything).
+					Run(device("uid")).
+					Return(nil).
+					Once()
+			},
+			expected: Expected{session, nil},
+		},
+	}
+
+	s := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, cacheMock, clientMock)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			tc.requiredMocks(ctx)
+
+			redeemed, err := s.RedeemWebSession(ctx, &requests.WebSessionRedeem{Token: tc.token})
+			assert.ErrorIs(t, err, tc.expected.err)
+			if tc.expected.session != nil {
+				assert.True(t, tc.expected.session.ExpiresAt.Equal(redeemed.ExpiresAt))
+
+				redeemed.ExpiresAt = tc.expected.session.ExpiresAt
+			}
+
+			assert.Equal(t, tc.expected.session, redeemed)
+		})
+	}
+
+	cacheMock.AssertExpectations(t)
+}
+
+func TestRedeemWebSessionConcurrently(t *testing.T) {
+	cacheMock := new(mockcache.Cache)
+
+	session := &models.WebSession{
+		ID:        "ffffffff-ffff-4fff-ffff-ffffffffffff",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "uid",
+		UserID:    "000000000000000000000000",
+		Username:  "root",
+		ExpiresAt: time.Now().Add(time.Minute).Truncate(time.Second),
+	}
+
+	token, err := jwttoken.EncodeWebSessionClaims(session, privateKey)
+	assert.NoError(t, err)
+
+	// NOTICE: as the cache's GetDelete, only the first redemption gets the token's ID.
+	cacheMock.
+		On("GetDelete", mock.Anything, "web-session={ffffffff-ffff-4fff-ffff-ffffffffffff}", mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*string) = "uid" }).
+		Return(nil).
+		Once()
+	cacheMock.
+		On("GetDelete", mock.Anything, "web-session={ffffffff-ffff-4fff-ffff-ffffffffffff}", mock.Anything).
+		Return(nil)
+
+	s := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, cacheMock, clientMock)
+
+	var redeemed atomic.Int32
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := s.RedeemWebSession(context.Background(), &requests.WebSessionRedeem{Token: token}); err == nil {
+				redeemed.Add(1)
+			} else {
+				assert.ErrorIs(t, err, ErrWebSessionInvalid)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), redeemed.Load())
+	cacheMock.AssertNumberOfCalls(t, "GetDelete", 10)
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -27,6 +28,10 @@ type APIKeyStore interface {
 	// Returns the list of API keys, the total count of matched documents, and an error if any.
 	APIKeyList(ctx context.Context, tenantID string, paginator query.Paginator, sorter query.Sorter) (apiKeys []models.APIKey, count int, err error)
 
+	// APIKeyListByCreator retrieves the API keys of the specified tenant created by the user with the specified ID at
+	// or after since, the most recent first. Returns the list of API keys and an error if any.
+	APIKeyListByCreator(ctx context.Context, tenantID, userID string, since time.Time) (apiKeys []models.APIKey, err error)
+
 	// APIKeyUpdate updates an API key with the specified name and tenant ID using the given changes.
 	// Any zero values in the changes (e.g., empty strings) will be ignored during the update.
 	// Returns an error if any.
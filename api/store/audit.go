@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AuditStore interface {
+	// AuditEntryCreate appends an entry to the namespace's audit trail. Returns an error if any.
+	AuditEntryCreate(ctx context.Context, entry *models.AuditEntry) (err error)
+
+	// AuditEntryList retrieves a list of the entries of the tenant's audit trail selected by the filter, most recent
+	// first. Returns the list of entries, the total count of matched documents, and an error if any.
+	AuditEntryList(ctx context.Context, tenantID string, filter models.AuditEntryFilter, paginator query.Paginator) (entries []models.AuditEntry, count int, err error)
+}
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type BannedAddressStore interface {
+	// BannedAddressList retrieves every banned address, including the temporary bans not yet removed after they
+	// expired. Returns the list of banned addresses and an error if any.
+	BannedAddressList(ctx context.Context) (bans []models.BannedAddress, err error)
+
+	// BannedAddressSave creates the banned address or, when it is already banned, replaces its ban. Returns an error
+	// if any.
+	BannedAddressSave(ctx context.Context, ban *models.BannedAddress) (err error)
+
+	// BannedAddressDelete deletes the banned address. Returns ErrNoDocuments when the address isn't banned.
+	BannedAddressDelete(ctx context.Context, address string) (err error)
+}
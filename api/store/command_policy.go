@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type CommandPolicyStore interface {
+	// CommandPolicyList retrieves the command policies of the specified tenant, the oldest first. Returns the list of
+	// policies and an error if any.
+	CommandPolicyList(ctx context.Context, tenantID string) (policies []models.CommandPolicy, err error)
+
+	// CommandPolicyGet retrieves the tenant's command policy with the specified ID. Returns the policy and an error if
+	// any, or ErrNoDocuments when the policy isn't found.
+	CommandPolicyGet(ctx context.Context, tenantID, id string) (policy *models.CommandPolicy, err error)
+
+	// CommandPolicyCreate creates a command policy. Returns an error if any.
+	CommandPolicyCreate(ctx context.Context, policy *models.CommandPolicy) (err error)
+
+	// CommandPolicyUpdate replaces the name, the tags, the mode, the patterns and the action of the command policy with
+	// the policy's ID and tenant ID. Returns ErrNoDocuments when the policy isn't found and an error if any.
+	CommandPolicyUpdate(ctx context.Context, policy *models.CommandPolicy) (err error)
+
+	// CommandPolicyDelete deletes the tenant's command policy with the specified ID. Returns ErrNoDocuments when the
+	// policy isn't found and an error if any.
+	CommandPolicyDelete(ctx context.Context, tenantID, id string) (err error)
+
+	// CommandPolicyEvaluationCreate creates the log of a command line that violated a command policy. Returns an error
+	// if any.
+	CommandPolicyEvaluationCreate(ctx context.Context, evaluation *models.CommandPolicyEvaluation) (err error)
+
+	// CommandPolicyEvaluationList retrieves a list of the command lines that violated the command policies of the
+	// specified tenant, most recent first. Returns the list of evaluations, the total count of matched documents, and an
+	// error if any.
+	CommandPolicyEvaluationList(ctx context.Context, tenantID string, paginator query.Paginator) (evaluations []models.CommandPolicyEvaluation, count int, err error)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/geohash"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -14,9 +15,10 @@ const (
 	// DeviceAcceptableIfNotAccepted is used to indicate the all devices not accepted will be defined as "acceptabled".
 	DeviceAcceptableIfNotAccepted DeviceAcceptable = iota + 1
 	// DeviceAcceptableFromRemoved is used to indicate that the namepsace's device maxium number of devices has been
-	// reached and should set the "acceptable" value to true for devices that were recently removed.
+	// reached and should set the "acceptable" value to true for devices that were recently removed or are exempt from
+	// the limit.
 	DeviceAcceptableFromRemoved
-	// DeviceAcceptableAsFalse set acceptable to false to all returned devices.
+	// DeviceAcceptableAsFalse set acceptable to false to all returned devices, except the ones exempt from the limit.
 	DeviceAcceptableAsFalse
 )
 
@@ -34,8 +36,19 @@ type DeviceStore interface {
 	DeviceGetByMac(ctx context.Context, mac string, tenantID string, status models.DeviceStatus) (*models.Device, error)
 	DeviceGetByName(ctx context.Context, name string, tenantID string, status models.DeviceStatus) (*models.Device, error)
 	DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error)
+	// DeviceGetByClaimCode gets the device with the claim code, in the formatted form, from the tenant with the status.
+	DeviceGetByClaimCode(ctx context.Context, code string, tenantID string, status models.DeviceStatus) (*models.Device, error)
 	DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error
+	// DevicePositionClusters groups the tenant's accepted devices positioned inside the box by the geohash prefix of
+	// their positions, with precision characters, sorted by the prefix.
+	DevicePositionClusters(ctx context.Context, tenantID string, box geohash.Box, precision int) ([]models.DevicePositionCluster, error)
 	DeviceListByUsage(ctx context.Context, tenantID string) ([]models.UID, error)
+	// DeviceListByTenant retrieves all the tenant's devices, whatever their status, with their UID, name, tags and
+	// info only.
+	DeviceListByTenant(ctx context.Context, tenantID string) ([]models.Device, error)
+	// DeviceListIdentities retrieves all the devices, of every tenant and whatever their status, with their UID,
+	// tenant ID, identity and public key only, which are what their UIDs are derived from.
+	DeviceListIdentities(ctx context.Context) ([]models.Device, error)
 	DeviceChooser(ctx context.Context, tenantID string, chosen []string) error
 	DeviceRemovedCount(ctx context.Context, tenant string) (int64, error)
 	DeviceRemovedGet(ctx context.Context, tenant string, uid models.UID) (*models.DeviceRemoved, error)
@@ -45,10 +58,72 @@ type DeviceStore interface {
 	DeviceCreatePublicURLAddress(ctx context.Context, uid models.UID) error
 	DeviceGetByPublicURLAddress(ctx context.Context, address string) (*models.Device, error)
 
-	// DeviceSetOnline receives a list of devices to mark as online. For each device in the array, it will upsert
-	// a connected device entry; each UID must exists in the "devices" collection.
-	DeviceSetOnline(ctx context.Context, connectedDevices []models.ConnectedDevice) error
+	// DeviceSetCompromised marks or unmarks the device with the specified UID as suspected of being compromised.
+	DeviceSetCompromised(ctx context.Context, uid models.UID, compromised bool) error
+
+	// DeviceSetLoginShell sets the program started on the interactive sessions of the tenant's device with the
+	// specified UID. An empty loginShell unsets it.
+	DeviceSetLoginShell(ctx context.Context, tenant string, uid models.UID, loginShell string) error
+
+	// DeviceSetConnectionNote sets the connection note of the tenant's device with the specified UID. An empty note
+	// unsets it.
+	DeviceSetConnectionNote(ctx context.Context, tenant string, uid models.UID, note string) error
+
+	// DeviceSetConfig replaces the configuration values of the tenant's device with the specified UID, incrementing
+	// the configuration's version.
+	DeviceSetConfig(ctx context.Context, tenant string, uid models.UID, values map[string]string, updatedAt time.Time) error
+
+	// DeviceSetLimitExempt exempts, or revokes the exemption of, the tenant's device with the specified UID from the
+	// namespace's maximum number of devices.
+	DeviceSetLimitExempt(ctx context.Context, tenant string, uid models.UID, exempt bool) error
+
+	// DeviceSetQueue sets the entry of the tenant's device with the specified UID on the namespace's acceptance queue.
+	// A nil queue removes the device from the queue.
+	DeviceSetQueue(ctx context.Context, tenant string, uid models.UID, queue *models.DeviceQueue) error
+
+	// DeviceQueueList retrieves the tenant's pending devices on the acceptance queue, in the order they must be
+	// accepted.
+	DeviceQueueList(ctx context.Context, tenant string) ([]models.Device, error)
+
+	// DeviceSearch retrieves the devices, from any of the tenants, whose names contain the text, case-insensitively.
+	// When the status is empty, the devices are matched whatever their status. The devices are sorted by name.
+	//
+	// It returns the list of devices, the total count of matching devices (ignoring pagination), and an error if any.
+	DeviceSearch(ctx context.Context, tenants []string, name string, status models.DeviceStatus, paginator query.Paginator) ([]models.Device, int, error)
+
+	// DeviceSetRemoteAccess enables or disables the reverse SSH tunnel of the tenant's device with the specified UID,
+	// when its agent runs in inventory-only mode.
+	DeviceSetRemoteAccess(ctx context.Context, tenant string, uid models.UID, remoteAccess bool) error
+
+	// DeviceAddAddress appends the address to the remote addresses history of the device with the specified UID,
+	// keeping only the last [models.DeviceAddressesMax] entries.
+	DeviceAddAddress(ctx context.Context, uid models.UID, address models.DeviceAddress) error
+
+	// DeviceAddConnectionSample appends the sample to the connection rolling window of the device with the specified
+	// UID, keeping only the last [models.DeviceConnectionSamplesMax] entries, and sets the window's summary.
+	DeviceAddConnectionSample(ctx context.Context, uid models.UID, sample models.DeviceConnectionSample, quality *models.DeviceConnectionQuality) error
+
+	// DeviceHeartbeatBulk marks online the devices of a batch of heartbeats, upserting a connected device entry for
+	// each one of them; each UID must exists in the "devices" collection. The heartbeats of a device are reduced to its
+	// latest one, and a heartbeat older than the device's last seen doesn't write it, so batches flushed out of order
+	// don't move it back. It returns the devices that had no connected device entry, meaning they were offline before.
+	DeviceHeartbeatBulk(ctx context.Context, heartbeats []models.ConnectedDevice) ([]models.ConnectedDevice, error)
 
 	// DeviceSetOffline sets a device's status to offline using its UID.
 	DeviceSetOffline(ctx context.Context, uid string) error
+
+	// DeviceGetConnected retrieves the connected device entry of the device with the specified UID. It returns
+	// [ErrNoDocuments] when the device isn't connected.
+	DeviceGetConnected(ctx context.Context, uid string) (*models.ConnectedDevice, error)
+
+	// DeviceSetOfflineAt schedules the device with the specified UID to be considered offline at the specified time,
+	// keeping it online until then. A heartbeat received before it cancels the schedule.
+	DeviceSetOfflineAt(ctx context.Context, uid string, at time.Time) error
+
+	// DeviceSetOfflineExpired sets offline the devices whose scheduled offline time is before or equal to now,
+	// returning them.
+	DeviceSetOfflineExpired(ctx context.Context, now time.Time) ([]models.ConnectedDevice, error)
+
+	// DeviceCountByStatus counts the tenant's devices with the specified status.
+	DeviceCountByStatus(ctx context.Context, tenantID string, status models.DeviceStatus) (int64, error)
 }
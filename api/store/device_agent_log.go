@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceAgentLogStore interface {
+	// DeviceAgentLogCreateMany creates the logs reported by a device's agent. Returns an error if any.
+	DeviceAgentLogCreateMany(ctx context.Context, logs []models.DeviceAgentLog) (err error)
+
+	// DeviceAgentLogList retrieves a list of logs reported by the agent of the tenant's device with the specified UID,
+	// most recent first. When level is empty, logs of any level are returned. Returns the list of logs, the total
+	// count of matched documents, and an error if any.
+	DeviceAgentLogList(ctx context.Context, tenantID string, uid models.UID, level string, paginator query.Paginator) (logs []models.DeviceAgentLog, count int, err error)
+}
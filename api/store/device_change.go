@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceChangeStore interface {
+	// DeviceChangeRecord records the change as the last one of its device, giving it the next sequence number. Returns
+	// an error if any.
+	DeviceChangeRecord(ctx context.Context, change *models.DeviceChange) (err error)
+
+	// DeviceChangeList retrieves up to limit changes of the tenant's devices with a sequence number greater than since,
+	// in the order they happened. Returns the list of changes and an error if any.
+	DeviceChangeList(ctx context.Context, tenantID string, since int64, limit int) (changes []models.DeviceChange, err error)
+}
@@ -0,0 +1,27 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceCommandStore interface {
+	// DeviceCommandCreate queues a command to a device. Returns an error if any.
+	DeviceCommandCreate(ctx context.Context, command *models.DeviceCommand) (err error)
+
+	// DeviceCommandList retrieves a list of the commands queued to the tenant's device with the specified UID, most
+	// recent first. Returns the list of commands, the total count of matched documents, and an error if any.
+	DeviceCommandList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) (commands []models.DeviceCommand, count int, err error)
+
+	// DeviceCommandDispatch marks up to limit commands queued to the tenant's device with the specified UID as sent
+	// at sentAt, so each command is fetched only once. Returns the commands, oldest first, and an error if any.
+	DeviceCommandDispatch(ctx context.Context, tenantID string, uid models.UID, limit int, sentAt time.Time) (commands []models.DeviceCommand, err error)
+
+	// DeviceCommandFinish records the result of the sent command with the specified ID, queued to the tenant's device
+	// with the specified UID, marking it as finished at finishedAt. Returns ErrNoDocuments when no sent command
+	// matches and an error if any.
+	DeviceCommandFinish(ctx context.Context, tenantID string, uid models.UID, id string, result models.DeviceCommandResult, finishedAt time.Time) (err error)
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceKeyIncidentStore interface {
+	// DeviceKeyIncidentCreate creates a device key incident with the provided data. Returns the inserted ID and an
+	// error if any.
+	DeviceKeyIncidentCreate(ctx context.Context, incident *models.DeviceKeyIncident) (insertedID string, err error)
+
+	// DeviceKeyIncidentGet retrieves a device key incident based on its ID and tenant ID. Returns the incident and an
+	// error if any.
+	DeviceKeyIncidentGet(ctx context.Context, tenantID, id string) (incident *models.DeviceKeyIncident, err error)
+
+	// DeviceKeyIncidentGetByPublicKey retrieves the most recent device key incident opened for the pinned device with
+	// the specified UID when it was presented with the public key. Returns the incident and an error if any.
+	DeviceKeyIncidentGetByPublicKey(ctx context.Context, tenantID string, uid models.UID, publicKey string) (incident *models.DeviceKeyIncident, err error)
+
+	// DeviceKeyIncidentList retrieves a list of device key incidents for the specified tenant, most recent first. When
+	// status is empty, incidents of any status are returned. Returns the list of incidents, the total count of matched
+	// documents, and an error if any.
+	DeviceKeyIncidentList(ctx context.Context, tenantID string, status models.DeviceKeyIncidentStatus, paginator query.Paginator) (incidents []models.DeviceKeyIncident, count int, err error)
+
+	// DeviceKeyIncidentUpdateStatus updates the status of the device key incident with the specified ID and tenant ID.
+	// Returns an error if any.
+	DeviceKeyIncidentUpdateStatus(ctx context.Context, tenantID, id string, status models.DeviceKeyIncidentStatus) (err error)
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceLimitExemptionStore interface {
+	// DeviceLimitExemptionCreate creates a record of a change on the exemption of a device from the namespace's
+	// maximum number of devices. Returns an error if any.
+	DeviceLimitExemptionCreate(ctx context.Context, exemption *models.DeviceLimitExemption) (err error)
+
+	// DeviceLimitExemptionList retrieves a list of changes on the exemption of the tenant's device with the specified
+	// UID, most recent first. Returns the list of changes, the total count of matched documents, and an error if any.
+	DeviceLimitExemptionList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) (exemptions []models.DeviceLimitExemption, count int, err error)
+
+	// DeviceLimitExemptionListByUser retrieves the changes on the exemptions of the tenant's devices made by the user
+	// with the specified ID at or after since, the most recent first. Returns the list of changes and an error if any.
+	DeviceLimitExemptionListByUser(ctx context.Context, tenantID, userID string, since time.Time) (exemptions []models.DeviceLimitExemption, err error)
+}
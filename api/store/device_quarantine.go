@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceQuarantineStore interface {
+	// DeviceQuarantineAttemptCreate records an authentication attempt of a quarantined device. Returns an error if any.
+	DeviceQuarantineAttemptCreate(ctx context.Context, attempt *models.DeviceQuarantineAttempt) (err error)
+
+	// DeviceQuarantineAttemptGetLast retrieves the most recent attempt of the tenant's device with the specified UID.
+	// Returns the attempt or an error, [ErrNoDocuments] when the device has no attempts.
+	DeviceQuarantineAttemptGetLast(ctx context.Context, tenantID string, uid models.UID) (attempt *models.DeviceQuarantineAttempt, err error)
+
+	// DeviceQuarantineAttemptList retrieves a list of attempts of the tenant's quarantined devices, most recent first.
+	// When uid is empty, the attempts of any device are returned. Returns the list of attempts, the total count of
+	// matched documents, and an error if any.
+	DeviceQuarantineAttemptList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) (attempts []models.DeviceQuarantineAttempt, count int, err error)
+}
@@ -0,0 +1,25 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceShareStore interface {
+	// DeviceShareCreate creates a device share. Returns an error if any.
+	DeviceShareCreate(ctx context.Context, share *models.DeviceShare) (err error)
+
+	// DeviceShareList retrieves the shares of the tenant's device with the specified UID, the oldest first, including
+	// the expired ones. Returns the list of shares and an error if any.
+	DeviceShareList(ctx context.Context, tenantID, uid string) (shares []models.DeviceShare, err error)
+
+	// DeviceShareListByGrantee retrieves the shares granted to the specified grantee tenant that are active at the
+	// instant, the oldest first. Returns the list of shares and an error if any.
+	DeviceShareListByGrantee(ctx context.Context, granteeTenantID string, at time.Time) (shares []models.DeviceShare, err error)
+
+	// DeviceShareDelete deletes the share, with the specified ID, of the tenant's device with the specified UID.
+	// Returns ErrNoDocuments when the share isn't found and an error if any.
+	DeviceShareDelete(ctx context.Context, tenantID, uid, id string) (err error)
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceSnapshotStore interface {
+	// DeviceSnapshotCreate stores a snapshot of a device's information. Returns an error if any.
+	DeviceSnapshotCreate(ctx context.Context, snapshot *models.DeviceSnapshot) (err error)
+
+	// DeviceSnapshotGet retrieves the latest snapshot of the tenant's device with the specified UID taken at or before
+	// at. Returns ErrNoDocuments when no snapshot matches and an error if any.
+	DeviceSnapshotGet(ctx context.Context, tenantID string, uid models.UID, at time.Time) (snapshot *models.DeviceSnapshot, err error)
+}
@@ -20,10 +20,11 @@ type DeviceTagsStore interface {
 	DeviceSetTags(ctx context.Context, uid models.UID, tags []string) (matchedCount int64, updatedCount int64, err error)
 
 	// DeviceBulkRenameTag replaces all occurrences of the old tag with the new tag for all devices belonging to the specified tenant.
+	// The descendants of the old tag, like "region/europe" for "region", are moved to the new tag as well.
 	// Returns the number of documents updated and an error if any issues occur during the tag renaming.
 	DeviceBulkRenameTag(ctx context.Context, tenant, currentTag, newTag string) (updatedCount int64, err error)
 
-	// DeviceBulkDeleteTag removes a tag from all devices belonging to the specified tenant.
+	// DeviceBulkDeleteTag removes a tag, and its descendants, from all devices belonging to the specified tenant.
 	// Returns the number of documents updated and an error if any issues occur during the tag deletion.
 	DeviceBulkDeleteTag(ctx context.Context, tenant, tag string) (deletedCount int64, err error)
 
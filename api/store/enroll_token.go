@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type EnrollTokenStore interface {
+	// EnrollTokenCreate creates an enroll token. Returns an error if any.
+	EnrollTokenCreate(ctx context.Context, token *models.EnrollToken) (err error)
+
+	// EnrollTokenGetByDigest retrieves the enroll token with the specified digest. Returns the token or an error,
+	// [ErrNoDocuments] when there is no token with the digest.
+	EnrollTokenGetByDigest(ctx context.Context, digest string) (token *models.EnrollToken, err error)
+
+	// EnrollTokenList retrieves a list of the enroll tokens of the specified tenant not revoked, most recent first.
+	// Returns the list of
+	// tokens, the total count of matched documents, and an error if any.
+	EnrollTokenList(ctx context.Context, tenantID string, paginator query.Paginator) (tokens []models.EnrollToken, count int, err error)
+
+	// EnrollTokenUse atomically increments the uses of the enroll token with the specified ID, when it is usable at the
+	// instant. Returns an error, [ErrNoDocuments] when the token doesn't exist, is revoked, expired or used up.
+	EnrollTokenUse(ctx context.Context, id string, at time.Time) (err error)
+
+	// EnrollTokenRevoke revokes, at the instant, the enroll token of the specified tenant with the specified ID. Returns
+	// an error, [ErrNoDocuments] when the token doesn't exist or is already revoked.
+	EnrollTokenRevoke(ctx context.Context, tenantID, id string, at time.Time) (err error)
+}
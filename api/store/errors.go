@@ -1,6 +1,11 @@
 package store
 
-import "github.com/shellhub-io/shellhub/pkg/errors"
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
 
 // ErrLayer is an error level. Each error defined at this level, is container to it.
 // ErrLayer is the errors' level for store's error.
@@ -10,14 +15,21 @@ const (
 	ErrCodeNoDocument = iota + 1
 	ErrCodeDuplicated
 	ErrCodeInvalid
+	ErrCodeUnavailable
 )
 
 var (
 	ErrDuplicate   = errors.New("document duplicate", ErrLayer, ErrCodeDuplicated)
 	ErrNoDocuments = errors.New("no documents", ErrLayer, ErrCodeNoDocument)
 	ErrInvalidHex  = errors.New("the provided hex string is not a valid ObjectID", ErrLayer, ErrCodeInvalid)
+	// ErrUnavailable is returned when the database can't handle the operation in time, like when its connections are
+	// all in use. The operation may succeed when retried later.
+	ErrUnavailable = errors.New("the database is unavailable", ErrLayer, ErrCodeUnavailable)
 )
 
+// UnavailableBackoff is the backoff hinted to the clients refused due to [ErrUnavailable].
+var UnavailableBackoff = models.Backoff{RetryAfter: 5 * time.Second, Jitter: 10 * time.Second}
+
 // Errors used by Cloud.
 var (
 	ErrDuplicateUser  = errors.New("user already exists", ErrLayer, ErrCodeDuplicated)
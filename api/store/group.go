@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type GroupStore interface {
+	// GroupList retrieves the groups of the specified tenant, sorted by name. Returns the list of groups and an error
+	// if any.
+	GroupList(ctx context.Context, tenantID string) (groups []models.Group, err error)
+
+	// GroupGet retrieves the tenant's group with the specified ID. Returns the group and an error if any, or
+	// ErrNoDocuments when the group isn't found.
+	GroupGet(ctx context.Context, tenantID, id string) (group *models.Group, err error)
+
+	// GroupCreate creates a group. Returns ErrDuplicate when the parent already has a group with the same name and an
+	// error if any.
+	GroupCreate(ctx context.Context, group *models.Group) (err error)
+
+	// GroupUpdate replaces the parent, the name and the description of the group with the group's ID and tenant ID.
+	// Returns ErrNoDocuments when the group isn't found, ErrDuplicate when the parent already has another group with
+	// the same name and an error if any.
+	GroupUpdate(ctx context.Context, group *models.Group) (err error)
+
+	// GroupDelete deletes the tenant's group with the specified ID, removing it from the devices that belong to it.
+	// Returns ErrNoDocuments when the group isn't found and an error if any.
+	GroupDelete(ctx context.Context, tenantID, id string) (err error)
+
+	// GroupAddDevice adds the tenant's device with the specified UID to the group. Returns ErrNoDocuments when the
+	// device isn't found and an error if any.
+	GroupAddDevice(ctx context.Context, tenantID, id string, uid models.UID) (err error)
+
+	// GroupRemoveDevice removes the tenant's device with the specified UID from the group. Returns ErrNoDocuments when
+	// the device isn't found and an error if any.
+	GroupRemoveDevice(ctx context.Context, tenantID, id string, uid models.UID) (err error)
+}
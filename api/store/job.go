@@ -0,0 +1,33 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type JobStore interface {
+	// JobCreate creates an asynchronous job. Returns [ErrDuplicate] when the tenant already has a job with the job's
+	// idempotency key, or another error if any.
+	JobCreate(ctx context.Context, job *models.Job) (err error)
+
+	// JobGet retrieves the job with the specified ID. Returns the job or an error, [ErrNoDocuments] when it doesn't
+	// exist.
+	JobGet(ctx context.Context, id string) (job *models.Job, err error)
+
+	// JobGetByIdempotencyKey retrieves the tenant's job with the specified idempotency key. Returns the job or an
+	// error, [ErrNoDocuments] when it doesn't exist.
+	JobGetByIdempotencyKey(ctx context.Context, tenantID, key string) (job *models.Job, err error)
+
+	// JobSetRunning sets the job with the specified ID as running since at. Returns an error if any.
+	JobSetRunning(ctx context.Context, id string, at time.Time) (err error)
+
+	// JobSetItem sets the result of the job's item at the specified index, counting it as processed, and as failed
+	// when it has failed. Returns an error if any.
+	JobSetItem(ctx context.Context, id string, index int, item *models.JobItem) (err error)
+
+	// JobSetFinished sets the job with the specified ID as finished at, with the status and, when it has failed, the
+	// reason. Returns an error if any.
+	JobSetFinished(ctx context.Context, id string, status models.JobStatus, reason string, at time.Time) (err error)
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	geohash "github.com/shellhub-io/shellhub/pkg/geohash"
+
 	models "github.com/shellhub-io/shellhub/pkg/models"
 	mock "github.com/stretchr/testify/mock"
 
@@ -176,6 +178,32 @@ func (_m *Store) APIKeyList(ctx context.Context, tenantID string, paginator quer
 	return r0, r1, r2
 }
 
+// APIKeyListByCreator provides a mock function with given fields: ctx, tenantID, userID, since
+func (_m *Store) APIKeyListByCreator(ctx context.Context, tenantID string, userID string, since time.Time) ([]models.APIKey, error) {
+	ret := _m.Called(ctx, tenantID, userID, since)
+
+	var r0 []models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) ([]models.APIKey, error)); ok {
+		return rf(ctx, tenantID, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) []models.APIKey); ok {
+		r0 = rf(ctx, tenantID, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // APIKeyUpdate provides a mock function with given fields: ctx, tenantID, name, changes
 func (_m *Store) APIKeyUpdate(ctx context.Context, tenantID string, name string, changes *models.APIKeyChanges) error {
 	ret := _m.Called(ctx, tenantID, name, changes)
@@ -190,47 +218,86 @@ func (_m *Store) APIKeyUpdate(ctx context.Context, tenantID string, name string,
 	return r0
 }
 
-// DeviceBulkDeleteTag provides a mock function with given fields: ctx, tenant, tag
-func (_m *Store) DeviceBulkDeleteTag(ctx context.Context, tenant string, tag string) (int64, error) {
-	ret := _m.Called(ctx, tenant, tag)
+// AuditEntryCreate provides a mock function with given fields: ctx, entry
+func (_m *Store) AuditEntryCreate(ctx context.Context, entry *models.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
 
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
-		return rf(ctx, tenant, tag)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
-		r0 = rf(ctx, tenant, tag)
+
+	return r0
+}
+
+// AuditEntryList provides a mock function with given fields: ctx, tenantID, filter, paginator
+func (_m *Store) AuditEntryList(ctx context.Context, tenantID string, filter models.AuditEntryFilter, paginator query.Paginator) ([]models.AuditEntry, int, error) {
+	ret := _m.Called(ctx, tenantID, filter, paginator)
+
+	var r0 []models.AuditEntry
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AuditEntryFilter, query.Paginator) ([]models.AuditEntry, int, error)); ok {
+		return rf(ctx, tenantID, filter, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AuditEntryFilter, query.Paginator) []models.AuditEntry); ok {
+		r0 = rf(ctx, tenantID, filter, paginator)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditEntry)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, tenant, tag)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.AuditEntryFilter, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, filter, paginator)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.AuditEntryFilter, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, filter, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// DeviceBulkRenameTag provides a mock function with given fields: ctx, tenant, currentTag, newTag
-func (_m *Store) DeviceBulkRenameTag(ctx context.Context, tenant string, currentTag string, newTag string) (int64, error) {
-	ret := _m.Called(ctx, tenant, currentTag, newTag)
+// BannedAddressDelete provides a mock function with given fields: ctx, address
+func (_m *Store) BannedAddressDelete(ctx context.Context, address string) error {
+	ret := _m.Called(ctx, address)
 
-	var r0 int64
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BannedAddressList provides a mock function with given fields: ctx
+func (_m *Store) BannedAddressList(ctx context.Context) ([]models.BannedAddress, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []models.BannedAddress
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (int64, error)); ok {
-		return rf(ctx, tenant, currentTag, newTag)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.BannedAddress, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) int64); ok {
-		r0 = rf(ctx, tenant, currentTag, newTag)
+	if rf, ok := ret.Get(0).(func(context.Context) []models.BannedAddress); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BannedAddress)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, tenant, currentTag, newTag)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -238,13 +305,13 @@ func (_m *Store) DeviceBulkRenameTag(ctx context.Context, tenant string, current
 	return r0, r1
 }
 
-// DeviceChooser provides a mock function with given fields: ctx, tenantID, chosen
-func (_m *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
-	ret := _m.Called(ctx, tenantID, chosen)
+// BannedAddressSave provides a mock function with given fields: ctx, ban
+func (_m *Store) BannedAddressSave(ctx context.Context, ban *models.BannedAddress) error {
+	ret := _m.Called(ctx, ban)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
-		r0 = rf(ctx, tenantID, chosen)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BannedAddress) error); ok {
+		r0 = rf(ctx, ban)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -252,13 +319,13 @@ func (_m *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []st
 	return r0
 }
 
-// DeviceCreate provides a mock function with given fields: ctx, d, hostname
-func (_m *Store) DeviceCreate(ctx context.Context, d models.Device, hostname string) error {
-	ret := _m.Called(ctx, d, hostname)
+// CommandPolicyCreate provides a mock function with given fields: ctx, policy
+func (_m *Store) CommandPolicyCreate(ctx context.Context, policy *models.CommandPolicy) error {
+	ret := _m.Called(ctx, policy)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.Device, string) error); ok {
-		r0 = rf(ctx, d, hostname)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CommandPolicy) error); ok {
+		r0 = rf(ctx, policy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -266,13 +333,13 @@ func (_m *Store) DeviceCreate(ctx context.Context, d models.Device, hostname str
 	return r0
 }
 
-// DeviceCreatePublicURLAddress provides a mock function with given fields: ctx, uid
-func (_m *Store) DeviceCreatePublicURLAddress(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// CommandPolicyDelete provides a mock function with given fields: ctx, tenantID, id
+func (_m *Store) CommandPolicyDelete(ctx context.Context, tenantID string, id string) error {
+	ret := _m.Called(ctx, tenantID, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenantID, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -280,13 +347,13 @@ func (_m *Store) DeviceCreatePublicURLAddress(ctx context.Context, uid models.UI
 	return r0
 }
 
-// DeviceDelete provides a mock function with given fields: ctx, uid
-func (_m *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// CommandPolicyEvaluationCreate provides a mock function with given fields: ctx, evaluation
+func (_m *Store) CommandPolicyEvaluationCreate(ctx context.Context, evaluation *models.CommandPolicyEvaluation) error {
+	ret := _m.Called(ctx, evaluation)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CommandPolicyEvaluation) error); ok {
+		r0 = rf(ctx, evaluation)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -294,51 +361,58 @@ func (_m *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
-// DeviceGet provides a mock function with given fields: ctx, uid
-func (_m *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device, error) {
-	ret := _m.Called(ctx, uid)
+// CommandPolicyEvaluationList provides a mock function with given fields: ctx, tenantID, paginator
+func (_m *Store) CommandPolicyEvaluationList(ctx context.Context, tenantID string, paginator query.Paginator) ([]models.CommandPolicyEvaluation, int, error) {
+	ret := _m.Called(ctx, tenantID, paginator)
 
-	var r0 *models.Device
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Device, error)); ok {
-		return rf(ctx, uid)
+	var r0 []models.CommandPolicyEvaluation
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.CommandPolicyEvaluation, int, error)); ok {
+		return rf(ctx, tenantID, paginator)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Device); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.CommandPolicyEvaluation); ok {
+		r0 = rf(ctx, tenantID, paginator)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).([]models.CommandPolicyEvaluation)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
-		r1 = rf(ctx, uid)
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, paginator)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// DeviceGetByMac provides a mock function with given fields: ctx, mac, tenantID, status
-func (_m *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
-	ret := _m.Called(ctx, mac, tenantID, status)
+// CommandPolicyGet provides a mock function with given fields: ctx, tenantID, id
+func (_m *Store) CommandPolicyGet(ctx context.Context, tenantID string, id string) (*models.CommandPolicy, error) {
+	ret := _m.Called(ctx, tenantID, id)
 
-	var r0 *models.Device
+	var r0 *models.CommandPolicy
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) (*models.Device, error)); ok {
-		return rf(ctx, mac, tenantID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.CommandPolicy, error)); ok {
+		return rf(ctx, tenantID, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) *models.Device); ok {
-		r0 = rf(ctx, mac, tenantID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.CommandPolicy); ok {
+		r0 = rf(ctx, tenantID, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).(*models.CommandPolicy)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.DeviceStatus) error); ok {
-		r1 = rf(ctx, mac, tenantID, status)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -346,25 +420,25 @@ func (_m *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string
 	return r0, r1
 }
 
-// DeviceGetByName provides a mock function with given fields: ctx, name, tenantID, status
-func (_m *Store) DeviceGetByName(ctx context.Context, name string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
-	ret := _m.Called(ctx, name, tenantID, status)
+// CommandPolicyList provides a mock function with given fields: ctx, tenantID
+func (_m *Store) CommandPolicyList(ctx context.Context, tenantID string) ([]models.CommandPolicy, error) {
+	ret := _m.Called(ctx, tenantID)
 
-	var r0 *models.Device
+	var r0 []models.CommandPolicy
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) (*models.Device, error)); ok {
-		return rf(ctx, name, tenantID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.CommandPolicy, error)); ok {
+		return rf(ctx, tenantID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) *models.Device); ok {
-		r0 = rf(ctx, name, tenantID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.CommandPolicy); ok {
+		r0 = rf(ctx, tenantID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).([]models.CommandPolicy)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.DeviceStatus) error); ok {
-		r1 = rf(ctx, name, tenantID, status)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -372,84 +446,88 @@ func (_m *Store) DeviceGetByName(ctx context.Context, name string, tenantID stri
 	return r0, r1
 }
 
-// DeviceGetByPublicURLAddress provides a mock function with given fields: ctx, address
-func (_m *Store) DeviceGetByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
-	ret := _m.Called(ctx, address)
+// CommandPolicyUpdate provides a mock function with given fields: ctx, policy
+func (_m *Store) CommandPolicyUpdate(ctx context.Context, policy *models.CommandPolicy) error {
+	ret := _m.Called(ctx, policy)
 
-	var r0 *models.Device
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Device, error)); ok {
-		return rf(ctx, address)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Device); ok {
-		r0 = rf(ctx, address)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CommandPolicy) error); ok {
+		r0 = rf(ctx, policy)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, address)
+	return r0
+}
+
+// DeviceAddAddress provides a mock function with given fields: ctx, uid, address
+func (_m *Store) DeviceAddAddress(ctx context.Context, uid models.UID, address models.DeviceAddress) error {
+	ret := _m.Called(ctx, uid, address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, models.DeviceAddress) error); ok {
+		r0 = rf(ctx, uid, address)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// DeviceGetByUID provides a mock function with given fields: ctx, uid, tenantID
-func (_m *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error) {
-	ret := _m.Called(ctx, uid, tenantID)
+// DeviceAddConnectionSample provides a mock function with given fields: ctx, uid, sample, quality
+func (_m *Store) DeviceAddConnectionSample(ctx context.Context, uid models.UID, sample models.DeviceConnectionSample, quality *models.DeviceConnectionQuality) error {
+	ret := _m.Called(ctx, uid, sample, quality)
 
-	var r0 *models.Device
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) (*models.Device, error)); ok {
-		return rf(ctx, uid, tenantID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) *models.Device); ok {
-		r0 = rf(ctx, uid, tenantID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, models.DeviceConnectionSample, *models.DeviceConnectionQuality) error); ok {
+		r0 = rf(ctx, uid, sample, quality)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string) error); ok {
-		r1 = rf(ctx, uid, tenantID)
+	return r0
+}
+
+// DeviceAgentLogCreateMany provides a mock function with given fields: ctx, logs
+func (_m *Store) DeviceAgentLogCreateMany(ctx context.Context, logs []models.DeviceAgentLog) error {
+	ret := _m.Called(ctx, logs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.DeviceAgentLog) error); ok {
+		r0 = rf(ctx, logs)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// DeviceGetTags provides a mock function with given fields: ctx, tenant
-func (_m *Store) DeviceGetTags(ctx context.Context, tenant string) ([]string, int, error) {
-	ret := _m.Called(ctx, tenant)
+// DeviceAgentLogList provides a mock function with given fields: ctx, tenantID, uid, level, paginator
+func (_m *Store) DeviceAgentLogList(ctx context.Context, tenantID string, uid models.UID, level string, paginator query.Paginator) ([]models.DeviceAgentLog, int, error) {
+	ret := _m.Called(ctx, tenantID, uid, level, paginator)
 
-	var r0 []string
+	var r0 []models.DeviceAgentLog
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
-		return rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string, query.Paginator) ([]models.DeviceAgentLog, int, error)); ok {
+		return rf(ctx, tenantID, uid, level, paginator)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string, query.Paginator) []models.DeviceAgentLog); ok {
+		r0 = rf(ctx, tenantID, uid, level, paginator)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]models.DeviceAgentLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
-		r1 = rf(ctx, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, uid, level, paginator)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, tenant)
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, uid, level, paginator)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -457,58 +535,1390 @@ func (_m *Store) DeviceGetTags(ctx context.Context, tenant string) ([]string, in
 	return r0, r1, r2
 }
 
-// DeviceList provides a mock function with given fields: ctx, status, pagination, filters, sorter, acceptable
-func (_m *Store) DeviceList(ctx context.Context, status models.DeviceStatus, pagination query.Paginator, filters query.Filters, sorter query.Sorter, acceptable store.DeviceAcceptable) ([]models.Device, int, error) {
-	ret := _m.Called(ctx, status, pagination, filters, sorter, acceptable)
+// DeviceBulkDeleteTag provides a mock function with given fields: ctx, tenant, tag
+func (_m *Store) DeviceBulkDeleteTag(ctx context.Context, tenant string, tag string) (int64, error) {
+	ret := _m.Called(ctx, tenant, tag)
 
-	var r0 []models.Device
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter, store.DeviceAcceptable) ([]models.Device, int, error)); ok {
-		return rf(ctx, status, pagination, filters, sorter, acceptable)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter, store.DeviceAcceptable) []models.Device); ok {
-		r0 = rf(ctx, status, pagination, filters, sorter, acceptable)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Device)
-		}
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, tenant, tag)
 	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter, store.DeviceAcceptable) int); ok {
-		r1 = rf(ctx, status, pagination, filters, sorter, acceptable)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, tenant, tag)
 	} else {
-		r1 = ret.Get(1).(int)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter, store.DeviceAcceptable) error); ok {
-		r2 = rf(ctx, status, pagination, filters, sorter, acceptable)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, tag)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// DeviceListByUsage provides a mock function with given fields: ctx, tenantID
-func (_m *Store) DeviceListByUsage(ctx context.Context, tenantID string) ([]models.UID, error) {
+// DeviceBulkRenameTag provides a mock function with given fields: ctx, tenant, currentTag, newTag
+func (_m *Store) DeviceBulkRenameTag(ctx context.Context, tenant string, currentTag string, newTag string) (int64, error) {
+	ret := _m.Called(ctx, tenant, currentTag, newTag)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (int64, error)); ok {
+		return rf(ctx, tenant, currentTag, newTag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) int64); ok {
+		r0 = rf(ctx, tenant, currentTag, newTag)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, currentTag, newTag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceChangeList provides a mock function with given fields: ctx, tenantID, since, limit
+func (_m *Store) DeviceChangeList(ctx context.Context, tenantID string, since int64, limit int) ([]models.DeviceChange, error) {
+	ret := _m.Called(ctx, tenantID, since, limit)
+
+	var r0 []models.DeviceChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int) ([]models.DeviceChange, error)); ok {
+		return rf(ctx, tenantID, since, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int) []models.DeviceChange); ok {
+		r0 = rf(ctx, tenantID, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int) error); ok {
+		r1 = rf(ctx, tenantID, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceChangeRecord provides a mock function with given fields: ctx, change
+func (_m *Store) DeviceChangeRecord(ctx context.Context, change *models.DeviceChange) error {
+	ret := _m.Called(ctx, change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceChange) error); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceChooser provides a mock function with given fields: ctx, tenantID, chosen
+func (_m *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
+	ret := _m.Called(ctx, tenantID, chosen)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, tenantID, chosen)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceCommandCreate provides a mock function with given fields: ctx, command
+func (_m *Store) DeviceCommandCreate(ctx context.Context, command *models.DeviceCommand) error {
+	ret := _m.Called(ctx, command)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceCommand) error); ok {
+		r0 = rf(ctx, command)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceCommandDispatch provides a mock function with given fields: ctx, tenantID, uid, limit, sentAt
+func (_m *Store) DeviceCommandDispatch(ctx context.Context, tenantID string, uid models.UID, limit int, sentAt time.Time) ([]models.DeviceCommand, error) {
+	ret := _m.Called(ctx, tenantID, uid, limit, sentAt)
+
+	var r0 []models.DeviceCommand
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, int, time.Time) ([]models.DeviceCommand, error)); ok {
+		return rf(ctx, tenantID, uid, limit, sentAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, int, time.Time) []models.DeviceCommand); ok {
+		r0 = rf(ctx, tenantID, uid, limit, sentAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceCommand)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, int, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, uid, limit, sentAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceCommandFinish provides a mock function with given fields: ctx, tenantID, uid, id, result, finishedAt
+func (_m *Store) DeviceCommandFinish(ctx context.Context, tenantID string, uid models.UID, id string, result models.DeviceCommandResult, finishedAt time.Time) error {
+	ret := _m.Called(ctx, tenantID, uid, id, result, finishedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string, models.DeviceCommandResult, time.Time) error); ok {
+		r0 = rf(ctx, tenantID, uid, id, result, finishedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceCommandList provides a mock function with given fields: ctx, tenantID, uid, paginator
+func (_m *Store) DeviceCommandList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceCommand, int, error) {
+	ret := _m.Called(ctx, tenantID, uid, paginator)
+
+	var r0 []models.DeviceCommand
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) ([]models.DeviceCommand, int, error)); ok {
+		return rf(ctx, tenantID, uid, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) []models.DeviceCommand); ok {
+		r0 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceCommand)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceCountByStatus provides a mock function with given fields: ctx, tenantID, status
+func (_m *Store) DeviceCountByStatus(ctx context.Context, tenantID string, status models.DeviceStatus) (int64, error) {
+	ret := _m.Called(ctx, tenantID, status)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceStatus) (int64, error)); ok {
+		return rf(ctx, tenantID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceStatus) int64); ok {
+		r0 = rf(ctx, tenantID, status)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.DeviceStatus) error); ok {
+		r1 = rf(ctx, tenantID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceCreate provides a mock function with given fields: ctx, d, hostname
+func (_m *Store) DeviceCreate(ctx context.Context, d models.Device, hostname string) error {
+	ret := _m.Called(ctx, d, hostname)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Device, string) error); ok {
+		r0 = rf(ctx, d, hostname)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceCreatePublicURLAddress provides a mock function with given fields: ctx, uid
+func (_m *Store) DeviceCreatePublicURLAddress(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceDelete provides a mock function with given fields: ctx, uid
+func (_m *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceGet provides a mock function with given fields: ctx, uid
+func (_m *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Device, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Device); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGetByClaimCode provides a mock function with given fields: ctx, code, tenantID, status
+func (_m *Store) DeviceGetByClaimCode(ctx context.Context, code string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	ret := _m.Called(ctx, code, tenantID, status)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) (*models.Device, error)); ok {
+		return rf(ctx, code, tenantID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) *models.Device); ok {
+		r0 = rf(ctx, code, tenantID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.DeviceStatus) error); ok {
+		r1 = rf(ctx, code, tenantID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGetByMac provides a mock function with given fields: ctx, mac, tenantID, status
+func (_m *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	ret := _m.Called(ctx, mac, tenantID, status)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) (*models.Device, error)); ok {
+		return rf(ctx, mac, tenantID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) *models.Device); ok {
+		r0 = rf(ctx, mac, tenantID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.DeviceStatus) error); ok {
+		r1 = rf(ctx, mac, tenantID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGetByName provides a mock function with given fields: ctx, name, tenantID, status
+func (_m *Store) DeviceGetByName(ctx context.Context, name string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	ret := _m.Called(ctx, name, tenantID, status)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) (*models.Device, error)); ok {
+		return rf(ctx, name, tenantID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceStatus) *models.Device); ok {
+		r0 = rf(ctx, name, tenantID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.DeviceStatus) error); ok {
+		r1 = rf(ctx, name, tenantID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGetByPublicURLAddress provides a mock function with given fields: ctx, address
+func (_m *Store) DeviceGetByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Device, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Device); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGetByUID provides a mock function with given fields: ctx, uid, tenantID
+func (_m *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error) {
+	ret := _m.Called(ctx, uid, tenantID)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) (*models.Device, error)); ok {
+		return rf(ctx, uid, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) *models.Device); ok {
+		r0 = rf(ctx, uid, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string) error); ok {
+		r1 = rf(ctx, uid, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGetConnected provides a mock function with given fields: ctx, uid
+func (_m *Store) DeviceGetConnected(ctx context.Context, uid string) (*models.ConnectedDevice, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *models.ConnectedDevice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ConnectedDevice, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ConnectedDevice); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConnectedDevice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGetTags provides a mock function with given fields: ctx, tenant
+func (_m *Store) DeviceGetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []string
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tenant)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceHeartbeatBulk provides a mock function with given fields: ctx, heartbeats
+func (_m *Store) DeviceHeartbeatBulk(ctx context.Context, heartbeats []models.ConnectedDevice) ([]models.ConnectedDevice, error) {
+	ret := _m.Called(ctx, heartbeats)
+
+	var r0 []models.ConnectedDevice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ConnectedDevice) ([]models.ConnectedDevice, error)); ok {
+		return rf(ctx, heartbeats)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ConnectedDevice) []models.ConnectedDevice); ok {
+		r0 = rf(ctx, heartbeats)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ConnectedDevice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.ConnectedDevice) error); ok {
+		r1 = rf(ctx, heartbeats)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceKeyIncidentCreate provides a mock function with given fields: ctx, incident
+func (_m *Store) DeviceKeyIncidentCreate(ctx context.Context, incident *models.DeviceKeyIncident) (string, error) {
+	ret := _m.Called(ctx, incident)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceKeyIncident) (string, error)); ok {
+		return rf(ctx, incident)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceKeyIncident) string); ok {
+		r0 = rf(ctx, incident)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DeviceKeyIncident) error); ok {
+		r1 = rf(ctx, incident)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceKeyIncidentGet provides a mock function with given fields: ctx, tenantID, id
+func (_m *Store) DeviceKeyIncidentGet(ctx context.Context, tenantID string, id string) (*models.DeviceKeyIncident, error) {
+	ret := _m.Called(ctx, tenantID, id)
+
+	var r0 *models.DeviceKeyIncident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceKeyIncident, error)); ok {
+		return rf(ctx, tenantID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceKeyIncident); ok {
+		r0 = rf(ctx, tenantID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceKeyIncident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceKeyIncidentGetByPublicKey provides a mock function with given fields: ctx, tenantID, uid, publicKey
+func (_m *Store) DeviceKeyIncidentGetByPublicKey(ctx context.Context, tenantID string, uid models.UID, publicKey string) (*models.DeviceKeyIncident, error) {
+	ret := _m.Called(ctx, tenantID, uid, publicKey)
+
+	var r0 *models.DeviceKeyIncident
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) (*models.DeviceKeyIncident, error)); ok {
+		return rf(ctx, tenantID, uid, publicKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) *models.DeviceKeyIncident); ok {
+		r0 = rf(ctx, tenantID, uid, publicKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceKeyIncident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, string) error); ok {
+		r1 = rf(ctx, tenantID, uid, publicKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceKeyIncidentList provides a mock function with given fields: ctx, tenantID, status, paginator
+func (_m *Store) DeviceKeyIncidentList(ctx context.Context, tenantID string, status models.DeviceKeyIncidentStatus, paginator query.Paginator) ([]models.DeviceKeyIncident, int, error) {
+	ret := _m.Called(ctx, tenantID, status, paginator)
+
+	var r0 []models.DeviceKeyIncident
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceKeyIncidentStatus, query.Paginator) ([]models.DeviceKeyIncident, int, error)); ok {
+		return rf(ctx, tenantID, status, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceKeyIncidentStatus, query.Paginator) []models.DeviceKeyIncident); ok {
+		r0 = rf(ctx, tenantID, status, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceKeyIncident)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.DeviceKeyIncidentStatus, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, status, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.DeviceKeyIncidentStatus, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, status, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceKeyIncidentUpdateStatus provides a mock function with given fields: ctx, tenantID, id, status
+func (_m *Store) DeviceKeyIncidentUpdateStatus(ctx context.Context, tenantID string, id string, status models.DeviceKeyIncidentStatus) error {
+	ret := _m.Called(ctx, tenantID, id, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.DeviceKeyIncidentStatus) error); ok {
+		r0 = rf(ctx, tenantID, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceLimitExemptionCreate provides a mock function with given fields: ctx, exemption
+func (_m *Store) DeviceLimitExemptionCreate(ctx context.Context, exemption *models.DeviceLimitExemption) error {
+	ret := _m.Called(ctx, exemption)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceLimitExemption) error); ok {
+		r0 = rf(ctx, exemption)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceLimitExemptionList provides a mock function with given fields: ctx, tenantID, uid, paginator
+func (_m *Store) DeviceLimitExemptionList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceLimitExemption, int, error) {
+	ret := _m.Called(ctx, tenantID, uid, paginator)
+
+	var r0 []models.DeviceLimitExemption
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) ([]models.DeviceLimitExemption, int, error)); ok {
+		return rf(ctx, tenantID, uid, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) []models.DeviceLimitExemption); ok {
+		r0 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceLimitExemption)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceLimitExemptionListByUser provides a mock function with given fields: ctx, tenantID, userID, since
+func (_m *Store) DeviceLimitExemptionListByUser(ctx context.Context, tenantID string, userID string, since time.Time) ([]models.DeviceLimitExemption, error) {
+	ret := _m.Called(ctx, tenantID, userID, since)
+
+	var r0 []models.DeviceLimitExemption
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) ([]models.DeviceLimitExemption, error)); ok {
+		return rf(ctx, tenantID, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) []models.DeviceLimitExemption); ok {
+		r0 = rf(ctx, tenantID, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceLimitExemption)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceList provides a mock function with given fields: ctx, status, pagination, filters, sorter, acceptable
+func (_m *Store) DeviceList(ctx context.Context, status models.DeviceStatus, pagination query.Paginator, filters query.Filters, sorter query.Sorter, acceptable store.DeviceAcceptable) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, status, pagination, filters, sorter, acceptable)
+
+	var r0 []models.Device
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter, store.DeviceAcceptable) ([]models.Device, int, error)); ok {
+		return rf(ctx, status, pagination, filters, sorter, acceptable)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter, store.DeviceAcceptable) []models.Device); ok {
+		r0 = rf(ctx, status, pagination, filters, sorter, acceptable)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter, store.DeviceAcceptable) int); ok {
+		r1 = rf(ctx, status, pagination, filters, sorter, acceptable)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.DeviceStatus, query.Paginator, query.Filters, query.Sorter, store.DeviceAcceptable) error); ok {
+		r2 = rf(ctx, status, pagination, filters, sorter, acceptable)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceListByTenant provides a mock function with given fields: ctx, tenantID
+func (_m *Store) DeviceListByTenant(ctx context.Context, tenantID string) ([]models.Device, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 []models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Device, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Device); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceListByUsage provides a mock function with given fields: ctx, tenantID
+func (_m *Store) DeviceListByUsage(ctx context.Context, tenantID string) ([]models.UID, error) {
 	ret := _m.Called(ctx, tenantID)
 
-	var r0 []models.UID
+	var r0 []models.UID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.UID, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.UID); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceListIdentities provides a mock function with given fields: ctx
+func (_m *Store) DeviceListIdentities(ctx context.Context) ([]models.Device, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Device, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Device); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceLookup provides a mock function with given fields: ctx, namespace, hostname
+func (_m *Store) DeviceLookup(ctx context.Context, namespace string, hostname string) (*models.Device, error) {
+	ret := _m.Called(ctx, namespace, hostname)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
+		return rf(ctx, namespace, hostname)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
+		r0 = rf(ctx, namespace, hostname)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, hostname)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DevicePositionClusters provides a mock function with given fields: ctx, tenantID, box, precision
+func (_m *Store) DevicePositionClusters(ctx context.Context, tenantID string, box geohash.Box, precision int) ([]models.DevicePositionCluster, error) {
+	ret := _m.Called(ctx, tenantID, box, precision)
+
+	var r0 []models.DevicePositionCluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, geohash.Box, int) ([]models.DevicePositionCluster, error)); ok {
+		return rf(ctx, tenantID, box, precision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, geohash.Box, int) []models.DevicePositionCluster); ok {
+		r0 = rf(ctx, tenantID, box, precision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DevicePositionCluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, geohash.Box, int) error); ok {
+		r1 = rf(ctx, tenantID, box, precision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DevicePullTag provides a mock function with given fields: ctx, uid, tag
+func (_m *Store) DevicePullTag(ctx context.Context, uid models.UID, tag string) error {
+	ret := _m.Called(ctx, uid, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DevicePushTag provides a mock function with given fields: ctx, uid, tag
+func (_m *Store) DevicePushTag(ctx context.Context, uid models.UID, tag string) error {
+	ret := _m.Called(ctx, uid, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceQuarantineAttemptCreate provides a mock function with given fields: ctx, attempt
+func (_m *Store) DeviceQuarantineAttemptCreate(ctx context.Context, attempt *models.DeviceQuarantineAttempt) error {
+	ret := _m.Called(ctx, attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceQuarantineAttempt) error); ok {
+		r0 = rf(ctx, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceQuarantineAttemptGetLast provides a mock function with given fields: ctx, tenantID, uid
+func (_m *Store) DeviceQuarantineAttemptGetLast(ctx context.Context, tenantID string, uid models.UID) (*models.DeviceQuarantineAttempt, error) {
+	ret := _m.Called(ctx, tenantID, uid)
+
+	var r0 *models.DeviceQuarantineAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) (*models.DeviceQuarantineAttempt, error)); ok {
+		return rf(ctx, tenantID, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) *models.DeviceQuarantineAttempt); ok {
+		r0 = rf(ctx, tenantID, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceQuarantineAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID) error); ok {
+		r1 = rf(ctx, tenantID, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceQuarantineAttemptList provides a mock function with given fields: ctx, tenantID, uid, paginator
+func (_m *Store) DeviceQuarantineAttemptList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceQuarantineAttempt, int, error) {
+	ret := _m.Called(ctx, tenantID, uid, paginator)
+
+	var r0 []models.DeviceQuarantineAttempt
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) ([]models.DeviceQuarantineAttempt, int, error)); ok {
+		return rf(ctx, tenantID, uid, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) []models.DeviceQuarantineAttempt); ok {
+		r0 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceQuarantineAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceQueueList provides a mock function with given fields: ctx, tenant
+func (_m *Store) DeviceQueueList(ctx context.Context, tenant string) ([]models.Device, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Device, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Device); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceRemovedCount provides a mock function with given fields: ctx, tenant
+func (_m *Store) DeviceRemovedCount(ctx context.Context, tenant string) (int64, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceRemovedDelete provides a mock function with given fields: ctx, tenant, uid
+func (_m *Store) DeviceRemovedDelete(ctx context.Context, tenant string, uid models.UID) error {
+	ret := _m.Called(ctx, tenant, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) error); ok {
+		r0 = rf(ctx, tenant, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceRemovedGet provides a mock function with given fields: ctx, tenant, uid
+func (_m *Store) DeviceRemovedGet(ctx context.Context, tenant string, uid models.UID) (*models.DeviceRemoved, error) {
+	ret := _m.Called(ctx, tenant, uid)
+
+	var r0 *models.DeviceRemoved
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) (*models.DeviceRemoved, error)); ok {
+		return rf(ctx, tenant, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) *models.DeviceRemoved); ok {
+		r0 = rf(ctx, tenant, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceRemoved)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID) error); ok {
+		r1 = rf(ctx, tenant, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceRemovedInsert provides a mock function with given fields: ctx, tenant, device
+func (_m *Store) DeviceRemovedInsert(ctx context.Context, tenant string, device *models.Device) error {
+	ret := _m.Called(ctx, tenant, device)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Device) error); ok {
+		r0 = rf(ctx, tenant, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceRemovedList provides a mock function with given fields: ctx, tenant, pagination, filters, sorter
+func (_m *Store) DeviceRemovedList(ctx context.Context, tenant string, pagination query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.DeviceRemoved, int, error) {
+	ret := _m.Called(ctx, tenant, pagination, filters, sorter)
+
+	var r0 []models.DeviceRemoved
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator, query.Filters, query.Sorter) ([]models.DeviceRemoved, int, error)); ok {
+		return rf(ctx, tenant, pagination, filters, sorter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator, query.Filters, query.Sorter) []models.DeviceRemoved); ok {
+		r0 = rf(ctx, tenant, pagination, filters, sorter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceRemoved)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator, query.Filters, query.Sorter) int); ok {
+		r1 = rf(ctx, tenant, pagination, filters, sorter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator, query.Filters, query.Sorter) error); ok {
+		r2 = rf(ctx, tenant, pagination, filters, sorter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceRename provides a mock function with given fields: ctx, uid, hostname
+func (_m *Store) DeviceRename(ctx context.Context, uid models.UID, hostname string) error {
+	ret := _m.Called(ctx, uid, hostname)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, hostname)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSearch provides a mock function with given fields: ctx, tenants, name, status, paginator
+func (_m *Store) DeviceSearch(ctx context.Context, tenants []string, name string, status models.DeviceStatus, paginator query.Paginator) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, tenants, name, status, paginator)
+
+	var r0 []models.Device
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, models.DeviceStatus, query.Paginator) ([]models.Device, int, error)); ok {
+		return rf(ctx, tenants, name, status, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, models.DeviceStatus, query.Paginator) []models.Device); ok {
+		r0 = rf(ctx, tenants, name, status, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string, models.DeviceStatus, query.Paginator) int); ok {
+		r1 = rf(ctx, tenants, name, status, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string, string, models.DeviceStatus, query.Paginator) error); ok {
+		r2 = rf(ctx, tenants, name, status, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceSetCompromised provides a mock function with given fields: ctx, uid, compromised
+func (_m *Store) DeviceSetCompromised(ctx context.Context, uid models.UID, compromised bool) error {
+	ret := _m.Called(ctx, uid, compromised)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, bool) error); ok {
+		r0 = rf(ctx, uid, compromised)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetConfig provides a mock function with given fields: ctx, tenant, uid, values, updatedAt
+func (_m *Store) DeviceSetConfig(ctx context.Context, tenant string, uid models.UID, values map[string]string, updatedAt time.Time) error {
+	ret := _m.Called(ctx, tenant, uid, values, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, map[string]string, time.Time) error); ok {
+		r0 = rf(ctx, tenant, uid, values, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetConnectionNote provides a mock function with given fields: ctx, tenant, uid, note
+func (_m *Store) DeviceSetConnectionNote(ctx context.Context, tenant string, uid models.UID, note string) error {
+	ret := _m.Called(ctx, tenant, uid, note)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) error); ok {
+		r0 = rf(ctx, tenant, uid, note)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetLimitExempt provides a mock function with given fields: ctx, tenant, uid, exempt
+func (_m *Store) DeviceSetLimitExempt(ctx context.Context, tenant string, uid models.UID, exempt bool) error {
+	ret := _m.Called(ctx, tenant, uid, exempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, bool) error); ok {
+		r0 = rf(ctx, tenant, uid, exempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetLoginShell provides a mock function with given fields: ctx, tenant, uid, loginShell
+func (_m *Store) DeviceSetLoginShell(ctx context.Context, tenant string, uid models.UID, loginShell string) error {
+	ret := _m.Called(ctx, tenant, uid, loginShell)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) error); ok {
+		r0 = rf(ctx, tenant, uid, loginShell)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetOffline provides a mock function with given fields: ctx, uid
+func (_m *Store) DeviceSetOffline(ctx context.Context, uid string) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetOfflineAt provides a mock function with given fields: ctx, uid, at
+func (_m *Store) DeviceSetOfflineAt(ctx context.Context, uid string, at time.Time) error {
+	ret := _m.Called(ctx, uid, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, uid, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetOfflineExpired provides a mock function with given fields: ctx, now
+func (_m *Store) DeviceSetOfflineExpired(ctx context.Context, now time.Time) ([]models.ConnectedDevice, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 []models.ConnectedDevice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.ConnectedDevice, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.ConnectedDevice); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ConnectedDevice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceSetPosition provides a mock function with given fields: ctx, uid, position
+func (_m *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error {
+	ret := _m.Called(ctx, uid, position)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, models.DevicePosition) error); ok {
+		r0 = rf(ctx, uid, position)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetQueue provides a mock function with given fields: ctx, tenant, uid, queue
+func (_m *Store) DeviceSetQueue(ctx context.Context, tenant string, uid models.UID, queue *models.DeviceQueue) error {
+	ret := _m.Called(ctx, tenant, uid, queue)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, *models.DeviceQueue) error); ok {
+		r0 = rf(ctx, tenant, uid, queue)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetRemoteAccess provides a mock function with given fields: ctx, tenant, uid, remoteAccess
+func (_m *Store) DeviceSetRemoteAccess(ctx context.Context, tenant string, uid models.UID, remoteAccess bool) error {
+	ret := _m.Called(ctx, tenant, uid, remoteAccess)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, bool) error); ok {
+		r0 = rf(ctx, tenant, uid, remoteAccess)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetTags provides a mock function with given fields: ctx, uid, tags
+func (_m *Store) DeviceSetTags(ctx context.Context, uid models.UID, tags []string) (int64, int64, error) {
+	ret := _m.Called(ctx, uid, tags)
+
+	var r0 int64
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []string) (int64, int64, error)); ok {
+		return rf(ctx, uid, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []string) int64); ok {
+		r0 = rf(ctx, uid, tags)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, []string) int64); ok {
+		r1 = rf(ctx, uid, tags)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.UID, []string) error); ok {
+		r2 = rf(ctx, uid, tags)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceShareCreate provides a mock function with given fields: ctx, share
+func (_m *Store) DeviceShareCreate(ctx context.Context, share *models.DeviceShare) error {
+	ret := _m.Called(ctx, share)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceShare) error); ok {
+		r0 = rf(ctx, share)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceShareDelete provides a mock function with given fields: ctx, tenantID, uid, id
+func (_m *Store) DeviceShareDelete(ctx context.Context, tenantID string, uid string, id string) error {
+	ret := _m.Called(ctx, tenantID, uid, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenantID, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceShareList provides a mock function with given fields: ctx, tenantID, uid
+func (_m *Store) DeviceShareList(ctx context.Context, tenantID string, uid string) ([]models.DeviceShare, error) {
+	ret := _m.Called(ctx, tenantID, uid)
+
+	var r0 []models.DeviceShare
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.UID, error)); ok {
-		return rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.DeviceShare, error)); ok {
+		return rf(ctx, tenantID, uid)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []models.UID); ok {
-		r0 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.DeviceShare); ok {
+		r0 = rf(ctx, tenantID, uid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.UID)
+			r0 = ret.Get(0).([]models.DeviceShare)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -516,25 +1926,25 @@ func (_m *Store) DeviceListByUsage(ctx context.Context, tenantID string) ([]mode
 	return r0, r1
 }
 
-// DeviceLookup provides a mock function with given fields: ctx, namespace, hostname
-func (_m *Store) DeviceLookup(ctx context.Context, namespace string, hostname string) (*models.Device, error) {
-	ret := _m.Called(ctx, namespace, hostname)
+// DeviceShareListByGrantee provides a mock function with given fields: ctx, granteeTenantID, at
+func (_m *Store) DeviceShareListByGrantee(ctx context.Context, granteeTenantID string, at time.Time) ([]models.DeviceShare, error) {
+	ret := _m.Called(ctx, granteeTenantID, at)
 
-	var r0 *models.Device
+	var r0 []models.DeviceShare
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
-		return rf(ctx, namespace, hostname)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]models.DeviceShare, error)); ok {
+		return rf(ctx, granteeTenantID, at)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
-		r0 = rf(ctx, namespace, hostname)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []models.DeviceShare); ok {
+		r0 = rf(ctx, granteeTenantID, at)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).([]models.DeviceShare)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, hostname)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, granteeTenantID, at)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -542,13 +1952,13 @@ func (_m *Store) DeviceLookup(ctx context.Context, namespace string, hostname st
 	return r0, r1
 }
 
-// DevicePullTag provides a mock function with given fields: ctx, uid, tag
-func (_m *Store) DevicePullTag(ctx context.Context, uid models.UID, tag string) error {
-	ret := _m.Called(ctx, uid, tag)
+// DeviceSnapshotCreate provides a mock function with given fields: ctx, snapshot
+func (_m *Store) DeviceSnapshotCreate(ctx context.Context, snapshot *models.DeviceSnapshot) error {
+	ret := _m.Called(ctx, snapshot)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
-		r0 = rf(ctx, uid, tag)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceSnapshot) error); ok {
+		r0 = rf(ctx, snapshot)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -556,13 +1966,39 @@ func (_m *Store) DevicePullTag(ctx context.Context, uid models.UID, tag string)
 	return r0
 }
 
-// DevicePushTag provides a mock function with given fields: ctx, uid, tag
-func (_m *Store) DevicePushTag(ctx context.Context, uid models.UID, tag string) error {
-	ret := _m.Called(ctx, uid, tag)
+// DeviceSnapshotGet provides a mock function with given fields: ctx, tenantID, uid, at
+func (_m *Store) DeviceSnapshotGet(ctx context.Context, tenantID string, uid models.UID, at time.Time) (*models.DeviceSnapshot, error) {
+	ret := _m.Called(ctx, tenantID, uid, at)
+
+	var r0 *models.DeviceSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, time.Time) (*models.DeviceSnapshot, error)); ok {
+		return rf(ctx, tenantID, uid, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, time.Time) *models.DeviceSnapshot); ok {
+		r0 = rf(ctx, tenantID, uid, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, uid, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceUpdate provides a mock function with given fields: ctx, tenant, uid, name, publicURL
+func (_m *Store) DeviceUpdate(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
+	ret := _m.Called(ctx, tenant, uid, name, publicURL)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
-		r0 = rf(ctx, uid, tag)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, *string, *bool) error); ok {
+		r0 = rf(ctx, tenant, uid, name, publicURL)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -570,23 +2006,81 @@ func (_m *Store) DevicePushTag(ctx context.Context, uid models.UID, tag string)
 	return r0
 }
 
-// DeviceRemovedCount provides a mock function with given fields: ctx, tenant
-func (_m *Store) DeviceRemovedCount(ctx context.Context, tenant string) (int64, error) {
-	ret := _m.Called(ctx, tenant)
+// DeviceUpdateLastSeen provides a mock function with given fields: ctx, uid, ts
+func (_m *Store) DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts time.Time) error {
+	ret := _m.Called(ctx, uid, ts)
 
-	var r0 int64
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time) error); ok {
+		r0 = rf(ctx, uid, ts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceUpdateOnline provides a mock function with given fields: ctx, uid, online
+func (_m *Store) DeviceUpdateOnline(ctx context.Context, uid models.UID, online bool) error {
+	ret := _m.Called(ctx, uid, online)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, bool) error); ok {
+		r0 = rf(ctx, uid, online)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceUpdateStatus provides a mock function with given fields: ctx, uid, status
+func (_m *Store) DeviceUpdateStatus(ctx context.Context, uid models.UID, status models.DeviceStatus) error {
+	ret := _m.Called(ctx, uid, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, models.DeviceStatus) error); ok {
+		r0 = rf(ctx, uid, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTokenCreate provides a mock function with given fields: ctx, token
+func (_m *Store) EnrollTokenCreate(ctx context.Context, token *models.EnrollToken) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EnrollToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTokenGetByDigest provides a mock function with given fields: ctx, digest
+func (_m *Store) EnrollTokenGetByDigest(ctx context.Context, digest string) (*models.EnrollToken, error) {
+	ret := _m.Called(ctx, digest)
+
+	var r0 *models.EnrollToken
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
-		return rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.EnrollToken, error)); ok {
+		return rf(ctx, digest)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
-		r0 = rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.EnrollToken); ok {
+		r0 = rf(ctx, digest)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EnrollToken)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, tenant)
+		r1 = rf(ctx, digest)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -594,13 +2088,46 @@ func (_m *Store) DeviceRemovedCount(ctx context.Context, tenant string) (int64,
 	return r0, r1
 }
 
-// DeviceRemovedDelete provides a mock function with given fields: ctx, tenant, uid
-func (_m *Store) DeviceRemovedDelete(ctx context.Context, tenant string, uid models.UID) error {
-	ret := _m.Called(ctx, tenant, uid)
+// EnrollTokenList provides a mock function with given fields: ctx, tenantID, paginator
+func (_m *Store) EnrollTokenList(ctx context.Context, tenantID string, paginator query.Paginator) ([]models.EnrollToken, int, error) {
+	ret := _m.Called(ctx, tenantID, paginator)
+
+	var r0 []models.EnrollToken
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.EnrollToken, int, error)); ok {
+		return rf(ctx, tenantID, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.EnrollToken); ok {
+		r0 = rf(ctx, tenantID, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.EnrollToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EnrollTokenRevoke provides a mock function with given fields: ctx, tenantID, id, at
+func (_m *Store) EnrollTokenRevoke(ctx context.Context, tenantID string, id string, at time.Time) error {
+	ret := _m.Called(ctx, tenantID, id, at)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) error); ok {
-		r0 = rf(ctx, tenant, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, tenantID, id, at)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -608,25 +2135,39 @@ func (_m *Store) DeviceRemovedDelete(ctx context.Context, tenant string, uid mod
 	return r0
 }
 
-// DeviceRemovedGet provides a mock function with given fields: ctx, tenant, uid
-func (_m *Store) DeviceRemovedGet(ctx context.Context, tenant string, uid models.UID) (*models.DeviceRemoved, error) {
-	ret := _m.Called(ctx, tenant, uid)
+// EnrollTokenUse provides a mock function with given fields: ctx, id, at
+func (_m *Store) EnrollTokenUse(ctx context.Context, id string, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
 
-	var r0 *models.DeviceRemoved
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetStats provides a mock function with given fields: ctx
+func (_m *Store) GetStats(ctx context.Context) (*models.Stats, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *models.Stats
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) (*models.DeviceRemoved, error)); ok {
-		return rf(ctx, tenant, uid)
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.Stats, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) *models.DeviceRemoved); ok {
-		r0 = rf(ctx, tenant, uid)
+	if rf, ok := ret.Get(0).(func(context.Context) *models.Stats); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.DeviceRemoved)
+			r0 = ret.Get(0).(*models.Stats)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID) error); ok {
-		r1 = rf(ctx, tenant, uid)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -634,13 +2175,13 @@ func (_m *Store) DeviceRemovedGet(ctx context.Context, tenant string, uid models
 	return r0, r1
 }
 
-// DeviceRemovedInsert provides a mock function with given fields: ctx, tenant, device
-func (_m *Store) DeviceRemovedInsert(ctx context.Context, tenant string, device *models.Device) error {
-	ret := _m.Called(ctx, tenant, device)
+// GroupAddDevice provides a mock function with given fields: ctx, tenantID, id, uid
+func (_m *Store) GroupAddDevice(ctx context.Context, tenantID string, id string, uid models.UID) error {
+	ret := _m.Called(ctx, tenantID, id, uid)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Device) error); ok {
-		r0 = rf(ctx, tenant, device)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.UID) error); ok {
+		r0 = rf(ctx, tenantID, id, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -648,60 +2189,93 @@ func (_m *Store) DeviceRemovedInsert(ctx context.Context, tenant string, device
 	return r0
 }
 
-// DeviceRemovedList provides a mock function with given fields: ctx, tenant, pagination, filters, sorter
-func (_m *Store) DeviceRemovedList(ctx context.Context, tenant string, pagination query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.DeviceRemoved, int, error) {
-	ret := _m.Called(ctx, tenant, pagination, filters, sorter)
+// GroupCreate provides a mock function with given fields: ctx, group
+func (_m *Store) GroupCreate(ctx context.Context, group *models.Group) error {
+	ret := _m.Called(ctx, group)
 
-	var r0 []models.DeviceRemoved
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator, query.Filters, query.Sorter) ([]models.DeviceRemoved, int, error)); ok {
-		return rf(ctx, tenant, pagination, filters, sorter)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Group) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator, query.Filters, query.Sorter) []models.DeviceRemoved); ok {
-		r0 = rf(ctx, tenant, pagination, filters, sorter)
+
+	return r0
+}
+
+// GroupDelete provides a mock function with given fields: ctx, tenantID, id
+func (_m *Store) GroupDelete(ctx context.Context, tenantID string, id string) error {
+	ret := _m.Called(ctx, tenantID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenantID, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.DeviceRemoved)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator, query.Filters, query.Sorter) int); ok {
-		r1 = rf(ctx, tenant, pagination, filters, sorter)
+	return r0
+}
+
+// GroupGet provides a mock function with given fields: ctx, tenantID, id
+func (_m *Store) GroupGet(ctx context.Context, tenantID string, id string) (*models.Group, error) {
+	ret := _m.Called(ctx, tenantID, id)
+
+	var r0 *models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Group, error)); ok {
+		return rf(ctx, tenantID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Group); ok {
+		r0 = rf(ctx, tenantID, id)
 	} else {
-		r1 = ret.Get(1).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Group)
+		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator, query.Filters, query.Sorter) error); ok {
-		r2 = rf(ctx, tenant, pagination, filters, sorter)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, id)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// DeviceRename provides a mock function with given fields: ctx, uid, hostname
-func (_m *Store) DeviceRename(ctx context.Context, uid models.UID, hostname string) error {
-	ret := _m.Called(ctx, uid, hostname)
+// GroupList provides a mock function with given fields: ctx, tenantID
+func (_m *Store) GroupList(ctx context.Context, tenantID string) ([]models.Group, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 []models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Group, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Group); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Group)
+		}
+	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
-		r0 = rf(ctx, uid, hostname)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// DeviceSetOffline provides a mock function with given fields: ctx, uid
-func (_m *Store) DeviceSetOffline(ctx context.Context, uid string) error {
-	ret := _m.Called(ctx, uid)
+// GroupRemoveDevice provides a mock function with given fields: ctx, tenantID, id, uid
+func (_m *Store) GroupRemoveDevice(ctx context.Context, tenantID string, id string, uid models.UID) error {
+	ret := _m.Called(ctx, tenantID, id, uid)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.UID) error); ok {
+		r0 = rf(ctx, tenantID, id, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -709,13 +2283,13 @@ func (_m *Store) DeviceSetOffline(ctx context.Context, uid string) error {
 	return r0
 }
 
-// DeviceSetOnline provides a mock function with given fields: ctx, connectedDevices
-func (_m *Store) DeviceSetOnline(ctx context.Context, connectedDevices []models.ConnectedDevice) error {
-	ret := _m.Called(ctx, connectedDevices)
+// GroupUpdate provides a mock function with given fields: ctx, group
+func (_m *Store) GroupUpdate(ctx context.Context, group *models.Group) error {
+	ret := _m.Called(ctx, group)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []models.ConnectedDevice) error); ok {
-		r0 = rf(ctx, connectedDevices)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Group) error); ok {
+		r0 = rf(ctx, group)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -723,13 +2297,13 @@ func (_m *Store) DeviceSetOnline(ctx context.Context, connectedDevices []models.
 	return r0
 }
 
-// DeviceSetPosition provides a mock function with given fields: ctx, uid, position
-func (_m *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error {
-	ret := _m.Called(ctx, uid, position)
+// JobCreate provides a mock function with given fields: ctx, job
+func (_m *Store) JobCreate(ctx context.Context, job *models.Job) error {
+	ret := _m.Called(ctx, job)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, models.DevicePosition) error); ok {
-		r0 = rf(ctx, uid, position)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job) error); ok {
+		r0 = rf(ctx, job)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -737,72 +2311,65 @@ func (_m *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position
 	return r0
 }
 
-// DeviceSetTags provides a mock function with given fields: ctx, uid, tags
-func (_m *Store) DeviceSetTags(ctx context.Context, uid models.UID, tags []string) (int64, int64, error) {
-	ret := _m.Called(ctx, uid, tags)
+// JobGet provides a mock function with given fields: ctx, id
+func (_m *Store) JobGet(ctx context.Context, id string) (*models.Job, error) {
+	ret := _m.Called(ctx, id)
 
-	var r0 int64
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []string) (int64, int64, error)); ok {
-		return rf(ctx, uid, tags)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []string) int64); ok {
-		r0 = rf(ctx, uid, tags)
-	} else {
-		r0 = ret.Get(0).(int64)
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Job, error)); ok {
+		return rf(ctx, id)
 	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, models.UID, []string) int64); ok {
-		r1 = rf(ctx, uid, tags)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Job); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r1 = ret.Get(1).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, models.UID, []string) error); ok {
-		r2 = rf(ctx, uid, tags)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// DeviceUpdate provides a mock function with given fields: ctx, tenant, uid, name, publicURL
-func (_m *Store) DeviceUpdate(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
-	ret := _m.Called(ctx, tenant, uid, name, publicURL)
+// JobGetByIdempotencyKey provides a mock function with given fields: ctx, tenantID, key
+func (_m *Store) JobGetByIdempotencyKey(ctx context.Context, tenantID string, key string) (*models.Job, error) {
+	ret := _m.Called(ctx, tenantID, key)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, *string, *bool) error); ok {
-		r0 = rf(ctx, tenant, uid, name, publicURL)
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Job, error)); ok {
+		return rf(ctx, tenantID, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Job); ok {
+		r0 = rf(ctx, tenantID, key)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
 	}
 
-	return r0
-}
-
-// DeviceUpdateLastSeen provides a mock function with given fields: ctx, uid, ts
-func (_m *Store) DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts time.Time) error {
-	ret := _m.Called(ctx, uid, ts)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time) error); ok {
-		r0 = rf(ctx, uid, ts)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, key)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// DeviceUpdateOnline provides a mock function with given fields: ctx, uid, online
-func (_m *Store) DeviceUpdateOnline(ctx context.Context, uid models.UID, online bool) error {
-	ret := _m.Called(ctx, uid, online)
+// JobSetFinished provides a mock function with given fields: ctx, id, status, reason, at
+func (_m *Store) JobSetFinished(ctx context.Context, id string, status models.JobStatus, reason string, at time.Time) error {
+	ret := _m.Called(ctx, id, status, reason, at)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, bool) error); ok {
-		r0 = rf(ctx, uid, online)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.JobStatus, string, time.Time) error); ok {
+		r0 = rf(ctx, id, status, reason, at)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -810,13 +2377,13 @@ func (_m *Store) DeviceUpdateOnline(ctx context.Context, uid models.UID, online
 	return r0
 }
 
-// DeviceUpdateStatus provides a mock function with given fields: ctx, uid, status
-func (_m *Store) DeviceUpdateStatus(ctx context.Context, uid models.UID, status models.DeviceStatus) error {
-	ret := _m.Called(ctx, uid, status)
+// JobSetItem provides a mock function with given fields: ctx, id, index, item
+func (_m *Store) JobSetItem(ctx context.Context, id string, index int, item *models.JobItem) error {
+	ret := _m.Called(ctx, id, index, item)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, models.DeviceStatus) error); ok {
-		r0 = rf(ctx, uid, status)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *models.JobItem) error); ok {
+		r0 = rf(ctx, id, index, item)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -824,30 +2391,18 @@ func (_m *Store) DeviceUpdateStatus(ctx context.Context, uid models.UID, status
 	return r0
 }
 
-// GetStats provides a mock function with given fields: ctx
-func (_m *Store) GetStats(ctx context.Context) (*models.Stats, error) {
-	ret := _m.Called(ctx)
-
-	var r0 *models.Stats
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*models.Stats, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) *models.Stats); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Stats)
-		}
-	}
+// JobSetRunning provides a mock function with given fields: ctx, id, at
+func (_m *Store) JobSetRunning(ctx context.Context, id string, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // NamespaceAddMember provides a mock function with given fields: ctx, tenantID, member
@@ -1446,6 +3001,79 @@ func (_m *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenant
 	return r0, r1
 }
 
+// PublicURLLogCreate provides a mock function with given fields: ctx, log
+func (_m *Store) PublicURLLogCreate(ctx context.Context, log *models.PublicURLLog) error {
+	ret := _m.Called(ctx, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicURLLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublicURLLogList provides a mock function with given fields: ctx, tenantID, uid, paginator
+func (_m *Store) PublicURLLogList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.PublicURLLog, int, error) {
+	ret := _m.Called(ctx, tenantID, uid, paginator)
+
+	var r0 []models.PublicURLLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) ([]models.PublicURLLog, int, error)); ok {
+		return rf(ctx, tenantID, uid, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) []models.PublicURLLog); ok {
+		r0 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicURLLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PublicURLLogStats provides a mock function with given fields: ctx, tenantID, uid
+func (_m *Store) PublicURLLogStats(ctx context.Context, tenantID string, uid models.UID) (*models.PublicURLStats, error) {
+	ret := _m.Called(ctx, tenantID, uid)
+
+	var r0 *models.PublicURLStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) (*models.PublicURLStats, error)); ok {
+		return rf(ctx, tenantID, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) *models.PublicURLStats); ok {
+		r0 = rf(ctx, tenantID, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicURLStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID) error); ok {
+		r1 = rf(ctx, tenantID, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SessionActiveCreate provides a mock function with given fields: ctx, uid, session
 func (_m *Store) SessionActiveCreate(ctx context.Context, uid models.UID, session *models.Session) error {
 	ret := _m.Called(ctx, uid, session)
@@ -1490,23 +3118,143 @@ func (_m *Store) SessionCreate(ctx context.Context, session models.Session) (*mo
 func (_m *Store) SessionDeleteActives(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionEvent provides a mock function with given fields: ctx, uid, event
+func (_m *Store) SessionEvent(ctx context.Context, uid models.UID, event *models.SessionEvent) error {
+	ret := _m.Called(ctx, uid, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.SessionEvent) error); ok {
+		r0 = rf(ctx, uid, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionGet provides a mock function with given fields: ctx, uid
+func (_m *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Session, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Session); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionList provides a mock function with given fields: ctx, paginator
+func (_m *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, paginator)
+
+	var r0 []models.Session
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.Session, int, error)); ok {
+		return rf(ctx, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.Session); ok {
+		r0 = rf(ctx, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
+		r1 = rf(ctx, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
+		r2 = rf(ctx, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SessionRecordingAccessCreate provides a mock function with given fields: ctx, access
+func (_m *Store) SessionRecordingAccessCreate(ctx context.Context, access *models.SessionRecordingAccess) error {
+	ret := _m.Called(ctx, access)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SessionRecordingAccess) error); ok {
+		r0 = rf(ctx, access)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRecordingAccessList provides a mock function with given fields: ctx, tenantID, uid, paginator
+func (_m *Store) SessionRecordingAccessList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.SessionRecordingAccess, int, error) {
+	ret := _m.Called(ctx, tenantID, uid, paginator)
+
+	var r0 []models.SessionRecordingAccess
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) ([]models.SessionRecordingAccess, int, error)); ok {
+		return rf(ctx, tenantID, uid, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) []models.SessionRecordingAccess); ok {
+		r0 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionRecordingAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, uid, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, uid, paginator)
 	} else {
-		r0 = ret.Error(0)
+		r2 = ret.Error(2)
 	}
 
-	return r0
+	return r0, r1, r2
 }
 
-// SessionEvent provides a mock function with given fields: ctx, uid, event
-func (_m *Store) SessionEvent(ctx context.Context, uid models.UID, event *models.SessionEvent) error {
-	ret := _m.Called(ctx, uid, event)
+// SessionScheduleOverrideCreate provides a mock function with given fields: ctx, override
+func (_m *Store) SessionScheduleOverrideCreate(ctx context.Context, override *models.SessionScheduleOverride) error {
+	ret := _m.Called(ctx, override)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.SessionEvent) error); ok {
-		r0 = rf(ctx, uid, event)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SessionScheduleOverride) error); ok {
+		r0 = rf(ctx, override)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1514,25 +3262,25 @@ func (_m *Store) SessionEvent(ctx context.Context, uid models.UID, event *models
 	return r0
 }
 
-// SessionGet provides a mock function with given fields: ctx, uid
-func (_m *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session, error) {
-	ret := _m.Called(ctx, uid)
+// SessionScheduleOverrideGetActive provides a mock function with given fields: ctx, tenantID, uid, at
+func (_m *Store) SessionScheduleOverrideGetActive(ctx context.Context, tenantID string, uid models.UID, at time.Time) (*models.SessionScheduleOverride, error) {
+	ret := _m.Called(ctx, tenantID, uid, at)
 
-	var r0 *models.Session
+	var r0 *models.SessionScheduleOverride
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Session, error)); ok {
-		return rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, time.Time) (*models.SessionScheduleOverride, error)); ok {
+		return rf(ctx, tenantID, uid, at)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Session); ok {
-		r0 = rf(ctx, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, time.Time) *models.SessionScheduleOverride); ok {
+		r0 = rf(ctx, tenantID, uid, at)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Session)
+			r0 = ret.Get(0).(*models.SessionScheduleOverride)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
-		r1 = rf(ctx, uid)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, uid, at)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1540,32 +3288,32 @@ func (_m *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Sessio
 	return r0, r1
 }
 
-// SessionList provides a mock function with given fields: ctx, paginator
-func (_m *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, paginator)
+// SessionScheduleOverrideList provides a mock function with given fields: ctx, tenantID, uid, paginator
+func (_m *Store) SessionScheduleOverrideList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.SessionScheduleOverride, int, error) {
+	ret := _m.Called(ctx, tenantID, uid, paginator)
 
-	var r0 []models.Session
+	var r0 []models.SessionScheduleOverride
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.Session, int, error)); ok {
-		return rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) ([]models.SessionScheduleOverride, int, error)); ok {
+		return rf(ctx, tenantID, uid, paginator)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.Session); ok {
-		r0 = rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, query.Paginator) []models.SessionScheduleOverride); ok {
+		r0 = rf(ctx, tenantID, uid, paginator)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Session)
+			r0 = ret.Get(0).([]models.SessionScheduleOverride)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
-		r1 = rf(ctx, paginator)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, query.Paginator) int); ok {
+		r1 = rf(ctx, tenantID, uid, paginator)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
-		r2 = rf(ctx, paginator)
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, query.Paginator) error); ok {
+		r2 = rf(ctx, tenantID, uid, paginator)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1573,6 +3321,32 @@ func (_m *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]
 	return r0, r1, r2
 }
 
+// SessionScheduleOverrideListByUser provides a mock function with given fields: ctx, tenantID, userID, since
+func (_m *Store) SessionScheduleOverrideListByUser(ctx context.Context, tenantID string, userID string, since time.Time) ([]models.SessionScheduleOverride, error) {
+	ret := _m.Called(ctx, tenantID, userID, since)
+
+	var r0 []models.SessionScheduleOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) ([]models.SessionScheduleOverride, error)); ok {
+		return rf(ctx, tenantID, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) []models.SessionScheduleOverride); ok {
+		r0 = rf(ctx, tenantID, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionScheduleOverride)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SessionSetLastSeen provides a mock function with given fields: ctx, uid
 func (_m *Store) SessionSetLastSeen(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -1669,6 +3443,100 @@ func (_m *Store) SystemSet(ctx context.Context, key string, value interface{}) e
 	return r0
 }
 
+// TagRuleCreate provides a mock function with given fields: ctx, rule
+func (_m *Store) TagRuleCreate(ctx context.Context, rule *models.TagRule) error {
+	ret := _m.Called(ctx, rule)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TagRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TagRuleDelete provides a mock function with given fields: ctx, tenantID, id
+func (_m *Store) TagRuleDelete(ctx context.Context, tenantID string, id string) error {
+	ret := _m.Called(ctx, tenantID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenantID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TagRuleGet provides a mock function with given fields: ctx, tenantID, id
+func (_m *Store) TagRuleGet(ctx context.Context, tenantID string, id string) (*models.TagRule, error) {
+	ret := _m.Called(ctx, tenantID, id)
+
+	var r0 *models.TagRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.TagRule, error)); ok {
+		return rf(ctx, tenantID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.TagRule); ok {
+		r0 = rf(ctx, tenantID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TagRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRuleList provides a mock function with given fields: ctx, tenantID
+func (_m *Store) TagRuleList(ctx context.Context, tenantID string) ([]models.TagRule, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 []models.TagRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.TagRule, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.TagRule); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TagRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagRuleUpdate provides a mock function with given fields: ctx, rule
+func (_m *Store) TagRuleUpdate(ctx context.Context, rule *models.TagRule) error {
+	ret := _m.Called(ctx, rule)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TagRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // TagsDelete provides a mock function with given fields: ctx, tenant, tag
 func (_m *Store) TagsDelete(ctx context.Context, tenant string, tag string) (int64, error) {
 	ret := _m.Called(ctx, tenant, tag)
@@ -1750,6 +3618,34 @@ func (_m *Store) TagsRename(ctx context.Context, tenant string, oldTag string, n
 	return r0, r1
 }
 
+// UserAliasDelete provides a mock function with given fields: ctx, userID, name
+func (_m *Store) UserAliasDelete(ctx context.Context, userID string, name string) error {
+	ret := _m.Called(ctx, userID, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserAliasSave provides a mock function with given fields: ctx, userID, alias
+func (_m *Store) UserAliasSave(ctx context.Context, userID string, alias *models.UserAlias) error {
+	ret := _m.Called(ctx, userID, alias)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.UserAlias) error); ok {
+		r0 = rf(ctx, userID, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserConflicts provides a mock function with given fields: ctx, target
 func (_m *Store) UserConflicts(ctx context.Context, target *models.UserConflicts) ([]string, bool, error) {
 	ret := _m.Called(ctx, target)
@@ -1989,6 +3885,128 @@ func (_m *Store) UserList(ctx context.Context, paginator query.Paginator, filter
 	return r0, r1, r2
 }
 
+// UserSessionDelete provides a mock function with given fields: ctx, userID, id
+func (_m *Store) UserSessionDelete(ctx context.Context, userID string, id string) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionDeleteAll provides a mock function with given fields: ctx, userID
+func (_m *Store) UserSessionDeleteAll(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionGet provides a mock function with given fields: ctx, id
+func (_m *Store) UserSessionGet(ctx context.Context, id string) (*models.UserSession, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UserSession, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserSession); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserSessionList provides a mock function with given fields: ctx, userID
+func (_m *Store) UserSessionList(ctx context.Context, userID string) ([]models.UserSession, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []models.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.UserSession, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.UserSession); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserSessionRotate provides a mock function with given fields: ctx, session, refreshToken
+func (_m *Store) UserSessionRotate(ctx context.Context, session *models.UserSession, refreshToken string) error {
+	ret := _m.Called(ctx, session, refreshToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserSession, string) error); ok {
+		r0 = rf(ctx, session, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionSave provides a mock function with given fields: ctx, session
+func (_m *Store) UserSessionSave(ctx context.Context, session *models.UserSession) error {
+	ret := _m.Called(ctx, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserSession) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionTouch provides a mock function with given fields: ctx, id, lastSeenAt
+func (_m *Store) UserSessionTouch(ctx context.Context, id string, lastSeenAt time.Time) error {
+	ret := _m.Called(ctx, id, lastSeenAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, lastSeenAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserUpdate provides a mock function with given fields: ctx, id, changes
 func (_m *Store) UserUpdate(ctx context.Context, id string, changes *models.UserChanges) error {
 	ret := _m.Called(ctx, id, changes)
@@ -2003,6 +4021,60 @@ func (_m *Store) UserUpdate(ctx context.Context, id string, changes *models.User
 	return r0
 }
 
+// UserVerificationDelete provides a mock function with given fields: ctx, userID
+func (_m *Store) UserVerificationDelete(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserVerificationGetByDigest provides a mock function with given fields: ctx, digest, at
+func (_m *Store) UserVerificationGetByDigest(ctx context.Context, digest string, at time.Time) (*models.UserVerification, error) {
+	ret := _m.Called(ctx, digest, at)
+
+	var r0 *models.UserVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*models.UserVerification, error)); ok {
+		return rf(ctx, digest, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *models.UserVerification); ok {
+		r0 = rf(ctx, digest, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, digest, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserVerificationSave provides a mock function with given fields: ctx, verification
+func (_m *Store) UserVerificationSave(ctx context.Context, verification *models.UserVerification) error {
+	ret := _m.Called(ctx, verification)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserVerification) error); ok {
+		r0 = rf(ctx, verification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WithTransaction provides a mock function with given fields: ctx, cb
 func (_m *Store) WithTransaction(ctx context.Context, cb store.TransactionCb) error {
 	ret := _m.Called(ctx, cb)
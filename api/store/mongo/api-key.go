@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
@@ -9,6 +10,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (s *Store) APIKeyCreate(ctx context.Context, apiKey *models.APIKey) (string, error) {
@@ -121,6 +123,25 @@ func (s *Store) APIKeyList(ctx context.Context, tenantID string, paginator query
 	return apiKeys, count, nil
 }
 
+func (s *Store) APIKeyListByCreator(ctx context.Context, tenantID, userID string, since time.Time) ([]models.APIKey, error) {
+	cursor, err := s.db.Collection("api_keys").Find(
+		ctx,
+		bson.M{"tenant_id": tenantID, "created_by": userID, "created_at": bson.M{"$gte": since}},
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	apiKeys := make([]models.APIKey, 0)
+	if err := cursor.All(ctx, &apiKeys); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return apiKeys, nil
+}
+
 func (s *Store) APIKeyUpdate(ctx context.Context, tenantID, name string, changes *models.APIKeyChanges) error {
 	changes.UpdatedAt = clock.Now()
 
@@ -378,6 +378,57 @@ func TestAPIKeyList(t *testing.T) {
 	}
 }
 
+func TestAPIKeyListByCreator(t *testing.T) {
+	type Expected struct {
+		names []string
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		userID      string
+		since       time.Time
+		expected    Expected
+	}{
+		{
+			description: "succeeds when the user created no api keys",
+			userID:      "nonexistent",
+			since:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:    Expected{names: []string{}, err: nil},
+		},
+		{
+			description: "succeeds listing the api keys created by the user since the date",
+			userID:      "507f1f77bcf86cd799439011",
+			since:       time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			expected:    Expected{names: []string{"prod"}, err: nil},
+		},
+		{
+			description: "succeeds listing the api keys created by the user, most recent first",
+			userID:      "507f1f77bcf86cd799439011",
+			since:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:    Expected{names: []string{"prod", "dev"}, err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, srv.Apply(fixtureAPIKeys))
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			apiKeys, err := s.APIKeyListByCreator(ctx, "00000000-0000-4000-0000-000000000000", tc.userID, tc.since)
+
+			names := []string{}
+			for _, apiKey := range apiKeys {
+				names = append(names, apiKey.Name)
+			}
+
+			require.Equal(t, tc.expected, Expected{names, err})
+		})
+	}
+}
+
 func TestAPIKeyUpdate(t *testing.T) {
 	type Expected struct {
 		name string
@@ -0,0 +1,83 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) AuditEntryCreate(ctx context.Context, entry *models.AuditEntry) error {
+	if _, err := s.db.Collection("audit_entries").InsertOne(ctx, entry); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) AuditEntryList(ctx context.Context, tenantID string, filter models.AuditEntryFilter, paginator query.Paginator) ([]models.AuditEntry, int, error) {
+	match := bson.M{"tenant_id": tenantID}
+
+	if filter.Action != "" {
+		match["action"] = filter.Action
+	}
+
+	if filter.ActorID != "" {
+		match["actor.id"] = filter.ActorID
+	}
+
+	if filter.TargetType != "" {
+		match["target.type"] = filter.TargetType
+	}
+
+	if filter.TargetID != "" {
+		match["target.id"] = filter.TargetID
+	}
+
+	if filter.From != nil || filter.To != nil {
+		createdAt := bson.M{}
+		if filter.From != nil {
+			createdAt["$gte"] = *filter.From
+		}
+
+		if filter.To != nil {
+			createdAt["$lte"] = *filter.To
+		}
+
+		match["created_at"] = createdAt
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("audit_entries"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.AuditEntry{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("audit_entries").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := make([]models.AuditEntry, 0)
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return entries, count, nil
+}
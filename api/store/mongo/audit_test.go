@@ -0,0 +1,70 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEntry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	tenantID := "00000000-0000-4000-0000-000000000000"
+
+	accepted := models.AuditEntry{
+		ID:        "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+		TenantID:  tenantID,
+		Actor:     models.AuditActor{Type: models.AuditActorUser, ID: "507f1f77bcf86cd799439011", Username: "john"},
+		Action:    models.AuditActionDeviceStatusUpdate,
+		Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"},
+		Details:   map[string]string{"status": "accepted"},
+		SourceIP:  "192.0.2.1",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	created := models.AuditEntry{
+		ID:        "6f1b2c3d-0c1b-4d7e-8f3a-000000000002",
+		TenantID:  tenantID,
+		Actor:     models.AuditActor{Type: models.AuditActorAPIKey, ID: "d9ef3b4ad3ac1eb7d4b4c2e3ba3ccfa4d1cb2ebf46e6c1b8b4f6e1d1cd4b2a11"},
+		Action:    models.AuditActionPublicKeyCreate,
+		Target:    models.AuditTarget{Type: models.AuditTargetPublicKey, ID: "fingerprint"},
+		SourceIP:  "192.0.2.2",
+		CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+
+	other := created
+	other.ID = "6f1b2c3d-0c1b-4d7e-8f3a-000000000003"
+	other.TenantID = "00000000-0000-4000-0000-000000000001"
+
+	require.NoError(t, s.AuditEntryCreate(ctx, &accepted))
+	require.NoError(t, s.AuditEntryCreate(ctx, &created))
+	require.NoError(t, s.AuditEntryCreate(ctx, &other))
+
+	entries, count, err := s.AuditEntryList(ctx, tenantID, models.AuditEntryFilter{}, query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []models.AuditEntry{created, accepted}, entries)
+
+	entries, count, err = s.AuditEntryList(ctx, tenantID, models.AuditEntryFilter{TargetType: models.AuditTargetDevice, ActorID: "507f1f77bcf86cd799439011"}, query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []models.AuditEntry{accepted}, entries)
+
+	from := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	entries, count, err = s.AuditEntryList(ctx, tenantID, models.AuditEntryFilter{From: &from}, query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []models.AuditEntry{created}, entries)
+
+	entries, count, err = s.AuditEntryList(ctx, tenantID, models.AuditEntryFilter{Action: models.AuditActionDeviceRemove}, query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, []models.AuditEntry{}, entries)
+}
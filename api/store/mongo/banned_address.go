@@ -0,0 +1,46 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) BannedAddressList(ctx context.Context) ([]models.BannedAddress, error) {
+	cursor, err := s.db.Collection("banned_addresses").Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	bans := make([]models.BannedAddress, 0)
+	if err := cursor.All(ctx, &bans); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return bans, nil
+}
+
+func (s *Store) BannedAddressSave(ctx context.Context, ban *models.BannedAddress) error {
+	if _, err := s.db.Collection("banned_addresses").ReplaceOne(ctx, bson.M{"_id": ban.Address}, ban, options.Replace().SetUpsert(true)); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) BannedAddressDelete(ctx context.Context, address string) error {
+	r, err := s.db.Collection("banned_addresses").DeleteOne(ctx, bson.M{"_id": address})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBannedAddress(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	expiresAt := time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	bans, err := s.BannedAddressList(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []models.BannedAddress{}, bans)
+
+	require.NoError(t, s.BannedAddressSave(ctx, &models.BannedAddress{
+		Address:   "198.51.100.7/32",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		ExpiresAt: &expiresAt,
+	}))
+	require.NoError(t, s.BannedAddressSave(ctx, &models.BannedAddress{
+		Address:   "192.0.2.0/24",
+		Reason:    "scanner",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}))
+
+	// NOTICE: banning an address already banned replaces its ban, turning the temporary one into a permanent one.
+	require.NoError(t, s.BannedAddressSave(ctx, &models.BannedAddress{
+		Address:   "198.51.100.7/32",
+		Reason:    "brute force",
+		CreatedAt: time.Date(2023, 1, 1, 12, 30, 0, 0, time.UTC),
+	}))
+
+	bans, err = s.BannedAddressList(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []models.BannedAddress{
+		{
+			Address:   "192.0.2.0/24",
+			Reason:    "scanner",
+			CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Address:   "198.51.100.7/32",
+			Reason:    "brute force",
+			CreatedAt: time.Date(2023, 1, 1, 12, 30, 0, 0, time.UTC),
+		},
+	}, bans)
+
+	require.NoError(t, s.BannedAddressDelete(ctx, "192.0.2.0/24"))
+	assert.ErrorIs(t, s.BannedAddressDelete(ctx, "192.0.2.0/24"), store.ErrNoDocuments)
+
+	bans, err = s.BannedAddressList(ctx)
+	require.NoError(t, err)
+	assert.Len(t, bans, 1)
+}
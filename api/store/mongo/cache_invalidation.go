@@ -0,0 +1,164 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mongooptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrChangeStreamsUnsupported is returned when the Mongo deployment doesn't support change streams, which require a
+// replica set or a sharded cluster.
+var ErrChangeStreamsUnsupported = errors.New("the Mongo deployment doesn't support change streams")
+
+// CacheInvalidatorRetryInterval is how long the [CacheInvalidator] waits before watching the changes again after its
+// change stream failed.
+const CacheInvalidatorRetryInterval = 5 * time.Second
+
+// Codes of the Mongo errors handled by the [CacheInvalidator].
+const (
+	// errCodeChangeStreamsUnsupported is returned when the change streams are opened on a standalone server.
+	errCodeChangeStreamsUnsupported = 40573
+	// errCodeChangeStreamHistoryLost is returned when the change stream is resumed after its token left the oplog.
+	errCodeChangeStreamHistoryLost = 286
+)
+
+// CacheInvalidator watches the changes on the devices and namespaces collections, through Mongo change streams,
+// deleting their cached entries as soon as they change, whoever changed them, like another API replica or the CLI.
+// Without it, the entries cached before a change made outside the store's methods are served until they expire.
+type CacheInvalidator struct {
+	db    *mongo.Database
+	cache cache.Cache
+}
+
+// NewCacheInvalidator creates a [CacheInvalidator] that deletes the entries of cache changed on db.
+func NewCacheInvalidator(db *mongo.Database, cache cache.Cache) *CacheInvalidator {
+	return &CacheInvalidator{db: db, cache: cache}
+}
+
+// cacheInvalidationChange is the part of a change stream's event used to know the cached entries it invalidates.
+type cacheInvalidationChange struct {
+	Namespace struct {
+		Collection string `bson:"coll"`
+	} `bson:"ns"`
+	// FullDocument is the document after an update or a replace. Before is the document before the change, only
+	// available when the collection records its pre-images, which is the only way to know the deleted documents.
+	FullDocument cacheInvalidationDocument `bson:"fullDocument"`
+	Before       cacheInvalidationDocument `bson:"fullDocumentBeforeChange"`
+}
+
+type cacheInvalidationDocument struct {
+	UID      string `bson:"uid"`
+	TenantID string `bson:"tenant_id"`
+	Name     string `bson:"name"`
+}
+
+// keys returns the cache's keys of the entries invalidated by the change.
+func (c *cacheInvalidationChange) keys() []string {
+	keys := make([]string, 0)
+	for _, doc := range []cacheInvalidationDocument{c.FullDocument, c.Before} {
+		switch c.Namespace.Collection {
+		case "devices":
+			if doc.UID != "" {
+				keys = append(keys, strings.Join([]string{"device", doc.UID}, "/"), strings.Join([]string{"auth_device", doc.UID}, "/"))
+			}
+		case "namespaces":
+			if doc.TenantID != "" {
+				keys = append(keys, strings.Join([]string{"namespace", doc.TenantID}, "/"))
+			}
+
+			if doc.Name != "" {
+				keys = append(keys, strings.Join([]string{"namespace", doc.Name}, "/"))
+			}
+		}
+	}
+
+	return keys
+}
+
+// Run watches the changes until ctx is done, watching them again, from where it stopped, when the change stream
+// fails. It returns [ErrChangeStreamsUnsupported] when the deployment doesn't support change streams.
+func (i *CacheInvalidator) Run(ctx context.Context) error {
+	var token bson.Raw
+	for {
+		err := i.watch(ctx, &token)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var cmd mongo.CommandError
+		if errors.As(err, &cmd) {
+			switch cmd.Code {
+			case errCodeChangeStreamsUnsupported:
+				return ErrChangeStreamsUnsupported
+			case errCodeChangeStreamHistoryLost:
+				// NOTICE: the changes missed while the stream was down are lost, so the stream starts from now.
+				token = nil
+			}
+		}
+
+		log.WithError(err).Warn("the cache invalidation's change stream failed")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(CacheInvalidatorRetryInterval):
+		}
+	}
+}
+
+func (i *CacheInvalidator) watch(ctx context.Context, token *bson.Raw) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"ns.coll":       bson.M{"$in": []string{"devices", "namespaces"}},
+			"operationType": bson.M{"$in": []string{"update", "replace", "delete"}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"ns":                                 1,
+			"fullDocument.uid":                   1,
+			"fullDocument.tenant_id":             1,
+			"fullDocument.name":                  1,
+			"fullDocumentBeforeChange.uid":       1,
+			"fullDocumentBeforeChange.tenant_id": 1,
+			"fullDocumentBeforeChange.name":      1,
+		}}},
+	}
+
+	opts := mongooptions.ChangeStream().
+		SetFullDocument(mongooptions.UpdateLookup).
+		SetFullDocumentBeforeChange(mongooptions.WhenAvailable)
+	if *token != nil {
+		opts.SetResumeAfter(*token)
+	}
+
+	stream, err := i.db.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		change := new(cacheInvalidationChange)
+		if err := stream.Decode(change); err != nil {
+			log.WithError(err).Warn("failed to decode the change of the cache invalidation")
+
+			continue
+		}
+
+		for _, key := range change.keys() {
+			if err := i.cache.Delete(ctx, key); err != nil {
+				log.WithError(err).WithField("key", key).Warn("failed to invalidate the cached entry")
+			}
+		}
+
+		*token = stream.ResumeToken()
+	}
+
+	return stream.Err()
+}
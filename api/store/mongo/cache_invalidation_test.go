@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCacheInvalidationChangeKeys(t *testing.T) {
+	cases := []struct {
+		description string
+		event       bson.M
+		expected    []string
+	}{
+		{
+			description: "invalidates the device and its authorization",
+			event: bson.M{
+				"ns":           bson.M{"db": "main", "coll": "devices"},
+				"fullDocument": bson.M{"uid": "uid", "tenant_id": "tenant", "name": "device"},
+			},
+			expected: []string{"device/uid", "auth_device/uid"},
+		},
+		{
+			description: "invalidates the namespace by its tenant and its names before and after the change",
+			event: bson.M{
+				"ns":                       bson.M{"db": "main", "coll": "namespaces"},
+				"fullDocument":             bson.M{"tenant_id": "tenant", "name": "renamed"},
+				"fullDocumentBeforeChange": bson.M{"tenant_id": "tenant", "name": "namespace"},
+			},
+			expected: []string{"namespace/tenant", "namespace/renamed", "namespace/tenant", "namespace/namespace"},
+		},
+		{
+			description: "invalidates nothing when the deleted document is unknown",
+			event: bson.M{
+				"ns":          bson.M{"db": "main", "coll": "devices"},
+				"documentKey": bson.M{"_id": "id"},
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			data, err := bson.Marshal(tc.event)
+			require.NoError(t, err)
+
+			change := new(cacheInvalidationChange)
+			require.NoError(t, bson.Unmarshal(data, change))
+
+			assert.Equal(t, tc.expected, change.keys())
+		})
+	}
+}
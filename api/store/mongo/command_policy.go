@@ -0,0 +1,132 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) CommandPolicyList(ctx context.Context, tenantID string) ([]models.CommandPolicy, error) {
+	cursor, err := s.db.Collection("command_policies").Find(
+		ctx,
+		bson.M{"tenant_id": tenantID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	policies := make([]models.CommandPolicy, 0)
+	if err := cursor.All(ctx, &policies); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return policies, nil
+}
+
+func (s *Store) CommandPolicyGet(ctx context.Context, tenantID, id string) (*models.CommandPolicy, error) {
+	policy := new(models.CommandPolicy)
+	if err := s.db.Collection("command_policies").FindOne(ctx, bson.M{"_id": id, "tenant_id": tenantID}).Decode(policy); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return policy, nil
+}
+
+func (s *Store) CommandPolicyCreate(ctx context.Context, policy *models.CommandPolicy) error {
+	if _, err := s.db.Collection("command_policies").InsertOne(ctx, policy); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) CommandPolicyUpdate(ctx context.Context, policy *models.CommandPolicy) error {
+	r, err := s.db.Collection("command_policies").UpdateOne(
+		ctx,
+		bson.M{"_id": policy.ID, "tenant_id": policy.TenantID},
+		bson.M{"$set": bson.M{
+			"name":       policy.Name,
+			"tags":       policy.Tags,
+			"mode":       policy.Mode,
+			"patterns":   policy.Patterns,
+			"action":     policy.Action,
+			"updated_at": policy.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) CommandPolicyDelete(ctx context.Context, tenantID, id string) error {
+	r, err := s.db.Collection("command_policies").DeleteOne(ctx, bson.M{"_id": id, "tenant_id": tenantID})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) CommandPolicyEvaluationCreate(ctx context.Context, evaluation *models.CommandPolicyEvaluation) error {
+	if _, err := s.db.Collection("command_policy_evaluations").InsertOne(ctx, evaluation); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) CommandPolicyEvaluationList(ctx context.Context, tenantID string, paginator query.Paginator) ([]models.CommandPolicyEvaluation, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenantID},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("command_policy_evaluations"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.CommandPolicyEvaluation{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"time": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("command_policy_evaluations").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	evaluations := make([]models.CommandPolicyEvaluation, 0)
+	for cursor.Next(ctx) {
+		evaluation := new(models.CommandPolicyEvaluation)
+		if err := cursor.Decode(evaluation); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		evaluations = append(evaluations, *evaluation)
+	}
+
+	return evaluations, count, nil
+}
@@ -0,0 +1,113 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	destructive := models.CommandPolicy{
+		ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		Name:      "destructive",
+		Tags:      []string{"production"},
+		Mode:      models.CommandPolicyModeDeny,
+		Patterns:  []string{`rm\s+-rf`},
+		Action:    models.CommandPolicyActionBlock,
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	readonly := models.CommandPolicy{
+		ID:        "5f2d1a0b-3c4e-4b8a-9d6f-7e8a9b0c1d2e",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		Name:      "read only",
+		Tags:      []string{},
+		Mode:      models.CommandPolicyModeAllow,
+		Patterns:  []string{`^(ls|cat)\b`},
+		Action:    models.CommandPolicyActionWarn,
+		CreatedAt: time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, s.CommandPolicyCreate(ctx, &readonly))
+	require.NoError(t, s.CommandPolicyCreate(ctx, &destructive))
+
+	policies, err := s.CommandPolicyList(ctx, "00000000-0000-4000-0000-000000000000")
+	require.NoError(t, err)
+	assert.Equal(t, []models.CommandPolicy{destructive, readonly}, policies)
+
+	policies, err = s.CommandPolicyList(ctx, "00000000-0000-4000-0000-000000000001")
+	require.NoError(t, err)
+	assert.Equal(t, []models.CommandPolicy{}, policies)
+
+	destructive.Action = models.CommandPolicyActionTerminate
+	destructive.UpdatedAt = time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC)
+	require.NoError(t, s.CommandPolicyUpdate(ctx, &destructive))
+
+	policy, err := s.CommandPolicyGet(ctx, "00000000-0000-4000-0000-000000000000", destructive.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &destructive, policy)
+
+	_, err = s.CommandPolicyGet(ctx, "00000000-0000-4000-0000-000000000001", destructive.ID)
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+
+	assert.ErrorIs(t, s.CommandPolicyUpdate(ctx, &models.CommandPolicy{ID: "nonexistent", TenantID: destructive.TenantID}), store.ErrNoDocuments)
+
+	require.NoError(t, s.CommandPolicyDelete(ctx, destructive.TenantID, destructive.ID))
+	assert.ErrorIs(t, s.CommandPolicyDelete(ctx, destructive.TenantID, destructive.ID), store.ErrNoDocuments)
+}
+
+func TestCommandPolicyEvaluation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	older := models.CommandPolicyEvaluation{
+		ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		PolicyID:   "5f2d1a0b-3c4e-4b8a-9d6f-7e8a9b0c1d2e",
+		PolicyName: "destructive",
+		SessionUID: "session",
+		DeviceUID:  "device",
+		Username:   "root",
+		Command:    "rm -rf /var",
+		Action:     models.CommandPolicyActionBlock,
+		Time:       time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	newer := older
+	newer.ID = "0b9a8c7d-6e5f-4a3b-9c2d-1e0f9a8b7c6d"
+	newer.Command = "rm -rf /"
+	newer.Action = models.CommandPolicyActionTerminate
+	newer.Time = time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	require.NoError(t, s.CommandPolicyEvaluationCreate(ctx, &older))
+	require.NoError(t, s.CommandPolicyEvaluationCreate(ctx, &newer))
+
+	evaluations, count, err := s.CommandPolicyEvaluationList(ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []models.CommandPolicyEvaluation{newer, older}, evaluations)
+
+	evaluations, count, err = s.CommandPolicyEvaluationList(ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: 2, PerPage: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []models.CommandPolicyEvaluation{older}, evaluations)
+
+	evaluations, count, err = s.CommandPolicyEvaluationList(ctx, "00000000-0000-4000-0000-000000000001", query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, []models.CommandPolicyEvaluation{}, evaluations)
+}
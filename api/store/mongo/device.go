@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/md5" //nolint:gosec
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -12,9 +13,11 @@ import (
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/geohash"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -39,17 +42,31 @@ func (s *Store) DeviceList(ctx context.Context, status models.DeviceStatus, pagi
 		},
 		{
 			"$addFields": bson.M{
-				"online": bson.M{"$anyElementTrue": []interface{}{"$online"}},
+				"online": connectedDeviceOnline(clock.Now()),
 			},
 		},
 	}
 
-	// Only match for the respective tenant if requested
+	// Only match for the respective tenant if requested, along with the devices shared with it.
+	var shared map[string]*models.DeviceShare
 	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		var err error
+		if shared, err = s.sharedDevices(ctx, tenant.ID, clock.Now()); err != nil {
+			return nil, 0, err
+		}
+
+		match := bson.M{"tenant_id": tenant.ID}
+		if len(shared) > 0 {
+			uids := make([]string, 0, len(shared))
+			for uid := range shared {
+				uids = append(uids, uid)
+			}
+
+			match = bson.M{"$or": bson.A{match, bson.M{"uid": bson.M{"$in": uids}}}}
+		}
+
 		query = append(query, bson.M{
-			"$match": bson.M{
-				"tenant_id": tenant.ID,
-			},
+			"$match": match,
 		})
 	}
 
@@ -81,7 +98,10 @@ func (s *Store) DeviceList(ctx context.Context, status models.DeviceStatus, pagi
 							"if": bson.M{
 								"$and": bson.A{
 									bson.M{"$ne": bson.A{"$status", models.DeviceStatusAccepted}},
-									bson.M{"$anyElementTrue": []interface{}{"$removed"}},
+									bson.M{"$or": bson.A{
+										bson.M{"$anyElementTrue": []interface{}{"$removed"}},
+										bson.M{"$eq": bson.A{"$limit_exempt", true}},
+									}},
 								},
 							},
 							"then": true,
@@ -95,9 +115,15 @@ func (s *Store) DeviceList(ctx context.Context, status models.DeviceStatus, pagi
 			},
 		}...)
 	case store.DeviceAcceptableAsFalse:
+		// NOTICE: the devices exempt from the namespace's maximum number of devices are still acceptable.
 		query = append(query, bson.M{
 			"$addFields": bson.M{
-				"acceptable": false,
+				"acceptable": bson.M{
+					"$and": bson.A{
+						bson.M{"$ne": bson.A{"$status", models.DeviceStatusAccepted}},
+						bson.M{"$eq": bson.A{"$limit_exempt", true}},
+					},
+				},
 			},
 		})
 	case store.DeviceAcceptableIfNotAccepted:
@@ -168,6 +194,10 @@ func (s *Store) DeviceList(ctx context.Context, status models.DeviceStatus, pagi
 			return devices, count, err
 		}
 
+		if share, ok := shared[device.UID]; ok && share.TenantID == device.TenantID {
+			device.Shared = share
+		}
+
 		devices = append(devices, *device)
 	}
 
@@ -189,7 +219,7 @@ func (s *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device,
 		},
 		{
 			"$addFields": bson.M{
-				"online": bson.M{"$anyElementTrue": []interface{}{"$online"}},
+				"online": connectedDeviceOnline(clock.Now()),
 			},
 		},
 		{
@@ -254,7 +284,7 @@ func (s *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
 		}
 
 		if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
-			logrus.Error(err)
+			logrus.WithContext(ctx).Error(err)
 		}
 
 		if _, err := s.db.Collection("sessions").DeleteMany(ctx, bson.M{"device_uid": uid}); err != nil {
@@ -269,6 +299,10 @@ func (s *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
 			return nil, FromMongoError(err)
 		}
 
+		if _, err := s.db.Collection("device_shares").DeleteMany(ctx, bson.M{"uid": uid}); err != nil {
+			return nil, FromMongoError(err)
+		}
+
 		return nil, nil
 	})
 
@@ -282,7 +316,7 @@ func (s *Store) DeviceCreate(ctx context.Context, d models.Device, hostname stri
 
 	var dev *models.Device
 	if err := s.cache.Get(ctx, strings.Join([]string{"device", d.UID}, "/"), &dev); err != nil {
-		logrus.Error(err)
+		logrus.WithContext(ctx).Error(err)
 	}
 
 	q := bson.M{
@@ -311,7 +345,7 @@ func (s *Store) DeviceRename(ctx context.Context, uid models.UID, hostname strin
 		return store.ErrNoDocuments
 	}
 
-	return nil
+	return s.sessionsSetDeviceName(ctx, uid, hostname)
 }
 
 func (s *Store) DeviceLookup(ctx context.Context, namespace, hostname string) (*models.Device, error) {
@@ -328,27 +362,69 @@ func (s *Store) DeviceLookup(ctx context.Context, namespace, hostname string) (*
 	return device, nil
 }
 
-func (s *Store) DeviceSetOnline(ctx context.Context, connectedDevices []models.ConnectedDevice) error {
-	var updateModels []mongo.WriteModel
-	var replaceModels []mongo.WriteModel
+func (s *Store) DeviceHeartbeatBulk(ctx context.Context, heartbeats []models.ConnectedDevice) ([]models.ConnectedDevice, error) {
+	// NOTICE: a device sends many heartbeats while a batch is buffered, which are reduced to the latest one, so each
+	// device is written once per batch.
+	latest := make([]models.ConnectedDevice, 0, len(heartbeats))
+	positions := make(map[string]int, len(heartbeats))
+	for _, h := range heartbeats {
+		if i, ok := positions[h.UID]; ok {
+			if h.LastSeen.After(latest[i].LastSeen) {
+				latest[i] = h
+			}
+
+			continue
+		}
+
+		positions[h.UID] = len(latest)
+		latest = append(latest, h)
+	}
+
+	if len(latest) == 0 {
+		return []models.ConnectedDevice{}, nil
+	}
+
+	updateModels := make([]mongo.WriteModel, 0, len(latest))
+	replaceModels := make([]mongo.WriteModel, 0, len(latest))
 
-	for _, d := range connectedDevices {
+	for _, d := range latest {
 		filter := bson.M{"uid": d.UID}
 
-		update := bson.M{"$set": bson.M{"last_seen": d.LastSeen}}
+		// NOTICE: $max doesn't write the device when the heartbeat is older than its last seen.
+		update := bson.M{"$max": bson.M{"last_seen": d.LastSeen}}
 		updateModels = append(updateModels, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(false))
-		replaceModels = append(replaceModels, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(d).SetUpsert(true))
+
+		// NOTICE: a heartbeat cancels a scheduled offline, keeping the time when the device got online.
+		connected := bson.M{
+			"$max":         bson.M{"last_seen": d.LastSeen},
+			"$setOnInsert": bson.M{"tenant_id": d.TenantID, "connected_at": d.LastSeen},
+			"$unset":       bson.M{"offline_at": ""},
+		}
+		replaceModels = append(replaceModels, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(connected).SetUpsert(true))
 	}
 
-	if _, err := s.db.Collection("devices").BulkWrite(ctx, updateModels); err != nil {
-		return FromMongoError(err)
+	// NOTICE: the writes are independent, so they are unordered and a failed one doesn't stop the others.
+	opts := options.BulkWrite().SetOrdered(false)
+
+	if _, err := s.db.Collection("devices").BulkWrite(ctx, updateModels, opts); err != nil {
+		return nil, FromMongoError(err)
 	}
 
-	if _, err := s.db.Collection("connected_devices").BulkWrite(ctx, replaceModels); err != nil {
-		return FromMongoError(err)
+	res, err := s.db.Collection("connected_devices").BulkWrite(ctx, replaceModels, opts)
+	if err != nil {
+		return nil, FromMongoError(err)
 	}
 
-	return nil
+	// NOTICE: the upserted IDs are indexed by the position of the write model that inserted the document, which is
+	// the same position of the device on the reduced list.
+	connected := make([]models.ConnectedDevice, 0, len(res.UpsertedIDs))
+	for i, d := range latest {
+		if _, ok := res.UpsertedIDs[int64(i)]; ok {
+			connected = append(connected, d)
+		}
+	}
+
+	return connected, nil
 }
 
 func (s *Store) DeviceSetOffline(ctx context.Context, uid string) error {
@@ -364,6 +440,78 @@ func (s *Store) DeviceSetOffline(ctx context.Context, uid string) error {
 	return nil
 }
 
+func (s *Store) DeviceGetConnected(ctx context.Context, uid string) (*models.ConnectedDevice, error) {
+	connected := new(models.ConnectedDevice)
+	if err := s.db.Collection("connected_devices").FindOne(ctx, bson.M{"uid": uid}).Decode(connected); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return connected, nil
+}
+
+func (s *Store) DeviceSetOfflineAt(ctx context.Context, uid string, at time.Time) error {
+	res, err := s.db.Collection("connected_devices").UpdateMany(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"offline_at": at}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetOfflineExpired(ctx context.Context, now time.Time) ([]models.ConnectedDevice, error) {
+	filter := bson.M{"offline_at": bson.M{"$lte": now}}
+
+	cursor, err := s.db.Collection("connected_devices").Find(ctx, filter)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	expired := make([]models.ConnectedDevice, 0)
+	if err := cursor.All(ctx, &expired); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	offline := make([]models.ConnectedDevice, 0, len(expired))
+	for _, d := range expired {
+		// NOTICE: the device may have sent a heartbeat after it was found, cancelling the scheduled offline.
+		res, err := s.db.Collection("connected_devices").DeleteOne(ctx, bson.M{"uid": d.UID, "offline_at": bson.M{"$lte": now}})
+		if err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		if res.DeletedCount > 0 {
+			offline = append(offline, d)
+		}
+	}
+
+	return offline, nil
+}
+
+// connectedDeviceOnline returns the expression that reports whether any of the connected device entries looked up
+// into the "online" field keeps the device online at now, which is when it isn't scheduled to be offline before it.
+func connectedDeviceOnline(now time.Time) bson.M {
+	return bson.M{
+		"$anyElementTrue": []interface{}{
+			bson.M{
+				"$map": bson.M{
+					"input": "$online",
+					"as":    "connected",
+					"in": bson.M{
+						"$or": []interface{}{
+							bson.M{"$not": []interface{}{"$$connected.offline_at"}},
+							bson.M{"$gt": []interface{}{"$$connected.offline_at", now}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func (s *Store) DeviceUpdateOnline(ctx context.Context, uid models.UID, online bool) error {
 	dev, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"online": online}})
 	if err != nil {
@@ -392,9 +540,15 @@ func (s *Store) DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts tim
 
 // DeviceUpdateStatus updates the status of a specific device in the devices collection
 func (s *Store) DeviceUpdateStatus(ctx context.Context, uid models.UID, status models.DeviceStatus) error {
+	update := bson.M{"$set": bson.M{"status": status, "status_updated_at": clock.Now()}}
+	// NOTICE: only the pending devices are kept on the acceptance queue.
+	if status != models.DeviceStatusPending {
+		update["$unset"] = bson.M{"queue": ""}
+	}
+
 	updateOptions := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	result := s.db.Collection("devices", options.Collection()).
-		FindOneAndUpdate(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"status": status, "status_updated_at": clock.Now()}}, updateOptions)
+		FindOneAndUpdate(ctx, bson.M{"uid": uid}, update, updateOptions)
 
 	if result.Err() != nil {
 		return FromMongoError(result.Err())
@@ -408,6 +562,44 @@ func (s *Store) DeviceUpdateStatus(ctx context.Context, uid models.UID, status m
 	return nil
 }
 
+func (s *Store) DeviceListByTenant(ctx context.Context, tenantID string) ([]models.Device, error) {
+	cursor, err := s.db.Collection("devices").Find(
+		ctx,
+		bson.M{"tenant_id": tenantID},
+		options.Find().SetProjection(bson.M{"uid": 1, "tenant_id": 1, "name": 1, "tags": 1, "info": 1}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	devices := make([]models.Device, 0)
+	if err := cursor.All(ctx, &devices); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return devices, nil
+}
+
+func (s *Store) DeviceListIdentities(ctx context.Context) ([]models.Device, error) {
+	cursor, err := s.db.Collection("devices").Find(
+		ctx,
+		bson.M{},
+		options.Find().SetProjection(bson.M{"uid": 1, "tenant_id": 1, "identity": 1, "public_key": 1}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	devices := make([]models.Device, 0)
+	if err := cursor.All(ctx, &devices); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return devices, nil
+}
+
 func (s *Store) DeviceListByUsage(ctx context.Context, tenant string) ([]models.UID, error) {
 	query := []bson.M{
 		{
@@ -471,6 +663,16 @@ func (s *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string,
 	return device, nil
 }
 
+func (s *Store) DeviceGetByClaimCode(ctx context.Context, code string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	device := new(models.Device)
+
+	if err := s.db.Collection("devices").FindOne(ctx, bson.M{"tenant_id": tenantID, "claim_code": code, "status": string(status)}).Decode(&device); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return device, nil
+}
+
 func (s *Store) DeviceGetByName(ctx context.Context, name string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
 	device := new(models.Device)
 
@@ -484,7 +686,7 @@ func (s *Store) DeviceGetByName(ctx context.Context, name string, tenantID strin
 func (s *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error) {
 	var device *models.Device
 	if err := s.cache.Get(ctx, strings.Join([]string{"device", string(uid)}, "/"), &device); err != nil {
-		logrus.Error(err)
+		logrus.WithContext(ctx).Error(err)
 	}
 
 	if device != nil {
@@ -496,7 +698,7 @@ func (s *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID str
 	}
 
 	if err := s.cache.Set(ctx, strings.Join([]string{"device", string(uid)}, "/"), device, time.Minute); err != nil {
-		logrus.Error(err)
+		logrus.WithContext(ctx).Error(err)
 	}
 
 	return device, nil
@@ -515,6 +717,101 @@ func (s *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position
 	return nil
 }
 
+func (s *Store) DevicePositionClusters(ctx context.Context, tenantID string, box geohash.Box, precision int) ([]models.DevicePositionCluster, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenantID,
+				"status":    models.DeviceStatusAccepted,
+				// NOTICE: the position is indexed as (latitude, longitude), the order of its fields, so the box's corners
+				// are written in the same order.
+				"position": bson.M{
+					"$geoWithin": bson.M{
+						"$box": bson.A{
+							bson.A{box.South, box.West},
+							bson.A{box.North, box.East},
+						},
+					},
+				},
+				"position.geohash": bson.M{"$type": "string"},
+			},
+		},
+		{
+			"$group": bson.M{
+				"_id":       bson.M{"$substrCP": bson.A{"$position.geohash", 0, precision}},
+				"latitude":  bson.M{"$avg": "$position.latitude"},
+				"longitude": bson.M{"$avg": "$position.longitude"},
+				"count":     bson.M{"$sum": 1},
+				"device":    bson.M{"$first": "$uid"},
+			},
+		},
+		{
+			"$addFields": bson.M{
+				"device": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$count", 1}}, "$device", "$$REMOVE"}},
+			},
+		},
+		{
+			"$sort": bson.M{"_id": 1},
+		},
+	}
+
+	cursor, err := s.db.Collection("devices").Aggregate(ctx, query)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	clusters := make([]models.DevicePositionCluster, 0)
+	if err := cursor.All(ctx, &clusters); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return clusters, nil
+}
+
+func (s *Store) DeviceAddAddress(ctx context.Context, uid models.UID, address models.DeviceAddress) error {
+	dev, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{
+		"$push": bson.M{
+			"addresses": bson.M{
+				"$each":  []models.DeviceAddress{address},
+				"$slice": -models.DeviceAddressesMax,
+			},
+		},
+	})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if dev.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceAddConnectionSample(ctx context.Context, uid models.UID, sample models.DeviceConnectionSample, quality *models.DeviceConnectionQuality) error {
+	dev, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{
+		"$push": bson.M{
+			"connection_samples": bson.M{
+				"$each":  []models.DeviceConnectionSample{sample},
+				"$slice": -models.DeviceConnectionSamplesMax,
+			},
+		},
+		"$set": bson.M{
+			"connection_quality": quality,
+		},
+	})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if dev.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
 func (s *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
 	filter := bson.M{
 		"status":    "accepted",
@@ -558,10 +855,16 @@ func (s *Store) DeviceUpdate(ctx context.Context, tenant string, uid models.UID,
 		return FromMongoError(err)
 	}
 
+	if name != nil {
+		if err := s.sessionsSetDeviceName(ctx, uid, *name); err != nil {
+			return err
+		}
+	}
+
 	// Not deleting the device from the cache may cause issues when trying to retrieve the device after the update.
 	// TODO: Maybe we can standardize the key creation?
 	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
-		logrus.Error(err)
+		logrus.WithContext(ctx).Error(err)
 	}
 
 	return nil
@@ -668,3 +971,240 @@ func (s *Store) DeviceGetByPublicURLAddress(ctx context.Context, address string)
 
 	return device, nil
 }
+
+func (s *Store) DeviceSetCompromised(ctx context.Context, uid models.UID, compromised bool) error {
+	dev, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"compromised": compromised}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if dev.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetConnectionNote(ctx context.Context, tenant string, uid models.UID, note string) error {
+	update := bson.M{"$set": bson.M{"connection_note": note}}
+	if note == "" {
+		update = bson.M{"$unset": bson.M{"connection_note": ""}}
+	}
+
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"tenant_id": tenant, "uid": uid}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetLimitExempt(ctx context.Context, tenant string, uid models.UID, exempt bool) error {
+	update := bson.M{"$set": bson.M{"limit_exempt": true}}
+	if !exempt {
+		update = bson.M{"$unset": bson.M{"limit_exempt": ""}}
+	}
+
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"tenant_id": tenant, "uid": uid}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetQueue(ctx context.Context, tenant string, uid models.UID, queue *models.DeviceQueue) error {
+	update := bson.M{"$set": bson.M{"queue": queue}}
+	if queue == nil {
+		update = bson.M{"$unset": bson.M{"queue": ""}}
+	}
+
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"tenant_id": tenant, "uid": uid}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceQueueList(ctx context.Context, tenant string) ([]models.Device, error) {
+	cursor, err := s.db.Collection("devices").Find(
+		ctx,
+		bson.M{"tenant_id": tenant, "status": models.DeviceStatusPending, "queue": bson.M{"$exists": true}},
+		options.Find().SetSort(bson.D{{Key: "queue.priority", Value: -1}, {Key: "queue.queued_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	devices := make([]models.Device, 0)
+	if err := cursor.All(ctx, &devices); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return devices, nil
+}
+
+func (s *Store) DeviceSearch(ctx context.Context, tenants []string, name string, status models.DeviceStatus, paginator query.Paginator) ([]models.Device, int, error) {
+	match := bson.M{
+		"tenant_id": bson.M{"$in": tenants},
+		"name":      primitive.Regex{Pattern: regexp.QuoteMeta(name), Options: "i"},
+	}
+
+	if status != "" {
+		match["status"] = status
+	}
+
+	query := []bson.M{{"$match": match}}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("devices"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.D{{Key: "name", Value: 1}, {Key: "tenant_id", Value: 1}}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+	query = append(query, []bson.M{
+		{
+			"$lookup": bson.M{
+				"from":         "connected_devices",
+				"localField":   "uid",
+				"foreignField": "uid",
+				"as":           "online",
+			},
+		},
+		{
+			"$addFields": bson.M{
+				"online": connectedDeviceOnline(clock.Now()),
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"from":         "namespaces",
+				"localField":   "tenant_id",
+				"foreignField": "tenant_id",
+				"as":           "namespace",
+			},
+		},
+		{
+			"$addFields": bson.M{
+				"namespace": "$namespace.name",
+			},
+		},
+		{
+			"$unwind": "$namespace",
+		},
+	}...)
+
+	cursor, err := s.db.Collection("devices").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	devices := make([]models.Device, 0)
+	if err := cursor.All(ctx, &devices); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return devices, count, nil
+}
+
+func (s *Store) DeviceSetRemoteAccess(ctx context.Context, tenant string, uid models.UID, remoteAccess bool) error {
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"tenant_id": tenant, "uid": uid}, bson.M{"$set": bson.M{"remote_access": remoteAccess}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	for _, key := range []string{"device", "auth_device"} {
+		if err := s.cache.Delete(ctx, strings.Join([]string{key, string(uid)}, "/")); err != nil {
+			logrus.WithContext(ctx).Error(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetLoginShell(ctx context.Context, tenant string, uid models.UID, loginShell string) error {
+	update := bson.M{"$set": bson.M{"login_shell": loginShell}}
+	if loginShell == "" {
+		update = bson.M{"$unset": bson.M{"login_shell": ""}}
+	}
+
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"tenant_id": tenant, "uid": uid}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetConfig(ctx context.Context, tenant string, uid models.UID, values map[string]string, updatedAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{"config.values": values, "config.updated_at": updatedAt},
+		"$inc": bson.M{"config.version": 1},
+	}
+
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"tenant_id": tenant, "uid": uid}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceCountByStatus(ctx context.Context, tenantID string, status models.DeviceStatus) (int64, error) {
+	count, err := s.db.Collection("devices").CountDocuments(ctx, bson.M{"tenant_id": tenantID, "status": status})
+	if err != nil {
+		return 0, FromMongoError(err)
+	}
+
+	return count, nil
+}
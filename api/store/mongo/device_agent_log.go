@@ -0,0 +1,71 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) DeviceAgentLogCreateMany(ctx context.Context, logs []models.DeviceAgentLog) error {
+	now := clock.Now()
+
+	docs := make([]interface{}, len(logs))
+	for i := range logs {
+		logs[i].CreatedAt = now
+		docs[i] = logs[i]
+	}
+
+	if _, err := s.db.Collection("device_agent_logs").InsertMany(ctx, docs); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceAgentLogList(ctx context.Context, tenantID string, uid models.UID, level string, paginator query.Paginator) ([]models.DeviceAgentLog, int, error) {
+	match := bson.M{"tenant_id": tenantID, "device_uid": uid}
+	if level != "" {
+		match["level"] = level
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_agent_logs"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.DeviceAgentLog{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"time": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("device_agent_logs").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	logs := make([]models.DeviceAgentLog, 0)
+	for cursor.Next(ctx) {
+		log := new(models.DeviceAgentLog)
+		if err := cursor.Decode(log); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		logs = append(logs, *log)
+	}
+
+	return logs, count, nil
+}
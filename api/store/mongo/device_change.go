@@ -0,0 +1,73 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// deviceChangesSequence is the document, on the counters' collection, holding the last sequence number given to a
+// device's change.
+const deviceChangesSequence = "device_changes"
+
+func (s *Store) DeviceChangeRecord(ctx context.Context, change *models.DeviceChange) error {
+	counter := struct {
+		Seq int64 `bson:"seq"`
+	}{}
+
+	if err := s.db.Collection("counters").FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": deviceChangesSequence},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter); err != nil {
+		return FromMongoError(err)
+	}
+
+	change.Seq = counter.Seq
+
+	set := bson.M{"seq": change.Seq, "type": change.Type, "changed_at": change.ChangedAt}
+	update := bson.M{"$set": set}
+	if change.ExpiresAt != nil {
+		set["expires_at"] = change.ExpiresAt
+	} else {
+		update["$unset"] = bson.M{"expires_at": ""}
+	}
+
+	if _, err := s.db.Collection("device_changes").UpdateOne(
+		ctx,
+		bson.M{"tenant_id": change.TenantID, "uid": change.UID},
+		update,
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceChangeList(ctx context.Context, tenantID string, since int64, limit int) ([]models.DeviceChange, error) {
+	cursor, err := s.db.Collection("device_changes").Find(
+		ctx,
+		bson.M{"tenant_id": tenantID, "seq": bson.M{"$gt": since}},
+		options.Find().SetSort(bson.M{"seq": 1}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	changes := make([]models.DeviceChange, 0)
+	for cursor.Next(ctx) {
+		change := new(models.DeviceChange)
+		if err := cursor.Decode(change); err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		changes = append(changes, *change)
+	}
+
+	return changes, nil
+}
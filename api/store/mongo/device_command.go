@@ -0,0 +1,106 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceCommandCreate(ctx context.Context, command *models.DeviceCommand) error {
+	if _, err := s.db.Collection("device_commands").InsertOne(ctx, command); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceCommandList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceCommand, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenantID, "device_uid": uid},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_commands"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.DeviceCommand{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("device_commands").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	commands := make([]models.DeviceCommand, 0)
+	if err := cursor.All(ctx, &commands); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return commands, count, nil
+}
+
+func (s *Store) DeviceCommandDispatch(ctx context.Context, tenantID string, uid models.UID, limit int, sentAt time.Time) ([]models.DeviceCommand, error) {
+	commands := make([]models.DeviceCommand, 0)
+
+	// NOTICE: each command is marked as sent on its own atomic update, so concurrent dispatches, like the ones of an
+	// agent authorizing twice in a row, never fetch the same command.
+	for len(commands) < limit {
+		command := new(models.DeviceCommand)
+		err := s.db.Collection("device_commands").FindOneAndUpdate(
+			ctx,
+			bson.M{"tenant_id": tenantID, "device_uid": uid, "status": models.DeviceCommandStatusQueued},
+			bson.M{"$set": bson.M{"status": models.DeviceCommandStatusSent, "sent_at": sentAt}},
+			options.FindOneAndUpdate().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetReturnDocument(options.After),
+		).Decode(command)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+
+		if err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		commands = append(commands, *command)
+	}
+
+	return commands, nil
+}
+
+func (s *Store) DeviceCommandFinish(ctx context.Context, tenantID string, uid models.UID, id string, result models.DeviceCommandResult, finishedAt time.Time) error {
+	res, err := s.db.Collection("device_commands").UpdateOne(
+		ctx,
+		bson.M{"_id": id, "tenant_id": tenantID, "device_uid": uid, "status": models.DeviceCommandStatusSent},
+		bson.M{"$set": bson.M{
+			"status":      models.DeviceCommandStatusFinished,
+			"exit_code":   result.ExitCode,
+			"output":      result.Output,
+			"finished_at": finishedAt,
+		}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceKeyIncidentCreate(ctx context.Context, incident *models.DeviceKeyIncident) (string, error) {
+	now := clock.Now()
+	incident.CreatedAt = now
+	incident.UpdatedAt = now
+
+	res, err := s.db.Collection("device_key_incidents").InsertOne(ctx, incident)
+	if err != nil {
+		return "", FromMongoError(err)
+	}
+
+	return res.InsertedID.(string), nil
+}
+
+func (s *Store) DeviceKeyIncidentGet(ctx context.Context, tenantID, id string) (*models.DeviceKeyIncident, error) {
+	incident := new(models.DeviceKeyIncident)
+	if err := s.db.Collection("device_key_incidents").FindOne(ctx, bson.M{"_id": id, "tenant_id": tenantID}).Decode(incident); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return incident, nil
+}
+
+func (s *Store) DeviceKeyIncidentGetByPublicKey(ctx context.Context, tenantID string, uid models.UID, publicKey string) (*models.DeviceKeyIncident, error) {
+	filter := bson.M{
+		"tenant_id":  tenantID,
+		"device_uid": uid,
+		"public_key": publicKey,
+	}
+
+	incident := new(models.DeviceKeyIncident)
+	if err := s.db.
+		Collection("device_key_incidents").
+		FindOne(ctx, filter, options.FindOne().SetSort(bson.M{"created_at": -1})).
+		Decode(incident); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return incident, nil
+}
+
+func (s *Store) DeviceKeyIncidentList(ctx context.Context, tenantID string, status models.DeviceKeyIncidentStatus, paginator query.Paginator) ([]models.DeviceKeyIncident, int, error) {
+	match := bson.M{"tenant_id": tenantID}
+	if status != "" {
+		match["status"] = status
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_key_incidents"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.DeviceKeyIncident{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("device_key_incidents").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	incidents := make([]models.DeviceKeyIncident, 0)
+	for cursor.Next(ctx) {
+		incident := new(models.DeviceKeyIncident)
+		if err := cursor.Decode(incident); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		incidents = append(incidents, *incident)
+	}
+
+	return incidents, count, nil
+}
+
+func (s *Store) DeviceKeyIncidentUpdateStatus(ctx context.Context, tenantID, id string, status models.DeviceKeyIncidentStatus) error {
+	res, err := s.db.
+		Collection("device_key_incidents").
+		UpdateOne(ctx, bson.M{"_id": id, "tenant_id": tenantID}, bson.M{"$set": bson.M{"status": status, "updated_at": clock.Now()}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceKeyIncidentGetByPublicKey(t *testing.T) {
+	type Expected struct {
+		id  string
+		err error
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		publicKey   string
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "fails when no incident was opened for the public key",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			publicKey:   "nonexistent",
+			fixtures:    []string{fixtureKeyIncidents},
+			expected: Expected{
+				id:  "",
+				err: store.ErrNoDocuments,
+			},
+		},
+		{
+			description: "succeeds returning the most recent incident",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			publicKey:   "new",
+			fixtures:    []string{fixtureKeyIncidents},
+			expected: Expected{
+				id:  "3e1b6d6a-5b5a-4f3c-9d2e-000000000002",
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			incident, err := s.DeviceKeyIncidentGetByPublicKey(ctx, "00000000-0000-4000-0000-000000000000", tc.uid, tc.publicKey)
+
+			id := ""
+			if incident != nil {
+				id = incident.ID
+			}
+
+			require.Equal(t, tc.expected, Expected{id, err})
+		})
+	}
+}
+
+func TestDeviceKeyIncidentList(t *testing.T) {
+	type Expected struct {
+		ids   []string
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		status      models.DeviceKeyIncidentStatus
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when there are no incidents",
+			status:      "",
+			fixtures:    []string{},
+			expected: Expected{
+				ids:   []string{},
+				count: 0,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds listing all incidents",
+			status:      "",
+			fixtures:    []string{fixtureKeyIncidents},
+			expected: Expected{
+				ids:   []string{"3e1b6d6a-5b5a-4f3c-9d2e-000000000002", "3e1b6d6a-5b5a-4f3c-9d2e-000000000001"},
+				count: 2,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds listing the incidents with status",
+			status:      models.DeviceKeyIncidentStatusOpen,
+			fixtures:    []string{fixtureKeyIncidents},
+			expected: Expected{
+				ids:   []string{"3e1b6d6a-5b5a-4f3c-9d2e-000000000002"},
+				count: 1,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			incidents, count, err := s.DeviceKeyIncidentList(ctx, "00000000-0000-4000-0000-000000000000", tc.status, query.Paginator{Page: 1, PerPage: 10})
+
+			ids := []string{}
+			for _, incident := range incidents {
+				ids = append(ids, incident.ID)
+			}
+
+			require.Equal(t, tc.expected, Expected{ids, count, err})
+		})
+	}
+}
+
+func TestDeviceKeyIncidentUpdateStatus(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the incident does not exist",
+			id:          "nonexistent",
+			fixtures:    []string{fixtureKeyIncidents},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds",
+			id:          "3e1b6d6a-5b5a-4f3c-9d2e-000000000002",
+			fixtures:    []string{fixtureKeyIncidents},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			err := s.DeviceKeyIncidentUpdateStatus(ctx, "00000000-0000-4000-0000-000000000000", tc.id, models.DeviceKeyIncidentStatusApproved)
+			require.Equal(t, tc.expected, err)
+
+			if err == nil {
+				incident, err := s.DeviceKeyIncidentGet(ctx, "00000000-0000-4000-0000-000000000000", tc.id)
+				require.NoError(t, err)
+				require.Equal(t, models.DeviceKeyIncidentStatusApproved, incident.Status)
+				require.WithinDuration(t, time.Now(), incident.UpdatedAt, time.Minute)
+			}
+		})
+	}
+}
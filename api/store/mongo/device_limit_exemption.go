@@ -0,0 +1,78 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceLimitExemptionCreate(ctx context.Context, exemption *models.DeviceLimitExemption) error {
+	if _, err := s.db.Collection("device_limit_exemptions").InsertOne(ctx, exemption); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceLimitExemptionList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceLimitExemption, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenantID, "device_uid": uid},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_limit_exemptions"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.DeviceLimitExemption{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("device_limit_exemptions").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	exemptions := make([]models.DeviceLimitExemption, 0)
+	for cursor.Next(ctx) {
+		exemption := new(models.DeviceLimitExemption)
+		if err := cursor.Decode(exemption); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		exemptions = append(exemptions, *exemption)
+	}
+
+	return exemptions, count, nil
+}
+
+func (s *Store) DeviceLimitExemptionListByUser(ctx context.Context, tenantID, userID string, since time.Time) ([]models.DeviceLimitExemption, error) {
+	cursor, err := s.db.Collection("device_limit_exemptions").Find(
+		ctx,
+		bson.M{"tenant_id": tenantID, "user_id": userID, "created_at": bson.M{"$gte": since}},
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	exemptions := make([]models.DeviceLimitExemption, 0)
+	if err := cursor.All(ctx, &exemptions); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return exemptions, nil
+}
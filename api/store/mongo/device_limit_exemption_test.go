@@ -0,0 +1,117 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+var deviceLimitExemptions = []models.DeviceLimitExemption{
+	{
+		ID:        "7a2c3d4e-1d2c-4e8f-9a4b-000000000001",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		Exempt:    true,
+		UserID:    "507f1f77bcf86cd799439011",
+		Reason:    "lab device",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:        "7a2c3d4e-1d2c-4e8f-9a4b-000000000002",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		Exempt:    false,
+		UserID:    "507f1f77bcf86cd799439011",
+		CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:        "7a2c3d4e-1d2c-4e8f-9a4b-000000000003",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+		Exempt:    true,
+		UserID:    "507f1f77bcf86cd799439011",
+		CreatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestDeviceLimitExemptionList(t *testing.T) {
+	type Expected struct {
+		ids   []string
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		expected    Expected
+	}{
+		{
+			description: "succeeds when the device has no exemptions",
+			uid:         models.UID("4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"),
+			expected: Expected{
+				ids:   []string{},
+				count: 0,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds listing the device's exemptions",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			expected: Expected{
+				ids:   []string{"7a2c3d4e-1d2c-4e8f-9a4b-000000000002", "7a2c3d4e-1d2c-4e8f-9a4b-000000000001"},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := range deviceLimitExemptions {
+				require.NoError(t, s.DeviceLimitExemptionCreate(ctx, &deviceLimitExemptions[i]))
+			}
+
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			exemptions, count, err := s.DeviceLimitExemptionList(ctx, "00000000-0000-4000-0000-000000000000", tc.uid, query.Paginator{Page: 1, PerPage: 10})
+
+			ids := []string{}
+			for _, exemption := range exemptions {
+				ids = append(ids, exemption.ID)
+			}
+
+			require.Equal(t, tc.expected, Expected{ids, count, err})
+		})
+	}
+}
+
+func TestDeviceLimitExemptionListByUser(t *testing.T) {
+	ctx := context.Background()
+
+	for i := range deviceLimitExemptions {
+		require.NoError(t, s.DeviceLimitExemptionCreate(ctx, &deviceLimitExemptions[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	exemptions, err := s.DeviceLimitExemptionListByUser(ctx, "00000000-0000-4000-0000-000000000000", "507f1f77bcf86cd799439011", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	ids := []string{}
+	for _, exemption := range exemptions {
+		ids = append(ids, exemption.ID)
+	}
+
+	require.Equal(t, []string{"7a2c3d4e-1d2c-4e8f-9a4b-000000000003", "7a2c3d4e-1d2c-4e8f-9a4b-000000000002"}, ids)
+
+	exemptions, err = s.DeviceLimitExemptionListByUser(ctx, "00000000-0000-4000-0000-000000000000", "nonexistent", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Empty(t, exemptions)
+}
@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceQuarantineAttemptCreate(ctx context.Context, attempt *models.DeviceQuarantineAttempt) error {
+	if _, err := s.db.Collection("device_quarantine_attempts").InsertOne(ctx, attempt); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceQuarantineAttemptGetLast(ctx context.Context, tenantID string, uid models.UID) (*models.DeviceQuarantineAttempt, error) {
+	attempt := new(models.DeviceQuarantineAttempt)
+	if err := s.db.Collection("device_quarantine_attempts").FindOne(
+		ctx,
+		bson.M{"tenant_id": tenantID, "device_uid": uid},
+		options.FindOne().SetSort(bson.M{"created_at": -1}),
+	).Decode(attempt); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return attempt, nil
+}
+
+func (s *Store) DeviceQuarantineAttemptList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceQuarantineAttempt, int, error) {
+	match := bson.M{"tenant_id": tenantID}
+	if uid != "" {
+		match["device_uid"] = uid
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_quarantine_attempts"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.DeviceQuarantineAttempt{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("device_quarantine_attempts").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	attempts := make([]models.DeviceQuarantineAttempt, 0)
+	for cursor.Next(ctx) {
+		attempt := new(models.DeviceQuarantineAttempt)
+		if err := cursor.Decode(attempt); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		attempts = append(attempts, *attempt)
+	}
+
+	return attempts, count, nil
+}
@@ -0,0 +1,85 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+var deviceQuarantineAttempts = []models.DeviceQuarantineAttempt{
+	{
+		ID:         "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		DeviceUID:  "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		Hostname:   "clone",
+		MAC:        "mac-1",
+		RemoteAddr: "192.168.0.1",
+		CreatedAt:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:         "6d1e2f3a-1d2c-4e8f-9a4b-000000000002",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		DeviceUID:  "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		Hostname:   "clone",
+		MAC:        "mac-1",
+		RemoteAddr: "192.168.0.2",
+		CreatedAt:  time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:         "6d1e2f3a-1d2c-4e8f-9a4b-000000000003",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		DeviceUID:  "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+		Hostname:   "image",
+		MAC:        "mac-2",
+		RemoteAddr: "192.168.0.3",
+		CreatedAt:  time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestDeviceQuarantineAttemptGetLast(t *testing.T) {
+	ctx := context.Background()
+
+	for i := range deviceQuarantineAttempts {
+		require.NoError(t, s.DeviceQuarantineAttemptCreate(ctx, &deviceQuarantineAttempts[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	_, err := s.DeviceQuarantineAttemptGetLast(ctx, "00000000-0000-4000-0000-000000000000", "4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e")
+	require.Equal(t, store.ErrNoDocuments, err)
+
+	attempt, err := s.DeviceQuarantineAttemptGetLast(ctx, "00000000-0000-4000-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+	require.NoError(t, err)
+	require.Equal(t, "6d1e2f3a-1d2c-4e8f-9a4b-000000000002", attempt.ID)
+}
+
+func TestDeviceQuarantineAttemptList(t *testing.T) {
+	ctx := context.Background()
+
+	for i := range deviceQuarantineAttempts {
+		require.NoError(t, s.DeviceQuarantineAttemptCreate(ctx, &deviceQuarantineAttempts[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	attempts, count, err := s.DeviceQuarantineAttemptList(ctx, "00000000-0000-4000-0000-000000000000", "", query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	require.Equal(t, "6d1e2f3a-1d2c-4e8f-9a4b-000000000003", attempts[0].ID)
+
+	attempts, count, err = s.DeviceQuarantineAttemptList(ctx, "00000000-0000-4000-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c", query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	ids := []string{}
+	for _, attempt := range attempts {
+		ids = append(ids, attempt.ID)
+	}
+
+	require.Equal(t, []string{"6d1e2f3a-1d2c-4e8f-9a4b-000000000002", "6d1e2f3a-1d2c-4e8f-9a4b-000000000001"}, ids)
+}
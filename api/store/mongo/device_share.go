@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceShareCreate(ctx context.Context, share *models.DeviceShare) error {
+	if _, err := s.db.Collection("device_shares").InsertOne(ctx, share); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceShareList(ctx context.Context, tenantID, uid string) ([]models.DeviceShare, error) {
+	return s.deviceShareFind(ctx, bson.M{"tenant_id": tenantID, "uid": uid})
+}
+
+func (s *Store) DeviceShareListByGrantee(ctx context.Context, granteeTenantID string, at time.Time) ([]models.DeviceShare, error) {
+	return s.deviceShareFind(ctx, bson.M{
+		"grantee_tenant_id": granteeTenantID,
+		"$or": bson.A{
+			bson.M{"expires_at": nil},
+			bson.M{"expires_at": bson.M{"$gt": at}},
+		},
+	})
+}
+
+func (s *Store) deviceShareFind(ctx context.Context, filter bson.M) ([]models.DeviceShare, error) {
+	cursor, err := s.db.Collection("device_shares").Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	shares := make([]models.DeviceShare, 0)
+	if err := cursor.All(ctx, &shares); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return shares, nil
+}
+
+func (s *Store) DeviceShareDelete(ctx context.Context, tenantID, uid, id string) error {
+	res, err := s.db.Collection("device_shares").DeleteOne(ctx, bson.M{"_id": id, "tenant_id": tenantID, "uid": uid})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// sharedDevices returns the shares of the devices shared with the grantee tenant at the instant, by the devices' UIDs.
+func (s *Store) sharedDevices(ctx context.Context, granteeTenantID string, at time.Time) (map[string]*models.DeviceShare, error) {
+	shares, err := s.DeviceShareListByGrantee(ctx, granteeTenantID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make(map[string]*models.DeviceShare, len(shares))
+	for i := range shares {
+		shared[shares[i].UID] = &shares[i]
+	}
+
+	return shared, nil
+}
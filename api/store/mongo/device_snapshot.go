@@ -0,0 +1,31 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceSnapshotCreate(ctx context.Context, snapshot *models.DeviceSnapshot) error {
+	if _, err := s.db.Collection("device_snapshots").InsertOne(ctx, snapshot); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSnapshotGet(ctx context.Context, tenantID string, uid models.UID, at time.Time) (*models.DeviceSnapshot, error) {
+	snapshot := new(models.DeviceSnapshot)
+	if err := s.db.Collection("device_snapshots").FindOne(
+		ctx,
+		bson.M{"tenant_id": tenantID, "device_uid": uid, "taken_at": bson.M{"$lte": at}},
+		options.FindOne().SetSort(bson.D{{Key: "taken_at", Value: -1}}),
+	).Decode(snapshot); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,69 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	tenantID := "00000000-0000-4000-0000-000000000000"
+	uid := models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+
+	// NOTICE: the snapshots are taken recently, as the older ones are expired.
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	first := models.DeviceSnapshot{
+		ID:        "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+		TenantID:  tenantID,
+		DeviceUID: string(uid),
+		Identity:  &models.DeviceIdentity{MAC: "mac"},
+		Info: &models.DeviceInfo{
+			ID:         "debian",
+			PrettyName: "Debian GNU/Linux 11 (bullseye)",
+			Version:    "v0.16.0",
+			Arch:       "amd64",
+			Platform:   "native",
+			Interfaces: []models.DeviceInterface{{Name: "eth0", MAC: "mac", Addresses: []string{"192.168.1.10/24"}}},
+		},
+		ConfigVersion: 1,
+		TakenAt:       now.Add(-2 * time.Hour),
+	}
+
+	second := first
+	second.ID = "6f1b2c3d-0c1b-4d7e-8f3a-000000000002"
+	second.Info = &models.DeviceInfo{
+		ID:         "debian",
+		PrettyName: "Debian GNU/Linux 12 (bookworm)",
+		Version:    "v0.17.0",
+		Arch:       "amd64",
+		Platform:   "native",
+	}
+	second.TakenAt = now.Add(-time.Hour)
+
+	require.NoError(t, s.DeviceSnapshotCreate(ctx, &first))
+	require.NoError(t, s.DeviceSnapshotCreate(ctx, &second))
+
+	_, err := s.DeviceSnapshotGet(ctx, tenantID, uid, now.Add(-3*time.Hour))
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+
+	snapshot, err := s.DeviceSnapshotGet(ctx, tenantID, uid, first.TakenAt)
+	require.NoError(t, err)
+	assert.Equal(t, &first, snapshot)
+
+	snapshot, err = s.DeviceSnapshotGet(ctx, tenantID, uid, now)
+	require.NoError(t, err)
+	assert.Equal(t, &second, snapshot)
+
+	_, err = s.DeviceSnapshotGet(ctx, "00000000-0000-4000-0000-000000000001", uid, second.TakenAt)
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+}
@@ -41,13 +41,13 @@ func (s *Store) DeviceSetTags(ctx context.Context, uid models.UID, tags []string
 }
 
 func (s *Store) DeviceBulkRenameTag(ctx context.Context, tenant, currentTag, newTag string) (int64, error) {
-	res, err := s.db.Collection("devices").UpdateMany(ctx, bson.M{"tenant_id": tenant, "tags": currentTag}, bson.M{"$set": bson.M{"tags.$": newTag}})
+	res, err := s.db.Collection("devices").UpdateMany(ctx, bson.M{"tenant_id": tenant, "tags": tagWithDescendants(currentTag)}, renameTagPipeline("tags", currentTag, newTag))
 
 	return res.ModifiedCount, FromMongoError(err)
 }
 
 func (s *Store) DeviceBulkDeleteTag(ctx context.Context, tenant, tag string) (int64, error) {
-	res, err := s.db.Collection("devices").UpdateMany(ctx, bson.M{"tenant_id": tenant}, bson.M{"$pull": bson.M{"tags": tag}})
+	res, err := s.db.Collection("devices").UpdateMany(ctx, bson.M{"tenant_id": tenant}, bson.M{"$pull": bson.M{"tags": tagWithDescendants(tag)}})
 
 	return res.ModifiedCount, FromMongoError(err)
 }
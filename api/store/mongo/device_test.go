@@ -8,9 +8,11 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/geohash"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestDeviceList(t *testing.T) {
@@ -578,6 +580,53 @@ func TestDeviceListByUsage(t *testing.T) {
 	}
 }
 
+func TestDeviceListByTenant(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, srv.Apply(fixtureDevices))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	devices, err := s.DeviceListByTenant(ctx, "00000000-0000-4000-0000-000000000000")
+	require.NoError(t, err)
+
+	uids := make([]string, 0, len(devices))
+	for _, device := range devices {
+		uids = append(uids, device.UID)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+		"4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e",
+		"2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		"3300330e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809d",
+	}, uids)
+
+	devices, err = s.DeviceListByTenant(ctx, "nonexistent")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+func TestDeviceListIdentities(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, srv.Apply(fixtureDevices))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	devices, err := s.DeviceListIdentities(ctx)
+	require.NoError(t, err)
+	require.Len(t, devices, 4)
+
+	for _, device := range devices {
+		assert.NotEmpty(t, device.UID)
+		assert.NotEmpty(t, device.TenantID)
+		assert.Empty(t, device.Name)
+	}
+}
+
 func TestDeviceGet(t *testing.T) {
 	type Expected struct {
 		dev *models.Device
@@ -852,6 +901,81 @@ func TestDeviceGetByName(t *testing.T) {
 	}
 }
 
+func TestDeviceGetByClaimCode(t *testing.T) {
+	type Expected struct {
+		dev *models.Device
+		err error
+	}
+
+	cases := []struct {
+		description string
+		code        string
+		tenant      string
+		status      models.DeviceStatus
+		expected    Expected
+	}{
+		{
+			description: "fails when device is not found due to code",
+			code:        "00000-00000",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			status:      models.DeviceStatusPending,
+			expected:    Expected{dev: nil, err: store.ErrNoDocuments},
+		},
+		{
+			description: "fails when device is not found due to tenant",
+			code:        "7K3QF-M2XAD",
+			tenant:      "00000000-0000-4000-0000-000000000001",
+			status:      models.DeviceStatusPending,
+			expected:    Expected{dev: nil, err: store.ErrNoDocuments},
+		},
+		{
+			description: "fails when device is not found due to status",
+			code:        "7K3QF-M2XAD",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			status:      models.DeviceStatusAccepted,
+			expected:    Expected{dev: nil, err: store.ErrNoDocuments},
+		},
+		{
+			description: "succeeds when device is found",
+			code:        "7K3QF-M2XAD",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			status:      models.DeviceStatusPending,
+			expected: Expected{
+				dev: &models.Device{
+					UID:       "uid",
+					Name:      "device",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Status:    models.DeviceStatusPending,
+					ClaimCode: "7K3QF-M2XAD",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := db.Collection("devices").InsertOne(ctx, bson.M{
+				"uid":        "uid",
+				"name":       "device",
+				"tenant_id":  "00000000-0000-4000-0000-000000000000",
+				"status":     "pending",
+				"claim_code": "7K3QF-M2XAD",
+			})
+			require.NoError(t, err)
+
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			dev, err := s.DeviceGetByClaimCode(ctx, tc.code, tc.tenant, tc.status)
+			assert.Equal(t, tc.expected, Expected{dev: dev, err: err})
+		})
+	}
+}
+
 func TestDeviceGetByUID(t *testing.T) {
 	type Expected struct {
 		dev *models.Device
@@ -1084,7 +1208,7 @@ func TestDeviceRename(t *testing.T) {
 			description: "succeeds when the device is found",
 			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
 			hostname:    "new_hostname",
-			fixtures:    []string{fixtureDevices},
+			fixtures:    []string{fixtureDevices, fixtureSessions},
 			expected:    nil,
 		},
 	}
@@ -1100,6 +1224,12 @@ func TestDeviceRename(t *testing.T) {
 
 			err := s.DeviceRename(ctx, tc.uid, tc.hostname)
 			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				count, err := db.Collection("sessions").CountDocuments(ctx, bson.M{"device_uid": tc.uid, "device_name": tc.hostname})
+				assert.NoError(t, err)
+				assert.Equal(t, int64(4), count)
+			}
 		})
 	}
 }
@@ -1221,12 +1351,19 @@ func TestDeviceUpdateLastSeen(t *testing.T) {
 	}
 }
 
-func TestDeviceSetOnline(t *testing.T) {
+func TestDeviceHeartbeatBulk(t *testing.T) {
+	type Expected struct {
+		connected []models.ConnectedDevice
+		err       error
+	}
+
+	now := clock.Now()
+
 	cases := []struct {
 		description string
 		devices     []models.ConnectedDevice
 		fixtures    []string
-		expected    error
+		expected    Expected
 	}{
 		{
 			description: "succeeds",
@@ -1234,11 +1371,70 @@ func TestDeviceSetOnline(t *testing.T) {
 				{
 					UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
 					TenantID: "00000000-0000-4000-0000-000000000000",
-					LastSeen: clock.Now(),
+					LastSeen: now,
 				},
 			},
 			fixtures: []string{fixtureDevices},
-			expected: nil,
+			expected: Expected{
+				connected: []models.ConnectedDevice{
+					{
+						UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						LastSeen: now,
+					},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds without returning the devices already connected",
+			devices: []models.ConnectedDevice{
+				{
+					UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+					TenantID: "00000000-0000-4000-0000-000000000000",
+					LastSeen: now,
+				},
+			},
+			fixtures: []string{fixtureDevices, fixtureConnectedDevices},
+			expected: Expected{
+				connected: []models.ConnectedDevice{},
+				err:       nil,
+			},
+		},
+		{
+			description: "succeeds reducing the heartbeats of a device to the latest one",
+			devices: []models.ConnectedDevice{
+				{
+					UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+					TenantID: "00000000-0000-4000-0000-000000000000",
+					LastSeen: now.Add(-time.Minute),
+				},
+				{
+					UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+					TenantID: "00000000-0000-4000-0000-000000000000",
+					LastSeen: now,
+				},
+			},
+			fixtures: []string{fixtureDevices},
+			expected: Expected{
+				connected: []models.ConnectedDevice{
+					{
+						UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						LastSeen: now,
+					},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds without heartbeats",
+			devices:     []models.ConnectedDevice{},
+			fixtures:    []string{},
+			expected: Expected{
+				connected: []models.ConnectedDevice{},
+				err:       nil,
+			},
 		},
 	}
 
@@ -1251,7 +1447,8 @@ func TestDeviceSetOnline(t *testing.T) {
 				assert.NoError(t, srv.Reset())
 			})
 
-			require.Equal(t, tc.expected, s.DeviceSetOnline(ctx, tc.devices))
+			connected, err := s.DeviceHeartbeatBulk(ctx, tc.devices)
+			require.Equal(t, tc.expected, Expected{connected, err})
 		})
 	}
 }
@@ -1291,6 +1488,95 @@ func TestDeviceSetOffline(t *testing.T) {
 	}
 }
 
+func TestDeviceSetOfflineAt(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when connected_device is not found",
+			uid:         "0000000000000000000000000000000000000000000000000000000000000000",
+			fixtures:    []string{fixtureConnectedDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when connected_device is found",
+			uid:         "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+			fixtures:    []string{fixtureConnectedDevices},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			at := time.Date(2023, 1, 1, 12, 1, 0, 0, time.UTC)
+			require.Equal(t, tc.expected, s.DeviceSetOfflineAt(ctx, tc.uid, at))
+
+			if tc.expected == nil {
+				connected, err := s.DeviceGetConnected(ctx, tc.uid)
+				require.NoError(t, err)
+				require.NotNil(t, connected.OfflineAt)
+				assert.Equal(t, at, connected.OfflineAt.UTC())
+			}
+		})
+	}
+}
+
+func TestDeviceSetOfflineExpired(t *testing.T) {
+	cases := []struct {
+		description string
+		at          time.Time
+		now         time.Time
+		expected    int
+	}{
+		{
+			description: "keeps the device when its offline time is after now",
+			at:          time.Date(2023, 1, 1, 12, 1, 0, 0, time.UTC),
+			now:         time.Date(2023, 1, 1, 12, 0, 30, 0, time.UTC),
+			expected:    0,
+		},
+		{
+			description: "sets the device offline when its offline time is before now",
+			at:          time.Date(2023, 1, 1, 12, 1, 0, 0, time.UTC),
+			now:         time.Date(2023, 1, 1, 12, 1, 30, 0, time.UTC),
+			expected:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(fixtureConnectedDevices))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			require.NoError(t, s.DeviceSetOfflineAt(ctx, "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c", tc.at))
+
+			offline, err := s.DeviceSetOfflineExpired(ctx, tc.now)
+			require.NoError(t, err)
+			assert.Len(t, offline, tc.expected)
+
+			_, err = s.DeviceGetConnected(ctx, "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+			if tc.expected > 0 {
+				assert.Equal(t, store.ErrNoDocuments, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestDeviceSetPosition(t *testing.T) {
 	cases := []struct {
 		description string
@@ -1336,18 +1622,36 @@ func TestDeviceSetPosition(t *testing.T) {
 	}
 }
 
-func TestDeviceChooser(t *testing.T) {
+func TestDeviceSetLoginShell(t *testing.T) {
 	cases := []struct {
 		description string
 		tenant      string
-		chosen      []string
+		uid         models.UID
+		loginShell  string
 		fixtures    []string
 		expected    error
 	}{
 		{
-			description: "",
+			description: "fails when the device is not found",
 			tenant:      "00000000-0000-4000-0000-000000000000",
-			chosen:      []string{""},
+			uid:         models.UID("nonexistent"),
+			loginShell:  "/usr/bin/htop",
+			fixtures:    []string{fixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the login shell is set",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			loginShell:  "/usr/bin/htop",
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the login shell is unset",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			loginShell:  "",
 			fixtures:    []string{fixtureDevices},
 			expected:    nil,
 		},
@@ -1362,28 +1666,42 @@ func TestDeviceChooser(t *testing.T) {
 				assert.NoError(t, srv.Reset())
 			})
 
-			err := s.DeviceChooser(ctx, tc.tenant, tc.chosen)
+			err := s.DeviceSetLoginShell(ctx, tc.tenant, tc.uid, tc.loginShell)
 			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := s.DeviceGetByUID(ctx, tc.uid, tc.tenant)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.loginShell, device.LoginShell)
+			}
 		})
 	}
 }
 
-func TestDeviceDelete(t *testing.T) {
+func TestDeviceSetConfig(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
 	cases := []struct {
 		description string
+		tenant      string
 		uid         models.UID
+		values      map[string]string
 		fixtures    []string
 		expected    error
 	}{
 		{
-			description: "fails when device is not found",
+			description: "fails when the device is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
 			uid:         models.UID("nonexistent"),
+			values:      map[string]string{"log_level": "debug"},
 			fixtures:    []string{fixtureDevices},
 			expected:    store.ErrNoDocuments,
 		},
 		{
-			description: "succeeds when device is found",
+			description: "succeeds when the config is set",
+			tenant:      "00000000-0000-4000-0000-000000000000",
 			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			values:      map[string]string{"log_level": "debug"},
 			fixtures:    []string{fixtureDevices},
 			expected:    nil,
 		},
@@ -1398,8 +1716,609 @@ func TestDeviceDelete(t *testing.T) {
 				assert.NoError(t, srv.Reset())
 			})
 
-			err := s.DeviceDelete(ctx, tc.uid)
+			err := s.DeviceSetConfig(ctx, tc.tenant, tc.uid, tc.values, updatedAt)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				assert.NoError(t, s.DeviceSetConfig(ctx, tc.tenant, tc.uid, tc.values, updatedAt))
+
+				device, err := s.DeviceGetByUID(ctx, tc.uid, tc.tenant)
+				assert.NoError(t, err)
+				assert.Equal(t, &models.DeviceConfig{Values: tc.values, Version: 2, UpdatedAt: updatedAt}, device.Config)
+			}
+		})
+	}
+}
+
+func TestDeviceSetConnectionNote(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		uid         models.UID
+		note        string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("nonexistent"),
+			note:        "Use the user **admin**",
+			fixtures:    []string{fixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the note is set",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			note:        "Use the user **admin**",
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the note is unset",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			note:        "",
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			err := s.DeviceSetConnectionNote(ctx, tc.tenant, tc.uid, tc.note)
 			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := s.DeviceGetByUID(ctx, tc.uid, tc.tenant)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.note, device.ConnectionNote)
+			}
+		})
+	}
+}
+
+func TestDeviceSetLimitExempt(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		uid         models.UID
+		exempt      bool
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("nonexistent"),
+			exempt:      true,
+			fixtures:    []string{fixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is exempted",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			exempt:      true,
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the exemption is revoked",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			exempt:      false,
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			err := s.DeviceSetLimitExempt(ctx, tc.tenant, tc.uid, tc.exempt)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := s.DeviceGetByUID(ctx, tc.uid, tc.tenant)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.exempt, device.LimitExempt)
+			}
+		})
+	}
+}
+
+func TestDeviceSetQueue(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		uid         models.UID
+		queue       *models.DeviceQueue
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("nonexistent"),
+			queue:       &models.DeviceQueue{Priority: 1, QueuedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+			fixtures:    []string{fixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is queued",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("3300330e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809d"),
+			queue:       &models.DeviceQueue{Priority: 1, QueuedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), QueuedBy: "507f1f77bcf86cd799439011"},
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the device is dequeued",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         models.UID("3300330e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809d"),
+			queue:       nil,
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			err := s.DeviceSetQueue(ctx, tc.tenant, tc.uid, tc.queue)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := s.DeviceGetByUID(ctx, tc.uid, tc.tenant)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.queue, device.Queue)
+			}
+		})
+	}
+}
+
+func TestDeviceQueueList(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, srv.Apply(fixtureDevices))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+	queuedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// NOTICE: only the pending devices are listed, so the accepted one is left out.
+	require.NoError(t, s.DeviceSetQueue(ctx, tenant, models.UID("3300330e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809d"), &models.DeviceQueue{Priority: 1, QueuedAt: queuedAt}))
+	require.NoError(t, s.DeviceSetQueue(ctx, tenant, models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"), &models.DeviceQueue{Priority: 2, QueuedAt: queuedAt}))
+
+	devices, err := s.DeviceQueueList(ctx, tenant)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "3300330e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809d", devices[0].UID)
+
+	devices, err = s.DeviceQueueList(ctx, "nonexistent")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+func TestDeviceSearch(t *testing.T) {
+	type Expected struct {
+		names []string
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		tenants     []string
+		name        string
+		status      models.DeviceStatus
+		paginator   query.Paginator
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when no tenant is searched",
+			tenants:     []string{},
+			name:        "device",
+			paginator:   query.Paginator{Page: 1, PerPage: 10},
+			fixtures:    []string{fixtureNamespaces, fixtureDevices},
+			expected:    Expected{names: []string{}, count: 0, err: nil},
+		},
+		{
+			description: "succeeds matching the name case-insensitively",
+			tenants:     []string{"00000000-0000-4000-0000-000000000000", "00000000-0000-4001-0000-000000000000"},
+			name:        "DEVICE-1",
+			paginator:   query.Paginator{Page: 1, PerPage: 10},
+			fixtures:    []string{fixtureNamespaces, fixtureDevices},
+			expected:    Expected{names: []string{"device-1"}, count: 1, err: nil},
+		},
+		{
+			description: "succeeds matching the devices with the status",
+			tenants:     []string{"00000000-0000-4000-0000-000000000000"},
+			name:        "device",
+			status:      models.DeviceStatusAccepted,
+			paginator:   query.Paginator{Page: 1, PerPage: 10},
+			fixtures:    []string{fixtureNamespaces, fixtureDevices},
+			expected:    Expected{names: []string{"device-1", "device-2", "device-3"}, count: 3, err: nil},
+		},
+		{
+			description: "succeeds paginating the devices",
+			tenants:     []string{"00000000-0000-4000-0000-000000000000"},
+			name:        "device",
+			paginator:   query.Paginator{Page: 2, PerPage: 3},
+			fixtures:    []string{fixtureNamespaces, fixtureDevices},
+			expected:    Expected{names: []string{"device-4"}, count: 4, err: nil},
+		},
+		{
+			description: "succeeds without matching the devices of other tenants",
+			tenants:     []string{"00000000-0000-4001-0000-000000000000"},
+			name:        "device",
+			paginator:   query.Paginator{Page: 1, PerPage: 10},
+			fixtures:    []string{fixtureNamespaces, fixtureDevices},
+			expected:    Expected{names: []string{}, count: 0, err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			devices, count, err := s.DeviceSearch(ctx, tc.tenants, tc.name, tc.status, tc.paginator)
+
+			names := make([]string, 0, len(devices))
+			for _, device := range devices {
+				assert.Equal(t, "namespace-1", device.Namespace)
+				names = append(names, device.Name)
+			}
+
+			assert.Equal(t, tc.expected, Expected{names, count, err})
+		})
+	}
+}
+
+func TestDeviceSetRemoteAccess(t *testing.T) {
+	cases := []struct {
+		description  string
+		tenant       string
+		uid          models.UID
+		remoteAccess bool
+		fixtures     []string
+		expected     error
+	}{
+		{
+			description:  "fails when the device is not found",
+			tenant:       "00000000-0000-4000-0000-000000000000",
+			uid:          models.UID("nonexistent"),
+			remoteAccess: true,
+			fixtures:     []string{fixtureDevices},
+			expected:     store.ErrNoDocuments,
+		},
+		{
+			description:  "succeeds when the remote access is enabled",
+			tenant:       "00000000-0000-4000-0000-000000000000",
+			uid:          models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			remoteAccess: true,
+			fixtures:     []string{fixtureDevices},
+			expected:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			err := s.DeviceSetRemoteAccess(ctx, tc.tenant, tc.uid, tc.remoteAccess)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := s.DeviceGetByUID(ctx, tc.uid, tc.tenant)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.remoteAccess, device.RemoteAccess)
+			}
+		})
+	}
+}
+
+func TestDeviceAddAddress(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		address     models.DeviceAddress
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("nonexistent"),
+			address:     models.DeviceAddress{RemoteAddr: "192.168.0.1", Country: "BR"},
+			fixtures:    []string{fixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			address:     models.DeviceAddress{RemoteAddr: "192.168.0.1", Country: "BR"},
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			err := s.DeviceAddAddress(ctx, tc.uid, tc.address)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDeviceAddConnectionSample(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		sample      models.DeviceConnectionSample
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("nonexistent"),
+			sample:      models.DeviceConnectionSample{RTT: 120, Reconnects: 1},
+			fixtures:    []string{fixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			sample:      models.DeviceConnectionSample{RTT: 120, Reconnects: 1},
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			quality := models.NewDeviceConnectionQuality([]models.DeviceConnectionSample{tc.sample})
+
+			err := s.DeviceAddConnectionSample(ctx, tc.uid, tc.sample, quality)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := s.DeviceGetByUID(ctx, tc.uid, "00000000-0000-4000-0000-000000000000")
+				assert.NoError(t, err)
+				assert.Len(t, device.ConnectionSamples, 1)
+				assert.Equal(t, quality, device.ConnectionQuality)
+			}
+		})
+	}
+}
+
+func TestDevicePositionClusters(t *testing.T) {
+	cases := []struct {
+		description string
+		box         geohash.Box
+		precision   int
+		fixtures    []string
+		expected    []models.DevicePositionCluster
+	}{
+		{
+			description: "succeeds when no device is inside the box",
+			box:         geohash.Box{West: 100, South: 0, East: 110, North: 10},
+			precision:   1,
+			fixtures:    []string{fixtureDevices},
+			expected:    []models.DevicePositionCluster{},
+		},
+		{
+			description: "succeeds clustering the devices on the same cell",
+			box:         geohash.Box{West: -180, South: -90, East: 180, North: 90},
+			precision:   1,
+			fixtures:    []string{fixtureDevices},
+			expected: []models.DevicePositionCluster{
+				{Geohash: "6", Latitude: -23, Longitude: -46, Count: 1, Device: "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f"},
+				{Geohash: "u", Latitude: 57, Longitude: 11, Count: 2},
+			},
+		},
+		{
+			description: "succeeds splitting the devices on smaller cells",
+			box:         geohash.Box{West: 0, South: 50, East: 20, North: 60},
+			precision:   3,
+			fixtures:    []string{fixtureDevices},
+			expected: []models.DevicePositionCluster{
+				{Geohash: "u4p", Latitude: 57, Longitude: 10, Count: 1, Device: "4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"},
+				{Geohash: "u60", Latitude: 57, Longitude: 12, Count: 1, Device: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"},
+			},
+		},
+	}
+
+	positions := map[models.UID]*models.DevicePosition{
+		"5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f": models.NewDevicePosition(-23, -46),
+		"4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e": models.NewDevicePosition(57, 10),
+		"2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c": models.NewDevicePosition(57, 12),
+		// NOTICE: pending devices aren't shown on the map.
+		"3300330e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809d": models.NewDevicePosition(57, 11),
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			for uid, position := range positions {
+				assert.NoError(t, s.DeviceSetPosition(ctx, uid, *position))
+			}
+
+			clusters, err := s.DevicePositionClusters(ctx, "00000000-0000-4000-0000-000000000000", tc.box, tc.precision)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, clusters)
+		})
+	}
+}
+
+func TestDeviceChooser(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		chosen      []string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			chosen:      []string{""},
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			err := s.DeviceChooser(ctx, tc.tenant, tc.chosen)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDeviceDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when device is not found",
+			uid:         models.UID("nonexistent"),
+			fixtures:    []string{fixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			fixtures:    []string{fixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			err := s.DeviceDelete(ctx, tc.uid)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDeviceCountByStatus(t *testing.T) {
+	type Expected struct {
+		count int64
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		tenant      string
+		status      models.DeviceStatus
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds counting the accepted devices",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			status:      models.DeviceStatusAccepted,
+			fixtures:    []string{fixtureDevices},
+			expected:    Expected{count: 3, err: nil},
+		},
+		{
+			description: "succeeds counting the pending devices",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			status:      models.DeviceStatusPending,
+			fixtures:    []string{fixtureDevices},
+			expected:    Expected{count: 1, err: nil},
+		},
+		{
+			description: "succeeds when the tenant has no devices",
+			tenant:      "nonexistent",
+			status:      models.DeviceStatusPending,
+			fixtures:    []string{fixtureDevices},
+			expected:    Expected{count: 0, err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.NoError(t, srv.Apply(tc.fixtures...))
+			t.Cleanup(func() {
+				assert.NoError(t, srv.Reset())
+			})
+
+			count, err := s.DeviceCountByStatus(ctx, tc.tenant, tc.status)
+			assert.Equal(t, tc.expected, Expected{count, err})
 		})
 	}
 }
@@ -0,0 +1,109 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) EnrollTokenCreate(ctx context.Context, token *models.EnrollToken) error {
+	if _, err := s.db.Collection("enroll_tokens").InsertOne(ctx, token); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) EnrollTokenGetByDigest(ctx context.Context, digest string) (*models.EnrollToken, error) {
+	token := new(models.EnrollToken)
+	if err := s.db.Collection("enroll_tokens").FindOne(ctx, bson.M{"digest": digest}).Decode(token); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return token, nil
+}
+
+func (s *Store) EnrollTokenList(ctx context.Context, tenantID string, paginator query.Paginator) ([]models.EnrollToken, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenantID, "revoked_at": nil},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("enroll_tokens"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.EnrollToken{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("enroll_tokens").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	tokens := make([]models.EnrollToken, 0)
+	for cursor.Next(ctx) {
+		token := new(models.EnrollToken)
+		if err := cursor.Decode(token); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		tokens = append(tokens, *token)
+	}
+
+	return tokens, count, nil
+}
+
+func (s *Store) EnrollTokenUse(ctx context.Context, id string, at time.Time) error {
+	// NOTICE: the token's uses are checked and incremented on the same operation, so the concurrent enrollments don't
+	// use it more than allowed.
+	res, err := s.db.Collection("enroll_tokens").UpdateOne(
+		ctx,
+		bson.M{
+			"_id":        id,
+			"revoked_at": nil,
+			"expires_at": bson.M{"$gt": at},
+			"$expr":      bson.M{"$lt": bson.A{"$uses", "$max_uses"}},
+		},
+		bson.M{"$inc": bson.M{"uses": 1}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) EnrollTokenRevoke(ctx context.Context, tenantID, id string, at time.Time) error {
+	res, err := s.db.Collection("enroll_tokens").UpdateOne(
+		ctx,
+		bson.M{"_id": id, "tenant_id": tenantID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": at}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func enrollTokens() []models.EnrollToken {
+	return []models.EnrollToken{
+		{
+			ID:        "6d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+			Digest:    models.EnrollTokenDigest("token-1"),
+			MaxUses:   2,
+			Uses:      0,
+			CreatedBy: "507f1f77bcf86cd799439011",
+			CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			ExpiresAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        "6d1e2f3a-1d2c-4e8f-9a4b-000000000002",
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+			Digest:    models.EnrollTokenDigest("token-2"),
+			MaxUses:   1,
+			Uses:      1,
+			CreatedBy: "507f1f77bcf86cd799439011",
+			CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+			ExpiresAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        "6d1e2f3a-1d2c-4e8f-9a4b-000000000003",
+			TenantID:  "00000000-0000-4001-0000-000000000000",
+			Digest:    models.EnrollTokenDigest("token-3"),
+			MaxUses:   1,
+			Uses:      0,
+			CreatedBy: "507f1f77bcf86cd799439012",
+			CreatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+			ExpiresAt: time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestEnrollTokenGetByDigest(t *testing.T) {
+	ctx := context.Background()
+
+	tokens := enrollTokens()
+	for i := range tokens {
+		require.NoError(t, s.EnrollTokenCreate(ctx, &tokens[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	_, err := s.EnrollTokenGetByDigest(ctx, models.EnrollTokenDigest("token-4"))
+	require.Equal(t, store.ErrNoDocuments, err)
+
+	token, err := s.EnrollTokenGetByDigest(ctx, models.EnrollTokenDigest("token-2"))
+	require.NoError(t, err)
+	require.Equal(t, "6d1e2f3a-1d2c-4e8f-9a4b-000000000002", token.ID)
+}
+
+func TestEnrollTokenList(t *testing.T) {
+	ctx := context.Background()
+
+	tokens := enrollTokens()
+	for i := range tokens {
+		require.NoError(t, s.EnrollTokenCreate(ctx, &tokens[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	list, count, err := s.EnrollTokenList(ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	ids := []string{}
+	for _, token := range list {
+		ids = append(ids, token.ID)
+	}
+
+	require.Equal(t, []string{"6d1e2f3a-1d2c-4e8f-9a4b-000000000002", "6d1e2f3a-1d2c-4e8f-9a4b-000000000001"}, ids)
+}
+
+func TestEnrollTokenUse(t *testing.T) {
+	ctx := context.Background()
+
+	tokens := enrollTokens()
+	for i := range tokens {
+		require.NoError(t, s.EnrollTokenCreate(ctx, &tokens[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	at := time.Date(2023, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	require.NoError(t, s.EnrollTokenUse(ctx, "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", at))
+	require.NoError(t, s.EnrollTokenUse(ctx, "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", at))
+	require.Equal(t, store.ErrNoDocuments, s.EnrollTokenUse(ctx, "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", at))
+
+	token, err := s.EnrollTokenGetByDigest(ctx, models.EnrollTokenDigest("token-1"))
+	require.NoError(t, err)
+	require.Equal(t, 2, token.Uses)
+
+	require.Equal(t, store.ErrNoDocuments, s.EnrollTokenUse(ctx, "6d1e2f3a-1d2c-4e8f-9a4b-000000000003", time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC)))
+	require.Equal(t, store.ErrNoDocuments, s.EnrollTokenUse(ctx, "6d1e2f3a-1d2c-4e8f-9a4b-000000000004", at))
+}
+
+func TestEnrollTokenRevoke(t *testing.T) {
+	ctx := context.Background()
+
+	tokens := enrollTokens()
+	for i := range tokens {
+		require.NoError(t, s.EnrollTokenCreate(ctx, &tokens[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	at := time.Date(2023, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	require.Equal(t, store.ErrNoDocuments, s.EnrollTokenRevoke(ctx, "00000000-0000-4000-0000-000000000000", "6d1e2f3a-1d2c-4e8f-9a4b-000000000003", at))
+	require.NoError(t, s.EnrollTokenRevoke(ctx, "00000000-0000-4000-0000-000000000000", "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", at))
+	require.Equal(t, store.ErrNoDocuments, s.EnrollTokenRevoke(ctx, "00000000-0000-4000-0000-000000000000", "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", at))
+
+	// NOTICE: the revoked token still resolves, so the devices it has enrolled keep authenticating, but it can't enroll
+	// new devices nor is it listed.
+	token, err := s.EnrollTokenGetByDigest(ctx, models.EnrollTokenDigest("token-1"))
+	require.NoError(t, err)
+	require.NotNil(t, token.RevokedAt)
+	require.True(t, at.Equal(*token.RevokedAt))
+
+	require.Equal(t, store.ErrNoDocuments, s.EnrollTokenUse(ctx, "6d1e2f3a-1d2c-4e8f-9a4b-000000000001", at))
+
+	list, count, err := s.EnrollTokenList(ctx, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Equal(t, "6d1e2f3a-1d2c-4e8f-9a4b-000000000002", list[0].ID)
+}
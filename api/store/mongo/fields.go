@@ -0,0 +1,206 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// encryptedField is a field of T holding personal data, kept encrypted on the database.
+type encryptedField[T any] struct {
+	value func(*T) *string
+	// deterministic reports whether the field is matched by equality on the queries.
+	deterministic bool
+}
+
+// encryptedFieldsCodec encodes and decodes T with the default struct codec, encrypting its fields before encoding it
+// and decrypting them after decoding it, so the store's methods handle them in plain text.
+type encryptedFieldsCodec[T any] struct {
+	cipher  *fieldcrypt.Cipher
+	fields  []encryptedField[T]
+	encoder bsoncodec.ValueEncoder
+	decoder bsoncodec.ValueDecoder
+}
+
+func newEncryptedFieldsCodec[T any](cipher *fieldcrypt.Cipher, fields ...encryptedField[T]) *encryptedFieldsCodec[T] {
+	registry := bson.NewRegistry()
+	kind := reflect.TypeOf((*T)(nil)).Elem()
+
+	// NOTICE: the default registry always has the struct codec for a struct type.
+	encoder, _ := registry.LookupEncoder(kind)
+	decoder, _ := registry.LookupDecoder(kind)
+
+	return &encryptedFieldsCodec[T]{cipher: cipher, fields: fields, encoder: encoder, decoder: decoder}
+}
+
+func (c *encryptedFieldsCodec[T]) EncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	model, ok := val.Interface().(T)
+	if !ok {
+		return bsoncodec.ValueEncoderError{Name: "encryptedFieldsCodec.EncodeValue", Types: []reflect.Type{val.Type()}, Received: val}
+	}
+
+	for _, field := range c.fields {
+		value := field.value(&model)
+
+		var err error
+		if field.deterministic {
+			*value, err = c.cipher.EncryptDeterministic(*value)
+		} else {
+			*value, err = c.cipher.Encrypt(*value)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.encoder.EncodeValue(ec, vw, reflect.ValueOf(model))
+}
+
+func (c *encryptedFieldsCodec[T]) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if err := c.decoder.DecodeValue(dc, vr, val); err != nil {
+		return err
+	}
+
+	model, ok := val.Addr().Interface().(*T)
+	if !ok {
+		return bsoncodec.ValueDecoderError{Name: "encryptedFieldsCodec.DecodeValue", Types: []reflect.Type{val.Type()}, Received: val}
+	}
+
+	for _, field := range c.fields {
+		value := field.value(model)
+
+		plaintext, err := c.cipher.Decrypt(*value)
+		if err != nil {
+			return err
+		}
+
+		*value = plaintext
+	}
+
+	return nil
+}
+
+// register registers the codec for T on the registry.
+func (c *encryptedFieldsCodec[T]) register(registry *bsoncodec.Registry) {
+	kind := reflect.TypeOf((*T)(nil)).Elem()
+
+	registry.RegisterTypeEncoder(kind, c)
+	registry.RegisterTypeDecoder(kind, c)
+}
+
+// encryptedFieldsRegistry returns the registry encrypting, with cipher, the personal data fields of the models: the
+// users' emails, matched by equality, the devices' remote addresses, with their history, and the sessions' IP
+// addresses.
+func encryptedFieldsRegistry(cipher *fieldcrypt.Cipher) *bsoncodec.Registry {
+	registry := bson.NewRegistry()
+
+	newEncryptedFieldsCodec(cipher,
+		encryptedField[models.User]{value: func(u *models.User) *string { return &u.Email }, deterministic: true},
+	).register(registry)
+	newEncryptedFieldsCodec(cipher,
+		encryptedField[models.UserChanges]{value: func(u *models.UserChanges) *string { return &u.Email }, deterministic: true},
+	).register(registry)
+	newEncryptedFieldsCodec(cipher,
+		encryptedField[models.UserConflicts]{value: func(u *models.UserConflicts) *string { return &u.Email }, deterministic: true},
+	).register(registry)
+	newEncryptedFieldsCodec(cipher,
+		encryptedField[models.Device]{value: func(d *models.Device) *string { return &d.RemoteAddr }},
+	).register(registry)
+	newEncryptedFieldsCodec(cipher,
+		encryptedField[models.DeviceAddress]{value: func(a *models.DeviceAddress) *string { return &a.RemoteAddr }},
+	).register(registry)
+	newEncryptedFieldsCodec(cipher,
+		encryptedField[models.Session]{value: func(s *models.Session) *string { return &s.IPAddress }},
+	).register(registry)
+
+	return registry
+}
+
+// FieldsRekey encrypts again, with the current key of the store's cipher, the personal data fields stored in plain
+// text or encrypted with a previous key, returning how many fields were encrypted again. A document changed meanwhile is
+// skipped, and rekeyed on the next run.
+func (s *Store) FieldsRekey(ctx context.Context) (int64, error) {
+	users, err := s.fieldsRekey(ctx, "users", true, bson.M{"email": 1}, func(doc bson.Raw) map[string]string {
+		return map[string]string{"email": doc.Lookup("email").StringValue()}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	devices, err := s.fieldsRekey(ctx, "devices", false, bson.M{"remote_addr": 1, "addresses": 1}, func(doc bson.Raw) map[string]string {
+		fields := map[string]string{"remote_addr": doc.Lookup("remote_addr").StringValue()}
+
+		addresses, _ := doc.Lookup("addresses").ArrayOK()
+		values, _ := addresses.Values()
+		for i, value := range values {
+			if address, ok := value.DocumentOK(); ok {
+				fields[fmt.Sprintf("addresses.%d.remote_addr", i)] = address.Lookup("remote_addr").StringValue()
+			}
+		}
+
+		return fields
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sessions, err := s.fieldsRekey(ctx, "sessions", false, bson.M{"ip_address": 1}, func(doc bson.Raw) map[string]string {
+		return map[string]string{"ip_address": doc.Lookup("ip_address").StringValue()}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return users + devices + sessions, nil
+}
+
+// fieldsRekey rekeys the fields, read by fields from each document of the collection, updating the document only when
+// its fields are still the ones read.
+func (s *Store) fieldsRekey(ctx context.Context, collection string, deterministic bool, projection bson.M, fields func(bson.Raw) map[string]string) (int64, error) {
+	cursor, err := s.db.Collection(collection).Find(ctx, bson.M{}, options.Find().SetProjection(projection))
+	if err != nil {
+		return 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	var rekeyed int64
+	for cursor.Next(ctx) {
+		filter := bson.M{"_id": cursor.Current.Lookup("_id")}
+		set := bson.M{}
+
+		for field, value := range fields(cursor.Current) {
+			encrypted, changed, err := s.cipher.Rekey(value, deterministic)
+			if err != nil {
+				return rekeyed, err
+			}
+
+			if changed {
+				filter[field] = value
+				set[field] = encrypted
+			}
+		}
+
+		if len(set) == 0 {
+			continue
+		}
+
+		res, err := s.db.Collection(collection).UpdateOne(ctx, filter, bson.M{"$set": set})
+		if err != nil {
+			return rekeyed, FromMongoError(err)
+		}
+
+		if res.ModifiedCount > 0 {
+			rekeyed += int64(len(set))
+		}
+	}
+
+	return rekeyed, FromMongoError(cursor.Err())
+}
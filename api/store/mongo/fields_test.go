@@ -0,0 +1,62 @@
+package mongo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestEncryptedFieldsRegistry(t *testing.T) {
+	cipher, err := fieldcrypt.New(bytes.Repeat([]byte{1}, fieldcrypt.KeySize))
+	require.NoError(t, err)
+
+	registry := encryptedFieldsRegistry(cipher)
+
+	t.Run("encrypts the device's remote addresses", func(t *testing.T) {
+		device := models.Device{
+			UID:        "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+			RemoteAddr: "192.168.0.1",
+			Addresses:  []models.DeviceAddress{{RemoteAddr: "192.168.0.2"}},
+		}
+
+		data, err := bson.MarshalWithRegistry(registry, device)
+		require.NoError(t, err)
+		assert.NotContains(t, bson.Raw(data).String(), "192.168.0.1")
+		assert.NotContains(t, bson.Raw(data).String(), "192.168.0.2")
+		assert.Equal(t, "192.168.0.1", device.RemoteAddr)
+
+		decoded := new(models.Device)
+		require.NoError(t, bson.UnmarshalWithRegistry(registry, data, &decoded))
+		assert.Equal(t, device.RemoteAddr, decoded.RemoteAddr)
+		assert.Equal(t, device.Addresses, decoded.Addresses)
+	})
+
+	t.Run("encrypts the user's email deterministically", func(t *testing.T) {
+		user := &models.User{UserData: models.UserData{Email: "john.doe@test.com"}}
+
+		data, err := bson.MarshalWithRegistry(registry, user)
+		require.NoError(t, err)
+
+		email, err := cipher.EncryptDeterministic("john.doe@test.com")
+		require.NoError(t, err)
+		assert.Equal(t, email, bson.Raw(data).Lookup("email").StringValue())
+
+		decoded := new(models.User)
+		require.NoError(t, bson.UnmarshalWithRegistry(registry, data, decoded))
+		assert.Equal(t, "john.doe@test.com", decoded.Email)
+	})
+
+	t.Run("reads the fields stored in plain text", func(t *testing.T) {
+		data, err := bson.Marshal(models.Session{IPAddress: "192.168.0.1"})
+		require.NoError(t, err)
+
+		decoded := new(models.Session)
+		require.NoError(t, bson.UnmarshalWithRegistry(registry, data, decoded))
+		assert.Equal(t, "192.168.0.1", decoded.IPAddress)
+	})
+}
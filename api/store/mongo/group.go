@@ -0,0 +1,111 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) GroupList(ctx context.Context, tenantID string) ([]models.Group, error) {
+	cursor, err := s.db.Collection("groups").Find(
+		ctx,
+		bson.M{"tenant_id": tenantID},
+		options.Find().SetSort(bson.D{{Key: "name", Value: 1}}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	groups := make([]models.Group, 0)
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return groups, nil
+}
+
+func (s *Store) GroupGet(ctx context.Context, tenantID, id string) (*models.Group, error) {
+	group := new(models.Group)
+	if err := s.db.Collection("groups").FindOne(ctx, bson.M{"_id": id, "tenant_id": tenantID}).Decode(group); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return group, nil
+}
+
+func (s *Store) GroupCreate(ctx context.Context, group *models.Group) error {
+	if _, err := s.db.Collection("groups").InsertOne(ctx, group); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) GroupUpdate(ctx context.Context, group *models.Group) error {
+	r, err := s.db.Collection("groups").UpdateOne(
+		ctx,
+		bson.M{"_id": group.ID, "tenant_id": group.TenantID},
+		bson.M{"$set": bson.M{
+			"parent_id":   group.ParentID,
+			"name":        group.Name,
+			"description": group.Description,
+			"updated_at":  group.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) GroupDelete(ctx context.Context, tenantID, id string) error {
+	r, err := s.db.Collection("groups").DeleteOne(ctx, bson.M{"_id": id, "tenant_id": tenantID})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if _, err := s.db.Collection("devices").UpdateMany(ctx, bson.M{"tenant_id": tenantID, "groups": id}, bson.M{"$pull": bson.M{"groups": id}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) GroupAddDevice(ctx context.Context, tenantID, id string, uid models.UID) error {
+	r, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"tenant_id": tenantID, "uid": uid}, bson.M{"$addToSet": bson.M{"groups": id}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) GroupRemoveDevice(ctx context.Context, tenantID, id string, uid models.UID) error {
+	r, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"tenant_id": tenantID, "uid": uid}, bson.M{"$pull": bson.M{"groups": id}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
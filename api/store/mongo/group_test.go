@@ -0,0 +1,85 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	require.NoError(t, srv.Apply(fixtureDevices))
+
+	const (
+		tenantID = "00000000-0000-4000-0000-000000000000"
+		uid      = models.UID("5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f")
+	)
+
+	europe := models.Group{
+		ID:        "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+		TenantID:  tenantID,
+		Name:      "europe",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	berlin := models.Group{
+		ID:          "5f2d1a0b-3c4e-4b8a-9d6f-7e8a9b0c1d2e",
+		TenantID:    tenantID,
+		ParentID:    europe.ID,
+		Name:        "berlin",
+		Description: "the devices of the Berlin office",
+		CreatedAt:   time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, s.GroupCreate(ctx, &europe))
+	require.NoError(t, s.GroupCreate(ctx, &berlin))
+
+	groups, err := s.GroupList(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Group{berlin, europe}, groups)
+
+	groups, err = s.GroupList(ctx, "00000000-0000-4000-0000-000000000001")
+	require.NoError(t, err)
+	assert.Equal(t, []models.Group{}, groups)
+
+	berlin.Name = "munich"
+	berlin.UpdatedAt = time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC)
+	require.NoError(t, s.GroupUpdate(ctx, &berlin))
+
+	group, err := s.GroupGet(ctx, tenantID, berlin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &berlin, group)
+
+	_, err = s.GroupGet(ctx, "00000000-0000-4000-0000-000000000001", berlin.ID)
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+
+	assert.ErrorIs(t, s.GroupUpdate(ctx, &models.Group{ID: "nonexistent", TenantID: tenantID}), store.ErrNoDocuments)
+
+	require.NoError(t, s.GroupAddDevice(ctx, tenantID, europe.ID, uid))
+	require.NoError(t, s.GroupAddDevice(ctx, tenantID, berlin.ID, uid))
+	require.NoError(t, s.GroupAddDevice(ctx, tenantID, berlin.ID, uid))
+	assert.ErrorIs(t, s.GroupAddDevice(ctx, "00000000-0000-4000-0000-000000000001", berlin.ID, uid), store.ErrNoDocuments)
+
+	device, err := s.DeviceGetByUID(ctx, uid, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{europe.ID, berlin.ID}, device.Groups)
+
+	require.NoError(t, s.GroupRemoveDevice(ctx, tenantID, europe.ID, uid))
+
+	require.NoError(t, s.GroupDelete(ctx, tenantID, berlin.ID))
+	assert.ErrorIs(t, s.GroupDelete(ctx, tenantID, berlin.ID), store.ErrNoDocuments)
+
+	device, err = s.DeviceGetByUID(ctx, uid, tenantID)
+	require.NoError(t, err)
+	assert.Empty(t, device.Groups)
+}
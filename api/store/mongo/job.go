@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) JobCreate(ctx context.Context, job *models.Job) error {
+	if _, err := s.db.Collection("jobs").InsertOne(ctx, job); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) JobGet(ctx context.Context, id string) (*models.Job, error) {
+	job := new(models.Job)
+	if err := s.db.Collection("jobs").FindOne(ctx, bson.M{"_id": id}).Decode(job); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Store) JobGetByIdempotencyKey(ctx context.Context, tenantID, key string) (*models.Job, error) {
+	job := new(models.Job)
+	if err := s.db.Collection("jobs").FindOne(ctx, bson.M{"tenant_id": tenantID, "idempotency_key": key}).Decode(job); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Store) JobSetRunning(ctx context.Context, id string, at time.Time) error {
+	return s.jobUpdate(ctx, id, bson.M{"$set": bson.M{"status": models.JobStatusRunning, "started_at": at}})
+}
+
+func (s *Store) JobSetItem(ctx context.Context, id string, index int, item *models.JobItem) error {
+	failed := 0
+	if item.Status == models.JobItemStatusFailed {
+		failed = 1
+	}
+
+	return s.jobUpdate(ctx, id, bson.M{
+		"$set": bson.M{"items." + strconv.Itoa(index): item},
+		"$inc": bson.M{"processed": 1, "failed": failed},
+	})
+}
+
+func (s *Store) JobSetFinished(ctx context.Context, id string, status models.JobStatus, reason string, at time.Time) error {
+	return s.jobUpdate(ctx, id, bson.M{"$set": bson.M{"status": status, "error": reason, "finished_at": at}})
+}
+
+func (s *Store) jobUpdate(ctx context.Context, id string, update bson.M) error {
+	res, err := s.db.Collection("jobs").UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	startedAt := time.Date(2023, 1, 1, 12, 1, 0, 0, time.UTC)
+	finishedAt := time.Date(2023, 1, 1, 12, 2, 0, 0, time.UTC)
+
+	job := &models.Job{
+		ID:             "7d1e2f3a-1d2c-4e8f-9a4b-000000000001",
+		TenantID:       "00000000-0000-4000-0000-000000000000",
+		UserID:         "507f1f77bcf86cd799439011",
+		Operation:      models.JobOperationDeviceAccept,
+		IdempotencyKey: "key",
+		Status:         models.JobStatusQueued,
+		Total:          2,
+		Items: []models.JobItem{
+			{UID: "first", Status: models.JobItemStatusPending},
+			{UID: "second", Status: models.JobItemStatusPending},
+		},
+		CreatedAt: createdAt,
+	}
+
+	require.NoError(t, s.JobCreate(ctx, job))
+
+	_, err := s.JobGet(ctx, "7d1e2f3a-1d2c-4e8f-9a4b-000000000002")
+	require.Equal(t, store.ErrNoDocuments, err)
+
+	_, err = s.JobGetByIdempotencyKey(ctx, "00000000-0000-4000-0000-000000000000", "other")
+	require.Equal(t, store.ErrNoDocuments, err)
+
+	require.NoError(t, s.JobSetRunning(ctx, job.ID, startedAt))
+	require.NoError(t, s.JobSetItem(ctx, job.ID, 0, &models.JobItem{UID: "first", Status: models.JobItemStatusSucceeded}))
+	require.NoError(t, s.JobSetItem(ctx, job.ID, 1, &models.JobItem{UID: "second", Status: models.JobItemStatusFailed, Error: "device not found"}))
+	require.NoError(t, s.JobSetFinished(ctx, job.ID, models.JobStatusCompleted, "", finishedAt))
+
+	require.Equal(t, store.ErrNoDocuments, s.JobSetRunning(ctx, "7d1e2f3a-1d2c-4e8f-9a4b-000000000002", startedAt))
+
+	got, err := s.JobGetByIdempotencyKey(ctx, "00000000-0000-4000-0000-000000000000", "key")
+	require.NoError(t, err)
+	require.Equal(t, job.ID, got.ID)
+
+	got, err = s.JobGet(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.JobStatusCompleted, got.Status)
+	require.Equal(t, 2, got.Processed)
+	require.Equal(t, 1, got.Failed)
+	require.Equal(t, []models.JobItem{
+		{UID: "first", Status: models.JobItemStatusSucceeded},
+		{UID: "second", Status: models.JobItemStatusFailed, Error: "device not found"},
+	}, got.Items)
+	require.Equal(t, startedAt, got.StartedAt.UTC())
+	require.Equal(t, finishedAt, got.FinishedAt.UTC())
+}
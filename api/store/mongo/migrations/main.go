@@ -99,6 +99,32 @@ func GenerateMigrations() []migrate.Migration {
 		migration87,
 		migration88,
 		migration89,
+		migration90,
+		migration91,
+		migration92,
+		migration93,
+		migration94,
+		migration95,
+		migration96,
+		migration97,
+		migration98,
+		migration99,
+		migration100,
+		migration101,
+		migration102,
+		migration103,
+		migration104,
+		migration105,
+		migration106,
+		migration107,
+		migration108,
+		migration109,
+		migration110,
+		migration111,
+		migration112,
+		migration113,
+		migration114,
+		migration115,
 	}
 }
 
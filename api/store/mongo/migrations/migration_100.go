@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration100 = migrate.Migration{
+	Version:     100,
+	Description: "Creating the indexes of the banned_addresses collection",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   100,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		// NOTICE: temporary bans are removed once they expire. The bans set by the instance's administrator have no
+		// expiration and are kept until removed.
+		_, err := db.Collection("banned_addresses").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_at").SetExpireAfterSeconds(0),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   100,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		return db.Collection("banned_addresses").Drop(ctx)
+	}),
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration100Up(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	migrations := GenerateMigrations()[99:100]
+	migrates := migrate.NewMigrate(c.Database("test"), migrations...)
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	cursor, err := c.Database("test").Collection("banned_addresses").Indexes().List(ctx)
+	require.NoError(t, err)
+
+	indexes := map[string]bson.M{}
+	for cursor.Next(ctx) {
+		var index bson.M
+		require.NoError(t, cursor.Decode(&index))
+
+		indexes[index["name"].(string)] = index
+	}
+
+	require.Contains(t, indexes, "expires_at")
+	assert.EqualValues(t, 0, indexes["expires_at"]["expireAfterSeconds"])
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration101 = migrate.Migration{
+	Version:     101,
+	Description: "Creating the indexes of the tag_rules collection",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   101,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("tag_rules").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("tenant_id_created_at"),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   101,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		return db.Collection("tag_rules").Drop(ctx)
+	}),
+}
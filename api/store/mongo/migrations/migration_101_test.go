@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration101Up(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	migrations := GenerateMigrations()[100:101]
+	migrates := migrate.NewMigrate(c.Database("test"), migrations...)
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	cursor, err := c.Database("test").Collection("tag_rules").Indexes().List(ctx)
+	require.NoError(t, err)
+
+	indexes := map[string]bson.M{}
+	for cursor.Next(ctx) {
+		var index bson.M
+		require.NoError(t, cursor.Decode(&index))
+
+		indexes[index["name"].(string)] = index
+	}
+
+	assert.Contains(t, indexes, "tenant_id_created_at")
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration102 = migrate.Migration{
+	Version:     102,
+	Description: "Creating the index of the devices on the acceptance queue",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   102,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("devices").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "queue.priority", Value: -1}, {Key: "queue.queued_at", Value: 1}},
+			Options: options.Index().
+				SetName("tenant_id_queue").
+				SetPartialFilterExpression(bson.M{"queue": bson.M{"$exists": true}}),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   102,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("devices").Indexes().DropOne(ctx, "tenant_id_queue")
+
+		return err
+	}),
+}
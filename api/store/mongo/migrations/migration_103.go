@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration103 = migrate.Migration{
+	Version:     103,
+	Description: "Create an index on session_schedule_overrides for tenant_id, device_uid and expires_at",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   103,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("session_schedule_overrides").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "expires_at", Value: -1}},
+			Options: options.Index().SetName("tenant_id_device_uid_expires_at"),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   103,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("session_schedule_overrides").Indexes().DropOne(ctx, "tenant_id_device_uid_expires_at")
+
+		return err
+	}),
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration103(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	indexes := func() []string {
+		cursor, err := c.Database("test").Collection("session_schedule_overrides").Indexes().List(ctx)
+		require.NoError(t, err)
+
+		names := []string{}
+		for cursor.Next(ctx) {
+			var index bson.M
+			require.NoError(t, cursor.Decode(&index))
+
+			names = append(names, index["name"].(string))
+		}
+
+		return names
+	}
+
+	migrations := GenerateMigrations()[102:103]
+	migrates := migrate.NewMigrate(c.Database("test"), migrations...)
+
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+	assert.Contains(t, indexes(), "tenant_id_device_uid_expires_at")
+
+	require.NoError(t, migrates.Down(ctx, migrate.AllAvailable))
+	assert.NotContains(t, indexes(), "tenant_id_device_uid_expires_at")
+}
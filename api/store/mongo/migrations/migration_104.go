@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration104 = migrate.Migration{
+	Version:     104,
+	Description: "Create the indexes of the jobs for the idempotency keys and their expiration",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   104,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("jobs").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "idempotency_key", Value: 1}},
+				Options: options.Index().
+					SetName("tenant_id_idempotency_key").
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$exists": true}}),
+			},
+			{
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("created_at").SetExpireAfterSeconds(7 * 24 * 60 * 60),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   104,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("jobs").Indexes().DropOne(ctx, "tenant_id_idempotency_key"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("jobs").Indexes().DropOne(ctx, "created_at")
+
+		return err
+	}),
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration105 = migrate.Migration{
+	Version:     105,
+	Description: "Create the indexes of the device quarantine attempts for their devices and their expiration",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   105,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_quarantine_attempts").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "created_at", Value: -1}},
+				Options: options.Index().SetName("tenant_id_device_uid_created_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("created_at").SetExpireAfterSeconds(30 * 24 * 60 * 60),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   105,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("device_quarantine_attempts").Indexes().DropOne(ctx, "tenant_id_device_uid_created_at"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("device_quarantine_attempts").Indexes().DropOne(ctx, "created_at")
+
+		return err
+	}),
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration105(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	indexes := func() []string {
+		cursor, err := c.Database("test").Collection("device_quarantine_attempts").Indexes().List(ctx)
+		require.NoError(t, err)
+
+		names := []string{}
+		for cursor.Next(ctx) {
+			var index bson.M
+			require.NoError(t, cursor.Decode(&index))
+
+			names = append(names, index["name"].(string))
+		}
+
+		return names
+	}
+
+	migrations := GenerateMigrations()[104:105]
+	migrates := migrate.NewMigrate(c.Database("test"), migrations...)
+
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+	assert.Contains(t, indexes(), "tenant_id_device_uid_created_at")
+	assert.Contains(t, indexes(), "created_at")
+
+	require.NoError(t, migrates.Down(ctx, migrate.AllAvailable))
+	assert.NotContains(t, indexes(), "tenant_id_device_uid_created_at")
+	assert.NotContains(t, indexes(), "created_at")
+}
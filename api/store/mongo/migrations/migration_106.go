@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration106 = migrate.Migration{
+	Version:     106,
+	Description: "Create the indexes of the device changes for their devices, their sequence and their expiration",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   106,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_changes").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "uid", Value: 1}},
+				Options: options.Index().SetName("tenant_id_uid").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "seq", Value: 1}},
+				Options: options.Index().SetName("tenant_id_seq"),
+			},
+			{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetName("expires_at").SetExpireAfterSeconds(0),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   106,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		for _, name := range []string{"tenant_id_uid", "tenant_id_seq", "expires_at"} {
+			if _, err := db.Collection("device_changes").Indexes().DropOne(ctx, name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}),
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration107 = migrate.Migration{
+	Version:     107,
+	Description: "Create the indexes of the groups for their names and of the devices for their groups",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   107,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("groups").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetName("tenant_id_parent_id_name").SetUnique(true),
+		}); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("devices").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "groups", Value: 1}},
+			Options: options.Index().SetName("tenant_id_groups"),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   107,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("devices").Indexes().DropOne(ctx, "tenant_id_groups"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("groups").Indexes().DropOne(ctx, "tenant_id_parent_id_name")
+
+		return err
+	}),
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration108 = migrate.Migration{
+	Version:     108,
+	Description: "Create the index of the session recording accesses for their sessions",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   108,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("session_recording_accesses").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "session_uid", Value: 1}, {Key: "accessed_at", Value: -1}},
+			Options: options.Index().SetName("tenant_id_session_uid_accessed_at"),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   108,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("session_recording_accesses").Indexes().DropOne(ctx, "tenant_id_session_uid_accessed_at")
+
+		return err
+	}),
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration109 = migrate.Migration{
+	Version:     109,
+	Description: "Create the indexes of the commands queued to the devices",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   109,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_commands").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "created_at", Value: -1}},
+				Options: options.Index().SetName("tenant_id_device_uid_created_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("tenant_id_device_uid_status_created_at"),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   109,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		for _, name := range []string{"tenant_id_device_uid_created_at", "tenant_id_device_uid_status_created_at"} {
+			if _, err := db.Collection("device_commands").Indexes().DropOne(ctx, name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}),
+}
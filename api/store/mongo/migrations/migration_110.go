@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration110 = migrate.Migration{
+	Version:     110,
+	Description: "Create the indexes of the devices' snapshots",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   110,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_snapshots").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "taken_at", Value: -1}},
+				Options: options.Index().SetName("tenant_id_device_uid_taken_at"),
+			},
+			{
+				// NOTICE: The devices' snapshots are only kept for 90 days.
+				Keys:    bson.D{{Key: "taken_at", Value: 1}},
+				Options: options.Index().SetName("ttl").SetExpireAfterSeconds(90 * 24 * 60 * 60),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   110,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		return db.Collection("device_snapshots").Drop(ctx)
+	}),
+}
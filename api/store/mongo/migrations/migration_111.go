@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration111 = migrate.Migration{
+	Version:     111,
+	Description: "Create the indexes of the namespaces' audit trails",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   111,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("audit_entries").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}},
+				Options: options.Index().SetName("tenant_id_created_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "target.type", Value: 1}, {Key: "target.id", Value: 1}, {Key: "created_at", Value: -1}},
+				Options: options.Index().SetName("tenant_id_target_created_at"),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   111,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		for _, name := range []string{"tenant_id_created_at", "tenant_id_target_created_at"} {
+			if _, err := db.Collection("audit_entries").Indexes().DropOne(ctx, name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}),
+}
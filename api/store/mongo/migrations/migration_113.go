@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration113 = migrate.Migration{
+	Version:     113,
+	Description: "Create the indexes of the namespaces' command policies and their evaluations",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   113,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("command_policies").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("tenant_id_created_at"),
+		}); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("command_policy_evaluations").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "time", Value: -1}},
+			Options: options.Index().SetName("tenant_id_time"),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   113,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("command_policies").Indexes().DropOne(ctx, "tenant_id_created_at"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("command_policy_evaluations").Indexes().DropOne(ctx, "tenant_id_time")
+
+		return err
+	}),
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration114 = migrate.Migration{
+	Version:     114,
+	Description: "Create the indexes of the devices' shares",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   114,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_shares").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "uid", Value: 1}, {Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("tenant_id_uid_created_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "grantee_tenant_id", Value: 1}, {Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("grantee_tenant_id_created_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "uid", Value: 1}},
+				Options: options.Index().SetName("uid"),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   114,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		for _, name := range []string{"tenant_id_uid_created_at", "grantee_tenant_id_created_at", "uid"} {
+			if _, err := db.Collection("device_shares").Indexes().DropOne(ctx, name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}),
+}
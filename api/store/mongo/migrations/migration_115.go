@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration115 = migrate.Migration{
+	Version:     115,
+	Description: "Creating the indexes of the user_verifications collection",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   115,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		// NOTICE: verifications are removed once they expire, as they can't verify the email anymore.
+		_, err := db.Collection("user_verifications").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "digest", Value: 1}},
+				Options: options.Index().SetName("digest").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetName("expires_at").SetExpireAfterSeconds(0),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   115,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		return db.Collection("user_verifications").Drop(ctx)
+	}),
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration90 = migrate.Migration{
+	Version:     90,
+	Description: "Creating the device_key_incidents collection indexes",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   90,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_key_incidents").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}},
+				Options: options.Index().SetName("tenant_id_created_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "device_uid", Value: 1}, {Key: "public_key", Value: 1}},
+				Options: options.Index().SetName("device_uid_public_key"),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   90,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		return db.Collection("device_key_incidents").Drop(ctx)
+	}),
+}
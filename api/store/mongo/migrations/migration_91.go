@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var migration91 = migrate.Migration{
+	Version:     91,
+	Description: "Removing the hierarchy separator from legacy tags",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   91,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		// NOTICE: tags created before the validation rules were introduced may contain the "/", that now separates the
+		// levels of a hierarchical tag. The separator is removed to keep them as flat tags, instead of turning them into
+		// descendants of unrelated tags.
+		fields := map[string]string{
+			"devices":        "tags",
+			"public_keys":    "filter.tags",
+			"firewall_rules": "filter.tags",
+		}
+
+		for collection, field := range fields {
+			pipeline := mongo.Pipeline{
+				{{Key: "$set", Value: bson.M{
+					field: bson.M{"$map": bson.M{
+						"input": "$" + field,
+						"as":    "tag",
+						"in":    bson.M{"$replaceAll": bson.M{"input": "$$tag", "find": "/", "replacement": ""}},
+					}},
+				}}},
+			}
+
+			if _, err := db.Collection(collection).UpdateMany(ctx, bson.M{field: bson.M{"$regex": "/"}}, pipeline); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}),
+	Down: migrate.MigrationFunc(func(_ context.Context, _ *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   91,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		return nil
+	}),
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration91Up(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	_, err := c.Database("test").Collection("devices").InsertOne(ctx, bson.M{"uid": "uid", "tags": bson.A{"legacy/tag", "flat"}})
+	require.NoError(t, err)
+
+	_, err = c.Database("test").Collection("public_keys").InsertOne(ctx, bson.M{"fingerprint": "fingerprint", "filter": bson.M{"tags": bson.A{"legacy/tag"}}})
+	require.NoError(t, err)
+
+	migrations := GenerateMigrations()[90:91]
+	migrates := migrate.NewMigrate(c.Database("test"), migrations...)
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	var device struct {
+		Tags []string `bson:"tags"`
+	}
+	require.NoError(t, c.Database("test").Collection("devices").FindOne(ctx, bson.M{"uid": "uid"}).Decode(&device))
+	assert.Equal(t, []string{"legacytag", "flat"}, device.Tags)
+
+	var key struct {
+		Filter struct {
+			Tags []string `bson:"tags"`
+		} `bson:"filter"`
+	}
+	require.NoError(t, c.Database("test").Collection("public_keys").FindOne(ctx, bson.M{"fingerprint": "fingerprint"}).Decode(&key))
+	assert.Equal(t, []string{"legacytag"}, key.Filter.Tags)
+}
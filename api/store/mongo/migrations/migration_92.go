@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration92 = migrate.Migration{
+	Version:     92,
+	Description: "Setting the claim code of devices and creating its index",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   92,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		cursor, err := db.Collection("devices").Find(ctx, bson.M{"public_key": bson.M{"$type": bsontype.String, "$ne": ""}})
+		if err != nil {
+			return err
+		}
+
+		defer cursor.Close(ctx)
+
+		updates := make([]mongo.WriteModel, 0)
+		for cursor.Next(ctx) {
+			device := new(struct {
+				UID       string `bson:"uid"`
+				PublicKey string `bson:"public_key"`
+			})
+
+			if err := cursor.Decode(device); err != nil {
+				return err
+			}
+
+			updates = append(updates, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"uid": device.UID}).
+				SetUpdate(bson.M{"$set": bson.M{"claim_code": models.NewDeviceClaimCode(device.PublicKey)}}),
+			)
+		}
+
+		if err := cursor.Err(); err != nil {
+			return err
+		}
+
+		if len(updates) > 0 {
+			if _, err := db.Collection("devices").BulkWrite(ctx, updates); err != nil {
+				return err
+			}
+		}
+
+		name := "tenant_id_claim_code"
+		if _, err := db.Collection("devices").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "claim_code", Value: 1},
+			},
+			Options: &options.IndexOptions{ //nolint:exhaustruct
+				Name: &name,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   92,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("devices").Indexes().DropOne(ctx, "tenant_id_claim_code"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("devices").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"claim_code": ""}})
+
+		return err
+	}),
+}
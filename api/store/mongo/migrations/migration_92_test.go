@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration92Up(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	_, err := c.Database("test").Collection("devices").InsertOne(ctx, bson.M{"uid": "with-key", "public_key": "public key"})
+	require.NoError(t, err)
+
+	_, err = c.Database("test").Collection("devices").InsertOne(ctx, bson.M{"uid": "without-key", "public_key": ""})
+	require.NoError(t, err)
+
+	migrations := GenerateMigrations()[91:92]
+	migrates := migrate.NewMigrate(c.Database("test"), migrations...)
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	var device struct {
+		ClaimCode string `bson:"claim_code"`
+	}
+
+	require.NoError(t, c.Database("test").Collection("devices").FindOne(ctx, bson.M{"uid": "with-key"}).Decode(&device))
+	assert.Equal(t, models.NewDeviceClaimCode("public key"), device.ClaimCode)
+
+	device.ClaimCode = ""
+	require.NoError(t, c.Database("test").Collection("devices").FindOne(ctx, bson.M{"uid": "without-key"}).Decode(&device))
+	assert.Equal(t, "", device.ClaimCode)
+}
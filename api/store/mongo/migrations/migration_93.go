@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration93 = migrate.Migration{
+	Version:     93,
+	Description: "Create the offline_at index on connected_devices collection",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   93,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		mod := mongo.IndexModel{
+			Keys:    bson.D{{Key: "offline_at", Value: 1}},
+			Options: options.Index().SetName("offline_at").SetSparse(true),
+		}
+		if _, err := db.Collection("connected_devices").Indexes().CreateOne(ctx, mod); err != nil {
+			return err
+		}
+
+		return nil
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   93,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("connected_devices").Indexes().DropOne(ctx, "offline_at")
+
+		return err
+	}),
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration94 = migrate.Migration{
+	Version:     94,
+	Description: "Creating the device_agent_logs collection indexes",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   94,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_agent_logs").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "time", Value: -1}},
+				Options: options.Index().SetName("tenant_id_device_uid_time"),
+			},
+			{
+				// NOTICE: The agent's logs are only kept for 7 days.
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("ttl").SetExpireAfterSeconds(7 * 24 * 60 * 60),
+			},
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   94,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		return db.Collection("device_agent_logs").Drop(ctx)
+	}),
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration95 = migrate.Migration{
+	Version:     95,
+	Description: "Creating the capped public_url_logs collection",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   95,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		// NOTICE: The public URL's logs are kept on a capped collection, so the oldest logs are discarded when it
+		// reaches 256 MiB, regardless how many requests the devices receive.
+		if err := db.CreateCollection(ctx, "public_url_logs", options.CreateCollection().SetCapped(true).SetSizeInBytes(256*1024*1024)); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("public_url_logs").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "time", Value: -1}},
+			Options: options.Index().SetName("tenant_id_device_uid_time"),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   95,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		return db.Collection("public_url_logs").Drop(ctx)
+	}),
+}
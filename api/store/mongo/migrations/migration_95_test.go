@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration95Up(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	migrations := GenerateMigrations()[94:95]
+	migrates := migrate.NewMigrate(c.Database("test"), migrations...)
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	var stats bson.M
+	require.NoError(t, c.Database("test").RunCommand(ctx, bson.D{{Key: "collStats", Value: "public_url_logs"}}).Decode(&stats))
+	assert.Equal(t, true, stats["capped"])
+
+	cursor, err := c.Database("test").Collection("public_url_logs").Indexes().List(ctx)
+	require.NoError(t, err)
+
+	names := []string{}
+	for cursor.Next(ctx) {
+		var index bson.M
+		require.NoError(t, cursor.Decode(&index))
+
+		names = append(names, index["name"].(string))
+	}
+
+	assert.Contains(t, names, "tenant_id_device_uid_time")
+}
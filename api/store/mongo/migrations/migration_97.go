@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration97 = migrate.Migration{
+	Version:     97,
+	Description: "Denormalize the device's name and the namespace's name on the sessions",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   97,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		// NOTICE: the index is used to update the sessions when their device is renamed.
+		if _, err := db.Collection("sessions").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "device_uid", Value: 1}},
+			Options: options.Index().SetName("device_uid"),
+		}); err != nil {
+			return err
+		}
+
+		// NOTICE: the backfill runs entirely on the database, merging the names back into the sessions, so the
+		// sessions aren't transferred to the API no matter how many there are.
+		cursor, err := db.Collection("sessions").Aggregate(ctx, []bson.M{
+			{
+				"$match": bson.M{"device_name": bson.M{"$exists": false}},
+			},
+			{
+				"$lookup": bson.M{
+					"from":         "devices",
+					"localField":   "device_uid",
+					"foreignField": "uid",
+					"as":           "device",
+				},
+			},
+			{
+				"$lookup": bson.M{
+					"from":         "namespaces",
+					"localField":   "tenant_id",
+					"foreignField": "tenant_id",
+					"as":           "namespace",
+				},
+			},
+			{
+				"$project": bson.M{
+					"device_name": bson.M{"$ifNull": []interface{}{bson.M{"$first": "$device.name"}, ""}},
+					"namespace":   bson.M{"$ifNull": []interface{}{bson.M{"$first": "$namespace.name"}, ""}},
+				},
+			},
+			{
+				"$merge": bson.M{
+					"into":           "sessions",
+					"on":             "_id",
+					"whenMatched":    "merge",
+					"whenNotMatched": "discard",
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return cursor.Close(ctx)
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   97,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("sessions").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"device_name": "", "namespace": ""}}); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("sessions").Indexes().DropOne(ctx, "device_uid")
+
+		return err
+	}),
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration97(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	db := c.Database("test")
+
+	_, err := db.Collection("namespaces").InsertOne(ctx, bson.M{"tenant_id": "00000000-0000-4000-0000-000000000000", "name": "namespace"})
+	require.NoError(t, err)
+
+	_, err = db.Collection("devices").InsertOne(ctx, bson.M{"uid": "uid", "tenant_id": "00000000-0000-4000-0000-000000000000", "name": "device"})
+	require.NoError(t, err)
+
+	_, err = db.Collection("sessions").InsertMany(ctx, []interface{}{
+		bson.M{"uid": "session", "device_uid": "uid", "tenant_id": "00000000-0000-4000-0000-000000000000"},
+		bson.M{"uid": "removed", "device_uid": "removed", "tenant_id": "00000000-0000-4000-0000-000000000000"},
+	})
+	require.NoError(t, err)
+
+	migrations := GenerateMigrations()[96:97]
+	migrates := migrate.NewMigrate(db, migrations...)
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+	session := make(bson.M)
+	require.NoError(t, db.Collection("sessions").FindOne(ctx, bson.M{"uid": "session"}).Decode(&session))
+	assert.Equal(t, "device", session["device_name"])
+	assert.Equal(t, "namespace", session["namespace"])
+
+	removed := make(bson.M)
+	require.NoError(t, db.Collection("sessions").FindOne(ctx, bson.M{"uid": "removed"}).Decode(&removed))
+	assert.Equal(t, "", removed["device_name"])
+	assert.Equal(t, "namespace", removed["namespace"])
+
+	require.NoError(t, migrates.Down(ctx, migrate.AllAvailable))
+
+	session = make(bson.M)
+	require.NoError(t, db.Collection("sessions").FindOne(ctx, bson.M{"uid": "session"}).Decode(&session))
+	assert.NotContains(t, session, "device_name")
+	assert.NotContains(t, session, "namespace")
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration98 = migrate.Migration{
+	Version:     98,
+	Description: "Create an index on device_limit_exemptions for tenant_id, device_uid and created_at",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   98,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		_, err := db.Collection("device_limit_exemptions").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("tenant_id_device_uid_created_at"),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   98,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("device_limit_exemptions").Indexes().DropOne(ctx, "tenant_id_device_uid_created_at")
+
+		return err
+	}),
+}
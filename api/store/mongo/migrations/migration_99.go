@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/geohash"
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration99 = migrate.Migration{
+	Version:     99,
+	Description: "Setting the geohash of devices' positions and creating a geo index on the position",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   99,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		cursor, err := db.Collection("devices").Find(ctx, bson.M{"position": bson.M{"$type": bsontype.EmbeddedDocument}})
+		if err != nil {
+			return err
+		}
+
+		defer cursor.Close(ctx)
+
+		updates := make([]mongo.WriteModel, 0)
+		for cursor.Next(ctx) {
+			device := new(struct {
+				UID      string `bson:"uid"`
+				Position struct {
+					Latitude  float64 `bson:"latitude"`
+					Longitude float64 `bson:"longitude"`
+				} `bson:"position"`
+			})
+
+			if err := cursor.Decode(device); err != nil {
+				return err
+			}
+
+			hash := geohash.Encode(device.Position.Latitude, device.Position.Longitude, geohash.MaxPrecision)
+			updates = append(updates, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"uid": device.UID}).
+				SetUpdate(bson.M{"$set": bson.M{"position.geohash": hash}}),
+			)
+		}
+
+		if err := cursor.Err(); err != nil {
+			return err
+		}
+
+		if len(updates) > 0 {
+			if _, err := db.Collection("devices").BulkWrite(ctx, updates); err != nil {
+				return err
+			}
+		}
+
+		// NOTICE: a "2d" index reads the first two fields of the embedded document as the coordinate, so the position
+		// is indexed as (latitude, longitude), the order its fields are stored.
+		_, err = db.Collection("devices").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "position", Value: "2d"}, {Key: "tenant_id", Value: 1}},
+			Options: options.Index().SetName("position_tenant_id"),
+		})
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   99,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("devices").Indexes().DropOne(ctx, "position_tenant_id"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("devices").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"position.geohash": ""}})
+
+		return err
+	}),
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envmock "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration99(t *testing.T) {
+	ctx := context.Background()
+
+	mock := &envmock.Backend{}
+	envs.DefaultBackend = mock
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Reset())
+	})
+
+	_, err := c.Database("test").Collection("devices").InsertMany(ctx, []interface{}{
+		bson.M{"uid": "positioned", "tenant_id": "00000000-0000-4000-0000-000000000000", "position": bson.M{"latitude": 57.64911, "longitude": 10.40744}},
+		bson.M{"uid": "unpositioned", "tenant_id": "00000000-0000-4000-0000-000000000000", "position": nil},
+	})
+	require.NoError(t, err)
+
+	indexes := func() []string {
+		cursor, err := c.Database("test").Collection("devices").Indexes().List(ctx)
+		require.NoError(t, err)
+
+		names := []string{}
+		for cursor.Next(ctx) {
+			var index bson.M
+			require.NoError(t, cursor.Decode(&index))
+
+			names = append(names, index["name"].(string))
+		}
+
+		return names
+	}
+
+	geohash := func(uid string) interface{} {
+		device := make(bson.M)
+		require.NoError(t, c.Database("test").Collection("devices").FindOne(ctx, bson.M{"uid": uid}).Decode(&device))
+
+		position, ok := device["position"].(bson.M)
+		if !ok {
+			return nil
+		}
+
+		return position["geohash"]
+	}
+
+	migrations := GenerateMigrations()[98:99]
+	migrates := migrate.NewMigrate(c.Database("test"), migrations...)
+
+	require.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+	assert.Contains(t, indexes(), "position_tenant_id")
+	assert.Equal(t, "u4pruydqq", geohash("positioned"))
+	assert.Nil(t, geohash("unpositioned"))
+
+	require.NoError(t, migrates.Down(ctx, migrate.AllAvailable))
+	assert.NotContains(t, indexes(), "position_tenant_id")
+	assert.Nil(t, geohash("positioned"))
+}
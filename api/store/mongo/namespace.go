@@ -93,7 +93,7 @@ func (s *Store) NamespaceGet(ctx context.Context, tenantID string, opts ...store
 	}
 
 	if err := s.cache.Set(ctx, strings.Join([]string{"namespace", tenantID}, "/"), ns, time.Minute); err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 	}
 
 Opts:
@@ -183,16 +183,20 @@ func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
 		}
 
 		if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
-			log.Error(err)
+			log.WithContext(ctx).Error(err)
 		}
 
-		collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions", "api_keys"}
+		collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions", "api_keys", "tag_rules", "command_policies", "command_policy_evaluations", "device_shares"}
 		for _, collection := range collections {
 			if _, err := s.db.Collection(collection).DeleteMany(sessCtx, bson.M{"tenant_id": tenantID}); err != nil {
 				return nil, FromMongoError(err)
 			}
 		}
 
+		if _, err := s.db.Collection("device_shares").DeleteMany(sessCtx, bson.M{"grantee_tenant_id": tenantID}); err != nil {
+			return nil, FromMongoError(err)
+		}
+
 		_, err = s.db.
 			Collection("users").
 			UpdateMany(ctx, bson.M{"preferred_namespace": tenantID}, bson.M{"$set": bson.M{"preferred_namespace": ""}})
@@ -221,7 +225,11 @@ func (s *Store) NamespaceEdit(ctx context.Context, tenant string, changes *model
 	}
 
 	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenant}, "/")); err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
+	}
+
+	if changes.Name != "" {
+		return s.sessionsSetNamespace(ctx, tenant, changes.Name)
 	}
 
 	return nil
@@ -250,10 +258,10 @@ func (s *Store) NamespaceUpdate(ctx context.Context, tenantID string, namespace
 	}
 
 	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 	}
 
-	return nil
+	return s.sessionsSetNamespace(ctx, tenantID, namespace.Name)
 }
 
 func (s *Store) NamespaceAddMember(ctx context.Context, tenantID string, member *models.Member) error {
@@ -285,7 +293,7 @@ func (s *Store) NamespaceAddMember(ctx context.Context, tenantID string, member
 	}
 
 	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 	}
 
 	return nil
@@ -317,7 +325,7 @@ func (s *Store) NamespaceUpdateMember(ctx context.Context, tenantID string, memb
 	}
 
 	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 	}
 
 	return nil
@@ -362,7 +370,7 @@ func (s *Store) NamespaceRemoveMember(ctx context.Context, tenantID string, memb
 	}
 
 	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
-		log.Error(err)
+		log.WithContext(ctx).Error(err)
 	}
 
 	return nil
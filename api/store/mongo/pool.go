@@ -0,0 +1,133 @@
+package mongo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// PoolMonitor keeps track of the connections checked out from the Mongo client's pools, so the requests can be refused
+// when a pool is saturated, instead of piling up waiting for a connection.
+//
+// The client has a pool for each server of the deployment, every one with up to size connections, so they are tracked
+// by the server's address.
+//
+// It must be set as the client's pool monitor, through [PoolMonitor.Monitor].
+type PoolMonitor struct {
+	// size is the maximum number of connections on each server's pool. Zero means no limit.
+	size int64
+
+	mu      sync.Mutex
+	servers map[string]*poolStats
+	// freed is closed, and replaced, every time a connection is returned to a pool.
+	freed chan struct{}
+}
+
+// poolStats is the number of connections checked out from a server's pool and the number of operations waiting for one.
+type poolStats struct {
+	inUse   int64
+	waiting int64
+}
+
+// NewPoolMonitor creates a [PoolMonitor] for pools with up to size connections.
+func NewPoolMonitor(size uint64) *PoolMonitor {
+	return &PoolMonitor{size: int64(size), servers: make(map[string]*poolStats), freed: make(chan struct{})} //nolint:gosec
+}
+
+// Monitor returns the driver's pool monitor that feeds the [PoolMonitor].
+func (p *PoolMonitor) Monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: p.handle}
+}
+
+func (p *PoolMonitor) handle(e *event.PoolEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	server, ok := p.servers[e.Address]
+	if !ok {
+		server = new(poolStats)
+		p.servers[e.Address] = server
+	}
+
+	switch e.Type {
+	case event.GetStarted:
+		server.waiting++
+	case event.GetFailed:
+		server.waiting--
+	case event.GetSucceeded:
+		server.waiting--
+		server.inUse++
+	case event.ConnectionReturned:
+		server.inUse--
+
+		close(p.freed)
+		p.freed = make(chan struct{})
+	}
+}
+
+// Stats returns the number of connections checked out from the pools and the number of operations waiting for one,
+// summed up across the servers.
+func (p *PoolMonitor) Stats() (inUse, waiting int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, server := range p.servers {
+		inUse += server.inUse
+		waiting += server.waiting
+	}
+
+	return inUse, waiting
+}
+
+// Saturated reports whether every connection of a server's pool is checked out and there are operations waiting for
+// one.
+func (p *PoolMonitor) Saturated() bool {
+	saturated, _ := p.saturated()
+
+	return saturated
+}
+
+func (p *PoolMonitor) saturated() (bool, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.size == 0 {
+		return false, p.freed
+	}
+
+	for _, server := range p.servers {
+		if server.inUse >= p.size && server.waiting > 0 {
+			return true, p.freed
+		}
+	}
+
+	return false, p.freed
+}
+
+// Wait waits, up to timeout, for the pool to not be saturated. It reports whether the pool has room for a new operation,
+// returning false when it is still saturated after the timeout or when ctx is done.
+func (p *PoolMonitor) Wait(ctx context.Context, timeout time.Duration) bool {
+	saturated, freed := p.saturated()
+	if !saturated {
+		return true
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for saturated {
+		select {
+		case <-freed:
+		case <-timer.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+
+		saturated, freed = p.saturated()
+	}
+
+	return true
+}
@@ -0,0 +1,180 @@
+package mongo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	routesmiddleware "github.com/shellhub-io/shellhub/api/routes/middleware"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// checkout simulates an operation checking out a connection from the pool monitored.
+func checkout(monitor *event.PoolMonitor) {
+	checkoutFrom(monitor, "")
+}
+
+// checkoutFrom simulates an operation checking out a connection from the pool of the server at address.
+func checkoutFrom(monitor *event.PoolMonitor, address string) {
+	monitor.Event(&event.PoolEvent{Type: event.GetStarted, Address: address})
+	monitor.Event(&event.PoolEvent{Type: event.GetSucceeded, Address: address})
+}
+
+func TestPoolMonitor(t *testing.T) {
+	t.Run("isn't saturated while there are free connections", func(t *testing.T) {
+		pool := NewPoolMonitor(2)
+		monitor := pool.Monitor()
+
+		checkout(monitor)
+		checkout(monitor)
+
+		inUse, waiting := pool.Stats()
+		assert.Equal(t, int64(2), inUse)
+		assert.Equal(t, int64(0), waiting)
+		assert.False(t, pool.Saturated())
+		assert.True(t, pool.Wait(context.Background(), time.Millisecond))
+	})
+
+	t.Run("is saturated when an operation waits for a connection", func(t *testing.T) {
+		pool := NewPoolMonitor(1)
+		monitor := pool.Monitor()
+
+		checkout(monitor)
+		monitor.Event(&event.PoolEvent{Type: event.GetStarted})
+
+		assert.True(t, pool.Saturated())
+		assert.False(t, pool.Wait(context.Background(), 10*time.Millisecond))
+	})
+
+	t.Run("isn't saturated while each server's pool has free connections", func(t *testing.T) {
+		pool := NewPoolMonitor(2)
+		monitor := pool.Monitor()
+
+		checkoutFrom(monitor, "primary:27017")
+		checkoutFrom(monitor, "secondary:27017")
+		monitor.Event(&event.PoolEvent{Type: event.GetStarted, Address: "secondary:27017"})
+
+		inUse, waiting := pool.Stats()
+		assert.Equal(t, int64(2), inUse)
+		assert.Equal(t, int64(1), waiting)
+		assert.False(t, pool.Saturated())
+	})
+
+	t.Run("is saturated when an operation waits for a connection of a server's pool", func(t *testing.T) {
+		pool := NewPoolMonitor(1)
+		monitor := pool.Monitor()
+
+		checkoutFrom(monitor, "primary:27017")
+		monitor.Event(&event.PoolEvent{Type: event.GetStarted, Address: "primary:27017"})
+
+		assert.True(t, pool.Saturated())
+	})
+
+	t.Run("isn't saturated without a limit", func(t *testing.T) {
+		pool := NewPoolMonitor(0)
+		monitor := pool.Monitor()
+
+		checkout(monitor)
+		monitor.Event(&event.PoolEvent{Type: event.GetStarted})
+
+		assert.False(t, pool.Saturated())
+	})
+
+	t.Run("stops waiting when a connection is returned", func(t *testing.T) {
+		pool := NewPoolMonitor(1)
+		monitor := pool.Monitor()
+
+		checkout(monitor)
+		monitor.Event(&event.PoolEvent{Type: event.GetStarted})
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			monitor.Event(&event.PoolEvent{Type: event.ConnectionReturned})
+		}()
+
+		assert.True(t, pool.Wait(context.Background(), time.Second))
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		pool := NewPoolMonitor(1)
+		monitor := pool.Monitor()
+
+		checkout(monitor)
+		monitor.Event(&event.PoolEvent{Type: event.GetStarted})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.False(t, pool.Wait(ctx, time.Second))
+	})
+}
+
+// BenchmarkBackpressure simulates a burst of requests, through the backpressure middleware, each one holding a
+// connection for a millisecond, on a pool smaller than the burst. It reports the requests refused with a
+// [http.StatusServiceUnavailable], instead of piling up, and the mean and the slowest latency of the requests.
+func BenchmarkBackpressure(b *testing.B) {
+	const size = 10
+
+	for _, timeout := range []time.Duration{0, time.Millisecond, 10 * time.Millisecond} {
+		b.Run(timeout.String(), func(b *testing.B) {
+			pool := NewPoolMonitor(size)
+			monitor := pool.Monitor()
+
+			// NOTICE: the connections are simulated by a semaphore, as the driver's pool makes the operations above its
+			// size wait for a returned connection.
+			connections := make(chan struct{}, size)
+
+			e := echo.New()
+			e.Use(routesmiddleware.Backpressure(pool, timeout, models.Backoff{RetryAfter: time.Second}))
+			e.GET("/", func(c echo.Context) error {
+				monitor.Event(&event.PoolEvent{Type: event.GetStarted, Address: "localhost:27017"})
+				connections <- struct{}{}
+				monitor.Event(&event.PoolEvent{Type: event.GetSucceeded, Address: "localhost:27017"})
+
+				time.Sleep(time.Millisecond)
+
+				<-connections
+				monitor.Event(&event.PoolEvent{Type: event.ConnectionReturned, Address: "localhost:27017"})
+
+				return c.NoContent(http.StatusOK)
+			})
+
+			var mu sync.Mutex
+			refused := 0
+			var total, slowest time.Duration
+
+			b.SetParallelism(4 * size)
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					started := time.Now()
+
+					rec := httptest.NewRecorder()
+					e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+					latency := time.Since(started)
+
+					mu.Lock()
+					if rec.Code == http.StatusServiceUnavailable {
+						refused++
+					}
+
+					total += latency
+					slowest = max(slowest, latency)
+					mu.Unlock()
+				}
+			})
+
+			b.ReportMetric(float64(refused)/float64(b.N), "503/op")
+			b.ReportMetric(float64(total.Microseconds())/float64(b.N), "µs/req")
+			b.ReportMetric(float64(slowest.Microseconds()), "max-µs/req")
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) PublicURLLogCreate(ctx context.Context, log *models.PublicURLLog) error {
+	if _, err := s.db.Collection("public_url_logs").InsertOne(ctx, log); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) PublicURLLogList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.PublicURLLog, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenantID, "device_uid": uid},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("public_url_logs"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.PublicURLLog{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"time": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("public_url_logs").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	logs := make([]models.PublicURLLog, 0)
+	for cursor.Next(ctx) {
+		log := new(models.PublicURLLog)
+		if err := cursor.Decode(log); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		logs = append(logs, *log)
+	}
+
+	return logs, count, nil
+}
+
+func (s *Store) PublicURLLogStats(ctx context.Context, tenantID string, uid models.UID) (*models.PublicURLStats, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenantID, "device_uid": uid},
+		},
+		{
+			"$group": bson.M{
+				"_id":      nil,
+				"requests": bson.M{"$sum": 1},
+				"errors": bson.M{"$sum": bson.M{
+					"$cond": bson.A{bson.M{"$or": bson.A{
+						bson.M{"$eq": bson.A{"$status", 0}},
+						bson.M{"$gte": bson.A{"$status", 400}},
+					}}, 1, 0},
+				}},
+				"bytes":      bson.M{"$sum": "$bytes"},
+				"latency":    bson.M{"$avg": "$latency"},
+				"source_ips": bson.M{"$addToSet": "$source_ip"},
+			},
+		},
+		{
+			"$project": bson.M{
+				"_id":        0,
+				"requests":   1,
+				"errors":     1,
+				"bytes":      1,
+				"latency":    1,
+				"source_ips": bson.M{"$size": "$source_ips"},
+			},
+		},
+	}
+
+	cursor, err := s.db.Collection("public_url_logs").Aggregate(ctx, query)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	stats := new(models.PublicURLStats)
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(stats); err != nil {
+			return nil, FromMongoError(err)
+		}
+	}
+
+	return stats, nil
+}
@@ -21,7 +21,7 @@ func (s *Store) PublicKeyPushTag(ctx context.Context, tenant, fingerprint, tag s
 }
 
 func (s *Store) PublicKeyPullTag(ctx context.Context, tenant, fingerprint, tag string) error {
-	result, err := s.db.Collection("public_keys").UpdateOne(ctx, bson.M{"tenant_id": tenant, "fingerprint": fingerprint}, bson.M{"$pull": bson.M{"filter.tags": tag}})
+	result, err := s.db.Collection("public_keys").UpdateOne(ctx, bson.M{"tenant_id": tenant, "fingerprint": fingerprint}, bson.M{"$pull": bson.M{"filter.tags": tagWithDescendants(tag)}})
 	if err != nil {
 		return err
 	}
@@ -40,13 +40,13 @@ func (s *Store) PublicKeySetTags(ctx context.Context, tenant, fingerprint string
 }
 
 func (s *Store) PublicKeyBulkRenameTag(ctx context.Context, tenant, currentTag, newTag string) (int64, error) {
-	res, err := s.db.Collection("public_keys").UpdateMany(ctx, bson.M{"tenant_id": tenant, "filter.tags": currentTag}, bson.M{"$set": bson.M{"filter.tags.$": newTag}})
+	res, err := s.db.Collection("public_keys").UpdateMany(ctx, bson.M{"tenant_id": tenant, "filter.tags": tagWithDescendants(currentTag)}, renameTagPipeline("filter.tags", currentTag, newTag))
 
 	return res.ModifiedCount, FromMongoError(err)
 }
 
 func (s *Store) PublicKeyBulkDeleteTag(ctx context.Context, tenant, tag string) (int64, error) {
-	res, err := s.db.Collection("public_keys").UpdateMany(ctx, bson.M{"tenant_id": tenant}, bson.M{"$pull": bson.M{"filter.tags": tag}})
+	res, err := s.db.Collection("public_keys").UpdateMany(ctx, bson.M{"tenant_id": tenant}, bson.M{"$pull": bson.M{"filter.tags": tagWithDescendants(tag)}})
 
 	return res.ModifiedCount, FromMongoError(err)
 }
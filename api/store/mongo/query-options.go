@@ -30,6 +30,13 @@ func (*queryOptions) CountAcceptedDevices() store.NamespaceQueryOption {
 
 		ns.DevicesCount = int(countDevice)
 
+		countExempt, err := db.Collection("devices").CountDocuments(ctx, bson.M{"tenant_id": ns.TenantID, "status": "accepted", "limit_exempt": true})
+		if err != nil {
+			return FromMongoError(err)
+		}
+
+		ns.LimitExemptDevicesCount = int(countExempt)
+
 		return nil
 	}
 }
@@ -46,7 +53,7 @@ func (*queryOptions) EnrichMembersData() store.NamespaceQueryOption {
 			objID, _ := primitive.ObjectIDFromHex(member.ID)
 
 			if err := db.Collection("users").FindOne(ctx, bson.M{"_id": objID}).Decode(&user); err != nil {
-				log.WithError(err).
+				log.WithContext(ctx).WithError(err).
 					WithField("id", member.ID).
 					Error("member not found")
 
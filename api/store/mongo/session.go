@@ -78,12 +78,14 @@ func (s *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]m
 			return sessions, count, err
 		}
 
-		device, err := s.DeviceGet(ctx, session.DeviceUID)
-		if err != nil {
-			return sessions, count, err
+		// NOTICE: the device isn't joined, as it would be once for each session listed; only its denormalized fields
+		// are returned.
+		session.Device = &models.Device{
+			UID:       string(session.DeviceUID),
+			Name:      session.DeviceName,
+			TenantID:  session.TenantID,
+			Namespace: session.Namespace,
 		}
-
-		session.Device = device
 		sessions = append(sessions, *session)
 	}
 
@@ -180,6 +182,8 @@ func (s *Store) SessionCreate(ctx context.Context, session models.Session) (*mod
 	}
 
 	session.TenantID = device.TenantID
+	session.DeviceName = device.Name
+	session.Namespace = device.Namespace
 
 	if _, err := s.db.Collection("sessions").InsertOne(ctx, &session); err != nil {
 		return nil, FromMongoError(err)
@@ -284,3 +288,21 @@ func (s *Store) SessionEvent(ctx context.Context, uid models.UID, event *models.
 
 	return nil
 }
+
+// sessionsSetDeviceName updates the device's name denormalized on its sessions.
+func (s *Store) sessionsSetDeviceName(ctx context.Context, uid models.UID, name string) error {
+	if _, err := s.db.Collection("sessions").UpdateMany(ctx, bson.M{"device_uid": uid}, bson.M{"$set": bson.M{"device_name": name}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+// sessionsSetNamespace updates the namespace's name denormalized on its sessions.
+func (s *Store) sessionsSetNamespace(ctx context.Context, tenantID, name string) error {
+	if _, err := s.db.Collection("sessions").UpdateMany(ctx, bson.M{"tenant_id": tenantID}, bson.M{"$set": bson.M{"namespace": name}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
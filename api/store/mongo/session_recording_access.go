@@ -0,0 +1,52 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) SessionRecordingAccessCreate(ctx context.Context, access *models.SessionRecordingAccess) error {
+	if _, err := s.db.Collection("session_recording_accesses").InsertOne(ctx, access); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) SessionRecordingAccessList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.SessionRecordingAccess, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenantID, "session_uid": uid},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("session_recording_accesses"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.SessionRecordingAccess{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"accessed_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("session_recording_accesses").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	accesses := make([]models.SessionRecordingAccess, 0)
+	if err := cursor.All(ctx, &accesses); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return accesses, count, nil
+}
@@ -0,0 +1,54 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRecordingAccess(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	play := models.SessionRecordingAccess{
+		ID:         "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		SessionUID: "a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68",
+		UserID:     "507f1f77bcf86cd799439011",
+		Username:   "john_doe",
+		Action:     models.SessionRecordingAccessActionPlay,
+		Range:      "bytes=0-1023",
+		SourceIP:   "192.168.0.1",
+		AccessedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	export := models.SessionRecordingAccess{
+		ID:         "6f1b2c3d-0c1b-4d7e-8f3a-000000000002",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		SessionUID: "a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68",
+		UserID:     "507f1f77bcf86cd799439011",
+		Username:   "john_doe",
+		Action:     models.SessionRecordingAccessActionExport,
+		SourceIP:   "192.168.0.1",
+		AccessedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, s.SessionRecordingAccessCreate(ctx, &play))
+	require.NoError(t, s.SessionRecordingAccessCreate(ctx, &export))
+
+	accesses, count, err := s.SessionRecordingAccessList(ctx, "00000000-0000-4000-0000-000000000000", models.UID(play.SessionUID), query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []models.SessionRecordingAccess{export, play}, accesses)
+
+	accesses, count, err = s.SessionRecordingAccessList(ctx, "00000000-0000-4000-0000-000000000001", models.UID(play.SessionUID), query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, []models.SessionRecordingAccess{}, accesses)
+}
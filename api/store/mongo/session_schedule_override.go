@@ -0,0 +1,91 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) SessionScheduleOverrideCreate(ctx context.Context, override *models.SessionScheduleOverride) error {
+	if _, err := s.db.Collection("session_schedule_overrides").InsertOne(ctx, override); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) SessionScheduleOverrideGetActive(ctx context.Context, tenantID string, uid models.UID, at time.Time) (*models.SessionScheduleOverride, error) {
+	override := new(models.SessionScheduleOverride)
+	if err := s.db.Collection("session_schedule_overrides").FindOne(
+		ctx,
+		bson.M{"tenant_id": tenantID, "device_uid": uid, "expires_at": bson.M{"$gt": at}},
+		options.FindOne().SetSort(bson.M{"expires_at": -1}),
+	).Decode(override); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return override, nil
+}
+
+func (s *Store) SessionScheduleOverrideList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.SessionScheduleOverride, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenantID, "device_uid": uid},
+		},
+	}
+
+	queryCount := append(query, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("session_schedule_overrides"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if count == 0 {
+		return []models.SessionScheduleOverride{}, 0, nil
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("session_schedule_overrides").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	overrides := make([]models.SessionScheduleOverride, 0)
+	for cursor.Next(ctx) {
+		override := new(models.SessionScheduleOverride)
+		if err := cursor.Decode(override); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		overrides = append(overrides, *override)
+	}
+
+	return overrides, count, nil
+}
+
+func (s *Store) SessionScheduleOverrideListByUser(ctx context.Context, tenantID, userID string, since time.Time) ([]models.SessionScheduleOverride, error) {
+	cursor, err := s.db.Collection("session_schedule_overrides").Find(
+		ctx,
+		bson.M{"tenant_id": tenantID, "user_id": userID, "created_at": bson.M{"$gte": since}},
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	overrides := make([]models.SessionScheduleOverride, 0)
+	if err := cursor.All(ctx, &overrides); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,121 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+var sessionScheduleOverrides = []models.SessionScheduleOverride{
+	{
+		ID:        "5c1d2e3f-1d2c-4e8f-9a4b-000000000001",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		UserID:    "507f1f77bcf86cd799439011",
+		Reason:    "incident 42",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:        "5c1d2e3f-1d2c-4e8f-9a4b-000000000002",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		UserID:    "507f1f77bcf86cd799439011",
+		Reason:    "incident 43",
+		CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2023, 1, 2, 13, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:        "5c1d2e3f-1d2c-4e8f-9a4b-000000000003",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+		UserID:    "507f1f77bcf86cd799439011",
+		Reason:    "incident 44",
+		CreatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+		ExpiresAt: time.Date(2023, 1, 3, 13, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestSessionScheduleOverrideGetActive(t *testing.T) {
+	type Expected struct {
+		id  string
+		err error
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		at          time.Time
+		expected    Expected
+	}{
+		{
+			description: "fails when the device has no overrides",
+			uid:         models.UID("4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"),
+			at:          time.Date(2023, 1, 2, 12, 30, 0, 0, time.UTC),
+			expected:    Expected{id: "", err: store.ErrNoDocuments},
+		},
+		{
+			description: "fails when the device's overrides expired",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			at:          time.Date(2023, 1, 2, 13, 0, 0, 0, time.UTC),
+			expected:    Expected{id: "", err: store.ErrNoDocuments},
+		},
+		{
+			description: "succeeds when the device has an active override",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			at:          time.Date(2023, 1, 2, 12, 30, 0, 0, time.UTC),
+			expected:    Expected{id: "5c1d2e3f-1d2c-4e8f-9a4b-000000000002", err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := range sessionScheduleOverrides {
+				require.NoError(t, s.SessionScheduleOverrideCreate(ctx, &sessionScheduleOverrides[i]))
+			}
+
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			override, err := s.SessionScheduleOverrideGetActive(ctx, "00000000-0000-4000-0000-000000000000", tc.uid, tc.at)
+
+			id := ""
+			if override != nil {
+				id = override.ID
+			}
+
+			require.Equal(t, tc.expected, Expected{id, err})
+		})
+	}
+}
+
+func TestSessionScheduleOverrideList(t *testing.T) {
+	ctx := context.Background()
+
+	for i := range sessionScheduleOverrides {
+		require.NoError(t, s.SessionScheduleOverrideCreate(ctx, &sessionScheduleOverrides[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	overrides, count, err := s.SessionScheduleOverrideList(ctx, "00000000-0000-4000-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c", query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	ids := []string{}
+	for _, override := range overrides {
+		ids = append(ids, override.ID)
+	}
+
+	require.Equal(t, []string{"5c1d2e3f-1d2c-4e8f-9a4b-000000000002", "5c1d2e3f-1d2c-4e8f-9a4b-000000000001"}, ids)
+
+	overrides, err = s.SessionScheduleOverrideListByUser(ctx, "00000000-0000-4000-0000-000000000000", "507f1f77bcf86cd799439011", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+}
@@ -46,25 +46,13 @@ func TestSessionList(t *testing.T) {
 						Username:  "john_doe",
 						IPAddress: "0.0.0.0",
 						Device: &models.Device{
-							CreatedAt:        time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							StatusUpdatedAt:  time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							LastSeen:         time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							UID:              "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
-							Name:             "device-3",
-							Identity:         &models.DeviceIdentity{MAC: "mac-3"},
-							Info:             nil,
-							PublicKey:        "",
-							TenantID:         "00000000-0000-4000-0000-000000000000",
-							Online:           true,
-							Namespace:        "namespace-1",
-							Status:           "accepted",
-							RemoteAddr:       "",
-							Position:         nil,
-							Tags:             []string{"tag-1"},
-							PublicURL:        false,
-							PublicURLAddress: "",
-							Acceptable:       false,
+							UID:       "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+							Name:      "device-3",
+							TenantID:  "00000000-0000-4000-0000-000000000000",
+							Namespace: "namespace-1",
 						},
+						DeviceName:    "device-3",
+						Namespace:     "namespace-1",
 						Active:        true,
 						Closed:        true,
 						Authenticated: true,
@@ -82,25 +70,13 @@ func TestSessionList(t *testing.T) {
 						Username:  "john_doe",
 						IPAddress: "0.0.0.0",
 						Device: &models.Device{
-							CreatedAt:        time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							StatusUpdatedAt:  time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							LastSeen:         time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							UID:              "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
-							Name:             "device-3",
-							Identity:         &models.DeviceIdentity{MAC: "mac-3"},
-							Info:             nil,
-							PublicKey:        "",
-							TenantID:         "00000000-0000-4000-0000-000000000000",
-							Online:           true,
-							Namespace:        "namespace-1",
-							Status:           "accepted",
-							RemoteAddr:       "",
-							Position:         nil,
-							Tags:             []string{"tag-1"},
-							PublicURL:        false,
-							PublicURLAddress: "",
-							Acceptable:       false,
+							UID:       "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+							Name:      "device-3",
+							TenantID:  "00000000-0000-4000-0000-000000000000",
+							Namespace: "namespace-1",
 						},
+						DeviceName:    "device-3",
+						Namespace:     "namespace-1",
 						Active:        false,
 						Closed:        true,
 						Authenticated: true,
@@ -118,25 +94,13 @@ func TestSessionList(t *testing.T) {
 						Username:  "john_doe",
 						IPAddress: "0.0.0.0",
 						Device: &models.Device{
-							CreatedAt:        time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							StatusUpdatedAt:  time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							LastSeen:         time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							UID:              "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
-							Name:             "device-3",
-							Identity:         &models.DeviceIdentity{MAC: "mac-3"},
-							Info:             nil,
-							PublicKey:        "",
-							TenantID:         "00000000-0000-4000-0000-000000000000",
-							Online:           true,
-							Namespace:        "namespace-1",
-							Status:           "accepted",
-							RemoteAddr:       "",
-							Position:         nil,
-							Tags:             []string{"tag-1"},
-							PublicURL:        false,
-							PublicURLAddress: "",
-							Acceptable:       false,
+							UID:       "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+							Name:      "device-3",
+							TenantID:  "00000000-0000-4000-0000-000000000000",
+							Namespace: "namespace-1",
 						},
+						DeviceName:    "device-3",
+						Namespace:     "namespace-1",
 						Active:        false,
 						Closed:        true,
 						Authenticated: true,
@@ -154,25 +118,13 @@ func TestSessionList(t *testing.T) {
 						Username:  "john_doe",
 						IPAddress: "0.0.0.0",
 						Device: &models.Device{
-							CreatedAt:        time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							StatusUpdatedAt:  time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							LastSeen:         time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
-							UID:              "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
-							Name:             "device-3",
-							Identity:         &models.DeviceIdentity{MAC: "mac-3"},
-							Info:             nil,
-							PublicKey:        "",
-							TenantID:         "00000000-0000-4000-0000-000000000000",
-							Online:           true,
-							Namespace:        "namespace-1",
-							Status:           "accepted",
-							RemoteAddr:       "",
-							Position:         nil,
-							Tags:             []string{"tag-1"},
-							PublicURL:        false,
-							PublicURLAddress: "",
-							Acceptable:       false,
+							UID:       "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+							Name:      "device-3",
+							TenantID:  "00000000-0000-4000-0000-000000000000",
+							Namespace: "namespace-1",
 						},
+						DeviceName:    "device-3",
+						Namespace:     "namespace-1",
 						Active:        false,
 						Closed:        true,
 						Authenticated: true,
@@ -281,6 +233,8 @@ func TestSessionGet(t *testing.T) {
 						PublicURLAddress: "",
 						Acceptable:       false,
 					},
+					DeviceName:    "device-3",
+					Namespace:     "namespace-1",
 					Active:        true,
 					Closed:        true,
 					Authenticated: true,
@@ -343,6 +297,8 @@ func TestSessionCreate(t *testing.T) {
 			session, err := s.SessionCreate(ctx, tc.session)
 			assert.Equal(t, tc.expected, err)
 			assert.NotEmpty(t, session)
+			assert.Equal(t, "device-3", session.DeviceName)
+			assert.Equal(t, "namespace-1", session.Namespace)
 		})
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -24,6 +25,14 @@ func (s *Store) GetStats(ctx context.Context) (*models.Stats, error) {
 	}
 
 	query = append([]bson.M{
+		{
+			"$match": bson.M{
+				"$or": []bson.M{
+					{"offline_at": bson.M{"$exists": false}},
+					{"offline_at": bson.M{"$gt": clock.Now()}},
+				},
+			},
+		},
 		{
 			"$lookup": bson.M{
 				"from":         "devices",
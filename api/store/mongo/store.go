@@ -7,6 +7,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/options"
 	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
 	"go.mongodb.org/mongo-driver/mongo"
 	mongooptions "go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
@@ -29,14 +30,18 @@ type Store struct {
 	db      *mongo.Database
 	options *queryOptions
 	cache   cache.Cache
+	// cipher encrypts the personal data fields. It is nil when their encryption isn't configured.
+	cipher *fieldcrypt.Cipher
 }
 
 func (s *Store) GetDB() *mongo.Database {
 	return s.db
 }
 
-func Connect(ctx context.Context, uri string) (*mongo.Client, *mongo.Database, error) {
-	client, err := mongo.Connect(ctx, mongooptions.Client().ApplyURI(uri))
+// Connect connects to the Mongo database on uri. The client's options, like its pool's settings, override the ones set
+// on uri.
+func Connect(ctx context.Context, uri string, opts ...*mongooptions.ClientOptions) (*mongo.Client, *mongo.Database, error) {
+	client, err := mongo.Connect(ctx, append([]*mongooptions.ClientOptions{mongooptions.Client().ApplyURI(uri)}, opts...)...)
 	if err != nil {
 		return nil, nil, errors.Join(ErrStoreConnect, err)
 	}
@@ -53,13 +58,24 @@ func Connect(ctx context.Context, uri string) (*mongo.Client, *mongo.Database, e
 	return client, client.Database(connStr.Database), nil
 }
 
-func NewStore(ctx context.Context, uri string, cache cache.Cache, opts ...options.DatabaseOpt) (store.Store, error) {
-	_, db, err := Connect(ctx, uri)
+// NewStore creates a [store.Store] on the Mongo database on uri. client, when not nil, sets the Mongo client's options.
+// The personal data fields are encrypted with cipher, when it isn't nil.
+func NewStore(ctx context.Context, uri string, cache cache.Cache, cipher *fieldcrypt.Cipher, client *mongooptions.ClientOptions, opts ...options.DatabaseOpt) (store.Store, error) {
+	var clientOpts []*mongooptions.ClientOptions
+	if client != nil {
+		clientOpts = append(clientOpts, client)
+	}
+
+	if cipher != nil {
+		clientOpts = append(clientOpts, mongooptions.Client().SetRegistry(encryptedFieldsRegistry(cipher)))
+	}
+
+	_, db, err := Connect(ctx, uri, clientOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	store := &Store{db: db, cache: cache, options: &queryOptions{}}
+	store := &Store{db: db, cache: cache, cipher: cipher, options: &queryOptions{}}
 
 	for _, opt := range opts {
 		if err := opt(ctx, store.db); err != nil {
@@ -0,0 +1,77 @@
+package mongo_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEncryptedFieldsStored(t *testing.T) {
+	ctx := context.Background()
+
+	cipher, err := fieldcrypt.New(bytes.Repeat([]byte{1}, fieldcrypt.KeySize))
+	require.NoError(t, err)
+
+	encrypted, err := mongo.NewStore(ctx, srv.Container.ConnectionString+"/"+srv.Container.Database, cache.NewNullCache(), cipher, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, srv.Apply(fixtureUsers, fixtureDevices))
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	t.Run("encrypts the user's email changed", func(t *testing.T) {
+		require.NoError(t, encrypted.UserUpdate(ctx, "507f1f77bcf86cd799439011", &models.UserChanges{Email: "john.doe@shellhub.io"}))
+
+		id, err := primitive.ObjectIDFromHex("507f1f77bcf86cd799439011")
+		require.NoError(t, err)
+
+		raw := bson.M{}
+		require.NoError(t, db.Collection("users").FindOne(ctx, bson.M{"_id": id}).Decode(&raw))
+		assert.Contains(t, raw["email"], "enc:d:")
+
+		user, err := encrypted.UserGetByEmail(ctx, "john.doe@shellhub.io")
+		require.NoError(t, err)
+		assert.Equal(t, "john.doe@shellhub.io", user.Email)
+	})
+
+	t.Run("encrypts the user's email invited", func(t *testing.T) {
+		id, err := encrypted.UserCreateInvited(ctx, "jane.doe@shellhub.io")
+		require.NoError(t, err)
+
+		objID, err := primitive.ObjectIDFromHex(id)
+		require.NoError(t, err)
+
+		raw := bson.M{}
+		require.NoError(t, db.Collection("users").FindOne(ctx, bson.M{"_id": objID}).Decode(&raw))
+		assert.Contains(t, raw["email"], "enc:d:")
+	})
+
+	t.Run("encrypts the device's remote addresses", func(t *testing.T) {
+		uid := "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f"
+
+		require.NoError(t, encrypted.DeviceCreate(ctx, models.Device{UID: uid, RemoteAddr: "192.168.0.1"}, "device"))
+		require.NoError(t, encrypted.DeviceAddAddress(ctx, models.UID(uid), models.DeviceAddress{RemoteAddr: "192.168.0.2"}))
+
+		name := "renamed"
+		require.NoError(t, encrypted.DeviceUpdate(ctx, "00000000-0000-4000-0000-000000000000", models.UID(uid), &name, nil))
+
+		raw := bson.Raw{}
+		require.NoError(t, db.Collection("devices").FindOne(ctx, bson.M{"uid": uid}).Decode(&raw))
+		assert.Contains(t, raw.Lookup("remote_addr").StringValue(), "enc:r:")
+		assert.NotContains(t, raw.String(), "192.168.0.1")
+		assert.NotContains(t, raw.String(), "192.168.0.2")
+
+		device, err := encrypted.DeviceGet(ctx, models.UID(uid))
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", device.Name)
+		assert.Equal(t, "192.168.0.1", device.RemoteAddr)
+	})
+}
@@ -23,17 +23,18 @@ var (
 )
 
 const (
-	fixtureAPIKeys          = "api-key"           // Check "store.mongo.fixtures.api-keys" for fixture info
-	fixtureConnectedDevices = "connected_devices" // Check "store.mongo.fixtures.connected_devices" for fixture info
-	fixtureDevices          = "devices"           // Check "store.mongo.fixtures.devices" for fixture info
-	fixtureSessions         = "sessions"          // Check "store.mongo.fixtures.sessions" for fixture info
-	fixtureActiveSessions   = "active_sessions"   // Check "store.mongo.fixtures.active_sessions" for fixture info
-	fixtureFirewallRules    = "firewall_rules"    // Check "store.mongo.fixtures.firewall_rules" for fixture info
-	fixturePublicKeys       = "public_keys"       // Check "store.mongo.fixtures.public_keys" for fixture info
-	fixturePrivateKeys      = "private_keys"      // Check "store.mongo.fixtures.private_keys" for fixture info
-	fixtureUsers            = "users"             // Check "store.mongo.fixtures.users" for fixture iefo
-	fixtureNamespaces       = "namespaces"        // Check "store.mongo.fixtures.namespaces" for fixture info
-	fixtureRecoveryTokens   = "recovery_tokens"   // Check "store.mongo.fixtures.recovery_tokens" for fixture info
+	fixtureAPIKeys          = "api-key"              // Check "store.mongo.fixtures.api-keys" for fixture info
+	fixtureConnectedDevices = "connected_devices"    // Check "store.mongo.fixtures.connected_devices" for fixture info
+	fixtureDevices          = "devices"              // Check "store.mongo.fixtures.devices" for fixture info
+	fixtureSessions         = "sessions"             // Check "store.mongo.fixtures.sessions" for fixture info
+	fixtureActiveSessions   = "active_sessions"      // Check "store.mongo.fixtures.active_sessions" for fixture info
+	fixtureFirewallRules    = "firewall_rules"       // Check "store.mongo.fixtures.firewall_rules" for fixture info
+	fixturePublicKeys       = "public_keys"          // Check "store.mongo.fixtures.public_keys" for fixture info
+	fixturePrivateKeys      = "private_keys"         // Check "store.mongo.fixtures.private_keys" for fixture info
+	fixtureUsers            = "users"                // Check "store.mongo.fixtures.users" for fixture iefo
+	fixtureNamespaces       = "namespaces"           // Check "store.mongo.fixtures.namespaces" for fixture info
+	fixtureRecoveryTokens   = "recovery_tokens"      // Check "store.mongo.fixtures.recovery_tokens" for fixture info
+	fixtureKeyIncidents     = "device_key_incidents" // Check "store.mongo.fixtures.device_key_incidents" for fixture info
 )
 
 func TestMain(m *testing.M) {
@@ -78,7 +79,7 @@ func TestMain(m *testing.M) {
 
 	var err error
 
-	s, err = mongo.NewStore(ctx, srv.Container.ConnectionString+"/"+srv.Container.Database, cache.NewNullCache())
+	s, err = mongo.NewStore(ctx, srv.Container.ConnectionString+"/"+srv.Container.Database, cache.NewNullCache(), nil, nil)
 	if err != nil {
 		log.WithError(err).Error("Failed to create the mongodb store")
 		os.Exit(1)
@@ -18,7 +18,7 @@ const (
 
 func (s *Store) SystemGet(ctx context.Context) (*models.System, error) {
 	if system, err := cache.Get[models.System](ctx, s.cache, SystemCollection); err == nil {
-		log.WithField("system", system).Warn("using system from cache")
+		log.WithContext(ctx).WithField("system", system).Warn("using system from cache")
 
 		return system, nil
 	}
@@ -34,7 +34,7 @@ func (s *Store) SystemGet(ctx context.Context) (*models.System, error) {
 	}
 
 	if err := s.cache.Set(ctx, SystemCollection, system, SystemCacheTTL); err != nil {
-		log.WithField("system", system).Warn("failed to set the system data on cache")
+		log.WithContext(ctx).WithField("system", system).Warn("failed to set the system data on cache")
 	}
 
 	return system, nil
@@ -55,7 +55,7 @@ func (s *Store) SystemSet(ctx context.Context, key string, value any) error {
 	}
 
 	if err := s.cache.Delete(ctx, SystemCollection); err != nil {
-		log.WithField(SystemCollection, key).Warn("failed to delete system from cache")
+		log.WithContext(ctx).WithField(SystemCollection, key).Warn("failed to delete system from cache")
 	}
 
 	return nil
@@ -0,0 +1,81 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) TagRuleList(ctx context.Context, tenantID string) ([]models.TagRule, error) {
+	cursor, err := s.db.Collection("tag_rules").Find(
+		ctx,
+		bson.M{"tenant_id": tenantID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	rules := make([]models.TagRule, 0)
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return rules, nil
+}
+
+func (s *Store) TagRuleGet(ctx context.Context, tenantID, id string) (*models.TagRule, error) {
+	rule := new(models.TagRule)
+	if err := s.db.Collection("tag_rules").FindOne(ctx, bson.M{"_id": id, "tenant_id": tenantID}).Decode(rule); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return rule, nil
+}
+
+func (s *Store) TagRuleCreate(ctx context.Context, rule *models.TagRule) error {
+	if _, err := s.db.Collection("tag_rules").InsertOne(ctx, rule); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) TagRuleUpdate(ctx context.Context, rule *models.TagRule) error {
+	r, err := s.db.Collection("tag_rules").UpdateOne(
+		ctx,
+		bson.M{"_id": rule.ID, "tenant_id": rule.TenantID},
+		bson.M{"$set": bson.M{
+			"name":       rule.Name,
+			"tag":        rule.Tag,
+			"conditions": rule.Conditions,
+			"updated_at": rule.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) TagRuleDelete(ctx context.Context, tenantID, id string) error {
+	r, err := s.db.Collection("tag_rules").DeleteOne(ctx, bson.M{"_id": id, "tenant_id": tenantID})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
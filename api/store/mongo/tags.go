@@ -2,11 +2,40 @@ package mongo
 
 import (
 	"context"
+	"regexp"
 
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	mongodriver "go.mongodb.org/mongo-driver/mongo"
 )
 
+// tagWithDescendants returns an expression that matches, on an array of tags, the tag and all of its descendants.
+func tagWithDescendants(tag string) bson.M {
+	return bson.M{"$in": bson.A{tag, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(tag+models.TagSeparator)}}}
+}
+
+// renameTagPipeline returns an update pipeline that renames, on the array of tags at field, the tag and the prefix of
+// all of its descendants.
+func renameTagPipeline(field, currentTag, newTag string) mongodriver.Pipeline {
+	return mongodriver.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			field: bson.M{"$map": bson.M{
+				"input": "$" + field,
+				"as":    "tag",
+				"in": bson.M{"$cond": bson.A{
+					bson.M{"$or": bson.A{
+						bson.M{"$eq": bson.A{"$$tag", currentTag}},
+						bson.M{"$eq": bson.A{bson.M{"$indexOfBytes": bson.A{"$$tag", currentTag + models.TagSeparator}}, 0}},
+					}},
+					bson.M{"$concat": bson.A{newTag, bson.M{"$substrBytes": bson.A{"$$tag", len(currentTag), -1}}}},
+					"$$tag",
+				}},
+			}},
+		}}},
+	}
+}
+
 func (s *Store) FirewallRuleGetTags(ctx context.Context, tenant string) ([]string, int, error) {
 	list, err := s.db.Collection("firewall_rules").Distinct(ctx, "filter.tags", bson.M{"tenant_id": tenant})
 
@@ -46,7 +75,8 @@ func (s *Store) TagsGet(ctx context.Context, tenant string) ([]string, int, erro
 		tags = append(tags, keyTags...)
 		tags = append(tags, ruleTags...)
 
-		return removeDuplicate[string](tags), nil
+		// NOTICE: the ancestors of hierarchical tags are listed as well, as they can be targeted by filters and rules.
+		return models.TagsExpand(removeDuplicate[string](tags)), nil
 	})
 	if err != nil {
 		return nil, 0, FromMongoError(err)
@@ -56,7 +86,7 @@ func (s *Store) TagsGet(ctx context.Context, tenant string) ([]string, int, erro
 }
 
 func (s *Store) FirewallRuleBulkRenameTag(ctx context.Context, tenant, currentTag, newTag string) (int64, error) {
-	res, err := s.db.Collection("firewall_rules").UpdateMany(ctx, bson.M{"tenant_id": tenant, "filter.tags": currentTag}, bson.M{"$set": bson.M{"filter.tags.$": newTag}})
+	res, err := s.db.Collection("firewall_rules").UpdateMany(ctx, bson.M{"tenant_id": tenant, "filter.tags": tagWithDescendants(currentTag)}, renameTagPipeline("filter.tags", currentTag, newTag))
 
 	return res.ModifiedCount, FromMongoError(err)
 }
@@ -94,7 +124,7 @@ func (s *Store) TagsRename(ctx context.Context, tenantID string, oldTag string,
 }
 
 func (s *Store) FirewallRuleBulkDeleteTag(ctx context.Context, tenant, tag string) (int64, error) {
-	res, err := s.db.Collection("firewall_rules").UpdateMany(ctx, bson.M{"tenant_id": tenant}, bson.M{"$pull": bson.M{"filter.tags": tag}})
+	res, err := s.db.Collection("firewall_rules").UpdateMany(ctx, bson.M{"tenant_id": tenant}, bson.M{"$pull": bson.M{"filter.tags": tagWithDescendants(tag)}})
 
 	return res.ModifiedCount, FromMongoError(err)
 }
@@ -94,6 +94,12 @@ func (s *Store) UserCreate(ctx context.Context, user *models.User) (string, erro
 }
 
 func (s *Store) UserCreateInvited(ctx context.Context, email string) (string, error) {
+	// NOTICE: structToBson marshals the user without the store's registry, so the email is encrypted here.
+	email, err := s.cipher.EncryptDeterministic(email)
+	if err != nil {
+		return "", err
+	}
+
 	user := structToBson(models.User{CreatedAt: clock.Now(), Status: models.UserStatusInvited, UserData: models.UserData{Email: email}})
 	sanitizeBson(user)
 
@@ -118,7 +124,7 @@ func (s *Store) UserGetByUsername(ctx context.Context, username string) (*models
 func (s *Store) UserGetByEmail(ctx context.Context, email string) (*models.User, error) {
 	user := new(models.User)
 
-	if err := s.db.Collection("users").FindOne(ctx, bson.M{"email": email}).Decode(&user); err != nil {
+	if err := s.db.Collection("users").FindOne(ctx, bson.M{"email": bson.M{"$in": s.cipher.Equivalents(email)}}).Decode(&user); err != nil {
 		return nil, FromMongoError(err)
 	}
 
@@ -203,7 +209,7 @@ func (s *Store) UserConflicts(ctx context.Context, target *models.UserConflicts)
 		{
 			"$match": bson.M{
 				"$or": []bson.M{
-					{"email": target.Email},
+					{"email": bson.M{"$in": s.cipher.Equivalents(target.Email)}},
 					{"username": target.Username},
 				},
 			},
@@ -0,0 +1,68 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) UserAliasSave(ctx context.Context, userID string, alias *models.UserAlias) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	r, err := s.db.Collection("users").UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "aliases.name": alias.Name},
+		bson.M{"$set": bson.M{"aliases.$.target": alias.Target}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount > 0 {
+		return nil
+	}
+
+	// NOTICE: the user doesn't have an alias with this name yet, so it's appended to the user's aliases.
+	r, err = s.db.Collection("users").UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "aliases.name": bson.M{"$ne": alias.Name}},
+		bson.M{"$push": bson.M{"aliases": alias}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserAliasDelete(ctx context.Context, userID, name string) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	r, err := s.db.Collection("users").UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "aliases.name": name},
+		bson.M{"$pull": bson.M{"aliases": bson.M{"name": name}}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.ModifiedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUserAliasSave(t *testing.T) {
+	type Expected struct {
+		aliases []models.UserAlias
+		err     error
+	}
+
+	cases := []struct {
+		description string
+		id          string
+		aliases     []models.UserAlias
+		expected    Expected
+	}{
+		{
+			description: "fails when user is not found",
+			id:          "000000000000000000000000",
+			aliases:     []models.UserAlias{{Name: "web1", Target: "namespace.device"}},
+			expected: Expected{
+				aliases: nil,
+				err:     store.ErrNoDocuments,
+			},
+		},
+		{
+			description: "succeeds when the alias is new",
+			id:          "507f1f77bcf86cd799439011",
+			aliases: []models.UserAlias{
+				{Name: "web1", Target: "namespace.device"},
+				{Name: "web2", Target: "namespace.device-2"},
+			},
+			expected: Expected{
+				aliases: []models.UserAlias{
+					{Name: "web1", Target: "namespace.device"},
+					{Name: "web2", Target: "namespace.device-2"},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds when the alias already exists",
+			id:          "507f1f77bcf86cd799439011",
+			aliases: []models.UserAlias{
+				{Name: "web1", Target: "namespace.device"},
+				{Name: "web1", Target: "namespace.device-2"},
+			},
+			expected: Expected{
+				aliases: []models.UserAlias{
+					{Name: "web1", Target: "namespace.device-2"},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, srv.Apply(fixtureUsers))
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			for _, alias := range tc.aliases {
+				if err := s.UserAliasSave(ctx, tc.id, &alias); err != nil {
+					require.Equal(t, tc.expected.err, err)
+
+					return
+				}
+			}
+
+			id, err := primitive.ObjectIDFromHex(tc.id)
+			require.NoError(t, err)
+
+			user := new(models.User)
+			require.NoError(t, db.Collection("users").FindOne(ctx, bson.M{"_id": id}).Decode(user))
+			require.Equal(t, tc.expected.aliases, user.Aliases)
+		})
+	}
+}
+
+func TestUserAliasDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		name        string
+		expected    error
+	}{
+		{
+			description: "fails when user is not found",
+			id:          "000000000000000000000000",
+			name:        "web1",
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "fails when alias is not found",
+			id:          "507f1f77bcf86cd799439011",
+			name:        "web2",
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when alias is found",
+			id:          "507f1f77bcf86cd799439011",
+			name:        "web1",
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, srv.Apply(fixtureUsers))
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			require.NoError(t, s.UserAliasSave(ctx, "507f1f77bcf86cd799439011", &models.UserAlias{Name: "web1", Target: "namespace.device"}))
+
+			err := s.UserAliasDelete(ctx, tc.id, tc.name)
+			require.Equal(t, tc.expected, err)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) UserSessionSave(ctx context.Context, session *models.UserSession) error {
+	_, err := s.db.Collection("user_sessions").UpdateOne(
+		ctx,
+		bson.M{"_id": session.ID, "user_id": session.UserID},
+		bson.M{
+			"$set": bson.M{
+				"user_agent":    session.UserAgent,
+				"ip":            session.IP,
+				"last_seen_at":  session.LastSeenAt,
+				"expires_at":    session.ExpiresAt,
+				"refresh_token": session.RefreshToken,
+			},
+			"$setOnInsert": bson.M{"created_at": session.CreatedAt},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionRotate(ctx context.Context, session *models.UserSession, refreshToken string) error {
+	res, err := s.db.Collection("user_sessions").UpdateOne(
+		ctx,
+		bson.M{"_id": session.ID, "user_id": session.UserID, "refresh_token": refreshToken},
+		bson.M{
+			"$set": bson.M{
+				"user_agent":    session.UserAgent,
+				"ip":            session.IP,
+				"last_seen_at":  session.LastSeenAt,
+				"expires_at":    session.ExpiresAt,
+				"refresh_token": session.RefreshToken,
+			},
+		},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionGet(ctx context.Context, id string) (*models.UserSession, error) {
+	session := new(models.UserSession)
+	if err := s.db.Collection("user_sessions").FindOne(ctx, bson.M{"_id": id}).Decode(session); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return session, nil
+}
+
+func (s *Store) UserSessionList(ctx context.Context, userID string) ([]models.UserSession, error) {
+	cursor, err := s.db.Collection("user_sessions").Find(
+		ctx,
+		bson.M{"user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	sessions := make([]models.UserSession, 0)
+	for cursor.Next(ctx) {
+		session := new(models.UserSession)
+		if err := cursor.Decode(session); err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		sessions = append(sessions, *session)
+	}
+
+	return sessions, nil
+}
+
+func (s *Store) UserSessionTouch(ctx context.Context, id string, lastSeenAt time.Time) error {
+	r, err := s.db.Collection("user_sessions").UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_seen_at": lastSeenAt}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionDelete(ctx context.Context, userID, id string) error {
+	r, err := s.db.Collection("user_sessions").DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionDeleteAll(ctx context.Context, userID string) error {
+	if _, err := s.db.Collection("user_sessions").DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
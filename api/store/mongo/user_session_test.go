@@ -0,0 +1,262 @@
+package mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+var userSessions = []models.UserSession{
+	{
+		ID:           "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+		UserID:       "507f1f77bcf86cd799439011",
+		UserAgent:    "Mozilla/5.0",
+		IP:           "192.168.0.1",
+		CreatedAt:    time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		LastSeenAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		ExpiresAt:    time.Date(3000, 1, 1, 12, 0, 0, 0, time.UTC),
+		RefreshToken: "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+	},
+	{
+		ID:         "6f1b2c3d-0c1b-4d7e-8f3a-000000000002",
+		UserID:     "507f1f77bcf86cd799439011",
+		UserAgent:  "curl/8.0.0",
+		IP:         "192.168.0.2",
+		CreatedAt:  time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		LastSeenAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		ExpiresAt:  time.Date(3000, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:         "6f1b2c3d-0c1b-4d7e-8f3a-000000000003",
+		UserID:     "608f32a2c7351f001f6475e0",
+		UserAgent:  "Mozilla/5.0",
+		IP:         "192.168.0.3",
+		CreatedAt:  time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+		LastSeenAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+		ExpiresAt:  time.Date(3000, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestUserSessionGet(t *testing.T) {
+	type Expected struct {
+		session *models.UserSession
+		err     error
+	}
+
+	cases := []struct {
+		description string
+		id          string
+		expected    Expected
+	}{
+		{
+			description: "fails when the session is not found",
+			id:          "6f1b2c3d-0c1b-4d7e-8f3a-000000000000",
+			expected:    Expected{session: nil, err: store.ErrNoDocuments},
+		},
+		{
+			description: "succeeds when the session is found",
+			id:          "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+			expected:    Expected{session: &userSessions[0], err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := range userSessions {
+				require.NoError(t, s.UserSessionSave(ctx, &userSessions[i]))
+			}
+
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			session, err := s.UserSessionGet(ctx, tc.id)
+			require.Equal(t, tc.expected, Expected{session, err})
+		})
+	}
+}
+
+func TestUserSessionList(t *testing.T) {
+	type Expected struct {
+		ids []string
+		err error
+	}
+
+	cases := []struct {
+		description string
+		userID      string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when the user has no sessions",
+			userID:      "000000000000000000000000",
+			expected:    Expected{ids: []string{}, err: nil},
+		},
+		{
+			description: "succeeds listing the user's sessions",
+			userID:      "507f1f77bcf86cd799439011",
+			expected: Expected{
+				ids: []string{"6f1b2c3d-0c1b-4d7e-8f3a-000000000002", "6f1b2c3d-0c1b-4d7e-8f3a-000000000001"},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := range userSessions {
+				require.NoError(t, s.UserSessionSave(ctx, &userSessions[i]))
+			}
+
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			sessions, err := s.UserSessionList(ctx, tc.userID)
+
+			ids := []string{}
+			for _, session := range sessions {
+				ids = append(ids, session.ID)
+			}
+
+			require.Equal(t, tc.expected, Expected{ids, err})
+		})
+	}
+}
+
+func TestUserSessionSave(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, s.UserSessionSave(ctx, &userSessions[0]))
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	updated := userSessions[0]
+	updated.CreatedAt = time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC)
+	updated.LastSeenAt = time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC)
+	updated.IP = "192.168.0.10"
+	require.NoError(t, s.UserSessionSave(ctx, &updated))
+
+	sessions, err := s.UserSessionList(ctx, "507f1f77bcf86cd799439011")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, userSessions[0].CreatedAt, sessions[0].CreatedAt)
+	require.Equal(t, updated.LastSeenAt, sessions[0].LastSeenAt)
+	require.Equal(t, "192.168.0.10", sessions[0].IP)
+}
+
+func TestUserSessionRotate(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, s.UserSessionSave(ctx, &userSessions[0]))
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	rotated := userSessions[0]
+	rotated.LastSeenAt = time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC)
+	rotated.RefreshToken = "7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730"
+	require.NoError(t, s.UserSessionRotate(ctx, &rotated, userSessions[0].RefreshToken))
+
+	// NOTICE: a concurrent rotation still holding the previous refresh token must not match the session.
+	reused := userSessions[0]
+	reused.RefreshToken = "3d4f2bf07dc1be38b20cd6e46949a1071f9d0e3d6f0b2b1d2e1c0b6d3c3e2a1f"
+	require.Equal(t, store.ErrNoDocuments, s.UserSessionRotate(ctx, &reused, userSessions[0].RefreshToken))
+
+	session, err := s.UserSessionGet(ctx, userSessions[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, rotated.RefreshToken, session.RefreshToken)
+	require.Equal(t, rotated.LastSeenAt, session.LastSeenAt)
+}
+
+func TestUserSessionTouch(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		expected    error
+	}{
+		{
+			description: "fails when the session is not found",
+			id:          "6f1b2c3d-0c1b-4d7e-8f3a-000000000000",
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the session is found",
+			id:          "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := range userSessions {
+				require.NoError(t, s.UserSessionSave(ctx, &userSessions[i]))
+			}
+
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			err := s.UserSessionTouch(ctx, tc.id, time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC))
+			require.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestUserSessionDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		userID      string
+		id          string
+		expected    error
+	}{
+		{
+			description: "fails when the session belongs to another user",
+			userID:      "608f32a2c7351f001f6475e0",
+			id:          "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the session is found",
+			userID:      "507f1f77bcf86cd799439011",
+			id:          "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := range userSessions {
+				require.NoError(t, s.UserSessionSave(ctx, &userSessions[i]))
+			}
+
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			err := s.UserSessionDelete(ctx, tc.userID, tc.id)
+			require.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestUserSessionDeleteAll(t *testing.T) {
+	ctx := context.Background()
+
+	for i := range userSessions {
+		require.NoError(t, s.UserSessionSave(ctx, &userSessions[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	require.NoError(t, s.UserSessionDeleteAll(ctx, "507f1f77bcf86cd799439011"))
+
+	sessions, err := s.UserSessionList(ctx, "507f1f77bcf86cd799439011")
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	sessions, err = s.UserSessionList(ctx, "608f32a2c7351f001f6475e0")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
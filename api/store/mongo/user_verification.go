@@ -0,0 +1,50 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) UserVerificationSave(ctx context.Context, verification *models.UserVerification) error {
+	if _, err := s.db.Collection("user_verifications").ReplaceOne(
+		ctx,
+		bson.M{"_id": verification.UserID},
+		verification,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserVerificationGetByDigest(ctx context.Context, digest string, at time.Time) (*models.UserVerification, error) {
+	// NOTICE: the expired verifications are only removed periodically by the TTL index, so they are filtered out here.
+	verification := new(models.UserVerification)
+	if err := s.db.Collection("user_verifications").FindOne(ctx, bson.M{
+		"digest":     digest,
+		"expires_at": bson.M{"$gt": at},
+	}).Decode(verification); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return verification, nil
+}
+
+func (s *Store) UserVerificationDelete(ctx context.Context, userID string) error {
+	res, err := s.db.Collection("user_verifications").DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -51,6 +51,10 @@ func FromMongoError(err error) error {
 		return store.ErrInvalidHex
 	case mongo.IsDuplicateKeyError(err):
 		return store.ErrDuplicate
+	case mongo.IsTimeout(err):
+		// NOTICE: a timeout is returned either when the operation has waited too long for a connection from the pool or
+		// when it has exceeded its own timeout; both mean the database can't keep up with the requests.
+		return errors.Wrap(store.ErrUnavailable, err)
 	default:
 		if err == nil {
 			return nil
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// apiKeyColumns are the columns of the api_keys table, in the order scanned by scanAPIKey.
+const apiKeyColumns = `id, name, tenant_id, role, created_by, created_at, updated_at, expires_in`
+
+// apiKeySorterColumns are the API keys' fields that they may be sorted by.
+var apiKeySorterColumns = queries.Columns{
+	"name":       queries.KindScalar,
+	"role":       queries.KindScalar,
+	"created_by": queries.KindScalar,
+	"created_at": queries.KindScalar,
+	"updated_at": queries.KindScalar,
+	"expires_in": queries.KindScalar,
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	apiKey := new(models.APIKey)
+	if err := row.Scan(&apiKey.ID, &apiKey.Name, &apiKey.TenantID, &apiKey.Role, &apiKey.CreatedBy, &apiKey.CreatedAt, &apiKey.UpdatedAt, &apiKey.ExpiresIn); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return apiKey, nil
+}
+
+func (s *Store) APIKeyCreate(ctx context.Context, apiKey *models.APIKey) (string, error) {
+	now := clock.Now()
+	apiKey.CreatedAt = now
+	apiKey.UpdatedAt = now
+
+	var id string
+	if err := s.db(ctx).QueryRow(ctx, `
+		INSERT INTO api_keys (`+apiKeyColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`,
+		apiKey.ID, apiKey.Name, apiKey.TenantID, string(apiKey.Role), apiKey.CreatedBy, apiKey.CreatedAt, apiKey.UpdatedAt, apiKey.ExpiresIn,
+	).Scan(&id); err != nil {
+		return "", FromPostgresError(err)
+	}
+
+	return id, nil
+}
+
+func (s *Store) APIKeyGet(ctx context.Context, id string) (*models.APIKey, error) {
+	return scanAPIKey(s.db(ctx).QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1`, id))
+}
+
+func (s *Store) APIKeyGetByName(ctx context.Context, tenantID string, name string) (*models.APIKey, error) {
+	return scanAPIKey(s.db(ctx).QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE tenant_id = $1 AND name = $2`, tenantID, name))
+}
+
+func (s *Store) APIKeyConflicts(ctx context.Context, tenantID string, target *models.APIKeyConflicts) ([]string, bool, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT id, name FROM api_keys WHERE tenant_id = $1 AND (id = $2 OR name = $3)`, tenantID, target.ID, target.Name)
+	if err != nil {
+		return nil, false, FromPostgresError(err)
+	}
+
+	apiKeys, err := collect(rows, func(row pgx.Row) (*models.APIKeyConflicts, error) {
+		apiKey := new(models.APIKeyConflicts)
+		if err := row.Scan(&apiKey.ID, &apiKey.Name); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return apiKey, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	conflicts := make([]string, 0)
+	for _, apiKey := range apiKeys {
+		if apiKey.ID == target.ID {
+			conflicts = append(conflicts, "id")
+		}
+
+		if apiKey.Name == target.Name {
+			conflicts = append(conflicts, "name")
+		}
+	}
+
+	return conflicts, len(conflicts) > 0, nil
+}
+
+func (s *Store) APIKeyList(ctx context.Context, tenantID string, paginator query.Paginator, sorter query.Sorter) ([]models.APIKey, int, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM api_keys WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.APIKey{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_keys WHERE tenant_id = $1`+queries.FromSorter(&sorter, apiKeySorterColumns, "created_at")+queries.FromPaginator(&paginator),
+		tenantID,
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	apiKeys, err := collect(rows, scanAPIKey)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return apiKeys, count, nil
+}
+
+func (s *Store) APIKeyListByCreator(ctx context.Context, tenantID, userID string, since time.Time) ([]models.APIKey, error) {
+	rows, err := s.db(ctx).Query(
+		ctx,
+		`SELECT `+apiKeyColumns+` FROM api_keys WHERE tenant_id = $1 AND created_by = $2 AND created_at >= $3 ORDER BY created_at DESC`,
+		tenantID, userID, since,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanAPIKey)
+}
+
+func (s *Store) APIKeyUpdate(ctx context.Context, tenantID, name string, changes *models.APIKeyChanges) error {
+	changes.UpdatedAt = clock.Now()
+
+	// NOTICE: the changes' zero values are ignored, as the Mongo store omits them from the update.
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE api_keys SET
+			updated_at = $3,
+			name = COALESCE(NULLIF($4, ''), name),
+			role = COALESCE(NULLIF($5, ''), role)
+		WHERE tenant_id = $1 AND name = $2`,
+		tenantID, name, changes.UpdatedAt, changes.Name, string(changes.Role),
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) APIKeyDelete(ctx context.Context, tenantID, name string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM api_keys WHERE tenant_id = $1 AND name = $2`, tenantID, name)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
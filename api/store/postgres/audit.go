@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// auditEntryColumns are the columns of the audit_entries table, in the order scanned by scanAuditEntry.
+const auditEntryColumns = `id, tenant_id, actor_type, actor_id, actor_username, action, target_type, target_id, details, source_ip, created_at`
+
+func scanAuditEntry(row pgx.Row) (*models.AuditEntry, error) {
+	entry := new(models.AuditEntry)
+	if err := row.Scan(
+		&entry.ID,
+		&entry.TenantID,
+		&entry.Actor.Type,
+		&entry.Actor.ID,
+		&entry.Actor.Username,
+		&entry.Action,
+		&entry.Target.Type,
+		&entry.Target.ID,
+		&entry.Details,
+		&entry.SourceIP,
+		&entry.CreatedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return entry, nil
+}
+
+func (s *Store) AuditEntryCreate(ctx context.Context, entry *models.AuditEntry) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO audit_entries (`+auditEntryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		entry.ID,
+		entry.TenantID,
+		string(entry.Actor.Type),
+		entry.Actor.ID,
+		entry.Actor.Username,
+		string(entry.Action),
+		string(entry.Target.Type),
+		entry.Target.ID,
+		entry.Details,
+		entry.SourceIP,
+		entry.CreatedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) AuditEntryList(ctx context.Context, tenantID string, filter models.AuditEntryFilter, paginator query.Paginator) ([]models.AuditEntry, int, error) {
+	args := queries.NewArgs(tenantID)
+
+	where := `tenant_id = $1`
+	if filter.Action != "" {
+		where += ` AND action = ` + args.Add(string(filter.Action))
+	}
+
+	if filter.ActorID != "" {
+		where += ` AND actor_id = ` + args.Add(filter.ActorID)
+	}
+
+	if filter.TargetType != "" {
+		where += ` AND target_type = ` + args.Add(string(filter.TargetType))
+	}
+
+	if filter.TargetID != "" {
+		where += ` AND target_id = ` + args.Add(filter.TargetID)
+	}
+
+	if filter.From != nil {
+		where += ` AND created_at >= ` + args.Add(*filter.From)
+	}
+
+	if filter.To != nil {
+		where += ` AND created_at <= ` + args.Add(*filter.To)
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM audit_entries WHERE `+where, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.AuditEntry{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+auditEntryColumns+` FROM audit_entries WHERE `+where+` ORDER BY created_at DESC`+queries.FromPaginator(&paginator), args.Values()...)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	entries, err := collect(rows, scanAuditEntry)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, count, nil
+}
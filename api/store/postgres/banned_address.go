@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// NOTICE: the temporary bans are pruned once they expire, as the Mongo store's TTL index does; the bans set by the
+// instance's administrator have no expiration and are kept until removed.
+
+func (s *Store) BannedAddressList(ctx context.Context) ([]models.BannedAddress, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT address, reason, created_at, expires_at FROM banned_addresses
+		WHERE expires_at IS NULL OR expires_at > now()
+		ORDER BY address ASC`,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, func(row pgx.Row) (*models.BannedAddress, error) {
+		ban := new(models.BannedAddress)
+		if err := row.Scan(&ban.Address, &ban.Reason, &ban.CreatedAt, &ban.ExpiresAt); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return ban, nil
+	})
+}
+
+func (s *Store) BannedAddressSave(ctx context.Context, ban *models.BannedAddress) error {
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM banned_addresses WHERE expires_at <= now()`); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO banned_addresses (address, reason, created_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (address) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at`,
+		ban.Address, ban.Reason, ban.CreatedAt, ban.ExpiresAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) BannedAddressDelete(ctx context.Context, address string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM banned_addresses WHERE address = $1 AND (expires_at IS NULL OR expires_at > now())`, address)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
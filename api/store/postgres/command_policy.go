@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// commandPolicyColumns are the columns of the command_policies table, in the order scanned by scanCommandPolicy.
+const commandPolicyColumns = `id, tenant_id, name, tags, mode, patterns, action, created_at, updated_at`
+
+func scanCommandPolicy(row pgx.Row) (*models.CommandPolicy, error) {
+	policy := new(models.CommandPolicy)
+	if err := row.Scan(
+		&policy.ID,
+		&policy.TenantID,
+		&policy.Name,
+		&policy.Tags,
+		&policy.Mode,
+		&policy.Patterns,
+		&policy.Action,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return policy, nil
+}
+
+// commandPolicyEvaluationColumns are the columns of the command_policy_evaluations table, in the order scanned by
+// scanCommandPolicyEvaluation.
+const commandPolicyEvaluationColumns = `id, tenant_id, policy_id, policy_name, session_uid, device_uid, username, command, action, time`
+
+func scanCommandPolicyEvaluation(row pgx.Row) (*models.CommandPolicyEvaluation, error) {
+	evaluation := new(models.CommandPolicyEvaluation)
+	if err := row.Scan(
+		&evaluation.ID,
+		&evaluation.TenantID,
+		&evaluation.PolicyID,
+		&evaluation.PolicyName,
+		&evaluation.SessionUID,
+		&evaluation.DeviceUID,
+		&evaluation.Username,
+		&evaluation.Command,
+		&evaluation.Action,
+		&evaluation.Time,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return evaluation, nil
+}
+
+func (s *Store) CommandPolicyList(ctx context.Context, tenantID string) ([]models.CommandPolicy, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+commandPolicyColumns+` FROM command_policies WHERE tenant_id = $1 ORDER BY created_at ASC`, tenantID)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanCommandPolicy)
+}
+
+func (s *Store) CommandPolicyGet(ctx context.Context, tenantID, id string) (*models.CommandPolicy, error) {
+	return scanCommandPolicy(s.db(ctx).QueryRow(ctx, `SELECT `+commandPolicyColumns+` FROM command_policies WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+}
+
+func (s *Store) CommandPolicyCreate(ctx context.Context, policy *models.CommandPolicy) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO command_policies (`+commandPolicyColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		policy.ID,
+		policy.TenantID,
+		policy.Name,
+		nonNil(policy.Tags),
+		string(policy.Mode),
+		nonNil(policy.Patterns),
+		string(policy.Action),
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) CommandPolicyUpdate(ctx context.Context, policy *models.CommandPolicy) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE command_policies SET name = $3, tags = $4, mode = $5, patterns = $6, action = $7, updated_at = $8
+		WHERE id = $1 AND tenant_id = $2`,
+		policy.ID,
+		policy.TenantID,
+		policy.Name,
+		nonNil(policy.Tags),
+		string(policy.Mode),
+		nonNil(policy.Patterns),
+		string(policy.Action),
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) CommandPolicyDelete(ctx context.Context, tenantID, id string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM command_policies WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) CommandPolicyEvaluationCreate(ctx context.Context, evaluation *models.CommandPolicyEvaluation) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO command_policy_evaluations (`+commandPolicyEvaluationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		evaluation.ID,
+		evaluation.TenantID,
+		evaluation.PolicyID,
+		evaluation.PolicyName,
+		evaluation.SessionUID,
+		evaluation.DeviceUID,
+		evaluation.Username,
+		evaluation.Command,
+		string(evaluation.Action),
+		evaluation.Time,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) CommandPolicyEvaluationList(ctx context.Context, tenantID string, paginator query.Paginator) ([]models.CommandPolicyEvaluation, int, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM command_policy_evaluations WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.CommandPolicyEvaluation{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+commandPolicyEvaluationColumns+` FROM command_policy_evaluations
+		WHERE tenant_id = $1
+		ORDER BY time DESC`+queries.FromPaginator(&paginator),
+		tenantID,
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	evaluations, err := collect(rows, scanCommandPolicyEvaluation)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return evaluations, count, nil
+}
@@ -0,0 +1,19 @@
+//go:build contract
+
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store/storetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContract(t *testing.T) {
+	storetest.Run(t, s, func(t *testing.T, tc storetest.Case) {
+		require.NoError(t, srv.Apply(tc.Fixtures...))
+		t.Cleanup(func() {
+			require.NoError(t, srv.Reset())
+		})
+	})
+}
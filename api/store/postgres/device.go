@@ -0,0 +1,1049 @@
+package postgres
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/geohash"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// deviceColumns are the columns of the devices table, in the order scanned by scanDevice.
+var deviceColumns = []string{
+	"uid", "name", "identity", "info", "public_key", "tenant_id", "last_seen", "status", "status_updated_at",
+	"created_at", "remote_addr", "latitude", "longitude", "geohash", "tags", "groups", "public_url",
+	"public_url_address", "acceptable", "compromised", "addresses", "claim_code", "login_shell", "remote_access",
+	"connection_note", "limit_exempt", "connection_samples", "connection_quality", "queue", "config", "config_version",
+}
+
+// deviceFilterColumns are the devices' fields that the listed devices may be filtered and sorted by.
+var deviceFilterColumns = queries.Columns{
+	"uid":                queries.KindScalar,
+	"name":               queries.KindScalar,
+	"identity":           queries.KindJSON,
+	"info":               queries.KindJSON,
+	"public_key":         queries.KindScalar,
+	"tenant_id":          queries.KindScalar,
+	"last_seen":          queries.KindScalar,
+	"online":             queries.KindScalar,
+	"namespace":          queries.KindScalar,
+	"status":             queries.KindScalar,
+	"status_updated_at":  queries.KindScalar,
+	"created_at":         queries.KindScalar,
+	"remote_addr":        queries.KindScalar,
+	"tags":               queries.KindArray,
+	"groups":             queries.KindArray,
+	"public_url":         queries.KindScalar,
+	"public_url_address": queries.KindScalar,
+	"acceptable":         queries.KindScalar,
+	"compromised":        queries.KindScalar,
+	"claim_code":         queries.KindScalar,
+	"login_shell":        queries.KindScalar,
+	"remote_access":      queries.KindScalar,
+	"connection_note":    queries.KindScalar,
+	"limit_exempt":       queries.KindScalar,
+	"connection_quality": queries.KindJSON,
+	"queue":              queries.KindJSON,
+	"config":             queries.KindJSON,
+	"config_version":     queries.KindScalar,
+}
+
+// selectDevice returns the columns of the devices table, prefixed by the table's alias. The columns on replace are
+// selected from their expressions instead.
+func selectDevice(alias string, replace map[string]string) string {
+	columns := make([]string, len(deviceColumns))
+	for i, column := range deviceColumns {
+		if expr, ok := replace[column]; ok {
+			columns[i] = expr + " AS " + column
+		} else if alias != "" {
+			columns[i] = alias + "." + column
+		} else {
+			columns[i] = column
+		}
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// deviceOnline returns the expression that reports whether the device aliased as d is online at the time on the
+// placeholder now, which is when it is connected and isn't scheduled to be offline before it.
+func deviceOnline(now string) string {
+	return fmt.Sprintf(
+		`EXISTS (SELECT 1 FROM connected_devices AS c WHERE c.uid = d.uid AND c.last_seen > now() - %s AND (c.offline_at IS NULL OR c.offline_at > %s))`,
+		connectedDeviceTTL, now,
+	)
+}
+
+// scanDevice scans a row whose first columns are the deviceColumns, followed by the columns scanned into extra.
+func (s *Store) scanDevice(row pgx.Row, extra ...any) (*models.Device, error) {
+	device := new(models.Device)
+
+	var latitude, longitude *float64
+	var hash *string
+
+	dest := []any{
+		&device.UID, &device.Name, &device.Identity, &device.Info, &device.PublicKey, &device.TenantID,
+		&device.LastSeen, &device.Status, &device.StatusUpdatedAt, &device.CreatedAt, &device.RemoteAddr, &latitude,
+		&longitude, &hash, &device.Tags, &device.Groups, &device.PublicURL, &device.PublicURLAddress,
+		&device.Acceptable, &device.Compromised, &device.Addresses, &device.ClaimCode, &device.LoginShell,
+		&device.RemoteAccess, &device.ConnectionNote, &device.LimitExempt, &device.ConnectionSamples,
+		&device.ConnectionQuality, &device.Queue, &device.Config, &device.ConfigVersion,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	if latitude != nil && longitude != nil {
+		device.Position = &models.DevicePosition{Latitude: *latitude, Longitude: *longitude}
+		if hash != nil {
+			device.Position.Geohash = *hash
+		}
+	}
+
+	// NOTICE: the Mongo store omits the empty arrays, which are decoded as nil.
+	if len(device.Groups) == 0 {
+		device.Groups = nil
+	}
+
+	if len(device.Addresses) == 0 {
+		device.Addresses = nil
+	}
+
+	remoteAddr, err := s.cipher.Decrypt(device.RemoteAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	device.RemoteAddr = remoteAddr
+
+	for i, address := range device.Addresses {
+		remoteAddr, err := s.cipher.Decrypt(address.RemoteAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		device.Addresses[i].RemoteAddr = remoteAddr
+	}
+
+	if len(device.ConnectionSamples) == 0 {
+		device.ConnectionSamples = nil
+	}
+
+	return device, nil
+}
+
+// scanDeviceJoined scans a device selected with its online status and its namespace's name.
+func (s *Store) scanDeviceJoined(row pgx.Row) (*models.Device, error) {
+	var online bool
+	var namespace string
+
+	device, err := s.scanDevice(row, &online, &namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	device.Online = online
+	device.Namespace = namespace
+
+	return device, nil
+}
+
+// devicePosition returns the position's columns, which are nil when the position is.
+func devicePosition(position *models.DevicePosition) (latitude, longitude *float64, hash *string) {
+	if position == nil {
+		return nil, nil, nil
+	}
+
+	latitude, longitude = &position.Latitude, &position.Longitude
+	if position.Geohash != "" {
+		hash = &position.Geohash
+	}
+
+	return latitude, longitude, hash
+}
+
+// DeviceList returns a list of devices based on the given filters, pagination and sorting.
+func (s *Store) DeviceList(ctx context.Context, status models.DeviceStatus, paginator query.Paginator, filters query.Filters, sorter query.Sorter, acceptable store.DeviceAcceptable) ([]models.Device, int, error) {
+	args := queries.NewArgs(clock.Now())
+
+	replace := map[string]string{}
+
+	// When the listing mode is [store.DeviceAcceptableFromRemoved], we should evaluate the `removed_devices` table to
+	// check its `acceptable` status.
+	switch acceptable {
+	case store.DeviceAcceptableFromRemoved:
+		replace["acceptable"] = `(d.status <> 'accepted' AND (d.limit_exempt OR EXISTS (SELECT 1 FROM removed_devices AS r WHERE r.uid = d.uid AND r.timestamp > now() - ` + deviceRemovedTTL + `)))`
+	case store.DeviceAcceptableAsFalse:
+		// NOTICE: the devices exempt from the namespace's maximum number of devices are still acceptable.
+		replace["acceptable"] = `(d.status <> 'accepted' AND d.limit_exempt)`
+	case store.DeviceAcceptableIfNotAccepted:
+		replace["acceptable"] = `(d.status <> 'accepted')`
+	}
+
+	where := []string{"TRUE"}
+
+	// Only match for the respective tenant if requested, along with the devices shared with it.
+	var shared map[string]*models.DeviceShare
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		var err error
+		if shared, err = s.sharedDevices(ctx, tenant.ID, clock.Now()); err != nil {
+			return nil, 0, err
+		}
+
+		match := "d.tenant_id = " + args.Add(tenant.ID)
+		if len(shared) > 0 {
+			uids := make([]string, 0, len(shared))
+			for uid := range shared {
+				uids = append(uids, uid)
+			}
+
+			match = "(" + match + " OR d.uid = ANY(" + args.Add(uids) + "))"
+		}
+
+		where = append(where, match)
+	}
+
+	if status != "" {
+		where = append(where, "d.status = "+args.Add(string(status)))
+	}
+
+	devices := fmt.Sprintf(
+		`SELECT %s, %s AS online, n.name AS namespace FROM devices AS d JOIN namespaces AS n ON n.tenant_id = d.tenant_id WHERE %s`,
+		selectDevice("d", replace), deviceOnline("$1"), strings.Join(where, " AND "),
+	)
+
+	filter, err := queries.FromFilters(&filters, deviceFilterColumns, args)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	if filter != "" {
+		devices = fmt.Sprintf(`SELECT * FROM (%s) AS devices WHERE %s`, devices, filter)
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM (`+devices+`) AS devices`, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if sorter.By == "" {
+		sorter.By = "last_seen"
+	}
+
+	rows, err := s.db(ctx).Query(
+		ctx,
+		fmt.Sprintf(`SELECT * FROM (%s) AS devices`, devices)+queries.FromSorter(&sorter, deviceFilterColumns, "last_seen")+queries.FromPaginator(&paginator),
+		args.Values()...,
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	list, err := collect(rows, s.scanDeviceJoined)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range list {
+		if share, ok := shared[list[i].UID]; ok && share.TenantID == list[i].TenantID {
+			list[i].Shared = share
+		}
+	}
+
+	return list, count, nil
+}
+
+func (s *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device, error) {
+	args := queries.NewArgs(clock.Now(), string(uid))
+
+	where := "d.uid = $2"
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		where += " AND d.tenant_id = " + args.Add(tenant.ID)
+	}
+
+	return s.scanDeviceJoined(s.db(ctx).QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`SELECT %s, %s AS online, n.name AS namespace FROM devices AS d JOIN namespaces AS n ON n.tenant_id = d.tenant_id WHERE %s`,
+			selectDevice("d", nil), deviceOnline("$1"), where,
+		),
+		args.Values()...,
+	))
+}
+
+func (s *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
+	return s.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err := s.db(ctx).Exec(ctx, `DELETE FROM devices WHERE uid = $1`, string(uid))
+		if err != nil {
+			return FromPostgresError(err)
+		}
+
+		if res.RowsAffected() < 1 {
+			return store.ErrNoDocuments
+		}
+
+		if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+			logrus.WithContext(ctx).Error(err)
+		}
+
+		if _, err := s.db(ctx).Exec(ctx, `DELETE FROM sessions WHERE device_uid = $1`, string(uid)); err != nil {
+			return FromPostgresError(err)
+		}
+
+		if _, err := s.db(ctx).Exec(ctx, `DELETE FROM connected_devices WHERE uid = $1`, string(uid)); err != nil {
+			return FromPostgresError(err)
+		}
+
+		if _, err := s.db(ctx).Exec(ctx, `DELETE FROM device_shares WHERE uid = $1`, string(uid)); err != nil {
+			return FromPostgresError(err)
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) DeviceCreate(ctx context.Context, d models.Device, hostname string) error {
+	if hostname == "" {
+		hostname = strings.ReplaceAll(d.Identity.MAC, ":", "-")
+	}
+
+	latitude, longitude, hash := devicePosition(d.Position)
+
+	remoteAddr, err := s.cipher.Encrypt(d.RemoteAddr)
+	if err != nil {
+		return err
+	}
+
+	// NOTICE: as the Mongo store, the name, status, claim code and configuration's version are only overwritten when
+	// they are set, while the device's identity, information, key, address and position are always.
+	_, err = s.db(ctx).Exec(ctx, `
+		INSERT INTO devices (
+			uid, name, identity, info, public_key, tenant_id, last_seen, remote_addr, latitude, longitude, geohash,
+			claim_code, config_version, status, status_updated_at, created_at, tags
+		)
+		VALUES (
+			$1, COALESCE(NULLIF($2, ''), $3), $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+			COALESCE(NULLIF($15, ''), 'pending'), $16, $16, '{}'
+		)
+		ON CONFLICT (uid) DO UPDATE SET
+			identity = EXCLUDED.identity,
+			info = EXCLUDED.info,
+			public_key = EXCLUDED.public_key,
+			tenant_id = EXCLUDED.tenant_id,
+			last_seen = EXCLUDED.last_seen,
+			remote_addr = EXCLUDED.remote_addr,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			geohash = EXCLUDED.geohash,
+			name = CASE WHEN $2 <> '' THEN EXCLUDED.name ELSE devices.name END,
+			claim_code = CASE WHEN $13 <> '' THEN EXCLUDED.claim_code ELSE devices.claim_code END,
+			config_version = CASE WHEN $14 <> 0 THEN EXCLUDED.config_version ELSE devices.config_version END,
+			status = CASE WHEN $15 <> '' THEN EXCLUDED.status ELSE devices.status END`,
+		d.UID, d.Name, hostname, d.Identity, d.Info, d.PublicKey, d.TenantID, d.LastSeen, remoteAddr, latitude,
+		longitude, hash, d.ClaimCode, d.ConfigVersion, string(d.Status), clock.Now(),
+	)
+
+	return FromPostgresError(err)
+}
+
+func (s *Store) DeviceRename(ctx context.Context, uid models.UID, hostname string) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET name = $2 WHERE uid = $1`, string(uid), hostname)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return s.sessionsSetDeviceName(ctx, uid, hostname)
+}
+
+func (s *Store) DeviceLookup(ctx context.Context, namespace, hostname string) (*models.Device, error) {
+	var tenantID string
+	if err := s.db(ctx).QueryRow(ctx, `SELECT tenant_id FROM namespaces WHERE name = $1`, namespace).Scan(&tenantID); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return s.scanDevice(s.db(ctx).QueryRow(
+		ctx,
+		`SELECT `+selectDevice("", nil)+` FROM devices WHERE tenant_id = $1 AND name = $2 AND status = 'accepted' LIMIT 1`,
+		tenantID, hostname,
+	))
+}
+
+func (s *Store) DeviceHeartbeatBulk(ctx context.Context, heartbeats []models.ConnectedDevice) ([]models.ConnectedDevice, error) {
+	// NOTICE: a device sends many heartbeats while a batch is buffered, which are reduced to the latest one, so each
+	// device is written once per batch.
+	latest := make([]models.ConnectedDevice, 0, len(heartbeats))
+	positions := make(map[string]int, len(heartbeats))
+	for _, h := range heartbeats {
+		if i, ok := positions[h.UID]; ok {
+			if h.LastSeen.After(latest[i].LastSeen) {
+				latest[i] = h
+			}
+
+			continue
+		}
+
+		positions[h.UID] = len(latest)
+		latest = append(latest, h)
+	}
+
+	if len(latest) == 0 {
+		return []models.ConnectedDevice{}, nil
+	}
+
+	uids := make([]string, len(latest))
+	tenants := make([]string, len(latest))
+	seen := make([]time.Time, len(latest))
+	for i, d := range latest {
+		uids[i], tenants[i], seen[i] = d.UID, d.TenantID, d.LastSeen
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM connected_devices WHERE last_seen <= now() - `+connectedDeviceTTL); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	// NOTICE: the device's last seen isn't written when the heartbeat is older than it.
+	if _, err := s.db(ctx).Exec(ctx, `
+		UPDATE devices AS d SET last_seen = h.last_seen
+		FROM unnest($1::text[], $2::timestamptz[]) AS h (uid, last_seen)
+		WHERE d.uid = h.uid AND d.last_seen < h.last_seen`,
+		uids, seen,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	// NOTICE: a heartbeat cancels a scheduled offline, keeping the time when the device got online. The rows inserted,
+	// instead of updated, are the devices that got online, which have no previous transaction on their xmax.
+	rows, err := s.db(ctx).Query(ctx, `
+		INSERT INTO connected_devices (uid, tenant_id, last_seen, connected_at)
+		SELECT uid, tenant_id, last_seen, last_seen FROM unnest($1::text[], $2::text[], $3::timestamptz[]) AS h (uid, tenant_id, last_seen)
+		ON CONFLICT (uid) DO UPDATE SET
+			last_seen = GREATEST(connected_devices.last_seen, EXCLUDED.last_seen),
+			offline_at = NULL
+		RETURNING uid, (xmax = 0) AS inserted`,
+		uids, tenants, seen,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	defer rows.Close()
+
+	inserted := make(map[string]bool, len(latest))
+	for rows.Next() {
+		var uid string
+		var ok bool
+		if err := rows.Scan(&uid, &ok); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		inserted[uid] = ok
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	connected := make([]models.ConnectedDevice, 0, len(inserted))
+	for _, d := range latest {
+		if inserted[d.UID] {
+			connected = append(connected, d)
+		}
+	}
+
+	return connected, nil
+}
+
+func (s *Store) DeviceSetOffline(ctx context.Context, uid string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM connected_devices WHERE uid = $1`, uid)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// connectedDeviceColumns are the columns of the connected_devices table, in the order scanned by scanConnectedDevice.
+const connectedDeviceColumns = `uid, tenant_id, last_seen, connected_at, offline_at`
+
+func scanConnectedDevice(row pgx.Row) (*models.ConnectedDevice, error) {
+	connected := new(models.ConnectedDevice)
+	if err := row.Scan(&connected.UID, &connected.TenantID, &connected.LastSeen, &connected.ConnectedAt, &connected.OfflineAt); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return connected, nil
+}
+
+func (s *Store) DeviceGetConnected(ctx context.Context, uid string) (*models.ConnectedDevice, error) {
+	return scanConnectedDevice(s.db(ctx).QueryRow(ctx, `SELECT `+connectedDeviceColumns+` FROM connected_devices WHERE uid = $1 AND last_seen > now() - `+connectedDeviceTTL, uid))
+}
+
+func (s *Store) DeviceSetOfflineAt(ctx context.Context, uid string, at time.Time) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE connected_devices SET offline_at = $2 WHERE uid = $1`, uid, at)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetOfflineExpired(ctx context.Context, now time.Time) ([]models.ConnectedDevice, error) {
+	// NOTICE: the device may send a heartbeat while the expired ones are deleted, cancelling its scheduled offline; the
+	// condition is evaluated on each deleted row, so it isn't deleted then.
+	rows, err := s.db(ctx).Query(ctx, `DELETE FROM connected_devices WHERE offline_at <= $1 RETURNING `+connectedDeviceColumns, now)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanConnectedDevice)
+}
+
+// DeviceUpdateOnline checks the device exists. Unlike the Mongo store, the device's online status isn't kept on the
+// device, as it is derived from the connected_devices table whenever the device is read.
+func (s *Store) DeviceUpdateOnline(ctx context.Context, uid models.UID, _ bool) error {
+	var exists bool
+	if err := s.db(ctx).QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM devices WHERE uid = $1)`, string(uid)).Scan(&exists); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if !exists {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts time.Time) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET last_seen = $2 WHERE uid = $1`, string(uid), ts)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// DeviceUpdateStatus updates the status of a specific device in the devices table
+func (s *Store) DeviceUpdateStatus(ctx context.Context, uid models.UID, status models.DeviceStatus) error {
+	// NOTICE: only the pending devices are kept on the acceptance queue.
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE devices SET status = $2, status_updated_at = $3, queue = CASE WHEN $2 = 'pending' THEN queue END
+		WHERE uid = $1`,
+		string(uid), string(status), clock.Now(),
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceListByTenant(ctx context.Context, tenantID string) ([]models.Device, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT uid, tenant_id, name, tags, info FROM devices WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, func(row pgx.Row) (*models.Device, error) {
+		device := new(models.Device)
+		if err := row.Scan(&device.UID, &device.TenantID, &device.Name, &device.Tags, &device.Info); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return device, nil
+	})
+}
+
+func (s *Store) DeviceListIdentities(ctx context.Context) ([]models.Device, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT uid, tenant_id, identity, public_key FROM devices`)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, func(row pgx.Row) (*models.Device, error) {
+		device := new(models.Device)
+		if err := row.Scan(&device.UID, &device.TenantID, &device.Identity, &device.PublicKey); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return device, nil
+	})
+}
+
+func (s *Store) DeviceListByUsage(ctx context.Context, tenant string) ([]models.UID, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT device_uid FROM sessions
+		WHERE tenant_id = $1
+		GROUP BY device_uid
+		ORDER BY count(*) DESC
+		LIMIT 3`,
+		tenant,
+	)
+	if err != nil {
+		return make([]models.UID, 0), FromPostgresError(err)
+	}
+
+	return collect(rows, func(row pgx.Row) (*models.UID, error) {
+		var uid models.UID
+		if err := row.Scan(&uid); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return &uid, nil
+	})
+}
+
+func (s *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	statement := `SELECT ` + selectDevice("", nil) + ` FROM devices WHERE tenant_id = $1 AND identity = jsonb_build_object('mac', $2::text)`
+	args := []any{tenantID, mac}
+
+	if status != "" {
+		statement += ` AND status = $3`
+		args = append(args, string(status))
+	}
+
+	return s.scanDevice(s.db(ctx).QueryRow(ctx, statement+` LIMIT 1`, args...))
+}
+
+func (s *Store) DeviceGetByClaimCode(ctx context.Context, code string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	return s.scanDevice(s.db(ctx).QueryRow(
+		ctx,
+		`SELECT `+selectDevice("", nil)+` FROM devices WHERE tenant_id = $1 AND claim_code = $2 AND status = $3 LIMIT 1`,
+		tenantID, code, string(status),
+	))
+}
+
+func (s *Store) DeviceGetByName(ctx context.Context, name string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	return s.scanDevice(s.db(ctx).QueryRow(
+		ctx,
+		`SELECT `+selectDevice("", nil)+` FROM devices WHERE tenant_id = $1 AND name = $2 AND status = $3 LIMIT 1`,
+		tenantID, name, string(status),
+	))
+}
+
+func (s *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error) {
+	var device *models.Device
+	if err := s.cache.Get(ctx, strings.Join([]string{"device", string(uid)}, "/"), &device); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	if device != nil {
+		return device, nil
+	}
+
+	device, err := s.scanDevice(s.db(ctx).QueryRow(
+		ctx,
+		`SELECT `+selectDevice("", nil)+` FROM devices WHERE tenant_id = $1 AND uid = $2`,
+		tenantID, string(uid),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, strings.Join([]string{"device", string(uid)}, "/"), device, time.Minute); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return device, nil
+}
+
+func (s *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error {
+	latitude, longitude, hash := devicePosition(&position)
+
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET latitude = $2, longitude = $3, geohash = $4 WHERE uid = $1`, string(uid), latitude, longitude, hash)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DevicePositionClusters(ctx context.Context, tenantID string, box geohash.Box, precision int) ([]models.DevicePositionCluster, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT substr(geohash, 1, $2) AS cell, avg(latitude), avg(longitude), count(*), CASE WHEN count(*) = 1 THEN min(uid) ELSE '' END
+		FROM devices
+		WHERE tenant_id = $1
+			AND status = 'accepted'
+			AND geohash IS NOT NULL
+			AND latitude BETWEEN $3 AND $4
+			AND longitude BETWEEN $5 AND $6
+		GROUP BY cell
+		ORDER BY cell`,
+		tenantID, precision, box.South, box.North, box.West, box.East,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, func(row pgx.Row) (*models.DevicePositionCluster, error) {
+		cluster := new(models.DevicePositionCluster)
+		if err := row.Scan(&cluster.Geohash, &cluster.Latitude, &cluster.Longitude, &cluster.Count, &cluster.Device); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return cluster, nil
+	})
+}
+
+func (s *Store) DeviceAddAddress(ctx context.Context, uid models.UID, address models.DeviceAddress) error {
+	remoteAddr, err := s.cipher.Encrypt(address.RemoteAddr)
+	if err != nil {
+		return err
+	}
+
+	address.RemoteAddr = remoteAddr
+
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE devices SET addresses = (
+			SELECT COALESCE(jsonb_agg(element ORDER BY position), '[]')
+			FROM jsonb_array_elements(addresses || jsonb_build_array($2::jsonb)) WITH ORDINALITY AS a (element, position)
+			WHERE position > jsonb_array_length(addresses) + 1 - $3
+		)
+		WHERE uid = $1`,
+		string(uid), address, models.DeviceAddressesMax,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceAddConnectionSample(ctx context.Context, uid models.UID, sample models.DeviceConnectionSample, quality *models.DeviceConnectionQuality) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE devices SET connection_samples = (
+			SELECT COALESCE(jsonb_agg(element ORDER BY position), '[]')
+			FROM jsonb_array_elements(connection_samples || jsonb_build_array($2::jsonb)) WITH ORDINALITY AS a (element, position)
+			WHERE position > jsonb_array_length(connection_samples) + 1 - $3
+		), connection_quality = $4
+		WHERE uid = $1`,
+		string(uid), sample, models.DeviceConnectionSamplesMax, quality,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
+	_, err := s.db(ctx).Exec(ctx, `
+		UPDATE devices SET status = 'pending'
+		WHERE status = 'accepted' AND tenant_id = $1 AND NOT (uid = ANY($2::text[]))`,
+		tenantID, nonNil(chosen),
+	)
+
+	return FromPostgresError(err)
+}
+
+func (s *Store) DeviceUpdate(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		UPDATE devices SET name = COALESCE($3, name), public_url = COALESCE($4, public_url)
+		WHERE tenant_id = $1 AND uid = $2`,
+		tenant, string(uid), name, publicURL,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if name != nil {
+		if err := s.sessionsSetDeviceName(ctx, uid, *name); err != nil {
+			return err
+		}
+	}
+
+	// Not deleting the device from the cache may cause issues when trying to retrieve the device after the update.
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+// deviceRemovedFilterColumns are the removed devices' fields that they may be filtered and sorted by.
+var deviceRemovedFilterColumns = queries.Columns{
+	"device":    queries.KindJSON,
+	"timestamp": queries.KindScalar,
+}
+
+// deviceRemovedAlive filters out the removed devices the Mongo store's TTL index would have expired.
+const deviceRemovedAlive = `timestamp > now() - ` + deviceRemovedTTL
+
+func scanDeviceRemoved(row pgx.Row) (*models.DeviceRemoved, error) {
+	removed := new(models.DeviceRemoved)
+	if err := row.Scan(&removed.Device, &removed.Timestamp); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return removed, nil
+}
+
+func (s *Store) DeviceRemovedCount(ctx context.Context, tenant string) (int64, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM removed_devices WHERE tenant_id = $1 AND `+deviceRemovedAlive, tenant)
+
+	return int64(count), err
+}
+
+func (s *Store) DeviceRemovedGet(ctx context.Context, tenant string, uid models.UID) (*models.DeviceRemoved, error) {
+	return scanDeviceRemoved(s.db(ctx).QueryRow(ctx, `SELECT device, timestamp FROM removed_devices WHERE tenant_id = $1 AND uid = $2 AND `+deviceRemovedAlive, tenant, string(uid)))
+}
+
+func (s *Store) DeviceRemovedInsert(ctx context.Context, tenant string, device *models.Device) error { //nolint:revive
+	now := time.Now()
+
+	device.Status = models.DeviceStatusRemoved
+	device.StatusUpdatedAt = now
+
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM removed_devices WHERE NOT (`+deviceRemovedAlive+`)`); err != nil {
+		return FromPostgresError(err)
+	}
+
+	_, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO removed_devices (tenant_id, uid, device, timestamp) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, uid) DO UPDATE SET device = EXCLUDED.device, timestamp = EXCLUDED.timestamp`,
+		device.TenantID, device.UID, device, now,
+	)
+
+	return FromPostgresError(err)
+}
+
+func (s *Store) DeviceRemovedDelete(ctx context.Context, tenant string, uid models.UID) error {
+	_, err := s.db(ctx).Exec(ctx, `DELETE FROM removed_devices WHERE tenant_id = $1 AND uid = $2`, tenant, string(uid))
+
+	return FromPostgresError(err)
+}
+
+func (s *Store) DeviceRemovedList(ctx context.Context, tenant string, paginator query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.DeviceRemoved, int, error) {
+	args := queries.NewArgs(tenant)
+
+	statement := `SELECT device, timestamp FROM removed_devices WHERE tenant_id = $1 AND ` + deviceRemovedAlive
+
+	filter, err := queries.FromFilters(&filters, deviceRemovedFilterColumns, args)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	if filter != "" {
+		statement += " AND " + filter
+	}
+
+	if sorter.By == "" {
+		sorter.By = "timestamp"
+	}
+
+	if sorter.Order == "" {
+		sorter.Order = query.OrderDesc
+	}
+
+	rows, err := s.db(ctx).Query(ctx, statement+queries.FromSorter(&sorter, deviceRemovedFilterColumns, "timestamp")+queries.FromPaginator(&paginator), args.Values()...)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	devices, err := collect(rows, scanDeviceRemoved)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return devices, len(devices), nil
+}
+
+func (s *Store) DeviceCreatePublicURLAddress(ctx context.Context, uid models.UID) error {
+	_, err := s.db(ctx).Exec(ctx, `UPDATE devices SET public_url_address = $2 WHERE uid = $1`, string(uid), fmt.Sprintf("%x", md5.Sum([]byte(uid)))) //nolint:gosec
+
+	return FromPostgresError(err)
+}
+
+func (s *Store) DeviceGetByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
+	return s.scanDevice(s.db(ctx).QueryRow(ctx, `SELECT `+selectDevice("", nil)+` FROM devices WHERE public_url_address = $1 LIMIT 1`, address))
+}
+
+func (s *Store) DeviceSetCompromised(ctx context.Context, uid models.UID, compromised bool) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET compromised = $2 WHERE uid = $1`, string(uid), compromised)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// deviceSet sets a column of the namespace's device, removing the device from the cache.
+func (s *Store) deviceSet(ctx context.Context, tenant string, uid models.UID, column string, value any) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET `+column+` = $3 WHERE tenant_id = $1 AND uid = $2`, tenant, string(uid), value)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetConnectionNote(ctx context.Context, tenant string, uid models.UID, note string) error {
+	return s.deviceSet(ctx, tenant, uid, "connection_note", note)
+}
+
+func (s *Store) DeviceSetLimitExempt(ctx context.Context, tenant string, uid models.UID, exempt bool) error {
+	return s.deviceSet(ctx, tenant, uid, "limit_exempt", exempt)
+}
+
+func (s *Store) DeviceSetQueue(ctx context.Context, tenant string, uid models.UID, queue *models.DeviceQueue) error {
+	return s.deviceSet(ctx, tenant, uid, "queue", queue)
+}
+
+func (s *Store) DeviceSetLoginShell(ctx context.Context, tenant string, uid models.UID, loginShell string) error {
+	return s.deviceSet(ctx, tenant, uid, "login_shell", loginShell)
+}
+
+func (s *Store) DeviceQueueList(ctx context.Context, tenant string) ([]models.Device, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+selectDevice("", nil)+` FROM devices
+		WHERE tenant_id = $1 AND status = 'pending' AND queue IS NOT NULL
+		ORDER BY (queue ->> 'priority')::integer DESC, (queue ->> 'queued_at')::timestamptz ASC`,
+		tenant,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, func(row pgx.Row) (*models.Device, error) {
+		return s.scanDevice(row)
+	})
+}
+
+func (s *Store) DeviceSearch(ctx context.Context, tenants []string, name string, status models.DeviceStatus, paginator query.Paginator) ([]models.Device, int, error) {
+	args := queries.NewArgs(clock.Now(), nonNil(tenants), regexp.QuoteMeta(name))
+
+	where := `d.tenant_id = ANY($2::text[]) AND d.name ~* $3`
+	if status != "" {
+		where += ` AND d.status = ` + args.Add(string(status))
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM devices AS d WHERE `+where, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db(ctx).Query(
+		ctx,
+		fmt.Sprintf(
+			`SELECT %s, %s AS online, n.name AS namespace FROM devices AS d JOIN namespaces AS n ON n.tenant_id = d.tenant_id WHERE %s ORDER BY d.name ASC, d.tenant_id ASC`,
+			selectDevice("d", nil), deviceOnline("$1"), where,
+		)+queries.FromPaginator(&paginator),
+		args.Values()...,
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	devices, err := collect(rows, s.scanDeviceJoined)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return devices, count, nil
+}
+
+func (s *Store) DeviceSetRemoteAccess(ctx context.Context, tenant string, uid models.UID, remoteAccess bool) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET remote_access = $3 WHERE tenant_id = $1 AND uid = $2`, tenant, string(uid), remoteAccess)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	for _, key := range []string{"device", "auth_device"} {
+		if err := s.cache.Delete(ctx, strings.Join([]string{key, string(uid)}, "/")); err != nil {
+			logrus.WithContext(ctx).Error(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetConfig(ctx context.Context, tenant string, uid models.UID, values map[string]string, updatedAt time.Time) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE devices SET config = jsonb_build_object(
+			'values', $3::jsonb,
+			'version', COALESCE((config ->> 'version')::integer, 0) + 1,
+			'updated_at', $4::timestamptz
+		)
+		WHERE tenant_id = $1 AND uid = $2`,
+		tenant, string(uid), values, updatedAt,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceCountByStatus(ctx context.Context, tenantID string, status models.DeviceStatus) (int64, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM devices WHERE tenant_id = $1 AND status = $2`, tenantID, string(status))
+
+	return int64(count), err
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceAgentLogColumns are the columns of the device_agent_logs table, in the order scanned by scanDeviceAgentLog.
+const deviceAgentLogColumns = `id, tenant_id, device_uid, level, message, error, time, created_at`
+
+func scanDeviceAgentLog(row pgx.Row) (*models.DeviceAgentLog, error) {
+	log := new(models.DeviceAgentLog)
+	if err := row.Scan(&log.ID, &log.TenantID, &log.DeviceUID, &log.Level, &log.Message, &log.Error, &log.Time, &log.CreatedAt); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return log, nil
+}
+
+func (s *Store) DeviceAgentLogCreateMany(ctx context.Context, logs []models.DeviceAgentLog) error {
+	now := clock.Now()
+
+	// NOTICE: The agent's logs are only kept for 7 days.
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM device_agent_logs WHERE created_at <= now() - `+deviceAgentLogTTL); err != nil {
+		return FromPostgresError(err)
+	}
+
+	ids := make([]string, len(logs))
+	tenants := make([]string, len(logs))
+	uids := make([]string, len(logs))
+	levels := make([]string, len(logs))
+	messages := make([]string, len(logs))
+	errs := make([]string, len(logs))
+	times := make([]time.Time, len(logs))
+	for i := range logs {
+		logs[i].CreatedAt = now
+
+		ids[i], tenants[i], uids[i], levels[i] = logs[i].ID, logs[i].TenantID, logs[i].DeviceUID, logs[i].Level
+		messages[i], errs[i], times[i] = logs[i].Message, logs[i].Error, logs[i].Time
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO device_agent_logs (`+deviceAgentLogColumns+`)
+		SELECT l.id, l.tenant_id, l.device_uid, l.level, l.message, l.error, l.time, $8::timestamptz
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[], $7::timestamptz[])
+			AS l (id, tenant_id, device_uid, level, message, error, time)`,
+		ids, tenants, uids, levels, messages, errs, times, now,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceAgentLogList(ctx context.Context, tenantID string, uid models.UID, level string, paginator query.Paginator) ([]models.DeviceAgentLog, int, error) {
+	args := queries.NewArgs(tenantID, string(uid))
+
+	where := `tenant_id = $1 AND device_uid = $2 AND created_at > now() - ` + deviceAgentLogTTL
+	if level != "" {
+		where += ` AND level = ` + args.Add(level)
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM device_agent_logs WHERE `+where, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.DeviceAgentLog{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+deviceAgentLogColumns+` FROM device_agent_logs WHERE `+where+` ORDER BY time DESC`+queries.FromPaginator(&paginator), args.Values()...)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	logs, err := collect(rows, scanDeviceAgentLog)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, count, nil
+}
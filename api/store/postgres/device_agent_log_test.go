@@ -0,0 +1,103 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceAgentLogList(t *testing.T) {
+	type Expected struct {
+		ids   []string
+		count int
+		err   error
+	}
+
+	logs := []models.DeviceAgentLog{
+		{
+			ID:        "7a4c2f1e-0c1b-4d7e-8f3a-000000000001",
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+			DeviceUID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+			Level:     "warning",
+			Message:   "Failed to start the PTY",
+			Time:      time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        "7a4c2f1e-0c1b-4d7e-8f3a-000000000002",
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+			DeviceUID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+			Level:     "error",
+			Message:   "Failed to connect to server through reverse tunnel. Retry in 10 seconds",
+			Error:     "connection refused",
+			Time:      time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        "7a4c2f1e-0c1b-4d7e-8f3a-000000000003",
+			TenantID:  "00000000-0000-4000-0000-000000000000",
+			DeviceUID: "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+			Level:     "error",
+			Message:   "Failed to wait command",
+			Time:      time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		level       string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when the device has no logs",
+			uid:         models.UID("4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"),
+			level:       "",
+			expected: Expected{
+				ids:   []string{},
+				count: 0,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds listing the device's logs",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			level:       "",
+			expected: Expected{
+				ids:   []string{"7a4c2f1e-0c1b-4d7e-8f3a-000000000002", "7a4c2f1e-0c1b-4d7e-8f3a-000000000001"},
+				count: 2,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds listing the device's logs with level",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			level:       "error",
+			expected: Expected{
+				ids:   []string{"7a4c2f1e-0c1b-4d7e-8f3a-000000000002"},
+				count: 1,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, s.DeviceAgentLogCreateMany(ctx, append([]models.DeviceAgentLog{}, logs...)))
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			logs, count, err := s.DeviceAgentLogList(ctx, "00000000-0000-4000-0000-000000000000", tc.uid, tc.level, query.Paginator{Page: 1, PerPage: 10})
+
+			ids := []string{}
+			for _, log := range logs {
+				ids = append(ids, log.ID)
+			}
+
+			require.Equal(t, tc.expected, Expected{ids, count, err})
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) DeviceChangeRecord(ctx context.Context, change *models.DeviceChange) error {
+	// NOTICE: the changes of the deleted devices are pruned once they expire, as the Mongo store's TTL index does.
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM device_changes WHERE expires_at <= now()`); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if err := s.db(ctx).QueryRow(ctx, `
+		INSERT INTO device_changes (tenant_id, uid, seq, type, changed_at, expires_at)
+		VALUES ($1, $2, nextval('device_changes_seq'), $3, $4, $5)
+		ON CONFLICT (tenant_id, uid) DO UPDATE SET
+			seq = EXCLUDED.seq,
+			type = EXCLUDED.type,
+			changed_at = EXCLUDED.changed_at,
+			expires_at = EXCLUDED.expires_at
+		RETURNING seq`,
+		change.TenantID, change.UID, change.Type, change.ChangedAt, change.ExpiresAt,
+	).Scan(&change.Seq); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceChangeList(ctx context.Context, tenantID string, since int64, limit int) ([]models.DeviceChange, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT seq, tenant_id, uid, type, changed_at, expires_at FROM device_changes
+		WHERE tenant_id = $1 AND seq > $2 AND (expires_at IS NULL OR expires_at > now())
+		ORDER BY seq ASC
+		LIMIT $3`,
+		tenantID, since, limit,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, func(row pgx.Row) (*models.DeviceChange, error) {
+		change := new(models.DeviceChange)
+		if err := row.Scan(&change.Seq, &change.TenantID, &change.UID, &change.Type, &change.ChangedAt, &change.ExpiresAt); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return change, nil
+	})
+}
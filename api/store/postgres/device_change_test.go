@@ -0,0 +1,50 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceChangeList(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	tenant := "00000000-0000-4000-0000-000000000000"
+	changedAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	// NOTICE: the changes of the deleted devices are filtered out once they expire, so the expiration must be ahead.
+	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+
+	changes := []models.DeviceChange{
+		{TenantID: tenant, UID: "first", Type: models.DeviceChangeCreated, ChangedAt: changedAt},
+		{TenantID: tenant, UID: "second", Type: models.DeviceChangeCreated, ChangedAt: changedAt},
+		{TenantID: "00000000-0000-4001-0000-000000000000", UID: "other", Type: models.DeviceChangeCreated, ChangedAt: changedAt},
+		{TenantID: tenant, UID: "first", Type: models.DeviceChangeDeleted, ChangedAt: changedAt, ExpiresAt: &expiresAt},
+	}
+
+	for i := range changes {
+		require.NoError(t, s.DeviceChangeRecord(ctx, &changes[i]))
+	}
+
+	list, err := s.DeviceChangeList(ctx, tenant, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "second", list[0].UID)
+	require.Equal(t, "first", list[1].UID)
+	require.Equal(t, models.DeviceChangeDeleted, list[1].Type)
+	require.Equal(t, changes[3].Seq, list[1].Seq)
+
+	list, err = s.DeviceChangeList(ctx, tenant, list[0].Seq, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "first", list[0].UID)
+
+	list, err = s.DeviceChangeList(ctx, tenant, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "second", list[0].UID)
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceCommandColumns are the columns of the device_commands table, in the order scanned by scanDeviceCommand.
+const deviceCommandColumns = `id, tenant_id, device_uid, command, "user", status, exit_code, output, created_by, created_at, sent_at, finished_at`
+
+func scanDeviceCommand(row pgx.Row) (*models.DeviceCommand, error) {
+	command := new(models.DeviceCommand)
+	if err := row.Scan(
+		&command.ID,
+		&command.TenantID,
+		&command.DeviceUID,
+		&command.Command,
+		&command.User,
+		&command.Status,
+		&command.ExitCode,
+		&command.Output,
+		&command.CreatedBy,
+		&command.CreatedAt,
+		&command.SentAt,
+		&command.FinishedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return command, nil
+}
+
+func (s *Store) DeviceCommandCreate(ctx context.Context, command *models.DeviceCommand) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO device_commands (`+deviceCommandColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		command.ID,
+		command.TenantID,
+		command.DeviceUID,
+		command.Command,
+		command.User,
+		string(command.Status),
+		command.ExitCode,
+		command.Output,
+		command.CreatedBy,
+		command.CreatedAt,
+		command.SentAt,
+		command.FinishedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceCommandList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceCommand, int, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM device_commands WHERE tenant_id = $1 AND device_uid = $2`, tenantID, string(uid))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.DeviceCommand{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+deviceCommandColumns+` FROM device_commands
+		WHERE tenant_id = $1 AND device_uid = $2
+		ORDER BY created_at DESC`+queries.FromPaginator(&paginator),
+		tenantID, string(uid),
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	commands, err := collect(rows, scanDeviceCommand)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return commands, count, nil
+}
+
+func (s *Store) DeviceCommandDispatch(ctx context.Context, tenantID string, uid models.UID, limit int, sentAt time.Time) ([]models.DeviceCommand, error) {
+	// NOTICE: the queued commands are locked, skipping the ones locked by a concurrent dispatch, so each command is
+	// fetched only once.
+	rows, err := s.db(ctx).Query(ctx, `
+		WITH dispatched AS (
+			UPDATE device_commands SET status = $4, sent_at = $5
+			WHERE id IN (
+				SELECT id FROM device_commands
+				WHERE tenant_id = $1 AND device_uid = $2 AND status = $6
+				ORDER BY created_at
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+deviceCommandColumns+`
+		)
+		SELECT `+deviceCommandColumns+` FROM dispatched ORDER BY created_at`,
+		tenantID, string(uid), limit, string(models.DeviceCommandStatusSent), sentAt, string(models.DeviceCommandStatusQueued),
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanDeviceCommand)
+}
+
+func (s *Store) DeviceCommandFinish(ctx context.Context, tenantID string, uid models.UID, id string, result models.DeviceCommandResult, finishedAt time.Time) error {
+	tag, err := s.db(ctx).Exec(ctx, `
+		UPDATE device_commands SET status = $5, exit_code = $6, output = $7, finished_at = $8
+		WHERE id = $1 AND tenant_id = $2 AND device_uid = $3 AND status = $4`,
+		id, tenantID, string(uid), string(models.DeviceCommandStatusSent),
+		string(models.DeviceCommandStatusFinished), result.ExitCode, result.Output, finishedAt,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
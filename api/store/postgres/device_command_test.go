@@ -0,0 +1,86 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceCommand(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	tenantID := "00000000-0000-4000-0000-000000000000"
+	uid := models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+
+	first := models.DeviceCommand{
+		ID:        "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+		TenantID:  tenantID,
+		DeviceUID: string(uid),
+		Command:   "apt-get update",
+		User:      "root",
+		Status:    models.DeviceCommandStatusQueued,
+		CreatedBy: "507f1f77bcf86cd799439011",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	second := models.DeviceCommand{
+		ID:        "6f1b2c3d-0c1b-4d7e-8f3a-000000000002",
+		TenantID:  tenantID,
+		DeviceUID: string(uid),
+		Command:   "reboot",
+		User:      "root",
+		Status:    models.DeviceCommandStatusQueued,
+		CreatedBy: "507f1f77bcf86cd799439011",
+		CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, s.DeviceCommandCreate(ctx, &first))
+	require.NoError(t, s.DeviceCommandCreate(ctx, &second))
+
+	commands, count, err := s.DeviceCommandList(ctx, tenantID, uid, query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []models.DeviceCommand{second, first}, commands)
+
+	sentAt := time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	dispatched, err := s.DeviceCommandDispatch(ctx, tenantID, uid, 1, sentAt)
+	require.NoError(t, err)
+	require.Len(t, dispatched, 1)
+	assert.Equal(t, first.ID, dispatched[0].ID)
+	assert.Equal(t, models.DeviceCommandStatusSent, dispatched[0].Status)
+	assert.Equal(t, &sentAt, dispatched[0].SentAt)
+
+	dispatched, err = s.DeviceCommandDispatch(ctx, tenantID, uid, 10, sentAt)
+	require.NoError(t, err)
+	require.Len(t, dispatched, 1)
+	assert.Equal(t, second.ID, dispatched[0].ID)
+
+	dispatched, err = s.DeviceCommandDispatch(ctx, tenantID, uid, 10, sentAt)
+	require.NoError(t, err)
+	assert.Equal(t, []models.DeviceCommand{}, dispatched)
+
+	finishedAt := time.Date(2023, 1, 3, 12, 1, 0, 0, time.UTC)
+	result := models.DeviceCommandResult{ExitCode: 1, Output: "permission denied"}
+
+	require.NoError(t, s.DeviceCommandFinish(ctx, tenantID, uid, first.ID, result, finishedAt))
+	assert.ErrorIs(t, s.DeviceCommandFinish(ctx, tenantID, uid, first.ID, result, finishedAt), store.ErrNoDocuments)
+	assert.ErrorIs(t, s.DeviceCommandFinish(ctx, "00000000-0000-4000-0000-000000000001", uid, second.ID, result, finishedAt), store.ErrNoDocuments)
+
+	commands, _, err = s.DeviceCommandList(ctx, tenantID, uid, query.Paginator{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, commands, 2)
+	assert.Equal(t, models.DeviceCommandStatusFinished, commands[1].Status)
+	assert.Equal(t, 1, commands[1].ExitCode)
+	assert.Equal(t, "permission denied", commands[1].Output)
+	assert.Equal(t, &finishedAt, commands[1].FinishedAt)
+	assert.Equal(t, models.DeviceCommandStatusSent, commands[0].Status)
+}
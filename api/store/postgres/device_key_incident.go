@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceKeyIncidentColumns are the columns of the device_key_incidents table, in the order scanned by
+// scanDeviceKeyIncident.
+const deviceKeyIncidentColumns = `id, tenant_id, device_uid, mac, pinned_public_key, public_key, remote_addr, status, created_at, updated_at`
+
+func scanDeviceKeyIncident(row pgx.Row) (*models.DeviceKeyIncident, error) {
+	incident := new(models.DeviceKeyIncident)
+	if err := row.Scan(
+		&incident.ID,
+		&incident.TenantID,
+		&incident.DeviceUID,
+		&incident.MAC,
+		&incident.PinnedPublicKey,
+		&incident.PublicKey,
+		&incident.RemoteAddr,
+		&incident.Status,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return incident, nil
+}
+
+func (s *Store) DeviceKeyIncidentCreate(ctx context.Context, incident *models.DeviceKeyIncident) (string, error) {
+	now := clock.Now()
+	incident.CreatedAt = now
+	incident.UpdatedAt = now
+
+	var id string
+	if err := s.db(ctx).QueryRow(ctx, `
+		INSERT INTO device_key_incidents (`+deviceKeyIncidentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`,
+		incident.ID,
+		incident.TenantID,
+		incident.DeviceUID,
+		incident.MAC,
+		incident.PinnedPublicKey,
+		incident.PublicKey,
+		incident.RemoteAddr,
+		incident.Status,
+		incident.CreatedAt,
+		incident.UpdatedAt,
+	).Scan(&id); err != nil {
+		return "", FromPostgresError(err)
+	}
+
+	return id, nil
+}
+
+func (s *Store) DeviceKeyIncidentGet(ctx context.Context, tenantID, id string) (*models.DeviceKeyIncident, error) {
+	return scanDeviceKeyIncident(s.db(ctx).QueryRow(ctx, `
+		SELECT `+deviceKeyIncidentColumns+` FROM device_key_incidents
+		WHERE id = $1 AND tenant_id = $2`,
+		id, tenantID,
+	))
+}
+
+func (s *Store) DeviceKeyIncidentGetByPublicKey(ctx context.Context, tenantID string, uid models.UID, publicKey string) (*models.DeviceKeyIncident, error) {
+	return scanDeviceKeyIncident(s.db(ctx).QueryRow(ctx, `
+		SELECT `+deviceKeyIncidentColumns+` FROM device_key_incidents
+		WHERE tenant_id = $1 AND device_uid = $2 AND public_key = $3
+		ORDER BY created_at DESC
+		LIMIT 1`,
+		tenantID, string(uid), publicKey,
+	))
+}
+
+func (s *Store) DeviceKeyIncidentList(ctx context.Context, tenantID string, status models.DeviceKeyIncidentStatus, paginator query.Paginator) ([]models.DeviceKeyIncident, int, error) {
+	args := queries.NewArgs(tenantID)
+
+	where := `tenant_id = $1`
+	if status != "" {
+		where += ` AND status = ` + args.Add(string(status))
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM device_key_incidents WHERE `+where, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.DeviceKeyIncident{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+deviceKeyIncidentColumns+` FROM device_key_incidents WHERE `+where+` ORDER BY created_at DESC`+queries.FromPaginator(&paginator), args.Values()...)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	incidents, err := collect(rows, scanDeviceKeyIncident)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return incidents, count, nil
+}
+
+func (s *Store) DeviceKeyIncidentUpdateStatus(ctx context.Context, tenantID, id string, status models.DeviceKeyIncidentStatus) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE device_key_incidents SET status = $3, updated_at = $4
+		WHERE id = $1 AND tenant_id = $2`,
+		id, tenantID, string(status), clock.Now(),
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
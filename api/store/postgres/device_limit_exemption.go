@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceLimitExemptionColumns are the columns of the device_limit_exemptions table, in the order scanned by
+// scanDeviceLimitExemption.
+const deviceLimitExemptionColumns = `id, tenant_id, device_uid, exempt, user_id, reason, created_at`
+
+func scanDeviceLimitExemption(row pgx.Row) (*models.DeviceLimitExemption, error) {
+	exemption := new(models.DeviceLimitExemption)
+	if err := row.Scan(
+		&exemption.ID,
+		&exemption.TenantID,
+		&exemption.DeviceUID,
+		&exemption.Exempt,
+		&exemption.UserID,
+		&exemption.Reason,
+		&exemption.CreatedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return exemption, nil
+}
+
+func (s *Store) DeviceLimitExemptionCreate(ctx context.Context, exemption *models.DeviceLimitExemption) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO device_limit_exemptions (`+deviceLimitExemptionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		exemption.ID,
+		exemption.TenantID,
+		exemption.DeviceUID,
+		exemption.Exempt,
+		exemption.UserID,
+		exemption.Reason,
+		exemption.CreatedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceLimitExemptionList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceLimitExemption, int, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM device_limit_exemptions WHERE tenant_id = $1 AND device_uid = $2`, tenantID, string(uid))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.DeviceLimitExemption{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+deviceLimitExemptionColumns+` FROM device_limit_exemptions
+		WHERE tenant_id = $1 AND device_uid = $2
+		ORDER BY created_at DESC`+queries.FromPaginator(&paginator),
+		tenantID, string(uid),
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	exemptions, err := collect(rows, scanDeviceLimitExemption)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return exemptions, count, nil
+}
+
+func (s *Store) DeviceLimitExemptionListByUser(ctx context.Context, tenantID, userID string, since time.Time) ([]models.DeviceLimitExemption, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+deviceLimitExemptionColumns+` FROM device_limit_exemptions
+		WHERE tenant_id = $1 AND user_id = $2 AND created_at >= $3
+		ORDER BY created_at DESC`,
+		tenantID, userID, since,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanDeviceLimitExemption)
+}
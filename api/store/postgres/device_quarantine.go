@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceQuarantineAttemptColumns are the columns of the device_quarantine_attempts table, in the order scanned by
+// scanDeviceQuarantineAttempt.
+const deviceQuarantineAttemptColumns = `id, tenant_id, device_uid, hostname, mac, public_key, remote_addr, country, info, created_at`
+
+// deviceQuarantineAttemptAlive filters out the attempts the Mongo store's TTL index would have expired.
+const deviceQuarantineAttemptAlive = `created_at > now() - ` + deviceQuarantineAttemptTTL
+
+func scanDeviceQuarantineAttempt(row pgx.Row) (*models.DeviceQuarantineAttempt, error) {
+	attempt := new(models.DeviceQuarantineAttempt)
+	if err := row.Scan(
+		&attempt.ID,
+		&attempt.TenantID,
+		&attempt.DeviceUID,
+		&attempt.Hostname,
+		&attempt.MAC,
+		&attempt.PublicKey,
+		&attempt.RemoteAddr,
+		&attempt.Country,
+		&attempt.Info,
+		&attempt.CreatedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return attempt, nil
+}
+
+func (s *Store) DeviceQuarantineAttemptCreate(ctx context.Context, attempt *models.DeviceQuarantineAttempt) error {
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM device_quarantine_attempts WHERE NOT (`+deviceQuarantineAttemptAlive+`)`); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO device_quarantine_attempts (`+deviceQuarantineAttemptColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		attempt.ID,
+		attempt.TenantID,
+		attempt.DeviceUID,
+		attempt.Hostname,
+		attempt.MAC,
+		attempt.PublicKey,
+		attempt.RemoteAddr,
+		attempt.Country,
+		attempt.Info,
+		attempt.CreatedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceQuarantineAttemptGetLast(ctx context.Context, tenantID string, uid models.UID) (*models.DeviceQuarantineAttempt, error) {
+	return scanDeviceQuarantineAttempt(s.db(ctx).QueryRow(ctx, `
+		SELECT `+deviceQuarantineAttemptColumns+` FROM device_quarantine_attempts
+		WHERE tenant_id = $1 AND device_uid = $2 AND `+deviceQuarantineAttemptAlive+`
+		ORDER BY created_at DESC
+		LIMIT 1`,
+		tenantID, string(uid),
+	))
+}
+
+func (s *Store) DeviceQuarantineAttemptList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.DeviceQuarantineAttempt, int, error) {
+	args := queries.NewArgs(tenantID)
+
+	where := `tenant_id = $1 AND ` + deviceQuarantineAttemptAlive
+	if uid != "" {
+		where += ` AND device_uid = ` + args.Add(string(uid))
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM device_quarantine_attempts WHERE `+where, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.DeviceQuarantineAttempt{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+deviceQuarantineAttemptColumns+` FROM device_quarantine_attempts WHERE `+where+` ORDER BY created_at DESC`+queries.FromPaginator(&paginator), args.Values()...)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	attempts, err := collect(rows, scanDeviceQuarantineAttempt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return attempts, count, nil
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceShareColumns are the columns of the device_shares table, in the order scanned by scanDeviceShare.
+const deviceShareColumns = `id, tenant_id, uid, grantee_tenant_id, permission, created_by, created_at, expires_at`
+
+func scanDeviceShare(row pgx.Row) (*models.DeviceShare, error) {
+	share := new(models.DeviceShare)
+	if err := row.Scan(
+		&share.ID,
+		&share.TenantID,
+		&share.UID,
+		&share.GranteeTenantID,
+		&share.Permission,
+		&share.CreatedBy,
+		&share.CreatedAt,
+		&share.ExpiresAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return share, nil
+}
+
+func (s *Store) DeviceShareCreate(ctx context.Context, share *models.DeviceShare) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO device_shares (`+deviceShareColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		share.ID,
+		share.TenantID,
+		share.UID,
+		share.GranteeTenantID,
+		string(share.Permission),
+		share.CreatedBy,
+		share.CreatedAt,
+		share.ExpiresAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceShareList(ctx context.Context, tenantID, uid string) ([]models.DeviceShare, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+deviceShareColumns+` FROM device_shares
+		WHERE tenant_id = $1 AND uid = $2
+		ORDER BY created_at`,
+		tenantID, uid,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanDeviceShare)
+}
+
+func (s *Store) DeviceShareListByGrantee(ctx context.Context, granteeTenantID string, at time.Time) ([]models.DeviceShare, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+deviceShareColumns+` FROM device_shares
+		WHERE grantee_tenant_id = $1 AND (expires_at IS NULL OR expires_at > $2)
+		ORDER BY created_at`,
+		granteeTenantID, at,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanDeviceShare)
+}
+
+func (s *Store) DeviceShareDelete(ctx context.Context, tenantID, uid, id string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM device_shares WHERE tenant_id = $1 AND uid = $2 AND id = $3`, tenantID, uid, id)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// sharedDevices returns the shares of the devices shared with the grantee tenant at the instant, by the devices' UIDs.
+func (s *Store) sharedDevices(ctx context.Context, granteeTenantID string, at time.Time) (map[string]*models.DeviceShare, error) {
+	shares, err := s.DeviceShareListByGrantee(ctx, granteeTenantID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make(map[string]*models.DeviceShare, len(shares))
+	for i := range shares {
+		shared[shares[i].UID] = &shares[i]
+	}
+
+	return shared, nil
+}
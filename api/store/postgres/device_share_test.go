@@ -0,0 +1,121 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func deviceShares() []models.DeviceShare {
+	expiresAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	return []models.DeviceShare{
+		{
+			ID:              "7a2b3c4d-1d2c-4e8f-9a4b-000000000001",
+			TenantID:        "00000000-0000-4000-0000-000000000000",
+			UID:             "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+			GranteeTenantID: "00000000-0000-4001-0000-000000000000",
+			Permission:      models.DeviceSharePermissionConnect,
+			CreatedBy:       "507f1f77bcf86cd799439011",
+			CreatedAt:       time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:              "7a2b3c4d-1d2c-4e8f-9a4b-000000000002",
+			TenantID:        "00000000-0000-4000-0000-000000000000",
+			UID:             "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+			GranteeTenantID: "00000000-0000-4002-0000-000000000000",
+			Permission:      models.DeviceSharePermissionRead,
+			CreatedBy:       "507f1f77bcf86cd799439011",
+			CreatedAt:       time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC),
+			ExpiresAt:       &expiresAt,
+		},
+		{
+			ID:              "7a2b3c4d-1d2c-4e8f-9a4b-000000000003",
+			TenantID:        "00000000-0000-4000-0000-000000000000",
+			UID:             "4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e",
+			GranteeTenantID: "00000000-0000-4002-0000-000000000000",
+			Permission:      models.DeviceSharePermissionConnect,
+			CreatedBy:       "507f1f77bcf86cd799439011",
+			CreatedAt:       time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestDeviceShareList(t *testing.T) {
+	ctx := context.Background()
+
+	shares := deviceShares()
+	for i := range shares {
+		require.NoError(t, s.DeviceShareCreate(ctx, &shares[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	list, err := s.DeviceShareList(ctx, "00000000-0000-4000-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "7a2b3c4d-1d2c-4e8f-9a4b-000000000001", list[0].ID)
+	require.Nil(t, list[0].ExpiresAt)
+	require.Equal(t, "7a2b3c4d-1d2c-4e8f-9a4b-000000000002", list[1].ID)
+	require.True(t, list[1].ExpiresAt.Equal(*shares[1].ExpiresAt))
+
+	list, err = s.DeviceShareList(ctx, "00000000-0000-4001-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
+
+func TestDeviceShareListByGrantee(t *testing.T) {
+	ctx := context.Background()
+
+	shares := deviceShares()
+	for i := range shares {
+		require.NoError(t, s.DeviceShareCreate(ctx, &shares[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	ids := func(list []models.DeviceShare) []string {
+		ids := []string{}
+		for _, share := range list {
+			ids = append(ids, share.ID)
+		}
+
+		return ids
+	}
+
+	list, err := s.DeviceShareListByGrantee(ctx, "00000000-0000-4002-0000-000000000000", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, []string{"7a2b3c4d-1d2c-4e8f-9a4b-000000000002", "7a2b3c4d-1d2c-4e8f-9a4b-000000000003"}, ids(list))
+
+	list, err = s.DeviceShareListByGrantee(ctx, "00000000-0000-4002-0000-000000000000", time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, []string{"7a2b3c4d-1d2c-4e8f-9a4b-000000000003"}, ids(list))
+
+	list, err = s.DeviceShareListByGrantee(ctx, "00000000-0000-4003-0000-000000000000", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
+
+func TestDeviceShareDelete(t *testing.T) {
+	ctx := context.Background()
+
+	shares := deviceShares()
+	for i := range shares {
+		require.NoError(t, s.DeviceShareCreate(ctx, &shares[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	require.Equal(t, store.ErrNoDocuments, s.DeviceShareDelete(ctx, "00000000-0000-4000-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c", "7a2b3c4d-1d2c-4e8f-9a4b-000000000003"))
+	require.Equal(t, store.ErrNoDocuments, s.DeviceShareDelete(ctx, "00000000-0000-4001-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c", "7a2b3c4d-1d2c-4e8f-9a4b-000000000001"))
+	require.NoError(t, s.DeviceShareDelete(ctx, "00000000-0000-4000-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c", "7a2b3c4d-1d2c-4e8f-9a4b-000000000001"))
+
+	list, err := s.DeviceShareList(ctx, "00000000-0000-4000-0000-000000000000", "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "7a2b3c4d-1d2c-4e8f-9a4b-000000000002", list[0].ID)
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceSnapshotColumns are the columns of the device_snapshots table, in the order scanned by scanDeviceSnapshot.
+const deviceSnapshotColumns = `id, tenant_id, device_uid, identity, info, config_version, taken_at`
+
+func scanDeviceSnapshot(row pgx.Row) (*models.DeviceSnapshot, error) {
+	snapshot := new(models.DeviceSnapshot)
+	if err := row.Scan(
+		&snapshot.ID,
+		&snapshot.TenantID,
+		&snapshot.DeviceUID,
+		&snapshot.Identity,
+		&snapshot.Info,
+		&snapshot.ConfigVersion,
+		&snapshot.TakenAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return snapshot, nil
+}
+
+func (s *Store) DeviceSnapshotCreate(ctx context.Context, snapshot *models.DeviceSnapshot) error {
+	// NOTICE: The devices' snapshots are only kept for 90 days.
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM device_snapshots WHERE taken_at <= now() - `+deviceSnapshotTTL); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO device_snapshots (`+deviceSnapshotColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		snapshot.ID,
+		snapshot.TenantID,
+		snapshot.DeviceUID,
+		snapshot.Identity,
+		snapshot.Info,
+		snapshot.ConfigVersion,
+		snapshot.TakenAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSnapshotGet(ctx context.Context, tenantID string, uid models.UID, at time.Time) (*models.DeviceSnapshot, error) {
+	return scanDeviceSnapshot(s.db(ctx).QueryRow(ctx, `
+		SELECT `+deviceSnapshotColumns+` FROM device_snapshots
+		WHERE tenant_id = $1 AND device_uid = $2 AND taken_at <= $3 AND taken_at > now() - `+deviceSnapshotTTL+`
+		ORDER BY taken_at DESC
+		LIMIT 1`,
+		tenantID, string(uid), at,
+	))
+}
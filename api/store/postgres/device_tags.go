@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) DevicePushTag(ctx context.Context, uid models.UID, tag string) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET tags = array_append(tags, $2) WHERE uid = $1`, string(uid), tag)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DevicePullTag(ctx context.Context, uid models.UID, tag string) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET tags = array_remove(tags, $2) WHERE uid = $1 AND $2 = ANY(tags)`, string(uid), tag)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetTags(ctx context.Context, uid models.UID, tags []string) (int64, int64, error) {
+	var matched, modified int64
+
+	err := s.db(ctx).QueryRow(ctx, `
+		WITH updated AS (
+			UPDATE devices SET tags = $2 WHERE uid = $1 AND tags IS DISTINCT FROM $2 RETURNING uid
+		)
+		SELECT (SELECT count(*) FROM devices WHERE uid = $1), (SELECT count(*) FROM updated)`,
+		string(uid), nonNil(tags),
+	).Scan(&matched, &modified)
+
+	return matched, modified, FromPostgresError(err)
+}
+
+func (s *Store) DeviceBulkRenameTag(ctx context.Context, tenant, currentTag, newTag string) (int64, error) {
+	res, err := s.db(ctx).Exec(
+		ctx,
+		`UPDATE devices SET tags = `+renameTagWithDescendants("tags", "$2", "$3", "$4")+` WHERE tenant_id = $1 AND `+hasTagWithDescendants("tags", "$2", "$3"),
+		tenant, currentTag, currentTag+models.TagSeparator, newTag,
+	)
+	if err != nil {
+		return 0, FromPostgresError(err)
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (s *Store) DeviceBulkDeleteTag(ctx context.Context, tenant, tag string) (int64, error) {
+	res, err := s.db(ctx).Exec(
+		ctx,
+		`UPDATE devices SET tags = `+pullTagWithDescendants("tags", "$2", "$3")+` WHERE tenant_id = $1 AND `+hasTagWithDescendants("tags", "$2", "$3"),
+		tenant, tag, tag+models.TagSeparator,
+	)
+	if err != nil {
+		return 0, FromPostgresError(err)
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (s *Store) DeviceGetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	return s.distinctTags(ctx, "devices", "tags", tenant)
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// enrollTokenColumns are the columns of the enroll_tokens table, in the order scanned by scanEnrollToken.
+const enrollTokenColumns = `id, tenant_id, digest, max_uses, uses, created_by, created_at, expires_at, revoked_at`
+
+func scanEnrollToken(row pgx.Row) (*models.EnrollToken, error) {
+	token := new(models.EnrollToken)
+	if err := row.Scan(
+		&token.ID,
+		&token.TenantID,
+		&token.Digest,
+		&token.MaxUses,
+		&token.Uses,
+		&token.CreatedBy,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return token, nil
+}
+
+func (s *Store) EnrollTokenCreate(ctx context.Context, token *models.EnrollToken) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO enroll_tokens (`+enrollTokenColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		token.ID,
+		token.TenantID,
+		token.Digest,
+		token.MaxUses,
+		token.Uses,
+		token.CreatedBy,
+		token.CreatedAt,
+		token.ExpiresAt,
+		token.RevokedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) EnrollTokenGetByDigest(ctx context.Context, digest string) (*models.EnrollToken, error) {
+	return scanEnrollToken(s.db(ctx).QueryRow(ctx, `SELECT `+enrollTokenColumns+` FROM enroll_tokens WHERE digest = $1`, digest))
+}
+
+func (s *Store) EnrollTokenList(ctx context.Context, tenantID string, paginator query.Paginator) ([]models.EnrollToken, int, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM enroll_tokens WHERE tenant_id = $1 AND revoked_at IS NULL`, tenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.EnrollToken{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+enrollTokenColumns+` FROM enroll_tokens
+		WHERE tenant_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC`+queries.FromPaginator(&paginator),
+		tenantID,
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	tokens, err := collect(rows, scanEnrollToken)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tokens, count, nil
+}
+
+func (s *Store) EnrollTokenUse(ctx context.Context, id string, at time.Time) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE enroll_tokens SET uses = uses + 1
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2 AND uses < max_uses`,
+		id, at,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) EnrollTokenRevoke(ctx context.Context, tenantID, id string, at time.Time) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE enroll_tokens SET revoked_at = $3
+		WHERE tenant_id = $1 AND id = $2 AND revoked_at IS NULL`,
+		tenantID, id, at,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// encryptedField is a field holding personal data, kept encrypted on the database, read from the row identified by key.
+type encryptedField struct {
+	key   string
+	value string
+	// path is the field's path on the column's JSON, when the column holds many fields.
+	path []string
+}
+
+// FieldsRekey encrypts again, with the current key of the store's cipher, the personal data fields stored in plain
+// text or encrypted with a previous key, returning how many fields were encrypted again. A field changed meanwhile is
+// skipped, and rekeyed on the next run.
+func (s *Store) FieldsRekey(ctx context.Context) (int64, error) {
+	users, err := s.fieldsRekey(ctx, true, `SELECT id, email FROM users`, `UPDATE users SET email = $3 WHERE id = $1 AND email = $2`)
+	if err != nil {
+		return 0, err
+	}
+
+	devices, err := s.fieldsRekey(ctx, false, `SELECT uid, remote_addr FROM devices`, `UPDATE devices SET remote_addr = $3 WHERE uid = $1 AND remote_addr = $2`)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `SELECT uid, addresses FROM devices`)
+	if err != nil {
+		return 0, FromPostgresError(err)
+	}
+
+	addresses, err := collect(rows, func(row pgx.Row) (*[]encryptedField, error) {
+		var uid string
+		var addresses []models.DeviceAddress
+		if err := row.Scan(&uid, &addresses); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		fields := make([]encryptedField, 0, len(addresses))
+		for i, address := range addresses {
+			fields = append(fields, encryptedField{key: uid, value: address.RemoteAddr, path: []string{strconv.Itoa(i), "remote_addr"}})
+		}
+
+		return &fields, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var history int64
+	for _, fields := range addresses {
+		n, err := s.fieldsUpdate(ctx, false, fields, `
+			UPDATE devices SET addresses = jsonb_set(addresses, $4::text[], to_jsonb($3::text))
+			WHERE uid = $1 AND addresses #>> $4::text[] = $2`,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		history += n
+	}
+
+	sessions, err := s.fieldsRekey(ctx, false, `SELECT uid, ip_address FROM sessions`, `UPDATE sessions SET ip_address = $3 WHERE uid = $1 AND ip_address = $2`)
+	if err != nil {
+		return 0, err
+	}
+
+	return users + devices + history + sessions, nil
+}
+
+// fieldsRekey rekeys the fields read by sql, as the rows' keys and fields, writing them with update.
+func (s *Store) fieldsRekey(ctx context.Context, deterministic bool, sql, update string) (int64, error) {
+	rows, err := s.db(ctx).Query(ctx, sql)
+	if err != nil {
+		return 0, FromPostgresError(err)
+	}
+
+	fields, err := collect(rows, func(row pgx.Row) (*encryptedField, error) {
+		field := new(encryptedField)
+		if err := row.Scan(&field.key, &field.value); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return field, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return s.fieldsUpdate(ctx, deterministic, fields, update)
+}
+
+// fieldsUpdate writes the rekeyed fields with update, which receives the row's key, the field's current value, its
+// rekeyed value and, when set, its path, writing the field only when it still has the current value.
+func (s *Store) fieldsUpdate(ctx context.Context, deterministic bool, fields []encryptedField, update string) (int64, error) {
+	var rekeyed int64
+	for _, field := range fields {
+		encrypted, changed, err := s.cipher.Rekey(field.value, deterministic)
+		if err != nil {
+			return 0, err
+		}
+
+		if !changed {
+			continue
+		}
+
+		args := []any{field.key, field.value, encrypted}
+		if field.path != nil {
+			args = append(args, field.path)
+		}
+
+		res, err := s.db(ctx).Exec(ctx, update, args...)
+		if err != nil {
+			return 0, FromPostgresError(err)
+		}
+
+		rekeyed += res.RowsAffected()
+	}
+
+	return rekeyed, nil
+}
@@ -0,0 +1,58 @@
+package postgres_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store/postgres"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldsRekey(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, srv.Apply(fixtureUsers, fixtureNamespaces, fixtureDevices, fixtureSessions))
+	require.NoError(t, s.DeviceAddAddress(ctx, "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f", models.DeviceAddress{
+		RemoteAddr: "192.168.0.1",
+		SeenAt:     time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}))
+
+	cipher, err := fieldcrypt.New(bytes.Repeat([]byte{1}, fieldcrypt.KeySize))
+	require.NoError(t, err)
+
+	encrypted, err := postgres.NewStore(ctx, uri, cache.NewNullCache(), cipher)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		encrypted.(*postgres.Store).GetPool().Close()
+		require.NoError(t, srv.Reset())
+	})
+
+	rekeyed, err := encrypted.(*postgres.Store).FieldsRekey(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, rekeyed, int64(0))
+
+	rekeyed, err = encrypted.(*postgres.Store).FieldsRekey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rekeyed)
+
+	var email string
+	require.NoError(t, srv.pool.QueryRow(ctx, `SELECT email FROM users WHERE username = 'john_doe'`).Scan(&email))
+	assert.True(t, strings.HasPrefix(email, "enc:d:"))
+
+	user, err := encrypted.UserGetByEmail(ctx, "john.doe@test.com")
+	require.NoError(t, err)
+	assert.Equal(t, "john.doe@test.com", user.Email)
+
+	device, err := encrypted.DeviceGet(ctx, "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f")
+	require.NoError(t, err)
+	require.Len(t, device.Addresses, 1)
+	assert.Equal(t, "192.168.0.1", device.Addresses[0].RemoteAddr)
+}
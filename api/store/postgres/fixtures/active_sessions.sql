@@ -0,0 +1,3 @@
+-- NOTICE: the active sessions expire 30 seconds after they were last seen, so the fixture is seen now.
+INSERT INTO active_sessions (uid, last_seen) VALUES
+    ('a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68', now());
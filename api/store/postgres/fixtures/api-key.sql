@@ -0,0 +1,3 @@
+INSERT INTO api_keys (id, name, created_by, tenant_id, role, created_at, updated_at, expires_in) VALUES
+    ('f23a2e56cd3fcfba002c72675c870e1e7813292adc40bbf14cea479a2e07976a', 'dev', '507f1f77bcf86cd799439011', '00000000-0000-4000-0000-000000000000', 'admin', '2023-01-01T12:00:00.000Z', '2023-01-01T12:00:00.000Z', 0),
+    ('a1b2c73ea41f70870c035283336d72228118213ed03ec78043ffee48d827af11', 'prod', '507f1f77bcf86cd799439011', '00000000-0000-4000-0000-000000000000', 'operator', '2023-01-02T12:00:00.000Z', '2023-01-02T12:00:00.000Z', 10);
@@ -0,0 +1,3 @@
+-- NOTICE: the connected devices expire two minutes after their last heartbeat, so the fixture is seen now.
+INSERT INTO connected_devices (uid, tenant_id, last_seen) VALUES
+    ('2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c', '00000000-0000-4000-0000-000000000000', now());
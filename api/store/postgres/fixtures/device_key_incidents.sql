@@ -0,0 +1,3 @@
+INSERT INTO device_key_incidents (id, tenant_id, device_uid, mac, pinned_public_key, public_key, remote_addr, status, created_at, updated_at) VALUES
+    ('3e1b6d6a-5b5a-4f3c-9d2e-000000000001', '00000000-0000-4000-0000-000000000000', '2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c', 'mac-3', 'pinned', 'new', '192.168.0.1', 'approved', '2023-01-01T12:00:00.000Z', '2023-01-02T12:00:00.000Z'),
+    ('3e1b6d6a-5b5a-4f3c-9d2e-000000000002', '00000000-0000-4000-0000-000000000000', '2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c', 'mac-3', 'pinned', 'new', '192.168.0.1', 'open', '2023-01-03T12:00:00.000Z', '2023-01-03T12:00:00.000Z');
@@ -0,0 +1,5 @@
+INSERT INTO devices (uid, name, identity, info, public_key, tenant_id, last_seen, status, status_updated_at, created_at, remote_addr, tags) VALUES
+    ('5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f', 'device-1', '{"mac": "mac-1"}', NULL, '', '00000000-0000-4000-0000-000000000000', '2023-01-01T12:00:00.000Z', 'accepted', '2023-01-01T12:00:00.000Z', '2023-01-01T12:00:00.000Z', '', '{tag-1}'),
+    ('4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e', 'device-2', '{"mac": "mac-2"}', NULL, '', '00000000-0000-4000-0000-000000000000', '2023-01-02T12:00:00.000Z', 'accepted', '2023-01-02T12:00:00.000Z', '2023-01-02T12:00:00.000Z', '', '{}'),
+    ('2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c', 'device-3', '{"mac": "mac-3"}', NULL, '', '00000000-0000-4000-0000-000000000000', '2023-01-03T12:00:00.000Z', 'accepted', '2023-01-03T12:00:00.000Z', '2023-01-03T12:00:00.000Z', '', '{tag-1}'),
+    ('3300330e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809d', 'device-4', '{"mac": "mac-4"}', NULL, '', '00000000-0000-4000-0000-000000000000', '2023-01-04T12:00:00.000Z', 'pending', '2023-01-04T12:00:00.000Z', '2023-01-04T12:00:00.000Z', '', '{}');
@@ -0,0 +1,31 @@
+INSERT INTO namespaces (tenant_id, name, owner, members, settings, max_devices, created_at) VALUES
+    (
+        '00000000-0000-4000-0000-000000000000', 'namespace-1', '507f1f77bcf86cd799439011',
+        '[
+            {"id": "507f1f77bcf86cd799439011", "added_at": "2023-01-01T12:00:00Z", "expires_at": "0001-01-01T00:00:00Z", "role": "owner", "status": "accepted"},
+            {"id": "6509e169ae6144b2f56bf288", "added_at": "2023-01-01T12:00:00Z", "expires_at": "0001-01-01T00:00:00Z", "role": "observer", "status": "pending"}
+        ]',
+        '{"session_record": true}', -1, '2023-01-01T12:00:00.000Z'
+    ),
+    (
+        '00000000-0000-4001-0000-000000000000', 'namespace-2', '6509e169ae6144b2f56bf288',
+        '[
+            {"id": "6509e169ae6144b2f56bf288", "added_at": "2023-01-01T12:00:00Z", "expires_at": "0001-01-01T00:00:00Z", "role": "owner", "status": "accepted"},
+            {"id": "907f1f77bcf86cd799439022", "added_at": "2023-01-01T12:00:00Z", "expires_at": "0001-01-01T00:00:00Z", "role": "operator", "status": "accepted"}
+        ]',
+        '{"session_record": false}', 10, '2023-01-01T12:00:00.000Z'
+    ),
+    (
+        '00000000-0000-4002-0000-000000000000', 'namespace-3', '657b0e3bff780d625f74e49a',
+        '[
+            {"id": "657b0e3bff780d625f74e49a", "added_at": "2023-01-01T12:00:00Z", "expires_at": "0001-01-01T00:00:00Z", "role": "owner", "status": "accepted"}
+        ]',
+        '{"session_record": true}', 3, '2023-01-01T12:00:00.000Z'
+    ),
+    (
+        '00000000-0000-4003-0000-000000000000', 'namespace-4', '6577267d8752d05270a4c07d',
+        '[
+            {"id": "6577267d8752d05270a4c07d", "added_at": "2023-01-01T12:00:00Z", "expires_at": "0001-01-01T00:00:00Z", "role": "owner", "status": "accepted"}
+        ]',
+        '{"session_record": true}', -1, '2023-01-01T12:00:00.000Z'
+    );
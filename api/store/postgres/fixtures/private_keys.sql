@@ -0,0 +1,3 @@
+-- NOTICE: the private keys expire a minute after they were created, so the fixture is seen now.
+INSERT INTO private_keys (fingerprint, data, created_at) VALUES
+    ('fingerprint', 'test', now());
@@ -0,0 +1,2 @@
+INSERT INTO public_keys (tenant_id, fingerprint, data, created_at, name, filter_hostname, filter_tags) VALUES
+    ('00000000-0000-4000-0000-000000000000', 'fingerprint', 'test', '2023-01-01T12:00:00.000Z', 'public_key', '.*', '{tag-1}');
@@ -0,0 +1,5 @@
+INSERT INTO sessions (uid, tenant_id, device_uid, device_name, namespace, started_at, last_seen, authenticated, recorded, closed, ip_address, position, term, type, username) VALUES
+    ('a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68', '00000000-0000-4000-0000-000000000000', '2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c', 'device-3', 'namespace-1', '2023-01-01T12:00:00.000Z', '2023-01-01T12:00:00.000Z', true, false, true, '0.0.0.0', '{"longitude": 0, "latitude": 0}', 'xterm', 'shell', 'john_doe'),
+    ('e7f3a56d8b9e1dc4c285c98c8ea9c33032a17bda5b6c6b05a6213c2a02f97824', '00000000-0000-4000-0000-000000000000', '2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c', 'device-3', 'namespace-1', '2023-01-02T12:00:00.000Z', '2023-01-02T12:00:00.000Z', true, true, true, '0.0.0.0', '{"longitude": 45.6789, "latitude": -12.3456}', 'xterm', 'shell', 'john_doe'),
+    ('fc2e1493d8b6a4c17bf6a2f7f9e55629e384b2d3a21e0c3d90f6e35b0c946178a', '00000000-0000-4000-0000-000000000000', '2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c', 'device-3', 'namespace-1', '2023-01-03T12:00:00.000Z', '2023-01-03T12:00:00.000Z', true, false, true, '0.0.0.0', '{"longitude": -78.9012, "latitude": 23.4567}', '', 'exec', 'john_doe'),
+    ('bc3d75821a29cfe70bf7986f9ee5629e384b2d3a21e0c3d90f6e35b0c946178a', '00000000-0000-4000-0000-000000000000', '2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c', 'device-3', 'namespace-1', '2023-01-04T12:00:00.000Z', '2023-01-04T12:00:00.000Z', true, true, true, '0.0.0.0', '{"longitude": -56.7890, "latitude": 34.5678}', 'xterm', 'shell', 'john_doe');
@@ -0,0 +1,6 @@
+INSERT INTO users (id, status, created_at, last_login, email, email_marketing, max_namespaces, name, password, username) VALUES
+    ('507f1f77bcf86cd799439011', 'confirmed', '2023-01-01T12:00:00.000Z', '2023-01-01T12:00:00.000Z', 'john.doe@test.com', true, 0, 'john doe', 'fcf730b6d95236ecd3c9fc2d92d7b6b2bb061514961aec041d6c7a7192f592e4', 'john_doe'),
+    ('608f32a2c7351f001f6475e0', 'confirmed', '2023-01-02T12:00:00.000Z', '2023-01-02T12:00:00.000Z', 'jane.smith@test.com', true, 3, 'Jane Smith', 'a0b8c29f4c8d57e542f5e81d35ebe801fd27f569f116fe670e8962d798512a1d', 'jane_smith'),
+    ('709f45b5e812c1002f3a67e7', 'confirmed', '2023-01-03T12:00:00.000Z', '2023-01-03T12:00:00.000Z', 'bob.johnson@test.com', true, 10, 'Bob Johnson', '5f3b3956a1a150b73e6b27e674f27d7aeb01ab1a40c179c3e1aa6026a36655a2', 'bob_johnson'),
+    ('80fdcea1d7299c002f3a67e8', 'not-confirmed', '2023-01-04T12:00:00.000Z', DEFAULT, 'alex.rodriguez@test.com', false, 3, 'Alex Rodriguez', 'c5093eb98678c7a3324825b84c6b67c1127b93786482ddbbd356e67e29b2763f', 'alex_rodriguez'),
+    ('6509e169ae6144b2f56bf288', 'confirmed', '2023-01-05T12:00:00.000Z', '2023-01-05T12:00:00.000Z', 'maria.garcia@test.com', true, 5, 'Maria Garcia', 'c2301b2b7e872843b473d2c301e4fb2e6e9f27f2e7a1b6ad44a3b2c97f1670b3', 'maria_garcia');
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// groupColumns are the columns of the groups table, in the order scanned by scanGroup.
+const groupColumns = `id, tenant_id, parent_id, name, description, created_at, updated_at`
+
+func scanGroup(row pgx.Row) (*models.Group, error) {
+	group := new(models.Group)
+	if err := row.Scan(&group.ID, &group.TenantID, &group.ParentID, &group.Name, &group.Description, &group.CreatedAt, &group.UpdatedAt); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return group, nil
+}
+
+func (s *Store) GroupList(ctx context.Context, tenantID string) ([]models.Group, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+groupColumns+` FROM groups WHERE tenant_id = $1 ORDER BY name ASC`, tenantID)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanGroup)
+}
+
+func (s *Store) GroupGet(ctx context.Context, tenantID, id string) (*models.Group, error) {
+	return scanGroup(s.db(ctx).QueryRow(ctx, `SELECT `+groupColumns+` FROM groups WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+}
+
+func (s *Store) GroupCreate(ctx context.Context, group *models.Group) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO groups (`+groupColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		group.ID, group.TenantID, group.ParentID, group.Name, group.Description, group.CreatedAt, group.UpdatedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) GroupUpdate(ctx context.Context, group *models.Group) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE groups SET parent_id = $3, name = $4, description = $5, updated_at = $6
+		WHERE id = $1 AND tenant_id = $2`,
+		group.ID, group.TenantID, group.ParentID, group.Name, group.Description, group.UpdatedAt,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) GroupDelete(ctx context.Context, tenantID, id string) error {
+	return s.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err := s.db(ctx).Exec(ctx, `DELETE FROM groups WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+		if err != nil {
+			return FromPostgresError(err)
+		}
+
+		if res.RowsAffected() < 1 {
+			return store.ErrNoDocuments
+		}
+
+		if _, err := s.db(ctx).Exec(ctx, `
+			UPDATE devices SET groups = array_remove(groups, $2)
+			WHERE tenant_id = $1 AND $2 = ANY(groups)`,
+			tenantID, id,
+		); err != nil {
+			return FromPostgresError(err)
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) GroupAddDevice(ctx context.Context, tenantID, id string, uid models.UID) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE devices SET groups = CASE WHEN $3 = ANY(groups) THEN groups ELSE array_append(groups, $3) END
+		WHERE tenant_id = $1 AND uid = $2`,
+		tenantID, string(uid), id,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) GroupRemoveDevice(ctx context.Context, tenantID, id string, uid models.UID) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE devices SET groups = array_remove(groups, $3) WHERE tenant_id = $1 AND uid = $2`, tenantID, string(uid), id)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
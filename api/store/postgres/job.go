@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// jobColumns are the columns of the jobs table, in the order scanned by scanJob.
+const jobColumns = `id, tenant_id, user_id, operation, idempotency_key, tag, status, error, total, processed, failed, items, created_at, started_at, finished_at`
+
+// jobAlive filters out the jobs the Mongo store's TTL index would have expired.
+const jobAlive = `created_at > now() - ` + jobTTL
+
+func scanJob(row pgx.Row) (*models.Job, error) {
+	job := new(models.Job)
+	if err := row.Scan(
+		&job.ID,
+		&job.TenantID,
+		&job.UserID,
+		&job.Operation,
+		&job.IdempotencyKey,
+		&job.Tag,
+		&job.Status,
+		&job.Error,
+		&job.Total,
+		&job.Processed,
+		&job.Failed,
+		&job.Items,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Store) JobCreate(ctx context.Context, job *models.Job) error {
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM jobs WHERE NOT (`+jobAlive+`)`); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO jobs (`+jobColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`,
+		job.ID,
+		job.TenantID,
+		job.UserID,
+		string(job.Operation),
+		job.IdempotencyKey,
+		job.Tag,
+		string(job.Status),
+		job.Error,
+		job.Total,
+		job.Processed,
+		job.Failed,
+		nonNil(job.Items),
+		job.CreatedAt,
+		job.StartedAt,
+		job.FinishedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) JobGet(ctx context.Context, id string) (*models.Job, error) {
+	return scanJob(s.db(ctx).QueryRow(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1 AND `+jobAlive, id))
+}
+
+func (s *Store) JobGetByIdempotencyKey(ctx context.Context, tenantID, key string) (*models.Job, error) {
+	return scanJob(s.db(ctx).QueryRow(ctx, `
+		SELECT `+jobColumns+` FROM jobs
+		WHERE tenant_id = $1 AND idempotency_key = $2 AND `+jobAlive+`
+		LIMIT 1`,
+		tenantID, key,
+	))
+}
+
+func (s *Store) JobSetRunning(ctx context.Context, id string, at time.Time) error {
+	return s.jobUpdate(ctx, id, `status = $2, started_at = $3`, string(models.JobStatusRunning), at)
+}
+
+func (s *Store) JobSetItem(ctx context.Context, id string, index int, item *models.JobItem) error {
+	failed := 0
+	if item.Status == models.JobItemStatusFailed {
+		failed = 1
+	}
+
+	return s.jobUpdate(
+		ctx,
+		id,
+		`items = jsonb_set(items, ARRAY[$2::text], $3::jsonb), processed = processed + 1, failed = failed + $4`,
+		strconv.Itoa(index),
+		item,
+		failed,
+	)
+}
+
+func (s *Store) JobSetFinished(ctx context.Context, id string, status models.JobStatus, reason string, at time.Time) error {
+	return s.jobUpdate(ctx, id, `status = $2, error = $3, finished_at = $4`, string(status), reason, at)
+}
+
+// jobUpdate sets the job's columns on set, whose placeholders start at $2, as $1 is the job's ID.
+func (s *Store) jobUpdate(ctx context.Context, id string, set string, args ...any) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE jobs SET `+set+` WHERE id = $1 AND `+jobAlive, append([]any{id}, args...)...)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
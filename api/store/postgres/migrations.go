@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationsLockID is the key of the advisory lock held while the migrations are applied, so the API's instances
+// started together don't apply them at the same time.
+const migrationsLockID = 0x5348454c4c485542
+
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrations returns the embedded migrations, ordered by their versions. A migration's version is the number that
+// prefixes its file's name, like 001 on 001_initial.sql.
+func migrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationsFS, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]migration, 0, len(entries))
+	for _, entry := range entries {
+		prefix, _, _ := strings.Cut(entry.Name(), "_")
+
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, errors.New("invalid migration's name: " + entry.Name())
+		}
+
+		data, err := migrationsFS.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, migration{version: version, name: entry.Name(), sql: string(data)})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].version < list[j].version
+	})
+
+	return list, nil
+}
+
+// RunMigrations applies the migrations not applied yet to the database, each one in its own transaction.
+func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
+	list, err := migrations()
+	if err != nil {
+		return errors.Join(ErrStoreApplyMigration, err)
+	}
+
+	for _, m := range list {
+		if err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(migrationsLockID)); err != nil {
+				return err
+			}
+
+			if _, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version integer PRIMARY KEY, applied_at timestamptz NOT NULL DEFAULT now())`); err != nil {
+				return err
+			}
+
+			var applied bool
+			if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, m.version).Scan(&applied); err != nil {
+				return err
+			}
+
+			if applied {
+				return nil
+			}
+
+			log.WithFields(log.Fields{"version": m.version, "name": m.name}).Info("Applying the PostgreSQL migration")
+
+			// NOTICE: a statement without arguments is sent on the simple protocol, which runs the many statements on
+			// the migration's file at once.
+			if _, err := tx.Exec(ctx, m.sql); err != nil {
+				return err
+			}
+
+			_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, m.version)
+
+			return err
+		}); err != nil {
+			return errors.Join(ErrStoreApplyMigration, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,373 @@
+-- The initial schema of the ShellHub's PostgreSQL store. Each table matches a Mongo collection; the fields grouped in
+-- embedded documents, which are read and written as a whole, are kept as JSONB columns.
+
+CREATE TABLE namespaces (
+    tenant_id text PRIMARY KEY,
+    name text NOT NULL,
+    owner text NOT NULL DEFAULT '',
+    members jsonb NOT NULL DEFAULT '[]',
+    settings jsonb,
+    devices integer NOT NULL DEFAULT 0,
+    sessions integer NOT NULL DEFAULT 0,
+    max_devices integer NOT NULL DEFAULT 0,
+    max_members integer NOT NULL DEFAULT 0,
+    max_invitations integer NOT NULL DEFAULT 0,
+    max_pending_devices integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    billing jsonb,
+    type text NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX namespaces_name_idx ON namespaces (name);
+CREATE INDEX namespaces_members_idx ON namespaces USING gin (members jsonb_path_ops);
+
+CREATE TABLE users (
+    id text PRIMARY KEY,
+    origin text NOT NULL DEFAULT '',
+    external_id text NOT NULL DEFAULT '',
+    status text NOT NULL DEFAULT '',
+    max_namespaces integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    last_login timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+    email_marketing boolean NOT NULL DEFAULT false,
+    name text NOT NULL DEFAULT '',
+    username text NOT NULL DEFAULT '',
+    email text NOT NULL DEFAULT '',
+    recovery_email text NOT NULL DEFAULT '',
+    mfa_enabled boolean NOT NULL DEFAULT false,
+    mfa_secret text NOT NULL DEFAULT '',
+    mfa_recovery_codes text[] NOT NULL DEFAULT '{}',
+    preferred_namespace text NOT NULL DEFAULT '',
+    auth_methods text[] NOT NULL DEFAULT '{}',
+    password text NOT NULL DEFAULT '',
+    aliases jsonb NOT NULL DEFAULT '[]'
+);
+
+-- NOTICE: the invited users have no username until they sign up, so only the filled usernames must be unique.
+CREATE UNIQUE INDEX users_username_idx ON users (username) WHERE username <> '';
+CREATE UNIQUE INDEX users_email_idx ON users (email);
+
+CREATE TABLE devices (
+    uid text PRIMARY KEY,
+    name text NOT NULL DEFAULT '',
+    identity jsonb,
+    info jsonb,
+    public_key text NOT NULL DEFAULT '',
+    tenant_id text NOT NULL DEFAULT '',
+    last_seen timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+    status text NOT NULL DEFAULT '',
+    status_updated_at timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+    created_at timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+    remote_addr text NOT NULL DEFAULT '',
+    latitude double precision,
+    longitude double precision,
+    geohash text,
+    tags text[] NOT NULL DEFAULT '{}',
+    groups text[] NOT NULL DEFAULT '{}',
+    public_url boolean NOT NULL DEFAULT false,
+    public_url_address text NOT NULL DEFAULT '',
+    acceptable boolean NOT NULL DEFAULT false,
+    compromised boolean NOT NULL DEFAULT false,
+    addresses jsonb NOT NULL DEFAULT '[]',
+    claim_code text NOT NULL DEFAULT '',
+    login_shell text NOT NULL DEFAULT '',
+    remote_access boolean NOT NULL DEFAULT false,
+    connection_note text NOT NULL DEFAULT '',
+    limit_exempt boolean NOT NULL DEFAULT false,
+    connection_samples jsonb NOT NULL DEFAULT '[]',
+    connection_quality jsonb,
+    queue jsonb,
+    config jsonb,
+    config_version integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX devices_tenant_id_status_idx ON devices (tenant_id, status);
+CREATE INDEX devices_tenant_id_name_idx ON devices (tenant_id, name);
+CREATE INDEX devices_last_seen_idx ON devices (last_seen);
+CREATE INDEX devices_tags_idx ON devices USING gin (tags);
+CREATE INDEX devices_public_url_address_idx ON devices (public_url_address) WHERE public_url_address <> '';
+CREATE INDEX devices_geohash_idx ON devices (tenant_id, geohash) WHERE geohash IS NOT NULL;
+
+CREATE TABLE connected_devices (
+    uid text PRIMARY KEY,
+    tenant_id text NOT NULL DEFAULT '',
+    last_seen timestamptz NOT NULL,
+    connected_at timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00+00',
+    offline_at timestamptz
+);
+
+CREATE INDEX connected_devices_tenant_id_idx ON connected_devices (tenant_id);
+CREATE INDEX connected_devices_offline_at_idx ON connected_devices (offline_at) WHERE offline_at IS NOT NULL;
+CREATE INDEX connected_devices_last_seen_idx ON connected_devices (last_seen);
+
+CREATE TABLE removed_devices (
+    tenant_id text NOT NULL,
+    uid text NOT NULL,
+    device jsonb NOT NULL,
+    timestamp timestamptz NOT NULL,
+    PRIMARY KEY (tenant_id, uid)
+);
+
+CREATE TABLE sessions (
+    uid text PRIMARY KEY,
+    device_uid text NOT NULL DEFAULT '',
+    tenant_id text NOT NULL DEFAULT '',
+    username text NOT NULL DEFAULT '',
+    ip_address text NOT NULL DEFAULT '',
+    started_at timestamptz NOT NULL,
+    last_seen timestamptz NOT NULL,
+    closed boolean NOT NULL DEFAULT false,
+    authenticated boolean NOT NULL DEFAULT false,
+    recorded boolean NOT NULL DEFAULT false,
+    type text NOT NULL DEFAULT '',
+    term text NOT NULL DEFAULT '',
+    position jsonb NOT NULL DEFAULT '{"longitude": 0, "latitude": 0}',
+    event_types text[] NOT NULL DEFAULT '{}',
+    events jsonb NOT NULL DEFAULT '[]',
+    client jsonb NOT NULL DEFAULT '{}',
+    device_name text NOT NULL DEFAULT '',
+    namespace text NOT NULL DEFAULT '',
+    record_type text NOT NULL DEFAULT '',
+    record_hash text NOT NULL DEFAULT '',
+    record_object text NOT NULL DEFAULT '',
+    attestation jsonb
+);
+
+CREATE INDEX sessions_tenant_id_started_at_idx ON sessions (tenant_id, started_at DESC);
+CREATE INDEX sessions_device_uid_idx ON sessions (device_uid);
+
+CREATE TABLE active_sessions (
+    uid text PRIMARY KEY,
+    last_seen timestamptz NOT NULL,
+    tenant_id text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX active_sessions_tenant_id_idx ON active_sessions (tenant_id);
+CREATE INDEX active_sessions_last_seen_idx ON active_sessions (last_seen);
+
+CREATE TABLE public_keys (
+    tenant_id text NOT NULL,
+    fingerprint text NOT NULL,
+    data bytea NOT NULL,
+    created_at timestamptz NOT NULL,
+    name text NOT NULL DEFAULT '',
+    username text NOT NULL DEFAULT '',
+    filter_hostname text NOT NULL DEFAULT '',
+    filter_tags text[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (tenant_id, fingerprint)
+);
+
+CREATE INDEX public_keys_filter_tags_idx ON public_keys USING gin (filter_tags);
+
+CREATE TABLE private_keys (
+    fingerprint text PRIMARY KEY,
+    data bytea NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+CREATE TABLE api_keys (
+    id text PRIMARY KEY,
+    name text NOT NULL,
+    tenant_id text NOT NULL,
+    role text NOT NULL,
+    created_by text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    expires_in bigint NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX api_keys_tenant_id_name_idx ON api_keys (tenant_id, name);
+
+CREATE TABLE user_sessions (
+    id text PRIMARY KEY,
+    user_id text NOT NULL,
+    user_agent text NOT NULL DEFAULT '',
+    ip text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    last_seen_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL,
+    refresh_token text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX user_sessions_user_id_idx ON user_sessions (user_id, last_seen_at DESC);
+
+-- NOTICE: the system's configuration is a single document, read and written by the paths of its fields, so it is kept
+-- as JSONB on a single row.
+CREATE TABLE system (
+    id boolean PRIMARY KEY DEFAULT true CHECK (id),
+    document jsonb NOT NULL
+);
+
+INSERT INTO system (document) VALUES ('{
+    "setup": false,
+    "authentication": {
+        "local": {"enabled": true},
+        "saml": {
+            "enabled": false,
+            "idp": {"entity_id": "", "signon_url": "", "certificates": []},
+            "sp": {"sign_auth_requests": false, "certificate": "", "private_key": ""}
+        }
+    }
+}');
+
+CREATE TABLE banned_addresses (
+    address text PRIMARY KEY,
+    reason text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz
+);
+
+CREATE TABLE device_agent_logs (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    device_uid text NOT NULL,
+    level text NOT NULL,
+    message text NOT NULL,
+    error text NOT NULL DEFAULT '',
+    time timestamptz NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX device_agent_logs_device_idx ON device_agent_logs (tenant_id, device_uid, time DESC);
+
+CREATE SEQUENCE device_changes_seq;
+
+CREATE TABLE device_changes (
+    tenant_id text NOT NULL,
+    uid text NOT NULL,
+    seq bigint NOT NULL,
+    type text NOT NULL,
+    changed_at timestamptz NOT NULL,
+    expires_at timestamptz,
+    PRIMARY KEY (tenant_id, uid)
+);
+
+CREATE INDEX device_changes_seq_idx ON device_changes (tenant_id, seq);
+
+CREATE TABLE device_key_incidents (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    device_uid text NOT NULL,
+    mac text NOT NULL DEFAULT '',
+    pinned_public_key text NOT NULL DEFAULT '',
+    public_key text NOT NULL DEFAULT '',
+    remote_addr text NOT NULL DEFAULT '',
+    status text NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+
+CREATE INDEX device_key_incidents_tenant_id_idx ON device_key_incidents (tenant_id, created_at DESC);
+
+CREATE TABLE device_limit_exemptions (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    device_uid text NOT NULL,
+    exempt boolean NOT NULL,
+    user_id text NOT NULL DEFAULT '',
+    reason text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX device_limit_exemptions_device_idx ON device_limit_exemptions (tenant_id, device_uid, created_at DESC);
+
+CREATE TABLE device_quarantine_attempts (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    device_uid text NOT NULL,
+    hostname text NOT NULL DEFAULT '',
+    mac text NOT NULL DEFAULT '',
+    public_key text NOT NULL DEFAULT '',
+    remote_addr text NOT NULL DEFAULT '',
+    country text NOT NULL DEFAULT '',
+    info jsonb,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX device_quarantine_attempts_device_idx ON device_quarantine_attempts (tenant_id, device_uid, created_at DESC);
+
+CREATE TABLE public_url_logs (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    device_uid text NOT NULL,
+    method text NOT NULL,
+    path text NOT NULL DEFAULT '',
+    status integer NOT NULL DEFAULT 0,
+    bytes bigint NOT NULL DEFAULT 0,
+    latency bigint NOT NULL DEFAULT 0,
+    source_ip text NOT NULL DEFAULT '',
+    time timestamptz NOT NULL
+);
+
+CREATE INDEX public_url_logs_device_idx ON public_url_logs (tenant_id, device_uid, time DESC);
+
+CREATE TABLE session_schedule_overrides (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    device_uid text NOT NULL,
+    user_id text NOT NULL DEFAULT '',
+    reason text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX session_schedule_overrides_device_idx ON session_schedule_overrides (tenant_id, device_uid, expires_at DESC);
+
+CREATE TABLE session_recording_accesses (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    session_uid text NOT NULL,
+    user_id text NOT NULL DEFAULT '',
+    username text NOT NULL DEFAULT '',
+    action text NOT NULL,
+    range text NOT NULL DEFAULT '',
+    source_ip text NOT NULL DEFAULT '',
+    accessed_at timestamptz NOT NULL
+);
+
+CREATE INDEX session_recording_accesses_session_idx ON session_recording_accesses (tenant_id, session_uid, accessed_at DESC);
+
+CREATE TABLE tag_rules (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    name text NOT NULL,
+    tag text NOT NULL,
+    conditions jsonb NOT NULL DEFAULT '[]',
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+
+CREATE INDEX tag_rules_tenant_id_idx ON tag_rules (tenant_id, created_at);
+
+CREATE TABLE groups (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    parent_id text NOT NULL DEFAULT '',
+    name text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+
+CREATE INDEX groups_tenant_id_idx ON groups (tenant_id, name);
+
+CREATE TABLE jobs (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    user_id text NOT NULL DEFAULT '',
+    operation text NOT NULL,
+    idempotency_key text NOT NULL DEFAULT '',
+    tag text NOT NULL DEFAULT '',
+    status text NOT NULL,
+    error text NOT NULL DEFAULT '',
+    total integer NOT NULL DEFAULT 0,
+    processed integer NOT NULL DEFAULT 0,
+    failed integer NOT NULL DEFAULT 0,
+    items jsonb NOT NULL DEFAULT '[]',
+    created_at timestamptz NOT NULL,
+    started_at timestamptz,
+    finished_at timestamptz
+);
+
+CREATE INDEX jobs_idempotency_key_idx ON jobs (tenant_id, idempotency_key) WHERE idempotency_key <> '';
+CREATE INDEX jobs_created_at_idx ON jobs (created_at);
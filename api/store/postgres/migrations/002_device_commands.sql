@@ -0,0 +1,17 @@
+CREATE TABLE device_commands (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    device_uid text NOT NULL,
+    command text NOT NULL,
+    "user" text NOT NULL,
+    status text NOT NULL,
+    exit_code integer NOT NULL DEFAULT 0,
+    output text NOT NULL DEFAULT '',
+    created_by text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    sent_at timestamptz,
+    finished_at timestamptz
+);
+
+CREATE INDEX device_commands_device_idx ON device_commands (tenant_id, device_uid, created_at DESC);
+CREATE INDEX device_commands_queued_idx ON device_commands (tenant_id, device_uid, created_at) WHERE status = 'queued';
@@ -0,0 +1,12 @@
+CREATE TABLE device_snapshots (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    device_uid text NOT NULL,
+    identity jsonb,
+    info jsonb,
+    config_version integer NOT NULL DEFAULT 0,
+    taken_at timestamptz NOT NULL
+);
+
+CREATE INDEX device_snapshots_device_idx ON device_snapshots (tenant_id, device_uid, taken_at DESC);
+CREATE INDEX device_snapshots_taken_at_idx ON device_snapshots (taken_at);
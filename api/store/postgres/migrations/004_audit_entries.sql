@@ -0,0 +1,16 @@
+CREATE TABLE audit_entries (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    actor_type text NOT NULL,
+    actor_id text NOT NULL DEFAULT '',
+    actor_username text NOT NULL DEFAULT '',
+    action text NOT NULL,
+    target_type text NOT NULL,
+    target_id text NOT NULL,
+    details jsonb,
+    source_ip text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX audit_entries_tenant_idx ON audit_entries (tenant_id, created_at DESC);
+CREATE INDEX audit_entries_target_idx ON audit_entries (tenant_id, target_type, target_id, created_at DESC);
@@ -0,0 +1,12 @@
+CREATE TABLE enroll_tokens (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    digest text NOT NULL UNIQUE,
+    max_uses integer NOT NULL,
+    uses integer NOT NULL DEFAULT 0,
+    created_by text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX enroll_tokens_tenant_idx ON enroll_tokens (tenant_id, created_at DESC);
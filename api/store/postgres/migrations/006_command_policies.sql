@@ -0,0 +1,28 @@
+CREATE TABLE command_policies (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    name text NOT NULL,
+    tags text[] NOT NULL DEFAULT '{}',
+    mode text NOT NULL,
+    patterns text[] NOT NULL DEFAULT '{}',
+    action text NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+
+CREATE INDEX command_policies_tenant_id_idx ON command_policies (tenant_id, created_at);
+
+CREATE TABLE command_policy_evaluations (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    policy_id text NOT NULL,
+    policy_name text NOT NULL DEFAULT '',
+    session_uid text NOT NULL,
+    device_uid text NOT NULL DEFAULT '',
+    username text NOT NULL DEFAULT '',
+    command text NOT NULL DEFAULT '',
+    action text NOT NULL,
+    time timestamptz NOT NULL
+);
+
+CREATE INDEX command_policy_evaluations_tenant_idx ON command_policy_evaluations (tenant_id, time DESC);
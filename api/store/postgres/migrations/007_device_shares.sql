@@ -0,0 +1,13 @@
+CREATE TABLE device_shares (
+    id text PRIMARY KEY,
+    tenant_id text NOT NULL,
+    uid text NOT NULL,
+    grantee_tenant_id text NOT NULL,
+    permission text NOT NULL,
+    created_by text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz
+);
+
+CREATE INDEX device_shares_tenant_uid_idx ON device_shares (tenant_id, uid, created_at);
+CREATE INDEX device_shares_grantee_idx ON device_shares (grantee_tenant_id, created_at);
@@ -0,0 +1,6 @@
+CREATE TABLE user_verifications (
+    user_id text PRIMARY KEY,
+    digest text NOT NULL UNIQUE,
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
@@ -0,0 +1 @@
+ALTER TABLE enroll_tokens ADD COLUMN revoked_at timestamptz;
@@ -0,0 +1,464 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// namespaceColumns are the columns of the namespaces table, in the order scanned by scanNamespace.
+const namespaceColumns = `tenant_id, name, owner, members, settings, devices, sessions, max_devices, max_members, max_invitations,
+	max_pending_devices, created_at, billing, type`
+
+// namespaceFilterColumns are the namespaces' fields that they may be filtered by.
+var namespaceFilterColumns = queries.Columns{
+	"tenant_id":           queries.KindScalar,
+	"name":                queries.KindScalar,
+	"owner":               queries.KindScalar,
+	"members":             queries.KindJSON,
+	"settings":            queries.KindJSON,
+	"devices":             queries.KindScalar,
+	"sessions":            queries.KindScalar,
+	"max_devices":         queries.KindScalar,
+	"max_members":         queries.KindScalar,
+	"max_invitations":     queries.KindScalar,
+	"max_pending_devices": queries.KindScalar,
+	"created_at":          queries.KindScalar,
+	"billing":             queries.KindJSON,
+	"type":                queries.KindScalar,
+}
+
+// namespaceHasMember returns an expression that matches the namespaces that have the member on the placeholder id.
+func namespaceHasMember(id string) string {
+	return "members @> jsonb_build_array(jsonb_build_object('id', " + id + "::text))"
+}
+
+func scanNamespace(row pgx.Row) (*models.Namespace, error) {
+	ns := new(models.Namespace)
+	if err := row.Scan(
+		&ns.TenantID, &ns.Name, &ns.Owner, &ns.Members, &ns.Settings, &ns.Devices, &ns.Sessions, &ns.MaxDevices,
+		&ns.MaxMembers, &ns.MaxInvitations, &ns.MaxPendingDevices, &ns.CreatedAt, &ns.Billing, &ns.Type,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return ns, nil
+}
+
+// applyNamespaceOptions applies the query's options to the namespace.
+func applyNamespaceOptions(ctx context.Context, ns *models.Namespace, opts []store.NamespaceQueryOption) error {
+	for _, opt := range opts {
+		if err := opt(ctx, ns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceList(ctx context.Context, paginator query.Paginator, filters query.Filters, opts ...store.NamespaceQueryOption) ([]models.Namespace, int, error) {
+	args := queries.NewArgs()
+
+	where := "TRUE"
+
+	filter, err := queries.FromFilters(&filters, namespaceFilterColumns, args)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	if filter != "" {
+		where = filter
+	}
+
+	// Only match for the respective tenant if requested
+	if id := gateway.IDFromContext(ctx); id != nil {
+		user, _, err := s.UserGetByID(ctx, id.ID, false)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		where += " AND EXISTS (SELECT 1 FROM jsonb_array_elements(members) AS m WHERE m ->> 'id' = " + args.Add(user.ID) + " AND m ->> 'status' IS DISTINCT FROM " + args.Add(string(models.MemberStatusPending)) + ")"
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM namespaces WHERE `+where, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+namespaceColumns+` FROM namespaces WHERE `+where+` ORDER BY created_at ASC`+queries.FromPaginator(&paginator), args.Values()...)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	namespaces, err := collect(rows, scanNamespace)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range namespaces {
+		if err := applyNamespaceOptions(ctx, &namespaces[i], opts); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return namespaces, count, nil
+}
+
+func (s *Store) NamespaceGet(ctx context.Context, tenantID string, opts ...store.NamespaceQueryOption) (*models.Namespace, error) {
+	var ns *models.Namespace
+
+	if _ = s.cache.Get(ctx, strings.Join([]string{"namespace", tenantID}, "/"), &ns); ns == nil || ns.TenantID == "" {
+		var err error
+		if ns, err = scanNamespace(s.db(ctx).QueryRow(ctx, `SELECT `+namespaceColumns+` FROM namespaces WHERE tenant_id = $1`, tenantID)); err != nil {
+			return nil, err
+		}
+
+		if err := s.cache.Set(ctx, strings.Join([]string{"namespace", tenantID}, "/"), ns, time.Minute); err != nil {
+			log.WithContext(ctx).Error(err)
+		}
+	}
+
+	if err := applyNamespaceOptions(ctx, ns, opts); err != nil {
+		return nil, err
+	}
+
+	return ns, nil
+}
+
+func (s *Store) NamespaceGetByName(ctx context.Context, name string, opts ...store.NamespaceQueryOption) (*models.Namespace, error) {
+	var ns *models.Namespace
+
+	if _ = s.cache.Get(ctx, strings.Join([]string{"namespace", name}, "/"), &ns); ns == nil || ns.TenantID == "" {
+		if ns != nil {
+			return ns, nil
+		}
+
+		var err error
+		if ns, err = scanNamespace(s.db(ctx).QueryRow(ctx, `SELECT `+namespaceColumns+` FROM namespaces WHERE name = $1`, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyNamespaceOptions(ctx, ns, opts); err != nil {
+		return nil, err
+	}
+
+	return ns, nil
+}
+
+func (s *Store) NamespaceGetPreferred(ctx context.Context, userID string, opts ...store.NamespaceQueryOption) (*models.Namespace, error) {
+	args := queries.NewArgs(userID)
+
+	where := namespaceHasMember("$1")
+
+	if user, _, _ := s.UserGetByID(ctx, userID, false); user != nil {
+		if user.Preferences.PreferredNamespace != "" {
+			where += " AND tenant_id = " + args.Add(user.Preferences.PreferredNamespace)
+		}
+	}
+
+	ns, err := scanNamespace(s.db(ctx).QueryRow(ctx, `SELECT `+namespaceColumns+` FROM namespaces WHERE `+where+` ORDER BY created_at ASC LIMIT 1`, args.Values()...))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyNamespaceOptions(ctx, ns, opts); err != nil {
+		return nil, err
+	}
+
+	return ns, nil
+}
+
+func (s *Store) NamespaceCreate(ctx context.Context, namespace *models.Namespace) (*models.Namespace, error) {
+	namespace.CreatedAt = clock.Now()
+
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO namespaces (`+namespaceColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		namespace.TenantID, namespace.Name, namespace.Owner, nonNil(namespace.Members), namespace.Settings,
+		namespace.Devices, namespace.Sessions, namespace.MaxDevices, namespace.MaxMembers, namespace.MaxInvitations,
+		namespace.MaxPendingDevices, namespace.CreatedAt, namespace.Billing, string(namespace.Type),
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return namespace, nil
+}
+
+func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
+	return s.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err := s.db(ctx).Exec(ctx, `DELETE FROM namespaces WHERE tenant_id = $1`, tenantID)
+		if err != nil {
+			return FromPostgresError(err)
+		}
+
+		if res.RowsAffected() < 1 {
+			return store.ErrNoDocuments
+		}
+
+		if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+			log.WithContext(ctx).Error(err)
+		}
+
+		tables := []string{"devices", "sessions", "connected_devices", "public_keys", "api_keys", "tag_rules", "command_policies", "command_policy_evaluations", "device_shares"}
+		for _, table := range tables {
+			if _, err := s.db(ctx).Exec(ctx, `DELETE FROM `+table+` WHERE tenant_id = $1`, tenantID); err != nil {
+				return FromPostgresError(err)
+			}
+		}
+
+		if _, err := s.db(ctx).Exec(ctx, `DELETE FROM device_shares WHERE grantee_tenant_id = $1`, tenantID); err != nil {
+			return FromPostgresError(err)
+		}
+
+		_, err = s.db(ctx).Exec(ctx, `UPDATE users SET preferred_namespace = '' WHERE preferred_namespace = $1`, tenantID)
+
+		return FromPostgresError(err)
+	})
+}
+
+// namespaceChanges returns the SET clause of the namespace's changes. The changes to the namespace's settings, whose
+// names are prefixed by "settings.", are written into the settings' JSON, and the others into their columns.
+func namespaceChanges(changes *models.NamespaceChanges, args *queries.Args) ([]string, error) {
+	set := make([]string, 0)
+	settings := "COALESCE(settings, '{}')"
+
+	value := reflect.ValueOf(changes).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.IsZero() {
+			continue
+		}
+
+		if field.Kind() == reflect.Pointer {
+			field = field.Elem()
+		}
+
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("bson"), ",")
+
+		key, ok := strings.CutPrefix(name, "settings.")
+		if !ok {
+			set = append(set, name+" = "+args.Add(field.Interface()))
+
+			continue
+		}
+
+		data, err := json.Marshal(field.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		settings = "jsonb_set(" + settings + ", " + args.Add([]string{key}) + "::text[], " + args.Add(string(data)) + "::jsonb)"
+	}
+
+	if settings != "COALESCE(settings, '{}')" {
+		set = append(set, "settings = "+settings)
+	}
+
+	return set, nil
+}
+
+func (s *Store) NamespaceEdit(ctx context.Context, tenant string, changes *models.NamespaceChanges) error {
+	args := queries.NewArgs(tenant)
+
+	set, err := namespaceChanges(changes, args)
+	if err != nil {
+		return err
+	}
+
+	// NOTICE: a namespace without changes is only checked to exist.
+	if len(set) == 0 {
+		set = append(set, "tenant_id = tenant_id")
+	}
+
+	res, err := s.db(ctx).Exec(ctx, `UPDATE namespaces SET `+strings.Join(set, ", ")+` WHERE tenant_id = $1`, args.Values()...)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenant}, "/")); err != nil {
+		log.WithContext(ctx).Error(err)
+	}
+
+	if changes.Name != "" {
+		return s.sessionsSetNamespace(ctx, tenant, changes.Name)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceUpdate(ctx context.Context, tenantID string, namespace *models.Namespace) error {
+	var sessionRecord bool
+	if namespace.Settings != nil {
+		sessionRecord = namespace.Settings.SessionRecord
+	}
+
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE namespaces SET
+			name = $2,
+			max_devices = $3,
+			settings = jsonb_set(COALESCE(settings, '{}'), '{session_record}', to_jsonb($4::boolean))
+		WHERE tenant_id = $1`,
+		tenantID, namespace.Name, namespace.MaxDevices, sessionRecord,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		log.WithContext(ctx).Error(err)
+	}
+
+	return s.sessionsSetNamespace(ctx, tenantID, namespace.Name)
+}
+
+func (s *Store) NamespaceAddMember(ctx context.Context, tenantID string, member *models.Member) error {
+	var exists bool
+	if err := s.db(ctx).QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM namespaces WHERE tenant_id = $1 AND `+namespaceHasMember("$2")+`)`, tenantID, member.ID).Scan(&exists); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if exists {
+		return ErrNamespaceDuplicatedMember
+	}
+
+	// NOTICE: the member's email isn't kept on the namespace, as it is the user's one.
+	res, err := s.db(ctx).Exec(
+		ctx,
+		`UPDATE namespaces SET members = members || jsonb_build_array($2::jsonb) WHERE tenant_id = $1`,
+		tenantID, models.Member{ID: member.ID, AddedAt: member.AddedAt, ExpiresAt: member.ExpiresAt, Role: member.Role, Status: member.Status},
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		log.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceUpdateMember(ctx context.Context, tenantID string, memberID string, changes *models.MemberChanges) error {
+	update := map[string]any{}
+
+	if changes.Role != "" {
+		update["role"] = changes.Role
+	}
+
+	if changes.Status != "" {
+		update["status"] = changes.Status
+	}
+
+	if changes.ExpiresAt != nil {
+		update["expires_at"] = *changes.ExpiresAt
+	}
+
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE namespaces SET members = (
+			SELECT jsonb_agg(CASE WHEN m ->> 'id' = $2 THEN m || $3::jsonb ELSE m END ORDER BY i)
+			FROM jsonb_array_elements(members) WITH ORDINALITY AS e (m, i)
+		)
+		WHERE tenant_id = $1 AND `+namespaceHasMember("$2"),
+		tenantID, memberID, update,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return ErrUserNotFound
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		log.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceRemoveMember(ctx context.Context, tenantID string, memberID string) error {
+	if err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		var member bool
+		if err := s.db(ctx).QueryRow(ctx, `SELECT `+namespaceHasMember("$2")+` FROM namespaces WHERE tenant_id = $1 FOR UPDATE`, tenantID, memberID).Scan(&member); err != nil {
+			return FromPostgresError(err) // tenant not found
+		}
+
+		if !member {
+			return ErrUserNotFound
+		}
+
+		if _, err := s.db(ctx).Exec(ctx, `
+			UPDATE namespaces SET members = (
+				SELECT COALESCE(jsonb_agg(m ORDER BY i), '[]')
+				FROM jsonb_array_elements(members) WITH ORDINALITY AS e (m, i)
+				WHERE m ->> 'id' IS DISTINCT FROM $2
+			)
+			WHERE tenant_id = $1`,
+			tenantID, memberID,
+		); err != nil {
+			return FromPostgresError(err)
+		}
+
+		_, err := s.db(ctx).Exec(ctx, `UPDATE users SET preferred_namespace = '' WHERE id = $1 AND preferred_namespace = $2`, memberID, tenantID)
+
+		return FromPostgresError(err)
+	}); err != nil {
+		return err
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		log.WithContext(ctx).Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
+	res, err := s.db(ctx).Exec(
+		ctx,
+		`UPDATE namespaces SET settings = jsonb_set(COALESCE(settings, '{}'), '{session_record}', to_jsonb($2::boolean)) WHERE tenant_id = $1`,
+		tenantID, sessionRecord,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
+	var sessionRecord bool
+	if err := s.db(ctx).QueryRow(ctx, `SELECT COALESCE((settings ->> 'session_record')::boolean, false) FROM namespaces WHERE tenant_id = $1`, tenantID).Scan(&sessionRecord); err != nil {
+		return false, FromPostgresError(err)
+	}
+
+	return sessionRecord, nil
+}
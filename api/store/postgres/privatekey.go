@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) PrivateKeyCreate(ctx context.Context, key *models.PrivateKey) error {
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM private_keys WHERE created_at <= now() - `+privateKeyTTL); err != nil {
+		return FromPostgresError(err)
+	}
+
+	_, err := s.db(ctx).Exec(ctx, `INSERT INTO private_keys (data, fingerprint, created_at) VALUES ($1, $2, $3)`, key.Data, key.Fingerprint, key.CreatedAt)
+
+	return FromPostgresError(err)
+}
+
+func (s *Store) PrivateKeyGet(ctx context.Context, fingerprint string) (*models.PrivateKey, error) {
+	privKey := new(models.PrivateKey)
+	if err := s.db(ctx).QueryRow(ctx, `SELECT data, fingerprint, created_at FROM private_keys WHERE fingerprint = $1 AND created_at > now() - `+privateKeyTTL, fingerprint).Scan(&privKey.Data, &privKey.Fingerprint, &privKey.CreatedAt); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return privKey, nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// publicURLLogColumns are the columns of the public_url_logs table, in the order scanned by scanPublicURLLog.
+const publicURLLogColumns = `id, tenant_id, device_uid, method, path, status, bytes, latency, source_ip, time`
+
+func scanPublicURLLog(row pgx.Row) (*models.PublicURLLog, error) {
+	log := new(models.PublicURLLog)
+	if err := row.Scan(
+		&log.ID,
+		&log.TenantID,
+		&log.DeviceUID,
+		&log.Method,
+		&log.Path,
+		&log.Status,
+		&log.Bytes,
+		&log.Latency,
+		&log.SourceIP,
+		&log.Time,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return log, nil
+}
+
+func (s *Store) PublicURLLogCreate(ctx context.Context, log *models.PublicURLLog) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO public_url_logs (`+publicURLLogColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		log.ID,
+		log.TenantID,
+		log.DeviceUID,
+		log.Method,
+		log.Path,
+		log.Status,
+		log.Bytes,
+		log.Latency,
+		log.SourceIP,
+		log.Time,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) PublicURLLogList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.PublicURLLog, int, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM public_url_logs WHERE tenant_id = $1 AND device_uid = $2`, tenantID, string(uid))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.PublicURLLog{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+publicURLLogColumns+` FROM public_url_logs
+		WHERE tenant_id = $1 AND device_uid = $2
+		ORDER BY time DESC`+queries.FromPaginator(&paginator),
+		tenantID, string(uid),
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	logs, err := collect(rows, scanPublicURLLog)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, count, nil
+}
+
+func (s *Store) PublicURLLogStats(ctx context.Context, tenantID string, uid models.UID) (*models.PublicURLStats, error) {
+	stats := new(models.PublicURLStats)
+	if err := s.db(ctx).QueryRow(ctx, `
+		SELECT
+			count(*),
+			count(*) FILTER (WHERE status = 0 OR status >= 400),
+			coalesce(sum(bytes), 0)::bigint,
+			coalesce(avg(latency), 0)::float8,
+			count(DISTINCT source_ip)
+		FROM public_url_logs
+		WHERE tenant_id = $1 AND device_uid = $2`,
+		tenantID, string(uid),
+	).Scan(&stats.Requests, &stats.Errors, &stats.Bytes, &stats.Latency, &stats.SourceIPs); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return stats, nil
+}
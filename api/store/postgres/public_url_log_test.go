@@ -0,0 +1,149 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+var publicURLLogs = []models.PublicURLLog{
+	{
+		ID:        "6f1b2c3d-0c1b-4d7e-8f3a-000000000001",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		Method:    "GET",
+		Path:      "/",
+		Status:    200,
+		Bytes:     1024,
+		Latency:   10,
+		SourceIP:  "192.168.0.1",
+		Time:      time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:        "6f1b2c3d-0c1b-4d7e-8f3a-000000000002",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		Method:    "POST",
+		Path:      "/login",
+		Status:    500,
+		Bytes:     512,
+		Latency:   30,
+		SourceIP:  "192.168.0.2",
+		Time:      time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:        "6f1b2c3d-0c1b-4d7e-8f3a-000000000003",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		DeviceUID: "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+		Method:    "GET",
+		Path:      "/",
+		Status:    200,
+		Bytes:     256,
+		Latency:   5,
+		SourceIP:  "192.168.0.1",
+		Time:      time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestPublicURLLogList(t *testing.T) {
+	type Expected struct {
+		ids   []string
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		expected    Expected
+	}{
+		{
+			description: "succeeds when the device has no logs",
+			uid:         models.UID("4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"),
+			expected: Expected{
+				ids:   []string{},
+				count: 0,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds listing the device's logs",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			expected: Expected{
+				ids:   []string{"6f1b2c3d-0c1b-4d7e-8f3a-000000000002", "6f1b2c3d-0c1b-4d7e-8f3a-000000000001"},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := range publicURLLogs {
+				require.NoError(t, s.PublicURLLogCreate(ctx, &publicURLLogs[i]))
+			}
+
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			logs, count, err := s.PublicURLLogList(ctx, "00000000-0000-4000-0000-000000000000", tc.uid, query.Paginator{Page: 1, PerPage: 10})
+
+			ids := []string{}
+			for _, log := range logs {
+				ids = append(ids, log.ID)
+			}
+
+			require.Equal(t, tc.expected, Expected{ids, count, err})
+		})
+	}
+}
+
+func TestPublicURLLogStats(t *testing.T) {
+	type Expected struct {
+		stats *models.PublicURLStats
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		expected    Expected
+	}{
+		{
+			description: "succeeds when the device has no logs",
+			uid:         models.UID("4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"),
+			expected: Expected{
+				stats: &models.PublicURLStats{},
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds aggregating the device's logs",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			expected: Expected{
+				stats: &models.PublicURLStats{Requests: 2, Errors: 1, Bytes: 1536, Latency: 20, SourceIPs: 2},
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := range publicURLLogs {
+				require.NoError(t, s.PublicURLLogCreate(ctx, &publicURLLogs[i]))
+			}
+
+			t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+			stats, err := s.PublicURLLogStats(ctx, "00000000-0000-4000-0000-000000000000", tc.uid)
+			require.Equal(t, tc.expected, Expected{stats, err})
+		})
+	}
+}
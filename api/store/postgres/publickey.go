@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// publicKeyColumns are the columns of the public_keys table, in the order scanned by scanPublicKey.
+const publicKeyColumns = `data, fingerprint, created_at, tenant_id, name, username, filter_hostname, filter_tags`
+
+func scanPublicKey(row pgx.Row) (*models.PublicKey, error) {
+	key := new(models.PublicKey)
+	if err := row.Scan(&key.Data, &key.Fingerprint, &key.CreatedAt, &key.TenantID, &key.Name, &key.Username, &key.Filter.Hostname, &key.Filter.Tags); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	// NOTICE: the Mongo store omits the empty filter's tags, which are decoded as nil.
+	if len(key.Filter.Tags) == 0 {
+		key.Filter.Tags = nil
+	}
+
+	return key, nil
+}
+
+func (s *Store) PublicKeyGet(ctx context.Context, fingerprint string, tenantID string) (*models.PublicKey, error) {
+	return scanPublicKey(s.db(ctx).QueryRow(ctx, `SELECT `+publicKeyColumns+` FROM public_keys WHERE fingerprint = $1 AND tenant_id = $2`, fingerprint, tenantID))
+}
+
+func (s *Store) PublicKeyList(ctx context.Context, paginator query.Paginator) ([]models.PublicKey, int, error) {
+	args := queries.NewArgs()
+
+	where := "TRUE"
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		where = "tenant_id = " + args.Add(tenant.ID)
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM public_keys WHERE `+where, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+publicKeyColumns+` FROM public_keys WHERE `+where+` ORDER BY created_at ASC`+queries.FromPaginator(&paginator), args.Values()...)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	list, err := collect(rows, scanPublicKey)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, count, nil
+}
+
+func (s *Store) PublicKeyCreate(ctx context.Context, key *models.PublicKey) error {
+	_, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO public_keys (data, fingerprint, created_at, tenant_id, name, username, filter_hostname, filter_tags)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		key.Data, key.Fingerprint, key.CreatedAt, key.TenantID, key.Name, key.Username, key.Filter.Hostname, nonNil(key.Filter.Tags),
+	)
+
+	return FromPostgresError(err)
+}
+
+func (s *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error) {
+	return scanPublicKey(s.db(ctx).QueryRow(ctx, `
+		UPDATE public_keys SET name = $3, username = $4, filter_hostname = $5, filter_tags = $6
+		WHERE fingerprint = $1 AND tenant_id = $2
+		RETURNING `+publicKeyColumns,
+		fingerprint, tenantID, key.Name, key.Username, key.Filter.Hostname, nonNil(key.Filter.Tags),
+	))
+}
+
+func (s *Store) PublicKeyDelete(ctx context.Context, fingerprint string, tenantID string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM public_keys WHERE fingerprint = $1 AND tenant_id = $2`, fingerprint, tenantID)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) PublicKeyPushTag(ctx context.Context, tenant, fingerprint, tag string) error {
+	res, err := s.db(ctx).Exec(
+		ctx,
+		`UPDATE public_keys SET filter_tags = array_append(filter_tags, $3) WHERE tenant_id = $1 AND fingerprint = $2 AND NOT ($3 = ANY(filter_tags))`,
+		tenant, fingerprint, tag,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) PublicKeyPullTag(ctx context.Context, tenant, fingerprint, tag string) error {
+	res, err := s.db(ctx).Exec(
+		ctx,
+		`UPDATE public_keys SET filter_tags = `+pullTagWithDescendants("filter_tags", "$3", "$4")+` WHERE tenant_id = $1 AND fingerprint = $2 AND `+hasTagWithDescendants("filter_tags", "$3", "$4"),
+		tenant, fingerprint, tag, tag+models.TagSeparator,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) PublicKeySetTags(ctx context.Context, tenant, fingerprint string, tags []string) (int64, int64, error) {
+	var matched, modified int64
+
+	err := s.db(ctx).QueryRow(ctx, `
+		WITH updated AS (
+			UPDATE public_keys SET filter_tags = $3
+			WHERE tenant_id = $1 AND fingerprint = $2 AND filter_tags IS DISTINCT FROM $3
+			RETURNING fingerprint
+		)
+		SELECT (SELECT count(*) FROM public_keys WHERE tenant_id = $1 AND fingerprint = $2), (SELECT count(*) FROM updated)`,
+		tenant, fingerprint, nonNil(tags),
+	).Scan(&matched, &modified)
+
+	return matched, modified, FromPostgresError(err)
+}
+
+func (s *Store) PublicKeyBulkRenameTag(ctx context.Context, tenant, currentTag, newTag string) (int64, error) {
+	res, err := s.db(ctx).Exec(
+		ctx,
+		`UPDATE public_keys SET filter_tags = `+renameTagWithDescendants("filter_tags", "$2", "$3", "$4")+` WHERE tenant_id = $1 AND `+hasTagWithDescendants("filter_tags", "$2", "$3"),
+		tenant, currentTag, currentTag+models.TagSeparator, newTag,
+	)
+	if err != nil {
+		return 0, FromPostgresError(err)
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (s *Store) PublicKeyBulkDeleteTag(ctx context.Context, tenant, tag string) (int64, error) {
+	res, err := s.db(ctx).Exec(
+		ctx,
+		`UPDATE public_keys SET filter_tags = `+pullTagWithDescendants("filter_tags", "$2", "$3")+` WHERE tenant_id = $1 AND `+hasTagWithDescendants("filter_tags", "$2", "$3"),
+		tenant, tag, tag+models.TagSeparator,
+	)
+	if err != nil {
+		return 0, FromPostgresError(err)
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (s *Store) PublicKeyGetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	return s.distinctTags(ctx, "public_keys", "filter_tags", tenant)
+}
@@ -0,0 +1,160 @@
+package queries
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+)
+
+// Args accumulates the arguments of a statement, as the clauses are built, returning their placeholders.
+type Args struct {
+	values []any
+}
+
+// NewArgs creates an [Args] with the statement's arguments already placed.
+func NewArgs(values ...any) *Args {
+	return &Args{values: values}
+}
+
+// Add appends value to the arguments, returning its placeholder.
+func (a *Args) Add(value any) string {
+	a.values = append(a.values, value)
+
+	return "$" + strconv.Itoa(len(a.values))
+}
+
+// Values returns the arguments, in the order of their placeholders.
+func (a *Args) Values() []any {
+	return a.values
+}
+
+// Kind is how a column's values are compared by the filters.
+type Kind int
+
+const (
+	// KindScalar is a column with a single value, like a text or a number.
+	KindScalar Kind = iota
+	// KindArray is a text array column, like the tags.
+	KindArray
+	// KindJSON is a JSONB column, whose fields are filtered and sorted by their dotted paths, like "info.platform".
+	KindJSON
+)
+
+// Columns are the columns a query may be filtered and sorted by, indexed by the names used on the filters.
+type Columns map[string]Kind
+
+// expression returns the SQL expression of the filter's or sorter's name, and its kind. A name that is a dotted path
+// into a JSONB column is extracted as text.
+func (c Columns) expression(name string, args *Args) (string, Kind, bool) {
+	if kind, ok := c[name]; ok {
+		return quote(name), kind, true
+	}
+
+	column, path, ok := strings.Cut(name, ".")
+	if !ok || c[column] != KindJSON {
+		return "", 0, false
+	}
+
+	return fmt.Sprintf("(%s #>> %s::text[])", quote(column), args.Add(strings.Split(path, "."))), KindScalar, true
+}
+
+// quote quotes an identifier, so the columns named after reserved words, like "type", may be used.
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// FromPaginator converts the Paginator instance to a LIMIT and OFFSET clause. If the per-page count is less than 1, it
+// returns an empty clause.
+func FromPaginator(p *query.Paginator) string {
+	if p.PerPage < 1 {
+		return ""
+	}
+
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", p.PerPage, p.PerPage*(p.Page-1))
+}
+
+// FromSorter converts the Sort instance to an ORDER BY clause. If an invalid value of `Sort.Order` is provided, it
+// defaults to descending order. When `Sort.By` isn't one of columns, the query is sorted by fallback.
+func FromSorter(s *query.Sorter, columns Columns, fallback string) string {
+	options := map[string]string{
+		query.OrderAsc:  "ASC",
+		query.OrderDesc: "DESC",
+	}
+
+	order, ok := options[s.Order]
+	if !ok {
+		order = "DESC"
+	}
+
+	by := quote(fallback)
+	if kind, ok := columns[s.By]; ok && kind != KindJSON {
+		by = quote(s.By)
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s", by, order)
+}
+
+// FromFilters converts the Filters instance to a boolean SQL expression, to be used on a WHERE clause, appending its
+// arguments to args. The properties are grouped by the operators that follow them, and the properties after the last
+// operator are combined with OR, as the Mongo store does. It returns an empty expression when there is no filter, and an
+// error when an invalid filter is found.
+func FromFilters(fs *query.Filters, columns Columns, args *Args) (string, error) {
+	if len(fs.Data) < 1 {
+		return "", nil
+	}
+
+	conditions := make([]string, 0)
+	matchers := make([]string, 0)
+
+	for _, filter := range fs.Data {
+		switch filter.Type {
+		case query.FilterTypeProperty:
+			param, ok := filter.Params.(*query.FilterProperty)
+			if !ok {
+				return "", query.ErrFilterInvalid
+			}
+
+			if !isFilterPropertyOperator(param.Operator) {
+				continue
+			}
+
+			expr, kind, ok := columns.expression(param.Name, args)
+			if !ok {
+				return "", query.ErrFilterPropertyInvalid
+			}
+
+			condition, err := parseFilterProperty(param, expr, kind, args)
+			if err != nil {
+				return "", query.ErrFilterPropertyInvalid
+			}
+
+			conditions = append(conditions, condition)
+		case query.FilterTypeOperator:
+			param, ok := filter.Params.(*query.FilterOperator)
+			if !ok {
+				return "", query.ErrFilterInvalid
+			}
+
+			op, ok := parseFilterOperator(param)
+			if !ok {
+				continue
+			}
+
+			if len(conditions) > 0 {
+				matchers = append(matchers, "("+strings.Join(conditions, " "+op+" ")+")")
+			}
+
+			conditions = nil
+		default:
+			return "", query.ErrFilterInvalid
+		}
+	}
+
+	if len(conditions) > 0 {
+		matchers = []string{"(" + strings.Join(conditions, " OR ") + ")"}
+	}
+
+	return strings.Join(matchers, " AND "), nil
+}
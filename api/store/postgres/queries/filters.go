@@ -0,0 +1,162 @@
+package queries
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+)
+
+// parseFilterOperator returns the SQL representation of the filter operator and a boolean indicating whether the
+// operator is valid or not.
+func parseFilterOperator(fo *query.FilterOperator) (string, bool) {
+	switch fo.Name {
+	case "and":
+		return "AND", true
+	case "or":
+		return "OR", true
+	default:
+		return "", false
+	}
+}
+
+// isFilterPropertyOperator reports whether the property's operator is valid. The properties with an invalid operator
+// are ignored.
+func isFilterPropertyOperator(operator string) bool {
+	switch operator {
+	case "contains", "eq", "bool", "gt", "ne":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseFilterProperty constructs the property's condition on the expression expr, returning its SQL representation
+// and an error if any. The property's operator must be valid.
+func parseFilterProperty(fp *query.FilterProperty, expr string, kind Kind, args *Args) (string, error) {
+	switch fp.Operator {
+	case "contains":
+		return fromContains(expr, kind, fp.Value, args)
+	case "eq":
+		return fromEq(expr, kind, fp.Value, args)
+	case "bool":
+		return fromBool(expr, fp.Value, args)
+	case "gt":
+		return fromGt(expr, fp.Value, args)
+	default:
+		return fromNe(expr, kind, fp.Value, args)
+	}
+}
+
+// fromContains converts a "contains" JSON expression to a case-insensitive regular expression match, when the value is
+// a string, or to an array containment, when it is a list.
+func fromContains(expr string, kind Kind, value interface{}, args *Args) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if kind == KindArray {
+			return fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(%s) AS element WHERE element ~* %s)", expr, args.Add(v)), nil
+		}
+
+		return fmt.Sprintf("%s::text ~* %s", expr, args.Add(v)), nil
+	case []interface{}:
+		switch kind {
+		case KindArray:
+			return fmt.Sprintf("%s @> %s::text[]", expr, args.Add(toStrings(v))), nil
+		case KindJSON:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf("%s @> %s::jsonb", expr, args.Add(string(data))), nil
+		}
+	}
+
+	return "", errors.New("invalid value type for fromContains")
+}
+
+// fromEq converts an "eq" JSON expression to an equality, comparing the values as text.
+func fromEq(expr string, kind Kind, value interface{}, args *Args) (string, error) {
+	if kind == KindArray {
+		return fmt.Sprintf("%s = ANY(%s)", args.Add(toString(value)), expr), nil
+	}
+
+	return fmt.Sprintf("%s::text = %s", expr, args.Add(toString(value))), nil
+}
+
+// fromBool converts a "bool" JSON expression to an equality between boolean values.
+func fromBool(expr string, value interface{}, args *Args) (string, error) {
+	switch v := value.(type) {
+	case int:
+		value = v != 0
+	case float64:
+		value = v != 0
+	case string:
+		var err error
+		value, err = strconv.ParseBool(v)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if _, ok := value.(bool); !ok {
+		return "", errors.New("invalid value type for fromBool")
+	}
+
+	return fmt.Sprintf("%s::boolean = %s", expr, args.Add(value)), nil
+}
+
+// fromGt converts a "gt" JSON expression to a numeric comparison.
+func fromGt(expr string, value interface{}, args *Args) (string, error) {
+	switch v := value.(type) {
+	case int:
+		value = float64(v)
+	case float64:
+		value = v
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return "", err
+		}
+
+		value = float64(n)
+	default:
+		return "", errors.New("invalid value type for fromGt")
+	}
+
+	return fmt.Sprintf("%s::numeric > %s", expr, args.Add(value)), nil
+}
+
+// fromNe converts a "ne" JSON expression to an inequality, comparing the values as text. It matches the rows without a
+// value too, as the Mongo store does.
+func fromNe(expr string, kind Kind, value interface{}, args *Args) (string, error) {
+	if kind == KindArray {
+		return fmt.Sprintf("NOT (%s = ANY(%s))", args.Add(toString(value)), expr), nil
+	}
+
+	return fmt.Sprintf("%s::text IS DISTINCT FROM %s", expr, args.Add(toString(value))), nil
+}
+
+// toString formats a JSON value as text, like PostgreSQL does when casting a column to text.
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return strings.Trim(fmt.Sprint(v), " ")
+	}
+}
+
+func toStrings(values []interface{}) []string {
+	list := make([]string, len(values))
+	for i, value := range values {
+		list[i] = toString(value)
+	}
+
+	return list
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func (s *Store) Options() store.QueryOptions {
+	return s.options
+}
+
+func (o *queryOptions) CountAcceptedDevices() store.NamespaceQueryOption {
+	return func(ctx context.Context, ns *models.Namespace) error {
+		err := o.store.db(ctx).QueryRow(ctx, `
+			SELECT count(*), count(*) FILTER (WHERE limit_exempt)
+			FROM devices
+			WHERE tenant_id = $1 AND status = 'accepted'`,
+			ns.TenantID,
+		).Scan(&ns.DevicesCount, &ns.LimitExemptDevicesCount)
+
+		return FromPostgresError(err)
+	}
+}
+
+func (o *queryOptions) EnrichMembersData() store.NamespaceQueryOption {
+	return func(ctx context.Context, ns *models.Namespace) error {
+		for i, member := range ns.Members {
+			var email string
+			if err := o.store.db(ctx).QueryRow(ctx, `SELECT email FROM users WHERE id = $1`, member.ID).Scan(&email); err != nil {
+				log.WithContext(ctx).WithError(err).
+					WithField("id", member.ID).
+					Error("member not found")
+
+				continue
+			}
+
+			plaintext, err := o.store.cipher.Decrypt(email)
+			if err != nil {
+				return err
+			}
+
+			ns.Members[i].Email = plaintext
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// sessionColumns are the columns of the sessions table, aliased as s, in the order scanned by scanSession, followed by
+// whether the session is active.
+var sessionColumns = `s.uid, s.device_uid, s.tenant_id, s.username, s.ip_address, s.started_at, s.last_seen, s.closed,
+	s.authenticated, s.recorded, s.type, s.term, s.position, s.event_types, s.events, s.client, s.device_name, s.namespace,
+	s.record_type, s.record_hash, s.record_object, s.attestation,
+	EXISTS (SELECT 1 FROM active_sessions AS a WHERE a.uid = s.uid AND a.last_seen > now() - ` + activeSessionTTL + `) AS active`
+
+func (s *Store) scanSession(row pgx.Row) (*models.Session, error) {
+	session := new(models.Session)
+	if err := row.Scan(
+		&session.UID, &session.DeviceUID, &session.TenantID, &session.Username, &session.IPAddress, &session.StartedAt,
+		&session.LastSeen, &session.Closed, &session.Authenticated, &session.Recorded, &session.Type, &session.Term,
+		&session.Position, &session.Events.Types, &session.Events.Items, &session.Client, &session.DeviceName,
+		&session.Namespace, &session.RecordType, &session.RecordHash, &session.RecordObject, &session.Attestation,
+		&session.Active,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	ipAddress, err := s.cipher.Decrypt(session.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	session.IPAddress = ipAddress
+
+	// NOTICE: the Mongo store omits the session's empty events, which are decoded as nil.
+	if len(session.Events.Types) == 0 {
+		session.Events.Types = nil
+	}
+
+	if len(session.Events.Items) == 0 {
+		session.Events.Items = nil
+	}
+
+	return session, nil
+}
+
+func (s *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
+	args := queries.NewArgs()
+
+	where := "TRUE"
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		where = "s.tenant_id = " + args.Add(tenant.ID)
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM sessions AS s WHERE `+where, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db(ctx).Query(
+		ctx,
+		`SELECT `+sessionColumns+` FROM sessions AS s WHERE `+where+` ORDER BY s.started_at DESC`+queries.FromPaginator(&paginator),
+		args.Values()...,
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	sessions, err := collect(rows, s.scanSession)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i, session := range sessions {
+		// NOTICE: the device isn't joined, as it would be once for each session listed; only its denormalized fields
+		// are returned.
+		sessions[i].Device = &models.Device{
+			UID:       string(session.DeviceUID),
+			Name:      session.DeviceName,
+			TenantID:  session.TenantID,
+			Namespace: session.Namespace,
+		}
+	}
+
+	return sessions, count, nil
+}
+
+func (s *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session, error) {
+	args := queries.NewArgs(string(uid))
+
+	where := "s.uid = $1"
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		where += " AND s.tenant_id = " + args.Add(tenant.ID)
+	}
+
+	session, err := s.scanSession(s.db(ctx).QueryRow(ctx, `SELECT `+sessionColumns+` FROM sessions AS s WHERE `+where, args.Values()...))
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := s.DeviceGet(ctx, session.DeviceUID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Device = device
+
+	return session, nil
+}
+
+func (s *Store) SessionUpdate(ctx context.Context, uid models.UID, model *models.Session) error {
+	// NOTICE: the session's events aren't written, as they are appended by [Store.SessionEvent] while the session is
+	// open; the fields the Mongo store omits when empty are kept as they are.
+	ipAddress, err := s.cipher.Encrypt(model.IPAddress)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE sessions SET
+			device_uid = $2,
+			tenant_id = $3,
+			username = $4,
+			ip_address = $5,
+			started_at = $6,
+			last_seen = $7,
+			closed = $8,
+			authenticated = $9,
+			recorded = $10,
+			type = $11,
+			term = $12,
+			position = $13,
+			client = $14,
+			device_name = COALESCE(NULLIF($15, ''), device_name),
+			namespace = COALESCE(NULLIF($16, ''), namespace),
+			record_type = COALESCE(NULLIF($17, ''), record_type),
+			record_hash = COALESCE(NULLIF($18, ''), record_hash),
+			record_object = COALESCE(NULLIF($19, ''), record_object),
+			attestation = COALESCE($20, attestation)
+		WHERE uid = $1`,
+		string(uid), string(model.DeviceUID), model.TenantID, model.Username, ipAddress, model.StartedAt,
+		model.LastSeen, model.Closed, model.Authenticated, model.Recorded, model.Type, model.Term, model.Position,
+		model.Client, model.DeviceName, model.Namespace, string(model.RecordType), model.RecordHash, model.RecordObject,
+		model.Attestation,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) SessionSetRecorded(ctx context.Context, uid models.UID, recorded bool) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE sessions SET recorded = $2 WHERE uid = $1`, string(uid), recorded)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) SessionCreate(ctx context.Context, session models.Session) (*models.Session, error) {
+	session.StartedAt = clock.Now()
+	session.LastSeen = session.StartedAt
+	session.Recorded = false
+
+	device, err := s.DeviceGet(ctx, session.DeviceUID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.TenantID = device.TenantID
+	session.DeviceName = device.Name
+	session.Namespace = device.Namespace
+
+	ipAddress, err := s.cipher.Encrypt(session.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO sessions (
+			uid, device_uid, tenant_id, username, ip_address, started_at, last_seen, closed, authenticated, recorded,
+			type, term, position, event_types, events, client, device_name, namespace, record_type, record_hash,
+			record_object, attestation
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+		session.UID, string(session.DeviceUID), session.TenantID, session.Username, ipAddress, session.StartedAt,
+		session.LastSeen, session.Closed, session.Authenticated, session.Recorded, session.Type, session.Term,
+		session.Position, nonNil(session.Events.Types), nonNil(session.Events.Items), session.Client,
+		session.DeviceName, session.Namespace, string(session.RecordType), session.RecordHash, session.RecordObject,
+		session.Attestation,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return &session, nil
+}
+
+func (s *Store) SessionSetLastSeen(ctx context.Context, uid models.UID) error {
+	var closed bool
+	if err := s.db(ctx).QueryRow(ctx, `SELECT closed FROM sessions WHERE uid = $1`, string(uid)).Scan(&closed); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if closed {
+		return nil
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `UPDATE sessions SET last_seen = $2 WHERE uid = $1`, string(uid), clock.Now()); err != nil {
+		return FromPostgresError(err)
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `UPDATE active_sessions SET last_seen = $2 WHERE uid = $1`, string(uid), clock.Now()); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+// SessionDeleteActives sets a session's "closed" status to true and deletes all related active_sessions.
+func (s *Store) SessionDeleteActives(ctx context.Context, uid models.UID) error {
+	return s.WithTransaction(ctx, func(ctx context.Context) error {
+		res, err := s.db(ctx).Exec(ctx, `UPDATE sessions SET last_seen = $2, closed = true WHERE uid = $1`, string(uid), clock.Now())
+		if err != nil {
+			return FromPostgresError(err)
+		}
+
+		if res.RowsAffected() < 1 {
+			return store.ErrNoDocuments
+		}
+
+		_, err = s.db(ctx).Exec(ctx, `DELETE FROM active_sessions WHERE uid = $1`, string(uid))
+
+		return FromPostgresError(err)
+	})
+}
+
+func (s *Store) SessionUpdateDeviceUID(ctx context.Context, oldUID models.UID, newUID models.UID) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE sessions SET device_uid = $2 WHERE device_uid = $1`, string(oldUID), string(newUID))
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) SessionActiveCreate(ctx context.Context, uid models.UID, session *models.Session) error {
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM active_sessions WHERE last_seen <= now() - `+activeSessionTTL); err != nil {
+		return FromPostgresError(err)
+	}
+
+	_, err := s.db(ctx).Exec(ctx, `INSERT INTO active_sessions (uid, last_seen, tenant_id) VALUES ($1, $2, $3)`, string(uid), session.StartedAt, session.TenantID)
+
+	return FromPostgresError(err)
+}
+
+// SessionEvent saves a [models.SessionEvent] into the database.
+//
+// It appends the event into the session's events, and the event type into a separated set. The set is used to improve
+// the performance of indexing when looking for sessions.
+func (s *Store) SessionEvent(ctx context.Context, uid models.UID, event *models.SessionEvent) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		UPDATE sessions SET
+			event_types = CASE WHEN $2 = ANY(event_types) THEN event_types ELSE array_append(event_types, $2) END,
+			events = events || jsonb_build_array($3::jsonb)
+		WHERE uid = $1`,
+		string(uid), event.Type, event,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+// sessionsSetDeviceName updates the device's name denormalized on its sessions.
+func (s *Store) sessionsSetDeviceName(ctx context.Context, uid models.UID, name string) error {
+	if _, err := s.db(ctx).Exec(ctx, `UPDATE sessions SET device_name = $2 WHERE device_uid = $1`, string(uid), name); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+// sessionsSetNamespace updates the namespace's name denormalized on its sessions.
+func (s *Store) sessionsSetNamespace(ctx context.Context, tenantID, name string) error {
+	if _, err := s.db(ctx).Exec(ctx, `UPDATE sessions SET namespace = $2 WHERE tenant_id = $1`, tenantID, name); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
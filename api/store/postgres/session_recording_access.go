@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// sessionRecordingAccessColumns are the columns of the session_recording_accesses table, in the order scanned by
+// scanSessionRecordingAccess.
+const sessionRecordingAccessColumns = `id, tenant_id, session_uid, user_id, username, action, range, source_ip, accessed_at`
+
+func scanSessionRecordingAccess(row pgx.Row) (*models.SessionRecordingAccess, error) {
+	access := new(models.SessionRecordingAccess)
+	if err := row.Scan(
+		&access.ID,
+		&access.TenantID,
+		&access.SessionUID,
+		&access.UserID,
+		&access.Username,
+		&access.Action,
+		&access.Range,
+		&access.SourceIP,
+		&access.AccessedAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return access, nil
+}
+
+func (s *Store) SessionRecordingAccessCreate(ctx context.Context, access *models.SessionRecordingAccess) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO session_recording_accesses (`+sessionRecordingAccessColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		access.ID,
+		access.TenantID,
+		access.SessionUID,
+		access.UserID,
+		access.Username,
+		string(access.Action),
+		access.Range,
+		access.SourceIP,
+		access.AccessedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) SessionRecordingAccessList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.SessionRecordingAccess, int, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM session_recording_accesses WHERE tenant_id = $1 AND session_uid = $2`, tenantID, string(uid))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.SessionRecordingAccess{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+sessionRecordingAccessColumns+` FROM session_recording_accesses
+		WHERE tenant_id = $1 AND session_uid = $2
+		ORDER BY accessed_at DESC`+queries.FromPaginator(&paginator),
+		tenantID, string(uid),
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	accesses, err := collect(rows, scanSessionRecordingAccess)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return accesses, count, nil
+}
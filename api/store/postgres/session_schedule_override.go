@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// sessionScheduleOverrideColumns are the columns of the session_schedule_overrides table, in the order scanned by
+// scanSessionScheduleOverride.
+const sessionScheduleOverrideColumns = `id, tenant_id, device_uid, user_id, reason, created_at, expires_at`
+
+func scanSessionScheduleOverride(row pgx.Row) (*models.SessionScheduleOverride, error) {
+	override := new(models.SessionScheduleOverride)
+	if err := row.Scan(
+		&override.ID,
+		&override.TenantID,
+		&override.DeviceUID,
+		&override.UserID,
+		&override.Reason,
+		&override.CreatedAt,
+		&override.ExpiresAt,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return override, nil
+}
+
+func (s *Store) SessionScheduleOverrideCreate(ctx context.Context, override *models.SessionScheduleOverride) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO session_schedule_overrides (`+sessionScheduleOverrideColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		override.ID,
+		override.TenantID,
+		override.DeviceUID,
+		override.UserID,
+		override.Reason,
+		override.CreatedAt,
+		override.ExpiresAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) SessionScheduleOverrideGetActive(ctx context.Context, tenantID string, uid models.UID, at time.Time) (*models.SessionScheduleOverride, error) {
+	return scanSessionScheduleOverride(s.db(ctx).QueryRow(ctx, `
+		SELECT `+sessionScheduleOverrideColumns+` FROM session_schedule_overrides
+		WHERE tenant_id = $1 AND device_uid = $2 AND expires_at > $3
+		ORDER BY expires_at DESC
+		LIMIT 1`,
+		tenantID, string(uid), at,
+	))
+}
+
+func (s *Store) SessionScheduleOverrideList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) ([]models.SessionScheduleOverride, int, error) {
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM session_schedule_overrides WHERE tenant_id = $1 AND device_uid = $2`, tenantID, string(uid))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if count == 0 {
+		return []models.SessionScheduleOverride{}, 0, nil
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+sessionScheduleOverrideColumns+` FROM session_schedule_overrides
+		WHERE tenant_id = $1 AND device_uid = $2
+		ORDER BY created_at DESC`+queries.FromPaginator(&paginator),
+		tenantID, string(uid),
+	)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	overrides, err := collect(rows, scanSessionScheduleOverride)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return overrides, count, nil
+}
+
+func (s *Store) SessionScheduleOverrideListByUser(ctx context.Context, tenantID, userID string, since time.Time) ([]models.SessionScheduleOverride, error) {
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+sessionScheduleOverrideColumns+` FROM session_schedule_overrides
+		WHERE tenant_id = $1 AND user_id = $2 AND created_at >= $3
+		ORDER BY created_at DESC`,
+		tenantID, userID, since,
+	)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanSessionScheduleOverride)
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) GetStats(ctx context.Context) (*models.Stats, error) {
+	args := []any{clock.Now()}
+
+	where := "TRUE"
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		args = append(args, tenant.ID)
+		where = "tenant_id = $2"
+	}
+
+	stats := new(models.Stats)
+	if err := s.db(ctx).QueryRow(ctx, `
+		SELECT
+			(SELECT count(*) FROM connected_devices AS c WHERE `+where+` AND c.last_seen > now() - `+connectedDeviceTTL+`
+				AND (c.offline_at IS NULL OR c.offline_at > $1)
+				AND EXISTS (SELECT 1 FROM devices AS d WHERE d.uid = c.uid AND d.status = 'accepted')),
+			(SELECT count(*) FROM devices WHERE `+where+` AND status = 'accepted'),
+			(SELECT count(*) FROM devices WHERE `+where+` AND status = 'pending'),
+			(SELECT count(*) FROM devices WHERE `+where+` AND status = 'rejected'),
+			(SELECT count(*) FROM active_sessions WHERE `+where+` AND last_seen > now() - `+activeSessionTTL+`)`,
+		args...,
+	).Scan(&stats.OnlineDevices, &stats.RegisteredDevices, &stats.PendingDevices, &stats.RejectedDevices, &stats.ActiveSessions); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return stats, nil
+}
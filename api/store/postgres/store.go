@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
+)
+
+var (
+	ErrNamespaceDuplicatedMember = errors.New("this member is already in this namespace")
+	ErrUserNotFound              = errors.New("user not found")
+	ErrStoreParseURI             = errors.New("fail to parse the PostgreSQL URI")
+	ErrStoreConnect              = errors.New("fail to connect to the database on PostgreSQL URI")
+	ErrStorePing                 = errors.New("fail to ping the PostgreSQL database")
+	ErrStoreApplyMigration       = errors.New("fail to apply PostgreSQL migrations")
+)
+
+// NOTICE: PostgreSQL has no TTL indexes as the Mongo's ones, so the rows that would have been expired by them are
+// filtered out when read and pruned when written.
+const (
+	// connectedDeviceTTL is the interval a device is kept connected after its last heartbeat.
+	connectedDeviceTTL = "interval '120 seconds'"
+	// activeSessionTTL is the interval a session is kept active after it was last seen.
+	activeSessionTTL = "interval '30 seconds'"
+	// privateKeyTTL is the interval a private key is kept after it was created.
+	privateKeyTTL = "interval '60 seconds'"
+	// deviceAgentLogTTL is the interval a device's agent log is kept after it was created.
+	deviceAgentLogTTL = "interval '7 days'"
+	// deviceRemovedTTL is the interval a removed device is kept after it was removed.
+	deviceRemovedTTL = "interval '720 hours'"
+	// deviceQuarantineAttemptTTL is the interval a quarantined device's attempt is kept after it was created.
+	deviceQuarantineAttemptTTL = "interval '30 days'"
+	// deviceSnapshotTTL is the interval a device's snapshot is kept after it was taken.
+	deviceSnapshotTTL = "interval '90 days'"
+	// jobTTL is the interval a job is kept after it was created.
+	jobTTL = "interval '7 days'"
+)
+
+// querier is the subset of the pool's and the transaction's methods used to query the database, so the store's methods
+// run the same statements inside and outside a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type queryOptions struct {
+	store *Store
+}
+
+type Store struct {
+	pool    *pgxpool.Pool
+	options *queryOptions
+	cache   cache.Cache
+	// cipher encrypts the personal data fields. It is nil when their encryption isn't configured.
+	cipher *fieldcrypt.Cipher
+}
+
+// StoreOpt is an option applied to the store when it is created, like running its migrations.
+type StoreOpt func(ctx context.Context, pool *pgxpool.Pool) error
+
+// GetPool returns the store's connection pool.
+func (s *Store) GetPool() *pgxpool.Pool {
+	return s.pool
+}
+
+// Connect connects to the PostgreSQL database on uri.
+func Connect(ctx context.Context, uri string) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(uri)
+	if err != nil {
+		return nil, errors.Join(ErrStoreParseURI, err)
+	}
+
+	config.AfterConnect = func(_ context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{Name: "timestamptz", OID: pgtype.TimestamptzOID, Codec: utcTimestamptzCodec{}})
+
+		return nil
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, errors.Join(ErrStoreConnect, err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+
+		return nil, errors.Join(ErrStorePing, err)
+	}
+
+	return pool, nil
+}
+
+// NewStore creates a [store.Store] on the PostgreSQL database on uri. The personal data fields are encrypted with
+// cipher, when it isn't nil.
+func NewStore(ctx context.Context, uri string, cache cache.Cache, cipher *fieldcrypt.Cipher, opts ...StoreOpt) (store.Store, error) {
+	pool, err := Connect(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{pool: pool, cache: cache, cipher: cipher}
+	store.options = &queryOptions{store: store}
+
+	for _, opt := range opts {
+		if err := opt(ctx, store.pool); err != nil {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// db returns the transaction started by [Store.WithTransaction] on ctx or, when there is none, the connection pool.
+func (s *Store) db(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return s.pool
+}
+
+// utcTimestamptzCodec decodes the timestamps on UTC, as the Mongo store does, instead of on the local time zone.
+type utcTimestamptzCodec struct {
+	pgtype.TimestamptzCodec
+}
+
+func (c utcTimestamptzCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	plan := c.TimestamptzCodec.PlanScan(m, oid, format, target)
+	if _, ok := target.(pgtype.TimestamptzScanner); !ok || plan == nil {
+		return plan
+	}
+
+	return utcTimestamptzScanPlan{next: plan}
+}
+
+type utcTimestamptzScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (p utcTimestamptzScanPlan) Scan(src []byte, target any) error {
+	return p.next.Scan(src, utcTimestamptzScanner{target.(pgtype.TimestamptzScanner)})
+}
+
+type utcTimestamptzScanner struct {
+	pgtype.TimestamptzScanner
+}
+
+func (s utcTimestamptzScanner) ScanTimestamptz(v pgtype.Timestamptz) error {
+	v.Time = v.Time.UTC()
+
+	return s.TimestamptzScanner.ScanTimestamptz(v)
+}
@@ -0,0 +1,124 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	log "github.com/sirupsen/logrus"
+	"github.com/testcontainers/testcontainers-go"
+	postgrescontainer "github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+var (
+	srv = &fixtures{}
+	s   store.Store
+	// uri is the connection string of the test's database.
+	uri string
+)
+
+const (
+	fixtureAPIKeys          = "api-key"              // Check "store.postgres.fixtures.api-key" for fixture info
+	fixtureConnectedDevices = "connected_devices"    // Check "store.postgres.fixtures.connected_devices" for fixture info
+	fixtureDevices          = "devices"              // Check "store.postgres.fixtures.devices" for fixture info
+	fixtureSessions         = "sessions"             // Check "store.postgres.fixtures.sessions" for fixture info
+	fixtureActiveSessions   = "active_sessions"      // Check "store.postgres.fixtures.active_sessions" for fixture info
+	fixturePublicKeys       = "public_keys"          // Check "store.postgres.fixtures.public_keys" for fixture info
+	fixturePrivateKeys      = "private_keys"         // Check "store.postgres.fixtures.private_keys" for fixture info
+	fixtureUsers            = "users"                // Check "store.postgres.fixtures.users" for fixture info
+	fixtureNamespaces       = "namespaces"           // Check "store.postgres.fixtures.namespaces" for fixture info
+	fixtureKeyIncidents     = "device_key_incidents" // Check "store.postgres.fixtures.device_key_incidents" for fixture info
+)
+
+// fixtures loads the SQL fixtures, named after their files, into the database and empties its tables after.
+type fixtures struct {
+	root string
+	pool *pgxpool.Pool
+}
+
+// Apply runs the fixtures' files on the database.
+func (f *fixtures) Apply(names ...string) error {
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(f.root, name+".sql"))
+		if err != nil {
+			return err
+		}
+
+		if _, err := f.pool.Exec(context.Background(), string(data)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reset empties every table, except the migrations' and the system's ones, which are kept as created by the
+// migrations.
+func (f *fixtures) Reset() error {
+	_, err := f.pool.Exec(context.Background(), `
+		DO $$
+		DECLARE
+			t text;
+		BEGIN
+			FOR t IN SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND tablename NOT IN ('schema_migrations', 'system') LOOP
+				EXECUTE format('TRUNCATE TABLE %I', t);
+			END LOOP;
+		END
+		$$`,
+	)
+
+	return err
+}
+
+func TestMain(m *testing.M) {
+	log.Info("Starting store tests")
+
+	ctx := context.Background()
+
+	container, err := postgrescontainer.Run(
+		ctx,
+		"postgres:16.4",
+		postgrescontainer.WithDatabase("test"),
+		postgrescontainer.WithUsername("test"),
+		postgrescontainer.WithPassword("test"),
+		postgrescontainer.BasicWaitStrategies(),
+	)
+	if err != nil {
+		log.WithError(err).Error("Failed to UP the postgres container")
+		os.Exit(1)
+	}
+
+	uri, err = container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		log.WithError(err).Error("Failed to get the postgres connection string")
+		os.Exit(1)
+	}
+
+	log.Info("Connecting to ", uri)
+
+	s, err = postgres.NewStore(ctx, uri, cache.NewNullCache(), nil, postgres.RunMigrations)
+	if err != nil {
+		log.WithError(err).Error("Failed to create the postgres store")
+		os.Exit(1)
+	}
+
+	_, file, _, _ := runtime.Caller(0)
+	srv.root = filepath.Join(filepath.Dir(file), "fixtures")
+	srv.pool = s.(*postgres.Store).GetPool()
+
+	code := m.Run()
+
+	log.Info("Stopping store tests")
+	if err := testcontainers.TerminateContainer(container); err != nil {
+		log.WithError(err).Error("Failed to DOWN the postgres container")
+		os.Exit(1)
+	}
+
+	os.Exit(code)
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	SystemCacheKey = "system"
+	SystemCacheTTL = 60 * time.Minute
+)
+
+// NOTICE: the system's document is kept as the extended JSON of its BSON, so its fields are written by the same paths,
+// like "authentication.saml.enabled", and the fields hidden from JSON, like the SAML's private key, are kept.
+
+func (s *Store) SystemGet(ctx context.Context) (*models.System, error) {
+	if system, err := cache.Get[models.System](ctx, s.cache, SystemCacheKey); err == nil {
+		log.WithContext(ctx).WithField("system", system).Warn("using system from cache")
+
+		return system, nil
+	}
+
+	var document string
+	if err := s.db(ctx).QueryRow(ctx, `SELECT document::text FROM system`).Scan(&document); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	var system *models.System
+	if err := bson.UnmarshalExtJSON([]byte(document), false, &system); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	if err := s.cache.Set(ctx, SystemCacheKey, system, SystemCacheTTL); err != nil {
+		log.WithContext(ctx).WithField("system", system).Warn("failed to set the system data on cache")
+	}
+
+	return system, nil
+}
+
+func (s *Store) SystemSet(ctx context.Context, key string, value any) error {
+	if err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		var document string
+		if err := s.db(ctx).QueryRow(ctx, `SELECT document::text FROM system FOR UPDATE`).Scan(&document); err != nil {
+			return FromPostgresError(err)
+		}
+
+		system := bson.M{}
+		if err := bson.UnmarshalExtJSON([]byte(document), false, &system); err != nil {
+			return FromPostgresError(err)
+		}
+
+		// NOTICE: the documents on the key's path are created when they don't exist, as the Mongo store does.
+		path := strings.Split(key, ".")
+		parent := system
+		for _, field := range path[:len(path)-1] {
+			child, ok := parent[field].(bson.M)
+			if !ok {
+				child = bson.M{}
+				parent[field] = child
+			}
+
+			parent = child
+		}
+
+		parent[path[len(path)-1]] = value
+
+		data, err := bson.MarshalExtJSON(system, false, false)
+		if err != nil {
+			return FromPostgresError(err)
+		}
+
+		_, err = s.db(ctx).Exec(ctx, `UPDATE system SET document = $1::jsonb`, string(data))
+
+		return FromPostgresError(err)
+	}); err != nil {
+		return err
+	}
+
+	if err := s.cache.Delete(ctx, SystemCacheKey); err != nil {
+		log.WithContext(ctx).WithField(SystemCacheKey, key).Warn("failed to delete system from cache")
+	}
+
+	return nil
+}
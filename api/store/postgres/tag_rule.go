@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// tagRuleColumns are the columns of the tag_rules table, in the order scanned by scanTagRule.
+const tagRuleColumns = `id, tenant_id, name, tag, conditions, created_at, updated_at`
+
+func scanTagRule(row pgx.Row) (*models.TagRule, error) {
+	rule := new(models.TagRule)
+	if err := row.Scan(&rule.ID, &rule.TenantID, &rule.Name, &rule.Tag, &rule.Conditions, &rule.CreatedAt, &rule.UpdatedAt); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return rule, nil
+}
+
+func (s *Store) TagRuleList(ctx context.Context, tenantID string) ([]models.TagRule, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+tagRuleColumns+` FROM tag_rules WHERE tenant_id = $1 ORDER BY created_at ASC`, tenantID)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanTagRule)
+}
+
+func (s *Store) TagRuleGet(ctx context.Context, tenantID, id string) (*models.TagRule, error) {
+	return scanTagRule(s.db(ctx).QueryRow(ctx, `SELECT `+tagRuleColumns+` FROM tag_rules WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+}
+
+func (s *Store) TagRuleCreate(ctx context.Context, rule *models.TagRule) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO tag_rules (`+tagRuleColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		rule.ID, rule.TenantID, rule.Name, rule.Tag, nonNil(rule.Conditions), rule.CreatedAt, rule.UpdatedAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) TagRuleUpdate(ctx context.Context, rule *models.TagRule) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE tag_rules SET name = $3, tag = $4, conditions = $5, updated_at = $6
+		WHERE id = $1 AND tenant_id = $2`,
+		rule.ID, rule.TenantID, rule.Name, rule.Tag, nonNil(rule.Conditions), rule.UpdatedAt,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) TagRuleDelete(ctx context.Context, tenantID, id string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM tag_rules WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	docker := models.TagRule{
+		ID:         "c7e4f5ad-8e33-4fd4-a8c5-3e3c1c9e2f1a",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		Name:       "containers",
+		Tag:        "container",
+		Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"}},
+		CreatedAt:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	outdated := models.TagRule{
+		ID:         "5f2d1a0b-3c4e-4b8a-9d6f-7e8a9b0c1d2e",
+		TenantID:   "00000000-0000-4000-0000-000000000000",
+		Name:       "outdated agents",
+		Tag:        "needs-update",
+		Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorLess, Value: "0.16.0"}},
+		CreatedAt:  time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, s.TagRuleCreate(ctx, &outdated))
+	require.NoError(t, s.TagRuleCreate(ctx, &docker))
+
+	rules, err := s.TagRuleList(ctx, "00000000-0000-4000-0000-000000000000")
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagRule{docker, outdated}, rules)
+
+	rules, err = s.TagRuleList(ctx, "00000000-0000-4000-0000-000000000001")
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagRule{}, rules)
+
+	docker.Tag = "docker"
+	docker.UpdatedAt = time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC)
+	require.NoError(t, s.TagRuleUpdate(ctx, &docker))
+
+	rule, err := s.TagRuleGet(ctx, "00000000-0000-4000-0000-000000000000", docker.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &docker, rule)
+
+	_, err = s.TagRuleGet(ctx, "00000000-0000-4000-0000-000000000001", docker.ID)
+	assert.ErrorIs(t, err, store.ErrNoDocuments)
+
+	assert.ErrorIs(t, s.TagRuleUpdate(ctx, &models.TagRule{ID: "nonexistent", TenantID: docker.TenantID}), store.ErrNoDocuments)
+
+	require.NoError(t, s.TagRuleDelete(ctx, docker.TenantID, docker.ID))
+	assert.ErrorIs(t, s.TagRuleDelete(ctx, docker.TenantID, docker.ID), store.ErrNoDocuments)
+}
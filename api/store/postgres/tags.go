@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// tagWithDescendants returns an expression that matches, on the tag at element, the tag on the placeholder tag and all of
+// its descendants, whose prefix is on the placeholder prefix.
+func tagWithDescendants(element, tag, prefix string) string {
+	return fmt.Sprintf("(%s = %s OR starts_with(%s, %s))", element, tag, element, prefix)
+}
+
+// hasTagWithDescendants returns an expression that matches the arrays of tags at column that have the tag or any of its
+// descendants.
+func hasTagWithDescendants(column, tag, prefix string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(%s) AS t WHERE %s)", column, tagWithDescendants("t", tag, prefix))
+}
+
+// pullTagWithDescendants returns an expression that removes, from the array of tags at column, the tag and all of its
+// descendants.
+func pullTagWithDescendants(column, tag, prefix string) string {
+	return fmt.Sprintf("ARRAY(SELECT t FROM unnest(%s) WITH ORDINALITY AS u (t, i) WHERE NOT %s ORDER BY i)", column, tagWithDescendants("t", tag, prefix))
+}
+
+// renameTagWithDescendants returns an expression that renames, on the array of tags at column, the tag and the prefix of
+// all of its descendants.
+func renameTagWithDescendants(column, tag, prefix, name string) string {
+	return fmt.Sprintf(
+		"ARRAY(SELECT CASE WHEN %s THEN %s || substr(t, length(%s) + 1) ELSE t END FROM unnest(%s) WITH ORDINALITY AS u (t, i) ORDER BY i)",
+		tagWithDescendants("t", tag, prefix), name, tag, column,
+	)
+}
+
+// distinctTags returns the distinct tags on the arrays of tags at column of table, for the tenant.
+func (s *Store) distinctTags(ctx context.Context, table, column, tenant string) ([]string, int, error) {
+	rows, err := s.db(ctx).Query(ctx, fmt.Sprintf(`SELECT DISTINCT unnest(%s) AS tag FROM %s WHERE tenant_id = $1 ORDER BY tag`, column, table), tenant)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	tags, err := collect(rows, func(row pgx.Row) (*string, error) {
+		var tag string
+		if err := row.Scan(&tag); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		return &tag, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tags, len(tags), nil
+}
+
+// NOTICE: the firewall rules aren't kept by the PostgreSQL store, so only the devices' and the public keys' tags are
+// considered.
+
+func (s *Store) TagsGet(ctx context.Context, tenant string) ([]string, int, error) {
+	var tags []string
+
+	if err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		deviceTags, _, err := s.DeviceGetTags(ctx, tenant)
+		if err != nil {
+			return err
+		}
+
+		keyTags, _, err := s.PublicKeyGetTags(ctx, tenant)
+		if err != nil {
+			return err
+		}
+
+		tags = []string{}
+		tags = append(tags, deviceTags...)
+		tags = append(tags, keyTags...)
+
+		// NOTICE: the ancestors of hierarchical tags are listed as well, as they can be targeted by filters and rules.
+		tags = models.TagsExpand(removeDuplicate[string](tags))
+
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	return tags, len(tags), nil
+}
+
+func (s *Store) TagsRename(ctx context.Context, tenantID string, oldTag string, newTag string) (int64, error) {
+	var count int64
+
+	if err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		devCount, err := s.DeviceBulkRenameTag(ctx, tenantID, oldTag, newTag)
+		if err != nil {
+			return err
+		}
+
+		keyCount, err := s.PublicKeyBulkRenameTag(ctx, tenantID, oldTag, newTag)
+		if err != nil {
+			return err
+		}
+
+		count = devCount + keyCount
+
+		return nil
+	}); err != nil {
+		return int64(0), err
+	}
+
+	return count, nil
+}
+
+func (s *Store) TagsDelete(ctx context.Context, tenantID string, tag string) (int64, error) {
+	var count int64
+
+	if err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		devCount, err := s.DeviceBulkDeleteTag(ctx, tenantID, tag)
+		if err != nil {
+			return err
+		}
+
+		keyCount, err := s.PublicKeyBulkDeleteTag(ctx, tenantID, tag)
+		if err != nil {
+			return err
+		}
+
+		count = devCount + keyCount
+
+		return nil
+	}); err != nil {
+		return int64(0), err
+	}
+
+	return count, nil
+}
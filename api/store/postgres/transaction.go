@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+)
+
+// txKey is the context's key of the transaction started by [Store.WithTransaction].
+type txKey struct{}
+
+func (s *Store) WithTransaction(ctx context.Context, cb store.TransactionCb) error {
+	// NOTICE: the store's methods that use transactions may be called inside another one; as PostgreSQL doesn't nest
+	// them, the callback joins the transaction already started.
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return cb(ctx)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return store.ErrStartTransactionFailed
+	}
+
+	if err := cb(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback(ctx)
+
+		return err
+	}
+
+	return FromPostgresError(tx.Commit(ctx))
+}
@@ -0,0 +1,305 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/postgres/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// userColumns are the columns of the users table, in the order scanned by scanUser.
+const userColumns = `id, origin, external_id, status, max_namespaces, created_at, last_login, email_marketing, name, username,
+	email, recovery_email, mfa_enabled, mfa_secret, mfa_recovery_codes, preferred_namespace, auth_methods, password, aliases`
+
+// userFilterColumns are the users' fields that they may be filtered by.
+var userFilterColumns = queries.Columns{
+	"id":              queries.KindScalar,
+	"origin":          queries.KindScalar,
+	"external_id":     queries.KindScalar,
+	"status":          queries.KindScalar,
+	"max_namespaces":  queries.KindScalar,
+	"created_at":      queries.KindScalar,
+	"last_login":      queries.KindScalar,
+	"email_marketing": queries.KindScalar,
+	"name":            queries.KindScalar,
+	"username":        queries.KindScalar,
+	"email":           queries.KindScalar,
+	"recovery_email":  queries.KindScalar,
+	"namespaces":      queries.KindScalar,
+}
+
+func (s *Store) scanUser(row pgx.Row) (*models.User, error) {
+	user := new(models.User)
+	if err := row.Scan(
+		&user.ID, &user.Origin, &user.ExternalID, &user.Status, &user.MaxNamespaces, &user.CreatedAt, &user.LastLogin,
+		&user.EmailMarketing, &user.Name, &user.Username, &user.Email, &user.RecoveryEmail, &user.MFA.Enabled,
+		&user.MFA.Secret, &user.MFA.RecoveryCodes, &user.Preferences.PreferredNamespace, &user.Preferences.AuthMethods,
+		&user.Password.Hash, &user.Aliases,
+	); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	email, err := s.cipher.Decrypt(user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Email = email
+
+	// NOTICE: the Mongo store omits the user's empty aliases, which are decoded as nil.
+	if len(user.Aliases) == 0 {
+		user.Aliases = nil
+	}
+
+	return user, nil
+}
+
+func (s *Store) UserList(ctx context.Context, paginator query.Paginator, filters query.Filters) ([]models.User, int, error) {
+	args := queries.NewArgs()
+
+	users := `SELECT *, (SELECT count(*) FROM namespaces AS n WHERE n.owner = u.id) AS namespaces FROM users AS u`
+
+	filter, err := queries.FromFilters(&filters, userFilterColumns, args)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	if filter != "" {
+		users = `SELECT * FROM (` + users + `) AS users WHERE ` + filter
+	}
+
+	count, err := count(ctx, s.db(ctx), `SELECT count(*) FROM (`+users+`) AS users`, args.Values()...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+userColumns+` FROM (`+users+`) AS users ORDER BY created_at ASC`+queries.FromPaginator(&paginator), args.Values()...)
+	if err != nil {
+		return nil, 0, FromPostgresError(err)
+	}
+
+	list, err := collect(rows, s.scanUser)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, count, nil
+}
+
+// userInsert inserts the user, identified by a new ID in the same format of the Mongo store's ones, returning it.
+func (s *Store) userInsert(ctx context.Context, user *models.User) (string, error) {
+	user.ID = primitive.NewObjectID().Hex()
+
+	email, err := s.cipher.EncryptDeterministic(user.Email)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO users (`+userColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+		user.ID, string(user.Origin), user.ExternalID, string(user.Status), user.MaxNamespaces, user.CreatedAt,
+		user.LastLogin, user.EmailMarketing, user.Name, user.Username, email, user.RecoveryEmail, user.MFA.Enabled,
+		user.MFA.Secret, nonNil(user.MFA.RecoveryCodes), user.Preferences.PreferredNamespace,
+		nonNil(user.Preferences.AuthMethods), user.Password.Hash, nonNil(user.Aliases),
+	); err != nil {
+		return "", FromPostgresError(err)
+	}
+
+	return user.ID, nil
+}
+
+func (s *Store) UserCreate(ctx context.Context, user *models.User) (string, error) {
+	user.CreatedAt = time.Now()
+	user.LastLogin = time.Time{}
+
+	return s.userInsert(ctx, user)
+}
+
+func (s *Store) UserCreateInvited(ctx context.Context, email string) (string, error) {
+	return s.userInsert(ctx, &models.User{CreatedAt: clock.Now(), Status: models.UserStatusInvited, UserData: models.UserData{Email: email}})
+}
+
+func (s *Store) UserGetByUsername(ctx context.Context, username string) (*models.User, error) {
+	return s.scanUser(s.db(ctx).QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE username = $1`, username))
+}
+
+func (s *Store) UserGetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return s.scanUser(s.db(ctx).QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE email = ANY($1)`, s.cipher.Equivalents(email)))
+}
+
+func (s *Store) UserGetByID(ctx context.Context, id string, ns bool) (*models.User, int, error) {
+	user, err := s.scanUser(s.db(ctx).QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !ns {
+		return user, 0, nil
+	}
+
+	owned, err := count(ctx, s.db(ctx), `SELECT count(*) FROM namespaces WHERE owner = $1`, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return user, owned, nil
+}
+
+func (s *Store) UserConflicts(ctx context.Context, target *models.UserConflicts) ([]string, bool, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT email, username FROM users WHERE email = ANY($1) OR username = $2`, s.cipher.Equivalents(target.Email), target.Username)
+	if err != nil {
+		return nil, false, FromPostgresError(err)
+	}
+
+	users, err := collect(rows, func(row pgx.Row) (*models.UserConflicts, error) {
+		user := new(models.UserConflicts)
+		if err := row.Scan(&user.Email, &user.Username); err != nil {
+			return nil, FromPostgresError(err)
+		}
+
+		email, err := s.cipher.Decrypt(user.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		user.Email = email
+
+		return user, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	conflicts := make([]string, 0)
+	for _, user := range users {
+		if user.Username == target.Username {
+			conflicts = append(conflicts, "username")
+		}
+
+		if user.Email == target.Email {
+			conflicts = append(conflicts, "email")
+		}
+	}
+
+	return conflicts, len(conflicts) > 0, nil
+}
+
+// userChanges returns the SET clause of the user's changes, ignoring their zero values as the Mongo store omits them.
+func userChanges(changes *models.UserChanges, args *queries.Args) []string {
+	set := make([]string, 0)
+
+	if !changes.LastLogin.IsZero() {
+		set = append(set, "last_login = "+args.Add(changes.LastLogin))
+	}
+
+	for column, value := range map[string]string{
+		"name":           changes.Name,
+		"username":       changes.Username,
+		"email":          changes.Email,
+		"recovery_email": changes.RecoveryEmail,
+		"password":       changes.Password,
+		"status":         string(changes.Status),
+	} {
+		if value != "" {
+			set = append(set, column+" = "+args.Add(value))
+		}
+	}
+
+	if changes.ExternalID != nil {
+		set = append(set, "external_id = "+args.Add(*changes.ExternalID))
+	}
+
+	if changes.PreferredNamespace != nil {
+		set = append(set, "preferred_namespace = "+args.Add(*changes.PreferredNamespace))
+	}
+
+	if changes.MaxNamespaces != nil {
+		set = append(set, "max_namespaces = "+args.Add(*changes.MaxNamespaces))
+	}
+
+	if changes.EmailMarketing != nil {
+		set = append(set, "email_marketing = "+args.Add(*changes.EmailMarketing))
+	}
+
+	if len(changes.AuthMethods) > 0 {
+		set = append(set, "auth_methods = "+args.Add(changes.AuthMethods))
+	}
+
+	return set
+}
+
+func (s *Store) UserUpdate(ctx context.Context, id string, changes *models.UserChanges) error {
+	args := queries.NewArgs(id)
+
+	encrypted := *changes
+
+	email, err := s.cipher.EncryptDeterministic(changes.Email)
+	if err != nil {
+		return err
+	}
+
+	encrypted.Email = email
+
+	set := userChanges(&encrypted, args)
+
+	// NOTICE: a user without changes is only checked to exist.
+	if len(set) == 0 {
+		set = append(set, "id = id")
+	}
+
+	res, err := s.db(ctx).Exec(ctx, `UPDATE users SET `+strings.Join(set, ", ")+` WHERE id = $1`, args.Values()...)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserDelete(ctx context.Context, id string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserGetInfo(ctx context.Context, id string) (*models.UserInfo, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+namespaceColumns+` FROM namespaces WHERE `+namespaceHasMember("$1")+` ORDER BY created_at ASC`, id)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	namespaces, err := collect(rows, scanNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo := &models.UserInfo{}
+
+	for _, ns := range namespaces {
+		if ns.Owner == id {
+			userInfo.OwnedNamespaces = append(userInfo.OwnedNamespaces, ns)
+		} else {
+			userInfo.AssociatedNamespaces = append(userInfo.AssociatedNamespaces, ns)
+		}
+	}
+
+	return userInfo, nil
+}
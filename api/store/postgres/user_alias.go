@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) UserAliasSave(ctx context.Context, userID string, alias *models.UserAlias) error {
+	// NOTICE: when the user doesn't have an alias with this name yet, it's appended to the user's aliases.
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE users SET aliases = CASE
+			WHEN aliases @> jsonb_build_array(jsonb_build_object('name', $2::text)) THEN (
+				SELECT jsonb_agg(CASE WHEN a ->> 'name' = $2 THEN jsonb_set(a, '{target}', to_jsonb($3::text)) ELSE a END ORDER BY i)
+				FROM jsonb_array_elements(aliases) WITH ORDINALITY AS e (a, i)
+			)
+			ELSE aliases || jsonb_build_array(jsonb_build_object('name', $2::text, 'target', $3::text))
+		END
+		WHERE id = $1`,
+		userID, alias.Name, alias.Target,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserAliasDelete(ctx context.Context, userID, name string) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE users SET aliases = (
+			SELECT COALESCE(jsonb_agg(a ORDER BY i), '[]')
+			FROM jsonb_array_elements(aliases) WITH ORDINALITY AS e (a, i)
+			WHERE a ->> 'name' <> $2
+		)
+		WHERE id = $1 AND aliases @> jsonb_build_array(jsonb_build_object('name', $2::text))`,
+		userID, name,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
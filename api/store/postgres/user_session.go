@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// userSessionColumns are the columns of the user_sessions table, in the order scanned by scanUserSession.
+const userSessionColumns = `id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, refresh_token`
+
+func scanUserSession(row pgx.Row) (*models.UserSession, error) {
+	session := new(models.UserSession)
+	if err := row.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP, &session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt, &session.RefreshToken); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return session, nil
+}
+
+func (s *Store) UserSessionSave(ctx context.Context, session *models.UserSession) error {
+	if _, err := s.db(ctx).Exec(ctx, `DELETE FROM user_sessions WHERE user_id = $1 AND expires_at <= now()`, session.UserID); err != nil {
+		return FromPostgresError(err)
+	}
+
+	// NOTICE: as the Mongo store, a session whose ID belongs to another user isn't overwritten, failing as a duplicate.
+	var id string
+	err := s.db(ctx).QueryRow(ctx, `
+		INSERT INTO user_sessions (`+userSessionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET
+			user_agent = EXCLUDED.user_agent,
+			ip = EXCLUDED.ip,
+			last_seen_at = EXCLUDED.last_seen_at,
+			expires_at = EXCLUDED.expires_at,
+			refresh_token = EXCLUDED.refresh_token
+		WHERE user_sessions.user_id = EXCLUDED.user_id
+		RETURNING id`,
+		session.ID, session.UserID, session.UserAgent, session.IP, session.CreatedAt, session.LastSeenAt, session.ExpiresAt, session.RefreshToken,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return store.ErrDuplicate
+	}
+
+	return FromPostgresError(err)
+}
+
+func (s *Store) UserSessionRotate(ctx context.Context, session *models.UserSession, refreshToken string) error {
+	res, err := s.db(ctx).Exec(ctx, `
+		UPDATE user_sessions SET user_agent = $4, ip = $5, last_seen_at = $6, expires_at = $7, refresh_token = $8
+		WHERE id = $1 AND user_id = $2 AND refresh_token = $3`,
+		session.ID, session.UserID, refreshToken, session.UserAgent, session.IP, session.LastSeenAt, session.ExpiresAt, session.RefreshToken,
+	)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionGet(ctx context.Context, id string) (*models.UserSession, error) {
+	return scanUserSession(s.db(ctx).QueryRow(ctx, `SELECT `+userSessionColumns+` FROM user_sessions WHERE id = $1 AND expires_at > now()`, id))
+}
+
+func (s *Store) UserSessionList(ctx context.Context, userID string) ([]models.UserSession, error) {
+	rows, err := s.db(ctx).Query(ctx, `SELECT `+userSessionColumns+` FROM user_sessions WHERE user_id = $1 AND expires_at > now() ORDER BY last_seen_at DESC`, userID)
+	if err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return collect(rows, scanUserSession)
+}
+
+func (s *Store) UserSessionTouch(ctx context.Context, id string, lastSeenAt time.Time) error {
+	res, err := s.db(ctx).Exec(ctx, `UPDATE user_sessions SET last_seen_at = $2 WHERE id = $1 AND expires_at > now()`, id, lastSeenAt)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionDelete(ctx context.Context, userID, id string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM user_sessions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionDeleteAll(ctx context.Context, userID string) error {
+	_, err := s.db(ctx).Exec(ctx, `DELETE FROM user_sessions WHERE user_id = $1`, userID)
+
+	return FromPostgresError(err)
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// userVerificationColumns are the columns of the user_verifications table, in the order scanned by
+// scanUserVerification.
+const userVerificationColumns = `user_id, digest, created_at, expires_at`
+
+func scanUserVerification(row pgx.Row) (*models.UserVerification, error) {
+	verification := new(models.UserVerification)
+	if err := row.Scan(&verification.UserID, &verification.Digest, &verification.CreatedAt, &verification.ExpiresAt); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return verification, nil
+}
+
+func (s *Store) UserVerificationSave(ctx context.Context, verification *models.UserVerification) error {
+	if _, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO user_verifications (`+userVerificationColumns+`)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			digest = EXCLUDED.digest,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at`,
+		verification.UserID, verification.Digest, verification.CreatedAt, verification.ExpiresAt,
+	); err != nil {
+		return FromPostgresError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserVerificationGetByDigest(ctx context.Context, digest string, at time.Time) (*models.UserVerification, error) {
+	return scanUserVerification(s.db(ctx).QueryRow(ctx, `SELECT `+userVerificationColumns+` FROM user_verifications WHERE digest = $1 AND expires_at > $2`, digest, at))
+}
+
+func (s *Store) UserVerificationDelete(ctx context.Context, userID string) error {
+	res, err := s.db(ctx).Exec(ctx, `DELETE FROM user_verifications WHERE user_id = $1`, userID)
+	if err != nil {
+		return FromPostgresError(err)
+	}
+
+	if res.RowsAffected() < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
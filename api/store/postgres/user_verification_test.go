@@ -0,0 +1,95 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func userVerifications() []models.UserVerification {
+	return []models.UserVerification{
+		{
+			UserID:    "507f1f77bcf86cd799439011",
+			Digest:    models.UserVerificationDigest("token-1"),
+			CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			ExpiresAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			UserID:    "608f32a2c7351f001f6475e0",
+			Digest:    models.UserVerificationDigest("token-2"),
+			CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			ExpiresAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestUserVerificationSave(t *testing.T) {
+	ctx := context.Background()
+
+	verifications := userVerifications()
+	for i := range verifications {
+		require.NoError(t, s.UserVerificationSave(ctx, &verifications[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	// NOTICE: a new token sent to the user replaces the previous one.
+	replaced := verifications[0]
+	replaced.Digest = models.UserVerificationDigest("token-3")
+	replaced.ExpiresAt = time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, s.UserVerificationSave(ctx, &replaced))
+
+	at := time.Date(2023, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	_, err := s.UserVerificationGetByDigest(ctx, models.UserVerificationDigest("token-1"), at)
+	require.Equal(t, store.ErrNoDocuments, err)
+
+	verification, err := s.UserVerificationGetByDigest(ctx, models.UserVerificationDigest("token-3"), at)
+	require.NoError(t, err)
+	require.Equal(t, "507f1f77bcf86cd799439011", verification.UserID)
+	require.Equal(t, replaced.ExpiresAt, verification.ExpiresAt.UTC())
+}
+
+func TestUserVerificationGetByDigest(t *testing.T) {
+	ctx := context.Background()
+
+	verifications := userVerifications()
+	for i := range verifications {
+		require.NoError(t, s.UserVerificationSave(ctx, &verifications[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	at := time.Date(2023, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	_, err := s.UserVerificationGetByDigest(ctx, models.UserVerificationDigest("token-4"), at)
+	require.Equal(t, store.ErrNoDocuments, err)
+
+	_, err = s.UserVerificationGetByDigest(ctx, models.UserVerificationDigest("token-2"), time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC))
+	require.Equal(t, store.ErrNoDocuments, err)
+
+	verification, err := s.UserVerificationGetByDigest(ctx, models.UserVerificationDigest("token-2"), at)
+	require.NoError(t, err)
+	require.Equal(t, "608f32a2c7351f001f6475e0", verification.UserID)
+}
+
+func TestUserVerificationDelete(t *testing.T) {
+	ctx := context.Background()
+
+	verifications := userVerifications()
+	for i := range verifications {
+		require.NoError(t, s.UserVerificationSave(ctx, &verifications[i]))
+	}
+
+	t.Cleanup(func() { require.NoError(t, srv.Reset()) })
+
+	require.Equal(t, store.ErrNoDocuments, s.UserVerificationDelete(ctx, "000000000000000000000000"))
+	require.NoError(t, s.UserVerificationDelete(ctx, "507f1f77bcf86cd799439011"))
+
+	_, err := s.UserVerificationGetByDigest(ctx, models.UserVerificationDigest("token-1"), time.Date(2023, 1, 1, 18, 0, 0, 0, time.UTC))
+	require.Equal(t, store.ErrNoDocuments, err)
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shellhub-io/shellhub/api/store"
+	pkgerrors "github.com/shellhub-io/shellhub/pkg/errors"
+)
+
+// ErrLayer is an error level. Each error defined at this level, is container to it.
+// ErrLayer is the errors' level for postgres's error.
+const ErrLayer = "postgres"
+
+// ErrPostgres is the error for any unknown postgres error.
+var ErrPostgres = pkgerrors.New("postgres error", ErrLayer, 1)
+
+// pgErrUniqueViolation is the PostgreSQL's error code for a violated unique constraint.
+const pgErrUniqueViolation = "23505"
+
+func FromPostgresError(err error) error {
+	var pgErr *pgconn.PgError
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, pgx.ErrNoRows):
+		return store.ErrNoDocuments
+	case errors.As(err, &pgErr) && pgErr.Code == pgErrUniqueViolation:
+		return store.ErrDuplicate
+	case pgconn.Timeout(err), errors.Is(err, context.DeadlineExceeded):
+		// NOTICE: a timeout is returned either when the operation has waited too long for a connection from the pool or
+		// when it has exceeded its own timeout; both mean the database can't keep up with the requests.
+		return pkgerrors.Wrap(store.ErrUnavailable, err)
+	default:
+		return pkgerrors.Wrap(ErrPostgres, err)
+	}
+}
+
+// removeDuplicate removes duplicate elements from a slice while maintaining the original order.
+func removeDuplicate[T comparable](slice []T) []T {
+	allKeys := make(map[T]bool)
+	list := []T{}
+	for _, item := range slice {
+		if _, value := allKeys[item]; !value {
+			allKeys[item] = true
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// count runs a query that counts rows, returning the count as an int.
+func count(ctx context.Context, db querier, sql string, args ...any) (int, error) {
+	var n int64
+	if err := db.QueryRow(ctx, sql, args...).Scan(&n); err != nil {
+		return 0, FromPostgresError(err)
+	}
+
+	return int(n), nil
+}
+
+// nonNil returns an empty slice instead of a nil one, as the array columns aren't nullable.
+func nonNil[T any](slice []T) []T {
+	if slice == nil {
+		return []T{}
+	}
+
+	return slice
+}
+
+// collect scans each one of the rows with scan, closing them after.
+func collect[T any](rows pgx.Rows, scan func(row pgx.Row) (*T, error)) ([]T, error) {
+	defer rows.Close()
+
+	list := make([]T, 0)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, FromPostgresError(err)
+	}
+
+	return list, nil
+}
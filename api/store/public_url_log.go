@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type PublicURLLogStore interface {
+	// PublicURLLogCreate creates a log of a request made to a device's public URL. Returns an error if any.
+	PublicURLLogCreate(ctx context.Context, log *models.PublicURLLog) (err error)
+
+	// PublicURLLogList retrieves a list of requests made to the public URL of the tenant's device with the specified
+	// UID, most recent first. Returns the list of logs, the total count of matched documents, and an error if any.
+	PublicURLLogList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) (logs []models.PublicURLLog, count int, err error)
+
+	// PublicURLLogStats aggregates the counters of the requests made to the public URL of the tenant's device with
+	// the specified UID. Only the requests still kept on the logs are counted. Returns the counters and an error if any.
+	PublicURLLogStats(ctx context.Context, tenantID string, uid models.UID) (stats *models.PublicURLStats, err error)
+}
@@ -22,10 +22,11 @@ type PublicKeyTagsStore interface {
 	PublicKeySetTags(ctx context.Context, tenant, fingerprint string, tags []string) (matchedCount int64, updatedCount int64, err error)
 
 	// PublicKeyBulkRenameTag replaces all occurrences of the old tag with the new tag for all public keys to the specified tenant.
+	// The descendants of the old tag, like "region/europe" for "region", are moved to the new tag as well.
 	// Returns the number of documents updated and an error if any issues occur during the tag renaming.
 	PublicKeyBulkRenameTag(ctx context.Context, tenant, currentTag, newTag string) (updatedCount int64, err error)
 
-	// PublicKeyBulkDeleteTag removes a tag from all public keys belonging to the specified tenant.
+	// PublicKeyBulkDeleteTag removes a tag, and its descendants, from all public keys belonging to the specified tenant.
 	// Returns the number of documents updated and an error if any issues occur during the tag deletion.
 	PublicKeyBulkDeleteTag(ctx context.Context, tenant, tag string) (updatedCount int64, err error)
 
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type SessionRecordingAccessStore interface {
+	// SessionRecordingAccessCreate creates an entry on the access log of a session's recording. Returns an error if
+	// any.
+	SessionRecordingAccessCreate(ctx context.Context, access *models.SessionRecordingAccess) (err error)
+
+	// SessionRecordingAccessList retrieves the access log of the recording of the tenant's session with the specified
+	// UID, most recent first. Returns the list of accesses, the total count of matched documents, and an error if any.
+	SessionRecordingAccessList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) (accesses []models.SessionRecordingAccess, count int, err error)
+}
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type SessionScheduleOverrideStore interface {
+	// SessionScheduleOverrideCreate creates a break-glass override of the namespace's session schedules for a device.
+	// Returns an error if any.
+	SessionScheduleOverrideCreate(ctx context.Context, override *models.SessionScheduleOverride) (err error)
+
+	// SessionScheduleOverrideGetActive retrieves the override of the tenant's device with the specified UID which
+	// expires the latest after at. Returns the override or an error, [ErrNoDocuments] when the device has no active
+	// override.
+	SessionScheduleOverrideGetActive(ctx context.Context, tenantID string, uid models.UID, at time.Time) (override *models.SessionScheduleOverride, err error)
+
+	// SessionScheduleOverrideList retrieves a list of overrides of the tenant's device with the specified UID, most
+	// recent first. Returns the list of overrides, the total count of matched documents, and an error if any.
+	SessionScheduleOverrideList(ctx context.Context, tenantID string, uid models.UID, paginator query.Paginator) (overrides []models.SessionScheduleOverride, count int, err error)
+
+	// SessionScheduleOverrideListByUser retrieves the overrides of the tenant's devices made by the user with the
+	// specified ID at or after since, the most recent first. Returns the list of overrides and an error if any.
+	SessionScheduleOverrideListByUser(ctx context.Context, tenantID, userID string, since time.Time) (overrides []models.SessionScheduleOverride, err error)
+}
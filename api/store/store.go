@@ -5,16 +5,37 @@ type Store interface {
 	TagsStore
 	DeviceStore
 	DeviceTagsStore
+	DeviceKeyIncidentStore
+	DeviceQuarantineStore
+	DeviceChangeStore
+	DeviceAgentLogStore
+	DeviceCommandStore
+	DeviceSnapshotStore
+	DeviceLimitExemptionStore
+	PublicURLLogStore
 	SessionStore
+	SessionScheduleOverrideStore
+	SessionRecordingAccessStore
 	UserStore
+	UserAliasStore
+	UserSessionStore
+	UserVerificationStore
 	NamespaceStore
 	PublicKeyStore
 	PublicKeyTagsStore
 	PrivateKeyStore
 	StatsStore
 	APIKeyStore
+	EnrollTokenStore
 	TransactionStore
 	SystemStore
+	BannedAddressStore
+	TagRuleStore
+	CommandPolicyStore
+	DeviceShareStore
+	GroupStore
+	JobStore
+	AuditStore
 
 	Options() QueryOptions
 }
@@ -0,0 +1,62 @@
+package storetest
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Cases returns the contract's cases.
+func Cases() []Case {
+	return []Case{
+		{
+			Description: "DeviceGetByUID returns ErrNoDocuments when the device is not found",
+			Fixtures:    []string{"devices"},
+			Method:      "DeviceGetByUID",
+			Args:        []interface{}{models.UID("nonexistent"), "00000000-0000-4000-0000-000000000000"},
+			Expected:    []interface{}{(*models.Device)(nil), store.ErrNoDocuments},
+		},
+		{
+			Description: "DeviceCountByStatus counts the namespace's accepted devices",
+			Fixtures:    []string{"devices"},
+			Method:      "DeviceCountByStatus",
+			Args:        []interface{}{"00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted},
+			Expected:    []interface{}{int64(3), nil},
+		},
+		{
+			Description: "DeviceCountByStatus counts the namespace's pending devices",
+			Fixtures:    []string{"devices"},
+			Method:      "DeviceCountByStatus",
+			Args:        []interface{}{"00000000-0000-4000-0000-000000000000", models.DeviceStatusPending},
+			Expected:    []interface{}{int64(1), nil},
+		},
+		{
+			Description: "DeviceRename returns ErrNoDocuments when the device is not found",
+			Fixtures:    []string{"devices"},
+			Method:      "DeviceRename",
+			Args:        []interface{}{models.UID("nonexistent"), "device"},
+			Expected:    []interface{}{store.ErrNoDocuments},
+		},
+		{
+			Description: "DeviceSetCompromised returns ErrNoDocuments when the device is not found",
+			Fixtures:    []string{"devices"},
+			Method:      "DeviceSetCompromised",
+			Args:        []interface{}{models.UID("nonexistent"), true},
+			Expected:    []interface{}{store.ErrNoDocuments},
+		},
+		{
+			Description: "NamespaceGet returns ErrNoDocuments when the namespace is not found",
+			Fixtures:    []string{"namespaces"},
+			Method:      "NamespaceGet",
+			Args:        []interface{}{"nonexistent"},
+			Expected:    []interface{}{(*models.Namespace)(nil), store.ErrNoDocuments},
+		},
+		{
+			Description: "UserSessionTouch returns ErrNoDocuments when the session is not found",
+			Method:      "UserSessionTouch",
+			Args:        []interface{}{"nonexistent", time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)},
+			Expected:    []interface{}{store.ErrNoDocuments},
+		},
+	}
+}
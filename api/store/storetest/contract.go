@@ -0,0 +1,79 @@
+// Package storetest holds the contract of the store: calls the services rely on and the values they must return.
+//
+// The contract is checked against the store's mock, ensuring the expectations programmed with it match the generated
+// methods, and against the Mongo and PostgreSQL stores, under the "contract" build tag, ensuring the real
+// implementations behave as the mocked expectations claim. It catches drifts between the store's interface, its mock
+// and the implementations that would only surface at runtime.
+package storetest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Case is a call to a store's method and the values it must return.
+type Case struct {
+	Description string
+	// Fixtures are the fixtures loaded before the call on stores backed by a database. Check the fixtures of each
+	// store's implementation for their content.
+	Fixtures []string
+	// Method is the name of the store's method called.
+	Method string
+	// Args are the arguments passed to the method, after the context.
+	Args []interface{}
+	// Expected are the values returned by the method, in order. Nil pointers must be typed.
+	Expected []interface{}
+}
+
+// Setup prepares a store to run a case.
+type Setup func(t *testing.T, tc Case)
+
+// Mock returns a [Setup] that programs the case as an expectation on the store's mock.
+func Mock(m *mocks.Store) Setup {
+	return func(_ *testing.T, tc Case) {
+		args := append([]interface{}{mock.Anything}, tc.Args...)
+
+		m.On(tc.Method, args...).Return(tc.Expected...).Once()
+	}
+}
+
+// Run runs the contract's cases against s, calling setup before each one.
+func Run(t *testing.T, s store.Store, setup Setup) {
+	for _, tc := range Cases() {
+		t.Run(tc.Description, func(t *testing.T) {
+			setup(t, tc)
+
+			method := reflect.ValueOf(s).MethodByName(tc.Method)
+			require.True(t, method.IsValid(), "store has no method %s", tc.Method)
+
+			in := []reflect.Value{reflect.ValueOf(context.Background())}
+			for _, arg := range tc.Args {
+				in = append(in, reflect.ValueOf(arg))
+			}
+
+			signature := method.Type()
+			require.True(t, signature.IsVariadic() || signature.NumIn() == len(in), "%s expects %d arguments", tc.Method, signature.NumIn())
+			for i := range in {
+				if signature.IsVariadic() && i >= signature.NumIn()-1 {
+					break
+				}
+
+				require.True(t, in[i].Type().AssignableTo(signature.In(i)), "%s expects %s as argument %d", tc.Method, signature.In(i), i)
+			}
+
+			out := method.Call(in)
+			require.Len(t, out, len(tc.Expected), "%s returns %d values", tc.Method, len(out))
+
+			for i, value := range out {
+				assert.Equal(t, tc.Expected[i], value.Interface())
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package storetest_test
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/api/store/storetest"
+)
+
+// NOTICE: fails to compile when the mock doesn't implement the store's interface anymore.
+var _ store.Store = (*mocks.Store)(nil)
+
+func TestContractMock(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	storetest.Run(t, storeMock, storetest.Mock(storeMock))
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type TagRuleStore interface {
+	// TagRuleList retrieves the tag rules of the specified tenant, the oldest first. Returns the list of rules and an
+	// error if any.
+	TagRuleList(ctx context.Context, tenantID string) (rules []models.TagRule, err error)
+
+	// TagRuleGet retrieves the tenant's tag rule with the specified ID. Returns the rule and an error if any, or
+	// ErrNoDocuments when the rule isn't found.
+	TagRuleGet(ctx context.Context, tenantID, id string) (rule *models.TagRule, err error)
+
+	// TagRuleCreate creates a tag rule. Returns an error if any.
+	TagRuleCreate(ctx context.Context, rule *models.TagRule) (err error)
+
+	// TagRuleUpdate replaces the name, the tag and the conditions of the tag rule with the rule's ID and tenant ID.
+	// Returns ErrNoDocuments when the rule isn't found and an error if any.
+	TagRuleUpdate(ctx context.Context, rule *models.TagRule) (err error)
+
+	// TagRuleDelete deletes the tenant's tag rule with the specified ID. Returns ErrNoDocuments when the rule isn't
+	// found and an error if any.
+	TagRuleDelete(ctx context.Context, tenantID, id string) (err error)
+}
@@ -6,7 +6,7 @@ type TagsStore interface {
 	// TagsGet retrieves all tags associated with the specified tenant. It functions by invoking "[document]GetTags"
 	// for each document that implements tags.
 	// Returns the tags, the count of unique tags, and an error if any issues arise.
-	// It also filters the returned tags, removing any duplicates.
+	// It also filters the returned tags, removing any duplicates, and includes the ancestors of hierarchical tags.
 	TagsGet(ctx context.Context, tenant string) (tags []string, n int, err error)
 
 	// TagsRename replaces all occurrences of the old tag with the new tag for all documents associated with the specified tenant.
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type UserAliasStore interface {
+	// UserAliasSave saves the alias on the aliases of the user with the specified ID. When the user already has an
+	// alias with the same name, its target is replaced. Returns an error if any.
+	UserAliasSave(ctx context.Context, userID string, alias *models.UserAlias) (err error)
+
+	// UserAliasDelete deletes the alias with the specified name from the aliases of the user with the specified ID.
+	// Returns [ErrNoDocuments] when the user doesn't have the alias and an error if any.
+	UserAliasDelete(ctx context.Context, userID, name string) (err error)
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type UserSessionStore interface {
+	// UserSessionSave creates the session or, when it already exists for the same user, updates its user agent, IP,
+	// last activity, expiration and refresh token. Returns an error if any.
+	UserSessionSave(ctx context.Context, session *models.UserSession) (err error)
+
+	// UserSessionRotate updates the session's user agent, IP, last activity, expiration and refresh token only while its
+	// refresh token is still the specified one, so only one of the concurrent rotations of a refresh token succeeds.
+	// Returns [ErrNoDocuments] when the session doesn't exist or its refresh token was already rotated and an error if
+	// any.
+	UserSessionRotate(ctx context.Context, session *models.UserSession, refreshToken string) (err error)
+
+	// UserSessionGet gets the session with the specified ID. Returns [ErrNoDocuments] when the session doesn't exist
+	// and an error if any.
+	UserSessionGet(ctx context.Context, id string) (session *models.UserSession, err error)
+
+	// UserSessionList lists the sessions of the user with the specified ID, the most recently active first.
+	UserSessionList(ctx context.Context, userID string) (sessions []models.UserSession, err error)
+
+	// UserSessionTouch sets the last activity of the session with the specified ID. Returns [ErrNoDocuments] when the
+	// session doesn't exist and an error if any.
+	UserSessionTouch(ctx context.Context, id string, lastSeenAt time.Time) (err error)
+
+	// UserSessionDelete deletes the session with the specified ID from the user with the specified ID. Returns
+	// [ErrNoDocuments] when the user doesn't have the session and an error if any.
+	UserSessionDelete(ctx context.Context, userID, id string) (err error)
+
+	// UserSessionDeleteAll deletes all the sessions of the user with the specified ID. Returns an error if any.
+	UserSessionDeleteAll(ctx context.Context, userID string) (err error)
+}
@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type UserVerificationStore interface {
+	// UserVerificationSave stores the user's email verification, replacing the one the user already has. Returns an
+	// error if any.
+	UserVerificationSave(ctx context.Context, verification *models.UserVerification) (err error)
+
+	// UserVerificationGetByDigest retrieves the email verification with the specified digest, when it isn't expired at
+	// the instant. Returns the verification or an error, [ErrNoDocuments] when there is no such verification.
+	UserVerificationGetByDigest(ctx context.Context, digest string, at time.Time) (verification *models.UserVerification, err error)
+
+	// UserVerificationDelete deletes the email verification of the user with the specified ID. Returns an error,
+	// [ErrNoDocuments] when the user doesn't have one.
+	UserVerificationDelete(ctx context.Context, userID string) (err error)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	"github.com/shellhub-io/shellhub/cli/services"
+	"github.com/spf13/cobra"
+)
+
+// BackupCommands a factory function that creates and returns a new command with create and restore subcommands
+// dedicated to the instance's backups. It receives a service for handling business logic.
+func BackupCommands(service services.Services) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Manage backups",
+		Long:  `Provides an interface for backing up the instance and restoring it from a backup, as its disaster recovery path.`,
+	}
+
+	cmd.AddCommand(backupCreate(service))
+	cmd.AddCommand(backupRestore(service))
+
+	return cmd
+}
+
+func backupCreate(service services.Services) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create <file>",
+		Short: "Create a backup",
+		Long: `Creates a backup of the instance on the file, which must not exist. The backup is a gzipped tarball with a
+consistent snapshot of the Mongo database, dumped like mongodump does, tagged with the instance's version and the
+version of its database schema, and the manifest of the sessions' recordings kept on the object storage.
+
+The snapshot is read on a Mongo transaction, so the database must be a replica set member and the backup must finish
+within its transactionLifetimeLimitSeconds parameter. The recordings themselves must be backed up by the object storage.`,
+		Example: `cli backup create /backups/shellhub.tar.gz`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var input inputs.BackupCreate
+
+			if err := bind(args, &input); err != nil {
+				return err
+			}
+
+			manifest, err := service.BackupCreate(cmd.Context(), &input)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Backup created successfully")
+			cmd.Println("Version:", manifest.Version)
+			cmd.Println("Schema:", manifest.Schema)
+			cmd.Println("Collections:", len(manifest.Collections))
+			for _, collection := range manifest.Collections {
+				cmd.Println("  ", collection.Name, collection.Documents)
+			}
+
+			cmd.Println("Recordings:", manifest.Recordings)
+
+			return nil
+		},
+	}
+}
+
+func backupRestore(service services.Services) *cobra.Command {
+	cmdBackup := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore a backup",
+		Long: `Restores the backup on the file to the instance's Mongo database, which must be empty unless --drop is set.
+Before the database is touched, the backup's checksums are verified and its compatibility is checked: its format must
+be supported and its database schema must not be newer than the one of this version, as the API migrates older ones
+when it starts. Stop the API and the SSH server while the backup is restored.
+
+When the CLI_RECORDING_S3_* variables are set, the recordings listed by the backup are looked up on the object storage,
+reporting the missing ones.`,
+		Example: `cli backup restore /backups/shellhub.tar.gz --drop`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var input inputs.BackupRestore
+
+			if err := bind(args, &input); err != nil {
+				return err
+			}
+
+			drop, err := cmd.Flags().GetBool("drop")
+			if err != nil {
+				return err
+			}
+			input.Drop = drop
+
+			restored, err := service.BackupRestore(cmd.Context(), &input)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Backup restored successfully")
+			cmd.Println("Created at:", restored.Manifest.CreatedAt)
+			cmd.Println("Version:", restored.Manifest.Version)
+			cmd.Println("Schema:", restored.Manifest.Schema)
+			cmd.Println("Collections:", len(restored.Manifest.Collections))
+			cmd.Println("Recordings:", restored.Manifest.Recordings)
+
+			if restored.Verified {
+				cmd.Println("Missing recordings:", len(restored.Missing))
+				for _, recording := range restored.Missing {
+					cmd.Println("  ", recording.UID, recording.Object)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmdBackup.PersistentFlags().Bool("drop", false, "drop the database's collections before restoring the backup")
+
+	return cmdBackup
+}
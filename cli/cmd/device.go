@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	"github.com/shellhub-io/shellhub/cli/services"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+// DeviceCommands a factory function that creates and returns a new command with subcommands dedicated to devices
+// management. It receives a service for handling business logic.
+func DeviceCommands(service services.Services) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "device",
+		Short: "Manage devices",
+		Long:  `Provides an interface for managing the devices within the system.`,
+	}
+
+	cmd.AddCommand(deviceUIDAudit(service))
+	cmd.AddCommand(deviceBulk(service, deviceBulkAccept))
+	cmd.AddCommand(deviceBulk(service, deviceBulkReject))
+	cmd.AddCommand(deviceBulk(service, deviceBulkDelete))
+
+	return cmd
+}
+
+func deviceUIDAudit(service services.Services) *cobra.Command {
+	return &cobra.Command{
+		Use:   "uid-audit [scheme]",
+		Short: "Audit the device UIDs",
+		Long: `Audits the UIDs of every device against a derivation scheme, "legacy" or "v2", defaulting to "legacy".
+It reports the devices whose UIDs are derived on the scheme, the ones derived on the legacy scheme, which keep their
+UIDs on the new one, the ones whose UIDs aren't derived from their identities and the ones whose identities derive the
+same UID. Run it before changing the API's SHELLHUB_DEVICE_UID_SCHEME.`,
+		Example: `cli device uid-audit v2`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var input inputs.DeviceUIDAudit
+
+			if err := bind(args, &input); err != nil {
+				return err
+			}
+
+			report, err := service.DeviceUIDAudit(cmd.Context(), &input)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Scheme:", report.Scheme)
+			cmd.Println("Devices:", report.Devices)
+			cmd.Println("Derived:", report.Derived)
+			cmd.Println("Legacy:", len(report.Legacy))
+			cmd.Println("Mismatched:", len(report.Mismatched))
+			for _, uid := range report.Mismatched {
+				cmd.Println("  ", uid)
+			}
+
+			cmd.Println("Collisions:", len(report.Collisions))
+			uids := make([]string, 0, len(report.Collisions))
+			for uid := range report.Collisions {
+				uids = append(uids, uid)
+			}
+
+			sort.Strings(uids)
+
+			for _, uid := range uids {
+				cmd.Println("  ", uid)
+				for _, colliding := range report.Collisions[uid] {
+					cmd.Println("    ", colliding)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// deviceBulkOperation describes a bulk operation on the devices selected by the flags.
+type deviceBulkOperation struct {
+	use     string
+	aliases []string
+	short   string
+	long    string
+	example string
+	// verb names the operation when its confirmation is asked.
+	verb string
+	// status is the status of the devices selected when the --status flag isn't set.
+	status string
+	run    func(services.Services, context.Context, []models.Device) (*services.DeviceBulkResult, error)
+}
+
+var deviceBulkAccept = deviceBulkOperation{
+	use:   "accept",
+	verb:  "Accept",
+	short: "Accept devices in bulk",
+	long: `Accepts the pending devices matching all the criteria set, from every namespace unless --namespace is set. A
+device is skipped when its namespace has reached its maximum number of accepted devices or when another accepted device
+has its name.`,
+	example: `cli device accept --namespace dev --tag edge --dry-run`,
+	status:  string(models.DeviceStatusPending),
+	run:     services.Services.DeviceAccept,
+}
+
+var deviceBulkReject = deviceBulkOperation{
+	use:   "reject",
+	verb:  "Reject",
+	short: "Reject devices in bulk",
+	long: `Rejects the pending devices matching all the criteria set, from every namespace unless --namespace is set.
+The accepted devices are skipped, as they must be deleted instead.`,
+	example: `cli device reject --name "test-*" --yes`,
+	status:  string(models.DeviceStatusPending),
+	run:     services.Services.DeviceReject,
+}
+
+var deviceBulkDelete = deviceBulkOperation{
+	use:     "delete",
+	verb:    "Delete",
+	aliases: []string{"prune"},
+	short:   "Delete devices in bulk",
+	long: `Deletes the devices matching all the criteria set, whatever their status unless --status is set, from every
+namespace unless --namespace is set. The devices' sessions are deleted with them.`,
+	example: `cli device prune --offline-for 90d --dry-run`,
+	status:  "",
+	run:     services.Services.DeviceDelete,
+}
+
+func deviceBulk(service services.Services, operation deviceBulkOperation) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     operation.use,
+		Aliases: operation.aliases,
+		Short:   operation.short,
+		Long: operation.long + `
+
+At least one of --namespace, --tag, --name or --offline-for must be set. The devices selected are listed and a
+confirmation is asked before they are changed, unless --yes is set; with --dry-run, they are only listed.`,
+		Example: operation.example,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			flags := cmd.Flags()
+
+			var input inputs.DeviceSelect
+			for flag, value := range map[string]*string{
+				"namespace":   &input.Namespace,
+				"status":      &input.Status,
+				"tag":         &input.Tag,
+				"name":        &input.Name,
+				"offline-for": &input.OfflineFor,
+			} {
+				if *value, err = flags.GetString(flag); err != nil {
+					return err
+				}
+			}
+
+			dryRun, _ := flags.GetBool("dry-run")
+			yes, _ := flags.GetBool("yes")
+			asJSON, _ := flags.GetBool("json")
+
+			devices, err := service.DeviceSelect(cmd.Context(), &input)
+			if err != nil {
+				return err
+			}
+
+			if dryRun || asJSON && !yes {
+				if asJSON {
+					return printJSON(cmd, devices)
+				}
+
+				printDevices(cmd, devices)
+
+				return nil
+			}
+
+			if len(devices) == 0 {
+				cmd.Println("No devices selected")
+
+				return nil
+			}
+
+			if !yes {
+				printDevices(cmd, devices)
+
+				if !confirm(cmd, fmt.Sprintf("%s %d devices?", operation.verb, len(devices))) {
+					cmd.Println("Aborted")
+
+					return nil
+				}
+			}
+
+			result, err := operation.run(service, cmd.Context(), devices)
+			if result != nil {
+				if asJSON {
+					if err := printJSON(cmd, result); err != nil {
+						return err
+					}
+				} else {
+					printDeviceBulkResult(cmd, result)
+				}
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().String("namespace", "", "select the devices of the namespace with this name")
+	cmd.Flags().String("status", operation.status, "select the devices with this status: accepted, pending or rejected")
+	cmd.Flags().String("tag", "", "select the devices with this tag")
+	cmd.Flags().String("name", "", "select the devices whose names match this glob pattern, like \"web-*\"")
+	cmd.Flags().String("offline-for", "", "select the devices offline for at least this long, like 90d or 12h")
+	cmd.Flags().Bool("dry-run", false, "only list the devices selected")
+	cmd.Flags().BoolP("yes", "y", false, "don't ask for confirmation")
+	cmd.Flags().Bool("json", false, "print the devices selected, or the result, as JSON; requires --yes to change them")
+
+	return cmd
+}
+
+// confirm asks the question on the standard error, so it doesn't mix with the command's output, reporting if it was
+// answered with yes.
+func confirm(cmd *cobra.Command, question string) bool {
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s [y/N] ", question)
+
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
+
+func printJSON(cmd *cobra.Command, v any) error {
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func printDevices(cmd *cobra.Command, devices []models.Device) {
+	cmd.Println("Devices:", len(devices))
+	for _, device := range devices {
+		cmd.Println("  ", device.UID, device.Name, device.TenantID, device.Status, device.LastSeen.Format("2006-01-02T15:04:05Z07:00"))
+	}
+}
+
+func printDeviceBulkResult(cmd *cobra.Command, result *services.DeviceBulkResult) {
+	cmd.Println("Changed:", len(result.Changed))
+	for _, entry := range result.Changed {
+		cmd.Println("  ", entry.UID, entry.Name, entry.TenantID)
+	}
+
+	cmd.Println("Skipped:", len(result.Skipped))
+	for _, entry := range result.Skipped {
+		cmd.Println("  ", entry.UID, entry.Name, entry.TenantID, "("+entry.Reason+")")
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/shellhub-io/shellhub/cli/services"
+	"github.com/spf13/cobra"
+)
+
+// FieldsCommands a factory function that creates and returns a new command with the rekey subcommand dedicated to the
+// encrypted personal data fields. It receives a service for handling business logic.
+func FieldsCommands(service services.Services) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fields",
+		Short: "Manage the encrypted fields",
+		Long:  `Provides an interface for managing the personal data fields encrypted on the database, like the users' emails and the devices' and sessions' IP addresses.`,
+	}
+
+	cmd.AddCommand(fieldsRekey(service))
+
+	return cmd
+}
+
+func fieldsRekey(service services.Services) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rekey",
+		Short: "Encrypt the fields again with the current key",
+		Long: `Encrypts again, with the key on CLI_FIELD_ENCRYPTION_KEY, the personal data fields stored in plain text or
+encrypted with one of the keys on CLI_FIELD_ENCRYPTION_PREVIOUS_KEYS. Run it after enabling the encryption, to encrypt
+the fields stored before, and after rotating the key, before removing the previous one from the API's and the CLI's
+configuration. The fields changed while it runs are skipped, so it may be run again until no field is rekeyed.`,
+		Example: `cli fields rekey`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			rekeyed, err := service.FieldsRekey(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Fields rekeyed successfully")
+			cmd.Println("Rekeyed:", rekeyed)
+
+			return nil
+		},
+	}
+}
@@ -18,6 +18,8 @@ func NamespaceCommands(service services.Services) *cobra.Command {
 
 	cmd.AddCommand(namespaceCreate(service))
 	cmd.AddCommand(namespaceDelete(service))
+	cmd.AddCommand(namespaceQuota(service))
+	cmd.AddCommand(namespaceDeviceLimits(service))
 	cmd.AddCommand(memberCommands(service))
 
 	return cmd
@@ -96,6 +98,68 @@ func namespaceDelete(service services.Services) *cobra.Command {
 	}
 }
 
+func namespaceQuota(service services.Services) *cobra.Command {
+	return &cobra.Command{
+		Use:   "quota <namespace> <members> <invitations>",
+		Short: "Set the member quota of a namespace",
+		Long: `Sets the maximum number of members, including the pending invitations, and the maximum number of pending
+invitations of a namespace, overriding the instance's defaults. Use 0 to restore the default and -1 to remove the limit.`,
+		Example: `cli namespace quota dev 10 5`,
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var input inputs.NamespaceQuota
+
+			if err := bind(args, &input); err != nil {
+				return err
+			}
+
+			namespace, err := service.NamespaceQuota(cmd.Context(), &input)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Namespace quota updated successfully")
+			cmd.Println("Namespace:", namespace.Name)
+			cmd.Println("Tenant:", namespace.TenantID)
+			cmd.Println("Members:", namespace.MaxMembers)
+			cmd.Println("Invitations:", namespace.MaxInvitations)
+
+			return nil
+		},
+	}
+}
+
+func namespaceDeviceLimits(service services.Services) *cobra.Command {
+	return &cobra.Command{
+		Use:   "device-limits <namespace> <devices> <pending>",
+		Short: "Set the device limits of a namespace",
+		Long: `Sets the maximum number of accepted devices and the maximum number of devices waiting for acceptance of a
+namespace, regardless of its billing. Use 0 or -1 to remove the limit.`,
+		Example: `cli namespace device-limits dev 50 10`,
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var input inputs.NamespaceDeviceLimits
+
+			if err := bind(args, &input); err != nil {
+				return err
+			}
+
+			namespace, err := service.NamespaceDeviceLimits(cmd.Context(), &input)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Namespace device limits updated successfully")
+			cmd.Println("Namespace:", namespace.Name)
+			cmd.Println("Tenant:", namespace.TenantID)
+			cmd.Println("Devices:", namespace.MaxDevices)
+			cmd.Println("Pending devices:", namespace.MaxPendingDevices)
+
+			return nil
+		},
+	}
+}
+
 // memberCommands factory function that creates and returns a new command with
 // add and remove subcommands dedicated to members management. It receives a service
 // for handling business logic.
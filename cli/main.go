@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/shellhub-io/shellhub/api/store/mongo"
 	"github.com/shellhub-io/shellhub/cli/cmd"
 	"github.com/shellhub-io/shellhub/cli/services"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +20,46 @@ import (
 type config struct {
 	MongoURI string `env:"MONGO_URI,default=mongodb://mongo:27017/main"`
 	RedisURI string `env:"REDIS_URI,default=redis://redis:6379"`
+
+	// RecordingS3Endpoint is the URL of the S3-compatible storage where the SSH server keeps the sessions' recordings,
+	// where the recordings of a restored backup are looked up. When empty, they aren't looked up.
+	RecordingS3Endpoint string `env:"RECORDING_S3_ENDPOINT,default="`
+	// RecordingS3Region is the region of the recordings' bucket.
+	RecordingS3Region string `env:"RECORDING_S3_REGION,default=us-east-1"`
+	// RecordingS3Bucket is the bucket where the recordings are kept.
+	RecordingS3Bucket string `env:"RECORDING_S3_BUCKET,default="`
+	// RecordingS3AccessKeyID is the access key used to read the recordings.
+	RecordingS3AccessKeyID string `env:"RECORDING_S3_ACCESS_KEY_ID,default="`
+	// RecordingS3SecretAccessKey is the secret of the access key.
+	RecordingS3SecretAccessKey string `env:"RECORDING_S3_SECRET_ACCESS_KEY,default="`
+	// RecordingS3PathStyle addresses the bucket on the URL's path, as required by MinIO.
+	RecordingS3PathStyle bool `env:"RECORDING_S3_PATH_STYLE,default=false"`
+
+	// FieldEncryptionKey is the key encrypting the personal data fields, which must be the API's one.
+	FieldEncryptionKey string `env:"FIELD_ENCRYPTION_KEY,default="`
+	// FieldEncryptionKeyFile is the file holding the encryption key, read when FieldEncryptionKey is empty.
+	FieldEncryptionKeyFile string `env:"FIELD_ENCRYPTION_KEY_FILE,default="`
+	// FieldEncryptionPreviousKeys are the comma-separated keys the fields were encrypted with before the current key.
+	FieldEncryptionPreviousKeys string `env:"FIELD_ENCRYPTION_PREVIOUS_KEYS,default="`
+
+	// Domain is the domain of the server, which must be the API's one. It is used to build the links sent to users by
+	// email.
+	Domain string `env:"DOMAIN,default=localhost"`
+	// AutoSSL indicates whether the server is exposed through HTTPS. It is used to build the links sent to users by email.
+	AutoSSL bool `env:"AUTO_SSL,default=false"`
+	// SMTPHost is the SMTP server's hostname used to send the verification email to the users created when the email
+	// verification is enabled.
+	SMTPHost string `env:"SMTP_HOST,default="`
+	// SMTPPort is the SMTP server's port.
+	SMTPPort int `env:"SMTP_PORT,default=587"`
+	// SMTPUsername is the user used to authenticate on the SMTP server. When empty, no authentication is performed.
+	SMTPUsername string `env:"SMTP_USERNAME,default="`
+	// SMTPPassword is the password used to authenticate on the SMTP server.
+	SMTPPassword string `env:"SMTP_PASSWORD,default="`
+	// SMTPFrom is the sender's address of every email.
+	SMTPFrom string `env:"SMTP_FROM,default="`
+	// EmailVerificationExpiration is how long, in hours, an email verification link remains valid.
+	EmailVerificationExpiration int `env:"EMAIL_VERIFICATION_EXPIRATION,default=24"`
 }
 
 func init() {
@@ -39,20 +83,67 @@ func main() {
 
 	log.Info("Connected to Redis")
 
+	cipher, err := fieldcrypt.Load(cfg.FieldEncryptionKey, cfg.FieldEncryptionKeyFile, cfg.FieldEncryptionPreviousKeys)
+	if err != nil {
+		log.
+			WithError(err).
+			Fatal("failed to load the field encryption key")
+	}
+
 	log.Trace("Connecting to MongoDB")
 
-	store, err := mongo.NewStore(ctx, cfg.MongoURI, cache)
+	store, err := mongo.NewStore(ctx, cfg.MongoURI, cache, cipher, nil)
 	if err != nil {
 		log.
 			WithError(err).
 			Fatal("failed to create the store")
 	}
 
-	service := services.NewService(store)
+	opts := []services.Option{services.WithFieldsCipher(cipher)}
+	if cfg.RecordingS3Endpoint != "" {
+		storage, err := objectstorage.NewS3Storage(objectstorage.S3Config{
+			Endpoint:        cfg.RecordingS3Endpoint,
+			Region:          cfg.RecordingS3Region,
+			Bucket:          cfg.RecordingS3Bucket,
+			AccessKeyID:     cfg.RecordingS3AccessKeyID,
+			SecretAccessKey: cfg.RecordingS3SecretAccessKey,
+			PathStyle:       cfg.RecordingS3PathStyle,
+		})
+		if err != nil {
+			log.WithError(err).Fatal("failed to configure the recordings' object storage")
+		}
+
+		opts = append(opts, services.WithRecordingStorage(storage))
+	}
+
+	if envs.HasEmailVerification() {
+		mailer, err := mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+		if err != nil {
+			log.WithError(err).Fatal("failed to configure the SMTP mailer")
+		}
+
+		scheme := "http"
+		if cfg.AutoSSL {
+			scheme = "https"
+		}
+
+		opts = append(opts, services.WithEmailVerification(mailer, scheme+"://"+cfg.Domain, time.Duration(cfg.EmailVerificationExpiration)*time.Hour))
+	}
+
+	service := services.NewService(store, opts...)
 
 	rootCmd := &cobra.Command{Use: "cli"}
 	rootCmd.AddCommand(cmd.UserCommands(service))
 	rootCmd.AddCommand(cmd.NamespaceCommands(service))
+	rootCmd.AddCommand(cmd.DeviceCommands(service))
+	rootCmd.AddCommand(cmd.BackupCommands(service))
+	rootCmd.AddCommand(cmd.FieldsCommands(service))
 	// WARN: this is deprecated and will be removed soon
 	cmd.DeprecatedCommands(rootCmd, service)
 
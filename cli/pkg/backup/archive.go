@@ -0,0 +1,176 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// The backup is a gzipped tarball with the following files:
+//
+//	dump/<collection>.bson           the collection's documents, as written by mongodump
+//	dump/<collection>.metadata.json  the collection's indexes, as written by mongodump
+//	recordings.json                  the recordings kept on the object storage
+//	manifest.json                    the backup's manifest
+//
+// The "dump" directory can be restored by mongorestore as well.
+const (
+	dumpDir        = "dump"
+	bsonExt        = ".bson"
+	metadataExt    = ".metadata.json"
+	recordingsName = "recordings.json"
+	manifestName   = "manifest.json"
+)
+
+// writer writes the backup's files into a gzipped tarball.
+type writer struct {
+	gz  *gzip.Writer
+	tar *tar.Writer
+}
+
+func newWriter(w io.Writer) *writer {
+	gz := gzip.NewWriter(w)
+
+	return &writer{gz: gz, tar: tar.NewWriter(gz)}
+}
+
+// file writes a file with the size bytes read from r.
+func (w *writer) file(name string, size int64, r io.Reader) error {
+	if err := w.tar.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: size, Typeflag: tar.TypeReg}); err != nil {
+		return err
+	}
+
+	_, err := io.CopyN(w.tar, r, size)
+
+	return err
+}
+
+// json writes a file with v encoded as JSON.
+func (w *writer) json(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return w.file(name, int64(len(data)), bytes.NewReader(data))
+}
+
+func (w *writer) Close() error {
+	if err := w.tar.Close(); err != nil {
+		return err
+	}
+
+	return w.gz.Close()
+}
+
+// read calls fn with each file of the backup on filename, by the order they were written.
+func read(filename string, fn func(name string, r io.Reader) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.Join(ErrCorrupted, err)
+	}
+
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return errors.Join(ErrCorrupted, err)
+		}
+
+		if err := fn(header.Name, archive); err != nil {
+			return err
+		}
+	}
+}
+
+// collectionName returns the name of the collection dumped on the file, when it is a collection's dump.
+func collectionName(name string) (string, bool) {
+	dir, file := path.Split(name)
+	if path.Clean(dir) != dumpDir || !strings.HasSuffix(file, bsonExt) {
+		return "", false
+	}
+
+	return strings.TrimSuffix(file, bsonExt), true
+}
+
+// metadataName returns the name of the collection whose indexes are on the file, when it is a collection's metadata.
+func metadataName(name string) (string, bool) {
+	dir, file := path.Split(name)
+	if path.Clean(dir) != dumpDir || !strings.HasSuffix(file, metadataExt) {
+		return "", false
+	}
+
+	return strings.TrimSuffix(file, metadataExt), true
+}
+
+// Inspect reads the manifest and the recordings of the backup on filename, verifying the checksums of its collections'
+// dumps. It returns [ErrCorrupted] when the backup cannot be read or its dumps don't match the manifest.
+func Inspect(filename string) (*Manifest, []Recording, error) {
+	var manifest *Manifest
+	var recordings []Recording
+
+	sums := make(map[string]string)
+
+	if err := read(filename, func(name string, r io.Reader) error {
+		switch name {
+		case manifestName:
+			if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+				return errors.Join(ErrCorrupted, err)
+			}
+
+			return nil
+		case recordingsName:
+			if err := json.NewDecoder(r).Decode(&recordings); err != nil {
+				return errors.Join(ErrCorrupted, err)
+			}
+
+			return nil
+		}
+
+		if collection, ok := collectionName(name); ok {
+			hash := sha256.New()
+			if _, err := io.Copy(hash, r); err != nil {
+				return errors.Join(ErrCorrupted, err)
+			}
+
+			sums[collection] = hex.EncodeToString(hash.Sum(nil))
+		}
+
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	if manifest == nil || len(sums) != len(manifest.Collections) || len(recordings) != manifest.Recordings {
+		return nil, nil, ErrCorrupted
+	}
+
+	for _, collection := range manifest.Collections {
+		if sums[collection.Name] != collection.SHA256 {
+			return nil, nil, ErrCorrupted
+		}
+	}
+
+	return manifest, recordings, nil
+}
@@ -0,0 +1,66 @@
+// Package backup creates and restores the backups of a ShellHub instance: a consistent snapshot of its Mongo database,
+// dumped like mongodump does, and the manifest of the sessions' recordings kept on the object storage, which are
+// backed up by the storage itself.
+package backup
+
+import (
+	"errors"
+	"time"
+)
+
+// Format is the version of the backup's layout, increased when a backup cannot be restored by an older CLI.
+const Format = 1
+
+var (
+	ErrIncompatibleFormat = errors.New("the backup's format isn't supported by this version")
+	ErrIncompatibleSchema = errors.New("the backup's database schema is newer than the one supported by this version")
+	ErrCorrupted          = errors.New("the backup is corrupted")
+	ErrNotEmpty           = errors.New("the database isn't empty")
+)
+
+// Manifest describes the backup's contents.
+type Manifest struct {
+	// Format is the version of the backup's layout.
+	Format int `json:"format"`
+	// CreatedAt is when the database's snapshot was taken.
+	CreatedAt time.Time `json:"created_at"`
+	// Version is the ShellHub's version of the instance backed up.
+	Version string `json:"version"`
+	// Schema is the version of the latest Mongo migration applied to the database backed up.
+	Schema uint64 `json:"schema"`
+	// Collections are the collections dumped, by the order of their names.
+	Collections []Collection `json:"collections"`
+	// Recordings is the number of the sessions' recordings listed on the recordings' manifest.
+	Recordings int `json:"recordings"`
+}
+
+// Collection is a collection dumped by the backup.
+type Collection struct {
+	Name string `json:"name"`
+	// Documents is the number of documents dumped.
+	Documents int `json:"documents"`
+	// SHA256 is the checksum of the collection's dump, verified before it is restored.
+	SHA256 string `json:"sha256"`
+}
+
+// Recording is a session's recording kept on the object storage when the backup was created.
+type Recording struct {
+	UID      string `json:"uid" bson:"uid"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Object is the recording's key on the object storage.
+	Object string `json:"object" bson:"record_object"`
+}
+
+// Compatible checks if the backup can be restored on an instance whose latest Mongo migration is schema. A backup of
+// an older schema is compatible, as the API migrates the database when it starts.
+func (m *Manifest) Compatible(schema uint64) error {
+	if m.Format != Format {
+		return ErrIncompatibleFormat
+	}
+
+	if m.Schema > schema {
+		return ErrIncompatibleSchema
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// archive writes a backup with the collection's documents to a temporary file, returning its path. edit changes the
+// manifest before it is written.
+func archive(t *testing.T, documents []bson.M, recordings []Recording, edit func(*Manifest)) string {
+	t.Helper()
+
+	var dump bytes.Buffer
+	for _, document := range documents {
+		data, err := bson.Marshal(document)
+		require.NoError(t, err)
+
+		dump.Write(data)
+	}
+
+	sum := sha256.Sum256(dump.Bytes())
+
+	manifest := &Manifest{
+		Format:      Format,
+		Version:     "v0.13.4",
+		Schema:      100,
+		Collections: []Collection{{Name: "devices", Documents: len(documents), SHA256: hex.EncodeToString(sum[:])}},
+		Recordings:  len(recordings),
+	}
+
+	if edit != nil {
+		edit(manifest)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	w := newWriter(file)
+	require.NoError(t, w.file("dump/devices.bson", int64(dump.Len()), &dump))
+	require.NoError(t, w.json(recordingsName, recordings))
+	require.NoError(t, w.json(manifestName, manifest))
+	require.NoError(t, w.Close())
+
+	return path
+}
+
+func TestInspect(t *testing.T) {
+	documents := []bson.M{{"uid": "1"}, {"uid": "2"}}
+	recordings := []Recording{{UID: "1", TenantID: "00000000-0000-4000-0000-000000000000", Object: "sessions/1"}}
+
+	t.Run("fails when the file isn't a backup", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "backup.tar.gz")
+		require.NoError(t, os.WriteFile(path, []byte("backup"), 0o600))
+
+		_, _, err := Inspect(path)
+		assert.ErrorIs(t, err, ErrCorrupted)
+	})
+
+	t.Run("fails when the checksum of a collection doesn't match", func(t *testing.T) {
+		path := archive(t, documents, recordings, func(manifest *Manifest) {
+			manifest.Collections[0].SHA256 = "0000"
+		})
+
+		_, _, err := Inspect(path)
+		assert.ErrorIs(t, err, ErrCorrupted)
+	})
+
+	t.Run("fails when a collection is missing", func(t *testing.T) {
+		path := archive(t, documents, recordings, func(manifest *Manifest) {
+			manifest.Collections = append(manifest.Collections, Collection{Name: "sessions"})
+		})
+
+		_, _, err := Inspect(path)
+		assert.ErrorIs(t, err, ErrCorrupted)
+	})
+
+	t.Run("succeeds when the backup matches its manifest", func(t *testing.T) {
+		manifest, listed, err := Inspect(archive(t, documents, recordings, nil))
+		require.NoError(t, err)
+		assert.Equal(t, 2, manifest.Collections[0].Documents)
+		assert.Equal(t, "v0.13.4", manifest.Version)
+		assert.Equal(t, recordings, listed)
+	})
+}
+
+func TestManifestCompatible(t *testing.T) {
+	cases := []struct {
+		description string
+		manifest    *Manifest
+		expected    error
+	}{
+		{
+			description: "fails when the format isn't supported",
+			manifest:    &Manifest{Format: Format + 1, Schema: 100},
+			expected:    ErrIncompatibleFormat,
+		},
+		{
+			description: "fails when the schema is newer",
+			manifest:    &Manifest{Format: Format, Schema: 101},
+			expected:    ErrIncompatibleSchema,
+		},
+		{
+			description: "succeeds when the schema is the same",
+			manifest:    &Manifest{Format: Format, Schema: 100},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the schema is older",
+			manifest:    &Manifest{Format: Format, Schema: 90},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.manifest.Compatible(100))
+		})
+	}
+}
+
+func TestReadDocument(t *testing.T) {
+	first, err := bson.Marshal(bson.M{"uid": "1"})
+	require.NoError(t, err)
+
+	second, err := bson.Marshal(bson.M{"uid": "2"})
+	require.NoError(t, err)
+
+	r := bytes.NewReader(append(append([]byte{}, first...), second[:len(second)-1]...))
+
+	document, err := readDocument(r)
+	require.NoError(t, err)
+	assert.Equal(t, bson.Raw(first), document)
+
+	_, err = readDocument(r)
+	assert.ErrorIs(t, err, ErrCorrupted)
+
+	_, err = readDocument(r)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestMissing(t *testing.T) {
+	ctx := context.Background()
+	storage := new(mocks.Storage)
+
+	recordings := []Recording{{UID: "1", Object: "sessions/1"}, {UID: "2", Object: "sessions/2"}}
+
+	storage.On("GetObject", ctx, "sessions/1").Return(io.NopCloser(bytes.NewReader(nil)), nil).Once()
+	storage.On("GetObject", ctx, "sessions/2").Return(nil, objectstorage.ErrObjectNotFound).Once()
+
+	missing, err := Missing(ctx, storage, recordings)
+	require.NoError(t, err)
+	assert.Equal(t, []Recording{recordings[1]}, missing)
+
+	storage.On("GetObject", ctx, "sessions/1").Return(nil, errors.New("error")).Once()
+
+	_, err = Missing(ctx, storage, recordings)
+	assert.Error(t, err)
+
+	storage.AssertExpectations(t)
+}
@@ -0,0 +1,388 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+)
+
+const (
+	// migrationsCollection is the collection where the Mongo migrations applied are recorded.
+	migrationsCollection = "migrations"
+	// sessionsCollection is the collection whose documents refer to the recordings on the object storage.
+	sessionsCollection = "sessions"
+	// insertBatchSize is the number of documents inserted at once when a collection is restored.
+	insertBatchSize = 1000
+)
+
+// excluded are the collections that aren't backed up, as they only keep transient state.
+var excluded = map[string]bool{
+	"locks":          true,
+	"migrations_tmp": true,
+}
+
+// Create dumps a snapshot of the database to w, tagged with the instance's version, returning the backup's manifest.
+//
+// The documents are read on a transaction with the snapshot read concern, so the collections are dumped at the same
+// point in time. It requires the database to be a replica set member and the dump to finish within the transactions'
+// lifetime limit, set by the transactionLifetimeLimitSeconds parameter.
+func Create(ctx context.Context, db *mongo.Database, w io.Writer, version string) (*Manifest, error) {
+	// NOTICE: the collections and their indexes cannot be listed on a transaction, so they are listed before it starts.
+	names, err := db.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+
+	indexes := make(map[string][]bson.D)
+	for _, name := range names {
+		if excluded[name] || strings.HasPrefix(name, "system.") {
+			continue
+		}
+
+		cursor, err := db.Collection(name).Indexes().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var specs []bson.D
+		if err := cursor.All(ctx, &specs); err != nil {
+			return nil, err
+		}
+
+		indexes[name] = specs
+	}
+
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+
+	defer session.EndSession(ctx)
+
+	if err := session.StartTransaction(options.Transaction().SetReadConcern(readconcern.Snapshot())); err != nil {
+		return nil, err
+	}
+
+	// NOTICE: the transaction only reads, so it is always aborted.
+	defer session.AbortTransaction(context.Background()) //nolint:errcheck
+
+	sc := mongo.NewSessionContext(ctx, session)
+
+	manifest := &Manifest{
+		Format:      Format,
+		CreatedAt:   time.Now(),
+		Version:     version,
+		Collections: []Collection{},
+	}
+
+	manifest.Schema, err = schema(sc, db)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := newWriter(w)
+
+	recordings := []Recording{}
+	for _, name := range names {
+		specs, ok := indexes[name]
+		if !ok {
+			continue
+		}
+
+		collection, err := dump(sc, db.Collection(name), archive)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Collections = append(manifest.Collections, *collection)
+
+		metadata, err := bson.MarshalExtJSON(bson.D{{Key: "indexes", Value: specs}}, true, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := archive.file(path.Join(dumpDir, name+metadataExt), int64(len(metadata)), bytes.NewReader(metadata)); err != nil {
+			return nil, err
+		}
+
+		if name == sessionsCollection {
+			if recordings, err = listRecordings(sc, db.Collection(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	manifest.Recordings = len(recordings)
+
+	if err := archive.json(recordingsName, recordings); err != nil {
+		return nil, err
+	}
+
+	if err := archive.json(manifestName, manifest); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// schema returns the version of the latest Mongo migration applied to the database.
+func schema(ctx context.Context, db *mongo.Database) (uint64, error) {
+	var migration struct {
+		Version uint64 `bson:"version"`
+	}
+
+	err := db.Collection(migrationsCollection).FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.M{"_id": -1})).Decode(&migration)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+
+	return migration.Version, err
+}
+
+// dump writes the collection's documents to the archive, like mongodump does. As the archive requires the file's size
+// beforehand, the documents are spilled to a temporary file first.
+func dump(ctx context.Context, collection *mongo.Collection, archive *writer) (*Collection, error) {
+	spill, err := os.CreateTemp("", "shellhub-backup-*"+bsonExt)
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(spill.Name())
+	defer spill.Close()
+
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	hash := sha256.New()
+	output := io.MultiWriter(spill, hash)
+
+	result := &Collection{Name: collection.Name()}
+	for cursor.Next(ctx) {
+		if _, err := output.Write(cursor.Current); err != nil {
+			return nil, err
+		}
+
+		result.Documents++
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	result.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	size, err := spill.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := spill.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	if err := archive.file(path.Join(dumpDir, result.Name+bsonExt), size, spill); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// listRecordings lists the sessions' recordings kept on the object storage.
+func listRecordings(ctx context.Context, collection *mongo.Collection) ([]Recording, error) {
+	cursor, err := collection.Find(ctx,
+		bson.M{"record_object": bson.M{"$exists": true, "$ne": ""}},
+		options.Find().SetProjection(bson.M{"uid": 1, "tenant_id": 1, "record_object": 1}).SetSort(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recordings := []Recording{}
+	if err := cursor.All(ctx, &recordings); err != nil {
+		return nil, err
+	}
+
+	return recordings, nil
+}
+
+// Restore restores the backup on filename to the database, returning the backup's manifest and its recordings, which are
+// expected to be on the object storage.
+//
+// Before the database is touched, the backup's integrity is verified and its compatibility is checked against schema,
+// the version of the latest Mongo migration known by the instance. The database must be empty, unless drop is set,
+// when its collections are dropped first.
+func Restore(ctx context.Context, db *mongo.Database, filename string, schema uint64, drop bool) (*Manifest, []Recording, error) {
+	manifest, recordings, err := Inspect(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := manifest.Compatible(schema); err != nil {
+		return nil, nil, err
+	}
+
+	names, err := db.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, name := range names {
+		if strings.HasPrefix(name, "system.") {
+			continue
+		}
+
+		if !drop {
+			return nil, nil, ErrNotEmpty
+		}
+
+		if err := db.Collection(name).Drop(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := read(filename, func(name string, r io.Reader) error {
+		if collection, ok := collectionName(name); ok {
+			return restoreDocuments(ctx, db, collection, r)
+		}
+
+		if collection, ok := metadataName(name); ok {
+			return restoreIndexes(ctx, db, collection, r)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return manifest, recordings, nil
+}
+
+// restoreDocuments creates the collection and inserts the documents dumped on r.
+func restoreDocuments(ctx context.Context, db *mongo.Database, name string, r io.Reader) error {
+	if err := db.CreateCollection(ctx, name); err != nil {
+		return err
+	}
+
+	batch := make([]interface{}, 0, insertBatchSize)
+	insert := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if _, err := db.Collection(name).InsertMany(ctx, batch); err != nil {
+			return err
+		}
+
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for {
+		document, err := readDocument(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, document)
+		if len(batch) == insertBatchSize {
+			if err := insert(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return insert()
+}
+
+// readDocument reads the next BSON document from r, which starts with its length as a little-endian int32.
+func readDocument(r io.Reader) (bson.Raw, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.LittleEndian.Uint32(length[:])
+	if size < 5 {
+		return nil, ErrCorrupted
+	}
+
+	document := make([]byte, size)
+	copy(document, length[:])
+
+	if _, err := io.ReadFull(r, document[4:]); err != nil {
+		return nil, errors.Join(ErrCorrupted, err)
+	}
+
+	return document, nil
+}
+
+// restoreIndexes creates the collection's indexes described on the metadata read from r.
+func restoreIndexes(ctx context.Context, db *mongo.Database, name string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var metadata struct {
+		Indexes []bson.D `bson:"indexes"`
+	}
+
+	if err := bson.UnmarshalExtJSON(data, true, &metadata); err != nil {
+		return errors.Join(ErrCorrupted, err)
+	}
+
+	specs := make([]bson.D, 0, len(metadata.Indexes))
+	for _, index := range metadata.Indexes {
+		spec := bson.D{}
+		primary := false
+		for _, field := range index {
+			switch {
+			case field.Key == "name" && field.Value == "_id_":
+				primary = true
+			case field.Key != "ns":
+				// NOTICE: the namespace, kept by older versions of Mongo, refers to the database backed up.
+				spec = append(spec, field)
+			}
+		}
+
+		// NOTICE: the index on the documents' IDs is created with the collection.
+		if !primary {
+			specs = append(specs, spec)
+		}
+	}
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	return db.RunCommand(ctx, bson.D{{Key: "createIndexes", Value: name}, {Key: "indexes", Value: specs}}).Err()
+}
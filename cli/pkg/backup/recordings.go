@@ -0,0 +1,30 @@
+package backup
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
+)
+
+// Missing returns the recordings that aren't on the object storage, which must be restored from the storage's own
+// backup before the restored sessions are played.
+func Missing(ctx context.Context, storage objectstorage.Storage, recordings []Recording) ([]Recording, error) {
+	missing := []Recording{}
+	for _, recording := range recordings {
+		object, err := storage.GetObject(ctx, recording.Object)
+		if errors.Is(err, objectstorage.ErrObjectNotFound) {
+			missing = append(missing, recording)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		object.Close()
+	}
+
+	return missing, nil
+}
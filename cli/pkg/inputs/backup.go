@@ -0,0 +1,13 @@
+package inputs
+
+// BackupCreate defines the structure for inputs when creating a backup.
+type BackupCreate struct {
+	Path string `validate:"required"`
+}
+
+// BackupRestore defines the structure for inputs when restoring a backup.
+type BackupRestore struct {
+	Path string `validate:"required"`
+	// Drop drops the database's collections before the backup is restored, instead of requiring it to be empty.
+	Drop bool
+}
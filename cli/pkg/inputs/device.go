@@ -0,0 +1,21 @@
+package inputs
+
+// DeviceUIDAudit defines the structure for inputs when auditing the devices' UIDs.
+type DeviceUIDAudit struct {
+	Scheme string
+}
+
+// DeviceSelect defines the structure for inputs when selecting the devices of a bulk operation. The devices selected
+// match all the criteria set, and at least one of the namespace, tag, name or offline-for criteria must be set.
+type DeviceSelect struct {
+	// Namespace is the name of the devices' namespace.
+	Namespace string
+	// Status is the devices' status. When empty, the devices are selected whatever their status.
+	Status string `validate:"omitempty,oneof=accepted pending rejected"`
+	// Tag is a tag the devices must have.
+	Tag string
+	// Name is a glob pattern, like "web-*", the devices' names must match.
+	Name string
+	// OfflineFor is how long the devices must have been offline for, like "90d" or "12h".
+	OfflineFor string
+}
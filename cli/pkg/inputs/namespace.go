@@ -12,3 +12,17 @@ type NamespaceCreate struct {
 type NamespaceDelete struct {
 	Namespace string
 }
+
+// NamespaceQuota defines the structure for inputs when setting the member quota of a namespace.
+type NamespaceQuota struct {
+	Namespace   string `validate:"required"`
+	Members     string `validate:"required,numeric"`
+	Invitations string `validate:"required,numeric"`
+}
+
+// NamespaceDeviceLimits defines the structure for inputs when setting the device limits of a namespace.
+type NamespaceDeviceLimits struct {
+	Namespace      string `validate:"required"`
+	Devices        string `validate:"required,numeric"`
+	PendingDevices string `validate:"required,numeric"`
+}
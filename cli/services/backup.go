@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/migrations"
+	"github.com/shellhub-io/shellhub/cli/pkg/backup"
+	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BackupRestored reports the outcome of a backup's restore.
+type BackupRestored struct {
+	Manifest *backup.Manifest
+	// Verified reports whether the recordings were looked up on the object storage.
+	Verified bool
+	// Missing are the recordings listed by the backup which aren't on the object storage.
+	Missing []backup.Recording
+}
+
+// database returns the store's Mongo database, from where the backups are created.
+func (s *service) database() (*mongo.Database, error) {
+	store, ok := s.store.(interface{ GetDB() *mongo.Database })
+	if !ok {
+		return nil, ErrBackupUnsupported
+	}
+
+	return store.GetDB(), nil
+}
+
+// BackupCreate creates a backup of the instance on the input's path, which must not exist.
+func (s *service) BackupCreate(ctx context.Context, input *inputs.BackupCreate) (*backup.Manifest, error) {
+	if ok, err := s.validator.Struct(input); !ok || err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	db, err := s.database()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(input.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, errors.Join(ErrFailedBackupCreate, err)
+	}
+
+	manifest, err := backup.Create(ctx, db, file, envs.DefaultBackend.Get("SHELLHUB_VERSION"))
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(input.Path)
+
+		return nil, errors.Join(ErrFailedBackupCreate, err)
+	}
+
+	return manifest, nil
+}
+
+// BackupRestore restores the backup on the input's path, after checking its integrity and its compatibility with the
+// instance. When the recordings' object storage is configured, the recordings listed by the backup are looked up on
+// it.
+func (s *service) BackupRestore(ctx context.Context, input *inputs.BackupRestore) (*BackupRestored, error) {
+	if ok, err := s.validator.Struct(input); !ok || err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	db, err := s.database()
+	if err != nil {
+		return nil, err
+	}
+
+	list := migrations.GenerateMigrations()
+
+	manifest, recordings, err := backup.Restore(ctx, db, input.Path, list[len(list)-1].Version, input.Drop)
+	if err != nil {
+		return nil, errors.Join(ErrFailedBackupRestore, err)
+	}
+
+	restored := &BackupRestored{Manifest: manifest}
+	if s.recordings != nil {
+		if restored.Missing, err = backup.Missing(ctx, s.recordings, recordings); err != nil {
+			return nil, errors.Join(ErrFailedBackupRecordings, err)
+		}
+
+		restored.Verified = true
+	}
+
+	return restored, nil
+}
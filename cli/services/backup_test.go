@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupCreate(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description string
+		input       *inputs.BackupCreate
+		expected    error
+	}{
+		{
+			description: "fails when the path is empty",
+			input:       &inputs.BackupCreate{},
+			expected:    ErrInvalidFormat,
+		},
+		{
+			description: "fails when the store isn't on Mongo",
+			input:       &inputs.BackupCreate{Path: "/tmp/shellhub.tar.gz"},
+			expected:    ErrBackupUnsupported,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			s := NewService(store.Store(mock))
+			manifest, err := s.BackupCreate(ctx, tc.input)
+			assert.Nil(t, manifest)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestBackupRestore(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description string
+		input       *inputs.BackupRestore
+		expected    error
+	}{
+		{
+			description: "fails when the path is empty",
+			input:       &inputs.BackupRestore{Drop: true},
+			expected:    ErrInvalidFormat,
+		},
+		{
+			description: "fails when the store isn't on Mongo",
+			input:       &inputs.BackupRestore{Path: "/tmp/shellhub.tar.gz"},
+			expected:    ErrBackupUnsupported,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			s := NewService(store.Store(mock))
+			restored, err := s.BackupRestore(ctx, tc.input)
+			assert.Nil(t, restored)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
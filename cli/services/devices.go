@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/deviceuid"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceUIDAudit audits the UIDs of every device against the scheme, reporting the devices whose identities collide.
+func (s *service) DeviceUIDAudit(ctx context.Context, input *inputs.DeviceUIDAudit) (*deviceuid.Report, error) {
+	scheme, err := deviceuid.ParseScheme(input.Scheme)
+	if err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	devices, err := s.store.DeviceListIdentities(ctx)
+	if err != nil {
+		return nil, ErrFailedDeviceUIDAudit
+	}
+
+	return deviceuid.Audit(scheme, devices), nil
+}
+
+// DeviceBulkResult reports the outcome of a bulk operation on devices.
+type DeviceBulkResult struct {
+	// Changed are the devices changed by the operation.
+	Changed []DeviceBulkEntry `json:"changed"`
+	// Skipped are the devices left unchanged, with the reason.
+	Skipped []DeviceBulkEntry `json:"skipped"`
+}
+
+// DeviceBulkEntry is a device of a bulk operation.
+type DeviceBulkEntry struct {
+	UID      string `json:"uid"`
+	Name     string `json:"name"`
+	TenantID string `json:"tenant_id"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func newDeviceBulkResult() *DeviceBulkResult {
+	return &DeviceBulkResult{Changed: []DeviceBulkEntry{}, Skipped: []DeviceBulkEntry{}}
+}
+
+func (r *DeviceBulkResult) changed(device *models.Device) {
+	r.Changed = append(r.Changed, DeviceBulkEntry{UID: device.UID, Name: device.Name, TenantID: device.TenantID})
+}
+
+func (r *DeviceBulkResult) skipped(device *models.Device, reason string) {
+	r.Skipped = append(r.Skipped, DeviceBulkEntry{UID: device.UID, Name: device.Name, TenantID: device.TenantID, Reason: reason})
+}
+
+// DeviceSelect selects the devices, of every namespace, matching all the criteria of a bulk operation.
+func (s *service) DeviceSelect(ctx context.Context, input *inputs.DeviceSelect) ([]models.Device, error) {
+	if ok, err := s.validator.Struct(input); !ok || err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	if input.Namespace == "" && input.Tag == "" && input.Name == "" && input.OfflineFor == "" {
+		return nil, ErrDeviceSelectEmpty
+	}
+
+	if _, err := path.Match(input.Name, ""); err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	var offlineSince time.Time
+	if input.OfflineFor != "" {
+		age, err := parseAge(input.OfflineFor)
+		if err != nil {
+			return nil, ErrInvalidFormat
+		}
+
+		offlineSince = clock.Now().Add(-age)
+	}
+
+	var tenant string
+	if input.Namespace != "" {
+		ns, err := s.store.NamespaceGetByName(ctx, input.Namespace)
+		if err != nil {
+			return nil, ErrNamespaceNotFound
+		}
+
+		tenant = ns.TenantID
+	}
+
+	paginator := query.Paginator{Page: query.MinPage, PerPage: query.MaxPerPage}
+	sorter := query.Sorter{By: "uid", Order: query.OrderAsc}
+
+	selected := []models.Device{}
+	for {
+		devices, count, err := s.store.DeviceList(ctx, models.DeviceStatus(input.Status), paginator, query.Filters{}, sorter, store.DeviceAcceptableAsFalse)
+		if err != nil {
+			return nil, ErrFailedDeviceSelect
+		}
+
+		for _, device := range devices {
+			if tenant != "" && device.TenantID != tenant {
+				continue
+			}
+
+			if input.Tag != "" && !slices.Contains(device.Tags, input.Tag) {
+				continue
+			}
+
+			if input.Name != "" {
+				if matched, _ := path.Match(input.Name, device.Name); !matched {
+					continue
+				}
+			}
+
+			if input.OfflineFor != "" && (device.Online || !device.LastSeen.Before(offlineSince)) {
+				continue
+			}
+
+			selected = append(selected, device)
+		}
+
+		if len(devices) == 0 || paginator.Page*paginator.PerPage >= count {
+			return selected, nil
+		}
+
+		paginator.Page++
+	}
+}
+
+// parseAge parses a duration like [time.ParseDuration] does, also accepting a number of days, like "90d".
+func parseAge(value string) (time.Duration, error) {
+	var age time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if age, err = time.ParseDuration(value); err != nil {
+			return 0, err
+		}
+	}
+
+	if age <= 0 {
+		return 0, ErrInvalidFormat
+	}
+
+	return age, nil
+}
+
+// namespaces gets the devices' namespaces, with their accepted devices counted, once for each of them.
+type namespaces struct {
+	store store.Store
+	got   map[string]*models.Namespace
+}
+
+func (n *namespaces) get(ctx context.Context, tenant string) (*models.Namespace, error) {
+	if ns, ok := n.got[tenant]; ok {
+		return ns, nil
+	}
+
+	ns, err := n.store.NamespaceGet(ctx, tenant, n.store.Options().CountAcceptedDevices())
+	if err != nil {
+		return nil, err
+	}
+
+	n.got[tenant] = ns
+
+	return ns, nil
+}
+
+func (s *service) namespaces() *namespaces {
+	return &namespaces{store: s.store, got: make(map[string]*models.Namespace)}
+}
+
+// DeviceAccept accepts the devices not accepted yet. A device is skipped when its namespace has reached its maximum
+// number of accepted devices, unless the device is exempt from it, or when another accepted device has its name.
+func (s *service) DeviceAccept(ctx context.Context, devices []models.Device) (*DeviceBulkResult, error) {
+	result := newDeviceBulkResult()
+	namespaces := s.namespaces()
+
+	for _, device := range devices {
+		if device.Status == models.DeviceStatusAccepted {
+			result.skipped(&device, "already accepted")
+
+			continue
+		}
+
+		ns, err := namespaces.get(ctx, device.TenantID)
+		if err != nil {
+			result.skipped(&device, "namespace not found")
+
+			continue
+		}
+
+		if !device.LimitExempt && ns.HasMaxDevices() && ns.HasMaxDevicesReached() {
+			result.skipped(&device, "namespace's device limit reached")
+
+			continue
+		}
+
+		if same, err := s.store.DeviceGetByName(ctx, device.Name, device.TenantID, models.DeviceStatusAccepted); same != nil {
+			result.skipped(&device, "name used by an accepted device")
+
+			continue
+		} else if err != nil && !errors.Is(err, store.ErrNoDocuments) {
+			return result, err
+		}
+
+		if err := s.store.DeviceUpdateStatus(ctx, models.UID(device.UID), models.DeviceStatusAccepted); err != nil {
+			return result, err
+		}
+
+		// NOTICE: the namespace is counted once, so the devices accepted are counted here.
+		ns.DevicesCount++
+		if device.LimitExempt {
+			ns.LimitExemptDevicesCount++
+		}
+
+		result.changed(&device)
+	}
+
+	return result, nil
+}
+
+// DeviceReject rejects the devices not accepted. The accepted devices must be deleted instead.
+func (s *service) DeviceReject(ctx context.Context, devices []models.Device) (*DeviceBulkResult, error) {
+	result := newDeviceBulkResult()
+
+	for _, device := range devices {
+		switch device.Status {
+		case models.DeviceStatusAccepted:
+			result.skipped(&device, "accepted devices cannot be rejected")
+
+			continue
+		case models.DeviceStatusRejected:
+			result.skipped(&device, "already rejected")
+
+			continue
+		}
+
+		if err := s.store.DeviceUpdateStatus(ctx, models.UID(device.UID), models.DeviceStatusRejected); err != nil {
+			return result, err
+		}
+
+		result.changed(&device)
+	}
+
+	return result, nil
+}
+
+// DeviceDelete deletes the devices with their sessions. On cloud, the devices of the namespaces without an active
+// billing are kept as removed, so they keep counting towards the namespace's limit, like when they are deleted on the
+// API.
+func (s *service) DeviceDelete(ctx context.Context, devices []models.Device) (*DeviceBulkResult, error) {
+	result := newDeviceBulkResult()
+	namespaces := s.namespaces()
+
+	for _, device := range devices {
+		ns, err := namespaces.get(ctx, device.TenantID)
+		if err != nil {
+			result.skipped(&device, "namespace not found")
+
+			continue
+		}
+
+		if envs.IsCloud() && envs.HasBilling() && !ns.Billing.IsActive() && !device.LimitExempt {
+			if err := s.store.DeviceRemovedInsert(ctx, device.TenantID, &device); err != nil {
+				return result, err
+			}
+		}
+
+		if err := s.store.DeviceDelete(ctx, models.UID(device.UID)); err != nil {
+			return result, err
+		}
+
+		result.changed(&device)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/deviceuid"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	env_mocks "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestDeviceUIDAudit(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		report *deviceuid.Report
+		err    error
+	}
+
+	auth := models.DeviceAuth{
+		Identity:  &models.DeviceIdentity{MAC: "00:00:00:00:00:01"},
+		PublicKey: "public-key",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+	}
+
+	devices := []models.Device{
+		{UID: deviceuid.Derive(deviceuid.SchemeLegacy, auth), Identity: auth.Identity, PublicKey: auth.PublicKey, TenantID: auth.TenantID},
+	}
+
+	cases := []struct {
+		description   string
+		input         *inputs.DeviceUIDAudit
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the scheme is invalid",
+			input:         &inputs.DeviceUIDAudit{Scheme: "v3"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, ErrInvalidFormat},
+		},
+		{
+			description: "fails when the devices cannot be listed",
+			input:       &inputs.DeviceUIDAudit{Scheme: "v2"},
+			requiredMocks: func() {
+				mock.On("DeviceListIdentities", ctx).Return(nil, errors.New("error")).Once()
+			},
+			expected: Expected{nil, ErrFailedDeviceUIDAudit},
+		},
+		{
+			description: "success to audit the device UIDs",
+			input:       &inputs.DeviceUIDAudit{Scheme: "v2"},
+			requiredMocks: func() {
+				mock.On("DeviceListIdentities", ctx).Return(devices, nil).Once()
+			},
+			expected: Expected{deviceuid.Audit(deviceuid.SchemeV2, devices), nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			s := NewService(store.Store(mock))
+			report, err := s.DeviceUIDAudit(ctx, tc.input)
+			assert.Equal(t, tc.expected, Expected{report, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeviceSelect(t *testing.T) {
+	mock := new(mocks.Store)
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	mockClock := new(clockmock.Clock)
+	clock.DefaultBackend = mockClock
+	mockClock.On("Now").Return(now)
+
+	ctx := context.TODO()
+
+	devices := []models.Device{
+		{UID: "1", Name: "web-1", TenantID: "00000000-0000-4000-0000-000000000000", Tags: []string{"edge"}, LastSeen: now.Add(-100 * 24 * time.Hour)},
+		{UID: "2", Name: "web-2", TenantID: "00000000-0000-4000-0000-000000000000", LastSeen: now.Add(-100 * 24 * time.Hour)},
+		{UID: "3", Name: "db-1", TenantID: "00000000-0000-4000-0000-000000000000", Tags: []string{"edge"}, LastSeen: now.Add(-time.Hour)},
+		{UID: "4", Name: "web-3", TenantID: "00000000-0000-4000-0000-000000000001", Tags: []string{"edge"}, LastSeen: now.Add(-100 * 24 * time.Hour), Online: true},
+	}
+
+	paginator := query.Paginator{Page: query.MinPage, PerPage: query.MaxPerPage}
+	sorter := query.Sorter{By: "uid", Order: query.OrderAsc}
+
+	type Expected struct {
+		devices []models.Device
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		input         *inputs.DeviceSelect
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when no criteria is set",
+			input:         &inputs.DeviceSelect{Status: "pending"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, ErrDeviceSelectEmpty},
+		},
+		{
+			description:   "fails when the status is invalid",
+			input:         &inputs.DeviceSelect{Status: "removed", Tag: "edge"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, ErrInvalidFormat},
+		},
+		{
+			description:   "fails when the age is invalid",
+			input:         &inputs.DeviceSelect{OfflineFor: "90 days"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, ErrInvalidFormat},
+		},
+		{
+			description:   "fails when the name pattern is invalid",
+			input:         &inputs.DeviceSelect{Name: "web-["},
+			requiredMocks: func() {},
+			expected:      Expected{nil, ErrInvalidFormat},
+		},
+		{
+			description: "fails when the namespace is not found",
+			input:       &inputs.DeviceSelect{Namespace: "dev"},
+			requiredMocks: func() {
+				mock.On("NamespaceGetByName", ctx, "dev").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, ErrNamespaceNotFound},
+		},
+		{
+			description: "fails when the devices cannot be listed",
+			input:       &inputs.DeviceSelect{Tag: "edge"},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, models.DeviceStatus(""), paginator, query.Filters{}, sorter, store.DeviceAcceptableAsFalse).
+					Return(nil, 0, errors.New("error")).Once()
+			},
+			expected: Expected{nil, ErrFailedDeviceSelect},
+		},
+		{
+			description: "succeeds selecting the devices matching all the criteria",
+			input:       &inputs.DeviceSelect{Namespace: "dev", Status: "pending", Tag: "edge", Name: "web-*", OfflineFor: "90d"},
+			requiredMocks: func() {
+				mock.On("NamespaceGetByName", ctx, "dev").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"}, nil).Once()
+				mock.On("DeviceList", ctx, models.DeviceStatusPending, paginator, query.Filters{}, sorter, store.DeviceAcceptableAsFalse).
+					Return(devices, len(devices), nil).Once()
+			},
+			expected: Expected{[]models.Device{devices[0]}, nil},
+		},
+		{
+			description: "succeeds selecting the offline devices of every namespace",
+			input:       &inputs.DeviceSelect{OfflineFor: "2160h"},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, models.DeviceStatus(""), paginator, query.Filters{}, sorter, store.DeviceAcceptableAsFalse).
+					Return(devices, len(devices), nil).Once()
+			},
+			expected: Expected{[]models.Device{devices[0], devices[1]}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			s := NewService(store.Store(mock))
+			selected, err := s.DeviceSelect(ctx, tc.input)
+			assert.Equal(t, tc.expected, Expected{selected, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeviceAccept(t *testing.T) {
+	mock := new(mocks.Store)
+	queryOptionsMock := new(mocks.QueryOptions)
+	mock.On("Options").Return(queryOptionsMock)
+	queryOptionsMock.On("CountAcceptedDevices").Return(nil)
+
+	ctx := context.TODO()
+
+	devices := []models.Device{
+		{UID: "1", Name: "web-1", TenantID: "00000000-0000-4000-0000-000000000000", Status: models.DeviceStatusAccepted},
+		{UID: "2", Name: "web-2", TenantID: "00000000-0000-4000-0000-000000000000", Status: models.DeviceStatusPending},
+		{UID: "3", Name: "web-3", TenantID: "00000000-0000-4000-0000-000000000000", Status: models.DeviceStatusPending},
+		{UID: "4", Name: "web-4", TenantID: "00000000-0000-4000-0000-000000000000", Status: models.DeviceStatusPending},
+		{UID: "5", Name: "web-5", TenantID: "00000000-0000-4000-0000-000000000000", Status: models.DeviceStatusPending, LimitExempt: true},
+	}
+
+	mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000", testifymock.AnythingOfType("store.NamespaceQueryOption")).
+		Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", MaxDevices: 3, DevicesCount: 1}, nil).Once()
+	mock.On("DeviceGetByName", ctx, "web-2", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+		Return(&models.Device{UID: "6", Name: "web-2"}, nil).Once()
+	mock.On("DeviceGetByName", ctx, "web-3", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("DeviceUpdateStatus", ctx, models.UID("3"), models.DeviceStatusAccepted).Return(nil).Once()
+	mock.On("DeviceGetByName", ctx, "web-4", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("DeviceUpdateStatus", ctx, models.UID("4"), models.DeviceStatusAccepted).Return(nil).Once()
+	mock.On("DeviceGetByName", ctx, "web-5", "00000000-0000-4000-0000-000000000000", models.DeviceStatusAccepted).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("DeviceUpdateStatus", ctx, models.UID("5"), models.DeviceStatusAccepted).Return(nil).Once()
+
+	devices = append(devices, models.Device{UID: "7", Name: "web-7", TenantID: "00000000-0000-4000-0000-000000000000", Status: models.DeviceStatusPending})
+
+	s := NewService(store.Store(mock))
+	result, err := s.DeviceAccept(ctx, devices)
+	assert.NoError(t, err)
+	assert.Equal(t, &DeviceBulkResult{
+		Changed: []DeviceBulkEntry{
+			{UID: "3", Name: "web-3", TenantID: "00000000-0000-4000-0000-000000000000"},
+			{UID: "4", Name: "web-4", TenantID: "00000000-0000-4000-0000-000000000000"},
+			{UID: "5", Name: "web-5", TenantID: "00000000-0000-4000-0000-000000000000"},
+		},
+		Skipped: []DeviceBulkEntry{
+			{UID: "1", Name: "web-1", TenantID: "00000000-0000-4000-0000-000000000000", Reason: "already accepted"},
+			{UID: "2", Name: "web-2", TenantID: "00000000-0000-4000-0000-000000000000", Reason: "name used by an accepted device"},
+			{UID: "7", Name: "web-7", TenantID: "00000000-0000-4000-0000-000000000000", Reason: "namespace's device limit reached"},
+		},
+	}, result)
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeviceReject(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	devices := []models.Device{
+		{UID: "1", Name: "web-1", Status: models.DeviceStatusAccepted},
+		{UID: "2", Name: "web-2", Status: models.DeviceStatusRejected},
+		{UID: "3", Name: "web-3", Status: models.DeviceStatusPending},
+		{UID: "4", Name: "web-4", Status: models.DeviceStatusPending},
+	}
+
+	mock.On("DeviceUpdateStatus", ctx, models.UID("3"), models.DeviceStatusRejected).Return(nil).Once()
+	mock.On("DeviceUpdateStatus", ctx, models.UID("4"), models.DeviceStatusRejected).Return(errors.New("error")).Once()
+
+	s := NewService(store.Store(mock))
+	result, err := s.DeviceReject(ctx, devices)
+	assert.EqualError(t, err, "error")
+	assert.Equal(t, &DeviceBulkResult{
+		Changed: []DeviceBulkEntry{{UID: "3", Name: "web-3"}},
+		Skipped: []DeviceBulkEntry{
+			{UID: "1", Name: "web-1", Reason: "accepted devices cannot be rejected"},
+			{UID: "2", Name: "web-2", Reason: "already rejected"},
+		},
+	}, result)
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeviceDelete(t *testing.T) {
+	mock := new(mocks.Store)
+	queryOptionsMock := new(mocks.QueryOptions)
+	mock.On("Options").Return(queryOptionsMock)
+	queryOptionsMock.On("CountAcceptedDevices").Return(nil)
+
+	envMock := new(env_mocks.Backend)
+	envs.DefaultBackend = envMock
+	envMock.On("Get", "SHELLHUB_CLOUD").Return("true")
+	envMock.On("Get", "SHELLHUB_BILLING").Return("true")
+
+	ctx := context.TODO()
+
+	devices := []models.Device{
+		{UID: "1", Name: "web-1", TenantID: "00000000-0000-4000-0000-000000000000"},
+		{UID: "2", Name: "web-2", TenantID: "00000000-0000-4000-0000-000000000000", LimitExempt: true},
+		{UID: "3", Name: "web-3", TenantID: "00000000-0000-4000-0000-000000000001"},
+	}
+
+	mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000", testifymock.AnythingOfType("store.NamespaceQueryOption")).
+		Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000"}, nil).Once()
+	mock.On("DeviceRemovedInsert", ctx, "00000000-0000-4000-0000-000000000000", &devices[0]).Return(nil).Once()
+	mock.On("DeviceDelete", ctx, models.UID("1")).Return(nil).Once()
+	mock.On("DeviceDelete", ctx, models.UID("2")).Return(nil).Once()
+	mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000001", testifymock.AnythingOfType("store.NamespaceQueryOption")).
+		Return(nil, store.ErrNoDocuments).Once()
+
+	s := NewService(store.Store(mock))
+	result, err := s.DeviceDelete(ctx, devices)
+	assert.NoError(t, err)
+	assert.Equal(t, &DeviceBulkResult{
+		Changed: []DeviceBulkEntry{
+			{UID: "1", Name: "web-1", TenantID: "00000000-0000-4000-0000-000000000000"},
+			{UID: "2", Name: "web-2", TenantID: "00000000-0000-4000-0000-000000000000"},
+		},
+		Skipped: []DeviceBulkEntry{
+			{UID: "3", Name: "web-3", TenantID: "00000000-0000-4000-0000-000000000001", Reason: "namespace not found"},
+		},
+	}, result)
+
+	mock.AssertExpectations(t)
+}
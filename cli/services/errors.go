@@ -22,4 +22,17 @@ var (
 	ErrNamespaceInvalid            = errors.New("namespace is invalid")
 	ErrFailedNamespaceAddMember    = errors.New("could not add this member to this namespace")
 	ErrUserUnhandledDuplicate      = errors.New("unhandled duplicated field for the user")
+	ErrFailedNamespaceQuota        = errors.New("failed to set the namespace quota")
+	ErrFailedNamespaceDeviceLimits = errors.New("failed to set the namespace device limits")
+	ErrFailedDeviceUIDAudit        = errors.New("failed to audit the device UIDs")
+	ErrDeviceSelectEmpty           = errors.New("at least one of the namespace, tag, name or offline-for criteria is required")
+	ErrFailedDeviceSelect          = errors.New("failed to select the devices")
+	ErrBackupUnsupported           = errors.New("backups are only supported on Mongo")
+	ErrFailedBackupCreate          = errors.New("failed to create the backup")
+	ErrFailedBackupRestore         = errors.New("failed to restore the backup")
+	ErrFailedBackupRecordings      = errors.New("failed to look up the backup's recordings on the object storage")
+	ErrFieldsEncryptionDisabled    = errors.New("the field encryption key isn't set")
+	ErrFieldsRekeyUnsupported      = errors.New("the store doesn't support rekeying the encrypted fields")
+	ErrFailedFieldsRekey           = errors.New("failed to rekey the encrypted fields")
+	ErrUserVerificationDisabled    = errors.New("the email verification mailer isn't configured")
 )
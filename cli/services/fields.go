@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"errors"
+)
+
+// FieldsRekey encrypts again the personal data fields with the current field encryption key. It is run after the
+// encryption is enabled, to encrypt the fields stored in plain text, and after the key is rotated, before the previous
+// key is removed.
+func (s *service) FieldsRekey(ctx context.Context) (int64, error) {
+	if s.cipher == nil {
+		return 0, ErrFieldsEncryptionDisabled
+	}
+
+	store, ok := s.store.(interface {
+		FieldsRekey(ctx context.Context) (int64, error)
+	})
+	if !ok {
+		return 0, ErrFieldsRekeyUnsupported
+	}
+
+	rekeyed, err := store.FieldsRekey(ctx)
+	if err != nil {
+		return rekeyed, errors.Join(ErrFailedFieldsRekey, err)
+	}
+
+	return rekeyed, nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldsRekey(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cipher, err := fieldcrypt.New(bytes.Repeat([]byte{1}, fieldcrypt.KeySize))
+	require.NoError(t, err)
+
+	cases := []struct {
+		description string
+		cipher      *fieldcrypt.Cipher
+		expected    error
+	}{
+		{
+			description: "fails when the field encryption key isn't set",
+			cipher:      nil,
+			expected:    ErrFieldsEncryptionDisabled,
+		},
+		{
+			description: "fails when the store doesn't support rekeying",
+			cipher:      cipher,
+			expected:    ErrFieldsRekeyUnsupported,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			s := NewService(store.Store(mock), WithFieldsCipher(tc.cipher))
+			rekeyed, err := s.FieldsRekey(ctx)
+			assert.Equal(t, int64(0), rekeyed)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
@@ -126,3 +127,67 @@ func (s *service) NamespaceDelete(ctx context.Context, input *inputs.NamespaceDe
 
 	return nil
 }
+
+// NamespaceQuota sets the maximum number of members and pending invitations of a namespace, overriding the instance's
+// defaults. Zero restores the default and a negative value removes the limit.
+func (s *service) NamespaceQuota(ctx context.Context, input *inputs.NamespaceQuota) (*models.Namespace, error) {
+	if ok, err := s.validator.Struct(input); !ok || err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	members, err := strconv.Atoi(input.Members)
+	if err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	invitations, err := strconv.Atoi(input.Invitations)
+	if err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	ns, err := s.store.NamespaceGetByName(ctx, input.Namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	if err := s.store.NamespaceEdit(ctx, ns.TenantID, &models.NamespaceChanges{MaxMembers: &members, MaxInvitations: &invitations}); err != nil {
+		return nil, ErrFailedNamespaceQuota
+	}
+
+	ns.MaxMembers = members
+	ns.MaxInvitations = invitations
+
+	return ns, nil
+}
+
+// NamespaceDeviceLimits sets the maximum number of accepted devices and of devices waiting for acceptance of a
+// namespace, regardless of its billing. A value lower or equal to zero removes the limit.
+func (s *service) NamespaceDeviceLimits(ctx context.Context, input *inputs.NamespaceDeviceLimits) (*models.Namespace, error) {
+	if ok, err := s.validator.Struct(input); !ok || err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	devices, err := strconv.Atoi(input.Devices)
+	if err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	pending, err := strconv.Atoi(input.PendingDevices)
+	if err != nil {
+		return nil, ErrInvalidFormat
+	}
+
+	ns, err := s.store.NamespaceGetByName(ctx, input.Namespace)
+	if err != nil {
+		return nil, ErrNamespaceNotFound
+	}
+
+	if err := s.store.NamespaceEdit(ctx, ns.TenantID, &models.NamespaceChanges{MaxDevices: &devices, MaxPendingDevices: &pending}); err != nil {
+		return nil, ErrFailedNamespaceDeviceLimits
+	}
+
+	ns.MaxDevices = devices
+	ns.MaxPendingDevices = pending
+
+	return ns, nil
+}
@@ -754,3 +754,143 @@ func TestNamespaceDelete(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestNamespaceQuota(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		namespace *models.Namespace
+		err       error
+	}
+
+	cases := []struct {
+		description   string
+		input         *inputs.NamespaceQuota
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the quota is invalid",
+			input:         &inputs.NamespaceQuota{Namespace: "namespace", Members: "ten", Invitations: "5"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, ErrInvalidFormat},
+		},
+		{
+			description: "fails when could not find a namespace",
+			input:       &inputs.NamespaceQuota{Namespace: "namespace", Members: "10", Invitations: "5"},
+			requiredMocks: func() {
+				mock.On("NamespaceGetByName", ctx, "namespace").Return(nil, errors.New("error")).Once()
+			},
+			expected: Expected{nil, ErrNamespaceNotFound},
+		},
+		{
+			description: "fails to set the namespace quota",
+			input:       &inputs.NamespaceQuota{Namespace: "namespace", Members: "10", Invitations: "5"},
+			requiredMocks: func() {
+				members, invitations := 10, 5
+
+				mock.On("NamespaceGetByName", ctx, "namespace").Return(&models.Namespace{Name: "namespace", TenantID: "00000000-0000-0000-0000-000000000000"}, nil).Once()
+				mock.On("NamespaceEdit", ctx, "00000000-0000-0000-0000-000000000000", &models.NamespaceChanges{MaxMembers: &members, MaxInvitations: &invitations}).Return(errors.New("error")).Once()
+			},
+			expected: Expected{nil, ErrFailedNamespaceQuota},
+		},
+		{
+			description: "success to set the namespace quota",
+			input:       &inputs.NamespaceQuota{Namespace: "namespace", Members: "10", Invitations: "-1"},
+			requiredMocks: func() {
+				members, invitations := 10, -1
+
+				mock.On("NamespaceGetByName", ctx, "namespace").Return(&models.Namespace{Name: "namespace", TenantID: "00000000-0000-0000-0000-000000000000"}, nil).Once()
+				mock.On("NamespaceEdit", ctx, "00000000-0000-0000-0000-000000000000", &models.NamespaceChanges{MaxMembers: &members, MaxInvitations: &invitations}).Return(nil).Once()
+			},
+			expected: Expected{
+				&models.Namespace{Name: "namespace", TenantID: "00000000-0000-0000-0000-000000000000", MaxMembers: 10, MaxInvitations: -1},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			s := NewService(store.Store(mock))
+			ns, err := s.NamespaceQuota(ctx, tc.input)
+			assert.Equal(t, tc.expected, Expected{ns, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestNamespaceDeviceLimits(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		namespace *models.Namespace
+		err       error
+	}
+
+	cases := []struct {
+		description   string
+		input         *inputs.NamespaceDeviceLimits
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the limit is invalid",
+			input:         &inputs.NamespaceDeviceLimits{Namespace: "namespace", Devices: "ten", PendingDevices: "5"},
+			requiredMocks: func() {},
+			expected:      Expected{nil, ErrInvalidFormat},
+		},
+		{
+			description: "fails when could not find a namespace",
+			input:       &inputs.NamespaceDeviceLimits{Namespace: "namespace", Devices: "10", PendingDevices: "5"},
+			requiredMocks: func() {
+				mock.On("NamespaceGetByName", ctx, "namespace").Return(nil, errors.New("error")).Once()
+			},
+			expected: Expected{nil, ErrNamespaceNotFound},
+		},
+		{
+			description: "fails to set the namespace device limits",
+			input:       &inputs.NamespaceDeviceLimits{Namespace: "namespace", Devices: "10", PendingDevices: "5"},
+			requiredMocks: func() {
+				devices, pending := 10, 5
+
+				mock.On("NamespaceGetByName", ctx, "namespace").Return(&models.Namespace{Name: "namespace", TenantID: "00000000-0000-0000-0000-000000000000"}, nil).Once()
+				mock.On("NamespaceEdit", ctx, "00000000-0000-0000-0000-000000000000", &models.NamespaceChanges{MaxDevices: &devices, MaxPendingDevices: &pending}).Return(errors.New("error")).Once()
+			},
+			expected: Expected{nil, ErrFailedNamespaceDeviceLimits},
+		},
+		{
+			description: "success to set the namespace device limits",
+			input:       &inputs.NamespaceDeviceLimits{Namespace: "namespace", Devices: "10", PendingDevices: "-1"},
+			requiredMocks: func() {
+				devices, pending := 10, -1
+
+				mock.On("NamespaceGetByName", ctx, "namespace").Return(&models.Namespace{Name: "namespace", TenantID: "00000000-0000-0000-0000-000000000000"}, nil).Once()
+				mock.On("NamespaceEdit", ctx, "00000000-0000-0000-0000-000000000000", &models.NamespaceChanges{MaxDevices: &devices, MaxPendingDevices: &pending}).Return(nil).Once()
+			},
+			expected: Expected{
+				&models.Namespace{Name: "namespace", TenantID: "00000000-0000-0000-0000-000000000000", MaxDevices: 10, MaxPendingDevices: -1},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			s := NewService(store.Store(mock))
+			ns, err := s.NamespaceDeviceLimits(ctx, tc.input)
+			assert.Equal(t, tc.expected, Expected{ns, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -2,10 +2,16 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"github.com/shellhub-io/shellhub/api/pkg/deviceuid"
 	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/cli/pkg/backup"
 	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	"github.com/shellhub-io/shellhub/pkg/fieldcrypt"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 )
 
@@ -17,7 +23,8 @@ const (
 
 type Services interface {
 	// UserCreate adds a new user based on the provided user's data. This method validates data and
-	// checks for conflicts.
+	// checks for conflicts. When the email verification is enabled, the user is created not confirmed and the
+	// verification email is sent to it.
 	UserCreate(ctx context.Context, input *inputs.UserCreate) (*models.User, error)
 	// UserDelete removes a user and cleans up related data based on the provided username.
 	UserDelete(ctx context.Context, input *inputs.UserDelete) error
@@ -29,10 +36,35 @@ type Services interface {
 	NamespaceCreate(ctx context.Context, input *inputs.NamespaceCreate) (*models.Namespace, error)
 	// NamespaceDelete deletes a namespace based on the provided namespace name.
 	NamespaceDelete(ctx context.Context, input *inputs.NamespaceDelete) error
+	// NamespaceQuota sets the maximum number of members and pending invitations of a namespace, overriding the
+	// instance's defaults. Zero restores the default and a negative value removes the limit.
+	NamespaceQuota(ctx context.Context, input *inputs.NamespaceQuota) (*models.Namespace, error)
+	// NamespaceDeviceLimits sets the maximum number of accepted devices and of devices waiting for acceptance of a
+	// namespace, regardless of its billing. A value lower or equal to zero removes the limit.
+	NamespaceDeviceLimits(ctx context.Context, input *inputs.NamespaceDeviceLimits) (*models.Namespace, error)
 	// NamespaceAddMember adds a new member with a specified role to a namespace.
 	NamespaceAddMember(ctx context.Context, input *inputs.MemberAdd) (*models.Namespace, error)
 	// NamespaceRemoveMember removes a member from a namespace.
 	NamespaceRemoveMember(ctx context.Context, input *inputs.MemberRemove) (*models.Namespace, error)
+	// DeviceUIDAudit audits the UIDs of every device against a scheme, reporting the ones derived on it, the legacy
+	// ones, the ones not derived from their identities and the ones whose identities collide.
+	DeviceUIDAudit(ctx context.Context, input *inputs.DeviceUIDAudit) (*deviceuid.Report, error)
+	// DeviceSelect selects the devices, of every namespace, matching all the criteria of a bulk operation.
+	DeviceSelect(ctx context.Context, input *inputs.DeviceSelect) ([]models.Device, error)
+	// DeviceAccept accepts the devices not accepted yet, respecting their namespaces' device limits and names.
+	DeviceAccept(ctx context.Context, devices []models.Device) (*DeviceBulkResult, error)
+	// DeviceReject rejects the devices not accepted.
+	DeviceReject(ctx context.Context, devices []models.Device) (*DeviceBulkResult, error)
+	// DeviceDelete deletes the devices with their sessions.
+	DeviceDelete(ctx context.Context, devices []models.Device) (*DeviceBulkResult, error)
+	// BackupCreate creates a backup of the instance: a consistent snapshot of its database, tagged with its version,
+	// and the manifest of the recordings kept on the object storage.
+	BackupCreate(ctx context.Context, input *inputs.BackupCreate) (*backup.Manifest, error)
+	// BackupRestore restores a backup on the instance, checking its integrity and its compatibility beforehand.
+	BackupRestore(ctx context.Context, input *inputs.BackupRestore) (*BackupRestored, error)
+	// FieldsRekey encrypts again, with the current field encryption key, the personal data fields stored in plain text
+	// or encrypted with a previous key, returning how many fields were encrypted again.
+	FieldsRekey(ctx context.Context) (int64, error)
 }
 
 // service is an internal struct that implements the Services interface.
@@ -40,9 +72,58 @@ type Services interface {
 type service struct {
 	store     store.Store
 	validator *validator.Validator
+	// recordings is the object storage where the SSH server keeps the sessions' recordings. It is nil when it isn't
+	// configured.
+	recordings objectstorage.Storage
+	// verification holds the settings used to send the verification email to the users created not confirmed. It is
+	// nil when the email verification is disabled.
+	verification *emailVerification
+	// cipher encrypts the personal data fields on the store. It is nil when their encryption isn't configured.
+	cipher *fieldcrypt.Cipher
+}
+
+// emailVerification holds the settings used by the email verification flow.
+type emailVerification struct {
+	// mailer delivers the verification emails.
+	mailer mailer.Mailer
+	// url is the base address used to build the verification link sent to users.
+	url string
+	// ttl is how long a verification token remains valid.
+	ttl time.Duration
+}
+
+// Option configures the service.
+type Option func(*service)
+
+// WithRecordingStorage sets the object storage where the SSH server keeps the sessions' recordings, where the
+// recordings of a restored backup are looked up.
+func WithRecordingStorage(storage objectstorage.Storage) Option {
+	return func(s *service) {
+		s.recordings = storage
+	}
+}
+
+// WithEmailVerification sets the mailer used to send the verification email to the users created not confirmed, the
+// base URL used to build its link and how long its token remains valid.
+func WithEmailVerification(mailer mailer.Mailer, url string, ttl time.Duration) Option {
+	return func(s *service) {
+		s.verification = &emailVerification{mailer: mailer, url: url, ttl: ttl}
+	}
+}
+
+// WithFieldsCipher sets the cipher the store encrypts the personal data fields with.
+func WithFieldsCipher(cipher *fieldcrypt.Cipher) Option {
+	return func(s *service) {
+		s.cipher = cipher
+	}
 }
 
 // NewService creates and returns a new instance of the service with the provided store.
-func NewService(store store.Store) Services {
-	return &service{store, validator.New()}
+func NewService(store store.Store, opts ...Option) Services {
+	s := &service{store: store, validator: validator.New()}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
@@ -6,11 +6,15 @@ import (
 
 	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
 	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
 )
 
 // UserCreate adds a new user based on the provided user's data. This method validates data and
-// checks for conflicts.
+// checks for conflicts. When the email verification is enabled, the user is created not confirmed and the
+// verification email is sent to it.
 func (s *service) UserCreate(ctx context.Context, input *inputs.UserCreate) (*models.User, error) {
 	// TODO: convert username and email to lower case.
 	userData := models.UserData{
@@ -54,7 +58,7 @@ func (s *service) UserCreate(ctx context.Context, input *inputs.UserCreate) (*mo
 		Origin:        models.UserOriginLocal,
 		UserData:      userData,
 		Password:      password,
-		Status:        models.UserStatusConfirmed,
+		Status:        getUserStatus(),
 		CreatedAt:     clock.Now(),
 		MaxNamespaces: MaxNumberNamespacesCommunity,
 		Preferences: models.UserPreferences{
@@ -62,15 +66,45 @@ func (s *service) UserCreate(ctx context.Context, input *inputs.UserCreate) (*mo
 		},
 	}
 
-	if _, err := s.store.UserCreate(ctx, user); err != nil {
+	id, err := s.store.UserCreate(ctx, user)
+	if err != nil {
 		return nil, ErrCreateNewUser
 	}
 
+	// NOTICE: the user is already created when the verification email fails, so it is only logged, as the user can
+	// request a new one.
+	if user.Status == models.UserStatusNotConfirmed {
+		if err := s.sendUserVerification(ctx, id, user); err != nil {
+			log.WithError(err).WithField("username", user.Username).Warn("failed to send the email verification")
+		}
+	}
+
 	s.store.SystemSet(ctx, "setup", true) //nolint:errcheck
 
 	return user, nil
 }
 
+// sendUserVerification saves a new verification token for the user with the specified ID and sends it to the user's
+// email, as the API does when the user requests it again.
+func (s *service) sendUserVerification(ctx context.Context, id string, user *models.User) error {
+	if s.verification == nil {
+		return ErrUserVerificationDisabled
+	}
+
+	token := uuid.Generate()
+	now := clock.Now()
+	if err := s.store.UserVerificationSave(ctx, &models.UserVerification{
+		UserID:    id,
+		Digest:    models.UserVerificationDigest(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.verification.ttl),
+	}); err != nil {
+		return err
+	}
+
+	return s.verification.mailer.Send(ctx, mailer.NewVerificationMessage(s.verification.url, user.Name, user.Email, token, s.verification.ttl))
+}
+
 // UserDelete removes a user and cleans up related data based on the provided username.
 func (s *service) UserDelete(ctx context.Context, input *inputs.UserDelete) error {
 	if ok, err := s.validator.Struct(input); !ok || err != nil {
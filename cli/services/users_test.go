@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
@@ -11,9 +12,15 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	env_mocks "github.com/shellhub-io/shellhub/pkg/envs/mocks"
 	"github.com/shellhub-io/shellhub/pkg/hash"
 	hashmock "github.com/shellhub-io/shellhub/pkg/hash/mocks"
+	"github.com/shellhub-io/shellhub/pkg/mailer"
+	mailermock "github.com/shellhub-io/shellhub/pkg/mailer/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuidmock "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,6 +40,14 @@ func TestUserCreate(t *testing.T) {
 	clock.DefaultBackend = mockClock
 	mockClock.On("Now").Return(now)
 
+	envMock := &env_mocks.Backend{}
+	envs.DefaultBackend = envMock
+
+	mailerMock := new(mailermock.Mailer)
+
+	uuidMock := new(uuidmock.Uuid)
+	uuid.DefaultBackend = uuidMock
+
 	cases := []struct {
 		description   string
 		requiredMocks func()
@@ -129,6 +144,10 @@ func TestUserCreate(t *testing.T) {
 					On("Do", "secret").
 					Return("$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YVVCIa2UYuFV4OJby7Yi", nil).
 					Once()
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("false").
+					Once()
 
 				user := &models.User{
 					Origin: models.UserOriginLocal,
@@ -166,6 +185,10 @@ func TestUserCreate(t *testing.T) {
 					On("Do", "secret").
 					Return("$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YVVCIa2UYuFV4OJby7Yi", nil).
 					Once()
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("false").
+					Once()
 
 				user := &models.User{
 					Origin: models.UserOriginLocal,
@@ -207,13 +230,159 @@ func TestUserCreate(t *testing.T) {
 				},
 			}, nil},
 		},
+		{
+			description: "successfully creates a not confirmed user and sends the verification email when email verification is enabled",
+			username:    "john_doe",
+			email:       "john.doe@test.com",
+			password:    "secret",
+			requiredMocks: func() {
+				mock.
+					On("UserConflicts", ctx, &models.UserConflicts{Username: "john_doe", Email: "john.doe@test.com"}).
+					Return([]string{}, false, nil).
+					Once()
+				hashMock.
+					On("Do", "secret").
+					Return("$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YVVCIa2UYuFV4OJby7Yi", nil).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+
+				user := &models.User{
+					Origin: models.UserOriginLocal,
+					UserData: models.UserData{
+						Name:     "john_doe",
+						Email:    "john.doe@test.com",
+						Username: "john_doe",
+					},
+					Password: models.UserPassword{
+						Plain: "secret",
+						Hash:  "$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YVVCIa2UYuFV4OJby7Yi",
+					},
+					Status:        models.UserStatusNotConfirmed,
+					CreatedAt:     clock.Now(),
+					MaxNamespaces: MaxNumberNamespacesCommunity,
+					Preferences: models.UserPreferences{
+						AuthMethods: []models.UserAuthMethod{models.UserAuthMethodLocal},
+					},
+				}
+				mock.On("UserCreate", ctx, user).Return("000000000000000000000000", nil).Once()
+				uuidMock.On("Generate").Return("ffffffff-ffff-4fff-ffff-ffffffffffff").Once()
+				mock.
+					On("UserVerificationSave", ctx, &models.UserVerification{
+						UserID:    "000000000000000000000000",
+						Digest:    models.UserVerificationDigest("ffffffff-ffff-4fff-ffff-ffffffffffff"),
+						CreatedAt: now,
+						ExpiresAt: now.Add(24 * time.Hour),
+					}).
+					Return(nil).
+					Once()
+				mailerMock.
+					On("Send", ctx, mailer.NewVerificationMessage("http://localhost", "john_doe", "john.doe@test.com", "ffffffff-ffff-4fff-ffff-ffffffffffff", 24*time.Hour)).
+					Return(nil).
+					Once()
+				mock.On("SystemSet", ctx, "setup", true).Return(nil).Once()
+			},
+			expected: Expected{&models.User{
+				Origin: models.UserOriginLocal,
+				UserData: models.UserData{
+					Name:     "john_doe",
+					Email:    "john.doe@test.com",
+					Username: "john_doe",
+				},
+				Password: models.UserPassword{
+					Plain: "secret",
+					Hash:  "$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YVVCIa2UYuFV4OJby7Yi",
+				},
+				Status:        models.UserStatusNotConfirmed,
+				CreatedAt:     clock.Now(),
+				MaxNamespaces: MaxNumberNamespacesCommunity,
+				Preferences: models.UserPreferences{
+					AuthMethods: []models.UserAuthMethod{models.UserAuthMethodLocal},
+				},
+			}, nil},
+		},
+		{
+			description: "successfully creates a not confirmed user when the verification email fails",
+			username:    "john_doe",
+			email:       "john.doe@test.com",
+			password:    "secret",
+			requiredMocks: func() {
+				mock.
+					On("UserConflicts", ctx, &models.UserConflicts{Username: "john_doe", Email: "john.doe@test.com"}).
+					Return([]string{}, false, nil).
+					Once()
+				hashMock.
+					On("Do", "secret").
+					Return("$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YVVCIa2UYuFV4OJby7Yi", nil).
+					Once()
+				envMock.
+					On("Get", "SHELLHUB_EMAIL_VERIFICATION").
+					Return("true").
+					Once()
+
+				user := &models.User{
+					Origin: models.UserOriginLocal,
+					UserData: models.UserData{
+						Name:     "john_doe",
+						Email:    "john.doe@test.com",
+						Username: "john_doe",
+					},
+					Password: models.UserPassword{
+						Plain: "secret",
+						Hash:  "$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YVVCIa2UYuFV4OJby7Yi",
+					},
+					Status:        models.UserStatusNotConfirmed,
+					CreatedAt:     clock.Now(),
+					MaxNamespaces: MaxNumberNamespacesCommunity,
+					Preferences: models.UserPreferences{
+						AuthMethods: []models.UserAuthMethod{models.UserAuthMethodLocal},
+					},
+				}
+				mock.On("UserCreate", ctx, user).Return("000000000000000000000000", nil).Once()
+				uuidMock.On("Generate").Return("ffffffff-ffff-4fff-ffff-ffffffffffff").Once()
+				mock.
+					On("UserVerificationSave", ctx, &models.UserVerification{
+						UserID:    "000000000000000000000000",
+						Digest:    models.UserVerificationDigest("ffffffff-ffff-4fff-ffff-ffffffffffff"),
+						CreatedAt: now,
+						ExpiresAt: now.Add(24 * time.Hour),
+					}).
+					Return(nil).
+					Once()
+				mailerMock.
+					On("Send", ctx, mailer.NewVerificationMessage("http://localhost", "john_doe", "john.doe@test.com", "ffffffff-ffff-4fff-ffff-ffffffffffff", 24*time.Hour)).
+					Return(errors.New("error")).
+					Once()
+				mock.On("SystemSet", ctx, "setup", true).Return(nil).Once()
+			},
+			expected: Expected{&models.User{
+				Origin: models.UserOriginLocal,
+				UserData: models.UserData{
+					Name:     "john_doe",
+					Email:    "john.doe@test.com",
+					Username: "john_doe",
+				},
+				Password: models.UserPassword{
+					Plain: "secret",
+					Hash:  "$2a$10$V/6N1wsjheBVvWosPfv02uf4WAOb9lmp8YVVCIa2UYuFV4OJby7Yi",
+				},
+				Status:        models.UserStatusNotConfirmed,
+				CreatedAt:     clock.Now(),
+				MaxNamespaces: MaxNumberNamespacesCommunity,
+				Preferences: models.UserPreferences{
+					AuthMethods: []models.UserAuthMethod{models.UserAuthMethodLocal},
+				},
+			}, nil},
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			service := NewService(store.Store(mock))
+			service := NewService(store.Store(mock), WithEmailVerification(mailerMock, "http://localhost", 24*time.Hour))
 			user, err := service.UserCreate(ctx, &inputs.UserCreate{Username: tc.username, Password: tc.password, Email: tc.email})
 
 			assert.Equal(t, tc.expected, Expected{user, err})
@@ -221,6 +390,7 @@ func TestUserCreate(t *testing.T) {
 	}
 
 	mock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
 }
 
 func TestUserDelete(t *testing.T) {
@@ -1,6 +1,9 @@
 package services
 
-import "github.com/shellhub-io/shellhub/pkg/envs"
+import (
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
 
 // getMaxDevices get the limit of devices that a namespace can have if environment
 // is cloud.
@@ -11,3 +14,13 @@ func getMaxDevices() int {
 
 	return MaxNumberDevicesUnlimited
 }
+
+// getUserStatus gets the status of a newly created user. When the email verification is enabled, the user must confirm
+// the email before logging in.
+func getUserStatus() models.UserStatus {
+	if envs.HasEmailVerification() {
+		return models.UserStatusNotConfirmed
+	}
+
+	return models.UserStatusConfirmed
+}
@@ -39,6 +39,7 @@
 package agent
 
 import (
+	"cmp"
 	"context"
 	"crypto/rsa"
 	"fmt"
@@ -49,6 +50,7 @@ import (
 	"net/netip"
 	"net/url"
 	"os"
+	"path"
 	"runtime"
 	"strings"
 	"sync"
@@ -56,15 +58,20 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver"
+	"github.com/docker/docker/api/types/network"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/keygen"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/provisioner"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sandbox"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sysinfo"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/tunnel"
 	"github.com/shellhub-io/shellhub/pkg/agent/server"
 	"github.com/shellhub-io/shellhub/pkg/api/client"
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/loglevel"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 	log "github.com/sirupsen/logrus"
@@ -91,6 +98,10 @@ type Config struct {
 	// This is required.
 	ServerAddress string `env:"SERVER_ADDRESS,required" validate:"required"`
 
+	// ServerCA is the path to a PEM file, or the PEM encoded certificates inline, of a CA bundle trusted to verify
+	// the server's TLS certificate, besides the system's ones. It is reloaded when the agent reconnects to the server.
+	ServerCA string `env:"SERVER_CA"`
+
 	// Specify the path to the device private key.
 	// If not provided, the agent will generate a new one.
 	// This is required.
@@ -98,8 +109,14 @@ type Config struct {
 
 	// Sets the account tenant id used during communication to associate the
 	// device to a specific tenant.
-	// This is required.
-	TenantID string `env:"TENANT_ID,required" validate:"required"`
+	// This is required, unless an enroll token is set.
+	TenantID string `env:"TENANT_ID" validate:"required_without=EnrollToken"`
+
+	// EnrollToken is one of the namespace's enroll tokens, used instead of the tenant ID to enroll the device, so the
+	// tenant ID isn't distributed to the devices. The tenant ID is learned from the server on the first authorization.
+	//
+	// The devices already enrolled keep authenticating with the token after it expires, is used up or is revoked.
+	EnrollToken string `env:"ENROLL_TOKEN" validate:"required_without=TenantID"`
 
 	// Determine the interval to send the keep alive message to the server. This
 	// has a direct impact of the bandwidth used by the device when in idle
@@ -119,6 +136,12 @@ type Config struct {
 	// NOTE: The password hash could be generated by ```openssl passwd```.
 	SingleUserPassword string `env:"SINGLE_USER_PASSWORD,default=$SIMPLE_USER_PASSWORD"`
 
+	// SingleUserSandbox runs the session's programs inside a sandbox that restricts the visibility of the file system
+	// to the user's home directory, hiding the other users' home directories and the shared temporary directories.
+	// It is only available in single-user mode on Linux, when the agent runs as a non-root user, and the agent refuses
+	// to start when it is enabled elsewhere.
+	SingleUserSandbox bool `env:"SINGLE_USER_SANDBOX,default=false"`
+
 	// SimpleUserPassword exists due to a typo on the environmental variable that stores the password for single user
 	// mode that was wrongly named `SIMPLE_USER_PASSWORD` instead of `SINGLE_USER_PASSWORD`, and willing to keep the
 	// compatibility, this new variable was created.
@@ -127,6 +150,74 @@ type Config struct {
 	// MaxRetryConnectionTimeout specifies the maximum time, in seconds, that an agent will wait
 	// before attempting to reconnect to the ShellHub server. Default is 60 seconds.
 	MaxRetryConnectionTimeout int `env:"MAX_RETRY_CONNECTION_TIMEOUT,default=60" validate:"min=10,max=120"`
+
+	// DockerContainers enables the listing of the Docker containers running on the device and allows exec sessions
+	// to target them through the SSHID. It requires access to the Docker Engine and is only available in host mode:
+	// in connector mode each container is already a device of its own, whose sessions run inside it, and its agent
+	// has no view of the other containers on the host.
+	DockerContainers bool `env:"DOCKER_CONTAINERS,default=false"`
+
+	// DockerContainersUsers is a comma-separated list of the device's users, besides root, allowed to execute sessions
+	// inside the containers. As access to the Docker Engine is equivalent to root access to the device, it should
+	// only list users already trusted as such.
+	DockerContainersUsers string `env:"DOCKER_CONTAINERS_USERS"`
+
+	// SOCKS5Proxy enables the SOCKS5 proxy over the reverse tunnel, which exposes the device's local network to the
+	// SSH clients allowed to open the proxy's channel, so they can reach the other hosts on the device's network. It
+	// is only available in host mode.
+	SOCKS5Proxy bool `env:"SOCKS5_PROXY,default=false"`
+
+	// PreSessionHook is the path to an executable run on the device before each session's program is started. The
+	// session's metadata is available to it through the SHELLHUB_SESSION_* environment variables.
+	PreSessionHook string `env:"PRE_SESSION_HOOK"`
+
+	// PostSessionHook is the path to an executable run on the device after each session's program has ended. The
+	// session's metadata is available to it through the SHELLHUB_SESSION_* environment variables.
+	PostSessionHook string `env:"POST_SESSION_HOOK"`
+
+	// PreSessionHookRequired blocks the session when the pre-session hook fails. When false, the failure is only
+	// logged.
+	PreSessionHookRequired bool `env:"PRE_SESSION_HOOK_REQUIRED,default=false"`
+
+	// SessionHookTimeout specifies the maximum time, in seconds, that a session hook can run before being killed.
+	// Default is 30 seconds.
+	SessionHookTimeout int `env:"SESSION_HOOK_TIMEOUT,default=30"`
+
+	// LoginShells is a comma-separated list of the programs that the server may set as the device's login shell,
+	// started instead of the user's shell on interactive sessions. When empty, the user's shell is always used.
+	LoginShells string `env:"LOGIN_SHELLS"`
+
+	// ForwardAllowlist is a comma-separated list of the destinations, as "host:port", the agent forwards the local
+	// port forwarding channels to, like "127.0.0.1:80,10.0.0.0/8:443". The host may be an IP address, a CIDR, a
+	// hostname or "*", and the port may be "*" for any port. When empty, every destination not denied is allowed.
+	ForwardAllowlist string `env:"FORWARD_ALLOWLIST"`
+
+	// ForwardDenylist is a comma-separated list of the destinations, on the same form of [Config.ForwardAllowlist],
+	// the agent never forwards the local port forwarding channels to, even when they are allowed.
+	ForwardDenylist string `env:"FORWARD_DENYLIST"`
+
+	// AgentLogs enables the report of the agent's significant errors, like tunnel failures and PTYs that couldn't be
+	// spawned, to the server, where they can be queried per device.
+	AgentLogs bool `env:"AGENT_LOGS,default=true"`
+
+	// InventoryOnly registers the device and keeps its inventory and last seen updated, but doesn't open the reverse
+	// SSH tunnel until the remote access is enabled for the device on the server. The change is honored on the next
+	// ping.
+	InventoryOnly bool `env:"INVENTORY_ONLY,default=false"`
+
+	// QueuedCommands enables the execution of the commands queued to the device on the server, fetched each time the
+	// agent authorizes, so the device can be maintained even when it is only connected from time to time. As the
+	// commands are executed without an SSH session, it is only available in host mode.
+	QueuedCommands bool `env:"QUEUED_COMMANDS,default=false"`
+
+	// UserProvisioning enables the creation of the local users targeted by the SSH sessions authenticated by a public
+	// key when they don't exist on the device, if the namespace allows it, and their removal once expired. It is only
+	// available in host mode, when the agent runs as root.
+	UserProvisioning bool `env:"USER_PROVISIONING,default=false"`
+
+	// UserProvisioningState is the path of the file where the provisioned users are recorded, so they are removed once
+	// expired even when the agent is restarted meanwhile.
+	UserProvisioningState string `env:"USER_PROVISIONING_STATE,default=/var/lib/shellhub/provisioned-users.json"`
 }
 
 func LoadConfigFromEnv() (*Config, map[string]interface{}, error) {
@@ -178,8 +269,36 @@ type Agent struct {
 	server     *server.Server
 	tunnel     *tunnel.Tunnel
 	listening  chan bool
+	// pinged is notified each time the agent pings the server, so the inventory-only mode can check if the remote
+	// access was enabled.
+	pinged chan struct{}
+	// listener is the reverse listener of the tunnel, when it is connected to the server.
+	listener   net.Listener
+	listenerMu sync.Mutex
 	closed     atomic.Bool
 	mode       Mode
+	// containers is the list of Docker containers running on the device. It is nil when the containers listing is
+	// disabled.
+	containers *Containers
+	// rtt is the round-trip time of the previous authorization, reported on the next one.
+	rtt time.Duration
+	// reconnects is the number of times the tunnel was reconnected since the previous authorization.
+	reconnects atomic.Int64
+	// infoHash is the hash of the device's information last accepted by the server. While the information is
+	// unchanged, only its hash is reported on the authorization.
+	infoHash string
+	// deviceConfig is the device's configuration last applied by the agent, whose version is reported on the
+	// authorization.
+	deviceConfig *models.DeviceConfig
+	// forwardPolicy restricts the destinations of the local port forwarding channels.
+	forwardPolicy *server.ForwardPolicy
+	// gateways are the clients to the regional SSH gateways advertised by the server, per address.
+	gateways map[string]client.Client
+	// commandsRunning indicates the queued commands are being executed, so they aren't fetched again meanwhile.
+	commandsRunning atomic.Bool
+	// provisioner creates the users that don't exist on the device, when the namespace allows it. It is nil when the
+	// user provisioning is disabled.
+	provisioner *provisioner.Provisioner
 }
 
 // NewAgent creates a new agent instance, requiring the ShellHub server's address to connect to, the namespace's tenant
@@ -197,9 +316,15 @@ func NewAgent(address string, tenantID string, privateKey string, mode Mode) (*A
 var (
 	ErrNewAgentWithConfigEmptyServerAddress   = errors.New("address is empty")
 	ErrNewAgentWithConfigInvalidServerAddress = errors.New("address is invalid")
-	ErrNewAgentWithConfigEmptyTenant          = errors.New("tenant is empty")
+	ErrNewAgentWithConfigEmptyTenant          = errors.New("tenant and enroll token are empty")
 	ErrNewAgentWithConfigEmptyPrivateKey      = errors.New("private key is empty")
 	ErrNewAgentWithConfigNilMode              = errors.New("agent's mode is nil")
+	ErrNewAgentWithConfigInvalidForwardPolicy = errors.New("forwarding policy is invalid")
+	ErrNewAgentWithConfigUnsupportedSandbox   = errors.New("single-user sandbox is not supported")
+	// ErrAuthRejected is returned when the server refuses to authorize the device, like when it was removed.
+	ErrAuthRejected = errors.New("device's authorization was rejected")
+	// ErrTenantNotFound is returned when the server has no namespace with the configured tenant ID.
+	ErrTenantNotFound = errors.New("tenant not found")
 )
 
 // NewAgentWithConfig creates a new agent instance with all configurations.
@@ -214,7 +339,7 @@ func NewAgentWithConfig(config *Config, mode Mode) (*Agent, error) {
 		return nil, ErrNewAgentWithConfigInvalidServerAddress
 	}
 
-	if config.TenantID == "" {
+	if config.TenantID == "" && config.EnrollToken == "" {
 		return nil, ErrNewAgentWithConfigEmptyTenant
 	}
 
@@ -226,9 +351,22 @@ func NewAgentWithConfig(config *Config, mode Mode) (*Agent, error) {
 		return nil, ErrNewAgentWithConfigNilMode
 	}
 
+	policy, err := server.NewForwardPolicy(config.ForwardAllowlist, config.ForwardDenylist)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNewAgentWithConfigInvalidForwardPolicy, err)
+	}
+
+	// NOTICE: refusing the sandbox when it can't be used avoids an agent that fails every session it opens.
+	if config.SingleUserSandbox {
+		if err := sandbox.Supported(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrNewAgentWithConfigUnsupportedSandbox, err)
+		}
+	}
+
 	return &Agent{
-		config: config,
-		mode:   mode,
+		config:        config,
+		mode:          mode,
+		forwardPolicy: policy,
 	}, nil
 }
 
@@ -239,7 +377,7 @@ func NewAgentWithConfig(config *Config, mode Mode) (*Agent, error) {
 func (a *Agent) Initialize() error {
 	var err error
 
-	a.cli, err = client.NewClient(a.config.ServerAddress)
+	a.cli, err = client.NewClient(a.config.ServerAddress, client.WithServerCA(a.config.ServerCA))
 	if err != nil {
 		return errors.Wrap(err, "failed to create the HTTP client")
 	}
@@ -291,6 +429,22 @@ func (a *Agent) readPublicKey() error {
 	return err
 }
 
+// ClaimCode returns the code that a user may enter on ShellHub to accept the device, without knowing its UID. It is
+// derived from the device's public key, so it doesn't change between restarts.
+func (a *Agent) ClaimCode() string {
+	return models.NewDeviceClaimCode(string(keygen.EncodePublicKeyToPem(a.pubKey)))
+}
+
+// ReadClaimCode reads the device's claim code from the private key on the file system. See [Agent.ClaimCode].
+func ReadClaimCode(privateKey string) (string, error) {
+	key, err := keygen.ReadPublicKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return models.NewDeviceClaimCode(string(keygen.EncodePublicKeyToPem(key))), nil
+}
+
 // generateDeviceIdentity generates a device identity.
 //
 // The default value for Agent Identity is a network interface MAC address, but if the `SHELLHUB_PREFERRED_IDENTITY` is
@@ -317,7 +471,7 @@ func (a *Agent) generateDeviceIdentity() error {
 	return nil
 }
 
-// loadDeviceInfo load some device informations like OS name, version, arch and platform.
+// loadDeviceInfo load some device informations like OS name, version, arch, platform, hardware and network interfaces.
 func (a *Agent) loadDeviceInfo() error {
 	info, err := a.mode.GetInfo()
 	if err != nil {
@@ -330,11 +484,47 @@ func (a *Agent) loadDeviceInfo() error {
 		Version:    AgentVersion,
 		Platform:   AgentPlatform,
 		Arch:       runtime.GOARCH,
+		Hardware:   deviceHardware(info.Hardware),
+		Interfaces: deviceInterfaces(info.Interfaces),
 	}
 
 	return nil
 }
 
+// deviceHardware converts the hardware inventory gathered by the Agent's mode to the one reported to the server.
+func deviceHardware(hardware *sysinfo.Hardware) *models.DeviceHardware {
+	if hardware == nil {
+		return nil
+	}
+
+	disks := make([]models.DeviceHardwareDisk, 0, len(hardware.Disks))
+	for _, disk := range hardware.Disks {
+		disks = append(disks, models.DeviceHardwareDisk{Name: disk.Name, Size: disk.Size})
+	}
+
+	return &models.DeviceHardware{
+		CPU:     models.DeviceHardwareCPU{Model: hardware.CPU.Model, Cores: hardware.CPU.Cores},
+		Memory:  hardware.Memory,
+		Disks:   disks,
+		Serial:  hardware.Serial,
+		Product: hardware.Product,
+	}
+}
+
+// deviceInterfaces converts the network interfaces gathered by the Agent's mode to the ones reported to the server.
+func deviceInterfaces(interfaces []sysinfo.Interface) []models.DeviceInterface {
+	if len(interfaces) == 0 {
+		return nil
+	}
+
+	converted := make([]models.DeviceInterface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		converted = append(converted, models.DeviceInterface{Name: iface.Name, MAC: iface.MAC, Addresses: iface.Addresses})
+	}
+
+	return converted
+}
+
 // probeServerInfo gets information about the ShellHub server.
 func (a *Agent) probeServerInfo() error {
 	info, err := a.cli.GetInfo(AgentVersion)
@@ -345,19 +535,173 @@ func (a *Agent) probeServerInfo() error {
 
 // authorize send auth request to the server with device information in order to register it in the namespace.
 func (a *Agent) authorize() error {
-	data, err := a.cli.AuthDevice(&models.DeviceAuthRequest{
-		Info: a.Info,
+	connection := a.connection()
+
+	req := &models.DeviceAuthRequest{
+		Info:       a.Info,
+		Connection: connection,
 		DeviceAuth: &models.DeviceAuth{
 			Hostname:  a.config.PreferredHostname,
 			Identity:  a.Identity,
 			TenantID:  a.config.TenantID,
 			PublicKey: string(keygen.EncodePublicKeyToPem(a.pubKey)),
 		},
-	})
+	}
 
-	a.authData = data
+	var hash string
+	if a.Info != nil {
+		hash = a.Info.Hash()
+	}
 
-	return err
+	// NOTICE: the enroll token is only presented until the tenant ID is learned from the server.
+	if a.config.TenantID == "" {
+		req.EnrollToken = a.config.EnrollToken
+	}
+
+	if a.deviceConfig != nil {
+		req.ConfigVersion = a.deviceConfig.Version
+	}
+
+	if a.infoHashSupported() && hash != "" && hash == a.infoHash {
+		req.Info = nil
+		req.InfoHash = hash
+	}
+
+	data, err := a.cli.AuthDevice(req)
+	if errors.Is(err, client.ErrPreconditionFailed) && req.Info == nil {
+		log.WithField("info_hash", hash).Debug("server doesn't know the device's information hash, reporting the whole information")
+
+		req.Info = a.Info
+		req.InfoHash = ""
+
+		data, err = a.cli.AuthDevice(req)
+	}
+
+	if err == nil && data != nil {
+		a.authData = data
+		a.infoHash = hash
+
+		if a.config.TenantID == "" {
+			a.config.TenantID = data.TenantID
+		}
+
+		a.checkClockSkew(data.ClockSkew)
+		a.rtt = data.RTT
+		a.applyDeviceConfig(data.Config)
+		a.provisioner.SetPolicy(data.UserProvisioning)
+	} else if connection != nil {
+		// NOTICE: the reconnects not reported are kept to the next authorization.
+		a.reconnects.Add(int64(connection.Reconnects))
+	}
+
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		return fmt.Errorf("%w: %w", ErrTenantNotFound, err)
+	case errors.Is(err, client.ErrUnauthorized), errors.Is(err, client.ErrForbidden):
+		return fmt.Errorf("%w: %w", ErrAuthRejected, err)
+	default:
+		return err
+	}
+}
+
+// applyDeviceConfig applies the device's configuration delivered on the authorization, when its version wasn't applied
+// yet, without restarting the agent. The keys removed from the configuration are restored to the agent's defaults.
+func (a *Agent) applyDeviceConfig(config *models.DeviceConfig) {
+	if config == nil || (a.deviceConfig != nil && a.deviceConfig.Version == config.Version) {
+		return
+	}
+
+	if level, ok := config.Values[models.DeviceConfigLogLevel]; ok {
+		if parsed, err := log.ParseLevel(level); err == nil {
+			log.SetLevel(parsed)
+		}
+	} else if a.deviceConfig != nil && a.deviceConfig.Values[models.DeviceConfigLogLevel] != "" {
+		loglevel.SetLogLevel()
+	}
+
+	a.deviceConfig = config
+	a.applyServerConfig()
+
+	log.WithFields(log.Fields{
+		"version":        AgentVersion,
+		"tenant_id":      a.authData.Namespace,
+		"server_address": a.config.ServerAddress,
+		"config_version": config.Version,
+	}).Info("device's config applied")
+}
+
+// applyServerConfig applies the device's configuration on the SSH server. As the first authorization happens before
+// the server is created, it is also called once the server is created.
+func (a *Agent) applyServerConfig() {
+	if a.server == nil || a.deviceConfig == nil {
+		return
+	}
+
+	a.server.SetForcedCommand(a.deviceConfig.Values[models.DeviceConfigForcedCommand])
+	a.server.SetSFTP(a.deviceConfig.Values[models.DeviceConfigSFTP])
+}
+
+// infoHashSupported reports whether the server accepts the device's information reported by its hash.
+func (a *Agent) infoHashSupported() bool {
+	return a.serverInfo != nil && a.serverInfo.Features.DeviceInfoHash
+}
+
+// connection returns the measure of the agent's connection since the previous authorization, to be reported on the
+// next one. It is nil before the first authorization succeeds.
+func (a *Agent) connection() *models.DeviceConnection {
+	if a.rtt == 0 {
+		return nil
+	}
+
+	return &models.DeviceConnection{
+		RTT:        a.rtt.Milliseconds(),
+		Reconnects: int(a.reconnects.Swap(0)),
+	}
+}
+
+// checkClockSkew stores the clock skew measured against the server to be reported on the next authorization, warning
+// when it is above the tolerated threshold, as it breaks the tokens' validation and the ordering of the recordings.
+func (a *Agent) checkClockSkew(skew time.Duration) {
+	if a.Info != nil {
+		a.Info.ClockSkew = int64(skew.Seconds())
+	}
+
+	if models.IsClockSkewed(skew) {
+		log.WithFields(log.Fields{
+			"version":        AgentVersion,
+			"tenant_id":      a.authData.Namespace,
+			"server_address": a.config.ServerAddress,
+			"clock_skew":     skew.String(),
+			"threshold":      models.DeviceClockSkewThreshold.String(),
+		}).Warn("device clock is not synchronized with the server; check the device's time synchronization")
+	}
+}
+
+// remoteAccessAllowed checks if the agent may open the reverse SSH tunnel. It is always allowed, except when the agent
+// runs in inventory-only mode and the remote access isn't enabled for the device on the server.
+func (a *Agent) remoteAccessAllowed() bool {
+	if !a.config.InventoryOnly {
+		return true
+	}
+
+	return a.authData != nil && a.authData.RemoteAccess
+}
+
+func (a *Agent) setListener(listener net.Listener) {
+	a.listenerMu.Lock()
+	defer a.listenerMu.Unlock()
+
+	a.listener = listener
+}
+
+// closeListener closes the reverse listener of the tunnel, if it is connected, without closing the agent.
+func (a *Agent) closeListener() {
+	a.listenerMu.Lock()
+	defer a.listenerMu.Unlock()
+
+	if a.listener != nil {
+		a.listener.Close() // nolint:errcheck
+	}
 }
 
 func (a *Agent) isClosed() bool {
@@ -386,7 +730,18 @@ func sshHandler(serv *server.Server) func(c echo.Context) error {
 		id := c.Param("id")
 		httpConn := c.Request().Context().Value("http-conn").(net.Conn)
 		serv.Sessions.Store(id, httpConn)
-		serv.HandleConn(httpConn)
+
+		requestID := c.Request().Header.Get(correlation.Header)
+		log.WithFields(log.Fields{"uid": id, "request_id": requestID}).Debug("Handling a session connection")
+
+		serv.HandleConn(&server.SessionConn{
+			Conn: httpConn,
+			UID:  id,
+			IP:   c.Request().Header.Get("X-Real-IP"),
+			// NOTICE: The login shell is only used when it is one of the agent's allowed login shells.
+			LoginShell: c.Request().Header.Get("X-Login-Shell"),
+			RequestID:  requestID,
+		})
 
 		conn.Close()
 
@@ -394,6 +749,66 @@ func sshHandler(serv *server.Server) func(c echo.Context) error {
 	}
 }
 
+// endpointHost returns the host of the endpoint, without its port. IPv6 literals are returned without the square
+// brackets, as expected by the SSH clients when they are used on a SSHID.
+func endpointHost(endpoint string) string {
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(endpoint, "["), "]")
+}
+
+// tunnelConnectionPath returns the path where the reverse tunnel is opened, under the prefix advertised by the server.
+// Servers that don't advertise it serve the tunnel under "/ssh".
+func tunnelConnectionPath(endpoints models.Endpoints) string {
+	return path.Join("/", cmp.Or(endpoints.Tunnel, "/ssh"), "connection")
+}
+
+// containerProxyTarget returns the address, on the container's networks, that a proxy connection to addr must reach.
+// A loopback address targets the container itself, preferring an address from the same IP family, while any other
+// address must belong to one of the container's IPv4 or IPv6 subnets. It returns an empty string when there isn't one.
+func containerProxyTarget(networks map[string]*network.EndpointSettings, addr netip.Addr) string {
+	if addr.IsLoopback() {
+		var fallback string
+		for _, endpoint := range networks {
+			if addr.Is4() && endpoint.IPAddress != "" {
+				return endpoint.IPAddress
+			}
+
+			if addr.Is6() && endpoint.GlobalIPv6Address != "" {
+				return endpoint.GlobalIPv6Address
+			}
+
+			if fallback == "" {
+				fallback = cmp.Or(endpoint.IPAddress, endpoint.GlobalIPv6Address)
+			}
+		}
+
+		return fallback
+	}
+
+	for _, endpoint := range networks {
+		subnets := []string{
+			fmt.Sprintf("%s/%d", endpoint.Gateway, endpoint.IPPrefixLen),
+			fmt.Sprintf("%s/%d", endpoint.IPv6Gateway, endpoint.GlobalIPv6PrefixLen),
+		}
+
+		for _, value := range subnets {
+			subnet, err := netip.ParsePrefix(value)
+			if err != nil {
+				continue
+			}
+
+			if subnet.Contains(addr) {
+				return addr.String()
+			}
+		}
+	}
+
+	return ""
+}
+
 // httpProxyHandler handlers proxy connections to the required address.
 func httpProxyHandler(agent *Agent) func(c echo.Context) error {
 	const ProxyHandlerNetwork = "tcp"
@@ -428,43 +843,12 @@ func httpProxyHandler(agent *Agent) func(c echo.Context) error {
 				return errorResponse(err, "failed to inspect the container", http.StatusInternalServerError)
 			}
 
-			var target string
-
 			addr, err := netip.ParseAddr(host)
 			if err != nil {
 				return errorResponse(err, "failed to parse the for lookback checkage", http.StatusInternalServerError)
 			}
 
-			if addr.IsLoopback() {
-				for _, network := range container.NetworkSettings.Networks {
-					target = network.IPAddress
-
-					break
-				}
-			} else {
-				for _, network := range container.NetworkSettings.Networks {
-					subnet, err := netip.ParsePrefix(fmt.Sprintf("%s/%d", network.Gateway, network.IPPrefixLen))
-					if err != nil {
-						logger.WithError(err).Trace("Failed to parse the gateway on proxy")
-
-						continue
-					}
-
-					ip, err := netip.ParseAddr(host)
-					if err != nil {
-						logger.WithError(err).Trace("Failed to parse the address on proxy")
-
-						continue
-					}
-
-					if subnet.Contains(ip) {
-						target = ip.String()
-
-						break
-					}
-				}
-			}
-
+			target := containerProxyTarget(container.NetworkSettings.Networks, addr)
 			if target == "" {
 				return errorResponse(nil, "address not found on the device", http.StatusInternalServerError)
 			}
@@ -474,7 +858,7 @@ func httpProxyHandler(agent *Agent) func(c echo.Context) error {
 
 		// NOTE: Gets the to address to connect to. This address can be just a port, :8080, or the host and port,
 		// localhost:8080.
-		addr := fmt.Sprintf("%s:%s", host, port)
+		addr := net.JoinHostPort(host, port)
 
 		in, err := net.Dial(ProxyHandlerNetwork, addr)
 		if err != nil {
@@ -530,6 +914,44 @@ func httpProxyHandler(agent *Agent) func(c echo.Context) error {
 	}
 }
 
+// socks5Handler serves a SOCKS5 proxy on the tunnel's connection, connecting to the hosts reachable from the device.
+// The destinations are evaluated against the forwarding policy. When the proxy is disabled, it responds with the
+// status not implemented.
+func socks5Handler(enabled bool, policy *server.ForwardPolicy) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		if !enabled {
+			return c.NoContent(http.StatusNotImplemented)
+		}
+
+		logger := log.WithFields(log.Fields{
+			"remote":  c.Request().RemoteAddr,
+			"version": AgentVersion,
+		})
+
+		// NOTE: Inform to the connection that the proxy is ready to receive the SOCKS5 session.
+		if err := c.NoContent(http.StatusOK); err != nil {
+			logger.WithError(err).Debug("failed to send the ok status code back to server")
+
+			return nil
+		}
+
+		conn, _, err := c.Response().Hijack()
+		if err != nil {
+			logger.WithError(err).Debug("failed to hijack connection")
+
+			return nil
+		}
+
+		defer conn.Close() // nolint:errcheck
+
+		if err := serveSOCKS5(conn, socks5PolicyDialer(policy)); err != nil {
+			logger.WithError(err).Debug("SOCKS5 session failed")
+		}
+
+		return nil
+	}
+}
+
 func sshCloseHandler(a *Agent, serv *server.Server) func(c echo.Context) error {
 	return func(c echo.Context) error {
 		id := c.Param("id")
@@ -552,16 +974,61 @@ func sshCloseHandler(a *Agent, serv *server.Server) func(c echo.Context) error {
 func (a *Agent) Listen(ctx context.Context) error {
 	a.mode.Serve(a)
 
+	// NOTICE: the containers are only listed by host mode agents, running on the Docker host. The connector already
+	// registers each container as a device of its own, so there is no device to list them on.
+	if _, ok := a.mode.(*HostMode); ok && a.config.DockerContainers {
+		cli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to the Docker Engine")
+		}
+
+		a.containers = NewContainers(cli)
+		a.server.SetDocker(cli, splitList(a.config.DockerContainersUsers))
+
+		go a.containers.Watch(ctx)
+	}
+
+	// NOTICE: the ports listening on the connector's host aren't the ones of its containers, so they aren't listed.
+	var ports *Ports
+	if _, ok := a.mode.(*HostMode); ok {
+		ports = NewPorts("/proc")
+	}
+
+	_, socks5 := a.mode.(*HostMode)
+	socks5 = socks5 && a.config.SOCKS5Proxy
+
 	a.tunnel = tunnel.NewBuilder().
 		WithSSHHandler(sshHandler(a.server)).
 		WithSSHCloseHandler(sshCloseHandler(a, a.server)).
 		WithHTTPProxyHandler(httpProxyHandler(a)).
+		WithContainersHandler(containersHandler(a.containers)).
+		WithPortsHandler(portsHandler(ports)).
+		WithSOCKS5Handler(socks5Handler(socks5, a.forwardPolicy)).
 		Build()
 
+	a.pinged = make(chan struct{}, 1)
 	go a.ping(ctx, AgentPingDefaultInterval) //nolint:errcheck
 
+	if a.config.AgentLogs {
+		logs := NewAgentLogs(AgentLogsDefaultLimit)
+		log.AddHook(logs)
+
+		go a.reportLogs(ctx, logs, AgentLogsDefaultInterval)
+	}
+
+	a.startQueuedCommands()
+
+	if a.provisioner != nil {
+		go a.provisioner.Watch(ctx, provisioner.DefaultCleanupInterval)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	go func() {
+		// connected indicates the tunnel was connected before, so a new connection is counted as a reconnect.
+		connected := false
+		// failures is the number of failed connections in a row, growing the wait before the next one.
+		failures := 0
+
 		for {
 			if a.isClosed() {
 				log.WithFields(log.Fields{
@@ -575,30 +1042,55 @@ func (a *Agent) Listen(ctx context.Context) error {
 				return
 			}
 
+			if !a.remoteAccessAllowed() {
+				log.WithFields(log.Fields{
+					"version":        AgentVersion,
+					"server_address": a.config.ServerAddress,
+				}).Info("Running in inventory-only mode. The tunnel will be opened when the remote access is enabled")
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-a.pinged:
+				}
+
+				continue
+			}
+
 			namespace := a.authData.Namespace
 			tenantName := a.authData.Name
-			sshEndpoint := a.serverInfo.Endpoints.SSH
+
+			listener, sshEndpoint, err := a.reverseListener(ctx)
 
 			sshid := strings.NewReplacer(
 				"{namespace}", namespace,
 				"{tenantName}", tenantName,
-				"{sshEndpoint}", strings.Split(sshEndpoint, ":")[0],
+				"{sshEndpoint}", endpointHost(sshEndpoint),
 			).Replace("{namespace}.{tenantName}@{sshEndpoint}")
 
-			listener, err := a.cli.NewReverseListener(ctx, a.authData.Token, "/ssh/connection")
 			if err != nil {
+				wait := reconnectBackoff(failures, time.Duration(a.config.MaxRetryConnectionTimeout)*time.Second)
+				failures++
+
 				log.WithError(err).WithFields(log.Fields{
 					"version":        AgentVersion,
 					"tenant_id":      a.authData.Namespace,
 					"server_address": a.config.ServerAddress,
 					"ssh_server":     sshEndpoint,
 					"sshid":          sshid,
-				}).Error("Failed to connect to server through reverse tunnel. Retry in 10 seconds")
-				time.Sleep(time.Second * 10)
+					"retry_in":       wait.String(),
+				}).Error("Failed to connect to server through reverse tunnel")
+
+				select {
+				case <-ctx.Done():
+				case <-time.After(wait):
+				}
 
 				continue
 			}
 
+			failures = 0
+
 			log.WithFields(log.Fields{
 				"namespace":      namespace,
 				"hostname":       tenantName,
@@ -607,6 +1099,13 @@ func (a *Agent) Listen(ctx context.Context) error {
 				"sshid":          sshid,
 			}).Info("Server connection established")
 
+			if connected {
+				a.reconnects.Add(1)
+			}
+
+			connected = true
+
+			a.setListener(listener)
 			a.listening <- true
 
 			{
@@ -623,6 +1122,7 @@ func (a *Agent) Listen(ctx context.Context) error {
 				}).Info("Tunnel listener closed")
 
 				listener.Close() // nolint:errcheck
+				a.setListener(nil)
 			}
 
 			a.listening <- false
@@ -634,6 +1134,21 @@ func (a *Agent) Listen(ctx context.Context) error {
 	return a.Close()
 }
 
+// reconnectBackoffBase is the wait before the first retry of a failed tunnel connection.
+const reconnectBackoffBase = 2 * time.Second
+
+// reconnectBackoff returns the wait before retrying a tunnel connection after the given number of failures in a row.
+// It doubles on each failure, up to max, and is randomized over its upper half, so the devices disconnected together,
+// like on a server restart, don't reconnect at the same time.
+func reconnectBackoff(failures int, max time.Duration) time.Duration {
+	wait := max
+	if failures < 16 && reconnectBackoffBase<<failures < max {
+		wait = reconnectBackoffBase << failures
+	}
+
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1)) //nolint:gosec
+}
+
 // AgentPingDefaultInterval is the default time interval between ping on agent.
 const AgentPingDefaultInterval = 10 * time.Minute
 
@@ -650,7 +1165,12 @@ func (a *Agent) ping(ctx context.Context, interval time.Duration) error {
 		interval = AgentPingDefaultInterval
 	}
 
-	<-a.listening // NOTE: wait for the first connection to start to ping the server.
+	// NOTE: wait for the first connection to start to ping the server. In inventory-only mode, the server is pinged
+	// even without a connection, as it is the way the device's inventory and last seen are kept updated.
+	if !a.config.InventoryOnly {
+		<-a.listening
+	}
+
 	ticker := time.NewTicker(interval)
 
 	for {
@@ -677,7 +1197,7 @@ func (a *Agent) ping(ctx context.Context, interval time.Duration) error {
 				}).Debug("Starting the ping interval to server")
 
 				ticker.Reset(interval)
-			} else {
+			} else if !a.config.InventoryOnly {
 				log.WithFields(log.Fields{
 					"version":        AgentVersion,
 					"tenant_id":      a.authData.Namespace,
@@ -688,8 +1208,27 @@ func (a *Agent) ping(ctx context.Context, interval time.Duration) error {
 				ticker.Stop()
 			}
 		case <-ticker.C:
+			// NOTICE: as the client doesn't retry the refused authorizations, they are retried on the next ping.
 			if err := a.authorize(); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"version":        AgentVersion,
+					"tenant_id":      a.authData.Namespace,
+					"server_address": a.config.ServerAddress,
+				}).Warn("Failed to authorize the device")
+			} else {
 				a.server.SetDeviceName(a.authData.Name)
+				a.startQueuedCommands()
+			}
+
+			select {
+			case a.pinged <- struct{}{}:
+			default:
+			}
+
+			// NOTICE: when the remote access was disabled for the device, the tunnel is closed until it is enabled
+			// again.
+			if !a.remoteAccessAllowed() {
+				a.closeListener()
 			}
 
 			log.WithFields(log.Fields{
@@ -736,7 +1275,7 @@ func (a *Agent) GetInfo() (*models.Info, error) {
 
 // GetInfo gets information like the version and the enpoints for HTTP and SSH to ShellHub server.
 func GetInfo(cfg *Config) (*models.Info, error) {
-	cli, err := client.NewClient(cfg.ServerAddress)
+	cli, err := client.NewClient(cfg.ServerAddress, client.WithServerCA(cfg.ServerCA))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create the HTTP client")
 	}
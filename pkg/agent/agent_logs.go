@@ -0,0 +1,132 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// AgentLogsDefaultInterval is the default time interval between each report of the agent's logs to the server.
+	AgentLogsDefaultInterval = 30 * time.Second
+	// AgentLogsDefaultLimit is the default maximum number of logs reported on each interval. The logs recorded after
+	// it is reached are dropped, being only counted.
+	AgentLogsDefaultLimit = 50
+)
+
+// AgentLogs is a [log.Hook] that records the significant errors logged by the agent, like tunnel failures, PTYs that
+// couldn't be spawned and SFTP crashes, to be reported in batches to the server.
+type AgentLogs struct {
+	mu      sync.Mutex
+	limit   int
+	logs    []models.DeviceAgentLog
+	dropped int
+}
+
+var _ log.Hook = new(AgentLogs)
+
+// NewAgentLogs creates a new [AgentLogs] that keeps up to limit logs between each report.
+func NewAgentLogs(limit int) *AgentLogs {
+	if limit <= 0 {
+		limit = AgentLogsDefaultLimit
+	}
+
+	return &AgentLogs{
+		limit: limit,
+		logs:  []models.DeviceAgentLog{},
+	}
+}
+
+// Levels returns the log levels recorded by [AgentLogs].
+func (l *AgentLogs) Levels() []log.Level {
+	return []log.Level{log.PanicLevel, log.FatalLevel, log.ErrorLevel}
+}
+
+// Fire records the log entry, or drops it when the limit was reached.
+func (l *AgentLogs) Fire(entry *log.Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.logs) >= l.limit {
+		l.dropped++
+
+		return nil
+	}
+
+	agentLog := models.DeviceAgentLog{
+		Level:   entry.Level.String(),
+		Message: truncate(entry.Message, 1024),
+		Time:    entry.Time,
+	}
+
+	if err, ok := entry.Data[log.ErrorKey].(error); ok && err != nil {
+		agentLog.Error = truncate(err.Error(), 1024)
+	}
+
+	l.logs = append(l.logs, agentLog)
+
+	return nil
+}
+
+// Flush returns the recorded logs, resetting them. When logs were dropped since the last flush, a warning with the
+// number of dropped logs is added to the end.
+func (l *AgentLogs) Flush() []models.DeviceAgentLog {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	logs := l.logs
+	if l.dropped > 0 {
+		logs = append(logs, models.DeviceAgentLog{
+			Level:   log.WarnLevel.String(),
+			Message: fmt.Sprintf("%d logs were dropped as the limit of %d logs per report was reached", l.dropped, l.limit),
+			Time:    time.Now(),
+		})
+	}
+
+	l.logs = []models.DeviceAgentLog{}
+	l.dropped = 0
+
+	return logs
+}
+
+// truncate limits the string s to n bytes.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n]
+}
+
+// reportLogs sends the logs recorded by the agent to the server at each interval, until the context is done. When the
+// report fails, the logs are discarded.
+func (a *Agent) reportLogs(ctx context.Context, logs *AgentLogs, interval time.Duration) {
+	if interval == 0 {
+		interval = AgentLogsDefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		entries := logs.Flush()
+		if len(entries) == 0 {
+			continue
+		}
+
+		// NOTICE: The failure is logged as a warning to avoid it being recorded as an agent's log itself.
+		if err := a.cli.ReportAgentLogs(a.authData.UID, entries, a.authData.Token); err != nil {
+			log.WithError(err).WithField("count", len(entries)).Warn("Failed to report the agent's logs to the server")
+		}
+	}
+}
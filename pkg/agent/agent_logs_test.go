@@ -0,0 +1,38 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentLogs(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	logs := NewAgentLogs(2)
+
+	logger := log.New()
+	logger.AddHook(logs)
+
+	logger.WithTime(now).WithError(errors.New("connection refused")).Error("Failed to connect to server through reverse tunnel")
+	logger.WithTime(now).Warn("Docker events stream closed")
+	logger.WithTime(now).Error("Failed to start the PTY")
+	logger.WithTime(now).Error("Failed to wait command")
+
+	flushed := logs.Flush()
+	require.Len(t, flushed, 3)
+
+	assert.Equal(t, []models.DeviceAgentLog{
+		{Level: "error", Message: "Failed to connect to server through reverse tunnel", Error: "connection refused", Time: now},
+		{Level: "error", Message: "Failed to start the PTY", Time: now},
+	}, flushed[:2])
+	assert.Equal(t, "warning", flushed[2].Level)
+	assert.Equal(t, "1 logs were dropped as the limit of 2 logs per report was reached", flushed[2].Message)
+
+	assert.Empty(t, logs.Flush())
+}
@@ -1,16 +1,25 @@
 package agent
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/netip"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/api/types/network"
 	"github.com/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sandbox"
+	"github.com/shellhub-io/shellhub/pkg/api/client"
 	client_mocks "github.com/shellhub-io/shellhub/pkg/api/client/mocks"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	env_mocks "github.com/shellhub-io/shellhub/pkg/envs/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func ExampleNewAgentWithConfig() {
@@ -76,8 +85,9 @@ func TestLoadConfigFromEnv(t *testing.T) {
 			expected: expected{
 				cfg: nil,
 				fields: map[string]interface{}{
-					"TenantID":   "required",
-					"PrivateKey": "required",
+					"TenantID":    "required_without",
+					"EnrollToken": "required_without",
+					"PrivateKey":  "required",
 				},
 				err: validator.ErrStructureInvalid,
 			},
@@ -100,7 +110,8 @@ func TestLoadConfigFromEnv(t *testing.T) {
 				cfg: nil,
 				fields: map[string]interface{}{
 					"ServerAddress": "required",
-					"TenantID":      "required",
+					"TenantID":      "required_without",
+					"EnrollToken":   "required_without",
 					"PrivateKey":    "required",
 				},
 				err: validator.ErrStructureInvalid,
@@ -224,6 +235,20 @@ func TestNewAgentWithConfig(t *testing.T) {
 				err:   ErrNewAgentWithConfigNilMode,
 			},
 		},
+		{
+			description: "fail when the forwarding policy is invalid",
+			config: &Config{
+				ServerAddress:    "http://localhost",
+				TenantID:         "1c462afa-e4b6-41a5-ba54-7236a1770466",
+				PrivateKey:       "/tmp/shellhub.key",
+				ForwardAllowlist: "127.0.0.1",
+			},
+			mode: new(HostMode),
+			expected: expected{
+				agent: nil,
+				err:   ErrNewAgentWithConfigInvalidForwardPolicy,
+			},
+		},
 		{
 			description: "success to create agent with config",
 			config:      config,
@@ -248,6 +273,27 @@ func TestNewAgentWithConfig(t *testing.T) {
 	}
 }
 
+func TestNewAgentWithConfig_sandbox(t *testing.T) {
+	config := &Config{
+		ServerAddress:      "http://localhost",
+		TenantID:           "1c462afa-e4b6-41a5-ba54-7236a1770466",
+		PrivateKey:         "/tmp/shellhub.key",
+		SingleUserPassword: "$1$AbCdEfGh$0123456789abcdefghijkl",
+		SingleUserSandbox:  true,
+	}
+
+	agent, err := NewAgentWithConfig(config, new(HostMode))
+
+	// NOTICE: the sandbox is only supported on Linux, when the agent doesn't run as root.
+	if sandbox.Supported() != nil {
+		assert.Nil(t, agent)
+		assert.ErrorIs(t, err, ErrNewAgentWithConfigUnsupportedSandbox)
+	} else {
+		assert.NotNil(t, agent)
+		assert.NoError(t, err)
+	}
+}
+
 func TestAgent_GetInfo(t *testing.T) {
 	clientMocks := new(client_mocks.Client)
 
@@ -306,3 +352,424 @@ func TestAgent_GetInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointHost(t *testing.T) {
+	cases := []struct {
+		description string
+		endpoint    string
+		expected    string
+	}{
+		{
+			description: "returns the host when the endpoint doesn't have a port",
+			endpoint:    "localhost",
+			expected:    "localhost",
+		},
+		{
+			description: "returns the host without the port",
+			endpoint:    "localhost:22",
+			expected:    "localhost",
+		},
+		{
+			description: "returns the IPv6 literal without the port and brackets",
+			endpoint:    "[2001:db8::1]:22",
+			expected:    "2001:db8::1",
+		},
+		{
+			description: "returns the IPv6 literal without the brackets",
+			endpoint:    "[2001:db8::1]",
+			expected:    "2001:db8::1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, endpointHost(tc.endpoint))
+		})
+	}
+}
+
+func TestTunnelConnectionPath(t *testing.T) {
+	cases := []struct {
+		description string
+		endpoints   models.Endpoints
+		expected    string
+	}{
+		{
+			description: "returns the default path when the server doesn't advertise the prefix",
+			endpoints:   models.Endpoints{API: "localhost", SSH: "localhost:22"},
+			expected:    "/ssh/connection",
+		},
+		{
+			description: "returns the path under the advertised prefix",
+			endpoints:   models.Endpoints{API: "localhost", SSH: "localhost:22", Tunnel: "/edge/ssh"},
+			expected:    "/edge/ssh/connection",
+		},
+		{
+			description: "returns the path under the advertised prefix without the trailing slash",
+			endpoints:   models.Endpoints{API: "localhost", SSH: "localhost:22", Tunnel: "edge/"},
+			expected:    "/edge/connection",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tunnelConnectionPath(tc.endpoints))
+		})
+	}
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	cases := []struct {
+		description string
+		failures    int
+		min         time.Duration
+		max         time.Duration
+	}{
+		{
+			description: "waits the base on the first failure",
+			failures:    0,
+			min:         1 * time.Second,
+			max:         2 * time.Second,
+		},
+		{
+			description: "doubles the wait on each failure",
+			failures:    3,
+			min:         8 * time.Second,
+			max:         16 * time.Second,
+		},
+		{
+			description: "caps the wait on the maximum",
+			failures:    10,
+			min:         30 * time.Second,
+			max:         60 * time.Second,
+		},
+		{
+			description: "caps the wait on the maximum after many failures",
+			failures:    100,
+			min:         30 * time.Second,
+			max:         60 * time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			wait := reconnectBackoff(tc.failures, 60*time.Second)
+			assert.GreaterOrEqual(t, wait, tc.min)
+			assert.LessOrEqual(t, wait, tc.max)
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	cases := []struct {
+		description string
+		list        string
+		expected    []string
+	}{
+		{
+			description: "returns no login shells when the list is empty",
+			list:        "",
+			expected:    []string{},
+		},
+		{
+			description: "returns the login shells without spaces and empty entries",
+			list:        "/usr/bin/htop, /opt/appliance/tui,,",
+			expected:    []string{"/usr/bin/htop", "/opt/appliance/tui"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, splitList(tc.list))
+		})
+	}
+}
+
+func TestAgent_remoteAccessAllowed(t *testing.T) {
+	cases := []struct {
+		description string
+		config      *Config
+		authData    *models.DeviceAuthResponse
+		expected    bool
+	}{
+		{
+			description: "allows when the agent isn't in inventory-only mode",
+			config:      &Config{InventoryOnly: false},
+			authData:    &models.DeviceAuthResponse{RemoteAccess: false},
+			expected:    true,
+		},
+		{
+			description: "denies when the device wasn't authorized",
+			config:      &Config{InventoryOnly: true},
+			authData:    nil,
+			expected:    false,
+		},
+		{
+			description: "denies when the remote access isn't enabled",
+			config:      &Config{InventoryOnly: true},
+			authData:    &models.DeviceAuthResponse{RemoteAccess: false},
+			expected:    false,
+		},
+		{
+			description: "allows when the remote access is enabled",
+			config:      &Config{InventoryOnly: true},
+			authData:    &models.DeviceAuthResponse{RemoteAccess: true},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			agent := &Agent{config: tc.config, authData: tc.authData}
+			assert.Equal(t, tc.expected, agent.remoteAccessAllowed())
+		})
+	}
+}
+
+func TestContainerProxyTarget(t *testing.T) {
+	networks := map[string]*network.EndpointSettings{
+		"bridge": {
+			Gateway:             "172.17.0.1",
+			IPAddress:           "172.17.0.2",
+			IPPrefixLen:         16,
+			IPv6Gateway:         "fd00::1",
+			GlobalIPv6Address:   "fd00::2",
+			GlobalIPv6PrefixLen: 64,
+		},
+	}
+
+	cases := []struct {
+		description string
+		networks    map[string]*network.EndpointSettings
+		addr        string
+		expected    string
+	}{
+		{
+			description: "returns the container's IPv4 address when the address is the IPv4 loopback",
+			networks:    networks,
+			addr:        "127.0.0.1",
+			expected:    "172.17.0.2",
+		},
+		{
+			description: "returns the container's IPv6 address when the address is the IPv6 loopback",
+			networks:    networks,
+			addr:        "::1",
+			expected:    "fd00::2",
+		},
+		{
+			description: "returns the container's IPv6 address when the network is IPv6-only",
+			networks: map[string]*network.EndpointSettings{
+				"ipv6": {IPv6Gateway: "fd00::1", GlobalIPv6Address: "fd00::2", GlobalIPv6PrefixLen: 64},
+			},
+			addr:     "127.0.0.1",
+			expected: "fd00::2",
+		},
+		{
+			description: "returns the address when it is on the IPv4 subnet",
+			networks:    networks,
+			addr:        "172.17.0.3",
+			expected:    "172.17.0.3",
+		},
+		{
+			description: "returns the address when it is on the IPv6 subnet",
+			networks:    networks,
+			addr:        "fd00::3",
+			expected:    "fd00::3",
+		},
+		{
+			description: "returns empty when the address is not on the container's subnets",
+			networks:    networks,
+			addr:        "2001:db8::1",
+			expected:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, containerProxyTarget(tc.networks, netip.MustParseAddr(tc.addr)))
+		})
+	}
+}
+
+func TestAgent_authorize(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	info := &models.DeviceInfo{ID: "ubuntu", PrettyName: "Ubuntu", Version: "latest", Arch: "amd64", Platform: "native"}
+
+	withInfo := mock.MatchedBy(func(req *models.DeviceAuthRequest) bool {
+		return req.Info != nil && req.InfoHash == ""
+	})
+
+	withInfoHash := mock.MatchedBy(func(req *models.DeviceAuthRequest) bool {
+		return req.Info == nil && req.InfoHash == info.Hash()
+	})
+
+	cases := []struct {
+		description   string
+		serverInfo    *models.Info
+		infoHash      string
+		requiredMocks func(clientMocks *client_mocks.Client)
+		expected      error
+	}{
+		{
+			description: "reports the whole information when the server doesn't support its hash",
+			serverInfo:  &models.Info{},
+			infoHash:    info.Hash(),
+			requiredMocks: func(clientMocks *client_mocks.Client) {
+				clientMocks.On("AuthDevice", withInfo).Return(&models.DeviceAuthResponse{}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "reports the whole information when it was never accepted",
+			serverInfo:  &models.Info{Features: models.Features{DeviceInfoHash: true}},
+			infoHash:    "",
+			requiredMocks: func(clientMocks *client_mocks.Client) {
+				clientMocks.On("AuthDevice", withInfo).Return(&models.DeviceAuthResponse{}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "reports the information's hash when it is unchanged",
+			serverInfo:  &models.Info{Features: models.Features{DeviceInfoHash: true}},
+			infoHash:    info.Hash(),
+			requiredMocks: func(clientMocks *client_mocks.Client) {
+				clientMocks.On("AuthDevice", withInfoHash).Return(&models.DeviceAuthResponse{}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "reports the whole information when the server doesn't know its hash",
+			serverInfo:  &models.Info{Features: models.Features{DeviceInfoHash: true}},
+			infoHash:    info.Hash(),
+			requiredMocks: func(clientMocks *client_mocks.Client) {
+				clientMocks.On("AuthDevice", withInfoHash).Return(nil, client.ErrPreconditionFailed).Once()
+				clientMocks.On("AuthDevice", withInfo).Return(&models.DeviceAuthResponse{}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the authorization fails",
+			serverInfo:  &models.Info{Features: models.Features{DeviceInfoHash: true}},
+			infoHash:    "",
+			requiredMocks: func(clientMocks *client_mocks.Client) {
+				clientMocks.On("AuthDevice", withInfo).Return(nil, client.ErrForbidden).Once()
+			},
+			expected: client.ErrForbidden,
+		},
+		{
+			description: "fails as rejected when the server refuses the device",
+			serverInfo:  &models.Info{Features: models.Features{DeviceInfoHash: true}},
+			infoHash:    "",
+			requiredMocks: func(clientMocks *client_mocks.Client) {
+				clientMocks.On("AuthDevice", withInfo).Return(nil, client.ErrUnauthorized).Once()
+			},
+			expected: ErrAuthRejected,
+		},
+		{
+			description: "fails when the tenant is not found",
+			serverInfo:  &models.Info{Features: models.Features{DeviceInfoHash: true}},
+			infoHash:    "",
+			requiredMocks: func(clientMocks *client_mocks.Client) {
+				clientMocks.On("AuthDevice", withInfo).Return(nil, client.ErrNotFound).Once()
+			},
+			expected: ErrTenantNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			clientMocks := new(client_mocks.Client)
+			tc.requiredMocks(clientMocks)
+
+			agent := &Agent{
+				config:     &Config{TenantID: "00000000-0000-4000-0000-000000000000"},
+				pubKey:     &privateKey.PublicKey,
+				Info:       &models.DeviceInfo{ID: info.ID, PrettyName: info.PrettyName, Version: info.Version, Arch: info.Arch, Platform: info.Platform},
+				cli:        clientMocks,
+				serverInfo: tc.serverInfo,
+				infoHash:   tc.infoHash,
+			}
+
+			err := agent.authorize()
+			assert.ErrorIs(t, err, tc.expected)
+
+			if tc.expected == nil {
+				assert.Equal(t, info.Hash(), agent.infoHash)
+			}
+
+			clientMocks.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAgent_authorizeWithEnrollToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	clientMocks := new(client_mocks.Client)
+	clientMocks.
+		On("AuthDevice", mock.MatchedBy(func(req *models.DeviceAuthRequest) bool {
+			return req.TenantID == "" && req.EnrollToken == "token"
+		})).
+		Return(&models.DeviceAuthResponse{TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+		Once()
+	clientMocks.
+		On("AuthDevice", mock.MatchedBy(func(req *models.DeviceAuthRequest) bool {
+			return req.TenantID == "00000000-0000-4000-0000-000000000000" && req.EnrollToken == ""
+		})).
+		Return(&models.DeviceAuthResponse{TenantID: "00000000-0000-4000-0000-000000000000"}, nil).
+		Once()
+
+	agent := &Agent{
+		config:     &Config{EnrollToken: "token"},
+		pubKey:     &privateKey.PublicKey,
+		cli:        clientMocks,
+		serverInfo: &models.Info{},
+	}
+
+	require.NoError(t, agent.authorize())
+	assert.Equal(t, "00000000-0000-4000-0000-000000000000", agent.config.TenantID)
+
+	// NOTICE: the tenant ID learned is presented on the next authorization, instead of the token.
+	require.NoError(t, agent.authorize())
+
+	clientMocks.AssertExpectations(t)
+}
+
+func TestAgent_applyDeviceConfig(t *testing.T) {
+	level := log.GetLevel()
+	t.Cleanup(func() { log.SetLevel(level) })
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	config := &models.DeviceConfig{Values: map[string]string{models.DeviceConfigLogLevel: "trace"}, Version: 2}
+
+	clientMocks := new(client_mocks.Client)
+	clientMocks.
+		On("AuthDevice", mock.MatchedBy(func(req *models.DeviceAuthRequest) bool { return req.ConfigVersion == 0 })).
+		Return(&models.DeviceAuthResponse{Config: config}, nil).
+		Once()
+	clientMocks.
+		On("AuthDevice", mock.MatchedBy(func(req *models.DeviceAuthRequest) bool { return req.ConfigVersion == 2 })).
+		Return(&models.DeviceAuthResponse{Config: config}, nil).
+		Once()
+
+	agent := &Agent{
+		config:     &Config{TenantID: "00000000-0000-4000-0000-000000000000"},
+		pubKey:     &privateKey.PublicKey,
+		cli:        clientMocks,
+		serverInfo: &models.Info{},
+	}
+
+	require.NoError(t, agent.authorize())
+	assert.Equal(t, log.TraceLevel, log.GetLevel())
+	assert.Equal(t, config, agent.deviceConfig)
+
+	// NOTICE: the applied version is reported on the next authorization.
+	require.NoError(t, agent.authorize())
+
+	clientMocks.AssertExpectations(t)
+}
@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+	"github.com/shellhub-io/shellhub/pkg/api/client"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// QueuedCommandDefaultTimeout is the default maximum time a queued command can run before being killed.
+const QueuedCommandDefaultTimeout = 10 * time.Minute
+
+// startQueuedCommands executes, in background, the commands queued to the device while it was offline. It does nothing
+// when the queued commands are disabled or the previous ones are still running.
+func (a *Agent) startQueuedCommands() {
+	// NOTICE: the queued commands are executed through the host's shell, so they aren't available in connector mode.
+	if _, ok := a.mode.(*HostMode); !ok || !a.config.QueuedCommands || a.authData == nil {
+		return
+	}
+
+	if !a.commandsRunning.CompareAndSwap(false, true) {
+		return
+	}
+
+	go func() {
+		defer a.commandsRunning.Store(false)
+
+		a.runQueuedCommands(QueuedCommandDefaultTimeout)
+	}()
+}
+
+// runQueuedCommands fetches the commands queued to the device and executes them, one at a time and in the order they
+// were queued, reporting the result of each one to the server.
+func (a *Agent) runQueuedCommands(timeout time.Duration) {
+	commands, err := a.cli.FetchDeviceCommands(a.authData.UID, a.authData.Token)
+	if err != nil {
+		// NOTICE: servers without the queued commands don't have the route to fetch them.
+		if errors.Is(err, client.ErrNotFound) {
+			log.WithError(err).Debug("Server doesn't support queued commands")
+
+			return
+		}
+
+		log.WithError(err).Warn("Failed to fetch the queued commands from the server")
+
+		return
+	}
+
+	for _, queued := range commands {
+		logger := log.WithFields(log.Fields{
+			"id":   queued.ID,
+			"user": queued.User,
+		})
+
+		result := execQueuedCommand(a.authData.Name, queued, timeout)
+
+		logger.WithField("exit_code", result.ExitCode).Info("Queued command executed")
+
+		if err := a.cli.ReportDeviceCommand(a.authData.UID, queued.ID, result, a.authData.Token); err != nil {
+			logger.WithError(err).Warn("Failed to report the queued command's result to the server")
+		}
+	}
+}
+
+// execQueuedCommand executes the queued command through the shell, as the command's user, killing it when it runs for
+// longer than timeout. When the command cannot be started, the exit code is -1 and the output is the failure's reason.
+func execQueuedCommand(deviceName string, queued models.DeviceCommand, timeout time.Duration) *models.DeviceCommandResult {
+	user, err := osauth.LookupUser(queued.User)
+	if err != nil {
+		return &models.DeviceCommandResult{ExitCode: -1, Output: fmt.Sprintf("user %s not found on the device", queued.User)}
+	}
+
+	output := &limitedBuffer{limit: models.DeviceCommandOutputMaxSize}
+
+	cmd := command.NewCmd(user, user.Shell, "", deviceName, nil, command.ShellArgs(command.DefaultShell, queued.Command)...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// NOTICE: the processes started by the command may keep its output open after it was killed, so the output isn't
+	// waited for longer than this.
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Start(); err != nil {
+		return &models.DeviceCommandResult{ExitCode: -1, Output: err.Error()}
+	}
+
+	timer := time.AfterFunc(timeout, func() {
+		cmd.Process.Kill() //nolint:errcheck
+	})
+	defer timer.Stop()
+
+	result := &models.DeviceCommandResult{}
+
+	var exitErr *exec.ExitError
+	switch err := cmd.Wait(); {
+	case err == nil:
+		result.ExitCode = 0
+	case errors.Is(err, exec.ErrWaitDelay):
+		// NOTICE: the command has exited, but a process started by it, like a daemon, kept its output open.
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	case errors.As(err, &exitErr):
+		// NOTICE: a command killed by a signal, like the one sent on timeout, exits with -1.
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.ExitCode = -1
+	}
+
+	result.Output = output.String()
+
+	return result
+}
+
+// limitedBuffer is a [bytes.Buffer] that discards the bytes written beyond its limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room < len(p) {
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+
+		// NOTICE: the whole write is reported as done, so the command isn't stopped by a short write.
+		return len(p), nil
+	}
+
+	return b.Buffer.Write(p)
+}
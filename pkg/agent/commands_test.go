@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	osauth_mocks "github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/client"
+	client_mocks "github.com/shellhub-io/shellhub/pkg/api/client/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunQueuedCommands(t *testing.T) {
+	current, err := user.Current()
+	require.NoError(t, err)
+
+	uid, err := strconv.ParseUint(current.Uid, 10, 32)
+	require.NoError(t, err)
+
+	gid, err := strconv.ParseUint(current.Gid, 10, 32)
+	require.NoError(t, err)
+
+	backend := osauth.DefaultBackend
+	t.Cleanup(func() { osauth.DefaultBackend = backend })
+
+	osauthMock := new(osauth_mocks.Backend)
+	osauth.DefaultBackend = osauthMock
+
+	osauthMock.On("LookupUser", current.Username).Return(&osauth.User{
+		UID:      uint32(uid),
+		GID:      uint32(gid),
+		Username: current.Username,
+		HomeDir:  os.TempDir(),
+		Shell:    "/bin/sh",
+	}, nil)
+	osauthMock.On("LookupUser", "unknown").Return(nil, errors.New("user not found"))
+
+	authData := &models.DeviceAuthResponse{UID: "uid", Token: "token", Name: "device"}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(clientMock *client_mocks.Client)
+	}{
+		{
+			description: "does nothing when the server doesn't support queued commands",
+			requiredMocks: func(clientMock *client_mocks.Client) {
+				clientMock.On("FetchDeviceCommands", "uid", "token").Return(nil, client.ErrNotFound).Once()
+			},
+		},
+		{
+			description: "reports the result of each queued command",
+			requiredMocks: func(clientMock *client_mocks.Client) {
+				clientMock.On("FetchDeviceCommands", "uid", "token").Return([]models.DeviceCommand{
+					{ID: "succeeds", Command: "echo updated", User: current.Username},
+					{ID: "fails", Command: "echo failed >&2; exit 3", User: current.Username},
+					{ID: "times out", Command: "sleep 10", User: current.Username},
+					{ID: "unknown user", Command: "true", User: "unknown"},
+				}, nil).Once()
+
+				clientMock.On("ReportDeviceCommand", "uid", "succeeds", &models.DeviceCommandResult{ExitCode: 0, Output: "updated\n"}, "token").Return(nil).Once()
+				clientMock.On("ReportDeviceCommand", "uid", "fails", &models.DeviceCommandResult{ExitCode: 3, Output: "failed\n"}, "token").Return(nil).Once()
+				clientMock.On("ReportDeviceCommand", "uid", "times out", &models.DeviceCommandResult{ExitCode: -1, Output: ""}, "token").Return(nil).Once()
+				clientMock.On("ReportDeviceCommand", "uid", "unknown user", &models.DeviceCommandResult{ExitCode: -1, Output: "user unknown not found on the device"}, "token").Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			clientMock := new(client_mocks.Client)
+			tc.requiredMocks(clientMock)
+
+			agent := &Agent{
+				config:   &Config{QueuedCommands: true},
+				authData: authData,
+				cli:      clientMock,
+			}
+
+			agent.runQueuedCommands(500 * time.Millisecond)
+
+			clientMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buffer := &limitedBuffer{limit: 8}
+
+	n, err := buffer.Write([]byte("hello "))
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+
+	n, err = buffer.Write([]byte("world"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	n, err = buffer.Write([]byte("!"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	assert.Equal(t, "hello wo", buffer.String())
+}
@@ -18,6 +18,8 @@ type Container struct {
 	Name string
 	// ServerAddress is the ShellHub address of the server that the agent will connect to.
 	ServerAddress string
+	// ServerCA is the CA bundle trusted to verify the server's TLS certificate.
+	ServerCA string
 	// Tenant is the tenant ID of the namespace that the agent belongs to.
 	Tenant string
 	// PrivateKey is the private key of the device. Specify the path to store the container private key. If not
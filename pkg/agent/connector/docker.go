@@ -24,6 +24,8 @@ type DockerConnector struct {
 	mu sync.Mutex
 	// server is the ShellHub address of the server that the agent will connect to.
 	server string
+	// serverCA is the CA bundle trusted to verify the server's TLS certificate.
+	serverCA string
 	// tenant is the tenant ID of the namespace that the agent belongs to.
 	tenant string
 	// cli is the Docker client.
@@ -41,6 +43,10 @@ type Config struct {
 	// This is required.
 	ServerAddress string `env:"SERVER_ADDRESS,required"`
 
+	// ServerCA is the path to a PEM file, or the PEM encoded certificates inline, of a CA bundle trusted to verify
+	// the server's TLS certificate, besides the system's ones.
+	ServerCA string `env:"SERVER_CA"`
+
 	// Specify the path to store the devices/containers private keys.
 	// If not provided, the agent will generate a new one.
 	// This is required.
@@ -73,9 +79,10 @@ func LoadConfigFromEnv() (*Config, map[string]interface{}, error) {
 	return cfg, nil, nil
 }
 
-func NewDockerConnectorWithClient(cli *dockerclient.Client, server string, tenant string, privateKey string) (Connector, error) {
+func NewDockerConnectorWithClient(cli *dockerclient.Client, server string, serverCA string, tenant string, privateKey string) (Connector, error) {
 	return &DockerConnector{
 		server:      server,
+		serverCA:    serverCA,
 		tenant:      tenant,
 		cli:         cli,
 		privateKeys: privateKey,
@@ -84,7 +91,7 @@ func NewDockerConnectorWithClient(cli *dockerclient.Client, server string, tenan
 }
 
 // NewDockerConnector creates a new [Connector] that uses Docker as the container runtime.
-func NewDockerConnector(server string, tenant string, privateKey string) (Connector, error) {
+func NewDockerConnector(server string, serverCA string, tenant string, privateKey string) (Connector, error) {
 	cli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, err
@@ -92,6 +99,7 @@ func NewDockerConnector(server string, tenant string, privateKey string) (Connec
 
 	return &DockerConnector{
 		server:      server,
+		serverCA:    serverCA,
 		tenant:      tenant,
 		cli:         cli,
 		privateKeys: privateKey,
@@ -138,6 +146,7 @@ func (d *DockerConnector) Start(ctx context.Context, id string, name string) {
 		ID:            id,
 		Name:          name,
 		ServerAddress: d.server,
+		ServerCA:      d.serverCA,
 		Tenant:        d.tenant,
 		PrivateKey:    privateKey,
 		Cancel:        d.cancels[id],
@@ -207,7 +216,7 @@ func (d *DockerConnector) Listen(ctx context.Context) error {
 }
 
 func (d *DockerConnector) CheckUpdate() (*semver.Version, error) {
-	api, err := client.NewClient(d.server)
+	api, err := client.NewClient(d.server, client.WithServerCA(d.serverCA))
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"version": ConnectorVersion,
@@ -236,6 +245,7 @@ func initContainerAgent(ctx context.Context, cli *dockerclient.Client, container
 	// TODO: Let this configuration build next to the Agent [agent.LoadConfigFromEnv] function.
 	cfg := &agent.Config{
 		ServerAddress:             container.ServerAddress,
+		ServerCA:                  container.ServerCA,
 		TenantID:                  container.Tenant,
 		PrivateKey:                container.PrivateKey,
 		PreferredIdentity:         container.ID,
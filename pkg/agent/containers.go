@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// containersRefreshEvents are the Docker's container events that change the list of containers running on the device.
+var containersRefreshEvents = []events.Action{
+	events.ActionStart,
+	events.ActionDie,
+	events.ActionDestroy,
+	events.ActionRename,
+	events.ActionPause,
+	events.ActionUnPause,
+}
+
+// Containers keeps the list of Docker containers running on the device, refreshing it when the container lifecycle
+// events are received from the Docker Engine.
+type Containers struct {
+	mu   sync.RWMutex
+	cli  dockerclient.APIClient
+	list []models.DeviceContainer
+}
+
+// NewContainers creates a new [Containers] using cli to communicate with the Docker Engine.
+func NewContainers(cli dockerclient.APIClient) *Containers {
+	return &Containers{
+		cli:  cli,
+		list: []models.DeviceContainer{},
+	}
+}
+
+// List returns the last known list of containers running on the device.
+func (c *Containers) List() []models.DeviceContainer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	list := make([]models.DeviceContainer, len(c.list))
+	copy(list, c.list)
+
+	return list
+}
+
+// Refresh gets the containers running on the device from the Docker Engine.
+func (c *Containers) Refresh(ctx context.Context) error {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	list := make([]models.DeviceContainer, len(containers))
+	for i, container := range containers {
+		list[i] = models.DeviceContainer{
+			ID:     container.ID[:12],
+			Image:  container.Image,
+			State:  container.State,
+			Status: container.Status,
+		}
+
+		if len(container.Names) > 0 {
+			// NOTICE: It removes the first character on container's name that is a `/`.
+			list[i].Name = strings.TrimPrefix(container.Names[0], "/")
+		}
+	}
+
+	c.mu.Lock()
+	c.list = list
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Watch refreshes the list of containers every time a container lifecycle event is received from the Docker Engine.
+// When the events stream fails, it tries to subscribe again after 10 seconds. It blocks until the context is done.
+func (c *Containers) Watch(ctx context.Context) {
+	args := filters.NewArgs(filters.Arg("type", string(events.ContainerEventType)))
+	for _, action := range containersRefreshEvents {
+		args.Add("event", string(action))
+	}
+
+	for {
+		if err := c.Refresh(ctx); err != nil {
+			log.WithError(err).Error("Failed to list the containers running on the device")
+		}
+
+		msgs, errs := c.cli.Events(ctx, events.ListOptions{Filters: args})
+
+	events:
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				log.WithError(err).Warn("Docker events stream closed. Retry in 10 seconds")
+
+				break events
+			case msg := <-msgs:
+				log.WithFields(log.Fields{
+					"id":     msg.Actor.ID,
+					"action": msg.Action,
+				}).Debug("Container event received")
+
+				if err := c.Refresh(ctx); err != nil {
+					log.WithError(err).Error("Failed to list the containers running on the device")
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// containersHandler responds with the containers running on the device. When the containers listing isn't enabled on
+// the agent, it responds with the status not implemented.
+func containersHandler(containers *Containers) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		if containers == nil {
+			return c.NoContent(http.StatusNotImplemented)
+		}
+
+		return c.JSON(http.StatusOK, containers.List())
+	}
+}
@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainersHandler(t *testing.T) {
+	type expected struct {
+		status int
+		body   string
+	}
+
+	cases := []struct {
+		description string
+		containers  *Containers
+		expected    expected
+	}{
+		{
+			description: "responds not implemented when containers listing is disabled",
+			containers:  nil,
+			expected: expected{
+				status: http.StatusNotImplemented,
+				body:   "",
+			},
+		},
+		{
+			description: "responds with an empty list when no container is running",
+			containers:  NewContainers(nil),
+			expected: expected{
+				status: http.StatusOK,
+				body:   "[]\n",
+			},
+		},
+		{
+			description: "responds with the containers running on device",
+			containers: &Containers{
+				list: []models.DeviceContainer{
+					{ID: "000000000000", Name: "nginx", Image: "nginx:latest", State: "running", Status: "Up 2 hours"},
+				},
+			},
+			expected: expected{
+				status: http.StatusOK,
+				body:   `[{"id":"000000000000","name":"nginx","image":"nginx:latest","state":"running","status":"Up 2 hours"}]` + "\n",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/containers", nil)
+			rec := httptest.NewRecorder()
+
+			err := containersHandler(tc.containers)(echo.New().NewContext(req, rec))
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expected, expected{rec.Code, rec.Body.String()})
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/client"
+)
+
+// ExitCode is the status the agent exits with, by the class of the failure that stopped it, so the supervisors and
+// the provisioning scripts can decide whether restarting it is worthwhile.
+//
+// The codes follow the sysexits(3) convention, so they don't clash with the ones used by the shells and the
+// supervisors themselves.
+type ExitCode int
+
+const (
+	// ExitFailure is a failure without a class, like losing the connection to the server.
+	ExitFailure ExitCode = 1
+	// ExitTenantNotFound is a tenant ID that the server doesn't know.
+	ExitTenantNotFound ExitCode = 68
+	// ExitUpdateInProgress is an update, started by a previous agent, that couldn't be completed yet.
+	ExitUpdateInProgress ExitCode = 75
+	// ExitAuthRejected is a device that the server refuses to authorize.
+	ExitAuthRejected ExitCode = 77
+	// ExitConfigInvalid is a configuration missing a required value or having an invalid one.
+	ExitConfigInvalid ExitCode = 78
+)
+
+// String returns the name of the exit code's class, reported on the exit line.
+func (c ExitCode) String() string {
+	switch c {
+	case ExitTenantNotFound:
+		return "tenant_not_found"
+	case ExitUpdateInProgress:
+		return "update_in_progress"
+	case ExitAuthRejected:
+		return "auth_rejected"
+	case ExitConfigInvalid:
+		return "config_invalid"
+	default:
+		return "failure"
+	}
+}
+
+// ExitCodeFromError returns the exit code of the failure's class, returned by creating or initializing the agent.
+func ExitCodeFromError(err error) ExitCode {
+	switch {
+	case errors.Is(err, ErrTenantNotFound):
+		return ExitTenantNotFound
+	case errors.Is(err, ErrAuthRejected):
+		return ExitAuthRejected
+	case errors.Is(err, ErrNewAgentWithConfigEmptyServerAddress),
+		errors.Is(err, ErrNewAgentWithConfigInvalidServerAddress),
+		errors.Is(err, ErrNewAgentWithConfigEmptyTenant),
+		errors.Is(err, ErrNewAgentWithConfigEmptyPrivateKey),
+		errors.Is(err, ErrNewAgentWithConfigInvalidForwardPolicy),
+		errors.Is(err, client.ErrParseAddress),
+		errors.Is(err, client.ErrServerCARead),
+		errors.Is(err, client.ErrServerCAParse):
+		return ExitConfigInvalid
+	default:
+		return ExitFailure
+	}
+}
+
+// exitLine is the machine-readable line written on the agent's exit.
+type exitLine struct {
+	Time     time.Time `json:"time"`
+	ExitCode int       `json:"exit_code"`
+	Class    string    `json:"class"`
+	Error    string    `json:"error"`
+}
+
+// writeExitLine writes the failure, with the exit code of its class, as a JSON line.
+func writeExitLine(w io.Writer, code ExitCode, err error) error {
+	line := exitLine{
+		Time:     time.Now().UTC(),
+		ExitCode: int(code),
+		Class:    code.String(),
+	}
+
+	if err != nil {
+		line.Error = err.Error()
+	}
+
+	return json.NewEncoder(w).Encode(line)
+}
+
+// Exit writes the failure that stops the agent as a JSON line on the standard error and exits with the exit code of
+// its class. The line is written apart from the logs, whose format is configured by the user, so it can always be
+// parsed.
+func Exit(code ExitCode, err error) {
+	writeExitLine(os.Stderr, code, err) //nolint:errcheck
+
+	os.Exit(int(code))
+}
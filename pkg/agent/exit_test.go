@@ -0,0 +1,67 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCodeFromError(t *testing.T) {
+	cases := []struct {
+		description string
+		err         error
+		expected    ExitCode
+	}{
+		{
+			description: "classifies the unknown tenant",
+			err:         fmt.Errorf("failed to authorize device: %w", fmt.Errorf("%w: %w", ErrTenantNotFound, client.ErrNotFound)),
+			expected:    ExitTenantNotFound,
+		},
+		{
+			description: "classifies the rejected authorization",
+			err:         fmt.Errorf("failed to authorize device: %w", fmt.Errorf("%w: %w", ErrAuthRejected, client.ErrForbidden)),
+			expected:    ExitAuthRejected,
+		},
+		{
+			description: "classifies the invalid configuration",
+			err:         ErrNewAgentWithConfigEmptyTenant,
+			expected:    ExitConfigInvalid,
+		},
+		{
+			description: "classifies the unreadable server's CA",
+			err:         fmt.Errorf("failed to create the HTTP client: %w", client.ErrServerCARead),
+			expected:    ExitConfigInvalid,
+		},
+		{
+			description: "doesn't classify the other failures",
+			err:         errors.New("connection refused"),
+			expected:    ExitFailure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ExitCodeFromError(tc.err))
+		})
+	}
+}
+
+func TestWriteExitLine(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	require.NoError(t, writeExitLine(buffer, ExitAuthRejected, ErrAuthRejected))
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &line))
+
+	assert.Equal(t, float64(77), line["exit_code"])
+	assert.Equal(t, "auth_rejected", line["class"])
+	assert.Equal(t, "device's authorization was rejected", line["error"])
+	assert.NotEmpty(t, line["time"])
+	assert.Equal(t, byte('\n'), buffer.Bytes()[buffer.Len()-1])
+}
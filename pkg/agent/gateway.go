@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/api/client"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/revdial"
+	log "github.com/sirupsen/logrus"
+)
+
+// gatewayProbeTimeout is the maximum time waited to measure the round-trip time to a gateway.
+const gatewayProbeTimeout = 3 * time.Second
+
+// gatewayProber measures the round-trip time to the gateway's address.
+type gatewayProber func(ctx context.Context, address string) (time.Duration, error)
+
+// probeGateway measures the round-trip time to the gateway's address by the time taken to open a TCP connection to it.
+func probeGateway(ctx context.Context, address string) (time.Duration, error) {
+	uri, err := url.Parse(address)
+	if err != nil {
+		return 0, err
+	}
+
+	port := uri.Port()
+	if port == "" {
+		port = "80"
+		if uri.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gatewayProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	conn, err := new(net.Dialer).DialContext(ctx, "tcp", net.JoinHostPort(uri.Hostname(), port))
+	if err != nil {
+		return 0, err
+	}
+
+	defer conn.Close()
+
+	return time.Since(start), nil
+}
+
+// rankGateways orders the gateways from the nearest to the farthest, by the round-trip time measured to each of them.
+// The gateways that couldn't be measured come last, ordered by the latency hinted by the server.
+func rankGateways(ctx context.Context, gateways []models.Gateway, probe gatewayProber) []models.Gateway {
+	rtts := make([]time.Duration, len(gateways))
+
+	var wg sync.WaitGroup
+	for i, gateway := range gateways {
+		wg.Add(1)
+
+		go func(i int, gateway models.Gateway) {
+			defer wg.Done()
+
+			rtt, err := probe(ctx, gateway.URL)
+			if err != nil {
+				log.WithError(err).WithField("region", gateway.Region).Debug("failed to measure the latency to the gateway")
+
+				rtt = -1
+			}
+
+			rtts[i] = rtt
+		}(i, gateway)
+	}
+
+	wg.Wait()
+
+	indexes := make([]int, len(gateways))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		switch measuredA, measuredB := rtts[a] >= 0, rtts[b] >= 0; {
+		case measuredA && measuredB:
+			return cmp.Compare(rtts[a], rtts[b])
+		case measuredA:
+			return -1
+		case measuredB:
+			return 1
+		default:
+			return cmp.Compare(gateways[a].Latency, gateways[b].Latency)
+		}
+	})
+
+	ranked := make([]models.Gateway, len(gateways))
+	for i, index := range indexes {
+		ranked[i] = gateways[index]
+	}
+
+	return ranked
+}
+
+// reverseListener opens the reverse tunnel, returning its listener and the SSH endpoint of the server where it was
+// opened. When the server advertises gateways, the tunnel is opened to the nearest one, failing over to the others and,
+// at last, to the server's address.
+func (a *Agent) reverseListener(ctx context.Context) (*revdial.Listener, string, error) {
+	connPath := tunnelConnectionPath(a.serverInfo.Endpoints)
+
+	for _, gateway := range rankGateways(ctx, a.serverInfo.Endpoints.Gateways, probeGateway) {
+		logger := log.WithFields(log.Fields{"region": gateway.Region, "gateway": gateway.URL})
+
+		cli, err := a.gatewayClient(gateway.URL)
+		if err != nil {
+			logger.WithError(err).Warn("failed to create the client to the gateway")
+
+			continue
+		}
+
+		listener, err := cli.NewReverseListener(ctx, a.authData.Token, connPath)
+		if err != nil {
+			logger.WithError(err).Warn("failed to connect to the gateway, trying the next one")
+
+			continue
+		}
+
+		logger.Info("Connected to the gateway")
+
+		return listener, cmp.Or(gateway.SSH, a.serverInfo.Endpoints.SSH), nil
+	}
+
+	listener, err := a.cli.NewReverseListener(ctx, a.authData.Token, connPath)
+
+	return listener, a.serverInfo.Endpoints.SSH, err
+}
+
+// gatewayClient returns the client to the gateway's address, creating it on the first use.
+func (a *Agent) gatewayClient(address string) (client.Client, error) {
+	if cli, ok := a.gateways[address]; ok {
+		return cli, nil
+	}
+
+	cli, err := client.NewClient(address, client.WithServerCA(a.config.ServerCA))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the HTTP client")
+	}
+
+	if a.gateways == nil {
+		a.gateways = make(map[string]client.Client)
+	}
+
+	a.gateways[address] = cli
+
+	return cli, nil
+}
@@ -0,0 +1,67 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankGateways(t *testing.T) {
+	gateways := []models.Gateway{
+		{Region: "us-east", URL: "https://us.shellhub.io", Latency: 120},
+		{Region: "eu-west", URL: "https://eu.shellhub.io", Latency: 40},
+		{Region: "sa-east", URL: "https://sa.shellhub.io", Latency: 80},
+	}
+
+	cases := []struct {
+		description string
+		rtts        map[string]time.Duration
+		expected    []string
+	}{
+		{
+			description: "orders the gateways by the measured latency",
+			rtts: map[string]time.Duration{
+				"https://us.shellhub.io": 30 * time.Millisecond,
+				"https://eu.shellhub.io": 90 * time.Millisecond,
+				"https://sa.shellhub.io": 10 * time.Millisecond,
+			},
+			expected: []string{"sa-east", "us-east", "eu-west"},
+		},
+		{
+			description: "orders the gateways not measured by the latency hinted",
+			rtts: map[string]time.Duration{
+				"https://us.shellhub.io": 30 * time.Millisecond,
+			},
+			expected: []string{"us-east", "eu-west", "sa-east"},
+		},
+		{
+			description: "orders the gateways by the latency hinted when none is measured",
+			rtts:        map[string]time.Duration{},
+			expected:    []string{"eu-west", "sa-east", "us-east"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			probe := func(_ context.Context, address string) (time.Duration, error) {
+				rtt, ok := tc.rtts[address]
+				if !ok {
+					return 0, errors.New("unreachable")
+				}
+
+				return rtt, nil
+			}
+
+			regions := []string{}
+			for _, gateway := range rankGateways(context.Background(), gateways, probe) {
+				regions = append(regions, gateway.Region)
+			}
+
+			assert.Equal(t, tc.expected, regions)
+		})
+	}
+}
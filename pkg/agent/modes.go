@@ -3,17 +3,27 @@ package agent
 import (
 	"context"
 	"os/exec"
+	"strings"
+	"time"
 
 	dockerclient "github.com/docker/docker/client"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/provisioner"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sysinfo"
 	"github.com/shellhub-io/shellhub/pkg/agent/server"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host"
+	log "github.com/sirupsen/logrus"
 )
 
 type Info struct {
 	ID   string
 	Name string
+	// Hardware is the hardware inventory of the system where the Agent is running. It is nil when it cannot be
+	// gathered, or when the Agent's mode doesn't report it.
+	Hardware *sysinfo.Hardware
+	// Interfaces are the network interfaces of the system where the Agent is running. It is nil when they cannot be
+	// gathered, or when the Agent's mode doesn't report them.
+	Interfaces []sysinfo.Interface
 }
 
 // Mode is the Agent execution mode.
@@ -38,20 +48,60 @@ type HostMode struct{}
 var _ Mode = new(HostMode)
 
 func (m *HostMode) Serve(agent *Agent) {
+	mode := &host.Mode{
+		Authenticator: *host.NewAuthenticator(agent.cli, agent.authData, agent.config.SingleUserPassword, &agent.authData.Name),
+		Sessioner:     *host.NewSessioner(&agent.authData.Name, make(map[string]*exec.Cmd)),
+	}
+
+	// NOTICE: The sandbox is only available in single-user mode, when the agent isn't running as root.
+	if agent.config.SingleUserPassword != "" && agent.config.SingleUserSandbox {
+		mode.Sessioner.SetSandbox(true)
+	}
+
+	// NOTICE: The users can only be provisioned in multi-user mode, when the agent is running as root.
+	if agent.config.UserProvisioning {
+		if agent.config.SingleUserPassword == "" {
+			agent.provisioner = provisioner.New(agent.config.UserProvisioningState)
+			agent.provisioner.SetPolicy(agent.authData.UserProvisioning)
+
+			mode.Authenticator.SetProvisioner(agent.provisioner)
+		} else {
+			log.Warn("The user provisioning isn't available in single-user mode")
+		}
+	}
+
 	agent.server = server.NewServer(
 		agent.cli,
-		&host.Mode{
-			Authenticator: *host.NewAuthenticator(agent.cli, agent.authData, agent.config.SingleUserPassword, &agent.authData.Name),
-			Sessioner:     *host.NewSessioner(&agent.authData.Name, make(map[string]*exec.Cmd)),
-		},
+		mode,
 		&server.Config{
 			PrivateKey:        agent.config.PrivateKey,
 			KeepAliveInterval: agent.config.KeepAliveInterval,
 			Features:          server.LocalPortForwardFeature,
+			Hooks: server.SessionHooks{
+				Pre:      agent.config.PreSessionHook,
+				Post:     agent.config.PostSessionHook,
+				Required: agent.config.PreSessionHookRequired,
+				Timeout:  time.Duration(agent.config.SessionHookTimeout) * time.Second,
+			},
+			LoginShells:   splitList(agent.config.LoginShells),
+			ForwardPolicy: agent.forwardPolicy,
 		},
 	)
 
 	agent.server.SetDeviceName(agent.authData.Name)
+	agent.applyServerConfig()
+}
+
+// splitList parses a comma-separated list of the agent's configuration, like the login shells allowed by the agent.
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
 }
 
 func (m *HostMode) GetInfo() (*Info, error) {
@@ -60,9 +110,22 @@ func (m *HostMode) GetInfo() (*Info, error) {
 		return nil, err
 	}
 
+	// NOTICE: the hardware inventory is optional, so a failure to gather it doesn't prevent the Agent from starting.
+	hardware, err := sysinfo.GetHardware()
+	if err != nil {
+		log.WithError(err).Warn("failed to gather the hardware inventory")
+	}
+
+	interfaces, err := sysinfo.GetInterfaces()
+	if err != nil {
+		log.WithError(err).Warn("failed to gather the network interfaces")
+	}
+
 	return &Info{
-		ID:   osrelease.ID,
-		Name: osrelease.Name,
+		ID:         osrelease.ID,
+		Name:       osrelease.Name,
+		Hardware:   hardware,
+		Interfaces: interfaces,
 	}, nil
 }
 
@@ -105,6 +168,7 @@ func (m *ConnectorMode) Serve(agent *Agent) {
 
 	agent.server.SetContainerID(agent.Identity.MAC)
 	agent.server.SetDeviceName(agent.authData.Name)
+	agent.applyServerConfig()
 }
 
 func (m *ConnectorMode) GetInfo() (*Info, error) {
@@ -0,0 +1,34 @@
+//go:build windows
+// +build windows
+
+package osauth
+
+import (
+	"os"
+)
+
+// windowsBackend is the [Backend] on Windows, where the agent only runs in single-user mode. Every session runs as the
+// account running the agent, so the users are resolved to it and the Windows' passwords are never checked: the
+// sessions authenticate through the single-user password or the public keys.
+type windowsBackend struct{}
+
+func (b *windowsBackend) AuthUser(_, _ string) bool {
+	return false
+}
+
+func (b *windowsBackend) LookupUser(_ string) (*User, error) {
+	user := singleUser()
+	if user.Shell == "" {
+		user.Shell = os.Getenv("COMSPEC")
+	}
+
+	if user.Shell == "" {
+		user.Shell = "cmd.exe"
+	}
+
+	return user, nil
+}
+
+func init() {
+	DefaultBackend = &windowsBackend{}
+}
@@ -0,0 +1,8 @@
+//go:build docker
+// +build docker
+
+package provisioner
+
+func init() {
+	DefaultRoot = "/host"
+}
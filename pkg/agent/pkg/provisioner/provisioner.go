@@ -0,0 +1,264 @@
+// Package provisioner creates, on demand, the local users targeted by the SSH sessions when they don't exist on the
+// device, as allowed by the namespace's [models.UserProvisioning], and removes them once expired.
+//
+// The users are created and removed with the shadow-utils' useradd and userdel, so the agent must run as root in host
+// mode. The provisioned users are recorded on a file, so they are removed even when the agent is restarted meanwhile.
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultRoot is the directory the users are created on, used as the useradd's and userdel's chroot when it isn't
+// the root directory, like when the agent runs in a container with the host's file system mounted.
+var DefaultRoot = "/"
+
+// DefaultCleanupInterval is the interval the expired users are removed on.
+const DefaultCleanupInterval = 10 * time.Minute
+
+// Comment is the comment, on the GECOS field, of the provisioned users.
+const Comment = "ShellHub provisioned user"
+
+var (
+	// ErrNotAllowed is returned when the namespace doesn't allow the user to be provisioned.
+	ErrNotAllowed = errors.New("user provisioning isn't allowed")
+	// ErrUsername is returned when the username cannot be given to a provisioned user.
+	ErrUsername = errors.New("username cannot be provisioned")
+)
+
+// User is a user created by the provisioner.
+type User struct {
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Provisioner creates the local users allowed by the namespace's user provisioning and removes them once expired.
+type Provisioner struct {
+	// path is the file where the provisioned users are recorded.
+	path string
+	// root is the directory the users are created on.
+	root string
+
+	mu sync.Mutex
+	// policy is the namespace's user provisioning, delivered on each authorization of the device.
+	policy *models.UserProvisioning
+
+	// run runs a command, returning its error and combined output.
+	run func(name string, args ...string) ([]byte, error)
+	// lookup reports if a user exists on the device.
+	lookup func(username string) bool
+}
+
+// New creates a [Provisioner] that records the provisioned users on the file at path.
+func New(path string) *Provisioner {
+	return &Provisioner{
+		path: path,
+		root: DefaultRoot,
+		run: func(name string, args ...string) ([]byte, error) {
+			return exec.Command(name, args...).CombinedOutput() //nolint:gosec
+		},
+		lookup: func(username string) bool {
+			_, err := osauth.LookupUser(username)
+
+			return err == nil
+		},
+	}
+}
+
+// SetPolicy sets the namespace's user provisioning. A nil policy doesn't allow any user to be provisioned, but the
+// users already provisioned are still removed once expired.
+func (p *Provisioner) SetPolicy(policy *models.UserProvisioning) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.policy = policy
+}
+
+// Allowed reports if the user may be provisioned. It is false on a nil [Provisioner].
+func (p *Provisioner) Allowed(username string) bool {
+	if p == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.policy.Allowed() && models.IsUserProvisionable(username)
+}
+
+// Provision creates the user, with the groups, the shell and the expiry of the namespace's user provisioning, when it
+// doesn't exist.
+func (p *Provisioner) Provision(username string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.policy.Allowed() {
+		return ErrNotAllowed
+	}
+
+	if !models.IsUserProvisionable(username) {
+		return ErrUsername
+	}
+
+	// NOTICE: the user may have been provisioned by a concurrent session meanwhile.
+	if p.lookup(username) {
+		return nil
+	}
+
+	users, err := p.load()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := clock.Now().Add(p.policy.Lifetime())
+
+	args := []string{"--create-home", "--comment", Comment}
+	if p.root != "/" {
+		args = append(args, "--root", p.root)
+	}
+
+	if p.policy.Shell != "" {
+		args = append(args, "--shell", p.policy.Shell)
+	}
+
+	if len(p.policy.Groups) > 0 {
+		args = append(args, "--groups", strings.Join(p.policy.Groups, ","))
+	}
+
+	// NOTICE: the account's expiration has the precision of days, so the account is also disabled by the system on
+	// the day after the expiry, when the agent doesn't remove it in time.
+	args = append(args, "--expiredate", expiresAt.UTC().AddDate(0, 0, 1).Format(time.DateOnly), username)
+
+	if output, err := p.run("useradd", args...); err != nil {
+		return fmt.Errorf("failed to create the user: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	users = append(users, User{Username: username, ExpiresAt: expiresAt})
+
+	return p.save(users)
+}
+
+// Cleanup removes the provisioned users expired, with their home directories. The users that couldn't be removed, like
+// the ones still logged in, are kept to the next cleanup.
+func (p *Provisioner) Cleanup() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	users, err := p.load()
+	if err != nil {
+		return err
+	}
+
+	now := clock.Now()
+
+	kept := make([]User, 0, len(users))
+	for _, user := range users {
+		if now.Before(user.ExpiresAt) {
+			kept = append(kept, user)
+
+			continue
+		}
+
+		if p.lookup(user.Username) {
+			args := []string{"--remove"}
+			if p.root != "/" {
+				args = append(args, "--root", p.root)
+			}
+
+			if output, err := p.run("userdel", append(args, user.Username)...); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"username": user.Username,
+					"output":   strings.TrimSpace(string(output)),
+				}).Warn("failed to remove the expired provisioned user")
+
+				kept = append(kept, user)
+
+				continue
+			}
+		}
+
+		log.WithField("username", user.Username).Info("expired provisioned user removed")
+	}
+
+	if len(kept) == len(users) {
+		return nil
+	}
+
+	return p.save(kept)
+}
+
+// Watch removes the expired users on each interval until ctx is done.
+func (p *Provisioner) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Cleanup(); err != nil {
+			log.WithError(err).Warn("failed to clean up the provisioned users")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Provisioner) load() ([]User, error) {
+	data, err := os.ReadFile(p.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []User{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	users := []User{}
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (p *Provisioner) save(users []User) error {
+	slices.SortFunc(users, func(a, b User) int { return strings.Compare(a.Username, b.Username) })
+
+	data, err := json.Marshal(users)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o700); err != nil {
+		return err
+	}
+
+	// NOTICE: the file is replaced atomically, so the record isn't lost when the agent stops while writing it.
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, p.path)
+}
@@ -0,0 +1,175 @@
+package provisioner
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fake is a device's users database, changed by the commands run by the provisioner.
+type fake struct {
+	users    map[string]bool
+	commands []string
+	err      error
+}
+
+func newProvisioner(t *testing.T, users ...string) (*Provisioner, *fake) {
+	t.Helper()
+
+	f := &fake{users: map[string]bool{}}
+	for _, user := range users {
+		f.users[user] = true
+	}
+
+	p := New(filepath.Join(t.TempDir(), "provisioned-users.json"))
+	p.run = func(name string, args ...string) ([]byte, error) {
+		f.commands = append(f.commands, name+" "+strings.Join(args, " "))
+		if f.err != nil {
+			return []byte("userdel: user is currently used by process 1"), f.err
+		}
+
+		username := args[len(args)-1]
+		switch name {
+		case "useradd":
+			f.users[username] = true
+		case "userdel":
+			delete(f.users, username)
+		}
+
+		return nil, nil
+	}
+	p.lookup = func(username string) bool { return f.users[username] }
+
+	return p, f
+}
+
+func TestProvisionerAllowed(t *testing.T) {
+	var p *Provisioner
+	assert.False(t, p.Allowed("contractor"))
+
+	p, _ = newProvisioner(t)
+	assert.False(t, p.Allowed("contractor"))
+
+	p.SetPolicy(&models.UserProvisioning{Enabled: false})
+	assert.False(t, p.Allowed("contractor"))
+
+	p.SetPolicy(&models.UserProvisioning{Enabled: true})
+	assert.True(t, p.Allowed("contractor"))
+	assert.False(t, p.Allowed("Contractor"))
+	assert.False(t, p.Allowed("../contractor"))
+}
+
+func TestProvisionerProvision(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	clockMock := new(clockmock.Clock)
+	backend := clock.DefaultBackend
+	clock.DefaultBackend = clockMock
+	t.Cleanup(func() { clock.DefaultBackend = backend })
+
+	clockMock.On("Now").Return(now)
+
+	t.Run("fails when the namespace doesn't allow it", func(t *testing.T) {
+		p, f := newProvisioner(t)
+
+		assert.ErrorIs(t, p.Provision("contractor"), ErrNotAllowed)
+		assert.Empty(t, f.commands)
+	})
+
+	t.Run("fails when the username cannot be provisioned", func(t *testing.T) {
+		p, f := newProvisioner(t)
+		p.SetPolicy(&models.UserProvisioning{Enabled: true})
+
+		assert.ErrorIs(t, p.Provision("-contractor"), ErrUsername)
+		assert.Empty(t, f.commands)
+	})
+
+	t.Run("fails when the user cannot be created", func(t *testing.T) {
+		p, f := newProvisioner(t)
+		p.SetPolicy(&models.UserProvisioning{Enabled: true})
+		f.err = errors.New("exit status 1")
+
+		assert.Error(t, p.Provision("contractor"))
+
+		users, err := p.load()
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("succeeds when the user already exists", func(t *testing.T) {
+		p, f := newProvisioner(t, "contractor")
+		p.SetPolicy(&models.UserProvisioning{Enabled: true})
+
+		assert.NoError(t, p.Provision("contractor"))
+		assert.Empty(t, f.commands)
+	})
+
+	t.Run("succeeds creating the user with the namespace's policy", func(t *testing.T) {
+		p, f := newProvisioner(t)
+		p.root = "/host"
+		p.SetPolicy(&models.UserProvisioning{Enabled: true, Groups: []string{"adm", "docker"}, Shell: "/bin/bash", Expiry: 3600})
+
+		require.NoError(t, p.Provision("contractor"))
+		assert.Equal(t, []string{
+			"useradd --create-home --comment ShellHub provisioned user --root /host --shell /bin/bash --groups adm,docker --expiredate 2026-01-02 contractor",
+		}, f.commands)
+
+		users, err := p.load()
+		require.NoError(t, err)
+		assert.Equal(t, []User{{Username: "contractor", ExpiresAt: now.Add(time.Hour)}}, users)
+	})
+}
+
+func TestProvisionerCleanup(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	clockMock := new(clockmock.Clock)
+	backend := clock.DefaultBackend
+	clock.DefaultBackend = clockMock
+	t.Cleanup(func() { clock.DefaultBackend = backend })
+
+	records := []User{
+		{Username: "expired", ExpiresAt: now.Add(-time.Minute)},
+		{Username: "gone", ExpiresAt: now.Add(-time.Hour)},
+		{Username: "valid", ExpiresAt: now.Add(time.Hour)},
+	}
+
+	t.Run("removes the expired users", func(t *testing.T) {
+		clockMock.On("Now").Return(now).Once()
+
+		p, f := newProvisioner(t, "expired", "valid")
+		require.NoError(t, p.save(append([]User{}, records...)))
+
+		require.NoError(t, p.Cleanup())
+		assert.Equal(t, []string{"userdel --remove expired"}, f.commands)
+		assert.Equal(t, map[string]bool{"valid": true}, f.users)
+
+		users, err := p.load()
+		require.NoError(t, err)
+		assert.Equal(t, []User{records[2]}, users)
+	})
+
+	t.Run("keeps the users that couldn't be removed", func(t *testing.T) {
+		clockMock.On("Now").Return(now).Once()
+
+		p, f := newProvisioner(t, "expired", "valid")
+		require.NoError(t, p.save(append([]User{}, records...)))
+		f.err = errors.New("exit status 8")
+
+		require.NoError(t, p.Cleanup())
+
+		users, err := p.load()
+		require.NoError(t, err)
+		assert.Equal(t, []User{records[0], records[2]}, users)
+	})
+
+	clockMock.AssertExpectations(t)
+}
@@ -0,0 +1,26 @@
+// Package sandbox isolates the programs started by the agent's sessions from the rest of the host.
+//
+// The sandbox is intended to be used in single-user mode, where the agent runs as a non-root user on a shared host, to
+// restrict what a session can see besides the user's home directory.
+//
+// As the isolation must be set up between the process creation and the program execution, a sandboxed command is run
+// through the agent's binary itself, with the [Subcommand] subcommand, that applies the restrictions and then executes
+// the original program.
+//
+// Only Linux is supported, where the sandbox relies on user and mount namespaces. On other platforms, [Supported] and
+// [Wrap] return [ErrUnsupported], so the agent refuses to start with the sandbox enabled.
+package sandbox
+
+import "errors"
+
+// Subcommand is the agent's subcommand that applies the sandbox restrictions before executing the session's program.
+const Subcommand = "sandbox"
+
+var (
+	// ErrUnsupported is returned when the sandbox isn't supported on the current platform.
+	ErrUnsupported = errors.New("sandbox is not supported on this platform")
+	// ErrPrivileged is returned when the agent is running as root, what the sandbox does not support.
+	ErrPrivileged = errors.New("sandbox is only supported when the agent runs as a non-root user")
+	// ErrInvalidArgs is returned when the [Subcommand] receives invalid arguments.
+	ErrInvalidArgs = errors.New("sandbox requires the home directory, the program path and its arguments")
+)
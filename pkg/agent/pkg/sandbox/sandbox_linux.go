@@ -0,0 +1,115 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// shared are the directories shared between the host's users that are replaced by empty ones inside the sandbox.
+var shared = []string{"/tmp", "/var/tmp", "/dev/shm"}
+
+// geteuid returns the effective user ID of the agent, replaced on tests.
+var geteuid = os.Geteuid
+
+// Supported checks if the sandbox can be used by the agent, returning [ErrPrivileged] when it runs as root.
+func Supported() error {
+	if geteuid() == 0 {
+		return ErrPrivileged
+	}
+
+	return nil
+}
+
+// Wrap changes cmd to run inside the sandbox, restricting the visibility of the file system to the user's home
+// directory. The command is started in new user and mount namespaces, where [Subcommand] hides the other users' home
+// directories and the shared temporary directories before executing the original program.
+func Wrap(cmd *exec.Cmd, home string) error {
+	if err := Supported(); err != nil {
+		return err
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	path := cmd.Path
+	args := cmd.Args
+
+	cmd.Path = self
+	cmd.Args = append([]string{self, Subcommand, home, path}, args...)
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS
+	cmd.SysProcAttr.UidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}}
+	cmd.SysProcAttr.GidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}}
+	cmd.SysProcAttr.GidMappingsEnableSetgroups = false
+	// NOTICE: As the user isn't root inside the namespace, the capability to mount is kept across the execution of
+	// the subcommand as an ambient capability, being dropped before the session's program is executed.
+	cmd.SysProcAttr.AmbientCaps = []uintptr{unix.CAP_SYS_ADMIN}
+
+	return nil
+}
+
+// Run applies the sandbox restrictions and replaces the current process with the program at path. It is intended to be
+// called by [Subcommand] in the namespaces created by [Wrap].
+func Run(home, path string, argv []string) error {
+	// NOTICE: Makes the mounts private to avoid the propagation of the changes below to the host.
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return err
+	}
+
+	parent := filepath.Dir(home)
+	if entries, err := os.ReadDir(parent); err == nil && parent != "/" {
+		for _, entry := range entries {
+			if !entry.IsDir() || filepath.Join(parent, entry.Name()) == home {
+				continue
+			}
+
+			if err := hide(filepath.Join(parent, entry.Name()), "0700"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := os.Stat("/root"); err == nil && !strings.HasPrefix(home+"/", "/root/") {
+		if err := hide("/root", "0700"); err != nil {
+			return err
+		}
+	}
+
+	for _, dir := range shared {
+		if _, err := os.Stat(dir); err != nil || strings.HasPrefix(home+"/", dir+"/") {
+			continue
+		}
+
+		if err := hide(dir, "1777"); err != nil {
+			return err
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0); err != nil {
+		return err
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return err
+	}
+
+	return unix.Exec(path, argv, os.Environ())
+}
+
+// hide mounts an empty file system, with the permission mode, over dir.
+func hide(dir, mode string) error {
+	return unix.Mount("tmpfs", dir, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode="+mode)
+}
@@ -0,0 +1,111 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// TestMain runs [Subcommand] when the test binary is started by a command wrapped by [Wrap], as the agent's binary
+// does, so the sandbox can be tested end to end.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == Subcommand {
+		if len(os.Args) < 4 {
+			os.Exit(2)
+		}
+
+		if err := Run(os.Args[2], os.Args[3], os.Args[4:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	os.Exit(m.Run())
+}
+
+// unprivileged makes the sandbox handle the agent as a non-root user until the test ends.
+func unprivileged(t *testing.T) {
+	t.Helper()
+
+	euid := geteuid
+	geteuid = func() int { return 1000 }
+	t.Cleanup(func() { geteuid = euid })
+}
+
+func TestSupported(t *testing.T) {
+	euid := geteuid
+	t.Cleanup(func() { geteuid = euid })
+
+	geteuid = func() int { return 0 }
+	assert.ErrorIs(t, Supported(), ErrPrivileged)
+
+	geteuid = func() int { return 1000 }
+	assert.NoError(t, Supported())
+}
+
+func TestWrap(t *testing.T) {
+	t.Run("fails when the agent runs as root", func(t *testing.T) {
+		euid := geteuid
+		geteuid = func() int { return 0 }
+		t.Cleanup(func() { geteuid = euid })
+
+		cmd := exec.Command("/bin/sh", "-c", "true")
+		assert.ErrorIs(t, Wrap(cmd, "/home/john_doe"), ErrPrivileged)
+		assert.Equal(t, []string{"/bin/sh", "-c", "true"}, cmd.Args)
+		assert.Nil(t, cmd.SysProcAttr)
+	})
+
+	t.Run("runs the command through the subcommand in new namespaces", func(t *testing.T) {
+		unprivileged(t)
+
+		self, err := os.Executable()
+		require.NoError(t, err)
+
+		cmd := exec.Command("/bin/sh", "-c", "true")
+		require.NoError(t, Wrap(cmd, "/home/john_doe"))
+
+		assert.Equal(t, self, cmd.Path)
+		assert.Equal(t, []string{self, Subcommand, "/home/john_doe", "/bin/sh", "/bin/sh", "-c", "true"}, cmd.Args)
+		assert.Equal(t, uintptr(syscall.CLONE_NEWUSER|syscall.CLONE_NEWNS), cmd.SysProcAttr.Cloneflags)
+		assert.Equal(t, []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}}, cmd.SysProcAttr.UidMappings)
+		assert.Equal(t, []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}}, cmd.SysProcAttr.GidMappings)
+		assert.Equal(t, []uintptr{unix.CAP_SYS_ADMIN}, cmd.SysProcAttr.AmbientCaps)
+	})
+}
+
+func TestRun(t *testing.T) {
+	unprivileged(t)
+
+	homes := t.TempDir()
+
+	home := filepath.Join(homes, "john_doe")
+	require.NoError(t, os.Mkdir(home, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "notes"), nil, 0o600))
+
+	other := filepath.Join(homes, "jane_doe")
+	require.NoError(t, os.Mkdir(other, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(other, "secrets"), nil, 0o600))
+
+	cmd := exec.Command("/bin/sh", "-c", "ls -A "+home+"; echo --; ls -A "+other)
+	require.NoError(t, Wrap(cmd, home))
+
+	out, err := cmd.CombinedOutput()
+	if err != nil && (strings.Contains(err.Error(), "operation not permitted") || strings.Contains(err.Error(), "invalid argument")) {
+		t.Skipf("user namespaces aren't available: %v", err)
+	}
+
+	require.NoError(t, err, string(out))
+
+	// NOTICE: the user's home directory is kept, while the other users' ones are replaced by empty directories.
+	assert.Equal(t, "notes\n--\n", string(out))
+}
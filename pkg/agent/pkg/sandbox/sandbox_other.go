@@ -0,0 +1,20 @@
+//go:build !linux
+
+package sandbox
+
+import "os/exec"
+
+// Supported checks if the sandbox can be used by the agent. It isn't supported on this platform.
+func Supported() error {
+	return ErrUnsupported
+}
+
+// Wrap changes cmd to run inside the sandbox. It isn't supported on this platform.
+func Wrap(_ *exec.Cmd, _ string) error {
+	return ErrUnsupported
+}
+
+// Run applies the sandbox restrictions and executes the program at path. It isn't supported on this platform.
+func Run(_, _ string, _ []string) error {
+	return ErrUnsupported
+}
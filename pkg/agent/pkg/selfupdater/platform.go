@@ -0,0 +1,116 @@
+package selfupdater
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+)
+
+// LibcLabel is the image's label with the C library, "musl" or "glibc", the agent's binary was built against.
+const LibcLabel = "io.shellhub.agent.libc"
+
+var ErrPlatformMismatch = errors.New("the update's artifact doesn't match the device's platform")
+
+// Platform is the operating system, CPU architecture and C library an agent's artifact is built for.
+type Platform struct {
+	OS           string
+	Architecture string
+	// Variant is the CPU architecture's variant, like "v6" and "v7" for ARM. It is empty when unknown.
+	Variant string
+	// Libc is the C library, "musl" or "glibc". It is empty when unknown.
+	Libc string
+}
+
+func (p Platform) String() string {
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+
+	if p.Libc != "" {
+		s += " (" + p.Libc + ")"
+	}
+
+	return s
+}
+
+// PlatformMismatchError is returned when an update's artifact is built for a platform different from the device's.
+type PlatformMismatchError struct {
+	Device   Platform
+	Artifact Platform
+}
+
+func (e *PlatformMismatchError) Error() string {
+	return fmt.Sprintf("%s: the device is %s, but the artifact is %s", ErrPlatformMismatch, e.Device, e.Artifact)
+}
+
+func (e *PlatformMismatchError) Is(target error) bool {
+	return target == ErrPlatformMismatch
+}
+
+// CheckPlatform checks if the artifact can run on the device, returning a [PlatformMismatchError] otherwise. The
+// variant and the C library are only compared when both are known.
+func CheckPlatform(device, artifact Platform) error {
+	mismatch := device.OS != artifact.OS ||
+		device.Architecture != artifact.Architecture ||
+		(device.Variant != "" && artifact.Variant != "" && device.Variant != artifact.Variant) ||
+		(device.Libc != "" && artifact.Libc != "" && device.Libc != artifact.Libc)
+
+	if mismatch {
+		return &PlatformMismatchError{Device: device, Artifact: artifact}
+	}
+
+	return nil
+}
+
+// CurrentPlatform returns the platform the running agent was built for.
+func CurrentPlatform() Platform {
+	return Platform{
+		OS:           runtime.GOOS,
+		Architecture: runtime.GOARCH,
+		Variant:      currentVariant(),
+		Libc:         currentLibc(),
+	}
+}
+
+// currentVariant returns the ARM variant the running agent was built for, from the GOARM build setting.
+func currentVariant() string {
+	if runtime.GOARCH != "arm" {
+		return ""
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	for _, setting := range info.Settings {
+		if setting.Key == "GOARM" && setting.Value != "" {
+			// NOTICE: The GOARM setting may have a floating point mode suffix, like "7,softfloat".
+			return "v" + setting.Value[:1]
+		}
+	}
+
+	return ""
+}
+
+// currentLibc returns the C library available to the running agent, detected from its dynamic loader.
+func currentLibc() string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+
+	if matches, _ := filepath.Glob("/lib/ld-musl-*.so.1"); len(matches) > 0 {
+		return "musl"
+	}
+
+	for _, pattern := range []string{"/lib/ld-linux*.so.*", "/lib64/ld-linux*.so.*", "/lib/*-linux-gnu*/ld-linux*.so.*"} {
+		if matches, _ := filepath.Glob(pattern); len(matches) > 0 {
+			return "glibc"
+		}
+	}
+
+	return ""
+}
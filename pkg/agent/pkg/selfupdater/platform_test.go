@@ -0,0 +1,64 @@
+package selfupdater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPlatform(t *testing.T) {
+	device := Platform{OS: "linux", Architecture: "arm", Variant: "v7", Libc: "musl"}
+
+	cases := []struct {
+		description string
+		artifact    Platform
+		expected    error
+	}{
+		{
+			description: "succeeds when the platforms are equal",
+			artifact:    Platform{OS: "linux", Architecture: "arm", Variant: "v7", Libc: "musl"},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the artifact's variant and C library are unknown",
+			artifact:    Platform{OS: "linux", Architecture: "arm"},
+			expected:    nil,
+		},
+		{
+			description: "fails when the architecture is different",
+			artifact:    Platform{OS: "linux", Architecture: "arm64", Libc: "musl"},
+			expected:    ErrPlatformMismatch,
+		},
+		{
+			description: "fails when the variant is different",
+			artifact:    Platform{OS: "linux", Architecture: "arm", Variant: "v6", Libc: "musl"},
+			expected:    ErrPlatformMismatch,
+		},
+		{
+			description: "fails when the C library is different",
+			artifact:    Platform{OS: "linux", Architecture: "arm", Variant: "v7", Libc: "glibc"},
+			expected:    ErrPlatformMismatch,
+		},
+		{
+			description: "fails when the operating system is different",
+			artifact:    Platform{OS: "windows", Architecture: "arm", Variant: "v7"},
+			expected:    ErrPlatformMismatch,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := CheckPlatform(device, tc.artifact)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestPlatformMismatchError(t *testing.T) {
+	err := CheckPlatform(
+		Platform{OS: "linux", Architecture: "amd64", Libc: "musl"},
+		Platform{OS: "linux", Architecture: "arm", Variant: "v6"},
+	)
+
+	assert.EqualError(t, err, "the update's artifact doesn't match the device's platform: the device is linux/amd64 (musl), but the artifact is linux/arm/v6")
+}
@@ -211,6 +211,10 @@ func (d *dockerUpdater) updateContainer(container *dockerContainer, image, name
 		return nil, err
 	}
 
+	if err := d.checkImagePlatform(ctx, image); err != nil {
+		return nil, err
+	}
+
 	// Create a new container using the cloned container config
 	clone, err := d.api.ContainerCreate(ctx, config, container.info.HostConfig, netConfig, nil, name)
 	if err != nil {
@@ -224,6 +228,27 @@ func (d *dockerUpdater) updateContainer(container *dockerContainer, image, name
 	return d.getContainer(clone.ID)
 }
 
+// checkImagePlatform checks if the image was built for the device's platform, avoiding to replace the running agent
+// by one that cannot start, when a wrong artifact is published.
+func (d *dockerUpdater) checkImagePlatform(ctx context.Context, image string) error {
+	info, _, err := d.api.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		return err
+	}
+
+	artifact := Platform{
+		OS:           info.Os,
+		Architecture: info.Architecture,
+		Variant:      info.Variant,
+	}
+
+	if info.Config != nil {
+		artifact.Libc = info.Config.Labels[LibcLabel]
+	}
+
+	return CheckPlatform(CurrentPlatform(), artifact)
+}
+
 func NewUpdater(version string) (Updater, error) {
 	// ensure we are running inside a docker container, otherwise returns a dummy updater implementation
 	if _, err := os.Stat("/.dockerenv"); os.IsNotExist(err) {
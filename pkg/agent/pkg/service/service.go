@@ -0,0 +1,33 @@
+// Package service runs the agent as a Windows service, started by the Service Control Manager with the system and
+// restarted when it fails.
+//
+// The service runs the agent's binary with the [Subcommand] subcommand, and its configuration, the SHELLHUB_
+// environmental variables, is kept on the service's registry key, as the services don't inherit the environment of who
+// installed them.
+//
+// Only Windows is supported. On other platforms, the functions return [ErrUnsupported], as the agent is managed by the
+// system's init instead.
+package service
+
+import "errors"
+
+const (
+	// Name is the service's name on the Service Control Manager.
+	Name = "ShellHubAgent"
+	// DisplayName is the service's name shown to the users.
+	DisplayName = "ShellHub Agent"
+	// Description describes the service to the users.
+	Description = "Keeps the device connected to the ShellHub server, enabling the remote access to it."
+	// Subcommand is the agent's subcommand that manages the service, whose "run" subcommand is started by the Service
+	// Control Manager.
+	Subcommand = "service"
+)
+
+var (
+	// ErrUnsupported is returned when the service isn't supported on the current platform.
+	ErrUnsupported = errors.New("service is only supported on Windows")
+	// ErrInstalled is returned when installing the service, but it is already installed.
+	ErrInstalled = errors.New("service is already installed")
+	// ErrNotInstalled is returned when uninstalling the service, but it isn't installed.
+	ErrNotInstalled = errors.New("service is not installed")
+)
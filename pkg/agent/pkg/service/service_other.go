@@ -0,0 +1,20 @@
+//go:build !windows
+
+package service
+
+import "context"
+
+// Install installs the service. It isn't supported on this platform.
+func Install(_ string, _ []string) error {
+	return ErrUnsupported
+}
+
+// Uninstall uninstalls the service. It isn't supported on this platform.
+func Uninstall() error {
+	return ErrUnsupported
+}
+
+// Run runs the service. It isn't supported on this platform.
+func Run(_ func(context.Context) error) error {
+	return ErrUnsupported
+}
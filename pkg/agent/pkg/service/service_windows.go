@@ -0,0 +1,179 @@
+//go:build windows
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+const (
+	// key is the service's registry key, where its environment is kept.
+	key = `SYSTEM\CurrentControlSet\Services\` + Name
+	// restartDelay is the wait before the Service Control Manager restarts the failed service.
+	restartDelay = 10 * time.Second
+	// stopTimeout is the wait for the service to stop before it is deleted.
+	stopTimeout = 30 * time.Second
+)
+
+// Install installs the service running the agent's executable, starting it with the system and now. The env are the
+// environmental variables, formatted as "key=value", set on the service.
+func Install(executable string, env []string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+
+	defer m.Disconnect() //nolint:errcheck
+
+	if s, err := m.OpenService(Name); err == nil {
+		s.Close()
+
+		return ErrInstalled
+	}
+
+	s, err := m.CreateService(Name, executable, mgr.Config{
+		DisplayName: DisplayName,
+		Description: Description,
+		StartType:   mgr.StartAutomatic,
+		// NOTICE: the agent needs the network, which may not be ready when the automatic services start.
+		DelayedAutoStart: true,
+	}, Subcommand, "run")
+	if err != nil {
+		return err
+	}
+
+	defer s.Close()
+
+	if err := configure(s, env); err != nil {
+		s.Delete() //nolint:errcheck
+
+		return err
+	}
+
+	return s.Start()
+}
+
+// configure sets the service's environment and its restart when it fails.
+func configure(s *mgr.Service, env []string) error {
+	actions := []mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: restartDelay},
+		{Type: mgr.ServiceRestart, Delay: restartDelay},
+		{Type: mgr.ServiceRestart, Delay: restartDelay},
+	}
+
+	// NOTICE: the failures count is reset after a day without them.
+	if err := s.SetRecoveryActions(actions, uint32((24 * time.Hour).Seconds())); err != nil {
+		return err
+	}
+
+	// NOTICE: the agent exits with a non-zero code on failures, what the Service Control Manager doesn't consider a
+	// crash by default.
+	if err := s.SetRecoveryActionsOnNonCrashFailures(true); err != nil {
+		return err
+	}
+
+	if len(env) == 0 {
+		return nil
+	}
+
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, key, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+
+	defer k.Close()
+
+	return k.SetStringsValue("Environment", env)
+}
+
+// Uninstall stops the service, when it is running, and uninstalls it.
+func Uninstall() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+
+	defer m.Disconnect() //nolint:errcheck
+
+	s, err := m.OpenService(Name)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_SERVICE_DOES_NOT_EXIST) {
+			return ErrNotInstalled
+		}
+
+		return err
+	}
+
+	defer s.Close()
+
+	if status, err := s.Control(svc.Stop); err == nil {
+		deadline := time.Now().Add(stopTimeout)
+		for status.State != svc.Stopped && time.Now().Before(deadline) {
+			time.Sleep(time.Second)
+
+			if status, err = s.Query(); err != nil {
+				return err
+			}
+		}
+	} else if !errors.Is(err, windows.ERROR_SERVICE_NOT_ACTIVE) {
+		return err
+	}
+
+	return s.Delete()
+}
+
+// handler is the service's [svc.Handler], running the agent until the service is stopped.
+type handler struct {
+	run func(context.Context) error
+}
+
+func (h *handler) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.run(ctx)
+	}()
+
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case err := <-done:
+			changes <- svc.Status{State: svc.StopPending}
+
+			// NOTICE: a service specific exit code makes the Service Control Manager restart the service.
+			if err != nil {
+				return true, 1
+			}
+
+			return false, 0
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				changes <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+
+				cancel()
+			default:
+			}
+		}
+	}
+}
+
+// Run runs the service, calling run with a context canceled when the Service Control Manager stops the service. It
+// must be called from the process started by the Service Control Manager.
+func Run(run func(context.Context) error) error {
+	return svc.Run(Name, &handler{run: run})
+}
@@ -0,0 +1,119 @@
+//go:build windows
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows/svc"
+)
+
+// exit is the handler's result, informed to the Service Control Manager when the service ends.
+type exit struct {
+	specific bool
+	code     uint32
+}
+
+// execute runs the handler as the Service Control Manager does, sending its result when it ends.
+func execute(h *handler, requests chan svc.ChangeRequest, changes chan svc.Status) <-chan exit {
+	exited := make(chan exit, 1)
+	go func() {
+		specific, code := h.Execute(nil, requests, changes)
+		exited <- exit{specific, code}
+	}()
+
+	return exited
+}
+
+// next receives the next status reported by the handler.
+func next(t *testing.T, changes <-chan svc.Status) svc.Status {
+	t.Helper()
+
+	select {
+	case status := <-changes:
+		return status
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the handler didn't report its status")
+
+		return svc.Status{}
+	}
+}
+
+func TestHandler_Execute(t *testing.T) {
+	t.Run("stops the agent when the service is stopped", func(t *testing.T) {
+		requests := make(chan svc.ChangeRequest)
+		changes := make(chan svc.Status, 10)
+
+		exited := execute(&handler{run: func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return nil
+		}}, requests, changes)
+
+		assert.Equal(t, svc.StartPending, next(t, changes).State)
+		assert.Equal(t, svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}, next(t, changes))
+
+		requests <- svc.ChangeRequest{Cmd: svc.Stop}
+
+		assert.Equal(t, svc.StopPending, next(t, changes).State)
+		assert.Equal(t, exit{false, 0}, <-exited)
+	})
+
+	t.Run("stops the agent when the system shuts down", func(t *testing.T) {
+		requests := make(chan svc.ChangeRequest)
+		changes := make(chan svc.Status, 10)
+
+		exited := execute(&handler{run: func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return nil
+		}}, requests, changes)
+
+		next(t, changes)
+		next(t, changes)
+
+		requests <- svc.ChangeRequest{Cmd: svc.Shutdown}
+
+		assert.Equal(t, svc.StopPending, next(t, changes).State)
+		assert.Equal(t, exit{false, 0}, <-exited)
+	})
+
+	t.Run("reports the current status when interrogated", func(t *testing.T) {
+		requests := make(chan svc.ChangeRequest)
+		changes := make(chan svc.Status, 10)
+
+		exited := execute(&handler{run: func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return nil
+		}}, requests, changes)
+
+		next(t, changes)
+		running := next(t, changes)
+
+		requests <- svc.ChangeRequest{Cmd: svc.Interrogate, CurrentStatus: running}
+		assert.Equal(t, running, next(t, changes))
+
+		requests <- svc.ChangeRequest{Cmd: svc.Stop}
+		<-exited
+	})
+
+	t.Run("exits with a service specific code when the agent fails", func(t *testing.T) {
+		requests := make(chan svc.ChangeRequest)
+		changes := make(chan svc.Status, 10)
+
+		exited := execute(&handler{run: func(context.Context) error {
+			return errors.New("error")
+		}}, requests, changes)
+
+		assert.Equal(t, svc.StartPending, next(t, changes).State)
+		assert.Equal(t, svc.Running, next(t, changes).State)
+		assert.Equal(t, svc.StopPending, next(t, changes).State)
+		assert.Equal(t, exit{true, 1}, <-exited)
+	})
+}
@@ -0,0 +1,213 @@
+package sftpcheck
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Channel sits between an SFTP server and its client's connection, answering the extension's requests by itself and
+// passing the other packets through, so the extension is provided without changing the server. The server's version
+// packet is extended to advertise it.
+//
+// The offsets of the reads and the writes are passed through untouched, so an interrupted transfer is resumed by the
+// client reopening the file and continuing from its verified size.
+type Channel struct {
+	rw io.ReadWriteCloser
+
+	// pending is the rest of the client's packet being read by the server.
+	pending []byte
+	// partial is the start of the server's packet not yet written by the server.
+	partial []byte
+	// advertised reports whether the server's version packet was already extended.
+	advertised bool
+	// mu serializes the writes of the server's packets and of the extension's replies.
+	mu sync.Mutex
+}
+
+var _ io.ReadWriteCloser = (*Channel)(nil)
+
+// NewChannel creates a channel over the client's connection, to be served by the SFTP server.
+func NewChannel(rw io.ReadWriteCloser) *Channel {
+	return &Channel{rw: rw}
+}
+
+// Read reads the client's packets to the server, answering the extension's requests on the way.
+func (c *Channel) Read(data []byte) (int, error) {
+	for len(c.pending) == 0 {
+		packet, err := readPacket(c.rw)
+		if err != nil {
+			return 0, err
+		}
+
+		handled, err := c.handle(packet)
+		if err != nil {
+			return 0, err
+		}
+
+		if !handled {
+			c.pending = packet
+		}
+	}
+
+	n := copy(data, c.pending)
+	c.pending = c.pending[n:]
+
+	return n, nil
+}
+
+// Write writes the server's packets to the client. As the server may write a packet in several pieces, the packets
+// are gathered before being written whole, so the extension's replies don't get between the pieces.
+func (c *Channel) Write(data []byte) (int, error) {
+	c.partial = append(c.partial, data...)
+
+	for {
+		packet, err := readPacket(bytes.NewReader(c.partial))
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		c.partial = c.partial[len(packet):]
+
+		if !c.advertised && packet[4] == fxpVersion {
+			packet = advertise(packet)
+			c.advertised = true
+		}
+
+		if err := c.write(packet); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Close closes the client's connection.
+func (c *Channel) Close() error {
+	return c.rw.Close()
+}
+
+func (c *Channel) write(packet []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := c.rw.Write(packet)
+
+	return err
+}
+
+// handle answers the packet when it's a request of the extension, reporting whether it did so.
+func (c *Channel) handle(packet []byte) (bool, error) {
+	if packet[4] != fxpExtended {
+		return false, nil
+	}
+
+	b := &buffer{data: packet[5:]}
+
+	id, err := b.uint32()
+	if err != nil {
+		return false, nil
+	}
+
+	if request, err := b.string(); err != nil || request != ExtensionName {
+		return false, nil
+	}
+
+	return true, c.write(reply(id, b))
+}
+
+// reply returns the reply to the extension's request, whose fields after the request's name are on the buffer.
+func reply(id uint32, b *buffer) []byte {
+	name, err := b.string()
+	if err != nil {
+		return status(id, fxFailure, err.Error())
+	}
+
+	names, err := b.string()
+	if err != nil {
+		return status(id, fxFailure, err.Error())
+	}
+
+	offset, err := b.uint64()
+	if err != nil {
+		return status(id, fxFailure, err.Error())
+	}
+
+	length, err := b.uint64()
+	if err != nil {
+		return status(id, fxFailure, err.Error())
+	}
+
+	blockSize, err := b.uint32()
+	if err != nil {
+		return status(id, fxFailure, err.Error())
+	}
+
+	algorithm, err := Algorithm(strings.Split(names, ","))
+	if err != nil {
+		return status(id, fxOpUnsupported, err.Error())
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return status(id, statusCode(err), err.Error())
+	}
+
+	defer file.Close()
+
+	sums, err := Sum(file, algorithm, offset, length, blockSize)
+	if err != nil {
+		return status(id, statusCode(err), err.Error())
+	}
+
+	r := &buffer{}
+	r.putUint8(fxpExtendedReply)
+	r.putUint32(id)
+	r.putString("check-file")
+	r.putString(algorithm)
+	for _, sum := range sums {
+		r.data = append(r.data, sum...)
+	}
+
+	return r.packet()
+}
+
+// status returns a status packet, replying to the request with the code.
+func status(id uint32, code uint32, message string) []byte {
+	b := &buffer{}
+	b.putUint8(fxpStatus)
+	b.putUint32(id)
+	b.putUint32(code)
+	b.putString(message)
+	b.putString("")
+
+	return b.packet()
+}
+
+func statusCode(err error) uint32 {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return fxNoSuchFile
+	case errors.Is(err, fs.ErrPermission):
+		return fxPermissionDenied
+	default:
+		return fxFailure
+	}
+}
+
+// advertise returns the server's version packet with the extension appended to the advertised ones.
+func advertise(packet []byte) []byte {
+	b := &buffer{data: append([]byte{}, packet[4:]...)}
+	b.putString(ExtensionName)
+	b.putString("1")
+
+	return b.packet()
+}
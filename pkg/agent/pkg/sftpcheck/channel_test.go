@@ -0,0 +1,334 @@
+package sftpcheck
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// transferSize is the size of the files transferred by the tests.
+	transferSize = 64 * 1024 * 1024
+	// transferBlockSize is the size of the blocks verified after an interruption.
+	transferBlockSize = 1024 * 1024
+)
+
+// serve serves an SFTP session, through a channel, to the returned connection.
+func serve(t *testing.T) net.Conn {
+	t.Helper()
+
+	client, conn := net.Pipe()
+
+	server, err := sftp.NewServer(NewChannel(conn))
+	require.NoError(t, err)
+
+	go server.Serve() //nolint:errcheck
+
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	return client
+}
+
+func newClient(conn net.Conn) (*sftp.Client, error) {
+	return sftp.NewClientPipe(conn, conn)
+}
+
+// flaky is a connection dropped after the limit of bytes is transferred in either direction.
+type flaky struct {
+	net.Conn
+	limit       int64
+	transferred atomic.Int64
+}
+
+var errDisconnected = errors.New("disconnected")
+
+func (f *flaky) count(n int) error {
+	if f.transferred.Add(int64(n)) > f.limit {
+		f.Conn.Close()
+
+		return errDisconnected
+	}
+
+	return nil
+}
+
+func (f *flaky) Read(data []byte) (int, error) {
+	n, err := f.Conn.Read(data)
+	if err != nil {
+		return n, err
+	}
+
+	return n, f.count(n)
+}
+
+func (f *flaky) Write(data []byte) (int, error) {
+	if err := f.count(len(data)); err != nil {
+		return 0, err
+	}
+
+	return f.Conn.Write(data)
+}
+
+func random(t *testing.T, size int) []byte {
+	t.Helper()
+
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestSum(t *testing.T) {
+	data := random(t, 1000)
+
+	whole, err := Sum(bytes.NewReader(data), "sha256", 0, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, whole, 1)
+
+	blocks, err := Sum(bytes.NewReader(data), "sha256", 0, 0, 256)
+	require.NoError(t, err)
+	assert.Len(t, blocks, 4)
+
+	ranged, err := Sum(bytes.NewReader(data), "sha256", 256, 256, 0)
+	require.NoError(t, err)
+	assert.Equal(t, blocks[1], ranged[0])
+
+	_, err = Sum(bytes.NewReader(data), "crc32", 0, 0, 0)
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+
+	_, err = Sum(bytes.NewReader(data), "sha256", 0, 0, 255)
+	assert.ErrorIs(t, err, ErrInvalidBlockSize)
+}
+
+func TestVerifiedLength(t *testing.T) {
+	data := random(t, 1000)
+
+	partial := append([]byte{}, data[:900]...)
+	copy(partial[600:700], make([]byte, 100))
+
+	result := &Result{Algorithm: "sha256"}
+	result.Sums, _ = Sum(bytes.NewReader(data), "sha256", 0, 900, 256)
+
+	length, err := VerifiedLength(bytes.NewReader(partial), 900, result, 256)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(512), length)
+
+	length, err = VerifiedLength(bytes.NewReader(data[:900]), 900, result, 256)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(900), length)
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	data := random(t, 4096)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), data, 0o600))
+
+	type Expected struct {
+		sums [][]byte
+		err  error
+	}
+
+	sums := func(offset, length uint64, blockSize uint32) [][]byte {
+		sums, err := Sum(bytes.NewReader(data), "sha256", offset, length, blockSize)
+		require.NoError(t, err)
+
+		return sums
+	}
+
+	cases := []struct {
+		description string
+		req         *Request
+		expected    Expected
+	}{
+		{
+			description: "fails when the file doesn't exist",
+			req:         &Request{Path: filepath.Join(dir, "missing"), Algorithms: []string{"sha256"}},
+			expected:    Expected{err: &StatusError{Code: fxNoSuchFile}},
+		},
+		{
+			description: "fails when none of the algorithms is supported",
+			req:         &Request{Path: filepath.Join(dir, "file"), Algorithms: []string{"crc32"}},
+			expected:    Expected{err: &StatusError{Code: fxOpUnsupported}},
+		},
+		{
+			description: "succeeds checking the whole file",
+			req:         &Request{Path: filepath.Join(dir, "file"), Algorithms: []string{"crc32", "sha256"}},
+			expected:    Expected{sums: sums(0, 0, 0)},
+		},
+		{
+			description: "succeeds checking the range by blocks",
+			req:         &Request{Path: filepath.Join(dir, "file"), Algorithms: []string{"sha256"}, Offset: 1024, Length: 2048, BlockSize: 512},
+			expected:    Expected{sums: sums(1024, 2048, 512)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			result, err := Check(serve(t), tc.req)
+			if tc.expected.err != nil {
+				var status *StatusError
+				require.ErrorAs(t, err, &status)
+				assert.Equal(t, tc.expected.err.(*StatusError).Code, status.Code)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "sha256", result.Algorithm)
+			assert.Equal(t, tc.expected.sums, result.Sums)
+		})
+	}
+}
+
+func TestChannelPassesThrough(t *testing.T) {
+	client, err := newClient(serve(t))
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	_, ok := client.HasExtension("posix-rename@openssh.com")
+	assert.True(t, ok)
+
+	path := filepath.Join(t.TempDir(), "file")
+
+	file, err := client.Create(path)
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte("content"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+}
+
+// TestResumeUpload interrupts an upload, verifies the partial file on the server and resumes it from its verified
+// length.
+func TestResumeUpload(t *testing.T) {
+	data := random(t, transferSize)
+	path := filepath.Join(t.TempDir(), "upload")
+
+	interrupted, err := newClient(&flaky{Conn: serve(t), limit: transferSize / 2})
+	require.NoError(t, err)
+
+	file, err := interrupted.Create(path)
+	require.NoError(t, err)
+
+	_, err = file.ReadFrom(bytes.NewReader(data))
+	require.Error(t, err)
+
+	interrupted.Close()
+
+	client, err := newClient(serve(t))
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	info, err := client.Stat(path)
+	require.NoError(t, err)
+	require.Less(t, info.Size(), int64(transferSize))
+
+	result, err := Check(serve(t), &Request{Path: path, Algorithms: []string{"sha256"}, BlockSize: transferBlockSize})
+	require.NoError(t, err)
+
+	offset, err := VerifiedLength(bytes.NewReader(data), uint64(info.Size()), result, transferBlockSize)
+	require.NoError(t, err)
+
+	file, err = client.OpenFile(path, os.O_WRONLY)
+	require.NoError(t, err)
+
+	_, err = file.Seek(int64(offset), io.SeekStart)
+	require.NoError(t, err)
+
+	_, err = file.ReadFrom(bytes.NewReader(data[offset:]))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	result, err = Check(serve(t), &Request{Path: path, Algorithms: []string{"sha256"}})
+	require.NoError(t, err)
+
+	sums, err := Sum(bytes.NewReader(data), "sha256", 0, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, sums, result.Sums)
+}
+
+// TestResumeDownload interrupts a download, verifies the partial file against the server's one and resumes it from
+// its verified length.
+func TestResumeDownload(t *testing.T) {
+	dir := t.TempDir()
+
+	data := random(t, transferSize)
+	path := filepath.Join(dir, "remote")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	local, err := os.Create(filepath.Join(dir, "local"))
+	require.NoError(t, err)
+
+	defer local.Close()
+
+	interrupted, err := newClient(&flaky{Conn: serve(t), limit: transferSize / 2})
+	require.NoError(t, err)
+
+	file, err := interrupted.Open(path)
+	require.NoError(t, err)
+
+	_, err = file.WriteTo(local)
+	require.Error(t, err)
+
+	interrupted.Close()
+
+	info, err := local.Stat()
+	require.NoError(t, err)
+	require.Less(t, info.Size(), int64(transferSize))
+
+	result, err := Check(serve(t), &Request{
+		Path:       path,
+		Algorithms: []string{"sha256"},
+		Length:     uint64(info.Size()),
+		BlockSize:  transferBlockSize,
+	})
+	require.NoError(t, err)
+
+	offset, err := VerifiedLength(local, uint64(info.Size()), result, transferBlockSize)
+	require.NoError(t, err)
+
+	client, err := newClient(serve(t))
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	file, err = client.Open(path)
+	require.NoError(t, err)
+
+	_, err = file.Seek(int64(offset), io.SeekStart)
+	require.NoError(t, err)
+
+	_, err = local.Seek(int64(offset), io.SeekStart)
+	require.NoError(t, err)
+
+	_, err = file.WriteTo(local)
+	require.NoError(t, err)
+
+	sums, err := Sum(local, "sha256", 0, 0, 0)
+	require.NoError(t, err)
+
+	result, err = Check(serve(t), &Request{Path: path, Algorithms: []string{"sha256"}})
+	require.NoError(t, err)
+	assert.Equal(t, sums, result.Sums)
+}
@@ -0,0 +1,189 @@
+package sftpcheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// sftpVersion is the version of the SFTP protocol requested by the client.
+const sftpVersion = 3
+
+var (
+	ErrNotAdvertised    = errors.New("server doesn't advertise the check-file-name extension")
+	ErrUnexpectedPacket = errors.New("unexpected packet")
+)
+
+// StatusError is a status, other than OK, replied by the server.
+type StatusError struct {
+	Code    uint32
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("sftp status %d: %s", e.Code, e.Message)
+}
+
+// Request is the range of a file whose checksums are requested.
+type Request struct {
+	// Path is the file's path on the server.
+	Path string
+	// Algorithms are the hash algorithms accepted, by the order of preference.
+	Algorithms []string
+	// Offset is the start of the range.
+	Offset uint64
+	// Length is the length of the range, or zero until the file's end.
+	Length uint64
+	// BlockSize is the size of the blocks hashed apart, or zero to hash the whole range at once.
+	BlockSize uint32
+}
+
+// Result is the checksums of the requested range.
+type Result struct {
+	// Algorithm is the hash algorithm chosen by the server.
+	Algorithm string
+	// Sums are the checksums, one for each block of the range.
+	Sums [][]byte
+}
+
+// Check requests the checksums of a file's range over a new SFTP session, like a "sftp" subsystem of an SSH
+// connection, which is initialized by the request.
+func Check(rw io.ReadWriter, req *Request) (*Result, error) {
+	init := &buffer{}
+	init.putUint8(fxpInit)
+	init.putUint32(sftpVersion)
+
+	if _, err := rw.Write(init.packet()); err != nil {
+		return nil, err
+	}
+
+	packet, err := readPacket(rw)
+	if err != nil {
+		return nil, err
+	}
+
+	if packet[4] != fxpVersion {
+		return nil, ErrUnexpectedPacket
+	}
+
+	if !advertised(&buffer{data: packet[5:]}) {
+		return nil, ErrNotAdvertised
+	}
+
+	const id = 1
+
+	request := &buffer{}
+	request.putUint8(fxpExtended)
+	request.putUint32(id)
+	request.putString(ExtensionName)
+	request.putString(req.Path)
+	request.putString(strings.Join(req.Algorithms, ","))
+	request.putUint64(req.Offset)
+	request.putUint64(req.Length)
+	request.putUint32(req.BlockSize)
+
+	if _, err := rw.Write(request.packet()); err != nil {
+		return nil, err
+	}
+
+	packet, err = readPacket(rw)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &buffer{data: packet[5:]}
+	if replied, err := b.uint32(); err != nil || replied != id {
+		return nil, ErrUnexpectedPacket
+	}
+
+	switch packet[4] {
+	case fxpStatus:
+		code, err := b.uint32()
+		if err != nil {
+			return nil, err
+		}
+
+		message, _ := b.string()
+
+		return nil, &StatusError{Code: code, Message: message}
+	case fxpExtendedReply:
+		return result(b)
+	default:
+		return nil, ErrUnexpectedPacket
+	}
+}
+
+// advertised reports whether the extension is between the ones advertised by the server's version packet.
+func advertised(b *buffer) bool {
+	if _, err := b.uint32(); err != nil {
+		return false
+	}
+
+	for len(b.data) > 0 {
+		name, err := b.string()
+		if err != nil {
+			return false
+		}
+
+		if _, err := b.string(); err != nil {
+			return false
+		}
+
+		if name == ExtensionName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func result(b *buffer) (*Result, error) {
+	if _, err := b.string(); err != nil {
+		return nil, err
+	}
+
+	algorithm, err := b.string()
+	if err != nil {
+		return nil, err
+	}
+
+	create, ok := algorithms[algorithm]
+	if !ok {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	size := create().Size()
+	if len(b.data)%size != 0 {
+		return nil, ErrPacketTooShort
+	}
+
+	sums := make([][]byte, 0, len(b.data)/size)
+	for i := 0; i < len(b.data); i += size {
+		sums = append(sums, b.data[i:i+size])
+	}
+
+	return &Result{Algorithm: algorithm, Sums: sums}, nil
+}
+
+// VerifiedLength returns the length of the reader's start, having size bytes, whose blocks match the checksums of the result, which were
+// requested from the file's start with the block size. An interrupted transfer is resumed from it, as the transferred
+// data may have gaps when its requests were concurrent.
+func VerifiedLength(r io.ReaderAt, size uint64, result *Result, blockSize uint32) (uint64, error) {
+	sums, err := Sum(r, result.Algorithm, 0, size, blockSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var length uint64
+	for i := 0; i < len(sums) && i < len(result.Sums); i++ {
+		if !bytes.Equal(sums[i], result.Sums[i]) {
+			break
+		}
+
+		length += uint64(blockSize)
+	}
+
+	return min(length, size), nil
+}
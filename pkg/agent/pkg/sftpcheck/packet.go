@@ -0,0 +1,134 @@
+package sftpcheck
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Packet types and status codes, from the SFTP protocol, used by the extension.
+const (
+	fxpInit          = 1
+	fxpVersion       = 2
+	fxpStatus        = 101
+	fxpExtended      = 200
+	fxpExtendedReply = 201
+
+	fxNoSuchFile       = 2
+	fxPermissionDenied = 3
+	fxFailure          = 4
+	fxOpUnsupported    = 8
+)
+
+// maxPacketLength is the length of the largest packet read. It's above the length accepted by the SFTP server, which
+// rejects the larger ones by itself.
+const maxPacketLength = 4 * 1024 * 1024
+
+var (
+	ErrPacketTooLong  = errors.New("packet is too long")
+	ErrPacketTooShort = errors.New("packet is too short")
+)
+
+// readPacket reads a whole packet, including its length.
+func readPacket(r io.Reader) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header)
+	if length > maxPacketLength {
+		return nil, ErrPacketTooLong
+	}
+
+	if length < 1 {
+		return nil, ErrPacketTooShort
+	}
+
+	packet := make([]byte, 4+length)
+	copy(packet, header)
+
+	if _, err := io.ReadFull(r, packet[4:]); err != nil {
+		return nil, err
+	}
+
+	return packet, nil
+}
+
+// buffer marshals and unmarshals the packets' fields.
+type buffer struct {
+	data []byte
+}
+
+func (b *buffer) uint8() (uint8, error) {
+	if len(b.data) < 1 {
+		return 0, ErrPacketTooShort
+	}
+
+	v := b.data[0]
+	b.data = b.data[1:]
+
+	return v, nil
+}
+
+func (b *buffer) uint32() (uint32, error) {
+	if len(b.data) < 4 {
+		return 0, ErrPacketTooShort
+	}
+
+	v := binary.BigEndian.Uint32(b.data)
+	b.data = b.data[4:]
+
+	return v, nil
+}
+
+func (b *buffer) uint64() (uint64, error) {
+	if len(b.data) < 8 {
+		return 0, ErrPacketTooShort
+	}
+
+	v := binary.BigEndian.Uint64(b.data)
+	b.data = b.data[8:]
+
+	return v, nil
+}
+
+func (b *buffer) string() (string, error) {
+	length, err := b.uint32()
+	if err != nil {
+		return "", err
+	}
+
+	if uint32(len(b.data)) < length { //nolint:gosec
+		return "", ErrPacketTooShort
+	}
+
+	v := string(b.data[:length])
+	b.data = b.data[length:]
+
+	return v, nil
+}
+
+func (b *buffer) putUint8(v uint8) {
+	b.data = append(b.data, v)
+}
+
+func (b *buffer) putUint32(v uint32) {
+	b.data = binary.BigEndian.AppendUint32(b.data, v)
+}
+
+func (b *buffer) putUint64(v uint64) {
+	b.data = binary.BigEndian.AppendUint64(b.data, v)
+}
+
+func (b *buffer) putString(v string) {
+	b.putUint32(uint32(len(v))) //nolint:gosec
+	b.data = append(b.data, v...)
+}
+
+// packet returns the marshaled fields as a packet, prefixed by its length.
+func (b *buffer) packet() []byte {
+	packet := binary.BigEndian.AppendUint32(nil, uint32(len(b.data))) //nolint:gosec
+
+	return append(packet, b.data...)
+}
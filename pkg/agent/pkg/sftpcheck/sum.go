@@ -0,0 +1,93 @@
+// Package sftpcheck implements the "check-file-name" SFTP extension, which returns the checksums of a file's range,
+// so an interrupted transfer can be verified before being resumed, and the whole file once it's completed.
+//
+// The extension is described at https://datatracker.ietf.org/doc/html/draft-ietf-secsh-filexfer-extensions-00#section-3.
+package sftpcheck
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"io"
+)
+
+// ExtensionName is the name of the extension, advertised by the server and used by the client's requests.
+const ExtensionName = "check-file-name"
+
+// MinBlockSize is the smallest block size accepted, besides zero, which hashes the whole range at once.
+const MinBlockSize = 256
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("none of the hash algorithms is supported")
+	ErrInvalidBlockSize     = errors.New("block size is smaller than the minimum")
+)
+
+// algorithms are the supported hash algorithms, by their names on the extension.
+var algorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,  //nolint:gosec
+	"sha1":   sha1.New, //nolint:gosec
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// Algorithm returns the first of the algorithms supported, in the client's order of preference.
+func Algorithm(names []string) (string, error) {
+	for _, name := range names {
+		if _, ok := algorithms[name]; ok {
+			return name, nil
+		}
+	}
+
+	return "", ErrUnsupportedAlgorithm
+}
+
+// Sum returns the checksums, by the algorithm, of the reader's range starting at offset and having length bytes, or
+// until its end when length is zero. When blockSize isn't zero, a checksum is returned for each block of the range,
+// so the first diverging block of a partial file can be found; otherwise, a single one for the whole range.
+func Sum(r io.ReaderAt, algorithm string, offset, length uint64, blockSize uint32) ([][]byte, error) {
+	create, ok := algorithms[algorithm]
+	if !ok {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	if blockSize != 0 && blockSize < MinBlockSize {
+		return nil, ErrInvalidBlockSize
+	}
+
+	var reader io.Reader
+	if length == 0 {
+		reader = io.NewSectionReader(r, int64(offset), 1<<63-1-int64(offset)) //nolint:gosec
+	} else {
+		reader = io.NewSectionReader(r, int64(offset), int64(length)) //nolint:gosec
+	}
+
+	if blockSize == 0 {
+		h := create()
+		if _, err := io.Copy(h, reader); err != nil {
+			return nil, err
+		}
+
+		return [][]byte{h.Sum(nil)}, nil
+	}
+
+	sums := make([][]byte, 0)
+	for {
+		h := create()
+
+		n, err := io.CopyN(h, reader, int64(blockSize))
+		if n > 0 {
+			sums = append(sums, h.Sum(nil))
+		}
+
+		if err == io.EOF {
+			return sums, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -2,4 +2,7 @@ package sysinfo
 
 import "errors"
 
-var ErrNoInterfaceFound = errors.New("no interface found")
+var (
+	ErrNoInterfaceFound     = errors.New("no interface found")
+	ErrHardwareNotSupported = errors.New("hardware inventory is not supported on this system")
+)
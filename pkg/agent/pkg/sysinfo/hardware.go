@@ -0,0 +1,23 @@
+package sysinfo
+
+// Hardware is the hardware inventory of the system where the agent is running.
+type Hardware struct {
+	CPU    CPU    `json:"cpu"`
+	Memory uint64 `json:"memory"`
+	Disks  []Disk `json:"disks"`
+	// Serial is the system's serial number. It is empty when it cannot be read.
+	Serial string `json:"serial"`
+	// Product is the system's product name. It is empty when it cannot be read.
+	Product string `json:"product"`
+}
+
+type CPU struct {
+	Model string `json:"model"`
+	Cores int    `json:"cores"`
+}
+
+type Disk struct {
+	Name string `json:"name"`
+	// Size is the disk's size, in bytes.
+	Size uint64 `json:"size"`
+}
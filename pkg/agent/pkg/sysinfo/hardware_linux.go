@@ -0,0 +1,172 @@
+//go:build linux
+// +build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+var (
+	DefaultProcFSPath = "/proc"
+	DefaultSysFSPath  = "/sys"
+)
+
+// sectorSize is the unit of the block devices' size reported by the sysfs, regardless of their physical sector size.
+const sectorSize = 512
+
+// GetHardware gets the system's hardware inventory from the procfs and the sysfs. The information that cannot be read,
+// like the serial number, which is only readable by the root user, is left empty.
+func GetHardware() (*Hardware, error) {
+	cpu, err := getCPU()
+	if err != nil {
+		return nil, err
+	}
+
+	memory, err := getMemory()
+	if err != nil {
+		return nil, err
+	}
+
+	disks, err := getDisks()
+	if err != nil {
+		return nil, err
+	}
+
+	serial, product := getProduct()
+
+	return &Hardware{
+		CPU:     *cpu,
+		Memory:  memory,
+		Disks:   disks,
+		Serial:  serial,
+		Product: product,
+	}, nil
+}
+
+func getCPU() (*CPU, error) {
+	file, err := os.Open(filepath.Join(DefaultProcFSPath, "cpuinfo"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	cpu := &CPU{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "processor":
+			cpu.Cores++
+		// NOTICE: the x86 processors report their model as "model name", while some ARM ones report it only as
+		// "Hardware" or "Model".
+		case "model name", "Hardware", "Model":
+			if cpu.Model == "" {
+				cpu.Model = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if cpu.Cores == 0 {
+		cpu.Cores = runtime.NumCPU()
+	}
+
+	return cpu, nil
+}
+
+func getMemory() (uint64, error) {
+	file, err := os.Open(filepath.Join(DefaultProcFSPath, "meminfo"))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+
+		// NOTICE: the memory is reported in kibibytes, even though its unit is shown as "kB".
+		total, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		return total * 1024, nil
+	}
+
+	return 0, scanner.Err()
+}
+
+// getDisks gets the system's physical disks. The virtual block devices, like the loop and the device mapper ones, are
+// skipped, as they don't have a backing device.
+func getDisks() ([]Disk, error) {
+	entries, err := os.ReadDir(filepath.Join(DefaultSysFSPath, "block"))
+	if err != nil {
+		return nil, err
+	}
+
+	disks := []Disk{}
+	for _, entry := range entries {
+		if _, err := os.Stat(filepath.Join(DefaultSysFSPath, "block", entry.Name(), "device")); err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(DefaultSysFSPath, "block", entry.Name(), "size"))
+		if err != nil {
+			continue
+		}
+
+		sectors, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil || sectors == 0 {
+			continue
+		}
+
+		disks = append(disks, Disk{Name: entry.Name(), Size: sectors * sectorSize})
+	}
+
+	return disks, nil
+}
+
+// getProduct gets the system's serial number and product name from its DMI table or, on the systems without one, like
+// the ARM boards, from its device tree.
+func getProduct() (string, string) {
+	serial := readSysFsValue("class/dmi/id/product_serial")
+	if serial == "" {
+		serial = readSysFsValue("firmware/devicetree/base/serial-number")
+	}
+
+	product := readSysFsValue("class/dmi/id/product_name")
+	if product == "" {
+		product = readSysFsValue("firmware/devicetree/base/model")
+	}
+
+	return serial, product
+}
+
+// readSysFsValue reads a value from the sysfs, returning an empty value when it cannot be read. The device tree's
+// values are terminated by a null character, which is trimmed.
+func readSysFsValue(path string) string {
+	data, err := os.ReadFile(filepath.Join(DefaultSysFSPath, path))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimRight(string(data), "\x00"))
+}
@@ -0,0 +1,102 @@
+//go:build linux
+// +build linux
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHardware(t *testing.T) {
+	write := func(t *testing.T, path, data string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	}
+
+	type Expected struct {
+		hardware *Hardware
+		err      bool
+	}
+
+	cases := []struct {
+		description string
+		files       map[string]string
+		expected    Expected
+	}{
+		{
+			description: "fails when the cpuinfo cannot be read",
+			files: map[string]string{
+				"proc/meminfo": "MemTotal:        2048 kB\n",
+			},
+			expected: Expected{nil, true},
+		},
+		{
+			description: "succeeds from the DMI table",
+			files: map[string]string{
+				"proc/cpuinfo":                    "processor\t: 0\nmodel name\t: Intel(R) Core(TM) i5\n\nprocessor\t: 1\nmodel name\t: Intel(R) Core(TM) i5\n",
+				"proc/meminfo":                    "MemTotal:        2048 kB\nMemFree:         1024 kB\n",
+				"sys/block/sda/size":              "2048\n",
+				"sys/block/sda/device/model":      "disk\n",
+				"sys/block/loop0/size":            "1024\n",
+				"sys/class/dmi/id/product_name":   "ThinkPad\n",
+				"sys/class/dmi/id/product_serial": "ABC123\n",
+			},
+			expected: Expected{
+				&Hardware{
+					CPU:     CPU{Model: "Intel(R) Core(TM) i5", Cores: 2},
+					Memory:  2048 * 1024,
+					Disks:   []Disk{{Name: "sda", Size: 2048 * 512}},
+					Serial:  "ABC123",
+					Product: "ThinkPad",
+				},
+				false,
+			},
+		},
+		{
+			description: "succeeds from the device tree",
+			files: map[string]string{
+				"proc/cpuinfo":                               "processor\t: 0\n\nHardware\t: BCM2835\nModel\t: Raspberry Pi 4 Model B\n",
+				"proc/meminfo":                               "MemTotal:        4096 kB\n",
+				"sys/block/mmcblk0/size":                     "4096\n",
+				"sys/block/mmcblk0/device/type":              "SD\n",
+				"sys/firmware/devicetree/base/model":         "Raspberry Pi 4 Model B\x00",
+				"sys/firmware/devicetree/base/serial-number": "10000000abcdef\x00",
+			},
+			expected: Expected{
+				&Hardware{
+					CPU:     CPU{Model: "BCM2835", Cores: 1},
+					Memory:  4096 * 1024,
+					Disks:   []Disk{{Name: "mmcblk0", Size: 4096 * 512}},
+					Serial:  "10000000abcdef",
+					Product: "Raspberry Pi 4 Model B",
+				},
+				false,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(root, "sys", "block"), 0o755))
+
+			for path, data := range tc.files {
+				write(t, filepath.Join(root, path), data)
+			}
+
+			procfs, sysfs := DefaultProcFSPath, DefaultSysFSPath
+			DefaultProcFSPath, DefaultSysFSPath = filepath.Join(root, "proc"), filepath.Join(root, "sys")
+			t.Cleanup(func() {
+				DefaultProcFSPath, DefaultSysFSPath = procfs, sysfs
+			})
+
+			hardware, err := GetHardware()
+			assert.Equal(t, tc.expected, Expected{hardware, err != nil})
+		})
+	}
+}
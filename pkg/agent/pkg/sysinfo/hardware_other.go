@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package sysinfo
+
+// GetHardware gets the system's hardware inventory, which isn't supported outside Linux.
+func GetHardware() (*Hardware, error) {
+	return nil, ErrHardwareNotSupported
+}
@@ -0,0 +1,65 @@
+package sysinfo
+
+import (
+	"net"
+	"sort"
+)
+
+// Interface is a network interface of the system where the agent is running.
+type Interface struct {
+	Name string `json:"name"`
+	MAC  string `json:"mac"`
+	// Addresses are the interface's addresses, in CIDR notation, except the link-local ones.
+	Addresses []string `json:"addresses"`
+}
+
+// GetInterfaces gets the system's network interfaces that are up and have, at least, an address that isn't
+// link-local, sorted by name. The loopback interfaces aren't included.
+func GetInterfaces() ([]Interface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces := make([]Interface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || iface.Flags&net.FlagUp == 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+
+		addresses := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok || ipnet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+
+			addresses = append(addresses, ipnet.String())
+		}
+
+		// NOTICE: the interfaces without addresses, like the virtual ones created for each container, come and go
+		// often, which would change the device's information on every report.
+		if len(addresses) == 0 {
+			continue
+		}
+
+		sort.Strings(addresses)
+
+		interfaces = append(interfaces, Interface{
+			Name:      iface.Name,
+			MAC:       iface.HardwareAddr.String(),
+			Addresses: addresses,
+		})
+	}
+
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].Name < interfaces[j].Name
+	})
+
+	return interfaces, nil
+}
@@ -1,5 +1,5 @@
-//go:build !freebsd
-// +build !freebsd
+//go:build !freebsd && !windows
+// +build !freebsd,!windows
 
 package sysinfo
 
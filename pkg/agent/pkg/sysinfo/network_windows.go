@@ -0,0 +1,35 @@
+//go:build windows
+// +build windows
+
+package sysinfo
+
+import (
+	"net"
+)
+
+// PrimaryInterface gets the network interface with the lowest index that is up and has a hardware address, as Windows
+// has no sysfs to tell the Ethernet interfaces apart.
+func PrimaryInterface() (*net.Interface, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, ErrNoInterfaceFound
+	}
+
+	var ifdev *net.Interface
+
+	for i, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback > 0 || iface.Flags&net.FlagUp == 0 || len(iface.HardwareAddr) == 0 {
+			continue
+		}
+
+		if ifdev == nil || iface.Index < ifdev.Index {
+			ifdev = &interfaces[i]
+		}
+	}
+
+	if ifdev == nil {
+		return nil, ErrNoInterfaceFound
+	}
+
+	return ifdev, nil
+}
@@ -0,0 +1,78 @@
+//go:build windows
+// +build windows
+
+package sysinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// windowsVersionKey is the registry key describing the installed Windows.
+const windowsVersionKey = `SOFTWARE\Microsoft\Windows NT\CurrentVersion`
+
+// windows11Build is the first build of Windows 11, which still calls itself Windows 10 on the registry.
+const windows11Build = 22000
+
+type OSRelease struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetOSRelease gets the installed Windows' release. Its ID is always "windows", and its name carries the edition, the
+// feature update and the build, like "Windows 11 Pro 23H2 (build 22631.2861)".
+//
+// The version is read from the kernel, as the one informed to the programs depends on their manifest, and the edition
+// and feature update from the registry, when available.
+func GetOSRelease() (*OSRelease, error) {
+	version := windows.RtlGetVersion()
+
+	var product, release string
+	var revision uint64
+
+	if key, err := registry.OpenKey(registry.LOCAL_MACHINE, windowsVersionKey, registry.QUERY_VALUE); err == nil {
+		defer key.Close()
+
+		product, _, _ = key.GetStringValue("ProductName")
+		release, _, _ = key.GetStringValue("DisplayVersion")
+		if release == "" {
+			// NOTICE: the releases before 20H2 only have the release ID, like 1909.
+			release, _, _ = key.GetStringValue("ReleaseId")
+		}
+
+		revision, _, _ = key.GetIntegerValue("UBR")
+	}
+
+	name := osReleaseName(product, release, version.MajorVersion, version.MinorVersion, version.BuildNumber, revision)
+
+	return &OSRelease{ID: "windows", Name: name}, nil
+}
+
+// osReleaseName builds the release's name from the product and the release read from the registry, when available,
+// and the version read from the kernel.
+func osReleaseName(product, release string, major, minor, build uint32, revision uint64) string {
+	if product == "" {
+		product = fmt.Sprintf("Windows %d.%d", major, minor)
+	}
+
+	if build >= windows11Build {
+		product = strings.Replace(product, "Windows 10", "Windows 11", 1)
+	}
+
+	name := product
+	if release != "" {
+		name += " " + release
+	}
+
+	name += fmt.Sprintf(" (build %d", build)
+	if revision != 0 {
+		name += fmt.Sprintf(".%d", revision)
+	}
+
+	name += ")"
+
+	return name
+}
@@ -0,0 +1,71 @@
+//go:build windows
+// +build windows
+
+package sysinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOSReleaseName(t *testing.T) {
+	cases := []struct {
+		description string
+		product     string
+		release     string
+		major       uint32
+		minor       uint32
+		build       uint32
+		revision    uint64
+		expected    string
+	}{
+		{
+			description: "names a Windows 10 with its feature update and revision",
+			product:     "Windows 10 Pro",
+			release:     "22H2",
+			major:       10,
+			build:       19045,
+			revision:    3803,
+			expected:    "Windows 10 Pro 22H2 (build 19045.3803)",
+		},
+		{
+			description: "names a Windows 11, which calls itself Windows 10 on the registry",
+			product:     "Windows 10 Pro",
+			release:     "23H2",
+			major:       10,
+			build:       22631,
+			revision:    2861,
+			expected:    "Windows 11 Pro 23H2 (build 22631.2861)",
+		},
+		{
+			description: "names a Windows Server, which isn't renamed",
+			product:     "Windows Server 2022 Datacenter",
+			release:     "21H2",
+			major:       10,
+			build:       20348,
+			revision:    2159,
+			expected:    "Windows Server 2022 Datacenter 21H2 (build 20348.2159)",
+		},
+		{
+			description: "names a release without its feature update and revision",
+			product:     "Windows 10 Enterprise LTSC",
+			major:       10,
+			build:       17763,
+			expected:    "Windows 10 Enterprise LTSC (build 17763)",
+		},
+		{
+			description: "names the release by the kernel's version when the registry isn't available",
+			major:       6,
+			minor:       3,
+			build:       9600,
+			expected:    "Windows 6.3 (build 9600)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, osReleaseName(tc.product, tc.release, tc.major, tc.minor, tc.build, tc.revision))
+		})
+	}
+}
@@ -10,11 +10,14 @@ import (
 )
 
 type Tunnel struct {
-	router           *echo.Echo
-	srv              *http.Server
-	HTTPProxyHandler func(e echo.Context) error
-	SSHHandler       func(e echo.Context) error
-	SSHCloseHandler  func(e echo.Context) error
+	router            *echo.Echo
+	srv               *http.Server
+	HTTPProxyHandler  func(e echo.Context) error
+	SSHHandler        func(e echo.Context) error
+	SSHCloseHandler   func(e echo.Context) error
+	ContainersHandler func(e echo.Context) error
+	PortsHandler      func(e echo.Context) error
+	SOCKS5Handler     func(e echo.Context) error
 }
 
 type Builder struct {
@@ -45,6 +48,24 @@ func (t *Builder) WithSSHCloseHandler(handler func(e echo.Context) error) *Build
 	return t
 }
 
+func (t *Builder) WithContainersHandler(handler func(e echo.Context) error) *Builder {
+	t.tunnel.ContainersHandler = handler
+
+	return t
+}
+
+func (t *Builder) WithPortsHandler(handler func(e echo.Context) error) *Builder {
+	t.tunnel.PortsHandler = handler
+
+	return t
+}
+
+func (t *Builder) WithSOCKS5Handler(handler func(e echo.Context) error) *Builder {
+	t.tunnel.SOCKS5Handler = handler
+
+	return t
+}
+
 func (t *Builder) Build() *Tunnel {
 	return t.tunnel
 }
@@ -69,6 +90,15 @@ func NewTunnel() *Tunnel {
 		HTTPProxyHandler: func(_ echo.Context) error {
 			panic("ProxyHandler can not be nil")
 		},
+		ContainersHandler: func(_ echo.Context) error {
+			panic("ContainersHandler can not be nil")
+		},
+		PortsHandler: func(_ echo.Context) error {
+			panic("PortsHandler can not be nil")
+		},
+		SOCKS5Handler: func(_ echo.Context) error {
+			panic("SOCKS5Handler can not be nil")
+		},
 	}
 	e.GET("/ssh/:id", func(e echo.Context) error {
 		return t.SSHHandler(e)
@@ -76,6 +106,15 @@ func NewTunnel() *Tunnel {
 	e.GET("/ssh/close/:id", func(e echo.Context) error {
 		return t.SSHCloseHandler(e)
 	})
+	e.GET("/containers", func(e echo.Context) error {
+		return t.ContainersHandler(e)
+	})
+	e.GET("/ports", func(e echo.Context) error {
+		return t.PortsHandler(e)
+	})
+	e.GET("/socks5", func(e echo.Context) error {
+		return t.SOCKS5Handler(e)
+	})
 	e.CONNECT("/http/proxy/:addr", func(e echo.Context) error {
 		// NOTE: The CONNECT HTTP method requests that a proxy establish a HTTP tunnel to this server, and if
 		// successful, blindly forward data in both directions until the tunnel is closed.
@@ -0,0 +1,223 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrPortsUnavailable is returned when the device doesn't expose its sockets on the proc filesystem.
+var ErrPortsUnavailable = errors.New("listening ports are unavailable on the device")
+
+// Socket states, on the proc filesystem, of the sockets waiting for connections or datagrams.
+const (
+	procSocketListen = "0A" // TCP_LISTEN
+	procSocketClose  = "07" // TCP_CLOSE, the state of the unconnected UDP sockets.
+)
+
+// Ports lists the TCP and UDP ports listening on the device, reading the sockets from the proc filesystem mounted on
+// root.
+type Ports struct {
+	root string
+}
+
+// NewPorts creates a new [Ports] reading the proc filesystem mounted on root.
+func NewPorts(root string) *Ports {
+	return &Ports{root: root}
+}
+
+// List returns the ports listening on the device, sorted by port. The process listening on each port is only resolved
+// when the agent is permitted to read the process' file descriptors, what usually requires it to run as root.
+func (p *Ports) List() ([]models.DevicePort, error) {
+	files := []struct {
+		name     string
+		protocol string
+		state    string
+	}{
+		{"tcp", "tcp", procSocketListen},
+		{"tcp6", "tcp", procSocketListen},
+		{"udp", "udp", procSocketClose},
+		{"udp6", "udp", procSocketClose},
+	}
+
+	ports := []models.DevicePort{}
+	inodes := make(map[string][]int)
+
+	found := false
+	for _, file := range files {
+		sockets, err := p.sockets(filepath.Join(p.root, "net", file.name), file.state)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		found = true
+
+		for _, socket := range sockets {
+			inodes[socket.inode] = append(inodes[socket.inode], len(ports))
+			ports = append(ports, models.DevicePort{Protocol: file.protocol, Address: socket.address, Port: socket.port})
+		}
+	}
+
+	if !found {
+		return nil, ErrPortsUnavailable
+	}
+
+	p.resolveProcesses(ports, inodes)
+
+	sort.SliceStable(ports, func(i, j int) bool {
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+
+		if ports[i].Protocol != ports[j].Protocol {
+			return ports[i].Protocol < ports[j].Protocol
+		}
+
+		return ports[i].Address < ports[j].Address
+	})
+
+	return ports, nil
+}
+
+type procSocket struct {
+	address string
+	port    int
+	inode   string
+}
+
+// sockets reads the sockets in the state from the proc file, like /proc/net/tcp.
+func (p *Ports) sockets(path, state string) ([]procSocket, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	sockets := []procSocket{}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // NOTICE: skips the header.
+
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[3] != state {
+			continue
+		}
+
+		address, port, err := parseProcAddress(fields[1])
+		if err != nil {
+			log.WithError(err).WithField("address", fields[1]).Debug("failed to parse the socket's address")
+
+			continue
+		}
+
+		sockets = append(sockets, procSocket{address: address, port: port, inode: fields[9]})
+	}
+
+	return sockets, scanner.Err()
+}
+
+// resolveProcesses sets the process of the ports whose sockets are open by a process the agent is permitted to see.
+func (p *Ports) resolveProcesses(ports []models.DevicePort, inodes map[string][]int) {
+	processes, err := os.ReadDir(p.root)
+	if err != nil {
+		return
+	}
+
+	for _, process := range processes {
+		pid, err := strconv.Atoi(process.Name())
+		if err != nil {
+			continue
+		}
+
+		// NOTICE: the descriptors of the processes the agent isn't permitted to read are skipped.
+		fds, err := os.ReadDir(filepath.Join(p.root, process.Name(), "fd"))
+		if err != nil {
+			continue
+		}
+
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(p.root, process.Name(), "fd", fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+
+			indexes, ok := inodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")]
+			if !ok {
+				continue
+			}
+
+			comm, _ := os.ReadFile(filepath.Join(p.root, process.Name(), "comm"))
+			for _, i := range indexes {
+				ports[i].PID = pid
+				ports[i].Process = strings.TrimSpace(string(comm))
+			}
+		}
+	}
+}
+
+// parseProcAddress parses an address of the proc filesystem's sockets, like "0100007F:0016", whose IP is written as
+// little-endian 32-bit words and whose port is written in hexadecimal.
+func parseProcAddress(address string) (string, int, error) {
+	host, port, ok := strings.Cut(address, ":")
+	if !ok {
+		return "", 0, errors.New("address without port")
+	}
+
+	raw, err := hex.DecodeString(host)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, errors.New("invalid address")
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+
+	number, err := strconv.ParseUint(port, 16, 16)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return ip.String(), int(number), nil
+}
+
+// portsHandler responds with the ports listening on the device. When the ports cannot be listed, like on the
+// connector mode or on the devices without the proc filesystem, it responds with the status not implemented.
+func portsHandler(ports *Ports) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		if ports == nil {
+			return c.NoContent(http.StatusNotImplemented)
+		}
+
+		list, err := ports.List()
+		if errors.Is(err, ErrPortsUnavailable) {
+			return c.NoContent(http.StatusNotImplemented)
+		}
+
+		if err != nil {
+			log.WithError(err).Error("Failed to list the ports listening on the device")
+
+			return c.NoContent(http.StatusInternalServerError)
+		}
+
+		return c.JSON(http.StatusOK, list)
+	}
+}
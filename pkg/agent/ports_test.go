@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const procNetHeader = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"
+
+// newProc creates a proc filesystem on a temporary directory, with the files on net and a process with pid, named
+// comm, holding a socket with the inode.
+func newProc(t *testing.T, net map[string]string, pid, comm, inode string) string {
+	t.Helper()
+
+	root := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "net"), 0o755))
+	for name, content := range net {
+		require.NoError(t, os.WriteFile(filepath.Join(root, "net", name), []byte(procNetHeader+content), 0o600))
+	}
+
+	if pid != "" {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, pid, "fd"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, pid, "comm"), []byte(comm+"\n"), 0o600))
+		require.NoError(t, os.Symlink("socket:["+inode+"]", filepath.Join(root, pid, "fd", "3")))
+	}
+
+	return root
+}
+
+func TestPorts_List(t *testing.T) {
+	type expected struct {
+		ports []models.DevicePort
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		root        func(t *testing.T) string
+		expected    expected
+	}{
+		{
+			description: "fails when the proc filesystem doesn't have the sockets",
+			root: func(t *testing.T) string {
+				return t.TempDir()
+			},
+			expected: expected{nil, ErrPortsUnavailable},
+		},
+		{
+			description: "succeeds listing the listening ports with the processes permitted to be seen",
+			root: func(t *testing.T) string {
+				return newProc(t, map[string]string{
+					"tcp": "   0: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1001 1 0000000000000000 100 0 0 10 0\n" +
+						"   1: 0100007F:1F90 0100007F:D431 01 00000000:00000000 00:00000000 00000000  1000        0 1002 1 0000000000000000 20 4 30 10 -1\n",
+					"tcp6": "   0: 00000000000000000000000001000000:1F90 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 1003 1 0000000000000000 100 0 0 10 0\n",
+					"udp":  "   0: 3500007F:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 1004 2 0000000000000000 0\n",
+				}, "42", "sshd", "1001")
+			},
+			expected: expected{
+				ports: []models.DevicePort{
+					{Protocol: "tcp", Address: "0.0.0.0", Port: 22, Process: "sshd", PID: 42},
+					{Protocol: "udp", Address: "127.0.0.53", Port: 53},
+					{Protocol: "tcp", Address: "::1", Port: 8080},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ports, err := NewPorts(tc.root(t)).List()
+			assert.Equal(t, tc.expected, expected{ports, err})
+		})
+	}
+}
+
+func TestPortsHandler(t *testing.T) {
+	type expected struct {
+		status int
+		body   string
+	}
+
+	cases := []struct {
+		description string
+		ports       func(t *testing.T) *Ports
+		expected    expected
+	}{
+		{
+			description: "responds not implemented when the ports listing is disabled",
+			ports: func(_ *testing.T) *Ports {
+				return nil
+			},
+			expected: expected{
+				status: http.StatusNotImplemented,
+				body:   "",
+			},
+		},
+		{
+			description: "responds not implemented when the proc filesystem doesn't have the sockets",
+			ports: func(t *testing.T) *Ports {
+				return NewPorts(t.TempDir())
+			},
+			expected: expected{
+				status: http.StatusNotImplemented,
+				body:   "",
+			},
+		},
+		{
+			description: "responds with the ports listening on device",
+			ports: func(t *testing.T) *Ports {
+				return NewPorts(newProc(t, map[string]string{
+					"tcp": "   0: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1001 1 0000000000000000 100 0 0 10 0\n",
+				}, "", "", ""))
+			},
+			expected: expected{
+				status: http.StatusOK,
+				body:   `[{"protocol":"tcp","address":"0.0.0.0","port":22}]` + "\n",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ports", nil)
+			rec := httptest.NewRecorder()
+
+			err := portsHandler(tc.ports(t))(echo.New().NewContext(req, rec))
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expected, expected{rec.Code, rec.Body.String()})
+		})
+	}
+}
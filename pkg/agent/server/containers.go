@@ -0,0 +1,89 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	dockerclient "github.com/docker/docker/client"
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
+	log "github.com/sirupsen/logrus"
+)
+
+// ContainerEnv is the environment variable set by ShellHub's SSH server on sessions that target a container running
+// on the device.
+const ContainerEnv = "SHELLHUB_CONTAINER"
+
+// SetDocker sets the Docker client used to execute sessions inside the device's containers and the device's users,
+// besides root, allowed to execute them.
+func (s *Server) SetDocker(cli dockerclient.APIClient, users []string) {
+	s.docker = cli
+	s.dockerUsers = users
+}
+
+// allowedContainerUser checks if the device's user may execute sessions inside the containers. As access to the
+// Docker Engine is equivalent to root access to the device, only root and the users allowed by the agent may.
+func (s *Server) allowedContainerUser(user string) bool {
+	return user == "root" || slices.Contains(s.dockerUsers, user)
+}
+
+// getSessionContainer gets the container targeted by the session, if any.
+func getSessionContainer(session gliderssh.Session) (string, bool) {
+	for _, env := range session.Environ() {
+		if name, value, ok := strings.Cut(env, "="); ok && name == ContainerEnv && value != "" {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// containerSessionHandler executes the session's command inside the container. The command runs as the user with the
+// same username inside the container.
+func (s *Server) containerSessionHandler(session gliderssh.Session, sessionType Type, container string) {
+	logger := log.WithFields(log.Fields{
+		"user":      session.User(),
+		"container": container,
+		"type":      sessionType,
+	})
+
+	fail := func(msg string) {
+		logger.Warn(msg)
+
+		fmt.Fprintln(session.Stderr(), msg) //nolint:errcheck
+		session.Exit(1)                     //nolint:errcheck
+	}
+
+	if s.docker == nil {
+		fail("containers are not enabled on this device")
+
+		return
+	}
+
+	if !s.allowedContainerUser(session.User()) {
+		fail(fmt.Sprintf("user %s isn't allowed to execute commands on containers", session.User()))
+
+		return
+	}
+
+	if sessionType != SessionTypeExec {
+		fail("only exec sessions can target a container")
+
+		return
+	}
+
+	// NOTICE: The user's shell is discarded as it belongs to the host and may not exist inside the container.
+	session.Context().SetValue("user", &osauth.User{Username: session.User()})
+
+	logger.Info("Container session started")
+
+	if err := connector.NewSessioner(&container, s.docker).Exec(session); err != nil {
+		fail(fmt.Sprintf("failed to execute the command on container %s", container))
+
+		return
+	}
+
+	logger.Info("Container session ended")
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	dockerclient "github.com/docker/docker/client"
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/stretchr/testify/assert"
+)
+
+// containerSession is a session targeting a container, recording what the handler writes to its stderr and the exit
+// status it sends.
+type containerSession struct {
+	gliderssh.Session
+
+	user   string
+	stderr bytes.Buffer
+	status int
+}
+
+func (s *containerSession) User() string { return s.user }
+
+func (s *containerSession) Stderr() io.ReadWriter { return &s.stderr }
+
+func (s *containerSession) Exit(code int) error {
+	s.status = code
+
+	return nil
+}
+
+func TestServer_allowedContainerUser(t *testing.T) {
+	cases := []struct {
+		description string
+		users       []string
+		user        string
+		expected    bool
+	}{
+		{
+			description: "allows root",
+			users:       []string{},
+			user:        "root",
+			expected:    true,
+		},
+		{
+			description: "allows a user listed by the agent",
+			users:       []string{"deploy"},
+			user:        "deploy",
+			expected:    true,
+		},
+		{
+			description: "refuses a user not listed by the agent",
+			users:       []string{"deploy"},
+			user:        "john_doe",
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			s := &Server{dockerUsers: tc.users}
+
+			assert.Equal(t, tc.expected, s.allowedContainerUser(tc.user))
+		})
+	}
+}
+
+func TestServer_containerSessionHandler(t *testing.T) {
+	// NOTICE: the Docker client is never called, as the user is refused before the command is executed.
+	s := &Server{}
+	s.SetDocker(struct{ dockerclient.APIClient }{}, []string{"deploy"})
+
+	session := &containerSession{user: "john_doe"}
+	s.containerSessionHandler(session, SessionTypeExec, "nginx")
+
+	assert.Equal(t, 1, session.status)
+	assert.Equal(t, "user john_doe isn't allowed to execute commands on containers\n", session.stderr.String())
+}
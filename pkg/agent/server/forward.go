@@ -0,0 +1,313 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+var ErrForwardRuleInvalid = errors.New("invalid forwarding rule")
+
+// forwardRule is a destination of the forwarding policy, as "host:port". The host may be an IP address, a CIDR, a
+// hostname or "*" for any host, and the port may be a number or "*" for any port.
+type forwardRule struct {
+	// prefix is the network of the rule's host, when it is an IP address or a CIDR.
+	prefix netip.Prefix
+	// hostname is the rule's host, when it isn't an IP address or a CIDR. It is "*" for any host.
+	hostname string
+	// port is the rule's port. It is zero for any port.
+	port uint32
+}
+
+// parseForwardRule parses a forwarding rule from its "host:port" form.
+func parseForwardRule(rule string) (forwardRule, error) {
+	host, port, err := net.SplitHostPort(rule)
+	if err != nil || host == "" || port == "" {
+		return forwardRule{}, fmt.Errorf("%w: %q", ErrForwardRuleInvalid, rule)
+	}
+
+	parsed := forwardRule{}
+
+	if port != "*" {
+		number, err := strconv.ParseUint(port, 10, 16)
+		if err != nil || number == 0 {
+			return forwardRule{}, fmt.Errorf("%w: %q", ErrForwardRuleInvalid, rule)
+		}
+
+		parsed.port = uint32(number)
+	}
+
+	switch {
+	case strings.Contains(host, "/"):
+		prefix, err := netip.ParsePrefix(host)
+		if err != nil {
+			return forwardRule{}, fmt.Errorf("%w: %q", ErrForwardRuleInvalid, rule)
+		}
+
+		parsed.prefix = prefix.Masked()
+	default:
+		if addr, err := netip.ParseAddr(host); err == nil {
+			parsed.prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		} else {
+			parsed.hostname = strings.ToLower(host)
+		}
+	}
+
+	return parsed, nil
+}
+
+// matchesHost checks if the rule's host matches the destination's hostname, when it is a hostname, or its address.
+func (r forwardRule) matchesHost(hostname string, addr netip.Addr) bool {
+	if r.hostname != "" {
+		return r.hostname == "*" || r.hostname == hostname
+	}
+
+	return addr.IsValid() && r.prefix.Contains(addr.Unmap())
+}
+
+func (r forwardRule) matchesPort(port uint32) bool {
+	return r.port == 0 || r.port == port
+}
+
+// ForwardPolicy restricts the destinations the agent forwards the direct-tcpip channels to. The denied destinations
+// are always rejected and, when there are allowed destinations, only them are accepted. An empty policy accepts every
+// destination.
+type ForwardPolicy struct {
+	allow []forwardRule
+	deny  []forwardRule
+}
+
+// NewForwardPolicy creates a forwarding policy from the comma-separated lists of allowed and denied destinations, like
+// "127.0.0.1:80,10.0.0.0/8:443". It returns a nil policy, which accepts every destination, when both lists are empty.
+func NewForwardPolicy(allowlist, denylist string) (*ForwardPolicy, error) {
+	parse := func(list string) ([]forwardRule, error) {
+		rules := []forwardRule{}
+		for _, rule := range strings.Split(list, ",") {
+			if rule = strings.TrimSpace(rule); rule == "" {
+				continue
+			}
+
+			parsed, err := parseForwardRule(rule)
+			if err != nil {
+				return nil, err
+			}
+
+			rules = append(rules, parsed)
+		}
+
+		return rules, nil
+	}
+
+	allow, err := parse(allowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	deny, err := parse(denylist)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil, nil
+	}
+
+	return &ForwardPolicy{allow: allow, deny: deny}, nil
+}
+
+// empty checks if the policy accepts every destination.
+func (p *ForwardPolicy) empty() bool {
+	return p == nil || (len(p.allow) == 0 && len(p.deny) == 0)
+}
+
+// Evaluate evaluates the destination, with the addresses its host resolves to, against the policy. It returns the
+// reason why the destination is rejected, or false when it is accepted.
+//
+// A destination is denied when its hostname, or any of its addresses, matches a denied destination, and it is allowed
+// when its hostname, or every one of its addresses, matches an allowed destination.
+func (p *ForwardPolicy) Evaluate(host string, addrs []netip.Addr, port uint32) (models.ForwardRejectionReason, bool) {
+	if p.empty() {
+		return "", false
+	}
+
+	hostname := strings.ToLower(host)
+
+	matches := func(rules []forwardRule, addr netip.Addr) bool {
+		for _, rule := range rules {
+			if rule.matchesPort(port) && rule.matchesHost(hostname, addr) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if matches(p.deny, netip.Addr{}) {
+		return models.ForwardRejectionDenied, true
+	}
+
+	for _, addr := range addrs {
+		if matches(p.deny, addr) {
+			return models.ForwardRejectionDenied, true
+		}
+	}
+
+	if len(p.allow) == 0 || matches(p.allow, netip.Addr{}) {
+		return "", false
+	}
+
+	if len(addrs) == 0 {
+		return models.ForwardRejectionNotAllowed, true
+	}
+
+	for _, addr := range addrs {
+		if !matches(p.allow, addr) {
+			return models.ForwardRejectionNotAllowed, true
+		}
+	}
+
+	return "", false
+}
+
+// ForwardRejectedError is returned by [ForwardPolicy.Destination] when the policy rejects the destination.
+type ForwardRejectedError struct {
+	Rejection models.ForwardRejection
+}
+
+func (e *ForwardRejectedError) Error() string {
+	return e.Rejection.Message()
+}
+
+// Destination evaluates the destination against the policy, returning the address it must be connected to, or a
+// [ForwardRejectedError] when the policy rejects it.
+//
+// NOTICE: when the policy restricts the destinations, the destination's host is resolved once, and the address
+// returned is the one evaluated, so the host can't resolve to another address between the evaluation and the
+// connection.
+func (p *ForwardPolicy) Destination(ctx context.Context, host string, port uint32) (string, error) {
+	dest := net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+	if p.empty() {
+		return dest, nil
+	}
+
+	addrs, err := resolveForwardHost(ctx, host)
+	if err != nil {
+		return "", err
+	}
+
+	if reason, rejected := p.Evaluate(host, addrs, port); rejected {
+		return "", &ForwardRejectedError{Rejection: models.ForwardRejection{Reason: reason, Destination: dest}}
+	}
+
+	return net.JoinHostPort(addrs[0].String(), strconv.FormatUint(uint64(port), 10)), nil
+}
+
+// directTCPIPHandler handles the direct-tcpip channels, like [gliderssh.DirectTCPIPHandler], enforcing the forwarding
+// policy when the channel is opened. A rejected channel carries a [models.ForwardRejection] as its message, so the SSH
+// server can tell why the channel was rejected.
+func (s *Server) directTCPIPHandler(srv *gliderssh.Server, _ *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+	type channelData struct {
+		DestAddr   string
+		DestPort   uint32
+		OriginAddr string
+		OriginPort uint32
+	}
+
+	data := new(channelData)
+	if err := gossh.Unmarshal(newChan.ExtraData(), data); err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "error parsing forward data: "+err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	if srv.LocalPortForwardingCallback == nil || !srv.LocalPortForwardingCallback(ctx, data.DestAddr, data.DestPort) {
+		newChan.Reject(gossh.Prohibited, "port forwarding is disabled") //nolint:errcheck
+
+		return
+	}
+
+	logger := log.WithFields(log.Fields{
+		"uid":         ctx.Value(contextKeySessionUID),
+		"user":        ctx.User(),
+		"destination": net.JoinHostPort(data.DestAddr, strconv.FormatUint(uint64(data.DestPort), 10)),
+	})
+
+	dest, err := s.forwardPolicy.Destination(ctx, data.DestAddr, data.DestPort)
+	if err != nil {
+		var rejected *ForwardRejectedError
+		if errors.As(err, &rejected) {
+			logger.WithField("reason", rejected.Rejection.Reason).Warn("port forwarding rejected by the forwarding policy")
+
+			newChan.Reject(gossh.Prohibited, rejected.Rejection.Message()) //nolint:errcheck
+
+			return
+		}
+
+		newChan.Reject(gossh.ConnectionFailed, err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	var dialer net.Dialer
+	dconn, err := dialer.DialContext(ctx, "tcp", dest)
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	ch, reqs, err := newChan.Accept()
+	if err != nil {
+		dconn.Close()
+
+		return
+	}
+
+	go gossh.DiscardRequests(reqs)
+
+	go func() {
+		defer ch.Close()
+		defer dconn.Close()
+
+		io.Copy(ch, dconn) //nolint:errcheck
+	}()
+
+	go func() {
+		defer ch.Close()
+		defer dconn.Close()
+
+		io.Copy(dconn, ch) //nolint:errcheck
+	}()
+}
+
+// resolveForwardHost resolves the destination's host to its addresses. An IP address is returned as is.
+func resolveForwardHost(ctx context.Context, host string) ([]netip.Addr, error) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return []netip.Addr{addr.Unmap()}, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no addresses found for %s", host)
+	}
+
+	for i, addr := range addrs {
+		addrs[i] = addr.Unmap()
+	}
+
+	return addrs, nil
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewForwardPolicy(t *testing.T) {
+	cases := []struct {
+		description string
+		allowlist   string
+		denylist    string
+		valid       bool
+	}{
+		{
+			description: "succeeds when the lists are empty",
+			valid:       true,
+		},
+		{
+			description: "succeeds with addresses, networks, hostnames and wildcards",
+			allowlist:   "127.0.0.1:80, 10.0.0.0/8:443,[::1]:22,localhost:*",
+			denylist:    "*:25",
+			valid:       true,
+		},
+		{
+			description: "fails when the port is missing",
+			allowlist:   "127.0.0.1",
+		},
+		{
+			description: "fails when the port is invalid",
+			denylist:    "127.0.0.1:70000",
+		},
+		{
+			description: "fails when the network is invalid",
+			allowlist:   "10.0.0.0/33:443",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := NewForwardPolicy(tc.allowlist, tc.denylist)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrForwardRuleInvalid)
+			}
+		})
+	}
+}
+
+func TestForwardPolicyEvaluate(t *testing.T) {
+	type Expected struct {
+		reason   models.ForwardRejectionReason
+		rejected bool
+	}
+
+	cases := []struct {
+		description string
+		allowlist   string
+		denylist    string
+		host        string
+		addrs       []netip.Addr
+		port        uint32
+		expected    Expected
+	}{
+		{
+			description: "accepts any destination when the policy is empty",
+			host:        "10.0.0.1",
+			addrs:       []netip.Addr{netip.MustParseAddr("10.0.0.1")},
+			port:        22,
+			expected:    Expected{"", false},
+		},
+		{
+			description: "accepts an allowed address",
+			allowlist:   "127.0.0.1:80,10.0.0.0/8:443",
+			host:        "10.1.2.3",
+			addrs:       []netip.Addr{netip.MustParseAddr("10.1.2.3")},
+			port:        443,
+			expected:    Expected{"", false},
+		},
+		{
+			description: "rejects an allowed address on another port",
+			allowlist:   "127.0.0.1:80,10.0.0.0/8:443",
+			host:        "10.1.2.3",
+			addrs:       []netip.Addr{netip.MustParseAddr("10.1.2.3")},
+			port:        22,
+			expected:    Expected{models.ForwardRejectionNotAllowed, true},
+		},
+		{
+			description: "accepts an allowed hostname",
+			allowlist:   "LocalHost:*",
+			host:        "localhost",
+			addrs:       []netip.Addr{netip.MustParseAddr("127.0.0.1")},
+			port:        8080,
+			expected:    Expected{"", false},
+		},
+		{
+			description: "rejects a hostname resolving to an address not allowed",
+			allowlist:   "10.0.0.0/8:*",
+			host:        "example.com",
+			addrs:       []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("93.184.216.34")},
+			port:        80,
+			expected:    Expected{models.ForwardRejectionNotAllowed, true},
+		},
+		{
+			description: "rejects a denied address even when it is allowed",
+			allowlist:   "10.0.0.0/8:*",
+			denylist:    "10.0.0.1:22",
+			host:        "10.0.0.1",
+			addrs:       []netip.Addr{netip.MustParseAddr("10.0.0.1")},
+			port:        22,
+			expected:    Expected{models.ForwardRejectionDenied, true},
+		},
+		{
+			description: "rejects a hostname resolving to a denied address",
+			denylist:    "169.254.169.254:*",
+			host:        "metadata.internal",
+			addrs:       []netip.Addr{netip.MustParseAddr("169.254.169.254")},
+			port:        80,
+			expected:    Expected{models.ForwardRejectionDenied, true},
+		},
+		{
+			description: "rejects a denied port on any host",
+			denylist:    "*:25",
+			host:        "mail.example.com",
+			addrs:       []netip.Addr{netip.MustParseAddr("192.0.2.25")},
+			port:        25,
+			expected:    Expected{models.ForwardRejectionDenied, true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			policy, err := NewForwardPolicy(tc.allowlist, tc.denylist)
+			assert.NoError(t, err)
+
+			reason, rejected := policy.Evaluate(tc.host, tc.addrs, tc.port)
+			assert.Equal(t, tc.expected, Expected{reason, rejected})
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultSessionHookTimeout is the maximum time a session hook can run before being killed.
+const DefaultSessionHookTimeout = 30 * time.Second
+
+// Session hook stages.
+const (
+	// SessionHookPre is the stage where the hook runs before the session's program is started.
+	SessionHookPre = "pre"
+	// SessionHookPost is the stage where the hook runs after the session's program has ended.
+	SessionHookPost = "post"
+)
+
+// Keys used to store the session's metadata, sent by ShellHub's SSH server, in the session's context.
+const (
+	contextKeySessionUID = "session_uid"
+	contextKeySessionIP  = "session_ip"
+)
+
+// SessionConn is a connection from ShellHub's SSH server to the agent, carrying the metadata of the session that will
+// be handled over it.
+type SessionConn struct {
+	net.Conn
+	// UID is the session's UID on ShellHub.
+	UID string
+	// IP is the IP address of the client that has started the session.
+	IP string
+	// LoginShell is the device's login shell, set on ShellHub, to be started instead of the user's shell.
+	LoginShell string
+	// RequestID is the correlation ID of the connection, included on the logs about the session.
+	RequestID string
+}
+
+// SessionHooks are the executables run by the agent, on the device, before and after each session.
+type SessionHooks struct {
+	// Pre is the path to the executable run before the session's program is started.
+	Pre string
+	// Post is the path to the executable run after the session's program has ended.
+	Post string
+	// Required defines if a failure on the pre-session hook blocks the session.
+	Required bool
+	// Timeout is the maximum time each hook can run.
+	Timeout time.Duration
+}
+
+// sessionHookEnv returns the environment variables with the session's metadata passed to the hooks.
+func (s *Server) sessionHookEnv(session gliderssh.Session, stage string) []string {
+	uid, _ := session.Context().Value(contextKeySessionUID).(string)
+	ip, _ := session.Context().Value(contextKeySessionIP).(string)
+	requestType, _ := session.Context().Value("request_type").(string)
+
+	return []string{
+		"SHELLHUB_HOOK=" + stage,
+		"SHELLHUB_DEVICE_NAME=" + s.deviceName,
+		"SHELLHUB_SESSION_ID=" + uid,
+		"SHELLHUB_SESSION_USER=" + session.User(),
+		"SHELLHUB_SESSION_IP=" + ip,
+		"SHELLHUB_SESSION_TYPE=" + requestType,
+	}
+}
+
+// runSessionHook runs the hook's executable with the session's metadata in its environment. It returns an error when
+// the hook cannot be started, exits with a non-zero status or exceeds the hook's timeout.
+func (s *Server) runSessionHook(session gliderssh.Session, stage, path string) error {
+	timeout := s.hooks.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSessionHookTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path) //nolint:gosec
+	cmd.Env = append(os.Environ(), s.sessionHookEnv(session, stage)...)
+	// NOTICE: the processes started by the hook may keep its output open after it was killed, so the output isn't
+	// waited for longer than this.
+	cmd.WaitDelay = time.Second
+
+	output, err := cmd.CombinedOutput()
+	if errors.Is(err, exec.ErrWaitDelay) && cmd.ProcessState.Success() {
+		// NOTICE: the hook has succeeded, but a process started by it, like a daemon, kept its output open.
+		err = nil
+	}
+
+	logger := log.WithContext(session.Context()).WithFields(log.Fields{
+		"hook":   stage,
+		"path":   path,
+		"user":   session.User(),
+		"output": string(output),
+	})
+
+	if err != nil {
+		logger.WithError(err).Warn("Session hook failed")
+
+		return err
+	}
+
+	logger.Debug("Session hook succeeded")
+
+	return nil
+}
+
+// withSessionHooks wraps the session's handler, running the pre-session hook before and the post-session hook after
+// it. When the pre-session hook is required and fails, the session is ended without calling the handler.
+func (s *Server) withSessionHooks(handler gliderssh.Handler) gliderssh.Handler {
+	return func(session gliderssh.Session) {
+		if s.hooks.Pre != "" {
+			if err := s.runSessionHook(session, SessionHookPre, s.hooks.Pre); err != nil && s.hooks.Required {
+				fmt.Fprintln(session.Stderr(), "session blocked by the device's pre-session hook") //nolint:errcheck
+				session.Exit(1)                                                                    //nolint:errcheck
+
+				return
+			}
+		}
+
+		if s.hooks.Post != "" {
+			defer s.runSessionHook(session, SessionHookPost, s.hooks.Post) //nolint:errcheck
+		}
+
+		handler(session)
+	}
+}
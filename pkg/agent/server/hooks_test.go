@@ -0,0 +1,200 @@
+//go:build !windows
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hookContext is a session's context carrying the metadata set from ShellHub's SSH server.
+type hookContext struct {
+	gliderssh.Context
+
+	values map[any]any
+}
+
+func (c *hookContext) Value(key any) any { return c.values[key] }
+
+// hookSession is a session with the metadata set from ShellHub's SSH server, recording what is written to its stderr
+// and the exit status it sends.
+type hookSession struct {
+	containerSession
+
+	ctx *hookContext
+}
+
+func (s *hookSession) Context() gliderssh.Context { return s.ctx }
+
+func newHookSession() *hookSession {
+	return &hookSession{
+		containerSession: containerSession{user: "john_doe", status: -1},
+		ctx: &hookContext{values: map[any]any{
+			contextKeySessionUID: "4a1d6f3e",
+			contextKeySessionIP:  "192.168.0.1",
+			"request_type":       "shell",
+		}},
+	}
+}
+
+// hook writes an executable script, with the specified body, to a temporary directory, returning its path.
+func hook(t *testing.T, body string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "hook")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o700)) //nolint:gosec
+
+	return path
+}
+
+func TestServer_runSessionHook(t *testing.T) {
+	t.Run("runs the hook with the session's metadata on its environment", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "env")
+
+		s := &Server{deviceName: "device"}
+		err := s.runSessionHook(newHookSession(), SessionHookPre, hook(t, "env | grep '^SHELLHUB_' | sort > "+out))
+		require.NoError(t, err)
+
+		env, err := os.ReadFile(out)
+		require.NoError(t, err)
+
+		assert.Equal(t, "SHELLHUB_DEVICE_NAME=device\n"+
+			"SHELLHUB_HOOK=pre\n"+
+			"SHELLHUB_SESSION_ID=4a1d6f3e\n"+
+			"SHELLHUB_SESSION_IP=192.168.0.1\n"+
+			"SHELLHUB_SESSION_TYPE=shell\n"+
+			"SHELLHUB_SESSION_USER=john_doe\n", string(env))
+	})
+
+	t.Run("fails when the hook exits with a non-zero status", func(t *testing.T) {
+		s := &Server{}
+
+		assert.Error(t, s.runSessionHook(newHookSession(), SessionHookPre, hook(t, "exit 1")))
+	})
+
+	t.Run("fails when the hook cannot be started", func(t *testing.T) {
+		s := &Server{}
+
+		assert.Error(t, s.runSessionHook(newHookSession(), SessionHookPre, filepath.Join(t.TempDir(), "missing")))
+	})
+
+	t.Run("succeeds when a process started by the hook keeps its output open", func(t *testing.T) {
+		s := &Server{}
+
+		started := time.Now()
+		err := s.runSessionHook(newHookSession(), SessionHookPost, hook(t, "sleep 10 &"))
+
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(started), 5*time.Second)
+	})
+
+	t.Run("kills the hook when it exceeds the timeout", func(t *testing.T) {
+		s := &Server{hooks: SessionHooks{Timeout: 100 * time.Millisecond}}
+
+		started := time.Now()
+		// NOTICE: the hook's child keeps its output open after the hook is killed.
+		err := s.runSessionHook(newHookSession(), SessionHookPre, hook(t, "sleep 10"))
+
+		assert.Error(t, err)
+		assert.Less(t, time.Since(started), 5*time.Second)
+	})
+}
+
+func TestServer_withSessionHooks(t *testing.T) {
+	cases := []struct {
+		description string
+		hooks       func(t *testing.T, out string) SessionHooks
+		handled     bool
+		status      int
+		stderr      string
+		ran         string
+	}{
+		{
+			description: "runs the pre-session hook before and the post-session hook after the session",
+			hooks: func(t *testing.T, out string) SessionHooks {
+				return SessionHooks{
+					Pre:  hook(t, "echo $SHELLHUB_HOOK >> "+out),
+					Post: hook(t, "echo $SHELLHUB_HOOK >> "+out),
+				}
+			},
+			handled: true,
+			status:  -1,
+			ran:     "pre\nhandler\npost\n",
+		},
+		{
+			description: "blocks the session when the required pre-session hook fails",
+			hooks: func(t *testing.T, out string) SessionHooks {
+				return SessionHooks{
+					Pre:      hook(t, "echo $SHELLHUB_HOOK >> "+out+"; exit 1"),
+					Post:     hook(t, "echo $SHELLHUB_HOOK >> "+out),
+					Required: true,
+				}
+			},
+			handled: false,
+			status:  1,
+			stderr:  "session blocked by the device's pre-session hook\n",
+			ran:     "pre\n",
+		},
+		{
+			description: "continues the session when the optional pre-session hook fails",
+			hooks: func(t *testing.T, out string) SessionHooks {
+				return SessionHooks{
+					Pre:  hook(t, "echo $SHELLHUB_HOOK >> "+out+"; exit 1"),
+					Post: hook(t, "echo $SHELLHUB_HOOK >> "+out),
+				}
+			},
+			handled: true,
+			status:  -1,
+			ran:     "pre\nhandler\npost\n",
+		},
+		{
+			description: "blocks the session when the required pre-session hook exceeds the timeout",
+			hooks: func(t *testing.T, out string) SessionHooks {
+				return SessionHooks{
+					Pre:      hook(t, "echo $SHELLHUB_HOOK >> "+out+"; sleep 10"),
+					Required: true,
+					Timeout:  100 * time.Millisecond,
+				}
+			},
+			handled: false,
+			status:  1,
+			stderr:  "session blocked by the device's pre-session hook\n",
+			ran:     "pre\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "ran")
+
+			s := &Server{hooks: tc.hooks(t, out)}
+			session := newHookSession()
+
+			handled := false
+			s.withSessionHooks(func(gliderssh.Session) {
+				handled = true
+
+				f, err := os.OpenFile(out, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+				require.NoError(t, err)
+				defer f.Close()
+
+				_, err = f.WriteString("handler\n")
+				require.NoError(t, err)
+			})(session)
+
+			assert.Equal(t, tc.handled, handled)
+			assert.Equal(t, tc.status, session.status)
+			assert.Equal(t, tc.stderr, session.stderr.String())
+
+			ran, err := os.ReadFile(out)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ran, string(ran))
+		})
+	}
+}
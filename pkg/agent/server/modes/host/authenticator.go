@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
@@ -16,6 +17,14 @@ import (
 	gossh "golang.org/x/crypto/ssh"
 )
 
+// Provisioner creates the users that don't exist on the device, as allowed by the namespace.
+type Provisioner interface {
+	// Allowed reports if the user may be provisioned.
+	Allowed(username string) bool
+	// Provision creates the user when it doesn't exist.
+	Provision(username string) error
+}
+
 // NOTICE: Ensures the Authenticator interface is implemented.
 var _ modes.Authenticator = (*Authenticator)(nil)
 
@@ -32,6 +41,9 @@ type Authenticator struct {
 	//
 	// NOTICE: Uses a pointer for later assignment.
 	deviceName *string
+	// provisioner creates the users that don't exist on the device, when the namespace allows it. When nil, the
+	// sessions to those users are refused.
+	provisioner Provisioner
 }
 
 // NewAuthenticator creates a new instance of Authenticator for the host mode.
@@ -50,6 +62,12 @@ func NewAuthenticator(api client.Client, authData *models.DeviceAuthResponse, si
 	}
 }
 
+// SetProvisioner sets the provisioner of the users targeted by the public key authenticated sessions that don't exist
+// on the device.
+func (a *Authenticator) SetProvisioner(p Provisioner) {
+	a.provisioner = p
+}
+
 // Password handles the server's SSH password authentication when server is running in host mode.
 func (a *Authenticator) Password(ctx gliderssh.Context, _ string, pass string) bool {
 	log := log.WithFields(log.Fields{
@@ -74,8 +92,14 @@ func (a *Authenticator) Password(ctx gliderssh.Context, _ string, pass string) b
 
 // PublicKey handles the server's SSH public key authentication when server is running in host mode.
 func (a *Authenticator) PublicKey(ctx gliderssh.Context, _ string, key gliderssh.PublicKey) bool {
+	// NOTICE: the users that don't exist on the device are only provisioned after the public key is verified.
+	provision := false
 	if _, err := osauth.LookupUser(ctx.User()); err != nil {
-		return false
+		if !errors.Is(err, osauth.ErrUserNotFound) || a.provisioner == nil || !a.provisioner.Allowed(ctx.User()) {
+			return false
+		}
+
+		provision = true
 	}
 
 	if key == nil {
@@ -176,6 +200,27 @@ func (a *Authenticator) PublicKey(ctx gliderssh.Context, _ string, key gliderssh
 		return false
 	}
 
+	if provision {
+		if err := a.provisioner.Provision(ctx.User()); err != nil {
+			log.WithFields(
+				log.Fields{
+					"container":   *a.deviceName,
+					"username":    ctx.User(),
+					"fingerprint": fingerprint,
+				},
+			).WithError(err).Error("failed to provision the user")
+
+			return false
+		}
+
+		log.WithFields(
+			log.Fields{
+				"container": *a.deviceName,
+				"username":  ctx.User(),
+			},
+		).Info("user provisioned")
+	}
+
 	log.WithFields(
 		log.Fields{
 			"container":   *a.deviceName,
@@ -20,6 +20,27 @@ import (
 	gossh "golang.org/x/crypto/ssh"
 )
 
+// provisionerFake is a [Provisioner] that records the users provisioned.
+type provisionerFake struct {
+	allowed     bool
+	err         error
+	provisioned []string
+}
+
+func (p *provisionerFake) Allowed(string) bool {
+	return p.allowed
+}
+
+func (p *provisionerFake) Provision(username string) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.provisioned = append(p.provisioned, username)
+
+	return nil
+}
+
 func TestPublicKey(t *testing.T) {
 	// stringToRef is a helper function to convert a string to a pointer to a string.
 	stringToRef := func(s string) *string { return &s }
@@ -27,6 +48,35 @@ func TestPublicKey(t *testing.T) {
 	privKey, _ := rsa.GenerateKey(rand.Reader, 2048)
 	key, _ := gossh.NewPublicKey(&privKey.PublicKey)
 
+	backend := new(osauthMocks.Backend)
+	osauth.DefaultBackend = backend
+
+	backend.On("LookupUser", "").Return(nil, osauth.ErrUserNotFound)
+	backend.On("LookupUser", "test").Return(&osauth.User{Username: "test"}, nil)
+	backend.On("LookupUser", "contractor").Return(nil, osauth.ErrUserNotFound)
+
+	// signed mocks the API signing the authentication of the username with the public key.
+	signed := func(apiMock *clientMocks.Client, username string) {
+		sigBytes, _ := json.Marshal(&struct {
+			Username  string
+			Namespace string
+		}{
+			Username:  username,
+			Namespace: "device",
+		})
+
+		digest := sha256.Sum256(sigBytes)
+
+		signature, _ := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, digest[:])
+
+		apiMock.On("AuthPublicKey", &models.PublicKeyAuthRequest{
+			Fingerprint: gossh.FingerprintLegacyMD5(key),
+			Data:        string(sigBytes),
+		}, "token").Return(&models.PublicKeyAuthResponse{
+			Signature: base64.StdEncoding.EncodeToString(signature),
+		}, nil).Once()
+	}
+
 	tests := []struct {
 		ctx           gliderssh.Context
 		authenticator *Authenticator
@@ -197,6 +247,55 @@ func TestPublicKey(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			ctx: &testSSHContext{
+				user: "contractor",
+			},
+			authenticator: &Authenticator{
+				authData:    &models.DeviceAuthResponse{Token: "token"},
+				deviceName:  stringToRef("device"),
+				api:         new(clientMocks.Client),
+				provisioner: &provisionerFake{allowed: false},
+			},
+			name:         "return false when the user doesn't exist and cannot be provisioned",
+			key:          key,
+			requiredMocs: func(_ *clientMocks.Client) {},
+			expected:     false,
+		},
+		{
+			ctx: &testSSHContext{
+				user: "contractor",
+			},
+			authenticator: &Authenticator{
+				authData:    &models.DeviceAuthResponse{Token: "token"},
+				deviceName:  stringToRef("device"),
+				api:         new(clientMocks.Client),
+				provisioner: &provisionerFake{allowed: true, err: errors.New("error")},
+			},
+			name: "return false when the user couldn't be provisioned",
+			key:  key,
+			requiredMocs: func(apiMock *clientMocks.Client) {
+				signed(apiMock, "contractor")
+			},
+			expected: false,
+		},
+		{
+			ctx: &testSSHContext{
+				user: "contractor",
+			},
+			authenticator: &Authenticator{
+				authData:    &models.DeviceAuthResponse{Token: "token"},
+				deviceName:  stringToRef("device"),
+				api:         new(clientMocks.Client),
+				provisioner: &provisionerFake{allowed: true},
+			},
+			name: "return true when the user is provisioned",
+			key:  key,
+			requiredMocs: func(apiMock *clientMocks.Client) {
+				signed(apiMock, "contractor")
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,12 @@
+//go:build !windows
+// +build !windows
+
+package command
+
+// DefaultShell is the shell running the command lines that aren't run through the user's shell, like the queued ones.
+const DefaultShell = "/bin/sh"
+
+// ShellArgs returns the arguments running the command line through the shell.
+func ShellArgs(shell, line string) []string {
+	return []string{shell, "-c", line}
+}
@@ -0,0 +1,40 @@
+//go:build windows
+// +build windows
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultShell is the shell running the command lines that aren't run through the user's shell, like the queued ones.
+// On Windows, it is the command interpreter, informed by the COMSPEC environment variable.
+var DefaultShell = func() string {
+	if comspec := os.Getenv("COMSPEC"); comspec != "" {
+		return comspec
+	}
+
+	return "cmd.exe"
+}()
+
+// ShellArgs returns the arguments running the command line through the shell, by the switch each shell expects: /c
+// for the command interpreter, -Command for the PowerShell and -c for the other ones, like the Git's bash.
+func ShellArgs(shell, line string) []string {
+	switch shellName(shell) {
+	case "cmd":
+		return []string{shell, "/c", line}
+	case "powershell", "pwsh":
+		return []string{shell, "-NoLogo", "-Command", line}
+	default:
+		return []string{shell, "-c", line}
+	}
+}
+
+// shellName returns the shell's program name, lowercased and without its extension.
+func shellName(shell string) string {
+	name := filepath.Base(shell)
+
+	return strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name)))
+}
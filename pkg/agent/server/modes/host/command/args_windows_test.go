@@ -0,0 +1,109 @@
+//go:build windows
+// +build windows
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellArgs(t *testing.T) {
+	cases := []struct {
+		description string
+		shell       string
+		line        string
+		args        []string
+		cmdline     string
+	}{
+		{
+			description: "runs the command interpreter with /c keeping the line as it is",
+			shell:       `C:\Windows\System32\cmd.exe`,
+			line:        `echo "hello world" & dir`,
+			args:        []string{`C:\Windows\System32\cmd.exe`, "/c", `echo "hello world" & dir`},
+			cmdline:     `C:\Windows\System32\cmd.exe /c echo "hello world" & dir`,
+		},
+		{
+			description: "runs the command interpreter regardless of its name's case",
+			shell:       "CMD.EXE",
+			line:        `dir "C:\Program Files\"`,
+			args:        []string{"CMD.EXE", "/c", `dir "C:\Program Files\"`},
+			cmdline:     `CMD.EXE /c dir "C:\Program Files\"`,
+		},
+		{
+			description: "runs the PowerShell with -Command quoting the line",
+			shell:       "powershell.exe",
+			line:        `Write-Output "hello world"`,
+			args:        []string{"powershell.exe", "-NoLogo", "-Command", `Write-Output "hello world"`},
+			cmdline:     `powershell.exe -NoLogo -Command "Write-Output \"hello world\""`,
+		},
+		{
+			description: "runs the PowerShell Core quoting its path with spaces",
+			shell:       `C:\Program Files\PowerShell\7\pwsh.exe`,
+			line:        `Write-Output "hello world"`,
+			args:        []string{`C:\Program Files\PowerShell\7\pwsh.exe`, "-NoLogo", "-Command", `Write-Output "hello world"`},
+			cmdline:     `"C:\Program Files\PowerShell\7\pwsh.exe" -NoLogo -Command "Write-Output \"hello world\""`,
+		},
+		{
+			description: "runs the other shells with -c quoting the line",
+			shell:       `C:\Program Files\Git\bin\bash.exe`,
+			line:        `echo 'hello world' | wc -c`,
+			args:        []string{`C:\Program Files\Git\bin\bash.exe`, "-c", `echo 'hello world' | wc -c`},
+			cmdline:     `"C:\Program Files\Git\bin\bash.exe" -c "echo 'hello world' | wc -c"`,
+		},
+		{
+			description: "escapes the trailing backslash of a quoted line",
+			shell:       "pwsh.exe",
+			line:        `dir "C:\Program Files\"`,
+			args:        []string{"pwsh.exe", "-NoLogo", "-Command", `dir "C:\Program Files\"`},
+			cmdline:     `pwsh.exe -NoLogo -Command "dir \"C:\Program Files\\\""`,
+		},
+		{
+			description: "quotes an empty line",
+			shell:       "pwsh.exe",
+			line:        "",
+			args:        []string{"pwsh.exe", "-NoLogo", "-Command", ""},
+			cmdline:     `pwsh.exe -NoLogo -Command ""`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			args := ShellArgs(tc.shell, tc.line)
+
+			assert.Equal(t, tc.args, args)
+			assert.Equal(t, tc.cmdline, CommandLine(args))
+		})
+	}
+}
+
+func TestCommandLine(t *testing.T) {
+	cases := []struct {
+		description string
+		args        []string
+		expected    string
+	}{
+		{
+			description: "quotes the arguments with spaces",
+			args:        []string{"sftp.exe", "-l", "C:\\Users\\John Doe"},
+			expected:    `sftp.exe -l "C:\Users\John Doe"`,
+		},
+		{
+			description: "keeps the command interpreter's line after an uppercase /C",
+			args:        []string{"cmd.exe", "/C", `echo "hello world"`},
+			expected:    `cmd.exe /C echo "hello world"`,
+		},
+		{
+			description: "escapes the /c argument of the other programs",
+			args:        []string{"bash.exe", "/c", `echo "hello world"`},
+			expected:    `bash.exe /c "echo \"hello world\""`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CommandLine(tc.args))
+		})
+	}
+}
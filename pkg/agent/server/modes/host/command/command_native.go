@@ -1,5 +1,5 @@
-//go:build !docker
-// +build !docker
+//go:build !docker && !windows
+// +build !docker,!windows
 
 package command
 
@@ -0,0 +1,65 @@
+//go:build windows
+// +build windows
+
+package command
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	log "github.com/sirupsen/logrus"
+)
+
+// NewCmd creates the command running as the agent's account, as the agent only runs in single-user mode on Windows.
+// The agent's environment is inherited, as the Windows programs depend on variables like SystemRoot to start.
+func NewCmd(u *osauth.User, shell, term, host string, envs []string, command ...string) *exec.Cmd {
+	cmd := exec.Command(command[0], command[1:]...) //nolint:gosec
+	cmd.Env = append(os.Environ(),
+		"TERM="+term,
+		"SHELL="+shell,
+		"SHELLHUB_HOST="+host,
+	)
+	cmd.Env = append(cmd.Env, envs...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: CommandLine(command)}
+
+	if _, err := os.Stat(u.HomeDir); err != nil {
+		log.WithError(err).WithField("dir", u.HomeDir).Warn("setting user's home directory to the system drive")
+
+		cmd.Dir = os.Getenv("SystemDrive") + `\`
+	} else {
+		cmd.Dir = u.HomeDir
+	}
+
+	return cmd
+}
+
+// CommandLine builds the Windows' command line from the arguments, quoting them as the programs parse it. The command
+// line run by the command interpreter, after its /c switch, is kept as it is, as the interpreter doesn't parse the
+// quotes as the other programs do.
+func CommandLine(args []string) string {
+	line := make([]string, 0, len(args))
+	for i, arg := range args {
+		if i > 1 && shellName(args[0]) == "cmd" && strings.EqualFold(args[i-1], "/c") {
+			line = append(line, args[i:]...)
+
+			break
+		}
+
+		line = append(line, syscall.EscapeArg(arg))
+	}
+
+	return strings.Join(line, " ")
+}
+
+// SFTPServerCommand creates the command used by agent to start the SFTP server used in a SFTP connection.
+func SFTPServerCommand() *exec.Cmd {
+	executable, err := os.Executable()
+	if err != nil {
+		executable = os.Args[0]
+	}
+
+	return exec.Command(executable, []string{"sftp", string(SFTPServerModeNative)}...) //nolint:gosec
+}
@@ -0,0 +1,60 @@
+package host
+
+import "strings"
+
+// TermEncodingEnv is the environment variable set by ShellHub's web terminal with the character encoding of the
+// terminal, as named on the locales, like "UTF-8" or "ISO-8859-1".
+const TermEncodingEnv = "SHELLHUB_TERM_ENCODING"
+
+// KeyboardLayoutEnv is the environment variable set by ShellHub's web terminal with the keyboard layout of the
+// user's browser, like "de" or "br".
+const KeyboardLayoutEnv = "SHELLHUB_KEYBOARD_LAYOUT"
+
+// defaultLanguage is the language used on the session's locale when the device's own language is unknown.
+const defaultLanguage = "en_US"
+
+// localeEnvs adds the locale matching the web terminal's encoding to the session's environment variables, so the
+// programs write their output in the encoding the terminal decodes.
+//
+// The locale is built from lang, the device's own locale, keeping its language with the terminal's encoding. It isn't
+// added when the client has set the locale itself. The keyboard layout, when informed, is exposed as
+// XKB_DEFAULT_LAYOUT.
+func localeEnvs(envs []string, lang string) []string {
+	var encoding, layout string
+	var locale bool
+
+	for _, env := range envs {
+		name, value, _ := strings.Cut(env, "=")
+		switch name {
+		case TermEncodingEnv:
+			encoding = value
+		case KeyboardLayoutEnv:
+			layout = value
+		case "LANG", "LC_ALL", "LC_CTYPE":
+			locale = true
+		}
+	}
+
+	if encoding != "" && !locale {
+		language, _, _ := strings.Cut(lang, ".")
+		language, _, _ = strings.Cut(language, "@")
+
+		switch {
+		case language == "C" || language == "POSIX":
+			// NOTICE: the C locale is only available with UTF-8 besides its own ASCII.
+			if !strings.EqualFold(encoding, "UTF-8") {
+				language = defaultLanguage
+			}
+		case language == "":
+			language = defaultLanguage
+		}
+
+		envs = append(envs, "LANG="+language+"."+encoding)
+	}
+
+	if layout != "" {
+		envs = append(envs, "XKB_DEFAULT_LAYOUT="+layout)
+	}
+
+	return envs
+}
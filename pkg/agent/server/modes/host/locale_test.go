@@ -0,0 +1,65 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleEnvs(t *testing.T) {
+	cases := []struct {
+		description string
+		envs        []string
+		lang        string
+		expected    []string
+	}{
+		{
+			description: "keeps the environment when the encoding is not set",
+			envs:        []string{"TERM=xterm"},
+			lang:        "de_DE.UTF-8",
+			expected:    []string{"TERM=xterm"},
+		},
+		{
+			description: "keeps the device's language with the terminal's encoding",
+			envs:        []string{TermEncodingEnv + "=ISO-8859-1"},
+			lang:        "de_DE.UTF-8@euro",
+			expected:    []string{TermEncodingEnv + "=ISO-8859-1", "LANG=de_DE.ISO-8859-1"},
+		},
+		{
+			description: "uses the default language when the device's one is unknown",
+			envs:        []string{TermEncodingEnv + "=KOI8-R"},
+			lang:        "",
+			expected:    []string{TermEncodingEnv + "=KOI8-R", "LANG=en_US.KOI8-R"},
+		},
+		{
+			description: "keeps the C locale with UTF-8",
+			envs:        []string{TermEncodingEnv + "=UTF-8"},
+			lang:        "C",
+			expected:    []string{TermEncodingEnv + "=UTF-8", "LANG=C.UTF-8"},
+		},
+		{
+			description: "uses the default language instead of the C locale with other encodings",
+			envs:        []string{TermEncodingEnv + "=CP1252"},
+			lang:        "POSIX",
+			expected:    []string{TermEncodingEnv + "=CP1252", "LANG=en_US.CP1252"},
+		},
+		{
+			description: "keeps the locale set by the client",
+			envs:        []string{"LC_ALL=pt_BR.UTF-8", TermEncodingEnv + "=ISO-8859-1"},
+			lang:        "de_DE.UTF-8",
+			expected:    []string{"LC_ALL=pt_BR.UTF-8", TermEncodingEnv + "=ISO-8859-1"},
+		},
+		{
+			description: "exposes the keyboard layout",
+			envs:        []string{KeyboardLayoutEnv + "=br"},
+			lang:        "pt_BR.UTF-8",
+			expected:    []string{KeyboardLayoutEnv + "=br", "XKB_DEFAULT_LAYOUT=br"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, localeEnvs(tc.envs, tc.lang))
+		})
+	}
+}
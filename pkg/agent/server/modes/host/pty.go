@@ -1,3 +1,5 @@
+//go:build !windows
+
 package host
 
 import (
@@ -8,6 +10,7 @@ import (
 
 	creackpty "github.com/creack/pty"
 	glidderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -122,3 +125,29 @@ func initPty(c *exec.Cmd, sess io.ReadWriter, winCh <-chan glidderssh.Window) (*
 
 	return pty, tty, nil
 }
+
+// startExecPty starts the command of an exec session on a new pseudo-terminal, owned by the user, relaying it to the
+// session. It returns a function releasing the pseudo-terminal after the command ends.
+func startExecPty(c *exec.Cmd, sess io.ReadWriter, _ glidderssh.Window, winCh <-chan glidderssh.Window, user *osauth.User) (func(), error) {
+	pty, tty, err := initPty(c, sess, winCh)
+	if err != nil {
+		return nil, err
+	}
+
+	release := func() {
+		pty.Close()
+		tty.Close()
+	}
+
+	if err := os.Chown(tty.Name(), int(user.UID), -1); err != nil {
+		log.Warn(err)
+	}
+
+	if err := c.Start(); err != nil {
+		release()
+
+		return nil, err
+	}
+
+	return release, nil
+}
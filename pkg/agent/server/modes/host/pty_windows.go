@@ -0,0 +1,242 @@
+//go:build windows
+
+package host
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"unsafe"
+
+	glidderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sys/windows"
+)
+
+// ErrConPtyUnsupported is returned when the Windows doesn't support the pseudo consoles, added on Windows 10 1809.
+var ErrConPtyUnsupported = errors.New("the pseudo consoles aren't supported by this Windows version")
+
+// conPty is a Windows pseudo console, through ConPTY, running a command and relaying its terminal to a session.
+//
+// Unlike a Unix pseudo-terminal, the pseudo console renders the command's console as a stream of VT sequences, and
+// translates the VT sequences received back to the console's input, so the command doesn't need to know about them.
+type conPty struct {
+	console windows.Handle
+	// input is written with the session's input, and output is read with the console's rendering.
+	input  *os.File
+	output *os.File
+	// relayed is done when the console's output was relayed to the session.
+	relayed sync.WaitGroup
+	once    sync.Once
+}
+
+// startConPty starts the command on a new pseudo console with the window's size, relaying it to the session and
+// resizing it on the window changes. As the process is created by ConPTY, the command's Process is set from it, so the
+// command is waited and killed as if it was started by [exec.Cmd.Start].
+func startConPty(c *exec.Cmd, sess io.ReadWriter, win glidderssh.Window, winCh <-chan glidderssh.Window) (*conPty, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	if err := windows.NewLazySystemDLL("kernel32.dll").NewProc("CreatePseudoConsole").Find(); err != nil {
+		return nil, ErrConPtyUnsupported
+	}
+
+	// NOTICE: the console reads its input from inRead and writes its rendering to outWrite, which are only needed
+	// until the console is created, as it duplicates them.
+	var inRead, inWrite, outRead, outWrite windows.Handle
+	if err := windows.CreatePipe(&inRead, &inWrite, nil, 0); err != nil {
+		return nil, err
+	}
+
+	defer windows.CloseHandle(inRead) //nolint:errcheck
+
+	if err := windows.CreatePipe(&outRead, &outWrite, nil, 0); err != nil {
+		windows.CloseHandle(inWrite) //nolint:errcheck
+
+		return nil, err
+	}
+
+	defer windows.CloseHandle(outWrite) //nolint:errcheck
+
+	p := &conPty{
+		input:  os.NewFile(uintptr(inWrite), "conpty-input"),
+		output: os.NewFile(uintptr(outRead), "conpty-output"),
+	}
+
+	if err := windows.CreatePseudoConsole(consoleSize(win), inRead, outWrite, 0, &p.console); err != nil {
+		p.input.Close()
+		p.output.Close()
+
+		return nil, err
+	}
+
+	if err := p.start(c); err != nil {
+		p.Close()
+
+		return nil, err
+	}
+
+	go func() {
+		for win := range winCh {
+			if err := windows.ResizePseudoConsole(p.console, consoleSize(win)); err != nil {
+				log.WithError(err).Warn("failed to resize the pseudo console")
+			}
+		}
+	}()
+
+	p.relayed.Add(1)
+	go func() {
+		defer p.relayed.Done()
+
+		if _, err := io.Copy(sess, p.output); err != nil {
+			log.Warn(err)
+		}
+	}()
+
+	go func() {
+		if _, err := io.Copy(p.input, sess); err != nil {
+			log.Warn(err)
+		}
+	}()
+
+	return p, nil
+}
+
+// start creates the command's process attached to the pseudo console.
+func (p *conPty) start(c *exec.Cmd) error {
+	attributes, err := windows.NewProcThreadAttributeList(1)
+	if err != nil {
+		return err
+	}
+
+	defer attributes.Delete()
+
+	// NOTICE: the attribute's value is the console's handle itself, not a pointer to it.
+	if err := attributes.Update(
+		windows.PROC_THREAD_ATTRIBUTE_PSEUDOCONSOLE,
+		*(*unsafe.Pointer)(unsafe.Pointer(&p.console)),
+		unsafe.Sizeof(p.console),
+	); err != nil {
+		return err
+	}
+
+	info := new(windows.StartupInfoEx)
+	info.Cb = uint32(unsafe.Sizeof(*info))
+	// NOTICE: without standard handles of its own, the process could use the agent's ones instead of the console.
+	info.Flags = windows.STARTF_USESTDHANDLES
+	info.ProcThreadAttributeList = attributes.List()
+
+	line := command.CommandLine(c.Args)
+	if c.SysProcAttr != nil && c.SysProcAttr.CmdLine != "" {
+		line = c.SysProcAttr.CmdLine
+	}
+
+	name, err := windows.UTF16PtrFromString(c.Path)
+	if err != nil {
+		return err
+	}
+
+	args, err := windows.UTF16PtrFromString(line)
+	if err != nil {
+		return err
+	}
+
+	var dir *uint16
+	if c.Dir != "" {
+		if dir, err = windows.UTF16PtrFromString(c.Dir); err != nil {
+			return err
+		}
+	}
+
+	env, err := environmentBlock(c.Env)
+	if err != nil {
+		return err
+	}
+
+	var process windows.ProcessInformation
+	if err := windows.CreateProcess(
+		name,
+		args,
+		nil,
+		nil,
+		false,
+		windows.EXTENDED_STARTUPINFO_PRESENT|windows.CREATE_UNICODE_ENVIRONMENT,
+		env,
+		dir,
+		&info.StartupInfo,
+		&process,
+	); err != nil {
+		return err
+	}
+
+	defer windows.CloseHandle(process.Process) //nolint:errcheck
+	defer windows.CloseHandle(process.Thread)  //nolint:errcheck
+
+	// NOTICE: the process' handle is kept open until the process is found, so its ID can't be reused meanwhile.
+	c.Process, err = os.FindProcess(int(process.ProcessId))
+
+	return err
+}
+
+// Close closes the pseudo console, ending its output after the rendering still pending is relayed to the session.
+func (p *conPty) Close() {
+	p.once.Do(func() {
+		windows.ClosePseudoConsole(p.console)
+
+		p.input.Close()
+		p.relayed.Wait()
+		p.output.Close()
+	})
+}
+
+// consoleSize converts the window's size to the pseudo console's one, which can't be empty.
+func consoleSize(win glidderssh.Window) windows.Coord {
+	size := windows.Coord{X: 80, Y: 24}
+	if win.Width > 0 && win.Width <= 0x7fff {
+		size.X = int16(win.Width) //nolint:gosec
+	}
+
+	if win.Height > 0 && win.Height <= 0x7fff {
+		size.Y = int16(win.Height) //nolint:gosec
+	}
+
+	return size
+}
+
+// environmentBlock builds the process' environment block: the variables, each one terminated by a null character, and
+// an additional null character ending the block.
+func environmentBlock(env []string) (*uint16, error) {
+	block := make([]uint16, 0)
+	for _, variable := range env {
+		encoded, err := windows.UTF16FromString(variable)
+		if err != nil {
+			return nil, err
+		}
+
+		block = append(block, encoded...)
+	}
+
+	if len(env) == 0 {
+		block = append(block, 0)
+	}
+
+	block = append(block, 0)
+
+	return &block[0], nil
+}
+
+// startExecPty starts the command of an exec session on a new pseudo console, relaying it to the session. It returns a
+// function closing the pseudo console after the command ends.
+func startExecPty(c *exec.Cmd, sess io.ReadWriter, win glidderssh.Window, winCh <-chan glidderssh.Window, _ *osauth.User) (func(), error) {
+	p, err := startConPty(c, sess, win, winCh)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Close, nil
+}
@@ -7,14 +7,13 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"os/user"
 	"sync"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sandbox"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
-	"github.com/shellhub-io/shellhub/pkg/agent/server/utmp"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -30,79 +29,51 @@ type Sessioner struct {
 	//
 	// NOTICE: It's a pointer because when the server is created, we don't know the device name yet, that is set later.
 	deviceName *string
+	// sandboxed defines if the session's programs run inside the sandbox. Check the [sandbox] package for more
+	// information.
+	sandboxed bool
 }
 
 func (s *Sessioner) SetCmds(cmds map[string]*exec.Cmd) {
 	s.cmds = cmds
 }
 
-// NewSessioner creates a new instance of Sessioner for the host mode.
-// The device name is a pointer to a string because when the server is created, we don't know the device name yet, that
-// is set later.
-func NewSessioner(deviceName *string, cmds map[string]*exec.Cmd) *Sessioner {
-	return &Sessioner{
-		deviceName: deviceName,
-		cmds:       cmds,
-	}
+// SetSandbox defines if the session's programs run inside the sandbox, restricting the visibility of the file system
+// to the user's home directory.
+func (s *Sessioner) SetSandbox(enabled bool) {
+	s.sandboxed = enabled
 }
 
-// Shell manages the SSH shell session of the server when operating in host mode.
-func (s *Sessioner) Shell(session gliderssh.Session) error {
-	sspty, winCh, isPty := session.Pty()
-
-	scmd := generateShellCmd(*s.deviceName, session, sspty.Term)
-
-	pts, err := startPty(scmd, session, winCh)
-	if err != nil {
-		log.Warn(err)
+// sandboxCmd wraps the command to run inside the sandbox when it is enabled, using the home directory of the user.
+func (s *Sessioner) sandboxCmd(cmd *exec.Cmd, username string) error {
+	if !s.sandboxed {
+		return nil
 	}
 
-	u, err := osauth.LookupUser(session.User())
+	user, err := osauth.LookupUser(username)
 	if err != nil {
 		return err
 	}
 
-	err = os.Chown(pts.Name(), int(u.UID), -1)
-	if err != nil {
-		log.Warn(err)
-	}
-
-	remoteAddr := session.RemoteAddr()
+	if err := sandbox.Wrap(cmd, user.HomeDir); err != nil {
+		log.WithError(err).WithField("user", username).Error("Failed to sandbox the session")
 
-	log.WithFields(log.Fields{
-		"user":       session.User(),
-		"pty":        pts.Name(),
-		"ispty":      isPty,
-		"remoteaddr": remoteAddr,
-		"localaddr":  session.LocalAddr(),
-	}).Info("Session started")
-
-	ut := utmp.UtmpStartSession(
-		pts.Name(),
-		session.User(),
-		remoteAddr.String(),
-	)
-
-	s.mu.Lock()
-	s.cmds[session.Context().Value(gliderssh.ContextKeySessionID).(string)] = scmd
-	s.mu.Unlock()
-
-	if err := scmd.Wait(); err != nil {
-		log.Warn(err)
+		return err
 	}
 
-	log.WithFields(log.Fields{
-		"user":       session.User(),
-		"pty":        pts.Name(),
-		"remoteaddr": remoteAddr,
-		"localaddr":  session.LocalAddr(),
-	}).Info("Session ended")
-
-	utmp.UtmpEndSession(ut)
-
 	return nil
 }
 
+// NewSessioner creates a new instance of Sessioner for the host mode.
+// The device name is a pointer to a string because when the server is created, we don't know the device name yet, that
+// is set later.
+func NewSessioner(deviceName *string, cmds map[string]*exec.Cmd) *Sessioner {
+	return &Sessioner{
+		deviceName: deviceName,
+		cmds:       cmds,
+	}
+}
+
 // Heredoc handles the server's SSH heredoc session when server is running in host mode.
 //
 // heredoc is special block of code that contains multi-line strings that will be redirected to a stdin of a shell. It
@@ -111,6 +82,11 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 	_, _, isPty := session.Pty()
 
 	cmd := generateShellCmd(*s.deviceName, session, "")
+	if err := s.sandboxCmd(cmd, session.User()); err != nil {
+		session.Exit(1) //nolint:errcheck
+
+		return err
+	}
 
 	stdout, _ := cmd.StdoutPipe()
 	stdin, _ := cmd.StdinPipe()
@@ -202,21 +178,23 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		term = "xterm"
 	}
 
-	cmd := command.NewCmd(user, shell, term, *s.deviceName, session.Environ(), shell, "-c", session.RawCommand())
+	cmd := command.NewCmd(user, shell, term, *s.deviceName, session.Environ(), command.ShellArgs(shell, session.RawCommand())...)
+	if err := s.sandboxCmd(cmd, session.User()); err != nil {
+		session.Exit(1) //nolint:errcheck
+
+		return err
+	}
 
 	wg := &sync.WaitGroup{}
 	if sIsPty {
-		pty, tty, err := initPty(cmd, session, sWinCh)
+		release, err := startExecPty(cmd, session, sPty.Window, sWinCh, user)
 		if err != nil {
-			log.Warn(err)
-		}
-
-		defer tty.Close()
-		defer pty.Close()
+			log.WithError(err).WithField("user", session.User()).Error("Failed to start the PTY")
 
-		if err := os.Chown(tty.Name(), int(user.UID), -1); err != nil {
-			log.Warn(err)
+			return err
 		}
+
+		defer release()
 	} else {
 		stdout, _ := cmd.StdoutPipe()
 		stdin, _ := cmd.StdinPipe()
@@ -241,6 +219,10 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 				fmt.Println(err) //nolint:forbidigo
 			}
 		}()
+
+		if err := cmd.Start(); err != nil {
+			return err
+		}
 	}
 
 	log.WithFields(log.Fields{
@@ -251,10 +233,6 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		"Raw command": session.RawCommand(),
 	}).Info("Command started")
 
-	if err := cmd.Start(); err != nil {
-		return err
-	}
-
 	if !sIsPty {
 		wg.Wait()
 	}
@@ -300,7 +278,7 @@ func (s *Sessioner) SFTP(session gliderssh.Session) error {
 
 	cmd := command.SFTPServerCommand()
 
-	looked, err := user.Lookup(session.User())
+	env, err := sftpUserEnv(session.User())
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"user": session.Context().User(),
@@ -309,13 +287,15 @@ func (s *Sessioner) SFTP(session gliderssh.Session) error {
 		return errors.New("failed to lookup user")
 	}
 
-	home := fmt.Sprintf("HOME=%s", looked.HomeDir)
-	gid := fmt.Sprintf("GID=%s", looked.Gid)
-	uid := fmt.Sprintf("UID=%s", looked.Uid)
+	cmd.Env = append(cmd.Env, env...)
 
-	cmd.Env = append(cmd.Env, home)
-	cmd.Env = append(cmd.Env, gid)
-	cmd.Env = append(cmd.Env, uid)
+	if readOnly, ok := session.Context().Value(modes.ContextKeySFTPReadOnly).(bool); ok && readOnly {
+		cmd.Env = append(cmd.Env, "READ_ONLY=true")
+	}
+
+	if err := s.sandboxCmd(cmd, session.User()); err != nil {
+		return errors.New("failed to sandbox the session")
+	}
 
 	input, err := cmd.StdinPipe()
 	if err != nil {
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package host
+
+import (
+	"fmt"
+	"os/user"
+)
+
+// sftpUserEnv returns the environment variables informing the SFTP server of the user it switches to: its home
+// directory, UID and GID.
+func sftpUserEnv(username string) ([]string, error) {
+	looked, err := user.Lookup(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		fmt.Sprintf("HOME=%s", looked.HomeDir),
+		fmt.Sprintf("GID=%s", looked.Gid),
+		fmt.Sprintf("UID=%s", looked.Uid),
+	}, nil
+}
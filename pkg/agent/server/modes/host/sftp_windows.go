@@ -0,0 +1,21 @@
+//go:build windows
+
+package host
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+)
+
+// sftpUserEnv returns the environment variables informing the SFTP server of the user's home directory. On Windows,
+// the server inherits the agent's environment and account, as the agent only runs in single-user mode.
+func sftpUserEnv(username string) ([]string, error) {
+	looked, err := osauth.LookupUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(os.Environ(), fmt.Sprintf("HOME=%s", looked.HomeDir)), nil
+}
@@ -0,0 +1,74 @@
+//go:build !windows
+
+package host
+
+import (
+	"os"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/utmp"
+	log "github.com/sirupsen/logrus"
+)
+
+// Shell manages the SSH shell session of the server when operating in host mode.
+func (s *Sessioner) Shell(session gliderssh.Session) error {
+	sspty, winCh, isPty := session.Pty()
+
+	scmd := generateShellCmd(*s.deviceName, session, sspty.Term)
+	if err := s.sandboxCmd(scmd, session.User()); err != nil {
+		session.Exit(1) //nolint:errcheck
+
+		return err
+	}
+
+	pts, err := startPty(scmd, session, winCh)
+	if err != nil {
+		log.WithError(err).WithField("user", session.User()).Error("Failed to start the PTY")
+	}
+
+	u, err := osauth.LookupUser(session.User())
+	if err != nil {
+		return err
+	}
+
+	err = os.Chown(pts.Name(), int(u.UID), -1)
+	if err != nil {
+		log.Warn(err)
+	}
+
+	remoteAddr := session.RemoteAddr()
+
+	log.WithFields(log.Fields{
+		"user":       session.User(),
+		"pty":        pts.Name(),
+		"ispty":      isPty,
+		"remoteaddr": remoteAddr,
+		"localaddr":  session.LocalAddr(),
+	}).Info("Session started")
+
+	ut := utmp.UtmpStartSession(
+		pts.Name(),
+		session.User(),
+		remoteAddr.String(),
+	)
+
+	s.mu.Lock()
+	s.cmds[session.Context().Value(gliderssh.ContextKeySessionID).(string)] = scmd
+	s.mu.Unlock()
+
+	if err := scmd.Wait(); err != nil {
+		log.Warn(err)
+	}
+
+	log.WithFields(log.Fields{
+		"user":       session.User(),
+		"pty":        pts.Name(),
+		"remoteaddr": remoteAddr,
+		"localaddr":  session.LocalAddr(),
+	}).Info("Session ended")
+
+	utmp.UtmpEndSession(ut)
+
+	return nil
+}
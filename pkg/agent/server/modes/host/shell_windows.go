@@ -0,0 +1,57 @@
+//go:build windows
+
+package host
+
+import (
+	gliderssh "github.com/gliderlabs/ssh"
+	log "github.com/sirupsen/logrus"
+)
+
+// Shell manages the SSH shell session of the server when operating in host mode. On Windows, the shell runs on a
+// pseudo console, through ConPTY, so the console programs work as on a terminal.
+func (s *Sessioner) Shell(session gliderssh.Session) error {
+	sspty, winCh, isPty := session.Pty()
+
+	scmd := generateShellCmd(*s.deviceName, session, sspty.Term)
+	if err := s.sandboxCmd(scmd, session.User()); err != nil {
+		session.Exit(1) //nolint:errcheck
+
+		return err
+	}
+
+	console, err := startConPty(scmd, session, sspty.Window, winCh)
+	if err != nil {
+		log.WithError(err).WithField("user", session.User()).Error("Failed to start the pseudo console")
+
+		session.Exit(1) //nolint:errcheck
+
+		return err
+	}
+
+	remoteAddr := session.RemoteAddr()
+
+	log.WithFields(log.Fields{
+		"user":       session.User(),
+		"ispty":      isPty,
+		"remoteaddr": remoteAddr,
+		"localaddr":  session.LocalAddr(),
+	}).Info("Session started")
+
+	s.mu.Lock()
+	s.cmds[session.Context().Value(gliderssh.ContextKeySessionID).(string)] = scmd
+	s.mu.Unlock()
+
+	if err := scmd.Wait(); err != nil {
+		log.Warn(err)
+	}
+
+	console.Close()
+
+	log.WithFields(log.Fields{
+		"user":       session.User(),
+		"remoteaddr": remoteAddr,
+		"localaddr":  session.LocalAddr(),
+	}).Info("Session ended")
+
+	return nil
+}
@@ -8,12 +8,13 @@ import (
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
 )
 
 func generateShellCmd(deviceName string, session gliderssh.Session, term string) *exec.Cmd {
 	username := session.User()
-	envs := session.Environ()
+	envs := localeEnvs(session.Environ(), os.Getenv("LANG"))
 
 	shell := os.Getenv("SHELL")
 
@@ -26,11 +27,20 @@ func generateShellCmd(deviceName string, session gliderssh.Session, term string)
 		shell = user.Shell
 	}
 
+	args := []string{shell, "-"}
+
+	// NOTICE: The device's login shell, when allowed by the agent, is started instead of the user's shell. As it may
+	// be any program, like a TUI, it is started without the login shell's arguments.
+	if loginShell, ok := session.Context().Value(modes.ContextKeyLoginShell).(string); ok && loginShell != "" {
+		shell = loginShell
+		args = []string{loginShell}
+	}
+
 	if term == "" {
 		term = "xterm"
 	}
 
-	cmd := command.NewCmd(user, shell, term, deviceName, envs, shell, "-")
+	cmd := command.NewCmd(user, shell, term, deviceName, envs, args...)
 
 	return cmd
 }
@@ -0,0 +1,46 @@
+//go:build windows
+
+package host
+
+import (
+	"os"
+	"os/exec"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+)
+
+func generateShellCmd(deviceName string, session gliderssh.Session, term string) *exec.Cmd {
+	username := session.User()
+	envs := localeEnvs(session.Environ(), os.Getenv("LANG"))
+
+	shell := os.Getenv("SHELL")
+
+	user, err := osauth.LookupUser(username)
+	if err != nil {
+		return nil
+	}
+
+	if shell == "" {
+		shell = user.Shell
+	}
+
+	// NOTICE: The Windows' shells have no login mode, so they are started without arguments.
+	args := []string{shell}
+
+	// NOTICE: The device's login shell, when allowed by the agent, is started instead of the user's shell.
+	if loginShell, ok := session.Context().Value(modes.ContextKeyLoginShell).(string); ok && loginShell != "" {
+		shell = loginShell
+		args = []string{loginShell}
+	}
+
+	if term == "" {
+		term = "xterm"
+	}
+
+	cmd := command.NewCmd(user, shell, term, deviceName, envs, args...)
+
+	return cmd
+}
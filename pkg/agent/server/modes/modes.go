@@ -3,6 +3,14 @@ package modes
 
 import gliderssh "github.com/gliderlabs/ssh"
 
+// ContextKeyLoginShell is the key, on the session's context, of the program to be started instead of the user's shell
+// on interactive sessions. It is only set when the program is allowed by the agent.
+const ContextKeyLoginShell = "login_shell"
+
+// ContextKeySFTPReadOnly is the key, on the session's context, that indicates the SFTP session must refuse the
+// operations that write to the device.
+const ContextKeySFTPReadOnly = "sftp_read_only"
+
 // Mode defines the SSH's server mode type.
 type Mode interface {
 	Authenticator
@@ -3,13 +3,16 @@ package server
 import (
 	"net"
 	"os/exec"
+	"slices"
 	"sync"
 	"time"
 
+	dockerclient "github.com/docker/docker/client"
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host"
 	"github.com/shellhub-io/shellhub/pkg/api/client"
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -51,6 +54,28 @@ type Server struct {
 	// Check the [modes] package for more information.
 	mode     modes.Mode
 	Sessions sync.Map
+
+	// hooks are the executables run before and after each session.
+	hooks SessionHooks
+
+	// loginShells are the programs allowed to be started, instead of the user's shell, as the device's login shell.
+	loginShells []string
+
+	// docker is the client used to execute sessions inside the device's containers. When nil, sessions targeting a
+	// container are refused.
+	docker dockerclient.APIClient
+	// dockerUsers are the device's users, besides root, allowed to execute sessions inside the device's containers.
+	dockerUsers []string
+
+	// configMu guards the settings below, delivered on the device's configuration and changed while the server runs.
+	configMu sync.RWMutex
+	// forcedCommand is the command executed on the sessions instead of the one requested by the SSH client.
+	forcedCommand string
+	// sftp is the restriction on the SFTP sessions, as one of [models.DeviceConfigSFTPModes].
+	sftp string
+
+	// forwardPolicy restricts the destinations of the direct-tcpip channels. When nil, every destination is accepted.
+	forwardPolicy *ForwardPolicy
 }
 
 // SSH channels supported by the SSH server.
@@ -95,6 +120,12 @@ type Config struct {
 	KeepAliveInterval uint32
 	// Features list of featues on SSH server.
 	Features Feature
+	// Hooks are the executables run before and after each session.
+	Hooks SessionHooks
+	// LoginShells are the programs allowed to be started, instead of the user's shell, as the device's login shell.
+	LoginShells []string
+	// ForwardPolicy restricts the destinations of the direct-tcpip channels. When nil, every destination is accepted.
+	ForwardPolicy *ForwardPolicy
 }
 
 // NewServer creates a new server SSH agent server.
@@ -105,6 +136,9 @@ func NewServer(api client.Client, mode modes.Mode, cfg *Config) *Server {
 		cmds:              make(map[string]*exec.Cmd),
 		keepAliveInterval: cfg.KeepAliveInterval,
 		Sessions:          sync.Map{},
+		hooks:             cfg.Hooks,
+		loginShells:       cfg.LoginShells,
+		forwardPolicy:     cfg.ForwardPolicy,
 	}
 
 	if m, ok := mode.(*host.Mode); ok {
@@ -114,12 +148,22 @@ func NewServer(api client.Client, mode modes.Mode, cfg *Config) *Server {
 	server.sshd = &gliderssh.Server{
 		PasswordHandler:        server.passwordHandler,
 		PublicKeyHandler:       server.publicKeyHandler,
-		Handler:                server.sessionHandler,
+		Handler:                server.withSessionHooks(server.sessionHandler),
 		SessionRequestCallback: server.sessionRequestCallback,
 		SubsystemHandlers: map[string]gliderssh.SubsystemHandler{
-			SFTPSubsystemName: server.sftpSubsystemHandler,
+			SFTPSubsystemName: gliderssh.SubsystemHandler(server.withSessionHooks(server.sftpSubsystemHandler)),
 		},
 		ConnCallback: func(ctx gliderssh.Context, conn net.Conn) net.Conn {
+			if c, ok := conn.(*SessionConn); ok {
+				ctx.SetValue(contextKeySessionUID, c.UID)
+				ctx.SetValue(contextKeySessionIP, c.IP)
+				ctx.SetValue(correlation.IDKey, c.RequestID)
+
+				if shell, ok := server.allowedLoginShell(c.LoginShell); ok {
+					ctx.SetValue(modes.ContextKeyLoginShell, shell)
+				}
+			}
+
 			closeCallback := func(id string) {
 				server.mu.Lock()
 				defer server.mu.Unlock()
@@ -140,7 +184,7 @@ func NewServer(api client.Client, mode modes.Mode, cfg *Config) *Server {
 		},
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
 			ChannelSession:     gliderssh.DefaultSessionHandler,
-			ChannelDirectTcpip: gliderssh.DirectTCPIPHandler,
+			ChannelDirectTcpip: server.directTCPIPHandler,
 		},
 	}
 
@@ -209,6 +253,22 @@ func (s *Server) sessionRequestCallback(session gliderssh.Session, requestType s
 	return true
 }
 
+// allowedLoginShell checks if the device's login shell, set on ShellHub, is one of the login shells allowed by the
+// agent. It returns false when the login shell is empty or isn't allowed.
+func (s *Server) allowedLoginShell(shell string) (string, bool) {
+	if shell == "" {
+		return "", false
+	}
+
+	if !slices.Contains(s.loginShells, shell) {
+		log.WithField("shell", shell).Warn("Login shell isn't allowed by the agent. Using the user's shell")
+
+		return "", false
+	}
+
+	return shell, true
+}
+
 func (s *Server) HandleConn(conn net.Conn) {
 	s.sshd.HandleConn(conn)
 }
@@ -217,6 +277,24 @@ func (s *Server) SetDeviceName(name string) {
 	s.deviceName = name
 }
 
+// SetForcedCommand sets the command executed on the sessions started after it, instead of the shell or the command
+// requested by the SSH client. An empty command restores the requested ones.
+func (s *Server) SetForcedCommand(command string) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	s.forcedCommand = command
+}
+
+// SetSFTP sets the restriction on the SFTP sessions started after it, as one of [models.DeviceConfigSFTPModes]. An
+// empty restriction allows the SFTP sessions.
+func (s *Server) SetSFTP(mode string) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	s.sftp = mode
+}
+
 func (s *Server) SetContainerID(id string) {
 	s.ContainerID = id
 }
@@ -5,9 +5,11 @@ import (
 	"os"
 	"os/user"
 	"path"
+	"runtime"
 	"strconv"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -55,7 +57,9 @@ func GetSessionType(session gliderssh.Session) (Type, error) {
 func (s *Server) sessionHandler(session gliderssh.Session) {
 	log.Info("New session request")
 
-	if gliderssh.AgentRequested(session) {
+	// NOTICE: The agent forwarding relies on Unix sockets owned by the session's user, so it isn't available on
+	// Windows, where the session continues without it.
+	if gliderssh.AgentRequested(session) && runtime.GOOS != "windows" {
 		user, err := user.Lookup(session.User())
 		if err != nil {
 			log.WithError(err).Error("failed to get the user")
@@ -114,7 +118,24 @@ func (s *Server) sessionHandler(session gliderssh.Session) {
 		return
 	}
 
-	log.WithField("type", sessionType).Info("Request type got")
+	log.WithContext(session.Context()).WithField("type", sessionType).Info("Request type got")
+
+	s.configMu.RLock()
+	forced := s.forcedCommand
+	s.configMu.RUnlock()
+
+	if forced != "" {
+		log.WithContext(session.Context()).WithField("command", forced).Info("Executing the forced command instead of the requested one")
+
+		session = &forcedCommandSession{Session: session, command: forced}
+		sessionType = SessionTypeExec
+	}
+
+	if container, ok := getSessionContainer(session); ok {
+		s.containerSessionHandler(session, sessionType, container)
+
+		return
+	}
 
 	switch sessionType {
 	case SessionTypeShell:
@@ -127,3 +148,22 @@ func (s *Server) sessionHandler(session gliderssh.Session) {
 
 	log.Info("Session ended")
 }
+
+// forcedCommandSession is a session whose requested shell or command is replaced by the forced command. As OpenSSH
+// does, the requested command is available to the forced one on the SSH_ORIGINAL_COMMAND environment variable.
+type forcedCommandSession struct {
+	gliderssh.Session
+	command string
+}
+
+func (f *forcedCommandSession) RawCommand() string {
+	return f.command
+}
+
+func (f *forcedCommandSession) Command() []string {
+	return command.ShellArgs(command.DefaultShell, f.command)
+}
+
+func (f *forcedCommandSession) Environ() []string {
+	return append(f.Session.Environ(), "SSH_ORIGINAL_COMMAND="+f.Session.RawCommand())
+}
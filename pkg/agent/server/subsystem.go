@@ -2,9 +2,27 @@ package server
 
 import (
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
 )
 
 // sftpSubsystemHandler handles the SFTP subsystem session.
 func (s *Server) sftpSubsystemHandler(session gliderssh.Session) {
+	s.configMu.RLock()
+	mode := s.sftp
+	s.configMu.RUnlock()
+
+	switch mode {
+	case models.DeviceConfigSFTPDisabled:
+		log.WithField("user", session.User()).Info("SFTP session refused as it is disabled by the device's config")
+
+		session.Exit(1) //nolint:errcheck
+
+		return
+	case models.DeviceConfigSFTPReadOnly:
+		session.Context().SetValue(modes.ContextKeySFTPReadOnly, true)
+	}
+
 	s.mode.SFTP(session) //nolint:errcheck
 }
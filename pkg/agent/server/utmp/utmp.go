@@ -1,3 +1,6 @@
+//go:build !windows
+// +build !windows
+
 package utmp
 
 /*	At session start, a utmp record is constructed containing the PID,
@@ -1,14 +1,12 @@
 package agent
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"os"
-	"strconv"
-	"syscall"
 
 	"github.com/pkg/sftp"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sftpcheck"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
 )
 
@@ -36,63 +34,18 @@ func (p *pipe) Close() error {
 func NewSFTPServer(mode command.SFTPServerMode) {
 	piped := &pipe{os.Stdin, os.Stdout, os.Stderr}
 
-	if mode == command.SFTPServerModeDocker {
-		if err := syscall.Chroot("/host"); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-
-			return
-		}
-	}
-
-	home, ok := os.LookupEnv("HOME")
-	if !ok {
-		fmt.Fprintln(os.Stderr, errors.New("HOME environment variable not set"))
-
-		return
-	}
-
-	toInt := func(s string, _ bool) (int, error) {
-		i, err := strconv.Atoi(s)
-		if err != nil {
-			return 0, err
-		}
-
-		return i, nil
-	}
-
-	gid, err := toInt(os.LookupEnv("GID"))
-	if err != nil {
-		fmt.Fprintln(os.Stderr, errors.New("GID environment variable not set"))
-
-		return
-	}
-
-	uid, err := toInt(os.LookupEnv("UID"))
-	if err != nil {
-		fmt.Fprintln(os.Stderr, errors.New("UID environment variable not set"))
-
-		return
-	}
-
-	if err := syscall.Chdir(home); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-
-		return
-	}
-
-	if err := syscall.Setgid(gid); err != nil {
+	if err := enterSFTPUser(mode); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 
 		return
 	}
 
-	if err := syscall.Setuid(uid); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-
-		return
+	options := []sftp.ServerOption{}
+	if os.Getenv("READ_ONLY") == "true" {
+		options = append(options, sftp.ReadOnly())
 	}
 
-	server, err := sftp.NewServer(piped, []sftp.ServerOption{}...)
+	server, err := sftp.NewServer(sftpcheck.NewChannel(piped), options...)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 
@@ -0,0 +1,60 @@
+//go:build !windows
+
+package agent
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"syscall"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+)
+
+// enterSFTPUser switches the SFTP server to the session's user, informed by the HOME, UID and GID environment
+// variables, changing to its home directory. On docker mode, the server is chrooted to the host's root first.
+func enterSFTPUser(mode command.SFTPServerMode) error {
+	if mode == command.SFTPServerModeDocker {
+		if err := syscall.Chroot("/host"); err != nil {
+			return err
+		}
+	}
+
+	home, ok := os.LookupEnv("HOME")
+	if !ok {
+		return errors.New("HOME environment variable not set")
+	}
+
+	toInt := func(s string, _ bool) (int, error) {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, err
+		}
+
+		return i, nil
+	}
+
+	gid, err := toInt(os.LookupEnv("GID"))
+	if err != nil {
+		return errors.New("GID environment variable not set")
+	}
+
+	uid, err := toInt(os.LookupEnv("UID"))
+	if err != nil {
+		return errors.New("UID environment variable not set")
+	}
+
+	if err := syscall.Chdir(home); err != nil {
+		return err
+	}
+
+	if err := syscall.Setgid(gid); err != nil {
+		return err
+	}
+
+	if err := syscall.Setuid(uid); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package agent
+
+import (
+	"os"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+)
+
+// enterSFTPUser changes the SFTP server to the home directory informed by the HOME environment variable. On Windows,
+// the server already runs as the session's user, the agent's account, as the agent only runs in single-user mode.
+func enterSFTPUser(_ command.SFTPServerMode) error {
+	if home, ok := os.LookupEnv("HOME"); ok {
+		return os.Chdir(home)
+	}
+
+	return nil
+}
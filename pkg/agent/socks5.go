@@ -0,0 +1,273 @@
+package agent
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/server"
+)
+
+// SOCKS5DialTimeout is the maximum time spent connecting to the destination requested by the SOCKS5 client.
+const SOCKS5DialTimeout = 10 * time.Second
+
+// SOCKS5 protocol's constants, as defined by RFC 1928.
+//
+// https://www.rfc-editor.org/rfc/rfc1928
+const (
+	socks5Version = 0x05
+
+	socks5MethodNoAuth       = 0x00
+	socks5MethodNoAcceptable = 0xff
+
+	socks5CommandConnect = 0x01
+
+	socks5AddressIPv4   = 0x01
+	socks5AddressDomain = 0x03
+	socks5AddressIPv6   = 0x04
+
+	socks5ReplySucceeded           = 0x00
+	socks5ReplyFailure             = 0x01
+	socks5ReplyNotAllowed          = 0x02
+	socks5ReplyNetworkUnreachable  = 0x03
+	socks5ReplyHostUnreachable     = 0x04
+	socks5ReplyConnectionRefused   = 0x05
+	socks5ReplyCommandNotSupported = 0x07
+	socks5ReplyAddressNotSupported = 0x08
+)
+
+var (
+	ErrSOCKS5Version         = errors.New("unsupported SOCKS version")
+	ErrSOCKS5Method          = errors.New("no acceptable SOCKS5 authentication method")
+	ErrSOCKS5Command         = errors.New("unsupported SOCKS5 command")
+	ErrSOCKS5AddressType     = errors.New("unsupported SOCKS5 address type")
+	ErrSOCKS5ConnectionSetup = errors.New("failed to connect to the SOCKS5 destination")
+)
+
+// SOCKS5Dialer connects to the destination requested by the SOCKS5 client.
+type SOCKS5Dialer func(network, address string) (net.Conn, error)
+
+// serveSOCKS5 serves a SOCKS5 session on conn, connecting to the destination requested by the client through dial and
+// piping the data between them until one of the sides is closed. As the tunnel's connection is already authenticated
+// by the server, only the CONNECT command, without authentication, is supported.
+func serveSOCKS5(conn io.ReadWriteCloser, dial SOCKS5Dialer) error {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+
+	if header[0] != socks5Version {
+		return ErrSOCKS5Version
+	}
+
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return err
+	}
+
+	method := byte(socks5MethodNoAcceptable)
+	for _, m := range methods {
+		if m == socks5MethodNoAuth {
+			method = socks5MethodNoAuth
+
+			break
+		}
+	}
+
+	if _, err := conn.Write([]byte{socks5Version, method}); err != nil {
+		return err
+	}
+
+	if method == socks5MethodNoAcceptable {
+		return ErrSOCKS5Method
+	}
+
+	// VER CMD RSV ATYP
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return err
+	}
+
+	if request[0] != socks5Version {
+		return ErrSOCKS5Version
+	}
+
+	address, err := readSOCKS5Address(conn, request[3])
+	if errors.Is(err, ErrSOCKS5AddressType) {
+		writeSOCKS5Reply(conn, socks5ReplyAddressNotSupported, nil) //nolint:errcheck
+
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if request[1] != socks5CommandConnect {
+		writeSOCKS5Reply(conn, socks5ReplyCommandNotSupported, nil) //nolint:errcheck
+
+		return ErrSOCKS5Command
+	}
+
+	destination, err := dial("tcp", address)
+	if err != nil {
+		writeSOCKS5Reply(conn, socks5DialReply(err), nil) //nolint:errcheck
+
+		return errors.Join(ErrSOCKS5ConnectionSetup, err)
+	}
+
+	defer destination.Close()
+
+	if err := writeSOCKS5Reply(conn, socks5ReplySucceeded, destination.LocalAddr()); err != nil {
+		return err
+	}
+
+	wg := new(sync.WaitGroup)
+	done := sync.OnceFunc(func() {
+		destination.Close()
+		conn.Close()
+	})
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer done()
+
+		io.Copy(destination, conn) //nolint:errcheck
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer done()
+
+		io.Copy(conn, destination) //nolint:errcheck
+	}()
+
+	wg.Wait()
+
+	return nil
+}
+
+// readSOCKS5Address reads the destination's address, of the type, and its port from the client's request.
+func readSOCKS5Address(r io.Reader, typ byte) (string, error) {
+	var host string
+
+	switch typ {
+	case socks5AddressIPv4, socks5AddressIPv6:
+		size := net.IPv4len
+		if typ == socks5AddressIPv6 {
+			size = net.IPv6len
+		}
+
+		ip := make(net.IP, size)
+		if _, err := io.ReadFull(r, ip); err != nil {
+			return "", err
+		}
+
+		host = ip.String()
+	case socks5AddressDomain:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(r, size); err != nil {
+			return "", err
+		}
+
+		domain := make([]byte, size[0])
+		if _, err := io.ReadFull(r, domain); err != nil {
+			return "", err
+		}
+
+		host = string(domain)
+	default:
+		return "", ErrSOCKS5AddressType
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(r, port); err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// writeSOCKS5Reply writes the reply to the client's request with the address bound to the destination's connection.
+// When the address isn't a TCP one, like when the request failed, the unspecified IPv4 address is replied.
+func writeSOCKS5Reply(w io.Writer, reply byte, bound net.Addr) error {
+	ip, port := net.IPv4zero.To4(), 0
+	if addr, ok := bound.(*net.TCPAddr); ok {
+		ip, port = addr.IP, addr.Port
+	}
+
+	typ := byte(socks5AddressIPv6)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, typ = ip4, socks5AddressIPv4
+	}
+
+	// VER REP RSV ATYP BND.ADDR BND.PORT
+	message := append([]byte{socks5Version, reply, 0x00, typ}, ip...)
+	message = binary.BigEndian.AppendUint16(message, uint16(port))
+
+	_, err := w.Write(message)
+
+	return err
+}
+
+// socks5PolicyDialer returns a dialer that connects to the destinations accepted by the forwarding policy, as the
+// direct-tcpip channels do, so the SOCKS5 proxy can't reach the destinations the policy rejects.
+func socks5PolicyDialer(policy *server.ForwardPolicy) SOCKS5Dialer {
+	dialer := &net.Dialer{Timeout: SOCKS5DialTimeout}
+
+	return func(network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		number, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), SOCKS5DialTimeout)
+		defer cancel()
+
+		destination, err := policy.Destination(ctx, host, uint32(number))
+		if err != nil {
+			return nil, err
+		}
+
+		return dialer.DialContext(ctx, network, destination)
+	}
+}
+
+// socks5DialReply returns the reply to a request whose destination couldn't be connected to.
+func socks5DialReply(err error) byte {
+	var rejected *server.ForwardRejectedError
+	if errors.As(err, &rejected) {
+		return socks5ReplyNotAllowed
+	}
+
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return socks5ReplyFailure
+	}
+
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr):
+		return socks5ReplyHostUnreachable
+	case opErr.Timeout():
+		return socks5ReplyHostUnreachable
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return socks5ReplyConnectionRefused
+	case errors.Is(err, syscall.ENETUNREACH):
+		return socks5ReplyNetworkUnreachable
+	default:
+		return socks5ReplyFailure
+	}
+}
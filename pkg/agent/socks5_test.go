@@ -0,0 +1,203 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/agent/server"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeSOCKS5(t *testing.T) {
+	type expected struct {
+		reply []byte
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		request     []byte
+		dial        func(t *testing.T) SOCKS5Dialer
+		expected    expected
+	}{
+		{
+			description: "fails when the client doesn't accept the absence of authentication",
+			request:     []byte{0x05, 0x01, 0x02},
+			dial: func(t *testing.T) SOCKS5Dialer {
+				return func(_, _ string) (net.Conn, error) {
+					t.Fatal("the destination must not be dialed")
+
+					return nil, nil
+				}
+			},
+			expected: expected{
+				reply: []byte{0x05, 0xff},
+				err:   ErrSOCKS5Method,
+			},
+		},
+		{
+			description: "fails when the command isn't connect",
+			request:     []byte{0x05, 0x01, 0x00, 0x05, 0x02, 0x00, 0x01, 127, 0, 0, 1, 0x00, 0x50},
+			dial: func(t *testing.T) SOCKS5Dialer {
+				return func(_, _ string) (net.Conn, error) {
+					t.Fatal("the destination must not be dialed")
+
+					return nil, nil
+				}
+			},
+			expected: expected{
+				reply: []byte{0x05, 0x00, 0x05, 0x07, 0x00, 0x01, 0, 0, 0, 0, 0x00, 0x00},
+				err:   ErrSOCKS5Command,
+			},
+		},
+		{
+			description: "fails when the address type is unknown",
+			request:     []byte{0x05, 0x01, 0x00, 0x05, 0x01, 0x00, 0x02},
+			dial: func(t *testing.T) SOCKS5Dialer {
+				return func(_, _ string) (net.Conn, error) {
+					t.Fatal("the destination must not be dialed")
+
+					return nil, nil
+				}
+			},
+			expected: expected{
+				reply: []byte{0x05, 0x00, 0x05, 0x08, 0x00, 0x01, 0, 0, 0, 0, 0x00, 0x00},
+				err:   ErrSOCKS5AddressType,
+			},
+		},
+		{
+			description: "fails when the destination cannot be connected",
+			request:     []byte{0x05, 0x01, 0x00, 0x05, 0x01, 0x00, 0x01, 192, 168, 0, 10, 0x00, 0x50},
+			dial: func(t *testing.T) SOCKS5Dialer {
+				return func(network, address string) (net.Conn, error) {
+					assert.Equal(t, "tcp", network)
+					assert.Equal(t, "192.168.0.10:80", address)
+
+					return nil, errors.New("error")
+				}
+			},
+			expected: expected{
+				reply: []byte{0x05, 0x00, 0x05, 0x01, 0x00, 0x01, 0, 0, 0, 0, 0x00, 0x00},
+				err:   errors.Join(ErrSOCKS5ConnectionSetup, errors.New("error")),
+			},
+		},
+		{
+			description: "fails when the forwarding policy rejects the destination",
+			request:     []byte{0x05, 0x01, 0x00, 0x05, 0x01, 0x00, 0x01, 192, 168, 0, 10, 0x00, 0x50},
+			dial: func(t *testing.T) SOCKS5Dialer {
+				policy, err := server.NewForwardPolicy("", "192.168.0.0/16:*")
+				require.NoError(t, err)
+
+				return socks5PolicyDialer(policy)
+			},
+			expected: expected{
+				reply: []byte{0x05, 0x00, 0x05, 0x02, 0x00, 0x01, 0, 0, 0, 0, 0x00, 0x00},
+				err: errors.Join(ErrSOCKS5ConnectionSetup, &server.ForwardRejectedError{
+					Rejection: models.ForwardRejection{Reason: models.ForwardRejectionDenied, Destination: "192.168.0.10:80"},
+				}),
+			},
+		},
+		{
+			description: "succeeds connecting to the destination by its domain",
+			request:     []byte{0x05, 0x02, 0x02, 0x00, 0x05, 0x01, 0x00, 0x03, 0x07, 'r', 'o', 'u', 't', 'e', 'r', '1', 0x1f, 0x90},
+			dial: func(t *testing.T) SOCKS5Dialer {
+				return func(_, address string) (net.Conn, error) {
+					assert.Equal(t, "router1:8080", address)
+
+					destination, remote := net.Pipe()
+					go func() {
+						defer remote.Close()
+
+						remote.Write([]byte("pong")) //nolint:errcheck
+					}()
+
+					return destination, nil
+				}
+			},
+			expected: expected{
+				reply: append([]byte{0x05, 0x00, 0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0x00, 0x00}, []byte("pong")...),
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			client, conn := net.Pipe()
+
+			result := make(chan error, 1)
+			go func() {
+				err := serveSOCKS5(conn, tc.dial(t))
+				conn.Close()
+
+				result <- err
+			}()
+
+			go client.Write(tc.request) //nolint:errcheck
+
+			reply, err := io.ReadAll(client)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, expected{reply, <-result})
+		})
+	}
+}
+
+func TestSOCKS5PolicyDialer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			conn.Close()
+		}
+	}()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	policy, err := server.NewForwardPolicy(fmt.Sprintf("127.0.0.1:%d", port), "")
+	require.NoError(t, err)
+
+	dial := socks5PolicyDialer(policy)
+
+	t.Run("connects to a destination the policy accepts", func(t *testing.T) {
+		conn, err := dial("tcp", listener.Addr().String())
+		require.NoError(t, err)
+
+		conn.Close()
+	})
+
+	t.Run("refuses a destination the policy doesn't allow", func(t *testing.T) {
+		_, err := dial("tcp", fmt.Sprintf("127.0.0.1:%d", port+1))
+
+		var rejected *server.ForwardRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, models.ForwardRejectionNotAllowed, rejected.Rejection.Reason)
+		assert.Equal(t, byte(socks5ReplyNotAllowed), socks5DialReply(err))
+	})
+}
+
+func TestSOCKS5Handler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/socks5", nil)
+	rec := httptest.NewRecorder()
+
+	err := socks5Handler(false, nil)(echo.New().NewContext(req, rec))
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
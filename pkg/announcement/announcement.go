@@ -0,0 +1,108 @@
+// Package announcement renders the namespaces' connection announcements, shown to everyone opening an interactive
+// session to the namespace's devices.
+//
+// The announcement is a [text/template] with the fields of [Data], like:
+//
+//	Welcome to {{.Device.Name}} on {{.Namespace}}, {{.User}}. This session ({{.SessionUID}}) is recorded.
+//
+// An announcement without actions is shown as it is.
+package announcement
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// ErrTemplate is returned when the announcement isn't a valid template.
+var ErrTemplate = errors.New("invalid connection announcement template")
+
+// Device is the device connected.
+type Device struct {
+	UID  string
+	Name string
+	Tags []string
+	// Info is the device's operating system and agent, like {{.Device.Info.PrettyName}}.
+	Info models.DeviceInfo
+}
+
+// Data are the values available to the announcement's template.
+type Data struct {
+	Device Device
+	// Namespace is the name of the device's namespace.
+	Namespace string
+	// User is the username used to log in on the device.
+	User string
+	// SessionUID is the identifier of the session, as listed on the namespace's sessions.
+	SessionUID string
+}
+
+// NewData creates the [Data] of a session to the device, logged in as user.
+func NewData(device *models.Device, user, session string) Data {
+	data := Data{User: user, SessionUID: session}
+	if device != nil {
+		data.Device = Device{UID: device.UID, Name: device.Name, Tags: device.Tags}
+		data.Namespace = device.Namespace
+
+		if device.Info != nil {
+			data.Device.Info = *device.Info
+		}
+	}
+
+	return data
+}
+
+// Sample returns the data the announcements of the namespace are validated and previewed with.
+func Sample(namespace string) Data {
+	return Data{
+		Device: Device{
+			UID:  "13b0c8ea878e61ff849db69461795006a9594c8f6a6390ce0000100b0c9d7d0a",
+			Name: "device",
+			Tags: []string{"production"},
+			Info: models.DeviceInfo{ID: "ubuntu", PrettyName: "Ubuntu 24.04 LTS", Version: "v0.16.0", Arch: "amd64", Platform: "native"},
+		},
+		Namespace:  namespace,
+		User:       "root",
+		SessionUID: "0d1b1b6e4a4b7a44a2b5b5e6c38c1ba7f3b0b6f2b0c4d7d8c7e4a5b3c2d1e0f9",
+	}
+}
+
+// Parse parses the announcement's template.
+func Parse(text string) (*template.Template, error) {
+	return template.New("announcement").Option("missingkey=error").Parse(text)
+}
+
+// Validate checks if the announcement is a valid template. As the fields are only resolved when the template is
+// executed, it is executed with [Sample] data to reject the unknown ones.
+func Validate(text string) error {
+	tmpl, err := Parse(text)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrTemplate, err.Error())
+	}
+
+	if err := tmpl.Execute(io.Discard, Sample("namespace")); err != nil {
+		return fmt.Errorf("%w: %s", ErrTemplate, err.Error())
+	}
+
+	return nil
+}
+
+// Render renders the announcement with the session's data. The announcements that cannot be rendered, like the ones
+// saved before they were validated, are returned as they are, so a template error never hides the announcement.
+func Render(text string, data Data) string {
+	tmpl, err := Parse(text)
+	if err != nil {
+		return text
+	}
+
+	builder := new(strings.Builder)
+	if err := tmpl.Execute(builder, data); err != nil {
+		return text
+	}
+
+	return builder.String()
+}
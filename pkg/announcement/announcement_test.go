@@ -0,0 +1,88 @@
+package announcement
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		description string
+		text        string
+		expected    error
+	}{
+		{
+			description: "succeeds when the announcement has no actions",
+			text:        "Welcome to ShellHub!",
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the announcement uses the variables",
+			text:        "Welcome to {{.Device.Name}} ({{.Device.Info.PrettyName}}) on {{.Namespace}}, {{.User}}. Session {{.SessionUID}}.",
+			expected:    nil,
+		},
+		{
+			description: "fails when the template cannot be parsed",
+			text:        "Welcome to {{.Device.Name}",
+			expected:    ErrTemplate,
+		},
+		{
+			description: "fails when the variable doesn't exist",
+			text:        "Welcome to {{.Hostname}}",
+			expected:    ErrTemplate,
+		},
+		{
+			description: "fails when the function doesn't exist",
+			text:        `{{exec "reboot"}}`,
+			expected:    ErrTemplate,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.ErrorIs(t, Validate(tc.text), tc.expected)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	device := &models.Device{
+		UID:       "uid",
+		Name:      "device",
+		Namespace: "namespace",
+		Tags:      []string{"production"},
+		Info:      &models.DeviceInfo{PrettyName: "Ubuntu"},
+	}
+
+	data := NewData(device, "root", "session")
+
+	cases := []struct {
+		description string
+		text        string
+		expected    string
+	}{
+		{
+			description: "renders the variables",
+			text:        "{{.User}}@{{.Device.Name}}.{{.Namespace}} ({{.Device.Info.PrettyName}}, {{index .Device.Tags 0}}): {{.SessionUID}}",
+			expected:    "root@device.namespace (Ubuntu, production): session",
+		},
+		{
+			description: "falls back to the text when the template cannot be parsed",
+			text:        "Welcome {{.User",
+			expected:    "Welcome {{.User",
+		},
+		{
+			description: "falls back to the text when the template cannot be executed",
+			text:        "Welcome {{.Hostname}}",
+			expected:    "Welcome {{.Hostname}}",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Render(tc.text, data))
+		})
+	}
+}
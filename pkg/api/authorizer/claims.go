@@ -11,6 +11,9 @@ type UserClaims struct {
 	Username string `json:"name"`
 	// MFA indicates whether multi-factor authentication is enabled for the user.
 	MFA bool `json:"mfa"`
+	// SessionID is the identifier of the user's session to which the token was issued. It's carried as the token's
+	// ID, and it's empty for tokens issued before sessions were tracked.
+	SessionID string `json:"-"`
 }
 
 // DeviceClaims represents the attributes needed to authenticate a device.
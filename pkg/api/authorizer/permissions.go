@@ -10,16 +10,39 @@ const (
 	DeviceConnect
 	DeviceRename
 	DeviceDetails
+	// DeviceSensitiveDetails allows reading the device's fields that could help to target it, like its public key,
+	// remote address and public URL address. Without it, they are masked on the device's responses.
+	DeviceSensitiveDetails
 	DeviceCreateTag
 	DeviceUpdateTag
 	DeviceRemoveTag
 	DeviceRenameTag
 	DeviceDeleteTag
+	// DeviceLimitExempt allows exempting devices from the namespace's maximum number of devices.
+	DeviceLimitExempt
+	// DeviceTagRules allows managing the rules that tag the namespace's devices automatically.
+	DeviceTagRules
+	// DeviceAcceptanceQueue allows managing the queue of devices accepted when the namespace has room for them.
+	DeviceAcceptanceQueue
+	// DeviceScheduleOverride allows connecting to devices outside the namespace's session schedules, through a
+	// break-glass override.
+	DeviceScheduleOverride
+	// DeviceGroups allows managing the namespace's device groups.
+	DeviceGroups
+	// DeviceCommand allows queuing commands to be executed by the devices' agents, even while they are offline.
+	DeviceCommand
+	// DeviceShare allows sharing the namespace's devices with other namespaces.
+	DeviceShare
+	// DeviceWebSession allows minting the tokens that open web terminals as any of the devices' OS users, without a
+	// public key or a password. It is only granted to the roles that can already register an unrestricted public key.
+	DeviceWebSession
 
 	SessionPlay
 	SessionClose
 	SessionRemove
 	SessionDetails
+	// SessionCommandPolicies allows managing the policies restricting the command lines run on the sessions.
+	SessionCommandPolicies
 
 	FirewallCreate
 	FirewallEdit
@@ -41,6 +64,10 @@ const (
 	NamespaceEditMember
 	NamespaceEnableSessionRecord
 	NamespaceDelete
+	// NamespaceReviewMembers allows reading the activity reports of the namespace's members.
+	NamespaceReviewMembers
+	// NamespaceAudit allows reading and exporting the namespace's audit trail.
+	NamespaceAudit
 
 	BillingCreateCustomer
 	BillingChooseDevices
@@ -56,6 +83,10 @@ const (
 	APIKeyUpdate
 	APIKeyDelete
 
+	// EnrollTokenCreate allows creating the namespace's enroll tokens, which enroll devices without its tenant ID.
+	EnrollTokenCreate
+	EnrollTokenDelete
+
 	ConnectorDelete
 	ConnectorUpdate
 	ConnectorSet
@@ -77,12 +108,14 @@ var operatorPermissions = []Permission{
 	DeviceConnect,
 	DeviceRename,
 	DeviceDetails,
+	DeviceSensitiveDetails,
 	DeviceUpdate,
 	DeviceCreateTag,
 	DeviceUpdateTag,
 	DeviceRemoveTag,
 	DeviceRenameTag,
 	DeviceDeleteTag,
+	DeviceScheduleOverride,
 
 	SessionDetails,
 }
@@ -94,17 +127,27 @@ var adminPermissions = []Permission{
 	DeviceConnect,
 	DeviceRename,
 	DeviceDetails,
+	DeviceSensitiveDetails,
 	DeviceUpdate,
 	DeviceCreateTag,
 	DeviceUpdateTag,
 	DeviceRemoveTag,
 	DeviceRenameTag,
 	DeviceDeleteTag,
+	DeviceLimitExempt,
+	DeviceTagRules,
+	DeviceAcceptanceQueue,
+	DeviceScheduleOverride,
+	DeviceGroups,
+	DeviceCommand,
+	DeviceShare,
+	DeviceWebSession,
 
 	SessionPlay,
 	SessionClose,
 	SessionRemove,
 	SessionDetails,
+	SessionCommandPolicies,
 
 	FirewallCreate,
 	FirewallEdit,
@@ -125,11 +168,16 @@ var adminPermissions = []Permission{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceReviewMembers,
+	NamespaceAudit,
 
 	APIKeyCreate,
 	APIKeyUpdate,
 	APIKeyDelete,
 
+	EnrollTokenCreate,
+	EnrollTokenDelete,
+
 	ConnectorDelete,
 	ConnectorUpdate,
 	ConnectorSet,
@@ -145,17 +193,27 @@ var ownerPermissions = []Permission{
 	DeviceConnect,
 	DeviceRename,
 	DeviceDetails,
+	DeviceSensitiveDetails,
 	DeviceUpdate,
 	DeviceCreateTag,
 	DeviceUpdateTag,
 	DeviceRemoveTag,
 	DeviceRenameTag,
 	DeviceDeleteTag,
+	DeviceLimitExempt,
+	DeviceTagRules,
+	DeviceAcceptanceQueue,
+	DeviceScheduleOverride,
+	DeviceGroups,
+	DeviceCommand,
+	DeviceShare,
+	DeviceWebSession,
 
 	SessionPlay,
 	SessionClose,
 	SessionRemove,
 	SessionDetails,
+	SessionCommandPolicies,
 
 	FirewallCreate,
 	FirewallEdit,
@@ -177,6 +235,8 @@ var ownerPermissions = []Permission{
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
 	NamespaceDelete,
+	NamespaceReviewMembers,
+	NamespaceAudit,
 
 	BillingCreateCustomer,
 	BillingChooseDevices,
@@ -191,6 +251,9 @@ var ownerPermissions = []Permission{
 	APIKeyUpdate,
 	APIKeyDelete,
 
+	EnrollTokenCreate,
+	EnrollTokenDelete,
+
 	ConnectorDelete,
 	ConnectorUpdate,
 	ConnectorSet,
@@ -68,16 +68,26 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.DeviceConnect,
 				authorizer.DeviceRename,
 				authorizer.DeviceDetails,
+				authorizer.DeviceSensitiveDetails,
 				authorizer.DeviceUpdate,
 				authorizer.DeviceCreateTag,
 				authorizer.DeviceUpdateTag,
 				authorizer.DeviceRemoveTag,
 				authorizer.DeviceRenameTag,
 				authorizer.DeviceDeleteTag,
+				authorizer.DeviceLimitExempt,
+				authorizer.DeviceTagRules,
+				authorizer.DeviceAcceptanceQueue,
+				authorizer.DeviceScheduleOverride,
+				authorizer.DeviceGroups,
+				authorizer.DeviceCommand,
+				authorizer.DeviceShare,
+				authorizer.DeviceWebSession,
 				authorizer.SessionPlay,
 				authorizer.SessionClose,
 				authorizer.SessionRemove,
 				authorizer.SessionDetails,
+				authorizer.SessionCommandPolicies,
 				authorizer.FirewallCreate,
 				authorizer.FirewallEdit,
 				authorizer.FirewallRemove,
@@ -96,6 +106,8 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.NamespaceEditMember,
 				authorizer.NamespaceEnableSessionRecord,
 				authorizer.NamespaceDelete,
+				authorizer.NamespaceReviewMembers,
+				authorizer.NamespaceAudit,
 				authorizer.BillingCreateCustomer,
 				authorizer.BillingChooseDevices,
 				authorizer.BillingAddPaymentMethod,
@@ -107,6 +119,8 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.APIKeyCreate,
 				authorizer.APIKeyUpdate,
 				authorizer.APIKeyDelete,
+				authorizer.EnrollTokenCreate,
+				authorizer.EnrollTokenDelete,
 				authorizer.ConnectorDelete,
 				authorizer.ConnectorUpdate,
 				authorizer.ConnectorSet,
@@ -124,16 +138,26 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.DeviceConnect,
 				authorizer.DeviceRename,
 				authorizer.DeviceDetails,
+				authorizer.DeviceSensitiveDetails,
 				authorizer.DeviceUpdate,
 				authorizer.DeviceCreateTag,
 				authorizer.DeviceUpdateTag,
 				authorizer.DeviceRemoveTag,
 				authorizer.DeviceRenameTag,
 				authorizer.DeviceDeleteTag,
+				authorizer.DeviceLimitExempt,
+				authorizer.DeviceTagRules,
+				authorizer.DeviceAcceptanceQueue,
+				authorizer.DeviceScheduleOverride,
+				authorizer.DeviceGroups,
+				authorizer.DeviceCommand,
+				authorizer.DeviceShare,
+				authorizer.DeviceWebSession,
 				authorizer.SessionPlay,
 				authorizer.SessionClose,
 				authorizer.SessionRemove,
 				authorizer.SessionDetails,
+				authorizer.SessionCommandPolicies,
 				authorizer.FirewallCreate,
 				authorizer.FirewallEdit,
 				authorizer.FirewallRemove,
@@ -151,9 +175,13 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.NamespaceRemoveMember,
 				authorizer.NamespaceEditMember,
 				authorizer.NamespaceEnableSessionRecord,
+				authorizer.NamespaceReviewMembers,
+				authorizer.NamespaceAudit,
 				authorizer.APIKeyCreate,
 				authorizer.APIKeyUpdate,
 				authorizer.APIKeyDelete,
+				authorizer.EnrollTokenCreate,
+				authorizer.EnrollTokenDelete,
 				authorizer.ConnectorDelete,
 				authorizer.ConnectorUpdate,
 				authorizer.ConnectorSet,
@@ -170,12 +198,14 @@ func TestRolePermissions(t *testing.T) {
 				authorizer.DeviceConnect,
 				authorizer.DeviceRename,
 				authorizer.DeviceDetails,
+				authorizer.DeviceSensitiveDetails,
 				authorizer.DeviceUpdate,
 				authorizer.DeviceCreateTag,
 				authorizer.DeviceUpdateTag,
 				authorizer.DeviceRemoveTag,
 				authorizer.DeviceRenameTag,
 				authorizer.DeviceDeleteTag,
+				authorizer.DeviceScheduleOverride,
 				authorizer.SessionDetails,
 			},
 		},
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	resty "github.com/go-resty/resty/v2"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -26,6 +27,12 @@ type publicAPI interface {
 	AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)
 	AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error)
 	NewReverseListener(ctx context.Context, token string, connPath string) (*revdial.Listener, error)
+	// ReportAgentLogs sends the significant errors recorded by the device's agent to the server.
+	ReportAgentLogs(uid string, logs []models.DeviceAgentLog, token string) error
+	// FetchDeviceCommands retrieves the commands queued to the device, marking them as sent.
+	FetchDeviceCommands(uid string, token string) ([]models.DeviceCommand, error)
+	// ReportDeviceCommand sends the result of a queued command executed by the device's agent to the server.
+	ReportDeviceCommand(uid string, id string, result *models.DeviceCommandResult, token string) error
 }
 
 //go:generate mockery --name=Client --filename=client.go
@@ -42,10 +49,39 @@ type client struct {
 	logger *log.Logger
 	// reverser is used to create a reverse listener to Agent from ShellHub's SSH server.
 	reverser IReverser
+	// serverCA is the path, or the inline PEM, of the CA bundle trusted to verify the server's TLS certificate.
+	serverCA string
 }
 
 var ErrParseAddress = fmt.Errorf("could not parse the address to the required format")
 
+// MaxRetryWaitTime is the maximum time waited between the retries of a request, reached by the exponential backoff
+// after many failures in a row.
+const MaxRetryWaitTime = 5 * time.Minute
+
+// retryAfter honors the backoff hinted by the server on the response of a refused request. Without a hint, zero is
+// returned and the exponential backoff is used.
+func retryAfter(_ *resty.Client, response *resty.Response) (time.Duration, error) {
+	if response == nil || response.RawResponse == nil {
+		return 0, nil
+	}
+
+	backoff, ok := models.BackoffFromHeader(response.Header())
+	if !ok {
+		return 0, nil
+	}
+
+	wait := backoff.Wait()
+
+	log.WithFields(log.Fields{
+		"status_code": response.StatusCode(),
+		"url":         response.Request.URL,
+		"wait":        wait.String(),
+	}).Warn("the server asked to wait before retrying")
+
+	return wait, nil
+}
+
 // NewClient creates a new ShellHub HTTP client.
 //
 // Server address must contain the scheme, the host and the port. For instance: `https://cloud.shellhub.io:443/`.
@@ -58,6 +94,8 @@ func NewClient(address string, opts ...Opt) (Client, error) {
 	client := new(client)
 	client.http = resty.New()
 	client.http.SetRetryCount(math.MaxInt32)
+	client.http.SetRetryMaxWaitTime(MaxRetryWaitTime)
+	client.http.SetRetryAfter(retryAfter)
 	client.http.SetRedirectPolicy(SameDomainRedirectPolicy())
 	client.http.SetBaseURL(uri.String())
 	client.http.AddRetryCondition(func(r *resty.Response, err error) bool {
@@ -89,5 +127,9 @@ func NewClient(address string, opts ...Opt) (Client, error) {
 		}
 	}
 
+	if err := client.loadServerCA(); err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
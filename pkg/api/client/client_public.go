@@ -3,6 +3,9 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"time"
 
 	resty "github.com/go-resty/resty/v2"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -40,6 +43,19 @@ func (c *client) AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthRe
 				return hostname
 			}
 
+			// NOTICE: the server refuses the device's information hash when it doesn't know it, so the device must
+			// report the whole information instead of retrying.
+			if r.StatusCode() == http.StatusPreconditionFailed {
+				return false
+			}
+
+			// NOTICE: the server refuses the device's authentication, or doesn't know its tenant, until someone acts on
+			// it, so the agent is left to decide whether to keep trying.
+			switch r.StatusCode() {
+			case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+				return false
+			}
+
 			if r.IsError() {
 				log.WithFields(log.Fields{
 					"tenant_id":   req.TenantID,
@@ -63,9 +79,25 @@ func (c *client) AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthRe
 		return nil, err
 	}
 
+	if res != nil {
+		res.ClockSkew = clockSkew(response)
+		res.RTT = response.Time()
+	}
+
 	return res, nil
 }
 
+// clockSkew returns how much the local clock is ahead of the server's, comparing when the response was received with
+// its Date header. As the header has a resolution of one second, it returns zero when the header is absent or invalid.
+func clockSkew(response *resty.Response) time.Duration {
+	date, err := http.ParseTime(response.Header().Get("Date"))
+	if err != nil {
+		return 0
+	}
+
+	return response.ReceivedAt().Sub(date).Truncate(time.Second)
+}
+
 func (c *client) Endpoints() (*models.Endpoints, error) {
 	var endpoints *models.Endpoints
 
@@ -102,6 +134,48 @@ func (c *client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (
 	return res, nil
 }
 
+func (c *client) ReportAgentLogs(uid string, logs []models.DeviceAgentLog, token string) error {
+	response, err := c.http.R().
+		SetBody(map[string]interface{}{"logs": logs}).
+		SetAuthToken(token).
+		Post(fmt.Sprintf("/api/devices/%s/agent-logs", uid))
+	if err != nil {
+		return err
+	}
+
+	return ErrorFromResponse(response)
+}
+
+func (c *client) FetchDeviceCommands(uid string, token string) ([]models.DeviceCommand, error) {
+	var commands []models.DeviceCommand
+
+	response, err := c.http.R().
+		SetResult(&commands).
+		SetAuthToken(token).
+		Post(fmt.Sprintf("/api/devices/%s/commands/dispatch", uid))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ErrorFromResponse(response); err != nil {
+		return nil, err
+	}
+
+	return commands, nil
+}
+
+func (c *client) ReportDeviceCommand(uid string, id string, result *models.DeviceCommandResult, token string) error {
+	response, err := c.http.R().
+		SetBody(result).
+		SetAuthToken(token).
+		Put(fmt.Sprintf("/api/devices/%s/commands/%s/result", uid, id))
+	if err != nil {
+		return err
+	}
+
+	return ErrorFromResponse(response)
+}
+
 // NewReverseListener creates a new reverse listener connection to ShellHub's server. This listener receives the SSH
 // requests coming from the ShellHub server. Only authenticated devices can obtain a listener connection.
 func (c *client) NewReverseListener(ctx context.Context, token string, connPath string) (*revdial.Listener, error) {
@@ -109,6 +183,11 @@ func (c *client) NewReverseListener(ctx context.Context, token string, connPath
 		return nil, errors.New("token is empty")
 	}
 
+	// NOTICE: The CA bundle is reloaded on each reconnection to pick up a rotated server's certificate chain.
+	if err := c.loadServerCA(); err != nil {
+		return nil, err
+	}
+
 	if err := c.reverser.Auth(ctx, token, connPath); err != nil {
 		return nil, err
 	}
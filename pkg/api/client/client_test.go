@@ -4,7 +4,9 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
+	resty "github.com/go-resty/resty/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -135,3 +137,51 @@ func FuzzNewClient(f *testing.F) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		description string
+		header      http.Header
+		min         time.Duration
+		max         time.Duration
+	}{
+		{
+			description: "uses the exponential backoff when there is no hint",
+			header:      http.Header{},
+			min:         0,
+			max:         0,
+		},
+		{
+			description: "uses the exponential backoff when the hint is a date",
+			header:      http.Header{"Retry-After": []string{"Wed, 21 Oct 2015 07:28:00 GMT"}},
+			min:         0,
+			max:         0,
+		},
+		{
+			description: "waits the hint when there is no jitter",
+			header:      http.Header{"Retry-After": []string{"10"}},
+			min:         10 * time.Second,
+			max:         10 * time.Second,
+		},
+		{
+			description: "waits inside the hint's window",
+			header:      http.Header{"Retry-After": []string{"10"}, "X-Retry-Jitter": []string{"50"}},
+			min:         10 * time.Second,
+			max:         60 * time.Second,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			response := &resty.Response{
+				Request:     &resty.Request{URL: "/api/devices/auth"},
+				RawResponse: &http.Response{StatusCode: http.StatusServiceUnavailable, Header: tc.header},
+			}
+
+			wait, err := retryAfter(nil, response)
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, wait, tc.min)
+			assert.LessOrEqual(t, wait, tc.max)
+		})
+	}
+}
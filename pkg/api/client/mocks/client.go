@@ -94,6 +94,32 @@ func (_m *Client) Endpoints() (*models.Endpoints, error) {
 	return r0, r1
 }
 
+// FetchDeviceCommands provides a mock function with given fields: uid, token
+func (_m *Client) FetchDeviceCommands(uid string, token string) ([]models.DeviceCommand, error) {
+	ret := _m.Called(uid, token)
+
+	var r0 []models.DeviceCommand
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]models.DeviceCommand, error)); ok {
+		return rf(uid, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []models.DeviceCommand); ok {
+		r0 = rf(uid, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceCommand)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(uid, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: uid
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
@@ -198,11 +224,39 @@ func (_m *Client) NewReverseListener(ctx context.Context, token string, connPath
 	return r0, r1
 }
 
+// ReportAgentLogs provides a mock function with given fields: uid, logs, token
+func (_m *Client) ReportAgentLogs(uid string, logs []models.DeviceAgentLog, token string) error {
+	ret := _m.Called(uid, logs, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []models.DeviceAgentLog, string) error); ok {
+		r0 = rf(uid, logs, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewClient interface {
 	mock.TestingT
 	Cleanup(func())
 }
 
+// ReportDeviceCommand provides a mock function with given fields: uid, id, result, token
+func (_m *Client) ReportDeviceCommand(uid string, id string, result *models.DeviceCommandResult, token string) error {
+	ret := _m.Called(uid, id, result, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, *models.DeviceCommandResult, string) error); ok {
+		r0 = rf(uid, id, result, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 func NewClient(t mockConstructorTestingTNewClient) *Client {
 	mock := &Client{}
@@ -73,3 +73,13 @@ func WithReverser(reverser IReverser) Opt {
 		return nil
 	}
 }
+
+// WithServerCA sets the CA bundle, a path to a PEM file or the PEM encoded certificates inline, trusted to verify the
+// server's TLS certificate, besides the system's ones.
+func WithServerCA(ca string) Opt {
+	return func(c *client) error {
+		c.serverCA = ca
+
+		return nil
+	}
+}
@@ -2,10 +2,12 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync/atomic"
 
 	"github.com/gorilla/websocket"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
@@ -24,14 +26,28 @@ type Reverser struct {
 	//
 	// It is used to create the websocket connection to the ShellHub's server.
 	host string
+	// dialer is used to create the websocket connections, holding the TLS configuration to use.
+	dialer atomic.Pointer[websocket.Dialer]
 }
 
 var _ IReverser = new(Reverser)
 
 func NewReverser(host string) *Reverser {
-	return &Reverser{
+	r := &Reverser{
 		host: host,
 	}
+
+	r.dialer.Store(websocket.DefaultDialer)
+
+	return r
+}
+
+// SetTLSConfig sets the TLS configuration used by the next websocket connections to the ShellHub's server.
+func (r *Reverser) SetTLSConfig(config *tls.Config) {
+	dialer := *websocket.DefaultDialer
+	dialer.TLSClientConfig = config
+
+	r.dialer.Store(&dialer)
 }
 
 // Auth creates a initial connection to the ShellHub SSH's server and authenticate it with the token received.
@@ -45,7 +61,7 @@ func (r *Reverser) Auth(ctx context.Context, token string, connPath string) erro
 		"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
 	}
 
-	conn, _, err := DialContext(ctx, uri, header)
+	conn, _, err := dialContext(ctx, r.dialer.Load(), uri, header)
 	if err != nil {
 		return err
 	}
@@ -71,6 +87,6 @@ func (r *Reverser) NewListener() (*revdial.Listener, error) {
 			return nil, nil, err
 		}
 
-		return DialContext(ctx, uri, nil)
+		return dialContext(ctx, r.dialer.Load(), uri, nil)
 	}), nil
 }
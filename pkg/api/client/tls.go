@@ -0,0 +1,74 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"strings"
+)
+
+var (
+	ErrServerCARead  = errors.New("failed to read the server's CA bundle")
+	ErrServerCAParse = errors.New("no certificate could be parsed from the server's CA bundle")
+)
+
+// pemPrefix is the prefix of a PEM encoded block, used to differentiate an inline CA bundle from a path to it.
+const pemPrefix = "-----BEGIN"
+
+// LoadServerCA loads the CA bundle trusted to verify the server's TLS certificate. The ca can be either a path to a PEM
+// file or the PEM encoded certificates inline. The certificates are appended to the system's pool, when it is
+// available, so public CAs keep being trusted.
+func LoadServerCA(ca string) (*x509.CertPool, error) {
+	data := []byte(ca)
+	if !strings.HasPrefix(strings.TrimSpace(ca), pemPrefix) {
+		var err error
+
+		data, err = os.ReadFile(ca)
+		if err != nil {
+			return nil, errors.Join(ErrServerCARead, err)
+		}
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, ErrServerCAParse
+	}
+
+	return pool, nil
+}
+
+// tlsConfigurer is implemented by the reversers able to use a custom TLS configuration on the websocket dialing.
+type tlsConfigurer interface {
+	SetTLSConfig(config *tls.Config)
+}
+
+// loadServerCA (re)loads the server's CA bundle, applying it to the HTTP client and to the reverser. It is called when
+// the client is created and before each reverse listener connection, so a rotated bundle is used on reconnect.
+func (c *client) loadServerCA() error {
+	if c.serverCA == "" {
+		return nil
+	}
+
+	pool, err := LoadServerCA(c.serverCA)
+	if err != nil {
+		return err
+	}
+
+	config := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	c.http.SetTLSClientConfig(config)
+
+	if reverser, ok := c.reverser.(tlsConfigurer); ok {
+		reverser.SetTLSConfig(config)
+	}
+
+	return nil
+}
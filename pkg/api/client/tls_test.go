@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadServerCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, []byte(ca), 0o600))
+
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(invalid, []byte("invalid"), 0o600))
+
+	cases := []struct {
+		description string
+		ca          string
+		expected    error
+	}{
+		{
+			description: "fails when the file does not exist",
+			ca:          filepath.Join(t.TempDir(), "missing.pem"),
+			expected:    ErrServerCARead,
+		},
+		{
+			description: "fails when the file has no certificate",
+			ca:          invalid,
+			expected:    ErrServerCAParse,
+		},
+		{
+			description: "fails when the inline PEM has no certificate",
+			ca:          "-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----\n",
+			expected:    ErrServerCAParse,
+		},
+		{
+			description: "succeeds when the CA is a path",
+			ca:          path,
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the CA is inline",
+			ca:          ca,
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			pool, err := LoadServerCA(tc.ca)
+			assert.ErrorIs(t, err, tc.expected)
+
+			if tc.expected == nil {
+				assert.NotNil(t, pool)
+			}
+		})
+	}
+}
+
+func TestClientWithServerCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version":"latest"}`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	t.Run("fails when the server's CA is not trusted", func(t *testing.T) {
+		cli, err := NewClient(server.URL)
+		require.NoError(t, err)
+
+		cli.(*client).http.SetRetryCount(0)
+
+		_, err = cli.GetInfo("latest")
+		assert.Error(t, err)
+	})
+
+	t.Run("succeeds when the server's CA is trusted", func(t *testing.T) {
+		cli, err := NewClient(server.URL, WithServerCA(ca))
+		require.NoError(t, err)
+
+		info, err := cli.GetInfo("latest")
+		require.NoError(t, err)
+		assert.Equal(t, "latest", info.Version)
+	})
+}
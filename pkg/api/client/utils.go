@@ -13,17 +13,22 @@ import (
 	"github.com/gorilla/websocket"
 )
 
-func getHostname(host string) (hostname string) {
-	if strings.Index(host, ":") > 0 {
-		host, _, _ = net.SplitHostPort(host)
+// getHostname removes the port from host, when present, and the square brackets around an IPv6 literal.
+func getHostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
 	}
-	hostname = strings.ToLower(host)
 
-	return
+	return strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"))
 }
 
 func getDomain(host string) string {
 	host = getHostname(host)
+	// NOTICE: IP addresses don't have subdomains, so the whole address must match.
+	if net.ParseIP(host) != nil {
+		return host
+	}
+
 	ss := strings.Split(host, ".")
 	if len(ss) < 3 {
 		return host
@@ -52,6 +57,11 @@ func SameDomainRedirectPolicy() resty.RedirectPolicyFunc {
 // redirect the connection with status [http.StatusTemporaryRedirect] or [http.StatusPermanentRedirect], the DialContext
 // method will follow. Any other response from the server will result in an error as result of this function.
 func DialContext(ctx context.Context, address string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	return dialContext(ctx, websocket.DefaultDialer, address, header)
+}
+
+// dialContext is like [DialContext], but uses dialer to create the websocket connection.
+func dialContext(ctx context.Context, dialer *websocket.Dialer, address string, header http.Header) (*websocket.Conn, *http.Response, error) {
 	parseToWS := func(uri string) string {
 		return regexp.MustCompile(`^http`).ReplaceAllString(uri, "ws")
 	}
@@ -61,7 +71,7 @@ func DialContext(ctx context.Context, address string, header http.Header) (*webs
 		return nil, nil, err
 	}
 
-	conn, res, err := websocket.DefaultDialer.DialContext(ctx, parseToWS(uri), header)
+	conn, res, err := dialer.DialContext(ctx, parseToWS(uri), header)
 	if err != nil {
 		if res == nil {
 			return nil, nil, err
@@ -74,7 +84,7 @@ func DialContext(ctx context.Context, address string, header http.Header) (*webs
 				return nil, nil, err
 			}
 
-			return DialContext(ctx, parseToWS(location.String()), header)
+			return dialContext(ctx, dialer, parseToWS(location.String()), header)
 		default:
 			return nil, nil, err
 		}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHostname(t *testing.T) {
+	cases := []struct {
+		description string
+		host        string
+		expected    string
+	}{
+		{
+			description: "returns the host when it doesn't have a port",
+			host:        "Cloud.ShellHub.io",
+			expected:    "cloud.shellhub.io",
+		},
+		{
+			description: "returns the host without the port",
+			host:        "cloud.shellhub.io:443",
+			expected:    "cloud.shellhub.io",
+		},
+		{
+			description: "returns the IPv4 address without the port",
+			host:        "192.168.0.1:80",
+			expected:    "192.168.0.1",
+		},
+		{
+			description: "returns the IPv6 address without the port",
+			host:        "[2001:db8::1]:80",
+			expected:    "2001:db8::1",
+		},
+		{
+			description: "returns the IPv6 address without the brackets",
+			host:        "[2001:DB8::1]",
+			expected:    "2001:db8::1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getHostname(tc.host))
+		})
+	}
+}
+
+func TestGetDomain(t *testing.T) {
+	cases := []struct {
+		description string
+		host        string
+		expected    string
+	}{
+		{
+			description: "returns the domain without the subdomain",
+			host:        "www.shellhub.io:443",
+			expected:    "shellhub.io",
+		},
+		{
+			description: "returns the whole IPv4 address",
+			host:        "192.168.0.1:80",
+			expected:    "192.168.0.1",
+		},
+		{
+			description: "returns the whole IPv6 address",
+			host:        "[2001:db8::1]:80",
+			expected:    "2001:db8::1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getDomain(tc.host))
+		})
+	}
+}
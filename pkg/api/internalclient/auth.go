@@ -0,0 +1,64 @@
+package internalclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+// authAPI defines methods for interacting with authentication-related functionality.
+type authAPI interface {
+	// AuthUserToken checks if the user's token, sent as a bearer token, is still valid to access the namespace with
+	// the specified tenant. It returns [ErrUnauthorized] when the token was revoked, has expired, or when the user is
+	// no longer a member of the namespace.
+	AuthUserToken(token, tenant string) error
+
+	// RecordAddressFailure records an authentication failure from the IP address, which is banned temporarily when it
+	// fails too many times in a row.
+	RecordAddressFailure(ctx context.Context, address string) error
+}
+
+var ErrUnauthorized = errors.New("unauthorized")
+
+func (c *client) AuthUserToken(token, tenant string) error {
+	res, err := c.http.
+		R().
+		SetHeader("Authorization", token).
+		Get("/internal/auth")
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch {
+	case res.StatusCode() == http.StatusOK:
+	case res.StatusCode() == http.StatusUnauthorized, res.StatusCode() == http.StatusForbidden, res.StatusCode() == http.StatusNotFound:
+		return ErrUnauthorized
+	default:
+		return ErrUnknown
+	}
+
+	if res.Header().Get("X-ID") == "" || res.Header().Get("X-Tenant-ID") != tenant {
+		return ErrUnauthorized
+	}
+
+	return nil
+}
+
+func (c *client) RecordAddressFailure(ctx context.Context, address string) error {
+	res, err := c.http.
+		R().
+		SetContext(ctx).
+		SetBody(&requests.BannedAddressFailure{Address: address}).
+		Post("/internal/banned-addresses/failures")
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	if res.StatusCode() != http.StatusOK {
+		return ErrUnknown
+	}
+
+	return nil
+}
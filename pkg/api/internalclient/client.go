@@ -7,10 +7,12 @@ import (
 	"net/http"
 
 	resty "github.com/go-resty/resty/v2"
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	"github.com/shellhub-io/shellhub/pkg/worker"
 	"github.com/sirupsen/logrus"
 )
 
+//go:generate mockery --name Client --filename internalclient.go
 type Client interface {
 	deviceAPI
 	namespaceAPI
@@ -18,6 +20,9 @@ type Client interface {
 	sessionAPI
 	sshkeyAPI
 	firewallAPI
+	authAPI
+	userAPI
+	jobAPI
 }
 
 type client struct {
@@ -48,6 +53,15 @@ func NewClient(opts ...clientOption) (Client, error) {
 
 		return r.StatusCode() >= http.StatusInternalServerError && r.StatusCode() != http.StatusNotImplemented
 	})
+	// NOTICE: The correlation ID carried by the request's context is propagated to the API, so its logs can be
+	// traced back to the service that made the request.
+	httpClient.OnBeforeRequest(func(_ *resty.Client, r *resty.Request) error {
+		if id := correlation.IDFromContext(r.Context()); id != "" {
+			r.SetHeader(correlation.Header, id)
+		}
+
+		return nil
+	})
 
 	c := &client{http: httpClient}
 	for _, opt := range opts {
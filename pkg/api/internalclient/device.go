@@ -28,12 +28,42 @@ type deviceAPI interface {
 	// DevicesHeartbeat enqueues a task to send a heartbeat for the device.
 	DevicesHeartbeat(tenant, uid string) error
 
+	// EnqueueDevicesHeartbeat enqueues the heartbeats received by another service, buffering them with the devices'
+	// heartbeats until the batch is flushed. It panics if the Client has no worker available.
+	EnqueueDevicesHeartbeat(ctx context.Context, heartbeats []models.ConnectedDevice) error
+
+	// EvaluateTagRules enqueues a task to evaluate the tenant's tag rules against all of its devices.
+	// It returns an error if any and panics if the Client has no worker available.
+	EvaluateTagRules(ctx context.Context, tenant string) error
+
 	// Lookup performs a lookup operation based on the provided parameters.
 	Lookup(lookup map[string]string) (string, []error)
 
 	// DeviceLookup performs a lookup operation based on the provided parameters.
 	DeviceLookup(lookup map[string]string) (*models.Device, []error)
 
+	// CreatePublicURLLog reports a request proxied to the HTTP service exposed by the device through its public URL.
+	CreatePublicURLLog(uid string, log *models.PublicURLLog) error
+
+	// GetDeviceCommandPolicies gets the command policies of the tenant's namespace applying to the device, to be
+	// evaluated on the sessions to it.
+	GetDeviceCommandPolicies(tenant, uid string) ([]models.CommandPolicy, error)
+
+	// CreateCommandPolicyEvaluation reports a command line that violated a command policy on a session to the device.
+	CreateCommandPolicyEvaluation(uid string, evaluation *models.CommandPolicyEvaluation) error
+
+	// EvaluateSessionSchedule evaluates if the namespace's session schedules allow connections to the tenant's device
+	// now. It returns [ErrForbidden] when they don't.
+	EvaluateSessionSchedule(tenant, uid string) error
+
+	// EvaluateSessionPolicy evaluates if the organization's policies allow the session, logging in as username from the
+	// address ip, to the tenant's device. It returns [ErrForbidden] when they don't.
+	EvaluateSessionPolicy(tenant, uid, username, ip string) error
+
+	// EvaluateSessionWebhook evaluates if the connection webhook of the tenant's namespace allows the session, logging
+	// in as username from the address ip, to the device. It returns [ErrForbidden] when it doesn't.
+	EvaluateSessionWebhook(tenant, uid, username, ip string) error
+
 	// LookupTunnel gets a tunnel from its addrss.
 	// TODO: Create a API interface for Tunnel routes.
 	LookupTunnel(address string) (*Tunnel, error)
@@ -51,9 +81,30 @@ func (c *client) DevicesOffline(uid string) error {
 }
 
 func (c *client) DevicesHeartbeat(tenant, uid string) error {
-	payload := fmt.Sprintf("%s:%s=%d", tenant, uid, clock.Now().Unix())
+	return c.worker.SubmitToBatch(context.TODO(), worker.TaskPattern("api:heartbeat"), heartbeatPayload(tenant, uid, clock.Now()))
+}
+
+func (c *client) EnqueueDevicesHeartbeat(ctx context.Context, heartbeats []models.ConnectedDevice) error {
+	c.mustWorker()
 
-	return c.worker.SubmitToBatch(context.TODO(), worker.TaskPattern("api:heartbeat"), []byte(payload))
+	for _, h := range heartbeats {
+		if err := c.worker.SubmitToBatch(ctx, worker.TaskPattern("api:heartbeat"), heartbeatPayload(h.TenantID, h.UID, h.LastSeen)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// heartbeatPayload encodes a device's heartbeat as the payload of the heartbeat's batch task.
+func heartbeatPayload(tenant, uid string, seenAt time.Time) []byte {
+	return []byte(fmt.Sprintf("%s:%s=%d", tenant, uid, seenAt.Unix()))
+}
+
+func (c *client) EvaluateTagRules(ctx context.Context, tenant string) error {
+	c.mustWorker()
+
+	return c.worker.Submit(ctx, worker.TaskPattern("api:tag-rules"), []byte(tenant))
 }
 
 func (c *client) Lookup(lookup map[string]string) (string, []error) {
@@ -147,6 +198,137 @@ func (c *client) GetDeviceByPublicURLAddress(address string) (*models.Device, er
 	}
 }
 
+func (c *client) CreatePublicURLLog(uid string, log *models.PublicURLLog) error {
+	resp, err := c.http.
+		R().
+		SetBody(log).
+		Post(fmt.Sprintf("/internal/devices/%s/public-url/logs", uid))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 404:
+		return ErrNotFound
+	default:
+		return ErrUnknown
+	}
+}
+
+func (c *client) GetDeviceCommandPolicies(tenant, uid string) ([]models.CommandPolicy, error) {
+	policies := make([]models.CommandPolicy, 0)
+
+	resp, err := c.http.
+		R().
+		SetHeader("X-Tenant-ID", tenant).
+		SetResult(&policies).
+		Get(fmt.Sprintf("/internal/devices/%s/command-policies", uid))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return policies, nil
+	case 404:
+		return nil, ErrNotFound
+	default:
+		return nil, ErrUnknown
+	}
+}
+
+func (c *client) CreateCommandPolicyEvaluation(uid string, evaluation *models.CommandPolicyEvaluation) error {
+	resp, err := c.http.
+		R().
+		SetBody(evaluation).
+		Post(fmt.Sprintf("/internal/devices/%s/command-policies/evaluations", uid))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 404:
+		return ErrNotFound
+	default:
+		return ErrUnknown
+	}
+}
+
+func (c *client) EvaluateSessionSchedule(tenant, uid string) error {
+	resp, err := c.http.
+		R().
+		SetHeader("X-Tenant-ID", tenant).
+		Get(fmt.Sprintf("/internal/devices/%s/session-schedule", uid))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 403:
+		return ErrForbidden
+	case 404:
+		return ErrNotFound
+	default:
+		return ErrUnknown
+	}
+}
+
+func (c *client) EvaluateSessionPolicy(tenant, uid, username, ip string) error {
+	resp, err := c.http.
+		R().
+		SetHeader("X-Tenant-ID", tenant).
+		SetBody(map[string]string{
+			"username":   username,
+			"ip_address": ip,
+		}).
+		Post(fmt.Sprintf("/internal/devices/%s/session-policy", uid))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 403:
+		return ErrForbidden
+	case 404:
+		return ErrNotFound
+	default:
+		return ErrUnknown
+	}
+}
+
+func (c *client) EvaluateSessionWebhook(tenant, uid, username, ip string) error {
+	resp, err := c.http.
+		R().
+		SetHeader("X-Tenant-ID", tenant).
+		SetBody(map[string]string{
+			"username":   username,
+			"ip_address": ip,
+		}).
+		Post(fmt.Sprintf("/internal/devices/%s/session-webhook", uid))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 403:
+		return ErrForbidden
+	case 404:
+		return ErrNotFound
+	default:
+		return ErrUnknown
+	}
+}
+
 type Tunnel struct {
 	Address    string    `json:"address"`
 	Namespace  string    `json:"namespace"`
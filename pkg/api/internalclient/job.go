@@ -0,0 +1,20 @@
+package internalclient
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/worker"
+)
+
+// jobAPI defines methods for interacting with the asynchronous jobs.
+type jobAPI interface {
+	// RunJob enqueues a task to execute the asynchronous job with the specified ID.
+	// It returns an error if any and panics if the Client has no worker available.
+	RunJob(ctx context.Context, id string) error
+}
+
+func (c *client) RunJob(ctx context.Context, id string) error {
+	c.mustWorker()
+
+	return c.worker.Submit(ctx, worker.TaskPattern("api:jobs"), []byte(id))
+}
@@ -20,6 +20,20 @@ type Client struct {
 	mock.Mock
 }
 
+// AuthUserToken provides a mock function with given fields: token, tenant
+func (_m *Client) AuthUserToken(token string, tenant string) error {
+	ret := _m.Called(token, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(token, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BillingEvaluate provides a mock function with given fields: tenantID
 func (_m *Client) BillingEvaluate(tenantID string) (*models.BillingEvaluation, int, error) {
 	ret := _m.Called(tenantID)
@@ -77,6 +91,24 @@ func (_m *Client) BillingReport(tenant string, action string) (int, error) {
 	return r0, r1
 }
 
+// CreateCommandPolicyEvaluation provides a mock function with given fields: uid, evaluation
+func (_m *Client) CreateCommandPolicyEvaluation(uid string, evaluation *models.CommandPolicyEvaluation) error {
+	ret := _m.Called(uid, evaluation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCommandPolicyEvaluation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *models.CommandPolicyEvaluation) error); ok {
+		r0 = rf(uid, evaluation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreatePrivateKey provides a mock function with given fields:
 func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
 	ret := _m.Called()
@@ -103,6 +135,20 @@ func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
 	return r0, r1
 }
 
+// CreatePublicURLLog provides a mock function with given fields: uid, log
+func (_m *Client) CreatePublicURLLog(uid string, log *models.PublicURLLog) error {
+	ret := _m.Called(uid, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *models.PublicURLLog) error); ok {
+		r0 = rf(uid, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceLookup provides a mock function with given fields: lookup
 func (_m *Client) DeviceLookup(lookup map[string]string) (*models.Device, []error) {
 	ret := _m.Called(lookup)
@@ -159,6 +205,20 @@ func (_m *Client) DevicesOffline(uid string) error {
 	return r0
 }
 
+// EnqueueDevicesHeartbeat provides a mock function with given fields: ctx, heartbeats
+func (_m *Client) EnqueueDevicesHeartbeat(ctx context.Context, heartbeats []models.ConnectedDevice) error {
+	ret := _m.Called(ctx, heartbeats)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ConnectedDevice) error); ok {
+		r0 = rf(ctx, heartbeats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EvaluateKey provides a mock function with given fields: fingerprint, dev, username
 func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error) {
 	ret := _m.Called(fingerprint, dev, username)
@@ -183,6 +243,70 @@ func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username s
 	return r0, r1
 }
 
+// EvaluateSessionPolicy provides a mock function with given fields: tenant, uid, username, ip
+func (_m *Client) EvaluateSessionPolicy(tenant string, uid string, username string, ip string) error {
+	ret := _m.Called(tenant, uid, username, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateSessionPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
+		r0 = rf(tenant, uid, username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateSessionSchedule provides a mock function with given fields: tenant, uid
+func (_m *Client) EvaluateSessionSchedule(tenant string, uid string) error {
+	ret := _m.Called(tenant, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(tenant, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateSessionWebhook provides a mock function with given fields: tenant, uid, username, ip
+func (_m *Client) EvaluateSessionWebhook(tenant string, uid string, username string, ip string) error {
+	ret := _m.Called(tenant, uid, username, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateSessionWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
+		r0 = rf(tenant, uid, username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateTagRules provides a mock function with given fields: ctx, tenant
+func (_m *Client) EvaluateTagRules(ctx context.Context, tenant string) error {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EventSession provides a mock function with given fields: uid, log
 func (_m *Client) EventSession(uid string, log *models.SessionEvent) error {
 	ret := _m.Called(uid, log)
@@ -279,6 +403,36 @@ func (_m *Client) GetDeviceByPublicURLAddress(address string) (*models.Device, e
 	return r0, r1
 }
 
+// GetDeviceCommandPolicies provides a mock function with given fields: tenant, uid
+func (_m *Client) GetDeviceCommandPolicies(tenant string, uid string) ([]models.CommandPolicy, error) {
+	ret := _m.Called(tenant, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceCommandPolicies")
+	}
+
+	var r0 []models.CommandPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]models.CommandPolicy, error)); ok {
+		return rf(tenant, uid)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []models.CommandPolicy); ok {
+		r0 = rf(tenant, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CommandPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tenant, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPublicKey provides a mock function with given fields: fingerprint, tenant
 func (_m *Client) GetPublicKey(fingerprint string, tenant string) (*models.PublicKey, error) {
 	ret := _m.Called(fingerprint, tenant)
@@ -441,6 +595,20 @@ func (_m *Client) NamespaceLookup(tenant string) (*models.Namespace, []error) {
 	return r0, r1
 }
 
+// RecordAddressFailure provides a mock function with given fields: ctx, address
+func (_m *Client) RecordAddressFailure(ctx context.Context, address string) error {
+	ret := _m.Called(ctx, address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RecordSession provides a mock function with given fields: ctx, uid, recordURL
 func (_m *Client) RecordSession(ctx context.Context, uid string, recordURL string) (*websocket.Conn, error) {
 	ret := _m.Called(ctx, uid, recordURL)
@@ -467,6 +635,72 @@ func (_m *Client) RecordSession(ctx context.Context, uid string, recordURL strin
 	return r0, r1
 }
 
+// RedeemWebSession provides a mock function with given fields: token
+func (_m *Client) RedeemWebSession(token string) (*models.WebSession, error) {
+	ret := _m.Called(token)
+
+	var r0 *models.WebSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.WebSession, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.WebSession); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveUserAlias provides a mock function with given fields: username, name
+func (_m *Client) ResolveUserAlias(username string, name string) (*models.UserAlias, error) {
+	ret := _m.Called(username, name)
+
+	var r0 *models.UserAlias
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*models.UserAlias, error)); ok {
+		return rf(username, name)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *models.UserAlias); ok {
+		r0 = rf(username, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAlias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunJob provides a mock function with given fields: ctx, id
+func (_m *Client) RunJob(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SessionAsAuthenticated provides a mock function with given fields: uid
 func (_m *Client) SessionAsAuthenticated(uid string) []error {
 	ret := _m.Called(uid)
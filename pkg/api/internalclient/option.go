@@ -1,6 +1,9 @@
 package internalclient
 
-import "github.com/shellhub-io/shellhub/pkg/worker/asynq"
+import (
+	"github.com/shellhub-io/shellhub/pkg/correlation"
+	"github.com/shellhub-io/shellhub/pkg/worker/asynq"
+)
 
 type clientOption func(c *client) error
 
@@ -16,3 +19,13 @@ func WithAsynqWorker(redisURI string) clientOption { //nolint:revive
 		return nil
 	}
 }
+
+// WithCorrelationID sets the correlation ID sent on every request made by the client, unless the request's context
+// carries another one.
+func WithCorrelationID(id string) clientOption { //nolint:revive
+	return func(c *client) error {
+		c.http.SetHeader(correlation.Header, id)
+
+		return nil
+	}
+}
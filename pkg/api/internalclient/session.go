@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/gorilla/websocket"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -35,6 +36,11 @@ type sessionAPI interface {
 	UpdateSession(uid string, model *models.SessionUpdate) error
 
 	EventSession(uid string, log *models.SessionEvent) error
+
+	// RedeemWebSession redeems the pre-authorized web session's token, returning the session it encodes. As the token
+	// is single-use, it can't be redeemed again. It returns [ErrUnauthorized] when the token is invalid, has expired
+	// or was already redeemed.
+	RedeemWebSession(token string) (*models.WebSession, error)
 }
 
 func (c *client) SessionCreate(session requests.SessionCreate) error {
@@ -142,3 +148,25 @@ func (c *client) EventSession(uid string, log *models.SessionEvent) error {
 
 	return nil
 }
+
+func (c *client) RedeemWebSession(token string) (*models.WebSession, error) {
+	session := new(models.WebSession)
+
+	resp, err := c.http.
+		R().
+		SetBody(&requests.WebSessionRedeem{Token: token}).
+		SetResult(session).
+		Post("/internal/web-sessions/redeem")
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return session, nil
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	default:
+		return nil, ErrUnknown
+	}
+}
@@ -0,0 +1,37 @@
+package internalclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// userAPI defines methods for interacting with user-related functionality.
+type userAPI interface {
+	// ResolveUserAlias retrieves the alias, identified by its name, defined by the user with the specified username.
+	// It returns [ErrNotFound] when the user doesn't exist or doesn't have the alias.
+	ResolveUserAlias(username, name string) (*models.UserAlias, error)
+}
+
+func (c *client) ResolveUserAlias(username, name string) (*models.UserAlias, error) {
+	alias := new(models.UserAlias)
+
+	resp, err := c.http.
+		R().
+		SetResult(alias).
+		Get(fmt.Sprintf("/internal/users/%s/aliases/%s", url.PathEscape(username), url.PathEscape(name)))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return alias, nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, ErrUnknown
+	}
+}
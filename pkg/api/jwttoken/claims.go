@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/uuid"
 )
 
@@ -56,21 +57,36 @@ func claimKindFromString(str string) claimKind {
 	}
 }
 
+// UserTokenTTL is how long a user token is valid after it's issued, when not specified.
+const UserTokenTTL = time.Hour * 72
+
 // EncodeUserClaims encodes the provided user claims into a signed JWT token. It returns
 // the encoded token and an error, if any.
 //
-// The token is valid for 72 hours; tenantID is optional.
+// The token is valid for [UserTokenTTL]; tenantID is optional. The claims' SessionID is
+// used as the token's ID, being generated when empty.
 func EncodeUserClaims(claims authorizer.UserClaims, privateKey *rsa.PrivateKey) (string, error) {
+	return EncodeUserClaimsWithTTL(claims, UserTokenTTL, privateKey)
+}
+
+// EncodeUserClaimsWithTTL is like [EncodeUserClaims], but the token is valid for the
+// specified duration.
+func EncodeUserClaimsWithTTL(claims authorizer.UserClaims, ttl time.Duration, privateKey *rsa.PrivateKey) (string, error) {
+	id := claims.SessionID
+	if id == "" {
+		id = uuid.Generate()
+	}
+
 	now := time.Now()
 	jwtClaims := userClaims{
 		Kind:       kindUserClaims,
 		UserClaims: claims,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ID:        uuid.Generate(),
+			ID:        id,
 			Issuer:    "", // TODO: how can we get the correct issuer?
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour * 72)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 		},
 	}
 
@@ -130,6 +146,8 @@ func unmarshalBearerToken(publicKey *rsa.PublicKey, raw string) (interface{}, er
 			return nil, err
 		}
 
+		claims.UserClaims.SessionID = claims.RegisteredClaims.ID
+
 		return &claims.UserClaims, nil
 	case kindDeviceClaims:
 		claims := new(deviceClaims)
@@ -142,3 +160,61 @@ func unmarshalBearerToken(publicKey *rsa.PublicKey, raw string) (interface{}, er
 		return nil, errors.New("invalid JWT's kind")
 	}
 }
+
+// webSessionClaims is an auxiliary type that embeds [github.com/golang-jwt/jwt/v4.RegisteredClaims] into the fields of
+// a [github.com/shellhub-io/shellhub/pkg/models.WebSession] to convert it into [github.com/golang-jwt/jwt/v4.Claims].
+//
+// As its kind isn't recognized by [ClaimsFromBearerToken], a web session's token can't be used as a bearer token.
+type webSessionClaims struct {
+	Kind      claimKind `json:"claims"`
+	TenantID  string    `json:"tenant_id"`
+	DeviceUID string    `json:"device_uid"`
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	jwt.RegisteredClaims
+}
+
+const kindWebSessionClaims claimKind = "web-session"
+
+// EncodeWebSessionClaims encodes the web session into a signed JWT token, valid until the session expires. The
+// session's ID is used as the token's ID.
+func EncodeWebSessionClaims(session *models.WebSession, privateKey *rsa.PrivateKey) (string, error) {
+	now := time.Now()
+	jwtClaims := webSessionClaims{
+		Kind:      kindWebSessionClaims,
+		TenantID:  session.TenantID,
+		DeviceUID: session.DeviceUID,
+		UserID:    session.UserID,
+		Username:  session.Username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        session.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
+		},
+	}
+
+	return encodeClaims(jwtClaims, privateKey)
+}
+
+// DecodeWebSessionClaims decodes the raw JWT token into the web session it encodes. It returns an error when the token
+// wasn't signed by the private key's pair, has expired, or isn't a web session's token.
+func DecodeWebSessionClaims(publicKey *rsa.PublicKey, raw string) (*models.WebSession, error) {
+	claims := new(webSessionClaims)
+	if err := decodeClaims(publicKey, raw, claims); err != nil {
+		return nil, err
+	}
+
+	if claims.Kind != kindWebSessionClaims || claims.ExpiresAt == nil {
+		return nil, errors.New("invalid JWT's kind")
+	}
+
+	return &models.WebSession{
+		ID:        claims.ID,
+		TenantID:  claims.TenantID,
+		DeviceUID: claims.DeviceUID,
+		UserID:    claims.UserID,
+		Username:  claims.Username,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
@@ -39,3 +39,12 @@ func (p *Paginator) Normalize() {
 		p.PerPage = int(math.Max(math.Min(float64(p.PerPage), float64(MaxPerPage)), float64(MinPerPage)))
 	}
 }
+
+// LastPage returns the number of the last page for a list with count items. An empty list still has the MinPage.
+func (p *Paginator) LastPage(count int) int {
+	if p.PerPage < MinPerPage {
+		return MinPage
+	}
+
+	return int(math.Max(float64(MinPage), math.Ceil(float64(count)/float64(p.PerPage))))
+}
@@ -45,3 +45,43 @@ func TestPaginatorNormalize(t *testing.T) {
 		})
 	}
 }
+
+func TestPaginatorLastPage(t *testing.T) {
+	cases := []struct {
+		description string
+		paginator   *Paginator
+		count       int
+		expected    int
+	}{
+		{
+			description: "returns MinPage when the list is empty",
+			paginator:   &Paginator{Page: 1, PerPage: 10},
+			count:       0,
+			expected:    1,
+		},
+		{
+			description: "returns MinPage when PerPage is invalid",
+			paginator:   &Paginator{Page: 1, PerPage: 0},
+			count:       10,
+			expected:    1,
+		},
+		{
+			description: "returns the last page when count is a multiple of PerPage",
+			paginator:   &Paginator{Page: 1, PerPage: 10},
+			count:       30,
+			expected:    3,
+		},
+		{
+			description: "returns the last page when the last page is incomplete",
+			paginator:   &Paginator{Page: 1, PerPage: 10},
+			count:       31,
+			expected:    4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.paginator.LastPage(tc.count))
+		})
+	}
+}
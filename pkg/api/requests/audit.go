@@ -0,0 +1,34 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+)
+
+// AuditEntryFilter is the structure to represent the filter of the namespace's audit trail, shared by its list and
+// export endpoints.
+type AuditEntryFilter struct {
+	Action     string `query:"action" validate:"max=64"`
+	ActorID    string `query:"actor_id" validate:"max=64"`
+	TargetType string `query:"target_type" validate:"omitempty,oneof=device user api_key enroll_token public_key tag ssh_ca namespace tag_rule group command_policy"`
+	TargetID   string `query:"target_id" validate:"max=255"`
+	// From and To limit the entries to the ones created on the interval. They are ignored when zero.
+	From time.Time `query:"from"`
+	To   time.Time `query:"to" validate:"omitempty,gtefield=From"`
+}
+
+// AuditEntriesList is the structure to represent the request data for the list audit entries endpoint.
+type AuditEntriesList struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	AuditEntryFilter
+	query.Paginator
+}
+
+// AuditEntriesExport is the structure to represent the request data for the export audit entries endpoint.
+type AuditEntriesExport struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	AuditEntryFilter
+	// Format is the export's format, either "csv" or "json". When empty, it is "csv".
+	Format string `query:"format" validate:"omitempty,oneof=csv json"`
+}
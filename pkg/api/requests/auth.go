@@ -13,4 +13,15 @@ type AuthTokenSwap struct {
 type CreateUserToken struct {
 	UserID   string `param:"id" header:"X-ID" validate:"required"`
 	TenantID string `param:"tenant" validate:"omitempty,uuid"`
+	// SessionID is the session of the token used to request the new one, which is kept on the new token.
+	SessionID string `header:"X-Session-ID"`
+	UserAgent string `header:"User-Agent"`
+	IP        string `header:"X-Real-IP"`
+}
+
+// RefreshUserToken is the structure to represent the request data for the refresh user token endpoint.
+type RefreshUserToken struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	UserAgent    string `header:"User-Agent"`
+	IP           string `header:"X-Real-IP"`
 }
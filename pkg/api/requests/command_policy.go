@@ -0,0 +1,61 @@
+package requests
+
+import (
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// CommandPolicyParam is a structure to represent and validate a command policy's ID as path param.
+type CommandPolicyParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// CommandPolicyList is the structure to represent the request data for the list command policies endpoint.
+type CommandPolicyList struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// CommandPolicyCreate is the structure to represent the request data for the create command policy endpoint.
+type CommandPolicyCreate struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	Name     string `json:"name" validate:"required,max=64"`
+	// Tags restrict the policy to the devices with any of them. When empty, the policy applies to every device.
+	Tags     []string                 `json:"tags" validate:"max=3,unique,dive,tag"`
+	Mode     models.CommandPolicyMode `json:"mode" validate:"required,oneof=deny allow"`
+	Patterns []string                 `json:"patterns" validate:"required,min=1,max=20,dive,required,max=256"`
+	// Action is what is done with the command lines violating the policy.
+	Action models.CommandPolicyAction `json:"action" validate:"required,oneof=warn block terminate"`
+}
+
+// CommandPolicyUpdate is the structure to represent the request data for the update command policy endpoint.
+type CommandPolicyUpdate struct {
+	CommandPolicyParam
+	CommandPolicyCreate
+}
+
+// CommandPolicyDelete is the structure to represent the request data for the delete command policy endpoint.
+type CommandPolicyDelete struct {
+	CommandPolicyParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// CommandPolicyEvaluationsList is the structure to represent the request data for the list command policy
+// evaluations endpoint.
+type CommandPolicyEvaluationsList struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	query.Paginator
+}
+
+// DeviceCommandPoliciesGet is the structure to represent the request data for the internal endpoint that gets the
+// command policies applying to a device.
+type DeviceCommandPoliciesGet struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// CommandPolicyEvaluationCreate is the structure to represent the request data for the internal command policy
+// evaluation endpoint.
+type CommandPolicyEvaluationCreate struct {
+	DeviceParam
+	models.CommandPolicyEvaluation
+}
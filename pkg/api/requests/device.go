@@ -1,6 +1,9 @@
 package requests
 
 import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
@@ -8,6 +11,8 @@ import (
 type DeviceList struct {
 	TenantID     string              `header:"X-Tenant-ID"`
 	DeviceStatus models.DeviceStatus `query:"status"` //  TODO: validate
+	// Group is the ID of the group whose devices, including the ones of its subgroups, are listed.
+	Group string `query:"group"`
 	query.Paginator
 	query.Sorter
 	query.Filters
@@ -68,7 +73,7 @@ type DeviceRemoveTag struct {
 // DeviceUpdateTag is the structure to represent the request data for device update tags endpoint.
 type DeviceUpdateTag struct {
 	DeviceParam
-	Tags []string `json:"tags" validate:"required,min=0,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags []string `json:"tags" validate:"required,min=0,max=3,unique,dive,tag"`
 }
 
 type DeviceIdentity struct {
@@ -81,16 +86,39 @@ type DeviceInfo struct {
 	Version    string `json:"version"`
 	Arch       string `json:"arch"`
 	Platform   string `json:"platform"`
+	// ClockSkew is how many seconds the device's clock is ahead of the server's.
+	ClockSkew int64 `json:"clock_skew"`
+	// Hardware is the device's hardware inventory. It is nil when the agent doesn't report it.
+	Hardware *models.DeviceHardware `json:"hardware,omitempty"`
+	// Interfaces are the device's network interfaces. It is empty when the agent doesn't report them.
+	Interfaces []models.DeviceInterface `json:"interfaces,omitempty" validate:"max=64"`
+}
+
+// DeviceConnection is the measure of the agent's connection since its previous ping.
+type DeviceConnection struct {
+	// RTT is the round-trip time, in milliseconds, of the agent's previous ping.
+	RTT int64 `json:"rtt" validate:"min=0"`
+	// Reconnects is the number of times the agent's tunnel was reconnected since its previous ping.
+	Reconnects int `json:"reconnects" validate:"min=0"`
 }
 
 // DeviceAuth is the structure to represent the request data for device auth endpoint.
 type DeviceAuth struct {
-	Info      *DeviceInfo     `json:"info" validate:"required"`
+	Info *DeviceInfo `json:"info" validate:"required_without=InfoHash"`
+	// InfoHash is the digest of the device's information, sent instead of it when unchanged since the previous
+	// authorization.
+	InfoHash  string          `json:"info_hash,omitempty" validate:"omitempty,len=64,hexadecimal"`
 	Sessions  []string        `json:"sessions,omitempty"`
 	Hostname  string          `json:"hostname,omitempty" validate:"required_without=Identity,omitempty,device_name" hash:"-"`
 	Identity  *DeviceIdentity `json:"identity,omitempty" validate:"required_without=Hostname,omitempty"`
 	PublicKey string          `json:"public_key" validate:"required"`
-	TenantID  string          `json:"tenant_id" validate:"required"`
+	TenantID  string          `json:"tenant_id" validate:"required_without=EnrollToken"`
+	// EnrollToken is an enroll token of the namespace, presented instead of the tenant ID.
+	EnrollToken string `json:"enroll_token,omitempty" validate:"required_without=TenantID"`
+	// Connection is the measure of the agent's connection since its previous ping. It is nil on the first one.
+	Connection *DeviceConnection `json:"connection,omitempty" validate:"omitempty"`
+	// ConfigVersion is the version of the device's configuration applied by the agent.
+	ConfigVersion int `json:"config_version,omitempty" validate:"min=0"`
 }
 
 type DeviceGetPublicURL struct {
@@ -107,3 +135,292 @@ type DeviceUpdate struct {
 type DevicePublicURLAddress struct {
 	PublicURLAddress string `param:"address" validate:"required"`
 }
+
+// DeviceKeyIncidentList is the structure to represent the request data for list device key incidents endpoint.
+type DeviceKeyIncidentList struct {
+	TenantID string                         `header:"X-Tenant-ID"`
+	Status   models.DeviceKeyIncidentStatus `query:"status" validate:"omitempty,oneof=open approved rejected"`
+	query.Paginator
+}
+
+// DeviceKeyIncidentUpdate is the structure to represent the request data for update device key incident endpoint.
+type DeviceKeyIncidentUpdate struct {
+	TenantID string                         `header:"X-Tenant-ID"`
+	ID       string                         `param:"id" validate:"required"`
+	Status   models.DeviceKeyIncidentStatus `json:"status" validate:"required,oneof=approved rejected"`
+}
+
+// DeviceAgentLogsCreate is the structure to represent the request data for the device's agent logs report endpoint.
+type DeviceAgentLogsCreate struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// DeviceUID is the UID of the authenticated device, that must be the one whose logs are reported.
+	DeviceUID string                  `header:"X-Device-UID" validate:"required"`
+	Logs      []models.DeviceAgentLog `json:"logs" validate:"required,min=1,max=100,dive"`
+}
+
+// DeviceAgentLogsList is the structure to represent the request data for the list device's agent logs endpoint.
+type DeviceAgentLogsList struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID"`
+	Level    string `query:"level" validate:"omitempty,oneof=panic fatal error warning"`
+	query.Paginator
+}
+
+// DeviceCommandCreate is the structure to represent the request data for the queue device's command endpoint.
+type DeviceCommandCreate struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	UserID   string `header:"X-ID" validate:"required"`
+	Command  string `json:"command" validate:"required,max=4096"`
+	// User is the device's user the command is executed as. When empty, the command is executed as root.
+	User string `json:"user" validate:"max=32"`
+}
+
+// DeviceCommandsList is the structure to represent the request data for the list device's commands endpoint.
+type DeviceCommandsList struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID"`
+	query.Paginator
+}
+
+// DeviceCommandsDispatch is the structure to represent the request data for the endpoint the device's agent fetches
+// its queued commands from.
+type DeviceCommandsDispatch struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// DeviceUID is the UID of the authenticated device, that must be the one whose commands are fetched.
+	DeviceUID string `header:"X-Device-UID" validate:"required"`
+}
+
+// DeviceCommandFinish is the structure to represent the request data for the endpoint the device's agent reports a
+// command's result to.
+type DeviceCommandFinish struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// DeviceUID is the UID of the authenticated device, that must be the one whose command's result is reported.
+	DeviceUID string `header:"X-Device-UID" validate:"required"`
+	ID        string `param:"id" validate:"required"`
+	models.DeviceCommandResult
+}
+
+// PublicURLLogCreate is the structure to represent the request data for the internal public URL log endpoint.
+type PublicURLLogCreate struct {
+	DeviceParam
+	models.PublicURLLog
+}
+
+// PublicURLLogsList is the structure to represent the request data for the list device's public URL logs endpoint.
+type PublicURLLogsList struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID"`
+	query.Paginator
+}
+
+// PublicURLStatsGet is the structure to represent the request data for the device's public URL stats endpoint.
+type PublicURLStatsGet struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID"`
+}
+
+// DeviceEventsSubscribe is the structure to represent the request data for the subscription of device events.
+type DeviceEventsSubscribe struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Status, when set, restricts the events to the devices with the given status.
+	Status models.DeviceStatus `query:"status" validate:"omitempty,oneof=accepted pending rejected removed unused"`
+	// Tags, when set, restricts the events to the devices with any of the given tags or their descendants.
+	Tags []string `query:"tags" validate:"omitempty,max=3,dive,tag"`
+}
+
+// DeviceUpdateLoginShell is the structure to represent the request data for the device update login shell endpoint.
+type DeviceUpdateLoginShell struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// LoginShell is the absolute path, on the device, of the program started on the interactive sessions. When
+	// empty, the user's shell is used.
+	LoginShell string `json:"login_shell" validate:"omitempty,startswith=/,max=255"`
+}
+
+// DeviceUpdateConnectionNote is the structure to represent the request data for the device update connection note
+// endpoint.
+type DeviceUpdateConnectionNote struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// ConnectionNote is the markdown note with instructions to connect to the device, limited to
+	// [models.DeviceConnectionNoteMaxLength] characters. When empty, the note is removed.
+	ConnectionNote string `json:"connection_note" validate:"max=4096"`
+}
+
+// DeviceUpdateConfig is the structure to represent the request data for the device update config endpoint.
+type DeviceUpdateConfig struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Values replace the device's whole configuration. Each one of them must be accepted by its key, as checked by
+	// [models.IsDeviceConfigValueValid].
+	Values map[string]string `json:"values" validate:"max=16,dive,keys,oneof=log_level forced_command sftp,endkeys,max=1024"`
+}
+
+// DeviceUpdateLimitExemption is the structure to represent the request data for the device update limit exemption
+// endpoint.
+type DeviceUpdateLimitExemption struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	UserID   string `header:"X-ID" validate:"required"`
+	// Exempt defines if the device is exempt from the namespace's maximum number of devices.
+	Exempt *bool `json:"exempt" validate:"required"`
+	// Reason is the justification kept on the exemption's audit trail.
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+// DeviceLimitExemptionsList is the structure to represent the request data for the list device's limit exemptions
+// endpoint.
+type DeviceLimitExemptionsList struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID"`
+	query.Paginator
+}
+
+// DeviceUpdateRemoteAccess is the structure to represent the request data for the device update remote access
+// endpoint.
+type DeviceUpdateRemoteAccess struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// RemoteAccess enables the reverse SSH tunnel of a device whose agent runs in inventory-only mode.
+	RemoteAccess bool `json:"remote_access"`
+}
+
+// DeviceClaim is the structure to represent the request data for the device claim endpoint.
+type DeviceClaim struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Code is the claim code shown by the device's agent, like "7K3QF-M2XAD".
+	Code string `json:"code" validate:"required"`
+}
+
+// DevicePositionsList is the structure to represent the request data for the list device positions endpoint.
+type DevicePositionsList struct {
+	TenantID string `header:"X-Tenant-ID"`
+	// BBox is the map's visible area, written as "west,south,east,north".
+	BBox string `query:"bbox" validate:"required,bbox"`
+	// Zoom is the map's zoom level, from 0, the whole globe, to 22.
+	Zoom int `query:"zoom" validate:"min=0,max=22"`
+}
+
+// DeviceQueueList is the structure to represent the request data for the list device acceptance queue endpoint.
+type DeviceQueueList struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// DeviceQueue is the structure to represent the request data for the queue device for acceptance endpoint.
+type DeviceQueue struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	UserID   string `header:"X-ID" validate:"required"`
+	// Priority is the device's priority on the queue. The devices with higher priorities are accepted first.
+	Priority int `json:"priority" validate:"min=-100,max=100"`
+}
+
+// DeviceDequeue is the structure to represent the request data for the remove device from the acceptance queue
+// endpoint.
+type DeviceDequeue struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// DeviceEvaluateSessionSchedule is the structure to represent the request data for the evaluate device's session
+// schedule endpoint.
+type DeviceEvaluateSessionSchedule struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// DeviceEvaluateSessionPolicy is the structure to represent the request data for the evaluate device's session policy
+// endpoint.
+type DeviceEvaluateSessionPolicy struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Username is the username the session logs in as on the device.
+	Username string `json:"username" validate:"required"`
+	// IPAddress is the address of the session's client.
+	IPAddress string `json:"ip_address" validate:"required"`
+}
+
+// DeviceEvaluateSessionWebhook is the structure to represent the request data for the evaluate device's session
+// webhook endpoint.
+type DeviceEvaluateSessionWebhook struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Username is the username the session logs in as on the device.
+	Username string `json:"username" validate:"required"`
+	// IPAddress is the address of the session's client.
+	IPAddress string `json:"ip_address" validate:"required"`
+}
+
+// DeviceOverrideSessionSchedule is the structure to represent the request data for the override device's session
+// schedule endpoint.
+type DeviceOverrideSessionSchedule struct {
+	DeviceParam
+	TenantID string          `header:"X-Tenant-ID" validate:"required"`
+	UserID   string          `header:"X-ID" validate:"required"`
+	Role     authorizer.Role `header:"X-Role"`
+	// Reason is the justification kept on the override's audit trail.
+	Reason string `json:"reason" validate:"required,max=255"`
+	// Duration is for how long, in minutes, the connections to the device are allowed outside the schedules.
+	Duration int `json:"duration" validate:"required,min=1,max=1440"`
+}
+
+// DeviceCreateWebSession is the structure to represent the request data for the create device's web session endpoint.
+type DeviceCreateWebSession struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	UserID   string `header:"X-ID" validate:"required"`
+	// Username is the user in the device's OS the terminal is opened for.
+	Username string `json:"username" validate:"required"`
+	// TTL is for how long, in seconds, the session's token can be redeemed. When zero, it is valid for a minute.
+	TTL int `json:"ttl" validate:"omitempty,min=1,max=300"`
+}
+
+// DeviceSessionScheduleOverridesList is the structure to represent the request data for the list device's session
+// schedule overrides endpoint.
+type DeviceSessionScheduleOverridesList struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID"`
+	query.Paginator
+}
+
+// DeviceQuarantineAttemptList is the structure to represent the request data for list device quarantine attempts
+// endpoint.
+type DeviceQuarantineAttemptList struct {
+	TenantID string `header:"X-Tenant-ID"`
+	// UID restricts the attempts to the ones of a single device.
+	UID string `query:"uid"`
+	query.Paginator
+}
+
+// DeviceChangesList is the structure to represent the request data for list device changes endpoint.
+type DeviceChangesList struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Since is the cursor returned by the previous request. The changes since the beginning are returned without it.
+	Since string `query:"since" validate:"omitempty,numeric"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=1000"`
+}
+
+// DevicesHeartbeat is a batch of heartbeats of the devices connected to the service sending it.
+type DevicesHeartbeat struct {
+	Heartbeats []DeviceHeartbeat `json:"heartbeats" validate:"required,min=1,max=10000,dive"`
+}
+
+// DeviceHeartbeat is a heartbeat received from a device.
+type DeviceHeartbeat struct {
+	TenantID string `json:"tenant_id" validate:"required"`
+	UID      string `json:"uid" validate:"required"`
+	// SeenAt is when the heartbeat was received, as a Unix timestamp in seconds.
+	SeenAt int64 `json:"seen_at" validate:"required,min=1"`
+}
+
+// DeviceDiff is the structure to represent the request data for the device's diff endpoint.
+type DeviceDiff struct {
+	DeviceParam
+	TenantID string    `header:"X-Tenant-ID"`
+	From     time.Time `query:"from" validate:"required,ltefield=To"`
+	To       time.Time `query:"to" validate:"required"`
+}
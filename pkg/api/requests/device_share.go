@@ -0,0 +1,32 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceShareList is the structure to represent the request data for the list device shares endpoint.
+type DeviceShareList struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// DeviceShareCreate is the structure to represent the request data for the create device share endpoint.
+type DeviceShareCreate struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	UserID   string `header:"X-ID"`
+	// GranteeTenantID is the namespace the device is shared with.
+	GranteeTenantID string                       `json:"grantee_tenant_id" validate:"required,uuid"`
+	Permission      models.DeviceSharePermission `json:"permission" validate:"required,oneof=read connect"`
+	// ExpiresAt is when the share stops granting access. When nil, the share doesn't expire.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// DeviceShareDelete is the structure to represent the request data for the delete device share endpoint.
+type DeviceShareDelete struct {
+	DeviceParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	ID       string `param:"id" validate:"required"`
+}
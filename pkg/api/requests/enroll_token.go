@@ -0,0 +1,25 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/query"
+
+// CreateEnrollToken is the structure to represent the request data for the create enroll token endpoint.
+type CreateEnrollToken struct {
+	TenantParam
+	UserID string `header:"X-ID"`
+	// MaxUses is how many devices the token may enroll.
+	MaxUses int `json:"max_uses" validate:"required,min=1,max=10000"`
+	// TTL is for how long, in seconds, the token may enroll devices, up to a year.
+	TTL int `json:"ttl" validate:"required,min=60,max=31536000"`
+}
+
+// ListEnrollTokens is the structure to represent the request data for the list enroll tokens endpoint.
+type ListEnrollTokens struct {
+	TenantParam
+	query.Paginator
+}
+
+// DeleteEnrollToken is the structure to represent the request data for the delete enroll token endpoint.
+type DeleteEnrollToken struct {
+	TenantParam
+	ID string `param:"id" validate:"required,uuid"`
+}
@@ -0,0 +1,40 @@
+package requests
+
+// GroupParam is a structure to represent and validate a group's ID as path param.
+type GroupParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// GroupList is the structure to represent the request data for the list groups endpoint.
+type GroupList struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// GroupCreate is the structure to represent the request data for the create group endpoint.
+type GroupCreate struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// ParentID is the ID of the group the group is nested into. It is empty for a top-level group.
+	ParentID    string `json:"parent_id"`
+	Name        string `json:"name" validate:"required,max=64"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+// GroupUpdate is the structure to represent the request data for the update group endpoint.
+type GroupUpdate struct {
+	GroupParam
+	GroupCreate
+}
+
+// GroupDelete is the structure to represent the request data for the delete group endpoint.
+type GroupDelete struct {
+	GroupParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// GroupDevice is the structure to represent the request data for the endpoints that add and remove a device from a
+// group.
+type GroupDevice struct {
+	GroupParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	UID      string `param:"uid" validate:"required"`
+}
@@ -0,0 +1,23 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/models"
+
+// DevicesJobCreate is the structure to represent the request data for the endpoints that create an asynchronous job
+// on a list of devices.
+type DevicesJobCreate struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	UserID   string `header:"X-ID"`
+	// IdempotencyKey identifies the job on the namespace, so a retried request doesn't create it twice.
+	IdempotencyKey string `header:"Idempotency-Key" validate:"omitempty,max=255"`
+	// Operation is the job's operation, set by the endpoint.
+	Operation models.JobOperation `json:"-"`
+	UIDs      []string            `json:"uids" validate:"required,min=1,max=1000,unique,dive,required"`
+	// Tag is the tag added to the devices, required by the [models.JobOperationDeviceTag] operation only.
+	Tag string `json:"tag" validate:"required_if=Operation device.tag,omitempty,tag"`
+}
+
+// JobGet is the structure to represent the request data for the get job endpoint.
+type JobGet struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	ID       string `param:"id" validate:"required"`
+}
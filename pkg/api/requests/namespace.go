@@ -3,6 +3,7 @@ package requests
 import (
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 // TenantParam is a structure to represent and validate a namespace tenant as path param.
@@ -51,6 +52,34 @@ type NamespaceEdit struct {
 	Settings struct {
 		SessionRecord          *bool   `json:"session_record" validate:"omitempty"`
 		ConnectionAnnouncement *string `json:"connection_announcement" validate:"omitempty,min=0,max=4096"`
+		DeviceKeyPinning       *bool   `json:"device_key_pinning" validate:"omitempty"`
+		WebSessionMaxDuration  *int    `json:"web_session_max_duration" validate:"omitempty,min=0"`
+		DeviceGeoAlert         *bool   `json:"device_geo_alert" validate:"omitempty"`
+		DeviceNameTemplate     *string `json:"device_name_template" validate:"omitempty,max=255"`
+		// RecordWatermark is how the recorded sessions are watermarked on playback. An empty value disables it.
+		RecordWatermark *string `json:"record_watermark" validate:"omitempty,oneof=metadata overlay"`
+		// DefaultTags are the tags applied to the devices when they are accepted. An empty list disables it.
+		DefaultTags *[]string `json:"default_tags" validate:"omitempty,max=3,unique,dive,tag"`
+		// SessionKeepAlive defines if the idle interactive sessions receive keep-alive requests from the SSH server.
+		SessionKeepAlive *bool `json:"session_keep_alive" validate:"omitempty"`
+		// SessionSchedules restrict the connections to the devices to the windows of the week. An empty list disables
+		// it.
+		SessionSchedules *[]models.SessionSchedule `json:"session_schedules" validate:"omitempty,max=16"`
+		// DeviceQuarantine defines if the authentication attempts of the rejected devices are recorded and alerted.
+		DeviceQuarantine *bool `json:"device_quarantine" validate:"omitempty"`
+		// SessionRecordPause defines if the users may pause the recording of their sessions.
+		SessionRecordPause *bool `json:"session_record_pause" validate:"omitempty"`
+		// SessionAttestation defines if the namespace's completed sessions are signed by the server.
+		SessionAttestation *bool `json:"session_attestation" validate:"omitempty"`
+		// MaxSessions is the maximum number of simultaneous interactive sessions to the namespace's devices. Zero
+		// disables it.
+		MaxSessions *int `json:"max_sessions" validate:"omitempty,min=0"`
+		// ConnectionWebhook is the endpoint asked to allow or deny each SSH connection to the namespace's devices. A
+		// webhook without URL disables it.
+		ConnectionWebhook *models.ConnectionWebhook `json:"connection_webhook" validate:"omitempty"`
+		// UserProvisioning allows the agents to create the local users targeted by the SSH sessions when they don't
+		// exist on the devices.
+		UserProvisioning *models.UserProvisioning `json:"user_provisioning" validate:"omitempty"`
 	} `json:"settings"`
 }
 
@@ -75,12 +104,27 @@ type NamespaceRemoveMember struct {
 	MemberID string `param:"uid" validate:"required"`
 }
 
+// NamespaceMemberActivity is the structure to represent the request data for the activity report of a namespace's
+// member.
+type NamespaceMemberActivity struct {
+	UserID   string `header:"X-ID" validate:"required"`
+	TenantID string `param:"tenant" validate:"required,uuid"`
+	MemberID string `param:"uid" validate:"required"`
+	// Days is the size, in days, of the report's window, ending now.
+	Days int `query:"days" validate:"omitempty,min=1,max=365"`
+}
+
 type LeaveNamespace struct {
 	UserID string `header:"X-ID" validate:"required"`
 	// TenantID represents the namespace that the user intends to leave.
 	TenantID string `param:"tenant" validate:"required,uuid"`
 	// AuthenticatedTenantID represents the namespace to which the user is currently authenticated.
 	AuthenticatedTenantID string `header:"X-Tenant-ID" validate:"required"`
+	// SessionID, UserAgent and IP are used to issue the token to the user's session, when leaving the namespace to
+	// which the user is currently authenticated.
+	SessionID string `header:"X-Session-ID"`
+	UserAgent string `header:"User-Agent"`
+	IP        string `header:"X-Real-IP"`
 }
 
 // SessionEditRecordStatus is the structure to represent the request data for edit session record status endpoint.
@@ -88,3 +132,46 @@ type SessionEditRecordStatus struct {
 	TenantParam
 	SessionRecord bool `json:"session_record"`
 }
+
+// NamespaceDeviceNameTemplatePreview is the structure to represent the request data for the preview device name
+// template endpoint.
+type NamespaceDeviceNameTemplatePreview struct {
+	TenantParam
+	Template string `json:"template" validate:"required,max=255"`
+}
+
+// NamespaceConnectionAnnouncementPreview is the structure to represent the request data for the preview connection
+// announcement endpoint.
+type NamespaceConnectionAnnouncementPreview struct {
+	TenantParam
+	Announcement string `json:"announcement" validate:"required,max=4096"`
+}
+
+// NamespaceDeviceLimits is the structure to represent the request data for the update namespace device limits
+// endpoint. A nil limit is kept unchanged, and a negative one removes the limit.
+type NamespaceDeviceLimits struct {
+	TenantParam
+	MaxDevices        *int `json:"max_devices" validate:"omitempty"`
+	MaxPendingDevices *int `json:"max_pending_devices" validate:"omitempty"`
+}
+
+// NamespaceEventsSubscribe is the structure to represent the request data for the subscription of namespace events.
+type NamespaceEventsSubscribe struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// NamespaceClone is the structure to represent the request data for the clone namespace endpoint. The namespace
+// cloned must be the one the user is authenticated on.
+type NamespaceClone struct {
+	TenantParam
+	UserID   string `header:"X-ID" validate:"required"`
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Name is the name of the namespace created.
+	Name string `json:"name" validate:"required,hostname_rfc1123,excludes=."`
+	// Clone is the tenant ID of the namespace created. When it is empty, a new one is generated.
+	Clone string `json:"tenant" validate:"omitempty,uuid"`
+	// PublicKeys defines if the namespace's public keys are copied, with their filters, to the namespace created.
+	PublicKeys bool `json:"public_keys"`
+	// FowardedHost is the host the invitations sent to the namespace's members link to.
+	FowardedHost string `header:"X-Forwarded-Host"`
+}
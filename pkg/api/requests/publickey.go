@@ -17,7 +17,7 @@ type PublicKeyFilter struct {
 	//
 	// If used `min=1` to do that validation, when tags is empty, its zero value, and only hostname is provided,
 	// it throws a error even with `required_without` and `excluded_with`.
-	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,tag"`
 }
 
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
@@ -30,6 +30,22 @@ type PublicKeyCreate struct {
 	Fingerprint string          `json:"-"`
 }
 
+// PublicKeyImport is the structure to represent the request data for import public keys endpoint.
+type PublicKeyImport struct {
+	// Provider is the code hosting provider where the users' keys are published.
+	Provider string `json:"provider" validate:"required,oneof=github gitlab"`
+	// Usernames are the users on the provider whose keys are imported.
+	Usernames []string `json:"usernames" validate:"required,min=1,max=20,unique,dive,required"`
+	// Name is the template of the imported keys' names. The "{username}" and "{index}" placeholders are replaced by the
+	// provider's username and the key's position on its list. When empty, "{username}-{index}" is used.
+	Name string `json:"name"`
+	// Username is the template of the imported keys' username. The "{username}" placeholder is replaced by the
+	// provider's username.
+	Username string          `json:"username" validate:"required,regexp"`
+	Filter   PublicKeyFilter `json:"filter" validate:"required"`
+	TenantID string          `json:"-"`
+}
+
 // PublicKeyUpdate is the structure to represent the request data for update public key endpoint.
 type PublicKeyUpdate struct {
 	FingerprintParam
@@ -61,7 +77,7 @@ type PublicKeyTagRemove struct {
 // PublicKeyTagsUpdate is the structure to represent the request data for update tags from public key endpoint.
 type PublicKeyTagsUpdate struct {
 	FingerprintParam
-	Tags []string `json:"tags" validate:"required,min=1,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags []string `json:"tags" validate:"required,min=1,max=3,unique,dive,tag"`
 }
 
 // PublicKeyAuth is the structure to represent the request data for public key auth endpoint.
@@ -69,3 +85,13 @@ type PublicKeyAuth struct {
 	Fingerprint string `json:"fingerprint" validate:"required"`
 	Data        string `json:"data" validate:"required"`
 }
+
+// PublicKeyTest is the structure to represent the request data for test public key endpoint.
+type PublicKeyTest struct {
+	FingerprintParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Device is the UID or the name of the device where the access is tested.
+	Device string `json:"device" validate:"required"`
+	// Username is the username used on the access tested.
+	Username string `json:"username" validate:"required"`
+}
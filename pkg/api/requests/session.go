@@ -1,6 +1,11 @@
 package requests
 
-import "time"
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
 
 // SessionIDParam is a structure to represent and validate a session UID as path param.
 type SessionIDParam struct {
@@ -27,6 +32,8 @@ type SessionCreate struct {
 	IPAddress string `json:"ip_address" validate:"required"`
 	Type      string `json:"type" validate:"required"`
 	Term      string `json:"term" validate:""`
+	// Client contains the metadata of the SSH client that opened the session.
+	Client models.SessionClient `json:"client"`
 }
 
 // SessionFinish is the structure to represent the request data for finish session endpoint.
@@ -43,6 +50,11 @@ type SessionUpdate struct {
 	SessionIDParam
 	Authenticated *bool   `json:"authenticated"`
 	Type          *string `json:"type"`
+	AuthMethod    *string `json:"auth_method"`
+	// CertificateKeyID is the key ID of the user certificate used by the client to authenticate the session.
+	CertificateKeyID *string `json:"certificate_key_id" validate:"omitempty,max=1024"`
+	RecordHash       *string `json:"record_hash" validate:"omitempty,len=64,hexadecimal"`
+	RecordObject     *string `json:"record_object" validate:"omitempty,max=1024"`
 }
 
 type SessionEvent struct {
@@ -51,3 +63,37 @@ type SessionEvent struct {
 	Timestamp time.Time `json:"timestamp" validate:"required"`
 	Data      any       `json:"data" validate:"required"`
 }
+
+// SessionRecordingGet is the request to retrieve the session's recording from the object storage, for playback.
+type SessionRecordingGet struct {
+	SessionIDParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// Username is the viewer the recording is watermarked with, when the namespace watermarks its recordings.
+	Username string `header:"X-Username"`
+	UserID   string `header:"X-ID"`
+	IP       string `header:"X-Real-IP"`
+	// Range is the byte range of the recording requested. It is empty when the whole recording is requested.
+	Range string `header:"Range" validate:"max=255"`
+	// Download indicates the recording is exported as a file, instead of retrieved for playback.
+	Download bool `query:"download"`
+}
+
+// SessionRecordingAccessList is the request to list the access log of the session's recording.
+type SessionRecordingAccessList struct {
+	SessionIDParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	query.Paginator
+}
+
+// SessionAttestationVerify is the session's attestation, as exported with the session, to be verified.
+type SessionAttestationVerify struct {
+	Statement models.SessionStatement `json:"statement" validate:"required"`
+	Digest    string                  `json:"digest" validate:"required,len=64,hexadecimal"`
+	Signature string                  `json:"signature" validate:"required,base64"`
+}
+
+// WebSessionRedeem is the request to redeem a pre-authorized web session's token, on behalf of the SSH server's web
+// bridge.
+type WebSessionRedeem struct {
+	Token string `json:"token" validate:"required"`
+}
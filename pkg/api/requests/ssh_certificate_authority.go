@@ -0,0 +1,32 @@
+package requests
+
+// SSHCertificateAuthoritiesGet is the structure to represent the request data for the get SSH certificate authorities
+// endpoint.
+type SSHCertificateAuthoritiesGet struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// SSHCertificateAuthoritySet is the structure to represent the request data for the set SSH certificate authority
+// endpoint.
+type SSHCertificateAuthoritySet struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// PublicKey is the CA's public key, in the authorized_keys format.
+	PublicKey string `json:"public_key" validate:"required,max=16384"`
+}
+
+// SSHCertificateAuthorityRotate is the structure to represent the request data for the rotate SSH certificate
+// authority endpoint.
+type SSHCertificateAuthorityRotate struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	// PublicKey is the new CA's public key, in the authorized_keys format.
+	PublicKey string `json:"public_key" validate:"required,max=16384"`
+	// GracePeriod is the number of seconds the previous CA is still trusted, up to 30 days. When zero, it stops being
+	// trusted at once.
+	GracePeriod int `json:"grace_period" validate:"min=0,max=2592000"`
+}
+
+// SSHCertificateAuthorityDelete is the structure to represent the request data for the delete SSH certificate
+// authority endpoint.
+type SSHCertificateAuthorityDelete struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
@@ -14,3 +14,23 @@ type SystemInstallScript struct {
 	PreferredHostname   string `query:"preferred_hostname"`
 	PreferredIdentity   string `query:"preferred_identity"`
 }
+
+// BannedAddressCreate is the structure to represent the request data for banning an IP address or a range of them.
+type BannedAddressCreate struct {
+	// Address is an IP address or a range in CIDR notation.
+	Address string `json:"address" validate:"required"`
+	Reason  string `json:"reason" validate:"omitempty,max=255"`
+	// Duration is how long, in seconds, the address is banned. Zero bans it until removed.
+	Duration int `json:"duration" validate:"omitempty,min=0"`
+}
+
+// BannedAddressDelete is the structure to represent the request data for lifting the ban of an address.
+type BannedAddressDelete struct {
+	Address string `query:"address" validate:"required"`
+}
+
+// BannedAddressFailure is the structure to represent the request data for recording an authentication failure from
+// an IP address.
+type BannedAddressFailure struct {
+	Address string `json:"address" validate:"required,ip"`
+}
@@ -1,13 +1,15 @@
 package requests
 
+import "github.com/shellhub-io/shellhub/pkg/models"
+
 // TagParam is a structure to represent and validate a tag as path param.
 type TagParam struct {
-	Tag string `param:"tag" validate:"required,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tag string `param:"tag" validate:"required,tag"`
 }
 
 // TagBody is a structure to represent and validate a tag as json request body.
 type TagBody struct {
-	Tag string `json:"tag" validate:"required,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tag string `json:"tag" validate:"required,tag"`
 }
 
 // TagDelete is the structure to represent the request data for delete tag endpoint.
@@ -18,5 +20,36 @@ type TagDelete struct {
 // TagRename is the structure to represent the request data for rename tag endpoint.
 type TagRename struct {
 	TagParam
-	NewTag string `json:"tag" validate:"required,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	NewTag string `json:"tag" validate:"required,tag"`
+}
+
+// TagRuleParam is a structure to represent and validate a tag rule's ID as path param.
+type TagRuleParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// TagRuleList is the structure to represent the request data for the list tag rules endpoint.
+type TagRuleList struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+}
+
+// TagRuleCreate is the structure to represent the request data for the create tag rule endpoint.
+type TagRuleCreate struct {
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
+	Name     string `json:"name" validate:"required,max=64"`
+	// Tag is the tag assigned to the devices matching all the conditions.
+	Tag        string                    `json:"tag" validate:"required,tag"`
+	Conditions []models.TagRuleCondition `json:"conditions" validate:"required,min=1,max=10,dive"`
+}
+
+// TagRuleUpdate is the structure to represent the request data for the update tag rule endpoint.
+type TagRuleUpdate struct {
+	TagRuleParam
+	TagRuleCreate
+}
+
+// TagRuleDelete is the structure to represent the request data for the delete tag rule endpoint.
+type TagRuleDelete struct {
+	TagRuleParam
+	TenantID string `header:"X-Tenant-ID" validate:"required"`
 }
@@ -1,7 +1,10 @@
 // Package requests defines structures to represent requests' bodies from API.
 package requests
 
-import "github.com/shellhub-io/shellhub/pkg/models"
+import (
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
 
 type UserParam struct {
 	ID string `param:"id" validate:"required"`
@@ -33,4 +36,68 @@ type AuthLocalUser struct {
 	// TODO: change json tag from username to identifier and update the OpenAPI.
 	Identifier models.UserAuthIdentifier `json:"username" validate:"required"`
 	Password   string                    `json:"password" validate:"required"`
+	UserAgent  string                    `header:"User-Agent"`
+}
+
+// ResendUserEmailVerification is the structure to represent the request body for the resend email verification endpoint.
+type ResendUserEmailVerification struct {
+	// Identifier represents an username or email.
+	Identifier models.UserAuthIdentifier `json:"username" validate:"required"`
+	IP         string                    `header:"X-Real-IP"`
+}
+
+// VerifyUserEmail is the structure to represent the request query for the email verification endpoint.
+type VerifyUserEmail struct {
+	Email string `query:"email" validate:"required,email"`
+	Token string `query:"token" validate:"required"`
+}
+
+// UserAliasList is the structure to represent the request data for the list user aliases endpoint.
+type UserAliasList struct {
+	UserID string `header:"X-ID" validate:"required"`
+}
+
+// UserAliasSave is the structure to represent the request data for the save user alias endpoint.
+type UserAliasSave struct {
+	UserID string `header:"X-ID" validate:"required"`
+	Name   string `param:"name" validate:"required,user_alias"`
+	Target string `json:"target" validate:"required,user_alias_target"`
+}
+
+// UserAliasDelete is the structure to represent the request data for the delete user alias endpoint.
+type UserAliasDelete struct {
+	UserID string `header:"X-ID" validate:"required"`
+	Name   string `param:"name" validate:"required"`
+}
+
+// UserSessionList is the structure to represent the request data for the list user sessions endpoint.
+type UserSessionList struct {
+	UserID    string `header:"X-ID" validate:"required"`
+	SessionID string `header:"X-Session-ID"`
+}
+
+// UserSessionRevoke is the structure to represent the request data for the revoke user session endpoint.
+type UserSessionRevoke struct {
+	UserID string `header:"X-ID" validate:"required"`
+	ID     string `param:"id" validate:"required"`
+}
+
+// UserSessionRevokeAll is the structure to represent the request data for the revoke user sessions endpoint.
+type UserSessionRevokeAll struct {
+	UserID string `header:"X-ID" validate:"required"`
+}
+
+// UserAliasResolve is the structure to represent the request data for the internal resolve user alias endpoint.
+type UserAliasResolve struct {
+	Username string `param:"username" validate:"required"`
+	Name     string `param:"name" validate:"required"`
+}
+
+// UserDeviceSearch is the structure to represent the request data for the search user's devices endpoint.
+type UserDeviceSearch struct {
+	UserID string `header:"X-ID" validate:"required"`
+	// Name is the text searched, case-insensitively, on the device's name.
+	Name   string              `query:"name" validate:"required,max=64"`
+	Status models.DeviceStatus `query:"status" validate:"omitempty,oneof=accepted pending rejected removed unused"`
+	query.Paginator
 }
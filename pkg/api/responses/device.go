@@ -0,0 +1,12 @@
+package responses
+
+import "github.com/shellhub-io/shellhub/pkg/models"
+
+// DeviceChanges is the structure to represent the response data for list device changes endpoint.
+type DeviceChanges struct {
+	Changes []models.DeviceChange `json:"changes"`
+	// Cursor is the cursor to be sent on the next request, to get the changes after these ones.
+	Cursor string `json:"cursor"`
+	// HasMore indicates whether there are more changes after the cursor, which can be requested right away.
+	HasMore bool `json:"has_more"`
+}
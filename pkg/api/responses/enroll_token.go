@@ -0,0 +1,9 @@
+package responses
+
+import "github.com/shellhub-io/shellhub/pkg/models"
+
+// CreateEnrollToken is an enroll token just created. It is the only time the token itself is returned.
+type CreateEnrollToken struct {
+	models.EnrollToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,13 @@
+package responses
+
+// ConnectionAnnouncementPreview is a connection announcement rendered with sample data.
+type ConnectionAnnouncementPreview struct {
+	Announcement string `json:"announcement"`
+}
+
+// DeviceNamePreview is the name a pending device would receive when accepted.
+type DeviceNamePreview struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+	New  string `json:"new"`
+}
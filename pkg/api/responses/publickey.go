@@ -6,7 +6,7 @@ type PublicKeyFilter struct {
 	//
 	// If used `min=1` to do that validation, when tags is empty, its zero value, and only hostname is provided,
 	// it throws a error even with `required_without` and `excluded_with`.
-	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,tag"`
 }
 
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
@@ -18,3 +18,44 @@ type PublicKeyCreate struct {
 	TenantID    string          `json:"tenant_id"`
 	Fingerprint string          `json:"fingerprint"`
 }
+
+const (
+	PublicKeyImportCreated    = "created"
+	PublicKeyImportDuplicated = "duplicated"
+	PublicKeyImportFailed     = "failed"
+)
+
+// PublicKeyImportResult is the result of importing a single key, or a username when its keys couldn't be fetched.
+type PublicKeyImportResult struct {
+	Username    string `json:"username"`
+	Name        string `json:"name,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// PublicKeyImport is the structure to represent the response data for import public keys endpoint.
+type PublicKeyImport struct {
+	Created int                     `json:"created"`
+	Results []PublicKeyImportResult `json:"results"`
+}
+
+// PublicKeyCheck is the result of one of the checks done by a public key to authorize an access.
+type PublicKeyCheck struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+// PublicKeyTest is the structure to represent the response data for test public key endpoint.
+type PublicKeyTest struct {
+	// Allowed indicates whether the public key would authorize the access, as all its checks allow it.
+	Allowed bool `json:"allowed"`
+	// DeviceUID is the UID of the device where the access was tested.
+	DeviceUID string `json:"device_uid"`
+	// DeviceName is the name of the device where the access was tested.
+	DeviceName string `json:"device_name"`
+	// Username is the check of the access' username against the public key's username pattern.
+	Username PublicKeyCheck `json:"username"`
+	// Filter is the check of the device against the public key's filter, by hostname or tags.
+	Filter PublicKeyCheck `json:"filter"`
+}
@@ -0,0 +1,7 @@
+package responses
+
+// SessionAttestationVerify is the structure to represent the response data for verify session attestation endpoint.
+type SessionAttestationVerify struct {
+	// Valid indicates whether the attestation was signed by the server and its statement is untampered.
+	Valid bool `json:"valid"`
+}
@@ -0,0 +1,13 @@
+package responses
+
+import (
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// UserDevice is a device found on one of the namespaces where the user is a member.
+type UserDevice struct {
+	models.Device
+	// Role is the user's role on the device's namespace.
+	Role authorizer.Role `json:"role"`
+}
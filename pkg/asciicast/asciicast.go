@@ -0,0 +1,120 @@
+// Package asciicast encodes the frames of a recorded session in the asciicast v2 format, used to export and stream
+// the recordings for playback, watermarking them with the viewer who requested them when configured.
+//
+// It also converts the recordings into plain text transcripts, for the reviews without a player.
+//
+// See https://docs.asciinema.org/manual/asciicast/v2/ for the format's specification.
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Version is the version of the asciicast format encoded.
+const Version = 2
+
+const (
+	// EventOutput is the event with data written to the terminal.
+	EventOutput = "o"
+	// EventResize is the event with the terminal's new size, formatted as "<width>x<height>".
+	EventResize = "r"
+)
+
+// Header is the first line of an asciicast file, with the recording's metadata.
+type Header struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Title     string `json:"title,omitempty"`
+	// Watermark identifies who the recording was exported to. It isn't part of the asciicast specification, so it is
+	// ignored by the players.
+	Watermark *Watermark `json:"watermark,omitempty"`
+}
+
+// Watermark identifies the viewer a recording was exported or streamed to, so a leaked export can be traced back.
+type Watermark struct {
+	// Viewer is the username of who requested the recording.
+	Viewer string `json:"viewer"`
+	// Time is when the recording was requested.
+	Time time.Time `json:"time"`
+}
+
+func (w *Watermark) String() string {
+	return fmt.Sprintf("%s %s", w.Viewer, w.Time.UTC().Format(time.RFC3339))
+}
+
+// Overlay returns the escape sequences that draw the watermark on the bottom right corner of a terminal with the
+// specified size, restoring the cursor's position and the text's attributes afterwards.
+func (w *Watermark) Overlay(width, height int) string {
+	text := w.String()
+
+	column := width - len(text) + 1
+	if column < 1 {
+		column = 1
+	}
+
+	if height < 1 {
+		height = 1
+	}
+
+	// NOTICE: the cursor is saved and restored with DECSC and DECRC, so the session's output isn't disturbed.
+	return fmt.Sprintf("\x1b7\x1b[%d;%dH\x1b[0;2m%s\x1b[0m\x1b8", height, column, text)
+}
+
+// Encode writes the frames as an asciicast file to w. When mode is not empty, the watermark is embedded on the header
+// and, on [models.RecordWatermarkOverlay], drawn over the terminal's output after each frame.
+func Encode(w io.Writer, title string, frames []models.SessionRecorded, mode models.RecordWatermark, watermark *Watermark) error {
+	encoder := json.NewEncoder(w)
+
+	header := Header{Version: Version, Title: title}
+	if len(frames) > 0 {
+		header.Width = frames[0].Width
+		header.Height = frames[0].Height
+
+		if !frames[0].Time.IsZero() {
+			header.Timestamp = frames[0].Time.Unix()
+		}
+	}
+
+	if mode != "" && watermark != nil {
+		header.Watermark = watermark
+	}
+
+	if err := encoder.Encode(header); err != nil {
+		return err
+	}
+
+	width, height := header.Width, header.Height
+	for _, frame := range frames {
+		elapsed := 0.0
+		if !frame.Time.IsZero() && !frames[0].Time.IsZero() {
+			elapsed = frame.Time.Sub(frames[0].Time).Seconds()
+		}
+
+		if (frame.Width != 0 && frame.Width != width) || (frame.Height != 0 && frame.Height != height) {
+			width, height = frame.Width, frame.Height
+
+			if err := encoder.Encode([]interface{}{elapsed, EventResize, fmt.Sprintf("%dx%d", width, height)}); err != nil {
+				return err
+			}
+		}
+
+		data := frame.Message
+		// NOTICE: the watermark is drawn again after each frame, as the session's output may overwrite or clear it.
+		if mode == models.RecordWatermarkOverlay && watermark != nil {
+			data += watermark.Overlay(width, height)
+		}
+
+		if err := encoder.Encode([]interface{}{elapsed, EventOutput, data}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package asciicast
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	frames := []models.SessionRecorded{
+		{Message: "$ ", Width: 80, Height: 24, Time: start},
+		{Message: "ls\r\n", Width: 80, Height: 24, Time: start.Add(500 * time.Millisecond)},
+		{Message: "file\r\n", Width: 100, Height: 30, Time: start.Add(2 * time.Second)},
+	}
+
+	watermark := &Watermark{Viewer: "john_doe", Time: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)}
+
+	cases := []struct {
+		description string
+		mode        models.RecordWatermark
+		expected    string
+	}{
+		{
+			description: "encodes the frames without watermark",
+			mode:        "",
+			expected: `{"version":2,"width":80,"height":24,"timestamp":1672574400,"title":"session"}
+[0,"o","$ "]
+[0.5,"o","ls\r\n"]
+[2,"r","100x30"]
+[2,"o","file\r\n"]
+`,
+		},
+		{
+			description: "embeds the watermark in the metadata",
+			mode:        models.RecordWatermarkMetadata,
+			expected: `{"version":2,"width":80,"height":24,"timestamp":1672574400,"title":"session","watermark":{"viewer":"john_doe","time":"2023-01-02T12:00:00Z"}}
+[0,"o","$ "]
+[0.5,"o","ls\r\n"]
+[2,"r","100x30"]
+[2,"o","file\r\n"]
+`,
+		},
+		{
+			description: "overlays the watermark on the output",
+			mode:        models.RecordWatermarkOverlay,
+			expected: `{"version":2,"width":80,"height":24,"timestamp":1672574400,"title":"session","watermark":{"viewer":"john_doe","time":"2023-01-02T12:00:00Z"}}
+[0,"o","$ \u001b7\u001b[24;52H\u001b[0;2mjohn_doe 2023-01-02T12:00:00Z\u001b[0m\u001b8"]
+[0.5,"o","ls\r\n\u001b7\u001b[24;52H\u001b[0;2mjohn_doe 2023-01-02T12:00:00Z\u001b[0m\u001b8"]
+[2,"r","100x30"]
+[2,"o","file\r\n\u001b7\u001b[30;72H\u001b[0;2mjohn_doe 2023-01-02T12:00:00Z\u001b[0m\u001b8"]
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+
+			require.NoError(t, Encode(buffer, "session", frames, tc.mode, watermark))
+			assert.Equal(t, tc.expected, buffer.String())
+		})
+	}
+}
+
+func TestWatermarkOverlay(t *testing.T) {
+	watermark := &Watermark{Viewer: "john_doe", Time: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)}
+
+	assert.Equal(t, "\x1b7\x1b[1;1H\x1b[0;2mjohn_doe 2023-01-02T12:00:00Z\x1b[0m\x1b8", watermark.Overlay(10, 0))
+}
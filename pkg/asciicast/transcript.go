@@ -0,0 +1,219 @@
+package asciicast
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// transcriptState is the state of the escape sequence being parsed by a [Transcript].
+type transcriptState int
+
+const (
+	// stateText is when the output is text.
+	stateText transcriptState = iota
+	// stateEscape is after an ESC, waiting for the sequence's type.
+	stateEscape
+	// stateCSI is inside a Control Sequence Introducer, like the colors and the cursor movements.
+	stateCSI
+	// stateString is inside an Operating System Command, like the terminal's title, or another string sequence.
+	stateString
+	// stateStringEscape is after an ESC inside a string sequence, which may be its terminator.
+	stateStringEscape
+)
+
+// Transcript converts the output of a terminal into plain text as it is written, keeping the text, like the prompts and
+// the commands' output, and dropping the escape sequences. The carriage returns, the backspaces and the erases of the
+// line are applied, so the lines edited by the shell are written as they were shown.
+//
+// As the output is converted while written, the escape sequences and the characters may be split across the writes.
+// [Transcript.Close] must be called to write the last line.
+type Transcript struct {
+	writer *bufio.Writer
+	state  transcriptState
+	// params are the parameters of the CSI being parsed.
+	params []byte
+	// pending are the bytes of a character split across the writes.
+	pending []byte
+	// line is the line being written, and column is the cursor's position on it.
+	line   []rune
+	column int
+}
+
+// NewTranscript creates a [Transcript] writing the plain text to w.
+func NewTranscript(w io.Writer) *Transcript {
+	return &Transcript{writer: bufio.NewWriter(w)}
+}
+
+// Write converts the terminal's output in p, writing the lines completed.
+func (t *Transcript) Write(p []byte) (int, error) {
+	data := p
+	if len(t.pending) > 0 {
+		data = append(t.pending, p...)
+		t.pending = nil
+	}
+
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size == 1 && !utf8.FullRune(data) {
+			t.pending = append([]byte{}, data...)
+
+			break
+		}
+
+		data = data[size:]
+
+		if err := t.feed(r); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close writes the last line, when not empty, and flushes the text written.
+func (t *Transcript) Close() error {
+	if len(t.line) > 0 {
+		if err := t.flush(); err != nil {
+			return err
+		}
+	}
+
+	return t.writer.Flush()
+}
+
+func (t *Transcript) feed(r rune) error {
+	switch t.state {
+	case stateEscape:
+		switch r {
+		case '[':
+			t.state, t.params = stateCSI, t.params[:0]
+		case ']', 'P', '_', '^', 'X':
+			t.state = stateString
+		default:
+			// NOTICE: the other sequences, like the charset selection, have a single character after the ESC.
+			t.state = stateText
+		}
+	case stateCSI:
+		if r >= 0x40 && r <= 0x7e {
+			t.state = stateText
+			t.csi(r)
+		} else {
+			t.params = append(t.params, byte(r))
+		}
+	case stateString:
+		switch r {
+		case '\a':
+			t.state = stateText
+		case '\x1b':
+			t.state = stateStringEscape
+		}
+	case stateStringEscape:
+		t.state = stateString
+		if r == '\\' {
+			t.state = stateText
+		}
+	default:
+		return t.text(r)
+	}
+
+	return nil
+}
+
+func (t *Transcript) text(r rune) error {
+	switch r {
+	case '\x1b':
+		t.state = stateEscape
+	case '\n':
+		return t.flush()
+	case '\r':
+		t.column = 0
+	case '\b':
+		if t.column > 0 {
+			t.column--
+		}
+	case '\t':
+		t.put(' ')
+		for t.column%8 != 0 {
+			t.put(' ')
+		}
+	default:
+		if r >= ' ' && r != 0x7f {
+			t.put(r)
+		}
+	}
+
+	return nil
+}
+
+// csi applies the cursor movements and the erases of the line, ignoring the other sequences.
+func (t *Transcript) csi(final rune) {
+	count, err := strconv.Atoi(string(t.params))
+	if err != nil || count < 1 {
+		count = 1
+	}
+
+	switch final {
+	case 'C':
+		for i := 0; i < count; i++ {
+			if t.column < len(t.line) {
+				t.column++
+			} else {
+				t.put(' ')
+			}
+		}
+	case 'D':
+		t.column -= count
+		if t.column < 0 {
+			t.column = 0
+		}
+	case 'K':
+		// NOTICE: only the erase from the cursor to the line's end, the one used by the shells to redraw the line, is
+		// applied.
+		if string(t.params) == "" || string(t.params) == "0" {
+			t.line = t.line[:t.column]
+		}
+	case 'P':
+		if t.column < len(t.line) {
+			end := t.column + count
+			if end > len(t.line) {
+				end = len(t.line)
+			}
+
+			t.line = append(t.line[:t.column], t.line[end:]...)
+		}
+	}
+}
+
+func (t *Transcript) put(r rune) {
+	if t.column < len(t.line) {
+		t.line[t.column] = r
+	} else {
+		t.line = append(t.line, r)
+	}
+
+	t.column++
+}
+
+func (t *Transcript) flush() error {
+	_, err := t.writer.WriteString(strings.TrimRight(string(t.line), " ") + "\n")
+	t.line, t.column = t.line[:0], 0
+
+	return err
+}
+
+// WriteTranscript writes the frames' output to w as a plain text transcript.
+func WriteTranscript(w io.Writer, frames []models.SessionRecorded) error {
+	transcript := NewTranscript(w)
+	for _, frame := range frames {
+		if _, err := io.WriteString(transcript, frame.Message); err != nil {
+			return err
+		}
+	}
+
+	return transcript.Close()
+}
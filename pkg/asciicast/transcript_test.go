@@ -0,0 +1,62 @@
+package asciicast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTranscript(t *testing.T) {
+	cases := []struct {
+		description string
+		frames      []string
+		expected    string
+	}{
+		{
+			description: "keeps the prompts and the output",
+			frames:      []string{"root@device:~# ", "ls\r\n", "file.txt\r\nroot@device:~# "},
+			expected:    "root@device:~# ls\nfile.txt\nroot@device:~#\n",
+		},
+		{
+			description: "strips the colors",
+			frames:      []string{"\x1b[01;32mroot@device\x1b[00m:\x1b[01;34m~\x1b[00m# whoami\r\nroot\r\n"},
+			expected:    "root@device:~# whoami\nroot\n",
+		},
+		{
+			description: "strips the terminal's title",
+			frames:      []string{"\x1b]0;root@device: ~\aroot@device:~# \x1b]2;title\x1b\\exit\r\n"},
+			expected:    "root@device:~# exit\n",
+		},
+		{
+			description: "applies the backspaces and the erases of the line",
+			frames:      []string{"# lx\b\x1b[Ks -la\r\n", "# typo\r# fixed\x1b[K\r\n"},
+			expected:    "# ls -la\n# fixed\n",
+		},
+		{
+			description: "joins the escape sequences and characters split across the frames",
+			frames:      []string{"caf\xc3", "\xa9 \x1b[3", "1mred\x1b", "[0m\r\n"},
+			expected:    "café red\n",
+		},
+		{
+			description: "expands the tabs",
+			frames:      []string{"a\tb\r\n"},
+			expected:    "a       b\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			frames := make([]models.SessionRecorded, len(tc.frames))
+			for i, message := range tc.frames {
+				frames[i] = models.SessionRecorded{Message: message}
+			}
+
+			builder := new(strings.Builder)
+			require.NoError(t, WriteTranscript(builder, frames))
+			assert.Equal(t, tc.expected, builder.String())
+		})
+	}
+}
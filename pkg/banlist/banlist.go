@@ -0,0 +1,141 @@
+// Package banlist enforces the instance-wide list of banned IP addresses and ranges.
+//
+// The list is kept by the API, which publishes a snapshot of it to the cache on every change. Every replica of the API
+// and of the SSH server reads the snapshot from there, refreshing its local copy periodically, so a ban reaches all of
+// them without a request to the API per connection.
+package banlist
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// CacheKey is the cache's key where the list's snapshot is published.
+const CacheKey = "banned-addresses"
+
+// DefaultRefreshInterval is how long a local copy of the list is used before being read again from the cache.
+const DefaultRefreshInterval = 10 * time.Second
+
+// ErrAddressInvalid is returned when an address is neither an IP address nor a range in CIDR notation.
+var ErrAddressInvalid = errors.New("invalid IP address or CIDR")
+
+// Parse parses address, an IP address or a range in CIDR notation, into a range. A single IP address becomes a range
+// with its full length, and the host bits of a range are zeroed, so equivalent addresses have the same form.
+func Parse(address string) (netip.Prefix, error) {
+	if strings.Contains(address, "/") {
+		prefix, err := netip.ParsePrefix(address)
+		if err != nil {
+			return netip.Prefix{}, ErrAddressInvalid
+		}
+
+		if prefix.Addr().Is4In6() {
+			bits := prefix.Bits() - 96
+			if bits < 0 {
+				return netip.Prefix{}, ErrAddressInvalid
+			}
+
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), bits)
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(address)
+	if err != nil || addr.Zone() != "" {
+		return netip.Prefix{}, ErrAddressInvalid
+	}
+
+	addr = addr.Unmap()
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Publish publishes bans as the list's snapshot, replacing the previous one.
+func Publish(ctx context.Context, c cache.Cache, bans []models.BannedAddress) error {
+	return c.Set(ctx, CacheKey, bans, 0)
+}
+
+type ban struct {
+	prefix netip.Prefix
+	banned models.BannedAddress
+}
+
+// Banlist is a local copy of the list's snapshot published to the cache.
+type Banlist struct {
+	cache    cache.Cache
+	interval time.Duration
+
+	mu       sync.Mutex
+	bans     []ban
+	loadedAt time.Time
+}
+
+// New creates a [Banlist] reading the snapshot from c, at most once per interval.
+func New(c cache.Cache, interval time.Duration) *Banlist {
+	return &Banlist{cache: c, interval: interval}
+}
+
+// load reads the snapshot from the cache when the local copy is older than the interval. When it fails, the local copy
+// is kept until the next interval.
+func (b *Banlist) load(ctx context.Context) []ban {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := clock.Now()
+	if !b.loadedAt.IsZero() && now.Sub(b.loadedAt) < b.interval {
+		return b.bans
+	}
+
+	b.loadedAt = now
+
+	var snapshot []models.BannedAddress
+	if err := b.cache.Get(ctx, CacheKey, &snapshot); err != nil {
+		log.WithError(err).Warn("failed to load the banned addresses")
+
+		return b.bans
+	}
+
+	bans := make([]ban, 0, len(snapshot))
+	for _, banned := range snapshot {
+		prefix, err := Parse(banned.Address)
+		if err != nil {
+			log.WithError(err).WithField("address", banned.Address).Warn("ignoring an invalid banned address")
+
+			continue
+		}
+
+		bans = append(bans, ban{prefix: prefix, banned: banned})
+	}
+
+	b.bans = bans
+
+	return b.bans
+}
+
+// Banned reports whether address, an IP address, is on the list. Invalid addresses are never banned.
+func (b *Banlist) Banned(ctx context.Context, address string) bool {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap().WithZone("")
+
+	now := clock.Now()
+	for _, ban := range b.load(ctx) {
+		if !ban.banned.Expired(now) && ban.prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,197 @@
+package banlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cachemock "github.com/shellhub-io/shellhub/pkg/cache/mocks"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParse(t *testing.T) {
+	type Expected struct {
+		prefix string
+		err    error
+	}
+
+	cases := []struct {
+		description string
+		address     string
+		expected    Expected
+	}{
+		{
+			description: "fails when the address is invalid",
+			address:     "192.0.2",
+			expected:    Expected{"invalid Prefix", ErrAddressInvalid},
+		},
+		{
+			description: "fails when the range is invalid",
+			address:     "192.0.2.0/33",
+			expected:    Expected{"invalid Prefix", ErrAddressInvalid},
+		},
+		{
+			description: "succeeds with an IPv4 address",
+			address:     "192.0.2.1",
+			expected:    Expected{"192.0.2.1/32", nil},
+		},
+		{
+			description: "succeeds with an IPv6 address",
+			address:     "2001:db8::1",
+			expected:    Expected{"2001:db8::1/128", nil},
+		},
+		{
+			description: "succeeds with an IPv4-mapped IPv6 address",
+			address:     "::ffff:192.0.2.1",
+			expected:    Expected{"192.0.2.1/32", nil},
+		},
+		{
+			description: "succeeds zeroing the host bits of a range",
+			address:     "192.0.2.10/24",
+			expected:    Expected{"192.0.2.0/24", nil},
+		},
+		{
+			description: "succeeds with an IPv4-mapped IPv6 range",
+			address:     "::ffff:192.0.2.0/120",
+			expected:    Expected{"192.0.2.0/24", nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			prefix, err := Parse(tc.address)
+			assert.Equal(t, tc.expected, Expected{prefix.String(), err})
+		})
+	}
+}
+
+func TestBanned(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Minute)
+	active := now.Add(time.Hour)
+
+	clockMock := new(clockmock.Clock)
+	clockMock.On("Now").Return(now)
+
+	backend := clock.DefaultBackend
+	clock.DefaultBackend = clockMock
+	t.Cleanup(func() { clock.DefaultBackend = backend })
+
+	snapshot := []models.BannedAddress{
+		{Address: "192.0.2.0/24"},
+		{Address: "198.51.100.7/32", ExpiresAt: &active},
+		{Address: "203.0.113.9/32", ExpiresAt: &expired},
+		{Address: "2001:db8::/32"},
+		{Address: "invalid"},
+	}
+
+	cache := new(cachemock.Cache)
+	cache.
+		On("Get", ctx, CacheKey, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]models.BannedAddress) = snapshot
+		}).
+		Return(nil).
+		Once()
+
+	list := New(cache, DefaultRefreshInterval)
+
+	cases := []struct {
+		description string
+		address     string
+		expected    bool
+	}{
+		{
+			description: "ignores invalid addresses",
+			address:     "invalid",
+			expected:    false,
+		},
+		{
+			description: "allows addresses out of the list",
+			address:     "192.0.3.1",
+			expected:    false,
+		},
+		{
+			description: "refuses addresses in a banned range",
+			address:     "192.0.2.200",
+			expected:    true,
+		},
+		{
+			description: "refuses IPv4-mapped IPv6 addresses in a banned range",
+			address:     "::ffff:192.0.2.200",
+			expected:    true,
+		},
+		{
+			description: "refuses addresses temporarily banned",
+			address:     "198.51.100.7",
+			expected:    true,
+		},
+		{
+			description: "allows addresses whose ban expired",
+			address:     "203.0.113.9",
+			expected:    false,
+		},
+		{
+			description: "refuses IPv6 addresses in a banned range",
+			address:     "2001:db8::1",
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, list.Banned(ctx, tc.address))
+		})
+	}
+
+	cache.AssertExpectations(t)
+}
+
+func TestBannedRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	clockMock := new(clockmock.Clock)
+
+	backend := clock.DefaultBackend
+	clock.DefaultBackend = clockMock
+	t.Cleanup(func() { clock.DefaultBackend = backend })
+
+	cache := new(cachemock.Cache)
+	list := New(cache, DefaultRefreshInterval)
+
+	clockMock.On("Now").Return(now).Times(2)
+	cache.
+		On("Get", ctx, CacheKey, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]models.BannedAddress) = []models.BannedAddress{{Address: "192.0.2.1/32"}}
+		}).
+		Return(nil).
+		Once()
+	assert.True(t, list.Banned(ctx, "192.0.2.1"))
+
+	// NOTICE: a failure to read the snapshot keeps the previous one.
+	clockMock.On("Now").Return(now.Add(DefaultRefreshInterval)).Times(2)
+	cache.On("Get", ctx, CacheKey, mock.Anything).Return(errors.New("error")).Once()
+	assert.True(t, list.Banned(ctx, "192.0.2.1"))
+
+	clockMock.On("Now").Return(now.Add(2 * DefaultRefreshInterval)).Times(2)
+	cache.
+		On("Get", ctx, CacheKey, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*[]models.BannedAddress) = []models.BannedAddress{}
+		}).
+		Return(nil).
+		Once()
+	assert.False(t, list.Banned(ctx, "192.0.2.1"))
+
+	cache.AssertExpectations(t)
+}
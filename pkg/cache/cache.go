@@ -26,6 +26,17 @@ type Cache interface {
 	Get(ctx context.Context, key string, value interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// GetDelete gets the value of the key and deletes it atomically, so only one of the concurrent callers gets it. A
+	// missing key leaves the value untouched.
+	GetDelete(ctx context.Context, key string, value interface{}) error
+	// Increment increments the key's counter, created with zero when missing, setting its TTL on the same transaction.
+	// It returns the counter's new value.
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// Decrement decrements the key's counter atomically, deleting it when it reaches zero. A missing key, like an
+	// expired one, isn't decremented. It returns the counter's new value.
+	Decrement(ctx context.Context, key string) (int64, error)
+	// Expire sets the key's TTL. A missing key is ignored.
+	Expire(ctx context.Context, key string, ttl time.Duration) error
 
 	// HasAccountLockout reports whether the source is currently blocked from attempting to
 	// log in to a user with the specified userID. It returns the absolute Unix timestamp
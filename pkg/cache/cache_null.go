@@ -25,6 +25,22 @@ func (*nullCache) Delete(_ context.Context, _ string) error {
 	return nil
 }
 
+func (*nullCache) GetDelete(_ context.Context, _ string, _ interface{}) error {
+	return nil
+}
+
+func (*nullCache) Increment(_ context.Context, _ string, _ time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func (*nullCache) Decrement(_ context.Context, _ string) (int64, error) {
+	return 0, nil
+}
+
+func (*nullCache) Expire(_ context.Context, _ string, _ time.Duration) error {
+	return nil
+}
+
 func (*nullCache) HasAccountLockout(_ context.Context, _, _ string) (int64, int, error) {
 	return 0, 0, nil
 }
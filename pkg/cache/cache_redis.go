@@ -14,8 +14,9 @@ import (
 )
 
 type redisCache struct {
-	cache *rediscache.Cache
-	cfg   *config
+	cache  *rediscache.Cache
+	client *redis.Client
+	cfg    *config
 }
 
 var _ Cache = &redisCache{}
@@ -35,10 +36,13 @@ func NewRedisCache(uri string, pool int) (Cache, error) {
 		log.WithError(err).Fatal("Failed to load environment variables")
 	}
 
+	client := redis.NewClient(opt)
+
 	return &redisCache{
-		cfg: cfg,
+		cfg:    cfg,
+		client: client,
 		cache: rediscache.New(&rediscache.Options{
-			Redis: redis.NewClient(opt),
+			Redis: client,
 		}),
 	}, nil
 }
@@ -68,6 +72,60 @@ func (c *redisCache) Delete(ctx context.Context, key string) error {
 	return c.cache.Delete(ctx, key)
 }
 
+// GetDelete gets the cache value for the given key, deleting it on the same GETDEL command.
+// NOTE: missing key is not an error.
+func (c *redisCache) GetDelete(ctx context.Context, key string, value interface{}) error {
+	data, err := c.client.GetDel(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return c.cache.Unmarshal(data, value)
+}
+
+// Increment increments the key's counter with INCR, setting its TTL on the same transaction.
+func (c *redisCache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	if _, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+// decrementScript decrements the key's counter, only when it exists, deleting it when it reaches zero.
+var decrementScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+
+local count = redis.call("DECR", KEYS[1])
+if count <= 0 then
+	redis.call("DEL", KEYS[1])
+end
+
+return count
+`)
+
+// Decrement decrements the key's counter with DECR, on a script, so it is checked and deleted atomically.
+func (c *redisCache) Decrement(ctx context.Context, key string) (int64, error) {
+	return decrementScript.Run(ctx, c.client, []string{key}).Int64()
+}
+
+// Expire sets the key's TTL.
+func (c *redisCache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.client.Expire(ctx, key, ttl).Err()
+}
+
 func (c *redisCache) HasAccountLockout(ctx context.Context, source, id string) (int64, int, error) {
 	if c.cfg.MaximumAccountLockout <= 0 {
 		return 0, 0, nil
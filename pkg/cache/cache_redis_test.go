@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+func newTestRedisCache(t *testing.T) Cache {
+	t.Helper()
+
+	ctx := context.Background()
+
+	image := "docker.io/redis:7"
+	if envs.DefaultBackend.Get("CI") == "true" {
+		image = "registry.infra.ossystems.io/cache/redis:7"
+	}
+
+	redisContainer, err := redis.Run(ctx, image)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, redisContainer.Terminate(ctx))
+	})
+
+	uri, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	cache, err := NewRedisCache(uri, 0)
+	require.NoError(t, err)
+
+	return cache
+}
+
+func TestRedisCacheGetDelete(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cache := newTestRedisCache(t)
+
+	require.NoError(t, cache.Set(ctx, "key", "value", time.Minute))
+
+	var got atomic.Int32
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var value string
+			assert.NoError(t, cache.GetDelete(ctx, "key", &value))
+
+			if value == "value" {
+				got.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), got.Load())
+
+	var value string
+	require.NoError(t, cache.Get(ctx, "key", &value))
+	assert.Equal(t, "", value)
+}
+
+func TestRedisCacheIncrementDecrement(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cache := newTestRedisCache(t)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := cache.Increment(ctx, "counter", time.Minute)
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	count, err := cache.Increment(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), count)
+
+	for range 11 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := cache.Decrement(ctx, "counter")
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	// NOTICE: the counter is deleted when it reaches zero, so it isn't decremented below it.
+	count, err = cache.Decrement(ctx, "counter")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	count, err = cache.Increment(ctx, "counter", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, cache.Expire(ctx, "counter", time.Millisecond))
+	time.Sleep(10 * time.Millisecond)
+
+	count, err = cache.Decrement(ctx, "counter")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
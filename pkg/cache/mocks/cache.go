@@ -14,6 +14,34 @@ type Cache struct {
 	mock.Mock
 }
 
+// Decrement provides a mock function with given fields: ctx, key
+func (_m *Cache) Decrement(ctx context.Context, key string) (int64, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrement")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, key
 func (_m *Cache) Delete(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -32,6 +60,24 @@ func (_m *Cache) Delete(ctx context.Context, key string) error {
 	return r0
 }
 
+// Expire provides a mock function with given fields: ctx, key, ttl
+func (_m *Cache) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Expire")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, key, value
 func (_m *Cache) Get(ctx context.Context, key string, value interface{}) error {
 	ret := _m.Called(ctx, key, value)
@@ -50,6 +96,24 @@ func (_m *Cache) Get(ctx context.Context, key string, value interface{}) error {
 	return r0
 }
 
+// GetDelete provides a mock function with given fields: ctx, key, value
+func (_m *Cache) GetDelete(ctx context.Context, key string, value interface{}) error {
+	ret := _m.Called(ctx, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // HasAccountLockout provides a mock function with given fields: ctx, source, userID
 func (_m *Cache) HasAccountLockout(ctx context.Context, source string, userID string) (int64, int, error) {
 	ret := _m.Called(ctx, source, userID)
@@ -85,6 +149,34 @@ func (_m *Cache) HasAccountLockout(ctx context.Context, source string, userID st
 	return r0, r1, r2
 }
 
+// Increment provides a mock function with given fields: ctx, key, ttl
+func (_m *Cache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResetLoginAttempts provides a mock function with given fields: ctx, source, userID
 func (_m *Cache) ResetLoginAttempts(ctx context.Context, source string, userID string) error {
 	ret := _m.Called(ctx, source, userID)
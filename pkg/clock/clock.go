@@ -4,6 +4,8 @@ import (
 	"time"
 )
 
+//go:generate mockery --name Clock --filename clock.go
+
 // Clock is an interface that can provide time related functionality which allows us to test time dependent code.
 type Clock interface {
 	Now() time.Time
@@ -0,0 +1,122 @@
+// Package commandpolicy evaluates the namespace's command policies against the command lines run on the sessions to
+// its devices, finding the policy a command line violates.
+package commandpolicy
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var (
+	ErrPatternsEmpty  = errors.New("policy has no patterns")
+	ErrPatternInvalid = errors.New("policy's pattern is not a valid regular expression")
+	ErrModeInvalid    = errors.New("policy's mode is invalid")
+	ErrActionInvalid  = errors.New("policy's action is invalid")
+)
+
+// severities orders the actions from the mildest to the most severe one.
+var severities = map[models.CommandPolicyAction]int{
+	models.CommandPolicyActionWarn:      1,
+	models.CommandPolicyActionBlock:     2,
+	models.CommandPolicyActionTerminate: 3,
+}
+
+// Validate checks whether the policy's mode, action and patterns can be evaluated.
+func Validate(mode models.CommandPolicyMode, action models.CommandPolicyAction, patterns []string) error {
+	if mode != models.CommandPolicyModeDeny && mode != models.CommandPolicyModeAllow {
+		return ErrModeInvalid
+	}
+
+	if _, ok := severities[action]; !ok {
+		return ErrActionInvalid
+	}
+
+	if len(patterns) == 0 {
+		return ErrPatternsEmpty
+	}
+
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return ErrPatternInvalid
+		}
+	}
+
+	return nil
+}
+
+type policy struct {
+	*models.CommandPolicy
+	patterns []*regexp.Regexp
+}
+
+// Evaluator evaluates the command lines against a set of policies, compiled once.
+type Evaluator struct {
+	policies []policy
+}
+
+// New creates an [Evaluator] for the policies. The patterns that aren't valid regular expressions are ignored, as the
+// policies are validated when they are saved.
+func New(policies []models.CommandPolicy) *Evaluator {
+	e := &Evaluator{policies: make([]policy, 0, len(policies))}
+	for i := range policies {
+		p := policy{CommandPolicy: &policies[i], patterns: make([]*regexp.Regexp, 0, len(policies[i].Patterns))}
+		for _, pattern := range policies[i].Patterns {
+			if re, err := regexp.Compile(pattern); err == nil {
+				p.patterns = append(p.patterns, re)
+			}
+		}
+
+		e.policies = append(e.policies, p)
+	}
+
+	return e
+}
+
+// Empty reports whether there is no policy to evaluate.
+func (e *Evaluator) Empty() bool {
+	return len(e.policies) == 0
+}
+
+// Evaluate returns the policy the command line violates, or nil when it violates none. When the command line violates
+// more than one policy, the one with the most severe action is returned, the first of them on a tie.
+//
+// The command line is matched without its leading and trailing spaces, and an empty command line violates no policy.
+func (e *Evaluator) Evaluate(command string) *models.CommandPolicy {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return nil
+	}
+
+	var violated *models.CommandPolicy
+	for _, p := range e.policies {
+		if !p.violated(command) {
+			continue
+		}
+
+		if violated == nil || severities[p.Action] > severities[violated.Action] {
+			violated = p.CommandPolicy
+		}
+	}
+
+	return violated
+}
+
+func (p *policy) violated(command string) bool {
+	matched := false
+	for _, re := range p.patterns {
+		if re.MatchString(command) {
+			matched = true
+
+			break
+		}
+	}
+
+	if p.Mode == models.CommandPolicyModeAllow {
+		return !matched
+	}
+
+	return matched
+}
@@ -0,0 +1,113 @@
+package commandpolicy
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		description string
+		mode        models.CommandPolicyMode
+		action      models.CommandPolicyAction
+		patterns    []string
+		expected    error
+	}{
+		{
+			description: "fails when the mode is invalid",
+			mode:        "audit",
+			action:      models.CommandPolicyActionBlock,
+			patterns:    []string{"^rm "},
+			expected:    ErrModeInvalid,
+		},
+		{
+			description: "fails when the action is invalid",
+			mode:        models.CommandPolicyModeDeny,
+			action:      "kill",
+			patterns:    []string{"^rm "},
+			expected:    ErrActionInvalid,
+		},
+		{
+			description: "fails when there are no patterns",
+			mode:        models.CommandPolicyModeDeny,
+			action:      models.CommandPolicyActionBlock,
+			patterns:    []string{},
+			expected:    ErrPatternsEmpty,
+		},
+		{
+			description: "fails when a pattern is invalid",
+			mode:        models.CommandPolicyModeDeny,
+			action:      models.CommandPolicyActionBlock,
+			patterns:    []string{"^rm ", "(reboot"},
+			expected:    ErrPatternInvalid,
+		},
+		{
+			description: "succeeds",
+			mode:        models.CommandPolicyModeAllow,
+			action:      models.CommandPolicyActionTerminate,
+			patterns:    []string{"^ls( |$)", "^cat "},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Validate(tc.mode, tc.action, tc.patterns))
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policies := []models.CommandPolicy{
+		{ID: "deny", Mode: models.CommandPolicyModeDeny, Action: models.CommandPolicyActionWarn, Patterns: []string{`^sudo\b`}},
+		{ID: "allow", Mode: models.CommandPolicyModeAllow, Action: models.CommandPolicyActionBlock, Patterns: []string{`^(ls|cat|sudo)\b`}},
+		{ID: "terminate", Mode: models.CommandPolicyModeDeny, Action: models.CommandPolicyActionTerminate, Patterns: []string{`rm\s+-rf\s+/`}},
+	}
+
+	cases := []struct {
+		description string
+		command     string
+		expected    string
+	}{
+		{
+			description: "violates no policy when the command line is empty",
+			command:     "   ",
+			expected:    "",
+		},
+		{
+			description: "violates no policy when the command line is allowed and not denied",
+			command:     "ls -la /etc",
+			expected:    "",
+		},
+		{
+			description: "violates the denylist matching the command line",
+			command:     "  sudo ls",
+			expected:    "deny",
+		},
+		{
+			description: "violates the allowlist not matching the command line",
+			command:     "reboot",
+			expected:    "allow",
+		},
+		{
+			description: "violates the policy with the most severe action",
+			command:     "sudo rm -rf /",
+			expected:    "terminate",
+		},
+	}
+
+	evaluator := New(policies)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var id string
+			if policy := evaluator.Evaluate(tc.command); policy != nil {
+				id = policy.ID
+			}
+
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Package correlation carries the request's correlation ID, and the tenant it belongs to, across ShellHub's services,
+// so a single request, or connection attempt, can be traced through the logs of the gateway, API, SSH and agent.
+package correlation
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// Header is the HTTP header with the correlation ID. The gateway sets it on every request, keeping the one sent by
+// the client when present.
+const Header = "X-Request-ID"
+
+// TenantHeader is the HTTP header with the tenant of the authenticated request, set by the gateway.
+const TenantHeader = "X-Tenant-ID"
+
+type contextKey string
+
+const (
+	// IDKey is the context's key to the correlation ID.
+	IDKey contextKey = "correlation_id"
+	// TenantKey is the context's key to the tenant of the request.
+	TenantKey contextKey = "correlation_tenant_id"
+)
+
+// NewID generates a new correlation ID.
+func NewID() string {
+	return uuid.Generate()
+}
+
+// WithID returns a copy of ctx carrying the correlation ID.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, IDKey, id)
+}
+
+// IDFromContext returns the correlation ID carried by ctx, or an empty string when there is none.
+func IDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(IDKey).(string)
+
+	return id
+}
+
+// WithTenant returns a copy of ctx carrying the tenant of the request.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, TenantKey, tenant)
+}
+
+// TenantFromContext returns the tenant carried by ctx, or an empty string when there is none.
+func TenantFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	tenant, _ := ctx.Value(TenantKey).(string)
+
+	return tenant
+}
@@ -0,0 +1,106 @@
+package correlation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	cases := []struct {
+		description string
+		headers     map[string]string
+		expected    func(t *testing.T, id, tenant string, rec *httptest.ResponseRecorder)
+	}{
+		{
+			description: "keeps the correlation ID sent on the request",
+			headers:     map[string]string{Header: "id", TenantHeader: "00000000-0000-4000-0000-000000000000"},
+			expected: func(t *testing.T, id, tenant string, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, "id", id)
+				assert.Equal(t, "00000000-0000-4000-0000-000000000000", tenant)
+				assert.Equal(t, "id", rec.Header().Get(Header))
+			},
+		},
+		{
+			description: "generates a correlation ID when absent",
+			headers:     map[string]string{},
+			expected: func(t *testing.T, id, tenant string, rec *httptest.ResponseRecorder) {
+				assert.NotEmpty(t, id)
+				assert.Empty(t, tenant)
+				assert.Equal(t, id, rec.Header().Get(Header))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var id, tenant string
+
+			e := echo.New()
+			e.Use(Middleware)
+			e.GET("/", func(c echo.Context) error {
+				id = IDFromContext(c.Request().Context())
+				tenant = TenantFromContext(c.Request().Context())
+
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			tc.expected(t, id, tenant, rec)
+		})
+	}
+}
+
+func TestHook(t *testing.T) {
+	cases := []struct {
+		description string
+		ctx         context.Context
+		expected    map[string]interface{}
+	}{
+		{
+			description: "adds the correlation ID and the tenant from the context",
+			ctx:         WithTenant(WithID(context.Background(), "id"), "tenant"),
+			expected:    map[string]interface{}{"request_id": "id", "tenant_id": "tenant"},
+		},
+		{
+			description: "adds nothing when the context has no correlation data",
+			ctx:         context.Background(),
+			expected:    map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+
+			logger := logrus.New()
+			logger.SetOutput(buffer)
+			logger.SetFormatter(&logrus.JSONFormatter{})
+			logger.AddHook(NewHook())
+
+			logger.WithContext(tc.ctx).Info("message")
+
+			fields := make(map[string]interface{})
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &fields))
+
+			for _, key := range []string{"request_id", "tenant_id"} {
+				assert.Equal(t, tc.expected[key], fields[key])
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package correlation
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is a [logrus.Hook] that adds the correlation ID and the tenant carried by the entry's context, set through
+// [logrus.WithContext], to the entry's fields.
+type Hook struct{}
+
+var _ logrus.Hook = (*Hook)(nil)
+
+// NewHook creates a new [Hook].
+func NewHook() *Hook {
+	return &Hook{}
+}
+
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	if id := IDFromContext(entry.Context); id != "" {
+		entry.Data["request_id"] = id
+	}
+
+	if tenant := TenantFromContext(entry.Context); tenant != "" {
+		entry.Data["tenant_id"] = tenant
+	}
+
+	return nil
+}
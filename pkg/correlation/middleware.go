@@ -0,0 +1,29 @@
+package correlation
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware is an echo middleware that stores the request's correlation ID, and its tenant, on the request's context.
+// The correlation ID is read from [Header], being generated when absent, and sent back on the response.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+
+		id := req.Header.Get(Header)
+		if id == "" {
+			id = NewID()
+			req.Header.Set(Header, id)
+		}
+
+		ctx := WithID(req.Context(), id)
+		if tenant := req.Header.Get(TenantHeader); tenant != "" {
+			ctx = WithTenant(ctx, tenant)
+		}
+
+		c.SetRequest(req.WithContext(ctx))
+		c.Response().Header().Set(Header, id)
+
+		return next(c)
+	}
+}
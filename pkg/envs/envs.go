@@ -8,6 +8,8 @@ const (
 	ENABLED = "true"
 )
 
+//go:generate mockery --name Backend --filename envs.go
+
 // Backend is an interface for any sort of underlying key/value store.
 type Backend interface {
 	Get(key string) string
@@ -47,6 +49,12 @@ func HasBilling() bool {
 	return DefaultBackend.Get("SHELLHUB_BILLING") == ENABLED
 }
 
+// HasEmailVerification returns true if the current ShellHub server instance requires local users to verify their
+// email address before logging in.
+func HasEmailVerification() bool {
+	return DefaultBackend.Get("SHELLHUB_EMAIL_VERIFICATION") == ENABLED
+}
+
 var ErrParseWithPrefix = errors.New("failed to parse environment variables for the given prefix")
 
 // ParseWithPrefix parses the environment variables for the a given prefix.
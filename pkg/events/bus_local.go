@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+type localBus struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+var _ Bus = &localBus{}
+
+// NewLocalBus creates a [Bus] that delivers the messages only to the subscribers in the same process.
+func NewLocalBus() Bus {
+	return &localBus{subscribers: make(map[string]map[chan []byte]struct{})}
+}
+
+func (b *localBus) Publish(_ context.Context, topic string, data []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for subscriber := range b.subscribers[topic] {
+		select {
+		case subscriber <- data:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (b *localBus) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	subscriber := make(chan []byte, SubscriptionBufferSize)
+
+	b.mu.Lock()
+	if _, ok := b.subscribers[topic]; !ok {
+		b.subscribers[topic] = make(map[chan []byte]struct{})
+	}
+
+	b.subscribers[topic][subscriber] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers[topic], subscriber)
+		if len(b.subscribers[topic]) == 0 {
+			delete(b.subscribers, topic)
+		}
+		b.mu.Unlock()
+
+		close(subscriber)
+	}()
+
+	return subscriber, nil
+}
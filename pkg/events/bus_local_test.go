@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBus(t *testing.T) {
+	t.Run("delivers the message to the topic's subscribers", func(t *testing.T) {
+		bus := NewLocalBus()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		first, err := bus.Subscribe(ctx, "topic")
+		require.NoError(t, err)
+
+		second, err := bus.Subscribe(ctx, "topic")
+		require.NoError(t, err)
+
+		other, err := bus.Subscribe(ctx, "other")
+		require.NoError(t, err)
+
+		require.NoError(t, bus.Publish(ctx, "topic", []byte("message")))
+
+		assert.Equal(t, []byte("message"), <-first)
+		assert.Equal(t, []byte("message"), <-second)
+		assert.Empty(t, other)
+	})
+
+	t.Run("drops the messages when the subscriber buffer is full", func(t *testing.T) {
+		bus := NewLocalBus()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		subscriber, err := bus.Subscribe(ctx, "topic")
+		require.NoError(t, err)
+
+		for i := 0; i < SubscriptionBufferSize+10; i++ {
+			require.NoError(t, bus.Publish(ctx, "topic", []byte("message")))
+		}
+
+		assert.Len(t, subscriber, SubscriptionBufferSize)
+	})
+
+	t.Run("closes the channel when the context is done", func(t *testing.T) {
+		bus := NewLocalBus()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		subscriber, err := bus.Subscribe(ctx, "topic")
+		require.NoError(t, err)
+
+		cancel()
+
+		select {
+		case _, ok := <-subscriber:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("subscription was not closed")
+		}
+
+		assert.NoError(t, bus.Publish(context.Background(), "topic", []byte("message")))
+	})
+}
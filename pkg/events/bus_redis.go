@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type redisBus struct {
+	client *redis.Client
+}
+
+var _ Bus = &redisBus{}
+
+// NewRedisBus creates a [Bus] backed by Redis' Pub/Sub, delivering the messages to the subscribers of every process
+// connected to the same Redis server.
+func NewRedisBus(uri string) (Bus, error) {
+	opt, err := redis.ParseURL(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisBus{client: redis.NewClient(opt)}, nil
+}
+
+func (b *redisBus) Publish(ctx context.Context, topic string, data []byte) error {
+	return b.client.Publish(ctx, topic, data).Err()
+}
+
+func (b *redisBus) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	pubsub := b.client.Subscribe(ctx, topic)
+
+	// NOTICE: waits for the subscription confirmation, so messages published after Subscribe returns are received.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+
+		return nil, err
+	}
+
+	subscriber := make(chan []byte, SubscriptionBufferSize)
+
+	go func() {
+		defer close(subscriber)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				select {
+				case subscriber <- []byte(message.Payload):
+				default:
+				}
+			}
+		}
+	}()
+
+	return subscriber, nil
+}
@@ -0,0 +1,18 @@
+// Package events provides a minimal publish and subscribe bus used to broadcast state changes, like device status
+// updates, between ShellHub's service instances.
+package events
+
+import "context"
+
+// Bus delivers the messages published on a topic to every subscriber of that topic. The delivery is at most once:
+// messages published while nobody is subscribed, or that a slow subscriber cannot keep up with, are dropped.
+type Bus interface {
+	// Publish sends data to the current subscribers of topic.
+	Publish(ctx context.Context, topic string, data []byte) error
+	// Subscribe returns a channel that receives the messages published on topic until ctx is done, when the channel
+	// is closed.
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
+
+// SubscriptionBufferSize is the number of messages kept for each subscriber before newer messages are dropped.
+const SubscriptionBufferSize = 100
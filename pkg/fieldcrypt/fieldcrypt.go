@@ -0,0 +1,225 @@
+// Package fieldcrypt encrypts single fields of the stored documents, like the users' emails and the devices' remote
+// addresses, so the personal data isn't readable on the database, its backups or its replicas.
+//
+// The fields are encrypted with AES-256-GCM, either randomly, when the field is only read, or deterministically, when
+// the field is matched by equality on the queries. A deterministic encryption always encrypts the same value, under the
+// same key, to the same ciphertext: it reveals which fields are equal, but lets the database match them.
+//
+// An encrypted field is stored as "enc:<mode>:<key's ID>:<nonce and ciphertext>", so the fields stored before the
+// encryption was enabled, or encrypted with a previous key, are still read until they are rekeyed.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// KeySize is the size, in bytes, of the encryption keys.
+const KeySize = 32
+
+const (
+	// prefix starts every encrypted field, telling it apart from the fields stored in plain text.
+	prefix = "enc:"
+
+	modeRandom        = "r"
+	modeDeterministic = "d"
+)
+
+var (
+	ErrKeySize    = errors.New("the field encryption key must have 32 bytes")
+	ErrKeyUnknown = errors.New("the field was encrypted with an unknown key")
+	ErrMalformed  = errors.New("the encrypted field is malformed")
+)
+
+type key struct {
+	// id identifies the key on the fields it encrypted, without revealing it.
+	id   string
+	aead cipher.AEAD
+	// nonce is the key deriving the nonces of the deterministic encryption from the plain text.
+	nonce []byte
+}
+
+func newKey(raw []byte) (*key, error) {
+	if len(raw) != KeySize {
+		return nil, ErrKeySize
+	}
+
+	block, err := aes.NewCipher(derive(raw, "encryption"))
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(raw)
+
+	return &key{id: hex.EncodeToString(sum[:4]), aead: aead, nonce: derive(raw, "nonce")}, nil
+}
+
+// derive derives, from the raw key, a key used only for purpose.
+func derive(raw []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, raw)
+	mac.Write([]byte("shellhub field " + purpose))
+
+	return mac.Sum(nil)
+}
+
+func (k *key) seal(plaintext, mode string) (string, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if mode == modeDeterministic {
+		mac := hmac.New(sha256.New, k.nonce)
+		mac.Write([]byte(plaintext))
+		copy(nonce, mac.Sum(nil))
+	} else if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	header := prefix + mode + ":" + k.id + ":"
+
+	// NOTICE: the header is authenticated with the ciphertext, so a field can't be moved to another mode.
+	return header + base64.RawURLEncoding.EncodeToString(k.aead.Seal(nonce, nonce, []byte(plaintext), []byte(header))), nil
+}
+
+// Cipher encrypts the fields with its current key, and decrypts them with either the current key or one of its
+// previous keys. A nil Cipher keeps the fields in plain text.
+type Cipher struct {
+	current *key
+	keys    map[string]*key
+}
+
+// New creates a [Cipher] encrypting the fields with current, and still decrypting the ones encrypted with the
+// previous keys.
+func New(current []byte, previous ...[]byte) (*Cipher, error) {
+	k, err := newKey(current)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cipher{current: k, keys: map[string]*key{k.id: k}}
+	for _, raw := range previous {
+		k, err := newKey(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := c.keys[k.id]; !ok {
+			c.keys[k.id] = k
+		}
+	}
+
+	return c, nil
+}
+
+// Encrypt encrypts the field randomly. The empty fields are kept empty.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	return c.current.seal(plaintext, modeRandom)
+}
+
+// EncryptDeterministic encrypts the field deterministically, so it can be matched by equality. The empty fields are
+// kept empty.
+func (c *Cipher) EncryptDeterministic(plaintext string) (string, error) {
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	return c.current.seal(plaintext, modeDeterministic)
+}
+
+// Decrypt decrypts the field, with the key that encrypted it. The fields in plain text are returned as they are.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+
+	parts := strings.SplitN(value, ":", 4)
+	if len(parts) != 4 || (parts[1] != modeRandom && parts[1] != modeDeterministic) {
+		return "", ErrMalformed
+	}
+
+	if c == nil {
+		return "", ErrKeyUnknown
+	}
+
+	k, ok := c.keys[parts[2]]
+	if !ok {
+		return "", ErrKeyUnknown
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil || len(sealed) < k.aead.NonceSize() {
+		return "", ErrMalformed
+	}
+
+	header := value[:len(value)-len(parts[3])]
+
+	plaintext, err := k.aead.Open(nil, sealed[:k.aead.NonceSize()], sealed[k.aead.NonceSize():], []byte(header))
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	return string(plaintext), nil
+}
+
+// Equivalents returns the values that a deterministically encrypted field equal to plaintext may be stored as: the
+// plain text itself, as stored before the encryption was enabled, and its encryption with each key, as stored before
+// the fields are rekeyed. It is used to match the field by equality.
+func (c *Cipher) Equivalents(plaintext string) []string {
+	if c == nil || plaintext == "" {
+		return []string{plaintext}
+	}
+
+	equivalents := make([]string, 0, len(c.keys)+1)
+	equivalents = append(equivalents, plaintext)
+
+	for _, k := range c.keys {
+		// NOTICE: the deterministic encryption fails only if the nonce can't be read, which it doesn't.
+		if encrypted, err := k.seal(plaintext, modeDeterministic); err == nil {
+			equivalents = append(equivalents, encrypted)
+		}
+	}
+
+	return equivalents
+}
+
+// Rekey encrypts again, with the current key, a field stored in plain text or encrypted with a previous key or on
+// another mode. It reports whether the field changed.
+func (c *Cipher) Rekey(value string, deterministic bool) (string, bool, error) {
+	if c == nil || value == "" {
+		return value, false, nil
+	}
+
+	mode := modeRandom
+	if deterministic {
+		mode = modeDeterministic
+	}
+
+	if strings.HasPrefix(value, prefix+mode+":"+c.current.id+":") {
+		return value, false, nil
+	}
+
+	plaintext, err := c.Decrypt(value)
+	if err != nil {
+		return "", false, err
+	}
+
+	encrypted, err := c.current.seal(plaintext, mode)
+	if err != nil {
+		return "", false, err
+	}
+
+	return encrypted, true, nil
+}
@@ -0,0 +1,259 @@
+package fieldcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCipher(t *testing.T, current byte, previous ...byte) *Cipher {
+	keys := make([][]byte, 0, len(previous))
+	for _, b := range previous {
+		keys = append(keys, bytes.Repeat([]byte{b}, KeySize))
+	}
+
+	c, err := New(bytes.Repeat([]byte{current}, KeySize), keys...)
+	require.NoError(t, err)
+
+	return c
+}
+
+// tamper changes a character in the middle of the field's ciphertext.
+func tamper(value string) string {
+	i := len(value) - 10
+	if value[i] == 'A' {
+		return value[:i] + "B" + value[i+1:]
+	}
+
+	return value[:i] + "A" + value[i+1:]
+}
+
+func TestNew(t *testing.T) {
+	_, err := New([]byte("short"))
+	assert.Equal(t, ErrKeySize, err)
+
+	_, err = New(bytes.Repeat([]byte{1}, KeySize), []byte("short"))
+	assert.Equal(t, ErrKeySize, err)
+}
+
+func TestEncrypt(t *testing.T) {
+	c := newTestCipher(t, 1)
+
+	first, err := c.Encrypt("192.168.0.1")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first, "enc:r:"))
+
+	second, err := c.Encrypt("192.168.0.1")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	for _, encrypted := range []string{first, second} {
+		plaintext, err := c.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "192.168.0.1", plaintext)
+	}
+
+	empty, err := c.Encrypt("")
+	require.NoError(t, err)
+	assert.Equal(t, "", empty)
+}
+
+func TestEncryptDeterministic(t *testing.T) {
+	c := newTestCipher(t, 1)
+
+	first, err := c.EncryptDeterministic("john.doe@test.com")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first, "enc:d:"))
+
+	second, err := c.EncryptDeterministic("john.doe@test.com")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	other, err := c.EncryptDeterministic("jane.doe@test.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, other)
+
+	plaintext, err := c.Decrypt(first)
+	require.NoError(t, err)
+	assert.Equal(t, "john.doe@test.com", plaintext)
+}
+
+func TestDecrypt(t *testing.T) {
+	previous := newTestCipher(t, 1)
+	encrypted, err := previous.Encrypt("192.168.0.1")
+	require.NoError(t, err)
+
+	cases := []struct {
+		description string
+		cipher      *Cipher
+		value       string
+		expected    string
+		err         error
+	}{
+		{
+			description: "returns the plain text as it is",
+			cipher:      newTestCipher(t, 2),
+			value:       "192.168.0.1",
+			expected:    "192.168.0.1",
+		},
+		{
+			description: "returns the plain text as it is without a cipher",
+			cipher:      nil,
+			value:       "192.168.0.1",
+			expected:    "192.168.0.1",
+		},
+		{
+			description: "decrypts with a previous key",
+			cipher:      newTestCipher(t, 2, 1),
+			value:       encrypted,
+			expected:    "192.168.0.1",
+		},
+		{
+			description: "fails when the key is unknown",
+			cipher:      newTestCipher(t, 2),
+			value:       encrypted,
+			err:         ErrKeyUnknown,
+		},
+		{
+			description: "fails when the field is encrypted without a cipher",
+			cipher:      nil,
+			value:       encrypted,
+			err:         ErrKeyUnknown,
+		},
+		{
+			description: "fails when the mode was changed",
+			cipher:      previous,
+			value:       strings.Replace(encrypted, "enc:r:", "enc:d:", 1),
+			err:         ErrMalformed,
+		},
+		{
+			description: "fails when the ciphertext was changed",
+			cipher:      previous,
+			value:       tamper(encrypted),
+			err:         ErrMalformed,
+		},
+		{
+			description: "fails when the field is truncated",
+			cipher:      previous,
+			value:       "enc:r:",
+			err:         ErrMalformed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			plaintext, err := tc.cipher.Decrypt(tc.value)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, plaintext)
+		})
+	}
+}
+
+func TestEquivalents(t *testing.T) {
+	previous := newTestCipher(t, 1)
+	old, err := previous.EncryptDeterministic("john.doe@test.com")
+	require.NoError(t, err)
+
+	c := newTestCipher(t, 2, 1)
+	current, err := c.EncryptDeterministic("john.doe@test.com")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"john.doe@test.com", old, current}, c.Equivalents("john.doe@test.com"))
+
+	var disabled *Cipher
+	assert.Equal(t, []string{"john.doe@test.com"}, disabled.Equivalents("john.doe@test.com"))
+}
+
+func TestRekey(t *testing.T) {
+	previous := newTestCipher(t, 1)
+	old, err := previous.Encrypt("192.168.0.1")
+	require.NoError(t, err)
+
+	c := newTestCipher(t, 2, 1)
+	current, err := c.Encrypt("192.168.0.1")
+	require.NoError(t, err)
+
+	cases := []struct {
+		description   string
+		value         string
+		deterministic bool
+		changed       bool
+	}{
+		{
+			description: "encrypts the plain text",
+			value:       "192.168.0.1",
+			changed:     true,
+		},
+		{
+			description: "encrypts again the field encrypted with a previous key",
+			value:       old,
+			changed:     true,
+		},
+		{
+			description:   "encrypts again the field encrypted on another mode",
+			value:         current,
+			deterministic: true,
+			changed:       true,
+		},
+		{
+			description: "keeps the field encrypted with the current key",
+			value:       current,
+			changed:     false,
+		},
+		{
+			description: "keeps the empty field",
+			value:       "",
+			changed:     false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			value, changed, err := c.Rekey(tc.value, tc.deterministic)
+			require.NoError(t, err)
+			assert.Equal(t, tc.changed, changed)
+
+			if !changed {
+				assert.Equal(t, tc.value, value)
+
+				return
+			}
+
+			plaintext, err := newTestCipher(t, 2).Decrypt(value)
+			require.NoError(t, err)
+			assert.Equal(t, "192.168.0.1", plaintext)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, KeySize))
+	previous := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, KeySize))
+
+	file := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(file, []byte(key+"\n"), 0o600))
+
+	c, err := Load("", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	c, err = Load(key, "", previous)
+	require.NoError(t, err)
+	assert.Len(t, c.keys, 2)
+
+	c, err = Load("", file, "")
+	require.NoError(t, err)
+	assert.Equal(t, newTestCipher(t, 1).current.id, c.current.id)
+
+	_, err = Load("key", "", "")
+	assert.Equal(t, ErrKeyEncoding, err)
+
+	_, err = Load(key, "", "key")
+	assert.Equal(t, ErrKeyEncoding, err)
+}
@@ -0,0 +1,58 @@
+package fieldcrypt
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+)
+
+var ErrKeyEncoding = errors.New("the field encryption key must be base64 encoded")
+
+// Load creates the [Cipher] from the base64 encoded key or, when it is empty, from the file holding it, like the ones
+// written by the agents of the key management services. The previous keys are comma-separated and base64 encoded too.
+// It returns nil when neither the key nor the file is set, keeping the fields in plain text.
+func Load(encoded, file, previous string) (*Cipher, error) {
+	if encoded == "" && file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded = strings.TrimSpace(string(data))
+	}
+
+	if encoded == "" {
+		return nil, nil
+	}
+
+	current, err := decodeKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys [][]byte
+	for _, encoded := range strings.Split(previous, ",") {
+		if encoded = strings.TrimSpace(encoded); encoded == "" {
+			continue
+		}
+
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return New(current, keys...)
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrKeyEncoding
+	}
+
+	return key, nil
+}
@@ -0,0 +1,122 @@
+// Package geohash encodes coordinates as geohashes, the strings whose prefixes identify ever smaller cells of the
+// globe, used to cluster nearby positions by their common prefix.
+package geohash
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// MaxPrecision is the length of the geohashes stored, enough to identify a cell of a few meters.
+const MaxPrecision = 9
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Encode returns the geohash, with precision characters, of the cell containing the coordinate.
+func Encode(latitude, longitude float64, precision int) string {
+	if precision < 1 {
+		precision = 1
+	}
+
+	if precision > MaxPrecision {
+		precision = MaxPrecision
+	}
+
+	latitudes := [2]float64{-90, 90}
+	longitudes := [2]float64{-180, 180}
+
+	hash := make([]byte, 0, precision)
+	even := true
+	bit, char := 0, 0
+
+	for len(hash) < precision {
+		// NOTICE: the bits alternate between the longitude and the latitude, starting with the longitude.
+		interval, value := &latitudes, latitude
+		if even {
+			interval, value = &longitudes, longitude
+		}
+
+		mid := (interval[0] + interval[1]) / 2
+		if value >= mid {
+			char |= 1 << (4 - bit)
+			interval[0] = mid
+		} else {
+			interval[1] = mid
+		}
+
+		even = !even
+
+		if bit < 4 {
+			bit++
+
+			continue
+		}
+
+		hash = append(hash, base32[char])
+		bit, char = 0, 0
+	}
+
+	return string(hash)
+}
+
+// PrecisionFromZoom returns the geohash precision that clusters the positions shown on a map at zoom, from 0, the
+// whole globe, to 22. The deeper the zoom, the smaller the cells and, so, the clusters.
+func PrecisionFromZoom(zoom int) int {
+	switch {
+	case zoom <= 2:
+		return 1
+	case zoom <= 4:
+		return 2
+	case zoom <= 7:
+		return 3
+	case zoom <= 10:
+		return 4
+	case zoom <= 12:
+		return 5
+	case zoom <= 15:
+		return 6
+	case zoom <= 17:
+		return 7
+	case zoom <= 19:
+		return 8
+	default:
+		return MaxPrecision
+	}
+}
+
+// Box is an area of the globe delimited by its coordinates.
+type Box struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
+}
+
+var ErrBoxInvalid = errors.New("the bounding box must be \"west,south,east,north\" with valid coordinates")
+
+// ParseBox parses a bounding box written as "west,south,east,north", the order used by GeoJSON. Boxes crossing the
+// antimeridian, whose west is greater than its east, aren't supported.
+func ParseBox(text string) (Box, error) {
+	parts := strings.Split(text, ",")
+	if len(parts) != 4 {
+		return Box{}, ErrBoxInvalid
+	}
+
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return Box{}, ErrBoxInvalid
+		}
+
+		values[i] = value
+	}
+
+	box := Box{West: values[0], South: values[1], East: values[2], North: values[3]}
+	if box.West < -180 || box.East > 180 || box.South < -90 || box.North > 90 || box.West > box.East || box.South > box.North {
+		return Box{}, ErrBoxInvalid
+	}
+
+	return box, nil
+}
@@ -0,0 +1,94 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	cases := []struct {
+		description string
+		latitude    float64
+		longitude   float64
+		precision   int
+		expected    string
+	}{
+		{
+			description: "encodes the coordinate",
+			latitude:    57.64911,
+			longitude:   10.40744,
+			precision:   11,
+			expected:    "u4pruydqq",
+		},
+		{
+			description: "encodes the coordinate with the precision",
+			latitude:    -23.5505,
+			longitude:   -46.6333,
+			precision:   5,
+			expected:    "6gyf4",
+		},
+		{
+			description: "encodes the coordinate with at least one character",
+			latitude:    0,
+			longitude:   0,
+			precision:   0,
+			expected:    "s",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Encode(tc.latitude, tc.longitude, tc.precision))
+		})
+	}
+}
+
+func TestPrecisionFromZoom(t *testing.T) {
+	assert.Equal(t, 1, PrecisionFromZoom(0))
+	assert.Equal(t, 4, PrecisionFromZoom(9))
+	assert.Equal(t, MaxPrecision, PrecisionFromZoom(22))
+}
+
+func TestParseBox(t *testing.T) {
+	cases := []struct {
+		description string
+		text        string
+		expected    Box
+		err         error
+	}{
+		{
+			description: "parses the bounding box",
+			text:        "-74.1, 40.6,-73.7,40.9",
+			expected:    Box{West: -74.1, South: 40.6, East: -73.7, North: 40.9},
+		},
+		{
+			description: "fails when a coordinate is missing",
+			text:        "-74.1,40.6,-73.7",
+			err:         ErrBoxInvalid,
+		},
+		{
+			description: "fails when a coordinate is not a number",
+			text:        "-74.1,40.6,-73.7,north",
+			err:         ErrBoxInvalid,
+		},
+		{
+			description: "fails when a coordinate is out of range",
+			text:        "-74.1,40.6,-73.7,91",
+			err:         ErrBoxInvalid,
+		},
+		{
+			description: "fails when the bounding box crosses the antimeridian",
+			text:        "170,-10,-170,10",
+			err:         ErrBoxInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			box, err := ParseBox(tc.text)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, box)
+		})
+	}
+}
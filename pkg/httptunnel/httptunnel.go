@@ -30,8 +30,11 @@ const (
 )
 
 type Tunnel struct {
-	ConnectionPath    string
-	DialerPath        string
+	ConnectionPath string
+	DialerPath     string
+	// aliases maps other connection paths, served alongside ConnectionPath, to the dialer path the agents connected
+	// through them are told to dial back.
+	aliases           map[string]string
 	ConnectionHandler func(*http.Request) (string, error)
 	CloseHandler      func(string)
 	KeepAliveHandler  func(string)
@@ -51,6 +54,7 @@ func NewTunnel(connectionPath, dialerPath string) *Tunnel {
 		},
 		KeepAliveHandler: func(string) {
 		},
+		aliases: make(map[string]string),
 		connman: connman.New(),
 		id:      make(chan string),
 		online:  make(chan bool),
@@ -67,10 +71,35 @@ func NewTunnel(connectionPath, dialerPath string) *Tunnel {
 	return tunnel
 }
 
+// Alias serves the tunnel on connectionPath too, telling the agents connected through it to dial back on dialerPath.
+// It is used to keep the agents that don't know the tunnel's paths connecting through the default ones.
+func (t *Tunnel) Alias(connectionPath, dialerPath string) {
+	if connectionPath == t.ConnectionPath {
+		return
+	}
+
+	t.aliases[connectionPath] = dialerPath
+}
+
 func (t *Tunnel) Router() http.Handler {
 	e := echo.New()
 
-	e.GET(t.ConnectionPath, func(c echo.Context) error {
+	e.GET(t.ConnectionPath, t.connectionHandler(t.DialerPath))
+	e.GET(t.DialerPath, echo.WrapHandler(revdial.ConnHandler(upgrader)))
+
+	for connectionPath, dialerPath := range t.aliases {
+		e.GET(connectionPath, t.connectionHandler(dialerPath))
+
+		if dialerPath != t.DialerPath {
+			e.GET(dialerPath, echo.WrapHandler(revdial.ConnHandler(upgrader)))
+		}
+	}
+
+	return e
+}
+
+func (t *Tunnel) connectionHandler(dialerPath string) echo.HandlerFunc {
+	return func(c echo.Context) error {
 		conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
 		if err != nil {
 			return c.String(http.StatusInternalServerError, err.Error())
@@ -94,15 +123,11 @@ func (t *Tunnel) Router() http.Handler {
 				New(conn).
 				WithID(requestID).
 				WithDevice(tenant, device),
-			t.DialerPath,
+			dialerPath,
 		)
 
 		return nil
-	})
-
-	e.GET(t.DialerPath, echo.WrapHandler(revdial.ConnHandler(upgrader)))
-
-	return e
+	}
 }
 
 func (t *Tunnel) Dial(ctx context.Context, id string) (net.Conn, error) {
@@ -0,0 +1,30 @@
+// Package keysource fetches the SSH public keys published by users on code hosting providers, like the ones served by
+// GitHub at https://github.com/<username>.keys, to be imported into ShellHub.
+package keysource
+
+import (
+	"context"
+	"errors"
+)
+
+// Provider is a code hosting provider that publishes its users' SSH public keys.
+type Provider string
+
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+)
+
+var (
+	ErrProviderUnknown = errors.New("unknown key source provider")
+	ErrUserNotFound    = errors.New("user not found on key source provider")
+	ErrUnavailable     = errors.New("key source provider unavailable")
+)
+
+//go:generate mockery --name Fetcher --filename fetcher.go
+type Fetcher interface {
+	// Fetch retrieves the SSH public keys, in the authorized keys format, published by the username on provider.
+	// It returns [ErrProviderUnknown] when the provider isn't supported and [ErrUserNotFound] when the user doesn't
+	// exist on it.
+	Fetch(ctx context.Context, provider Provider, username string) ([]byte, error)
+}
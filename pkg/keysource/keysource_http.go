@@ -0,0 +1,91 @@
+package keysource
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxKeysSize is the maximum number of bytes read from the provider's response.
+const maxKeysSize = 256 * 1024
+
+// DefaultURLs are the base addresses of each provider where the users' keys are published as "<base>/<username>.keys".
+var DefaultURLs = map[Provider]string{
+	ProviderGitHub: "https://github.com",
+	ProviderGitLab: "https://gitlab.com",
+}
+
+type httpFetcher struct {
+	client *http.Client
+	urls   map[Provider]string
+}
+
+var _ Fetcher = (*httpFetcher)(nil)
+
+type Option func(*httpFetcher)
+
+// WithProviderURL overrides the base address of provider, like a self-hosted GitLab instance.
+func WithProviderURL(provider Provider, base string) Option {
+	return func(f *httpFetcher) {
+		f.urls[provider] = base
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to reach the providers.
+func WithHTTPClient(client *http.Client) Option {
+	return func(f *httpFetcher) {
+		f.client = client
+	}
+}
+
+// NewHTTPFetcher returns a [Fetcher] that retrieves the keys through the providers' public HTTP endpoints.
+func NewHTTPFetcher(opts ...Option) Fetcher {
+	f := &httpFetcher{
+		client: &http.Client{Timeout: 10 * time.Second},
+		urls:   make(map[Provider]string, len(DefaultURLs)),
+	}
+
+	for provider, base := range DefaultURLs {
+		f.urls[provider] = base
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+func (f *httpFetcher) Fetch(ctx context.Context, provider Provider, username string) ([]byte, error) {
+	base, ok := f.urls[provider]
+	if !ok {
+		return nil, ErrProviderUnknown
+	}
+
+	uri := strings.TrimSuffix(base, "/") + "/" + url.PathEscape(username) + ".keys"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, ErrUserNotFound
+	case res.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: status %d", ErrUnavailable, res.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, maxKeysSize))
+}
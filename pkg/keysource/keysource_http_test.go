@@ -0,0 +1,73 @@
+package keysource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/john.keys":
+			w.Write([]byte("ssh-ed25519 AAAA\n")) //nolint:errcheck
+		case "/unavailable.keys":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPFetcher(WithProviderURL(ProviderGitHub, server.URL), WithProviderURL(ProviderGitLab, server.URL+"/"))
+
+	cases := []struct {
+		description string
+		provider    Provider
+		username    string
+		expected    []byte
+		err         error
+	}{
+		{
+			description: "fails when the provider is unknown",
+			provider:    Provider("bitbucket"),
+			username:    "john",
+			err:         ErrProviderUnknown,
+		},
+		{
+			description: "fails when the user is not found",
+			provider:    ProviderGitHub,
+			username:    "jane",
+			err:         ErrUserNotFound,
+		},
+		{
+			description: "fails when the provider is unavailable",
+			provider:    ProviderGitHub,
+			username:    "unavailable",
+			err:         ErrUnavailable,
+		},
+		{
+			description: "succeeds",
+			provider:    ProviderGitHub,
+			username:    "john",
+			expected:    []byte("ssh-ed25519 AAAA\n"),
+		},
+		{
+			description: "succeeds when the base address has a trailing slash",
+			provider:    ProviderGitLab,
+			username:    "john",
+			expected:    []byte("ssh-ed25519 AAAA\n"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			keys, err := fetcher.Fetch(context.Background(), tc.provider, tc.username)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, keys)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Package mailer provides a minimal abstraction to deliver transactional emails, like account verification
+// messages, from ShellHub's services.
+package mailer
+
+import "context"
+
+// Message is an email to be delivered by a [Mailer].
+type Message struct {
+	// To is the recipient's email address.
+	To string
+	// Subject is the email's subject line.
+	Subject string
+	// Body is the plain text content of the email.
+	Body string
+}
+
+//go:generate mockery --name Mailer --filename mailer.go
+type Mailer interface {
+	// Send delivers the message to its recipient. It returns an error if the message could not be delivered.
+	Send(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,16 @@
+package mailer
+
+import "context"
+
+type nullMailer struct{}
+
+var _ Mailer = (*nullMailer)(nil)
+
+// NewNullMailer returns a [Mailer] that discards every message. It is used when no mail server is configured.
+func NewNullMailer() Mailer {
+	return &nullMailer{}
+}
+
+func (*nullMailer) Send(_ context.Context, _ *Message) error {
+	return nil
+}
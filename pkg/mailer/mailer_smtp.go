@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+var ErrSMTPInvalidConfig = errors.New("invalid SMTP configuration")
+
+// SMTPConfig holds the information required to deliver messages through a SMTP server.
+type SMTPConfig struct {
+	// Host is the SMTP server's hostname.
+	Host string
+	// Port is the SMTP server's port.
+	Port int
+	// Username is the user used to authenticate on the SMTP server. When empty, no authentication is performed.
+	Username string
+	// Password is the password used to authenticate on the SMTP server.
+	Password string
+	// From is the sender's address of every message.
+	From string
+}
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+	// send is the function used to deliver the message. It is defined as a field to allow replacing it on tests.
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+var _ Mailer = (*smtpMailer)(nil)
+
+// NewSMTPMailer creates a [Mailer] that delivers messages through the SMTP server described by cfg.
+func NewSMTPMailer(cfg SMTPConfig) (Mailer, error) {
+	if cfg.Host == "" || cfg.Port <= 0 || cfg.From == "" {
+		return nil, ErrSMTPInvalidConfig
+	}
+
+	m := &smtpMailer{
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from: cfg.From,
+		send: smtp.SendMail,
+	}
+
+	if cfg.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return m, nil
+}
+
+func (m *smtpMailer) Send(ctx context.Context, message *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return m.send(m.addr, m.auth, m.from, []string{message.To}, m.build(message))
+}
+
+// build builds the RFC 5322 representation of the message.
+func (m *smtpMailer) build(message *Message) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(message.Body)
+
+	return []byte(b.String())
+}
@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSMTPMailer(t *testing.T) {
+	cases := []struct {
+		description string
+		cfg         SMTPConfig
+		expected    error
+	}{
+		{
+			description: "fails when host is empty",
+			cfg:         SMTPConfig{Host: "", Port: 25, From: "noreply@shellhub.io"},
+			expected:    ErrSMTPInvalidConfig,
+		},
+		{
+			description: "fails when port is invalid",
+			cfg:         SMTPConfig{Host: "localhost", Port: 0, From: "noreply@shellhub.io"},
+			expected:    ErrSMTPInvalidConfig,
+		},
+		{
+			description: "fails when sender is empty",
+			cfg:         SMTPConfig{Host: "localhost", Port: 25, From: ""},
+			expected:    ErrSMTPInvalidConfig,
+		},
+		{
+			description: "succeeds",
+			cfg:         SMTPConfig{Host: "localhost", Port: 25, From: "noreply@shellhub.io"},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := NewSMTPMailer(tc.cfg)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	cases := []struct {
+		description string
+		sendErr     error
+		expected    error
+	}{
+		{
+			description: "fails when the server rejects the message",
+			sendErr:     errors.New("error"),
+			expected:    errors.New("error"),
+		},
+		{
+			description: "succeeds",
+			sendErr:     nil,
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			m, err := NewSMTPMailer(SMTPConfig{Host: "localhost", Port: 25, From: "noreply@shellhub.io"})
+			require.NoError(t, err)
+
+			var addr string
+			var to []string
+			var msg []byte
+
+			m.(*smtpMailer).send = func(a string, _ smtp.Auth, _ string, t []string, b []byte) error {
+				addr, to, msg = a, t, b
+
+				return tc.sendErr
+			}
+
+			err = m.Send(context.Background(), &Message{To: "john.doe@test.com", Subject: "subject", Body: "body"})
+			assert.Equal(t, tc.expected, err)
+			assert.Equal(t, "localhost:25", addr)
+			assert.Equal(t, []string{"john.doe@test.com"}, to)
+			assert.Contains(t, string(msg), "Subject: subject\r\n")
+			assert.Contains(t, string(msg), "\r\n\r\nbody")
+		})
+	}
+}
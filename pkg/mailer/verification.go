@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NewVerificationMessage builds the message asking a user to confirm the email address. It links to the account
+// validation page of the instance at baseURL with the verification token, which remains valid for ttl.
+func NewVerificationMessage(baseURL, name, email, token string, ttl time.Duration) *Message {
+	link := fmt.Sprintf(
+		"%s/validation-account?%s",
+		strings.TrimSuffix(baseURL, "/"),
+		url.Values{"email": {email}, "token": {token}}.Encode(),
+	)
+
+	return &Message{
+		To:      email,
+		Subject: "Confirm your ShellHub account",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nTo finish setting up your ShellHub account, confirm your email address by opening the link below:\n\n%s\n\nThis link expires in %s. If you did not request it, you can ignore this message.\n",
+			name,
+			link,
+			ttl,
+		),
+	}
+}
@@ -0,0 +1,18 @@
+package mailer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVerificationMessage(t *testing.T) {
+	message := NewVerificationMessage("https://shellhub.io/", "John Doe", "john.doe@test.com", "token", 24*time.Hour)
+
+	assert.Equal(t, "john.doe@test.com", message.To)
+	assert.Equal(t, "Confirm your ShellHub account", message.Subject)
+	assert.Contains(t, message.Body, "Hello John Doe,")
+	assert.Contains(t, message.Body, "https://shellhub.io/validation-account?email=john.doe%40test.com&token=token\n")
+	assert.Contains(t, message.Body, "This link expires in 24h0m0s.")
+}
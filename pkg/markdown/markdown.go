@@ -0,0 +1,41 @@
+// Package markdown sanitizes user provided markdown before it is stored, so it can be rendered by the clients without
+// executing scripts or loading content from dangerous sources.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// html matches raw HTML tags and comments. Autolinks, like <https://shellhub.io>, aren't matched as their names
+	// contain a colon.
+	html = regexp.MustCompile(`(?s)<!--.*?-->|</?[A-Za-z][A-Za-z0-9-]*(\s[^>]*)?/?>`)
+	// inline matches the destination of inline links and images with a dangerous scheme, allowing one level of nested
+	// parentheses on it.
+	inline = regexp.MustCompile(`(?i)\]\(\s*<?\s*(javascript|vbscript|data|file):(\([^)]*\)|[^()])*\)`)
+	// autolink matches autolinks with a dangerous scheme.
+	autolink = regexp.MustCompile(`(?i)<\s*(javascript|vbscript|data|file):[^>]*>`)
+	// reference matches link reference definitions with a dangerous scheme.
+	reference = regexp.MustCompile(`(?im)^[ \t]*\[[^\]]+\]:[ \t]*<?[ \t]*(javascript|vbscript|data|file):.*$`)
+)
+
+// Sanitize removes the raw HTML, the links with dangerous schemes and the control characters from text, keeping the
+// remaining markdown as is.
+func Sanitize(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.Map(func(r rune) rune {
+		if (r < ' ' && r != '\n' && r != '\t') || r == 0x7f {
+			return -1
+		}
+
+		return r
+	}, text)
+
+	text = html.ReplaceAllString(text, "")
+	text = inline.ReplaceAllString(text, "](#)")
+	text = autolink.ReplaceAllString(text, "")
+	text = reference.ReplaceAllString(text, "")
+
+	return strings.TrimSpace(text)
+}
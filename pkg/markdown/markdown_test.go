@@ -0,0 +1,57 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		description string
+		text        string
+		expected    string
+	}{
+		{
+			description: "keeps the markdown",
+			text:        "Use the user **admin**; `sudo` requires a [ticket](https://tickets.example.com).",
+			expected:    "Use the user **admin**; `sudo` requires a [ticket](https://tickets.example.com).",
+		},
+		{
+			description: "keeps the autolinks",
+			text:        "See <https://docs.shellhub.io>",
+			expected:    "See <https://docs.shellhub.io>",
+		},
+		{
+			description: "removes the raw HTML",
+			text:        "<script>alert(1)</script>Hello <img src=x onerror=alert(1)/><!-- comment -->world",
+			expected:    "alert(1)Hello world",
+		},
+		{
+			description: "neutralizes links with dangerous schemes",
+			text:        "[click](javascript:alert(1)) and ![image](data:image/png;base64,AAAA)",
+			expected:    "[click](#) and ![image](#)",
+		},
+		{
+			description: "removes autolinks with dangerous schemes",
+			text:        "Open <javascript:alert(1)>",
+			expected:    "Open",
+		},
+		{
+			description: "removes reference definitions with dangerous schemes",
+			text:        "[click][link]\n\n[link]: javascript:alert(1)",
+			expected:    "[click][link]",
+		},
+		{
+			description: "removes the control characters",
+			text:        "line\r\nnext\x00\x1b[31m line\tend",
+			expected:    "line\nnext[31m line\tend",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Sanitize(tc.text))
+		})
+	}
+}
@@ -5,18 +5,21 @@ import (
 	"time"
 
 	echo "github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	"github.com/sirupsen/logrus"
 )
 
 func Log(next echo.HandlerFunc) echo.HandlerFunc {
 	log := logrus.New()
 	log.SetFormatter(&logrus.JSONFormatter{})
+	log.AddHook(correlation.NewHook())
 
 	return func(c echo.Context) error {
 		level := logrus.InfoLevel
 
-		// Assign request tracking ID to log entry
-		entry := logrus.NewEntry(log).WithFields(logrus.Fields{
+		// Assign request tracking ID to log entry. The correlation ID and the tenant are also added from the request's
+		// context, when it was set by the correlation middleware.
+		entry := logrus.NewEntry(log).WithContext(c.Request().Context()).WithFields(logrus.Fields{
 			"id": c.Request().Header.Get(echo.HeaderXRequestID),
 		})
 
@@ -6,6 +6,10 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
 )
 
+// APIKeyPrefix prefixes the keys generated by ShellHub, telling them apart from the JSON Web Tokens when sent as a
+// bearer token on the Authorization header.
+const APIKeyPrefix = "sk_"
+
 // APIKey is used to authenticate a request. It is similar to [UserAuthClaims] but only for
 // namespace information, which means that user-related routes are blocked for use with api keys.
 // The ID and key are never returned to the end user; the "external" identification must be made
@@ -0,0 +1,136 @@
+package models
+
+import "time"
+
+// AuditAction is the name of an operation kept on the namespace's audit trail.
+type AuditAction string
+
+const (
+	AuditActionDeviceStatusUpdate AuditAction = "device.status.update"
+	AuditActionDeviceRename       AuditAction = "device.rename"
+	AuditActionDeviceRemove       AuditAction = "device.remove"
+	AuditActionDeviceUpdate       AuditAction = "device.update"
+	AuditActionDeviceTagAdd       AuditAction = "device.tag.add"
+	AuditActionDeviceTagRemove    AuditAction = "device.tag.remove"
+	AuditActionDeviceTagsUpdate   AuditAction = "device.tags.update"
+	AuditActionDeviceShareCreate  AuditAction = "device.share.create"
+	AuditActionDeviceShareDelete  AuditAction = "device.share.delete"
+
+	AuditActionMemberAdd    AuditAction = "namespace.member.add"
+	AuditActionMemberUpdate AuditAction = "namespace.member.update"
+	AuditActionMemberRemove AuditAction = "namespace.member.remove"
+	AuditActionMemberLeave  AuditAction = "namespace.member.leave"
+
+	AuditActionAPIKeyCreate AuditAction = "api_key.create"
+	AuditActionAPIKeyUpdate AuditAction = "api_key.update"
+	AuditActionAPIKeyDelete AuditAction = "api_key.delete"
+
+	AuditActionEnrollTokenCreate AuditAction = "enroll_token.create"
+	AuditActionEnrollTokenDelete AuditAction = "enroll_token.delete"
+
+	AuditActionPublicKeyCreate     AuditAction = "public_key.create"
+	AuditActionPublicKeyUpdate     AuditAction = "public_key.update"
+	AuditActionPublicKeyDelete     AuditAction = "public_key.delete"
+	AuditActionPublicKeyTagAdd     AuditAction = "public_key.tag.add"
+	AuditActionPublicKeyTagRemove  AuditAction = "public_key.tag.remove"
+	AuditActionPublicKeyTagsUpdate AuditAction = "public_key.tags.update"
+
+	AuditActionTagRename AuditAction = "tag.rename"
+	AuditActionTagDelete AuditAction = "tag.delete"
+
+	AuditActionSSHCASet    AuditAction = "ssh_ca.set"
+	AuditActionSSHCARotate AuditAction = "ssh_ca.rotate"
+	AuditActionSSHCADelete AuditAction = "ssh_ca.delete"
+
+	AuditActionNamespaceUpdate              AuditAction = "namespace.update"
+	AuditActionNamespaceDelete              AuditAction = "namespace.delete"
+	AuditActionNamespaceSessionRecordUpdate AuditAction = "namespace.session_record.update"
+
+	AuditActionTagRuleCreate AuditAction = "tag_rule.create"
+	AuditActionTagRuleUpdate AuditAction = "tag_rule.update"
+	AuditActionTagRuleDelete AuditAction = "tag_rule.delete"
+
+	AuditActionGroupCreate       AuditAction = "group.create"
+	AuditActionGroupUpdate       AuditAction = "group.update"
+	AuditActionGroupDelete       AuditAction = "group.delete"
+	AuditActionGroupDeviceAdd    AuditAction = "group.device.add"
+	AuditActionGroupDeviceRemove AuditAction = "group.device.remove"
+
+	AuditActionSessionScheduleOverride AuditAction = "session_schedule.override"
+
+	AuditActionCommandPolicyCreate AuditAction = "command_policy.create"
+	AuditActionCommandPolicyUpdate AuditAction = "command_policy.update"
+	AuditActionCommandPolicyDelete AuditAction = "command_policy.delete"
+)
+
+// AuditActorType is the kind of the actor of an audited operation.
+type AuditActorType string
+
+const (
+	AuditActorUser   AuditActorType = "user"
+	AuditActorAPIKey AuditActorType = "api_key"
+	// AuditActorSystem is the server itself, like on the operations executed by its jobs.
+	AuditActorSystem AuditActorType = "system"
+)
+
+// AuditActor is who has executed an audited operation.
+type AuditActor struct {
+	Type AuditActorType `json:"type" bson:"type"`
+	// ID is the user's ID or, for an API key, the key's digest. It is empty for the system.
+	ID string `json:"id" bson:"id"`
+	// Username is the user's username. It is empty for the other actors.
+	Username string `json:"username,omitempty" bson:"username,omitempty"`
+}
+
+// AuditTargetType is the kind of the resource changed by an audited operation.
+type AuditTargetType string
+
+const (
+	AuditTargetDevice        AuditTargetType = "device"
+	AuditTargetUser          AuditTargetType = "user"
+	AuditTargetAPIKey        AuditTargetType = "api_key"
+	AuditTargetEnrollToken   AuditTargetType = "enroll_token"
+	AuditTargetPublicKey     AuditTargetType = "public_key"
+	AuditTargetTag           AuditTargetType = "tag"
+	AuditTargetSSHCA         AuditTargetType = "ssh_ca"
+	AuditTargetNamespace     AuditTargetType = "namespace"
+	AuditTargetTagRule       AuditTargetType = "tag_rule"
+	AuditTargetGroup         AuditTargetType = "group"
+	AuditTargetCommandPolicy AuditTargetType = "command_policy"
+)
+
+// AuditTarget is the resource changed by an audited operation.
+type AuditTarget struct {
+	Type AuditTargetType `json:"type" bson:"type"`
+	// ID identifies the resource on its type: the device's UID, the user's ID, the API key's name, the public key's
+	// fingerprint, the tag's name, the SSH certificate authority's fingerprint, the namespace's tenant ID, the tag
+	// rule's ID, the group's ID or the command policy's ID.
+	ID string `json:"id" bson:"id"`
+}
+
+// AuditEntry is an operation that changed a namespace's resource, kept on the namespace's audit trail.
+type AuditEntry struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the ID of the namespace whose resource was changed.
+	TenantID string      `json:"tenant_id" bson:"tenant_id"`
+	Actor    AuditActor  `json:"actor" bson:"actor"`
+	Action   AuditAction `json:"action" bson:"action"`
+	Target   AuditTarget `json:"target" bson:"target"`
+	// Details are the operation's arguments, like the device's new status or the member's new role.
+	Details map[string]string `json:"details,omitempty" bson:"details,omitempty"`
+	// SourceIP is the address the operation was requested from. It is empty for the operations not requested through
+	// the API, like the ones executed by the jobs.
+	SourceIP  string    `json:"source_ip" bson:"source_ip"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// AuditEntryFilter selects the entries of a namespace's audit trail. Its empty fields select any entry.
+type AuditEntryFilter struct {
+	Action     AuditAction
+	ActorID    string
+	TargetType AuditTargetType
+	TargetID   string
+	// From and To limit the entries to the ones created on the interval, inclusive.
+	From *time.Time
+	To   *time.Time
+}
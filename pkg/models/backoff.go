@@ -0,0 +1,58 @@
+package models
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// RetryAfterHeader is the header with the minimum number of seconds to wait before retrying a refused request.
+	RetryAfterHeader = "Retry-After"
+	// RetryJitterHeader is the header with the number of seconds, after the [RetryAfterHeader], over which the retries
+	// should be spread.
+	RetryJitterHeader = "X-Retry-Jitter"
+)
+
+// Backoff is a hint, sent by the server on the headers of a refused request, of how long the client should wait before
+// retrying it, so the clients refused at the same time, like a fleet of devices reconnecting after a server restart,
+// don't retry at the same time again.
+type Backoff struct {
+	// RetryAfter is the minimum time to wait before retrying.
+	RetryAfter time.Duration
+	// Jitter is the window, after RetryAfter, over which the clients spread their retries.
+	Jitter time.Duration
+}
+
+// SetHeader writes the hint on header, in seconds.
+func (b Backoff) SetHeader(header http.Header) {
+	header.Set(RetryAfterHeader, strconv.Itoa(int(b.RetryAfter.Seconds())))
+	header.Set(RetryJitterHeader, strconv.Itoa(int(b.Jitter.Seconds())))
+}
+
+// Wait returns a random time to wait inside the hint's window.
+func (b Backoff) Wait() time.Duration {
+	if b.Jitter <= 0 {
+		return b.RetryAfter
+	}
+
+	return b.RetryAfter + time.Duration(rand.Int63n(int64(b.Jitter))) //nolint:gosec
+}
+
+// BackoffFromHeader reads the hint from header. It returns false when the header has no hint, or when it is written as
+// a date, that isn't sent by ShellHub.
+func BackoffFromHeader(header http.Header) (Backoff, bool) {
+	after, err := strconv.Atoi(header.Get(RetryAfterHeader))
+	if err != nil || after < 0 {
+		return Backoff{}, false
+	}
+
+	// NOTICE: the jitter is optional, as the Retry-After header may be sent by a proxy in front of the server.
+	jitter, err := strconv.Atoi(header.Get(RetryJitterHeader))
+	if err != nil || jitter < 0 {
+		jitter = 0
+	}
+
+	return Backoff{RetryAfter: time.Duration(after) * time.Second, Jitter: time.Duration(jitter) * time.Second}, true
+}
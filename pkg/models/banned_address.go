@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// BannedAddress is an IP address, or a range of them in CIDR notation, refused by the instance on both the SSH server
+// and the API.
+type BannedAddress struct {
+	// Address is the banned range in CIDR notation. A single IP address is kept as a range with its full length, like
+	// 192.0.2.1/32.
+	Address string `json:"address" bson:"_id"`
+	// Reason is why the address was banned, if any.
+	Reason    string    `json:"reason" bson:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// ExpiresAt is when a temporary ban, like the ones set after repeated authentication failures, is lifted. It is
+	// nil for the bans set by the instance's administrator, kept until removed.
+	ExpiresAt *time.Time `json:"expires_at" bson:"expires_at,omitempty"`
+}
+
+// Expired reports whether the ban was already lifted at now.
+func (b *BannedAddress) Expired(now time.Time) bool {
+	return b.ExpiresAt != nil && !now.Before(*b.ExpiresAt)
+}
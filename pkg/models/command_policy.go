@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// CommandPolicy restricts the command lines run on the interactive sessions to a namespace's devices, like the
+// commands allowed to the contractors on the production devices. The SSH server evaluates the policies against each
+// command line typed on the sessions, and against the command line of the command executions, before it reaches the
+// device.
+type CommandPolicy struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the policy's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	Name     string `json:"name" bson:"name"`
+	// Tags restrict the policy to the devices with any of them, or any of their descendants. When empty, the policy
+	// applies to every device of the namespace.
+	Tags []string `json:"tags" bson:"tags"`
+	// Mode is how the command lines are matched against the patterns.
+	Mode CommandPolicyMode `json:"mode" bson:"mode"`
+	// Patterns are the regular expressions matched against the command lines.
+	Patterns []string `json:"patterns" bson:"patterns"`
+	// Action is what the SSH server does when a command line violates the policy.
+	Action    CommandPolicyAction `json:"action" bson:"action"`
+	CreatedAt time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+// Applies reports if the policy restricts the command lines run on a device with the tags.
+func (p *CommandPolicy) Applies(tags []string) bool {
+	return len(p.Tags) == 0 || TagsMatch(tags, p.Tags)
+}
+
+// CommandPolicyMode is how a [CommandPolicy] matches the command lines against its patterns.
+type CommandPolicyMode string
+
+const (
+	// CommandPolicyModeDeny is a denylist: the command lines matching any of the patterns violate the policy.
+	CommandPolicyModeDeny CommandPolicyMode = "deny"
+	// CommandPolicyModeAllow is an allowlist: the command lines matching none of the patterns violate the policy.
+	//
+	// As every line typed on the session is evaluated, the answers to the programs' prompts must be allowed too.
+	CommandPolicyModeAllow CommandPolicyMode = "allow"
+)
+
+// CommandPolicyAction is what the SSH server does with a command line violating a [CommandPolicy].
+type CommandPolicyAction string
+
+const (
+	// CommandPolicyActionWarn runs the command line, warning the user it violates the policy.
+	CommandPolicyActionWarn CommandPolicyAction = "warn"
+	// CommandPolicyActionBlock discards the command line, telling the user it was rejected, and keeps the session.
+	CommandPolicyActionBlock CommandPolicyAction = "block"
+	// CommandPolicyActionTerminate discards the command line and terminates the session.
+	CommandPolicyActionTerminate CommandPolicyAction = "terminate"
+)
+
+// CommandPolicyEvaluation is a command line that violated a [CommandPolicy] on a session, and the action taken.
+type CommandPolicyEvaluation struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the device's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id" validate:"required"`
+	// PolicyID is the ID of the violated policy.
+	PolicyID string `json:"policy_id" bson:"policy_id" validate:"required"`
+	// PolicyName is the name of the violated policy when it was evaluated.
+	PolicyName string `json:"policy_name" bson:"policy_name"`
+	// SessionUID is the UID of the session the command line was run on.
+	SessionUID string `json:"session_uid" bson:"session_uid" validate:"required"`
+	// DeviceUID is the UID of the session's device.
+	DeviceUID string `json:"device_uid" bson:"device_uid"`
+	// Username is the user the session logged in as on the device.
+	Username string `json:"username" bson:"username"`
+	// Command is the command line, as reconstructed from the session's input.
+	Command string              `json:"command" bson:"command" validate:"max=4096"`
+	Action  CommandPolicyAction `json:"action" bson:"action" validate:"required,oneof=warn block terminate"`
+	// Time is when the command line was evaluated.
+	Time time.Time `json:"time" bson:"time"`
+}
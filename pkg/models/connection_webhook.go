@@ -0,0 +1,95 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+const (
+	// ConnectionWebhookDefaultTimeout is how long the SSH connections wait for the webhook's answer when the
+	// namespace doesn't configure it.
+	ConnectionWebhookDefaultTimeout = 5 * time.Second
+	// ConnectionWebhookMaxTimeout is the longest the SSH connections may wait for the webhook's answer.
+	ConnectionWebhookMaxTimeout = 30 * time.Second
+)
+
+var (
+	ErrConnectionWebhookURL     = errors.New("the connection webhook's URL must be an absolute http or https URL")
+	ErrConnectionWebhookTimeout = errors.New("the connection webhook's timeout must be between 0 and 30 seconds")
+)
+
+// ConnectionWebhook is an HTTP endpoint, like the one of a ticketing or change-management system, asked to allow or
+// deny each SSH connection to a namespace's devices before the session is established. The endpoint receives the
+// user, the device, the client's address and the time, and answers if the connection is allowed.
+//
+// As the namespace's settings are visible to its members, the webhook has no secret of its own; the endpoint may
+// authenticate the requests through a token on its URL.
+type ConnectionWebhook struct {
+	// URL is the endpoint the webhook's requests are sent to. When it is empty, the webhook is disabled.
+	URL string `json:"url" bson:"url"`
+	// Timeout is how long, in seconds, the connections wait for the endpoint's answer. When it is zero,
+	// [ConnectionWebhookDefaultTimeout] is used.
+	Timeout int `json:"timeout" bson:"timeout"`
+	// FailOpen defines if the connections are allowed when the endpoint doesn't answer in time, or answers an error.
+	// When it is false, those connections are denied.
+	FailOpen bool `json:"fail_open" bson:"fail_open"`
+}
+
+// Enabled reports if the connections must be authorized by the webhook.
+func (w *ConnectionWebhook) Enabled() bool {
+	return w != nil && w.URL != ""
+}
+
+// Deadline returns how long the connections wait for the endpoint's answer.
+func (w *ConnectionWebhook) Deadline() time.Duration {
+	if w.Timeout <= 0 {
+		return ConnectionWebhookDefaultTimeout
+	}
+
+	return time.Duration(w.Timeout) * time.Second
+}
+
+// Validate checks if the webhook is valid. A webhook without URL is valid, as it is disabled.
+func (w *ConnectionWebhook) Validate() error {
+	if w.Timeout < 0 || time.Duration(w.Timeout)*time.Second > ConnectionWebhookMaxTimeout {
+		return ErrConnectionWebhookTimeout
+	}
+
+	if w.URL == "" {
+		return nil
+	}
+
+	endpoint, err := url.Parse(w.URL)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return ErrConnectionWebhookURL
+	}
+
+	return nil
+}
+
+// ConnectionWebhookDevice is the device a connection sent to a [ConnectionWebhook] is made to.
+type ConnectionWebhookDevice struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+}
+
+// ConnectionWebhookRequest is the body of the requests sent to a [ConnectionWebhook], as JSON, for each connection.
+type ConnectionWebhookRequest struct {
+	TenantID  string                   `json:"tenant_id"`
+	Namespace string                   `json:"namespace"`
+	Device    *ConnectionWebhookDevice `json:"device"`
+	// User is the username the session logs in as on the device.
+	User string `json:"user"`
+	// SourceIP is the address of the connection's client.
+	SourceIP string    `json:"source_ip"`
+	Time     time.Time `json:"time"`
+}
+
+// ConnectionWebhookResponse is the body a [ConnectionWebhook] must answer, as JSON with the status 200, for each
+// connection.
+type ConnectionWebhookResponse struct {
+	Allow bool `json:"allow"`
+	// Reason explains, when the connection is denied, why it was denied, like the missing change ticket.
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// DeviceContainer is a Docker container running on a device, as reported by the agent when the containers listing is
+// enabled.
+type DeviceContainer struct {
+	// ID is the container's short ID.
+	ID string `json:"id"`
+	// Name is the container's name, used to target it on the SSHID.
+	Name string `json:"name"`
+	// Image is the image the container was created from.
+	Image string `json:"image"`
+	// State is the container's state, like "running" or "paused".
+	State string `json:"state"`
+	// Status is a human-readable description of the container's state, like "Up 2 hours".
+	Status string `json:"status"`
+}
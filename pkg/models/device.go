@@ -1,7 +1,12 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/geohash"
 )
 
 type DeviceStatus string
@@ -17,29 +22,100 @@ const (
 
 type Device struct {
 	// UID is the unique identifier for a device.
-	UID              string          `json:"uid"`
-	Name             string          `json:"name" bson:"name,omitempty" validate:"required,device_name"`
-	Identity         *DeviceIdentity `json:"identity"`
-	Info             *DeviceInfo     `json:"info"`
-	PublicKey        string          `json:"public_key" bson:"public_key"`
-	TenantID         string          `json:"tenant_id" bson:"tenant_id"`
-	LastSeen         time.Time       `json:"last_seen" bson:"last_seen"`
-	Online           bool            `json:"online" bson:",omitempty"`
-	Namespace        string          `json:"namespace" bson:",omitempty"`
-	Status           DeviceStatus    `json:"status" bson:"status,omitempty" validate:"oneof=accepted rejected pending unused"`
-	StatusUpdatedAt  time.Time       `json:"status_updated_at" bson:"status_updated_at,omitempty"`
-	CreatedAt        time.Time       `json:"created_at" bson:"created_at,omitempty"`
-	RemoteAddr       string          `json:"remote_addr" bson:"remote_addr"`
-	Position         *DevicePosition `json:"position" bson:"position"`
-	Tags             []string        `json:"tags" bson:"tags,omitempty"`
-	PublicURL        bool            `json:"public_url" bson:"public_url,omitempty"`
-	PublicURLAddress string          `json:"public_url_address" bson:"public_url_address,omitempty"`
-	Acceptable       bool            `json:"acceptable" bson:"acceptable,omitempty"`
+	UID             string          `json:"uid"`
+	Name            string          `json:"name" bson:"name,omitempty" validate:"required,device_name"`
+	Identity        *DeviceIdentity `json:"identity"`
+	Info            *DeviceInfo     `json:"info"`
+	PublicKey       string          `json:"public_key" bson:"public_key"`
+	TenantID        string          `json:"tenant_id" bson:"tenant_id"`
+	LastSeen        time.Time       `json:"last_seen" bson:"last_seen"`
+	Online          bool            `json:"online" bson:",omitempty"`
+	Namespace       string          `json:"namespace" bson:",omitempty"`
+	Status          DeviceStatus    `json:"status" bson:"status,omitempty" validate:"oneof=accepted rejected pending unused"`
+	StatusUpdatedAt time.Time       `json:"status_updated_at" bson:"status_updated_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at" bson:"created_at,omitempty"`
+	RemoteAddr      string          `json:"remote_addr" bson:"remote_addr"`
+	Position        *DevicePosition `json:"position" bson:"position"`
+	Tags            []string        `json:"tags" bson:"tags,omitempty"`
+	// Groups are the IDs of the [Group]s the device belongs to.
+	Groups           []string `json:"groups" bson:"groups,omitempty"`
+	PublicURL        bool     `json:"public_url" bson:"public_url,omitempty"`
+	PublicURLAddress string   `json:"public_url_address" bson:"public_url_address,omitempty"`
+	Acceptable       bool     `json:"acceptable" bson:"acceptable,omitempty"`
+	// Compromised indicates the device is suspected of being compromised, as it tried to authenticate with a public
+	// key different from the pinned one. It is cleared when an admin reviews the related [DeviceKeyIncident].
+	Compromised bool `json:"compromised" bson:"compromised,omitempty"`
+	// Addresses is the history of remote addresses used by the device, from the oldest to the newest, limited to the
+	// last [DeviceAddressesMax] entries.
+	Addresses []DeviceAddress `json:"addresses" bson:"addresses,omitempty"`
+	// ClaimCode is the short code, derived from the device's public key, that a user may enter to accept the device
+	// without knowing its UID. See [NewDeviceClaimCode].
+	ClaimCode string `json:"claim_code" bson:"claim_code,omitempty"`
+	// LoginShell is the program started, instead of the user's shell, on the device's interactive sessions. The agent
+	// only uses it when the program is on its allowed login shells.
+	LoginShell string `json:"login_shell" bson:"login_shell,omitempty"`
+	// RemoteAccess enables the reverse SSH tunnel of a device whose agent runs in inventory-only mode. It is honored
+	// by the agent on its next ping, and has no effect on the other agents.
+	RemoteAccess bool `json:"remote_access" bson:"remote_access,omitempty"`
+	// ConnectionNote is the markdown note, written by the operators, with instructions to connect to the device (e.g.
+	// "use the user admin; sudo requires a ticket"). It is sanitized before being stored.
+	ConnectionNote string `json:"connection_note" bson:"connection_note,omitempty"`
+	// LimitExempt indicates the device isn't counted towards the namespace's maximum number of devices, like the lab
+	// devices of a namespace shared with production ones. The changes on it are kept as [DeviceLimitExemption]s.
+	LimitExempt bool `json:"limit_exempt" bson:"limit_exempt,omitempty"`
+	// ConnectionSamples is the rolling window of the connection measures reported by the agent, from the oldest to the
+	// newest, limited to the last [DeviceConnectionSamplesMax] entries.
+	ConnectionSamples []DeviceConnectionSample `json:"connection_samples" bson:"connection_samples,omitempty"`
+	// ConnectionQuality is the summary of ConnectionSamples. It is nil until the agent reports its first measure.
+	ConnectionQuality *DeviceConnectionQuality `json:"connection_quality" bson:"connection_quality,omitempty"`
+	// Queue is the pending device's entry on the namespace's acceptance queue. It is nil when the device isn't queued.
+	Queue *DeviceQueue `json:"queue" bson:"queue,omitempty"`
+	// Config is the configuration delivered to the device's agent. It is nil when the device was never configured.
+	Config *DeviceConfig `json:"config" bson:"config,omitempty"`
+	// ConfigVersion is the version of Config last applied by the device's agent.
+	ConfigVersion int `json:"config_version" bson:"config_version,omitempty"`
+	// Shared is the [DeviceShare] through which the device is seen by a namespace other than its own, marking it as
+	// shared on the grantee's device list. It is nil when the device is seen by its own namespace, and never stored.
+	Shared *DeviceShare `json:"shared,omitempty" bson:"-"`
+}
+
+// DeviceQueue is the entry of a pending device on the namespace's acceptance queue. The queued devices are accepted
+// as the namespace's maximum number of devices allows, from the highest priority to the lowest and, on the same
+// priority, from the oldest entry to the newest.
+type DeviceQueue struct {
+	Priority int       `json:"priority" bson:"priority"`
+	QueuedAt time.Time `json:"queued_at" bson:"queued_at"`
+	// QueuedBy is the ID of the user who queued the device.
+	QueuedBy string `json:"queued_by" bson:"queued_by"`
+}
+
+// DeviceConnectionNoteMaxLength is the maximum number of characters of a device's connection note.
+const DeviceConnectionNoteMaxLength = 4096
+
+// DeviceAddressesMax is the maximum number of entries kept on the device's remote addresses history.
+const DeviceAddressesMax = 20
+
+// DeviceAddress is an entry on the device's remote addresses history.
+type DeviceAddress struct {
+	RemoteAddr string `json:"remote_addr" bson:"remote_addr"`
+	// Country is the ISO country code where the remote address is located. It is empty when it cannot be found.
+	Country string    `json:"country" bson:"country,omitempty"`
+	SeenAt  time.Time `json:"seen_at" bson:"seen_at"`
 }
 
 type DeviceAuthRequest struct {
-	Info     *DeviceInfo `json:"info"`
-	Sessions []string    `json:"sessions,omitempty"`
+	Info *DeviceInfo `json:"info,omitempty"`
+	// InfoHash is the [DeviceInfo.Hash] of the device's information, sent instead of the information when it is
+	// unchanged since the previous authorization.
+	InfoHash string   `json:"info_hash,omitempty"`
+	Sessions []string `json:"sessions,omitempty"`
+	// Connection is the measure of the agent's connection since its previous ping. It is nil on the first one.
+	Connection *DeviceConnection `json:"connection,omitempty"`
+	// ConfigVersion is the version of the device's configuration applied by the agent. It is zero until the agent
+	// applies its first configuration.
+	ConfigVersion int `json:"config_version,omitempty"`
+	// EnrollToken is the [EnrollToken] presented instead of the tenant ID, when the agent doesn't know it.
+	EnrollToken string `json:"enroll_token,omitempty"`
 	*DeviceAuth
 }
 
@@ -51,10 +127,24 @@ type DeviceAuth struct {
 }
 
 type DeviceAuthResponse struct {
-	UID       string `json:"uid"`
-	Token     string `json:"token"`
+	UID   string `json:"uid"`
+	Token string `json:"token"`
+	// TenantID is the device's namespace ID, which the agents enrolled by an [EnrollToken] don't know beforehand.
+	TenantID  string `json:"tenant_id"`
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
+	// RemoteAccess indicates if an agent running in inventory-only mode may open the reverse SSH tunnel.
+	RemoteAccess bool `json:"remote_access"`
+	// Config is the device's configuration to be applied by the agent. It is nil when the device isn't configured.
+	Config *DeviceConfig `json:"config,omitempty"`
+	// UserProvisioning is the namespace's policy on the local users the agent may create for the SSH sessions. It is
+	// nil when the namespace doesn't allow it.
+	UserProvisioning *UserProvisioning `json:"user_provisioning,omitempty"`
+	// ClockSkew is how much the device's clock is ahead of the server's, measured by the client from the response's
+	// Date header. It isn't sent by the server.
+	ClockSkew time.Duration `json:"-"`
+	// RTT is the round-trip time of the request, measured by the client. It isn't sent by the server.
+	RTT time.Duration `json:"-"`
 }
 
 type DeviceIdentity struct {
@@ -67,17 +157,101 @@ type DeviceInfo struct {
 	Version    string `json:"version"`
 	Arch       string `json:"arch"`
 	Platform   string `json:"platform"`
+	// ClockSkew is how many seconds the device's clock is ahead of the server's; it is negative when behind.
+	ClockSkew int64 `json:"clock_skew" bson:"clock_skew"`
+	// ClockUnsynchronized indicates the device's clock skew is above [DeviceClockSkewThreshold].
+	ClockUnsynchronized bool `json:"clock_unsynchronized" bson:"clock_unsynchronized"`
+	// Hardware is the device's hardware inventory. It is nil when the agent doesn't report it.
+	Hardware *DeviceHardware `json:"hardware,omitempty" bson:"hardware,omitempty"`
+	// Interfaces are the device's network interfaces. It is empty when the agent doesn't report them.
+	Interfaces []DeviceInterface `json:"interfaces,omitempty" bson:"interfaces,omitempty"`
+}
+
+// DeviceInterface is a network interface reported by the device's agent.
+type DeviceInterface struct {
+	Name string `json:"name" bson:"name"`
+	MAC  string `json:"mac" bson:"mac"`
+	// Addresses are the interface's addresses, in CIDR notation.
+	Addresses []string `json:"addresses" bson:"addresses"`
+}
+
+// DeviceHardware is the hardware inventory reported by the device's agent.
+type DeviceHardware struct {
+	CPU DeviceHardwareCPU `json:"cpu" bson:"cpu"`
+	// Memory is the device's total memory, in bytes.
+	Memory uint64               `json:"memory" bson:"memory"`
+	Disks  []DeviceHardwareDisk `json:"disks" bson:"disks"`
+	// Serial is the device's serial number, from its DMI table. It is empty when it cannot be read.
+	Serial string `json:"serial" bson:"serial"`
+	// Product is the device's product name, from its DMI table. It is empty when it cannot be read.
+	Product string `json:"product" bson:"product"`
+}
+
+type DeviceHardwareCPU struct {
+	Model string `json:"model" bson:"model"`
+	Cores int    `json:"cores" bson:"cores"`
+}
+
+type DeviceHardwareDisk struct {
+	Name string `json:"name" bson:"name"`
+	// Size is the disk's size, in bytes.
+	Size uint64 `json:"size" bson:"size"`
+}
+
+// Hash returns the digest of the information reported by the device's agent, so an unchanged information can be
+// reported by its digest only. The fields derived by the server aren't digested.
+func (i *DeviceInfo) Hash() string {
+	fields := []any{i.ID, i.PrettyName, i.Version, i.Arch, i.Platform, i.ClockSkew}
+	// NOTICE: the hardware is digested only when reported, so the hashes of the agents that don't report it are kept.
+	if i.Hardware != nil {
+		fields = append(fields, i.Hardware)
+	}
+
+	// NOTICE: as the hardware, the interfaces are digested only when reported.
+	if len(i.Interfaces) > 0 {
+		fields = append(fields, i.Interfaces)
+	}
+
+	data, _ := json.Marshal(fields)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// DeviceClockSkewThreshold is the maximum clock skew tolerated between a device and the server before the device is
+// considered unsynchronized, as it may break the tokens' validation and the ordering of the sessions' recordings.
+const DeviceClockSkewThreshold = 30 * time.Second
+
+// IsClockSkewed reports whether skew, in any direction, is above [DeviceClockSkewThreshold].
+func IsClockSkewed(skew time.Duration) bool {
+	return skew > DeviceClockSkewThreshold || skew < -DeviceClockSkewThreshold
 }
 
 type ConnectedDevice struct {
 	UID      string    `json:"uid"`
 	TenantID string    `json:"tenant_id" bson:"tenant_id"`
 	LastSeen time.Time `json:"last_seen" bson:"last_seen"`
+	// ConnectedAt is when the device's current online period started.
+	ConnectedAt time.Time `json:"-" bson:"connected_at,omitempty"`
+	// OfflineAt is when a device, whose connection was closed, will be considered offline. It is nil while the
+	// device's connection is open.
+	OfflineAt *time.Time `json:"-" bson:"offline_at,omitempty"`
 }
 
 type DevicePosition struct {
 	Latitude  float64 `json:"latitude" bson:"latitude"`
 	Longitude float64 `json:"longitude" bson:"longitude"`
+	// Geohash is the position's geohash, whose prefixes are used to cluster the devices shown on the map.
+	Geohash string `json:"-" bson:"geohash,omitempty"`
+}
+
+// NewDevicePosition creates a [DevicePosition] at the coordinate, with its geohash.
+func NewDevicePosition(latitude, longitude float64) *DevicePosition {
+	return &DevicePosition{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Geohash:   geohash.Encode(latitude, longitude, geohash.MaxPrecision),
+	}
 }
 
 type DeviceRemoved struct {
@@ -86,7 +260,7 @@ type DeviceRemoved struct {
 }
 
 type DeviceTag struct {
-	Tag string `validate:"required,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tag string `validate:"required,tag"`
 }
 
 func NewDeviceTag(tag string) DeviceTag {
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// DeviceAgentLog is a significant error, like a tunnel failure or a PTY that couldn't be spawned, reported by the
+// device's agent to the server, allowing operators to debug the device without shell access.
+type DeviceAgentLog struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the device's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// DeviceUID is the UID of the device whose agent has reported the log.
+	DeviceUID string `json:"device_uid" bson:"device_uid"`
+	// Level is the log's level on the agent, like "error" or "warning".
+	Level string `json:"level" bson:"level" validate:"required,oneof=panic fatal error warning"`
+	// Message is the log's message.
+	Message string `json:"message" bson:"message" validate:"required,max=1024"`
+	// Error is the error attached to the log, if any.
+	Error string `json:"error" bson:"error,omitempty" validate:"max=1024"`
+	// Time is when the log was recorded on the agent.
+	Time time.Time `json:"time" bson:"time"`
+	// CreatedAt is when the log was received by the server.
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// DeviceChangeType is the kind of change recorded on a device's [DeviceChange].
+type DeviceChangeType string
+
+const (
+	DeviceChangeCreated DeviceChangeType = "created"
+	DeviceChangeUpdated DeviceChangeType = "updated"
+	DeviceChangeDeleted DeviceChangeType = "deleted"
+)
+
+// DeviceChange is the last change on a device, kept so external systems, like CMDBs, can sync the namespace's devices
+// incrementally. Each device has only its last change, which gets a new sequence number each time the device changes,
+// so a device changed many times since a cursor is returned once. The changes of deleted devices are removed 30 days
+// after the deletion.
+type DeviceChange struct {
+	// Seq is the sequence number of the change, increasing across all changes. It's the cursor of the sync.
+	Seq       int64            `json:"-" bson:"seq"`
+	TenantID  string           `json:"-" bson:"tenant_id"`
+	UID       string           `json:"uid" bson:"uid"`
+	Type      DeviceChangeType `json:"type" bson:"type"`
+	ChangedAt time.Time        `json:"changed_at" bson:"changed_at"`
+	// ExpiresAt is when the change of a deleted device is removed.
+	ExpiresAt *time.Time `json:"-" bson:"expires_at,omitempty"`
+	// Device is the device's current record. It is nil when the device was deleted.
+	Device *Device `json:"device,omitempty" bson:"-"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"crypto/sha256"
+	"strings"
+)
+
+// deviceClaimCodeAlphabet is the Crockford's Base32 alphabet, which doesn't have letters easily mistaken by digits.
+const deviceClaimCodeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// DeviceClaimCodeLength is the number of characters of a device claim code, without the separator.
+const DeviceClaimCodeLength = 10
+
+// NewDeviceClaimCode derives the device's claim code from its public key, as sent by the agent. As both the agent and
+// the server can derive it, the code doesn't need to be exchanged between them.
+//
+// The code is formatted as two groups of five characters, like "7K3QF-M2XAD".
+func NewDeviceClaimCode(publicKey string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(publicKey)))
+
+	code := make([]byte, 0, DeviceClaimCodeLength+1)
+	for i := 0; i < DeviceClaimCodeLength; i++ {
+		if i == DeviceClaimCodeLength/2 {
+			code = append(code, '-')
+		}
+
+		// NOTICE: each character holds 5 bits of the sum, so 10 characters use the first 50 bits.
+		bit := i * 5
+		value := (uint16(sum[bit/8])<<8 | uint16(sum[bit/8+1])) >> (11 - bit%8) & 0x1f
+
+		code = append(code, deviceClaimCodeAlphabet[value])
+	}
+
+	return string(code)
+}
+
+// NormalizeDeviceClaimCode converts a claim code typed by a user to the format returned by [NewDeviceClaimCode],
+// ignoring the case, spaces and separators, and replacing the characters commonly mistaken by the ones on the
+// alphabet. It returns an empty string when the code is invalid.
+func NormalizeDeviceClaimCode(code string) string {
+	replacer := strings.NewReplacer("-", "", " ", "", "I", "1", "L", "1", "O", "0")
+	code = replacer.Replace(strings.ToUpper(code))
+
+	if len(code) != DeviceClaimCodeLength || strings.Trim(code, deviceClaimCodeAlphabet) != "" {
+		return ""
+	}
+
+	return code[:DeviceClaimCodeLength/2] + "-" + code[DeviceClaimCodeLength/2:]
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// DeviceCommandStatus is the status of a command queued to a device.
+type DeviceCommandStatus string
+
+const (
+	// DeviceCommandStatusQueued is the command waiting for the device's agent to fetch it.
+	DeviceCommandStatusQueued DeviceCommandStatus = "queued"
+	// DeviceCommandStatusSent is the command fetched by the device's agent, which hasn't reported its result yet.
+	DeviceCommandStatusSent DeviceCommandStatus = "sent"
+	// DeviceCommandStatusFinished is the command whose result was reported by the device's agent.
+	DeviceCommandStatusFinished DeviceCommandStatus = "finished"
+)
+
+// DeviceCommandOutputMaxSize is the maximum size, in bytes, of a queued command's output kept by the server.
+const DeviceCommandOutputMaxSize = 64 * 1024
+
+// DeviceCommand is a shell command queued to a device, possibly while it is offline, that the device's agent fetches
+// and executes when it authorizes on the server, reporting its exit code and output back.
+type DeviceCommand struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the device's namespace ID.
+	TenantID  string `json:"tenant_id" bson:"tenant_id"`
+	DeviceUID string `json:"device_uid" bson:"device_uid"`
+	// Command is the command line executed through the device's shell.
+	Command string `json:"command" bson:"command"`
+	// User is the device's user the command is executed as.
+	User   string              `json:"user" bson:"user"`
+	Status DeviceCommandStatus `json:"status" bson:"status"`
+	// ExitCode is the command's exit code, reported by the device's agent. It is meaningful only when the command is
+	// finished.
+	ExitCode int `json:"exit_code" bson:"exit_code"`
+	// Output is the command's combined standard output and error, limited to [DeviceCommandOutputMaxSize] bytes.
+	Output string `json:"output" bson:"output"`
+	// CreatedBy is the ID of the user who has queued the command.
+	CreatedBy string    `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// SentAt is when the command was fetched by the device's agent.
+	SentAt *time.Time `json:"sent_at,omitempty" bson:"sent_at,omitempty"`
+	// FinishedAt is when the command's result was reported by the device's agent.
+	FinishedAt *time.Time `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+}
+
+// DeviceCommandResult is the result of a queued command, reported by the device's agent after executing it.
+type DeviceCommandResult struct {
+	ExitCode int    `json:"exit_code"`
+	Output   string `json:"output" validate:"max=65536"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Keys of the device's configuration understood by the agent. The keys unknown by an agent are ignored by it.
+const (
+	// DeviceConfigLogLevel is the level of the agent's log, as one of [DeviceConfigLogLevels].
+	DeviceConfigLogLevel = "log_level"
+	// DeviceConfigForcedCommand is the command executed on the device's sessions instead of the shell or the command
+	// requested by the SSH client, which is available to it on the SSH_ORIGINAL_COMMAND environment variable.
+	DeviceConfigForcedCommand = "forced_command"
+	// DeviceConfigSFTP is the restriction on the device's SFTP sessions, as one of [DeviceConfigSFTPModes].
+	DeviceConfigSFTP = "sftp"
+)
+
+// DeviceConfigKeys are the keys of the device's configuration understood by the agent.
+var DeviceConfigKeys = []string{DeviceConfigLogLevel, DeviceConfigForcedCommand, DeviceConfigSFTP}
+
+// DeviceConfigLogLevels are the levels accepted by the [DeviceConfigLogLevel] key.
+var DeviceConfigLogLevels = []string{"trace", "debug", "info", "warn", "error"}
+
+const (
+	// DeviceConfigSFTPEnabled allows the device's SFTP sessions without restrictions.
+	DeviceConfigSFTPEnabled = "enabled"
+	// DeviceConfigSFTPReadOnly allows the device's SFTP sessions, refusing the operations that write to the device.
+	DeviceConfigSFTPReadOnly = "read-only"
+	// DeviceConfigSFTPDisabled refuses the device's SFTP sessions.
+	DeviceConfigSFTPDisabled = "disabled"
+)
+
+// DeviceConfigSFTPModes are the restrictions accepted by the [DeviceConfigSFTP] key.
+var DeviceConfigSFTPModes = []string{DeviceConfigSFTPEnabled, DeviceConfigSFTPReadOnly, DeviceConfigSFTPDisabled}
+
+// DeviceConfig is the configuration of a device, managed on the server and delivered to its agent on each
+// authorization, which applies it without being restarted.
+type DeviceConfig struct {
+	Values map[string]string `json:"values" bson:"values"`
+	// Version is incremented each time the configuration is changed. The version applied by the agent is reported on
+	// its authorization and kept on [Device.ConfigVersion], so the devices behind the latest configuration are known.
+	Version   int       `json:"version" bson:"version"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// IsDeviceConfigValueValid reports whether value is accepted by the device's configuration key.
+func IsDeviceConfigValueValid(key, value string) bool {
+	switch key {
+	case DeviceConfigLogLevel:
+		return slices.Contains(DeviceConfigLogLevels, value)
+	case DeviceConfigForcedCommand:
+		return value != ""
+	case DeviceConfigSFTP:
+		return slices.Contains(DeviceConfigSFTPModes, value)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// DeviceConnection is the measure of the agent's connection to the server, reported on its pings.
+type DeviceConnection struct {
+	// RTT is the round-trip time, in milliseconds, of the agent's previous ping.
+	RTT int64 `json:"rtt"`
+	// Reconnects is the number of times the agent's tunnel was reconnected since its previous ping.
+	Reconnects int `json:"reconnects"`
+}
+
+// DeviceConnectionSamplesMax is the maximum number of samples kept on the device's connection rolling window.
+const DeviceConnectionSamplesMax = 12
+
+// DeviceConnectionSample is an entry on the device's connection rolling window.
+type DeviceConnectionSample struct {
+	RTT        int64     `json:"rtt" bson:"rtt"`
+	Reconnects int       `json:"reconnects" bson:"reconnects"`
+	ReportedAt time.Time `json:"reported_at" bson:"reported_at"`
+}
+
+// DeviceConnectionQualityLevel is the indicator of how reliable the device's connection to the server is.
+type DeviceConnectionQualityLevel string
+
+const (
+	DeviceConnectionQualityGood DeviceConnectionQualityLevel = "good"
+	DeviceConnectionQualityFair DeviceConnectionQualityLevel = "fair"
+	DeviceConnectionQualityPoor DeviceConnectionQualityLevel = "poor"
+)
+
+const (
+	// DeviceConnectionFairRTT is the average round-trip time, in milliseconds, from which a connection is fair.
+	DeviceConnectionFairRTT = 150
+	// DeviceConnectionPoorRTT is the average round-trip time, in milliseconds, from which a connection is poor.
+	DeviceConnectionPoorRTT = 500
+	// DeviceConnectionPoorReconnects is the number of reconnects on the rolling window from which a connection is
+	// poor. Any reconnect makes it, at least, fair.
+	DeviceConnectionPoorReconnects = 3
+)
+
+// DeviceConnectionQuality summarizes the samples on the device's connection rolling window.
+type DeviceConnectionQuality struct {
+	Level DeviceConnectionQualityLevel `json:"level" bson:"level"`
+	// RTT is the average round-trip time, in milliseconds, of the samples.
+	RTT int64 `json:"rtt" bson:"rtt"`
+	// Reconnects is the total of tunnel reconnects of the samples.
+	Reconnects int `json:"reconnects" bson:"reconnects"`
+	// Samples is the number of samples summarized.
+	Samples int `json:"samples" bson:"samples"`
+}
+
+// NewDeviceConnectionQuality summarizes the samples of a device's connection rolling window. It returns nil when there
+// are no samples.
+func NewDeviceConnectionQuality(samples []DeviceConnectionSample) *DeviceConnectionQuality {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	quality := &DeviceConnectionQuality{Samples: len(samples)}
+
+	var rtt int64
+	for _, sample := range samples {
+		rtt += sample.RTT
+		quality.Reconnects += sample.Reconnects
+	}
+
+	quality.RTT = rtt / int64(len(samples))
+
+	switch {
+	case quality.RTT >= DeviceConnectionPoorRTT, quality.Reconnects >= DeviceConnectionPoorReconnects:
+		quality.Level = DeviceConnectionQualityPoor
+	case quality.RTT >= DeviceConnectionFairRTT, quality.Reconnects > 0:
+		quality.Level = DeviceConnectionQualityFair
+	default:
+		quality.Level = DeviceConnectionQualityGood
+	}
+
+	return quality
+}
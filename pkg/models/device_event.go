@@ -0,0 +1,40 @@
+package models
+
+// DeviceEventType is the kind of change that happened to a device.
+type DeviceEventType string
+
+const (
+	// DeviceEventCreated means that a new device was registered on the namespace.
+	DeviceEventCreated DeviceEventType = "created"
+	DeviceEventOnline  DeviceEventType = "online"
+	DeviceEventOffline DeviceEventType = "offline"
+	DeviceEventStatus  DeviceEventType = "status"
+	DeviceEventName    DeviceEventType = "name"
+	DeviceEventTags    DeviceEventType = "tags"
+	DeviceEventRemoved DeviceEventType = "removed"
+	// DeviceEventAcceptedLimit means that the device couldn't be accepted because the namespace has reached its
+	// maximum number of accepted devices.
+	DeviceEventAcceptedLimit DeviceEventType = "accepted_limit"
+	// DeviceEventPendingLimit means that a new device couldn't be registered because the namespace has reached its
+	// maximum number of devices waiting for acceptance. The device doesn't exist on the namespace.
+	DeviceEventPendingLimit DeviceEventType = "pending_limit"
+	// DeviceEventQueue means that the device was added to, changed on or removed from the namespace's acceptance
+	// queue, without being accepted.
+	DeviceEventQueue DeviceEventType = "queue"
+	// DeviceEventQuarantine means that the rejected device tried to authenticate in a namespace that quarantines its
+	// rejected devices.
+	DeviceEventQuarantine DeviceEventType = "quarantine"
+	// DeviceEventResync means that the subscriber may have missed changes, like when many devices changed at once or
+	// it could not keep up with the events, and must list the devices again.
+	DeviceEventResync DeviceEventType = "resync"
+)
+
+// DeviceEvent notifies a change on a device's state.
+type DeviceEvent struct {
+	Type     DeviceEventType `json:"type"`
+	UID      string          `json:"uid,omitempty"`
+	TenantID string          `json:"tenant_id"`
+	// Device is the device's state after the change. It is empty on events not related to a single device or when
+	// the device does not exist anymore.
+	Device *Device `json:"device,omitempty"`
+}
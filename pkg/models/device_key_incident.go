@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+type DeviceKeyIncidentStatus string
+
+const (
+	// DeviceKeyIncidentStatusOpen is the status of an incident waiting for an admin's review.
+	DeviceKeyIncidentStatusOpen DeviceKeyIncidentStatus = "open"
+	// DeviceKeyIncidentStatusApproved is the status of an incident where the new public key was trusted by an admin.
+	DeviceKeyIncidentStatusApproved DeviceKeyIncidentStatus = "approved"
+	// DeviceKeyIncidentStatusRejected is the status of an incident where the new public key was rejected by an admin.
+	DeviceKeyIncidentStatusRejected DeviceKeyIncidentStatus = "rejected"
+)
+
+// DeviceKeyIncident is a security incident opened when a device, in a namespace with the device key pinning enabled,
+// tries to authenticate with a public key different from the one pinned by its accepted device. While the incident
+// isn't approved, the device cannot authenticate using the new public key.
+type DeviceKeyIncident struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the incident's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// DeviceUID is the UID of the accepted device that has pinned the public key.
+	DeviceUID string `json:"device_uid" bson:"device_uid"`
+	// MAC is the MAC address used to identify the device.
+	MAC string `json:"mac" bson:"mac"`
+	// PinnedPublicKey is the public key pinned by the accepted device.
+	PinnedPublicKey string `json:"pinned_public_key" bson:"pinned_public_key"`
+	// PublicKey is the new public key presented by the device.
+	PublicKey string `json:"public_key" bson:"public_key"`
+	// RemoteAddr is the IP address where the new public key was presented from.
+	RemoteAddr string                  `json:"remote_addr" bson:"remote_addr"`
+	Status     DeviceKeyIncidentStatus `json:"status" bson:"status"`
+	CreatedAt  time.Time               `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time               `json:"updated_at" bson:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// DeviceLimitExemption is a change on the exemption of a device from the namespace's maximum number of devices, kept
+// as the audit trail of who exempted the device, or revoked its exemption, and why.
+type DeviceLimitExemption struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the device's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// DeviceUID is the UID of the device whose exemption was changed.
+	DeviceUID string `json:"device_uid" bson:"device_uid"`
+	// Exempt is whether the device was exempted or its exemption was revoked.
+	Exempt bool `json:"exempt" bson:"exempt"`
+	// UserID is the ID of the user who changed the exemption.
+	UserID string `json:"user_id" bson:"user_id"`
+	// Reason is the justification given by the user, if any.
+	Reason    string    `json:"reason" bson:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// DevicePort is a TCP or UDP port listening on a device, as reported by the agent, which the users may forward or
+// publish without opening a shell on the device first.
+type DevicePort struct {
+	// Protocol is the port's transport protocol, "tcp" or "udp".
+	Protocol string `json:"protocol"`
+	// Address is the local address the port is bound to, like "0.0.0.0" or "::1".
+	Address string `json:"address"`
+	Port    int    `json:"port"`
+	// Process is the name of the process listening on the port. It is empty when the agent isn't permitted to see it.
+	Process string `json:"process,omitempty"`
+	// PID is the identifier of the process listening on the port. It is zero when the agent isn't permitted to see it.
+	PID int `json:"pid,omitempty"`
+}
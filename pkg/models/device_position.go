@@ -0,0 +1,15 @@
+package models
+
+// DevicePositionCluster is a group of the namespace's devices positioned on the same geohash cell.
+type DevicePositionCluster struct {
+	// Geohash is the cell's geohash, whose length depends on the map's zoom.
+	Geohash string `json:"geohash" bson:"_id"`
+	// Latitude is the average latitude of the devices on the cell.
+	Latitude float64 `json:"latitude" bson:"latitude"`
+	// Longitude is the average longitude of the devices on the cell.
+	Longitude float64 `json:"longitude" bson:"longitude"`
+	// Count is the number of devices on the cell.
+	Count int `json:"count" bson:"count"`
+	// Device is the UID of the device when it is alone on the cell, so it can be shown without another request.
+	Device string `json:"device,omitempty" bson:"device,omitempty"`
+}
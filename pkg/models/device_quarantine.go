@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// DeviceQuarantineAttempt is an authentication attempt of a rejected device, refused by a namespace that quarantines
+// its rejected devices. The attempt keeps what the device has presented, so cloned tenant IDs and misconfigured images
+// trying to join the namespace can be traced back to their origin. Attempts are removed 30 days after they were made.
+type DeviceQuarantineAttempt struct {
+	ID        string `json:"id" bson:"_id"`
+	TenantID  string `json:"tenant_id" bson:"tenant_id"`
+	DeviceUID string `json:"device_uid" bson:"device_uid"`
+	// Hostname is the hostname presented by the device.
+	Hostname string `json:"hostname" bson:"hostname"`
+	// MAC is the MAC address used to identify the device.
+	MAC       string `json:"mac" bson:"mac"`
+	PublicKey string `json:"public_key" bson:"public_key"`
+	// RemoteAddr is the IP address where the attempt came from, and Country, its ISO code, when it could be located.
+	RemoteAddr string      `json:"remote_addr" bson:"remote_addr"`
+	Country    string      `json:"country,omitempty" bson:"country,omitempty"`
+	Info       *DeviceInfo `json:"info" bson:"info"`
+	CreatedAt  time.Time   `json:"created_at" bson:"created_at"`
+}
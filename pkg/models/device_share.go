@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// DeviceSharePermission is what a [DeviceShare] allows the grantee namespace to do with the shared device.
+type DeviceSharePermission string
+
+const (
+	// DeviceSharePermissionRead only lists the device on the grantee namespace.
+	DeviceSharePermissionRead DeviceSharePermission = "read"
+	// DeviceSharePermissionConnect also allows the grantee namespace's members to connect to the device over SSH.
+	DeviceSharePermissionConnect DeviceSharePermission = "connect"
+)
+
+// DeviceShare grants another namespace, the grantee, access to one of the namespace's devices, so teams needing the
+// same box don't run an agent each. The shared device is listed on the grantee namespace, and, when the share allows
+// it, the grantee's members connect to it through their own namespace, as "user@grantee.device", authenticated by the
+// grantee's public keys.
+//
+// The device still belongs to its namespace, whose policies apply to the sessions, and the share stops granting
+// access when it expires or is deleted.
+type DeviceShare struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the namespace owning the device.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// UID is the shared device's UID.
+	UID string `json:"uid" bson:"uid"`
+	// GranteeTenantID is the namespace the device is shared with.
+	GranteeTenantID string                `json:"grantee_tenant_id" bson:"grantee_tenant_id"`
+	Permission      DeviceSharePermission `json:"permission" bson:"permission"`
+	// CreatedBy is the ID of the user who shared the device.
+	CreatedBy string    `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// ExpiresAt is when the share stops granting access. It is nil when the share doesn't expire.
+	ExpiresAt *time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+// Active reports if the share grants access at the instant.
+func (s *DeviceShare) Active(at time.Time) bool {
+	return s.ExpiresAt == nil || at.Before(*s.ExpiresAt)
+}
+
+// AllowsConnect reports if the share allows the grantee's members to connect to the device.
+func (s *DeviceShare) AllowsConnect() bool {
+	return s.Permission == DeviceSharePermissionConnect
+}
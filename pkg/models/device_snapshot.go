@@ -0,0 +1,114 @@
+package models
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// DeviceSnapshotInterval is the maximum interval between two snapshots of a device whose information hasn't changed,
+// so the device's information at a point in time is still known after the oldest snapshots expire.
+const DeviceSnapshotInterval = 24 * time.Hour
+
+// DeviceSnapshot is the information reported by a device at a point in time. A snapshot is taken when the device
+// authorizes with an information different from its latest snapshot's, or when the latest one is older than
+// [DeviceSnapshotInterval]; so, the device's information at a point in time is the one on the latest snapshot taken
+// before it.
+type DeviceSnapshot struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the device's namespace ID.
+	TenantID  string          `json:"tenant_id" bson:"tenant_id"`
+	DeviceUID string          `json:"device_uid" bson:"device_uid"`
+	Identity  *DeviceIdentity `json:"identity" bson:"identity"`
+	Info      *DeviceInfo     `json:"info" bson:"info"`
+	// ConfigVersion is the version of the device's configuration applied by its agent.
+	ConfigVersion int       `json:"config_version" bson:"config_version"`
+	TakenAt       time.Time `json:"taken_at" bson:"taken_at"`
+}
+
+// DeviceSnapshotChange is a field changed between two snapshots of a device.
+type DeviceSnapshotChange struct {
+	// Field is the changed field's path, like "info.version" or "info.interfaces.eth0".
+	Field string `json:"field"`
+	// From is the field's value on the older snapshot. It is nil when the field was added.
+	From any `json:"from"`
+	// To is the field's value on the newer snapshot. It is nil when the field was removed.
+	To any `json:"to"`
+}
+
+// DeviceDiff is what changed on the information reported by a device between two points in time.
+type DeviceDiff struct {
+	// From is the device's snapshot at the older point in time. It is nil when no snapshot was taken before it.
+	From *DeviceSnapshot `json:"from"`
+	// To is the device's snapshot at the newer point in time. It is nil when no snapshot was taken before it.
+	To      *DeviceSnapshot        `json:"to"`
+	Changes []DeviceSnapshotChange `json:"changes"`
+}
+
+// Diff returns the fields changed from the snapshot to the other, sorted by their paths. Any of the snapshots may be
+// nil, what has no fields.
+//
+// The device's clock skew isn't compared, as it changes on almost every report.
+func (s *DeviceSnapshot) Diff(other *DeviceSnapshot) []DeviceSnapshotChange {
+	from, to := s.fields(), other.fields()
+
+	paths := make([]string, 0, len(from)+len(to))
+	for path := range from {
+		paths = append(paths, path)
+	}
+
+	for path := range to {
+		if _, ok := from[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	changes := make([]DeviceSnapshotChange, 0)
+	for _, path := range paths {
+		if !reflect.DeepEqual(from[path], to[path]) {
+			changes = append(changes, DeviceSnapshotChange{Field: path, From: from[path], To: to[path]})
+		}
+	}
+
+	return changes
+}
+
+// fields flattens the snapshot's compared fields, keyed by their paths.
+func (s *DeviceSnapshot) fields() map[string]any {
+	fields := make(map[string]any)
+	if s == nil {
+		return fields
+	}
+
+	fields["config_version"] = s.ConfigVersion
+
+	if s.Identity != nil {
+		fields["identity.mac"] = s.Identity.MAC
+	}
+
+	if info := s.Info; info != nil {
+		fields["info.id"] = info.ID
+		fields["info.pretty_name"] = info.PrettyName
+		fields["info.version"] = info.Version
+		fields["info.arch"] = info.Arch
+		fields["info.platform"] = info.Platform
+
+		if hardware := info.Hardware; hardware != nil {
+			fields["info.hardware.cpu"] = hardware.CPU
+			fields["info.hardware.memory"] = hardware.Memory
+			fields["info.hardware.disks"] = hardware.Disks
+			fields["info.hardware.serial"] = hardware.Serial
+			fields["info.hardware.product"] = hardware.Product
+		}
+
+		// NOTICE: the interfaces are compared one by one, so an added or removed interface doesn't show the others as
+		// changed.
+		for _, iface := range info.Interfaces {
+			fields["info.interfaces."+iface.Name] = iface
+		}
+	}
+
+	return fields
+}
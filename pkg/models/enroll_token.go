@@ -0,0 +1,52 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// EnrollTokenMaxUses is the maximum number of devices an enroll token may enroll.
+	EnrollTokenMaxUses = 10000
+	// EnrollTokenMaxTTL is the longest an enroll token may be valid for.
+	EnrollTokenMaxTTL = 365 * 24 * time.Hour
+)
+
+// EnrollToken enrolls devices on a namespace without distributing its tenant ID. The agents present the token instead
+// of the tenant ID when they authenticate, and each device enrolled uses the token once, until it expires or is used
+// up. A leaked token is revoked, without rotating the tenant.
+//
+// The token itself is never stored, only its digest, so it is returned only when created.
+type EnrollToken struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the namespace the devices are enrolled on.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Digest is the [EnrollTokenDigest] of the token.
+	Digest string `json:"-" bson:"digest"`
+	// MaxUses is how many devices the token may enroll.
+	MaxUses int `json:"max_uses" bson:"max_uses"`
+	// Uses is how many devices the token has enrolled.
+	Uses int `json:"uses" bson:"uses"`
+	// CreatedBy is the ID of the user who created the token.
+	CreatedBy string    `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// ExpiresAt is when the token can no longer enroll devices.
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+	// RevokedAt is when the token was revoked. A revoked token can no longer enroll devices, but it still identifies
+	// the namespace of the devices it has enrolled, so they aren't disconnected when it is revoked.
+	RevokedAt *time.Time `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+}
+
+// Usable reports if the token may enroll a device at the instant.
+func (t *EnrollToken) Usable(at time.Time) bool {
+	return t.RevokedAt == nil && t.Uses < t.MaxUses && at.Before(t.ExpiresAt)
+}
+
+// EnrollTokenDigest returns the digest the token is stored by. As the token is a random value, its SHA256 digest is
+// enough to find it without keeping the token itself.
+func EnrollTokenDigest(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
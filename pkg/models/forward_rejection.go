@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ForwardRejectionReason is why the agent's forwarding policy rejected a direct-tcpip channel.
+type ForwardRejectionReason string
+
+const (
+	// ForwardRejectionDenied means the destination matches one of the policy's denied destinations.
+	ForwardRejectionDenied ForwardRejectionReason = "denied"
+	// ForwardRejectionNotAllowed means the policy allows only some destinations, and the destination isn't one of them.
+	ForwardRejectionNotAllowed ForwardRejectionReason = "not-allowed"
+)
+
+// forwardRejectionPrefix identifies the rejection messages sent by the agent's forwarding policy.
+const forwardRejectionPrefix = "forward-policy"
+
+// ForwardRejection is the rejection of a direct-tcpip channel by the agent's forwarding policy. It is sent to the SSH
+// server as the channel's rejection message, on the form "forward-policy reason=<reason> destination=<host:port>", so
+// the server can tell it apart from a failure to reach the destination.
+type ForwardRejection struct {
+	Reason ForwardRejectionReason
+	// Destination is the address and port the channel was requested to, as "host:port".
+	Destination string
+}
+
+// Message encodes the rejection as the channel's rejection message.
+func (r *ForwardRejection) Message() string {
+	return fmt.Sprintf("%s reason=%s destination=%s", forwardRejectionPrefix, r.Reason, r.Destination)
+}
+
+// ParseForwardRejection decodes a channel's rejection message sent by the agent's forwarding policy. It returns false
+// when the message wasn't sent by the policy.
+func ParseForwardRejection(message string) (*ForwardRejection, bool) {
+	fields := strings.Fields(message)
+	if len(fields) == 0 || fields[0] != forwardRejectionPrefix {
+		return nil, false
+	}
+
+	rejection := new(ForwardRejection)
+	for _, field := range fields[1:] {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "reason":
+			rejection.Reason = ForwardRejectionReason(value)
+		case "destination":
+			rejection.Destination = value
+		}
+	}
+
+	if rejection.Reason == "" {
+		return nil, false
+	}
+
+	return rejection, true
+}
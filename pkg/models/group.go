@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Group is a named set of the namespace's devices. The groups are hierarchical, as a group may be nested into another
+// one, and a device may belong to many groups.
+type Group struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the group's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// ParentID is the ID of the group the group is nested into. It is empty for a top-level group.
+	ParentID    string    `json:"parent_id" bson:"parent_id"`
+	Name        string    `json:"name" bson:"name"`
+	Description string    `json:"description" bson:"description"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+}
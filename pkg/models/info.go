@@ -3,9 +3,37 @@ package models
 type Info struct {
 	Version   string    `json:"version"`
 	Endpoints Endpoints `json:"endpoints"`
+	Features  Features  `json:"features"`
+}
+
+// Features are the optional behaviors supported by the server, so the agent doesn't rely on them when connected to an
+// older one.
+type Features struct {
+	// DeviceInfoHash indicates the device's information may be reported by its [DeviceInfo.Hash] on the authorization,
+	// when it is unchanged.
+	DeviceInfoHash bool `json:"device_info_hash"`
 }
 
 type Endpoints struct {
 	API string `json:"api"`
 	SSH string `json:"ssh"`
+	// Tunnel is the path prefix where the agent opens the reverse tunnel. It is empty on servers that don't advertise
+	// it, which serve the tunnel under "/ssh".
+	Tunnel string `json:"tunnel,omitempty"`
+	// Gateways are the regional SSH gateways where the reverse tunnel may be opened. When there are gateways, the agent
+	// opens the tunnel to the nearest one, failing over to the others. It is empty on servers that don't advertise
+	// them, where the tunnel is opened to the server's address.
+	Gateways []Gateway `json:"gateways,omitempty"`
+}
+
+// Gateway is a regional SSH gateway advertised by the server.
+type Gateway struct {
+	// Region labels the gateway's location.
+	Region string `json:"region"`
+	// URL is the address, with the scheme, where the reverse tunnel is opened to the gateway.
+	URL string `json:"url"`
+	// SSH is the gateway's SSH endpoint, used on the device's SSHID. When empty, the server's SSH endpoint is used.
+	SSH string `json:"ssh,omitempty"`
+	// Latency is the expected latency, in milliseconds, to the gateway, hinted by the server.
+	Latency int `json:"latency,omitempty"`
 }
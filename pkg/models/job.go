@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// JobOperation is the operation an asynchronous [Job] executes on each one of its items.
+type JobOperation string
+
+const (
+	// JobOperationDeviceAccept accepts each one of the job's devices.
+	JobOperationDeviceAccept JobOperation = "device.accept"
+	// JobOperationDeviceTag adds the job's tag to each one of its devices.
+	JobOperationDeviceTag JobOperation = "device.tag"
+	// JobOperationDeviceExport exports the data of each one of the job's devices to its items.
+	JobOperationDeviceExport JobOperation = "device.export"
+	// JobOperationDeviceRefresh refreshes the information derived from each one of the job's devices, like its
+	// position and the tags of the namespace's tag rules.
+	JobOperationDeviceRefresh JobOperation = "device.refresh"
+)
+
+type JobStatus string
+
+const (
+	// JobStatusQueued is the status of a job waiting for the worker.
+	JobStatusQueued JobStatus = "queued"
+	// JobStatusRunning is the status of a job being executed by the worker.
+	JobStatusRunning JobStatus = "running"
+	// JobStatusCompleted is the status of a job whose items were all processed, even if some of them failed.
+	JobStatusCompleted JobStatus = "completed"
+	// JobStatusFailed is the status of a job that couldn't be executed at all.
+	JobStatusFailed JobStatus = "failed"
+)
+
+type JobItemStatus string
+
+const (
+	JobItemStatusPending   JobItemStatus = "pending"
+	JobItemStatusSucceeded JobItemStatus = "succeeded"
+	JobItemStatusFailed    JobItemStatus = "failed"
+)
+
+// Job is a heavy operation on a list of items, like the acceptance of thousands of devices, executed by the worker
+// instead of the request that created it, so the request doesn't time out on the gateway. Jobs are removed a week
+// after they were created.
+type Job struct {
+	ID        string       `json:"id" bson:"_id"`
+	TenantID  string       `json:"tenant_id" bson:"tenant_id"`
+	UserID    string       `json:"user_id" bson:"user_id"`
+	Operation JobOperation `json:"operation" bson:"operation"`
+	// IdempotencyKey is the key, chosen by the client, that identifies the job on the namespace. A job created again
+	// with the same key isn't created twice, what allows the client to retry the creation safely.
+	IdempotencyKey string `json:"idempotency_key,omitempty" bson:"idempotency_key,omitempty"`
+	// Tag is the tag added to the devices by the [JobOperationDeviceTag] operation.
+	Tag    string    `json:"tag,omitempty" bson:"tag,omitempty"`
+	Status JobStatus `json:"status" bson:"status"`
+	// Error is the reason the job has [JobStatusFailed].
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+	// Total is the number of the job's items.
+	Total int `json:"total" bson:"total"`
+	// Processed is the number of the job's items already processed, succeeded or failed.
+	Processed int `json:"processed" bson:"processed"`
+	// Failed is the number of the job's items that failed.
+	Failed     int        `json:"failed" bson:"failed"`
+	Items      []JobItem  `json:"items" bson:"items"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	StartedAt  *time.Time `json:"started_at" bson:"started_at"`
+	FinishedAt *time.Time `json:"finished_at" bson:"finished_at"`
+}
+
+// JobItem is the result of a [Job]'s operation on one of its items.
+type JobItem struct {
+	UID    string        `json:"uid" bson:"uid"`
+	Status JobItemStatus `json:"status" bson:"status"`
+	// Error is the reason the operation failed on the item.
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+	// Device is the device's data, exported by the [JobOperationDeviceExport] operation.
+	Device *Device `json:"device,omitempty" bson:"device,omitempty"`
+}
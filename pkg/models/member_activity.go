@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+)
+
+// MemberActivity summarizes the activity of a namespace's member over a window of time, supporting the periodic
+// access reviews required by security policies.
+type MemberActivity struct {
+	ID       string          `json:"id"`
+	Username string          `json:"username"`
+	Email    string          `json:"email"`
+	Role     authorizer.Role `json:"role"`
+	AddedAt  time.Time       `json:"added_at"`
+	// LastLogin is when the member last logged in, on any namespace. It is nil when the member never logged in.
+	LastLogin *time.Time `json:"last_login"`
+	// From and To are the boundaries of the report's window.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Sessions are the member's sessions, on any namespace, active during the window, the most recently active first.
+	Sessions []UserSession `json:"sessions"`
+	// Actions are the changes made by the member on the namespace during the window, the most recent first.
+	Actions []MemberAction `json:"actions"`
+}
+
+// MemberAction is a change made by a member on the namespace.
+type MemberAction struct {
+	// Action identifies the change, like "api_key.create".
+	Action string `json:"action"`
+	// Target identifies the resource changed, like the API key's name or the device's UID.
+	Target    string    `json:"target"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	MemberActionAPIKeyCreate            = "api_key.create"
+	MemberActionDeviceLimitExempt       = "device.limit_exempt"
+	MemberActionDeviceLimitExemptRevoke = "device.limit_exempt_revoke"
+	MemberActionSessionScheduleOverride = "device.session_schedule_override"
+)
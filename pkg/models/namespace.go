@@ -15,6 +15,23 @@ type Namespace struct {
 	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
 	Billing      *Billing           `json:"billing" bson:"billing,omitempty"`
 	Type         Type               `json:"type" bson:"type"`
+
+	// MaxMembers is the maximum number of members, including the pending invitations, allowed in the namespace. When
+	// zero, the instance's default is used; when negative, the number of members is unlimited.
+	MaxMembers int `json:"max_members" bson:"max_members,omitempty"`
+	// MaxInvitations is the maximum number of pending invitations allowed in the namespace. When zero, the instance's
+	// default is used; when negative, the number of pending invitations is unlimited.
+	MaxInvitations int `json:"max_invitations" bson:"max_invitations,omitempty"`
+	// MembersCount is the number of members, including the pending invitations, in the namespace.
+	MembersCount int `json:"members_count" bson:"-"`
+	// InvitationsCount is the number of pending invitations in the namespace.
+	InvitationsCount int `json:"invitations_count" bson:"-"`
+	// MaxPendingDevices is the maximum number of devices waiting for acceptance in the namespace. When zero or
+	// negative, the number of pending devices is unlimited.
+	MaxPendingDevices int `json:"max_pending_devices" bson:"max_pending_devices,omitempty"`
+	// LimitExemptDevicesCount is the number of accepted devices exempt from the namespace's maximum number of devices.
+	// They are included in DevicesCount.
+	LimitExemptDevicesCount int `json:"limit_exempt_devices_count" bson:"-"`
 }
 
 // HasMaxDevices checks if the namespace has a maximum number of devices.
@@ -25,16 +42,40 @@ func (n *Namespace) HasMaxDevices() bool {
 	return n.MaxDevices > 0
 }
 
-// HasMaxDevicesReached checks if the namespace has reached the maximum number of devices.
+// HasMaxDevicesReached checks if the namespace has reached the maximum number of devices. The devices exempt from the
+// limit aren't counted.
 func (n *Namespace) HasMaxDevicesReached() bool {
-	return n.DevicesCount >= n.MaxDevices
+	return n.DevicesCount-n.LimitExemptDevicesCount >= n.MaxDevices
+}
+
+// HasMaxPendingDevices checks if the namespace has a maximum number of devices waiting for acceptance.
+func (n *Namespace) HasMaxPendingDevices() bool {
+	return n.MaxPendingDevices > 0
 }
 
 // HasLimitDevicesReached checks if the namespace limit was reached using the removed devices collection.
 //
 // This method is intended to be run only when the ShellHub instance is Cloud.
 func (n *Namespace) HasLimitDevicesReached(removed int64) bool {
-	return int64(n.DevicesCount)+removed >= int64(n.MaxDevices)
+	return int64(n.DevicesCount-n.LimitExemptDevicesCount)+removed >= int64(n.MaxDevices)
+}
+
+// CountMembers counts the members and the pending invitations in the namespace. Members whose invitation has expired
+// at now aren't counted.
+func (n *Namespace) CountMembers(now time.Time) (members int, invitations int) {
+	for _, member := range n.Members {
+		if member.Status == MemberStatusPending {
+			if !member.ExpiresAt.IsZero() && member.ExpiresAt.Before(now) {
+				continue
+			}
+
+			invitations++
+		}
+
+		members++
+	}
+
+	return members, invitations
 }
 
 // FindMember checks if a member with the specified ID exists in the namespace.
@@ -51,12 +92,88 @@ func (n *Namespace) FindMember(id string) (*Member, bool) {
 type NamespaceSettings struct {
 	SessionRecord          bool   `json:"session_record" bson:"session_record,omitempty"`
 	ConnectionAnnouncement string `json:"connection_announcement" bson:"connection_announcement"`
+	// DeviceKeyPinning defines if the public key of an accepted device is pinned. When enabled, any change in the
+	// device's public key is rejected and a [DeviceKeyIncident] is opened until an admin approves the new key.
+	DeviceKeyPinning bool `json:"device_key_pinning" bson:"device_key_pinning,omitempty"`
+	// WebSessionMaxDuration is the maximum duration, in seconds, of a web terminal session. When it is zero, the web
+	// sessions are only limited by the validity of the user's token.
+	WebSessionMaxDuration int `json:"web_session_max_duration" bson:"web_session_max_duration,omitempty"`
+	// DeviceGeoAlert defines if the namespace's owner is notified by email when a device starts to connect from a
+	// country different from the previous one.
+	DeviceGeoAlert bool `json:"device_geo_alert" bson:"device_geo_alert,omitempty"`
+	// DeviceNameTemplate is a text template used to name the devices when they are accepted, instead of keeping the
+	// name they were registered with. When it is empty, the devices keep their names.
+	DeviceNameTemplate string `json:"device_name_template" bson:"device_name_template,omitempty"`
+	// RecordWatermark defines how the recorded sessions exported or streamed for playback are watermarked with the
+	// viewer who requested them. When it is empty, the recordings aren't watermarked.
+	RecordWatermark RecordWatermark `json:"record_watermark" bson:"record_watermark,omitempty"`
+	// DefaultTags are the tags applied to the devices when they are accepted, so automations keyed on tags can pick up
+	// the new devices. The device's own tags are kept, up to the device's tags limit.
+	DefaultTags []string `json:"default_tags" bson:"default_tags,omitempty"`
+	// SessionKeepAlive defines if the SSH server sends keep-alive requests to the clients of the idle interactive
+	// sessions, so the NATs and firewalls between them don't expire the connection's state between keystrokes.
+	SessionKeepAlive bool `json:"session_keep_alive" bson:"session_keep_alive,omitempty"`
+	// SessionSchedules restrict the SSH connections to the namespace's devices to the windows of the week. A device
+	// restricted by more than one schedule only accepts connections inside the windows of all of them.
+	SessionSchedules []SessionSchedule `json:"session_schedules" bson:"session_schedules,omitempty"`
+	// DeviceQuarantine defines if the rejected devices are quarantined: their authentication attempts are refused and
+	// recorded as [DeviceQuarantineAttempt], and the namespace's owner is alerted, instead of being silently ignored.
+	DeviceQuarantine bool `json:"device_quarantine" bson:"device_quarantine,omitempty"`
+	// SessionRecordPause defines if the users connected to the namespace's devices may pause the recording of their
+	// sessions, like while entering secrets. The pauses and resumes are marked on the recordings.
+	SessionRecordPause bool `json:"session_record_pause" bson:"session_record_pause,omitempty"`
+	// SessionAttestation defines if the namespace's sessions are attested when completed: a digest of their metadata
+	// and recording is signed by the server, so the exported audit evidence can be proven untampered.
+	SessionAttestation bool `json:"session_attestation" bson:"session_attestation,omitempty"`
+	// MaxSessions is the maximum number of simultaneous interactive sessions to the namespace's devices. When it is
+	// zero, the interactive sessions aren't limited.
+	MaxSessions int `json:"max_sessions" bson:"max_sessions,omitempty"`
+	// SSHCertificateAuthorities are the CAs whose signed OpenSSH user certificates authenticate the SSH connections to
+	// the namespace's devices. The first one is the current CA; the others were rotated and are trusted until their
+	// grace period ends.
+	SSHCertificateAuthorities []SSHCertificateAuthority `json:"ssh_certificate_authorities" bson:"ssh_certificate_authorities,omitempty"`
+	// ConnectionWebhook is the endpoint asked to allow or deny each SSH connection to the namespace's devices before
+	// the session is established. When it is nil, or has no URL, the connections aren't sent to any webhook.
+	ConnectionWebhook *ConnectionWebhook `json:"connection_webhook" bson:"connection_webhook,omitempty"`
+	// UserProvisioning allows the agents to create the local users targeted by the SSH sessions to the namespace's
+	// devices when they don't exist. When it is nil, the users aren't provisioned.
+	UserProvisioning *UserProvisioning `json:"user_provisioning" bson:"user_provisioning,omitempty"`
 }
 
+// RecordWatermark is how a recorded session is watermarked with its viewer on playback.
+type RecordWatermark string
+
+const (
+	// RecordWatermarkMetadata embeds the watermark in the recording's metadata, keeping the terminal output intact.
+	RecordWatermarkMetadata RecordWatermark = "metadata"
+	// RecordWatermarkOverlay embeds the watermark in the recording's metadata and overlays it on the terminal output,
+	// so it is visible on the rendered stream.
+	RecordWatermarkOverlay RecordWatermark = "overlay"
+)
+
 type NamespaceChanges struct {
-	Name                   string  `bson:"name,omitempty"`
-	SessionRecord          *bool   `bson:"settings.session_record,omitempty"`
-	ConnectionAnnouncement *string `bson:"settings.connection_announcement,omitempty"`
+	Name                      string                     `bson:"name,omitempty"`
+	MaxMembers                *int                       `bson:"max_members,omitempty"`
+	MaxInvitations            *int                       `bson:"max_invitations,omitempty"`
+	SessionRecord             *bool                      `bson:"settings.session_record,omitempty"`
+	ConnectionAnnouncement    *string                    `bson:"settings.connection_announcement,omitempty"`
+	DeviceKeyPinning          *bool                      `bson:"settings.device_key_pinning,omitempty"`
+	WebSessionMaxDuration     *int                       `bson:"settings.web_session_max_duration,omitempty"`
+	DeviceGeoAlert            *bool                      `bson:"settings.device_geo_alert,omitempty"`
+	DeviceNameTemplate        *string                    `bson:"settings.device_name_template,omitempty"`
+	RecordWatermark           *string                    `bson:"settings.record_watermark,omitempty"`
+	DefaultTags               *[]string                  `bson:"settings.default_tags,omitempty"`
+	SessionKeepAlive          *bool                      `bson:"settings.session_keep_alive,omitempty"`
+	SessionSchedules          *[]SessionSchedule         `bson:"settings.session_schedules,omitempty"`
+	DeviceQuarantine          *bool                      `bson:"settings.device_quarantine,omitempty"`
+	SessionRecordPause        *bool                      `bson:"settings.session_record_pause,omitempty"`
+	SessionAttestation        *bool                      `bson:"settings.session_attestation,omitempty"`
+	MaxSessions               *int                       `bson:"settings.max_sessions,omitempty"`
+	SSHCertificateAuthorities *[]SSHCertificateAuthority `bson:"settings.ssh_certificate_authorities,omitempty"`
+	ConnectionWebhook         *ConnectionWebhook         `bson:"settings.connection_webhook,omitempty"`
+	UserProvisioning          *UserProvisioning          `bson:"settings.user_provisioning,omitempty"`
+	MaxDevices                *int                       `bson:"max_devices,omitempty"`
+	MaxPendingDevices         *int                       `bson:"max_pending_devices,omitempty"`
 }
 
 // default Announcement Message for the shellhub namespace
@@ -0,0 +1,26 @@
+package models
+
+// NamespaceEventType is the kind of change streamed to the namespace's members.
+type NamespaceEventType string
+
+const (
+	NamespaceEventDeviceOnline   NamespaceEventType = "device.online"
+	NamespaceEventDeviceOffline  NamespaceEventType = "device.offline"
+	NamespaceEventDeviceAccepted NamespaceEventType = "device.accepted"
+	NamespaceEventDeviceRenamed  NamespaceEventType = "device.renamed"
+	NamespaceEventSessionStarted NamespaceEventType = "session.started"
+	NamespaceEventSessionEnded   NamespaceEventType = "session.ended"
+	// NamespaceEventResync means that the subscriber may have missed changes, as it could not keep up with the
+	// events, and must list the devices and the sessions again.
+	NamespaceEventResync NamespaceEventType = "resync"
+)
+
+// NamespaceEvent notifies a change on the namespace's devices or sessions.
+type NamespaceEvent struct {
+	Type     NamespaceEventType `json:"type"`
+	TenantID string             `json:"tenant_id"`
+	// Device is the device's state after the change, on the device events.
+	Device *Device `json:"device,omitempty"`
+	// Session is the session's state after the change, on the session events.
+	Session *Session `json:"session,omitempty"`
+}
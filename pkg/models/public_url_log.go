@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// PublicURLLog is a request made to the HTTP service exposed by a device through its public URL.
+type PublicURLLog struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the device's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id" validate:"required"`
+	// DeviceUID is the UID of the device whose HTTP service was requested.
+	DeviceUID string `json:"device_uid" bson:"device_uid"`
+	Method    string `json:"method" bson:"method" validate:"required"`
+	Path      string `json:"path" bson:"path" validate:"max=2048"`
+	// Status is the HTTP status code responded by the device. It is zero when the device didn't respond.
+	Status int `json:"status" bson:"status"`
+	// Bytes is the number of bytes responded by the device, including the headers.
+	Bytes int64 `json:"bytes" bson:"bytes"`
+	// Latency is the time, in milliseconds, spent to proxy the request.
+	Latency int64 `json:"latency" bson:"latency"`
+	// SourceIP is the IP address of the client that made the request.
+	SourceIP string `json:"source_ip" bson:"source_ip"`
+	// Time is when the request was received.
+	Time time.Time `json:"time" bson:"time"`
+}
+
+// PublicURLStats are the aggregate counters of the requests made to a device's public URL.
+type PublicURLStats struct {
+	Requests int64 `json:"requests" bson:"requests"`
+	// Errors is the number of requests responded with a status code greater than or equal to 400, or not responded.
+	Errors int64 `json:"errors" bson:"errors"`
+	Bytes  int64 `json:"bytes" bson:"bytes"`
+	// Latency is the average time, in milliseconds, spent to proxy the requests.
+	Latency float64 `json:"latency" bson:"latency"`
+	// SourceIPs is the number of distinct IP addresses that made requests.
+	SourceIPs int64 `json:"source_ips" bson:"source_ips"`
+}
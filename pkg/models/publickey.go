@@ -12,7 +12,7 @@ import (
 // A PublicKeyFilter can contain either Hostname, string, or Tags, slice of strings never both.
 type PublicKeyFilter struct {
 	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
-	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,tag"`
 }
 
 type PublicKeyFields struct {
@@ -26,6 +26,46 @@ type Session struct {
 	Term          string          `json:"term" bson:"term"`
 	Position      SessionPosition `json:"position" bson:"position"`
 	Events        SessionEvents   `json:"events" bson:"events"`
+	Client        SessionClient   `json:"client" bson:"client"`
+	// DeviceName and Namespace are the names of the session's device and namespace, denormalized when the session is
+	// created so the sessions can be listed without joining their devices and namespaces.
+	DeviceName string `json:"-" bson:"device_name,omitempty"`
+	Namespace  string `json:"-" bson:"namespace,omitempty"`
+	// RecordType is the kind of recording captured from the session. It is empty when the session wasn't recorded.
+	RecordType SessionRecordType `json:"record_type,omitempty" bson:"record_type,omitempty"`
+	// RecordHash is the SHA-256 of the session's recording, as the frames were sent to the record endpoint, encoded
+	// as JSON lines. It is empty when the session wasn't recorded.
+	RecordHash string `json:"record_hash,omitempty" bson:"record_hash,omitempty"`
+	// RecordObject is the key of the session's recording on the object storage, when the SSH server records the
+	// sessions on it instead of the record endpoint.
+	RecordObject string `json:"-" bson:"record_object,omitempty"`
+	// Attestation is the server's signature of the completed session, when its namespace attests the sessions.
+	Attestation *SessionAttestation `json:"attestation,omitempty" bson:"attestation,omitempty"`
+}
+
+// SessionClient contains the metadata of the SSH client that opened the session, like its identification string and
+// the algorithms negotiated with the SSH server.
+type SessionClient struct {
+	// Version is the client's identification string (e.g. SSH-2.0-OpenSSH_9.6).
+	Version string `json:"version" bson:"version"`
+	// AuthMethod is the authentication method used by the client to authenticate the session (e.g. "publickey" or
+	// "password").
+	AuthMethod string `json:"auth_method" bson:"auth_method"`
+	// CertificateKeyID is the key ID of the OpenSSH user certificate the client authenticated with, signed by the
+	// namespace's SSH certificate authority. It is empty when the client didn't use a certificate.
+	CertificateKeyID string `json:"certificate_key_id,omitempty" bson:"certificate_key_id,omitempty"`
+	// KeyExchange is the negotiated key exchange algorithm.
+	KeyExchange string `json:"kex" bson:"kex"`
+	// HostKey is the negotiated server's host key algorithm.
+	HostKey string `json:"host_key" bson:"host_key"`
+	// CipherClientServer is the negotiated cipher from client to server.
+	CipherClientServer string `json:"cipher_client_server" bson:"cipher_client_server"`
+	// CipherServerClient is the negotiated cipher from server to client.
+	CipherServerClient string `json:"cipher_server_client" bson:"cipher_server_client"`
+	// MACClientServer is the negotiated MAC from client to server. It is empty when the cipher is an AEAD one.
+	MACClientServer string `json:"mac_client_server" bson:"mac_client_server"`
+	// MACServerClient is the negotiated MAC from server to client. It is empty when the cipher is an AEAD one.
+	MACServerClient string `json:"mac_server_client" bson:"mac_server_client"`
 }
 
 type ActiveSession struct {
@@ -51,17 +91,69 @@ type Status struct {
 	Authenticated bool `json:"authenticated"`
 }
 
+// SessionRecordType is the kind of recording captured from a session.
+type SessionRecordType string
+
+const (
+	// SessionRecordTypePty is the recording of an interactive session with a pseudo-terminal, replayed as a terminal.
+	SessionRecordTypePty SessionRecordType = "pty"
+	// SessionRecordTypeExec is the recording of a session without a pseudo-terminal, like a command execution, with
+	// the command line and its output streams.
+	SessionRecordTypeExec SessionRecordType = "exec"
+)
+
+// SessionRecordStream is the stream of an exec session's recording a frame belongs to.
+type SessionRecordStream string
+
+const (
+	// SessionRecordStreamCommand is the stream of the command line executed.
+	SessionRecordStreamCommand SessionRecordStream = "command"
+	// SessionRecordStreamStdout is the command's standard output.
+	SessionRecordStreamStdout SessionRecordStream = "stdout"
+	// SessionRecordStreamStderr is the command's standard error.
+	SessionRecordStreamStderr SessionRecordStream = "stderr"
+)
+
 type SessionRecorded struct {
 	UID       string `json:"uid"`
 	Namespace string `json:"namespace" bson:"namespace"`
 	Message   string `json:"message" bson:"message"`
 	Width     int    `json:"width" bson:"width,omitempty"`
 	Height    int    `json:"height" bson:"height,omitempty"`
+	// Time is when the frame was captured, as it can reach the record endpoint later than that.
+	Time time.Time `json:"time" bson:"time,omitempty"`
+	// Type is the kind of recording the frame belongs to. Frames without it are from a pty recording.
+	Type SessionRecordType `json:"type,omitempty" bson:"type,omitempty"`
+	// Stream is the stream of an exec recording the frame belongs to.
+	Stream SessionRecordStream `json:"stream,omitempty" bson:"stream,omitempty"`
+	// Truncated marks the last frame of a stream cut for exceeding its size cap. The data after it wasn't recorded.
+	Truncated bool `json:"truncated,omitempty" bson:"truncated,omitempty"`
+	// Marker marks the frame as the point where the user paused or resumed the recording. The data between a pause and
+	// its resume wasn't recorded.
+	Marker SessionRecordMarker `json:"marker,omitempty" bson:"marker,omitempty"`
 }
 
+// SessionRecordMarker is a point, requested by the session's user, marked on the session's recording.
+type SessionRecordMarker string
+
+const (
+	SessionRecordMarkerPause  SessionRecordMarker = "pause"
+	SessionRecordMarkerResume SessionRecordMarker = "resume"
+)
+
 type SessionUpdate struct {
 	Authenticated *bool   `json:"authenticated"`
 	Type          *string `json:"type"`
+	// AuthMethod is the authentication method used by the client to authenticate the session.
+	AuthMethod *string `json:"auth_method"`
+	// CertificateKeyID is the key ID of the user certificate used by the client to authenticate the session.
+	CertificateKeyID *string `json:"certificate_key_id"`
+	// RecordType is the kind of recording captured from the session.
+	RecordType *SessionRecordType `json:"record_type"`
+	// RecordHash is the hash of the session's recording, reported when the recording is closed.
+	RecordHash *string `json:"record_hash"`
+	// RecordObject is the key of the session's recording on the object storage, reported when its upload is completed.
+	RecordObject *string `json:"record_object"`
 }
 
 // SessionEvent represents a session event.
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// SessionAttestationAlgorithm is the algorithm the sessions' attestations are signed with: an RSA PKCS #1 v1.5
+// signature of the statement's SHA-256 digest, using the server's private key.
+const SessionAttestationAlgorithm = "RS256"
+
+// SessionStatement is the data of a completed session attested by the server.
+type SessionStatement struct {
+	UID        string            `json:"uid" bson:"uid"`
+	TenantID   string            `json:"tenant_id" bson:"tenant_id"`
+	DeviceUID  UID               `json:"device_uid" bson:"device_uid"`
+	Username   string            `json:"username" bson:"username"`
+	IPAddress  string            `json:"ip_address" bson:"ip_address"`
+	Type       string            `json:"type" bson:"type"`
+	StartedAt  time.Time         `json:"started_at" bson:"started_at"`
+	FinishedAt time.Time         `json:"finished_at" bson:"finished_at"`
+	RecordType SessionRecordType `json:"record_type,omitempty" bson:"record_type,omitempty"`
+	RecordHash string            `json:"record_hash,omitempty" bson:"record_hash,omitempty"`
+}
+
+// SessionAttestation is the server's signature of a [SessionStatement], stored with the session when it is completed
+// and exported with it as audit evidence. Anyone holding the server's public key can prove the statement untampered.
+type SessionAttestation struct {
+	Statement SessionStatement `json:"statement" bson:"statement"`
+	// Digest is the hex-encoded SHA-256 of the statement encoded as JSON.
+	Digest string `json:"digest" bson:"digest"`
+	// Signature is the base64-encoded signature of the digest, using [SessionAttestationAlgorithm].
+	Signature string    `json:"signature" bson:"signature"`
+	Algorithm string    `json:"algorithm" bson:"algorithm"`
+	SignedAt  time.Time `json:"signed_at" bson:"signed_at"`
+}
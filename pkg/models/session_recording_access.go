@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// SessionRecordingAccessAction is how a session's recording was accessed.
+type SessionRecordingAccessAction string
+
+const (
+	// SessionRecordingAccessActionPlay is the recording retrieved for playback.
+	SessionRecordingAccessActionPlay SessionRecordingAccessAction = "play"
+	// SessionRecordingAccessActionExport is the recording downloaded as a file.
+	SessionRecordingAccessActionExport SessionRecordingAccessAction = "export"
+)
+
+// SessionRecordingAccess is an entry on the access log of a session's recording, telling who accessed the recording,
+// when and which part of it.
+type SessionRecordingAccess struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the session's namespace ID.
+	TenantID   string                       `json:"tenant_id" bson:"tenant_id"`
+	SessionUID string                       `json:"session_uid" bson:"session_uid"`
+	UserID     string                       `json:"user_id" bson:"user_id"`
+	Username   string                       `json:"username" bson:"username"`
+	Action     SessionRecordingAccessAction `json:"action" bson:"action"`
+	// Range is the byte range of the recording requested, as the request's Range header. It is empty when the whole
+	// recording was requested.
+	Range string `json:"range" bson:"range,omitempty"`
+	// SourceIP is the IP address of the client that accessed the recording.
+	SourceIP   string    `json:"source_ip" bson:"source_ip"`
+	AccessedAt time.Time `json:"accessed_at" bson:"accessed_at"`
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	// NOTICE: the schedules' timezones are loaded from the embedded database, as the containers may not have one.
+	_ "time/tzdata"
+
+	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+)
+
+// SessionScheduleClockLayout is the layout of the start and the end of a [SessionScheduleWindow].
+const SessionScheduleClockLayout = "15:04"
+
+var (
+	ErrSessionScheduleTimezone = errors.New("invalid session schedule's timezone")
+	ErrSessionScheduleWindows  = errors.New("a session schedule must have at least one window")
+	ErrSessionScheduleDay      = errors.New("invalid session schedule window's day")
+	ErrSessionScheduleClock    = errors.New("invalid session schedule window's clock")
+)
+
+// SessionSchedule restricts the SSH connections to a namespace's devices to the windows of the week, on a timezone,
+// like the business hours required by a change-window policy.
+type SessionSchedule struct {
+	// Tags restrict the schedule to the devices with any of them, or any of their descendants. When empty, the
+	// schedule restricts every device of the namespace.
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty"`
+	// Roles are the roles of the members allowed to override the schedule, through a break-glass override, outside
+	// its windows. When empty, every member allowed to override a schedule can override it.
+	Roles []authorizer.Role `json:"roles,omitempty" bson:"roles,omitempty"`
+	// Timezone is the IANA timezone the windows are on, like "America/Sao_Paulo".
+	Timezone string `json:"timezone" bson:"timezone"`
+	// Windows are the windows of the week when the connections are allowed.
+	Windows []SessionScheduleWindow `json:"windows" bson:"windows"`
+}
+
+// SessionScheduleWindow is a window of the week when the connections are allowed. A window ending before, or when, it
+// starts ends on the next day, like a night shift.
+type SessionScheduleWindow struct {
+	// Days are the days of the week the window starts on, from 0, Sunday, to 6, Saturday.
+	Days []time.Weekday `json:"days" bson:"days"`
+	// Start is the clock, like "09:00", the window starts at.
+	Start string `json:"start" bson:"start"`
+	// End is the clock, like "18:00", the window ends at.
+	End string `json:"end" bson:"end"`
+}
+
+// Validate checks if the schedule's timezone and windows are valid.
+func (s *SessionSchedule) Validate() error {
+	if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "" {
+		return fmt.Errorf("%w: %s", ErrSessionScheduleTimezone, s.Timezone)
+	}
+
+	if len(s.Windows) == 0 {
+		return ErrSessionScheduleWindows
+	}
+
+	for _, window := range s.Windows {
+		for _, day := range window.Days {
+			if day < time.Sunday || day > time.Saturday {
+				return fmt.Errorf("%w: %d", ErrSessionScheduleDay, day)
+			}
+		}
+
+		for _, clock := range []string{window.Start, window.End} {
+			if _, err := time.Parse(SessionScheduleClockLayout, clock); err != nil {
+				return fmt.Errorf("%w: %s", ErrSessionScheduleClock, clock)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Applies reports if the schedule restricts the connections to a device with the tags.
+func (s *SessionSchedule) Applies(tags []string) bool {
+	return len(s.Tags) == 0 || TagsMatch(tags, s.Tags)
+}
+
+// Overridable reports if a member with the role can override the schedule.
+func (s *SessionSchedule) Overridable(role authorizer.Role) bool {
+	if len(s.Roles) == 0 {
+		return true
+	}
+
+	for _, r := range s.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allows reports if the connections are allowed at the instant, when it is inside one of the schedule's windows. An
+// invalid schedule doesn't allow any connection.
+func (s *SessionSchedule) Allows(at time.Time) bool {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false
+	}
+
+	at = at.In(location)
+	minute := at.Hour()*60 + at.Minute()
+
+	for _, window := range s.Windows {
+		start, err := time.Parse(SessionScheduleClockLayout, window.Start)
+		if err != nil {
+			continue
+		}
+
+		end, err := time.Parse(SessionScheduleClockLayout, window.End)
+		if err != nil {
+			continue
+		}
+
+		from, to := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+
+		for _, day := range window.Days {
+			switch {
+			case from < to:
+				if at.Weekday() == day && minute >= from && minute < to {
+					return true
+				}
+			// NOTICE: the window ends on the next day, so it is split into the part on the day it starts and the part
+			// on the next day.
+			case at.Weekday() == day && minute >= from:
+				return true
+			case at.Weekday() == (day+1)%7 && minute < to:
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SessionScheduleOverride is a break-glass override of the namespace's session schedules, allowing the connections to
+// a device outside the schedules' windows until it expires. It is kept as the audit trail of who overrode the
+// schedules and why.
+type SessionScheduleOverride struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the device's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// DeviceUID is the UID of the device whose connections are allowed.
+	DeviceUID string `json:"device_uid" bson:"device_uid"`
+	// UserID is the ID of the user who overrode the schedules.
+	UserID string `json:"user_id" bson:"user_id"`
+	// Reason is the justification given by the user.
+	Reason    string    `json:"reason" bson:"reason"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// ExpiresAt is when the connections to the device are restricted by the schedules again.
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// SSHCertificateAuthority is a CA trusted by a namespace to sign the OpenSSH user certificates that authenticate the
+// SSH connections to its devices, instead of registering each user's public key.
+type SSHCertificateAuthority struct {
+	// PublicKey is the CA's public key, in the authorized_keys format.
+	PublicKey string `json:"public_key" bson:"public_key"`
+	// Fingerprint is the SHA256 fingerprint of the CA's public key.
+	Fingerprint string    `json:"fingerprint" bson:"fingerprint"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	// ExpiresAt is when a rotated CA stops being trusted. It is nil for the current CA.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+}
+
+// Trusted reports whether the CA's certificates are accepted at now.
+func (c *SSHCertificateAuthority) Trusted(now time.Time) bool {
+	return c.ExpiresAt == nil || now.Before(*c.ExpiresAt)
+}
@@ -0,0 +1,53 @@
+package models
+
+import "strings"
+
+// TagSeparator separates the levels of a hierarchical tag. The tag "region/europe" is a child of "region", so a
+// device tagged with "region/europe" also matches rules and filters targeting "region".
+const TagSeparator = "/"
+
+// TagImplies reports whether tag implies target, that is, when tag is the target itself or one of its descendants.
+func TagImplies(tag, target string) bool {
+	return tag == target || strings.HasPrefix(tag, target+TagSeparator)
+}
+
+// TagsMatch reports whether any tag in tags implies any tag in targets.
+func TagsMatch(tags, targets []string) bool {
+	for _, tag := range tags {
+		for _, target := range targets {
+			if TagImplies(tag, target) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// TagAncestors returns the ancestors of tag, from the root to its direct parent. A root tag has no ancestors.
+func TagAncestors(tag string) []string {
+	ancestors := []string{}
+	for i := range tag {
+		if strings.HasPrefix(tag[i:], TagSeparator) {
+			ancestors = append(ancestors, tag[:i])
+		}
+	}
+
+	return ancestors
+}
+
+// TagsExpand returns the tags with their ancestors, without duplicates, keeping the order in which they first appear.
+func TagsExpand(tags []string) []string {
+	seen := make(map[string]bool)
+	expanded := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		for _, t := range append(TagAncestors(tag), tag) {
+			if !seen[t] {
+				seen[t] = true
+				expanded = append(expanded, t)
+			}
+		}
+	}
+
+	return expanded
+}
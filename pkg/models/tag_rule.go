@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// TagRule assigns a tag to the namespace's devices whose attributes, as reported by their agents, match all of the
+// rule's conditions. The tag is removed from the devices that stop matching it, unless another rule assigns it.
+type TagRule struct {
+	ID string `json:"id" bson:"_id"`
+	// TenantID is the rule's namespace ID.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	Name     string `json:"name" bson:"name"`
+	// Tag is the tag assigned to the matching devices.
+	Tag string `json:"tag" bson:"tag"`
+	// Conditions are the conditions a device must match, all of them, to receive the tag.
+	Conditions []TagRuleCondition `json:"conditions" bson:"conditions"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// TagRuleCondition compares one of the device's attributes with a value.
+type TagRuleCondition struct {
+	Attribute TagRuleAttribute `json:"attribute" bson:"attribute" validate:"required,oneof=platform version arch os pretty_name name"`
+	Operator  TagRuleOperator  `json:"operator" bson:"operator" validate:"required,oneof=eq ne contains prefix lt lte gt gte"`
+	Value     string           `json:"value" bson:"value" validate:"max=255"`
+}
+
+// TagRuleAttribute is a device's attribute a [TagRuleCondition] can compare.
+type TagRuleAttribute string
+
+const (
+	// TagRuleAttributePlatform is the platform the agent runs on, like "docker" or "native".
+	TagRuleAttributePlatform TagRuleAttribute = "platform"
+	// TagRuleAttributeVersion is the agent's version.
+	TagRuleAttributeVersion TagRuleAttribute = "version"
+	// TagRuleAttributeArch is the device's architecture.
+	TagRuleAttributeArch TagRuleAttribute = "arch"
+	// TagRuleAttributeOS is the ID of the device's operating system, like "debian" or "alpine".
+	TagRuleAttributeOS TagRuleAttribute = "os"
+	// TagRuleAttributePrettyName is the name of the device's operating system, including its version.
+	TagRuleAttributePrettyName TagRuleAttribute = "pretty_name"
+	// TagRuleAttributeName is the device's name.
+	TagRuleAttributeName TagRuleAttribute = "name"
+)
+
+// TagRuleOperator is how a [TagRuleCondition] compares the device's attribute with its value.
+type TagRuleOperator string
+
+const (
+	TagRuleOperatorEqual    TagRuleOperator = "eq"
+	TagRuleOperatorNotEqual TagRuleOperator = "ne"
+	TagRuleOperatorContains TagRuleOperator = "contains"
+	TagRuleOperatorPrefix   TagRuleOperator = "prefix"
+	// TagRuleOperatorLess, and the other ordering operators, compare the attribute and the value as versions, like
+	// "0.15.2". An attribute that isn't a version doesn't match them.
+	TagRuleOperatorLess         TagRuleOperator = "lt"
+	TagRuleOperatorLessEqual    TagRuleOperator = "lte"
+	TagRuleOperatorGreater      TagRuleOperator = "gt"
+	TagRuleOperatorGreaterEqual TagRuleOperator = "gte"
+)
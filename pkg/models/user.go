@@ -79,6 +79,8 @@ type User struct {
 	MFA         UserMFA         `json:"mfa" bson:"mfa"`
 	Preferences UserPreferences `json:"preferences" bson:"preferences"`
 	Password    UserPassword    `bson:",inline"`
+	// Aliases are the user's personal shortcuts to devices' SSHIDs.
+	Aliases []UserAlias `json:"-" bson:"aliases,omitempty"`
 }
 
 type UserData struct {
@@ -164,6 +166,9 @@ type UserAuthResponse struct {
 	Role          string           `json:"role"`
 	MFA           bool             `json:"mfa"`
 	MaxNamespaces int              `json:"max_namespaces"`
+	// RefreshToken is used to get a new token, when it expires, without the user's credentials. It's rotated every
+	// time a token is issued, so each refresh token can be used only once.
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 // NOTE: This struct has been moved to the cloud repo as it is only used in a cloud context;
@@ -0,0 +1,27 @@
+package models
+
+import "strings"
+
+// UserAliasSeparator separates the user's username from the alias's name when an alias is used as the target of a
+// SSH connection, like "username/alias".
+const UserAliasSeparator = "/"
+
+// UserAlias is a personal shortcut, defined by a user, to a device's SSHID.
+type UserAlias struct {
+	// Name is the alias's name, unique per user.
+	Name string `json:"name" bson:"name"`
+	// Target is the SSHID, without the device's OS user, that the alias resolves to, like "namespace.hostname". It may
+	// also target a container running on the device, like "namespace.hostname+container".
+	Target string `json:"target" bson:"target"`
+}
+
+// SplitUserAlias splits a value like "username/alias" into the user's username and the alias's name. It reports
+// false when the value doesn't refer to a user's alias.
+func SplitUserAlias(value string) (string, string, bool) {
+	username, name, ok := strings.Cut(value, UserAliasSeparator)
+	if !ok || username == "" || name == "" {
+		return "", "", false
+	}
+
+	return username, name, true
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"time"
+)
+
+const (
+	// UserProvisioningDefaultExpiry is for how long the provisioned users are kept when the namespace doesn't
+	// configure it.
+	UserProvisioningDefaultExpiry = 24 * time.Hour
+	// UserProvisioningMaxExpiry is the longest the provisioned users may be kept.
+	UserProvisioningMaxExpiry = 30 * 24 * time.Hour
+	// UserProvisioningMaxGroups is the maximum number of groups the provisioned users are added to.
+	UserProvisioningMaxGroups = 16
+)
+
+// userProvisioningName matches the names of the users and of the groups accepted by the useradd of the most
+// distributions.
+var userProvisioningName = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
+var (
+	ErrUserProvisioningGroup  = errors.New("invalid user provisioning's group")
+	ErrUserProvisioningGroups = fmt.Errorf("the provisioned users may be added to %d groups at most", UserProvisioningMaxGroups)
+	ErrUserProvisioningShell  = errors.New("the user provisioning's shell must be an absolute path")
+	ErrUserProvisioningExpiry = errors.New("the user provisioning's expiry must be between 0 and 30 days")
+)
+
+// UserProvisioning allows the agents to create, on demand, the local users the SSH sessions to the namespace's devices
+// target when they don't exist on the device, like for the ephemeral access of contractors, without provisioning the
+// accounts beforehand. The users are only created after the session is authenticated by a public key of the namespace,
+// whose username filter restricts which users may be created, and are removed by the agent once expired.
+//
+// The agents must also enable it, as it requires them to run as root in host mode.
+type UserProvisioning struct {
+	Enabled bool `json:"enabled" bson:"enabled"`
+	// Groups are the supplementary groups, existing on the device, the provisioned users are added to.
+	Groups []string `json:"groups" bson:"groups"`
+	// Shell is the provisioned users' login shell. When it is empty, the device's default shell is used.
+	Shell string `json:"shell" bson:"shell"`
+	// Expiry is for how long, in seconds, the provisioned users are kept. When it is zero,
+	// [UserProvisioningDefaultExpiry] is used.
+	Expiry int `json:"expiry" bson:"expiry"`
+}
+
+// Allowed reports if the agents may provision the users.
+func (p *UserProvisioning) Allowed() bool {
+	return p != nil && p.Enabled
+}
+
+// Lifetime returns for how long the provisioned users are kept.
+func (p *UserProvisioning) Lifetime() time.Duration {
+	if p.Expiry <= 0 {
+		return UserProvisioningDefaultExpiry
+	}
+
+	return time.Duration(p.Expiry) * time.Second
+}
+
+// Validate checks if the user provisioning is valid.
+func (p *UserProvisioning) Validate() error {
+	if len(p.Groups) > UserProvisioningMaxGroups {
+		return ErrUserProvisioningGroups
+	}
+
+	for _, group := range p.Groups {
+		if !userProvisioningName.MatchString(group) {
+			return fmt.Errorf("%w: %s", ErrUserProvisioningGroup, group)
+		}
+	}
+
+	if p.Shell != "" && !path.IsAbs(p.Shell) {
+		return ErrUserProvisioningShell
+	}
+
+	if p.Expiry < 0 || time.Duration(p.Expiry)*time.Second > UserProvisioningMaxExpiry {
+		return ErrUserProvisioningExpiry
+	}
+
+	return nil
+}
+
+// IsUserProvisionable reports whether username may be given to a provisioned user.
+func IsUserProvisionable(username string) bool {
+	return userProvisioningName.MatchString(username)
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// UserSession is a token issued to a user, when signing in, tracked so the user can review where the account is
+// signed in and revoke the token before it expires.
+type UserSession struct {
+	// ID is the session's identifier, carried as the ID of the tokens issued to it.
+	ID     string `json:"id" bson:"_id"`
+	UserID string `json:"-" bson:"user_id"`
+	// UserAgent is the user agent of the device or browser that requested the token.
+	UserAgent string `json:"user_agent" bson:"user_agent"`
+	// IP is the address from where the token was requested.
+	IP        string    `json:"ip" bson:"ip"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// LastSeenAt is when the session's token was last used to authenticate a request.
+	LastSeenAt time.Time `json:"last_seen_at" bson:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at" bson:"expires_at"`
+	// RefreshToken is the SHA256 hash of the secret of the session's current refresh token.
+	RefreshToken string `json:"-" bson:"refresh_token"`
+	// Current indicates whether the session is the one that requested its listing.
+	Current bool `json:"current" bson:"-"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// UserVerification is the pending verification of a user's email. A user has at most one, replaced each time a new
+// token is sent, so only the last token sent verifies the email.
+//
+// The token itself is never stored, only its digest, so it is only known by the user who received it.
+type UserVerification struct {
+	// UserID is the ID of the user whose email is verified.
+	UserID string `json:"user_id" bson:"_id"`
+	// Digest is the [UserVerificationDigest] of the token.
+	Digest    string    `json:"-" bson:"digest"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// ExpiresAt is when the token can no longer verify the email.
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+// UserVerificationDigest returns the digest the token is stored by. As the token is a random value, its SHA256 digest
+// is enough to find it without keeping the token itself.
+func UserVerificationDigest(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
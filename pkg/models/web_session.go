@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// WebSession is a pre-authorized web terminal session, opened by the SSH server's web bridge without prompting the
+// user for the device's credentials. It is minted by the API for a member of the device's namespace and can only be
+// redeemed once, before it expires.
+type WebSession struct {
+	ID string `json:"id"`
+	// TenantID is the device's namespace ID.
+	TenantID string `json:"tenant_id"`
+	// DeviceUID is the UID of the device the terminal is opened on.
+	DeviceUID string `json:"device_uid"`
+	// UserID is the ID of the user who requested the session.
+	UserID string `json:"user_id"`
+	// Username is the user in the device's OS the terminal is opened for.
+	Username string `json:"username"`
+	// ExpiresAt is when the session can no longer be redeemed.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// WebSessionToken is the token that redeems a [WebSession] on the SSH server's web bridge.
+type WebSessionToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
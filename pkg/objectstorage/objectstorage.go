@@ -0,0 +1,37 @@
+// Package objectstorage provides a minimal abstraction to store large objects, like the sessions' recordings, on an
+// object storage, uploading them in parts as they are produced.
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrObjectNotFound is returned when the object doesn't exist on the storage.
+var ErrObjectNotFound = errors.New("object not found")
+
+// MinPartSize is the minimum size, in bytes, of each part of a multipart upload, except for the last one.
+const MinPartSize = 5 * 1024 * 1024
+
+// Part is a part of a multipart upload already uploaded to the storage.
+type Part struct {
+	// Number is the part's position on the object, starting at 1.
+	Number int
+	// ETag identifies the part's content on the storage.
+	ETag string
+}
+
+//go:generate mockery --name Storage --filename storage.go
+type Storage interface {
+	// CreateMultipartUpload starts the upload of the object in parts, returning the upload's ID.
+	CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error)
+	// UploadPart uploads the data as the part number of the upload.
+	UploadPart(ctx context.Context, key, uploadID string, number int, data []byte) (*Part, error)
+	// CompleteMultipartUpload assembles the object from its parts, which must be in ascending order.
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error
+	// AbortMultipartUpload discards the upload and the parts already uploaded.
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+	// GetObject opens the object for reading. It returns [ErrObjectNotFound] when the object doesn't exist.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+}
@@ -0,0 +1,371 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+)
+
+var ErrS3InvalidConfig = errors.New("invalid S3 configuration")
+
+// S3Config holds the information required to store objects on a S3-compatible storage, like AWS S3 or MinIO.
+type S3Config struct {
+	// Endpoint is the storage's URL, like https://s3.us-east-1.amazonaws.com or http://minio:9000.
+	Endpoint string
+	// Region is the bucket's region, used to sign the requests.
+	Region string
+	// Bucket is the bucket where the objects are stored.
+	Bucket string
+	// AccessKeyID is the access key used to sign the requests.
+	AccessKeyID string
+	// SecretAccessKey is the secret of the access key.
+	SecretAccessKey string
+	// PathStyle addresses the bucket on the URL's path, instead of on its host, as required by MinIO and most of the
+	// self-hosted storages.
+	PathStyle bool
+}
+
+// S3Error is an error answered by the S3 storage.
+type S3Error struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *S3Error) Error() string {
+	return fmt.Sprintf("s3: %s (%d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+type s3Storage struct {
+	endpoint  *url.URL
+	bucket    string
+	pathStyle bool
+	signer    *signer
+	client    *http.Client
+}
+
+var _ Storage = (*s3Storage)(nil)
+
+// NewS3Storage creates a [Storage] that keeps the objects on the bucket of the S3-compatible storage described by
+// cfg.
+func NewS3Storage(cfg S3Config) (Storage, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, ErrS3InvalidConfig
+	}
+
+	if cfg.Region == "" || cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, ErrS3InvalidConfig
+	}
+
+	return &s3Storage{
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		pathStyle: cfg.PathStyle,
+		signer: &signer{
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			region:          cfg.Region,
+			service:         "s3",
+		},
+		client: &http.Client{},
+	}, nil
+}
+
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (s *s3Storage) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	res, err := s.do(ctx, http.MethodPost, key, map[string]string{"uploads": ""}, nil, contentType)
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	result := new(initiateMultipartUploadResult)
+	if err := xml.NewDecoder(res.Body).Decode(result); err != nil {
+		return "", err
+	}
+
+	if result.UploadID == "" {
+		return "", errors.New("s3: the multipart upload was created without an ID")
+	}
+
+	return result.UploadID, nil
+}
+
+func (s *s3Storage) UploadPart(ctx context.Context, key, uploadID string, number int, data []byte) (*Part, error) {
+	query := map[string]string{"partNumber": strconv.Itoa(number), "uploadId": uploadID}
+
+	res, err := s.do(ctx, http.MethodPut, key, query, data, "")
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	io.Copy(io.Discard, res.Body) //nolint:errcheck
+
+	return &Part{Number: number, ETag: res.Header.Get("ETag")}, nil
+}
+
+func (s *s3Storage) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error {
+	complete := completeMultipartUpload{Parts: make([]completedPart, len(parts))}
+	for i, part := range parts {
+		complete.Parts[i] = completedPart{PartNumber: part.Number, ETag: part.ETag}
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do(ctx, http.MethodPost, key, map[string]string{"uploadId": uploadID}, body, "application/xml")
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	// NOTICE: the storage may fail to assemble the object after answering with a success, reporting the failure on
+	// the response's body instead.
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	var root struct {
+		XMLName xml.Name
+		S3Error
+	}
+
+	if err := xml.Unmarshal(data, &root); err == nil && root.XMLName.Local == "Error" {
+		root.S3Error.StatusCode = res.StatusCode
+
+		return &root.S3Error
+	}
+
+	return nil
+}
+
+func (s *s3Storage) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	res, err := s.do(ctx, http.MethodDelete, key, map[string]string{"uploadId": uploadID}, nil, "")
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+func (s *s3Storage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	res, err := s.do(ctx, http.MethodGet, key, nil, nil, "")
+	if err != nil {
+		var e *S3Error
+		if errors.As(err, &e) && (e.StatusCode == http.StatusNotFound || e.Code == "NoSuchKey") {
+			return nil, ErrObjectNotFound
+		}
+
+		return nil, err
+	}
+
+	return res.Body, nil
+}
+
+// do sends the signed request to the object's URL, returning an [S3Error] when the storage answers with a failure.
+func (s *s3Storage) do(ctx context.Context, method, key string, query map[string]string, body []byte, contentType string) (*http.Response, error) {
+	escapedPath := s.objectPath(key)
+	rawQuery := canonicalQuery(query)
+
+	u := *s.endpoint
+	u.Path, u.RawPath, u.RawQuery = "", "", rawQuery
+	u.Opaque = "//" + s.host() + escapedPath
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+
+	headers := map[string]string{
+		"host":                 s.host(),
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           clock.Now().UTC().Format(amzDateFormat),
+	}
+
+	if contentType != "" {
+		headers["content-type"] = contentType
+	}
+
+	for name, value := range headers {
+		if name != "host" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	req.Header.Set("Authorization", s.signer.authorization(method, escapedPath, rawQuery, headers, payloadHash))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer res.Body.Close()
+
+		e := &S3Error{StatusCode: res.StatusCode}
+		if data, err := io.ReadAll(io.LimitReader(res.Body, 64*1024)); err == nil {
+			xml.Unmarshal(data, e) //nolint:errcheck
+		}
+
+		if e.Code == "" {
+			e.Code = http.StatusText(res.StatusCode)
+		}
+
+		return nil, e
+	}
+
+	return res, nil
+}
+
+// host returns the host the requests are sent to, which includes the bucket when it isn't addressed on the path.
+func (s *s3Storage) host() string {
+	if s.pathStyle {
+		return s.endpoint.Host
+	}
+
+	return s.bucket + "." + s.endpoint.Host
+}
+
+// objectPath returns the escaped path of the object, which includes the bucket when it is addressed on the path.
+func (s *s3Storage) objectPath(key string) string {
+	prefix := path.Join("/", s.endpoint.Path)
+	if s.pathStyle {
+		prefix = path.Join(prefix, s.bucket)
+	}
+
+	return strings.TrimSuffix(prefix, "/") + "/" + uriEncode(strings.TrimPrefix(key, "/"), false)
+}
+
+const amzDateFormat = "20060102T150405Z"
+
+// signer signs the requests with the AWS Signature Version 4.
+type signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	region          string
+	service         string
+}
+
+// authorization returns the value of the Authorization header of the request. The headers, whose names must be in
+// lower case, are the ones signed, including the host and the x-amz-date, which is the request's time.
+func (s *signer) authorization(method, escapedPath, rawQuery string, headers map[string]string, payloadHash string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		escapedPath,
+		rawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	amzDate := headers["x-amz-date"]
+	scope := strings.Join([]string{amzDate[:8], s.region, s.service, "aws4_request"}, "/")
+
+	digest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(digest[:])}, "\n")
+
+	key := []byte("AWS4" + s.secretAccessKey)
+	for _, part := range []string{amzDate[:8], s.region, s.service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	return fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.accessKeyID, scope, signedHeaders, signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
+
+// canonicalQuery encodes the query sorted by its keys, as required by the signature.
+func canonicalQuery(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = uriEncode(key, true) + "=" + uriEncode(query[key], true)
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode escapes every byte except the unreserved characters of RFC 3986. The slash is kept unless encodeSlash is
+// set.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
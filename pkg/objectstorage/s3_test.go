@@ -0,0 +1,218 @@
+package objectstorage
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignerAuthorization(t *testing.T) {
+	// NOTICE: the "get-vanilla" case of the AWS Signature Version 4 test suite.
+	s := &signer{
+		accessKeyID:     "AKIDEXAMPLE",
+		secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		region:          "us-east-1",
+		service:         "service",
+	}
+
+	headers := map[string]string{
+		"host":       "example.amazonaws.com",
+		"x-amz-date": "20150830T123600Z",
+	}
+
+	authorization := s.authorization(
+		http.MethodGet,
+		"/",
+		"",
+		headers,
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	)
+
+	assert.Equal(
+		t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		authorization,
+	)
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	assert.Equal(t, "", canonicalQuery(nil))
+	assert.Equal(t, "uploads=", canonicalQuery(map[string]string{"uploads": ""}))
+	assert.Equal(t, "partNumber=1&uploadId=a%2Bb%2Fc", canonicalQuery(map[string]string{"uploadId": "a+b/c", "partNumber": "1"}))
+}
+
+func TestNewS3Storage(t *testing.T) {
+	cases := []struct {
+		description string
+		cfg         S3Config
+		expected    error
+	}{
+		{
+			description: "fails when the endpoint isn't an URL",
+			cfg:         S3Config{Endpoint: "minio:9000", Region: "us-east-1", Bucket: "records", AccessKeyID: "id", SecretAccessKey: "secret"},
+			expected:    ErrS3InvalidConfig,
+		},
+		{
+			description: "fails when the bucket is empty",
+			cfg:         S3Config{Endpoint: "http://minio:9000", Region: "us-east-1", AccessKeyID: "id", SecretAccessKey: "secret"},
+			expected:    ErrS3InvalidConfig,
+		},
+		{
+			description: "fails when the credentials are empty",
+			cfg:         S3Config{Endpoint: "http://minio:9000", Region: "us-east-1", Bucket: "records"},
+			expected:    ErrS3InvalidConfig,
+		},
+		{
+			description: "succeeds",
+			cfg:         S3Config{Endpoint: "http://minio:9000", Region: "us-east-1", Bucket: "records", AccessKeyID: "id", SecretAccessKey: "secret"},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := NewS3Storage(tc.cfg)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+// bucketServer is a S3-compatible storage, addressed on the path, that keeps the objects of a single bucket.
+type bucketServer struct {
+	mu      sync.Mutex
+	parts   map[string]map[string][]byte
+	objects map[string][]byte
+}
+
+func (b *bucketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=id/") || r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/records/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		b.parts[key] = map[string][]byte{}
+		w.Write([]byte("<InitiateMultipartUploadResult><UploadId>upload</UploadId></InitiateMultipartUploadResult>")) //nolint:errcheck
+	case r.Method == http.MethodPut && query.Get("uploadId") == "upload":
+		data, _ := io.ReadAll(r.Body)
+		b.parts[key][query.Get("partNumber")] = data
+		w.Header().Set("ETag", `"etag-`+query.Get("partNumber")+`"`)
+	case r.Method == http.MethodPost && query.Get("uploadId") == "upload":
+		complete := new(completeMultipartUpload)
+		if err := xml.NewDecoder(r.Body).Decode(complete); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		var object []byte
+		for _, part := range complete.Parts {
+			data, ok := b.parts[key][strings.TrimSuffix(strings.TrimPrefix(part.ETag, `"etag-`), `"`)]
+			if !ok {
+				w.Write([]byte("<Error><Code>InvalidPart</Code><Message>part not found</Message></Error>")) //nolint:errcheck
+
+				return
+			}
+
+			object = append(object, data...)
+		}
+
+		b.objects[key] = object
+		delete(b.parts, key)
+	case r.Method == http.MethodDelete && query.Get("uploadId") == "upload":
+		delete(b.parts, key)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet:
+		object, ok := b.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>")) //nolint:errcheck
+
+			return
+		}
+
+		w.Write(object) //nolint:errcheck
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	bucket := &bucketServer{parts: map[string]map[string][]byte{}, objects: map[string][]byte{}}
+
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+
+	storage, err := NewS3Storage(S3Config{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "records",
+		AccessKeyID:     "id",
+		SecretAccessKey: "secret",
+		PathStyle:       true,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("uploads the object in parts", func(t *testing.T) {
+		uploadID, err := storage.CreateMultipartUpload(ctx, "tenant/session.jsonl", "application/x-ndjson")
+		require.NoError(t, err)
+		assert.Equal(t, "upload", uploadID)
+
+		first, err := storage.UploadPart(ctx, "tenant/session.jsonl", uploadID, 1, []byte("first\n"))
+		require.NoError(t, err)
+		assert.Equal(t, &Part{Number: 1, ETag: `"etag-1"`}, first)
+
+		second, err := storage.UploadPart(ctx, "tenant/session.jsonl", uploadID, 2, []byte("second\n"))
+		require.NoError(t, err)
+
+		require.NoError(t, storage.CompleteMultipartUpload(ctx, "tenant/session.jsonl", uploadID, []Part{*first, *second}))
+
+		object, err := storage.GetObject(ctx, "tenant/session.jsonl")
+		require.NoError(t, err)
+
+		defer object.Close()
+
+		data, err := io.ReadAll(object)
+		require.NoError(t, err)
+		assert.Equal(t, "first\nsecond\n", string(data))
+	})
+
+	t.Run("fails when the completion reports an error with a success", func(t *testing.T) {
+		uploadID, err := storage.CreateMultipartUpload(ctx, "tenant/failed.jsonl", "application/x-ndjson")
+		require.NoError(t, err)
+
+		err = storage.CompleteMultipartUpload(ctx, "tenant/failed.jsonl", uploadID, []Part{{Number: 1, ETag: `"etag-1"`}})
+		assert.Equal(t, &S3Error{StatusCode: http.StatusOK, Code: "InvalidPart", Message: "part not found"}, err)
+
+		require.NoError(t, storage.AbortMultipartUpload(ctx, "tenant/failed.jsonl", uploadID))
+	})
+
+	t.Run("fails when the object doesn't exist", func(t *testing.T) {
+		_, err := storage.GetObject(ctx, "tenant/missing.jsonl")
+		assert.Equal(t, ErrObjectNotFound, err)
+	})
+}
@@ -0,0 +1,231 @@
+// Package tagrule evaluates the namespace's tag rules against the attributes reported by its devices, computing the
+// tags each device must have.
+package tagrule
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var (
+	ErrConditionsEmpty  = errors.New("rule has no conditions")
+	ErrAttributeInvalid = errors.New("condition's attribute is invalid")
+	ErrOperatorInvalid  = errors.New("condition's operator is invalid")
+	ErrVersionInvalid   = errors.New("condition's value is not a version")
+)
+
+var attributes = []models.TagRuleAttribute{
+	models.TagRuleAttributePlatform,
+	models.TagRuleAttributeVersion,
+	models.TagRuleAttributeArch,
+	models.TagRuleAttributeOS,
+	models.TagRuleAttributePrettyName,
+	models.TagRuleAttributeName,
+}
+
+// Validate checks whether the rule's conditions can be evaluated.
+func Validate(conditions []models.TagRuleCondition) error {
+	if len(conditions) == 0 {
+		return ErrConditionsEmpty
+	}
+
+	for _, condition := range conditions {
+		if !slices.Contains(attributes, condition.Attribute) {
+			return ErrAttributeInvalid
+		}
+
+		switch condition.Operator {
+		case models.TagRuleOperatorEqual, models.TagRuleOperatorNotEqual, models.TagRuleOperatorContains, models.TagRuleOperatorPrefix:
+		case models.TagRuleOperatorLess, models.TagRuleOperatorLessEqual, models.TagRuleOperatorGreater, models.TagRuleOperatorGreaterEqual:
+			if _, ok := parseVersion(condition.Value); !ok {
+				return ErrVersionInvalid
+			}
+		default:
+			return ErrOperatorInvalid
+		}
+	}
+
+	return nil
+}
+
+// Match reports whether the device matches all the rule's conditions.
+func Match(rule *models.TagRule, device *models.Device) bool {
+	if len(rule.Conditions) == 0 {
+		return false
+	}
+
+	for _, condition := range rule.Conditions {
+		if !matchCondition(condition, attribute(device, condition.Attribute)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Apply evaluates the rules against the device, returning the tags it must have and whether they differ from the
+// device's current tags.
+//
+// The tags of the rules the device doesn't match are removed, unless a matched rule assigns the same tag, and the
+// tags of the matched rules are appended, when they aren't implied by the device's tags, up to limit tags. The tags no
+// rule assigns are kept as they are.
+func Apply(rules []models.TagRule, device *models.Device, limit int) ([]string, bool) {
+	matched := make([]string, 0, len(rules))
+	unmatched := make([]string, 0, len(rules))
+	for i := range rules {
+		if Match(&rules[i], device) {
+			matched = append(matched, rules[i].Tag)
+		} else {
+			unmatched = append(unmatched, rules[i].Tag)
+		}
+	}
+
+	tags := make([]string, 0, len(device.Tags)+len(matched))
+	for _, tag := range device.Tags {
+		if !slices.Contains(unmatched, tag) || slices.Contains(matched, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, tag := range matched {
+		if len(tags) >= limit {
+			break
+		}
+
+		if !models.TagsMatch(tags, []string{tag}) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, !slices.Equal(tags, device.Tags)
+}
+
+// attribute returns the value of the device's attribute, or an empty string when the device hasn't reported it.
+func attribute(device *models.Device, name models.TagRuleAttribute) string {
+	if name == models.TagRuleAttributeName {
+		return device.Name
+	}
+
+	if device.Info == nil {
+		return ""
+	}
+
+	switch name {
+	case models.TagRuleAttributePlatform:
+		return device.Info.Platform
+	case models.TagRuleAttributeVersion:
+		return device.Info.Version
+	case models.TagRuleAttributeArch:
+		return device.Info.Arch
+	case models.TagRuleAttributeOS:
+		return device.Info.ID
+	case models.TagRuleAttributePrettyName:
+		return device.Info.PrettyName
+	default:
+		return ""
+	}
+}
+
+func matchCondition(condition models.TagRuleCondition, value string) bool {
+	switch condition.Operator {
+	case models.TagRuleOperatorEqual:
+		return strings.EqualFold(value, condition.Value)
+	case models.TagRuleOperatorNotEqual:
+		return !strings.EqualFold(value, condition.Value)
+	case models.TagRuleOperatorContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(condition.Value))
+	case models.TagRuleOperatorPrefix:
+		return strings.HasPrefix(strings.ToLower(value), strings.ToLower(condition.Value))
+	}
+
+	result, ok := compareVersions(value, condition.Value)
+	if !ok {
+		return false
+	}
+
+	switch condition.Operator {
+	case models.TagRuleOperatorLess:
+		return result < 0
+	case models.TagRuleOperatorLessEqual:
+		return result <= 0
+	case models.TagRuleOperatorGreater:
+		return result > 0
+	case models.TagRuleOperatorGreaterEqual:
+		return result >= 0
+	default:
+		return false
+	}
+}
+
+// version is a version like "v0.15.2-rc.1", split on its numeric components and its pre-release.
+type version struct {
+	numbers    []int
+	prerelease string
+}
+
+// parseVersion parses a version with up to three numeric components, an optional "v" prefix, pre-release and build
+// metadata. It reports false when value isn't a version.
+func parseVersion(value string) (version, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if i := strings.IndexByte(value, '+'); i >= 0 {
+		value = value[:i]
+	}
+
+	var v version
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		value, v.prerelease = value[:i], value[i+1:]
+	}
+
+	parts := strings.Split(value, ".")
+	if len(parts) > 3 {
+		return version{}, false
+	}
+
+	for _, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return version{}, false
+		}
+
+		v.numbers = append(v.numbers, number)
+	}
+
+	for len(v.numbers) < 3 {
+		v.numbers = append(v.numbers, 0)
+	}
+
+	return v, true
+}
+
+// compareVersions returns -1, 0 or 1 when a is lower, equal or greater than b. A pre-release is lower than its
+// release. It reports false when either isn't a version.
+func compareVersions(a, b string) (int, bool) {
+	va, ok := parseVersion(a)
+	if !ok {
+		return 0, false
+	}
+
+	vb, ok := parseVersion(b)
+	if !ok {
+		return 0, false
+	}
+
+	if result := slices.Compare(va.numbers, vb.numbers); result != 0 {
+		return result, true
+	}
+
+	switch {
+	case va.prerelease == vb.prerelease:
+		return 0, true
+	case va.prerelease == "":
+		return 1, true
+	case vb.prerelease == "":
+		return -1, true
+	default:
+		return strings.Compare(va.prerelease, vb.prerelease), true
+	}
+}
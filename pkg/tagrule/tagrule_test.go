@@ -0,0 +1,220 @@
+package tagrule
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		description string
+		conditions  []models.TagRuleCondition
+		expected    error
+	}{
+		{
+			description: "fails when there are no conditions",
+			conditions:  []models.TagRuleCondition{},
+			expected:    ErrConditionsEmpty,
+		},
+		{
+			description: "fails when the attribute is invalid",
+			conditions:  []models.TagRuleCondition{{Attribute: "kernel", Operator: models.TagRuleOperatorEqual, Value: "6.1"}},
+			expected:    ErrAttributeInvalid,
+		},
+		{
+			description: "fails when the operator is invalid",
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: "matches", Value: "docker"}},
+			expected:    ErrOperatorInvalid,
+		},
+		{
+			description: "fails when an ordering operator's value is not a version",
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorLess, Value: "latest"}},
+			expected:    ErrVersionInvalid,
+		},
+		{
+			description: "succeeds",
+			conditions: []models.TagRuleCondition{
+				{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"},
+				{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorLess, Value: "v0.16.0"},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Validate(tc.conditions))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	device := &models.Device{
+		Name: "web-01",
+		Info: &models.DeviceInfo{
+			ID:         "debian",
+			PrettyName: "Debian GNU/Linux 12 (bookworm)",
+			Version:    "v0.15.2",
+			Arch:       "amd64",
+			Platform:   "docker",
+		},
+	}
+
+	cases := []struct {
+		description string
+		device      *models.Device
+		conditions  []models.TagRuleCondition
+		expected    bool
+	}{
+		{
+			description: "does not match a rule without conditions",
+			device:      device,
+			conditions:  []models.TagRuleCondition{},
+			expected:    false,
+		},
+		{
+			description: "matches when the attribute is equal, ignoring the case",
+			device:      device,
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "Docker"}},
+			expected:    true,
+		},
+		{
+			description: "does not match when the attribute is different",
+			device:      device,
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "native"}},
+			expected:    false,
+		},
+		{
+			description: "matches when the attribute is not equal",
+			device:      device,
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributeArch, Operator: models.TagRuleOperatorNotEqual, Value: "arm64"}},
+			expected:    true,
+		},
+		{
+			description: "matches when the attribute contains the value",
+			device:      device,
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributePrettyName, Operator: models.TagRuleOperatorContains, Value: "bookworm"}},
+			expected:    true,
+		},
+		{
+			description: "matches when the attribute starts with the value",
+			device:      device,
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributeName, Operator: models.TagRuleOperatorPrefix, Value: "web-"}},
+			expected:    true,
+		},
+		{
+			description: "matches when the version is lower",
+			device:      device,
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorLess, Value: "0.16.0"}},
+			expected:    true,
+		},
+		{
+			description: "does not match when the version is greater",
+			device:      device,
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorLess, Value: "0.15.1"}},
+			expected:    false,
+		},
+		{
+			description: "matches when the version is equal on an inclusive operator",
+			device:      device,
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorGreaterEqual, Value: "0.15.2"}},
+			expected:    true,
+		},
+		{
+			description: "does not match the ordering operators when the attribute is not a version",
+			device:      &models.Device{Info: &models.DeviceInfo{Version: "latest"}},
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorLess, Value: "0.16.0"}},
+			expected:    false,
+		},
+		{
+			description: "does not match when the device has not reported its attributes",
+			device:      &models.Device{Name: "web-01"},
+			conditions:  []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"}},
+			expected:    false,
+		},
+		{
+			description: "does not match when one of the conditions does not match",
+			device:      device,
+			conditions: []models.TagRuleCondition{
+				{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"},
+				{Attribute: models.TagRuleAttributeArch, Operator: models.TagRuleOperatorEqual, Value: "arm64"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Match(&models.TagRule{Conditions: tc.conditions}, tc.device))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	docker := models.TagRule{
+		Tag:        "container",
+		Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributePlatform, Operator: models.TagRuleOperatorEqual, Value: "docker"}},
+	}
+
+	outdated := models.TagRule{
+		Tag:        "needs-update",
+		Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributeVersion, Operator: models.TagRuleOperatorLess, Value: "0.16.0"}},
+	}
+
+	cases := []struct {
+		description string
+		rules       []models.TagRule
+		device      *models.Device
+		tags        []string
+		changed     bool
+	}{
+		{
+			description: "appends the tags of the matched rules",
+			rules:       []models.TagRule{docker, outdated},
+			device:      &models.Device{Tags: []string{"production"}, Info: &models.DeviceInfo{Platform: "docker", Version: "0.15.0"}},
+			tags:        []string{"production", "container", "needs-update"},
+			changed:     true,
+		},
+		{
+			description: "removes the tags of the rules no longer matched",
+			rules:       []models.TagRule{docker, outdated},
+			device:      &models.Device{Tags: []string{"production", "container", "needs-update"}, Info: &models.DeviceInfo{Platform: "docker", Version: "0.16.0"}},
+			tags:        []string{"production", "container"},
+			changed:     true,
+		},
+		{
+			description: "keeps a tag when another rule assigning it is matched",
+			rules: []models.TagRule{docker, {
+				Tag:        "container",
+				Conditions: []models.TagRuleCondition{{Attribute: models.TagRuleAttributeOS, Operator: models.TagRuleOperatorEqual, Value: "alpine"}},
+			}},
+			device:  &models.Device{Tags: []string{"container"}, Info: &models.DeviceInfo{ID: "alpine", Platform: "native"}},
+			tags:    []string{"container"},
+			changed: false,
+		},
+		{
+			description: "skips the tags implied by the device's tags",
+			rules:       []models.TagRule{docker},
+			device:      &models.Device{Tags: []string{"container/docker"}, Info: &models.DeviceInfo{Platform: "docker"}},
+			tags:        []string{"container/docker"},
+			changed:     false,
+		},
+		{
+			description: "ignores the tags exceeding the limit",
+			rules:       []models.TagRule{docker, outdated},
+			device:      &models.Device{Tags: []string{"production", "europe"}, Info: &models.DeviceInfo{Platform: "docker", Version: "0.15.0"}},
+			tags:        []string{"production", "europe", "container"},
+			changed:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tags, changed := Apply(tc.rules, tc.device, 3)
+			assert.Equal(t, tc.tags, tags)
+			assert.Equal(t, tc.changed, changed)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/google/uuid" //nolint
 )
 
+//go:generate mockery --name UUID --structname Uuid --filename uuid.go
+
 // UUID is an interface that can provide uuid related functionality which allows us to test uuid dependent code.
 type UUID interface {
 	Generate() string
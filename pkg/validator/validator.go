@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/shellhub-io/shellhub/pkg/api/authorizer"
+	"github.com/shellhub-io/shellhub/pkg/geohash"
 )
 
 var (
@@ -39,6 +40,14 @@ const (
 	UserPasswordTag = "password"
 	// DeviceNameTag contains the rule to validate the device's name.
 	DeviceNameTag = "device_name"
+	// TagTag contains the rule to validate a tag.
+	TagTag = "tag"
+	// UserAliasTag contains the rule to validate the name of a user's alias.
+	UserAliasTag = "user_alias"
+	// UserAliasTargetTag contains the rule to validate the SSHID targeted by a user's alias.
+	UserAliasTargetTag = "user_alias_target"
+	// BoundingBoxTag contains the rule to validate a bounding box written as "west,south,east,north".
+	BoundingBoxTag = "bbox"
 	// PrivateKeyPEMTag contains the rule to validate a private key.
 	PrivateKeyPEMTag = "privateKeyPEM"
 	CertPEMTag       = "certPEM"
@@ -83,6 +92,38 @@ var Rules = []Rule{
 		},
 		Error: fmt.Errorf("the device name can only contain `_`, `-` and alpha numeric characters"),
 	},
+	{
+		Tag: TagTag,
+		Handler: func(field validator.FieldLevel) bool {
+			tag := field.Field().String()
+
+			return len(tag) >= 3 && len(tag) <= 255 && regexp.MustCompile(`^[a-zA-Z0-9]+(/[a-zA-Z0-9]+)*$`).MatchString(tag)
+		},
+		Error: fmt.Errorf("the tag must be between 3 and 255 characters, and can only contain alpha numeric levels separated by `/`"),
+	},
+	{
+		Tag: UserAliasTag,
+		Handler: func(field validator.FieldLevel) bool {
+			return regexp.MustCompile(`^([a-z0-9_-]){1,32}$`).MatchString(field.Field().String())
+		},
+		Error: fmt.Errorf("the alias must be between 1 and 32 characters, and can only contain lower case letters, numbers, `_` and `-`"),
+	},
+	{
+		Tag: UserAliasTargetTag,
+		Handler: func(field validator.FieldLevel) bool {
+			return regexp.MustCompile(`^[a-zA-Z0-9_-]+\.[a-zA-Z0-9_-]{1,64}(\+[a-zA-Z0-9_.-]+)?$`).MatchString(field.Field().String())
+		},
+		Error: fmt.Errorf("the alias target must be a SSHID like `namespace.hostname`, optionally followed by `+container`"),
+	},
+	{
+		Tag: BoundingBoxTag,
+		Handler: func(field validator.FieldLevel) bool {
+			_, err := geohash.ParseBox(field.Field().String())
+
+			return err == nil
+		},
+		Error: geohash.ErrBoxInvalid,
+	},
 	// api-key_name reports whether a given string is a valid name for an api key or not. A valid
 	// value must be more than 3 characters, less than 20 and does not contains any whitespace.
 	{
@@ -261,6 +261,183 @@ func TestDeviceName(t *testing.T) {
 	}
 }
 
+func TestTag(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		want        bool
+	}{
+		{
+			description: "failed when the tag is too short",
+			value:       "ab",
+			want:        false,
+		},
+		{
+			description: "failed when the tag contains invalid characters",
+			value:       "tag@1",
+			want:        false,
+		},
+		{
+			description: "failed when the tag has an empty level",
+			value:       "region//europe",
+			want:        false,
+		},
+		{
+			description: "failed when the tag ends with the separator",
+			value:       "region/",
+			want:        false,
+		},
+		{
+			description: "success when the tag is valid",
+			value:       "region",
+			want:        true,
+		},
+		{
+			description: "success when the tag is hierarchical",
+			value:       "region/europe/west",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				Tag string `validate:"required,tag"`
+			}{
+				Tag: tt.value,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestUserAlias(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		want        bool
+	}{
+		{
+			description: "failed when the alias contains a dot",
+			value:       "web.1",
+			want:        false,
+		},
+		{
+			description: "failed when the alias contains the separator",
+			value:       "john/web1",
+			want:        false,
+		},
+		{
+			description: "failed when the alias is too long",
+			value:       "abcdefghijklmnopqrstuvwxyz0123456",
+			want:        false,
+		},
+		{
+			description: "success when the alias is valid",
+			value:       "web_1-a",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				Alias string `validate:"required,user_alias"`
+			}{
+				Alias: tt.value,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestUserAliasTarget(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		want        bool
+	}{
+		{
+			description: "failed when the target doesn't have a namespace",
+			value:       "device",
+			want:        false,
+		},
+		{
+			description: "failed when the target has the device's OS user",
+			value:       "root@namespace.device",
+			want:        false,
+		},
+		{
+			description: "success when the target is a SSHID",
+			value:       "namespace.device",
+			want:        true,
+		},
+		{
+			description: "success when the target is a container",
+			value:       "namespace.device+nginx",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				Target string `validate:"required,user_alias_target"`
+			}{
+				Target: tt.value,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		want        bool
+	}{
+		{
+			description: "failed when the bounding box is incomplete",
+			value:       "-74.1,40.6",
+			want:        false,
+		},
+		{
+			description: "failed when the bounding box is reversed",
+			value:       "-73.7,40.9,-74.1,40.6",
+			want:        false,
+		},
+		{
+			description: "success when the bounding box is valid",
+			value:       "-74.1,40.6,-73.7,40.9",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				BBox string `validate:"required,bbox"`
+			}{
+				BBox: tt.value,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
 func TestKeyPEM(t *testing.T) {
 	tests := []struct {
 		description string
@@ -1,36 +1,102 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"runtime"
 	"time"
 
 	"github.com/labstack/echo-contrib/pprof"
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/banlist"
 	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
+	"github.com/shellhub-io/shellhub/ssh/pkg/motd"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sessionlimit"
 	"github.com/shellhub-io/shellhub/ssh/pkg/tunnel"
 	"github.com/shellhub-io/shellhub/ssh/server"
+	"github.com/shellhub-io/shellhub/ssh/session"
 	"github.com/shellhub-io/shellhub/ssh/web"
 	log "github.com/sirupsen/logrus"
 )
 
+//go:generate mockery --name Session --output ./mocks --filename session.go --srcpkg github.com/gliderlabs/ssh
+
 const ListenAddress = ":8080"
 
 func init() {
 	loglevel.SetLogLevel()
 	log.SetFormatter(&log.JSONFormatter{})
+	log.AddHook(correlation.NewHook())
 }
 
 type Envs struct {
 	ConnectTimeout time.Duration `env:"CONNECT_TIMEOUT,default=30s"`
 	RedisURI       string        `env:"REDIS_URI,default=redis://redis:6379"`
 	RecordURL      string        `env:"RECORD_URL,default=cloud-api:8080"`
+	// RecordSpillDir is the directory where the session's frames are spilled to when the record endpoint is slow or
+	// unavailable, until they are sent.
+	RecordSpillDir string `env:"RECORD_SPILL_DIR,default=/tmp/shellhub/records"`
+	// RecordingBackend is where the sessions are recorded: "api", the record endpoint, or "s3", a S3-compatible
+	// object storage configured by the RECORDING_S3 variables.
+	RecordingBackend string `env:"RECORDING_BACKEND,default=api"`
+	// RecordingS3Endpoint is the URL of the S3-compatible storage, like http://minio:9000.
+	RecordingS3Endpoint string `env:"RECORDING_S3_ENDPOINT"`
+	// RecordingS3Region is the region of the recordings' bucket.
+	RecordingS3Region string `env:"RECORDING_S3_REGION,default=us-east-1"`
+	// RecordingS3Bucket is the bucket where the recordings are kept.
+	RecordingS3Bucket string `env:"RECORDING_S3_BUCKET"`
+	// RecordingS3AccessKeyID is the access key used to upload the recordings.
+	RecordingS3AccessKeyID string `env:"RECORDING_S3_ACCESS_KEY_ID"`
+	// RecordingS3SecretAccessKey is the secret of the access key.
+	RecordingS3SecretAccessKey string `env:"RECORDING_S3_SECRET_ACCESS_KEY"`
+	// RecordingS3PathStyle addresses the bucket on the URL's path, as required by MinIO.
+	RecordingS3PathStyle bool `env:"RECORDING_S3_PATH_STYLE,default=false"`
+	// RecordingS3Prefix is the prefix of the recordings' keys on the bucket.
+	RecordingS3Prefix string `env:"RECORDING_S3_PREFIX"`
 	// Allows SSH to connect with an agent via a public key when the agent version is less than 0.6.0.
 	// Agents 0.5.x or earlier do not validate the public key request and may panic.
 	// Please refer to: https://github.com/shellhub-io/shellhub/issues/3453
 	AllowPublickeyAccessBelow060 bool `env:"ALLOW_PUBLIC_KEY_ACCESS_BELLOW_0_6_0,default=false"`
+	// MOTD is the message of the day shown, on interactive sessions, to everyone connecting through the instance. It
+	// is a template with the instance's name, the maintenance notice and the session's user, device and namespace.
+	MOTD string `env:"MOTD"`
+	// InstanceName is the name of the instance shown on the message of the day.
+	InstanceName string `env:"INSTANCE_NAME,default=ShellHub"`
+	// MaintenanceNotice is the maintenance notice shown on the message of the day.
+	MaintenanceNotice string `env:"MAINTENANCE_NOTICE"`
+	// TunnelPathPrefix is the path prefix where the agents open the reverse tunnel, for deployments behind proxies that
+	// rewrite the paths. It must match the prefix advertised by the API.
+	TunnelPathPrefix string `env:"TUNNEL_PATH_PREFIX,default=/ssh"`
+	// SessionKeepAliveInterval is for how long an interactive session stays idle before a keep-alive request is sent to
+	// its client, on the namespaces that enabled it. It is distinct from the keep-alive of the agent's tunnel. A zero
+	// interval disables it.
+	SessionKeepAliveInterval time.Duration `env:"SESSION_KEEPALIVE_INTERVAL,default=30s"`
+	// DenialFirewallMessage is the message shown, on the SSH banner, when a firewall rule denies the connection. The
+	// default message is shown when it is empty.
+	DenialFirewallMessage string `env:"DENIAL_FIREWALL_MESSAGE"`
+	// DenialBillingMessage is the message shown, on the SSH banner, when the namespace's billing denies the connection.
+	DenialBillingMessage string `env:"DENIAL_BILLING_MESSAGE"`
+	// DenialUnavailableMessage is the message shown, on the SSH banner, when the connection's policies couldn't be
+	// evaluated.
+	DenialUnavailableMessage string `env:"DENIAL_UNAVAILABLE_MESSAGE"`
+	// DenialScheduleMessage is the message shown, on the SSH banner, when the namespace's session schedules deny the
+	// connection.
+	DenialScheduleMessage string `env:"DENIAL_SCHEDULE_MESSAGE"`
+	// DenialPolicyMessage is the message shown, on the SSH banner, when the organization's policies deny the
+	// connection.
+	DenialPolicyMessage string `env:"DENIAL_POLICY_MESSAGE"`
+	// DenialWebhookMessage is the message shown, on the SSH banner, when the namespace's connection webhook denies the
+	// connection.
+	DenialWebhookMessage string `env:"DENIAL_WEBHOOK_MESSAGE"`
+	// SessionLimitCacheTTL is for how long the namespace's limit of simultaneous interactive sessions is kept before
+	// being fetched again from the API.
+	SessionLimitCacheTTL time.Duration `env:"SESSION_LIMIT_CACHE_TTL,default=30s"`
 }
 
 func main() {
@@ -46,16 +112,44 @@ func main() {
 			Fatal("failed to connect to redis cache")
 	}
 
-	tun, err := tunnel.NewTunnel("/ssh/connection", "/ssh/revdial", env.RedisURI)
+	tun, err := tunnel.NewTunnel(env.TunnelPathPrefix, env.RedisURI)
 	if err != nil {
 		log.WithError(err).
 			Fatal("failed to create the internalclient")
 	}
 
+	msg, err := motd.New(env.MOTD, env.InstanceName, env.MaintenanceNotice)
+	if err != nil {
+		log.WithError(err).
+			Fatal("failed to parse the message of the day")
+	}
+
+	storage, err := newRecordStorage(env)
+	if err != nil {
+		log.WithError(err).
+			Fatal("failed to configure the recording backend")
+	}
+
+	cli, err := internalclient.NewClient()
+	if err != nil {
+		log.WithError(err).
+			Fatal("failed to create the internalclient")
+	}
+
+	limiter := sessionlimit.New(cache, sessionlimit.FetchFromClient(cli), env.SessionLimitCacheTTL)
+	go limiter.Refresh(context.Background())
+
 	router := tun.GetRouter()
+	router.Use(correlation.Middleware)
 
 	web.NewSSHServerBridge(router, cache)
 
+	// NOTICE: The counts are of the interactive sessions served by this instance only, while the limit is enforced
+	// on the counts shared by every instance.
+	router.GET("/internal/sessions/counts", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, limiter.Counts())
+	})
+
 	if envs.IsDevelopment() {
 		runtime.SetBlockProfileRate(1)
 		pprof.Register(router)
@@ -89,7 +183,22 @@ func main() {
 		errs <- server.NewServer(&server.Options{
 			ConnectTimeout:               env.ConnectTimeout,
 			RecordURL:                    env.RecordURL,
+			RecordSpillDir:               env.RecordSpillDir,
+			RecordStorage:                storage,
+			RecordStoragePrefix:          env.RecordingS3Prefix,
 			AllowPublickeyAccessBelow060: env.AllowPublickeyAccessBelow060,
+			MOTD:                         msg,
+			Banlist:                      banlist.New(cache, banlist.DefaultRefreshInterval),
+			SessionKeepAliveInterval:     env.SessionKeepAliveInterval,
+			SessionLimiter:               limiter,
+			DenialMessages: session.DenialMessages{
+				session.DenialFirewall:    env.DenialFirewallMessage,
+				session.DenialBilling:     env.DenialBillingMessage,
+				session.DenialUnavailable: env.DenialUnavailableMessage,
+				session.DenialSchedule:    env.DenialScheduleMessage,
+				session.DenialPolicy:      env.DenialPolicyMessage,
+				session.DenialWebhook:     env.DenialWebhookMessage,
+			},
 		}, tun.Tunnel, cache).ListenAndServe()
 	}()
 
@@ -99,3 +208,23 @@ func main() {
 
 	log.Warn("ssh service is closed")
 }
+
+// newRecordStorage creates the object storage where the sessions are recorded, as configured by the recording backend.
+// It returns nil when the sessions are recorded on the record endpoint.
+func newRecordStorage(env *Envs) (objectstorage.Storage, error) {
+	switch env.RecordingBackend {
+	case "", "api":
+		return nil, nil
+	case "s3":
+		return objectstorage.NewS3Storage(objectstorage.S3Config{
+			Endpoint:        env.RecordingS3Endpoint,
+			Region:          env.RecordingS3Region,
+			Bucket:          env.RecordingS3Bucket,
+			AccessKeyID:     env.RecordingS3AccessKeyID,
+			SecretAccessKey: env.RecordingS3SecretAccessKey,
+			PathStyle:       env.RecordingS3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown recording backend %q", env.RecordingBackend)
+	}
+}
@@ -0,0 +1,196 @@
+// Package commandline reconstructs the command lines typed on an interactive session from the client's input, editing
+// them like the line editor of a shell would.
+//
+// The reconstruction is best-effort: the editions done by the shell itself, like the history recall and the tab
+// completion, aren't visible on the input, so the reconstructed line may differ from the one the shell runs.
+package commandline
+
+import (
+	"unicode/utf8"
+)
+
+// MaxLength is the maximum number of characters kept from a command line. The characters typed after it are discarded.
+const MaxLength = 4096
+
+// Control characters handled by [Line].
+const (
+	ctrlA     = 0x01 // Moves the cursor to the start of the line.
+	ctrlB     = 0x02 // Moves the cursor one character left.
+	ctrlC     = 0x03 // Discards the line.
+	ctrlE     = 0x05 // Moves the cursor to the end of the line.
+	ctrlF     = 0x06 // Moves the cursor one character right.
+	ctrlH     = 0x08 // Deletes the character before the cursor.
+	lineFeed  = 0x0a // Submits the line.
+	ctrlK     = 0x0b // Deletes from the cursor to the end of the line.
+	carriage  = 0x0d // Submits the line.
+	ctrlU     = 0x15 // Deletes from the start of the line to the cursor.
+	ctrlW     = 0x17 // Deletes the word before the cursor.
+	escape    = 0x1b // Starts an escape sequence.
+	backspace = 0x7f // Deletes the character before the cursor.
+)
+
+// Line is a command line being typed on a session. Its zero value is an empty line ready to use.
+type Line struct {
+	runes  []rune
+	cursor int
+	// sequence is the escape sequence being received, starting with the escape character.
+	sequence []byte
+	// partial is the start of an UTF-8 character split between writes.
+	partial []byte
+}
+
+// Feed edits the line with a byte of the client's input. When the byte submits the line, it returns the line and true,
+// starting a new one.
+func (l *Line) Feed(b byte) (string, bool) {
+	if l.sequence != nil {
+		l.escape(b)
+
+		return "", false
+	}
+
+	if l.partial != nil || b >= utf8.RuneSelf {
+		l.partial = append(l.partial, b)
+		if !utf8.FullRune(l.partial) {
+			return "", false
+		}
+
+		r, _ := utf8.DecodeRune(l.partial)
+		l.partial = nil
+
+		if r != utf8.RuneError {
+			l.insert(r)
+		}
+
+		return "", false
+	}
+
+	switch b {
+	case carriage, lineFeed:
+		line := string(l.runes)
+		l.Reset()
+
+		return line, true
+	case ctrlA:
+		l.cursor = 0
+	case ctrlE:
+		l.cursor = len(l.runes)
+	case ctrlB:
+		l.move(-1)
+	case ctrlF:
+		l.move(1)
+	case ctrlH, backspace:
+		if l.cursor > 0 {
+			l.delete(l.cursor-1, l.cursor)
+		}
+	case ctrlK:
+		l.delete(l.cursor, len(l.runes))
+	case ctrlU:
+		l.delete(0, l.cursor)
+	case ctrlW:
+		start := l.cursor
+		for start > 0 && l.runes[start-1] == ' ' {
+			start--
+		}
+
+		for start > 0 && l.runes[start-1] != ' ' {
+			start--
+		}
+
+		l.delete(start, l.cursor)
+	case ctrlC:
+		l.Reset()
+	case escape:
+		l.sequence = []byte{b}
+	default:
+		// NOTICE: the other control characters, like the tab, don't change the line as typed.
+		if b >= ' ' {
+			l.insert(rune(b))
+		}
+	}
+
+	return "", false
+}
+
+// String returns the line typed so far.
+func (l *Line) String() string {
+	return string(l.runes)
+}
+
+// Reset discards the line typed so far.
+func (l *Line) Reset() {
+	l.runes = l.runes[:0]
+	l.cursor = 0
+	l.sequence = nil
+	l.partial = nil
+}
+
+func (l *Line) insert(r rune) {
+	if len(l.runes) >= MaxLength {
+		return
+	}
+
+	l.runes = append(l.runes, 0)
+	copy(l.runes[l.cursor+1:], l.runes[l.cursor:])
+	l.runes[l.cursor] = r
+	l.cursor++
+}
+
+func (l *Line) delete(start, end int) {
+	l.runes = append(l.runes[:start], l.runes[end:]...)
+	l.cursor = start
+}
+
+func (l *Line) move(offset int) {
+	l.cursor = min(max(l.cursor+offset, 0), len(l.runes))
+}
+
+// escape receives a byte of an escape sequence, applying the sequence when it is complete. The sequences moving the
+// cursor and deleting the character under it are applied; the other ones, like the bracketed paste's markers, are
+// ignored.
+func (l *Line) escape(b byte) {
+	l.sequence = append(l.sequence, b)
+
+	switch {
+	case len(l.sequence) == 2:
+		// NOTICE: the sequences started by ESC [ are control sequences and ESC O are the application mode's keys. Any
+		// other character, like the ones of the Alt key combinations, completes the sequence.
+		if b != '[' && b != 'O' {
+			l.sequence = nil
+		}
+
+		return
+	case b >= 0x40 && b <= 0x7e:
+		// NOTICE: a byte in this range is the final byte of the sequence.
+	case len(l.sequence) > 16:
+		l.sequence = nil
+
+		return
+	default:
+		return
+	}
+
+	params := string(l.sequence[2 : len(l.sequence)-1])
+	l.sequence = nil
+
+	switch b {
+	case 'C':
+		l.move(1)
+	case 'D':
+		l.move(-1)
+	case 'H':
+		l.cursor = 0
+	case 'F':
+		l.cursor = len(l.runes)
+	case '~':
+		switch params {
+		case "1", "7":
+			l.cursor = 0
+		case "4", "8":
+			l.cursor = len(l.runes)
+		case "3":
+			if l.cursor < len(l.runes) {
+				l.delete(l.cursor, l.cursor+1)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package commandline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLine(t *testing.T) {
+	cases := []struct {
+		description string
+		input       string
+		expected    []string
+		pending     string
+	}{
+		{
+			description: "submits the typed line on carriage return",
+			input:       "ls -la\r",
+			expected:    []string{"ls -la"},
+		},
+		{
+			description: "submits the typed line on line feed",
+			input:       "ls -la\n",
+			expected:    []string{"ls -la"},
+		},
+		{
+			description: "submits each line of the input",
+			input:       "cd /tmp\rls\r",
+			expected:    []string{"cd /tmp", "ls"},
+		},
+		{
+			description: "keeps the line not submitted",
+			input:       "rm -rf",
+			expected:    nil,
+			pending:     "rm -rf",
+		},
+		{
+			description: "deletes the characters before the cursor on backspace",
+			input:       "lss\x7f -l\x08a\r",
+			expected:    []string{"ls -a"},
+		},
+		{
+			description: "discards the line on Ctrl-C",
+			input:       "rm -rf /\x03ls\r",
+			expected:    []string{"ls"},
+		},
+		{
+			description: "deletes the line before the cursor on Ctrl-U",
+			input:       "rm -rf /\x15ls\r",
+			expected:    []string{"ls"},
+		},
+		{
+			description: "deletes the word before the cursor on Ctrl-W",
+			input:       "rm -rf  \x17-f /tmp/a\r",
+			expected:    []string{"rm -f /tmp/a"},
+		},
+		{
+			description: "inserts the characters at the start of the line after Ctrl-A",
+			input:       "rf /\x01rm -\r",
+			expected:    []string{"rm -rf /"},
+		},
+		{
+			description: "deletes the line after the cursor on Ctrl-K",
+			input:       "ls /etc\x01\x06\x06\x0b\r",
+			expected:    []string{"ls"},
+		},
+		{
+			description: "moves the cursor with the arrow keys",
+			input:       "rm -rf/\x1b[D\x1b[D\x1b[C \r",
+			expected:    []string{"rm -rf /"},
+		},
+		{
+			description: "moves the cursor with the application mode's arrow keys",
+			input:       "rm -rf/\x1bOD\x1bOD\x1bOC \r",
+			expected:    []string{"rm -rf /"},
+		},
+		{
+			description: "moves the cursor with home and end keys",
+			input:       "-rf\x1b[Hrm \x1b[F /\r",
+			expected:    []string{"rm -rf /"},
+		},
+		{
+			description: "deletes the character under the cursor on delete",
+			input:       "lss\x1b[D\x1b[3~\r",
+			expected:    []string{"ls"},
+		},
+		{
+			description: "ignores the bracketed paste's markers",
+			input:       "\x1b[200~rm -rf /\x1b[201~\r",
+			expected:    []string{"rm -rf /"},
+		},
+		{
+			description: "ignores the Alt key combinations",
+			input:       "ls\x1bb -l\r",
+			expected:    []string{"ls -l"},
+		},
+		{
+			description: "ignores the tab",
+			input:       "ls\t\r",
+			expected:    []string{"ls"},
+		},
+		{
+			description: "keeps the multi-byte characters",
+			input:       "echo ação\x7f\r",
+			expected:    []string{"echo açã"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var line Line
+			var submitted []string
+
+			for _, b := range []byte(tc.input) {
+				if l, ok := line.Feed(b); ok {
+					submitted = append(submitted, l)
+				}
+			}
+
+			assert.Equal(t, tc.expected, submitted)
+			assert.Equal(t, tc.pending, line.String())
+		})
+	}
+}
+
+func TestLineMaxLength(t *testing.T) {
+	var line Line
+
+	for i := 0; i < MaxLength+10; i++ {
+		line.Feed('a')
+	}
+
+	l, ok := line.Feed('\r')
+	assert.True(t, ok)
+	assert.Len(t, l, MaxLength)
+}
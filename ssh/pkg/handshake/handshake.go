@@ -0,0 +1,235 @@
+// Package handshake inspects the plain text part of the SSH handshake, the key exchange init messages sent by both
+// sides of the connection, to find out which algorithms were negotiated between the SSH client and server.
+//
+// The [golang.org/x/crypto/ssh] package doesn't expose the negotiated algorithms, so the connection is wrapped by
+// [Conn] that keeps a copy of the first packets sent on each direction until the key exchange init is found.
+package handshake
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// msgKexInit is the SSH message number of the key exchange init message.
+const msgKexInit = 20
+
+// maxBufferSize is the maximum number of bytes kept from each direction while looking for the key exchange init.
+const maxBufferSize = 64 * 1024
+
+var (
+	ErrNotFound  = errors.New("key exchange init message not found")
+	ErrMalformed = errors.New("malformed key exchange init message")
+)
+
+// aeadCiphers are the ciphers that provide its own message authentication, where the MAC isn't negotiated.
+var aeadCiphers = []string{
+	"aes128-gcm@openssh.com",
+	"aes256-gcm@openssh.com",
+	"chacha20-poly1305@openssh.com",
+}
+
+// Algorithms are the algorithms negotiated between the SSH client and server.
+type Algorithms struct {
+	KeyExchange        string
+	HostKey            string
+	CipherClientServer string
+	CipherServerClient string
+	MACClientServer    string
+	MACServerClient    string
+}
+
+// kexInit contains the name-lists sent in the key exchange init message.
+type kexInit struct {
+	KeyExchange        []string
+	HostKey            []string
+	CipherClientServer []string
+	CipherServerClient []string
+	MACClientServer    []string
+	MACServerClient    []string
+}
+
+// sniffer keeps the bytes sent on a direction of the connection until the key exchange init message is found.
+type sniffer struct {
+	buffer  []byte
+	kexInit *kexInit
+	done    bool
+}
+
+func (s *sniffer) write(data []byte) {
+	if s.done {
+		return
+	}
+
+	s.buffer = append(s.buffer, data...)
+
+	kexInit, err := findKexInit(s.buffer)
+	switch {
+	case err == nil:
+		s.kexInit = kexInit
+		s.done = true
+	case errors.Is(err, ErrNotFound) && len(s.buffer) < maxBufferSize:
+		return
+	default:
+		s.done = true
+	}
+
+	s.buffer = nil
+}
+
+// Conn wraps a [net.Conn] on the server side of a SSH connection, inspecting the key exchange init messages.
+type Conn struct {
+	net.Conn
+
+	mu     sync.Mutex
+	client sniffer
+	server sniffer
+}
+
+// NewConn creates a new [Conn] wrapping conn.
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{Conn: conn}
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.mu.Lock()
+		c.client.write(b[:n])
+		c.mu.Unlock()
+	}
+
+	return n, err
+}
+
+func (c *Conn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	c.server.write(b)
+	c.mu.Unlock()
+
+	return c.Conn.Write(b)
+}
+
+// Algorithms returns the algorithms negotiated between the SSH client and server, following the algorithm negotiation
+// described on RFC 4253, section 7.1. It returns [ErrNotFound] when the key exchange init messages weren't seen yet.
+func (c *Conn) Algorithms() (*Algorithms, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.client.kexInit == nil || c.server.kexInit == nil {
+		return nil, ErrNotFound
+	}
+
+	client, server := c.client.kexInit, c.server.kexInit
+
+	algorithms := &Algorithms{
+		KeyExchange:        agree(client.KeyExchange, server.KeyExchange),
+		HostKey:            agree(client.HostKey, server.HostKey),
+		CipherClientServer: agree(client.CipherClientServer, server.CipherClientServer),
+		CipherServerClient: agree(client.CipherServerClient, server.CipherServerClient),
+	}
+
+	if !slices.Contains(aeadCiphers, algorithms.CipherClientServer) {
+		algorithms.MACClientServer = agree(client.MACClientServer, server.MACClientServer)
+	}
+
+	if !slices.Contains(aeadCiphers, algorithms.CipherServerClient) {
+		algorithms.MACServerClient = agree(client.MACServerClient, server.MACServerClient)
+	}
+
+	return algorithms, nil
+}
+
+// agree returns the first client's algorithm supported by the server.
+func agree(client, server []string) string {
+	for _, algorithm := range client {
+		if slices.Contains(server, algorithm) {
+			return algorithm
+		}
+	}
+
+	return ""
+}
+
+// findKexInit looks for the key exchange init message on data, that starts with the identification strings, followed
+// by the first binary packet.
+func findKexInit(data []byte) (*kexInit, error) {
+	// NOTICE: The server may send other lines of data before the identification string, but all of them end before
+	// the line starting with "SSH-".
+	for {
+		end := bytes.IndexByte(data, '\n')
+		if end < 0 {
+			return nil, ErrNotFound
+		}
+
+		line := data[:end]
+		data = data[end+1:]
+
+		if bytes.HasPrefix(line, []byte("SSH-")) {
+			break
+		}
+	}
+
+	// NOTICE: A binary packet is composed of the packet length, the padding length, the payload and the padding.
+	if len(data) < 5 {
+		return nil, ErrNotFound
+	}
+
+	length := binary.BigEndian.Uint32(data[:4])
+	if length > maxBufferSize {
+		return nil, ErrMalformed
+	}
+
+	if uint32(len(data)-4) < length {
+		return nil, ErrNotFound
+	}
+
+	padding := uint32(data[4])
+	if padding+1 > length {
+		return nil, ErrMalformed
+	}
+
+	return parseKexInit(data[5 : 4+length-padding])
+}
+
+// parseKexInit parses the payload of a key exchange init message.
+func parseKexInit(payload []byte) (*kexInit, error) {
+	const cookieSize = 16
+
+	if len(payload) < 1+cookieSize || payload[0] != msgKexInit {
+		return nil, ErrMalformed
+	}
+
+	payload = payload[1+cookieSize:]
+
+	lists := make([][]string, 6)
+	for i := range lists {
+		if len(payload) < 4 {
+			return nil, ErrMalformed
+		}
+
+		size := binary.BigEndian.Uint32(payload[:4])
+		if uint32(len(payload)-4) < size {
+			return nil, ErrMalformed
+		}
+
+		if size > 0 {
+			lists[i] = strings.Split(string(payload[4:4+size]), ",")
+		}
+
+		payload = payload[4+size:]
+	}
+
+	return &kexInit{
+		KeyExchange:        lists[0],
+		HostKey:            lists[1],
+		CipherClientServer: lists[2],
+		CipherServerClient: lists[3],
+		MACClientServer:    lists[4],
+		MACServerClient:    lists[5],
+	}, nil
+}
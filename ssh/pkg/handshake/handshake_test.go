@@ -0,0 +1,133 @@
+package handshake
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestConnAlgorithms(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := gossh.NewSignerFromKey(key)
+	require.NoError(t, err)
+
+	cases := []struct {
+		description string
+		config      gossh.Config
+		expected    *Algorithms
+	}{
+		{
+			description: "succeeds when cipher requires a MAC",
+			config: gossh.Config{
+				KeyExchanges: []string{"curve25519-sha256"},
+				Ciphers:      []string{"aes128-ctr"},
+				MACs:         []string{"hmac-sha2-256"},
+			},
+			expected: &Algorithms{
+				KeyExchange:        "curve25519-sha256",
+				HostKey:            "ssh-ed25519",
+				CipherClientServer: "aes128-ctr",
+				CipherServerClient: "aes128-ctr",
+				MACClientServer:    "hmac-sha2-256",
+				MACServerClient:    "hmac-sha2-256",
+			},
+		},
+		{
+			description: "succeeds when cipher is an AEAD one",
+			config: gossh.Config{
+				KeyExchanges: []string{"ecdh-sha2-nistp256"},
+				Ciphers:      []string{"chacha20-poly1305@openssh.com"},
+			},
+			expected: &Algorithms{
+				KeyExchange:        "ecdh-sha2-nistp256",
+				HostKey:            "ssh-ed25519",
+				CipherClientServer: "chacha20-poly1305@openssh.com",
+				CipherServerClient: "chacha20-poly1305@openssh.com",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer listener.Close()
+
+			conns := make(chan *Conn, 1)
+			go func() {
+				serverConn, err := listener.Accept()
+				if err != nil {
+					close(conns)
+
+					return
+				}
+
+				conn := NewConn(serverConn)
+				conns <- conn
+
+				config := &gossh.ServerConfig{NoClientAuth: true}
+				config.AddHostKey(signer)
+
+				gossh.NewServerConn(conn, config) //nolint:errcheck
+			}()
+
+			clientConn, err := net.Dial("tcp", listener.Addr().String())
+			require.NoError(t, err)
+			defer clientConn.Close()
+
+			conn := <-conns
+			require.NotNil(t, conn)
+			defer conn.Close()
+
+			client, _, _, err := gossh.NewClientConn(clientConn, "", &gossh.ClientConfig{
+				Config:          tc.config,
+				User:            "root",
+				HostKeyCallback: gossh.InsecureIgnoreHostKey(), //nolint:gosec
+			})
+			require.NoError(t, err)
+			defer client.Close()
+
+			algorithms, err := conn.Algorithms()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, algorithms)
+		})
+	}
+}
+
+func TestFindKexInit(t *testing.T) {
+	cases := []struct {
+		description string
+		data        []byte
+		expected    error
+	}{
+		{
+			description: "fails when identification string is incomplete",
+			data:        []byte("SSH-2.0-OpenSSH"),
+			expected:    ErrNotFound,
+		},
+		{
+			description: "fails when packet is incomplete",
+			data:        []byte("SSH-2.0-OpenSSH\r\n\x00\x00\x01\x00\x04\x14"),
+			expected:    ErrNotFound,
+		},
+		{
+			description: "fails when packet is not a key exchange init",
+			data:        []byte("SSH-2.0-OpenSSH\r\n\x00\x00\x00\x0c\x0a\x15\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"),
+			expected:    ErrMalformed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := findKexInit(tc.data)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
@@ -1,17 +1,27 @@
 package host
 
-import "net"
+import (
+	"net"
+	"net/netip"
+)
 
 type Host struct {
 	Host string
 }
 
+// NewHost creates a new [Host] from an address with the form "host:port". When the host is an IP address, it is kept
+// on its canonical form, where IPv6 literals are compressed and IPv4-mapped IPv6 addresses become IPv4 ones, so it can
+// be stored on session records and matched against the firewall rules regardless of how the client connected.
 func NewHost(address string) (*Host, error) {
 	host, _, err := net.SplitHostPort(address)
 	if err != nil {
 		return nil, err
 	}
 
+	if addr, err := netip.ParseAddr(host); err == nil {
+		host = addr.Unmap().String()
+	}
+
 	return &Host{Host: host}, nil
 }
 
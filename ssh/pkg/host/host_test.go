@@ -35,7 +35,12 @@ func TestNewHost(t *testing.T) {
 		{
 			description: "succeeds when address contains an IPv6 and port",
 			address:     "[2001:0db8:85a3:0000:0000:8a2e:0370:7334]:8080",
-			expected:    &Host{"2001:0db8:85a3:0000:0000:8a2e:0370:7334"},
+			expected:    &Host{"2001:db8:85a3::8a2e:370:7334"},
+		},
+		{
+			description: "succeeds when address contains an IPv4-mapped IPv6 and port",
+			address:     "[::ffff:192.168.0.1]:8080",
+			expected:    &Host{"192.168.0.1"},
 		},
 		{
 			description: "fails when the address is neither IPv4 nor IPv6 and does not contain any port",
@@ -0,0 +1,68 @@
+// Package motd renders the message of the day configured by the instance's administrator, shown to everyone opening
+// an interactive session through the instance, before the namespace's announcement.
+//
+// The message is a [text/template] with the fields of [Variables], like:
+//
+//	Welcome to {{.Instance}}, {{.User}}.{{if .Maintenance}} Maintenance: {{.Maintenance}}{{end}}
+package motd
+
+import (
+	"strings"
+	"text/template"
+)
+
+// Variables are the values available to the message's template.
+type Variables struct {
+	// Instance is the name of the ShellHub instance.
+	Instance string
+	// Maintenance is the maintenance notice set by the instance's administrator, if any.
+	Maintenance string
+	// User is the username used to log in on the device.
+	User string
+	// Device is the name of the device connected.
+	Device string
+	// Namespace is the name of the device's namespace.
+	Namespace string
+	// SSHID is the combination of the device's name and the namespace's name.
+	SSHID string
+}
+
+// MOTD is a message of the day, with the values of the variables set by the instance's administrator.
+type MOTD struct {
+	template    *template.Template
+	instance    string
+	maintenance string
+}
+
+// New parses text as the message's template. As the message is set through environment variables, a literal "\n" on
+// text is a line break. It returns nil, with no error, when text is empty.
+func New(text, instance, maintenance string) (*MOTD, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("motd").Option("missingkey=zero").Parse(strings.ReplaceAll(text, `\n`, "\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MOTD{template: tmpl, instance: instance, maintenance: maintenance}, nil
+}
+
+// Render renders the message with the session's variables, ready to be written to the client's terminal.
+func (m *MOTD) Render(vars Variables) (string, error) {
+	vars.Instance = m.instance
+	vars.Maintenance = m.maintenance
+
+	builder := new(strings.Builder)
+	if err := m.template.Execute(builder, vars); err != nil {
+		return "", err
+	}
+
+	message := strings.TrimRight(builder.String(), " \n\t")
+	if message == "" {
+		return "", nil
+	}
+
+	return strings.ReplaceAll(message, "\n", "\n\r") + "\n\r", nil
+}
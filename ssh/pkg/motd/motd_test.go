@@ -0,0 +1,95 @@
+package motd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		description string
+		text        string
+		expected    func(t *testing.T, motd *MOTD, err error)
+	}{
+		{
+			description: "returns nil when the message is empty",
+			text:        "",
+			expected: func(t *testing.T, motd *MOTD, err error) {
+				assert.NoError(t, err)
+				assert.Nil(t, motd)
+			},
+		},
+		{
+			description: "fails when the template is invalid",
+			text:        "Welcome to {{.Instance",
+			expected: func(t *testing.T, motd *MOTD, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, motd)
+			},
+		},
+		{
+			description: "succeeds",
+			text:        "Welcome to {{.Instance}}",
+			expected: func(t *testing.T, motd *MOTD, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, motd)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			motd, err := New(tc.text, "ShellHub", "")
+			tc.expected(t, motd, err)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		description string
+		text        string
+		maintenance string
+		expected    string
+	}{
+		{
+			description: "renders the variables",
+			text:        "Welcome to {{.Instance}}, {{.User}}. You are on {{.Device}} of {{.Namespace}} ({{.SSHID}}).",
+			expected:    "Welcome to ShellHub, root. You are on device of namespace (namespace.device@localhost).\n\r",
+		},
+		{
+			description: "renders the maintenance notice when set",
+			text:        `Welcome to {{.Instance}}.{{if .Maintenance}}\nMaintenance: {{.Maintenance}}{{end}}`,
+			maintenance: "on Sunday at 10:00 UTC",
+			expected:    "Welcome to ShellHub.\n\rMaintenance: on Sunday at 10:00 UTC\n\r",
+		},
+		{
+			description: "omits the maintenance notice when unset",
+			text:        `Welcome to {{.Instance}}.{{if .Maintenance}}\nMaintenance: {{.Maintenance}}{{end}}`,
+			expected:    "Welcome to ShellHub.\n\r",
+		},
+		{
+			description: "renders nothing when the message is blank",
+			text:        `{{if .Maintenance}}Maintenance: {{.Maintenance}}{{end}}\n`,
+			expected:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			motd, err := New(tc.text, "ShellHub", tc.maintenance)
+			require.NoError(t, err)
+
+			message, err := motd.Render(Variables{
+				User:      "root",
+				Device:    "device",
+				Namespace: "namespace",
+				SSHID:     "namespace.device@localhost",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, message)
+		})
+	}
+}
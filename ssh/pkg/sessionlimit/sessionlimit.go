@@ -0,0 +1,193 @@
+// Package sessionlimit enforces the namespaces' maximum number of simultaneous interactive sessions, defined on their
+// settings, counting the interactive sessions served by the SSH server.
+//
+// The limits are fetched from the API and kept for a while, so opening a session doesn't cost a request to it. The
+// sessions are counted on the cache, shared by every SSH server instance, so the limit holds across them. Each
+// instance refreshes the counts of the namespaces it has sessions of, and a count expires when none refreshes it, so
+// the sessions of a crashed instance stop counting once their namespace has no other session open.
+package sessionlimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrLimitReached = errors.New("the namespace reached its limit of simultaneous interactive sessions, close one of them to open a new one")
+
+// DefaultTTL is how long a namespace's limit is kept before being fetched again.
+const DefaultTTL = 30 * time.Second
+
+// CountTTL is how long a namespace's count is kept on the cache without being refreshed by the instances holding its
+// sessions.
+const CountTTL = time.Minute
+
+// countKey is the cache's key where the namespace's count is kept.
+func countKey(tenant string) string {
+	return "session-limit={" + tenant + "}"
+}
+
+// Fetcher fetches the namespace's maximum number of simultaneous interactive sessions. Zero means no limit.
+type Fetcher func(tenant string) (int, error)
+
+// FetchFromClient creates a [Fetcher] that reads the limit from the namespace's settings through the API.
+func FetchFromClient(cli internalclient.Client) Fetcher {
+	return func(tenant string) (int, error) {
+		namespace, errs := cli.NamespaceLookup(tenant)
+		if len(errs) > 0 {
+			return 0, errs[0]
+		}
+
+		if namespace == nil || namespace.Settings == nil {
+			return 0, nil
+		}
+
+		return namespace.Settings.MaxSessions, nil
+	}
+}
+
+type limit struct {
+	max     int
+	expires time.Time
+}
+
+// Limiter counts the interactive sessions per namespace, refusing the ones above the namespace's limit.
+type Limiter struct {
+	mu     sync.Mutex
+	cache  cache.Cache
+	fetch  Fetcher
+	ttl    time.Duration
+	limits map[string]limit
+	// counts are the sessions of each namespace held by this instance.
+	counts map[string]int
+	// now returns the current time. It is replaced on tests.
+	now func() time.Time
+}
+
+// New creates a [Limiter] that counts the sessions on c and fetches the namespaces' limits with fetch, keeping them
+// for ttl.
+func New(c cache.Cache, fetch Fetcher, ttl time.Duration) *Limiter {
+	return &Limiter{
+		cache:  c,
+		fetch:  fetch,
+		ttl:    ttl,
+		limits: make(map[string]limit),
+		counts: make(map[string]int),
+		now:    time.Now,
+	}
+}
+
+// max returns the namespace's limit, fetching it when it isn't kept or is expired. When it couldn't be fetched, the
+// last limit known is used, or none when there isn't one.
+func (l *Limiter) max(tenant string) int {
+	l.mu.Lock()
+	cached, ok := l.limits[tenant]
+	l.mu.Unlock()
+
+	if ok && l.now().Before(cached.expires) {
+		return cached.max
+	}
+
+	max, err := l.fetch(tenant)
+	if err != nil {
+		log.WithError(err).WithField("tenant_id", tenant).Warn("failed to fetch the namespace's limit of sessions")
+
+		return cached.max
+	}
+
+	l.mu.Lock()
+	l.limits[tenant] = limit{max: max, expires: l.now().Add(l.ttl)}
+	l.mu.Unlock()
+
+	return max
+}
+
+// Acquire counts a new interactive session on the namespace. It returns [ErrLimitReached] when the namespace already
+// has its maximum number of sessions. Otherwise, the release function returned must be called when the session ends.
+//
+// When the count couldn't be kept on the cache, the session isn't refused, as when the limit couldn't be fetched.
+func (l *Limiter) Acquire(ctx context.Context, tenant string) (func(), error) {
+	max := l.max(tenant)
+
+	logger := log.WithContext(ctx).WithField("tenant_id", tenant)
+
+	counted := true
+
+	count, err := l.cache.Increment(ctx, countKey(tenant), CountTTL)
+	if err != nil {
+		logger.WithError(err).Warn("failed to count the namespace's session on the cache")
+
+		counted = false
+	}
+
+	if counted && max > 0 && count > int64(max) {
+		if _, err := l.cache.Decrement(ctx, countKey(tenant)); err != nil {
+			logger.WithError(err).Warn("failed to discount the namespace's refused session on the cache")
+		}
+
+		return nil, ErrLimitReached
+	}
+
+	l.mu.Lock()
+	l.counts[tenant]++
+	l.mu.Unlock()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			if l.counts[tenant]--; l.counts[tenant] <= 0 {
+				delete(l.counts, tenant)
+			}
+			l.mu.Unlock()
+
+			if !counted {
+				return
+			}
+
+			// NOTICE: the session's context is usually done when it is released.
+			if _, err := l.cache.Decrement(context.Background(), countKey(tenant)); err != nil {
+				logger.WithError(err).Warn("failed to discount the namespace's session on the cache")
+			}
+		})
+	}, nil
+}
+
+// Refresh keeps the counts of the namespaces with sessions held by this instance from expiring on the cache, until the
+// context is done.
+func (l *Limiter) Refresh(ctx context.Context) {
+	ticker := time.NewTicker(CountTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for tenant := range l.Counts() {
+				if err := l.cache.Expire(ctx, countKey(tenant), CountTTL); err != nil {
+					log.WithError(err).WithField("tenant_id", tenant).Warn("failed to refresh the namespace's count of sessions")
+				}
+			}
+		}
+	}
+}
+
+// Counts returns the number of interactive sessions held by this instance now, per namespace's tenant.
+func (l *Limiter) Counts() map[string]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	counts := make(map[string]int, len(l.counts))
+	for tenant, count := range l.counts {
+		counts[tenant] = count
+	}
+
+	return counts
+}
@@ -0,0 +1,255 @@
+package sessionlimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// counterCache is a cache shared by the instances, keeping only the counters.
+type counterCache struct {
+	cache.Cache
+
+	mu       sync.Mutex
+	counters map[string]int64
+	ttls     map[string]time.Duration
+	err      error
+}
+
+func newCounterCache() *counterCache {
+	return &counterCache{counters: make(map[string]int64), ttls: make(map[string]time.Duration)}
+}
+
+func (c *counterCache) Increment(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	c.counters[key]++
+	c.ttls[key] = ttl
+
+	return c.counters[key], nil
+}
+
+func (c *counterCache) Decrement(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	if _, ok := c.counters[key]; !ok {
+		return 0, nil
+	}
+
+	if c.counters[key]--; c.counters[key] <= 0 {
+		delete(c.counters, key)
+		delete(c.ttls, key)
+
+		return 0, nil
+	}
+
+	return c.counters[key], nil
+}
+
+func (c *counterCache) Expire(_ context.Context, key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.counters[key]; ok {
+		c.ttls[key] = ttl
+	}
+
+	return c.err
+}
+
+// expire expires the key, as the cache does when no instance refreshes it.
+func (c *counterCache) expire(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.counters, key)
+	delete(c.ttls, key)
+}
+
+func TestAcquire(t *testing.T) {
+	cases := []struct {
+		description string
+		max         int
+		sessions    int
+		expected    error
+	}{
+		{
+			description: "succeeds when the namespace has no limit",
+			max:         0,
+			sessions:    8,
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the namespace is under its limit",
+			max:         2,
+			sessions:    1,
+			expected:    nil,
+		},
+		{
+			description: "fails when the namespace reached its limit",
+			max:         2,
+			sessions:    2,
+			expected:    ErrLimitReached,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			limiter := New(newCounterCache(), func(string) (int, error) { return tc.max, nil }, DefaultTTL)
+
+			for i := 0; i < tc.sessions; i++ {
+				_, err := limiter.Acquire(context.Background(), "tenant")
+				require.NoError(t, err)
+			}
+
+			_, err := limiter.Acquire(context.Background(), "tenant")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestAcquireRelease(t *testing.T) {
+	limiter := New(newCounterCache(), func(string) (int, error) { return 1, nil }, DefaultTTL)
+
+	release, err := limiter.Acquire(context.Background(), "tenant")
+	require.NoError(t, err)
+
+	_, err = limiter.Acquire(context.Background(), "tenant")
+	assert.Equal(t, ErrLimitReached, err)
+
+	_, err = limiter.Acquire(context.Background(), "other")
+	assert.NoError(t, err)
+
+	release()
+	release()
+
+	assert.Equal(t, map[string]int{"other": 1}, limiter.Counts())
+
+	_, err = limiter.Acquire(context.Background(), "tenant")
+	assert.NoError(t, err)
+}
+
+func TestAcquireCache(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	fetches := 0
+	max := 1
+	var fetchErr error
+
+	limiter := New(newCounterCache(), func(string) (int, error) {
+		fetches++
+
+		return max, fetchErr
+	}, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	release, err := limiter.Acquire(context.Background(), "tenant")
+	require.NoError(t, err)
+	release()
+
+	max = 0
+
+	// The limit is kept until it expires.
+	release, err = limiter.Acquire(context.Background(), "tenant")
+	require.NoError(t, err)
+	_, err = limiter.Acquire(context.Background(), "tenant")
+	assert.Equal(t, ErrLimitReached, err)
+	assert.Equal(t, 1, fetches)
+
+	// The last limit known is used when it couldn't be fetched again.
+	now = now.Add(2 * time.Minute)
+	fetchErr = errors.New("error")
+
+	_, err = limiter.Acquire(context.Background(), "tenant")
+	assert.Equal(t, ErrLimitReached, err)
+	assert.Equal(t, 2, fetches)
+
+	release()
+
+	// The limit fetched again replaces the expired one.
+	fetchErr = nil
+
+	_, err = limiter.Acquire(context.Background(), "tenant")
+	require.NoError(t, err)
+	_, err = limiter.Acquire(context.Background(), "tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fetches)
+}
+
+func TestAcquireShared(t *testing.T) {
+	ctx := context.Background()
+	c := newCounterCache()
+
+	// NOTICE: each limiter is an instance of the SSH server, sharing the cache.
+	first := New(c, func(string) (int, error) { return 2, nil }, DefaultTTL)
+	second := New(c, func(string) (int, error) { return 2, nil }, DefaultTTL)
+
+	release, err := first.Acquire(ctx, "tenant")
+	require.NoError(t, err)
+	_, err = second.Acquire(ctx, "tenant")
+	require.NoError(t, err)
+
+	_, err = first.Acquire(ctx, "tenant")
+	assert.Equal(t, ErrLimitReached, err)
+	_, err = second.Acquire(ctx, "tenant")
+	assert.Equal(t, ErrLimitReached, err)
+
+	// NOTICE: the refused sessions aren't counted.
+	assert.Equal(t, map[string]int64{"session-limit={tenant}": 2}, c.counters)
+	assert.Equal(t, map[string]time.Duration{"session-limit={tenant}": CountTTL}, c.ttls)
+	assert.Equal(t, map[string]int{"tenant": 1}, first.Counts())
+	assert.Equal(t, map[string]int{"tenant": 1}, second.Counts())
+
+	release()
+
+	_, err = second.Acquire(ctx, "tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"tenant": 2}, second.Counts())
+
+	// NOTICE: the count expires when no instance refreshes it, like after they have crashed, so the sessions stop
+	// counting.
+	c.expire("session-limit={tenant}")
+
+	_, err = first.Acquire(ctx, "tenant")
+	assert.NoError(t, err)
+}
+
+func TestAcquireCacheFailure(t *testing.T) {
+	ctx := context.Background()
+	c := newCounterCache()
+	c.err = errors.New("error")
+
+	limiter := New(c, func(string) (int, error) { return 1, nil }, DefaultTTL)
+
+	release, err := limiter.Acquire(ctx, "tenant")
+	require.NoError(t, err)
+	_, err = limiter.Acquire(ctx, "tenant")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"tenant": 2}, limiter.Counts())
+
+	// NOTICE: the sessions not counted on the cache aren't discounted from it.
+	c.err = nil
+	c.counters["session-limit={tenant}"] = 1
+
+	release()
+
+	assert.Equal(t, int64(1), c.counters["session-limit={tenant}"])
+	assert.Equal(t, map[string]int{"tenant": 1}, limiter.Counts())
+}
@@ -3,11 +3,14 @@ package target
 import (
 	"errors"
 	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 var (
 	ErrSplitTarget = errors.New("could not split the target into two parts")
 	ErrNotSSHID    = errors.New("target is not from SSHID type")
+	ErrNotAlias    = errors.New("target is not from alias type")
 )
 
 type Target struct {
@@ -34,6 +37,46 @@ func NewTarget(sshid string) (*Target, error) {
 	return &Target{Username: parts[USERNAME], Data: parts[DATA]}, nil
 }
 
+// IsAlias checks if target is an alias defined by a ShellHub's user, like "username/alias". It must be checked before
+// [Target.IsSSHID], as the user's username may contain dots.
+//
+// Example: username@john_doe/web1+container.
+func (t *Target) IsAlias() bool {
+	sshid, _, _ := strings.Cut(t.Data, "+")
+	_, _, ok := models.SplitUserAlias(sshid)
+
+	return ok
+}
+
+// SplitAlias splits the alias into the ShellHub user's username and the alias's name, ignoring the container's name.
+func (t *Target) SplitAlias() (string, string, error) {
+	sshid, _, _ := strings.Cut(t.Data, "+")
+
+	username, name, ok := models.SplitUserAlias(sshid)
+	if !ok {
+		return "", "", ErrNotAlias
+	}
+
+	return username, name, nil
+}
+
+// ReplaceAlias replaces the alias by the SSHID it resolves to. When the alias is followed by a container's name, it
+// takes precedence over the container defined by the alias.
+func (t *Target) ReplaceAlias(sshid string) error {
+	if !t.IsAlias() {
+		return ErrNotAlias
+	}
+
+	if _, container, ok := strings.Cut(t.Data, "+"); ok {
+		sshid, _, _ = strings.Cut(sshid, "+")
+		sshid += "+" + container
+	}
+
+	t.Data = sshid
+
+	return nil
+}
+
 // IsSSHID checks if target is a SSHID or a device's ID.
 func (t *Target) IsSSHID() bool {
 	return strings.Contains(t.Data, ".")
@@ -56,3 +99,17 @@ func (t *Target) SplitSSHID() (string, string, error) {
 
 	return parts[NAMESPACE], parts[HOSTNAME], nil
 }
+
+// SplitContainer splits the SSHID into the SSHID without the container and the container's name, separated by a `+`.
+// When the SSHID doesn't target a container, the container's name is empty.
+//
+// Example: namespace.00-00-00-00-00-00+container.
+func (t *Target) SplitContainer() (string, string, error) {
+	if !t.IsSSHID() {
+		return "", "", ErrNotSSHID
+	}
+
+	sshid, container, _ := strings.Cut(t.Data, "+")
+
+	return sshid, container, nil
+}
@@ -123,3 +123,173 @@ func TestSplitSSHID(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitContainer(t *testing.T) {
+	type Expected struct {
+		sshid     string
+		container string
+		err       error
+	}
+
+	cases := []struct {
+		description string
+		target      *Target
+		expected    Expected
+	}{
+		{
+			description: "fails when when Data is not a SSHID",
+			target: &Target{
+				Username: "username",
+				Data:     "00000000000000000000000000000000000000000000000000000000000000",
+			},
+			expected: Expected{
+				sshid:     "",
+				container: "",
+				err:       ErrNotSSHID,
+			},
+		},
+		{
+			description: "succeeds when SSHID does not target a container",
+			target: &Target{
+				Username: "username",
+				Data:     "namespace.00-00-00-00-00-00",
+			},
+			expected: Expected{
+				sshid:     "namespace.00-00-00-00-00-00",
+				container: "",
+				err:       nil,
+			},
+		},
+		{
+			description: "succeeds when SSHID targets a container",
+			target: &Target{
+				Username: "username",
+				Data:     "namespace.00-00-00-00-00-00+container",
+			},
+			expected: Expected{
+				sshid:     "namespace.00-00-00-00-00-00",
+				container: "container",
+				err:       nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			sshid, container, err := tc.target.SplitContainer()
+			assert.Equal(t, tc.expected, Expected{sshid, container, err})
+		})
+	}
+}
+
+func TestIsAlias(t *testing.T) {
+	cases := []struct {
+		description string
+		target      *Target
+		expected    bool
+	}{
+		{
+			description: "returns false when Data is a SSHID",
+			target:      &Target{Username: "username", Data: "namespace.00-00-00-00-00-00"},
+			expected:    false,
+		},
+		{
+			description: "returns false when Data doesn't have the alias's name",
+			target:      &Target{Username: "username", Data: "john.doe/"},
+			expected:    false,
+		},
+		{
+			description: "returns true when Data is an alias",
+			target:      &Target{Username: "username", Data: "john.doe/web1"},
+			expected:    true,
+		},
+		{
+			description: "returns true when Data is an alias targeting a container",
+			target:      &Target{Username: "username", Data: "john.doe/web1+container"},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.target.IsAlias())
+		})
+	}
+}
+
+func TestSplitAlias(t *testing.T) {
+	type Expected struct {
+		username string
+		name     string
+		err      error
+	}
+
+	cases := []struct {
+		description string
+		target      *Target
+		expected    Expected
+	}{
+		{
+			description: "fails when Data is not an alias",
+			target:      &Target{Username: "username", Data: "namespace.00-00-00-00-00-00"},
+			expected:    Expected{"", "", ErrNotAlias},
+		},
+		{
+			description: "succeeds when Data is an alias",
+			target:      &Target{Username: "username", Data: "john.doe/web1"},
+			expected:    Expected{"john.doe", "web1", nil},
+		},
+		{
+			description: "succeeds when Data is an alias targeting a container",
+			target:      &Target{Username: "username", Data: "john.doe/web1+container"},
+			expected:    Expected{"john.doe", "web1", nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			username, name, err := tc.target.SplitAlias()
+			assert.Equal(t, tc.expected, Expected{username, name, err})
+		})
+	}
+}
+
+func TestReplaceAlias(t *testing.T) {
+	type Expected struct {
+		data string
+		err  error
+	}
+
+	cases := []struct {
+		description string
+		target      *Target
+		sshid       string
+		expected    Expected
+	}{
+		{
+			description: "fails when Data is not an alias",
+			target:      &Target{Username: "username", Data: "namespace.00-00-00-00-00-00"},
+			sshid:       "namespace.device",
+			expected:    Expected{"namespace.00-00-00-00-00-00", ErrNotAlias},
+		},
+		{
+			description: "succeeds when Data is an alias",
+			target:      &Target{Username: "username", Data: "john.doe/web1"},
+			sshid:       "namespace.device+nginx",
+			expected:    Expected{"namespace.device+nginx", nil},
+		},
+		{
+			description: "succeeds when Data is an alias targeting a container",
+			target:      &Target{Username: "username", Data: "john.doe/web1+container"},
+			sshid:       "namespace.device+nginx",
+			expected:    Expected{"namespace.device+container", nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.target.ReplaceAlias(tc.sshid)
+			assert.Equal(t, tc.expected, Expected{tc.target.Data, err})
+		})
+	}
+}
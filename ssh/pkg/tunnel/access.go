@@ -0,0 +1,63 @@
+package tunnel
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+// accessStatusLineMax is the maximum length of the response's status line inspected to get its status code.
+const accessStatusLineMax = 64
+
+// accessWriter is a writer that counts the bytes of an HTTP response written through it, getting its status code
+// from the status line. It is used to log the requests proxied to the devices, whose responses are copied directly
+// to the client's connection.
+type accessWriter struct {
+	w io.Writer
+	// bytes is the number of bytes written, including the response's headers.
+	bytes int64
+	// status is the response's status code. It is zero until the status line is written.
+	status int
+	line   []byte
+	parsed bool
+}
+
+func newAccessWriter(w io.Writer) *accessWriter {
+	return &accessWriter{w: w}
+}
+
+func (a *accessWriter) Write(p []byte) (int, error) {
+	if !a.parsed {
+		a.parse(p)
+	}
+
+	n, err := a.w.Write(p)
+	a.bytes += int64(n)
+
+	return n, err
+}
+
+// parse accumulates the written data until the end of the status line, like "HTTP/1.1 200 OK", getting the status
+// code from it.
+func (a *accessWriter) parse(p []byte) {
+	end := bytes.IndexByte(p, '\n')
+	if end < 0 {
+		end = len(p)
+	}
+
+	a.line = append(a.line, p[:min(end, accessStatusLineMax)]...)
+	if end == len(p) && len(a.line) < accessStatusLineMax {
+		return
+	}
+
+	a.parsed = true
+
+	fields := bytes.Fields(a.line)
+	if len(fields) < 2 {
+		return
+	}
+
+	if status, err := strconv.Atoi(string(fields[1])); err == nil {
+		a.status = status
+	}
+}
@@ -0,0 +1,51 @@
+package tunnel
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessWriter(t *testing.T) {
+	cases := []struct {
+		description string
+		writes      []string
+		status      int
+	}{
+		{
+			description: "gets the status from a single write",
+			writes:      []string{"HTTP/1.1 404 Not Found\r\nContent-Length: 0\r\n\r\n"},
+			status:      404,
+		},
+		{
+			description: "gets the status from the status line split across writes",
+			writes:      []string{"HTTP/1.1 2", "00 OK\r\n", "Content-Length: 2\r\n\r\nok"},
+			status:      200,
+		},
+		{
+			description: "keeps the status empty when the response isn't HTTP",
+			writes:      []string{"garbage\n"},
+			status:      0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			writer := newAccessWriter(buffer)
+
+			expected := ""
+			for _, w := range tc.writes {
+				_, err := writer.Write([]byte(w))
+				assert.NoError(t, err)
+
+				expected += w
+			}
+
+			assert.Equal(t, tc.status, writer.status)
+			assert.Equal(t, int64(len(expected)), writer.bytes)
+			assert.Equal(t, expected, buffer.String())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package tunnel
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -9,11 +10,14 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -25,8 +29,19 @@ var (
 	ErrDeviceTunnelHijackRequest = errors.New("failed to capture the request")
 	ErrDeviceTunnelParsePath     = errors.New("failed to parse the path")
 	ErrDeviceTunnelConnect       = errors.New("failed to connect to the port on device")
+	ErrDeviceNotFound            = errors.New("device not found")
+	ErrDeviceContainersDisabled  = errors.New("containers listing is not enabled on device")
+	ErrDevicePortsUnavailable    = errors.New("ports listing is not available on device")
 )
 
+// DefaultPathPrefix is the path prefix of the reverse tunnel used by the agents that don't get it from the server.
+const DefaultPathPrefix = "/ssh"
+
+// Paths returns the paths, under prefix, where the agents open the reverse tunnel and dial back its connections.
+func Paths(prefix string) (string, string) {
+	return path.Join("/", prefix, "connection"), path.Join("/", prefix, "revdial")
+}
+
 type Message struct {
 	Message string `json:"message"`
 }
@@ -43,17 +58,23 @@ type Tunnel struct {
 	router *echo.Echo
 }
 
-func NewTunnel(connection, dial, redisURI string) (*Tunnel, error) {
+// NewTunnel creates the reverse tunnel served under prefix. The tunnel is served under the [DefaultPathPrefix] too, so
+// the agents that don't get the prefix from the server keep connecting.
+func NewTunnel(prefix, redisURI string) (*Tunnel, error) {
 	api, err := internalclient.NewClient(internalclient.WithAsynqWorker(redisURI))
 	if err != nil {
 		return nil, err
 	}
 
+	connection, dial := Paths(cmp.Or(prefix, DefaultPathPrefix))
+
 	tunnel := &Tunnel{
 		Tunnel: httptunnel.NewTunnel(connection, dial),
 		API:    api,
 	}
 
+	tunnel.Tunnel.Alias(Paths(DefaultPathPrefix))
+
 	tunnel.Tunnel.ConnectionHandler = func(request *http.Request) (string, error) {
 		tenant := request.Header.Get("X-Tenant-ID")
 		uid := request.Header.Get("X-Device-UID")
@@ -187,6 +208,27 @@ func NewTunnel(connection, dial, redisURI string) (*Tunnel, error) {
 			"device":     tun.Device,
 		})
 
+		access := &models.PublicURLLog{
+			TenantID: tun.Namespace,
+			Method:   c.Request().Method,
+			Path:     path,
+			SourceIP: c.RealIP(),
+			Time:     clock.Now(),
+		}
+
+		// NOTICE: When the response is copied directly from the device, the writer is set to get its status and size;
+		// otherwise, the ones of the response sent by this route are used.
+		var writer *accessWriter
+		defer func() {
+			if writer != nil {
+				access.Status, access.Bytes = writer.status, writer.bytes
+			} else {
+				access.Status, access.Bytes = c.Response().Status, c.Response().Size
+			}
+
+			tunnel.logAccess(tun.Device, access)
+		}()
+
 		in, err := tunnel.Dial(c.Request().Context(), fmt.Sprintf("%s:%s", tun.Namespace, tun.Device))
 		if err != nil {
 			logger.WithError(err).Error("failed to dial to device")
@@ -240,7 +282,8 @@ func NewTunnel(connection, dial, redisURI string) (*Tunnel, error) {
 
 		defer out.Close()
 
-		if _, err := io.Copy(out, in); errors.Is(err, io.ErrUnexpectedEOF) {
+		writer = newAccessWriter(out)
+		if _, err := io.Copy(writer, in); errors.Is(err, io.ErrUnexpectedEOF) {
 			logger.WithError(err).Error("failed to copy the response to the client")
 
 			return c.JSON(http.StatusInternalServerError, NewMessageFromError(ErrDeviceTunnelReadResponse))
@@ -249,6 +292,18 @@ func NewTunnel(connection, dial, redisURI string) (*Tunnel, error) {
 		return nil
 	})
 
+	// `/api/devices/:uid/containers` is the endpoint that lists the Docker containers running on the device, when
+	// the agent has the containers listing enabled.
+	tunnel.router.GET("/api/devices/:uid/containers", func(c echo.Context) error {
+		return tunnel.deviceList(c, "/containers", ErrDeviceContainersDisabled)
+	})
+
+	// `/api/devices/:uid/ports` is the endpoint that lists the TCP and UDP ports listening on the device, so the user
+	// knows what can be forwarded or published without opening a shell on it.
+	tunnel.router.GET("/api/devices/:uid/ports", func(c echo.Context) error {
+		return tunnel.deviceList(c, "/ports", ErrDevicePortsUnavailable)
+	})
+
 	tunnel.router.GET("/healthcheck", func(c echo.Context) error {
 		return c.String(http.StatusOK, "OK")
 	})
@@ -256,6 +311,77 @@ func NewTunnel(connection, dial, redisURI string) (*Tunnel, error) {
 	return tunnel, nil
 }
 
+// deviceList responds with the list the device's agent returns on path, through the tunnel, responding with the status
+// not implemented and the unavailable error when the agent doesn't have the list enabled.
+func (t *Tunnel) deviceList(c echo.Context, path string, unavailable error) error {
+	uid := c.Param("uid")
+	tenant := c.Request().Header.Get("X-Tenant-ID")
+
+	logger := log.WithFields(log.Fields{
+		"tenant_id": tenant,
+		"device":    uid,
+	})
+
+	device, err := t.API.GetDevice(uid)
+	if err != nil || device.TenantID != tenant {
+		logger.WithError(err).Error("failed to get the device")
+
+		return c.JSON(http.StatusNotFound, NewMessageFromError(ErrDeviceNotFound))
+	}
+
+	in, err := t.Dial(c.Request().Context(), fmt.Sprintf("%s:%s", tenant, uid))
+	if err != nil {
+		logger.WithError(err).Error("failed to dial to device")
+
+		return c.JSON(http.StatusServiceUnavailable, NewMessageFromError(ErrDeviceTunnelDial))
+	}
+
+	defer in.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	if err := req.Write(in); err != nil {
+		logger.WithError(err).Error("failed to write the request to the agent")
+
+		return c.JSON(http.StatusInternalServerError, NewMessageFromError(ErrDeviceTunnelWriteRequest))
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(in), req)
+	if err != nil {
+		logger.WithError(err).Error("failed to read the response from the agent")
+
+		return c.JSON(http.StatusInternalServerError, NewMessageFromError(ErrDeviceTunnelReadResponse))
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return c.Stream(http.StatusOK, echo.MIMEApplicationJSON, resp.Body)
+	// NOTICE: Agents that don't have the listing enabled, or older agents without the route, don't respond with the
+	// list.
+	case http.StatusNotImplemented, http.StatusNotFound:
+		return c.JSON(http.StatusNotImplemented, NewMessageFromError(unavailable))
+	default:
+		logger.WithField("status", resp.StatusCode).Error("unexpected status from the agent")
+
+		return c.JSON(http.StatusInternalServerError, NewMessageFromError(ErrDeviceTunnelReadResponse))
+	}
+}
+
+// logAccess reports, in background, a request proxied to the HTTP service exposed by the device through its public
+// URL.
+func (t *Tunnel) logAccess(device string, access *models.PublicURLLog) {
+	access.Latency = clock.Now().Sub(access.Time).Milliseconds()
+
+	go func() {
+		if err := t.API.CreatePublicURLLog(device, access); err != nil {
+			log.WithError(err).
+				WithFields(log.Fields{"tenant_id": access.TenantID, "device": device}).
+				Warn("failed to report the public URL's access log")
+		}
+	}()
+}
+
 func (t *Tunnel) GetRouter() *echo.Echo {
 	return t.router
 }
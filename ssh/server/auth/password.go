@@ -33,6 +33,8 @@ func PasswordHandler(ctx gliderssh.Context, passwd string) bool {
 	if err := sess.Auth(ctx, session.AuthPassword(passwd)); err != nil {
 		logger.Warn("failed to authenticate on device using password")
 
+		sess.RecordAuthFailure()
+
 		return false
 	}
 
@@ -20,6 +20,15 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 
 	sess, state := session.ObtainSession(ctx)
 	if state < session.StateEvaluated {
+		// NOTICE: the detail of a denied connection is only shown to the namespace's members, identified by their
+		// public keys. As the client must prove to hold the key's private part, the authentication is accepted, and the
+		// detail is shown on the channel opened, instead of a session.
+		if denial := session.ObtainDenial(ctx); denial != nil && sess != nil && sess.NamespaceKey(publicKey) {
+			logger.WithField("reason", denial.Reason).Info("accepting a namespace's member to show why the connection was denied")
+
+			return true
+		}
+
 		logger.Trace("failed to get the session from context on public key handler")
 
 		conn, ok := ctx.Value("conn").(net.Conn)
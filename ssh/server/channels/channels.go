@@ -13,4 +13,7 @@ const (
 	// Example of dynamic application-level port forwarding: `ssh -D 1080 user@sshid`.
 	DirectTCPIPChannel = "direct-tcpip"
 	SessionChannel     = "session"
+	// SOCKS5Channel is the channel type whose data is a SOCKS5 session served by the agent, exposing the device's
+	// network to the client without a port forward for each one of its hosts. The agent must have the proxy enabled.
+	SOCKS5Channel = "socks5@shellhub.io"
 )
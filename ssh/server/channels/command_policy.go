@@ -0,0 +1,220 @@
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/shellhub-io/shellhub/pkg/commandpolicy"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/commandline"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// commandPolicyMessages are the messages written to the client when a command line violates a command policy, by the
+// policy's action.
+var commandPolicyMessages = map[models.CommandPolicyAction]string{
+	models.CommandPolicyActionWarn:      "\r\n[ShellHub: the command violates the policy %q of the namespace]\r\n",
+	models.CommandPolicyActionBlock:     "\r\n[ShellHub: the command was blocked by the policy %q of the namespace]\r\n",
+	models.CommandPolicyActionTerminate: "\r\n[ShellHub: the session was terminated as the command violates the policy %q of the namespace]\r\n",
+}
+
+// commandPolicyDiscard is sent to the agent, instead of the submission of a blocked command line, to discard the line
+// typed on the terminal: Ctrl-E moves the cursor to the end of the line, Ctrl-U deletes it and the carriage return
+// submits the empty line, so the shell shows a new prompt.
+const commandPolicyDiscard = "\x05\x15\r"
+
+// errCommandPolicyTerminated is returned by the [CommandFirewall]'s writer when a command line terminated the session.
+var errCommandPolicyTerminated = errors.New("the session was terminated by a command policy")
+
+// CommandFirewall evaluates the namespace's command policies against the command lines run on a session, before they
+// reach the agent. The command line of a command execution is evaluated when it is requested. The command lines typed
+// on a shell are reconstructed from the client's input, and evaluated when they are submitted.
+//
+// The subsystems, like the SFTP, aren't evaluated, as they don't run command lines.
+type CommandFirewall struct {
+	session *session.Session
+	// client and stderr are the client's output streams, where the user is told about the violated policies.
+	client io.Writer
+	stderr io.Writer
+	agent  io.Writer
+	// report reports a violated policy to the namespace's log.
+	report func(policy *models.CommandPolicy, command string)
+	// terminate closes the session's channels.
+	terminate func()
+
+	mu        sync.Mutex
+	evaluator *commandpolicy.Evaluator
+	// hold indicates the shell has no pseudo-terminal, so the input of a line is held until it is submitted.
+	hold bool
+	held []byte
+	line commandline.Line
+}
+
+// NewCommandFirewall creates a [CommandFirewall] forwarding the client's input to agent. Until the session's program
+// is started, through [CommandFirewall.Start], the input passes through it.
+func NewCommandFirewall(sess *session.Session, client gossh.Channel, agent io.Writer, terminate func()) *CommandFirewall {
+	return &CommandFirewall{
+		session:   sess,
+		client:    client,
+		stderr:    client.Stderr(),
+		agent:     agent,
+		report:    sess.ReportCommandPolicyEvaluation,
+		terminate: terminate,
+	}
+}
+
+// Start gets the command policies applying to the session when its program is started by a request, reporting if the
+// program is allowed to start. The program isn't started when the policies can't be retrieved or when the command
+// line of a command execution is blocked by them.
+func (f *CommandFirewall) Start(request string, payload []byte) bool {
+	evaluator, err := f.session.CommandPolicies()
+	if err != nil {
+		f.stderr.Write([]byte(err.Error() + "\r\n")) //nolint:errcheck
+
+		return false
+	}
+
+	if evaluator.Empty() {
+		return true
+	}
+
+	if request == ExecRequestType {
+		var cmd session.Command
+		gossh.Unmarshal(payload, &cmd) //nolint:errcheck
+
+		policy := evaluator.Evaluate(cmd.Command)
+		if policy == nil {
+			return true
+		}
+
+		f.violated(f.stderr, policy, cmd.Command)
+
+		return policy.Action == models.CommandPolicyActionWarn
+	}
+
+	f.mu.Lock()
+	f.evaluator = evaluator
+	f.hold = f.session.Pty.Term == ""
+	f.mu.Unlock()
+
+	return true
+}
+
+// Write forwards the client's input to the agent, evaluating the command lines submitted on it. A blocked command
+// line is discarded and a terminating one closes the session, returning an error.
+//
+// NOTICE: on a pseudo-terminal, the characters of a line are forwarded as typed, so the shell echoes them, and only its
+// submission is held. Without a pseudo-terminal, the whole line is held until it is submitted.
+func (f *CommandFirewall) Write(data []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.evaluator == nil {
+		return f.agent.Write(data)
+	}
+
+	// start is the first byte of data not forwarded or held yet.
+	start := 0
+	for i, b := range data {
+		command, submitted := f.line.Feed(b)
+		if !submitted {
+			continue
+		}
+
+		policy := f.evaluator.Evaluate(command)
+		if policy != nil {
+			f.violated(f.client, policy, command)
+		}
+
+		switch {
+		case policy == nil, policy.Action == models.CommandPolicyActionWarn:
+			if err := f.forward(data[start : i+1]); err != nil {
+				return 0, err
+			}
+		case policy.Action == models.CommandPolicyActionBlock:
+			if err := f.discard(data[start:i]); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, errCommandPolicyTerminated
+		}
+
+		start = i + 1
+	}
+
+	if f.hold {
+		f.held = append(f.held, data[start:]...)
+	} else if _, err := f.agent.Write(data[start:]); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// forward writes the input of an allowed line, with the input held before it, to the agent.
+func (f *CommandFirewall) forward(data []byte) error {
+	if f.hold {
+		data = append(f.held, data...)
+		f.held = nil
+	}
+
+	_, err := f.agent.Write(data)
+
+	return err
+}
+
+// discard drops the input of a blocked line. On a pseudo-terminal, the input typed before the submission reaches the
+// agent, and the line is discarded on the terminal through [commandPolicyDiscard].
+func (f *CommandFirewall) discard(data []byte) error {
+	if f.hold {
+		f.held = nil
+
+		return nil
+	}
+
+	if _, err := f.agent.Write(data); err != nil {
+		return err
+	}
+
+	_, err := f.agent.Write([]byte(commandPolicyDiscard))
+
+	return err
+}
+
+// violated handles a command line that violated a policy, reporting it to the namespace's log and telling the user
+// through w. When the policy terminates the session, its channels are closed.
+func (f *CommandFirewall) violated(w io.Writer, policy *models.CommandPolicy, command string) {
+	log.WithFields(log.Fields{
+		"uid":    f.session.UID,
+		"sshid":  f.session.SSHID,
+		"policy": policy.ID,
+		"action": policy.Action,
+	}).Info("a command line violated a command policy")
+
+	f.report(policy, truncateCommand(command))
+
+	fmt.Fprintf(w, commandPolicyMessages[policy.Action], policy.Name) //nolint:errcheck
+
+	if policy.Action == models.CommandPolicyActionTerminate {
+		f.terminate()
+	}
+}
+
+// truncateCommand cuts the command line to the maximum size accepted on the namespace's log, on a character boundary.
+func truncateCommand(command string) string {
+	if len(command) <= RecordExecCommandLimit {
+		return command
+	}
+
+	end := RecordExecCommandLimit
+	for end > 0 && !utf8.RuneStart(command[end]) {
+		end--
+	}
+
+	return command[:end]
+}
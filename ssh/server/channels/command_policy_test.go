@@ -0,0 +1,154 @@
+package channels
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/commandpolicy"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandFirewall(t *testing.T) {
+	policies := []models.CommandPolicy{
+		{ID: "warn", Name: "packages", Mode: models.CommandPolicyModeDeny, Patterns: []string{`^apt`}, Action: models.CommandPolicyActionWarn},
+		{ID: "block", Name: "destructive", Mode: models.CommandPolicyModeDeny, Patterns: []string{`rm\s+-rf`}, Action: models.CommandPolicyActionBlock},
+		{ID: "terminate", Name: "shutdown", Mode: models.CommandPolicyModeDeny, Patterns: []string{`^shutdown`}, Action: models.CommandPolicyActionTerminate},
+	}
+
+	type expected struct {
+		agent      string
+		client     string
+		reported   []string
+		terminated bool
+		err        error
+	}
+
+	cases := []struct {
+		description string
+		term        string
+		started     bool
+		input       []string
+		expected    expected
+	}{
+		{
+			description: "forwards the input when the firewall isn't started",
+			term:        "xterm",
+			started:     false,
+			input:       []string{"rm -rf /\r"},
+			expected: expected{
+				agent: "rm -rf /\r",
+			},
+		},
+		{
+			description: "forwards the command lines violating no policy",
+			term:        "xterm",
+			started:     true,
+			input:       []string{"ls -la\r", "cd /tmp\r"},
+			expected: expected{
+				agent: "ls -la\rcd /tmp\r",
+			},
+		},
+		{
+			description: "forwards the command line warning the user",
+			term:        "xterm",
+			started:     true,
+			input:       []string{"apt install vim\r"},
+			expected: expected{
+				agent:    "apt install vim\r",
+				client:   `[ShellHub: the command violates the policy "packages" of the namespace]`,
+				reported: []string{"warn:apt install vim"},
+			},
+		},
+		{
+			description: "discards the blocked command line on the terminal",
+			term:        "xterm",
+			started:     true,
+			input:       []string{"rm -r", "f /\rls\r"},
+			expected: expected{
+				agent:    "rm -rf /" + commandPolicyDiscard + "ls\r",
+				client:   `[ShellHub: the command was blocked by the policy "destructive" of the namespace]`,
+				reported: []string{"block:rm -rf /"},
+			},
+		},
+		{
+			description: "evaluates the command line as edited",
+			term:        "xterm",
+			started:     true,
+			input:       []string{"rm -rf /\x15ls\r"},
+			expected: expected{
+				agent: "rm -rf /\x15ls\r",
+			},
+		},
+		{
+			description: "drops the blocked command line without a pseudo-terminal",
+			term:        "",
+			started:     true,
+			input:       []string{"rm -r", "f /\nls\n"},
+			expected: expected{
+				agent:    "ls\n",
+				client:   `[ShellHub: the command was blocked by the policy "destructive" of the namespace]`,
+				reported: []string{"block:rm -rf /"},
+			},
+		},
+		{
+			description: "terminates the session",
+			term:        "xterm",
+			started:     true,
+			input:       []string{"shutdown now\r"},
+			expected: expected{
+				agent:      "",
+				client:     `[ShellHub: the session was terminated as the command violates the policy "shutdown" of the namespace]`,
+				reported:   []string{"terminate:shutdown now"},
+				terminated: true,
+				err:        errCommandPolicyTerminated,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			agent, client := new(bytes.Buffer), new(bytes.Buffer)
+
+			var reported []string
+			var terminated bool
+
+			firewall := &CommandFirewall{
+				session: &session.Session{UID: "uid", Data: session.Data{Pty: session.Pty{Term: tc.term}}},
+				client:  client,
+				stderr:  client,
+				agent:   agent,
+				report: func(policy *models.CommandPolicy, command string) {
+					reported = append(reported, string(policy.Action)+":"+command)
+				},
+				terminate: func() { terminated = true },
+			}
+
+			if tc.started {
+				firewall.evaluator = commandpolicy.New(policies)
+				firewall.hold = tc.term == ""
+			}
+
+			var err error
+			for _, input := range tc.input {
+				if _, err = firewall.Write([]byte(input)); err != nil {
+					break
+				}
+			}
+
+			assert.Equal(t, tc.expected.err, err)
+			assert.Equal(t, tc.expected.agent, agent.String())
+			assert.Equal(t, tc.expected.client, strings.TrimSpace(client.String()))
+			assert.Equal(t, tc.expected.reported, reported)
+			assert.Equal(t, tc.expected.terminated, terminated)
+		})
+	}
+}
+
+func TestTruncateCommand(t *testing.T) {
+	assert.Equal(t, "ls", truncateCommand("ls"))
+	assert.Len(t, truncateCommand(strings.Repeat("a", RecordExecCommandLimit+1)), RecordExecCommandLimit)
+	assert.Len(t, truncateCommand(strings.Repeat("a", RecordExecCommandLimit-1)+"ç"), RecordExecCommandLimit-1)
+}
@@ -0,0 +1,64 @@
+package channels
+
+import (
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Denied wraps a channel handler to show why the connection was denied, when it was, instead of handling the channel.
+//
+// A denied connection is only authenticated when the client proves to be one of the namespace's members, so the
+// detail of the denial, not shown on the SSH banner, is written to the session channel's standard error before the
+// connection is closed. The other channels are rejected with the detail.
+func Denied(handler gliderssh.ChannelHandler) gliderssh.ChannelHandler {
+	return func(srv *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+		denial := session.ObtainDenial(ctx)
+		if denial == nil {
+			handler(srv, conn, newChan, ctx)
+
+			return
+		}
+
+		defer conn.Close()
+
+		logger := log.WithFields(log.Fields{"uid": ctx.SessionID(), "sshid": ctx.User(), "reason": denial.Reason})
+
+		if newChan.ChannelType() != SessionChannel {
+			newChan.Reject(gossh.Prohibited, denial.Detail) //nolint:errcheck
+
+			return
+		}
+
+		channel, reqs, err := newChan.Accept()
+		if err != nil {
+			logger.WithError(err).Error("failed to accept the channel to show the denial")
+
+			return
+		}
+
+		defer channel.Close()
+
+		// NOTICE: the detail is written once the client starts its program, so it is shown as the program's output.
+		for req := range reqs {
+			if req.WantReply {
+				req.Reply(true, nil) //nolint:errcheck
+			}
+
+			if req.Type == ShellRequestType || req.Type == ExecRequestType || req.Type == SubsystemRequestType {
+				break
+			}
+		}
+
+		go gossh.DiscardRequests(reqs)
+
+		if _, err := channel.Stderr().Write([]byte(denial.Detail + "\r\n")); err != nil {
+			logger.WithError(err).Error("failed to write the denial to the client")
+		}
+
+		channel.SendRequest(ExitStatusRequest, false, gossh.Marshal(&session.Status{Status: 1})) //nolint:errcheck
+
+		logger.Info("the denial's detail was shown to a namespace's member")
+	}
+}
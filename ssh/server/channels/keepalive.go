@@ -0,0 +1,83 @@
+package channels
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// IdleKeepAliveRequestType is the request sent to the client of an idle interactive session. It is the same no-op
+// request sent by the OpenSSH's server, that the clients reply with a failure, but it keeps the state of the NATs and
+// firewalls between them and the server.
+const IdleKeepAliveRequestType = "keepalive@openssh.com"
+
+// idleChannel is a [gossh.Channel] that tracks the last time data was read from or written to it.
+type idleChannel struct {
+	gossh.Channel
+	last atomic.Int64
+}
+
+func newIdleChannel(channel gossh.Channel) *idleChannel {
+	c := &idleChannel{Channel: channel}
+	c.touch()
+
+	return c
+}
+
+func (c *idleChannel) touch() {
+	c.last.Store(time.Now().UnixNano())
+}
+
+func (c *idleChannel) Read(data []byte) (int, error) {
+	read, err := c.Channel.Read(data)
+	if read > 0 {
+		c.touch()
+	}
+
+	return read, err
+}
+
+func (c *idleChannel) Write(data []byte) (int, error) {
+	written, err := c.Channel.Write(data)
+	if written > 0 {
+		c.touch()
+	}
+
+	return written, err
+}
+
+// idle returns for how long no data was read from or written to the channel.
+func (c *idleChannel) idle() time.Duration {
+	return time.Since(time.Unix(0, c.last.Load()))
+}
+
+// keepAlive sends an [IdleKeepAliveRequestType] request to the client every time the channel stays idle for interval,
+// until the context is done or the request fails to be sent.
+func keepAlive(ctx context.Context, channel *idleChannel, interval time.Duration) error {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		wait := interval - channel.idle()
+		if wait <= 0 {
+			// NOTICE: The reply is awaited to make the request's round trip through the NATs and firewalls, but its
+			// value is meaningless, as the clients don't know this request.
+			if _, err := channel.SendRequest(IdleKeepAliveRequestType, true, nil); err != nil {
+				return err
+			}
+
+			channel.touch()
+			wait = interval
+		}
+
+		timer.Reset(wait)
+	}
+}
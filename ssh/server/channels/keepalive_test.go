@@ -0,0 +1,69 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+type fakeChannel struct {
+	gossh.Channel
+	requests atomic.Int32
+	err      error
+}
+
+func (c *fakeChannel) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (c *fakeChannel) SendRequest(name string, _ bool, _ []byte) (bool, error) {
+	if name == IdleKeepAliveRequestType {
+		c.requests.Add(1)
+	}
+
+	return false, c.err
+}
+
+func TestKeepAlive(t *testing.T) {
+	t.Run("sends the requests while the channel is idle", func(t *testing.T) {
+		fake := new(fakeChannel)
+		channel := newIdleChannel(fake)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		assert.NoError(t, keepAlive(ctx, channel, 10*time.Millisecond))
+		assert.GreaterOrEqual(t, fake.requests.Load(), int32(3))
+	})
+
+	t.Run("doesn't send the requests while the channel is active", func(t *testing.T) {
+		fake := new(fakeChannel)
+		channel := newIdleChannel(fake)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		go func() {
+			for ctx.Err() == nil {
+				channel.Write([]byte("a")) //nolint:errcheck
+				time.Sleep(5 * time.Millisecond)
+			}
+		}()
+
+		assert.NoError(t, keepAlive(ctx, channel, 50*time.Millisecond))
+		assert.Equal(t, int32(0), fake.requests.Load())
+	})
+
+	t.Run("stops when the request fails", func(t *testing.T) {
+		fake := &fakeChannel{err: errors.New("channel closed")}
+		channel := newIdleChannel(fake)
+
+		assert.EqualError(t, keepAlive(context.Background(), channel, 10*time.Millisecond), "channel closed")
+		assert.Equal(t, int32(1), fake.requests.Load())
+	})
+}
@@ -2,8 +2,11 @@ package channels
 
 import (
 	"strings"
+	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/ssh/pkg/motd"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sessionlimit"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -60,8 +63,28 @@ const (
 	//
 	// https://datatracker.ietf.org/doc/html/rfc4254#section-6.10
 	ExitSignalRequest = "exit-signal"
+	// Environment variables may be passed to the shell/command to be started later.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.4
+	EnvRequestType = "env"
+)
+
+// Requests sent by the client on the session's channel to pause and resume the session's recording, when allowed by
+// the namespace. They are handled by the server and never reach the agent.
+const (
+	RecordPauseRequestType  = "record-pause@shellhub.io"
+	RecordResumeRequestType = "record-resume@shellhub.io"
 )
 
+// ContainerEnv is the environment variable sent to the agent to inform the container targeted by the session.
+const ContainerEnv = "SHELLHUB_CONTAINER"
+
+// Env is the payload of the [EnvRequestType] request.
+type Env struct {
+	Name  string
+	Value string
+}
+
 // A client may request agent forwarding for a previously-opened session using the following channel request. This
 // request is sent after the channel has been opened, but before a [ShellRequestType], command or
 // [SubsystemRequestType] has been executed.
@@ -112,13 +135,16 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 		logger.Info("session channel started")
 		defer logger.Info("session channel done")
 
-		client, clientReqs, err := newChan.Accept()
+		channel, clientReqs, err := newChan.Accept()
 		if err != nil {
 			reject(err, "failed to accept the channel opening")
 
 			return
 		}
 
+		// NOTICE: The client's channel tracks when data is exchanged with it to know when the session is idle.
+		client := newIdleChannel(channel)
+
 		defer client.Close()
 
 		agent, agentReqs, err := sess.AgentClient.OpenChannel(SessionChannel, nil)
@@ -130,7 +156,17 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 
 		defer agent.Close()
 
-		go pipe(ctx, sess, client, agent)
+		recorder := newSessionRecorder(ctx, sess)
+
+		firewall := NewCommandFirewall(sess, client, agent, func() {
+			client.Close() //nolint:errcheck
+			agent.Close()  //nolint:errcheck
+		})
+
+		go pipe(sess, client, agent, recorder, firewall)
+
+		// release frees the interactive session counted on the namespace's limit.
+		var release func()
 
 		// TODO: Add middleware to block a certain type of requests.
 		for {
@@ -209,12 +245,100 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 					return
 				}
 
+				if req.Type == RecordPauseRequestType || req.Type == RecordResumeRequestType {
+					ok := recordPause(sess, recorder, req.Type)
+					if !ok {
+						logger.WithField("request", req.Type).Info("refused the request to pause or resume the session's recording")
+					}
+
+					if req.WantReply {
+						if err := req.Reply(ok, nil); err != nil {
+							logger.WithError(err).Error(err)
+						}
+					}
+
+					continue
+				}
+
+				// NOTICE: The program started on the session is evaluated against the namespace's command policies, and
+				// refused when they can't be retrieved or when they block the command execution.
+				if (req.Type == ShellRequestType || req.Type == ExecRequestType) && !firewall.Start(req.Type, req.Payload) {
+					logger.WithField("request", req.Type).Info("refused the session's program by the namespace's command policies")
+
+					if req.WantReply {
+						if err := req.Reply(false, nil); err != nil {
+							logger.WithError(err).Error(err)
+						}
+					}
+
+					continue
+				}
+
+				// NOTICE: The interactive sessions are counted while the channel is open, and refused when the
+				// namespace already has its maximum number of simultaneous interactive sessions.
+				if limiter, _ := ctx.Value("SESSION_LIMITER").(*sessionlimit.Limiter); req.Type == ShellRequestType && limiter != nil && release == nil {
+					var err error
+					if release, err = limiter.Acquire(ctx, sess.Device.TenantID); err != nil {
+						logger.WithError(err).Info("refused the interactive session above the namespace's limit")
+
+						client.Stderr().Write([]byte(err.Error() + "\r\n")) //nolint:errcheck
+
+						if req.WantReply {
+							if err := req.Reply(false, nil); err != nil {
+								logger.WithError(err).Error(err)
+							}
+						}
+
+						continue
+					}
+
+					defer release()
+				}
+
+				switch req.Type {
+				case ShellRequestType, ExecRequestType, SubsystemRequestType:
+					// NOTICE: The container targeted by the session must be informed to the agent before the request
+					// that starts the program, as the agent decides where to start it when this request is received.
+					if sess.Container != "" {
+						payload := gossh.Marshal(&Env{Name: ContainerEnv, Value: sess.Container})
+						if _, err := agent.SendRequest(EnvRequestType, true, payload); err != nil {
+							logger.WithError(err).Error("failed to send the container environment variable to agent")
+						}
+					}
+				case EnvRequestType:
+					var env Env
+
+					// NOTICE: The container can only be set through the SSHID, so the client isn't allowed to set it.
+					if err := gossh.Unmarshal(req.Payload, &env); err == nil && env.Name == ContainerEnv {
+						if req.WantReply {
+							if err := req.Reply(false, nil); err != nil {
+								logger.WithError(err).Error(err)
+							}
+						}
+
+						continue
+					}
+				}
+
 				switch req.Type {
 				case ShellRequestType:
 					if sess.Pty.Term != "" {
-						if err := sess.Announce(client); err != nil {
+						msg, _ := ctx.Value("MOTD").(*motd.MOTD)
+						if err := sess.Announce(client, msg); err != nil {
 							logger.WithError(err).Warn("failed to get the namespace announcement")
 						}
+
+						if interval, _ := ctx.Value("SESSION_KEEPALIVE_INTERVAL").(time.Duration); interval > 0 && sess.IdleKeepAlive() {
+							go func() {
+								if err := keepAlive(ctx, client, interval); err != nil {
+									logger.WithError(err).Debug("failed to send the keep-alive request to the idle session's client")
+								}
+							}()
+						}
+					}
+
+					if recorder != nil {
+						sess.SetRecordType(recorder.Start(""))
 					}
 
 					sess.Event(req.Type, req.Payload)
@@ -222,6 +346,14 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 					session.Event[session.Command](sess, req.Type, req.Payload)
 
 					sess.Type = ExecRequestType
+
+					if recorder != nil {
+						// NOTICE: the subsystem's request carries its name in the same format of the command line.
+						var cmd session.Command
+						gossh.Unmarshal(req.Payload, &cmd) //nolint:errcheck
+
+						sess.SetRecordType(recorder.Start(cmd.Command))
+					}
 				case PtyRequestType:
 					var pty session.Pty
 
@@ -306,3 +438,25 @@ func DefaultSessionHandler() gliderssh.ChannelHandler {
 		}
 	}
 }
+
+// recordPause pauses or resumes the session's recording, as requested by the client, reporting if it was done. It is
+// refused when the session isn't recorded, when the namespace doesn't allow its users to pause the recordings or when
+// the recording is already on the requested state.
+func recordPause(sess *session.Session, recorder *Recorder, request string) bool {
+	if recorder == nil || !sess.RecordPause() {
+		return false
+	}
+
+	var ok bool
+	if request == RecordPauseRequestType {
+		ok = recorder.Pause()
+	} else {
+		ok = recorder.Resume()
+	}
+
+	if ok {
+		sess.Event(request, nil)
+	}
+
+	return ok
+}
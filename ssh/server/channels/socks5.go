@@ -0,0 +1,93 @@
+package channels
+
+import (
+	"io"
+	"sync"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// DefaultSOCKS5Handler is the channel's handler for SOCKS5 channels. It pipes the channel's data to a SOCKS5 proxy
+// served by the agent over the reverse tunnel, so the client reaches the hosts on the device's network.
+//
+// As the proxy forwards to any host reachable from the device, it follows the same callback of the local port
+// forwarding.
+func DefaultSOCKS5Handler(server *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+	sess, _ := session.ObtainSession(ctx)
+	go func() {
+		// NOTICE: As [gossh.ServerConn] is shared by all channels calls, close it after a channel close block any
+		// other channel involkation. To avoid it, we wait for the connection be closed to finish the sesison.
+		conn.Wait() //nolint:errcheck
+
+		sess.Finish() //nolint:errcheck
+	}()
+
+	logger := log.WithFields(log.Fields{
+		"username": sess.Target.Username,
+		"sshid":    sess.Target.Data,
+	})
+
+	if server.LocalPortForwardingCallback == nil || !server.LocalPortForwardingCallback(ctx, "", 0) {
+		newChan.Reject(gossh.Prohibited, "port forwarding is disabled") //nolint:errcheck
+		logger.Info("port forwarding is disabled")
+
+		return
+	}
+
+	agent, err := sess.DialSOCKS5(ctx)
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, err.Error()) //nolint:errcheck
+		logger.WithError(err).Error("failed to open the SOCKS5 proxy on the agent")
+
+		return
+	}
+
+	defer agent.Close()
+
+	client, reqs, err := newChan.Accept()
+	if err != nil {
+		logger.WithError(err).Error("failed accepting the channel")
+
+		return
+	}
+
+	defer client.Close()
+
+	go gossh.DiscardRequests(reqs)
+
+	sess.Event(SOCKS5Channel, nil) //nolint:errcheck
+
+	logger.Info("piping SOCKS5 data between client and agent")
+
+	wg := new(sync.WaitGroup)
+	done := sync.OnceFunc(func() {
+		agent.Close()
+		client.Close()
+	})
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer done()
+
+		if _, err := io.Copy(agent, client); err != nil && err != io.EOF {
+			logger.WithError(err).Debug("failed to copy data from client to agent")
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer done()
+
+		if _, err := io.Copy(client, agent); err != nil && err != io.EOF {
+			logger.WithError(err).Debug("failed to copy data from agent to client")
+		}
+	}()
+
+	wg.Wait()
+
+	logger.Trace("handling SOCKS5 channel finished")
+}
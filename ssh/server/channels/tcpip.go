@@ -1,12 +1,15 @@
 package channels
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"net"
 	"strconv"
 	"sync"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -76,6 +79,23 @@ func DefaultDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn,
 	connection := sess.AgentClient
 
 	agent, err := connection.Dial("tcp", dest)
+	if rejection, ok := forwardRejection(err); ok {
+		// NOTICE: the agent's forwarding policy rejects the channel with a structured reason, relayed to the client as
+		// a prohibited channel instead of a failure to reach the destination.
+		newChan.Reject(gossh.Prohibited, fmt.Sprintf("port forwarding to %s is %s by the device's policy", rejection.Destination, rejection.Reason)) //nolint:errcheck
+		log.WithFields(log.Fields{
+			"username":    sess.Target.Username,
+			"sshid":       sess.Target.Data,
+			"origin_port": data.OriginAddr,
+			"origin_addr": data.OriginPort,
+			"dest_port":   data.DestPort,
+			"dest_addr":   data.DestAddr,
+			"reason":      rejection.Reason,
+		}).Info("port forwarding rejected by the agent's forwarding policy")
+
+		return
+	}
+
 	if err != nil {
 		newChan.Reject(gossh.ConnectionFailed, "failed dialing the agent to host and port: "+err.Error()) //nolint:errcheck
 		log.WithError(err).WithFields(log.Fields{
@@ -174,3 +194,14 @@ func DefaultDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn,
 		"dest_addr":   data.DestAddr,
 	}).Trace("handling direct-tcpip finished")
 }
+
+// forwardRejection gets the rejection of the agent's forwarding policy from the error returned when the direct-tcpip
+// channel is opened on the agent. It returns false when the channel wasn't rejected by the policy.
+func forwardRejection(err error) (*models.ForwardRejection, bool) {
+	var openErr *gossh.OpenChannelError
+	if !errors.As(err, &openErr) || openErr.Reason != gossh.Prohibited {
+		return nil, false
+	}
+
+	return models.ParseForwardRejection(openErr.Message)
+}
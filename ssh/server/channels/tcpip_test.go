@@ -0,0 +1,59 @@
+package channels
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestForwardRejection(t *testing.T) {
+	type Expected struct {
+		rejection *models.ForwardRejection
+		ok        bool
+	}
+
+	cases := []struct {
+		description string
+		err         error
+		expected    Expected
+	}{
+		{
+			description: "fails when there is no error",
+			err:         nil,
+			expected:    Expected{nil, false},
+		},
+		{
+			description: "fails when the channel wasn't rejected",
+			err:         errors.New("connection reset"),
+			expected:    Expected{nil, false},
+		},
+		{
+			description: "fails when the channel was rejected for another reason",
+			err:         &gossh.OpenChannelError{Reason: gossh.ConnectionFailed, Message: "connection refused"},
+			expected:    Expected{nil, false},
+		},
+		{
+			description: "fails when the channel was prohibited without the policy",
+			err:         &gossh.OpenChannelError{Reason: gossh.Prohibited, Message: "port forwarding is disabled"},
+			expected:    Expected{nil, false},
+		},
+		{
+			description: "succeeds when the channel was rejected by the policy",
+			err: &gossh.OpenChannelError{
+				Reason:  gossh.Prohibited,
+				Message: (&models.ForwardRejection{Reason: models.ForwardRejectionDenied, Destination: "10.0.0.1:22"}).Message(),
+			},
+			expected: Expected{&models.ForwardRejection{Reason: models.ForwardRejectionDenied, Destination: "10.0.0.1:22"}, true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			rejection, ok := forwardRejection(tc.err)
+			assert.Equal(t, tc.expected, Expected{rejection, ok})
+		})
+	}
+}
@@ -1,100 +1,271 @@
 package channels
 
 import (
+	"context"
+	"errors"
 	"io"
+	"path/filepath"
 	"sync"
 
 	"github.com/Masterminds/semver"
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
 )
 
+const (
+	// RecordExecCommandLimit is the maximum size, in bytes, of the command line recorded from an exec session.
+	RecordExecCommandLimit = 4 * 1024
+	// RecordExecStreamLimit is the maximum size, in bytes, recorded from each output stream of an exec session. The
+	// output after it still reaches the client, but it isn't recorded.
+	RecordExecStreamLimit = 1024 * 1024
+)
+
+// FrameWriter enqueues the session's frames to be sent to where the session is recorded, like the record endpoint,
+// through [session.Uploader], or the object storage, through [session.ObjectUploader].
+type FrameWriter interface {
+	WriteFrame(frame *models.SessionRecorded)
+	// Close stops receiving frames, sending the pending ones.
+	Close()
+	// Hash returns the hash of the frames written, or an empty string when the writer doesn't hash them.
+	Hash() string
+}
+
+// Recorder records the session's frames, sending them to the record endpoint through a [FrameWriter], so a slow or
+// unavailable endpoint doesn't block the session.
+//
+// The sessions with a pseudo-terminal are recorded as a terminal's output. The other ones, like the command
+// executions, are recorded with the command line and the output streams apart, each one capped to a maximum size.
 type Recorder struct {
-	queue   chan string
-	channel gossh.Channel
+	writer  FrameWriter
+	session *session.Session
+
+	mu  sync.Mutex
+	typ models.SessionRecordType
+	// recorded is the number of bytes recorded from each stream of an exec recording.
+	recorded map[models.SessionRecordStream]int
+	// truncated are the streams of an exec recording already cut for exceeding their limit.
+	truncated map[models.SessionRecordStream]bool
+	// paused indicates the user paused the recording; the data isn't recorded until it is resumed.
+	paused bool
 }
 
-func NewRecorder(channel gossh.Channel, sess *session.Session, camera *session.Camera) (io.WriteCloser, error) {
-	// NOTE: The queue's size is a random number.
-	queue := make(chan string, 100)
+// NewRecorder creates a [Recorder] for the session, writing its frames to writer. Until the session's program is
+// started, through [Recorder.Start], the frames are recorded as a pty recording.
+func NewRecorder(sess *session.Session, writer FrameWriter) *Recorder {
+	return &Recorder{
+		writer:    writer,
+		session:   sess,
+		typ:       models.SessionRecordTypePty,
+		recorded:  make(map[models.SessionRecordStream]int),
+		truncated: make(map[models.SessionRecordStream]bool),
+	}
+}
 
-	go func() {
-		for {
-			msg, ok := <-queue
-			if !ok {
-				log.WithFields(log.Fields{"session": sess.UID, "sshid": sess.SSHID}).
-					Warning("recorder queue is closed")
+// newSessionRecorder creates a [Recorder] for the session when the instance records its sessions. It returns nil when
+// the sessions aren't recorded, so a problem on the recording never stops the session.
+//
+// When an object storage is configured, the sessions of the namespaces recording them are uploaded to it, on any
+// edition. Otherwise, they are sent to the record endpoint, only available on the enterprise and cloud editions.
+func newSessionRecorder(ctx gliderssh.Context, sess *session.Session) *Recorder {
+	if storage, _ := ctx.Value("RECORD_STORAGE").(objectstorage.Storage); storage != nil {
+		if !sess.RecordEnabled() {
+			return nil
+		}
 
-				return
-			}
+		prefix, _ := ctx.Value("RECORD_STORAGE_PREFIX").(string)
 
-			if err := camera.WriteFrame(&models.SessionRecorded{ //nolint:errcheck
-				UID:       sess.UID,
-				Namespace: sess.Lookup["domain"],
-				Message:   msg,
-				Width:     int(sess.Pty.Columns),
-				Height:    int(sess.Pty.Rows),
-			}); err != nil {
-				log.WithError(err).
-					WithFields(log.Fields{"session": sess.UID, "sshid": sess.SSHID}).
-					Warning("failed to send the session frame to record")
-
-					// NOTE: When a frame isn't sent correctly, we stop the writing loop, only reading from the queue,
-					// and discarding the messages to avoid stuck the go routine.
-				break
-			}
-		}
+		uploader := session.NewObjectUploader(storage, session.RecordObjectKey(prefix, sess.Device.TenantID, sess.UID), sess.SetRecordObject)
 
-		for {
-			// NOTE: Reads the queue and discards the data to avoid stuck the go routine.
-			if _, ok := <-queue; !ok {
-				log.WithFields(log.Fields{"session": sess.UID, "sshid": sess.SSHID}).
-					Warning("recorder queue is closed")
+		return NewRecorder(sess, uploader)
+	}
 
-				return
-			}
-		}
-	}()
+	if !envs.IsEnterprise() && !envs.IsCloud() {
+		return nil
+	}
 
-	return &Recorder{
-		queue:   queue,
-		channel: channel,
-	}, nil
+	recordURL, _ := ctx.Value("RECORD_URL").(string)
+	if recordURL == "" {
+		log.WithFields(log.Fields{"session": sess.UID, "sshid": sess.SSHID, "record_url": recordURL}).
+			Warning("failed to start session's record because the record URL is empty")
+
+		return nil
+	}
+
+	spillDir, _ := ctx.Value("RECORD_SPILL_DIR").(string)
+
+	uploader := session.NewUploader(filepath.Join(spillDir, sess.UID), func(dialCtx context.Context) (*session.Camera, error) {
+		return sess.Record(dialCtx, recordURL)
+	})
+
+	return NewRecorder(sess, uploader)
 }
 
-// record enqueues a session frame to be recorded. If the queue is closed, nothing is done.
-func (c *Recorder) record(msg string) {
-	select {
-	case c.queue <- msg:
-	default:
-		log.Trace("the message couldn't sent to the record queue")
+// Start sets the kind of the recording when the session's program is started, returning it. The sessions with a
+// pseudo-terminal are recorded as pty; the other ones as exec, starting with the command line executed, if any.
+func (r *Recorder) Start(command string) models.SessionRecordType {
+	typ := models.SessionRecordTypeExec
+	if r.session.Pty.Term != "" {
+		typ = models.SessionRecordTypePty
 	}
+
+	r.mu.Lock()
+	r.typ = typ
+	r.mu.Unlock()
+
+	if typ == models.SessionRecordTypeExec && command != "" {
+		r.record(models.SessionRecordStreamCommand, []byte(command))
+	}
+
+	return typ
+}
+
+// Stream returns a writer recording the data written to it as the stream of the session's output.
+func (r *Recorder) Stream(stream models.SessionRecordStream) io.Writer {
+	return &recorderStream{recorder: r, stream: stream}
 }
 
-func (c *Recorder) Write(data []byte) (int, error) {
-	read, err := c.channel.Write(data)
-	if err != nil {
-		return read, err
+// record enqueues a frame with the stream's data to be recorded. On exec recordings, the data above the stream's limit
+// is discarded, and the stream's last frame is marked as truncated.
+func (r *Recorder) record(stream models.SessionRecordStream, data []byte) {
+	frame := &models.SessionRecorded{
+		UID:       r.session.UID,
+		Namespace: r.session.Lookup["domain"],
+		Time:      clock.Now(),
+	}
+
+	r.mu.Lock()
+
+	if r.paused {
+		r.mu.Unlock()
+
+		return
+	}
+
+	if r.typ == models.SessionRecordTypePty {
+		r.mu.Unlock()
+
+		frame.Message = string(data)
+		frame.Width = int(r.session.Pty.Columns)
+		frame.Height = int(r.session.Pty.Rows)
+
+		r.writer.WriteFrame(frame)
+
+		return
 	}
 
-	c.record(string(data))
+	if r.truncated[stream] {
+		r.mu.Unlock()
+
+		return
+	}
+
+	limit := RecordExecStreamLimit
+	if stream == models.SessionRecordStreamCommand {
+		limit = RecordExecCommandLimit
+	}
+
+	// NOTICE: when the stream has exactly reached its limit, the frame only carries the truncation marker.
+	if left := limit - r.recorded[stream]; len(data) > left {
+		data = data[:left]
+
+		frame.Truncated = true
+		r.truncated[stream] = true
+	}
 
-	return read, nil
+	r.recorded[stream] += len(data)
+
+	r.mu.Unlock()
+
+	frame.Type = models.SessionRecordTypeExec
+	frame.Stream = stream
+	frame.Message = string(data)
+
+	r.writer.WriteFrame(frame)
+}
+
+// recordPauseMessages are the messages of the pty frames marking the pauses and resumes, so they are visible on the
+// recording's playback.
+var recordPauseMessages = map[models.SessionRecordMarker]string{
+	models.SessionRecordMarkerPause:  "\r\n[ShellHub: the recording was paused by the user]\r\n",
+	models.SessionRecordMarkerResume: "\r\n[ShellHub: the recording was resumed by the user]\r\n",
+}
+
+// Pause stops recording the session's data until [Recorder.Resume] is called, marking the point on the recording. It
+// returns false when the recording is already paused.
+func (r *Recorder) Pause() bool {
+	return r.mark(models.SessionRecordMarkerPause, true)
+}
+
+// Resume records again the session's data after a [Recorder.Pause], marking the point on the recording. It returns
+// false when the recording isn't paused.
+func (r *Recorder) Resume() bool {
+	return r.mark(models.SessionRecordMarkerResume, false)
+}
+
+// mark sets the recording as paused, or not, enqueuing the frame that marks it. It does nothing when the recording is
+// already on the state.
+func (r *Recorder) mark(marker models.SessionRecordMarker, paused bool) bool {
+	r.mu.Lock()
+	if r.paused == paused {
+		r.mu.Unlock()
+
+		return false
+	}
+
+	r.paused = paused
+	typ := r.typ
+	r.mu.Unlock()
+
+	frame := &models.SessionRecorded{
+		UID:       r.session.UID,
+		Namespace: r.session.Lookup["domain"],
+		Time:      clock.Now(),
+		Marker:    marker,
+	}
+
+	if typ == models.SessionRecordTypePty {
+		frame.Message = recordPauseMessages[marker]
+		frame.Width = int(r.session.Pty.Columns)
+		frame.Height = int(r.session.Pty.Rows)
+	} else {
+		frame.Type = models.SessionRecordTypeExec
+	}
+
+	r.writer.WriteFrame(frame)
+
+	return true
+}
+
+// Close stops the recording, sending the frames still pending, and reports the recording's hash, which is attested
+// with the session when its namespace attests the sessions.
+func (r *Recorder) Close() {
+	r.writer.Close()
+
+	if hash := r.writer.Hash(); hash != "" {
+		r.session.SetRecordHash(hash)
+	}
+}
+
+type recorderStream struct {
+	recorder *Recorder
+	stream   models.SessionRecordStream
 }
 
-func (c *Recorder) Close() error {
-	close(c.queue)
+func (s *recorderStream) Write(data []byte) (int, error) {
+	s.recorder.record(s.stream, data)
 
-	return c.channel.CloseWrite()
+	return len(data), nil
 }
 
-// pipe pipes data between client and agent, and vice versa, recording each frame when ShellHub instance are Cloud or
-// Enterprise.
-func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, agent gossh.Channel) {
+// pipe pipes data between client and agent, and vice versa, recording the agent's output through recorder and
+// evaluating the client's input through firewall, when they aren't nil.
+func pipe(sess *session.Session, client gossh.Channel, agent gossh.Channel, recorder *Recorder, firewall *CommandFirewall) {
 	defer log.
 		WithFields(log.Fields{"session": sess.UID, "sshid": sess.SSHID}).
 		Trace("data pipe between client and agent has done")
@@ -103,50 +274,20 @@ func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, ag
 	wg.Add(2)
 
 	c := io.MultiReader(client, client.Stderr())
-	a := io.MultiReader(agent, agent.Stderr())
 
 	go func() {
 		defer wg.Done()
+		defer client.CloseWrite() //nolint:errcheck
 
-		if envs.IsEnterprise() || envs.IsCloud() {
-			recordURL := ctx.Value("RECORD_URL").(string)
-			if recordURL == "" {
-				log.WithFields(log.Fields{"session": sess.UID, "sshid": sess.SSHID, "record_url": recordURL}).
-					Warning("failed to start session's record because the record URL is empty")
-
-				goto normal
-			}
-
-			camera, err := sess.Record(ctx, recordURL)
-			if err != nil {
-				goto normal
-			}
-
-			recorder, err := NewRecorder(client, sess, camera)
-			if err != nil {
-				log.WithError(err).
-					WithFields(log.Fields{"session": sess.UID, "sshid": sess.SSHID, "record_url": recordURL}).
-					Warning("failed to connect to session record endpoint")
-
-				goto normal
-			}
-
-			defer recorder.Close() //nolint:errcheck
-
-			if _, err := io.Copy(recorder, a); err != nil && err != io.EOF {
-				log.WithError(err).Error("failed on coping data from client to agent")
-			}
+		var stdout, stderr io.Reader = agent, agent.Stderr()
+		if recorder != nil {
+			defer recorder.Close()
 
-			return
+			stdout = io.TeeReader(stdout, recorder.Stream(models.SessionRecordStreamStdout))
+			stderr = io.TeeReader(stderr, recorder.Stream(models.SessionRecordStreamStderr))
 		}
 
-		// NOTE: "normal" labels indicate the default way of copying data between clients and the agent without recording.
-		// Their idea was, if something goes wrong with the recording flow, the session will continue, even without the
-		// recording.
-	normal:
-		defer client.CloseWrite() //nolint:errcheck
-
-		if _, err := io.Copy(client, a); err != nil && err != io.EOF {
+		if _, err := io.Copy(client, io.MultiReader(stdout, stderr)); err != nil && err != io.EOF {
 			log.WithError(err).Error("failed on coping data from client to agent")
 		}
 
@@ -170,7 +311,12 @@ func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, ag
 			}
 		}()
 
-		if _, err := io.Copy(agent, c); err != nil && err != io.EOF {
+		var input io.Writer = agent
+		if firewall != nil {
+			input = firewall
+		}
+
+		if _, err := io.Copy(input, c); err != nil && err != io.EOF && !errors.Is(err, errCommandPolicyTerminated) {
 			log.WithError(err).Error("failed on coping data from client to agent")
 		}
 
@@ -0,0 +1,156 @@
+package channels
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeFrameWriter struct {
+	frames []*models.SessionRecorded
+	closed bool
+}
+
+func (w *fakeFrameWriter) WriteFrame(frame *models.SessionRecorded) {
+	w.frames = append(w.frames, frame)
+}
+
+func (w *fakeFrameWriter) Close() {
+	w.closed = true
+}
+
+func (w *fakeFrameWriter) Hash() string {
+	return ""
+}
+
+func TestRecorder(t *testing.T) {
+	newSession := func(term string) *session.Session {
+		return &session.Session{
+			UID: "uid",
+			Data: session.Data{
+				Lookup: map[string]string{"domain": "namespace"},
+				Pty:    session.Pty{Term: term, Columns: 80, Rows: 24},
+			},
+		}
+	}
+
+	t.Run("records the sessions with a pseudo-terminal as pty", func(t *testing.T) {
+		writer := new(fakeFrameWriter)
+		recorder := NewRecorder(newSession("xterm"), writer)
+
+		assert.Equal(t, models.SessionRecordTypePty, recorder.Start("ls"))
+
+		recorder.Stream(models.SessionRecordStreamStdout).Write([]byte("output")) //nolint:errcheck
+		recorder.Close()
+
+		assert.Len(t, writer.frames, 1)
+		assert.Equal(t, "output", writer.frames[0].Message)
+		assert.Equal(t, 80, writer.frames[0].Width)
+		assert.Equal(t, 24, writer.frames[0].Height)
+		assert.Empty(t, writer.frames[0].Type)
+		assert.Empty(t, writer.frames[0].Stream)
+		assert.True(t, writer.closed)
+	})
+
+	t.Run("records the sessions without a pseudo-terminal as exec", func(t *testing.T) {
+		writer := new(fakeFrameWriter)
+		recorder := NewRecorder(newSession(""), writer)
+
+		assert.Equal(t, models.SessionRecordTypeExec, recorder.Start("ls -la"))
+
+		recorder.Stream(models.SessionRecordStreamStdout).Write([]byte("output")) //nolint:errcheck
+		recorder.Stream(models.SessionRecordStreamStderr).Write([]byte("error"))  //nolint:errcheck
+
+		assert.Len(t, writer.frames, 3)
+
+		for i, expected := range []struct {
+			stream  models.SessionRecordStream
+			message string
+		}{
+			{models.SessionRecordStreamCommand, "ls -la"},
+			{models.SessionRecordStreamStdout, "output"},
+			{models.SessionRecordStreamStderr, "error"},
+		} {
+			assert.Equal(t, models.SessionRecordTypeExec, writer.frames[i].Type)
+			assert.Equal(t, expected.stream, writer.frames[i].Stream)
+			assert.Equal(t, expected.message, writer.frames[i].Message)
+			assert.False(t, writer.frames[i].Truncated)
+		}
+	})
+
+	t.Run("doesn't record the command line of a shell without a pseudo-terminal", func(t *testing.T) {
+		writer := new(fakeFrameWriter)
+		recorder := NewRecorder(newSession(""), writer)
+
+		assert.Equal(t, models.SessionRecordTypeExec, recorder.Start(""))
+		assert.Empty(t, writer.frames)
+	})
+
+	t.Run("truncates the streams above their limit", func(t *testing.T) {
+		writer := new(fakeFrameWriter)
+		recorder := NewRecorder(newSession(""), writer)
+
+		recorder.Start(strings.Repeat("c", RecordExecCommandLimit+1))
+
+		stdout := recorder.Stream(models.SessionRecordStreamStdout)
+		stdout.Write([]byte(strings.Repeat("o", RecordExecStreamLimit-1))) //nolint:errcheck
+		stdout.Write([]byte("oo"))                                         //nolint:errcheck
+		stdout.Write([]byte("o"))                                          //nolint:errcheck
+
+		assert.Len(t, writer.frames, 3)
+
+		assert.Len(t, writer.frames[0].Message, RecordExecCommandLimit)
+		assert.True(t, writer.frames[0].Truncated)
+
+		assert.False(t, writer.frames[1].Truncated)
+		assert.Equal(t, "o", writer.frames[2].Message)
+		assert.True(t, writer.frames[2].Truncated)
+	})
+
+	t.Run("marks the stream as truncated when it exceeds its limit exactly after it", func(t *testing.T) {
+		writer := new(fakeFrameWriter)
+		recorder := NewRecorder(newSession(""), writer)
+
+		recorder.Start("")
+
+		stderr := recorder.Stream(models.SessionRecordStreamStderr)
+		stderr.Write([]byte(strings.Repeat("e", RecordExecStreamLimit))) //nolint:errcheck
+		stderr.Write([]byte("e"))                                        //nolint:errcheck
+		stderr.Write([]byte("e"))                                        //nolint:errcheck
+
+		assert.Len(t, writer.frames, 2)
+		assert.False(t, writer.frames[0].Truncated)
+		assert.Empty(t, writer.frames[1].Message)
+		assert.True(t, writer.frames[1].Truncated)
+	})
+
+	t.Run("doesn't record the data between a pause and its resume", func(t *testing.T) {
+		writer := new(fakeFrameWriter)
+		recorder := NewRecorder(newSession("xterm"), writer)
+		recorder.Start("")
+
+		recorder.Stream(models.SessionRecordStreamStdout).Write([]byte("before")) //nolint:errcheck
+
+		assert.True(t, recorder.Pause())
+		assert.False(t, recorder.Pause())
+
+		recorder.Stream(models.SessionRecordStreamStdout).Write([]byte("secret")) //nolint:errcheck
+
+		assert.True(t, recorder.Resume())
+		assert.False(t, recorder.Resume())
+
+		recorder.Stream(models.SessionRecordStreamStdout).Write([]byte("after")) //nolint:errcheck
+
+		assert.Len(t, writer.frames, 4)
+		assert.Equal(t, "before", writer.frames[0].Message)
+		assert.Equal(t, models.SessionRecordMarkerPause, writer.frames[1].Marker)
+		assert.Contains(t, writer.frames[1].Message, "paused")
+		assert.Equal(t, models.SessionRecordMarkerResume, writer.frames[2].Marker)
+		assert.Contains(t, writer.frames[2].Message, "resumed")
+		assert.Equal(t, "after", writer.frames[3].Message)
+		assert.Empty(t, writer.frames[3].Marker)
+	})
+}
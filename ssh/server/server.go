@@ -7,8 +7,13 @@ import (
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/pires/go-proxyproto"
+	"github.com/shellhub-io/shellhub/pkg/banlist"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
+	"github.com/shellhub-io/shellhub/ssh/pkg/handshake"
+	"github.com/shellhub-io/shellhub/ssh/pkg/motd"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sessionlimit"
 	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	"github.com/shellhub-io/shellhub/ssh/server/auth"
 	"github.com/shellhub-io/shellhub/ssh/server/channels"
@@ -20,10 +25,31 @@ type Options struct {
 	ConnectTimeout time.Duration
 	// TODO: add default value for RECORD_URL.
 	RecordURL string
+	// RecordSpillDir is the directory where the session's frames are spilled to when the record endpoint is slow or
+	// unavailable.
+	RecordSpillDir string
+	// RecordStorage is the object storage where the sessions are recorded, instead of the record endpoint. It is nil
+	// when the sessions are recorded on the record endpoint.
+	RecordStorage objectstorage.Storage
+	// RecordStoragePrefix is the prefix of the recordings' keys on the object storage.
+	RecordStoragePrefix string
 	// Allows SSH to connect with an agent via a public key when the agent version is less than 0.6.0.
 	// Agents 0.5.x or earlier do not validate the public key request and may panic.
 	// Please refer to: https://github.com/shellhub-io/shellhub/issues/3453
 	AllowPublickeyAccessBelow060 bool
+	// MOTD is the message of the day shown on the interactive sessions. It is nil when not configured.
+	MOTD *motd.MOTD
+	// Banlist refuses the connections from the banned addresses. It is nil when not enforced.
+	Banlist *banlist.Banlist
+	// SessionKeepAliveInterval is for how long an interactive session stays idle before a keep-alive request is sent
+	// to its client, when enabled by the namespace. A zero interval disables it.
+	SessionKeepAliveInterval time.Duration
+	// DenialMessages are the messages shown, on the SSH banner, to the clients whose connections were denied, per
+	// reason. The reasons without a message show the default one.
+	DenialMessages session.DenialMessages
+	// SessionLimiter refuses the interactive sessions above the namespace's limit of simultaneous interactive
+	// sessions. It is nil when not enforced.
+	SessionLimiter *sessionlimit.Limiter
 }
 
 type Server struct {
@@ -41,10 +67,27 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel, cache cache.Cache) *Ser
 	server.sshd = &gliderssh.Server{ // nolint: exhaustruct
 		Addr: ":2222",
 		ConnCallback: func(ctx gliderssh.Context, conn net.Conn) net.Conn {
-			ctx.SetValue("conn", conn)
-			ctx.SetValue("RECORD_URL", opts.RecordURL)
+			if host, _, err := net.SplitHostPort(conn.RemoteAddr().String()); err == nil && opts.Banlist != nil && opts.Banlist.Banned(ctx, host) {
+				log.WithField("ip", host).Info("connection refused from a banned address")
+
+				// NOTICE: returning nil closes the connection before the SSH handshake.
+				return nil
+			}
 
-			return conn
+			// NOTICE: The connection is wrapped to inspect the algorithms negotiated with the client, that are saved
+			// as session's metadata.
+			wrapped := handshake.NewConn(conn)
+
+			ctx.SetValue("conn", wrapped)
+			ctx.SetValue("RECORD_URL", opts.RecordURL)
+			ctx.SetValue("RECORD_SPILL_DIR", opts.RecordSpillDir)
+			ctx.SetValue("RECORD_STORAGE", opts.RecordStorage)
+			ctx.SetValue("RECORD_STORAGE_PREFIX", opts.RecordStoragePrefix)
+			ctx.SetValue("MOTD", opts.MOTD)
+			ctx.SetValue("SESSION_KEEPALIVE_INTERVAL", opts.SessionKeepAliveInterval)
+			ctx.SetValue("SESSION_LIMITER", opts.SessionLimiter)
+
+			return wrapped
 		},
 		BannerHandler: func(ctx gliderssh.Context) string {
 			logger := log.WithFields(
@@ -75,9 +118,12 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel, cache cache.Cache) *Ser
 			}
 
 			if err := sess.Evaluate(ctx); err != nil {
-				logger.WithError(err).Error("destination device has a firewall to blocked it or a billing issue")
+				denial := session.NewDenial(err)
+				session.SetDenial(ctx, denial)
+
+				logger.WithError(err).WithField("reason", denial.Reason).Error("destination device has a firewall to blocked it or a billing issue")
 
-				return "you cannot access the device due a policy rule"
+				return opts.DenialMessages.Message(denial.Reason)
 			}
 
 			return ""
@@ -89,8 +135,9 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel, cache cache.Cache) *Ser
 		// and the server. SSH channels serve as the infrastructure for executing commands, establishing shell sessions,
 		// and securely forwarding network services.
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
-			channels.SessionChannel:     channels.DefaultSessionHandler(),
-			channels.DirectTCPIPChannel: channels.DefaultDirectTCPIPHandler,
+			channels.SessionChannel:     channels.Denied(channels.DefaultSessionHandler()),
+			channels.DirectTCPIPChannel: channels.Denied(channels.DefaultDirectTCPIPHandler),
+			channels.SOCKS5Channel:      channels.Denied(channels.DefaultSOCKS5Handler),
 		},
 		LocalPortForwardingCallback: func(_ gliderssh.Context, _ string, _ uint32) bool {
 			return true
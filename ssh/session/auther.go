@@ -19,6 +19,18 @@ const (
 	AuthMethodPassword                    // AuthMethodPassword represents a password authentication
 )
 
+// String returns the SSH name of the authentication method.
+func (m authMethod) String() string {
+	switch m {
+	case AuthMethodPublicKey:
+		return "publickey"
+	case AuthMethodPassword:
+		return "password"
+	default:
+		return "unknown"
+	}
+}
+
 // Auth interface defines a common interface for authenticating a session. An 'Auth'
 // must have an associated [authMethod], an [authFunc] to authenticate the session, and
 // an 'Evaluate' method to evaluate the session's context if necessary (e.g. the agent
@@ -94,6 +106,11 @@ func (p *publicKeyAuth) Evaluate(session *Session) error {
 		}
 	}
 
+	// User certificates are trusted by the namespace's certificate authorities, instead of the registered public keys.
+	if cert, ok := p.pk.(*gossh.Certificate); ok {
+		return session.evaluateCertificate(cert)
+	}
+
 	fingerprint := gossh.FingerprintLegacyMD5(p.pk)
 
 	magic, err := gossh.NewPublicKey(&magickey.GetRerefence().PublicKey)
@@ -102,11 +119,16 @@ func (p *publicKeyAuth) Evaluate(session *Session) error {
 	}
 
 	if gossh.FingerprintLegacyMD5(magic) != fingerprint {
-		if _, err = session.api.GetPublicKey(fingerprint, session.Device.TenantID); err != nil {
+		if _, err = session.api.GetPublicKey(fingerprint, session.credentialsTenant()); err != nil {
 			return err
 		}
 
-		if ok, err := session.api.EvaluateKey(fingerprint, session.Device, session.Data.Target.Username); !ok || err != nil {
+		// NOTICE: the key is evaluated as one of the namespace holding it, so a shared device is evaluated with the
+		// grantee's tenant.
+		device := *session.Device
+		device.TenantID = session.credentialsTenant()
+
+		if ok, err := session.api.EvaluateKey(fingerprint, &device, session.Data.Target.Username); !ok || err != nil {
 			return ErrEvaluatePublicKey
 		}
 	}
@@ -114,6 +136,26 @@ func (p *publicKeyAuth) Evaluate(session *Session) error {
 	return err
 }
 
+// certificateKeyID returns the key ID of the user certificate authenticating the session, or an empty string when the
+// public key isn't a certificate.
+func (p *publicKeyAuth) certificateKeyID() string {
+	if cert, ok := p.pk.(*gossh.Certificate); ok {
+		return cert.KeyId
+	}
+
+	return ""
+}
+
+// credentialsTenant returns the tenant of the namespace whose public keys and certificate authorities authenticate the
+// session: the grantee's one when the device is shared with the namespace in the SSHID, or the device's one otherwise.
+func (s *Session) credentialsTenant() string {
+	if s.Device.Shared != nil {
+		return s.Device.Shared.GranteeTenantID
+	}
+
+	return s.Device.TenantID
+}
+
 type passwordAuth struct {
 	pwd string
 }
@@ -0,0 +1,108 @@
+package session
+
+import (
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// checkCertificate checks if the OpenSSH user certificate authenticates the username, connecting from the client's ip,
+// being signed by one of the authorities trusted at now, valid at now, issued to the username as one of its principals
+// and, when it is restricted to source addresses, used from one of them.
+func checkCertificate(cert *gossh.Certificate, username, ip string, authorities []models.SSHCertificateAuthority, now time.Time) error {
+	if cert.CertType != gossh.UserCert {
+		return ErrCertificateType
+	}
+
+	fingerprint := gossh.FingerprintSHA256(cert.SignatureKey)
+
+	trusted := false
+	for _, authority := range authorities {
+		if authority.Trusted(now) && authority.Fingerprint == fingerprint {
+			trusted = true
+
+			break
+		}
+	}
+
+	if !trusted {
+		return ErrCertificateAuthority
+	}
+
+	// NOTICE: like OpenSSH does, a certificate without principals isn't accepted, instead of being valid to any user.
+	if len(cert.ValidPrincipals) == 0 {
+		return ErrCertificatePrincipal
+	}
+
+	checker := &gossh.CertChecker{
+		Clock: func() time.Time {
+			return now
+		},
+	}
+
+	// CheckCert verifies the certificate's signature, its validity window, its principals and its critical options,
+	// except the source addresses, which are left to the server's handshake.
+	if err := checker.CheckCert(username, cert); err != nil {
+		return ErrEvaluateCertificate
+	}
+
+	// NOTICE: the handshake only sees the gateway's address, so the source addresses are checked against the client's
+	// address forwarded by it.
+	if addresses, ok := cert.CriticalOptions["source-address"]; ok {
+		if !matchSourceAddress(ip, addresses) {
+			return ErrCertificateSourceAddress
+		}
+	}
+
+	return nil
+}
+
+// matchSourceAddress reports whether the ip is one of the addresses, a comma separated list of addresses and CIDR
+// ranges as the certificate's source-address option. Like OpenSSH does, a malformed list matches no address.
+func matchSourceAddress(ip string, addresses string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	matched := false
+	for _, address := range strings.Split(addresses, ",") {
+		prefix, err := netip.ParsePrefix(address)
+		if err != nil {
+			single, err := netip.ParseAddr(address)
+			if err != nil {
+				return false
+			}
+
+			prefix = netip.PrefixFrom(single, single.BitLen())
+		}
+
+		if prefix.Contains(addr) {
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+// evaluateCertificate checks the OpenSSH user certificate against the SSH certificate authorities of the namespace
+// authenticating the session, which is the grantee's one on a shared device.
+func (s *Session) evaluateCertificate(cert *gossh.Certificate) error {
+	namespace, errs := s.api.NamespaceLookup(s.credentialsTenant())
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	var authorities []models.SSHCertificateAuthority
+	if namespace.Settings != nil {
+		authorities = namespace.Settings.SSHCertificateAuthorities
+	}
+
+	return checkCertificate(cert, s.Target.Username, s.IPAddress, authorities, clock.Now())
+}
@@ -0,0 +1,176 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestCheckCertificate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newSigner := func() gossh.Signer {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		signer, err := gossh.NewSignerFromKey(key)
+		require.NoError(t, err)
+
+		return signer
+	}
+
+	ca := newSigner()
+	rotated := newSigner()
+	user := newSigner()
+
+	expired := now.Add(-time.Hour)
+	authorities := []models.SSHCertificateAuthority{
+		{Fingerprint: gossh.FingerprintSHA256(ca.PublicKey())},
+		{Fingerprint: gossh.FingerprintSHA256(rotated.PublicKey()), ExpiresAt: &expired},
+	}
+
+	newCertificate := func(signer gossh.Signer, edit func(*gossh.Certificate)) *gossh.Certificate {
+		cert := &gossh.Certificate{
+			Key:             user.PublicKey(),
+			KeyId:           "user@example.com",
+			CertType:        gossh.UserCert,
+			ValidPrincipals: []string{"root"},
+			ValidAfter:      uint64(now.Add(-time.Hour).Unix()),
+			ValidBefore:     uint64(now.Add(time.Hour).Unix()),
+		}
+
+		if edit != nil {
+			edit(cert)
+		}
+
+		require.NoError(t, cert.SignCert(rand.Reader, signer))
+
+		return cert
+	}
+
+	cases := []struct {
+		description string
+		cert        *gossh.Certificate
+		username    string
+		ip          string
+		expected    error
+	}{
+		{
+			description: "fails when the certificate is a host certificate",
+			cert: newCertificate(ca, func(cert *gossh.Certificate) {
+				cert.CertType = gossh.HostCert
+			}),
+			username: "root",
+			expected: ErrCertificateType,
+		},
+		{
+			description: "fails when the certificate is signed by an unknown authority",
+			cert:        newCertificate(newSigner(), nil),
+			username:    "root",
+			expected:    ErrCertificateAuthority,
+		},
+		{
+			description: "fails when the certificate is signed by an authority whose grace period has ended",
+			cert:        newCertificate(rotated, nil),
+			username:    "root",
+			expected:    ErrCertificateAuthority,
+		},
+		{
+			description: "fails when the certificate doesn't list any principal",
+			cert: newCertificate(ca, func(cert *gossh.Certificate) {
+				cert.ValidPrincipals = nil
+			}),
+			username: "root",
+			expected: ErrCertificatePrincipal,
+		},
+		{
+			description: "fails when the username isn't one of the certificate's principals",
+			cert:        newCertificate(ca, nil),
+			username:    "admin",
+			expected:    ErrEvaluateCertificate,
+		},
+		{
+			description: "fails when the certificate has expired",
+			cert: newCertificate(ca, func(cert *gossh.Certificate) {
+				cert.ValidBefore = uint64(now.Add(-time.Minute).Unix())
+			}),
+			username: "root",
+			expected: ErrEvaluateCertificate,
+		},
+		{
+			description: "fails when the certificate has an unsupported critical option",
+			cert: newCertificate(ca, func(cert *gossh.Certificate) {
+				cert.CriticalOptions = map[string]string{"force-command": "/bin/true"}
+			}),
+			username: "root",
+			expected: ErrEvaluateCertificate,
+		},
+		{
+			description: "fails when the client's address isn't one of the certificate's source addresses",
+			cert: newCertificate(ca, func(cert *gossh.Certificate) {
+				cert.CriticalOptions = map[string]string{"source-address": "10.0.0.0/8,192.168.0.1"}
+			}),
+			username: "root",
+			ip:       "192.168.0.2",
+			expected: ErrCertificateSourceAddress,
+		},
+		{
+			description: "fails when the certificate's source addresses are malformed",
+			cert: newCertificate(ca, func(cert *gossh.Certificate) {
+				cert.CriticalOptions = map[string]string{"source-address": "192.168.0.1,localhost"}
+			}),
+			username: "root",
+			ip:       "192.168.0.1",
+			expected: ErrCertificateSourceAddress,
+		},
+		{
+			description: "succeeds when the client's address is in a certificate's source range",
+			cert: newCertificate(ca, func(cert *gossh.Certificate) {
+				cert.CriticalOptions = map[string]string{"source-address": "10.0.0.0/8,192.168.0.1"}
+			}),
+			username: "root",
+			ip:       "10.1.2.3",
+			expected: nil,
+		},
+		{
+			description: "succeeds when the client's address is a certificate's source address",
+			cert: newCertificate(ca, func(cert *gossh.Certificate) {
+				cert.CriticalOptions = map[string]string{"source-address": "10.0.0.0/8,192.168.0.1"}
+			}),
+			username: "root",
+			ip:       "192.168.0.1",
+			expected: nil,
+		},
+		{
+			description: "succeeds when the certificate is valid to the username",
+			cert:        newCertificate(ca, nil),
+			username:    "root",
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, checkCertificate(tc.cert, tc.username, tc.ip, authorities, now))
+		})
+	}
+}
+
+func TestPublicKeyAuthCertificateKeyID(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := gossh.NewSignerFromKey(key)
+	require.NoError(t, err)
+
+	cert := &gossh.Certificate{Key: signer.PublicKey(), KeyId: "user@example.com", CertType: gossh.UserCert}
+
+	assert.Equal(t, "user@example.com", (&publicKeyAuth{pk: cert}).certificateKeyID())
+	assert.Equal(t, "", (&publicKeyAuth{pk: signer.PublicKey()}).certificateKeyID())
+}
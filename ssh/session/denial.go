@@ -0,0 +1,105 @@
+package session
+
+import (
+	"errors"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// DenialReason is why a connection was denied before its authentication.
+type DenialReason string
+
+const (
+	// DenialFirewall is a connection denied by a firewall rule of the device's namespace.
+	DenialFirewall DenialReason = "firewall"
+	// DenialBilling is a connection denied due to the billing of the device's namespace.
+	DenialBilling DenialReason = "billing"
+	// DenialUnavailable is a connection denied because its policies couldn't be evaluated.
+	DenialUnavailable DenialReason = "unavailable"
+	// DenialSchedule is a connection denied, outside the business hours, by the session schedules of the device's
+	// namespace.
+	DenialSchedule DenialReason = "schedule"
+	// DenialPolicy is a connection denied by the organization's policies evaluated on the policy engine.
+	DenialPolicy DenialReason = "policy"
+	// DenialWebhook is a connection denied by the connection webhook of the device's namespace, like a
+	// change-management system without an approved ticket for it.
+	DenialWebhook DenialReason = "webhook"
+)
+
+// DefaultDenialMessages are the messages shown, on the SSH banner, to the clients whose connections were denied, when
+// the instance doesn't configure them. They tell the reason without disclosing the namespace's details.
+var DefaultDenialMessages = DenialMessages{
+	DenialFirewall:    "you cannot access the device because a firewall rule denied the connection",
+	DenialBilling:     "you cannot access the device because its namespace's plan doesn't allow the connection",
+	DenialUnavailable: "you cannot access the device because its policies couldn't be evaluated, try again later",
+	DenialSchedule:    "you cannot access the device now because its namespace restricts the connections to scheduled hours",
+	DenialPolicy:      "you cannot access the device because your organization's policies denied the connection",
+	DenialWebhook:     "you cannot access the device because its namespace's approval system denied the connection",
+}
+
+// DenialMessages are the messages shown, on the SSH banner, to everyone whose connection was denied, per reason.
+type DenialMessages map[DenialReason]string
+
+// Message returns the message for the reason, falling back to the default one when it isn't set.
+func (m DenialMessages) Message(reason DenialReason) string {
+	if message, ok := m[reason]; ok && message != "" {
+		return message
+	}
+
+	return DefaultDenialMessages[reason]
+}
+
+// Denial is a connection denied before its authentication. Its detail is only shown to the namespace's members.
+type Denial struct {
+	Reason DenialReason
+	// Detail is the detailed description of why the connection was denied.
+	Detail string
+}
+
+// NewDenial creates a [Denial] from the error returned by [Session.Evaluate].
+func NewDenial(err error) *Denial {
+	switch {
+	case errors.Is(err, ErrFirewallBlock):
+		return &Denial{Reason: DenialFirewall, Detail: err.Error()}
+	case errors.Is(err, ErrBillingBlock):
+		return &Denial{Reason: DenialBilling, Detail: err.Error()}
+	case errors.Is(err, ErrScheduleBlock):
+		return &Denial{Reason: DenialSchedule, Detail: err.Error()}
+	case errors.Is(err, ErrPolicyBlock):
+		return &Denial{Reason: DenialPolicy, Detail: err.Error()}
+	case errors.Is(err, ErrWebhookBlock):
+		return &Denial{Reason: DenialWebhook, Detail: err.Error()}
+	default:
+		return &Denial{Reason: DenialUnavailable, Detail: err.Error()}
+	}
+}
+
+// SetDenial saves, on the context, the denial of the connection.
+func SetDenial(ctx gliderssh.Context, denial *Denial) {
+	ctx.SetValue("denial", denial)
+}
+
+// ObtainDenial obtains the denial of the connection from the context. It returns nil when the connection wasn't
+// denied.
+func ObtainDenial(ctx gliderssh.Context) *Denial {
+	denial, _ := ctx.Value("denial").(*Denial)
+
+	return denial
+}
+
+// NamespaceKey reports if the public key is registered on the namespace authenticating the session, or is a certificate
+// signed by one of its certificate authorities, identifying the client as one of the namespace's members.
+func (s *Session) NamespaceKey(key gossh.PublicKey) bool {
+	if s.Device == nil {
+		return false
+	}
+
+	if cert, ok := key.(*gossh.Certificate); ok {
+		return s.evaluateCertificate(cert) == nil
+	}
+
+	_, err := s.api.GetPublicKey(gossh.FingerprintLegacyMD5(key), s.credentialsTenant())
+
+	return err == nil
+}
@@ -0,0 +1,67 @@
+package session
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDenial(t *testing.T) {
+	cases := []struct {
+		description string
+		err         error
+		expected    *Denial
+	}{
+		{
+			description: "denies by the firewall",
+			err:         ErrFirewallBlock,
+			expected:    &Denial{Reason: DenialFirewall, Detail: ErrFirewallBlock.Error()},
+		},
+		{
+			description: "denies by the billing",
+			err:         ErrBillingBlock,
+			expected:    &Denial{Reason: DenialBilling, Detail: ErrBillingBlock.Error()},
+		},
+		{
+			description: "denies by the session schedules",
+			err:         ErrScheduleBlock,
+			expected:    &Denial{Reason: DenialSchedule, Detail: ErrScheduleBlock.Error()},
+		},
+		{
+			description: "denies by the organization's policies",
+			err:         ErrPolicyBlock,
+			expected:    &Denial{Reason: DenialPolicy, Detail: ErrPolicyBlock.Error()},
+		},
+		{
+			description: "denies by the namespace's connection webhook",
+			err:         ErrWebhookBlock,
+			expected:    &Denial{Reason: DenialWebhook, Detail: ErrWebhookBlock.Error()},
+		},
+		{
+			description: "denies as unavailable when the policies couldn't be evaluated",
+			err:         ErrFirewallConnection,
+			expected:    &Denial{Reason: DenialUnavailable, Detail: ErrFirewallConnection.Error()},
+		},
+		{
+			description: "denies as unavailable on unknown errors",
+			err:         errors.New("error"),
+			expected:    &Denial{Reason: DenialUnavailable, Detail: "error"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewDenial(tc.err))
+		})
+	}
+}
+
+func TestDenialMessages(t *testing.T) {
+	messages := DenialMessages{DenialFirewall: "blocked", DenialBilling: ""}
+
+	assert.Equal(t, "blocked", messages.Message(DenialFirewall))
+	assert.Equal(t, DefaultDenialMessages[DenialBilling], messages.Message(DenialBilling))
+	assert.Equal(t, DefaultDenialMessages[DenialUnavailable], messages.Message(DenialUnavailable))
+	assert.Equal(t, DefaultDenialMessages[DenialFirewall], DenialMessages(nil).Message(DenialFirewall))
+}
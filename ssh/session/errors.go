@@ -4,15 +4,29 @@ import "fmt"
 
 // Errors returned by the NewSession to the client.
 var (
-	ErrBillingBlock            = fmt.Errorf("Connection to this device is not available as your current namespace doesn't qualify for the free plan. To gain access, you'll need to contact the namespace owner to initiate an upgrade.\n\nFor a detailed estimate of costs based on your use-cases with ShellHub Cloud, visit our pricing page at https://www.shellhub.io/pricing. If you wish to upgrade immediately, navigate to https://cloud.shellhub.io/settings/billing. Your cooperation is appreciated.") //nolint:all
-	ErrFirewallBlock           = fmt.Errorf("you cannot connect to this device because a firewall rule block your connection")
-	ErrFirewallConnection      = fmt.Errorf("failed to communicate to the firewall")
-	ErrFirewallUnknown         = fmt.Errorf("failed to evaluate the firewall rule")
-	ErrHost                    = fmt.Errorf("failed to get the device address")
-	ErrFindDevice              = fmt.Errorf("failed to find the device")
-	ErrDial                    = fmt.Errorf("failed to connect to device agent, please check the device connection")
-	ErrInvalidVersion          = fmt.Errorf("failed to parse device version")
-	ErrUnsuportedPublicKeyAuth = fmt.Errorf("connections using public keys are not permitted when the agent version is 0.5.x or earlier")
-	ErrUnexpectedAuthMethod    = fmt.Errorf("failed to authenticate the session due to a unexpected method")
-	ErrEvaluatePublicKey       = fmt.Errorf("failed to evaluate the provided public key")
+	ErrBillingBlock             = fmt.Errorf("Connection to this device is not available as your current namespace doesn't qualify for the free plan. To gain access, you'll need to contact the namespace owner to initiate an upgrade.\n\nFor a detailed estimate of costs based on your use-cases with ShellHub Cloud, visit our pricing page at https://www.shellhub.io/pricing. If you wish to upgrade immediately, navigate to https://cloud.shellhub.io/settings/billing. Your cooperation is appreciated.") //nolint:all
+	ErrFirewallBlock            = fmt.Errorf("you cannot connect to this device because a firewall rule block your connection")
+	ErrFirewallConnection       = fmt.Errorf("failed to communicate to the firewall")
+	ErrFirewallUnknown          = fmt.Errorf("failed to evaluate the firewall rule")
+	ErrScheduleBlock            = fmt.Errorf("you cannot connect to this device outside the windows of your namespace's session schedules, unless a member overrides them")
+	ErrScheduleUnknown          = fmt.Errorf("failed to evaluate the session schedules")
+	ErrPolicyBlock              = fmt.Errorf("you cannot connect to this device because your organization's policies deny the connection")
+	ErrPolicyUnknown            = fmt.Errorf("failed to evaluate the organization's policies")
+	ErrWebhookBlock             = fmt.Errorf("you cannot connect to this device because its namespace's connection webhook denied the connection")
+	ErrWebhookUnknown           = fmt.Errorf("failed to evaluate the namespace's connection webhook")
+	ErrCommandPolicyUnknown     = fmt.Errorf("failed to evaluate the namespace's command policies")
+	ErrHost                     = fmt.Errorf("failed to get the device address")
+	ErrFindDevice               = fmt.Errorf("failed to find the device")
+	ErrFindAlias                = fmt.Errorf("failed to find the alias")
+	ErrDial                     = fmt.Errorf("failed to connect to device agent, please check the device connection")
+	ErrInvalidVersion           = fmt.Errorf("failed to parse device version")
+	ErrUnsuportedPublicKeyAuth  = fmt.Errorf("connections using public keys are not permitted when the agent version is 0.5.x or earlier")
+	ErrUnexpectedAuthMethod     = fmt.Errorf("failed to authenticate the session due to a unexpected method")
+	ErrEvaluatePublicKey        = fmt.Errorf("failed to evaluate the provided public key")
+	ErrCertificateType          = fmt.Errorf("the provided certificate isn't an user certificate")
+	ErrCertificateAuthority     = fmt.Errorf("the provided certificate isn't signed by a certificate authority of the namespace")
+	ErrCertificatePrincipal     = fmt.Errorf("the provided certificate doesn't list any principal")
+	ErrEvaluateCertificate      = fmt.Errorf("failed to evaluate the provided certificate")
+	ErrCertificateSourceAddress = fmt.Errorf("the provided certificate isn't allowed from the client's address")
+	ErrSOCKS5Disabled           = fmt.Errorf("the SOCKS5 proxy is not enabled on the device")
 )
@@ -0,0 +1,223 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
+	log "github.com/sirupsen/logrus"
+)
+
+// RecordObjectContentType is the content type of the recordings kept on the object storage, which have one JSON
+// document per frame.
+const RecordObjectContentType = "application/x-ndjson"
+
+// recordObjectRequestTimeout is the maximum time spent on each request to the object storage.
+const recordObjectRequestTimeout = time.Minute
+
+// RecordObjectKey returns the key of the session's recording on the object storage.
+func RecordObjectKey(prefix, tenant, uid string) string {
+	return path.Join(prefix, tenant, uid+".jsonl")
+}
+
+// ObjectUploader uploads the session's frames to an object storage, as a single object, without blocking the
+// session's data path.
+//
+// The frames are encoded as JSON lines, like they are hashed, and uploaded in parts of [objectstorage.MinPartSize] as
+// they are produced, so only the part being filled is kept in memory. When the upload fails, it is retried until the
+// uploader expires. After the last part is uploaded, the object is assembled and its key informed through done.
+type ObjectUploader struct {
+	storage objectstorage.Storage
+	key     string
+	done    func(key string)
+
+	frames chan *models.SessionRecorded
+	// expired is closed when the uploader should give up uploading the pending frames.
+	expired   chan struct{}
+	closeOnce sync.Once
+
+	uploadID string
+	parts    []objectstorage.Part
+	// buffer is the part being filled with the encoded frames.
+	buffer bytes.Buffer
+
+	logger *log.Entry
+
+	// hashMu keeps the frames enqueued on the order they are hashed.
+	hashMu sync.Mutex
+	// hash is the SHA-256 of the frames enqueued, encoded as they are uploaded to the object storage.
+	hash hash.Hash
+}
+
+// NewObjectUploader creates a new [ObjectUploader] uploading the frames to the object storage under key. The uploader
+// is started immediately.
+func NewObjectUploader(storage objectstorage.Storage, key string, done func(key string)) *ObjectUploader {
+	u := &ObjectUploader{
+		storage: storage,
+		key:     key,
+		done:    done,
+		frames:  make(chan *models.SessionRecorded, recordFramesQueueSize),
+		expired: make(chan struct{}),
+		logger:  log.WithField("key", key),
+		hash:    sha256.New(),
+	}
+
+	go u.run()
+
+	return u
+}
+
+// WriteFrame enqueues a frame to be uploaded to the object storage. It never blocks; when the queue is full, the frame
+// is discarded.
+func (u *ObjectUploader) WriteFrame(frame *models.SessionRecorded) {
+	u.hashMu.Lock()
+	defer u.hashMu.Unlock()
+
+	select {
+	case u.frames <- frame:
+		json.NewEncoder(u.hash).Encode(frame) //nolint:errcheck
+	default:
+		u.logger.Trace("the frame couldn't be sent to the record queue")
+	}
+}
+
+// Hash returns the hex-encoded SHA-256 of the frames enqueued until now, encoded as JSON lines, which is the hash the
+// recording's object has when all of them are uploaded.
+func (u *ObjectUploader) Hash() string {
+	u.hashMu.Lock()
+	defer u.hashMu.Unlock()
+
+	return hex.EncodeToString(u.hash.Sum(nil))
+}
+
+// Close stops receiving frames. The pending ones are still uploaded in background for up to [RecordCloseTimeout].
+func (u *ObjectUploader) Close() {
+	u.closeOnce.Do(func() {
+		close(u.frames)
+
+		time.AfterFunc(RecordCloseTimeout, func() {
+			close(u.expired)
+		})
+	})
+}
+
+// run encodes the frames on the part being filled, uploading it when it is full, and completes the upload when the
+// uploader is closed.
+func (u *ObjectUploader) run() {
+	encoder := json.NewEncoder(&u.buffer)
+
+	for frame := range u.frames {
+		encoder.Encode(frame) //nolint:errcheck
+
+		if u.buffer.Len() < objectstorage.MinPartSize {
+			continue
+		}
+
+		if err := u.flush(); err != nil {
+			u.discard(err)
+
+			return
+		}
+	}
+
+	if u.buffer.Len() > 0 {
+		if err := u.flush(); err != nil {
+			u.discard(err)
+
+			return
+		}
+	}
+
+	// NOTICE: a session without frames has nothing to be kept.
+	if u.uploadID == "" {
+		return
+	}
+
+	if err := u.retry(func(ctx context.Context) error {
+		return u.storage.CompleteMultipartUpload(ctx, u.key, u.uploadID, u.parts)
+	}); err != nil {
+		u.discard(err)
+
+		return
+	}
+
+	u.done(u.key)
+}
+
+// flush uploads the part being filled, creating the upload on the first one.
+func (u *ObjectUploader) flush() error {
+	if u.uploadID == "" {
+		if err := u.retry(func(ctx context.Context) error {
+			uploadID, err := u.storage.CreateMultipartUpload(ctx, u.key, RecordObjectContentType)
+			u.uploadID = uploadID
+
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	number := len(u.parts) + 1
+
+	var part *objectstorage.Part
+	if err := u.retry(func(ctx context.Context) error {
+		var err error
+		part, err = u.storage.UploadPart(ctx, u.key, u.uploadID, number, u.buffer.Bytes())
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	u.parts = append(u.parts, *part)
+	u.buffer.Reset()
+
+	return nil
+}
+
+// retry calls fn until it succeeds, waiting for [RecordRetryInterval] between the attempts. It returns
+// [ErrUploaderExpired] when the uploader expires before.
+func (u *ObjectUploader) retry(fn func(ctx context.Context) error) error {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), recordObjectRequestTimeout)
+		err := fn(ctx)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+
+		u.logger.WithError(err).Warn("failed to upload the session's frames to the object storage. Retrying")
+
+		select {
+		case <-time.After(RecordRetryInterval):
+		case <-u.expired:
+			return ErrUploaderExpired
+		}
+	}
+}
+
+// discard aborts the upload, removing the parts already uploaded, and drains the frames still enqueued.
+func (u *ObjectUploader) discard(err error) {
+	u.logger.WithError(err).Error("the session's frames not uploaded to the object storage were lost")
+
+	if u.uploadID != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), recordObjectRequestTimeout)
+		defer cancel()
+
+		if err := u.storage.AbortMultipartUpload(ctx, u.key, u.uploadID); err != nil {
+			u.logger.WithError(err).Warn("failed to abort the upload of the session's recording")
+		}
+	}
+
+	for range u.frames { //nolint:revive
+	}
+}
@@ -0,0 +1,158 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/objectstorage"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStorage is an object storage that keeps the objects in memory, failing the first uploads of parts when asked.
+type memoryStorage struct {
+	mu      sync.Mutex
+	uploads int
+	failing int
+	parts   map[int][]byte
+	objects map[string][]byte
+	aborted bool
+}
+
+func (s *memoryStorage) CreateMultipartUpload(_ context.Context, _, _ string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.uploads++
+	s.parts = map[int][]byte{}
+
+	return "upload", nil
+}
+
+func (s *memoryStorage) UploadPart(_ context.Context, _, _ string, number int, data []byte) (*objectstorage.Part, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failing > 0 {
+		s.failing--
+
+		return nil, errors.New("object storage unavailable")
+	}
+
+	s.parts[number] = append([]byte{}, data...)
+
+	return &objectstorage.Part{Number: number, ETag: fmt.Sprintf("etag-%d", number)}, nil
+}
+
+func (s *memoryStorage) CompleteMultipartUpload(_ context.Context, key, _ string, parts []objectstorage.Part) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var object []byte
+	for _, part := range parts {
+		object = append(object, s.parts[part.Number]...)
+	}
+
+	s.objects[key] = object
+
+	return nil
+}
+
+func (s *memoryStorage) AbortMultipartUpload(_ context.Context, _, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.aborted = true
+
+	return nil
+}
+
+func (s *memoryStorage) GetObject(_ context.Context, _ string) (io.ReadCloser, error) {
+	return nil, objectstorage.ErrObjectNotFound
+}
+
+func (s *memoryStorage) object(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[key]
+
+	return object, ok
+}
+
+func TestObjectUploader(t *testing.T) {
+	cases := []struct {
+		description string
+		frames      int
+		failing     int
+	}{
+		{
+			description: "uploads the frames as a single object",
+			frames:      100,
+		},
+		{
+			description: "uploads the frames after the object storage fails",
+			frames:      100,
+			failing:     1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storage := &memoryStorage{failing: tc.failing, objects: map[string][]byte{}}
+
+			reported := make(chan string, 1)
+			uploader := NewObjectUploader(storage, "tenant/uid.jsonl", func(key string) {
+				reported <- key
+			})
+
+			for i := 0; i < tc.frames; i++ {
+				uploader.WriteFrame(&models.SessionRecorded{UID: "uid", Message: fmt.Sprintf("frame %d", i)})
+			}
+
+			uploader.Close()
+
+			select {
+			case key := <-reported:
+				assert.Equal(t, "tenant/uid.jsonl", key)
+			case <-time.After(2 * RecordRetryInterval):
+				t.Fatal("the upload wasn't completed")
+			}
+
+			object, ok := storage.object("tenant/uid.jsonl")
+			assert.True(t, ok)
+
+			sum := sha256.Sum256(object)
+			assert.Equal(t, hex.EncodeToString(sum[:]), uploader.Hash())
+		})
+	}
+
+	t.Run("keeps nothing when the session has no frames", func(t *testing.T) {
+		storage := &memoryStorage{objects: map[string][]byte{}}
+
+		uploader := NewObjectUploader(storage, "tenant/uid.jsonl", func(string) {
+			t.Error("the upload of a session without frames was completed")
+		})
+
+		uploader.Close()
+
+		time.Sleep(100 * time.Millisecond)
+
+		storage.mu.Lock()
+		defer storage.mu.Unlock()
+
+		assert.Equal(t, 0, storage.uploads)
+	})
+}
+
+func TestRecordObjectKey(t *testing.T) {
+	assert.Equal(t, "tenant/uid.jsonl", RecordObjectKey("", "tenant", "uid"))
+	assert.Equal(t, "records/tenant/uid.jsonl", RecordObjectKey("records/", "tenant", "uid"))
+}
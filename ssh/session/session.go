@@ -1,6 +1,7 @@
 package session
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -11,14 +12,19 @@ import (
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/announcement"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/commandpolicy"
+	"github.com/shellhub-io/shellhub/pkg/correlation"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/handshake"
 	"github.com/shellhub-io/shellhub/ssh/pkg/host"
+	"github.com/shellhub-io/shellhub/ssh/pkg/motd"
 	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -65,7 +71,10 @@ type Data struct {
 	// SSHID is the combination of device's name and namespace name.
 	SSHID string
 	// Device is the device connected.
-	Device    *models.Device
+	Device *models.Device
+	// Container is the name of the Docker container, running on the device, targeted by the session. It is empty when
+	// the session targets the device itself.
+	Container string
 	IPAddress string
 	// Type is the connection type.
 	Type string
@@ -106,7 +115,11 @@ type Session struct {
 func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, cache cache.Cache) (*Session, error) {
 	snap := getSnapshot(ctx)
 
-	api, err := internalclient.NewClient()
+	// NOTICE: SSH connections don't pass through the gateway, so the session's UID is used as their correlation ID,
+	// propagated to the API and to the agent.
+	ctx.SetValue(correlation.IDKey, ctx.SessionID())
+
+	api, err := internalclient.NewClient(internalclient.WithCorrelationID(ctx.SessionID()))
 	if err != nil {
 		return nil, err
 	}
@@ -126,8 +139,33 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, cache cache.Ca
 		return nil, err
 	}
 
-	var namespace, hostname string
+	if target.IsAlias() {
+		username, name, err := target.SplitAlias()
+		if err != nil {
+			return nil, err
+		}
+
+		alias, err := api.ResolveUserAlias(username, name)
+		if err != nil {
+			log.WithError(err).
+				WithFields(log.Fields{"username": username, "alias": name}).
+				Error("failed to resolve the user's alias")
+
+			return nil, ErrFindAlias
+		}
+
+		if err := target.ReplaceAlias(alias.Target); err != nil {
+			return nil, err
+		}
+	}
+
+	var namespace, hostname, container string
 	if target.IsSSHID() {
+		target.Data, container, err = target.SplitContainer()
+		if err != nil {
+			return nil, err
+		}
+
 		namespace, hostname, err = target.SplitSSHID()
 		if err != nil {
 			return nil, err
@@ -150,9 +188,8 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, cache cache.Ca
 				return nil, err
 			}
 
-			parts := strings.Split(data, ":")
-			target.Data = parts[0]
-			hos.Host = parts[1]
+			// NOTICE: the data has the form "device:ip", where the IP may be an IPv6 literal that contains colons too.
+			target.Data, hos.Host, _ = strings.Cut(data, ":")
 		}
 
 		device, err := api.GetDevice(target.Data)
@@ -182,6 +219,7 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, cache cache.Ca
 			IPAddress: hos.Host,
 			Target:    target,
 			Device:    device,
+			Container: container,
 			Lookup:    lookup,
 			SSHID:     fmt.Sprintf("%s@%s.%s", target.Username, namespace, hostname),
 		},
@@ -216,6 +254,57 @@ func (s *Session) checkFirewall() (bool, error) {
 	return true, nil
 }
 
+func (s *Session) checkSchedule() (bool, error) {
+	if err := s.api.EvaluateSessionSchedule(s.Device.TenantID, s.Device.UID); err != nil {
+		defer log.WithError(err).WithFields(log.Fields{
+			"uid":   s.UID,
+			"sshid": s.SSHID,
+		}).Info("an error or a session schedule blocked this connection")
+
+		if errors.Is(err, internalclient.ErrForbidden) {
+			return false, ErrScheduleBlock
+		}
+
+		return false, ErrScheduleUnknown
+	}
+
+	return true, nil
+}
+
+func (s *Session) checkPolicy() (bool, error) {
+	if err := s.api.EvaluateSessionPolicy(s.Device.TenantID, s.Device.UID, s.Target.Username, s.IPAddress); err != nil {
+		defer log.WithError(err).WithFields(log.Fields{
+			"uid":   s.UID,
+			"sshid": s.SSHID,
+		}).Info("an error or the organization's policies blocked this connection")
+
+		if errors.Is(err, internalclient.ErrForbidden) {
+			return false, ErrPolicyBlock
+		}
+
+		return false, ErrPolicyUnknown
+	}
+
+	return true, nil
+}
+
+func (s *Session) checkWebhook() (bool, error) {
+	if err := s.api.EvaluateSessionWebhook(s.Device.TenantID, s.Device.UID, s.Target.Username, s.IPAddress); err != nil {
+		defer log.WithError(err).WithFields(log.Fields{
+			"uid":   s.UID,
+			"sshid": s.SSHID,
+		}).Info("an error or the namespace's connection webhook blocked this connection")
+
+		if errors.Is(err, internalclient.ErrForbidden) {
+			return false, ErrWebhookBlock
+		}
+
+		return false, ErrWebhookUnknown
+	}
+
+	return true, nil
+}
+
 func (s *Session) checkBilling() (bool, error) {
 	device, err := s.api.GetDevice(s.Device.UID)
 	if err != nil {
@@ -239,8 +328,38 @@ func (s *Session) checkBilling() (bool, error) {
 	return true, nil
 }
 
+// client returns the metadata of the SSH client, like its identification string and the negotiated algorithms.
+func (s *Session) client(ctx gliderssh.Context) models.SessionClient {
+	client := models.SessionClient{
+		Version: ctx.ClientVersion(),
+	}
+
+	conn, ok := ctx.Value("conn").(*handshake.Conn)
+	if !ok {
+		return client
+	}
+
+	algorithms, err := conn.Algorithms()
+	if err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{"session": s.UID, "sshid": s.SSHID}).
+			Warn("failed to get the algorithms negotiated with the client")
+
+		return client
+	}
+
+	client.KeyExchange = algorithms.KeyExchange
+	client.HostKey = algorithms.HostKey
+	client.CipherClientServer = algorithms.CipherClientServer
+	client.CipherServerClient = algorithms.CipherServerClient
+	client.MACClientServer = algorithms.MACClientServer
+	client.MACServerClient = algorithms.MACServerClient
+
+	return client
+}
+
 // registerAPISession registers a new session on the API.
-func (s *Session) register() error {
+func (s *Session) register(ctx gliderssh.Context) error {
 	err := s.api.SessionCreate(requests.SessionCreate{
 		UID:       s.UID,
 		DeviceUID: s.Device.UID,
@@ -248,6 +367,7 @@ func (s *Session) register() error {
 		IPAddress: s.IPAddress,
 		Type:      "none",
 		Term:      "none",
+		Client:    s.client(ctx),
 	})
 	if err != nil {
 		log.WithError(err).
@@ -260,15 +380,25 @@ func (s *Session) register() error {
 	return nil
 }
 
-// Authenticate marks the session as authenticated on the API.
+// Authenticate marks the session as authenticated on the API, saving the authentication method used by the client.
 //
 // It returns an error if authentication fails.
-func (s *Session) authenticate() error {
+func (s *Session) authenticate(auth Auth) error {
 	value := true
+	name := auth.Method().String()
 
-	return s.api.UpdateSession(s.UID, &models.SessionUpdate{
+	update := &models.SessionUpdate{
 		Authenticated: &value,
-	})
+		AuthMethod:    &name,
+	}
+
+	if pk, ok := auth.(*publicKeyAuth); ok {
+		if keyID := pk.certificateKeyID(); keyID != "" {
+			update.CertificateKeyID = &keyID
+		}
+	}
+
+	return s.api.UpdateSession(s.UID, update)
 }
 
 // connect connects the session's client to the session's agent.
@@ -343,6 +473,14 @@ func (s *Session) Dial(ctx gliderssh.Context) error {
 	}
 
 	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/ssh/%s", s.UID), nil)
+	// NOTICE: The client's IP address is sent to the agent to be available as session's metadata on the device.
+	req.Header.Set("X-Real-IP", s.IPAddress)
+	// NOTICE: The correlation ID is sent to the agent to be included on its logs about the session.
+	req.Header.Set(correlation.Header, s.UID)
+	// NOTICE: The agent decides if the device's login shell is used, based on the login shells allowed on it.
+	if s.Device != nil && s.Device.LoginShell != "" {
+		req.Header.Set("X-Login-Shell", s.Device.LoginShell)
+	}
 	if err = req.Write(s.AgentConn); err != nil {
 		return err
 	}
@@ -351,9 +489,63 @@ func (s *Session) Dial(ctx gliderssh.Context) error {
 	return nil
 }
 
+// DialSOCKS5 opens a new connection to the agent, through the tunnel, serving a SOCKS5 proxy to the device's network.
+// The connection carries the SOCKS5 session from the client's first message on.
+func (s *Session) DialSOCKS5(ctx context.Context) (net.Conn, error) {
+	conn, err := s.tunnel.Dial(ctx, s.Device.TenantID+":"+s.Device.UID)
+	if err != nil {
+		return nil, errors.Join(ErrDial, err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/socks5", nil)
+	req.Header.Set(correlation.Header, s.UID)
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	// NOTICE: the agent doesn't write anything after the response until the client starts the SOCKS5 session, so
+	// nothing is lost on the reader's buffer.
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return conn, nil
+	// NOTICE: agents without the proxy enabled, or older agents without the route, don't serve it.
+	case http.StatusNotImplemented, http.StatusNotFound:
+		conn.Close()
+
+		return nil, ErrSOCKS5Disabled
+	default:
+		conn.Close()
+
+		return nil, fmt.Errorf("unexpected status from the agent: %d", resp.StatusCode)
+	}
+}
+
 func (s *Session) Evaluate(ctx gliderssh.Context) error {
 	snap := getSnapshot(ctx)
 
+	if ok, err := s.checkSchedule(); err != nil || !ok {
+		return err
+	}
+
+	if ok, err := s.checkPolicy(); err != nil || !ok {
+		return err
+	}
+
+	if ok, err := s.checkWebhook(); err != nil || !ok {
+		return err
+	}
+
 	if envs.IsCloud() || envs.IsEnterprise() {
 		if ok, err := s.checkFirewall(); err != nil || !ok {
 			return err
@@ -399,7 +591,7 @@ func (s *Session) Auth(ctx gliderssh.Context, auth Auth) error {
 			return err
 		}
 
-		if err := sess.register(); err != nil {
+		if err := sess.register(ctx); err != nil {
 			return err
 		}
 
@@ -411,7 +603,7 @@ func (s *Session) Auth(ctx gliderssh.Context, auth Auth) error {
 			return err
 		}
 
-		if err := sess.authenticate(); err != nil {
+		if err := sess.authenticate(auth); err != nil {
 			return err
 		}
 	default:
@@ -424,6 +616,18 @@ func (s *Session) Auth(ctx gliderssh.Context, auth Auth) error {
 	return nil
 }
 
+// RecordAuthFailure records, in the background, the authentication failure of the session's client, whose address is
+// banned temporarily when it fails too many times in a row.
+func (s *Session) RecordAuthFailure() {
+	go func() {
+		if err := s.api.RecordAddressFailure(context.Background(), s.IPAddress); err != nil {
+			log.WithError(err).
+				WithFields(log.Fields{"uid": s.UID, "ip": s.IPAddress}).
+				Warn("failed to record the authentication failure")
+		}
+	}()
+}
+
 func (s *Session) Record(ctx context.Context, url string) (*Camera, error) {
 	conn, err := s.api.RecordSession(ctx, s.UID, url)
 	if err != nil {
@@ -435,6 +639,36 @@ func (s *Session) Record(ctx context.Context, url string) (*Camera, error) {
 	return NewCamera(conn), nil
 }
 
+// SetRecordType informs, in the background, the kind of recording captured from the session.
+func (s *Session) SetRecordType(t models.SessionRecordType) {
+	go func() {
+		if err := s.api.UpdateSession(s.UID, &models.SessionUpdate{RecordType: &t}); err != nil {
+			log.WithError(err).
+				WithFields(log.Fields{"uid": s.UID, "record_type": t}).
+				Warn("failed to update the session's record type")
+		}
+	}()
+}
+
+// SetRecordHash informs the hash of the session's recording. Unlike [Session.SetRecordType], it is informed before
+// returning, so it reaches the server before the session is finished and attested.
+func (s *Session) SetRecordHash(hash string) {
+	if err := s.api.UpdateSession(s.UID, &models.SessionUpdate{RecordHash: &hash}); err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{"uid": s.UID}).
+			Warn("failed to update the session's record hash")
+	}
+}
+
+// SetRecordObject informs the key of the session's recording on the object storage, after its upload is completed.
+func (s *Session) SetRecordObject(key string) {
+	if err := s.api.UpdateSession(s.UID, &models.SessionUpdate{RecordObject: &key}); err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{"uid": s.UID, "key": key}).
+			Warn("failed to update the session's record object")
+	}
+}
+
 func Event[D any](sess *Session, t string, data []byte) {
 	d := new(D)
 	if err := gossh.Unmarshal(data, d); err != nil {
@@ -467,17 +701,114 @@ func (s *Session) KeepAlive() error {
 	return nil
 }
 
+// IdleKeepAlive reports if the namespace of the session's device has enabled the keep-alive requests on the idle
+// interactive sessions.
+func (s *Session) IdleKeepAlive() bool {
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 {
+		log.WithError(errs[0]).Warn("unable to retrieve the namespace's session keep-alive setting")
+
+		return false
+	}
+
+	return namespace.Settings != nil && namespace.Settings.SessionKeepAlive
+}
+
+// RecordPause reports if the namespace of the session's device allows its users to pause the recording of their
+// sessions.
+func (s *Session) RecordPause() bool {
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 {
+		log.WithError(errs[0]).Warn("unable to retrieve the namespace's session record pause setting")
+
+		return false
+	}
+
+	return namespace.Settings != nil && namespace.Settings.SessionRecordPause
+}
+
+// RecordEnabled reports if the namespace of the session's device records its sessions.
+func (s *Session) RecordEnabled() bool {
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 {
+		log.WithError(errs[0]).Warn("unable to retrieve the namespace's session record setting")
+
+		return false
+	}
+
+	return namespace.Settings != nil && namespace.Settings.SessionRecord
+}
+
+// CommandPolicies gets the command policies of the namespace applying to the session's device, compiled to be
+// evaluated against the command lines run on the session.
+func (s *Session) CommandPolicies() (*commandpolicy.Evaluator, error) {
+	policies, err := s.api.GetDeviceCommandPolicies(s.Device.TenantID, s.Device.UID)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"uid":   s.UID,
+			"sshid": s.SSHID,
+		}).Warn("failed to get the command policies of the session's device")
+
+		return nil, ErrCommandPolicyUnknown
+	}
+
+	return commandpolicy.New(policies), nil
+}
+
+// ReportCommandPolicyEvaluation reports a command line run on the session that violated a command policy, and the
+// action taken, to the namespace's log.
+func (s *Session) ReportCommandPolicyEvaluation(policy *models.CommandPolicy, command string) {
+	evaluation := &models.CommandPolicyEvaluation{
+		TenantID:   s.Device.TenantID,
+		PolicyID:   policy.ID,
+		PolicyName: policy.Name,
+		SessionUID: s.UID,
+		Username:   s.Target.Username,
+		Command:    command,
+		Action:     policy.Action,
+		Time:       clock.Now(),
+	}
+
+	go func() {
+		if err := s.api.CreateCommandPolicyEvaluation(s.Device.UID, evaluation); err != nil {
+			log.WithError(err).
+				WithFields(log.Fields{"uid": s.UID, "policy": policy.ID}).
+				Warn("failed to report the command policy's evaluation")
+		}
+	}()
+}
+
 // Announce is a custom message provided by the end user that can be printed when a new connection within the namespace
-// is established.
+// is established, rendered as a template with the session's data. It is preceded by the instance's message of the day,
+// when msg isn't nil.
 //
 // Returns the announcement or an error, if any. If no announcement is set, it returns an empty string.
-func (s *Session) Announce(client gossh.Channel) error {
+func (s *Session) Announce(client gossh.Channel, msg *motd.MOTD) error {
 	if _, err := client.Write([]byte(
 		"Connected to " + s.SSHID + " via ShellHub.\n\r",
 	)); err != nil {
 		return err
 	}
 
+	if msg != nil {
+		vars := motd.Variables{SSHID: s.SSHID}
+		if s.Target != nil {
+			vars.User = s.Target.Username
+		}
+
+		if s.Device != nil {
+			vars.Device = s.Device.Name
+			vars.Namespace = s.Device.Namespace
+		}
+
+		message, err := msg.Render(vars)
+		if err != nil {
+			log.WithError(err).Warn("unable to render the message of the day")
+		} else if _, err := client.Write([]byte(message)); err != nil {
+			return err
+		}
+	}
+
 	namespace, errs := s.api.
 		NamespaceLookup(s.Device.TenantID)
 	if len(errs) > 0 {
@@ -486,18 +817,23 @@ func (s *Session) Announce(client gossh.Channel) error {
 		return errs[0]
 	}
 
-	announcement := namespace.Settings.ConnectionAnnouncement
-
-	if announcement == "" {
+	if namespace.Settings.ConnectionAnnouncement == "" {
 		return nil
 	}
 
+	var user string
+	if s.Target != nil {
+		user = s.Target.Username
+	}
+
+	text := announcement.Render(namespace.Settings.ConnectionAnnouncement, announcement.NewData(s.Device, user, s.UID))
+
 	// Remove whitespaces and new lines at end
-	announcement = strings.TrimRightFunc(announcement, func(r rune) bool {
+	text = strings.TrimRightFunc(text, func(r rune) bool {
 		return r == ' ' || r == '\n' || r == '\t'
 	})
 
-	if _, err := client.Write([]byte(strings.ReplaceAll(announcement, "\n", "\n\r") + "\n\r")); err != nil {
+	if _, err := client.Write([]byte(strings.ReplaceAll(text, "\n", "\n\r") + "\n\r")); err != nil {
 		return err
 	}
 
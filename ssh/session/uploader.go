@@ -0,0 +1,375 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// RecordChunkSize is the maximum number of frames sent together to the record endpoint.
+	RecordChunkSize = 64
+	// RecordFlushInterval is the maximum time a frame waits for its chunk to be completed before being sent.
+	RecordFlushInterval = time.Second
+	// RecordRetryInterval is the time waited before connecting to the record endpoint again after a failure.
+	RecordRetryInterval = 5 * time.Second
+	// RecordCloseTimeout is the maximum time spent sending the pending frames after the session is closed. The frames
+	// not sent until then are lost.
+	RecordCloseTimeout = 5 * time.Minute
+)
+
+const (
+	// recordFramesQueueSize is the number of frames waiting to be grouped on chunks.
+	recordFramesQueueSize = 1024
+	// recordMemoryChunks is the number of chunks kept in memory waiting to be sent. When it is reached, the chunks
+	// are spilled to disk until the uploader catches up.
+	recordMemoryChunks = 16
+	// recordDialTimeout is the maximum time spent connecting to the record endpoint.
+	recordDialTimeout = 10 * time.Second
+)
+
+var ErrUploaderExpired = errors.New("the uploader expired before sending the pending frames")
+
+// CameraDialer connects to the record endpoint.
+type CameraDialer func(ctx context.Context) (*Camera, error)
+
+// Uploader sends the session's frames to the record endpoint in chunks, without blocking the session's data path.
+//
+// The chunks waiting to be sent are kept in memory, and spilled to disk when the record endpoint is slow or
+// unavailable. When the connection to the record endpoint fails, the uploader connects again and resumes from the
+// first frame not sent, keeping the frames' order.
+type Uploader struct {
+	dial CameraDialer
+	// dir is the directory where the chunks are spilled to.
+	dir string
+
+	frames chan *models.SessionRecorded
+	chunks chan []*models.SessionRecorded
+	// expired is closed when the uploader should give up sending the pending frames.
+	expired   chan struct{}
+	closeOnce sync.Once
+
+	mu sync.Mutex
+	// spilled are the sequence numbers of the chunks spilled to disk, in the order they must be sent.
+	spilled []int
+	seq     int
+
+	camera *Camera
+	logger *log.Entry
+
+	// hashMu keeps the frames enqueued on the order they are hashed.
+	hashMu sync.Mutex
+	// hash is the SHA-256 of the frames enqueued, encoded as they are sent to the record endpoint.
+	hash hash.Hash
+}
+
+// NewUploader creates a new [Uploader] connecting to the record endpoint through dial and spilling the chunks to dir.
+// The uploader is started immediately.
+func NewUploader(dir string, dial CameraDialer) *Uploader {
+	u := &Uploader{
+		dial:    dial,
+		dir:     dir,
+		frames:  make(chan *models.SessionRecorded, recordFramesQueueSize),
+		chunks:  make(chan []*models.SessionRecorded, recordMemoryChunks),
+		expired: make(chan struct{}),
+		spilled: []int{},
+		logger:  log.WithField("dir", dir),
+		hash:    sha256.New(),
+	}
+
+	go u.collect()
+	go u.send()
+
+	return u
+}
+
+// WriteFrame enqueues a frame to be sent to the record endpoint. It never blocks; when the queue is full, the frame is
+// discarded.
+func (u *Uploader) WriteFrame(frame *models.SessionRecorded) {
+	u.hashMu.Lock()
+	defer u.hashMu.Unlock()
+
+	select {
+	case u.frames <- frame:
+		// NOTICE: the frames are hashed as the camera writes them, one JSON document per frame.
+		json.NewEncoder(u.hash).Encode(frame) //nolint:errcheck
+	default:
+		u.logger.Trace("the frame couldn't be sent to the record queue")
+	}
+}
+
+// Hash returns the hex-encoded SHA-256 of the frames enqueued until now, encoded as JSON lines, which is the hash the
+// recording has when all of them reach the record endpoint.
+func (u *Uploader) Hash() string {
+	u.hashMu.Lock()
+	defer u.hashMu.Unlock()
+
+	return hex.EncodeToString(u.hash.Sum(nil))
+}
+
+// Close stops receiving frames. The pending ones are still sent in background for up to [RecordCloseTimeout].
+func (u *Uploader) Close() {
+	u.closeOnce.Do(func() {
+		close(u.frames)
+
+		time.AfterFunc(RecordCloseTimeout, func() {
+			close(u.expired)
+		})
+	})
+}
+
+// collect groups the frames on chunks, handing them to the sender.
+func (u *Uploader) collect() {
+	defer close(u.chunks)
+
+	ticker := time.NewTicker(RecordFlushInterval)
+	defer ticker.Stop()
+
+	chunk := make([]*models.SessionRecorded, 0, RecordChunkSize)
+
+	for {
+		select {
+		case frame, ok := <-u.frames:
+			if !ok {
+				u.handoff(chunk)
+
+				return
+			}
+
+			chunk = append(chunk, frame)
+			if len(chunk) >= RecordChunkSize {
+				u.handoff(chunk)
+				chunk = make([]*models.SessionRecorded, 0, RecordChunkSize)
+			}
+		case <-ticker.C:
+			if len(chunk) > 0 {
+				u.handoff(chunk)
+				chunk = make([]*models.SessionRecorded, 0, RecordChunkSize)
+			}
+		}
+	}
+}
+
+// handoff hands the chunk to the sender, spilling it to disk when the memory queue is full.
+func (u *Uploader) handoff(chunk []*models.SessionRecorded) {
+	if len(chunk) == 0 {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// NOTICE: While there are spilled chunks, the new ones are spilled too, as they must be sent after them.
+	if len(u.spilled) == 0 {
+		select {
+		case u.chunks <- chunk:
+			return
+		default:
+		}
+	}
+
+	if err := u.spill(chunk); err != nil {
+		u.logger.WithError(err).Error("failed to spill the session's frames to disk")
+	}
+}
+
+// spill writes the chunk to disk. It must be called with the mutex locked.
+func (u *Uploader) spill(chunk []*models.SessionRecorded) error {
+	if err := os.MkdirAll(u.dir, 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(u.chunkPath(u.seq), data, 0o600); err != nil {
+		return err
+	}
+
+	u.spilled = append(u.spilled, u.seq)
+	u.seq++
+
+	return nil
+}
+
+func (u *Uploader) chunkPath(seq int) string {
+	return filepath.Join(u.dir, fmt.Sprintf("%08d.json", seq))
+}
+
+// unspill reads the oldest chunk spilled to disk. It returns false when there is no spilled chunk.
+func (u *Uploader) unspill() (int, []*models.SessionRecorded, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if len(u.spilled) == 0 {
+		return 0, nil, false
+	}
+
+	seq := u.spilled[0]
+
+	var chunk []*models.SessionRecorded
+	data, err := os.ReadFile(u.chunkPath(seq))
+	if err == nil {
+		err = json.Unmarshal(data, &chunk)
+	}
+
+	if err != nil {
+		u.logger.WithError(err).Error("failed to read the session's frames spilled to disk")
+	}
+
+	return seq, chunk, true
+}
+
+// release removes the spilled chunk after it was sent.
+func (u *Uploader) release(seq int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	os.Remove(u.chunkPath(seq)) //nolint:errcheck
+
+	u.spilled = u.spilled[1:]
+}
+
+// send sends the chunks to the record endpoint. The chunks in memory are always older than the spilled ones.
+func (u *Uploader) send() {
+	defer u.cleanup()
+
+	for {
+		select {
+		case chunk, ok := <-u.chunks:
+			if ok {
+				if err := u.upload(chunk); err != nil {
+					return
+				}
+
+				continue
+			}
+
+			// NOTICE: The collector is done, so the spilled chunks are the last ones.
+			for {
+				seq, chunk, ok := u.unspill()
+				if !ok {
+					return
+				}
+
+				if err := u.upload(chunk); err != nil {
+					return
+				}
+
+				u.release(seq)
+			}
+		default:
+		}
+
+		if seq, chunk, ok := u.unspill(); ok {
+			if err := u.upload(chunk); err != nil {
+				return
+			}
+
+			u.release(seq)
+
+			continue
+		}
+
+		// NOTICE: There is nothing to send, so it waits for the next chunk.
+		chunk, ok := <-u.chunks
+		if !ok {
+			continue
+		}
+
+		if err := u.upload(chunk); err != nil {
+			return
+		}
+	}
+}
+
+// upload writes the chunk's frames to the record endpoint, connecting to it when needed. When the connection fails,
+// it connects again and resumes from the frame that failed. It only returns an error when the uploader expires.
+func (u *Uploader) upload(chunk []*models.SessionRecorded) error {
+	for i := 0; i < len(chunk); {
+		if u.camera == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), recordDialTimeout)
+			camera, err := u.dial(ctx)
+			cancel()
+
+			if err != nil {
+				u.logger.WithError(err).Warn("failed to connect to the record endpoint. Retrying")
+
+				if err := u.wait(); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			u.camera = camera
+		}
+
+		if err := u.camera.WriteFrame(chunk[i]); err != nil {
+			u.logger.WithError(err).Warn("failed to send the session's frame to the record endpoint. Retrying")
+
+			u.camera.conn.Close() //nolint:errcheck
+			u.camera = nil
+
+			if err := u.wait(); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		i++
+	}
+
+	return nil
+}
+
+// wait waits for [RecordRetryInterval], returning [ErrUploaderExpired] when the uploader expires before.
+func (u *Uploader) wait() error {
+	select {
+	case <-time.After(RecordRetryInterval):
+		return nil
+	case <-u.expired:
+		return ErrUploaderExpired
+	}
+}
+
+// cleanup closes the connection to the record endpoint and removes the spilled chunks, discarding the frames not sent.
+func (u *Uploader) cleanup() {
+	if u.camera != nil {
+		if err := u.camera.Close(); err != nil {
+			u.logger.WithError(err).Debug("failed to close the connection to the record endpoint")
+		}
+	}
+
+	u.mu.Lock()
+	lost := len(u.spilled)
+	u.spilled = nil
+	u.mu.Unlock()
+
+	// NOTICE: The chunks still in memory are drained to avoid blocking the collector.
+	for range u.chunks {
+		lost++
+	}
+
+	if lost > 0 {
+		u.logger.WithField("chunks", lost).Error("the session's frames not sent to the record endpoint were lost")
+	}
+
+	if err := os.RemoveAll(u.dir); err != nil {
+		u.logger.WithError(err).Warn("failed to remove the session's frames spilled to disk")
+	}
+}
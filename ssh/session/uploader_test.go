@@ -0,0 +1,137 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordServer is a record endpoint that keeps the messages of the frames received and the hash of their data.
+type recordServer struct {
+	mu       sync.Mutex
+	messages []string
+	hash     hash.Hash
+}
+
+func (s *recordServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		frame := new(models.SessionRecorded)
+		if err := json.Unmarshal(data, frame); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.messages = append(s.messages, frame.Message)
+		s.hash.Write(data)
+		s.mu.Unlock()
+	}
+}
+
+func (s *recordServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.messages...)
+}
+
+func (s *recordServer) sum() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return hex.EncodeToString(s.hash.Sum(nil))
+}
+
+func TestUploader(t *testing.T) {
+	record := &recordServer{hash: sha256.New()}
+	server := httptest.NewServer(record)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	var mu sync.Mutex
+	available := false
+	dial := func(ctx context.Context) (*Camera, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !available {
+			return nil, errors.New("record endpoint unavailable")
+		}
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil) //nolint:bodyclose
+		if err != nil {
+			return nil, err
+		}
+
+		return NewCamera(conn), nil
+	}
+
+	dir := filepath.Join(t.TempDir(), "session")
+	uploader := NewUploader(dir, dial)
+
+	expected := make([]string, 0, RecordChunkSize*(recordMemoryChunks+4))
+	for i := 0; i < cap(expected); i++ {
+		message := fmt.Sprintf("frame %d", i)
+		expected = append(expected, message)
+
+		uploader.WriteFrame(&models.SessionRecorded{UID: "uid", Message: message})
+
+		// NOTICE: Writing slower than the collector avoids discarding frames when its queue is full.
+		if i%RecordChunkSize == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// NOTICE: As the record endpoint is unavailable, the chunks exceeding the memory queue are spilled to disk.
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+
+		return err == nil && len(entries) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	available = true
+	mu.Unlock()
+
+	uploader.Close()
+
+	assert.Eventually(t, func() bool {
+		return len(record.received()) == len(expected)
+	}, 2*RecordRetryInterval, 100*time.Millisecond)
+	assert.Equal(t, expected, record.received())
+	assert.Equal(t, record.sum(), uploader.Hash())
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(dir)
+
+		return os.IsNotExist(err)
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,95 @@
+package web
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+// TermEncodingEnv is the environment variable sent to the agent with the encoding of the web terminal, as named on
+// the locales, so the agent can set the session's locale to it.
+const TermEncodingEnv = "SHELLHUB_TERM_ENCODING"
+
+// KeyboardLayoutEnv is the environment variable sent to the agent with the keyboard layout of the user's browser.
+const KeyboardLayoutEnv = "SHELLHUB_KEYBOARD_LAYOUT"
+
+// Encoding is a character encoding the web terminal can use with the device. The browser always talks UTF-8, so the
+// terminal's output is decoded from, and its input encoded to, the encoding on the bridge.
+type Encoding struct {
+	// Name is the encoding's name on the locales, like "ISO-8859-1".
+	Name string
+	// codec converts from and to the encoding. It is nil for UTF-8, which needs no conversion.
+	codec encoding.Encoding
+}
+
+// encodings are the encodings supported by the web terminal, by the name the client asks for them.
+var encodings = map[string]*Encoding{
+	"utf-8":        {Name: "UTF-8"},
+	"iso-8859-1":   {Name: "ISO-8859-1", codec: charmap.ISO8859_1},
+	"iso-8859-2":   {Name: "ISO-8859-2", codec: charmap.ISO8859_2},
+	"iso-8859-5":   {Name: "ISO-8859-5", codec: charmap.ISO8859_5},
+	"iso-8859-15":  {Name: "ISO-8859-15", codec: charmap.ISO8859_15},
+	"windows-1251": {Name: "CP1251", codec: charmap.Windows1251},
+	"windows-1252": {Name: "CP1252", codec: charmap.Windows1252},
+	"koi8-r":       {Name: "KOI8-R", codec: charmap.KOI8R},
+	"koi8-u":       {Name: "KOI8-U", codec: charmap.KOI8U},
+}
+
+// layoutRegex matches a keyboard layout, optionally with its variant, like "us" or "us(intl)".
+var layoutRegex = regexp.MustCompile(`^[a-z]{2,8}(\([a-z0-9_-]{1,32}\))?$`)
+
+// lookupEncoding gets the encoding by name, ignoring its case.
+func lookupEncoding(name string) (*Encoding, bool) {
+	enc, ok := encodings[strings.ToLower(name)]
+
+	return enc, ok
+}
+
+// Decoder wraps r to convert the terminal's output from the encoding to UTF-8.
+func (e *Encoding) Decoder(r io.Reader) io.Reader {
+	if e == nil || e.codec == nil {
+		return r
+	}
+
+	return transform.NewReader(r, e.codec.NewDecoder())
+}
+
+// Encode converts the terminal's input from UTF-8 to the encoding. The characters the encoding can't represent are
+// replaced.
+func (e *Encoding) Encode(data []byte) []byte {
+	if e == nil || e.codec == nil {
+		return data
+	}
+
+	encoded, err := encoding.ReplaceUnsupported(e.codec.NewEncoder()).Bytes(data)
+	if err != nil {
+		return data
+	}
+
+	return encoded
+}
+
+// Locale is the terminal's encoding and keyboard layout negotiated with the client. Both are optional, keeping the
+// device's defaults when unset.
+type Locale struct {
+	Encoding *Encoding
+	Layout   string
+}
+
+// envs returns the environment variables that inform the agent about the locale.
+func (l Locale) envs() map[string]string {
+	envs := make(map[string]string)
+	if l.Encoding != nil {
+		envs[TermEncodingEnv] = l.Encoding.Name
+	}
+
+	if l.Layout != "" {
+		envs[KeyboardLayoutEnv] = l.Layout
+	}
+
+	return envs
+}
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoding(t *testing.T) {
+	tests := []struct {
+		description string
+		encoding    *Encoding
+		text        string
+		encoded     []byte
+	}{
+		{
+			description: "keeps the data when the encoding is not set",
+			encoding:    nil,
+			text:        "olá",
+			encoded:     []byte("olá"),
+		},
+		{
+			description: "keeps the data when the encoding is UTF-8",
+			encoding:    encodings["utf-8"],
+			text:        "olá",
+			encoded:     []byte("olá"),
+		},
+		{
+			description: "converts from and to ISO-8859-1",
+			encoding:    encodings["iso-8859-1"],
+			text:        "olá",
+			encoded:     []byte{'o', 'l', 0xe1},
+		},
+		{
+			description: "converts from and to KOI8-R",
+			encoding:    encodings["koi8-r"],
+			text:        "да",
+			encoded:     []byte{0xc4, 0xc1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			assert.Equal(t, test.encoded, test.encoding.Encode([]byte(test.text)))
+
+			decoded, err := io.ReadAll(test.encoding.Decoder(bytes.NewReader(test.encoded)))
+			require.NoError(t, err)
+			assert.Equal(t, test.text, string(decoded))
+		})
+	}
+}
+
+func TestEncodingReplaceUnsupported(t *testing.T) {
+	assert.Equal(t, []byte("a\x1ab"), encodings["iso-8859-1"].Encode([]byte("a✓b")))
+}
@@ -14,6 +14,7 @@ var (
 	ErrGetAuth                 = fmt.Errorf("failed to get auth data from key")
 	ErrWebData                 = fmt.Errorf("failed to get the data to connect to device")
 	ErrFindDevice              = fmt.Errorf("failed to find the device")
+	ErrFindAlias               = fmt.Errorf("failed to find the alias")
 	ErrFindPublicKey           = fmt.Errorf("failed to get the public key from the server")
 	ErrEvaluatePublicKey       = fmt.Errorf("failed to evaluate the public key in the server")
 	ErrForbiddenPublicKey      = fmt.Errorf("failed to use the public key for this action")
@@ -46,14 +47,26 @@ var (
 	ErrWebSocketGetToken      = errors.New("failed to get the token from query")
 	ErrWebSocketGetDimensions = errors.New("failed to get terminal dimensions from query")
 	ErrWebSocketGetIP         = errors.New("failed to get IP from query")
+	ErrWebSocketGetLocale     = errors.New("failed to get the terminal's locale from query")
 )
 
-var ErrBridgeCredentialsNotFound = errors.New("failed to find the credentials")
+var (
+	ErrBridgeCredentialsNotFound = errors.New("failed to find the credentials")
+	ErrRedeemWebSession          = errors.New("failed to redeem the web session's token")
+)
 
 var (
 	ErrGetToken      = errors.New("token not found on request query")
 	ErrGetIP         = errors.New("ip not found on request query")
 	ErrGetDimensions = errors.New("failed to get a terminal dimension")
+	ErrGetEncoding   = errors.New("terminal encoding not supported")
+	ErrGetLayout     = errors.New("keyboard layout invalid")
+)
+
+var (
+	ErrFindNamespace  = errors.New("failed to find the namespace")
+	ErrSessionExpired = errors.New("the session has reached the maximum duration allowed by the namespace")
+	ErrSessionRevoked = errors.New("the session was terminated because the user's access was revoked")
 )
 
 var ErrCreditialsNoPassword = errors.New("this creditials does not have a password defined")
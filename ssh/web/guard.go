@@ -0,0 +1,93 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// guardRevalidationInterval is the interval between each validation of the user's access during a web session.
+const guardRevalidationInterval = 1 * time.Minute
+
+// guard watches a web session, terminating it when the namespace's maximum session duration is reached or when the
+// user who opened it is no longer allowed to access the namespace.
+type guard struct {
+	cli      internalclient.Client
+	interval time.Duration
+}
+
+func newGuard(cli internalclient.Client) *guard {
+	return &guard{
+		cli:      cli,
+		interval: guardRevalidationInterval,
+	}
+}
+
+// watch blocks until the context is done or until the session must be terminated. It returns nil when the context is
+// done, or the reason why the session must be terminated otherwise.
+//
+// The user's access is validated by the user's token or, on the sessions pre-authorized by the API, by the user's
+// membership on the namespace. When the credentials carry neither, only the namespace's maximum session duration is
+// enforced.
+func (g *guard) watch(ctx context.Context, creds *Credentials) error {
+	device, err := g.cli.GetDevice(creds.Device)
+	if err != nil {
+		return ErrFindDevice
+	}
+
+	namespace, errs := g.cli.NamespaceLookup(device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return ErrFindNamespace
+	}
+
+	// NOTICE: a nil channel blocks forever, what disables the duration limit when the namespace doesn't define one.
+	var expired <-chan time.Time
+	if namespace.Settings != nil && namespace.Settings.WebSessionMaxDuration > 0 {
+		duration := namespace.Settings.WebSessionMaxDuration
+		timer := time.NewTimer(time.Duration(duration) * time.Second)
+		defer timer.Stop()
+
+		expired = timer.C
+	}
+
+	var revalidate <-chan time.Time
+	if creds.Authorization != "" || creds.UserID != "" {
+		ticker := time.NewTicker(g.interval)
+		defer ticker.Stop()
+
+		revalidate = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-expired:
+			return ErrSessionExpired
+		case <-revalidate:
+			if !g.allowed(creds, device.TenantID) {
+				return ErrSessionRevoked
+			}
+		}
+	}
+}
+
+// allowed reports whether the user who opened the session is still allowed to access the namespace. When the access
+// couldn't be checked, the session is kept until the next validation.
+func (g *guard) allowed(creds *Credentials, tenant string) bool {
+	if creds.Authorization != "" {
+		return !errors.Is(g.cli.AuthUserToken(creds.Authorization, tenant), internalclient.ErrUnauthorized)
+	}
+
+	namespace, errs := g.cli.NamespaceLookup(tenant)
+	if len(errs) > 0 || namespace == nil {
+		return true
+	}
+
+	member, ok := namespace.FindMember(creds.UserID)
+
+	return ok && member.Status == models.MemberStatusAccepted
+}
@@ -0,0 +1,122 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardWatch(t *testing.T) {
+	cases := []struct {
+		description   string
+		creds         *Credentials
+		timeout       time.Duration
+		requiredMocks func(*mocks.Client)
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			creds:       &Credentials{Device: "device"},
+			timeout:     time.Second,
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("GetDevice", "device").Return(nil, errors.New("error")).Once()
+			},
+			expected: ErrFindDevice,
+		},
+		{
+			description: "fails when the namespace is not found",
+			creds:       &Credentials{Device: "device"},
+			timeout:     time.Second,
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("GetDevice", "device").Return(&models.Device{UID: "device", TenantID: "tenant"}, nil).Once()
+				cli.On("NamespaceLookup", "tenant").Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: ErrFindNamespace,
+		},
+		{
+			description: "succeeds when the context is done before any limit",
+			creds:       &Credentials{Device: "device"},
+			timeout:     100 * time.Millisecond,
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("GetDevice", "device").Return(&models.Device{UID: "device", TenantID: "tenant"}, nil).Once()
+				cli.On("NamespaceLookup", "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the session reaches the maximum duration",
+			creds:       &Credentials{Device: "device"},
+			timeout:     5 * time.Second,
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("GetDevice", "device").Return(&models.Device{UID: "device", TenantID: "tenant"}, nil).Once()
+				cli.On("NamespaceLookup", "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Settings: &models.NamespaceSettings{WebSessionMaxDuration: 1},
+				}, nil).Once()
+			},
+			expected: ErrSessionExpired,
+		},
+		{
+			description: "fails when the user's token is no longer valid",
+			creds:       &Credentials{Device: "device", Authorization: "Bearer token"},
+			timeout:     5 * time.Second,
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("GetDevice", "device").Return(&models.Device{UID: "device", TenantID: "tenant"}, nil).Once()
+				cli.On("NamespaceLookup", "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				cli.On("AuthUserToken", "Bearer token", "tenant").Return(nil).Once()
+				cli.On("AuthUserToken", "Bearer token", "tenant").Return(internalclient.ErrUnauthorized).Once()
+			},
+			expected: ErrSessionRevoked,
+		},
+		{
+			description: "fails when the user of a pre-authorized session is no longer a member of the namespace",
+			creds:       &Credentials{Device: "device", UserID: "user"},
+			timeout:     5 * time.Second,
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("GetDevice", "device").Return(&models.Device{UID: "device", TenantID: "tenant"}, nil).Once()
+				cli.On("NamespaceLookup", "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Members:  []models.Member{{ID: "user", Status: models.MemberStatusAccepted}},
+				}, nil).Twice()
+				cli.On("NamespaceLookup", "tenant").Return(nil, []error{errors.New("error")}).Once()
+				cli.On("NamespaceLookup", "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+			},
+			expected: ErrSessionRevoked,
+		},
+		{
+			description: "fails when the user of a pre-authorized session has a pending membership",
+			creds:       &Credentials{Device: "device", UserID: "user"},
+			timeout:     5 * time.Second,
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("GetDevice", "device").Return(&models.Device{UID: "device", TenantID: "tenant"}, nil).Once()
+				cli.On("NamespaceLookup", "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				cli.On("NamespaceLookup", "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Members:  []models.Member{{ID: "user", Status: models.MemberStatusPending}},
+				}, nil).Once()
+			},
+			expected: ErrSessionRevoked,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cli := new(mocks.Client)
+			tc.requiredMocks(cli)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			g := &guard{cli: cli, interval: 10 * time.Millisecond}
+			assert.Equal(t, tc.expected, g.watch(ctx, tc.creds))
+
+			cli.AssertExpectations(t)
+		})
+	}
+}
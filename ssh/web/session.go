@@ -35,6 +35,18 @@ func (b *BannerError) Error() string {
 
 // getAuth gets the authentication methods from credentials.
 func getAuth(creds *Credentials, magicKey *rsa.PrivateKey) ([]ssh.AuthMethod, error) {
+	// NOTICE: a pre-authorized session was already authorized by the API, so it connects with the magic key, trusted by
+	// the SSH server, without evaluating the user's public key. The API only mints its token for the roles that can
+	// already register a public key allowing any username on any device.
+	if creds.preauthorized {
+		signer, err := ssh.NewSignerFromKey(magicKey)
+		if err != nil {
+			return nil, ErrSignerPublicKey
+		}
+
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+	}
+
 	if creds.isPassword() {
 		return []ssh.AuthMethod{ssh.Password(creds.Password)}, nil
 	}
@@ -91,19 +103,31 @@ func getAuth(creds *Credentials, magicKey *rsa.PrivateKey) ([]ssh.AuthMethod, er
 	return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
 }
 
-func newSession(ctx context.Context, cache cache.Cache, conn *Conn, creds *Credentials, dim Dimensions, info Info) error {
+func newSession(ctx context.Context, cache cache.Cache, conn *Conn, creds *Credentials, dim Dimensions, info Info, locale Locale) error {
 	logger := log.WithFields(log.Fields{
 		"user":   creds.Username,
 		"device": creds.Device,
 		"cols":   dim.Cols,
 		"rows":   dim.Rows,
 		"ip":     info.IP,
+		"layout": locale.Layout,
 	})
 
 	logger.Info("handling web client request started")
 
 	defer logger.Info("handling web client request end")
 
+	cli, err := internalclient.NewClient()
+	if err != nil {
+		return err
+	}
+
+	if err := creds.resolveAlias(cli); err != nil {
+		logger.WithError(err).Debug("failed to resolve the user's alias")
+
+		return err
+	}
+
 	uuid := uuid.Generate()
 
 	user := fmt.Sprintf("%s@%s", creds.Username, uuid)
@@ -179,6 +203,14 @@ func newSession(ctx context.Context, cache cache.Cache, conn *Conn, creds *Crede
 		return err
 	}
 
+	// NOTICE: the locale is only a hint to the agent, which may be too old to use it, so a refused variable doesn't
+	// prevent the session from starting.
+	for name, value := range locale.envs() {
+		if err := agent.Setenv(name, value); err != nil {
+			logger.WithError(err).WithField("env", name).Debug("failed to set the locale's environment variable")
+		}
+	}
+
 	if err := agent.RequestPty("xterm", dim.Rows, dim.Cols, ssh.TerminalModes{
 		ssh.ECHO:          1,
 		ssh.TTY_OP_ISPEED: 14400,
@@ -215,7 +247,7 @@ func newSession(ctx context.Context, cache cache.Cache, conn *Conn, creds *Crede
 			case messageKindInput:
 				buffer := message.Data.([]byte)
 
-				if _, err := stdin.Write(buffer); err != nil {
+				if _, err := stdin.Write(locale.Encoding.Encode(buffer)); err != nil {
 					logger.WithError(err).Error("failed to write the message data on the SSH session")
 
 					return
@@ -232,13 +264,39 @@ func newSession(ctx context.Context, cache cache.Cache, conn *Conn, creds *Crede
 		}
 	}()
 
-	go redirToWs(stdout, conn) // nolint:errcheck
-	go io.Copy(conn, stderr)   //nolint:errcheck
+	go redirToWs(locale.Encoding.Decoder(stdout), conn) // nolint:errcheck
+	go io.Copy(conn, locale.Encoding.Decoder(stderr))   //nolint:errcheck
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	terminated := make(chan error, 1)
+	go func() {
+		cli, err := internalclient.NewClient()
+		if err != nil {
+			logger.WithError(err).Error("failed to create the internal client to guard the session")
+
+			return
+		}
+
+		if err := newGuard(cli).watch(ctx, creds); err != nil {
+			logger.WithError(err).Info("terminating the web session")
+
+			terminated <- err
+			connection.Close()
+		}
+	}()
 
 	if err := agent.Wait(); err != nil {
 		logger.WithError(err).Warning("client remote command returned a error")
 	}
 
+	select {
+	case err := <-terminated:
+		return err
+	default:
+	}
+
 	return nil
 }
 
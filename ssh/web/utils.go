@@ -6,10 +6,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 type Credentials struct {
-	// Device is the device what the session is open.
+	// Device is the device what the session is open. It may also be an alias defined by the user, like
+	// "username/alias", that is resolved to the device's UID before the session starts.
 	Device string `json:"device"`
 	// Username is the username in the device's OS.
 	Username string `json:"username"`
@@ -18,6 +23,31 @@ type Credentials struct {
 	// Fingerprint is the identifier of the public key used in the device's OS.
 	Fingerprint string `json:"fingerprint"`
 	Signature   string `json:"signature"`
+	// Authorization is the bearer token of the user who requested the session. It is used to check, during the
+	// session, if the user is still allowed to access the namespace.
+	Authorization string `json:"-"`
+	// UserID is the ID of the user who requested a web session pre-authorized by the API. As these sessions don't
+	// carry the user's token, it is used to check, during the session, if the user is still a member of the namespace.
+	UserID string `json:"-"`
+	// preauthorized indicates the credentials come from a web session pre-authorized by the API, what opens the
+	// session without checking the device's password or the user's public key.
+	preauthorized bool
+}
+
+// redeemWebSession redeems the token of a web session pre-authorized by the API into the credentials of the session.
+// As the token is single-use, it can't open another session.
+func redeemWebSession(cli internalclient.Client, token string) (*Credentials, error) {
+	session, err := cli.RedeemWebSession(token)
+	if err != nil {
+		return nil, ErrRedeemWebSession
+	}
+
+	return &Credentials{
+		Device:        session.DeviceUID,
+		Username:      session.Username,
+		UserID:        session.UserID,
+		preauthorized: true,
+	}, nil
 }
 
 func (c *Credentials) encryptPassword(key *rsa.PrivateKey) error {
@@ -55,6 +85,32 @@ func (c *Credentials) decryptPassword(key *rsa.PrivateKey) error {
 	return nil
 }
 
+// resolveAlias replaces the credentials' device, when it is a user's alias, by the UID of the device targeted by the
+// alias. As the web terminal always connects to the device itself, the container targeted by the alias is ignored.
+func (c *Credentials) resolveAlias(cli internalclient.Client) error {
+	username, name, ok := models.SplitUserAlias(c.Device)
+	if !ok {
+		return nil
+	}
+
+	alias, err := cli.ResolveUserAlias(username, name)
+	if err != nil {
+		return ErrFindAlias
+	}
+
+	sshid, _, _ := strings.Cut(alias.Target, "+")
+	namespace, hostname, _ := strings.Cut(sshid, ".")
+
+	device, errs := cli.DeviceLookup(map[string]string{"domain": namespace, "name": hostname})
+	if len(errs) > 0 || device == nil {
+		return ErrFindDevice
+	}
+
+	c.Device = device.UID
+
+	return nil
+}
+
 func (c *Credentials) isPublicKey() bool { // nolint: unused
 	return c.Fingerprint != "" && c.Signature != ""
 }
@@ -0,0 +1,120 @@
+package web
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialsResolveAlias(t *testing.T) {
+	type Expected struct {
+		device string
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		creds         *Credentials
+		requiredMocks func(*mocks.Client)
+		expected      Expected
+	}{
+		{
+			description:   "succeeds when the device is not an alias",
+			creds:         &Credentials{Device: "device"},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{"device", nil},
+		},
+		{
+			description: "fails when the alias is not found",
+			creds:       &Credentials{Device: "john_doe/web1"},
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("ResolveUserAlias", "john_doe", "web1").Return(nil, internalclient.ErrNotFound).Once()
+			},
+			expected: Expected{"john_doe/web1", ErrFindAlias},
+		},
+		{
+			description: "fails when the device targeted by the alias is not found",
+			creds:       &Credentials{Device: "john_doe/web1"},
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("ResolveUserAlias", "john_doe", "web1").
+					Return(&models.UserAlias{Name: "web1", Target: "namespace.device"}, nil).
+					Once()
+				cli.On("DeviceLookup", map[string]string{"domain": "namespace", "name": "device"}).
+					Return(nil, []error{errors.New("error")}).
+					Once()
+			},
+			expected: Expected{"john_doe/web1", ErrFindDevice},
+		},
+		{
+			description: "succeeds when the alias targets a container",
+			creds:       &Credentials{Device: "john_doe/web1"},
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("ResolveUserAlias", "john_doe", "web1").
+					Return(&models.UserAlias{Name: "web1", Target: "namespace.device+nginx"}, nil).
+					Once()
+				cli.On("DeviceLookup", map[string]string{"domain": "namespace", "name": "device"}).
+					Return(&models.Device{UID: "uid"}, nil).
+					Once()
+			},
+			expected: Expected{"uid", nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cli := new(mocks.Client)
+			tc.requiredMocks(cli)
+
+			err := tc.creds.resolveAlias(cli)
+			assert.Equal(t, tc.expected, Expected{tc.creds.Device, err})
+
+			cli.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRedeemWebSession(t *testing.T) {
+	type Expected struct {
+		creds *Credentials
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(*mocks.Client)
+		expected      Expected
+	}{
+		{
+			description: "fails when the token cannot be redeemed",
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("RedeemWebSession", "token").Return(nil, internalclient.ErrUnauthorized).Once()
+			},
+			expected: Expected{nil, ErrRedeemWebSession},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func(cli *mocks.Client) {
+				cli.On("RedeemWebSession", "token").
+					Return(&models.WebSession{DeviceUID: "uid", UserID: "user", Username: "root"}, nil).
+					Once()
+			},
+			expected: Expected{&Credentials{Device: "uid", Username: "root", UserID: "user", preauthorized: true}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cli := new(mocks.Client)
+			tc.requiredMocks(cli)
+
+			creds, err := redeemWebSession(cli, "token")
+			assert.Equal(t, tc.expected, Expected{creds, err})
+
+			cli.AssertExpectations(t)
+		})
+	}
+}
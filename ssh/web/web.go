@@ -2,10 +2,12 @@ package web
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
 	"github.com/shellhub-io/shellhub/ssh/web/pkg/token"
@@ -46,6 +48,8 @@ func NewSSHServerBridge(router *echo.Echo, cache cache.Cache) {
 				return
 			}
 
+			request.Authorization = req.Header.Get("Authorization")
+
 			key := magickey.GetRerefence()
 
 			token, err := token.NewToken(key)
@@ -72,30 +76,59 @@ func NewSSHServerBridge(router *echo.Echo, cache cache.Cache) {
 			wsconn.Write([]byte(err.Error())) //nolint:errcheck
 		}
 
-		token, err := getToken(wsconn.Request())
+		cols, rows, err := getDimensions(wsconn.Request())
 		if err != nil {
-			exit(wsconn, ErrWebSocketGetToken)
+			exit(wsconn, ErrWebSocketGetDimensions)
 
 			return
 		}
 
-		cols, rows, err := getDimensions(wsconn.Request())
+		ip, err := getIP(wsconn.Request())
 		if err != nil {
-			exit(wsconn, ErrWebSocketGetDimensions)
+			exit(wsconn, ErrWebSocketGetIP)
 
 			return
 		}
 
-		ip, err := getIP(wsconn.Request())
+		locale, err := getLocale(wsconn.Request())
 		if err != nil {
-			exit(wsconn, ErrWebSocketGetIP)
+			exit(wsconn, errors.Join(ErrWebSocketGetLocale, err))
 
 			return
 		}
 
-		creds, ok := manager.get(token)
-		if !ok {
-			exit(wsconn, ErrBridgeCredentialsNotFound)
+		var creds *Credentials
+		// NOTICE: a web session pre-authorized by the API, like the ones embedded in external portals, carries its own
+		// token instead of the credentials saved by the POST route.
+		if session := wsconn.Request().URL.Query().Get("web-session"); session != "" {
+			cli, err := internalclient.NewClient()
+			if err != nil {
+				exit(wsconn, err)
+
+				return
+			}
+
+			if creds, err = redeemWebSession(cli, session); err != nil {
+				exit(wsconn, err)
+
+				return
+			}
+		} else {
+			token, err := getToken(wsconn.Request())
+			if err != nil {
+				exit(wsconn, ErrWebSocketGetToken)
+
+				return
+			}
+
+			var ok bool
+			if creds, ok = manager.get(token); !ok {
+				exit(wsconn, ErrBridgeCredentialsNotFound)
+
+				return
+			}
+
+			creds.decryptPassword(magickey.GetRerefence()) //nolint:errcheck
 		}
 
 		conn := NewConn(wsconn)
@@ -103,8 +136,6 @@ func NewSSHServerBridge(router *echo.Echo, cache cache.Cache) {
 
 		go conn.KeepAlive()
 
-		creds.decryptPassword(magickey.GetRerefence()) //nolint:errcheck
-
 		if err := newSession(
 			wsconn.Request().Context(),
 			cache,
@@ -112,6 +143,7 @@ func NewSSHServerBridge(router *echo.Echo, cache cache.Cache) {
 			creds,
 			Dimensions{cols, rows},
 			Info{IP: ip},
+			locale,
 		); err != nil {
 			exit(wsconn, err)
 
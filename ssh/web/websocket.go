@@ -48,3 +48,28 @@ func getIP(req *http.Request) (string, error) {
 
 	return ip, nil
 }
+
+// getLocale gets the terminal's encoding and keyboard layout from the "encoding" and "layout" query params. They are
+// optional, but when set, they must be supported.
+func getLocale(req *http.Request) (Locale, error) {
+	var locale Locale
+
+	if name := req.URL.Query().Get("encoding"); name != "" {
+		enc, ok := lookupEncoding(name)
+		if !ok {
+			return Locale{}, ErrGetEncoding
+		}
+
+		locale.Encoding = enc
+	}
+
+	if layout := req.URL.Query().Get("layout"); layout != "" {
+		if !layoutRegex.MatchString(layout) {
+			return Locale{}, ErrGetLayout
+		}
+
+		locale.Layout = layout
+	}
+
+	return locale, nil
+}
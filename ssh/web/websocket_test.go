@@ -221,3 +221,60 @@ func TestGetIP(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLocale(t *testing.T) {
+	type Expected struct {
+		locale Locale
+		err    error
+	}
+
+	tests := []struct {
+		description string
+		uri         string
+		expected    Expected
+	}{
+		{
+			description: "success to keep the device's locale when not set",
+			uri:         "http://localhost",
+			expected: Expected{
+				locale: Locale{},
+				err:    nil,
+			},
+		},
+		{
+			description: "fail when the encoding is not supported",
+			uri:         "http://localhost?encoding=ebcdic",
+			expected: Expected{
+				locale: Locale{},
+				err:    ErrGetEncoding,
+			},
+		},
+		{
+			description: "fail when the layout is invalid",
+			uri:         "http://localhost?layout=us%3Brm",
+			expected: Expected{
+				locale: Locale{},
+				err:    ErrGetLayout,
+			},
+		},
+		{
+			description: "success to get the encoding and the layout from query",
+			uri:         "http://localhost?encoding=ISO-8859-1&layout=us(intl)",
+			expected: Expected{
+				locale: Locale{Encoding: encodings["iso-8859-1"], Layout: "us(intl)"},
+				err:    nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			req, _ := http.NewRequest("", test.uri, nil)
+
+			locale, err := getLocale(req)
+
+			assert.Equal(t, test.expected.locale, locale)
+			assert.ErrorIs(t, err, test.expected.err)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+	tc "github.com/testcontainers/testcontainers-go"
+)
+
+var (
+	// AgentUsername is the username of the user created on the agent's container.
+	AgentUsername = "root"
+	// AgentPassword is the password of the user created on the agent's container.
+	AgentPassword = "password"
+)
+
+// AgentOption is a function that changes the environment variables passed to an agent's container.
+type AgentOption func(envs map[string]string)
+
+// AgentWithIdentity sets the agent's preferred identity, used instead of the host's MAC address.
+func AgentWithIdentity(identity string) AgentOption {
+	return func(envs map[string]string) {
+		envs["SHELLHUB_PREFERRED_IDENTITY"] = identity
+	}
+}
+
+// AgentWithTenantID sets the tenant of the namespace the agent registers the device on.
+func AgentWithTenantID(tenantID string) AgentOption {
+	return func(envs map[string]string) {
+		envs["SHELLHUB_TENANT_ID"] = tenantID
+	}
+}
+
+// AgentWithEnv sets an environment variable with the specified key and value on the agent's container.
+func AgentWithEnv(key, val string) AgentOption {
+	return func(envs map[string]string) {
+		envs[key] = val
+	}
+}
+
+// NewAgent starts an agent's container connected to the instance, terminating it when the test finishes.
+//
+// It is not intended to be a test of the agent, but it makes some assertions to guarantee that the following
+// instructions will not fail, calling assert.FailNow if any do.
+func (dc *DockerCompose) NewAgent(ctx context.Context, opts ...AgentOption) tc.Container {
+	container, err := dc.newAgent(ctx, opts...)
+	if !assert.NoError(dc.t, err) {
+		assert.FailNow(dc.t, err.Error())
+	}
+
+	return container
+}
+
+// NewAgents starts n agents' containers at once, terminating them when the test finishes. As all of them share the
+// host's network, and so its MAC address, each agent is started with the preferred identity "agent-{i}" to register
+// a different device.
+//
+// It is not intended to be a test of the agent, but it makes some assertions to guarantee that the following
+// instructions will not fail, calling assert.FailNow if any do.
+func (dc *DockerCompose) NewAgents(ctx context.Context, n int, opts ...AgentOption) []tc.Container {
+	containers := make([]tc.Container, n)
+	errs := make([]error, n)
+
+	wg := new(sync.WaitGroup)
+	for i := range containers {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			// NOTICE: the identity is set after the options to avoid every agent using the same one.
+			options := append(append([]AgentOption{}, opts...), AgentWithIdentity(fmt.Sprintf("agent-%d", i)))
+
+			containers[i], errs[i] = dc.newAgent(ctx, options...)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if !assert.NoError(dc.t, err) {
+			assert.FailNow(dc.t, err.Error())
+		}
+	}
+
+	return containers
+}
+
+func (dc *DockerCompose) newAgent(ctx context.Context, opts ...AgentOption) (tc.Container, error) {
+	envs := map[string]string{
+		"SHELLHUB_SERVER_ADDRESS":     dc.client.BaseURL,
+		"SHELLHUB_TENANT_ID":          "00000000-0000-4000-0000-000000000000",
+		"SHELLHUB_PRIVATE_KEY":        "/tmp/shellhub.key",
+		"SHELLHUB_LOG_FORMAT":         "json",
+		"SHELLHUB_KEEPALIVE_INTERVAL": "1",
+		"SHELLHUB_LOG_LEVEL":          "trace",
+	}
+
+	for _, opt := range opts {
+		opt(envs)
+	}
+
+	container, err := tc.GenericContainer(ctx, tc.GenericContainerRequest{
+		ContainerRequest: tc.ContainerRequest{
+			Env:         envs,
+			NetworkMode: "host",
+			FromDockerfile: tc.FromDockerfile{
+				Context:       "..",
+				Dockerfile:    "agent/Dockerfile.test",
+				PrintBuildLog: false,
+				KeepImage:     true,
+				BuildArgs: map[string]*string{
+					"USERNAME": &AgentUsername,
+					"PASSWORD": &AgentPassword,
+				},
+			},
+		},
+		Started: true,
+		Logger:  log.New(io.Discard, "", log.LstdFlags),
+	})
+	if container != nil {
+		dc.t.Cleanup(func() {
+			assert.NoError(dc.t, container.Terminate(context.Background()))
+		})
+	}
+
+	return container, err
+}
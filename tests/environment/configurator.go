@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"log"
+	"net"
 	"sync"
 	"testing"
 
@@ -16,9 +17,10 @@ import (
 )
 
 type DockerComposeConfigurator struct {
-	envs map[string]string
-	t    *testing.T
-	mu   *sync.Mutex
+	envs     map[string]string
+	fixtures Fixtures
+	t        *testing.T
+	mu       *sync.Mutex
 }
 
 // New creates a new [DockerComposeConfigurator]. By default, it reads from the .env file, but
@@ -58,6 +60,22 @@ func (dcc *DockerComposeConfigurator) WithEnvs(envs map[string]string) *DockerCo
 	return dcc
 }
 
+// WithFixtures sets the users, namespaces and devices seeded on the instance when it is up.
+func (dcc *DockerComposeConfigurator) WithFixtures(fixtures Fixtures) *DockerComposeConfigurator {
+	dcc.fixtures = fixtures
+
+	return dcc
+}
+
+// WithIPv6 enables IPv6 on the ShellHub's network, publishing the gateway on the IPv6 loopback address. The
+// [DockerCompose] client will reach the instance through "http://[::1]:{SHELLHUB_HTTP_PORT}".
+func (dcc *DockerComposeConfigurator) WithIPv6() *DockerComposeConfigurator {
+	dcc.envs["SHELLHUB_NETWORK_IPV6"] = "true"
+	dcc.envs["SHELLHUB_BIND_ADDRESS"] = "[::1]"
+
+	return dcc
+}
+
 // Clone clones a [DockerComposeConfigurator] instance, automatically assigning random ports
 // and network to available services. The new instance will use the provided testing.T.
 //
@@ -65,8 +83,10 @@ func (dcc *DockerComposeConfigurator) WithEnvs(envs map[string]string) *DockerCo
 // arises.
 func (dcc *DockerComposeConfigurator) Clone(t *testing.T) *DockerComposeConfigurator {
 	clonedEnv := &DockerComposeConfigurator{
-		envs: make(map[string]string),
-		t:    t,
+		envs:     make(map[string]string),
+		fixtures: dcc.fixtures,
+		t:        t,
+		mu:       dcc.mu,
 	}
 
 	for k, v := range dcc.envs {
@@ -83,16 +103,24 @@ func (dcc *DockerComposeConfigurator) Clone(t *testing.T) *DockerComposeConfigur
 }
 
 // Up initiates the ShellHub instance, blocking until all services are in the running or
-// healthy state.
+// healthy state, and seeds the fixtures set by [DockerComposeConfigurator.WithFixtures].
+//
+// Each instance is an isolated Docker Compose project, with its own ports, network and
+// volumes, so tests calling [testing.T.Parallel] can run their own instances at once.
 //
 // It returns a [DockerCompose], which is a ShellHub Docker environment, calling
 // [assert.FailNow] if an error arises.
 func (dcc *DockerComposeConfigurator) Up(ctx context.Context) *DockerCompose {
+	host := "localhost"
+	if dcc.envs["SHELLHUB_NETWORK_IPV6"] == "true" {
+		host = "::1"
+	}
+
 	dc := &DockerCompose{
 		envs:     dcc.envs,
 		services: make(map[Service]*tc.DockerContainer),
 		t:        dcc.t,
-		client:   resty.New().SetBaseURL("http://localhost:" + dcc.envs["SHELLHUB_HTTP_PORT"]),
+		client:   resty.New().SetBaseURL("http://" + net.JoinHostPort(host, dcc.envs["SHELLHUB_HTTP_PORT"])),
 		down:     nil,
 	}
 
@@ -117,7 +145,7 @@ func (dcc *DockerComposeConfigurator) Up(ctx context.Context) *DockerCompose {
 		}
 	}
 
-	services := []Service{ServiceGateway, ServiceAPI, ServiceSSH, ServiceUI}
+	services := []Service{ServiceGateway, ServiceAPI, ServiceSSH, ServiceUI, ServiceMongo}
 	// TODO: Perhaps we could devise a strategy to wait for specific services instead
 	// of blocking until all are running|healthy?
 	if !assert.NoError(dc.t, tcDc.WithEnv(dcc.envs).Up(ctx, compose.Wait(true))) {
@@ -133,5 +161,7 @@ func (dcc *DockerComposeConfigurator) Up(ctx context.Context) *DockerCompose {
 		dc.services[service] = composeService
 	}
 
+	dc.Seed(ctx, dcc.fixtures)
+
 	return dc
 }
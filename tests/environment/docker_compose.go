@@ -2,6 +2,7 @@ package environment
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 	tc "github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 type DockerCompose struct {
@@ -20,7 +22,8 @@ type DockerCompose struct {
 	// services is a list of running services such as API and CLI.
 	services map[Service]*tc.DockerContainer
 
-	// client is a HTTP client with "http://localhost:{SHELLHUB_HTTP_PORT}" as the base URL.
+	// client is a HTTP client with "http://localhost:{SHELLHUB_HTTP_PORT}" as the base URL, or
+	// "http://[::1]:{SHELLHUB_HTTP_PORT}" when IPv6 is enabled.
 	client *resty.Client
 
 	// envs is a map containing all environment variables passed to the services.
@@ -61,11 +64,14 @@ func (dc *DockerCompose) Service(service Service) *tc.DockerContainer {
 	return dc.services[service]
 }
 
+// runCLICommand runs the CLI with the specified commands, blocking until it exits. It returns an error when the CLI
+// exits with a non-zero code.
 func (dc *DockerCompose) runCLICommand(ctx context.Context, cmds []string) error {
 	container, err := tc.GenericContainer(ctx, tc.GenericContainerRequest{
 		ContainerRequest: tc.ContainerRequest{
-			Cmd:      cmds,
-			Networks: []string{dc.envs["SHELLHUB_NETWORK"]},
+			Cmd:        cmds,
+			Networks:   []string{dc.envs["SHELLHUB_NETWORK"]},
+			WaitingFor: wait.ForExit(),
 			FromDockerfile: tc.FromDockerfile{
 				Context:       "..",
 				Dockerfile:    "cli/Dockerfile.test",
@@ -79,7 +85,22 @@ func (dc *DockerCompose) runCLICommand(ctx context.Context, cmds []string) error
 		return err
 	}
 
-	return container.Start(ctx)
+	defer container.Terminate(context.Background()) //nolint:errcheck
+
+	if err := container.Start(ctx); err != nil {
+		return err
+	}
+
+	state, err := container.State(ctx)
+	if err != nil {
+		return err
+	}
+
+	if state.ExitCode != 0 {
+		return fmt.Errorf("the command %v exited with code %d", cmds, state.ExitCode)
+	}
+
+	return nil
 }
 
 // NewUser creates a new user with the specified values. It is an abstraction around the "user create" method
@@ -52,6 +52,29 @@
 //	    // Do something ...
 //	}
 //
+// Fixtures can be seeded as soon as the instance is up with [DockerComposeConfigurator.WithFixtures].
+// Users and namespaces are created through the CLI, while devices are inserted directly on the
+// database, as they would need an agent otherwise. When a device must be reachable, use
+// [DockerCompose.NewAgents] to start many agents at once:
+//
+//	func TestSomething(t *testing.T) {
+//	    t.Parallel()
+//
+//	    ctx := context.Background()
+//	    dockerCompose := environment.New(t).WithFixtures(environment.Fixtures{
+//	        Users:      []environment.FixtureUser{{Username: "john_doe", Email: "john.doe@test.com", Password: "secret"}},
+//	        Namespaces: []environment.FixtureNamespace{{Name: "dev", Owner: "john_doe", TenantID: "00000000-0000-4000-0000-000000000000"}},
+//	        Devices:    []environment.FixtureDevice{{Name: "device", TenantID: "00000000-0000-4000-0000-000000000000", MAC: "ff:ff:ff:ff:ff:ff"}},
+//	    }).Up(ctx)
+//	    t.Cleanup(dockerCompose.Down)
+//
+//	    agents := dockerCompose.NewAgents(ctx, 3) // Three more pending devices
+//	    // Do something ...
+//	}
+//
+// Every instance is an isolated Docker Compose project, so tests calling [testing.T.Parallel]
+// run their own instances at once.
+//
 // You can also use [DockerCompose.Service] and [DockerCompose.Env] to retrieve running
 // docker-compose services and environment variable values. [DockerCompose.R] can be used to
 // make internal HTTP requests. Refer to the [docker_compose] file for more methods.
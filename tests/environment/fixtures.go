@@ -0,0 +1,131 @@
+package environment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fixtures are the users, namespaces and devices seeded on the instance as soon as it is up. Users are created
+// before namespaces, and namespaces before devices, so a fixture can reference the ones defined before it.
+type Fixtures struct {
+	Users      []FixtureUser
+	Namespaces []FixtureNamespace
+	Devices    []FixtureDevice
+}
+
+type FixtureUser struct {
+	Username string
+	Email    string
+	Password string
+}
+
+// FixtureMember is a user added to a namespace with the specified role.
+type FixtureMember struct {
+	Username string
+	Role     string
+}
+
+type FixtureNamespace struct {
+	Name     string
+	Owner    string
+	TenantID string
+	Members  []FixtureMember
+}
+
+// FixtureDevice is a device inserted directly on the database, without an agent running for it. It is useful to
+// test the API with many devices, but it cannot be connected through SSH; use [DockerCompose.NewAgents] for that.
+type FixtureDevice struct {
+	Name     string
+	TenantID string
+	MAC      string
+	// Status is the device's status. When empty, it is [models.DeviceStatusAccepted].
+	Status models.DeviceStatus
+}
+
+// UID returns the UID of the device fixture, derived from its tenant and name.
+func (fd FixtureDevice) UID() string {
+	sum := sha256.Sum256([]byte(fd.TenantID + fd.Name))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Seed creates the users, namespaces and devices defined on fixtures.
+//
+// It is not intended to be a test of the seeding methods, but it makes some assertions to guarantee that the
+// following instructions will not fail, calling assert.FailNow if any do.
+func (dc *DockerCompose) Seed(ctx context.Context, fixtures Fixtures) {
+	for _, user := range fixtures.Users {
+		dc.NewUser(ctx, user.Username, user.Email, user.Password)
+	}
+
+	for _, namespace := range fixtures.Namespaces {
+		dc.NewNamespace(ctx, namespace.Owner, namespace.Name, namespace.TenantID)
+
+		for _, member := range namespace.Members {
+			dc.NewNamespaceMember(ctx, member.Username, namespace.Name, member.Role)
+		}
+	}
+
+	for _, device := range fixtures.Devices {
+		dc.NewDevice(ctx, device)
+	}
+}
+
+// NewNamespaceMember adds a user to the namespace with the specified role. It is an abstraction around the
+// "namespace member add" method of the CLI.
+//
+// It is not intended to be a test of the method, but it makes some assertions to guarantee that the following
+// instructions will not fail, calling assert.FailNow if any do.
+func (dc *DockerCompose) NewNamespaceMember(ctx context.Context, username, namespace, role string) {
+	err := dc.runCLICommand(
+		ctx,
+		[]string{"./cli", "namespace", "member", "add", username, namespace, role},
+	)
+	if !assert.NoError(dc.t, err) {
+		assert.FailNow(dc.t, err.Error())
+	}
+}
+
+// NewDevice inserts the device directly on the database through the Mongo shell, as there is no API to create a
+// device without an agent.
+//
+// It is not intended to be a test of the method, but it makes some assertions to guarantee that the following
+// instructions will not fail, calling assert.FailNow if any do.
+func (dc *DockerCompose) NewDevice(ctx context.Context, device FixtureDevice) {
+	if device.Status == "" {
+		device.Status = models.DeviceStatusAccepted
+	}
+
+	document, err := json.Marshal(map[string]interface{}{
+		"uid":       device.UID(),
+		"name":      device.Name,
+		"tenant_id": device.TenantID,
+		"status":    device.Status,
+		"identity":  map[string]string{"mac": device.MAC},
+		"info":      map[string]string{"id": "fixture", "pretty_name": "Fixture", "version": "latest"},
+	})
+	if !assert.NoError(dc.t, err) {
+		assert.FailNow(dc.t, err.Error())
+	}
+
+	// NOTICE: the dates are set by the Mongo shell as JSON has no representation for them.
+	script := fmt.Sprintf(
+		"db.devices.insertOne(Object.assign(%s, {created_at: new Date(), last_seen: new Date(), status_updated_at: new Date()}))",
+		document,
+	)
+
+	code, _, err := dc.Service(ServiceMongo).Exec(ctx, []string{"mongo", "--quiet", "main", "--eval", script})
+	if !assert.NoError(dc.t, err) {
+		assert.FailNow(dc.t, err.Error())
+	}
+
+	if !assert.Equal(dc.t, 0, code) {
+		assert.FailNow(dc.t, "failed to insert the device "+device.Name)
+	}
+}
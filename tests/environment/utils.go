@@ -5,6 +5,7 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/docker/docker/pkg/stdcopy"
@@ -21,13 +22,24 @@ const (
 	ServiceAPI     Service = "api"
 	ServiceSSH     Service = "ssh"
 	ServiceUI      Service = "ui"
+	ServiceMongo   Service = "mongo"
 )
 
-var freePortController []string
+var (
+	freePortController []string
+	freePortMu         sync.Mutex
+)
 
 // GetFreePort returns a randomly available TCP port. It can be used to avoid
 // network conflicts in Docker Compose.
 func GetFreePort(t *testing.T) string {
+	freePortMu.Lock()
+	defer freePortMu.Unlock()
+
+	return getFreePort(t)
+}
+
+func getFreePort(t *testing.T) string {
 	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
 	require.NoError(t, err)
 
@@ -38,7 +50,7 @@ func GetFreePort(t *testing.T) string {
 
 	port := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
 	if slices.Contains(freePortController, port) {
-		return GetFreePort(t)
+		return getFreePort(t)
 	}
 
 	freePortController = append(freePortController, port)
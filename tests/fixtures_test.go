@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/tests/environment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	compose := environment.New(t).WithFixtures(environment.Fixtures{
+		Users: []environment.FixtureUser{
+			{Username: ShellHubUsername, Email: ShellHubEmail, Password: ShellHubPassword},
+			{Username: "member", Email: "member@ossystems.com.br", Password: ShellHubPassword},
+		},
+		Namespaces: []environment.FixtureNamespace{
+			{
+				Name:     ShellHubNamespaceName,
+				Owner:    ShellHubUsername,
+				TenantID: ShellHubNamespace,
+				Members:  []environment.FixtureMember{{Username: "member", Role: "observer"}},
+			},
+		},
+		Devices: []environment.FixtureDevice{
+			{Name: "accepted", TenantID: ShellHubNamespace, MAC: "00:00:00:00:00:01"},
+			{Name: "pending", TenantID: ShellHubNamespace, MAC: "00:00:00:00:00:02", Status: models.DeviceStatusPending},
+		},
+	}).Up(ctx)
+	t.Cleanup(compose.Down)
+
+	compose.JWT(compose.AuthUser(ctx, ShellHubUsername, ShellHubPassword).Token)
+
+	devices := []models.Device{}
+
+	resp, err := compose.R(ctx).SetResult(&devices).Get("/api/devices?status=accepted")
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode())
+	require.Len(t, devices, 1)
+	assert.Equal(t, "accepted", devices[0].Name)
+
+	compose.NewAgents(ctx, 2)
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		resp, err := compose.R(ctx).SetResult(&devices).Get("/api/devices?status=pending")
+		assert.NoError(tt, err)
+		assert.Equal(tt, 200, resp.StatusCode())
+
+		assert.Len(tt, devices, 3)
+	}, 30*time.Second, 1*time.Second)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/tests/environment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestIPv6(t *testing.T) {
+	ctx := context.Background()
+
+	compose := environment.New(t).WithIPv6().Up(ctx)
+	t.Cleanup(func() {
+		compose.Down()
+	})
+
+	compose.NewUser(ctx, ShellHubUsername, ShellHubEmail, ShellHubPassword)
+	compose.NewNamespace(ctx, ShellHubUsername, ShellHubNamespaceName, ShellHubNamespace)
+
+	auth := models.UserAuthResponse{}
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		resp, err := compose.R(ctx).
+			SetBody(map[string]string{
+				"username": ShellHubUsername,
+				"password": ShellHubPassword,
+			}).
+			SetResult(&auth).
+			Post("/api/login")
+		assert.Equal(tt, 200, resp.StatusCode())
+		assert.NoError(tt, err)
+	}, 30*time.Second, 1*time.Second)
+
+	compose.JWT(auth.Token)
+
+	agent, err := NewAgentContainer(
+		ctx,
+		compose.Env("SHELLHUB_HTTP_PORT"),
+		NewAgentContainerWithServerAddress("http://"+net.JoinHostPort("::1", compose.Env("SHELLHUB_HTTP_PORT"))),
+	)
+	require.NoError(t, err)
+
+	err = agent.Start(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, agent.Terminate(ctx))
+	})
+
+	devices := []models.Device{}
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		resp, err := compose.R(ctx).SetResult(&devices).
+			Get("/api/devices?status=pending")
+		assert.Equal(tt, 200, resp.StatusCode())
+		assert.NoError(tt, err)
+
+		assert.Len(tt, devices, 1)
+	}, 30*time.Second, 1*time.Second)
+
+	resp, err := compose.R(ctx).
+		Patch(fmt.Sprintf("/api/devices/%s/accept", devices[0].UID))
+	require.Equal(t, 200, resp.StatusCode())
+	require.NoError(t, err)
+
+	device := models.Device{}
+
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		resp, err := compose.R(ctx).
+			SetResult(&device).
+			Get(fmt.Sprintf("/api/devices/%s", devices[0].UID))
+		assert.Equal(tt, 200, resp.StatusCode())
+		assert.NoError(tt, err)
+
+		assert.True(tt, device.Online)
+	}, 30*time.Second, 1*time.Second)
+
+	t.Run("authenticate with password over IPv6", func(t *testing.T) {
+		config := &ssh.ClientConfig{
+			User: fmt.Sprintf("%s@%s.%s", ShellHubAgentUsername, ShellHubNamespaceName, device.Name),
+			Auth: []ssh.AuthMethod{
+				ssh.Password(ShellHubAgentPassword),
+			},
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+		}
+
+		var conn *ssh.Client
+
+		require.EventuallyWithT(t, func(tt *assert.CollectT) {
+			var err error
+
+			conn, err = ssh.Dial("tcp", net.JoinHostPort("::1", compose.Env("SHELLHUB_SSH_PORT")), config)
+			assert.NoError(tt, err)
+		}, 30*time.Second, 1*time.Second)
+
+		conn.Close()
+	})
+
+	t.Run("record the session with a canonical IP address", func(t *testing.T) {
+		sessions := []models.Session{}
+
+		require.EventuallyWithT(t, func(tt *assert.CollectT) {
+			resp, err := compose.R(ctx).
+				SetResult(&sessions).
+				Get("/api/sessions")
+			assert.Equal(tt, 200, resp.StatusCode())
+			assert.NoError(tt, err)
+
+			assert.NotEmpty(tt, sessions)
+		}, 30*time.Second, 1*time.Second)
+
+		// NOTICE: Depending on the Docker's userland proxy, the connection may reach the SSH server from an IPv4 or an
+		// IPv6 address, but it must always be stored in its canonical form, without brackets or port.
+		for _, session := range sessions {
+			addr, err := netip.ParseAddr(session.IPAddress)
+			require.NoError(t, err)
+			assert.Equal(t, addr.Unmap().String(), session.IPAddress)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"log"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/bramvdbogaerde/go-scp"
 	"github.com/pkg/sftp"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sftpcheck"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/tests/environment"
@@ -48,6 +50,12 @@ func NewAgentContainerWithIdentity(identity string) NewAgentContainerOption {
 	}
 }
 
+func NewAgentContainerWithServerAddress(address string) NewAgentContainerOption {
+	return func(envs map[string]string) {
+		envs["SHELLHUB_SERVER_ADDRESS"] = address
+	}
+}
+
 func NewAgentContainer(ctx context.Context, port string, opts ...NewAgentContainerOption) (testcontainers.Container, error) {
 	envs := map[string]string{
 		"SHELLHUB_SERVER_ADDRESS":     fmt.Sprintf("http://localhost:%s", port),
@@ -86,6 +94,81 @@ func NewAgentContainer(ctx context.Context, port string, opts ...NewAgentContain
 	return c, nil
 }
 
+const (
+	// SFTPTransferSize is the size of the files transferred by the tests of the interrupted transfers.
+	SFTPTransferSize = 300 * 1024 * 1024
+	// SFTPTransferBlockSize is the size of the blocks verified after an interrupted transfer.
+	SFTPTransferBlockSize = 1024 * 1024
+)
+
+// interruptingReader calls interrupt after the limit of bytes is read, simulating a dropped connection.
+type interruptingReader struct {
+	reader    io.Reader
+	limit     int64
+	read      int64
+	interrupt func() error
+}
+
+func (r *interruptingReader) Read(data []byte) (int, error) {
+	if r.read >= r.limit {
+		r.interrupt() //nolint:errcheck
+
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	n, err := r.reader.Read(data)
+	r.read += int64(n)
+
+	return n, err
+}
+
+// interruptingWriter calls interrupt after the limit of bytes is written, simulating a dropped connection.
+type interruptingWriter struct {
+	writer    io.Writer
+	limit     int64
+	written   int64
+	interrupt func() error
+}
+
+func (w *interruptingWriter) Write(data []byte) (int, error) {
+	if w.written >= w.limit {
+		w.interrupt() //nolint:errcheck
+
+		return 0, io.ErrClosedPipe
+	}
+
+	n, err := w.writer.Write(data)
+	w.written += int64(n)
+
+	return n, err
+}
+
+// sftpCheck requests the checksums of a file's range on a new "sftp" subsystem of the connection.
+func sftpCheck(t *testing.T, conn *ssh.Client, req *sftpcheck.Request) *sftpcheck.Result {
+	t.Helper()
+
+	sess, err := conn.NewSession()
+	require.NoError(t, err)
+
+	defer sess.Close()
+
+	stdin, err := sess.StdinPipe()
+	require.NoError(t, err)
+
+	stdout, err := sess.StdoutPipe()
+	require.NoError(t, err)
+
+	require.NoError(t, sess.RequestSubsystem("sftp"))
+
+	result, err := sftpcheck.Check(struct {
+		io.Reader
+		io.Writer
+	}{stdout, stdin}, req)
+	require.NoError(t, err)
+
+	return result
+}
+
 func TestSSH(t *testing.T) {
 	type Environment struct {
 		services *environment.DockerCompose
@@ -656,6 +739,177 @@ func TestSSH(t *testing.T) {
 				conn.Close()
 			},
 		},
+		{
+			name:    "connection SFTP to resume an interrupted upload",
+			options: []NewAgentContainerOption{},
+			run: func(t *testing.T, environment *Environment, device *models.Device) {
+				config := &ssh.ClientConfig{
+					User: fmt.Sprintf("%s@%s.%s", ShellHubAgentUsername, ShellHubNamespaceName, device.Name),
+					Auth: []ssh.AuthMethod{
+						ssh.Password(ShellHubAgentPassword),
+					},
+					HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+				}
+
+				dial := func() *ssh.Client {
+					var conn *ssh.Client
+
+					require.EventuallyWithT(t, func(tt *assert.CollectT) {
+						var err error
+
+						conn, err = ssh.Dial("tcp", fmt.Sprintf("localhost:%s", environment.services.Env("SHELLHUB_SSH_PORT")), config)
+						assert.NoError(tt, err)
+					}, 30*time.Second, 1*time.Second)
+
+					return conn
+				}
+
+				data := make([]byte, SFTPTransferSize)
+				_, err := rand.Read(data)
+				require.NoError(t, err)
+
+				// NOTICE: The connection is dropped in the middle of the upload, like on a flaky link.
+				conn := dial()
+
+				sess, err := sftp.NewClient(conn)
+				require.NoError(t, err)
+
+				sent, err := sess.Create("/tmp/resumed")
+				require.NoError(t, err)
+
+				_, err = sent.ReadFrom(&interruptingReader{reader: bytes.NewReader(data), limit: SFTPTransferSize / 2, interrupt: conn.Close})
+				require.Error(t, err)
+
+				conn = dial()
+
+				sess, err = sftp.NewClient(conn)
+				require.NoError(t, err)
+
+				info, err := sess.Stat("/tmp/resumed")
+				require.NoError(t, err)
+				require.Less(t, info.Size(), int64(SFTPTransferSize))
+
+				result := sftpCheck(t, conn, &sftpcheck.Request{
+					Path:       "/tmp/resumed",
+					Algorithms: []string{"sha256"},
+					BlockSize:  SFTPTransferBlockSize,
+				})
+
+				offset, err := sftpcheck.VerifiedLength(bytes.NewReader(data), uint64(info.Size()), result, SFTPTransferBlockSize)
+				require.NoError(t, err)
+
+				sent, err = sess.OpenFile("/tmp/resumed", os.O_WRONLY)
+				require.NoError(t, err)
+
+				_, err = sent.Seek(int64(offset), io.SeekStart)
+				require.NoError(t, err)
+
+				_, err = sent.ReadFrom(bytes.NewReader(data[offset:]))
+				require.NoError(t, err)
+				require.NoError(t, sent.Close())
+
+				result = sftpCheck(t, conn, &sftpcheck.Request{Path: "/tmp/resumed", Algorithms: []string{"sha256"}})
+
+				sum := sha256.Sum256(data)
+				assert.Equal(t, [][]byte{sum[:]}, result.Sums)
+
+				sess.Close()
+				conn.Close()
+			},
+		},
+		{
+			name:    "connection SFTP to resume an interrupted download",
+			options: []NewAgentContainerOption{},
+			run: func(t *testing.T, environment *Environment, device *models.Device) {
+				config := &ssh.ClientConfig{
+					User: fmt.Sprintf("%s@%s.%s", ShellHubAgentUsername, ShellHubNamespaceName, device.Name),
+					Auth: []ssh.AuthMethod{
+						ssh.Password(ShellHubAgentPassword),
+					},
+					HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+				}
+
+				dial := func() *ssh.Client {
+					var conn *ssh.Client
+
+					require.EventuallyWithT(t, func(tt *assert.CollectT) {
+						var err error
+
+						conn, err = ssh.Dial("tcp", fmt.Sprintf("localhost:%s", environment.services.Env("SHELLHUB_SSH_PORT")), config)
+						assert.NoError(tt, err)
+					}, 30*time.Second, 1*time.Second)
+
+					return conn
+				}
+
+				conn := dial()
+
+				sess, err := conn.NewSession()
+				require.NoError(t, err)
+
+				_, err = sess.Output(fmt.Sprintf("head -c %d /dev/urandom > /tmp/download", SFTPTransferSize))
+				require.NoError(t, err)
+
+				local, err := os.CreateTemp(t.TempDir(), "download")
+				require.NoError(t, err)
+
+				defer local.Close()
+
+				// NOTICE: The connection is dropped in the middle of the download, like on a flaky link.
+				client, err := sftp.NewClient(conn)
+				require.NoError(t, err)
+
+				received, err := client.Open("/tmp/download")
+				require.NoError(t, err)
+
+				_, err = received.WriteTo(&interruptingWriter{writer: local, limit: SFTPTransferSize / 2, interrupt: conn.Close})
+				require.Error(t, err)
+
+				info, err := local.Stat()
+				require.NoError(t, err)
+				require.Less(t, info.Size(), int64(SFTPTransferSize))
+
+				conn = dial()
+
+				result := sftpCheck(t, conn, &sftpcheck.Request{
+					Path:       "/tmp/download",
+					Algorithms: []string{"sha256"},
+					Length:     uint64(info.Size()),
+					BlockSize:  SFTPTransferBlockSize,
+				})
+
+				offset, err := sftpcheck.VerifiedLength(local, uint64(info.Size()), result, SFTPTransferBlockSize)
+				require.NoError(t, err)
+
+				client, err = sftp.NewClient(conn)
+				require.NoError(t, err)
+
+				received, err = client.Open("/tmp/download")
+				require.NoError(t, err)
+
+				_, err = received.Seek(int64(offset), io.SeekStart)
+				require.NoError(t, err)
+
+				_, err = local.Seek(int64(offset), io.SeekStart)
+				require.NoError(t, err)
+
+				_, err = received.WriteTo(local)
+				require.NoError(t, err)
+
+				_, err = local.Seek(0, io.SeekStart)
+				require.NoError(t, err)
+
+				hash := sha256.New()
+				_, err = io.Copy(hash, local)
+				require.NoError(t, err)
+
+				result = sftpCheck(t, conn, &sftpcheck.Request{Path: "/tmp/download", Algorithms: []string{"sha256"}})
+				assert.Equal(t, [][]byte{hash.Sum(nil)}, result.Sums)
+
+				client.Close()
+				conn.Close()
+			},
+		},
 		{
 			name:    "connection SCP to upload file",
 			options: []NewAgentContainerOption{},